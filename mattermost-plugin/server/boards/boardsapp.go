@@ -4,6 +4,7 @@
 package boards
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
@@ -33,6 +34,7 @@ const (
 
 	notifyFreqCardSecondsKey  = "notify_freq_card_seconds"
 	notifyFreqBoardSecondsKey = "notify_freq_board_seconds"
+	trashRetentionDaysKey     = "trash_retention_days"
 )
 
 type BoardsEmbed struct {
@@ -153,7 +155,7 @@ func NewBoardsApp(api model.ServicesAPI) (*BoardsApp, error) {
 			return nil, fmt.Errorf("error fetching cloud limits when starting Boards: %w", err)
 		}
 
-		if err := server.App().SetCloudLimits(limits); err != nil {
+		if err := server.App().SetCloudLimits(context.Background(), limits); err != nil {
 			return nil, fmt.Errorf("error setting cloud limits when starting Boards: %w", err)
 		}
 	}
@@ -211,7 +213,7 @@ func (b *BoardsApp) OnPluginClusterEvent(_ *plugin.Context, ev mm_model.PluginCl
 }
 
 func (b *BoardsApp) OnCloudLimitsUpdated(limits *mm_model.ProductLimits) {
-	if err := b.server.App().SetCloudLimits(limits); err != nil {
+	if err := b.server.App().SetCloudLimits(context.Background(), limits); err != nil {
 		b.logger.Error("Error setting the cloud limits for Boards", mlog.Err(err))
 	}
 }
@@ -99,6 +99,7 @@ func createBoardsConfig(mmconfig mm_model.Config, baseURL string, serverID strin
 		EnableDataRetention:      enableBoardsDeletion,
 		DataRetentionDays:        *mmconfig.DataRetentionSettings.BoardsRetentionDays,
 		TeammateNameDisplay:      *mmconfig.TeamSettings.TeammateNameDisplay,
+		TrashRetentionDays:       getPluginSettingInt(mmconfig, trashRetentionDaysKey, 30),
 	}
 }
 
@@ -1,6 +1,7 @@
 package boards
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -74,8 +75,8 @@ func createDelivery(servicesAPI model.ServicesAPI, serverRoot string) (*pluginde
 }
 
 type appIface interface {
-	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
-	AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, error)
+	CreateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error)
+	AddMemberToBoard(ctx context.Context, member *model.BoardMember, modifiedBy string) (*model.BoardMember, error)
 }
 
 // appAPI provides app and store APIs for notification services. Where appropriate calls are made to the
@@ -92,45 +93,49 @@ func (a *appAPI) init(store store.Store, app appIface) {
 }
 
 func (a *appAPI) GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
-	return a.store.GetBlockHistory(blockID, opts)
+	return a.store.GetBlockHistory(context.Background(), blockID, opts)
 }
 
 func (a *appAPI) GetSubTree2(boardID, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
-	return a.store.GetSubTree2(boardID, blockID, opts)
+	return a.store.GetSubTree2(context.Background(), boardID, blockID, opts)
 }
 
 func (a *appAPI) GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error) {
-	return a.store.GetBoardAndCardByID(blockID)
+	return a.store.GetBoardAndCardByID(context.Background(), blockID)
 }
 
 func (a *appAPI) GetUserByID(userID string) (*model.User, error) {
-	return a.store.GetUserByID(userID)
+	return a.store.GetUserByID(context.Background(), userID)
 }
 
 func (a *appAPI) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
-	return a.app.CreateSubscription(sub)
+	return a.app.CreateSubscription(context.Background(), sub)
 }
 
 func (a *appAPI) GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error) {
-	return a.store.GetSubscribersForBlock(blockID)
+	return a.store.GetSubscribersForBlock(context.Background(), blockID, model.SubscriptionEventsAll)
 }
 
 func (a *appAPI) UpdateSubscribersNotifiedAt(blockID string, notifyAt int64) error {
-	return a.store.UpdateSubscribersNotifiedAt(blockID, notifyAt)
+	return a.store.UpdateSubscribersNotifiedAt(context.Background(), blockID, notifyAt)
+}
+
+func (a *appAPI) UpdateSubscriberNotifiedAt(blockID, subscriberID string, notifyAt int64) error {
+	return a.store.UpdateSubscriberNotifiedAt(context.Background(), blockID, subscriberID, notifyAt)
 }
 
 func (a *appAPI) UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
-	return a.store.UpsertNotificationHint(hint, notificationFreq)
+	return a.store.UpsertNotificationHint(context.Background(), hint, notificationFreq)
 }
 
 func (a *appAPI) GetNextNotificationHint(remove bool) (*model.NotificationHint, error) {
-	return a.store.GetNextNotificationHint(remove)
+	return a.store.GetNextNotificationHint(context.Background(), remove)
 }
 
 func (a *appAPI) GetMemberForBoard(boardID, userID string) (*model.BoardMember, error) {
-	return a.store.GetMemberForBoard(boardID, userID)
+	return a.store.GetMemberForBoard(context.Background(), boardID, userID)
 }
 
-func (a *appAPI) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, error) {
-	return a.app.AddMemberToBoard(member)
+func (a *appAPI) AddMemberToBoard(member *model.BoardMember, modifiedBy string) (*model.BoardMember, error) {
+	return a.app.AddMemberToBoard(context.Background(), member, modifiedBy)
 }
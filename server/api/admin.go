@@ -2,9 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/model"
@@ -43,7 +46,7 @@ func (a *API) handleAdminSetPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = a.app.UpdateUserPassword(username, requestData.Password)
+	err = a.app.UpdateUserPassword(r.Context(), username, requestData.Password)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -54,3 +57,98 @@ func (a *API) handleAdminSetPassword(w http.ResponseWriter, r *http.Request) {
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
 }
+
+func (a *API) handleAdminGetAuditRecords(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	boardID := query.Get("board_id")
+
+	opts, err := getQueryAuditOptions(r)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminGetAuditRecords", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if query.Get("format") == "csv" {
+		filename := fmt.Sprintf("audit-%s.csv", time.Now().Format("2006-01-02"))
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+
+		if err := a.app.ExportAuditRecords(r.Context(), w, boardID, opts); err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+
+		a.logger.Debug("AdminExportAuditRecords", mlog.String("boardID", boardID))
+		auditRec.Success()
+		return
+	}
+
+	records, err := a.app.GetAuditRecords(r.Context(), boardID, opts)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminGetAuditRecords",
+		mlog.String("boardID", boardID),
+		mlog.Int("recordCount", len(records)),
+	)
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+// getQueryAuditOptions reads the filters and the pagination of an audit
+// trail query from the query parameters of the request.
+func getQueryAuditOptions(r *http.Request) (model.QueryAuditOptions, error) {
+	query := r.URL.Query()
+	opts := model.QueryAuditOptions{
+		ActorID: query.Get("actor_id"),
+		Action:  model.AuditAction(query.Get("action")),
+	}
+
+	intParams := []struct {
+		name  string
+		value *int64
+	}{
+		{"since", &opts.Since},
+		{"until", &opts.Until},
+	}
+	for _, param := range intParams {
+		if str := query.Get(param.name); str != "" {
+			value, err := strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				return opts, model.NewErrBadRequest(fmt.Sprintf("invalid `%s` parameter: %s", param.name, str))
+			}
+			*param.value = value
+		}
+	}
+
+	if str := query.Get("per_page"); str != "" {
+		perPage, err := strconv.Atoi(str)
+		if err != nil || perPage <= 0 {
+			return opts, model.NewErrBadRequest(fmt.Sprintf("invalid `per_page` parameter: %s", str))
+		}
+		opts.PerPage = perPage
+	}
+
+	if str := query.Get("page"); str != "" {
+		page, err := strconv.Atoi(str)
+		if err != nil || page < 0 || opts.PerPage == 0 {
+			return opts, model.NewErrBadRequest(fmt.Sprintf("invalid `page` parameter: %s", str))
+		}
+		opts.Page = page
+	}
+
+	return opts, nil
+}
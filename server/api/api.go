@@ -1,17 +1,22 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"runtime/debug"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/services/ratelimit"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -21,6 +26,8 @@ const (
 	HeaderRequestedWithXML = "XMLHttpRequest"
 	UploadFormFileKey      = "file"
 	True                   = "true"
+	HeaderNextCursor       = "X-Next-Cursor"
+	HeaderSharePassword    = "X-Share-Password"
 
 	ErrorNoTeamCode    = 1000
 	ErrorNoTeamMessage = "No team"
@@ -42,6 +49,8 @@ type API struct {
 	logger          mlog.LoggerIFace
 	audit           *audit.Audit
 	isPlugin        bool
+	rateLimiter     *ratelimit.Limiter
+	metrics         *metrics.Metrics
 }
 
 func NewAPI(
@@ -52,6 +61,8 @@ func NewAPI(
 	logger mlog.LoggerIFace,
 	audit *audit.Audit,
 	isPlugin bool,
+	rateLimiter *ratelimit.Limiter,
+	metrics *metrics.Metrics,
 ) *API {
 	return &API{
 		app:             app,
@@ -61,12 +72,20 @@ func NewAPI(
 		logger:          logger,
 		audit:           audit,
 		isPlugin:        isPlugin,
+		rateLimiter:     rateLimiter,
+		metrics:         metrics,
 	}
 }
 
 func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv2 := r.PathPrefix("/api/v2").Subrouter()
+	if a.metrics != nil {
+		apiv2.Use(a.metricsHandler)
+	}
 	apiv2.Use(a.panicHandler)
+	if a.rateLimiter != nil {
+		apiv2.Use(a.rateLimiter.IPMiddleware)
+	}
 	apiv2.Use(a.requireCSRFToken)
 
 	/* ToDo:
@@ -84,6 +103,8 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	a.registerTeamsRoutes(apiv2)
 	a.registerAchivesRoutes(apiv2)
 	a.registerSubscriptionsRoutes(apiv2)
+	a.registerWebhooksRoutes(apiv2)
+	a.registerBoardRolesRoutes(apiv2)
 	a.registerFilesRoutes(apiv2)
 	a.registerLimitsRoutes(apiv2)
 	a.registerInsightsRoutes(apiv2)
@@ -106,6 +127,7 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 
 func (a *API) RegisterAdminRoutes(r *mux.Router) {
 	r.HandleFunc("/api/v2/admin/users/{username}/password", a.adminRequired(a.handleAdminSetPassword)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/audit", a.adminRequired(a.handleAdminGetAuditRecords)).Methods("GET")
 }
 
 func getUserID(r *http.Request) string {
@@ -134,6 +156,34 @@ func (a *API) panicHandler(next http.Handler) http.Handler {
 	})
 }
 
+// statusRecorder keeps the status code written by a handler, so that
+// it can be reported once the request is served.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (sr *statusRecorder) WriteHeader(statusCode int) {
+	sr.statusCode = statusCode
+	sr.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (a *API) metricsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		handler := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				handler = template
+			}
+		}
+		a.metrics.ObserveAPIRequest(handler, r.Method, recorder.statusCode, time.Since(start))
+	})
+}
+
 func (a *API) requireCSRFToken(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !a.checkCSRFToken(r) {
@@ -159,20 +209,30 @@ func (a *API) hasValidReadTokenForBoard(r *http.Request, boardID string) bool {
 		return false
 	}
 
-	isValid, err := a.app.IsValidReadToken(boardID, readToken)
+	isValid, err := a.app.IsValidReadToken(r.Context(), boardID, readToken)
 	if err != nil {
 		a.logger.Error("IsValidReadTokenForBoard ERROR", mlog.Err(err))
 		return false
 	}
+	if isValid {
+		return true
+	}
+
+	// board scoped share tokens can be used as read tokens too
+	isValid, err = a.app.IsValidShareReadToken(r.Context(), boardID, readToken, r.Header.Get(HeaderSharePassword))
+	if err != nil {
+		a.logger.Error("IsValidShareReadToken ERROR", mlog.Err(err))
+		return false
+	}
 
 	return isValid
 }
 
-func (a *API) userIsGuest(userID string) (bool, error) {
+func (a *API) userIsGuest(ctx context.Context, userID string) (bool, error) {
 	if a.singleUserToken != "" {
 		return false, nil
 	}
-	return a.app.UserIsGuest(userID)
+	return a.app.UserIsGuest(ctx, userID)
 }
 
 // Response helpers
@@ -190,6 +250,8 @@ func (a *API) errorResponse(w http.ResponseWriter, r *http.Request, err error) {
 		errorResponse.ErrorCode = http.StatusForbidden
 	case model.IsErrNotFound(err):
 		errorResponse.ErrorCode = http.StatusNotFound
+	case model.IsErrConflict(err):
+		errorResponse.ErrorCode = http.StatusConflict
 	case model.IsErrRequestEntityTooLarge(err):
 		errorResponse.ErrorCode = http.StatusRequestEntityTooLarge
 	case model.IsErrNotImplemented(err):
@@ -238,3 +300,32 @@ func setResponseHeader(w http.ResponseWriter, key string, value string) {
 	}
 	header.Set(key, value)
 }
+
+// getCursorPage reads the `cursor` and `per_page` query parameters of a
+// listing. The listing is only paginated when one of them is set, and
+// per_page then defaults to defaultPerPage.
+func getCursorPage(r *http.Request) (string, int, bool, error) {
+	query := r.URL.Query()
+	cursor := query.Get("cursor")
+	strPerPage := query.Get("per_page")
+
+	if cursor == "" && strPerPage == "" {
+		return "", 0, false, nil
+	}
+
+	if strPerPage == "" {
+		strPerPage = defaultPerPage
+	}
+
+	perPage, err := strconv.Atoi(strPerPage)
+	if err != nil || perPage <= 0 {
+		return "", 0, false, model.NewErrBadRequest(fmt.Sprintf("invalid `per_page` parameter: %s", strPerPage))
+	}
+
+	return cursor, perPage, true, nil
+}
+
+// setNextCursor sets the cursor of the next page of a paginated listing.
+func setNextCursor(w http.ResponseWriter, cursor string) {
+	setResponseHeader(w, HeaderNextCursor, cursor)
+}
@@ -8,7 +8,9 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 	"github.com/stretchr/testify/require"
 
@@ -48,6 +50,10 @@ func TestErrorResponse(t *testing.T) {
 		{"mattermost-plugin-api/ErrNotFound", pluginapi.ErrNotFound, http.StatusNotFound, "not found"},
 		{"ErrNotFound", model.ErrCategoryDeleted, http.StatusNotFound, "category is deleted"},
 
+		// conflict
+		{"ErrDuplicate", model.NewErrDuplicate("user"), http.StatusConflict, "already exists"},
+		{"ErrBoardMemberIsLastAdminRole", model.ErrBoardMemberIsLastAdminRole, http.StatusConflict, "last admin"},
+
 		// request entity too large
 		{"ErrRequestEntityTooLarge", model.ErrRequestEntityTooLarge, http.StatusRequestEntityTooLarge, "entity too large"},
 
@@ -76,3 +82,32 @@ func TestErrorResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestMetricsHandler(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	metricsService := metrics.NewMetrics(metrics.InstanceInfo{})
+	testAPI := API{logger: logger, metrics: metricsService}
+
+	r := mux.NewRouter()
+	r.Use(testAPI.metricsHandler)
+	r.HandleFunc("/boards/{boardID}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["boardID"] == "missing" {
+			testAPI.errorResponse(w, r, model.NewErrNotFound("board"))
+			return
+		}
+		jsonStringResponse(w, http.StatusOK, "{}")
+	})
+
+	for _, path := range []string{"/boards/board-1", "/boards/board-2", "/boards/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	metricsServer := metrics.NewMetricsServer("", metricsService, logger)
+	metricsServer.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	require.Contains(t, body, `focalboard_api_request_duration_seconds_count{handler="/boards/{boardID}",method="GET",status_code="200"} 2`)
+	require.Contains(t, body, `focalboard_api_request_duration_seconds_count{handler="/boards/{boardID}",method="GET",status_code="404"} 1`)
+}
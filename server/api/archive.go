@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/importer"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -21,6 +23,7 @@ func (a *API) registerAchivesRoutes(r *mux.Router) {
 	r.HandleFunc("/boards/{boardID}/archive/export", a.sessionRequired(a.handleArchiveExportBoard)).Methods("GET")
 	r.HandleFunc("/teams/{teamID}/archive/import", a.sessionRequired(a.handleArchiveImport)).Methods("POST")
 	r.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(a.handleArchiveExportTeam)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/import/{source}", a.sessionRequired(a.handleExternalImport)).Methods("POST")
 }
 
 func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
@@ -64,7 +67,7 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("BoardID", boardID)
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.app.GetBoard(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -80,7 +83,7 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Transfer-Encoding", "binary")
 
-	if err := a.app.ExportArchive(w, opts); err != nil {
+	if err := a.app.ExportArchive(r.Context(), w, opts); err != nil {
 		a.errorResponse(w, r, err)
 	}
 
@@ -130,7 +133,7 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -157,7 +160,7 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 		ModifiedBy: userID,
 	}
 
-	if err := a.app.ImportArchive(file, opt); err != nil {
+	if err := a.app.ImportArchive(r.Context(), file, opt); err != nil {
 		a.logger.Debug("Error importing archive",
 			mlog.String("team_id", teamID),
 			mlog.Err(err),
@@ -170,6 +173,118 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleExternalImport(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/import/{source} externalImport
+	//
+	// Imports a Trello JSON or an Asana CSV export as a new board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - multipart/form-data
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: source
+	//   in: path
+	//   description: The tool the export comes from, trello or asana
+	//   required: true
+	//   type: string
+	// - name: file
+	//   in: formData
+	//   description: export file to import
+	//   required: true
+	//   type: file
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ImportExternalResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	source := importer.Source(vars["source"])
+
+	if source != importer.SourceTrello && source != importer.SourceAsana {
+		a.errorResponse(w, r, model.NewErrBadRequest("unsupported import source: "+string(source)))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create board"))
+		return
+	}
+
+	isGuest, err := a.userIsGuest(r.Context(), userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if isGuest {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create board"))
+		return
+	}
+
+	file, handle, err := r.FormFile(UploadFormFileKey)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	defer file.Close()
+
+	auditRec := a.makeAuditRecord(r, "externalImport", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("source", source)
+	auditRec.AddMeta("filename", handle.Filename)
+	auditRec.AddMeta("size", handle.Size)
+
+	opt := model.ImportExternalOptions{
+		TeamID:     teamID,
+		ModifiedBy: userID,
+		Progress: func(progress model.ImportProgress) {
+			a.logger.Debug("External import progress",
+				mlog.String("team_id", teamID),
+				mlog.String("source", string(source)),
+				mlog.Int("blocks_imported", progress.BlocksImported),
+				mlog.Int("blocks_total", progress.BlocksTotal),
+			)
+		},
+	}
+
+	result, err := a.app.ImportExternal(r.Context(), source, file, opt)
+	if err != nil {
+		a.logger.Debug("Error importing external export",
+			mlog.String("team_id", teamID),
+			mlog.String("source", string(source)),
+			mlog.Err(err),
+		)
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardID", result.Board.ID)
+	auditRec.Success()
+}
+
 func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /teams/{teamID}/archive/export archiveExportTeam
 	//
@@ -213,13 +328,13 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("TeamID", teamID)
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
 
-	boards, err := a.app.GetBoardsForUserAndTeam(userID, teamID, !isGuest)
+	boards, err := a.app.GetBoardsForUserAndTeam(r.Context(), userID, teamID, !isGuest)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -239,7 +354,7 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Transfer-Encoding", "binary")
 
-	if err := a.app.ExportArchive(w, opts); err != nil {
+	if err := a.app.ExportArchive(r.Context(), w, opts); err != nil {
 		a.errorResponse(w, r, err)
 	}
 
@@ -86,7 +86,7 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("type", loginData.Type)
 
 	if loginData.Type == "normal" {
-		token, err := a.app.Login(loginData.Username, loginData.Email, loginData.Password, loginData.MfaToken)
+		token, err := a.app.Login(r.Context(), loginData.Username, loginData.Email, loginData.Password, loginData.MfaToken)
 		if err != nil {
 			a.errorResponse(w, r, model.NewErrUnauthorized("incorrect login"))
 			return
@@ -141,7 +141,7 @@ func (a *API) handleLogout(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 	auditRec.AddMeta("userID", session.UserID)
 
-	if err := a.app.Logout(session.ID); err != nil {
+	if err := a.app.Logout(r.Context(), session.ID); err != nil {
 		a.errorResponse(w, r, model.NewErrUnauthorized("incorrect logout"))
 		return
 	}
@@ -204,7 +204,7 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 
 	// Validate token
 	if len(registerData.Token) > 0 {
-		team, err2 := a.app.GetRootTeam()
+		team, err2 := a.app.GetRootTeam(r.Context())
 		if err2 != nil {
 			a.errorResponse(w, r, err2)
 			return
@@ -216,7 +216,7 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		// No signup token, check if no active users
-		userCount, err2 := a.app.GetRegisteredUserCount()
+		userCount, err2 := a.app.GetRegisteredUserCount(r.Context())
 		if err2 != nil {
 			a.errorResponse(w, r, err2)
 			return
@@ -236,7 +236,11 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 	auditRec.AddMeta("username", registerData.Username)
 
-	err = a.app.RegisterUser(registerData.Username, registerData.Email, registerData.Password)
+	err = a.app.RegisterUser(r.Context(), registerData.Username, registerData.Email, registerData.Password)
+	if model.IsErrDuplicate(err) {
+		a.errorResponse(w, r, err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
 		return
@@ -313,7 +317,7 @@ func (a *API) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "changePassword", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 
-	if err = a.app.ChangePassword(userID, requestData.OldPassword, requestData.NewPassword); err != nil {
+	if err = a.app.ChangePassword(r.Context(), userID, requestData.OldPassword, requestData.NewPassword); err != nil {
 		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
 		return
 	}
@@ -365,12 +369,16 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 				UpdateAt:    now,
 			}
 
+			if !a.allowUser(w, r, userID) {
+				return
+			}
+
 			ctx := context.WithValue(r.Context(), sessionContextKey, session)
 			handler(w, r.WithContext(ctx))
 			return
 		}
 
-		session, err := a.app.GetSession(token)
+		session, err := a.app.GetSession(r.Context(), token)
 		if err != nil {
 			if required {
 				a.errorResponse(w, r, model.NewErrUnauthorized(err.Error()))
@@ -392,11 +400,21 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
+		if !a.allowUser(w, r, session.UserID) {
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), sessionContextKey, session)
 		handler(w, r.WithContext(ctx))
 	}
 }
 
+// allowUser applies the per user rate limit, if rate limiting is enabled.
+// It writes a 429 response and returns false when the user exceeded it.
+func (a *API) allowUser(w http.ResponseWriter, r *http.Request, userID string) bool {
+	return a.rateLimiter == nil || a.rateLimiter.AllowUser(w, r, userID)
+}
+
 func (a *API) adminRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Currently, admin APIs require local unix connections
@@ -22,6 +22,11 @@ func (a *API) registerBlocksRoutes(r *mux.Router) {
 	r.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(a.handleDeleteBlock)).Methods("DELETE")
 	r.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(a.handlePatchBlock)).Methods("PATCH")
 	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/undelete", a.sessionRequired(a.handleUndeleteBlock)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/blocks/deleted", a.sessionRequired(a.handleGetDeletedBlocks)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/restore", a.sessionRequired(a.handleRestoreBlock)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/history", a.sessionRequired(a.handleGetBlockHistory)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/diff", a.sessionRequired(a.handleGetBlockDiff)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/revert", a.sessionRequired(a.handleRevertBlock)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/duplicate", a.sessionRequired(a.handleDuplicateBlock)).Methods("POST")
 }
 
@@ -49,11 +54,21 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	//   description: Type of blocks to return, omit to specify all types
 	//   required: false
 	//   type: string
+	// - name: cursor
+	//   in: query
+	//   description: With all, return the blocks after this cursor, taken from the X-Next-Cursor header of the previous page
+	//   required: false
+	//   type: string
+	// - name: per_page
+	//   in: query
+	//   description: With all, number of blocks to return per page (default=100 when a cursor is given, all the blocks otherwise)
+	//   required: false
+	//   type: integer
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
-	//     description: success
+	//     description: success, with the X-Next-Cursor header set when there are more blocks
 	//     schema:
 	//       type: array
 	//       items:
@@ -80,7 +95,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.app.GetBoard(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -100,7 +115,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		}
 		if board.IsTemplate {
 			var isGuest bool
-			isGuest, err = a.userIsGuest(userID)
+			isGuest, err = a.userIsGuest(r.Context(), userID)
 			if err != nil {
 				a.errorResponse(w, r, err)
 				return
@@ -121,17 +136,57 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("all", all)
 	auditRec.AddMeta("blockID", blockID)
 
+	snapshot, err := a.publishedSnapshotForReadToken(r.Context(), userID, hasValidReadToken, boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	var blocks []*model.Block
 	var block *model.Block
 	switch {
+	case snapshot != nil && all != "":
+		blocks = snapshot.Blocks
+	case snapshot != nil && blockID != "":
+		for _, b := range snapshot.Blocks {
+			if b.ID == blockID {
+				blocks = append(blocks, b)
+			}
+		}
+		if len(blocks) == 0 {
+			message := fmt.Sprintf("block ID=%s on BoardID=%s", blockID, boardID)
+			a.errorResponse(w, r, model.NewErrNotFound(message))
+			return
+		}
+	case snapshot != nil:
+		blocks = snapshot.FilterBlocks(parentID, blockType)
 	case all != "":
-		blocks, err = a.app.GetBlocksForBoard(boardID)
+		cursor, perPage, paginated, pErr := getCursorPage(r)
+		if pErr != nil {
+			a.errorResponse(w, r, pErr)
+			return
+		}
+
+		if !paginated {
+			blocks, err = a.app.GetBlocksForBoard(r.Context(), boardID)
+			if err != nil {
+				a.errorResponse(w, r, err)
+				return
+			}
+			break
+		}
+
+		var hasMore bool
+		blocks, hasMore, err = a.app.GetBlocksForBoardPage(r.Context(), boardID, cursor, perPage)
 		if err != nil {
 			a.errorResponse(w, r, err)
 			return
 		}
+		if hasMore {
+			setNextCursor(w, blocks[len(blocks)-1].ID)
+		}
 	case blockID != "":
-		block, err = a.app.GetBlockByID(blockID)
+		block, err = a.app.GetBlockByID(r.Context(), blockID)
 		if err != nil {
 			a.errorResponse(w, r, err)
 			return
@@ -144,7 +199,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 
 		blocks = append(blocks, block)
 	default:
-		blocks, err = a.app.GetBlocks(boardID, parentID, blockType)
+		blocks, err = a.app.GetBlocks(r.Context(), boardID, parentID, blockType)
 		if err != nil {
 			a.errorResponse(w, r, err)
 			return
@@ -160,7 +215,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	)
 
 	var bErr error
-	blocks, bErr = a.app.ApplyCloudLimits(blocks)
+	blocks, bErr = a.app.ApplyCloudLimits(r.Context(), blocks)
 	if bErr != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -241,7 +296,17 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// new cards and the contents posted along with them only need the
+	// permission to create cards
+	newCardIDs := map[string]bool{}
+	for _, block := range blocks {
+		if block.Type == model.TypeCard {
+			newCardIDs[block.ID] = true
+		}
+	}
+
 	hasComments := false
+	hasCards := false
 	hasContents := false
 	for _, block := range blocks {
 		// Error checking
@@ -251,9 +316,12 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if block.Type == model.TypeComment {
+		switch {
+		case block.Type == model.TypeComment:
 			hasComments = true
-		} else {
+		case block.Type == model.TypeCard || newCardIDs[block.ParentID]:
+			hasCards = true
+		default:
 			hasContents = true
 		}
 
@@ -276,6 +344,12 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if hasCards {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionCreateBoardCards) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to create cards"))
+			return
+		}
+	}
 	if hasContents {
 		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
 			a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
@@ -303,13 +377,13 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	// this query param exists when creating template from board, or board from template
 	sourceBoardID := r.URL.Query().Get("sourceBoardID")
 	if sourceBoardID != "" {
-		if updateFileIDsErr := a.app.CopyCardFiles(sourceBoardID, blocks); updateFileIDsErr != nil {
+		if updateFileIDsErr := a.app.CopyCardFiles(r.Context(), sourceBoardID, blocks); updateFileIDsErr != nil {
 			a.errorResponse(w, r, updateFileIDsErr)
 			return
 		}
 	}
 
-	newBlocks, err := a.app.InsertBlocksAndNotify(blocks, session.UserID, disableNotify)
+	newBlocks, err := a.app.InsertBlocksAndNotify(r.Context(), blocks, session.UserID, disableNotify)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -376,12 +450,12 @@ func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
 	val := r.URL.Query().Get("disable_notify")
 	disableNotify := val == True
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoardCards) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
 		return
 	}
 
-	block, err := a.app.GetBlockByID(blockID)
+	block, err := a.app.GetBlockByID(r.Context(), blockID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -397,7 +471,7 @@ func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("blockID", blockID)
 
-	err = a.app.DeleteBlockAndNotify(blockID, userID, disableNotify)
+	err = a.app.DeleteBlockAndNotify(r.Context(), blockID, userID, disableNotify)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -450,13 +524,13 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 	blockID := vars["blockID"]
 	boardID := vars["boardID"]
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.app.GetBoard(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
 
-	block, err := a.app.GetLastBlockHistoryEntry(blockID)
+	block, err := a.app.GetLastBlockHistoryEntry(r.Context(), blockID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -477,7 +551,7 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("blockID", blockID)
 
-	undeletedBlock, err := a.app.UndeleteBlock(blockID, userID)
+	undeletedBlock, err := a.app.UndeleteBlock(r.Context(), blockID, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -495,6 +569,425 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleGetDeletedBlocks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/blocks/deleted getDeletedBlocks
+	//
+	// Returns the deleted blocks of a board that are in the trash
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board trash"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getDeletedBlocks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	blocks, err := a.app.GetDeletedBlocksForBoard(r.Context(), boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetDeletedBlocks",
+		mlog.String("boardID", boardID),
+		mlog.Int("block_count", len(blocks)),
+	)
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("blockCount", len(blocks))
+	auditRec.Success()
+}
+
+func (a *API) handleRestoreBlock(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/restore restoreBlock
+	//
+	// Restores a block from the trash as it was before being deleted
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of block to restore
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '404':
+	//     description: block not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	blockID := vars["blockID"]
+	boardID := vars["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to restore block"))
+		return
+	}
+
+	block, err := a.app.GetLastBlockHistoryEntry(r.Context(), blockID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if block == nil || block.BoardID != boardID {
+		message := fmt.Sprintf("block ID=%s on BoardID=%s", blockID, boardID)
+		a.errorResponse(w, r, model.NewErrNotFound(message))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "restoreBlock", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+
+	restoredBlock, err := a.app.RestoreBlock(r.Context(), blockID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(restoredBlock)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RESTORE Block", mlog.String("blockID", blockID))
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetBlockHistory(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/blocks/{blockID}/history getBlockHistory
+	//
+	// Returns the versions of a block, most recent first. The update time
+	// of each version identifies it for the diff and revert APIs
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: Block ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '404':
+	//     description: block not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	blockID := vars["blockID"]
+	boardID := vars["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBlockHistory", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+
+	history, err := a.app.GetBlockHistory(r.Context(), blockID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if len(history) == 0 || history[0].BoardID != boardID {
+		message := fmt.Sprintf("block ID=%s on BoardID=%s", blockID, boardID)
+		a.errorResponse(w, r, model.NewErrNotFound(message))
+		return
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBlockHistory",
+		mlog.String("blockID", blockID),
+		mlog.Int("version_count", len(history)),
+	)
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetBlockDiff(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/blocks/{blockID}/diff getBlockDiff
+	//
+	// Returns the changes between two versions of a block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: Block ID
+	//   required: true
+	//   type: string
+	// - name: from
+	//   in: query
+	//   description: The version to compute the changes from, the update time of a history entry
+	//   required: true
+	//   type: integer
+	// - name: to
+	//   in: query
+	//   description: The version to compute the changes to, the update time of a history entry
+	//   required: true
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BlockDiff"
+	//   '404':
+	//     description: block or version not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	blockID := vars["blockID"]
+	boardID := vars["boardID"]
+	query := r.URL.Query()
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	fromVersion, err := strconv.ParseInt(query.Get("from"), 10, 64)
+	if err != nil {
+		message := fmt.Sprintf("error converting from parameter to integer: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	toVersion, err := strconv.ParseInt(query.Get("to"), 10, 64)
+	if err != nil {
+		message := fmt.Sprintf("error converting to parameter to integer: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	block, err := a.app.GetLastBlockHistoryEntry(r.Context(), blockID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if block == nil || block.BoardID != boardID {
+		message := fmt.Sprintf("block ID=%s on BoardID=%s", blockID, boardID)
+		a.errorResponse(w, r, model.NewErrNotFound(message))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBlockDiff", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+
+	diff, err := a.app.GetBlockDiff(r.Context(), blockID, fromVersion, toVersion)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(diff)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBlockDiff",
+		mlog.String("blockID", blockID),
+		mlog.Int("change_count", len(diff.Changes)),
+	)
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleRevertBlock(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/revert revertBlock
+	//
+	// Reverts a block to a previous version, recording the revert as a new
+	// version
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of block to revert
+	//   required: true
+	//   type: string
+	// - name: version
+	//   in: query
+	//   description: The version to revert to, the update time of a history entry
+	//   required: true
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '404':
+	//     description: block or version not found
+	//   '409':
+	//     description: the block was updated during the revert
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	blockID := vars["blockID"]
+	boardID := vars["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify board cards"))
+		return
+	}
+
+	version, err := strconv.ParseInt(r.URL.Query().Get("version"), 10, 64)
+	if err != nil {
+		message := fmt.Sprintf("error converting version parameter to integer: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	block, err := a.app.GetBlockByID(r.Context(), blockID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if block.BoardID != boardID {
+		message := fmt.Sprintf("block ID=%s on BoardID=%s", blockID, boardID)
+		a.errorResponse(w, r, model.NewErrNotFound(message))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "revertBlock", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+	auditRec.AddMeta("version", version)
+
+	revertedBlock, err := a.app.RevertBlock(r.Context(), blockID, version, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(revertedBlock)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("REVERT Block", mlog.String("blockID", blockID), mlog.Int64("version", version))
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
 func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation PATCH /boards/{boardID}/blocks/{blockID} patchBlock
 	//
@@ -550,7 +1043,7 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	block, err := a.app.GetBlockByID(blockID)
+	block, err := a.app.GetBlockByID(r.Context(), blockID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -579,7 +1072,7 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("blockID", blockID)
 
-	if _, err = a.app.PatchBlockAndNotify(blockID, patch, userID, disableNotify); err != nil {
+	if _, err = a.app.PatchBlockAndNotify(r.Context(), blockID, patch, userID, disableNotify); err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
@@ -656,7 +1149,7 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 
 	for _, blockID := range patches.BlockIDs {
 		var block *model.Block
-		block, err = a.app.GetBlockByID(blockID)
+		block, err = a.app.GetBlockByID(r.Context(), blockID)
 		if err != nil {
 			a.errorResponse(w, r, model.NewErrForbidden("access denied to make board changes"))
 			return
@@ -667,7 +1160,7 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	err = a.app.PatchBlocksAndNotify(teamID, patches, userID, disableNotify)
+	err = a.app.PatchBlocksAndNotify(r.Context(), teamID, patches, userID, disableNotify)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -720,7 +1213,7 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	asTemplate := query.Get("asTemplate")
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.app.GetBoard(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -731,7 +1224,7 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	block, err := a.app.GetBlockByID(blockID)
+	block, err := a.app.GetBlockByID(r.Context(), blockID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -765,7 +1258,7 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 		mlog.String("blockID", blockID),
 	)
 
-	blocks, err := a.app.DuplicateBlock(boardID, blockID, userID, asTemplate == True)
+	blocks, err := a.app.DuplicateBlock(r.Context(), boardID, blockID, userID, asTemplate == True)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -0,0 +1,432 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerBoardRolesRoutes(r *mux.Router) {
+	// Custom board role APIs
+	r.HandleFunc("/boards/{boardID}/roles", a.sessionRequired(a.handleGetBoardCustomRoles)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/roles", a.sessionRequired(a.handleCreateBoardCustomRole)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/roles/{roleID}", a.sessionRequired(a.handleUpdateBoardCustomRole)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/roles/{roleID}", a.sessionRequired(a.handleDeleteBoardCustomRole)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/members/{userID}/role", a.sessionRequired(a.handleSetMemberCustomRole)).Methods("PUT")
+}
+
+func (a *API) handleGetBoardCustomRoles(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/roles getBoardCustomRoles
+	//
+	// Returns the custom roles of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardCustomRole"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board roles"))
+		return
+	}
+
+	roles, err := a.app.GetBoardCustomRoles(r.Context(), boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(roles)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleCreateBoardCustomRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/roles createBoardCustomRole
+	//
+	// Creates a custom role for a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: role definition
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardCustomRole"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardCustomRole"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board roles"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var role model.BoardCustomRole
+	if err = json.Unmarshal(requestBody, &role); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	role.ID = ""
+	role.BoardID = boardID
+	role.CreatedBy = userID
+
+	if err = role.IsValid(); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createBoardCustomRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err = a.app.CreateBoardCustomRole(r.Context(), &role); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateBoardCustomRole",
+		mlog.String("boardID", boardID),
+		mlog.String("roleID", role.ID),
+	)
+
+	data, err := json.Marshal(role)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("roleID", role.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateBoardCustomRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/roles/{roleID} updateBoardCustomRole
+	//
+	// Updates the name and permissions of a custom board role
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: roleID
+	//   in: path
+	//   description: Role ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: role definition
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardCustomRole"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardCustomRole"
+	//   '404':
+	//     description: role not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	roleID := vars["roleID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board roles"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var role model.BoardCustomRole
+	if err = json.Unmarshal(requestBody, &role); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	existing, err := a.app.GetBoardCustomRole(r.Context(), roleID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if existing.BoardID != boardID {
+		a.errorResponse(w, r, model.NewErrNotFound("board custom role ID="+roleID))
+		return
+	}
+
+	role.ID = roleID
+	role.BoardID = boardID
+	role.CreatedBy = existing.CreatedBy
+	role.CreateAt = existing.CreateAt
+
+	if err = role.IsValid(); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateBoardCustomRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("roleID", roleID)
+
+	if err = a.app.UpdateBoardCustomRole(r.Context(), &role, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateBoardCustomRole",
+		mlog.String("boardID", boardID),
+		mlog.String("roleID", roleID),
+	)
+
+	data, err := json.Marshal(role)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardCustomRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/roles/{roleID} deleteBoardCustomRole
+	//
+	// Deletes a custom board role. The members it was assigned to get back
+	// their board member permissions
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: roleID
+	//   in: path
+	//   description: Role ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: role not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	roleID := vars["roleID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board roles"))
+		return
+	}
+
+	existing, err := a.app.GetBoardCustomRole(r.Context(), roleID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if existing.BoardID != boardID {
+		a.errorResponse(w, r, model.NewErrNotFound("board custom role ID="+roleID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardCustomRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("roleID", roleID)
+
+	if err = a.app.DeleteBoardCustomRole(r.Context(), roleID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteBoardCustomRole",
+		mlog.String("boardID", boardID),
+		mlog.String("roleID", roleID),
+	)
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleSetMemberCustomRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/members/{userID}/role setMemberCustomRole
+	//
+	// Assigns a custom role of the board to a member, or unassigns it if the
+	// role ID is empty. A member with a custom role gets the permissions of
+	// the role instead of the ones of its member scheme
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: userID
+	//   in: path
+	//   description: User ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the custom role ID, under customRoleId
+	//   required: true
+	//   schema:
+	//     type: object
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardMember"
+	//   '404':
+	//     description: board member not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	memberID := vars["userID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board roles"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var body struct {
+		CustomRoleID string `json:"customRoleId"`
+	}
+	if err = json.Unmarshal(requestBody, &body); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setMemberCustomRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("memberID", memberID)
+	auditRec.AddMeta("roleID", body.CustomRoleID)
+
+	member, err := a.app.SetMemberCustomRole(r.Context(), boardID, memberID, body.CustomRoleID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetMemberCustomRole",
+		mlog.String("boardID", boardID),
+		mlog.String("memberID", memberID),
+		mlog.String("roleID", body.CustomRoleID),
+	)
+
+	data, err := json.Marshal(member)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
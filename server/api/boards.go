@@ -20,6 +20,9 @@ func (a *API) registerBoardsRoutes(r *mux.Router) {
 	r.HandleFunc("/boards/{boardID}", a.sessionRequired(a.handleDeleteBoard)).Methods("DELETE")
 	r.HandleFunc("/boards/{boardID}/duplicate", a.sessionRequired(a.handleDuplicateBoard)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/undelete", a.sessionRequired(a.handleUndeleteBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/archive", a.sessionRequired(a.handleArchiveBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/restore", a.sessionRequired(a.handleRestoreBoard)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}/boards/archived", a.sessionRequired(a.handleGetArchivedBoards)).Methods("GET")
 	r.HandleFunc("/boards/{boardID}/metadata", a.sessionRequired(a.handleGetBoardMetadata)).Methods("GET")
 }
 
@@ -37,11 +40,21 @@ func (a *API) handleGetBoards(w http.ResponseWriter, r *http.Request) {
 	//   description: Team ID
 	//   required: true
 	//   type: string
+	// - name: cursor
+	//   in: query
+	//   description: Return the boards after this cursor, taken from the X-Next-Cursor header of the previous page
+	//   required: false
+	//   type: string
+	// - name: per_page
+	//   in: query
+	//   description: Number of boards to return per page (default=100 when a cursor is given, all the boards otherwise)
+	//   required: false
+	//   type: integer
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
-	//     description: success
+	//     description: success, with the X-Next-Cursor header set when there are more boards
 	//     schema:
 	//       type: array
 	//       items:
@@ -63,19 +76,38 @@ func (a *API) handleGetBoards(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("teamID", teamID)
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
 
-	// retrieve boards list
-	boards, err := a.app.GetBoardsForUserAndTeam(userID, teamID, !isGuest)
+	cursor, perPage, paginated, err := getCursorPage(r)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
 
+	// retrieve boards list
+	var boards []*model.Board
+	if paginated {
+		var hasMore bool
+		boards, hasMore, err = a.app.GetBoardsForUserAndTeamPage(r.Context(), userID, teamID, !isGuest, cursor, perPage)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+		if hasMore {
+			setNextCursor(w, boards[len(boards)-1].ID)
+		}
+	} else {
+		boards, err = a.app.GetBoardsForUserAndTeam(r.Context(), userID, teamID, !isGuest)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
 	a.logger.Debug("GetBoards",
 		mlog.String("teamID", teamID),
 		mlog.Int("boardsCount", len(boards)),
@@ -147,7 +179,7 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -168,7 +200,7 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardType", newBoard.Type)
 
 	// create board
-	board, err := a.app.CreateBoard(newBoard, userID, true)
+	board, err := a.app.CreateBoard(r.Context(), newBoard, userID, true)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -230,7 +262,7 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.app.GetBoard(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -244,7 +276,7 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 			}
 		} else {
 			var isGuest bool
-			isGuest, err = a.userIsGuest(userID)
+			isGuest, err = a.userIsGuest(r.Context(), userID)
 			if err != nil {
 				a.errorResponse(w, r, err)
 				return
@@ -271,6 +303,15 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 		mlog.String("boardID", boardID),
 	)
 
+	snapshot, err := a.publishedSnapshotForReadToken(r.Context(), userID, hasValidReadToken, boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if snapshot != nil && snapshot.Board != nil {
+		board = snapshot.Board
+	}
+
 	data, err := json.Marshal(board)
 	if err != nil {
 		a.errorResponse(w, r, err)
@@ -318,7 +359,7 @@ func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
 	//       "$ref": "#/definitions/ErrorResponse"
 
 	boardID := mux.Vars(r)["boardID"]
-	if _, err := a.app.GetBoard(boardID); err != nil {
+	if _, err := a.app.GetBoard(r.Context(), boardID); err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
@@ -366,7 +407,7 @@ func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("userID", userID)
 
 	// patch board
-	updatedBoard, err := a.app.PatchBoard(patch, boardID, userID)
+	updatedBoard, err := a.app.PatchBoard(r.Context(), patch, boardID, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -392,7 +433,7 @@ func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
 func (a *API) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation DELETE /boards/{boardID} deleteBoard
 	//
-	// Removes a board
+	// Moves a board to the trash, from where it can be restored until it is purged
 	//
 	// ---
 	// produces:
@@ -419,7 +460,7 @@ func (a *API) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
 	userID := getUserID(r)
 
 	// Check if board exists
-	if _, err := a.app.GetBoard(boardID); err != nil {
+	if _, err := a.app.GetBoard(r.Context(), boardID); err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
@@ -433,7 +474,7 @@ func (a *API) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
-	if err := a.app.DeleteBoard(boardID, userID); err != nil {
+	if err := a.app.DeleteBoard(r.Context(), boardID, userID); err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
@@ -483,7 +524,7 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.app.GetBoard(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -515,7 +556,7 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -533,7 +574,7 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 		mlog.String("boardID", boardID),
 	)
 
-	boardsAndBlocks, _, err := a.app.DuplicateBoard(boardID, userID, toTeam, asTemplate == True)
+	boardsAndBlocks, _, err := a.app.DuplicateBoard(r.Context(), boardID, userID, toTeam, asTemplate == True)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -591,7 +632,7 @@ func (a *API) handleUndeleteBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := a.app.UndeleteBoard(boardID, userID)
+	err := a.app.UndeleteBoard(r.Context(), boardID, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -603,6 +644,184 @@ func (a *API) handleUndeleteBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleArchiveBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/archive archiveBoard
+	//
+	// Moves a board to the trash, from where it can be restored until it is
+	// purged
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of board to archive
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to archive board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "archiveBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.app.ArchiveBoard(r.Context(), boardID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ARCHIVE Board", mlog.String("boardID", boardID))
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleRestoreBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/restore restoreBoard
+	//
+	// Restores a board from the trash
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of board to restore
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Board"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to restore board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "restoreBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	board, err := a.app.RestoreBoard(r.Context(), boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RESTORE Board", mlog.String("boardID", boardID))
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetArchivedBoards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/boards/archived getArchivedBoards
+	//
+	// Returns the boards of a team in the trash that the user can restore
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Board"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getArchivedBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	archivedBoards, err := a.app.GetArchivedBoards(r.Context(), teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	boards := []*model.Board{}
+	for _, board := range archivedBoards {
+		if a.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionDeleteBoard) {
+			boards = append(boards, board)
+		}
+	}
+
+	a.logger.Debug("GetArchivedBoards",
+		mlog.String("teamID", teamID),
+		mlog.Int("boardsCount", len(boards)),
+	)
+
+	data, err := json.Marshal(boards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardsCount", len(boards))
+	auditRec.Success()
+}
+
 func (a *API) handleGetBoardMetadata(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /boards/{boardID}/metadata getBoardMetadata
 	//
@@ -636,7 +855,7 @@ func (a *API) handleGetBoardMetadata(w http.ResponseWriter, r *http.Request) {
 	boardID := mux.Vars(r)["boardID"]
 	userID := getUserID(r)
 
-	board, boardMetadata, err := a.app.GetBoardMetadata(boardID)
+	board, boardMetadata, err := a.app.GetBoardMetadata(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
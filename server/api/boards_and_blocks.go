@@ -92,7 +92,7 @@ func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -145,7 +145,7 @@ func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	auditRec.AddMeta("blocksCount", len(newBab.Blocks))
 
 	// create boards and blocks
-	bab, err := a.app.CreateBoardsAndBlocks(newBab, userID, true)
+	bab, err := a.app.CreateBoardsAndBlocks(r.Context(), newBab, userID, true)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -239,7 +239,7 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 			}
 		}
 
-		board, err2 := a.app.GetBoard(boardID)
+		board, err2 := a.app.GetBoard(r.Context(), boardID)
 		if err2 != nil {
 			a.errorResponse(w, r, err2)
 			return
@@ -255,7 +255,7 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 	}
 
 	for _, blockID := range pbab.BlockIDs {
-		block, err2 := a.app.GetBlockByID(blockID)
+		block, err2 := a.app.GetBlockByID(r.Context(), blockID)
 		if err2 != nil {
 			a.errorResponse(w, r, err2)
 			return
@@ -277,7 +277,7 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 	auditRec.AddMeta("boardsCount", len(pbab.BoardIDs))
 	auditRec.AddMeta("blocksCount", len(pbab.BlockIDs))
 
-	bab, err := a.app.PatchBoardsAndBlocks(pbab, userID)
+	bab, err := a.app.PatchBoardsAndBlocks(r.Context(), pbab, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -346,7 +346,7 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	for _, boardID := range dbab.Boards {
 		boardIDMap[boardID] = true
 		// all boards in the request should belong to the same team
-		board, err := a.app.GetBoard(boardID)
+		board, err := a.app.GetBoard(r.Context(), boardID)
 		if err != nil {
 			a.errorResponse(w, r, err)
 			return
@@ -367,7 +367,7 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	}
 
 	for _, blockID := range dbab.Blocks {
-		block, err2 := a.app.GetBlockByID(blockID)
+		block, err2 := a.app.GetBlockByID(r.Context(), blockID)
 		if err2 != nil {
 			a.errorResponse(w, r, err2)
 			return
@@ -394,7 +394,7 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	auditRec.AddMeta("boardsCount", len(dbab.Boards))
 	auditRec.AddMeta("blocksCount", len(dbab.Blocks))
 
-	if err := a.app.DeleteBoardsAndBlocks(dbab, userID); err != nil {
+	if err := a.app.DeleteBoardsAndBlocks(r.Context(), dbab, userID); err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
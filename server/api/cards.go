@@ -82,7 +82,7 @@ func (a *API) handleCreateCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionCreateBoardCards) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to create card"))
 		return
 	}
@@ -103,7 +103,7 @@ func (a *API) handleCreateCard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 
 	// create card
-	card, err := a.app.CreateCard(newCard, boardID, userID, disableNotify)
+	card, err := a.app.CreateCard(r.Context(), newCard, boardID, userID, disableNotify)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -201,7 +201,7 @@ func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("page", page)
 	auditRec.AddMeta("per_page", perPage)
 
-	cards, err := a.app.GetCardsForBoard(boardID, page, perPage)
+	cards, err := a.app.GetCardsForBoard(r.Context(), boardID, page, perPage)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -276,7 +276,7 @@ func (a *API) handlePatchCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	card, err := a.app.GetCardByID(cardID)
+	card, err := a.app.GetCardByID(r.Context(), cardID)
 	if err != nil {
 		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
 		a.errorResponse(w, r, model.NewErrBadRequest(message))
@@ -300,7 +300,7 @@ func (a *API) handlePatchCard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("cardID", card.ID)
 
 	// patch card
-	cardPatched, err := a.app.PatchCard(patch, card.ID, userID, disableNotify)
+	cardPatched, err := a.app.PatchCard(r.Context(), patch, card.ID, userID, disableNotify)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -353,7 +353,7 @@ func (a *API) handleGetCard(w http.ResponseWriter, r *http.Request) {
 	userID := getUserID(r)
 	cardID := mux.Vars(r)["cardID"]
 
-	card, err := a.app.GetCardByID(cardID)
+	card, err := a.app.GetCardByID(r.Context(), cardID)
 	if err != nil {
 		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
 		a.errorResponse(w, r, model.NewErrBadRequest(message))
@@ -87,7 +87,7 @@ func (a *API) handleCreateCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	createdCategory, err := a.app.CreateCategory(&category)
+	createdCategory, err := a.app.CreateCategory(r.Context(), &category)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -180,7 +180,7 @@ func (a *API) handleUpdateCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedCategory, err := a.app.UpdateCategory(&category)
+	updatedCategory, err := a.app.UpdateCategory(r.Context(), &category)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -236,7 +236,7 @@ func (a *API) handleDeleteCategory(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "deleteCategory", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 
-	deletedCategory, err := a.app.DeleteCategory(categoryID, userID, teamID)
+	deletedCategory, err := a.app.DeleteCategory(r.Context(), categoryID, userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -290,7 +290,7 @@ func (a *API) handleGetUserCategoryBoards(w http.ResponseWriter, r *http.Request
 	auditRec := a.makeAuditRecord(r, "getUserCategoryBoards", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 
-	categoryBlocks, err := a.app.GetUserCategoryBoards(userID, teamID)
+	categoryBlocks, err := a.app.GetUserCategoryBoards(r.Context(), userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -353,7 +353,7 @@ func (a *API) handleUpdateCategoryBoard(w http.ResponseWriter, r *http.Request)
 	userID := session.UserID
 
 	// TODO: Check the category and the team matches
-	err := a.app.AddUpdateUserCategoryBoard(teamID, userID, categoryID, boardID)
+	err := a.app.AddUpdateUserCategoryBoard(r.Context(), teamID, userID, categoryID, boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
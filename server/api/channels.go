@@ -74,7 +74,7 @@ func (a *API) handleGetChannel(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", teamID)
 	auditRec.AddMeta("channelID", teamID)
 
-	channel, err := a.app.GetChannel(teamID, channelID)
+	channel, err := a.app.GetChannel(r.Context(), teamID, channelID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
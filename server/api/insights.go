@@ -102,7 +102,7 @@ func (a *API) handleTeamBoardsInsights(w http.ResponseWriter, r *http.Request) {
 		a.errorResponse(w, r, model.NewErrBadRequest("Invalid page parameter"))
 	}
 
-	userTimezone, aErr := a.app.GetUserTimezone(userID)
+	userTimezone, aErr := a.app.GetUserTimezone(r.Context(), userID)
 	if aErr != nil {
 		message := fmt.Sprintf("Error getting time zone of user: %s", aErr)
 		a.errorResponse(w, r, model.NewErrBadRequest(message))
@@ -114,7 +114,7 @@ func (a *API) handleTeamBoardsInsights(w http.ResponseWriter, r *http.Request) {
 	}
 	// get unix time for duration
 	startTime := mmModel.StartOfDayForTimeRange(timeRange, userLocation)
-	boardsInsights, err := a.app.GetTeamBoardsInsights(userID, teamID, &mmModel.InsightsOpts{
+	boardsInsights, err := a.app.GetTeamBoardsInsights(r.Context(), userID, teamID, &mmModel.InsightsOpts{
 		StartUnixMilli: mmModel.GetMillisForTime(*startTime),
 		Page:           page,
 		PerPage:        perPage,
@@ -215,7 +215,7 @@ func (a *API) handleUserBoardsInsights(w http.ResponseWriter, r *http.Request) {
 	if perPage < 0 {
 		a.errorResponse(w, r, model.NewErrBadRequest("Invalid page parameter"))
 	}
-	userTimezone, aErr := a.app.GetUserTimezone(userID)
+	userTimezone, aErr := a.app.GetUserTimezone(r.Context(), userID)
 	if aErr != nil {
 		message := fmt.Sprintf("Error getting time zone of user: %s", aErr)
 		a.errorResponse(w, r, model.NewErrBadRequest(message))
@@ -227,7 +227,7 @@ func (a *API) handleUserBoardsInsights(w http.ResponseWriter, r *http.Request) {
 	}
 	// get unix time for duration
 	startTime := mmModel.StartOfDayForTimeRange(timeRange, userLocation)
-	boardsInsights, err := a.app.GetUserBoardsInsights(userID, teamID, &mmModel.InsightsOpts{
+	boardsInsights, err := a.app.GetUserBoardsInsights(r.Context(), userID, teamID, &mmModel.InsightsOpts{
 		StartUnixMilli: mmModel.GetMillisForTime(*startTime),
 		Page:           page,
 		PerPage:        perPage,
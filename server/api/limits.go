@@ -34,7 +34,7 @@ func (a *API) handleCloudLimits(w http.ResponseWriter, r *http.Request) {
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	boardsCloudLimits, err := a.app.GetBoardsCloudLimits()
+	boardsCloudLimits, err := a.app.GetBoardsCloudLimits(r.Context())
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -81,7 +81,7 @@ func (a *API) handleNotifyAdminUpgrade(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamID := vars["teamID"]
 
-	if err := a.app.NotifyPortalAdminsUpgradeRequest(teamID); err != nil {
+	if err := a.app.NotifyPortalAdminsUpgradeRequest(r.Context(), teamID); err != nil {
 		jsonStringResponse(w, http.StatusOK, "{}")
 	}
 }
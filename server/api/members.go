@@ -35,11 +35,21 @@ func (a *API) handleGetMembersForBoard(w http.ResponseWriter, r *http.Request) {
 	//   description: Board ID
 	//   required: true
 	//   type: string
+	// - name: cursor
+	//   in: query
+	//   description: Return the members after this cursor, taken from the X-Next-Cursor header of the previous page
+	//   required: false
+	//   type: string
+	// - name: per_page
+	//   in: query
+	//   description: Number of members to return per page (default=100 when a cursor is given, all the members otherwise)
+	//   required: false
+	//   type: integer
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
-	//     description: success
+	//     description: success, with the X-Next-Cursor header set when there are more members
 	//     schema:
 	//       type: array
 	//       items:
@@ -61,12 +71,31 @@ func (a *API) handleGetMembersForBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
-	members, err := a.app.GetMembersForBoard(boardID)
+	cursor, perPage, paginated, err := getCursorPage(r)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
 
+	var members []*model.BoardMember
+	if paginated {
+		var hasMore bool
+		members, hasMore, err = a.app.GetMembersForBoardPage(r.Context(), boardID, cursor, perPage)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+		if hasMore {
+			setNextCursor(w, members[len(members)-1].UserID)
+		}
+	} else {
+		members, err = a.app.GetMembersForBoard(r.Context(), boardID)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
 	a.logger.Debug("GetMembersForBoard",
 		mlog.String("boardID", boardID),
 		mlog.Int("membersCount", len(members)),
@@ -121,7 +150,7 @@ func (a *API) handleAddMember(w http.ResponseWriter, r *http.Request) {
 	boardID := mux.Vars(r)["boardID"]
 	userID := getUserID(r)
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.app.GetBoard(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -164,7 +193,7 @@ func (a *API) handleAddMember(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", reqBoardMember.UserID)
 
-	member, err := a.app.AddMemberToBoard(newBoardMember)
+	member, err := a.app.AddMemberToBoard(r.Context(), newBoardMember, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -224,7 +253,7 @@ func (a *API) handleJoinBoard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	boardID := mux.Vars(r)["boardID"]
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.app.GetBoard(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -239,7 +268,7 @@ func (a *API) handleJoinBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -263,7 +292,7 @@ func (a *API) handleJoinBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", userID)
 
-	member, err := a.app.AddMemberToBoard(newBoardMember)
+	member, err := a.app.AddMemberToBoard(r.Context(), newBoardMember, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -327,7 +356,7 @@ func (a *API) handleLeaveBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.app.GetBoard(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -338,7 +367,7 @@ func (a *API) handleLeaveBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", userID)
 
-	err = a.app.DeleteBoardMember(boardID, userID)
+	err = a.app.DeleteBoardMember(r.Context(), boardID, userID, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -416,7 +445,7 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 		SchemeViewer:    reqBoardMember.SchemeViewer,
 	}
 
-	isGuest, err := a.userIsGuest(paramsUserID)
+	isGuest, err := a.userIsGuest(r.Context(), paramsUserID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -436,7 +465,7 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("patchedUserID", paramsUserID)
 
-	member, err := a.app.UpdateBoardMember(newBoardMember)
+	member, err := a.app.UpdateBoardMember(r.Context(), newBoardMember, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -494,7 +523,7 @@ func (a *API) handleDeleteMember(w http.ResponseWriter, r *http.Request) {
 	paramsUserID := mux.Vars(r)["userID"]
 	userID := getUserID(r)
 
-	if _, err := a.app.GetBoard(boardID); err != nil {
+	if _, err := a.app.GetBoard(r.Context(), boardID); err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
@@ -509,7 +538,7 @@ func (a *API) handleDeleteMember(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", paramsUserID)
 
-	deleteErr := a.app.DeleteBoardMember(boardID, paramsUserID)
+	deleteErr := a.app.DeleteBoardMember(r.Context(), boardID, paramsUserID, userID)
 	if deleteErr != nil {
 		a.errorResponse(w, r, deleteErr)
 		return
@@ -53,7 +53,7 @@ func (a *API) handleOnboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -63,7 +63,7 @@ func (a *API) handleOnboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	teamID, boardID, err := a.app.PrepareOnboardingTour(userID, teamID)
+	teamID, boardID, err := a.app.PrepareOnboardingTour(r.Context(), userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
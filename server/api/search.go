@@ -2,7 +2,9 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/model"
@@ -16,6 +18,7 @@ func (a *API) registerSearchRoutes(r *mux.Router) {
 	r.HandleFunc("/teams/{teamID}/boards/search", a.sessionRequired(a.handleSearchBoards)).Methods("GET")
 	r.HandleFunc("/teams/{teamID}/boards/search/linkable", a.sessionRequired(a.handleSearchLinkableBoards)).Methods("GET")
 	r.HandleFunc("/boards/search", a.sessionRequired(a.handleSearchAllBoards)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/blocks/search", a.sessionRequired(a.handleSearchBlocks)).Methods("GET")
 }
 
 func (a *API) handleSearchMyChannels(w http.ResponseWriter, r *http.Request) {
@@ -71,7 +74,7 @@ func (a *API) handleSearchMyChannels(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("teamID", teamID)
 
-	channels, err := a.app.SearchUserChannels(teamID, userID, searchQuery)
+	channels, err := a.app.SearchUserChannels(r.Context(), teamID, userID, searchQuery)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -146,14 +149,14 @@ func (a *API) handleSearchBoards(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("teamID", teamID)
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
 
 	// retrieve boards list
-	boards, err := a.app.SearchBoardsForUser(term, userID, !isGuest)
+	boards, err := a.app.SearchBoardsForUser(r.Context(), term, userID, !isGuest)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -235,7 +238,7 @@ func (a *API) handleSearchLinkableBoards(w http.ResponseWriter, r *http.Request)
 	auditRec.AddMeta("teamID", teamID)
 
 	// retrieve boards list
-	boards, err := a.app.SearchBoardsForUserInTeam(teamID, term, userID)
+	boards, err := a.app.SearchBoardsForUserInTeam(r.Context(), teamID, term, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -305,14 +308,14 @@ func (a *API) handleSearchAllBoards(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "searchAllBoards", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
 
 	// retrieve boards list
-	boards, err := a.app.SearchBoardsForUser(term, userID, !isGuest)
+	boards, err := a.app.SearchBoardsForUser(r.Context(), term, userID, !isGuest)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -334,3 +337,124 @@ func (a *API) handleSearchAllBoards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardsCount", len(boards))
 	auditRec.Success()
 }
+
+func (a *API) handleSearchBlocks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/blocks/search searchBlocks
+	//
+	// Returns the blocks that match with a search term in the boards of the
+	// team that the user can access
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: The search term. Must have at least one character
+	//   required: true
+	//   type: string
+	// - name: type
+	//   in: query
+	//   description: The block types to search, can be repeated
+	//   required: false
+	//   type: array
+	//   items:
+	//     type: string
+	// - name: limit
+	//   in: query
+	//   description: The maximum number of blocks to return
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	query := r.URL.Query()
+	teamID := mux.Vars(r)["teamID"]
+	term := query.Get("q")
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+
+	if len(term) == 0 {
+		jsonStringResponse(w, http.StatusOK, "[]")
+		return
+	}
+
+	isGuest, err := a.userIsGuest(r.Context(), userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	opts := model.SearchBlocksOptions{IncludePublicBoards: !isGuest}
+	for _, t := range query["type"] {
+		blockType, typeErr := model.BlockTypeFromString(t)
+		if typeErr != nil {
+			a.errorResponse(w, r, model.NewErrBadRequest(typeErr.Error()))
+			return
+		}
+		opts.BlockTypes = append(opts.BlockTypes, blockType)
+	}
+
+	if strLimit := query.Get("limit"); strLimit != "" {
+		limit, limitErr := strconv.ParseUint(strLimit, 10, 64)
+		if limitErr != nil {
+			message := fmt.Sprintf("error converting limit parameter to integer: %s", limitErr)
+			a.errorResponse(w, r, model.NewErrBadRequest(message))
+			return
+		}
+		opts.Limit = limit
+	}
+
+	auditRec := a.makeAuditRecord(r, "searchBlocks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	blocks, err := a.app.SearchBlocksForUser(r.Context(), teamID, term, userID, opts)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	blocks, err = a.app.ApplyCloudLimits(r.Context(), blocks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SearchBlocks",
+		mlog.String("teamID", teamID),
+		mlog.Int("blocksCount", len(blocks)),
+	)
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("blocksCount", len(blocks))
+	auditRec.Success()
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -19,6 +20,12 @@ func (a *API) registerSharingRoutes(r *mux.Router) {
 	// Sharing APIs
 	r.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(a.handlePostSharing)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(a.handleGetSharing)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/sharing/snapshot", a.sessionRequired(a.handlePostSharingSnapshot)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/sharing/rotate", a.sessionRequired(a.handlePostSharingRotate)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/sharing/tokens", a.sessionRequired(a.handleGetShareTokens)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/sharing/tokens", a.sessionRequired(a.handleCreateShareToken)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/sharing/tokens/{shareTokenID}", a.sessionRequired(a.handleDeleteShareToken)).Methods("DELETE")
+	r.HandleFunc("/share/{token}", a.handleGetSharedContent).Methods("GET")
 }
 
 func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
@@ -62,7 +69,7 @@ func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
-	sharing, err := a.app.GetSharing(boardID)
+	sharing, err := a.app.GetSharing(r.Context(), boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -167,7 +174,7 @@ func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
 
 	sharing.ModifiedBy = userID
 
-	err = a.app.UpsertSharing(sharing)
+	err = a.app.UpsertSharing(r.Context(), sharing)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -178,3 +185,430 @@ func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
 	a.logger.Debug("POST sharing", mlog.String("sharingID", sharing.ID))
 	auditRec.Success()
 }
+
+func (a *API) handlePostSharingSnapshot(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/sharing/snapshot postSharingSnapshot
+	//
+	// Publishes a read-only snapshot of a board, which is served to public
+	// viewers instead of the live board. Publishing again replaces the
+	// previous snapshot.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardSnapshot"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to sharing the board"))
+		return
+	}
+
+	if !a.app.GetClientConfig().EnablePublicSharedBoards {
+		a.logger.Warn(
+			"Attempt to publish a board snapshot via API failed, sharing off in configuration.",
+			mlog.String("boardID", boardID),
+			mlog.String("userID", userID))
+		a.errorResponse(w, r, ErrTurningOnSharing)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "postSharingSnapshot", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if userID == model.SingleUser {
+		userID = ""
+	}
+
+	snapshot, err := a.app.PublishBoardSnapshot(r.Context(), boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	a.logger.Debug("POST sharing snapshot", mlog.String("boardID", boardID))
+	auditRec.AddMeta("blockCount", len(snapshot.Blocks))
+	auditRec.Success()
+}
+
+func (a *API) handlePostSharingRotate(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/sharing/rotate postSharingRotate
+	//
+	// Replaces the sharing token of a board with a new one, which
+	// invalidates the links built with the previous token.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Sharing"
+	//   '404':
+	//     description: sharing not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to sharing the board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "postSharingRotate", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if userID == model.SingleUser {
+		userID = ""
+	}
+
+	sharing, err := a.app.RotateSharingToken(r.Context(), boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	sharingData, err := json.Marshal(sharing)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, sharingData)
+
+	a.logger.Debug("POST sharing rotate", mlog.String("boardID", boardID))
+	auditRec.Success()
+}
+
+func (a *API) handleGetShareTokens(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/sharing/tokens getShareTokens
+	//
+	// Returns the share tokens of a board, including the expired ones
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/ShareToken"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to sharing the board"))
+		return
+	}
+
+	shareTokens, err := a.app.GetShareTokensForBoard(r.Context(), boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(shareTokens)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleCreateShareToken(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/sharing/tokens createShareToken
+	//
+	// Creates a read-only share token for a board, or for one of its cards.
+	// The token can expire and be protected by a password
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: share token definition
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ShareTokenWithPassword"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ShareToken"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to sharing the board"))
+		return
+	}
+
+	if !a.app.GetClientConfig().EnablePublicSharedBoards {
+		a.logger.Warn(
+			"Attempt to create a share token via API failed, sharing off in configuration.",
+			mlog.String("boardID", boardID),
+			mlog.String("userID", userID))
+		a.errorResponse(w, r, ErrTurningOnSharing)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var shareToken model.ShareTokenWithPassword
+	if err = json.Unmarshal(requestBody, &shareToken); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if userID == model.SingleUser {
+		userID = ""
+	}
+
+	shareToken.BoardID = boardID
+	shareToken.CreatedBy = userID
+	if shareToken.Scope == "" {
+		shareToken.Scope = model.ShareTokenScopeBoard
+	}
+
+	if err = shareToken.IsValid(); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createShareToken", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("scope", shareToken.Scope)
+
+	if err = a.app.CreateShareToken(r.Context(), &shareToken.ShareToken, shareToken.Password); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(shareToken.ShareToken)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	a.logger.Debug("POST share token",
+		mlog.String("boardID", boardID),
+		mlog.String("shareTokenID", shareToken.ID),
+	)
+	auditRec.AddMeta("shareTokenID", shareToken.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteShareToken(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/sharing/tokens/{shareTokenID} deleteShareToken
+	//
+	// Deletes a share token of a board, which revokes the links built with it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: shareTokenID
+	//   in: path
+	//   description: Share token ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: share token not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	shareTokenID := vars["shareTokenID"]
+
+	userID := getUserID(r)
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to sharing the board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteShareToken", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("shareTokenID", shareTokenID)
+
+	if err := a.app.DeleteShareToken(r.Context(), boardID, shareTokenID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	a.logger.Debug("DELETE share token",
+		mlog.String("boardID", boardID),
+		mlog.String("shareTokenID", shareTokenID),
+	)
+	auditRec.Success()
+}
+
+func (a *API) handleGetSharedContent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /share/{token} getSharedContent
+	//
+	// Returns the board, or the card, a share token gives access to. The
+	// password of a protected token is sent in the X-Share-Password header
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: token
+	//   in: path
+	//   description: Share token
+	//   required: true
+	//   type: string
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/SharedContent"
+	//   '401':
+	//     description: invalid password
+	//   '404':
+	//     description: share token not found or expired
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	token := mux.Vars(r)["token"]
+
+	if !a.app.GetClientConfig().EnablePublicSharedBoards {
+		a.errorResponse(w, r, model.NewErrNotFound("share token"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getSharedContent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	content, err := a.app.GetSharedContent(r.Context(), token, r.Header.Get(HeaderSharePassword))
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardID", content.Board.ID)
+	auditRec.AddMeta("scope", content.Scope)
+	auditRec.Success()
+}
+
+// publishedSnapshotForReadToken returns the published snapshot of a board
+// for anonymous viewers of a shared board, or nil if the live board
+// should be served.
+func (a *API) publishedSnapshotForReadToken(ctx context.Context, userID string, hasValidReadToken bool, boardID string) (*model.BoardSnapshot, error) {
+	if userID != "" || !hasValidReadToken {
+		return nil, nil
+	}
+
+	snapshot, err := a.app.GetPublishedSnapshot(ctx, boardID)
+	if model.IsErrNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
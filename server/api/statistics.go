@@ -45,12 +45,12 @@ func (a *API) handleStatistics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardCount, err := a.app.GetBoardCount()
+	boardCount, err := a.app.GetBoardCount(r.Context())
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
-	cardCount, err := a.app.GetUsedCardsCount()
+	cardCount, err := a.app.GetUsedCardsCount(r.Context())
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
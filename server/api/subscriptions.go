@@ -82,14 +82,14 @@ func (a *API) handleCreateSubscription(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// check for valid block
-	_, bErr := a.app.GetBlockByID(sub.BlockID)
+	_, bErr := a.app.GetBlockByID(r.Context(), sub.BlockID)
 	if bErr != nil {
 		message := fmt.Sprintf("invalid blockID: %s", bErr)
 		a.errorResponse(w, r, model.NewErrBadRequest(message))
 		return
 	}
 
-	subNew, err := a.app.CreateSubscription(&sub)
+	subNew, err := a.app.CreateSubscription(r.Context(), &sub)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -157,7 +157,7 @@ func (a *API) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err := a.app.DeleteSubscription(blockID, subscriberID); err != nil {
+	if _, err := a.app.DeleteSubscription(r.Context(), blockID, subscriberID); err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
@@ -214,7 +214,7 @@ func (a *API) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	subs, err := a.app.GetSubscriptions(subscriberID)
+	subs, err := a.app.GetSubscriptions(r.Context(), subscriberID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -42,7 +42,7 @@ func (a *API) handleGetTeams(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserID(r)
 
-	teams, err := a.app.GetTeamsForUser(userID)
+	teams, err := a.app.GetTeamsForUser(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 	}
@@ -100,7 +100,7 @@ func (a *API) handleGetTeam(w http.ResponseWriter, r *http.Request) {
 	var err error
 
 	if a.MattermostAuth {
-		team, err = a.app.GetTeam(teamID)
+		team, err = a.app.GetTeam(r.Context(), teamID)
 		if model.IsErrNotFound(err) {
 			a.errorResponse(w, r, model.NewErrUnauthorized("invalid team"))
 		}
@@ -108,7 +108,7 @@ func (a *API) handleGetTeam(w http.ResponseWriter, r *http.Request) {
 			a.errorResponse(w, r, err)
 		}
 	} else {
-		team, err = a.app.GetRootTeam()
+		team, err = a.app.GetRootTeam(r.Context())
 		if err != nil {
 			a.errorResponse(w, r, err)
 			return
@@ -157,7 +157,7 @@ func (a *API) handlePostTeamRegenerateSignupToken(w http.ResponseWriter, r *http
 		return
 	}
 
-	team, err := a.app.GetRootTeam()
+	team, err := a.app.GetRootTeam(r.Context())
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -168,7 +168,7 @@ func (a *API) handlePostTeamRegenerateSignupToken(w http.ResponseWriter, r *http
 
 	team.SignupToken = utils.NewID(utils.IDTypeToken)
 
-	if err = a.app.UpsertTeamSignupToken(*team); err != nil {
+	if err = a.app.UpsertTeamSignupToken(r.Context(), *team); err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
@@ -230,7 +230,7 @@ func (a *API) handleGetTeamUsers(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "getUsers", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -240,7 +240,7 @@ func (a *API) handleGetTeamUsers(w http.ResponseWriter, r *http.Request) {
 		asGuestUser = userID
 	}
 
-	users, err := a.app.SearchTeamUsers(teamID, searchQuery, asGuestUser, excludeBots)
+	users, err := a.app.SearchTeamUsers(r.Context(), teamID, searchQuery, asGuestUser, excludeBots)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
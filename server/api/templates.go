@@ -51,7 +51,7 @@ func (a *API) handleGetTemplates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isGuest, err := a.userIsGuest(userID)
+	isGuest, err := a.userIsGuest(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -66,7 +66,7 @@ func (a *API) handleGetTemplates(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", teamID)
 
 	// retrieve boards list
-	boards, err := a.app.GetTemplateBoards(teamID, userID)
+	boards, err := a.app.GetTemplateBoards(r.Context(), teamID, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
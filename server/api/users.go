@@ -71,7 +71,7 @@ func (a *API) handleGetUsersList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if userIDs[0] == model.SingleUser {
-		ws, _ := a.app.GetRootTeam()
+		ws, _ := a.app.GetRootTeam(r.Context())
 		now := utils.GetMillis()
 		user := &model.User{
 			ID:       model.SingleUser,
@@ -82,7 +82,7 @@ func (a *API) handleGetUsersList(w http.ResponseWriter, r *http.Request) {
 		}
 		users = append(users, user)
 	} else {
-		users, error = a.app.GetUsersList(userIDs)
+		users, error = a.app.GetUsersList(r.Context(), userIDs)
 		if error != nil {
 			a.errorResponse(w, r, error)
 			return
@@ -128,7 +128,7 @@ func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
 	if userID == model.SingleUser {
-		ws, _ := a.app.GetRootTeam()
+		ws, _ := a.app.GetRootTeam(r.Context())
 		now := utils.GetMillis()
 		user = &model.User{
 			ID:       model.SingleUser,
@@ -138,7 +138,7 @@ func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
 			UpdateAt: now,
 		}
 	} else {
-		user, err = a.app.GetUser(userID)
+		user, err = a.app.GetUser(r.Context(), userID)
 		if err != nil {
 			// ToDo: wrap with an invalid token error
 			a.errorResponse(w, r, err)
@@ -185,7 +185,7 @@ func (a *API) handleGetMyMemberships(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("userID", userID)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
-	members, err := a.app.GetMembersForUser(userID)
+	members, err := a.app.GetMembersForUser(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -235,7 +235,7 @@ func (a *API) handleGetUser(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("userID", userID)
 
-	user, err := a.app.GetUser(userID)
+	user, err := a.app.GetUser(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -244,7 +244,7 @@ func (a *API) handleGetUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	session := ctx.Value(sessionContextKey).(*model.Session)
 
-	canSeeUser, err := a.app.CanSeeUser(session.UserID, userID)
+	canSeeUser, err := a.app.CanSeeUser(r.Context(), session.UserID, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -322,7 +322,7 @@ func (a *API) handleUpdateUserConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedConfig, err := a.app.UpdateUserConfig(userID, *patch)
+	updatedConfig, err := a.app.UpdateUserConfig(r.Context(), userID, *patch)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -363,7 +363,7 @@ func (a *API) handleGetUserPreferences(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "getUserConfig", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
-	preferences, err := a.app.GetUserPreferences(userID)
+	preferences, err := a.app.GetUserPreferences(r.Context(), userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
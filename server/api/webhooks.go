@@ -0,0 +1,341 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerWebhooksRoutes(r *mux.Router) {
+	// Webhook APIs
+	r.HandleFunc("/boards/{boardID}/webhooks", a.sessionRequired(a.handleGetWebhooks)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/webhooks", a.sessionRequired(a.handleCreateWebhook)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handleUpdateWebhook)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handleDeleteWebhook)).Methods("DELETE")
+}
+
+func (a *API) handleGetWebhooks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/webhooks getWebhooks
+	//
+	// Returns the webhooks registered for a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Webhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board webhooks"))
+		return
+	}
+
+	webhooks, err := a.app.GetWebhooksForBoard(r.Context(), boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(webhooks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/webhooks createWebhook
+	//
+	// Registers a webhook for a board. Its secret is generated if none is
+	// provided, and is only returned in this response
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: webhook definition
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/WebhookWithSecret"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/WebhookWithSecret"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board webhooks"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var webhook model.WebhookWithSecret
+	if err = json.Unmarshal(requestBody, &webhook); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	webhook.ID = ""
+	webhook.BoardID = boardID
+	webhook.CreatedBy = userID
+	webhook.Webhook.Secret = webhook.Secret
+
+	if err = webhook.IsValid(); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err = a.app.CreateWebhook(r.Context(), &webhook.Webhook); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	webhook.Secret = webhook.Webhook.Secret
+
+	a.logger.Debug("CreateWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhook.ID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("webhookID", webhook.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/webhooks/{webhookID} updateWebhook
+	//
+	// Updates the URL and event types of a board webhook, and its secret if
+	// one is provided
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: webhook definition
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/WebhookWithSecret"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Webhook"
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board webhooks"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var webhook model.WebhookWithSecret
+	if err = json.Unmarshal(requestBody, &webhook); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	existing, err := a.app.GetWebhook(r.Context(), webhookID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if existing.BoardID != boardID {
+		a.errorResponse(w, r, model.NewErrNotFound("webhook ID="+webhookID))
+		return
+	}
+
+	webhook.ID = webhookID
+	webhook.BoardID = boardID
+	webhook.CreatedBy = existing.CreatedBy
+	webhook.CreateAt = existing.CreateAt
+	webhook.Webhook.Secret = webhook.Secret
+
+	if err = webhook.IsValid(); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	if err = a.app.UpdateWebhook(r.Context(), &webhook.Webhook, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+	)
+
+	data, err := json.Marshal(webhook.Webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/webhooks/{webhookID} deleteWebhook
+	//
+	// Deletes a board webhook. Its pending deliveries are dropped
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board webhooks"))
+		return
+	}
+
+	existing, err := a.app.GetWebhook(r.Context(), webhookID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if existing.BoardID != boardID {
+		a.errorResponse(w, r, model.NewErrNotFound("webhook ID="+webhookID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	if err = a.app.DeleteWebhook(r.Context(), webhookID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+	)
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// auditExportPageSize is the number of records read at once while
+// exporting the audit trail.
+const auditExportPageSize = 1000
+
+var auditExportHeader = []string{"id", "createAt", "boardId", "actorId", "action", "resourceId", "summary"}
+
+// GetAuditRecords returns the audit trail of a board, or of all the
+// boards if boardID is empty, oldest first.
+func (a *App) GetAuditRecords(ctx context.Context, boardID string, opts model.QueryAuditOptions) ([]*model.AuditRecord, error) {
+	return a.store.GetAuditRecords(ctx, boardID, opts)
+}
+
+// ExportAuditRecords writes the audit trail of a board, or of all the
+// boards if boardID is empty, as CSV. The pagination of the options is
+// ignored, and the records are read in pages instead.
+func (a *App) ExportAuditRecords(ctx context.Context, w io.Writer, boardID string, opts model.QueryAuditOptions) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(auditExportHeader); err != nil {
+		return err
+	}
+
+	opts.PerPage = auditExportPageSize
+	for opts.Page = 0; ; opts.Page++ {
+		records, err := a.store.GetAuditRecords(ctx, boardID, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			row := []string{
+				record.ID,
+				strconv.FormatInt(record.CreateAt, 10),
+				record.BoardID,
+				record.ActorID,
+				string(record.Action),
+				record.ResourceID,
+				record.Summary,
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+
+		if len(records) < auditExportPageSize {
+			break
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAuditRecords(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("should export the records as CSV", func(t *testing.T) {
+		records := []*model.AuditRecord{
+			{ID: "record-1", BoardID: "board-id", ActorID: "user-id", Action: model.AuditActionPatchBlock, ResourceID: "card-id", Summary: "changed: title, sortOrder", CreateAt: 100},
+			{ID: "record-2", BoardID: "board-id", ActorID: "user-id", Action: model.AuditActionDeleteBlock, ResourceID: "card-id", Summary: "type: card", CreateAt: 200},
+		}
+		opts := model.QueryAuditOptions{ActorID: "user-id", PerPage: auditExportPageSize}
+		th.Store.EXPECT().GetAuditRecords(gomock.Any(), "board-id", opts).Return(records, nil)
+
+		var buf bytes.Buffer
+		err := th.App.ExportAuditRecords(context.Background(), &buf, "board-id", model.QueryAuditOptions{ActorID: "user-id", PerPage: 10})
+		require.NoError(t, err)
+		require.Equal(t, "id,createAt,boardId,actorId,action,resourceId,summary\n"+
+			"record-1,100,board-id,user-id,patchBlock,card-id,\"changed: title, sortOrder\"\n"+
+			"record-2,200,board-id,user-id,deleteBlock,card-id,type: card\n", buf.String())
+	})
+
+	t.Run("should read the records in pages", func(t *testing.T) {
+		fullPage := make([]*model.AuditRecord, auditExportPageSize)
+		for i := range fullPage {
+			fullPage[i] = &model.AuditRecord{ID: "record-id"}
+		}
+		gomock.InOrder(
+			th.Store.EXPECT().GetAuditRecords(gomock.Any(), "", model.QueryAuditOptions{PerPage: auditExportPageSize}).Return(fullPage, nil),
+			th.Store.EXPECT().GetAuditRecords(gomock.Any(), "", model.QueryAuditOptions{Page: 1, PerPage: auditExportPageSize}).Return([]*model.AuditRecord{}, nil),
+		)
+
+		var buf bytes.Buffer
+		require.NoError(t, th.App.ExportAuditRecords(context.Background(), &buf, "", model.QueryAuditOptions{}))
+		require.Equal(t, auditExportPageSize+1, bytes.Count(buf.Bytes(), []byte("\n")))
+	})
+
+	t.Run("should fail if the records can't be read", func(t *testing.T) {
+		th.Store.EXPECT().GetAuditRecords(gomock.Any(), "board-id", gomock.Any()).Return(nil, errors.New("db error"))
+
+		var buf bytes.Buffer
+		require.Error(t, th.App.ExportAuditRecords(context.Background(), &buf, "board-id", model.QueryAuditOptions{}))
+	})
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/auth"
 	"github.com/mattermost/focalboard/server/utils"
@@ -19,57 +21,70 @@ const (
 )
 
 // GetSession Get a user active session and refresh the session if is needed.
-func (a *App) GetSession(token string) (*model.Session, error) {
-	return a.auth.GetSession(token)
+func (a *App) GetSession(ctx context.Context, token string) (*model.Session, error) {
+	return a.auth.GetSession(ctx, token)
 }
 
 // IsValidReadToken validates the read token for a block.
-func (a *App) IsValidReadToken(boardID string, readToken string) (bool, error) {
-	return a.auth.IsValidReadToken(boardID, readToken)
+func (a *App) IsValidReadToken(ctx context.Context, boardID string, readToken string) (bool, error) {
+	return a.auth.IsValidReadToken(ctx, boardID, readToken)
 }
 
 // GetRegisteredUserCount returns the number of registered users.
-func (a *App) GetRegisteredUserCount() (int, error) {
-	return a.store.GetRegisteredUserCount()
+func (a *App) GetRegisteredUserCount(ctx context.Context) (int, error) {
+	return a.store.GetRegisteredUserCount(ctx)
 }
 
 // GetDailyActiveUsers returns the number of daily active users.
-func (a *App) GetDailyActiveUsers() (int, error) {
+func (a *App) GetDailyActiveUsers(ctx context.Context) (int, error) {
 	secondsAgo := int64(SecondsPerMinute * MinutesPerHour * HoursPerDay)
-	return a.store.GetActiveUserCount(secondsAgo)
+	return a.store.GetActiveUserCount(ctx, secondsAgo)
 }
 
 // GetWeeklyActiveUsers returns the number of weekly active users.
-func (a *App) GetWeeklyActiveUsers() (int, error) {
+func (a *App) GetWeeklyActiveUsers(ctx context.Context) (int, error) {
 	secondsAgo := int64(SecondsPerMinute * MinutesPerHour * HoursPerDay * DaysPerWeek)
-	return a.store.GetActiveUserCount(secondsAgo)
+	return a.store.GetActiveUserCount(ctx, secondsAgo)
 }
 
 // GetMonthlyActiveUsers returns the number of monthly active users.
-func (a *App) GetMonthlyActiveUsers() (int, error) {
+func (a *App) GetMonthlyActiveUsers(ctx context.Context) (int, error) {
 	secondsAgo := int64(SecondsPerMinute * MinutesPerHour * HoursPerDay * DaysPerMonth)
-	return a.store.GetActiveUserCount(secondsAgo)
+	return a.store.GetActiveUserCount(ctx, secondsAgo)
+}
+
+// GetActiveUserCountByTeam returns the number of users of a team that
+// have been active since the given time.
+func (a *App) GetActiveUserCountByTeam(ctx context.Context, teamID string, since int64) (int, error) {
+	return a.store.GetActiveUserCountByTeam(ctx, teamID, since)
+}
+
+// GetActiveUserCountsByDay returns the number of users of a team that
+// were active on each day of the [from, to) range, keyed by the start
+// of the day.
+func (a *App) GetActiveUserCountsByDay(ctx context.Context, teamID string, from, to int64) (map[int64]int, error) {
+	return a.store.GetActiveUserCountsByDay(ctx, teamID, from, to)
 }
 
 // GetUser gets an existing active user by id.
-func (a *App) GetUser(id string) (*model.User, error) {
+func (a *App) GetUser(ctx context.Context, id string) (*model.User, error) {
 	if len(id) < 1 {
 		return nil, errors.New("no user ID")
 	}
 
-	user, err := a.store.GetUserByID(id)
+	user, err := a.store.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to find user")
 	}
 	return user, nil
 }
 
-func (a *App) GetUsersList(userIDs []string) ([]*model.User, error) {
+func (a *App) GetUsersList(ctx context.Context, userIDs []string) ([]*model.User, error) {
 	if len(userIDs) == 0 {
 		return nil, errors.New("No User IDs")
 	}
 
-	users, err := a.store.GetUsersList(userIDs)
+	users, err := a.store.GetUsersList(ctx, userIDs)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to find users")
 	}
@@ -77,11 +92,11 @@ func (a *App) GetUsersList(userIDs []string) ([]*model.User, error) {
 }
 
 // Login create a new user session if the authentication data is valid.
-func (a *App) Login(username, email, password, mfaToken string) (string, error) {
+func (a *App) Login(ctx context.Context, username, email, password, mfaToken string) (string, error) {
 	var user *model.User
 	if username != "" {
 		var err error
-		user, err = a.store.GetUserByUsername(username)
+		user, err = a.store.GetUserByUsername(ctx, username)
 		if err != nil && !model.IsErrNotFound(err) {
 			a.metrics.IncrementLoginFailCount(1)
 			return "", errors.Wrap(err, "invalid username or password")
@@ -90,7 +105,7 @@ func (a *App) Login(username, email, password, mfaToken string) (string, error)
 
 	if user == nil && email != "" {
 		var err error
-		user, err = a.store.GetUserByEmail(email)
+		user, err = a.store.GetUserByEmail(ctx, email)
 		if err != nil && model.IsErrNotFound(err) {
 			a.metrics.IncrementLoginFailCount(1)
 			return "", errors.Wrap(err, "invalid username or password")
@@ -120,7 +135,7 @@ func (a *App) Login(username, email, password, mfaToken string) (string, error)
 		AuthService: authService,
 		Props:       map[string]interface{}{},
 	}
-	err := a.store.CreateSession(&session)
+	err := a.store.CreateSession(ctx, &session)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to create session")
 	}
@@ -132,8 +147,8 @@ func (a *App) Login(username, email, password, mfaToken string) (string, error)
 }
 
 // Logout invalidates the user session.
-func (a *App) Logout(sessionID string) error {
-	err := a.store.DeleteSession(sessionID)
+func (a *App) Logout(ctx context.Context, sessionID string) error {
+	err := a.store.DeleteSession(ctx, sessionID)
 	if err != nil {
 		return errors.Wrap(err, "unable to delete the session")
 	}
@@ -144,27 +159,27 @@ func (a *App) Logout(sessionID string) error {
 }
 
 // RegisterUser creates a new user if the provided data is valid.
-func (a *App) RegisterUser(username, email, password string) error {
+func (a *App) RegisterUser(ctx context.Context, username, email, password string) error {
 	var user *model.User
 	if username != "" {
 		var err error
-		user, err = a.store.GetUserByUsername(username)
+		user, err = a.store.GetUserByUsername(ctx, username)
 		if err != nil && !model.IsErrNotFound(err) {
 			return err
 		}
 		if user != nil {
-			return errors.New("The username already exists")
+			return model.NewErrDuplicate("username")
 		}
 	}
 
 	if user == nil && email != "" {
 		var err error
-		user, err = a.store.GetUserByEmail(email)
+		user, err = a.store.GetUserByEmail(ctx, email)
 		if err != nil && !model.IsErrNotFound(err) {
 			return err
 		}
 		if user != nil {
-			return errors.New("The email already exists")
+			return model.NewErrDuplicate("email")
 		}
 	}
 
@@ -178,7 +193,7 @@ func (a *App) RegisterUser(username, email, password string) error {
 		return errors.Wrap(err, "Invalid password")
 	}
 
-	_, err = a.store.CreateUser(&model.User{
+	_, err = a.store.CreateUser(ctx, &model.User{
 		ID:          utils.NewID(utils.IDTypeUser),
 		Username:    username,
 		Email:       email,
@@ -194,8 +209,8 @@ func (a *App) RegisterUser(username, email, password string) error {
 	return nil
 }
 
-func (a *App) UpdateUserPassword(username, password string) error {
-	err := a.store.UpdateUserPassword(username, auth.HashPassword(password))
+func (a *App) UpdateUserPassword(ctx context.Context, username, password string) error {
+	err := a.store.UpdateUserPassword(ctx, username, auth.HashPassword(password))
 	if err != nil {
 		return err
 	}
@@ -203,11 +218,11 @@ func (a *App) UpdateUserPassword(username, password string) error {
 	return nil
 }
 
-func (a *App) ChangePassword(userID, oldPassword, newPassword string) error {
+func (a *App) ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error {
 	var user *model.User
 	if userID != "" {
 		var err error
-		user, err = a.store.GetUserByID(userID)
+		user, err = a.store.GetUserByID(ctx, userID)
 		if err != nil {
 			return errors.Wrap(err, "invalid username or password")
 		}
@@ -222,7 +237,7 @@ func (a *App) ChangePassword(userID, oldPassword, newPassword string) error {
 		return errors.New("invalid username or password")
 	}
 
-	err := a.store.UpdateUserPasswordByID(userID, auth.HashPassword(newPassword))
+	err := a.store.UpdateUserPasswordByID(ctx, userID, auth.HashPassword(newPassword))
 	if err != nil {
 		return errors.Wrap(err, "unable to update password")
 	}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -38,15 +39,15 @@ func TestLogin(t *testing.T) {
 		{"success, using email", "", "testEmail", "testPassword", "", false},
 	}
 
-	th.Store.EXPECT().GetUserByUsername("badUsername").Return(nil, errors.New("Bad Username"))
-	th.Store.EXPECT().GetUserByEmail("badEmail").Return(nil, errors.New("Bad Email"))
-	th.Store.EXPECT().GetUserByUsername("testUsername").Return(mockUser, nil).Times(2)
-	th.Store.EXPECT().GetUserByEmail("testEmail").Return(mockUser, nil)
-	th.Store.EXPECT().CreateSession(gomock.Any()).Return(nil).Times(2)
+	th.Store.EXPECT().GetUserByUsername(gomock.Any(), "badUsername").Return(nil, errors.New("Bad Username"))
+	th.Store.EXPECT().GetUserByEmail(gomock.Any(), "badEmail").Return(nil, errors.New("Bad Email"))
+	th.Store.EXPECT().GetUserByUsername(gomock.Any(), "testUsername").Return(mockUser, nil).Times(2)
+	th.Store.EXPECT().GetUserByEmail(gomock.Any(), "testEmail").Return(mockUser, nil)
+	th.Store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Return(nil).Times(2)
 
 	for _, test := range testcases {
 		t.Run(test.title, func(t *testing.T) {
-			token, err := th.App.Login(test.userName, test.email, test.password, test.mfa)
+			token, err := th.App.Login(context.Background(), test.userName, test.email, test.password, test.mfa)
 			if test.isError {
 				require.Error(t, err)
 			} else {
@@ -71,12 +72,12 @@ func TestGetUser(t *testing.T) {
 		{"success", "goodID", false},
 	}
 
-	th.Store.EXPECT().GetUserByID("badID").Return(nil, errors.New("Bad Id"))
-	th.Store.EXPECT().GetUserByID("goodID").Return(mockUser, nil)
+	th.Store.EXPECT().GetUserByID(gomock.Any(), "badID").Return(nil, errors.New("Bad Id"))
+	th.Store.EXPECT().GetUserByID(gomock.Any(), "goodID").Return(mockUser, nil)
 
 	for _, test := range testcases {
 		t.Run(test.title, func(t *testing.T) {
-			token, err := th.App.GetUser(test.id)
+			token, err := th.App.GetUser(context.Background(), test.id)
 			if test.isError {
 				require.Error(t, err)
 			} else {
@@ -105,15 +106,15 @@ func TestRegisterUser(t *testing.T) {
 		{"success, using email", "", "newEmail", "testPassword", false},
 	}
 
-	th.Store.EXPECT().GetUserByUsername("existingUsername").Return(mockUser, nil)
-	th.Store.EXPECT().GetUserByUsername("newUsername").Return(mockUser, errors.New("user not found"))
-	th.Store.EXPECT().GetUserByEmail("existingEmail").Return(mockUser, nil)
-	th.Store.EXPECT().GetUserByEmail("newEmail").Return(nil, model.NewErrNotFound("user"))
-	th.Store.EXPECT().CreateUser(gomock.Any()).Return(nil, nil)
+	th.Store.EXPECT().GetUserByUsername(gomock.Any(), "existingUsername").Return(mockUser, nil)
+	th.Store.EXPECT().GetUserByUsername(gomock.Any(), "newUsername").Return(mockUser, errors.New("user not found"))
+	th.Store.EXPECT().GetUserByEmail(gomock.Any(), "existingEmail").Return(mockUser, nil)
+	th.Store.EXPECT().GetUserByEmail(gomock.Any(), "newEmail").Return(nil, model.NewErrNotFound("user"))
+	th.Store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil, nil)
 
 	for _, test := range testcases {
 		t.Run(test.title, func(t *testing.T) {
-			err := th.App.RegisterUser(test.userName, test.email, test.password)
+			err := th.App.RegisterUser(context.Background(), test.userName, test.email, test.password)
 			if test.isError {
 				require.Error(t, err)
 			} else {
@@ -138,13 +139,13 @@ func TestUpdateUserPassword(t *testing.T) {
 		{"success, username", "testUsername", "testPassword", false},
 	}
 
-	th.Store.EXPECT().UpdateUserPassword("", gomock.Any()).Return(errors.New("user not found"))
-	th.Store.EXPECT().UpdateUserPassword("badUsername", gomock.Any()).Return(errors.New("user not found"))
-	th.Store.EXPECT().UpdateUserPassword("testUsername", gomock.Any()).Return(nil)
+	th.Store.EXPECT().UpdateUserPassword(gomock.Any(), "", gomock.Any()).Return(errors.New("user not found"))
+	th.Store.EXPECT().UpdateUserPassword(gomock.Any(), "badUsername", gomock.Any()).Return(errors.New("user not found"))
+	th.Store.EXPECT().UpdateUserPassword(gomock.Any(), "testUsername", gomock.Any()).Return(nil)
 
 	for _, test := range testcases {
 		t.Run(test.title, func(t *testing.T) {
-			err := th.App.UpdateUserPassword(test.userName, test.password)
+			err := th.App.UpdateUserPassword(context.Background(), test.userName, test.password)
 			if test.isError {
 				require.Error(t, err)
 			} else {
@@ -171,13 +172,13 @@ func TestChangePassword(t *testing.T) {
 		{"success, using username", mockUser.ID, "testPassword", "newPassword", false},
 	}
 
-	th.Store.EXPECT().GetUserByID("badID").Return(nil, errors.New("userID not found"))
-	th.Store.EXPECT().GetUserByID(mockUser.ID).Return(mockUser, nil).Times(2)
-	th.Store.EXPECT().UpdateUserPasswordByID(mockUser.ID, gomock.Any()).Return(nil)
+	th.Store.EXPECT().GetUserByID(gomock.Any(), "badID").Return(nil, errors.New("userID not found"))
+	th.Store.EXPECT().GetUserByID(gomock.Any(), mockUser.ID).Return(mockUser, nil).Times(2)
+	th.Store.EXPECT().UpdateUserPasswordByID(gomock.Any(), mockUser.ID, gomock.Any()).Return(nil)
 
 	for _, test := range testcases {
 		t.Run(test.title, func(t *testing.T) {
-			err := th.App.ChangePassword(test.userName, test.oldPassword, test.password)
+			err := th.App.ChangePassword(context.Background(), test.userName, test.oldPassword, test.password)
 			if test.isError {
 				require.Error(t, err)
 			} else {
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -14,24 +15,25 @@ import (
 
 var ErrBlocksFromMultipleBoards = errors.New("the block set contain blocks from multiple boards")
 
-func (a *App) GetBlocks(boardID, parentID string, blockType string) ([]*model.Block, error) {
+func (a *App) GetBlocks(ctx context.Context, boardID, parentID string, blockType string) ([]*model.Block, error) {
 	if boardID == "" {
 		return []*model.Block{}, nil
 	}
 
 	if blockType != "" && parentID != "" {
-		return a.store.GetBlocksWithParentAndType(boardID, parentID, blockType)
+		return a.store.GetBlocksWithParentAndType(ctx, boardID, parentID, blockType)
 	}
 
 	if blockType != "" {
-		return a.store.GetBlocksWithType(boardID, blockType)
+		blocks, _, err := a.store.GetBlocksWithType(ctx, boardID, blockType, model.QueryBlocksOptions{})
+		return blocks, err
 	}
 
-	return a.store.GetBlocksWithParent(boardID, parentID)
+	return a.store.GetBlocksWithParent(ctx, boardID, parentID)
 }
 
-func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
-	board, err := a.GetBoard(boardID)
+func (a *App) DuplicateBlock(ctx context.Context, boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
+	board, err := a.GetBoard(ctx, boardID)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +41,7 @@ func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTe
 		return nil, fmt.Errorf("cannot fetch board %s for DuplicateBlock: %w", boardID, err)
 	}
 
-	blocks, err := a.store.DuplicateBlock(boardID, blockID, userID, asTemplate)
+	blocks, err := a.store.DuplicateBlock(ctx, boardID, blockID, userID, asTemplate)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +54,7 @@ func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTe
 	})
 
 	go func() {
-		if uErr := a.UpdateCardLimitTimestamp(); uErr != nil {
+		if uErr := a.UpdateCardLimitTimestamp(context.Background()); uErr != nil {
 			a.logger.Error(
 				"UpdateCardLimitTimestamp failed duplicating a block",
 				mlog.Err(uErr),
@@ -63,18 +65,18 @@ func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTe
 	return blocks, err
 }
 
-func (a *App) PatchBlock(blockID string, blockPatch *model.BlockPatch, modifiedByID string) (*model.Block, error) {
-	return a.PatchBlockAndNotify(blockID, blockPatch, modifiedByID, false)
+func (a *App) PatchBlock(ctx context.Context, blockID string, blockPatch *model.BlockPatch, modifiedByID string) (*model.Block, error) {
+	return a.PatchBlockAndNotify(ctx, blockID, blockPatch, modifiedByID, false)
 }
 
-func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch, modifiedByID string, disableNotify bool) (*model.Block, error) {
-	oldBlock, err := a.store.GetBlock(blockID)
+func (a *App) PatchBlockAndNotify(ctx context.Context, blockID string, blockPatch *model.BlockPatch, modifiedByID string, disableNotify bool) (*model.Block, error) {
+	oldBlock, err := a.store.GetBlock(ctx, blockID)
 	if err != nil {
 		return nil, err
 	}
 
-	if a.IsCloudLimited() {
-		containsLimitedBlocks, lErr := a.ContainsLimitedBlocks([]*model.Block{oldBlock})
+	if a.IsCloudLimited(ctx) {
+		containsLimitedBlocks, lErr := a.ContainsLimitedBlocks(ctx, []*model.Block{oldBlock})
 		if lErr != nil {
 			return nil, lErr
 		}
@@ -83,24 +85,27 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 		}
 	}
 
-	board, err := a.store.GetBoard(oldBlock.BoardID)
+	board, err := a.store.GetBoard(ctx, oldBlock.BoardID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = a.store.PatchBlock(blockID, blockPatch, modifiedByID)
+	count, err := a.store.PatchBlock(ctx, blockID, blockPatch, modifiedByID)
 	if err != nil {
 		return nil, err
 	}
+	if count == 0 {
+		return nil, model.NewErrNotFound("block ID=" + blockID)
+	}
 
 	a.metrics.IncrementBlocksPatched(1)
-	block, err := a.store.GetBlock(blockID)
+	block, err := a.store.GetBlock(ctx, blockID)
 	if err != nil {
 		return nil, err
 	}
 	a.blockChangeNotifier.Enqueue(func() error {
 		// broadcast on websocket
-		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		a.wsAdapter.BroadcastBlockPatch(board.TeamID, oldBlock, block)
 
 		// broadcast on webhooks
 		a.webhook.NotifyUpdate(block)
@@ -114,18 +119,18 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 	return block, nil
 }
 
-func (a *App) PatchBlocks(teamID string, blockPatches *model.BlockPatchBatch, modifiedByID string) error {
-	return a.PatchBlocksAndNotify(teamID, blockPatches, modifiedByID, false)
+func (a *App) PatchBlocks(ctx context.Context, teamID string, blockPatches *model.BlockPatchBatch, modifiedByID string) error {
+	return a.PatchBlocksAndNotify(ctx, teamID, blockPatches, modifiedByID, false)
 }
 
-func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatchBatch, modifiedByID string, disableNotify bool) error {
-	oldBlocks, err := a.store.GetBlocksByIDs(blockPatches.BlockIDs)
+func (a *App) PatchBlocksAndNotify(ctx context.Context, teamID string, blockPatches *model.BlockPatchBatch, modifiedByID string, disableNotify bool) error {
+	oldBlocks, err := a.store.GetBlocksByIDs(ctx, blockPatches.BlockIDs)
 	if err != nil {
 		return err
 	}
 
-	if a.IsCloudLimited() {
-		containsLimitedBlocks, err := a.ContainsLimitedBlocks(oldBlocks)
+	if a.IsCloudLimited(ctx) {
+		containsLimitedBlocks, err := a.ContainsLimitedBlocks(ctx, oldBlocks)
 		if err != nil {
 			return err
 		}
@@ -134,18 +139,27 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 		}
 	}
 
-	if err := a.store.PatchBlocks(blockPatches, modifiedByID); err != nil {
+	if err := a.store.PatchBlocks(ctx, blockPatches, modifiedByID); err != nil {
 		return err
 	}
 
+	oldBlocksMap := map[string]*model.Block{}
+	for _, block := range oldBlocks {
+		oldBlocksMap[block.ID] = block
+	}
+
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.metrics.IncrementBlocksPatched(len(oldBlocks))
 		for i, blockID := range blockPatches.BlockIDs {
-			newBlock, err := a.store.GetBlock(blockID)
+			newBlock, err := a.store.GetBlock(context.Background(), blockID)
 			if err != nil {
 				return err
 			}
-			a.wsAdapter.BroadcastBlockChange(teamID, newBlock)
+			if oldBlock, ok := oldBlocksMap[blockID]; ok {
+				a.wsAdapter.BroadcastBlockPatch(teamID, oldBlock, newBlock)
+			} else {
+				a.wsAdapter.BroadcastBlockChange(teamID, newBlock)
+			}
 			a.webhook.NotifyUpdate(newBlock)
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Update, newBlock, oldBlocks[i], modifiedByID)
@@ -156,17 +170,17 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 	return nil
 }
 
-func (a *App) InsertBlock(block *model.Block, modifiedByID string) error {
-	return a.InsertBlockAndNotify(block, modifiedByID, false)
+func (a *App) InsertBlock(ctx context.Context, block *model.Block, modifiedByID string) error {
+	return a.InsertBlockAndNotify(ctx, block, modifiedByID, false)
 }
 
-func (a *App) InsertBlockAndNotify(block *model.Block, modifiedByID string, disableNotify bool) error {
-	board, bErr := a.store.GetBoard(block.BoardID)
+func (a *App) InsertBlockAndNotify(ctx context.Context, block *model.Block, modifiedByID string, disableNotify bool) error {
+	board, bErr := a.store.GetBoard(ctx, block.BoardID)
 	if bErr != nil {
 		return bErr
 	}
 
-	err := a.store.InsertBlock(block, modifiedByID)
+	err := a.store.InsertBlock(ctx, block, modifiedByID)
 	if err == nil {
 		a.blockChangeNotifier.Enqueue(func() error {
 			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
@@ -180,7 +194,7 @@ func (a *App) InsertBlockAndNotify(block *model.Block, modifiedByID string, disa
 	}
 
 	go func() {
-		if uErr := a.UpdateCardLimitTimestamp(); uErr != nil {
+		if uErr := a.UpdateCardLimitTimestamp(context.Background()); uErr != nil {
 			a.logger.Error(
 				"UpdateCardLimitTimestamp failed after inserting a block",
 				mlog.Err(uErr),
@@ -191,8 +205,8 @@ func (a *App) InsertBlockAndNotify(block *model.Block, modifiedByID string, disa
 	return err
 }
 
-func (a *App) isWithinViewsLimit(boardID string, block *model.Block) (bool, error) {
-	limits, err := a.GetBoardsCloudLimits()
+func (a *App) isWithinViewsLimit(ctx context.Context, boardID string, block *model.Block) (bool, error) {
+	limits, err := a.GetBoardsCloudLimits(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -201,7 +215,7 @@ func (a *App) isWithinViewsLimit(boardID string, block *model.Block) (bool, erro
 		return true, nil
 	}
 
-	views, err := a.store.GetBlocksWithParentAndType(boardID, block.ParentID, model.TypeView)
+	views, err := a.store.GetBlocksWithParentAndType(ctx, boardID, block.ParentID, model.TypeView)
 	if err != nil {
 		return false, err
 	}
@@ -213,11 +227,11 @@ func (a *App) isWithinViewsLimit(boardID string, block *model.Block) (bool, erro
 	return len(views) < limits.Views, nil
 }
 
-func (a *App) InsertBlocks(blocks []*model.Block, modifiedByID string) ([]*model.Block, error) {
-	return a.InsertBlocksAndNotify(blocks, modifiedByID, false)
+func (a *App) InsertBlocks(ctx context.Context, blocks []*model.Block, modifiedByID string) ([]*model.Block, error) {
+	return a.InsertBlocksAndNotify(ctx, blocks, modifiedByID, false)
 }
 
-func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string, disableNotify bool) ([]*model.Block, error) {
+func (a *App) InsertBlocksAndNotify(ctx context.Context, blocks []*model.Block, modifiedByID string, disableNotify bool) ([]*model.Block, error) {
 	if len(blocks) == 0 {
 		return []*model.Block{}, nil
 	}
@@ -230,7 +244,7 @@ func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string,
 		}
 	}
 
-	board, err := a.store.GetBoard(boardID)
+	board, err := a.store.GetBoard(ctx, boardID)
 	if err != nil {
 		return nil, err
 	}
@@ -240,7 +254,7 @@ func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string,
 		// this check is needed to whitelist inbuilt template
 		// initialization. They do contain more than 5 views per board.
 		if boardID != "0" && blocks[i].Type == model.TypeView {
-			withinLimit, err := a.isWithinViewsLimit(board.ID, blocks[i])
+			withinLimit, err := a.isWithinViewsLimit(ctx, board.ID, blocks[i])
 			if err != nil {
 				return nil, err
 			}
@@ -251,7 +265,7 @@ func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string,
 			}
 		}
 
-		err := a.store.InsertBlock(blocks[i], modifiedByID)
+		err := a.store.InsertBlock(ctx, blocks[i], modifiedByID)
 		if err != nil {
 			return nil, err
 		}
@@ -273,7 +287,7 @@ func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string,
 	})
 
 	go func() {
-		if err := a.UpdateCardLimitTimestamp(); err != nil {
+		if err := a.UpdateCardLimitTimestamp(context.Background()); err != nil {
 			a.logger.Error(
 				"UpdateCardLimitTimestamp failed after inserting blocks",
 				mlog.Err(err),
@@ -284,7 +298,7 @@ func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string,
 	return blocks, nil
 }
 
-func (a *App) CopyCardFiles(sourceBoardID string, copiedBlocks []*model.Block) error {
+func (a *App) CopyCardFiles(ctx context.Context, sourceBoardID string, copiedBlocks []*model.Block) error {
 	// Images attached in cards have a path comprising the card's board ID.
 	// When we create a template from this board, we need to copy the files
 	// with the new board ID in path.
@@ -292,7 +306,7 @@ func (a *App) CopyCardFiles(sourceBoardID string, copiedBlocks []*model.Block) e
 	// template) to fail to load.
 
 	// look up ID of source sourceBoard, which may be different than the blocks.
-	sourceBoard, err := a.GetBoard(sourceBoardID)
+	sourceBoard, err := a.GetBoard(ctx, sourceBoardID)
 	if err != nil || sourceBoard == nil {
 		return fmt.Errorf("cannot fetch source board %s for CopyCardFiles: %w", sourceBoardID, err)
 	}
@@ -314,7 +328,7 @@ func (a *App) CopyCardFiles(sourceBoardID string, copiedBlocks []*model.Block) e
 
 		if destBoardID == "" || block.BoardID != destBoardID {
 			destBoardID = block.BoardID
-			destBoard, err := a.GetBoard(destBoardID)
+			destBoard, err := a.GetBoard(ctx, destBoardID)
 			if err != nil {
 				return fmt.Errorf("cannot fetch destination board %s for CopyCardFiles: %w", sourceBoardID, err)
 			}
@@ -344,21 +358,21 @@ func (a *App) CopyCardFiles(sourceBoardID string, copiedBlocks []*model.Block) e
 	return nil
 }
 
-func (a *App) GetBlockByID(blockID string) (*model.Block, error) {
-	return a.store.GetBlock(blockID)
+func (a *App) GetBlockByID(ctx context.Context, blockID string) (*model.Block, error) {
+	return a.store.GetBlock(ctx, blockID)
 }
 
-func (a *App) DeleteBlock(blockID string, modifiedBy string) error {
-	return a.DeleteBlockAndNotify(blockID, modifiedBy, false)
+func (a *App) DeleteBlock(ctx context.Context, blockID string, modifiedBy string) error {
+	return a.DeleteBlockAndNotify(ctx, blockID, modifiedBy, false)
 }
 
-func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNotify bool) error {
-	block, err := a.store.GetBlock(blockID)
+func (a *App) DeleteBlockAndNotify(ctx context.Context, blockID string, modifiedBy string, disableNotify bool) error {
+	block, err := a.store.GetBlock(ctx, blockID)
 	if err != nil {
 		return err
 	}
 
-	board, err := a.store.GetBoard(block.BoardID)
+	board, err := a.store.GetBoard(ctx, block.BoardID)
 	if err != nil {
 		return err
 	}
@@ -368,10 +382,13 @@ func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNot
 		return nil
 	}
 
-	err = a.store.DeleteBlock(blockID, modifiedBy)
+	count, err := a.store.DeleteBlock(ctx, blockID, modifiedBy)
 	if err != nil {
 		return err
 	}
+	if count == 0 {
+		return model.NewErrNotFound("block ID=" + blockID)
+	}
 
 	if block.Type == model.TypeImage {
 		fileName, fileIDExists := block.Fields["fileId"]
@@ -397,7 +414,7 @@ func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNot
 	})
 
 	go func() {
-		if err := a.UpdateCardLimitTimestamp(); err != nil {
+		if err := a.UpdateCardLimitTimestamp(context.Background()); err != nil {
 			a.logger.Error(
 				"UpdateCardLimitTimestamp failed after deleting a block",
 				mlog.Err(err),
@@ -408,8 +425,8 @@ func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNot
 	return nil
 }
 
-func (a *App) GetLastBlockHistoryEntry(blockID string) (*model.Block, error) {
-	blocks, err := a.store.GetBlockHistory(blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+func (a *App) GetLastBlockHistoryEntry(ctx context.Context, blockID string) (*model.Block, error) {
+	blocks, err := a.store.GetBlockHistory(ctx, blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
 	if err != nil {
 		return nil, err
 	}
@@ -419,8 +436,8 @@ func (a *App) GetLastBlockHistoryEntry(blockID string) (*model.Block, error) {
 	return blocks[0], nil
 }
 
-func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, error) {
-	blocks, err := a.store.GetBlockHistory(blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+func (a *App) UndeleteBlock(ctx context.Context, blockID string, modifiedBy string) (*model.Block, error) {
+	blocks, err := a.store.GetBlockHistory(ctx, blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
 	if err != nil {
 		return nil, err
 	}
@@ -430,12 +447,12 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		return nil, nil
 	}
 
-	err = a.store.UndeleteBlock(blockID, modifiedBy)
+	err = a.store.UndeleteBlock(ctx, blockID, modifiedBy)
 	if err != nil {
 		return nil, err
 	}
 
-	block, err := a.store.GetBlock(blockID)
+	block, err := a.store.GetBlock(ctx, blockID)
 	if model.IsErrNotFound(err) {
 		a.logger.Error("Error loading the block after a successful undelete, not propagating through websockets or notifications", mlog.String("blockID", blockID))
 		return nil, err
@@ -444,7 +461,7 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		return nil, err
 	}
 
-	board, err := a.store.GetBoard(block.BoardID)
+	board, err := a.store.GetBoard(ctx, block.BoardID)
 	if err != nil {
 		return nil, err
 	}
@@ -459,7 +476,7 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 	})
 
 	go func() {
-		if err := a.UpdateCardLimitTimestamp(); err != nil {
+		if err := a.UpdateCardLimitTimestamp(context.Background()); err != nil {
 			a.logger.Error(
 				"UpdateCardLimitTimestamp failed after undeleting a block",
 				mlog.Err(err),
@@ -470,28 +487,140 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 	return block, nil
 }
 
-func (a *App) GetBlockCountsByType() (map[string]int64, error) {
-	return a.store.GetBlockCountsByType()
+// GetDeletedBlocksForBoard returns the deleted blocks of a board that
+// are still in the trash and can be restored.
+func (a *App) GetDeletedBlocksForBoard(ctx context.Context, boardID string) ([]*model.Block, error) {
+	return a.store.GetDeletedBlocksForBoard(ctx, boardID)
+}
+
+// RestoreBlock brings a block back from the trash as it was before being
+// deleted.
+func (a *App) RestoreBlock(ctx context.Context, blockID string, modifiedBy string) (*model.Block, error) {
+	block, err := a.store.RestoreBlock(ctx, blockID, modifiedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(ctx, block.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		a.metrics.IncrementBlocksInserted(1)
+		a.webhook.NotifyUpdate(block)
+		a.notifyBlockChanged(notify.Add, block, nil, modifiedBy)
+
+		return nil
+	})
+
+	go func() {
+		if err := a.UpdateCardLimitTimestamp(context.Background()); err != nil {
+			a.logger.Error(
+				"UpdateCardLimitTimestamp failed after restoring a block",
+				mlog.Err(err),
+			)
+		}
+	}()
+
+	return block, nil
+}
+
+// GetBlockHistory returns the versions of a block, most recent first.
+func (a *App) GetBlockHistory(ctx context.Context, blockID string) ([]*model.Block, error) {
+	return a.store.GetBlockHistory(ctx, blockID, model.QueryBlockHistoryOptions{Descending: true})
 }
 
-func (a *App) GetBlocksForBoard(boardID string) ([]*model.Block, error) {
-	return a.store.GetBlocksForBoard(boardID)
+// GetBlockDiff returns the changes between two versions of a block,
+// identified by the update time of their history entries.
+func (a *App) GetBlockDiff(ctx context.Context, blockID string, fromVersion, toVersion int64) (*model.BlockDiff, error) {
+	from, err := a.store.GetBlockHistoryEntry(ctx, blockID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := a.store.GetBlockHistoryEntry(ctx, blockID, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.DiffBlocks(from, to), nil
+}
+
+// RevertBlock brings a block back to a previous version by patching it,
+// so the revert is recorded as a new version in its history.
+func (a *App) RevertBlock(ctx context.Context, blockID string, version int64, modifiedBy string) (*model.Block, error) {
+	current, err := a.store.GetBlock(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := a.store.GetBlockHistoryEntry(ctx, blockID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous.DeleteAt != 0 {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("version %d of block %s is a deletion", version, blockID))
+	}
+
+	return a.PatchBlock(ctx, blockID, model.NewRevertPatch(current, previous), modifiedBy)
+}
+
+func (a *App) GetBlockCountsByType(ctx context.Context) (map[string]int64, error) {
+	return a.store.GetBlockCountsByType(ctx)
+}
+
+func (a *App) GetBlocksForBoard(ctx context.Context, boardID string) ([]*model.Block, error) {
+	blocks, _, err := a.store.GetBlocksForBoard(ctx, boardID, model.QueryBlocksOptions{})
+	return blocks, err
+}
+
+// GetBlocksForBoardPage returns up to perPage blocks of a board after the
+// block with the afterID cursor, and whether there are more.
+func (a *App) GetBlocksForBoardPage(ctx context.Context, boardID, afterID string, perPage int) ([]*model.Block, bool, error) {
+	opts := model.QueryBlocksOptions{
+		AfterID: afterID,
+		PerPage: perPage,
+	}
+	return a.store.GetBlocksForBoard(ctx, boardID, opts)
+}
+
+// SearchBlocksForUser returns the blocks matching the term in the team
+// boards that the user can access.
+func (a *App) SearchBlocksForUser(ctx context.Context, teamID, term, userID string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	return a.store.SearchBlocksForUser(ctx, teamID, term, userID, opts)
+}
+
+// GetRecentComments returns the latest comments of a board, newest first,
+// along with their authors.
+func (a *App) GetRecentComments(ctx context.Context, boardID string, limit int) ([]*model.CommentWithAuthor, error) {
+	return a.store.GetRecentComments(ctx, boardID, limit)
+}
+
+var blockWebhookEvents = map[notify.Action]string{
+	notify.Add:    model.WebhookEventBlockCreated,
+	notify.Update: model.WebhookEventBlockUpdated,
+	notify.Delete: model.WebhookEventBlockDeleted,
 }
 
 func (a *App) notifyBlockChanged(action notify.Action, block *model.Block, oldBlock *model.Block, modifiedByID string) {
+	a.enqueueWebhookEvent(block.BoardID, blockWebhookEvents[action], modifiedByID, block)
+
 	// don't notify if notifications service disabled, or block change is generated via system user.
 	if a.notifications == nil || modifiedByID == model.SystemUserID {
 		return
 	}
 
 	// find card and board for the changed block.
-	board, card, err := a.getBoardAndCard(block)
+	board, card, err := a.getBoardAndCard(context.Background(), block)
 	if err != nil {
 		a.logger.Error("Error notifying for block change; cannot determine board or card", mlog.Err(err))
 		return
 	}
 
-	boardMember, _ := a.GetMemberForBoard(board.ID, modifiedByID)
+	boardMember, _ := a.GetMemberForBoard(context.Background(), board.ID, modifiedByID)
 	if boardMember == nil {
 		// create temporary guest board member
 		boardMember = &model.BoardMember{
@@ -518,8 +647,8 @@ const (
 
 // getBoardAndCard returns the first parent of type `card` its board for the specified block.
 // `board` and/or `card` may return nil without error if the block does not belong to a board or card.
-func (a *App) getBoardAndCard(block *model.Block) (board *model.Board, card *model.Block, err error) {
-	board, err = a.store.GetBoard(block.BoardID)
+func (a *App) getBoardAndCard(ctx context.Context, block *model.Block) (board *model.Board, card *model.Block, err error) {
+	board, err = a.store.GetBoard(ctx, block.BoardID)
 	if err != nil {
 		return board, card, err
 	}
@@ -536,7 +665,7 @@ func (a *App) getBoardAndCard(block *model.Block) (board *model.Board, card *mod
 			break
 		}
 
-		iter, err = a.store.GetBlock(iter.ParentID)
+		iter, err = a.store.GetBlock(ctx, iter.ParentID)
 		if model.IsErrNotFound(err) {
 			return board, card, nil
 		}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
@@ -30,10 +31,10 @@ func TestInsertBlock(t *testing.T) {
 		boardID := testBoardID
 		block := &model.Block{BoardID: boardID}
 		board := &model.Board{ID: boardID}
-		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
-		th.Store.EXPECT().InsertBlock(block, "user-id-1").Return(nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
-		err := th.App.InsertBlock(block, "user-id-1")
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), block, "user-id-1").Return(nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil)
+		err := th.App.InsertBlock(context.Background(), block, "user-id-1")
 		require.NoError(t, err)
 	})
 
@@ -41,9 +42,9 @@ func TestInsertBlock(t *testing.T) {
 		boardID := testBoardID
 		block := &model.Block{BoardID: boardID}
 		board := &model.Board{ID: boardID}
-		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
-		th.Store.EXPECT().InsertBlock(block, "user-id-1").Return(blockError{"error"})
-		err := th.App.InsertBlock(block, "user-id-1")
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), block, "user-id-1").Return(blockError{"error"})
+		err := th.App.InsertBlock(context.Background(), block, "user-id-1")
 		require.Error(t, err, "error")
 	})
 }
@@ -61,19 +62,19 @@ func TestPatchBlocks(t *testing.T) {
 		}
 
 		block1 := &model.Block{ID: "block1"}
-		th.Store.EXPECT().GetBlocksByIDs([]string{"block1"}).Return([]*model.Block{block1}, nil)
-		th.Store.EXPECT().PatchBlocks(gomock.Eq(&blockPatches), gomock.Eq("user-id-1")).Return(nil)
-		th.Store.EXPECT().GetBlock("block1").Return(block1, nil)
+		th.Store.EXPECT().GetBlocksByIDs(gomock.Any(), []string{"block1"}).Return([]*model.Block{block1}, nil)
+		th.Store.EXPECT().PatchBlocks(gomock.Any(), gomock.Eq(&blockPatches), gomock.Eq("user-id-1")).Return(nil)
+		th.Store.EXPECT().GetBlock(gomock.Any(), "block1").Return(block1, nil)
 		// this call comes from the WS server notification
-		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Times(1)
-		err := th.App.PatchBlocks("team-id", &blockPatches, "user-id-1")
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), gomock.Any()).Times(1)
+		err := th.App.PatchBlocks(context.Background(), "team-id", &blockPatches, "user-id-1")
 		require.NoError(t, err)
 	})
 
 	t.Run("patchBlocks error scenario", func(t *testing.T) {
 		blockPatches := model.BlockPatchBatch{BlockIDs: []string{}}
-		th.Store.EXPECT().GetBlocksByIDs([]string{}).Return(nil, sql.ErrNoRows)
-		err := th.App.PatchBlocks("team-id", &blockPatches, "user-id-1")
+		th.Store.EXPECT().GetBlocksByIDs(gomock.Any(), []string{}).Return(nil, sql.ErrNoRows)
+		err := th.App.PatchBlocks(context.Background(), "team-id", &blockPatches, "user-id-1")
 		require.ErrorIs(t, err, sql.ErrNoRows)
 	})
 
@@ -104,11 +105,11 @@ func TestPatchBlocks(t *testing.T) {
 			Type: model.BoardTypeOpen,
 		}
 
-		th.Store.EXPECT().GetBlocksByIDs([]string{"block1"}).Return([]*model.Block{block1}, nil)
-		th.Store.EXPECT().GetBoard("board-id").Return(board1, nil)
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(150), nil)
-		err := th.App.PatchBlocks("team-id", &blockPatches, "user-id-1")
+		th.Store.EXPECT().GetBlocksByIDs(gomock.Any(), []string{"block1"}).Return([]*model.Block{block1}, nil)
+		th.Store.EXPECT().GetBoards(gomock.Any(), []string{"board-id"}).Return([]*model.Board{board1}, nil)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(150), nil)
+		err := th.App.PatchBlocks(context.Background(), "team-id", &blockPatches, "user-id-1")
 		require.ErrorIs(t, err, model.ErrPatchUpdatesLimitedCards)
 	})
 }
@@ -124,11 +125,11 @@ func TestDeleteBlock(t *testing.T) {
 			ID:      "block-id",
 			BoardID: board.ID,
 		}
-		th.Store.EXPECT().GetBlock(gomock.Eq("block-id")).Return(block, nil)
-		th.Store.EXPECT().DeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(nil)
-		th.Store.EXPECT().GetBoard(gomock.Eq(testBoardID)).Return(board, nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
-		err := th.App.DeleteBlock("block-id", "user-id-1")
+		th.Store.EXPECT().GetBlock(gomock.Any(), gomock.Eq("block-id")).Return(block, nil)
+		th.Store.EXPECT().DeleteBlock(gomock.Any(), gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(int64(1), nil)
+		th.Store.EXPECT().GetBoard(gomock.Any(), gomock.Eq(testBoardID)).Return(board, nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil)
+		err := th.App.DeleteBlock(context.Background(), "block-id", "user-id-1")
 		require.NoError(t, err)
 	})
 
@@ -139,12 +140,26 @@ func TestDeleteBlock(t *testing.T) {
 			ID:      "block-id",
 			BoardID: board.ID,
 		}
-		th.Store.EXPECT().GetBlock(gomock.Eq("block-id")).Return(block, nil)
-		th.Store.EXPECT().DeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(blockError{"error"})
-		th.Store.EXPECT().GetBoard(gomock.Eq(testBoardID)).Return(board, nil)
-		err := th.App.DeleteBlock("block-id", "user-id-1")
+		th.Store.EXPECT().GetBlock(gomock.Any(), gomock.Eq("block-id")).Return(block, nil)
+		th.Store.EXPECT().DeleteBlock(gomock.Any(), gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(int64(0), blockError{"error"})
+		th.Store.EXPECT().GetBoard(gomock.Any(), gomock.Eq(testBoardID)).Return(board, nil)
+		err := th.App.DeleteBlock(context.Background(), "block-id", "user-id-1")
 		require.Error(t, err, "error")
 	})
+
+	t.Run("nothing deleted scenario", func(t *testing.T) {
+		boardID := testBoardID
+		board := &model.Board{ID: boardID}
+		block := &model.Block{
+			ID:      "block-id",
+			BoardID: board.ID,
+		}
+		th.Store.EXPECT().GetBlock(gomock.Any(), gomock.Eq("block-id")).Return(block, nil)
+		th.Store.EXPECT().DeleteBlock(gomock.Any(), gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(int64(0), nil)
+		th.Store.EXPECT().GetBoard(gomock.Any(), gomock.Eq(testBoardID)).Return(board, nil)
+		err := th.App.DeleteBlock(context.Background(), "block-id", "user-id-1")
+		require.True(t, model.IsErrNotFound(err))
+	})
 }
 
 func TestUndeleteBlock(t *testing.T) {
@@ -159,14 +174,15 @@ func TestUndeleteBlock(t *testing.T) {
 			BoardID: board.ID,
 		}
 		th.Store.EXPECT().GetBlockHistory(
+			gomock.Any(),
 			gomock.Eq("block-id"),
 			gomock.Eq(model.QueryBlockHistoryOptions{Limit: 1, Descending: true}),
 		).Return([]*model.Block{block}, nil)
-		th.Store.EXPECT().UndeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(nil)
-		th.Store.EXPECT().GetBlock(gomock.Eq("block-id")).Return(block, nil)
-		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
-		_, err := th.App.UndeleteBlock("block-id", "user-id-1")
+		th.Store.EXPECT().UndeleteBlock(gomock.Any(), gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(nil)
+		th.Store.EXPECT().GetBlock(gomock.Any(), gomock.Eq("block-id")).Return(block, nil)
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(board, nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil)
+		_, err := th.App.UndeleteBlock(context.Background(), "block-id", "user-id-1")
 		require.NoError(t, err)
 	})
 
@@ -175,11 +191,12 @@ func TestUndeleteBlock(t *testing.T) {
 			ID: "block-id",
 		}
 		th.Store.EXPECT().GetBlockHistory(
+			gomock.Any(),
 			gomock.Eq("block-id"),
 			gomock.Eq(model.QueryBlockHistoryOptions{Limit: 1, Descending: true}),
 		).Return([]*model.Block{block}, nil)
-		th.Store.EXPECT().UndeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(blockError{"error"})
-		_, err := th.App.UndeleteBlock("block-id", "user-id-1")
+		th.Store.EXPECT().UndeleteBlock(gomock.Any(), gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(blockError{"error"})
+		_, err := th.App.UndeleteBlock(context.Background(), "block-id", "user-id-1")
 		require.Error(t, err, "error")
 	})
 }
@@ -193,73 +210,73 @@ func TestIsWithinViewsLimit(t *testing.T) {
 	}
 
 	t.Run("within views limit", func(t *testing.T) {
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
 
 		cloudLimit := &mmModel.ProductLimits{
 			Boards: &mmModel.BoardsLimits{
 				Views: mmModel.NewInt(2),
 			},
 		}
-		th.Store.EXPECT().GetCloudLimits().Return(cloudLimit, nil)
-		th.Store.EXPECT().GetUsedCardsCount().Return(1, nil)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(1), nil)
-		th.Store.EXPECT().GetBlocksWithParentAndType("board_id", "parent_id", "view").Return([]*model.Block{{}}, nil)
+		th.Store.EXPECT().GetCloudLimits(gomock.Any()).Return(cloudLimit, nil)
+		th.Store.EXPECT().GetUsedCardsCount(gomock.Any()).Return(1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(1), nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Any(), "board_id", "parent_id", "view").Return([]*model.Block{{}}, nil)
 
-		withinLimits, err := th.App.isWithinViewsLimit("board_id", &model.Block{ParentID: "parent_id"})
+		withinLimits, err := th.App.isWithinViewsLimit(context.Background(), "board_id", &model.Block{ParentID: "parent_id"})
 		assert.NoError(t, err)
 		assert.True(t, withinLimits)
 	})
 
 	t.Run("view limit exactly reached", func(t *testing.T) {
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
 
 		cloudLimit := &mmModel.ProductLimits{
 			Boards: &mmModel.BoardsLimits{
 				Views: mmModel.NewInt(1),
 			},
 		}
-		th.Store.EXPECT().GetCloudLimits().Return(cloudLimit, nil)
-		th.Store.EXPECT().GetUsedCardsCount().Return(1, nil)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(1), nil)
-		th.Store.EXPECT().GetBlocksWithParentAndType("board_id", "parent_id", "view").Return([]*model.Block{{}}, nil)
+		th.Store.EXPECT().GetCloudLimits(gomock.Any()).Return(cloudLimit, nil)
+		th.Store.EXPECT().GetUsedCardsCount(gomock.Any()).Return(1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(1), nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Any(), "board_id", "parent_id", "view").Return([]*model.Block{{}}, nil)
 
-		withinLimits, err := th.App.isWithinViewsLimit("board_id", &model.Block{ParentID: "parent_id"})
+		withinLimits, err := th.App.isWithinViewsLimit(context.Background(), "board_id", &model.Block{ParentID: "parent_id"})
 		assert.NoError(t, err)
 		assert.False(t, withinLimits)
 	})
 
 	t.Run("view limit already exceeded", func(t *testing.T) {
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
 
 		cloudLimit := &mmModel.ProductLimits{
 			Boards: &mmModel.BoardsLimits{
 				Views: mmModel.NewInt(2),
 			},
 		}
-		th.Store.EXPECT().GetCloudLimits().Return(cloudLimit, nil)
-		th.Store.EXPECT().GetUsedCardsCount().Return(1, nil)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(1), nil)
-		th.Store.EXPECT().GetBlocksWithParentAndType("board_id", "parent_id", "view").Return([]*model.Block{{}, {}, {}}, nil)
+		th.Store.EXPECT().GetCloudLimits(gomock.Any()).Return(cloudLimit, nil)
+		th.Store.EXPECT().GetUsedCardsCount(gomock.Any()).Return(1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(1), nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Any(), "board_id", "parent_id", "view").Return([]*model.Block{{}, {}, {}}, nil)
 
-		withinLimits, err := th.App.isWithinViewsLimit("board_id", &model.Block{ParentID: "parent_id"})
+		withinLimits, err := th.App.isWithinViewsLimit(context.Background(), "board_id", &model.Block{ParentID: "parent_id"})
 		assert.NoError(t, err)
 		assert.False(t, withinLimits)
 	})
 
 	t.Run("creating first view", func(t *testing.T) {
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
 
 		cloudLimit := &mmModel.ProductLimits{
 			Boards: &mmModel.BoardsLimits{
 				Views: mmModel.NewInt(2),
 			},
 		}
-		th.Store.EXPECT().GetCloudLimits().Return(cloudLimit, nil)
-		th.Store.EXPECT().GetUsedCardsCount().Return(1, nil)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(1), nil)
-		th.Store.EXPECT().GetBlocksWithParentAndType("board_id", "parent_id", "view").Return([]*model.Block{}, nil)
+		th.Store.EXPECT().GetCloudLimits(gomock.Any()).Return(cloudLimit, nil)
+		th.Store.EXPECT().GetUsedCardsCount(gomock.Any()).Return(1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(1), nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Any(), "board_id", "parent_id", "view").Return([]*model.Block{}, nil)
 
-		withinLimits, err := th.App.isWithinViewsLimit("board_id", &model.Block{ParentID: "parent_id"})
+		withinLimits, err := th.App.isWithinViewsLimit(context.Background(), "board_id", &model.Block{ParentID: "parent_id"})
 		assert.NoError(t, err)
 		assert.True(t, withinLimits)
 	})
@@ -268,9 +285,9 @@ func TestIsWithinViewsLimit(t *testing.T) {
 		nonCloudLicense := &mmModel.License{
 			Features: &mmModel.Features{Cloud: mmModel.NewBool(false)},
 		}
-		th.Store.EXPECT().GetLicense().Return(nonCloudLicense)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(nonCloudLicense)
 
-		withinLimits, err := th.App.isWithinViewsLimit("board_id", &model.Block{ParentID: "parent_id"})
+		withinLimits, err := th.App.isWithinViewsLimit(context.Background(), "board_id", &model.Block{ParentID: "parent_id"})
 		assert.NoError(t, err)
 		assert.True(t, withinLimits)
 	})
@@ -284,10 +301,10 @@ func TestInsertBlocks(t *testing.T) {
 		boardID := testBoardID
 		block := &model.Block{BoardID: boardID}
 		board := &model.Board{ID: boardID}
-		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
-		th.Store.EXPECT().InsertBlock(block, "user-id-1").Return(nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
-		_, err := th.App.InsertBlocks([]*model.Block{block}, "user-id-1")
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), block, "user-id-1").Return(nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil)
+		_, err := th.App.InsertBlocks(context.Background(), []*model.Block{block}, "user-id-1")
 		require.NoError(t, err)
 	})
 
@@ -295,9 +312,9 @@ func TestInsertBlocks(t *testing.T) {
 		boardID := testBoardID
 		block := &model.Block{BoardID: boardID}
 		board := &model.Board{ID: boardID}
-		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
-		th.Store.EXPECT().InsertBlock(block, "user-id-1").Return(blockError{"error"})
-		_, err := th.App.InsertBlocks([]*model.Block{block}, "user-id-1")
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), block, "user-id-1").Return(blockError{"error"})
+		_, err := th.App.InsertBlocks(context.Background(), []*model.Block{block}, "user-id-1")
 		require.Error(t, err, "error")
 	})
 
@@ -309,27 +326,27 @@ func TestInsertBlocks(t *testing.T) {
 			BoardID:  boardID,
 		}
 		board := &model.Board{ID: boardID}
-		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
-		th.Store.EXPECT().InsertBlock(block, "user-id-1").Return(nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), block, "user-id-1").Return(nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil)
 
 		// setting up mocks for limits
 		fakeLicense := &mmModel.License{
 			Features: &mmModel.Features{Cloud: mmModel.NewBool(true)},
 		}
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
 
 		cloudLimit := &mmModel.ProductLimits{
 			Boards: &mmModel.BoardsLimits{
 				Views: mmModel.NewInt(2),
 			},
 		}
-		th.Store.EXPECT().GetCloudLimits().Return(cloudLimit, nil)
-		th.Store.EXPECT().GetUsedCardsCount().Return(1, nil)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(1), nil)
-		th.Store.EXPECT().GetBlocksWithParentAndType("test-board-id", "parent_id", "view").Return([]*model.Block{{}}, nil)
+		th.Store.EXPECT().GetCloudLimits(gomock.Any()).Return(cloudLimit, nil)
+		th.Store.EXPECT().GetUsedCardsCount(gomock.Any()).Return(1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(1), nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Any(), "test-board-id", "parent_id", "view").Return([]*model.Block{{}}, nil)
 
-		_, err := th.App.InsertBlocks([]*model.Block{block}, "user-id-1")
+		_, err := th.App.InsertBlocks(context.Background(), []*model.Block{block}, "user-id-1")
 		require.NoError(t, err)
 	})
 
@@ -341,25 +358,25 @@ func TestInsertBlocks(t *testing.T) {
 			BoardID:  boardID,
 		}
 		board := &model.Board{ID: boardID}
-		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(board, nil)
 
 		// setting up mocks for limits
 		fakeLicense := &mmModel.License{
 			Features: &mmModel.Features{Cloud: mmModel.NewBool(true)},
 		}
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
 
 		cloudLimit := &mmModel.ProductLimits{
 			Boards: &mmModel.BoardsLimits{
 				Views: mmModel.NewInt(2),
 			},
 		}
-		th.Store.EXPECT().GetCloudLimits().Return(cloudLimit, nil)
-		th.Store.EXPECT().GetUsedCardsCount().Return(1, nil)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(1), nil)
-		th.Store.EXPECT().GetBlocksWithParentAndType("test-board-id", "parent_id", "view").Return([]*model.Block{{}, {}}, nil)
+		th.Store.EXPECT().GetCloudLimits(gomock.Any()).Return(cloudLimit, nil)
+		th.Store.EXPECT().GetUsedCardsCount(gomock.Any()).Return(1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(1), nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Any(), "test-board-id", "parent_id", "view").Return([]*model.Block{{}, {}}, nil)
 
-		_, err := th.App.InsertBlocks([]*model.Block{block}, "user-id-1")
+		_, err := th.App.InsertBlocks(context.Background(), []*model.Block{block}, "user-id-1")
 		require.Error(t, err)
 	})
 
@@ -380,27 +397,63 @@ func TestInsertBlocks(t *testing.T) {
 		}
 
 		board := &model.Board{ID: boardID}
-		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
-		th.Store.EXPECT().InsertBlock(view1, "user-id-1").Return(nil).Times(2)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(2)
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), view1, "user-id-1").Return(nil).Times(2)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil).Times(2)
 
 		// setting up mocks for limits
 		fakeLicense := &mmModel.License{
 			Features: &mmModel.Features{Cloud: mmModel.NewBool(true)},
 		}
-		th.Store.EXPECT().GetLicense().Return(fakeLicense).Times(2)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense).Times(2)
 
 		cloudLimit := &mmModel.ProductLimits{
 			Boards: &mmModel.BoardsLimits{
 				Views: mmModel.NewInt(2),
 			},
 		}
-		th.Store.EXPECT().GetCloudLimits().Return(cloudLimit, nil).Times(2)
-		th.Store.EXPECT().GetUsedCardsCount().Return(1, nil).Times(2)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(1), nil).Times(2)
-		th.Store.EXPECT().GetBlocksWithParentAndType("test-board-id", "parent_id", "view").Return([]*model.Block{{}}, nil).Times(2)
+		th.Store.EXPECT().GetCloudLimits(gomock.Any()).Return(cloudLimit, nil).Times(2)
+		th.Store.EXPECT().GetUsedCardsCount(gomock.Any()).Return(1, nil).Times(2)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(1), nil).Times(2)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Any(), "test-board-id", "parent_id", "view").Return([]*model.Block{{}}, nil).Times(2)
+
+		_, err := th.App.InsertBlocks(context.Background(), []*model.Block{view1, view2}, "user-id-1")
+		require.Error(t, err)
+	})
+}
+
+func TestGetRecentComments(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("comments with authors", func(t *testing.T) {
+		comments := []*model.CommentWithAuthor{
+			{Block: model.Block{ID: "comment1", BoardID: testBoardID, Type: model.TypeComment, CreatedBy: "user-1"}, Author: &model.User{ID: "user-1", Username: "user1"}},
+			{Block: model.Block{ID: "comment2", BoardID: testBoardID, Type: model.TypeComment, CreatedBy: "user-2"}},
+		}
+
+		th.Store.EXPECT().GetRecentComments(gomock.Any(), testBoardID, 10).Return(comments, nil)
+
+		result, err := th.App.GetRecentComments(context.Background(), testBoardID, 10)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, "comment1", result[0].ID)
+		require.Equal(t, "user1", result[0].Author.Username)
+		require.Nil(t, result[1].Author)
+	})
+
+	t.Run("no comments", func(t *testing.T) {
+		th.Store.EXPECT().GetRecentComments(gomock.Any(), testBoardID, 10).Return([]*model.CommentWithAuthor{}, nil)
+
+		result, err := th.App.GetRecentComments(context.Background(), testBoardID, 10)
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("error scenario", func(t *testing.T) {
+		th.Store.EXPECT().GetRecentComments(gomock.Any(), testBoardID, 10).Return(nil, blockError{"error"})
 
-		_, err := th.App.InsertBlocks([]*model.Block{view1, view2}, "user-id-1")
+		_, err := th.App.GetRecentComments(context.Background(), testBoardID, 10)
 		require.Error(t, err)
 	})
 }
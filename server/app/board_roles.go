@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func (a *App) CreateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole) error {
+	return a.store.CreateBoardCustomRole(ctx, role)
+}
+
+func (a *App) GetBoardCustomRole(ctx context.Context, roleID string) (*model.BoardCustomRole, error) {
+	return a.store.GetBoardCustomRole(ctx, roleID)
+}
+
+func (a *App) GetBoardCustomRoles(ctx context.Context, boardID string) ([]*model.BoardCustomRole, error) {
+	return a.store.GetBoardCustomRoles(ctx, boardID)
+}
+
+func (a *App) UpdateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole, userID string) error {
+	return a.store.UpdateBoardCustomRole(ctx, role, userID)
+}
+
+func (a *App) DeleteBoardCustomRole(ctx context.Context, roleID, userID string) error {
+	return a.store.DeleteBoardCustomRole(ctx, roleID, userID)
+}
+
+// SetMemberCustomRole assigns a custom role of the board to one of its
+// members, or unassigns it if the role ID is empty, and notifies the
+// clients of the member change.
+func (a *App) SetMemberCustomRole(ctx context.Context, boardID, userID, roleID, modifiedBy string) (*model.BoardMember, error) {
+	board, err := a.store.GetBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.store.SetMemberCustomRole(ctx, boardID, userID, roleID, modifiedBy); err != nil {
+		return nil, err
+	}
+
+	member, err := a.store.GetMemberForBoard(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, member)
+		a.enqueueWebhookEvent(boardID, model.WebhookEventMemberUpdated, "", member)
+		return nil
+	})
+
+	return member, nil
+}
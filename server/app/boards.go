@@ -4,6 +4,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -23,28 +24,34 @@ const unlinkBoardMessage = "@%s unlinked the board [%s](%s) with this channel"
 
 var errNoDefaultCategoryFound = errors.New("no default category found for user")
 
-func (a *App) GetBoard(boardID string) (*model.Board, error) {
-	board, err := a.store.GetBoard(boardID)
+func (a *App) GetBoard(ctx context.Context, boardID string) (*model.Board, error) {
+	board, err := a.store.GetBoard(ctx, boardID)
 	if err != nil {
 		return nil, err
 	}
 	return board, nil
 }
 
-func (a *App) GetBoardCount() (int64, error) {
-	return a.store.GetBoardCount()
+// GetBoards returns the boards with the given IDs in the same order as
+// the IDs, skipping the ones that don't exist.
+func (a *App) GetBoards(ctx context.Context, boardIDs []string) ([]*model.Board, error) {
+	return a.store.GetBoards(ctx, boardIDs)
 }
 
-func (a *App) GetBoardMetadata(boardID string) (*model.Board, *model.BoardMetadata, error) {
-	license := a.store.GetLicense()
+func (a *App) GetBoardCount(ctx context.Context) (int64, error) {
+	return a.store.GetBoardCount(ctx)
+}
+
+func (a *App) GetBoardMetadata(ctx context.Context, boardID string) (*model.Board, *model.BoardMetadata, error) {
+	license := a.store.GetLicense(ctx)
 	if license == nil || !(*license.Features.Compliance) {
 		return nil, nil, model.ErrInsufficientLicense
 	}
 
-	board, err := a.GetBoard(boardID)
+	board, err := a.GetBoard(ctx, boardID)
 	if model.IsErrNotFound(err) {
 		// Board may have been deleted, retrieve most recent history instead
-		board, err = a.getBoardHistory(boardID, true)
+		board, err = a.getBoardHistory(ctx, boardID, true)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -53,12 +60,12 @@ func (a *App) GetBoardMetadata(boardID string) (*model.Board, *model.BoardMetada
 		return nil, nil, err
 	}
 
-	earliestTime, _, err := a.getBoardDescendantModifiedInfo(boardID, false)
+	earliestTime, _, err := a.getBoardDescendantModifiedInfo(ctx, boardID, false)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	latestTime, lastModifiedBy, err := a.getBoardDescendantModifiedInfo(boardID, true)
+	latestTime, lastModifiedBy, err := a.getBoardDescendantModifiedInfo(ctx, boardID, true)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -74,13 +81,13 @@ func (a *App) GetBoardMetadata(boardID string) (*model.Board, *model.BoardMetada
 }
 
 // getBoardForBlock returns the board that owns the specified block.
-func (a *App) getBoardForBlock(blockID string) (*model.Board, error) {
-	block, err := a.GetBlockByID(blockID)
+func (a *App) getBoardForBlock(ctx context.Context, blockID string) (*model.Board, error) {
+	block, err := a.GetBlockByID(ctx, blockID)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get block %s: %w", blockID, err)
 	}
 
-	board, err := a.GetBoard(block.BoardID)
+	board, err := a.GetBoard(ctx, block.BoardID)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get board %s: %w", block.BoardID, err)
 	}
@@ -88,12 +95,12 @@ func (a *App) getBoardForBlock(blockID string) (*model.Board, error) {
 	return board, nil
 }
 
-func (a *App) getBoardHistory(boardID string, latest bool) (*model.Board, error) {
+func (a *App) getBoardHistory(ctx context.Context, boardID string, latest bool) (*model.Board, error) {
 	opts := model.QueryBoardHistoryOptions{
 		Limit:      1,
 		Descending: latest,
 	}
-	boards, err := a.store.GetBoardHistory(boardID, opts)
+	boards, err := a.store.GetBoardHistory(ctx, boardID, opts)
 	if err != nil {
 		return nil, fmt.Errorf("could not get history for board: %w", err)
 	}
@@ -104,8 +111,8 @@ func (a *App) getBoardHistory(boardID string, latest bool) (*model.Board, error)
 	return boards[0], nil
 }
 
-func (a *App) getBoardDescendantModifiedInfo(boardID string, latest bool) (int64, string, error) {
-	board, err := a.getBoardHistory(boardID, latest)
+func (a *App) getBoardDescendantModifiedInfo(ctx context.Context, boardID string, latest bool) (int64, string, error) {
+	board, err := a.getBoardHistory(ctx, boardID, latest)
 	if err != nil {
 		return 0, "", err
 	}
@@ -126,7 +133,7 @@ func (a *App) getBoardDescendantModifiedInfo(boardID string, latest bool) (int64
 		Limit:      1,
 		Descending: latest,
 	}
-	blocks, err := a.store.GetBlockHistoryDescendants(boardID, opts)
+	blocks, err := a.store.GetBlockHistoryDescendants(ctx, boardID, opts)
 	if err != nil {
 		return 0, "", fmt.Errorf("could not get blocks history descendants for board: %w", err)
 	}
@@ -144,9 +151,50 @@ func (a *App) getBoardDescendantModifiedInfo(boardID string, latest bool) (int64
 	return timestamp, modifiedBy, nil
 }
 
-func (a *App) setBoardCategoryFromSource(sourceBoardID, destinationBoardID, userID, teamID string, asTemplate bool) error {
+// GetBoardLastModifiedBy returns the ID of the user that last edited a
+// board, either its metadata or its content.
+func (a *App) GetBoardLastModifiedBy(ctx context.Context, boardID string) (string, error) {
+	board, err := a.store.GetBoard(ctx, boardID)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := a.store.GetLastModifiedBlockForBoard(ctx, boardID)
+	if model.IsErrNotFound(err) {
+		return board.ModifiedBy, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if block.UpdateAt > board.UpdateAt && block.ModifiedBy != "" {
+		return block.ModifiedBy, nil
+	}
+	return board.ModifiedBy, nil
+}
+
+// SetBoardPropertyOrder sets the order of the card properties of a board,
+// which views use as their default column order.
+func (a *App) SetBoardPropertyOrder(ctx context.Context, boardID string, propertyIDs []string, userID string) error {
+	if err := a.store.SetBoardPropertyOrder(ctx, boardID, propertyIDs, userID); err != nil {
+		return err
+	}
+
+	board, err := a.store.GetBoard(ctx, boardID)
+	if err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(board.TeamID, board)
+		return nil
+	})
+	return nil
+}
+
+func (a *App) setBoardCategoryFromSource(ctx context.Context, sourceBoardID, destinationBoardID, userID, teamID string, asTemplate bool) error {
 	// find source board's category ID for the user
-	userCategoryBoards, err := a.GetUserCategoryBoards(userID, teamID)
+	userCategoryBoards, err := a.GetUserCategoryBoards(ctx, userID, teamID)
 	if err != nil {
 		return err
 	}
@@ -167,7 +215,7 @@ func (a *App) setBoardCategoryFromSource(sourceBoardID, destinationBoardID, user
 		// if source board is not mapped to a category for this user,
 		// then move new board to default category
 		if !asTemplate {
-			return a.addBoardsToDefaultCategory(userID, teamID, []*model.Board{{ID: destinationBoardID}})
+			return a.addBoardsToDefaultCategory(ctx, userID, teamID, []*model.Board{{ID: destinationBoardID}})
 		} else {
 			return nil
 		}
@@ -175,22 +223,22 @@ func (a *App) setBoardCategoryFromSource(sourceBoardID, destinationBoardID, user
 
 	// now that we have source board's category,
 	// we send destination board to the same category
-	return a.AddUpdateUserCategoryBoard(teamID, userID, destinationCategoryID, destinationBoardID)
+	return a.AddUpdateUserCategoryBoard(ctx, teamID, userID, destinationCategoryID, destinationBoardID)
 }
 
-func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
-	bab, members, err := a.store.DuplicateBoard(boardID, userID, toTeam, asTemplate)
+func (a *App) DuplicateBoard(ctx context.Context, boardID, userID, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	bab, members, err := a.store.DuplicateBoard(ctx, boardID, userID, toTeam, asTemplate)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// copy any file attachments from the duplicated blocks.
-	if err = a.CopyCardFiles(boardID, bab.Blocks); err != nil {
+	if err = a.CopyCardFiles(ctx, boardID, bab.Blocks); err != nil {
 		a.logger.Error("Could not copy files while duplicating board", mlog.String("BoardID", boardID), mlog.Err(err))
 	}
 
 	for _, board := range bab.Boards {
-		if categoryErr := a.setBoardCategoryFromSource(boardID, board.ID, userID, toTeam, asTemplate); categoryErr != nil {
+		if categoryErr := a.setBoardCategoryFromSource(ctx, boardID, board.ID, userID, toTeam, asTemplate); categoryErr != nil {
 			return nil, nil, categoryErr
 		}
 	}
@@ -216,9 +264,11 @@ func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*
 			BlockIDs:     blockIDs,
 			BlockPatches: blockPatches,
 		}
-		if err = a.store.PatchBlocks(patches, userID); err != nil {
+		if err = a.store.PatchBlocks(ctx, patches, userID); err != nil {
+			// the context may be the reason of the failure, so the
+			// cleanup doesn't use it
 			dbab := model.NewDeleteBoardsAndBlocksFromBabs(bab)
-			if err = a.store.DeleteBoardsAndBlocks(dbab, userID); err != nil {
+			if err = a.store.DeleteBoardsAndBlocks(ctx, dbab, userID); err != nil {
 				a.logger.Error("Cannot delete board after duplication error when updating block's file info", mlog.String("boardID", bab.Boards[0].ID), mlog.Err(err))
 			}
 			return nil, nil, fmt.Errorf("could not patch file IDs while duplicating board %s: %w", boardID, err)
@@ -244,7 +294,7 @@ func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*
 
 	if len(bab.Blocks) != 0 {
 		go func() {
-			if uErr := a.UpdateCardLimitTimestamp(); uErr != nil {
+			if uErr := a.UpdateCardLimitTimestamp(context.Background()); uErr != nil {
 				a.logger.Error(
 					"UpdateCardLimitTimestamp failed after duplicating a board",
 					mlog.Err(uErr),
@@ -256,15 +306,75 @@ func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*
 	return bab, members, err
 }
 
-func (a *App) GetBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*model.Board, error) {
-	return a.store.GetBoardsForUserAndTeam(userID, teamID, includePublicBoards)
+// GetBoardsModifiedSince returns the boards that the user can access
+// and that were updated or deleted after the since timestamp. Guests
+// only get the boards they are explicit members of.
+func (a *App) GetBoardsModifiedSince(ctx context.Context, teamID, userID string, since int64) ([]*model.Board, error) {
+	boards, err := a.store.GetBoardsModifiedSince(ctx, teamID, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	isGuest, err := a.UserIsGuest(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isGuest {
+		return boards, nil
+	}
+
+	members, err := a.store.GetMembersForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberBoardIDs := map[string]bool{}
+	for _, member := range members {
+		memberBoardIDs[member.BoardID] = true
+	}
+
+	guestBoards := []*model.Board{}
+	for _, board := range boards {
+		if memberBoardIDs[board.ID] {
+			guestBoards = append(guestBoards, board)
+		}
+	}
+	return guestBoards, nil
+}
+
+func (a *App) GetBoardsForUserAndTeam(ctx context.Context, userID, teamID string, includePublicBoards bool) ([]*model.Board, error) {
+	return a.store.GetBoardsForUserAndTeam(ctx, userID, teamID, includePublicBoards)
+}
+
+// GetBoardsForUserAndTeamPage returns up to perPage boards of the team
+// after the board with the afterID cursor, and whether there are more.
+func (a *App) GetBoardsForUserAndTeamPage(ctx context.Context, userID, teamID string, includePublicBoards bool, afterID string, perPage int) ([]*model.Board, bool, error) {
+	opts := model.QueryBoardsOptions{
+		IncludePublicBoards: includePublicBoards,
+		AfterID:             afterID,
+	}
+
+	// one more board is fetched to know if there is a next page
+	if perPage > 0 {
+		opts.PerPage = perPage + 1
+	}
+
+	boards, err := a.store.GetBoardsForUserAndTeamWithOptions(ctx, userID, teamID, opts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if perPage > 0 && len(boards) > perPage {
+		return boards[:perPage], true, nil
+	}
+	return boards, false, nil
 }
 
-func (a *App) GetTemplateBoards(teamID, userID string) ([]*model.Board, error) {
-	return a.store.GetTemplateBoards(teamID, userID)
+func (a *App) GetTemplateBoards(ctx context.Context, teamID, userID string) ([]*model.Board, error) {
+	return a.store.GetTemplateBoards(ctx, teamID, userID)
 }
 
-func (a *App) CreateBoard(board *model.Board, userID string, addMember bool) (*model.Board, error) {
+func (a *App) CreateBoard(ctx context.Context, board *model.Board, userID string, addMember bool) (*model.Board, error) {
 	if board.ID != "" {
 		return nil, ErrNewBoardCannotHaveID
 	}
@@ -274,9 +384,9 @@ func (a *App) CreateBoard(board *model.Board, userID string, addMember bool) (*m
 	var member *model.BoardMember
 	var err error
 	if addMember {
-		newBoard, member, err = a.store.InsertBoardWithAdmin(board, userID)
+		newBoard, member, err = a.store.InsertBoardWithAdmin(ctx, board, userID)
 	} else {
-		newBoard, err = a.store.InsertBoard(board, userID)
+		newBoard, err = a.store.InsertBoard(ctx, board, userID)
 	}
 
 	if err != nil {
@@ -287,7 +397,7 @@ func (a *App) CreateBoard(board *model.Board, userID string, addMember bool) (*m
 		a.wsAdapter.BroadcastBoardChange(newBoard.TeamID, newBoard)
 
 		if newBoard.ChannelID != "" {
-			members, err := a.GetMembersForBoard(board.ID)
+			members, err := a.GetMembersForBoard(context.Background(), board.ID)
 			if err != nil {
 				a.logger.Error("Unable to get the board members", mlog.Err(err))
 			}
@@ -301,7 +411,7 @@ func (a *App) CreateBoard(board *model.Board, userID string, addMember bool) (*m
 	})
 
 	if board.TeamID != "0" {
-		if err := a.addBoardsToDefaultCategory(userID, newBoard.TeamID, []*model.Board{newBoard}); err != nil {
+		if err := a.addBoardsToDefaultCategory(ctx, userID, newBoard.TeamID, []*model.Board{newBoard}); err != nil {
 			return nil, err
 		}
 	}
@@ -309,8 +419,8 @@ func (a *App) CreateBoard(board *model.Board, userID string, addMember bool) (*m
 	return newBoard, nil
 }
 
-func (a *App) addBoardsToDefaultCategory(userID, teamID string, boards []*model.Board) error {
-	userCategoryBoards, err := a.GetUserCategoryBoards(userID, teamID)
+func (a *App) addBoardsToDefaultCategory(ctx context.Context, userID, teamID string, boards []*model.Board) error {
+	userCategoryBoards, err := a.GetUserCategoryBoards(ctx, userID, teamID)
 	if err != nil {
 		return err
 	}
@@ -328,7 +438,7 @@ func (a *App) addBoardsToDefaultCategory(userID, teamID string, boards []*model.
 	}
 
 	for _, board := range boards {
-		if err := a.AddUpdateUserCategoryBoard(teamID, userID, defaultCategoryID, board.ID); err != nil {
+		if err := a.AddUpdateUserCategoryBoard(ctx, teamID, userID, defaultCategoryID, board.ID); err != nil {
 			return err
 		}
 	}
@@ -336,7 +446,7 @@ func (a *App) addBoardsToDefaultCategory(userID, teamID string, boards []*model.
 	return nil
 }
 
-func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*model.Board, error) {
+func (a *App) PatchBoard(ctx context.Context, patch *model.BoardPatch, boardID, userID string) (*model.Board, error) {
 	var oldChannelID string
 	var isTemplate bool
 	var oldMembers []*model.BoardMember
@@ -344,13 +454,13 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 	if patch.Type != nil || patch.ChannelID != nil {
 		if patch.ChannelID != nil && *patch.ChannelID == "" {
 			var err error
-			oldMembers, err = a.GetMembersForBoard(boardID)
+			oldMembers, err = a.GetMembersForBoard(ctx, boardID)
 			if err != nil {
 				a.logger.Error("Unable to get the board members", mlog.Err(err))
 			}
 		}
 
-		board, err := a.store.GetBoard(boardID)
+		board, err := a.store.GetBoard(ctx, boardID)
 		if model.IsErrNotFound(err) {
 			return nil, model.NewErrNotFound("board ID=" + boardID)
 		}
@@ -360,7 +470,7 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 		oldChannelID = board.ChannelID
 		isTemplate = board.IsTemplate
 	}
-	updatedBoard, err := a.store.PatchBoard(boardID, patch, userID)
+	updatedBoard, err := a.store.PatchBoard(ctx, boardID, patch, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -369,7 +479,7 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 	if patch.ChannelID != nil {
 		var username string
 
-		user, err := a.store.GetUserByID(userID)
+		user, err := a.store.GetUserByID(ctx, userID)
 		if err != nil {
 			a.logger.Error("Unable to get the board updater", mlog.Err(err))
 			username = "unknown"
@@ -391,7 +501,7 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 
 		if patch.ChannelID != nil {
 			if *patch.ChannelID != "" {
-				members, err := a.GetMembersForBoard(updatedBoard.ID)
+				members, err := a.GetMembersForBoard(context.Background(), updatedBoard.ID)
 				if err != nil {
 					a.logger.Error("Unable to get the board members", mlog.Err(err))
 				}
@@ -410,7 +520,7 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 		}
 
 		if patch.Type != nil && isTemplate {
-			members, err := a.GetMembersForBoard(updatedBoard.ID)
+			members, err := a.GetMembersForBoard(context.Background(), updatedBoard.ID)
 			if err != nil {
 				a.logger.Error("Unable to get the board members", mlog.Err(err))
 			}
@@ -423,7 +533,7 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 }
 
 func (a *App) postChannelMessage(message, channelID string) {
-	err := a.store.PostMessage(message, "", channelID)
+	err := a.store.PostMessage(context.Background(), message, "", channelID)
 	if err != nil {
 		a.logger.Error("Unable to post the link message to channel", mlog.Err(err))
 	}
@@ -432,7 +542,7 @@ func (a *App) postChannelMessage(message, channelID string) {
 // broadcastTeamUsers notifies the members of a team when a template changes its type
 // from public to private or viceversa.
 func (a *App) broadcastTeamUsers(teamID, boardID string, boardType model.BoardType, members []*model.BoardMember) {
-	users, err := a.GetTeamUsers(teamID, "")
+	users, err := a.GetTeamUsers(context.Background(), teamID, "")
 	if err != nil {
 		a.logger.Error("Unable to get the team users", mlog.Err(err))
 	}
@@ -454,50 +564,40 @@ func (a *App) broadcastTeamUsers(teamID, boardID string, boardType model.BoardTy
 	}
 }
 
-func (a *App) DeleteBoard(boardID, userID string) error {
-	board, err := a.store.GetBoard(boardID)
+// DeleteBoard moves a board to the trash, where it can be restored from
+// until it is purged.
+func (a *App) DeleteBoard(ctx context.Context, boardID, userID string) error {
+	err := a.ArchiveBoard(ctx, boardID, userID)
 	if model.IsErrNotFound(err) {
 		return nil
 	}
-	if err != nil {
-		return err
-	}
-
-	if err := a.store.DeleteBoard(boardID, userID); err != nil {
-		return err
-	}
-
-	a.blockChangeNotifier.Enqueue(func() error {
-		a.wsAdapter.BroadcastBoardDelete(board.TeamID, boardID)
-		return nil
-	})
-
-	go func() {
-		if err := a.UpdateCardLimitTimestamp(); err != nil {
-			a.logger.Error(
-				"UpdateCardLimitTimestamp failed after deleting a board",
-				mlog.Err(err),
-			)
-		}
-	}()
+	return err
+}
 
-	return nil
+func (a *App) GetMembersForBoard(ctx context.Context, boardID string) ([]*model.BoardMember, error) {
+	return a.store.GetMembersForBoard(ctx, boardID)
 }
 
-func (a *App) GetMembersForBoard(boardID string) ([]*model.BoardMember, error) {
-	return a.store.GetMembersForBoard(boardID)
+// GetMembersForBoardPage returns up to perPage members of a board after
+// the one with the afterUserID cursor, and whether there are more.
+func (a *App) GetMembersForBoardPage(ctx context.Context, boardID, afterUserID string, perPage int) ([]*model.BoardMember, bool, error) {
+	opts := model.QueryBoardMembersOptions{
+		AfterUserID: afterUserID,
+		PerPage:     perPage,
+	}
+	return a.store.GetMembersForBoardWithOptions(ctx, boardID, opts)
 }
 
-func (a *App) GetMembersForUser(userID string) ([]*model.BoardMember, error) {
-	return a.store.GetMembersForUser(userID)
+func (a *App) GetMembersForUser(ctx context.Context, userID string) ([]*model.BoardMember, error) {
+	return a.store.GetMembersForUser(ctx, userID)
 }
 
-func (a *App) GetMemberForBoard(boardID string, userID string) (*model.BoardMember, error) {
-	return a.store.GetMemberForBoard(boardID, userID)
+func (a *App) GetMemberForBoard(ctx context.Context, boardID string, userID string) (*model.BoardMember, error) {
+	return a.store.GetMemberForBoard(ctx, boardID, userID)
 }
 
-func (a *App) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, error) {
-	board, err := a.store.GetBoard(member.BoardID)
+func (a *App) AddMemberToBoard(ctx context.Context, member *model.BoardMember, modifiedBy string) (*model.BoardMember, error) {
+	board, err := a.store.GetBoard(ctx, member.BoardID)
 	if model.IsErrNotFound(err) {
 		return nil, nil
 	}
@@ -505,7 +605,7 @@ func (a *App) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, e
 		return nil, err
 	}
 
-	existingMembership, err := a.store.GetMemberForBoard(member.BoardID, member.UserID)
+	existingMembership, err := a.store.GetMemberForBoard(ctx, member.BoardID, member.UserID)
 	if err != nil && !model.IsErrNotFound(err) {
 		return nil, err
 	}
@@ -514,21 +614,27 @@ func (a *App) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, e
 		return existingMembership, nil
 	}
 
-	newMember, err := a.store.SaveMember(member)
+	var newMember *model.BoardMember
+	if a.config.MaxBoardMembers > 0 {
+		newMember, err = a.store.SaveMemberWithLimit(ctx, member, a.config.MaxBoardMembers, modifiedBy)
+	} else {
+		newMember, err = a.store.SaveMember(ctx, member, modifiedBy)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastMemberChange(board.TeamID, member.BoardID, member)
+		a.enqueueWebhookEvent(member.BoardID, model.WebhookEventMemberAdded, "", newMember)
 		return nil
 	})
 
 	return newMember, nil
 }
 
-func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember, error) {
-	board, bErr := a.store.GetBoard(member.BoardID)
+func (a *App) UpdateBoardMember(ctx context.Context, member *model.BoardMember, modifiedBy string) (*model.BoardMember, error) {
+	board, bErr := a.store.GetBoard(ctx, member.BoardID)
 	if model.IsErrNotFound(bErr) {
 		return nil, nil
 	}
@@ -536,7 +642,7 @@ func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember,
 		return nil, bErr
 	}
 
-	oldMember, err := a.store.GetMemberForBoard(member.BoardID, member.UserID)
+	oldMember, err := a.store.GetMemberForBoard(ctx, member.BoardID, member.UserID)
 	if model.IsErrNotFound(err) {
 		return nil, nil
 	}
@@ -547,7 +653,7 @@ func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember,
 	// if we're updating an admin, we need to check that there is at
 	// least still another admin on the board
 	if oldMember.SchemeAdmin && !member.SchemeAdmin {
-		isLastAdmin, err2 := a.isLastAdmin(member.UserID, member.BoardID)
+		isLastAdmin, err2 := a.isLastAdmin(ctx, member.UserID, member.BoardID)
 		if err2 != nil {
 			return nil, err2
 		}
@@ -556,21 +662,22 @@ func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember,
 		}
 	}
 
-	newMember, err := a.store.SaveMember(member)
+	newMember, err := a.store.SaveMember(ctx, member, modifiedBy)
 	if err != nil {
 		return nil, err
 	}
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastMemberChange(board.TeamID, member.BoardID, member)
+		a.enqueueWebhookEvent(member.BoardID, model.WebhookEventMemberUpdated, "", newMember)
 		return nil
 	})
 
 	return newMember, nil
 }
 
-func (a *App) isLastAdmin(userID, boardID string) (bool, error) {
-	members, err := a.store.GetMembersForBoard(boardID)
+func (a *App) isLastAdmin(ctx context.Context, userID, boardID string) (bool, error) {
+	members, err := a.store.GetMembersForBoard(ctx, boardID)
 	if err != nil {
 		return false, err
 	}
@@ -583,8 +690,8 @@ func (a *App) isLastAdmin(userID, boardID string) (bool, error) {
 	return true, nil
 }
 
-func (a *App) DeleteBoardMember(boardID, userID string) error {
-	board, bErr := a.store.GetBoard(boardID)
+func (a *App) DeleteBoardMember(ctx context.Context, boardID, userID, modifiedBy string) error {
+	board, bErr := a.store.GetBoard(ctx, boardID)
 	if model.IsErrNotFound(bErr) {
 		return nil
 	}
@@ -592,7 +699,7 @@ func (a *App) DeleteBoardMember(boardID, userID string) error {
 		return bErr
 	}
 
-	oldMember, err := a.store.GetMemberForBoard(boardID, userID)
+	oldMember, err := a.store.GetMemberForBoard(ctx, boardID, userID)
 	if model.IsErrNotFound(err) {
 		return nil
 	}
@@ -603,7 +710,7 @@ func (a *App) DeleteBoardMember(boardID, userID string) error {
 	// if we're removing an admin, we need to check that there is at
 	// least still another admin on the board
 	if oldMember.SchemeAdmin {
-		isLastAdmin, err := a.isLastAdmin(userID, boardID)
+		isLastAdmin, err := a.isLastAdmin(ctx, userID, boardID)
 		if err != nil {
 			return err
 		}
@@ -612,32 +719,133 @@ func (a *App) DeleteBoardMember(boardID, userID string) error {
 		}
 	}
 
-	if err := a.store.DeleteMember(boardID, userID); err != nil {
+	count, err := a.store.DeleteMember(ctx, boardID, userID, modifiedBy)
+	if err != nil {
 		return err
 	}
+	if count == 0 {
+		return model.NewErrNotFound(fmt.Sprintf("board member BoardID=%s UserID=%s", boardID, userID))
+	}
 
 	a.blockChangeNotifier.Enqueue(func() error {
-		if syntheticMember, _ := a.GetMemberForBoard(boardID, userID); syntheticMember != nil {
+		if syntheticMember, _ := a.GetMemberForBoard(context.Background(), boardID, userID); syntheticMember != nil {
 			a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, syntheticMember)
 		} else {
 			a.wsAdapter.BroadcastMemberDelete(board.TeamID, boardID, userID)
 		}
+		a.enqueueWebhookEvent(boardID, model.WebhookEventMemberRemoved, "", oldMember)
+		return nil
+	})
+
+	return nil
+}
+
+// DeleteBoardMembers removes several users from a board at once, and
+// returns the number of memberships removed. Nothing is removed if any
+// of the users isn't a member or if the board would be left without
+// admins.
+func (a *App) DeleteBoardMembers(ctx context.Context, boardID string, userIDs []string, modifiedBy string) (int, error) {
+	board, err := a.store.GetBoard(ctx, boardID)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := a.store.DeleteMembers(ctx, boardID, userIDs, modifiedBy)
+	if err != nil {
+		return 0, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, userID := range userIDs {
+			if syntheticMember, _ := a.GetMemberForBoard(context.Background(), boardID, userID); syntheticMember != nil {
+				a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, syntheticMember)
+			} else {
+				a.wsAdapter.BroadcastMemberDelete(board.TeamID, boardID, userID)
+			}
+			a.enqueueWebhookEvent(boardID, model.WebhookEventMemberRemoved, "", &model.BoardMember{BoardID: boardID, UserID: userID})
+		}
+		return nil
+	})
+
+	return count, nil
+}
+
+func (a *App) SearchBoardsForUser(ctx context.Context, term, userID string, includePublicBoards bool) ([]*model.Board, error) {
+	return a.store.SearchBoardsForUser(ctx, term, userID, includePublicBoards)
+}
+
+func (a *App) SearchBoardsForUserInTeam(ctx context.Context, teamID, term, userID string) ([]*model.Board, error) {
+	return a.store.SearchBoardsForUserInTeam(ctx, teamID, term, userID)
+}
+
+// ArchiveBoard moves a board to the trash, where it stays until it is
+// restored or purged.
+func (a *App) ArchiveBoard(ctx context.Context, boardID, userID string) error {
+	board, err := a.store.GetBoard(ctx, boardID)
+	if err != nil {
+		return err
+	}
+
+	if board.IsTemplate && board.CreatedBy == model.SystemUserID {
+		return model.NewErrForbidden("default templates cannot be deleted")
+	}
+
+	if err := a.store.ArchiveBoard(ctx, boardID, userID); err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardDelete(board.TeamID, boardID)
 		return nil
 	})
 
+	go func() {
+		if err := a.UpdateCardLimitTimestamp(context.Background()); err != nil {
+			a.logger.Error(
+				"UpdateCardLimitTimestamp failed after archiving a board",
+				mlog.Err(err),
+			)
+		}
+	}()
+
 	return nil
 }
 
-func (a *App) SearchBoardsForUser(term, userID string, includePublicBoards bool) ([]*model.Board, error) {
-	return a.store.SearchBoardsForUser(term, userID, includePublicBoards)
+// GetArchivedBoards returns the boards of a team that are in the trash.
+func (a *App) GetArchivedBoards(ctx context.Context, teamID string) ([]*model.Board, error) {
+	return a.store.GetArchivedBoards(ctx, teamID)
 }
 
-func (a *App) SearchBoardsForUserInTeam(teamID, term, userID string) ([]*model.Board, error) {
-	return a.store.SearchBoardsForUserInTeam(teamID, term, userID)
+// RestoreBoard brings a board back from the trash.
+func (a *App) RestoreBoard(ctx context.Context, boardID, userID string) (*model.Board, error) {
+	if err := a.store.RestoreBoard(ctx, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(board.TeamID, board)
+		return nil
+	})
+
+	go func() {
+		if err := a.UpdateCardLimitTimestamp(context.Background()); err != nil {
+			a.logger.Error(
+				"UpdateCardLimitTimestamp failed after restoring a board",
+				mlog.Err(err),
+			)
+		}
+	}()
+
+	return board, nil
 }
 
-func (a *App) UndeleteBoard(boardID string, modifiedBy string) error {
-	boards, err := a.store.GetBoardHistory(boardID, model.QueryBoardHistoryOptions{Limit: 1, Descending: true})
+func (a *App) UndeleteBoard(ctx context.Context, boardID string, modifiedBy string) error {
+	boards, err := a.store.GetBoardHistory(ctx, boardID, model.QueryBoardHistoryOptions{Limit: 1, Descending: true})
 	if err != nil {
 		return err
 	}
@@ -647,12 +855,12 @@ func (a *App) UndeleteBoard(boardID string, modifiedBy string) error {
 		return nil
 	}
 
-	err = a.store.UndeleteBoard(boardID, modifiedBy)
+	err = a.store.UndeleteBoard(ctx, boardID, modifiedBy)
 	if err != nil {
 		return err
 	}
 
-	board, err := a.store.GetBoard(boardID)
+	board, err := a.store.GetBoard(ctx, boardID)
 	if err != nil {
 		return err
 	}
@@ -668,7 +876,7 @@ func (a *App) UndeleteBoard(boardID string, modifiedBy string) error {
 	})
 
 	go func() {
-		if err := a.UpdateCardLimitTimestamp(); err != nil {
+		if err := a.UpdateCardLimitTimestamp(context.Background()); err != nil {
 			a.logger.Error(
 				"UpdateCardLimitTimestamp failed after undeleting a board",
 				mlog.Err(err),
@@ -1,21 +1,23 @@
 package app
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, addMember bool) (*model.BoardsAndBlocks, error) {
+func (a *App) CreateBoardsAndBlocks(ctx context.Context, bab *model.BoardsAndBlocks, userID string, addMember bool) (*model.BoardsAndBlocks, error) {
 	var newBab *model.BoardsAndBlocks
 	var members []*model.BoardMember
 	var err error
 
 	if addMember {
-		newBab, members, err = a.store.CreateBoardsAndBlocksWithAdmin(bab, userID)
+		newBab, members, err = a.store.CreateBoardsAndBlocksWithAdmin(ctx, bab, userID)
 	} else {
-		newBab, err = a.store.CreateBoardsAndBlocks(bab, userID)
+		newBab, err = a.store.CreateBoardsAndBlocks(ctx, bab, userID)
 	}
 
 	if err != nil {
@@ -46,7 +48,7 @@ func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, a
 
 	if len(newBab.Blocks) != 0 {
 		go func() {
-			if uErr := a.UpdateCardLimitTimestamp(); uErr != nil {
+			if uErr := a.UpdateCardLimitTimestamp(context.Background()); uErr != nil {
 				a.logger.Error(
 					"UpdateCardLimitTimestamp failed after creating boards and blocks",
 					mlog.Err(uErr),
@@ -57,7 +59,7 @@ func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, a
 
 	for _, board := range newBab.Boards {
 		if !board.IsTemplate {
-			if err := a.addBoardsToDefaultCategory(userID, board.TeamID, []*model.Board{board}); err != nil {
+			if err := a.addBoardsToDefaultCategory(ctx, userID, board.TeamID, []*model.Board{board}); err != nil {
 				return nil, err
 			}
 		}
@@ -66,14 +68,14 @@ func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, a
 	return newBab, nil
 }
 
-func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
-	oldBlocks, err := a.store.GetBlocksByIDs(pbab.BlockIDs)
+func (a *App) PatchBoardsAndBlocks(ctx context.Context, pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	oldBlocks, err := a.store.GetBlocksByIDs(ctx, pbab.BlockIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	if a.IsCloudLimited() {
-		containsLimitedBlocks, cErr := a.ContainsLimitedBlocks(oldBlocks)
+	if a.IsCloudLimited(ctx) {
+		containsLimitedBlocks, cErr := a.ContainsLimitedBlocks(ctx, oldBlocks)
 		if cErr != nil {
 			return nil, cErr
 		}
@@ -87,7 +89,7 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 		oldBlocksMap[block.ID] = block
 	}
 
-	bab, err := a.store.PatchBoardsAndBlocks(pbab, userID)
+	bab, err := a.store.PatchBoardsAndBlocks(ctx, pbab, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +106,7 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 
 			b := block
 			a.metrics.IncrementBlocksPatched(1)
-			a.wsAdapter.BroadcastBlockChange(teamID, b)
+			a.wsAdapter.BroadcastBlockPatch(teamID, oldBlock, b)
 			a.webhook.NotifyUpdate(b)
 			a.notifyBlockChanged(notify.Update, b, oldBlock, userID)
 		}
@@ -118,8 +120,8 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 	return bab, nil
 }
 
-func (a *App) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error {
-	firstBoard, err := a.store.GetBoard(dbab.Boards[0])
+func (a *App) DeleteBoardsAndBlocks(ctx context.Context, dbab *model.DeleteBoardsAndBlocks, userID string) error {
+	firstBoard, err := a.store.GetBoard(ctx, dbab.Boards[0])
 	if err != nil {
 		return err
 	}
@@ -128,14 +130,14 @@ func (a *App) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID st
 	// fetch and store the blocks first
 	blocks := []*model.Block{}
 	for _, blockID := range dbab.Blocks {
-		block, err := a.store.GetBlock(blockID)
+		block, err := a.store.GetBlock(ctx, blockID)
 		if err != nil {
 			return err
 		}
 		blocks = append(blocks, block)
 	}
 
-	if err := a.store.DeleteBoardsAndBlocks(dbab, userID); err != nil {
+	if err := a.store.DeleteBoardsAndBlocks(ctx, dbab, userID); err != nil {
 		return err
 	}
 
@@ -154,7 +156,7 @@ func (a *App) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID st
 
 	if len(dbab.Blocks) != 0 {
 		go func() {
-			if uErr := a.UpdateCardLimitTimestamp(); uErr != nil {
+			if uErr := a.UpdateCardLimitTimestamp(context.Background()); uErr != nil {
 				a.logger.Error(
 					"UpdateCardLimitTimestamp failed after deleting boards and blocks",
 					mlog.Err(uErr),
@@ -1,10 +1,12 @@
 package app
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/utils"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -26,23 +28,23 @@ func TestAddMemberToBoard(t *testing.T) {
 			SchemeEditor: true,
 		}
 
-		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
 			TeamID: "team_id_1",
 		}, nil)
 
-		th.Store.EXPECT().GetMemberForBoard(boardID, userID).Return(nil, nil)
+		th.Store.EXPECT().GetMemberForBoard(gomock.Any(), boardID, userID).Return(nil, nil)
 
-		th.Store.EXPECT().SaveMember(mock.MatchedBy(func(i interface{}) bool {
+		th.Store.EXPECT().SaveMember(gomock.Any(), mock.MatchedBy(func(i interface{}) bool {
 			p := i.(*model.BoardMember)
 			return p.BoardID == boardID && p.UserID == userID
-		})).Return(&model.BoardMember{
+		}), "user-id").Return(&model.BoardMember{
 			BoardID: boardID,
 		}, nil)
 
 		// for WS change broadcast
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil)
 
-		addedBoardMember, err := th.App.AddMemberToBoard(boardMember)
+		addedBoardMember, err := th.App.AddMemberToBoard(context.Background(), boardMember, "user-id")
 		require.NoError(t, err)
 		require.Equal(t, boardID, addedBoardMember.BoardID)
 	})
@@ -57,17 +59,17 @@ func TestAddMemberToBoard(t *testing.T) {
 			SchemeEditor: true,
 		}
 
-		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
 			TeamID: "team_id_1",
 		}, nil)
 
-		th.Store.EXPECT().GetMemberForBoard(boardID, userID).Return(&model.BoardMember{
+		th.Store.EXPECT().GetMemberForBoard(gomock.Any(), boardID, userID).Return(&model.BoardMember{
 			UserID:    userID,
 			BoardID:   boardID,
 			Synthetic: false,
 		}, nil)
 
-		addedBoardMember, err := th.App.AddMemberToBoard(boardMember)
+		addedBoardMember, err := th.App.AddMemberToBoard(context.Background(), boardMember, "user-id")
 		require.NoError(t, err)
 		require.Equal(t, boardID, addedBoardMember.BoardID)
 	})
@@ -82,32 +84,58 @@ func TestAddMemberToBoard(t *testing.T) {
 			SchemeEditor: true,
 		}
 
-		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
 			TeamID: "team_id_1",
 		}, nil)
 
-		th.Store.EXPECT().GetMemberForBoard(boardID, userID).Return(&model.BoardMember{
+		th.Store.EXPECT().GetMemberForBoard(gomock.Any(), boardID, userID).Return(&model.BoardMember{
 			UserID:    userID,
 			BoardID:   boardID,
 			Synthetic: true,
 		}, nil)
 
-		th.Store.EXPECT().SaveMember(mock.MatchedBy(func(i interface{}) bool {
+		th.Store.EXPECT().SaveMember(gomock.Any(), mock.MatchedBy(func(i interface{}) bool {
 			p := i.(*model.BoardMember)
 			return p.BoardID == boardID && p.UserID == userID
-		})).Return(&model.BoardMember{
+		}), "user-id").Return(&model.BoardMember{
 			UserID:    userID,
 			BoardID:   boardID,
 			Synthetic: false,
 		}, nil)
 
 		// for WS change broadcast
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil)
 
-		addedBoardMember, err := th.App.AddMemberToBoard(boardMember)
+		addedBoardMember, err := th.App.AddMemberToBoard(context.Background(), boardMember, "user-id")
 		require.NoError(t, err)
 		require.Equal(t, boardID, addedBoardMember.BoardID)
 	})
+
+	t.Run("should enforce the board member limit if configured", func(t *testing.T) {
+		const boardID = "board_id_1"
+		const userID = "user_id_1"
+
+		th.App.config.MaxBoardMembers = 5
+		defer func() { th.App.config.MaxBoardMembers = 0 }()
+
+		boardMember := &model.BoardMember{
+			BoardID:      boardID,
+			UserID:       userID,
+			SchemeEditor: true,
+		}
+
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
+			TeamID: "team_id_1",
+		}, nil)
+
+		th.Store.EXPECT().GetMemberForBoard(gomock.Any(), boardID, userID).Return(nil, nil)
+
+		th.Store.EXPECT().SaveMemberWithLimit(gomock.Any(), boardMember, 5, "user-id").Return(nil, model.ErrBoardMemberLimit)
+
+		addedBoardMember, err := th.App.AddMemberToBoard(context.Background(), boardMember, "user-id")
+		require.ErrorIs(t, err, model.ErrBoardMemberLimit)
+		require.Nil(t, addedBoardMember)
+	})
 }
 
 func TestPatchBoard(t *testing.T) {
@@ -124,7 +152,7 @@ func TestPatchBoard(t *testing.T) {
 			Title: &patchTitle,
 		}
 
-		th.Store.EXPECT().PatchBoard(boardID, patch, userID).Return(
+		th.Store.EXPECT().PatchBoard(gomock.Any(), boardID, patch, userID).Return(
 			&model.Board{
 				ID:     boardID,
 				TeamID: teamID,
@@ -133,9 +161,9 @@ func TestPatchBoard(t *testing.T) {
 			nil)
 
 		// for WS BroadcastBoardChange
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(1)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil).Times(1)
 
-		patchedBoard, err := th.App.PatchBoard(patch, boardID, userID)
+		patchedBoard, err := th.App.PatchBoard(context.Background(), patch, boardID, userID)
 		require.NoError(t, err)
 		require.Equal(t, patchTitle, patchedBoard.Title)
 	})
@@ -152,16 +180,16 @@ func TestPatchBoard(t *testing.T) {
 
 		// Type not nil, will cause board to be reteived
 		// to check isTemplate
-		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
 			ID:         boardID,
 			TeamID:     teamID,
 			IsTemplate: true,
 		}, nil)
 
 		// Type not null will retrieve team members
-		th.Store.EXPECT().GetUsersByTeam(teamID, "").Return([]*model.User{}, nil)
+		th.Store.EXPECT().GetUsersByTeam(gomock.Any(), teamID, "").Return([]*model.User{}, nil)
 
-		th.Store.EXPECT().PatchBoard(boardID, patch, userID).Return(
+		th.Store.EXPECT().PatchBoard(gomock.Any(), boardID, patch, userID).Return(
 			&model.Board{
 				ID:     boardID,
 				TeamID: teamID,
@@ -171,9 +199,9 @@ func TestPatchBoard(t *testing.T) {
 		// Should call GetMembersForBoard 2 times
 		// - for WS BroadcastBoardChange
 		// - for AddTeamMembers check
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(2)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil).Times(2)
 
-		patchedBoard, err := th.App.PatchBoard(patch, boardID, userID)
+		patchedBoard, err := th.App.PatchBoard(context.Background(), patch, boardID, userID)
 		require.NoError(t, err)
 		require.Equal(t, boardID, patchedBoard.ID)
 	})
@@ -190,16 +218,16 @@ func TestPatchBoard(t *testing.T) {
 
 		// Type not nil, will cause board to be reteived
 		// to check isTemplate
-		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
 			ID:         boardID,
 			TeamID:     teamID,
 			IsTemplate: true,
 		}, nil)
 
 		// Type not null will retrieve team members
-		th.Store.EXPECT().GetUsersByTeam(teamID, "").Return([]*model.User{}, nil)
+		th.Store.EXPECT().GetUsersByTeam(gomock.Any(), teamID, "").Return([]*model.User{}, nil)
 
-		th.Store.EXPECT().PatchBoard(boardID, patch, userID).Return(
+		th.Store.EXPECT().PatchBoard(gomock.Any(), boardID, patch, userID).Return(
 			&model.Board{
 				ID:     boardID,
 				TeamID: teamID,
@@ -209,9 +237,9 @@ func TestPatchBoard(t *testing.T) {
 		// Should call GetMembersForBoard 2 times
 		// - for WS BroadcastBoardChange
 		// - for AddTeamMembers check
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(2)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil).Times(2)
 
-		patchedBoard, err := th.App.PatchBoard(patch, boardID, userID)
+		patchedBoard, err := th.App.PatchBoard(context.Background(), patch, boardID, userID)
 		require.NoError(t, err)
 		require.Equal(t, boardID, patchedBoard.ID)
 	})
@@ -228,15 +256,15 @@ func TestPatchBoard(t *testing.T) {
 
 		// Type not nil, will cause board to be reteived
 		// to check isTemplate
-		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
 			ID:         boardID,
 			TeamID:     teamID,
 			IsTemplate: true,
 		}, nil)
 		// Type not null will retrieve team members
-		th.Store.EXPECT().GetUsersByTeam(teamID, "").Return([]*model.User{{ID: userID}}, nil)
+		th.Store.EXPECT().GetUsersByTeam(gomock.Any(), teamID, "").Return([]*model.User{{ID: userID}}, nil)
 
-		th.Store.EXPECT().PatchBoard(boardID, patch, userID).Return(
+		th.Store.EXPECT().PatchBoard(gomock.Any(), boardID, patch, userID).Return(
 			&model.Board{
 				ID:     boardID,
 				TeamID: teamID,
@@ -247,9 +275,9 @@ func TestPatchBoard(t *testing.T) {
 		// for WS BroadcastBoardChange
 		// for AddTeamMembers check
 		// for WS BroadcastMemberChange
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(3)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil).Times(3)
 
-		patchedBoard, err := th.App.PatchBoard(patch, boardID, userID)
+		patchedBoard, err := th.App.PatchBoard(context.Background(), patch, boardID, userID)
 		require.NoError(t, err)
 		require.Equal(t, boardID, patchedBoard.ID)
 	})
@@ -266,15 +294,15 @@ func TestPatchBoard(t *testing.T) {
 
 		// Type not nil, will cause board to be reteived
 		// to check isTemplate
-		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
 			ID:         boardID,
 			TeamID:     teamID,
 			IsTemplate: true,
 		}, nil)
 		// Type not null will retrieve team members
-		th.Store.EXPECT().GetUsersByTeam(teamID, "").Return([]*model.User{{ID: userID}}, nil)
+		th.Store.EXPECT().GetUsersByTeam(gomock.Any(), teamID, "").Return([]*model.User{{ID: userID}}, nil)
 
-		th.Store.EXPECT().PatchBoard(boardID, patch, userID).Return(
+		th.Store.EXPECT().PatchBoard(gomock.Any(), boardID, patch, userID).Return(
 			&model.Board{
 				ID:     boardID,
 				TeamID: teamID,
@@ -285,9 +313,9 @@ func TestPatchBoard(t *testing.T) {
 		// for WS BroadcastBoardChange
 		// for AddTeamMembers check
 		// for WS BroadcastMemberChange
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(3)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{}, nil).Times(3)
 
-		patchedBoard, err := th.App.PatchBoard(patch, boardID, userID)
+		patchedBoard, err := th.App.PatchBoard(context.Background(), patch, boardID, userID)
 		require.NoError(t, err)
 		require.Equal(t, boardID, patchedBoard.ID)
 	})
@@ -304,15 +332,15 @@ func TestPatchBoard(t *testing.T) {
 
 		// Type not nil, will cause board to be reteived
 		// to check isTemplate
-		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
 			ID:         boardID,
 			TeamID:     teamID,
 			IsTemplate: true,
 		}, nil)
 		// Type not null will retrieve team members
-		th.Store.EXPECT().GetUsersByTeam(teamID, "").Return([]*model.User{{ID: userID}}, nil)
+		th.Store.EXPECT().GetUsersByTeam(gomock.Any(), teamID, "").Return([]*model.User{{ID: userID}}, nil)
 
-		th.Store.EXPECT().PatchBoard(boardID, patch, userID).Return(
+		th.Store.EXPECT().PatchBoard(gomock.Any(), boardID, patch, userID).Return(
 			&model.Board{
 				ID:     boardID,
 				TeamID: teamID,
@@ -323,9 +351,9 @@ func TestPatchBoard(t *testing.T) {
 		// for WS BroadcastBoardChange
 		// for AddTeamMembers check
 		// We are returning the user as a direct Board Member, so BroadcastMemberDelete won't be called
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{{BoardID: boardID, UserID: userID, SchemeEditor: true}}, nil).Times(2)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{{BoardID: boardID, UserID: userID, SchemeEditor: true}}, nil).Times(2)
 
-		patchedBoard, err := th.App.PatchBoard(patch, boardID, userID)
+		patchedBoard, err := th.App.PatchBoard(context.Background(), patch, boardID, userID)
 		require.NoError(t, err)
 		require.Equal(t, boardID, patchedBoard.ID)
 	})
@@ -342,15 +370,15 @@ func TestPatchBoard(t *testing.T) {
 
 		// Type not nil, will cause board to be reteived
 		// to check isTemplate
-		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{
+		th.Store.EXPECT().GetBoard(gomock.Any(), boardID).Return(&model.Board{
 			ID:         boardID,
 			TeamID:     teamID,
 			IsTemplate: true,
 		}, nil)
 		// Type not null will retrieve team members
-		th.Store.EXPECT().GetUsersByTeam(teamID, "").Return([]*model.User{{ID: userID}}, nil)
+		th.Store.EXPECT().GetUsersByTeam(gomock.Any(), teamID, "").Return([]*model.User{{ID: userID}}, nil)
 
-		th.Store.EXPECT().PatchBoard(boardID, patch, userID).Return(
+		th.Store.EXPECT().PatchBoard(gomock.Any(), boardID, patch, userID).Return(
 			&model.Board{
 				ID:     boardID,
 				TeamID: teamID,
@@ -361,9 +389,9 @@ func TestPatchBoard(t *testing.T) {
 		// for WS BroadcastBoardChange
 		// for AddTeamMembers check
 		// We are returning the user as a direct Board Member, so BroadcastMemberDelete won't be called
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{{BoardID: boardID, UserID: userID, SchemeEditor: true}}, nil).Times(2)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{{BoardID: boardID, UserID: userID, SchemeEditor: true}}, nil).Times(2)
 
-		patchedBoard, err := th.App.PatchBoard(patch, boardID, userID)
+		patchedBoard, err := th.App.PatchBoard(context.Background(), patch, boardID, userID)
 		require.NoError(t, err)
 		require.Equal(t, boardID, patchedBoard.ID)
 	})
@@ -375,21 +403,87 @@ func TestGetBoardCount(t *testing.T) {
 
 	t.Run("base case", func(t *testing.T) {
 		boardCount := int64(100)
-		th.Store.EXPECT().GetBoardCount().Return(boardCount, nil)
+		th.Store.EXPECT().GetBoardCount(gomock.Any()).Return(boardCount, nil)
 
-		count, err := th.App.GetBoardCount()
+		count, err := th.App.GetBoardCount(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, boardCount, count)
 	})
 }
 
+func TestGetBoardsModifiedSince(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boards := []*model.Board{
+		{ID: "board_id_1", Type: model.BoardTypeOpen},
+		{ID: "board_id_2", Type: model.BoardTypePrivate},
+	}
+
+	t.Run("regular users get all the modified boards", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsModifiedSince(gomock.Any(), "team_id", "user_id", int64(100)).Return(boards, nil)
+		th.Store.EXPECT().GetUserByID(gomock.Any(), "user_id").Return(&model.User{ID: "user_id"}, nil)
+
+		got, err := th.App.GetBoardsModifiedSince(context.Background(), "team_id", "user_id", 100)
+		require.NoError(t, err)
+		require.Equal(t, boards, got)
+	})
+
+	t.Run("guests only get the boards they are members of", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsModifiedSince(gomock.Any(), "team_id", "guest_id", int64(100)).Return(boards, nil)
+		th.Store.EXPECT().GetUserByID(gomock.Any(), "guest_id").Return(&model.User{ID: "guest_id", IsGuest: true}, nil)
+		th.Store.EXPECT().GetMembersForUser(gomock.Any(), "guest_id").Return([]*model.BoardMember{
+			{BoardID: "board_id_2", UserID: "guest_id"},
+		}, nil)
+
+		got, err := th.App.GetBoardsModifiedSince(context.Background(), "team_id", "guest_id", 100)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, "board_id_2", got[0].ID)
+	})
+}
+
+func TestGetBoardLastModifiedBy(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id", ModifiedBy: "board_editor", UpdateAt: 100}
+
+	t.Run("board without blocks", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(gomock.Any(), "board_id").Return(board, nil)
+		th.Store.EXPECT().GetLastModifiedBlockForBoard(gomock.Any(), "board_id").Return(nil, model.NewErrNotFound("blocks"))
+
+		modifiedBy, err := th.App.GetBoardLastModifiedBy(context.Background(), "board_id")
+		require.NoError(t, err)
+		require.Equal(t, "board_editor", modifiedBy)
+	})
+
+	t.Run("content edited after the board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(gomock.Any(), "board_id").Return(board, nil)
+		th.Store.EXPECT().GetLastModifiedBlockForBoard(gomock.Any(), "board_id").Return(&model.Block{ModifiedBy: "block_editor", UpdateAt: 200}, nil)
+
+		modifiedBy, err := th.App.GetBoardLastModifiedBy(context.Background(), "board_id")
+		require.NoError(t, err)
+		require.Equal(t, "block_editor", modifiedBy)
+	})
+
+	t.Run("board edited after the content", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(gomock.Any(), "board_id").Return(board, nil)
+		th.Store.EXPECT().GetLastModifiedBlockForBoard(gomock.Any(), "board_id").Return(&model.Block{ModifiedBy: "block_editor", UpdateAt: 50}, nil)
+
+		modifiedBy, err := th.App.GetBoardLastModifiedBy(context.Background(), "board_id")
+		require.NoError(t, err)
+		require.Equal(t, "board_editor", modifiedBy)
+	})
+}
+
 func TestBoardCategory(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
 
 	t.Run("test addBoardsToDefaultCategory", func(t *testing.T) {
 		t.Run("no boards default category exists", func(t *testing.T) {
-			th.Store.EXPECT().GetUserCategoryBoards("user_id", "team_id").Return([]model.CategoryBoards{
+			th.Store.EXPECT().GetUserCategoryBoards(gomock.Any(), "user_id", "team_id").Return([]model.CategoryBoards{
 				{
 					Category: model.Category{ID: "category_id_1", Name: "Category 1"},
 					BoardIDs: []string{"board_id_1", "board_id_2"},
@@ -404,15 +498,15 @@ func TestBoardCategory(t *testing.T) {
 				},
 			}, nil)
 
-			th.Store.EXPECT().CreateCategory(utils.Anything).Return(nil)
-			th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+			th.Store.EXPECT().CreateCategory(gomock.Any(), utils.Anything).Return(nil)
+			th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 				ID:   "default_category_id",
 				Name: "Boards",
 			}, nil)
-			th.Store.EXPECT().GetBoardsForUserAndTeam("user_id", "team_id", false).Return([]*model.Board{}, nil)
-			th.Store.EXPECT().AddUpdateCategoryBoard("user_id", "default_category_id", "board_id_1").Return(nil)
-			th.Store.EXPECT().AddUpdateCategoryBoard("user_id", "default_category_id", "board_id_2").Return(nil)
-			th.Store.EXPECT().AddUpdateCategoryBoard("user_id", "default_category_id", "board_id_3").Return(nil)
+			th.Store.EXPECT().GetBoardsForUserAndTeam(gomock.Any(), "user_id", "team_id", false).Return([]*model.Board{}, nil)
+			th.Store.EXPECT().AddUpdateCategoryBoard(gomock.Any(), "user_id", "default_category_id", "board_id_1").Return(nil)
+			th.Store.EXPECT().AddUpdateCategoryBoard(gomock.Any(), "user_id", "default_category_id", "board_id_2").Return(nil)
+			th.Store.EXPECT().AddUpdateCategoryBoard(gomock.Any(), "user_id", "default_category_id", "board_id_3").Return(nil)
 
 			boards := []*model.Board{
 				{ID: "board_id_1"},
@@ -420,7 +514,7 @@ func TestBoardCategory(t *testing.T) {
 				{ID: "board_id_3"},
 			}
 
-			err := th.App.addBoardsToDefaultCategory("user_id", "team_id", boards)
+			err := th.App.addBoardsToDefaultCategory(context.Background(), "user_id", "team_id", boards)
 			assert.NoError(t, err)
 		})
 	})
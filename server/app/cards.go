@@ -4,13 +4,14 @@
 package app
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 )
 
-func (a *App) CreateCard(card *model.Card, boardID string, userID string, disableNotify bool) (*model.Card, error) {
+func (a *App) CreateCard(ctx context.Context, card *model.Card, boardID string, userID string, disableNotify bool) (*model.Card, error) {
 	// Convert the card struct to a block and insert the block.
 	now := utils.GetMillis()
 
@@ -24,7 +25,7 @@ func (a *App) CreateCard(card *model.Card, boardID string, userID string, disabl
 
 	block := model.Card2Block(card)
 
-	newBlocks, err := a.InsertBlocksAndNotify([]*model.Block{block}, userID, disableNotify)
+	newBlocks, err := a.InsertBlocksAndNotify(ctx, []*model.Block{block}, userID, disableNotify)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create card: %w", err)
 	}
@@ -37,7 +38,7 @@ func (a *App) CreateCard(card *model.Card, boardID string, userID string, disabl
 	return newCard, nil
 }
 
-func (a *App) GetCardsForBoard(boardID string, page int, perPage int) ([]*model.Card, error) {
+func (a *App) GetCardsForBoard(ctx context.Context, boardID string, page int, perPage int) ([]*model.Card, error) {
 	opts := model.QueryBlocksOptions{
 		BoardID:   boardID,
 		BlockType: model.TypeCard,
@@ -45,7 +46,7 @@ func (a *App) GetCardsForBoard(boardID string, page int, perPage int) ([]*model.
 		PerPage:   perPage,
 	}
 
-	blocks, err := a.store.GetBlocks(opts)
+	blocks, err := a.store.GetBlocks(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -62,13 +63,13 @@ func (a *App) GetCardsForBoard(boardID string, page int, perPage int) ([]*model.
 	return cards, nil
 }
 
-func (a *App) PatchCard(cardPatch *model.CardPatch, cardID string, userID string, disableNotify bool) (*model.Card, error) {
+func (a *App) PatchCard(ctx context.Context, cardPatch *model.CardPatch, cardID string, userID string, disableNotify bool) (*model.Card, error) {
 	blockPatch, err := model.CardPatch2BlockPatch(cardPatch)
 	if err != nil {
 		return nil, err
 	}
 
-	newBlock, err := a.PatchBlockAndNotify(cardID, blockPatch, userID, disableNotify)
+	newBlock, err := a.PatchBlockAndNotify(ctx, cardID, blockPatch, userID, disableNotify)
 	if err != nil {
 		return nil, fmt.Errorf("cannot patch card %s: %w", cardID, err)
 	}
@@ -81,8 +82,8 @@ func (a *App) PatchCard(cardPatch *model.CardPatch, cardID string, userID string
 	return newCard, nil
 }
 
-func (a *App) GetCardByID(cardID string) (*model.Card, error) {
-	cardBlock, err := a.GetBlockByID(cardID)
+func (a *App) GetCardByID(ctx context.Context, cardID string) (*model.Card, error) {
+	cardBlock, err := a.GetBlockByID(ctx, cardID)
 	if err != nil {
 		return nil, err
 	}
@@ -94,3 +95,56 @@ func (a *App) GetCardByID(cardID string) (*model.Card, error) {
 
 	return card, nil
 }
+
+// ArchiveCard files a card away: it's removed from the board card
+// listings and its subscriptions are paused until it's unarchived.
+func (a *App) ArchiveCard(ctx context.Context, cardID, userID string) (*model.Card, error) {
+	if err := a.store.ArchiveCard(ctx, cardID, userID); err != nil {
+		return nil, fmt.Errorf("cannot archive card %s: %w", cardID, err)
+	}
+	return a.notifyCardArchiveChange(ctx, cardID)
+}
+
+// UnarchiveCard restores an archived card to the board.
+func (a *App) UnarchiveCard(ctx context.Context, cardID, userID string) (*model.Card, error) {
+	if err := a.store.UnarchiveCard(ctx, cardID, userID); err != nil {
+		return nil, fmt.Errorf("cannot unarchive card %s: %w", cardID, err)
+	}
+	return a.notifyCardArchiveChange(ctx, cardID)
+}
+
+func (a *App) notifyCardArchiveChange(ctx context.Context, cardID string) (*model.Card, error) {
+	block, err := a.store.GetBlock(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(ctx, block.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		return nil
+	})
+
+	return model.Block2Card(block)
+}
+
+func (a *App) GetArchivedCards(ctx context.Context, boardID string) ([]*model.Card, error) {
+	blocks, err := a.store.GetArchivedCards(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]*model.Card, 0, len(blocks))
+	for _, block := range blocks {
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, fmt.Errorf("Block2Card fail: %w", err)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -10,17 +11,17 @@ import (
 var ErrCannotDeleteSystemCategory = errors.New("cannot delete a system category")
 var ErrCannotUpdateSystemCategory = errors.New("cannot update a system category")
 
-func (a *App) CreateCategory(category *model.Category) (*model.Category, error) {
+func (a *App) CreateCategory(ctx context.Context, category *model.Category) (*model.Category, error) {
 	category.Hydrate()
 	if err := category.IsValid(); err != nil {
 		return nil, err
 	}
 
-	if err := a.store.CreateCategory(*category); err != nil {
+	if err := a.store.CreateCategory(ctx, *category); err != nil {
 		return nil, err
 	}
 
-	createdCategory, err := a.store.GetCategory(category.ID)
+	createdCategory, err := a.store.GetCategory(ctx, category.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -32,13 +33,13 @@ func (a *App) CreateCategory(category *model.Category) (*model.Category, error)
 	return createdCategory, nil
 }
 
-func (a *App) UpdateCategory(category *model.Category) (*model.Category, error) {
+func (a *App) UpdateCategory(ctx context.Context, category *model.Category) (*model.Category, error) {
 	if err := category.IsValid(); err != nil {
 		return nil, err
 	}
 
 	// verify if category belongs to the user
-	existingCategory, err := a.store.GetCategory(category.ID)
+	existingCategory, err := a.store.GetCategory(ctx, category.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -66,11 +67,11 @@ func (a *App) UpdateCategory(category *model.Category) (*model.Category, error)
 	if err = category.IsValid(); err != nil {
 		return nil, err
 	}
-	if err = a.store.UpdateCategory(*category); err != nil {
+	if err = a.store.UpdateCategory(ctx, *category); err != nil {
 		return nil, err
 	}
 
-	updatedCategory, err := a.store.GetCategory(category.ID)
+	updatedCategory, err := a.store.GetCategory(ctx, category.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -82,8 +83,8 @@ func (a *App) UpdateCategory(category *model.Category) (*model.Category, error)
 	return updatedCategory, nil
 }
 
-func (a *App) DeleteCategory(categoryID, userID, teamID string) (*model.Category, error) {
-	existingCategory, err := a.store.GetCategory(categoryID)
+func (a *App) DeleteCategory(ctx context.Context, categoryID, userID, teamID string) (*model.Category, error) {
+	existingCategory, err := a.store.GetCategory(ctx, categoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -108,11 +109,11 @@ func (a *App) DeleteCategory(categoryID, userID, teamID string) (*model.Category
 		return nil, ErrCannotDeleteSystemCategory
 	}
 
-	if err = a.store.DeleteCategory(categoryID, userID, teamID); err != nil {
+	if err = a.store.DeleteCategory(ctx, categoryID, userID, teamID); err != nil {
 		return nil, err
 	}
 
-	deletedCategory, err := a.store.GetCategory(categoryID)
+	deletedCategory, err := a.store.GetCategory(ctx, categoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -8,13 +9,13 @@ import (
 
 const defaultCategoryBoards = "Boards"
 
-func (a *App) GetUserCategoryBoards(userID, teamID string) ([]model.CategoryBoards, error) {
-	categoryBoards, err := a.store.GetUserCategoryBoards(userID, teamID)
+func (a *App) GetUserCategoryBoards(ctx context.Context, userID, teamID string) ([]model.CategoryBoards, error) {
+	categoryBoards, err := a.store.GetUserCategoryBoards(ctx, userID, teamID)
 	if err != nil {
 		return nil, err
 	}
 
-	createdCategoryBoards, err := a.createDefaultCategoriesIfRequired(categoryBoards, userID, teamID)
+	createdCategoryBoards, err := a.createDefaultCategoriesIfRequired(ctx, categoryBoards, userID, teamID)
 	if err != nil {
 		return nil, err
 	}
@@ -23,7 +24,22 @@ func (a *App) GetUserCategoryBoards(userID, teamID string) ([]model.CategoryBoar
 	return categoryBoards, nil
 }
 
-func (a *App) createDefaultCategoriesIfRequired(existingCategoryBoards []model.CategoryBoards, userID, teamID string) ([]model.CategoryBoards, error) {
+// GetCategoryForBoard returns the category a board is assigned to for a
+// user, or the user's default category if the board isn't assigned to
+// any. The default category is created if it doesn't exist yet.
+func (a *App) GetCategoryForBoard(ctx context.Context, userID, teamID, boardID string) (*model.Category, error) {
+	category, err := a.store.GetCategoryForBoard(ctx, userID, teamID, boardID)
+	if !model.IsErrNotFound(err) {
+		return category, err
+	}
+
+	if _, err := a.GetUserCategoryBoards(ctx, userID, teamID); err != nil {
+		return nil, err
+	}
+	return a.store.GetCategoryForBoard(ctx, userID, teamID, boardID)
+}
+
+func (a *App) createDefaultCategoriesIfRequired(ctx context.Context, existingCategoryBoards []model.CategoryBoards, userID, teamID string) ([]model.CategoryBoards, error) {
 	createdCategories := []model.CategoryBoards{}
 
 	boardsCategoryExist := false
@@ -34,7 +50,7 @@ func (a *App) createDefaultCategoriesIfRequired(existingCategoryBoards []model.C
 	}
 
 	if !boardsCategoryExist {
-		createdCategoryBoards, err := a.createBoardsCategory(userID, teamID, existingCategoryBoards)
+		createdCategoryBoards, err := a.createBoardsCategory(ctx, userID, teamID, existingCategoryBoards)
 		if err != nil {
 			return nil, err
 		}
@@ -45,7 +61,7 @@ func (a *App) createDefaultCategoriesIfRequired(existingCategoryBoards []model.C
 	return createdCategories, nil
 }
 
-func (a *App) createBoardsCategory(userID, teamID string, existingCategoryBoards []model.CategoryBoards) (*model.CategoryBoards, error) {
+func (a *App) createBoardsCategory(ctx context.Context, userID, teamID string, existingCategoryBoards []model.CategoryBoards) (*model.CategoryBoards, error) {
 	// create the category
 	category := model.Category{
 		Name:      defaultCategoryBoards,
@@ -54,7 +70,7 @@ func (a *App) createBoardsCategory(userID, teamID string, existingCategoryBoards
 		Collapsed: false,
 		Type:      model.CategoryTypeSystem,
 	}
-	createdCategory, err := a.CreateCategory(&category)
+	createdCategory, err := a.CreateCategory(ctx, &category)
 	if err != nil {
 		return nil, fmt.Errorf("createBoardsCategory default category creation failed: %w", err)
 	}
@@ -62,7 +78,7 @@ func (a *App) createBoardsCategory(userID, teamID string, existingCategoryBoards
 	// once the category is created, we need to move all boards which do not
 	// belong to any category, into this category.
 
-	userBoards, err := a.GetBoardsForUserAndTeam(userID, teamID, false)
+	userBoards, err := a.GetBoardsForUserAndTeam(ctx, userID, teamID, false)
 	if err != nil {
 		return nil, fmt.Errorf("createBoardsCategory error fetching user's team's boards: %w", err)
 	}
@@ -91,7 +107,7 @@ func (a *App) createBoardsCategory(userID, teamID string, existingCategoryBoards
 		}
 
 		if !belongsToCategory {
-			if err := a.AddUpdateUserCategoryBoard(teamID, userID, createdCategory.ID, board.ID); err != nil {
+			if err := a.AddUpdateUserCategoryBoard(ctx, teamID, userID, createdCategory.ID, board.ID); err != nil {
 				return nil, fmt.Errorf("createBoardsCategory failed to add category-less board to the default category, defaultCategoryID: %s, error: %w", createdCategory.ID, err)
 			}
 
@@ -102,8 +118,8 @@ func (a *App) createBoardsCategory(userID, teamID string, existingCategoryBoards
 	return createdCategoryBoards, nil
 }
 
-func (a *App) AddUpdateUserCategoryBoard(teamID, userID, categoryID, boardID string) error {
-	err := a.store.AddUpdateCategoryBoard(userID, categoryID, boardID)
+func (a *App) AddUpdateUserCategoryBoard(ctx context.Context, teamID, userID, categoryID, boardID string) error {
+	err := a.store.AddUpdateCategoryBoard(ctx, userID, categoryID, boardID)
 	if err != nil {
 		return err
 	}
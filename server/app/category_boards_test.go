@@ -1,10 +1,12 @@
 package app
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/utils"
 
+	"github.com/golang/mock/gomock"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,9 +16,9 @@ func TestGetUserCategoryBoards(t *testing.T) {
 	defer tearDown()
 
 	t.Run("user had no default category and had boards", func(t *testing.T) {
-		th.Store.EXPECT().GetUserCategoryBoards("user_id", "team_id").Return([]model.CategoryBoards{}, nil)
-		th.Store.EXPECT().CreateCategory(utils.Anything).Return(nil)
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetUserCategoryBoards(gomock.Any(), "user_id", "team_id").Return([]model.CategoryBoards{}, nil)
+		th.Store.EXPECT().CreateCategory(gomock.Any(), utils.Anything).Return(nil)
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:   "boards_category_id",
 			Name: "Boards",
 		}, nil)
@@ -33,12 +35,12 @@ func TestGetUserCategoryBoards(t *testing.T) {
 			ID: "board_id_3",
 		}
 
-		th.Store.EXPECT().GetBoardsForUserAndTeam("user_id", "team_id", false).Return([]*model.Board{board1, board2, board3}, nil)
-		th.Store.EXPECT().AddUpdateCategoryBoard("user_id", "boards_category_id", "board_id_1").Return(nil)
-		th.Store.EXPECT().AddUpdateCategoryBoard("user_id", "boards_category_id", "board_id_2").Return(nil)
-		th.Store.EXPECT().AddUpdateCategoryBoard("user_id", "boards_category_id", "board_id_3").Return(nil)
+		th.Store.EXPECT().GetBoardsForUserAndTeam(gomock.Any(), "user_id", "team_id", false).Return([]*model.Board{board1, board2, board3}, nil)
+		th.Store.EXPECT().AddUpdateCategoryBoard(gomock.Any(), "user_id", "boards_category_id", "board_id_1").Return(nil)
+		th.Store.EXPECT().AddUpdateCategoryBoard(gomock.Any(), "user_id", "boards_category_id", "board_id_2").Return(nil)
+		th.Store.EXPECT().AddUpdateCategoryBoard(gomock.Any(), "user_id", "boards_category_id", "board_id_3").Return(nil)
 
-		categoryBoards, err := th.App.GetUserCategoryBoards("user_id", "team_id")
+		categoryBoards, err := th.App.GetUserCategoryBoards(context.Background(), "user_id", "team_id")
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(categoryBoards))
 		assert.Equal(t, "Boards", categoryBoards[0].Name)
@@ -49,16 +51,16 @@ func TestGetUserCategoryBoards(t *testing.T) {
 	})
 
 	t.Run("user had no default category BUT had no boards", func(t *testing.T) {
-		th.Store.EXPECT().GetUserCategoryBoards("user_id", "team_id").Return([]model.CategoryBoards{}, nil)
-		th.Store.EXPECT().CreateCategory(utils.Anything).Return(nil)
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetUserCategoryBoards(gomock.Any(), "user_id", "team_id").Return([]model.CategoryBoards{}, nil)
+		th.Store.EXPECT().CreateCategory(gomock.Any(), utils.Anything).Return(nil)
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:   "boards_category_id",
 			Name: "Boards",
 		}, nil)
 
-		th.Store.EXPECT().GetBoardsForUserAndTeam("user_id", "team_id", false).Return([]*model.Board{}, nil)
+		th.Store.EXPECT().GetBoardsForUserAndTeam(gomock.Any(), "user_id", "team_id", false).Return([]*model.Board{}, nil)
 
-		categoryBoards, err := th.App.GetUserCategoryBoards("user_id", "team_id")
+		categoryBoards, err := th.App.GetUserCategoryBoards(context.Background(), "user_id", "team_id")
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(categoryBoards))
 		assert.Equal(t, "Boards", categoryBoards[0].Name)
@@ -66,17 +68,46 @@ func TestGetUserCategoryBoards(t *testing.T) {
 	})
 
 	t.Run("user already had a default Boards category with boards in it", func(t *testing.T) {
-		th.Store.EXPECT().GetUserCategoryBoards("user_id", "team_id").Return([]model.CategoryBoards{
+		th.Store.EXPECT().GetUserCategoryBoards(gomock.Any(), "user_id", "team_id").Return([]model.CategoryBoards{
 			{
 				Category: model.Category{Name: "Boards"},
 				BoardIDs: []string{"board_id_1", "board_id_2"},
 			},
 		}, nil)
 
-		categoryBoards, err := th.App.GetUserCategoryBoards("user_id", "team_id")
+		categoryBoards, err := th.App.GetUserCategoryBoards(context.Background(), "user_id", "team_id")
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(categoryBoards))
 		assert.Equal(t, "Boards", categoryBoards[0].Name)
 		assert.Equal(t, 2, len(categoryBoards[0].BoardIDs))
 	})
 }
+
+func TestGetCategoryForBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("board assigned to a category", func(t *testing.T) {
+		th.Store.EXPECT().GetCategoryForBoard(gomock.Any(), "user_id", "team_id", "board_id").Return(&model.Category{ID: "category_id"}, nil)
+
+		category, err := th.App.GetCategoryForBoard(context.Background(), "user_id", "team_id", "board_id")
+		assert.NoError(t, err)
+		assert.Equal(t, "category_id", category.ID)
+	})
+
+	t.Run("user had no default category", func(t *testing.T) {
+		th.Store.EXPECT().GetCategoryForBoard(gomock.Any(), "user_id", "team_id", "board_id").Return(nil, model.NewErrNotFound("default category"))
+		th.Store.EXPECT().GetUserCategoryBoards(gomock.Any(), "user_id", "team_id").Return([]model.CategoryBoards{}, nil)
+		th.Store.EXPECT().CreateCategory(gomock.Any(), utils.Anything).Return(nil)
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
+			ID:   "boards_category_id",
+			Name: "Boards",
+		}, nil)
+		th.Store.EXPECT().GetBoardsForUserAndTeam(gomock.Any(), "user_id", "team_id", false).Return([]*model.Board{}, nil)
+		th.Store.EXPECT().GetCategoryForBoard(gomock.Any(), "user_id", "team_id", "board_id").Return(&model.Category{ID: "boards_category_id"}, nil)
+
+		category, err := th.App.GetCategoryForBoard(context.Background(), "user_id", "team_id", "board_id")
+		assert.NoError(t, err)
+		assert.Equal(t, "boards_category_id", category.ID)
+	})
+}
@@ -1,8 +1,10 @@
 package app
 
 import (
+	"context"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/stretchr/testify/assert"
@@ -13,9 +15,9 @@ func TestCreateCategory(t *testing.T) {
 	defer tearDown()
 
 	t.Run("base case", func(t *testing.T) {
-		th.Store.EXPECT().CreateCategory(utils.Anything).Return(nil)
+		th.Store.EXPECT().CreateCategory(gomock.Any(), utils.Anything).Return(nil)
 
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID: "category_id_1",
 		}, nil)
 
@@ -25,7 +27,7 @@ func TestCreateCategory(t *testing.T) {
 			TeamID: "team_id",
 			Type:   "custom",
 		}
-		createdCategory, err := th.App.CreateCategory(category)
+		createdCategory, err := th.App.CreateCategory(context.Background(), category)
 		assert.NotNil(t, createdCategory)
 		assert.NoError(t, err)
 	})
@@ -37,24 +39,24 @@ func TestCreateCategory(t *testing.T) {
 			TeamID: "team_id",
 			Type:   "custom",
 		}
-		createdCategory, err := th.App.CreateCategory(category)
+		createdCategory, err := th.App.CreateCategory(context.Background(), category)
 		assert.Nil(t, createdCategory)
 		assert.Error(t, err)
 
 		category.Name = "Name"
 		category.UserID = "" // empty creator user id shouldn't be allowed
-		createdCategory, err = th.App.CreateCategory(category)
+		createdCategory, err = th.App.CreateCategory(context.Background(), category)
 		assert.Nil(t, createdCategory)
 		assert.Error(t, err)
 
 		category.UserID = "user_id"
 		category.TeamID = "" // empty TeamID shouldn't be allowed
-		createdCategory, err = th.App.CreateCategory(category)
+		createdCategory, err = th.App.CreateCategory(context.Background(), category)
 		assert.Nil(t, createdCategory)
 		assert.Error(t, err)
 
 		category.Type = "invalid" // unknown type shouldn't be allowed
-		createdCategory, err = th.App.CreateCategory(category)
+		createdCategory, err = th.App.CreateCategory(context.Background(), category)
 		assert.Nil(t, createdCategory)
 		assert.Error(t, err)
 	})
@@ -65,7 +67,7 @@ func TestUpdateCategory(t *testing.T) {
 	defer tearDown()
 
 	t.Run("base case", func(t *testing.T) {
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:     "category_id_1",
 			Name:   "Category",
 			TeamID: "team_id_1",
@@ -73,8 +75,8 @@ func TestUpdateCategory(t *testing.T) {
 			Type:   "custom",
 		}, nil)
 
-		th.Store.EXPECT().UpdateCategory(utils.Anything).Return(nil)
-		th.Store.EXPECT().GetCategory("category_id_1").Return(&model.Category{
+		th.Store.EXPECT().UpdateCategory(gomock.Any(), utils.Anything).Return(nil)
+		th.Store.EXPECT().GetCategory(gomock.Any(), "category_id_1").Return(&model.Category{
 			ID:   "category_id_1",
 			Name: "Category",
 		}, nil)
@@ -86,7 +88,7 @@ func TestUpdateCategory(t *testing.T) {
 			TeamID: "team_id_1",
 			Type:   "custom",
 		}
-		updatedCategory, err := th.App.UpdateCategory(category)
+		updatedCategory, err := th.App.UpdateCategory(context.Background(), category)
 		assert.NotNil(t, updatedCategory)
 		assert.NoError(t, err)
 	})
@@ -101,36 +103,36 @@ func TestUpdateCategory(t *testing.T) {
 		}
 
 		category.ID = ""
-		createdCategory, err := th.App.UpdateCategory(category)
+		createdCategory, err := th.App.UpdateCategory(context.Background(), category)
 		assert.Nil(t, createdCategory)
 		assert.Error(t, err)
 
 		category.ID = "category_id_1"
 		category.Name = ""
-		createdCategory, err = th.App.UpdateCategory(category)
+		createdCategory, err = th.App.UpdateCategory(context.Background(), category)
 		assert.Nil(t, createdCategory)
 		assert.Error(t, err)
 
 		category.Name = "Name"
 		category.UserID = "" // empty creator user id shouldn't be allowed
-		createdCategory, err = th.App.UpdateCategory(category)
+		createdCategory, err = th.App.UpdateCategory(context.Background(), category)
 		assert.Nil(t, createdCategory)
 		assert.Error(t, err)
 
 		category.UserID = "user_id"
 		category.TeamID = "" // empty TeamID shouldn't be allowed
-		createdCategory, err = th.App.UpdateCategory(category)
+		createdCategory, err = th.App.UpdateCategory(context.Background(), category)
 		assert.Nil(t, createdCategory)
 		assert.Error(t, err)
 
 		category.Type = "invalid" // unknown type shouldn't be allowed
-		createdCategory, err = th.App.UpdateCategory(category)
+		createdCategory, err = th.App.UpdateCategory(context.Background(), category)
 		assert.Nil(t, createdCategory)
 		assert.Error(t, err)
 	})
 
 	t.Run("trying to update someone else's category", func(t *testing.T) {
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:     "category_id_1",
 			Name:   "Category",
 			TeamID: "team_id_1",
@@ -145,13 +147,13 @@ func TestUpdateCategory(t *testing.T) {
 			TeamID: "team_id_1",
 			Type:   "custom",
 		}
-		updatedCategory, err := th.App.UpdateCategory(category)
+		updatedCategory, err := th.App.UpdateCategory(context.Background(), category)
 		assert.Nil(t, updatedCategory)
 		assert.Error(t, err)
 	})
 
 	t.Run("trying to update some other team's category", func(t *testing.T) {
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:     "category_id_1",
 			Name:   "Category",
 			TeamID: "team_id_1",
@@ -166,13 +168,13 @@ func TestUpdateCategory(t *testing.T) {
 			TeamID: "team_id_2",
 			Type:   "custom",
 		}
-		updatedCategory, err := th.App.UpdateCategory(category)
+		updatedCategory, err := th.App.UpdateCategory(context.Background(), category)
 		assert.Nil(t, updatedCategory)
 		assert.Error(t, err)
 	})
 
 	t.Run("should not be allowed to rename system category", func(t *testing.T) {
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:     "category_id_1",
 			Name:   "Category",
 			TeamID: "team_id_1",
@@ -180,9 +182,9 @@ func TestUpdateCategory(t *testing.T) {
 			Type:   "system",
 		}, nil).Times(1)
 
-		th.Store.EXPECT().UpdateCategory(utils.Anything).Return(nil)
+		th.Store.EXPECT().UpdateCategory(gomock.Any(), utils.Anything).Return(nil)
 
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:        "category_id_1",
 			Name:      "Category",
 			TeamID:    "team_id_1",
@@ -198,14 +200,14 @@ func TestUpdateCategory(t *testing.T) {
 			TeamID: "team_id_1",
 			Type:   "system",
 		}
-		updatedCategory, err := th.App.UpdateCategory(category)
+		updatedCategory, err := th.App.UpdateCategory(context.Background(), category)
 		assert.NotNil(t, updatedCategory)
 		assert.NoError(t, err)
 		assert.Equal(t, "Category", updatedCategory.Name)
 	})
 
 	t.Run("should be allowed to collapse and expand any category type", func(t *testing.T) {
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:        "category_id_1",
 			Name:      "Category",
 			TeamID:    "team_id_1",
@@ -214,9 +216,9 @@ func TestUpdateCategory(t *testing.T) {
 			Collapsed: false,
 		}, nil).Times(1)
 
-		th.Store.EXPECT().UpdateCategory(utils.Anything).Return(nil)
+		th.Store.EXPECT().UpdateCategory(gomock.Any(), utils.Anything).Return(nil)
 
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:        "category_id_1",
 			Name:      "Category",
 			TeamID:    "team_id_1",
@@ -233,7 +235,7 @@ func TestUpdateCategory(t *testing.T) {
 			Type:      "system",
 			Collapsed: true,
 		}
-		updatedCategory, err := th.App.UpdateCategory(category)
+		updatedCategory, err := th.App.UpdateCategory(context.Background(), category)
 		assert.NotNil(t, updatedCategory)
 		assert.NoError(t, err)
 		assert.Equal(t, "Category", updatedCategory.Name, "The name should have not been updated")
@@ -246,7 +248,7 @@ func TestDeleteCategory(t *testing.T) {
 	defer tearDown()
 
 	t.Run("base case", func(t *testing.T) {
-		th.Store.EXPECT().GetCategory("category_id_1").Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), "category_id_1").Return(&model.Category{
 			ID:       "category_id_1",
 			DeleteAt: 0,
 			UserID:   "user_id_1",
@@ -254,19 +256,19 @@ func TestDeleteCategory(t *testing.T) {
 			Type:     "custom",
 		}, nil)
 
-		th.Store.EXPECT().DeleteCategory("category_id_1", "user_id_1", "team_id_1").Return(nil)
+		th.Store.EXPECT().DeleteCategory(gomock.Any(), "category_id_1", "user_id_1", "team_id_1").Return(nil)
 
-		th.Store.EXPECT().GetCategory("category_id_1").Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), "category_id_1").Return(&model.Category{
 			DeleteAt: 10000,
 		}, nil)
 
-		deletedCategory, err := th.App.DeleteCategory("category_id_1", "user_id_1", "team_id_1")
+		deletedCategory, err := th.App.DeleteCategory(context.Background(), "category_id_1", "user_id_1", "team_id_1")
 		assert.NotNil(t, deletedCategory)
 		assert.NoError(t, err)
 	})
 
 	t.Run("trying to delete already deleted category", func(t *testing.T) {
-		th.Store.EXPECT().GetCategory("category_id_1").Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), "category_id_1").Return(&model.Category{
 			ID:       "category_id_1",
 			DeleteAt: 1000,
 			UserID:   "user_id_1",
@@ -274,13 +276,13 @@ func TestDeleteCategory(t *testing.T) {
 			Type:     "custom",
 		}, nil)
 
-		deletedCategory, err := th.App.DeleteCategory("category_id_1", "user_id_1", "team_id_1")
+		deletedCategory, err := th.App.DeleteCategory(context.Background(), "category_id_1", "user_id_1", "team_id_1")
 		assert.NotNil(t, deletedCategory)
 		assert.NoError(t, err)
 	})
 
 	t.Run("trying to delete system category", func(t *testing.T) {
-		th.Store.EXPECT().GetCategory("category_id_1").Return(&model.Category{
+		th.Store.EXPECT().GetCategory(gomock.Any(), "category_id_1").Return(&model.Category{
 			ID:       "category_id_1",
 			DeleteAt: 0,
 			UserID:   "user_id_1",
@@ -288,7 +290,7 @@ func TestDeleteCategory(t *testing.T) {
 			Type:     "system",
 		}, nil)
 
-		deletedCategory, err := th.App.DeleteCategory("category_id_1", "user_id_1", "team_id_1")
+		deletedCategory, err := th.App.DeleteCategory(context.Background(), "category_id_1", "user_id_1", "team_id_1")
 		assert.Nil(t, deletedCategory)
 		assert.Error(t, err)
 	})
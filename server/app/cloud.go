@@ -4,6 +4,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -19,22 +20,22 @@ var ErrNilPluginAPI = errors.New("server not running in plugin mode")
 
 // GetBoardsCloudLimits returns the limits of the server, and an empty
 // limits struct if there are no limits set.
-func (a *App) GetBoardsCloudLimits() (*model.BoardsCloudLimits, error) {
-	if !a.IsCloud() {
+func (a *App) GetBoardsCloudLimits(ctx context.Context) (*model.BoardsCloudLimits, error) {
+	if !a.IsCloud(ctx) {
 		return &model.BoardsCloudLimits{}, nil
 	}
 
-	productLimits, err := a.store.GetCloudLimits()
+	productLimits, err := a.store.GetCloudLimits(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	usedCards, err := a.store.GetUsedCardsCount()
+	usedCards, err := a.store.GetUsedCardsCount(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	cardLimitTimestamp, err := a.store.GetCardLimitTimestamp()
+	cardLimitTimestamp, err := a.store.GetCardLimitTimestamp(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -55,24 +56,24 @@ func (a *App) GetBoardsCloudLimits() (*model.BoardsCloudLimits, error) {
 	return boardsCloudLimits, nil
 }
 
-func (a *App) GetUsedCardsCount() (int, error) {
-	return a.store.GetUsedCardsCount()
+func (a *App) GetUsedCardsCount(ctx context.Context) (int, error) {
+	return a.store.GetUsedCardsCount(ctx)
 }
 
 // IsCloud returns true if the server is running as a plugin in a
 // cloud licensed server.
-func (a *App) IsCloud() bool {
-	return utils.IsCloudLicense(a.store.GetLicense())
+func (a *App) IsCloud(ctx context.Context) bool {
+	return utils.IsCloudLicense(a.store.GetLicense(ctx))
 }
 
 // IsCloudLimited returns true if the server is running in cloud mode
 // and the card limit has been set.
-func (a *App) IsCloudLimited() bool {
-	return a.CardLimit() != 0 && a.IsCloud()
+func (a *App) IsCloudLimited(ctx context.Context) bool {
+	return a.CardLimit() != 0 && a.IsCloud(ctx)
 }
 
 // SetCloudLimits sets the limits of the server.
-func (a *App) SetCloudLimits(limits *mmModel.ProductLimits) error {
+func (a *App) SetCloudLimits(ctx context.Context, limits *mmModel.ProductLimits) error {
 	oldCardLimit := a.CardLimit()
 
 	// if the limit object doesn't come complete, we assume limits are
@@ -89,7 +90,7 @@ func (a *App) SetCloudLimits(limits *mmModel.ProductLimits) error {
 			mlog.Int("cardLimit", cardLimit),
 		)
 		a.SetCardLimit(cardLimit)
-		return a.doUpdateCardLimitTimestamp()
+		return a.doUpdateCardLimitTimestamp(ctx)
 	}
 
 	a.logger.Info(
@@ -101,8 +102,8 @@ func (a *App) SetCloudLimits(limits *mmModel.ProductLimits) error {
 
 // doUpdateCardLimitTimestamp performs the update without running any
 // checks.
-func (a *App) doUpdateCardLimitTimestamp() error {
-	cardLimitTimestamp, err := a.store.UpdateCardLimitTimestamp(a.CardLimit())
+func (a *App) doUpdateCardLimitTimestamp(ctx context.Context) error {
+	cardLimitTimestamp, err := a.store.UpdateCardLimitTimestamp(ctx, a.CardLimit())
 	if err != nil {
 		return err
 	}
@@ -116,32 +117,41 @@ func (a *App) doUpdateCardLimitTimestamp() error {
 // with limits applied, and if that's true, recalculates the card
 // limit timestamp and propagates the new one to the connected
 // clients.
-func (a *App) UpdateCardLimitTimestamp() error {
-	if !a.IsCloudLimited() {
+func (a *App) UpdateCardLimitTimestamp(ctx context.Context) error {
+	if !a.IsCloudLimited(ctx) {
 		return nil
 	}
 
-	return a.doUpdateCardLimitTimestamp()
+	return a.doUpdateCardLimitTimestamp(ctx)
 }
 
 // getTemplateMapForBlocks gets all board ids for the blocks, and
 // builds a map with the board IDs as the key and their isTemplate
 // field as the value.
-func (a *App) getTemplateMapForBlocks(blocks []*model.Block) (map[string]bool, error) {
-	boardMap := map[string]*model.Board{}
+func (a *App) getTemplateMapForBlocks(ctx context.Context, blocks []*model.Block) (map[string]bool, error) {
+	boardIDs := []string{}
+	seen := map[string]bool{}
 	for _, block := range blocks {
-		if _, ok := boardMap[block.BoardID]; !ok {
-			board, err := a.store.GetBoard(block.BoardID)
-			if err != nil {
-				return nil, err
-			}
-			boardMap[block.BoardID] = board
+		if !seen[block.BoardID] {
+			seen[block.BoardID] = true
+			boardIDs = append(boardIDs, block.BoardID)
 		}
 	}
 
+	boards, err := a.store.GetBoards(ctx, boardIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	templateMap := map[string]bool{}
-	for boardID, board := range boardMap {
-		templateMap[boardID] = board.IsTemplate
+	for _, board := range boards {
+		templateMap[board.ID] = board.IsTemplate
+	}
+
+	for _, boardID := range boardIDs {
+		if _, ok := templateMap[boardID]; !ok {
+			return nil, model.NewErrNotFound("board ID=" + boardID)
+		}
 	}
 
 	return templateMap, nil
@@ -150,18 +160,18 @@ func (a *App) getTemplateMapForBlocks(blocks []*model.Block) (map[string]bool, e
 // ApplyCloudLimits takes a set of blocks and, if the server is cloud
 // limited, limits those that are outside of the card limit and don't
 // belong to a template.
-func (a *App) ApplyCloudLimits(blocks []*model.Block) ([]*model.Block, error) {
+func (a *App) ApplyCloudLimits(ctx context.Context, blocks []*model.Block) ([]*model.Block, error) {
 	// if there is no limit currently being applied, return
-	if !a.IsCloudLimited() {
+	if !a.IsCloudLimited(ctx) {
 		return blocks, nil
 	}
 
-	cardLimitTimestamp, err := a.store.GetCardLimitTimestamp()
+	cardLimitTimestamp, err := a.store.GetCardLimitTimestamp(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	templateMap, err := a.getTemplateMapForBlocks(blocks)
+	templateMap, err := a.getTemplateMapForBlocks(ctx, blocks)
 	if err != nil {
 		return nil, err
 	}
@@ -187,8 +197,8 @@ func (a *App) ApplyCloudLimits(blocks []*model.Block) ([]*model.Block, error) {
 
 // ContainsLimitedBlocks checks if a list of blocks contain any block
 // that references a limited card.
-func (a *App) ContainsLimitedBlocks(blocks []*model.Block) (bool, error) {
-	cardLimitTimestamp, err := a.store.GetCardLimitTimestamp()
+func (a *App) ContainsLimitedBlocks(ctx context.Context, blocks []*model.Block) (bool, error) {
+	cardLimitTimestamp, err := a.store.GetCardLimitTimestamp(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -226,14 +236,14 @@ func (a *App) ContainsLimitedBlocks(blocks []*model.Block) (bool, error) {
 	}
 
 	if len(cardIDs) > 0 {
-		fetchedCards, fErr := a.store.GetBlocksByIDs(cardIDs)
+		fetchedCards, fErr := a.store.GetBlocksByIDs(ctx, cardIDs)
 		if fErr != nil {
 			return false, fErr
 		}
 		cards = append(cards, fetchedCards...)
 	}
 
-	templateMap, err := a.getTemplateMapForBlocks(cards)
+	templateMap, err := a.getTemplateMapForBlocks(ctx, cards)
 	if err != nil {
 		return false, err
 	}
@@ -270,12 +280,12 @@ func (eb *errBoardNotFoundInTemplateMap) Error() string {
 	return fmt.Sprintf("board %q not found in template map", eb.id)
 }
 
-func (a *App) NotifyPortalAdminsUpgradeRequest(teamID string) error {
+func (a *App) NotifyPortalAdminsUpgradeRequest(ctx context.Context, teamID string) error {
 	if a.servicesAPI == nil {
 		return ErrNilPluginAPI
 	}
 
-	team, err := a.store.GetTeam(teamID)
+	team, err := a.store.GetTeam(ctx, teamID)
 	if err != nil {
 		return err
 	}
@@ -314,7 +324,7 @@ func (a *App) NotifyPortalAdminsUpgradeRequest(teamID string) error {
 			receiptUserIDs = append(receiptUserIDs, systemAdmin.Id)
 		}
 
-		if err := a.store.SendMessage(message, "custom_cloud_upgrade_nudge", receiptUserIDs); err != nil {
+		if err := a.store.SendMessage(ctx, message, "custom_cloud_upgrade_nudge", receiptUserIDs); err != nil {
 			return err
 		}
 	}
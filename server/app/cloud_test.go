@@ -4,6 +4,7 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
@@ -23,8 +24,8 @@ func TestIsCloud(t *testing.T) {
 		th, tearDown := SetupTestHelper(t)
 		defer tearDown()
 
-		th.Store.EXPECT().GetLicense().Return(nil)
-		require.False(t, th.App.IsCloud())
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(nil)
+		require.False(t, th.App.IsCloud(context.Background()))
 	})
 
 	t.Run("if it's running on plugin mode but the license is incomplete", func(t *testing.T) {
@@ -33,13 +34,13 @@ func TestIsCloud(t *testing.T) {
 
 		fakeLicense := &mmModel.License{}
 
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
-		require.False(t, th.App.IsCloud())
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
+		require.False(t, th.App.IsCloud(context.Background()))
 
 		fakeLicense = &mmModel.License{Features: &mmModel.Features{}}
 
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
-		require.False(t, th.App.IsCloud())
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
+		require.False(t, th.App.IsCloud(context.Background()))
 	})
 
 	t.Run("if it's running on plugin mode, with a non-cloud license", func(t *testing.T) {
@@ -50,8 +51,8 @@ func TestIsCloud(t *testing.T) {
 			Features: &mmModel.Features{Cloud: mmModel.NewBool(false)},
 		}
 
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
-		require.False(t, th.App.IsCloud())
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
+		require.False(t, th.App.IsCloud(context.Background()))
 	})
 
 	t.Run("if it's running on plugin mode with a cloud license", func(t *testing.T) {
@@ -62,8 +63,8 @@ func TestIsCloud(t *testing.T) {
 			Features: &mmModel.Features{Cloud: mmModel.NewBool(true)},
 		}
 
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
-		require.True(t, th.App.IsCloud())
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
+		require.True(t, th.App.IsCloud(context.Background()))
 	})
 }
 
@@ -73,7 +74,7 @@ func TestIsCloudLimited(t *testing.T) {
 		defer tearDown()
 
 		require.Zero(t, th.App.CardLimit())
-		require.False(t, th.App.IsCloudLimited())
+		require.False(t, th.App.IsCloudLimited(context.Background()))
 	})
 
 	t.Run("if the limit is set, it should be true", func(t *testing.T) {
@@ -83,10 +84,10 @@ func TestIsCloudLimited(t *testing.T) {
 		fakeLicense := &mmModel.License{
 			Features: &mmModel.Features{Cloud: mmModel.NewBool(true)},
 		}
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
 
 		th.App.SetCardLimit(5)
-		require.True(t, th.App.IsCloudLimited())
+		require.True(t, th.App.IsCloudLimited(context.Background()))
 	})
 }
 
@@ -98,7 +99,7 @@ func TestSetCloudLimits(t *testing.T) {
 
 			require.Zero(t, th.App.CardLimit())
 
-			require.NoError(t, th.App.SetCloudLimits(nil))
+			require.NoError(t, th.App.SetCloudLimits(context.Background(), nil))
 			require.Zero(t, th.App.CardLimit())
 		})
 
@@ -110,7 +111,7 @@ func TestSetCloudLimits(t *testing.T) {
 
 			limits := &mmModel.ProductLimits{}
 
-			require.NoError(t, th.App.SetCloudLimits(limits))
+			require.NoError(t, th.App.SetCloudLimits(context.Background(), limits))
 			require.Zero(t, th.App.CardLimit())
 		})
 
@@ -124,7 +125,7 @@ func TestSetCloudLimits(t *testing.T) {
 				Boards: &mmModel.BoardsLimits{},
 			}
 
-			require.NoError(t, th.App.SetCloudLimits(limits))
+			require.NoError(t, th.App.SetCloudLimits(context.Background(), limits))
 			require.Zero(t, th.App.CardLimit())
 		})
 	})
@@ -136,13 +137,13 @@ func TestSetCloudLimits(t *testing.T) {
 		require.Zero(t, th.App.CardLimit())
 
 		newCardLimitTimestamp := int64(27)
-		th.Store.EXPECT().UpdateCardLimitTimestamp(5).Return(newCardLimitTimestamp, nil)
+		th.Store.EXPECT().UpdateCardLimitTimestamp(gomock.Any(), 5).Return(newCardLimitTimestamp, nil)
 
 		limits := &mmModel.ProductLimits{
 			Boards: &mmModel.BoardsLimits{Cards: mmModel.NewInt(5)},
 		}
 
-		require.NoError(t, th.App.SetCloudLimits(limits))
+		require.NoError(t, th.App.SetCloudLimits(context.Background(), limits))
 		require.Equal(t, 5, th.App.CardLimit())
 	})
 
@@ -152,9 +153,9 @@ func TestSetCloudLimits(t *testing.T) {
 
 		th.App.SetCardLimit(20)
 
-		th.Store.EXPECT().UpdateCardLimitTimestamp(0)
+		th.Store.EXPECT().UpdateCardLimitTimestamp(gomock.Any(), 0)
 
-		require.NoError(t, th.App.SetCloudLimits(nil))
+		require.NoError(t, th.App.SetCloudLimits(context.Background(), nil))
 
 		require.Zero(t, th.App.CardLimit())
 	})
@@ -167,13 +168,13 @@ func TestSetCloudLimits(t *testing.T) {
 
 		// the call to update card limit timestamp should not happen
 		// as the limits didn't change
-		th.Store.EXPECT().UpdateCardLimitTimestamp(gomock.Any()).Times(0)
+		th.Store.EXPECT().UpdateCardLimitTimestamp(gomock.Any(), gomock.Any()).Times(0)
 
 		limits := &mmModel.ProductLimits{
 			Boards: &mmModel.BoardsLimits{Cards: mmModel.NewInt(20)},
 		}
 
-		require.NoError(t, th.App.SetCloudLimits(limits))
+		require.NoError(t, th.App.SetCloudLimits(context.Background(), limits))
 		require.Equal(t, 20, th.App.CardLimit())
 	})
 }
@@ -191,12 +192,12 @@ func TestUpdateCardLimitTimestamp(t *testing.T) {
 
 		// the license check will not be done as the limit not being
 		// set is enough for the method to return
-		th.Store.EXPECT().GetLicense().Times(0)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Times(0)
 		// no call to UpdateCardLimitTimestamp should happen as the
 		// method should shortcircuit if not cloud limited
-		th.Store.EXPECT().UpdateCardLimitTimestamp(gomock.Any()).Times(0)
+		th.Store.EXPECT().UpdateCardLimitTimestamp(gomock.Any(), gomock.Any()).Times(0)
 
-		require.NoError(t, th.App.UpdateCardLimitTimestamp())
+		require.NoError(t, th.App.UpdateCardLimitTimestamp(context.Background()))
 	})
 
 	t.Run("if the server is a cloud instance and the timestamp is set, it should run the update", func(t *testing.T) {
@@ -205,12 +206,12 @@ func TestUpdateCardLimitTimestamp(t *testing.T) {
 
 		th.App.SetCardLimit(5)
 
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
 		// no call to UpdateCardLimitTimestamp should happen as the
 		// method should shortcircuit if not cloud limited
-		th.Store.EXPECT().UpdateCardLimitTimestamp(5)
+		th.Store.EXPECT().UpdateCardLimitTimestamp(gomock.Any(), 5)
 
-		require.NoError(t, th.App.UpdateCardLimitTimestamp())
+		require.NoError(t, th.App.UpdateCardLimitTimestamp(context.Background()))
 	})
 }
 
@@ -253,15 +254,11 @@ func TestGetTemplateMapForBlocks(t *testing.T) {
 		}
 
 		th.Store.EXPECT().
-			GetBoard("board1").
-			Return(board1, nil).
-			Times(1)
-		th.Store.EXPECT().
-			GetBoard("board2").
-			Return(board2, nil).
+			GetBoards(gomock.Any(), []string{"board1", "board2"}).
+			Return([]*model.Board{board1, board2}, nil).
 			Times(1)
 
-		templateMap, err := th.App.getTemplateMapForBlocks(blocks)
+		templateMap, err := th.App.getTemplateMapForBlocks(context.Background(), blocks)
 		require.NoError(t, err)
 		require.Len(t, templateMap, 2)
 		require.Contains(t, templateMap, "board1")
@@ -290,11 +287,34 @@ func TestGetTemplateMapForBlocks(t *testing.T) {
 		}
 
 		th.Store.EXPECT().
-			GetBoard("board1").
+			GetBoards(gomock.Any(), []string{"board1", "board2"}).
+			Return([]*model.Board{{ID: "board2"}}, nil).
+			Times(1)
+
+		templateMap, err := th.App.getTemplateMapForBlocks(context.Background(), blocks)
+		require.True(t, model.IsErrNotFound(err))
+		require.Empty(t, templateMap)
+	})
+
+	t.Run("should fail if the boards can't be fetched", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		blocks := []*model.Block{
+			{
+				ID:       "card1",
+				Type:     model.TypeCard,
+				ParentID: "board1",
+				BoardID:  "board1",
+			},
+		}
+
+		th.Store.EXPECT().
+			GetBoards(gomock.Any(), []string{"board1"}).
 			Return(nil, sql.ErrNoRows).
 			Times(1)
 
-		templateMap, err := th.App.getTemplateMapForBlocks(blocks)
+		templateMap, err := th.App.getTemplateMapForBlocks(context.Background(), blocks)
 		require.ErrorIs(t, err, sql.ErrNoRows)
 		require.Empty(t, templateMap)
 	})
@@ -354,7 +374,7 @@ func TestApplyCloudLimits(t *testing.T) {
 
 		require.Zero(t, th.App.CardLimit())
 
-		newBlocks, err := th.App.ApplyCloudLimits(blocks)
+		newBlocks, err := th.App.ApplyCloudLimits(context.Background(), blocks)
 		require.NoError(t, err)
 		require.ElementsMatch(t, blocks, newBlocks)
 	})
@@ -375,12 +395,11 @@ func TestApplyCloudLimits(t *testing.T) {
 
 		th.App.SetCardLimit(5)
 
-		th.Store.EXPECT().GetLicense().Return(fakeLicense)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(150), nil)
-		th.Store.EXPECT().GetBoard("board1").Return(board1, nil).Times(1)
-		th.Store.EXPECT().GetBoard("template").Return(template, nil).Times(1)
+		th.Store.EXPECT().GetLicense(gomock.Any()).Return(fakeLicense)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(150), nil)
+		th.Store.EXPECT().GetBoards(gomock.Any(), gomock.InAnyOrder([]string{"board1", "template"})).Return([]*model.Board{board1, template}, nil)
 
-		newBlocks, err := th.App.ApplyCloudLimits(blocks)
+		newBlocks, err := th.App.ApplyCloudLimits(context.Background(), blocks)
 		require.NoError(t, err)
 
 		// should be limited as it's beyond the threshold
@@ -414,9 +433,9 @@ func TestContainsLimitedBlocks(t *testing.T) {
 			},
 		}
 
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(int64(0), nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(int64(0), nil)
 
-		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(blocks)
+		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(context.Background(), blocks)
 		require.NoError(t, err)
 		require.False(t, containsLimitedBlocks)
 	})
@@ -442,10 +461,10 @@ func TestContainsLimitedBlocks(t *testing.T) {
 
 		th.App.SetCardLimit(500)
 		cardLimitTimestamp := int64(150)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(cardLimitTimestamp, nil)
-		th.Store.EXPECT().GetBoard("board1").Return(board1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(cardLimitTimestamp, nil)
+		th.Store.EXPECT().GetBoards(gomock.Any(), []string{"board1"}).Return([]*model.Board{board1}, nil)
 
-		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(blocks)
+		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(context.Background(), blocks)
 		require.NoError(t, err)
 		require.True(t, containsLimitedBlocks)
 	})
@@ -472,10 +491,10 @@ func TestContainsLimitedBlocks(t *testing.T) {
 
 		th.App.SetCardLimit(500)
 		cardLimitTimestamp := int64(150)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(cardLimitTimestamp, nil)
-		th.Store.EXPECT().GetBoard("board1").Return(board1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(cardLimitTimestamp, nil)
+		th.Store.EXPECT().GetBoards(gomock.Any(), []string{"board1"}).Return([]*model.Board{board1}, nil)
 
-		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(blocks)
+		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(context.Background(), blocks)
 		require.NoError(t, err)
 		require.False(t, containsLimitedBlocks)
 	})
@@ -509,11 +528,11 @@ func TestContainsLimitedBlocks(t *testing.T) {
 
 		th.App.SetCardLimit(500)
 		cardLimitTimestamp := int64(150)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(cardLimitTimestamp, nil)
-		th.Store.EXPECT().GetBlocksByIDs([]string{"card1"}).Return([]*model.Block{card1}, nil)
-		th.Store.EXPECT().GetBoard("board1").Return(board1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(cardLimitTimestamp, nil)
+		th.Store.EXPECT().GetBlocksByIDs(gomock.Any(), []string{"card1"}).Return([]*model.Block{card1}, nil)
+		th.Store.EXPECT().GetBoards(gomock.Any(), []string{"board1"}).Return([]*model.Board{board1}, nil)
 
-		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(blocks)
+		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(context.Background(), blocks)
 		require.NoError(t, err)
 		require.True(t, containsLimitedBlocks)
 	})
@@ -547,11 +566,11 @@ func TestContainsLimitedBlocks(t *testing.T) {
 
 		th.App.SetCardLimit(500)
 		cardLimitTimestamp := int64(150)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(cardLimitTimestamp, nil)
-		th.Store.EXPECT().GetBlocksByIDs([]string{"card1"}).Return([]*model.Block{card1}, nil)
-		th.Store.EXPECT().GetBoard("board1").Return(board1, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(cardLimitTimestamp, nil)
+		th.Store.EXPECT().GetBlocksByIDs(gomock.Any(), []string{"card1"}).Return([]*model.Block{card1}, nil)
+		th.Store.EXPECT().GetBoards(gomock.Any(), []string{"board1"}).Return([]*model.Board{board1}, nil)
 
-		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(blocks)
+		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(context.Background(), blocks)
 		require.NoError(t, err)
 		require.False(t, containsLimitedBlocks)
 	})
@@ -632,13 +651,11 @@ func TestContainsLimitedBlocks(t *testing.T) {
 
 		th.App.SetCardLimit(500)
 		cardLimitTimestamp := int64(150)
-		th.Store.EXPECT().GetCardLimitTimestamp().Return(cardLimitTimestamp, nil)
-		th.Store.EXPECT().GetBlocksByIDs(gomock.InAnyOrder([]string{"card1", "card3"})).Return([]*model.Block{card1, card3}, nil)
-		th.Store.EXPECT().GetBoard("board1").Return(board1, nil)
-		th.Store.EXPECT().GetBoard("board2").Return(board2, nil)
-		th.Store.EXPECT().GetBoard("board3").Return(board3, nil)
+		th.Store.EXPECT().GetCardLimitTimestamp(gomock.Any()).Return(cardLimitTimestamp, nil)
+		th.Store.EXPECT().GetBlocksByIDs(gomock.Any(), gomock.InAnyOrder([]string{"card1", "card3"})).Return([]*model.Block{card1, card3}, nil)
+		th.Store.EXPECT().GetBoards(gomock.Any(), gomock.InAnyOrder([]string{"board1", "board2", "board3"})).Return([]*model.Board{board1, board2, board3}, nil)
 
-		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(blocks)
+		containsLimitedBlocks, err := th.App.ContainsLimitedBlocks(context.Background(), blocks)
 		require.NoError(t, err)
 		require.False(t, containsLimitedBlocks)
 	})
@@ -684,10 +701,10 @@ func TestNotifyPortalAdminsUpgradeRequest(t *testing.T) {
 			Title: "Dunder Mifflin",
 		}
 
-		th.Store.EXPECT().GetTeam("team-id-1").Return(team, nil)
-		th.Store.EXPECT().SendMessage(gomock.Any(), "custom_cloud_upgrade_nudge", gomock.Any()).Return(nil).Times(1)
+		th.Store.EXPECT().GetTeam(gomock.Any(), "team-id-1").Return(team, nil)
+		th.Store.EXPECT().SendMessage(gomock.Any(), gomock.Any(), "custom_cloud_upgrade_nudge", gomock.Any()).Return(nil).Times(1)
 
-		err := th.App.NotifyPortalAdminsUpgradeRequest("team-id-1")
+		err := th.App.NotifyPortalAdminsUpgradeRequest(context.Background(), "team-id-1")
 		assert.NoError(t, err)
 	})
 
@@ -709,9 +726,9 @@ func TestNotifyPortalAdminsUpgradeRequest(t *testing.T) {
 			Title: "Dunder Mifflin",
 		}
 
-		th.Store.EXPECT().GetTeam("team-id-1").Return(team, nil)
+		th.Store.EXPECT().GetTeam(gomock.Any(), "team-id-1").Return(team, nil)
 
-		err := th.App.NotifyPortalAdminsUpgradeRequest("team-id-1")
+		err := th.App.NotifyPortalAdminsUpgradeRequest(context.Background(), "team-id-1")
 		assert.NoError(t, err)
 	})
 
@@ -759,10 +776,10 @@ func TestNotifyPortalAdminsUpgradeRequest(t *testing.T) {
 			Title: "Dunder Mifflin",
 		}
 
-		th.Store.EXPECT().GetTeam("team-id-1").Return(team, nil)
-		th.Store.EXPECT().SendMessage(gomock.Any(), "custom_cloud_upgrade_nudge", gomock.Any()).Return(nil).Times(2)
+		th.Store.EXPECT().GetTeam(gomock.Any(), "team-id-1").Return(team, nil)
+		th.Store.EXPECT().SendMessage(gomock.Any(), gomock.Any(), "custom_cloud_upgrade_nudge", gomock.Any()).Return(nil).Times(2)
 
-		err := th.App.NotifyPortalAdminsUpgradeRequest("team-id-1")
+		err := th.App.NotifyPortalAdminsUpgradeRequest(context.Background(), "team-id-1")
 		assert.NoError(t, err)
 	})
 }
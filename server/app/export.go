@@ -2,6 +2,7 @@ package app
 
 import (
 	"archive/zip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,8 +17,8 @@ var (
 	newline = []byte{'\n'}
 )
 
-func (a *App) ExportArchive(w io.Writer, opt model.ExportArchiveOptions) (errs error) {
-	boards, err := a.getBoardsForArchive(opt.BoardIDs)
+func (a *App) ExportArchive(ctx context.Context, w io.Writer, opt model.ExportArchiveOptions) (errs error) {
+	boards, err := a.getBoardsForArchive(ctx, opt.BoardIDs)
 	if err != nil {
 		return err
 	}
@@ -39,7 +40,7 @@ func (a *App) ExportArchive(w io.Writer, opt model.ExportArchiveOptions) (errs e
 	}
 
 	for _, board := range boards {
-		if err := a.writeArchiveBoard(zw, board, opt); err != nil {
+		if err := a.writeArchiveBoard(ctx, zw, board, opt); err != nil {
 			merr.Append(fmt.Errorf("cannot export board %s: %w", board.ID, err))
 			return
 		}
@@ -67,7 +68,7 @@ func (a *App) writeArchiveVersion(zw *zip.Writer) error {
 }
 
 // writeArchiveBoard writes a single board to the archive in a zip directory.
-func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.ExportArchiveOptions) error {
+func (a *App) writeArchiveBoard(ctx context.Context, zw *zip.Writer, board model.Board, opt model.ExportArchiveOptions) error {
 	// create a directory per board
 	w, err := zw.Create(board.ID + "/board.jsonl")
 	if err != nil {
@@ -80,9 +81,9 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 	}
 
 	var files []string
-	// write the board's blocks
+	// write the board's blocks, archived cards included
 	// TODO: paginate this
-	blocks, err := a.GetBlocksForBoard(board.ID)
+	blocks, _, err := a.store.GetBlocksForBoard(ctx, board.ID, model.QueryBlocksOptions{IncludeArchived: true})
 	if err != nil {
 		return err
 	}
@@ -185,13 +186,22 @@ func (a *App) writeArchiveFile(zw *zip.Writer, filename string, boardID string,
 }
 
 // getBoardsForArchive fetches all the specified boards.
-func (a *App) getBoardsForArchive(boardIDs []string) ([]model.Board, error) {
-	boards := make([]model.Board, 0, len(boardIDs))
+func (a *App) getBoardsForArchive(ctx context.Context, boardIDs []string) ([]model.Board, error) {
+	found, err := a.GetBoards(ctx, boardIDs)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch boards: %w", err)
+	}
 
+	foundMap := make(map[string]*model.Board, len(found))
+	for _, b := range found {
+		foundMap[b.ID] = b
+	}
+
+	boards := make([]model.Board, 0, len(boardIDs))
 	for _, id := range boardIDs {
-		b, err := a.GetBoard(id)
-		if err != nil {
-			return nil, fmt.Errorf("could not fetch board %s: %w", id, err)
+		b, ok := foundMap[id]
+		if !ok {
+			return nil, fmt.Errorf("could not fetch board %s: %w", id, model.NewErrNotFound("board ID="+id))
 		}
 
 		boards = append(boards, *b)
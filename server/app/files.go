@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -19,7 +20,7 @@ const emptyString = "empty"
 var errEmptyFilename = errors.New("IsFileArchived: empty filename not allowed")
 var ErrFileNotFound = errors.New("file not found")
 
-func (a *App) SaveFile(reader io.Reader, teamID, rootID, filename string) (string, error) {
+func (a *App) SaveFile(ctx context.Context, reader io.Reader, teamID, rootID, filename string) (string, error) {
 	// NOTE: File extension includes the dot
 	fileExtension := strings.ToLower(filepath.Ext(filename))
 	if fileExtension == ".jpeg" {
@@ -59,7 +60,7 @@ func (a *App) SaveFile(reader io.Reader, teamID, rootID, filename string) (strin
 		Content:         "",
 		RemoteId:        nil,
 	}
-	err := a.store.SaveFileInfo(fileInfo)
+	err := a.store.SaveFileInfo(ctx, fileInfo)
 	if err != nil {
 		return "", err
 	}
@@ -67,7 +68,7 @@ func (a *App) SaveFile(reader io.Reader, teamID, rootID, filename string) (strin
 	return fullFilename, nil
 }
 
-func (a *App) GetFileInfo(filename string) (*mmModel.FileInfo, error) {
+func (a *App) GetFileInfo(ctx context.Context, filename string) (*mmModel.FileInfo, error) {
 	if len(filename) == 0 {
 		return nil, errEmptyFilename
 	}
@@ -77,7 +78,7 @@ func (a *App) GetFileInfo(filename string) (*mmModel.FileInfo, error) {
 	// will be the fileinfo id.
 	parts := strings.Split(filename, ".")
 	fileInfoID := parts[0][1:]
-	fileInfo, err := a.store.GetFileInfo(fileInfoID)
+	fileInfo, err := a.store.GetFileInfo(ctx, fileInfoID)
 	if err != nil {
 		return nil, err
 	}
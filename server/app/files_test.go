@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"io"
 	"os"
@@ -191,7 +192,7 @@ func TestSaveFile(t *testing.T) {
 		fileName := "temp-file-name.txt"
 		mockedFileBackend := &mocks.FileBackend{}
 		th.App.filesBackend = mockedFileBackend
-		th.Store.EXPECT().SaveFileInfo(gomock.Any()).Return(nil)
+		th.Store.EXPECT().SaveFileInfo(gomock.Any(), gomock.Any()).Return(nil)
 
 		writeFileFunc := func(reader io.Reader, path string) int64 {
 			paths := strings.Split(path, string(os.PathSeparator))
@@ -206,7 +207,7 @@ func TestSaveFile(t *testing.T) {
 		}
 
 		mockedFileBackend.On("WriteFile", mockedReadCloseSeek, mock.Anything).Return(writeFileFunc, writeFileErrorFunc)
-		actual, err := th.App.SaveFile(mockedReadCloseSeek, "1", testBoardID, fileName)
+		actual, err := th.App.SaveFile(context.Background(), mockedReadCloseSeek, "1", testBoardID, fileName)
 		assert.Equal(t, fileName, actual)
 		assert.Nil(t, err)
 	})
@@ -215,7 +216,7 @@ func TestSaveFile(t *testing.T) {
 		fileName := "temp-file-name.jpeg"
 		mockedFileBackend := &mocks.FileBackend{}
 		th.App.filesBackend = mockedFileBackend
-		th.Store.EXPECT().SaveFileInfo(gomock.Any()).Return(nil)
+		th.Store.EXPECT().SaveFileInfo(gomock.Any(), gomock.Any()).Return(nil)
 
 		writeFileFunc := func(reader io.Reader, path string) int64 {
 			paths := strings.Split(path, string(os.PathSeparator))
@@ -230,7 +231,7 @@ func TestSaveFile(t *testing.T) {
 		}
 
 		mockedFileBackend.On("WriteFile", mockedReadCloseSeek, mock.Anything).Return(writeFileFunc, writeFileErrorFunc)
-		actual, err := th.App.SaveFile(mockedReadCloseSeek, "1", "test-board-id", fileName)
+		actual, err := th.App.SaveFile(context.Background(), mockedReadCloseSeek, "1", "test-board-id", fileName)
 		assert.Nil(t, err)
 		assert.NotNil(t, actual)
 	})
@@ -254,7 +255,7 @@ func TestSaveFile(t *testing.T) {
 		}
 
 		mockedFileBackend.On("WriteFile", mockedReadCloseSeek, mock.Anything).Return(writeFileFunc, writeFileErrorFunc)
-		actual, err := th.App.SaveFile(mockedReadCloseSeek, "1", "test-board-id", fileName)
+		actual, err := th.App.SaveFile(context.Background(), mockedReadCloseSeek, "1", "test-board-id", fileName)
 		assert.Equal(t, "", actual)
 		assert.Equal(t, "unable to store the file in the files storage: Mocked File backend error", err.Error())
 	})
@@ -269,14 +270,14 @@ func TestGetFileInfo(t *testing.T) {
 			Archived: false,
 		}
 
-		th.Store.EXPECT().GetFileInfo("filename").Return(fileInfo, nil).Times(2)
+		th.Store.EXPECT().GetFileInfo(gomock.Any(), "filename").Return(fileInfo, nil).Times(2)
 
-		fetchedFileInfo, err := th.App.GetFileInfo("Afilename")
+		fetchedFileInfo, err := th.App.GetFileInfo(context.Background(), "Afilename")
 		assert.NoError(t, err)
 		assert.Equal(t, "file_info_id", fetchedFileInfo.Id)
 		assert.False(t, fetchedFileInfo.Archived)
 
-		fetchedFileInfo, err = th.App.GetFileInfo("Afilename.txt")
+		fetchedFileInfo, err = th.App.GetFileInfo(context.Background(), "Afilename.txt")
 		assert.NoError(t, err)
 		assert.Equal(t, "file_info_id", fetchedFileInfo.Id)
 		assert.False(t, fetchedFileInfo.Archived)
@@ -288,18 +289,18 @@ func TestGetFileInfo(t *testing.T) {
 			Archived: true,
 		}
 
-		th.Store.EXPECT().GetFileInfo("filename").Return(fileInfo, nil)
+		th.Store.EXPECT().GetFileInfo(gomock.Any(), "filename").Return(fileInfo, nil)
 
-		fetchedFileInfo, err := th.App.GetFileInfo("Afilename")
+		fetchedFileInfo, err := th.App.GetFileInfo(context.Background(), "Afilename")
 		assert.NoError(t, err)
 		assert.Equal(t, "file_info_id", fetchedFileInfo.Id)
 		assert.True(t, fetchedFileInfo.Archived)
 	})
 
 	t.Run("should return archived file infoerror", func(t *testing.T) {
-		th.Store.EXPECT().GetFileInfo("filename").Return(nil, errDummy)
+		th.Store.EXPECT().GetFileInfo(gomock.Any(), "filename").Return(nil, errDummy)
 
-		fetchedFileInfo, err := th.App.GetFileInfo("Afilename")
+		fetchedFileInfo, err := th.App.GetFileInfo(context.Background(), "Afilename")
 		assert.Error(t, err)
 		assert.Nil(t, fetchedFileInfo)
 	})
@@ -33,7 +33,7 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 	auth := auth.New(&cfg, store, nil)
 	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
 	sessionToken := "TESTTOKEN"
-	wsserver := ws.NewServer(auth, sessionToken, false, logger, store)
+	wsserver := ws.NewServer(auth, sessionToken, false, logger, store, nil, nil)
 	webhook := webhook.NewClient(&cfg, logger)
 	metricsService := metrics.NewMetrics(metrics.InstanceInfo{})
 
@@ -48,6 +48,9 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 	}
 	app2 := New(&cfg, wsserver, appServices)
 
+	// block and member changes look up the board webhooks to queue their events
+	store.EXPECT().GetWebhooksForBoard(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
 	tearDown := func() {
 		app2.Shutdown()
 		if logger != nil {
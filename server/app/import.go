@@ -3,6 +3,7 @@ package app
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"github.com/krolaw/zipstream"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/importer"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -22,6 +24,8 @@ import (
 const (
 	archiveVersion  = 2
 	legacyFileBegin = "{\"version\":1"
+
+	defaultImportBatchSize = 500
 )
 
 var (
@@ -33,16 +37,16 @@ var (
 //
 // Archives are ZIP files containing a `version.json` file and zero or more
 // directories, each containing a `board.jsonl` and zero or more image files.
-func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
+func (a *App) ImportArchive(ctx context.Context, r io.Reader, opt model.ImportArchiveOptions) error {
 	// peek at the first bytes to see if this is a legacy archive format
 	br := bufio.NewReader(r)
 	peek, err := br.Peek(len(legacyFileBegin))
 	if err == nil && string(peek) == legacyFileBegin {
 		a.logger.Debug("importing legacy archive")
-		_, errImport := a.ImportBoardJSONL(br, opt)
+		_, errImport := a.ImportBoardJSONL(ctx, br, opt)
 
 		go func() {
-			if err := a.UpdateCardLimitTimestamp(); err != nil {
+			if err := a.UpdateCardLimitTimestamp(context.Background()); err != nil {
 				a.logger.Error(
 					"UpdateCardLimitTimestamp failed after importing a legacy file",
 					mlog.Err(err),
@@ -81,7 +85,7 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 				return model.NewErrUnsupportedArchiveVersion(ver, archiveVersion)
 			}
 		case "board.jsonl":
-			boardID, err := a.ImportBoardJSONL(zr, opt)
+			boardID, err := a.ImportBoardJSONL(ctx, zr, opt)
 			if err != nil {
 				return fmt.Errorf("cannot import board %s: %w", dir, err)
 			}
@@ -110,7 +114,7 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 		)
 
 		go func() {
-			if err := a.UpdateCardLimitTimestamp(); err != nil {
+			if err := a.UpdateCardLimitTimestamp(context.Background()); err != nil {
 				a.logger.Error(
 					"UpdateCardLimitTimestamp failed after importing an archive",
 					mlog.Err(err),
@@ -120,9 +124,99 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 	}
 }
 
+// ImportExternal imports the export of another tool as a new board of the
+// team. The board is created along with the first batch of blocks, and
+// the remaining blocks are then inserted one batch at a time, reporting
+// the progress after each of them.
+func (a *App) ImportExternal(ctx context.Context, source importer.Source, r io.Reader, opt model.ImportExternalOptions) (*model.ImportExternalResult, error) {
+	boardsAndBlocks, err := importer.Import(source, r, opt.TeamID)
+	if err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	batchSize := opt.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+
+	board := boardsAndBlocks.Boards[0]
+	board.CreatedBy = opt.ModifiedBy
+	blocks := boardsAndBlocks.Blocks
+	for _, block := range blocks {
+		block.CreatedBy = opt.ModifiedBy
+		block.ModifiedBy = opt.ModifiedBy
+	}
+
+	progress := model.ImportProgress{BlocksTotal: len(blocks)}
+	for start := 0; start < len(blocks); start += batchSize {
+		end := start + batchSize
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+
+		if start == 0 {
+			bab := &model.BoardsAndBlocks{Boards: []*model.Board{board}, Blocks: blocks[:end]}
+			newBab, err := a.CreateBoardsAndBlocks(ctx, bab, opt.ModifiedBy, true)
+			if err != nil {
+				return nil, fmt.Errorf("error creating imported board: %w", err)
+			}
+			board = newBab.Boards[0]
+		} else if _, err := a.InsertBlocksAndNotify(ctx, blocks[start:end], opt.ModifiedBy, true); err != nil {
+			return nil, fmt.Errorf("error inserting imported blocks %d to %d: %w", start, end, err)
+		}
+
+		progress.BlocksImported = end
+		a.logger.Debug("import external - batch done",
+			mlog.String("board_id", board.ID),
+			mlog.Int("blocks_imported", progress.BlocksImported),
+			mlog.Int("blocks_total", progress.BlocksTotal),
+		)
+		if opt.Progress != nil {
+			opt.Progress(progress)
+		}
+	}
+
+	return &model.ImportExternalResult{
+		Board:          board,
+		BlocksImported: progress.BlocksImported,
+	}, nil
+}
+
 // ImportBoardJSONL imports a JSONL file containing blocks for one board. The resulting
 // board id is returned.
-func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (string, error) {
+func (a *App) ImportBoardJSONL(ctx context.Context, r io.Reader, opt model.ImportArchiveOptions) (string, error) {
+	boardsAndBlocks, err := a.readBoardJSONL(r, opt)
+	if err != nil {
+		return "", err
+	}
+
+	boardsAndBlocks, err = a.CreateBoardsAndBlocks(ctx, boardsAndBlocks, opt.ModifiedBy, false)
+	if err != nil {
+		return "", fmt.Errorf("error inserting archive blocks: %w", err)
+	}
+
+	// add user to all the new boards.
+	for _, board := range boardsAndBlocks.Boards {
+		boardMember := &model.BoardMember{
+			BoardID:     board.ID,
+			UserID:      opt.ModifiedBy,
+			SchemeAdmin: true,
+		}
+		if _, err := a.AddMemberToBoard(ctx, boardMember, opt.ModifiedBy); err != nil {
+			return "", fmt.Errorf("cannot add member to board: %w", err)
+		}
+	}
+
+	// find new board id
+	for _, board := range boardsAndBlocks.Boards {
+		return board.ID, nil
+	}
+	return "", fmt.Errorf("missing board in archive: %w", model.ErrInvalidBoardBlock)
+}
+
+// readBoardJSONL reads a JSONL file containing blocks for one board, and
+// returns its boards and blocks with new IDs, ready to be inserted.
+func (a *App) readBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*model.BoardsAndBlocks, error) {
 	// TODO: Stream this once `model.GenerateBlockIDs` can take a stream of blocks.
 	//       We don't want to load the whole file in memory, even though it's a single board.
 	boardsAndBlocks := &model.BoardsAndBlocks{
@@ -154,7 +248,7 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 			if !skip {
 				var archiveLine model.ArchiveLine
 				if err := json.Unmarshal(line, &archiveLine); err != nil {
-					return "", fmt.Errorf("error parsing archive line %d: %w", lineNum, err)
+					return nil, fmt.Errorf("error parsing archive line %d: %w", lineNum, err)
 				}
 
 				// first line must be a board
@@ -166,7 +260,7 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 				case "board":
 					var board model.Board
 					if err2 := json.Unmarshal(archiveLine.Data, &board); err2 != nil {
-						return "", fmt.Errorf("invalid board in archive line %d: %w", lineNum, err2)
+						return nil, fmt.Errorf("invalid board in archive line %d: %w", lineNum, err2)
 					}
 					board.ModifiedBy = userID
 					board.UpdateAt = now
@@ -177,27 +271,27 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 					// legacy archives encoded boards as blocks; we need to convert them to real boards.
 					var block *model.Block
 					if err2 := json.Unmarshal(archiveLine.Data, &block); err2 != nil {
-						return "", fmt.Errorf("invalid board block in archive line %d: %w", lineNum, err2)
+						return nil, fmt.Errorf("invalid board block in archive line %d: %w", lineNum, err2)
 					}
 					block.ModifiedBy = userID
 					block.UpdateAt = now
 					board, err := a.blockToBoard(block, opt)
 					if err != nil {
-						return "", fmt.Errorf("cannot convert archive line %d to block: %w", lineNum, err)
+						return nil, fmt.Errorf("cannot convert archive line %d to block: %w", lineNum, err)
 					}
 					boardsAndBlocks.Boards = append(boardsAndBlocks.Boards, board)
 					boardID = board.ID
 				case "block":
 					var block *model.Block
 					if err2 := json.Unmarshal(archiveLine.Data, &block); err2 != nil {
-						return "", fmt.Errorf("invalid block in archive line %d: %w", lineNum, err2)
+						return nil, fmt.Errorf("invalid block in archive line %d: %w", lineNum, err2)
 					}
 					block.ModifiedBy = userID
 					block.UpdateAt = now
 					block.BoardID = boardID
 					boardsAndBlocks.Blocks = append(boardsAndBlocks.Blocks, block)
 				default:
-					return "", model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
+					return nil, model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
 				}
 				firstLine = false
 			}
@@ -207,7 +301,7 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 			if errors.Is(errRead, io.EOF) {
 				break
 			}
-			return "", fmt.Errorf("error reading archive line %d: %w", lineNum, errRead)
+			return nil, fmt.Errorf("error reading archive line %d: %w", lineNum, errRead)
 		}
 		lineNum++
 	}
@@ -217,31 +311,52 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 	var err error
 	boardsAndBlocks, err = model.GenerateBoardsAndBlocksIDs(boardsAndBlocks, a.logger)
 	if err != nil {
-		return "", fmt.Errorf("error generating archive block IDs: %w", err)
+		return nil, fmt.Errorf("error generating archive block IDs: %w", err)
 	}
 
-	boardsAndBlocks, err = a.CreateBoardsAndBlocks(boardsAndBlocks, opt.ModifiedBy, false)
-	if err != nil {
-		return "", fmt.Errorf("error inserting archive blocks: %w", err)
+	for _, board := range boardsAndBlocks.Boards {
+		board.CreationSource = model.BoardCreationSourceImport
+		board.SourceID = ""
 	}
 
-	// add user to all the new boards.
+	boardsAndBlocks.Blocks = a.removeOrphanBlocks(boardsAndBlocks)
+
+	return boardsAndBlocks, nil
+}
+
+// removeOrphanBlocks returns the blocks of an archive leaving out the
+// ones whose parent isn't part of it, as the store rejects blocks with
+// missing parents.
+func (a *App) removeOrphanBlocks(boardsAndBlocks *model.BoardsAndBlocks) []*model.Block {
+	parents := map[string]bool{"": true}
 	for _, board := range boardsAndBlocks.Boards {
-		boardMember := &model.BoardMember{
-			BoardID:     board.ID,
-			UserID:      opt.ModifiedBy,
-			SchemeAdmin: true,
+		parents[board.ID] = true
+	}
+
+	blocks := boardsAndBlocks.Blocks
+	for {
+		for _, block := range blocks {
+			parents[block.ID] = true
 		}
-		if _, err := a.AddMemberToBoard(boardMember); err != nil {
-			return "", fmt.Errorf("cannot add member to board: %w", err)
+
+		kept := make([]*model.Block, 0, len(blocks))
+		for _, block := range blocks {
+			if parents[block.ParentID] {
+				kept = append(kept, block)
+				continue
+			}
+			a.logger.Debug("skipping archive block without parent",
+				mlog.String("blockID", block.ID),
+				mlog.String("parentID", block.ParentID),
+			)
+			delete(parents, block.ID)
 		}
-	}
 
-	// find new board id
-	for _, board := range boardsAndBlocks.Boards {
-		return board.ID, nil
+		if len(kept) == len(blocks) {
+			return kept
+		}
+		blocks = kept
 	}
-	return "", fmt.Errorf("missing board in archive: %w", model.ErrInvalidBoardBlock)
 }
 
 // fixBoardsandBlocks allows the caller of `ImportArchive` to modify or filters boards and blocks being
@@ -2,6 +2,7 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/utils"
@@ -45,20 +46,20 @@ func TestApp_ImportArchive(t *testing.T) {
 			ModifiedBy: "user",
 		}
 
-		th.Store.EXPECT().CreateBoardsAndBlocks(gomock.AssignableToTypeOf(&model.BoardsAndBlocks{}), "user").Return(babs, nil)
-		th.Store.EXPECT().GetMembersForBoard(board.ID).AnyTimes().Return([]*model.BoardMember{boardMember}, nil)
-		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
-		th.Store.EXPECT().GetMemberForBoard(board.ID, "user").Return(boardMember, nil)
-		th.Store.EXPECT().GetUserCategoryBoards("user", "test-team")
-		th.Store.EXPECT().CreateCategory(utils.Anything).Return(nil)
-		th.Store.EXPECT().GetCategory(utils.Anything).Return(&model.Category{
+		th.Store.EXPECT().CreateBoardsAndBlocks(gomock.Any(), gomock.AssignableToTypeOf(&model.BoardsAndBlocks{}), "user").Return(babs, nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), board.ID).AnyTimes().Return([]*model.BoardMember{boardMember}, nil)
+		th.Store.EXPECT().GetBoard(gomock.Any(), board.ID).Return(board, nil)
+		th.Store.EXPECT().GetMemberForBoard(gomock.Any(), board.ID, "user").Return(boardMember, nil)
+		th.Store.EXPECT().GetUserCategoryBoards(gomock.Any(), "user", "test-team")
+		th.Store.EXPECT().CreateCategory(gomock.Any(), utils.Anything).Return(nil)
+		th.Store.EXPECT().GetCategory(gomock.Any(), utils.Anything).Return(&model.Category{
 			ID:   "boards_category_id",
 			Name: "Boards",
 		}, nil)
-		th.Store.EXPECT().GetBoardsForUserAndTeam("user", "test-team", false).Return([]*model.Board{}, nil)
-		th.Store.EXPECT().AddUpdateCategoryBoard("user", "boards_category_id", utils.Anything).Return(nil)
+		th.Store.EXPECT().GetBoardsForUserAndTeam(gomock.Any(), "user", "test-team", false).Return([]*model.Board{}, nil)
+		th.Store.EXPECT().AddUpdateCategoryBoard(gomock.Any(), "user", "boards_category_id", utils.Anything).Return(nil)
 
-		err := th.App.ImportArchive(r, opts)
+		err := th.App.ImportArchive(context.Background(), r, opts)
 		require.NoError(t, err, "import archive should not fail")
 	})
 }
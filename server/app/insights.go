@@ -1,52 +1,54 @@
 package app
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/model"
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/pkg/errors"
 )
 
-func (a *App) GetTeamBoardsInsights(userID string, teamID string, opts *mmModel.InsightsOpts) (*model.BoardInsightsList, error) {
+func (a *App) GetTeamBoardsInsights(ctx context.Context, userID string, teamID string, opts *mmModel.InsightsOpts) (*model.BoardInsightsList, error) {
 	// check if server is properly licensed, and user is not a guest
-	userPermitted, err := insightPermissionGate(a, userID)
+	userPermitted, err := insightPermissionGate(ctx, a, userID)
 	if err != nil {
 		return nil, err
 	}
 	if !userPermitted {
 		return nil, errors.New("User isn't authorized to access insights.")
 	}
-	boardIDs, err := getUserBoards(userID, teamID, a)
+	boardIDs, err := getUserBoards(ctx, userID, teamID, a)
 	if err != nil {
 		return nil, err
 	}
-	return a.store.GetTeamBoardsInsights(teamID, userID, opts.StartUnixMilli, opts.Page*opts.PerPage, opts.PerPage, boardIDs)
+	return a.store.GetTeamBoardsInsights(ctx, teamID, userID, opts.StartUnixMilli, opts.Page*opts.PerPage, opts.PerPage, boardIDs)
 }
 
-func (a *App) GetUserBoardsInsights(userID string, teamID string, opts *mmModel.InsightsOpts) (*model.BoardInsightsList, error) {
+func (a *App) GetUserBoardsInsights(ctx context.Context, userID string, teamID string, opts *mmModel.InsightsOpts) (*model.BoardInsightsList, error) {
 	// check if server is properly licensed, and user is not a guest
-	userPermitted, err := insightPermissionGate(a, userID)
+	userPermitted, err := insightPermissionGate(ctx, a, userID)
 	if err != nil {
 		return nil, err
 	}
 	if !userPermitted {
 		return nil, errors.New("User isn't authorized to access insights.")
 	}
-	boardIDs, err := getUserBoards(userID, teamID, a)
+	boardIDs, err := getUserBoards(ctx, userID, teamID, a)
 	if err != nil {
 		return nil, err
 	}
-	return a.store.GetUserBoardsInsights(teamID, userID, opts.StartUnixMilli, opts.Page*opts.PerPage, opts.PerPage, boardIDs)
+	return a.store.GetUserBoardsInsights(ctx, teamID, userID, opts.StartUnixMilli, opts.Page*opts.PerPage, opts.PerPage, boardIDs)
 }
 
-func insightPermissionGate(a *App, userID string) (bool, error) {
+func insightPermissionGate(ctx context.Context, a *App, userID string) (bool, error) {
 	licenseError := errors.New("invalid license/authorization to use insights API")
 	guestError := errors.New("guests aren't authorized to use insights API")
-	lic := a.store.GetLicense()
+	lic := a.store.GetLicense(ctx)
 	if lic == nil {
 		a.logger.Debug("Deployment doesn't have a license")
 		return false, licenseError
 	}
-	user, err := a.store.GetUserByID(userID)
+	user, err := a.store.GetUserByID(ctx, userID)
 	if err != nil {
 		return false, err
 	}
@@ -59,13 +61,13 @@ func insightPermissionGate(a *App, userID string) (bool, error) {
 	return true, nil
 }
 
-func (a *App) GetUserTimezone(userID string) (string, error) {
-	return a.store.GetUserTimezone(userID)
+func (a *App) GetUserTimezone(ctx context.Context, userID string) (string, error) {
+	return a.store.GetUserTimezone(ctx, userID)
 }
 
-func getUserBoards(userID string, teamID string, a *App) ([]string, error) {
+func getUserBoards(ctx context.Context, userID string, teamID string, a *App) ([]string, error) {
 	// get boards accessible by user and filter boardIDs
-	boards, err := a.store.GetBoardsForUserAndTeam(userID, teamID, true)
+	boards, err := a.store.GetBoardsForUserAndTeam(ctx, userID, teamID, true)
 	if err != nil {
 		return nil, errors.New("error getting boards for user")
 	}
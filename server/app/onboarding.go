@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -22,9 +23,9 @@ var (
 	errCannotCreateBoard        = errors.New("new board wasn't created")
 )
 
-func (a *App) PrepareOnboardingTour(userID string, teamID string) (string, string, error) {
+func (a *App) PrepareOnboardingTour(ctx context.Context, userID string, teamID string) (string, string, error) {
 	// copy the welcome board into this workspace
-	boardID, err := a.createWelcomeBoard(userID, teamID)
+	boardID, err := a.createWelcomeBoard(ctx, userID, teamID)
 	if err != nil {
 		return "", "", err
 	}
@@ -37,15 +38,15 @@ func (a *App) PrepareOnboardingTour(userID string, teamID string) (string, strin
 			KeyOnboardingTourCategory: ValueTourCategoryOnboarding,
 		},
 	}
-	if _, err := a.store.PatchUserPreferences(userID, userPreferencesPatch); err != nil {
+	if _, err := a.store.PatchUserPreferences(ctx, userID, userPreferencesPatch); err != nil {
 		return "", "", err
 	}
 
 	return teamID, boardID, nil
 }
 
-func (a *App) getOnboardingBoardID() (string, error) {
-	boards, err := a.store.GetTemplateBoards(model.GlobalTeamID, "")
+func (a *App) getOnboardingBoardID(ctx context.Context) (string, error) {
+	boards, err := a.store.GetTemplateBoards(ctx, model.GlobalTeamID, "")
 	if err != nil {
 		return "", err
 	}
@@ -65,13 +66,13 @@ func (a *App) getOnboardingBoardID() (string, error) {
 	return onboardingBoardID, nil
 }
 
-func (a *App) createWelcomeBoard(userID, teamID string) (string, error) {
-	onboardingBoardID, err := a.getOnboardingBoardID()
+func (a *App) createWelcomeBoard(ctx context.Context, userID, teamID string) (string, error) {
+	onboardingBoardID, err := a.getOnboardingBoardID(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	bab, _, err := a.DuplicateBoard(onboardingBoardID, userID, teamID, false)
+	bab, _, err := a.DuplicateBoard(ctx, onboardingBoardID, userID, teamID, false)
 	if err != nil {
 		return "", err
 	}
@@ -88,7 +89,7 @@ func (a *App) createWelcomeBoard(userID, teamID string) (string, error) {
 		Type: &newType,
 	}
 
-	if _, err := a.PatchBoard(patch, bab.Boards[0].ID, userID); err != nil {
+	if _, err := a.PatchBoard(ctx, patch, bab.Boards[0].ID, userID); err != nil {
 		return "", err
 	}
 
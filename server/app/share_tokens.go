@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// CreateShareToken creates a share token for a board, protected by the
+// password if one is given. The card of a card scoped token must belong
+// to the board.
+func (a *App) CreateShareToken(ctx context.Context, shareToken *model.ShareToken, password string) error {
+	if shareToken.Scope == model.ShareTokenScopeCard {
+		card, err := a.store.GetBlock(ctx, shareToken.CardID)
+		if model.IsErrNotFound(err) {
+			return model.NewErrBadRequest("shared card not found")
+		}
+		if err != nil {
+			return err
+		}
+		if card.BoardID != shareToken.BoardID || card.Type != model.TypeCard {
+			return model.NewErrBadRequest("shared card not found")
+		}
+	}
+
+	shareToken.PasswordHash = ""
+	if password != "" {
+		shareToken.PasswordHash = auth.HashPassword(password)
+	}
+
+	return a.store.CreateShareToken(ctx, shareToken)
+}
+
+func (a *App) GetShareTokensForBoard(ctx context.Context, boardID string) ([]*model.ShareToken, error) {
+	return a.store.GetShareTokensForBoard(ctx, boardID)
+}
+
+func (a *App) DeleteShareToken(ctx context.Context, boardID, shareTokenID, userID string) error {
+	return a.store.DeleteShareToken(ctx, boardID, shareTokenID, userID)
+}
+
+// getValidShareToken returns the share token if it is not expired and the
+// password matches the one protecting it, if any.
+func (a *App) getValidShareToken(ctx context.Context, token, password string) (*model.ShareToken, error) {
+	shareToken, err := a.store.GetShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if shareToken.IsExpired(utils.GetMillis()) {
+		return nil, model.ErrSharingExpired
+	}
+
+	if shareToken.HasPassword && !auth.ComparePassword(shareToken.PasswordHash, password) {
+		return nil, model.NewErrUnauthorized("invalid share token password")
+	}
+
+	return shareToken, nil
+}
+
+// IsValidShareReadToken returns true if the token is a valid board scoped
+// share token of the board, which can be used as the read token of the
+// board.
+func (a *App) IsValidShareReadToken(ctx context.Context, boardID, token, password string) (bool, error) {
+	shareToken, err := a.getValidShareToken(ctx, token, password)
+	if model.IsErrNotFound(err) || model.IsErrUnauthorized(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return shareToken.BoardID == boardID && shareToken.Scope == model.ShareTokenScopeBoard, nil
+}
+
+// GetSharedContent returns the board and the blocks a share token gives
+// access to. The published snapshot of the board is served instead of
+// the live board if there is one.
+func (a *App) GetSharedContent(ctx context.Context, token, password string) (*model.SharedContent, error) {
+	shareToken, err := a.getValidShareToken(ctx, token, password)
+	if err != nil {
+		return nil, err
+	}
+
+	var board *model.Board
+	var blocks []*model.Block
+	snapshot, err := a.store.GetBoardSnapshot(ctx, shareToken.BoardID)
+	switch {
+	case err == nil:
+		board = snapshot.Board
+		blocks = snapshot.Blocks
+	case model.IsErrNotFound(err):
+		if board, err = a.store.GetBoard(ctx, shareToken.BoardID); err != nil {
+			return nil, err
+		}
+		if blocks, err = a.GetBlocksForBoard(ctx, shareToken.BoardID); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if shareToken.Scope == model.ShareTokenScopeCard {
+		blocks = cardAndContents(blocks, shareToken.CardID)
+		if len(blocks) == 0 {
+			return nil, model.NewErrNotFound("shared card ID=" + shareToken.CardID)
+		}
+	}
+
+	return &model.SharedContent{
+		Scope:  shareToken.Scope,
+		Board:  board,
+		Blocks: blocks,
+	}, nil
+}
+
+// cardAndContents returns the card with the given ID and its content
+// blocks, or nothing if the card is not among the blocks.
+func cardAndContents(blocks []*model.Block, cardID string) []*model.Block {
+	var card *model.Block
+	contents := []*model.Block{}
+	for _, block := range blocks {
+		switch {
+		case block.ID == cardID:
+			card = block
+		case block.ParentID == cardID:
+			contents = append(contents, block)
+		}
+	}
+
+	if card == nil {
+		return nil
+	}
+	return append([]*model.Block{card}, contents...)
+}
@@ -1,17 +1,63 @@
 package app
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/model"
 )
 
-func (a *App) GetSharing(boardID string) (*model.Sharing, error) {
-	sharing, err := a.store.GetSharing(boardID)
+func (a *App) GetSharing(ctx context.Context, boardID string) (*model.Sharing, error) {
+	sharing, err := a.store.GetSharing(ctx, boardID)
 	if err != nil {
 		return nil, err
 	}
 	return sharing, nil
 }
 
-func (a *App) UpsertSharing(sharing model.Sharing) error {
-	return a.store.UpsertSharing(sharing)
+func (a *App) UpsertSharing(ctx context.Context, sharing model.Sharing) error {
+	return a.store.UpsertSharing(ctx, sharing)
+}
+
+// GetSharingForBoards returns the enabled sharing of the given boards
+// keyed by board ID.
+func (a *App) GetSharingForBoards(ctx context.Context, boardIDs []string) (map[string]*model.Sharing, error) {
+	return a.store.GetSharingForBoards(ctx, boardIDs)
+}
+
+// RotateSharingToken replaces the sharing token of a board with a new
+// one, invalidating the links built with the previous token.
+func (a *App) RotateSharingToken(ctx context.Context, boardID, userID string) (*model.Sharing, error) {
+	return a.store.RotateSharingToken(ctx, boardID, userID)
+}
+
+// PublishBoardSnapshot stores a frozen copy of a board and its blocks,
+// which is served to public viewers instead of the live board.
+// Publishing again replaces the previous snapshot.
+func (a *App) PublishBoardSnapshot(ctx context.Context, boardID, userID string) (*model.BoardSnapshot, error) {
+	board, err := a.store.GetBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, _, err := a.store.GetBlocksForBoard(ctx, boardID, model.QueryBlocksOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &model.BoardSnapshot{
+		BoardID:   boardID,
+		Board:     board,
+		Blocks:    blocks,
+		CreatedBy: userID,
+	}
+	if err := a.store.UpsertBoardSnapshot(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetPublishedSnapshot returns the published snapshot of a board, or a
+// not found error if none was published.
+func (a *App) GetPublishedSnapshot(ctx context.Context, rootID string) (*model.BoardSnapshot, error) {
+	return a.store.GetBoardSnapshot(ctx, rootID)
 }
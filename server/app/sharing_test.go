@@ -1,9 +1,11 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/pkg/errors"
@@ -22,9 +24,9 @@ func TestGetSharing(t *testing.T) {
 			ModifiedBy: "otherid",
 			UpdateAt:   utils.GetMillis(),
 		}
-		th.Store.EXPECT().GetSharing("test-id").Return(want, nil)
+		th.Store.EXPECT().GetSharing(gomock.Any(), "test-id").Return(want, nil)
 
-		result, err := th.App.GetSharing("test-id")
+		result, err := th.App.GetSharing(context.Background(), "test-id")
 		require.NoError(t, err)
 
 		require.Equal(t, result, want)
@@ -32,11 +34,11 @@ func TestGetSharing(t *testing.T) {
 	})
 
 	t.Run("should fail to get a sharing", func(t *testing.T) {
-		th.Store.EXPECT().GetSharing("test-id").Return(
+		th.Store.EXPECT().GetSharing(gomock.Any(), "test-id").Return(
 			nil,
 			errors.New("sharing not found"),
 		)
-		result, err := th.App.GetSharing("test-id")
+		result, err := th.App.GetSharing(context.Background(), "test-id")
 
 		require.Nil(t, result)
 		require.Error(t, err)
@@ -44,11 +46,11 @@ func TestGetSharing(t *testing.T) {
 	})
 
 	t.Run("should return a not found error", func(t *testing.T) {
-		th.Store.EXPECT().GetSharing("test-id").Return(
+		th.Store.EXPECT().GetSharing(gomock.Any(), "test-id").Return(
 			nil,
 			sql.ErrNoRows,
 		)
-		result, err := th.App.GetSharing("test-id")
+		result, err := th.App.GetSharing(context.Background(), "test-id")
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, result)
@@ -68,15 +70,15 @@ func TestUpsertSharing(t *testing.T) {
 	}
 
 	t.Run("should success to upsert sharing", func(t *testing.T) {
-		th.Store.EXPECT().UpsertSharing(sharing).Return(nil)
-		err := th.App.UpsertSharing(sharing)
+		th.Store.EXPECT().UpsertSharing(gomock.Any(), sharing).Return(nil)
+		err := th.App.UpsertSharing(context.Background(), sharing)
 
 		require.NoError(t, err)
 	})
 
 	t.Run("should fail to upsert a sharing", func(t *testing.T) {
-		th.Store.EXPECT().UpsertSharing(sharing).Return(errors.New("sharing not found"))
-		err := th.App.UpsertSharing(sharing)
+		th.Store.EXPECT().UpsertSharing(gomock.Any(), sharing).Return(errors.New("sharing not found"))
+		err := th.App.UpsertSharing(context.Background(), sharing)
 
 		require.Error(t, err)
 		require.Equal(t, "sharing not found", err.Error())
@@ -1,14 +1,17 @@
 package app
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-func (a *App) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
-	sub, err := a.store.CreateSubscription(sub)
+func (a *App) CreateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	sub, err := a.store.CreateSubscription(ctx, sub)
 	if err != nil {
 		return nil, err
 	}
@@ -17,22 +20,38 @@ func (a *App) CreateSubscription(sub *model.Subscription) (*model.Subscription,
 	return sub, nil
 }
 
-func (a *App) DeleteSubscription(blockID string, subscriberID string) (*model.Subscription, error) {
-	sub, err := a.store.GetSubscription(blockID, subscriberID)
+// UpdateSubscription changes the events an existing subscription
+// notifies.
+func (a *App) UpdateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	sub, err := a.store.UpdateSubscription(ctx, sub)
 	if err != nil {
 		return nil, err
 	}
-	if err := a.store.DeleteSubscription(blockID, subscriberID); err != nil {
+	a.notifySubscriptionChanged(sub)
+
+	return sub, nil
+}
+
+func (a *App) DeleteSubscription(ctx context.Context, blockID string, subscriberID string) (*model.Subscription, error) {
+	sub, err := a.store.GetSubscription(ctx, blockID, subscriberID)
+	if err != nil {
 		return nil, err
 	}
+	count, err := a.store.DeleteSubscription(ctx, blockID, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, model.NewErrNotFound(fmt.Sprintf("subscription BlockID=%s SubscriberID=%s", blockID, subscriberID))
+	}
 	sub.DeleteAt = utils.GetMillis()
 	a.notifySubscriptionChanged(sub)
 
 	return sub, nil
 }
 
-func (a *App) GetSubscriptions(subscriberID string) ([]*model.Subscription, error) {
-	return a.store.GetSubscriptions(subscriberID)
+func (a *App) GetSubscriptions(ctx context.Context, subscriberID string) ([]*model.Subscription, error) {
+	return a.store.GetSubscriptions(ctx, subscriberID)
 }
 
 func (a *App) notifySubscriptionChanged(subscription *model.Subscription) {
@@ -40,7 +59,7 @@ func (a *App) notifySubscriptionChanged(subscription *model.Subscription) {
 		return
 	}
 
-	board, err := a.getBoardForBlock(subscription.BlockID)
+	board, err := a.getBoardForBlock(context.Background(), subscription.BlockID)
 	if err != nil {
 		a.logger.Error("Error notifying subscription change",
 			mlog.String("subscriber_id", subscription.SubscriberID),
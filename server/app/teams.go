@@ -1,27 +1,29 @@
 package app
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-func (a *App) GetRootTeam() (*model.Team, error) {
+func (a *App) GetRootTeam(ctx context.Context) (*model.Team, error) {
 	teamID := "0"
-	team, _ := a.store.GetTeam(teamID)
+	team, _ := a.store.GetTeam(ctx, teamID)
 	if team == nil {
 		team = &model.Team{
 			ID:          teamID,
 			SignupToken: utils.NewID(utils.IDTypeToken),
 		}
-		err := a.store.UpsertTeamSignupToken(*team)
+		err := a.store.UpsertTeamSignupToken(ctx, *team)
 		if err != nil {
 			a.logger.Error("Unable to initialize team", mlog.Err(err))
 			return nil, err
 		}
 
-		team, err = a.store.GetTeam(teamID)
+		team, err = a.store.GetTeam(ctx, teamID)
 		if err != nil {
 			a.logger.Error("Unable to get initialized team", mlog.Err(err))
 			return nil, err
@@ -33,8 +35,8 @@ func (a *App) GetRootTeam() (*model.Team, error) {
 	return team, nil
 }
 
-func (a *App) GetTeam(id string) (*model.Team, error) {
-	team, err := a.store.GetTeam(id)
+func (a *App) GetTeam(ctx context.Context, id string) (*model.Team, error) {
+	team, err := a.store.GetTeam(ctx, id)
 	if model.IsErrNotFound(err) {
 		return nil, nil
 	}
@@ -44,22 +46,40 @@ func (a *App) GetTeam(id string) (*model.Team, error) {
 	return team, nil
 }
 
-func (a *App) GetTeamsForUser(userID string) ([]*model.Team, error) {
-	return a.store.GetTeamsForUser(userID)
+func (a *App) GetTeamsForUser(ctx context.Context, userID string) ([]*model.Team, error) {
+	return a.store.GetTeamsForUser(ctx, userID)
 }
 
 func (a *App) DoesUserHaveTeamAccess(userID string, teamID string) bool {
 	return a.auth.DoesUserHaveTeamAccess(userID, teamID)
 }
 
-func (a *App) UpsertTeamSettings(team model.Team) error {
-	return a.store.UpsertTeamSettings(team)
+func (a *App) UpsertTeamSettings(ctx context.Context, team model.Team) error {
+	return a.store.UpsertTeamSettings(ctx, team)
+}
+
+func (a *App) UpsertTeamSignupToken(ctx context.Context, team model.Team) error {
+	return a.store.UpsertTeamSignupToken(ctx, team)
+}
+
+func (a *App) GetTeamBySignupToken(ctx context.Context, token string) (*model.Team, error) {
+	return a.store.GetTeamBySignupToken(ctx, token)
 }
 
-func (a *App) UpsertTeamSignupToken(team model.Team) error {
-	return a.store.UpsertTeamSignupToken(team)
+func (a *App) GetTeamCount(ctx context.Context) (int64, error) {
+	return a.store.GetTeamCount(ctx)
 }
 
-func (a *App) GetTeamCount() (int64, error) {
-	return a.store.GetTeamCount()
+// DeleteTeam permanently removes a team along with all its boards.
+func (a *App) DeleteTeam(ctx context.Context, teamID string) error {
+	count, err := a.store.DeleteTeam(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("Deleted team",
+		mlog.String("team_id", teamID),
+		mlog.Int("boards_removed", count),
+	)
+	return nil
 }
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"testing"
@@ -56,18 +57,18 @@ func TestGetRootTeam(t *testing.T) {
 		t.Run(tc.title, func(t *testing.T) {
 			th, tearDown := SetupTestHelper(t)
 			defer tearDown()
-			th.Store.EXPECT().GetTeam("0").Return(tc.teamToReturnBeforeUpsert, nil)
+			th.Store.EXPECT().GetTeam(gomock.Any(), "0").Return(tc.teamToReturnBeforeUpsert, nil)
 			if tc.teamToReturnBeforeUpsert == nil {
-				th.Store.EXPECT().UpsertTeamSignupToken(gomock.Any()).DoAndReturn(
-					func(arg0 model.Team) error {
+				th.Store.EXPECT().UpsertTeamSignupToken(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, arg0 model.Team) error {
 						if tc.isError {
 							return errUpsertSignupToken
 						}
-						th.Store.EXPECT().GetTeam("0").Return(tc.teamToReturnAfterUpsert, nil)
+						th.Store.EXPECT().GetTeam(gomock.Any(), "0").Return(tc.teamToReturnAfterUpsert, nil)
 						return nil
 					})
 			}
-			rootTeam, err := th.App.GetRootTeam()
+			rootTeam, err := th.App.GetRootTeam(context.Background())
 
 			if tc.isError {
 				require.Error(t, err)
@@ -110,13 +111,13 @@ func TestGetTeam(t *testing.T) {
 		},
 	}
 
-	th.Store.EXPECT().GetTeam("mock-team-id").Return(mockTeam, nil)
-	th.Store.EXPECT().GetTeam("invalid-team-id").Return(nil, errInvalidTeam)
-	th.Store.EXPECT().GetTeam("team-not-available-id").Return(nil, sql.ErrNoRows)
+	th.Store.EXPECT().GetTeam(gomock.Any(), "mock-team-id").Return(mockTeam, nil)
+	th.Store.EXPECT().GetTeam(gomock.Any(), "invalid-team-id").Return(nil, errInvalidTeam)
+	th.Store.EXPECT().GetTeam(gomock.Any(), "team-not-available-id").Return(nil, sql.ErrNoRows)
 	for _, tc := range testCases {
 		t.Run(tc.title, func(t *testing.T) {
 			t.Log(tc.title)
-			team, err := th.App.GetTeam(tc.teamID)
+			team, err := th.App.GetTeam(context.Background(), tc.teamID)
 
 			if tc.isError {
 				require.Error(t, err)
@@ -138,17 +139,21 @@ func TestTeamOperations(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
 
-	th.Store.EXPECT().UpsertTeamSettings(*mockTeam).Return(nil)
-	th.Store.EXPECT().UpsertTeamSignupToken(*mockTeam).Return(nil)
-	th.Store.EXPECT().GetTeamCount().Return(int64(10), nil)
+	th.Store.EXPECT().UpsertTeamSettings(gomock.Any(), *mockTeam).Return(nil)
+	th.Store.EXPECT().UpsertTeamSignupToken(gomock.Any(), *mockTeam).Return(nil)
+	th.Store.EXPECT().GetTeamCount(gomock.Any()).Return(int64(10), nil)
+	th.Store.EXPECT().DeleteTeam(gomock.Any(), mockTeam.ID).Return(2, nil)
 
-	errUpsertTeamSettings := th.App.UpsertTeamSettings(*mockTeam)
+	errUpsertTeamSettings := th.App.UpsertTeamSettings(context.Background(), *mockTeam)
 	assert.NoError(t, errUpsertTeamSettings)
 
-	errUpsertTeamSignupToken := th.App.UpsertTeamSignupToken(*mockTeam)
+	errUpsertTeamSignupToken := th.App.UpsertTeamSignupToken(context.Background(), *mockTeam)
 	assert.NoError(t, errUpsertTeamSignupToken)
 
-	count, errGetTeamCount := th.App.GetTeamCount()
+	count, errGetTeamCount := th.App.GetTeamCount(context.Background())
 	assert.NoError(t, errGetTeamCount)
 	assert.Equal(t, int64(10), count)
+
+	errDeleteTeam := th.App.DeleteTeam(context.Background(), mockTeam.ID)
+	assert.NoError(t, errDeleteTeam)
 }
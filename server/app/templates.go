@@ -2,9 +2,16 @@ package app
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"path"
+	"path/filepath"
 	"strings"
 
+	"github.com/krolaw/zipstream"
+
 	"github.com/mattermost/focalboard/server/assets"
 	"github.com/mattermost/focalboard/server/model"
 
@@ -15,14 +22,14 @@ const (
 	defaultTemplateVersion = 4
 )
 
-func (a *App) InitTemplates() error {
-	_, err := a.initializeTemplates()
+func (a *App) InitTemplates(ctx context.Context) error {
+	_, err := a.initializeTemplates(ctx)
 	return err
 }
 
 // initializeTemplates imports default templates if the boards table is empty.
-func (a *App) initializeTemplates() (bool, error) {
-	boards, err := a.store.GetTemplateBoards(model.GlobalTeamID, "")
+func (a *App) initializeTemplates(ctx context.Context) (bool, error) {
+	boards, err := a.store.GetTemplateBoards(ctx, model.GlobalTeamID, "")
 	if err != nil {
 		return false, fmt.Errorf("cannot initialize templates: %w", err)
 	}
@@ -40,8 +47,15 @@ func (a *App) initializeTemplates() (bool, error) {
 		mlog.Int("size", len(assets.DefaultTemplatesArchive)),
 	)
 
+	if len(boards) != 0 {
+		if err = a.UpgradeDefaultTemplates(ctx, model.GlobalTeamID, defaultTemplateVersion); err != nil {
+			return false, fmt.Errorf("cannot upgrade template boards: %w", err)
+		}
+		return true, nil
+	}
+
 	// Remove in case of newer Templates
-	if err = a.store.RemoveDefaultTemplates(boards); err != nil {
+	if err = a.store.RemoveDefaultTemplates(ctx, boards); err != nil {
 		return false, fmt.Errorf("cannot remove old template boards: %w", err)
 	}
 
@@ -53,12 +67,112 @@ func (a *App) initializeTemplates() (bool, error) {
 		BlockModifier: fixTemplateBlock,
 		BoardModifier: fixTemplateBoard,
 	}
-	if err = a.ImportArchive(r, opt); err != nil {
+	if err = a.ImportArchive(ctx, r, opt); err != nil {
 		return false, fmt.Errorf("cannot initialize global templates for team %s: %w", model.GlobalTeamID, err)
 	}
 	return true, nil
 }
 
+// UpgradeDefaultTemplates replaces the default templates of a team with a
+// version below toVersion by the templates bundled with the server.
+// Default templates marked as customized are kept, and upgrading again
+// to the same version does nothing.
+func (a *App) UpgradeDefaultTemplates(ctx context.Context, teamID string, toVersion int) error {
+	boards, err := a.store.GetTemplateBoards(ctx, teamID, "")
+	if err != nil {
+		return fmt.Errorf("cannot fetch template boards for team %s: %w", teamID, err)
+	}
+
+	if !hasOutdatedDefaultTemplates(boards, toVersion) {
+		a.logger.Debug("Default templates are up to date, skipping upgrade",
+			mlog.String("teamID", teamID),
+			mlog.Int("version", toVersion),
+		)
+		return nil
+	}
+
+	opt := model.ImportArchiveOptions{
+		TeamID:        teamID,
+		ModifiedBy:    model.SystemUserID,
+		BlockModifier: fixTemplateBlock,
+		BoardModifier: fixTemplateBoard,
+	}
+	templates, err := a.readTemplatesArchive(bytes.NewReader(assets.DefaultTemplatesArchive), opt)
+	if err != nil {
+		return fmt.Errorf("cannot read default templates: %w", err)
+	}
+
+	return a.store.UpgradeDefaultTemplates(ctx, teamID, toVersion, templates)
+}
+
+// hasOutdatedDefaultTemplates returns true if any of the boards is a
+// default template that is not customized and has a version below
+// toVersion.
+func hasOutdatedDefaultTemplates(boards []*model.Board, toVersion int) bool {
+	for _, board := range boards {
+		if board.CreatedBy != model.SystemUserID || board.IsCustomizedTemplate() {
+			continue
+		}
+		if board.TemplateVersion < toVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// readTemplatesArchive reads the boards and blocks of a templates archive
+// without inserting them. The files of the archive are saved as they are
+// found, so the templates can reference them once inserted.
+func (a *App) readTemplatesArchive(r io.Reader, opt model.ImportArchiveOptions) (*model.BoardsAndBlocks, error) {
+	templates := &model.BoardsAndBlocks{}
+	boardMap := make(map[string]string) // maps old board ids to new
+
+	zr := zipstream.NewReader(r)
+	for {
+		hdr, err := zr.Next()
+		if errors.Is(err, io.EOF) {
+			return templates, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		dir, filename := filepath.Split(hdr.Name)
+		dir = path.Clean(dir)
+
+		switch filename {
+		case "version.json":
+			ver, errVer := parseVersionFile(zr)
+			if errVer != nil {
+				return nil, errVer
+			}
+			if ver != archiveVersion {
+				return nil, model.NewErrUnsupportedArchiveVersion(ver, archiveVersion)
+			}
+		case "board.jsonl":
+			bab, err := a.readBoardJSONL(zr, opt)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read template %s: %w", dir, err)
+			}
+			if len(bab.Boards) == 0 {
+				continue
+			}
+			boardMap[dir] = bab.Boards[0].ID
+			templates.Boards = append(templates.Boards, bab.Boards...)
+			templates.Blocks = append(templates.Blocks, bab.Blocks...)
+		default:
+			boardID, ok := boardMap[dir]
+			if !ok {
+				continue
+			}
+			filePath := filepath.Join(opt.TeamID, boardID, filename)
+			if _, err := a.filesBackend.WriteFile(zr, filePath); err != nil {
+				return nil, fmt.Errorf("cannot save file %s for template %s: %w", filename, dir, err)
+			}
+		}
+	}
+}
+
 // isInitializationNeeded returns true if the blocks table contains no default templates,
 // or contains at least one default template with an old version number.
 func (a *App) isInitializationNeeded(boards []*model.Board) (bool, string) {
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -43,16 +44,16 @@ func TestApp_initializeTemplates(t *testing.T) {
 		th, tearDown := SetupTestHelper(t)
 		defer tearDown()
 
-		th.Store.EXPECT().GetTemplateBoards(model.GlobalTeamID, "").Return([]*model.Board{}, nil)
-		th.Store.EXPECT().RemoveDefaultTemplates([]*model.Board{}).Return(nil)
-		th.Store.EXPECT().CreateBoardsAndBlocks(gomock.Any(), gomock.Any()).AnyTimes().Return(boardsAndBlocks, nil)
-		th.Store.EXPECT().GetMembersForBoard(board.ID).AnyTimes().Return([]*model.BoardMember{}, nil)
-		th.Store.EXPECT().GetBoard(board.ID).AnyTimes().Return(board, nil)
-		th.Store.EXPECT().GetMemberForBoard(gomock.Any(), gomock.Any()).AnyTimes().Return(boardMember, nil)
+		th.Store.EXPECT().GetTemplateBoards(gomock.Any(), model.GlobalTeamID, "").Return([]*model.Board{}, nil)
+		th.Store.EXPECT().RemoveDefaultTemplates(gomock.Any(), []*model.Board{}).Return(nil)
+		th.Store.EXPECT().CreateBoardsAndBlocks(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(boardsAndBlocks, nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any(), board.ID).AnyTimes().Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetBoard(gomock.Any(), board.ID).AnyTimes().Return(board, nil)
+		th.Store.EXPECT().GetMemberForBoard(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(boardMember, nil)
 
 		th.FilesBackend.On("WriteFile", mock.Anything, mock.Anything).Return(int64(1), nil)
 
-		done, err := th.App.initializeTemplates()
+		done, err := th.App.initializeTemplates(context.Background())
 		require.NoError(t, err, "initializeTemplates should not error")
 		require.True(t, done, "initialization was needed")
 	})
@@ -61,10 +62,59 @@ func TestApp_initializeTemplates(t *testing.T) {
 		th, tearDown := SetupTestHelper(t)
 		defer tearDown()
 
-		th.Store.EXPECT().GetTemplateBoards(model.GlobalTeamID, "").Return([]*model.Board{board}, nil)
+		th.Store.EXPECT().GetTemplateBoards(gomock.Any(), model.GlobalTeamID, "").Return([]*model.Board{board}, nil)
 
-		done, err := th.App.initializeTemplates()
+		done, err := th.App.initializeTemplates(context.Background())
 		require.NoError(t, err, "initializeTemplates should not error")
 		require.False(t, done, "initialization was not needed")
 	})
 }
+
+func TestApp_UpgradeDefaultTemplates(t *testing.T) {
+	defaultTemplate := func(version int, customized bool) *model.Board {
+		return &model.Board{
+			ID:              utils.NewID(utils.IDTypeBoard),
+			TeamID:          model.GlobalTeamID,
+			Type:            model.BoardTypeOpen,
+			CreatedBy:       model.SystemUserID,
+			IsTemplate:      true,
+			TemplateVersion: version,
+			Properties:      map[string]interface{}{model.TemplateCustomizedProperty: customized},
+		}
+	}
+
+	t.Run("Upgrade outdated templates", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetTemplateBoards(gomock.Any(), model.GlobalTeamID, "").Return([]*model.Board{defaultTemplate(defaultTemplateVersion-1, false)}, nil)
+		th.Store.EXPECT().UpgradeDefaultTemplates(gomock.Any(), model.GlobalTeamID, defaultTemplateVersion, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ string, _ int, templates *model.BoardsAndBlocks) error {
+				require.NotEmpty(t, templates.Boards)
+				require.NotEmpty(t, templates.Blocks)
+				for _, board := range templates.Boards {
+					require.True(t, board.IsTemplate)
+				}
+				return nil
+			})
+
+		th.FilesBackend.On("WriteFile", mock.Anything, mock.Anything).Return(int64(1), nil)
+
+		err := th.App.UpgradeDefaultTemplates(context.Background(), model.GlobalTeamID, defaultTemplateVersion)
+		require.NoError(t, err)
+	})
+
+	t.Run("Skip up to date and customized templates", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		boards := []*model.Board{
+			defaultTemplate(defaultTemplateVersion, false),
+			defaultTemplate(defaultTemplateVersion-1, true),
+		}
+		th.Store.EXPECT().GetTemplateBoards(gomock.Any(), model.GlobalTeamID, "").Return(boards, nil)
+
+		err := th.App.UpgradeDefaultTemplates(context.Background(), model.GlobalTeamID, defaultTemplateVersion)
+		require.NoError(t, err)
+	})
+}
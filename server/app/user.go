@@ -1,20 +1,22 @@
 package app
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/model"
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 )
 
-func (a *App) GetTeamUsers(teamID string, asGuestID string) ([]*model.User, error) {
-	return a.store.GetUsersByTeam(teamID, asGuestID)
+func (a *App) GetTeamUsers(ctx context.Context, teamID string, asGuestID string) ([]*model.User, error) {
+	return a.store.GetUsersByTeam(ctx, teamID, asGuestID)
 }
 
-func (a *App) SearchTeamUsers(teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
-	return a.store.SearchUsersByTeam(teamID, searchQuery, asGuestID, excludeBots)
+func (a *App) SearchTeamUsers(ctx context.Context, teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
+	return a.store.SearchUsersByTeam(ctx, teamID, searchQuery, asGuestID, excludeBots)
 }
 
-func (a *App) UpdateUserConfig(userID string, patch model.UserPreferencesPatch) ([]mmModel.Preference, error) {
-	updatedPreferences, err := a.store.PatchUserPreferences(userID, patch)
+func (a *App) UpdateUserConfig(ctx context.Context, userID string, patch model.UserPreferencesPatch) ([]mmModel.Preference, error) {
+	updatedPreferences, err := a.store.PatchUserPreferences(ctx, userID, patch)
 	if err != nil {
 		return nil, err
 	}
@@ -22,25 +24,25 @@ func (a *App) UpdateUserConfig(userID string, patch model.UserPreferencesPatch)
 	return updatedPreferences, nil
 }
 
-func (a *App) GetUserPreferences(userID string) ([]mmModel.Preference, error) {
-	return a.store.GetUserPreferences(userID)
+func (a *App) GetUserPreferences(ctx context.Context, userID string) ([]mmModel.Preference, error) {
+	return a.store.GetUserPreferences(ctx, userID)
 }
 
-func (a *App) UserIsGuest(userID string) (bool, error) {
-	user, err := a.store.GetUserByID(userID)
+func (a *App) UserIsGuest(ctx context.Context, userID string) (bool, error) {
+	user, err := a.store.GetUserByID(ctx, userID)
 	if err != nil {
 		return false, err
 	}
 	return user.IsGuest, nil
 }
 
-func (a *App) CanSeeUser(seerUser string, seenUser string) (bool, error) {
-	isGuest, err := a.UserIsGuest(seerUser)
+func (a *App) CanSeeUser(ctx context.Context, seerUser string, seenUser string) (bool, error) {
+	isGuest, err := a.UserIsGuest(ctx, seerUser)
 	if err != nil {
 		return false, err
 	}
 	if isGuest {
-		hasSharedChannels, err := a.store.CanSeeUser(seerUser, seenUser)
+		hasSharedChannels, err := a.store.CanSeeUser(ctx, seerUser, seenUser)
 		if err != nil {
 			return false, err
 		}
@@ -49,10 +51,10 @@ func (a *App) CanSeeUser(seerUser string, seenUser string) (bool, error) {
 	return true, nil
 }
 
-func (a *App) SearchUserChannels(teamID string, userID string, query string) ([]*mmModel.Channel, error) {
-	return a.store.SearchUserChannels(teamID, userID, query)
+func (a *App) SearchUserChannels(ctx context.Context, teamID string, userID string, query string) ([]*mmModel.Channel, error) {
+	return a.store.SearchUserChannels(ctx, teamID, userID, query)
 }
 
-func (a *App) GetChannel(teamID string, channelID string) (*mmModel.Channel, error) {
-	return a.store.GetChannel(teamID, channelID)
+func (a *App) GetChannel(ctx context.Context, teamID string, channelID string) (*mmModel.Channel, error) {
+	return a.store.GetChannel(ctx, teamID, channelID)
 }
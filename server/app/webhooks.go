@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const webhookDeliveryBatchSize = 100
+
+func (a *App) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	return a.store.CreateWebhook(ctx, webhook)
+}
+
+func (a *App) GetWebhook(ctx context.Context, webhookID string) (*model.Webhook, error) {
+	return a.store.GetWebhook(ctx, webhookID)
+}
+
+func (a *App) GetWebhooksForBoard(ctx context.Context, boardID string) ([]*model.Webhook, error) {
+	return a.store.GetWebhooksForBoard(ctx, boardID)
+}
+
+func (a *App) UpdateWebhook(ctx context.Context, webhook *model.Webhook, userID string) error {
+	return a.store.UpdateWebhook(ctx, webhook, userID)
+}
+
+func (a *App) DeleteWebhook(ctx context.Context, webhookID, userID string) error {
+	return a.store.DeleteWebhook(ctx, webhookID, userID)
+}
+
+// enqueueWebhookEvent queues a delivery of the event for each webhook of
+// the board that receives its type. The deliveries are posted later by
+// DeliverWebhooks. It runs once the change is saved, so it doesn't use
+// the context of the request that made it.
+func (a *App) enqueueWebhookEvent(boardID, eventType, userID string, data interface{}) {
+	webhooks, err := a.store.GetWebhooksForBoard(context.Background(), boardID)
+	if err != nil {
+		a.logger.Error("Error fetching webhooks for board", mlog.String("board_id", boardID), mlog.Err(err))
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.WantsEvent(eventType) {
+			continue
+		}
+
+		evt := model.WebhookEvent{
+			ID:       utils.NewID(utils.IDTypeNone),
+			Type:     eventType,
+			BoardID:  boardID,
+			UserID:   userID,
+			CreateAt: utils.GetMillis(),
+			Data:     data,
+		}
+		payload, jsonErr := json.Marshal(evt)
+		if jsonErr != nil {
+			a.logger.Error("Error encoding webhook event", mlog.String("webhook_id", webhook.ID), mlog.Err(jsonErr))
+			return
+		}
+
+		delivery := &model.WebhookDelivery{
+			ID:        evt.ID,
+			WebhookID: webhook.ID,
+			BoardID:   boardID,
+			EventType: eventType,
+			Payload:   string(payload),
+		}
+		if err = a.store.RecordWebhookDelivery(context.Background(), delivery); err != nil {
+			a.logger.Error("Error queuing webhook delivery", mlog.String("webhook_id", webhook.ID), mlog.Err(err))
+		}
+	}
+}
+
+// DeliverWebhooks posts the pending webhook deliveries and returns how
+// many of them were accepted. Deliveries that fail stay pending and are
+// retried with an increasing delay, while the ones whose webhook no
+// longer exists are dropped.
+func (a *App) DeliverWebhooks(ctx context.Context) (int, error) {
+	if a.webhook == nil {
+		return 0, nil
+	}
+
+	deliveries, err := a.store.GetPendingWebhookDeliveries(ctx, webhookDeliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		webhook, err := a.store.GetWebhook(ctx, delivery.WebhookID)
+		if model.IsErrNotFound(err) {
+			if err = a.store.MarkWebhookDelivered(ctx, delivery.ID, 0); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+		if err != nil {
+			return delivered, err
+		}
+
+		status, deliverErr := a.webhook.Deliver(webhook, delivery)
+		if deliverErr != nil || status < 200 || status >= 300 {
+			a.logger.Warn("Webhook delivery failed",
+				mlog.String("webhook_id", webhook.ID),
+				mlog.String("delivery_id", delivery.ID),
+				mlog.Int("attempts", delivery.Attempts),
+				mlog.Int("status", status),
+				mlog.Err(deliverErr),
+			)
+			continue
+		}
+
+		if err = a.store.MarkWebhookDelivered(ctx, delivery.ID, status); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+	return delivered, nil
+}
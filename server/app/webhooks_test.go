@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliverWebhooks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	var signature string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(model.WebhookSignatureHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	webhook := &model.Webhook{ID: "webhook-id", BoardID: "board-id", URL: ts.URL, Secret: "secret"}
+
+	t.Run("should deliver and sign the pending deliveries", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{ID: "delivery-id", WebhookID: webhook.ID, Payload: "{}"}
+		th.Store.EXPECT().GetPendingWebhookDeliveries(gomock.Any(), webhookDeliveryBatchSize).Return([]*model.WebhookDelivery{delivery}, nil)
+		th.Store.EXPECT().GetWebhook(gomock.Any(), webhook.ID).Return(webhook, nil)
+		th.Store.EXPECT().MarkWebhookDelivered(gomock.Any(), delivery.ID, http.StatusOK).Return(nil)
+
+		delivered, err := th.App.DeliverWebhooks(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, delivered)
+		require.Equal(t, model.SignWebhookPayload("secret", []byte("{}")), signature)
+	})
+
+	t.Run("should drop the deliveries of deleted webhooks", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{ID: "delivery-id", WebhookID: "deleted-id", Payload: "{}"}
+		th.Store.EXPECT().GetPendingWebhookDeliveries(gomock.Any(), webhookDeliveryBatchSize).Return([]*model.WebhookDelivery{delivery}, nil)
+		th.Store.EXPECT().GetWebhook(gomock.Any(), "deleted-id").Return(nil, model.NewErrNotFound("webhook ID=deleted-id"))
+		th.Store.EXPECT().MarkWebhookDelivered(gomock.Any(), delivery.ID, 0).Return(nil)
+
+		delivered, err := th.App.DeliverWebhooks(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 0, delivered)
+	})
+
+	t.Run("should leave failed deliveries pending", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+
+		delivery := &model.WebhookDelivery{ID: "delivery-id", WebhookID: "failing-id", Payload: "{}"}
+		th.Store.EXPECT().GetPendingWebhookDeliveries(gomock.Any(), webhookDeliveryBatchSize).Return([]*model.WebhookDelivery{delivery}, nil)
+		th.Store.EXPECT().GetWebhook(gomock.Any(), "failing-id").Return(&model.Webhook{ID: "failing-id", URL: failing.URL}, nil)
+
+		delivered, err := th.App.DeliverWebhooks(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 0, delivered)
+	})
+}
@@ -2,6 +2,9 @@
 package auth
 
 import (
+	"context"
+	"time"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/permissions"
@@ -11,8 +14,8 @@ import (
 )
 
 type AuthInterface interface {
-	GetSession(token string) (*model.Session, error)
-	IsValidReadToken(boardID string, readToken string) (bool, error)
+	GetSession(ctx context.Context, token string) (*model.Session, error)
+	IsValidReadToken(ctx context.Context, boardID string, readToken string) (bool, error)
 	DoesUserHaveTeamAccess(userID string, teamID string) bool
 }
 
@@ -29,24 +32,28 @@ func New(config *config.Configuration, store store.Store, permissions permission
 }
 
 // GetSession Get a user active session and refresh the session if needed.
-func (a *Auth) GetSession(token string) (*model.Session, error) {
+func (a *Auth) GetSession(ctx context.Context, token string) (*model.Session, error) {
 	if len(token) < 1 {
 		return nil, errors.New("no session token")
 	}
 
-	session, err := a.store.GetSession(token, a.config.SessionExpireTime)
+	now := utils.GetMillis()
+	idleTimeout := time.Duration(a.config.SessionExpireTime) * time.Second
+	maxLifetime := time.Duration(a.config.SessionMaxLifetime) * time.Second
+
+	session, _, err := a.store.GetSessionWithUser(ctx, token, now, idleTimeout, maxLifetime)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get the session for the token")
 	}
-	if session.UpdateAt < (utils.GetMillis() - utils.SecondsToMillis(a.config.SessionRefreshTime)) {
-		_ = a.store.RefreshSession(session)
+	if session.UpdateAt < (now - utils.SecondsToMillis(a.config.SessionRefreshTime)) {
+		_ = a.store.RefreshSession(ctx, session)
 	}
 	return session, nil
 }
 
 // IsValidReadToken validates the read token for a board.
-func (a *Auth) IsValidReadToken(boardID string, readToken string) (bool, error) {
-	sharing, err := a.store.GetSharing(boardID)
+func (a *Auth) IsValidReadToken(ctx context.Context, boardID string, readToken string) (bool, error) {
+	sharing, err := a.store.GetSharing(ctx, boardID)
 	if model.IsErrNotFound(err) {
 		return false, nil
 	}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -30,6 +31,11 @@ var mockSession = &model.Session{
 	UpdateAt: utils.GetMillis() - utils.SecondsToMillis(2000),
 }
 
+var mockUser = &model.User{
+	ID:       "12345",
+	Username: "username",
+}
+
 func setupTestHelper(t *testing.T) *TestHelper {
 	ctrl := gomock.NewController(t)
 	ctrlPermissions := gomock.NewController(t)
@@ -41,9 +47,9 @@ func setupTestHelper(t *testing.T) *TestHelper {
 	newAuth := New(&cfg, mockStore, localpermissions.New(mockPermissions, logger))
 
 	// called during default template setup for every test
-	mockStore.EXPECT().GetTemplateBoards("0", "").AnyTimes()
-	mockStore.EXPECT().RemoveDefaultTemplates(gomock.Any()).AnyTimes()
-	mockStore.EXPECT().InsertBlock(gomock.Any(), gomock.Any()).AnyTimes()
+	mockStore.EXPECT().GetTemplateBoards(gomock.Any(), "0", "").AnyTimes()
+	mockStore.EXPECT().RemoveDefaultTemplates(gomock.Any(), gomock.Any()).AnyTimes()
+	mockStore.EXPECT().InsertBlock(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 
 	return &TestHelper{
 		Auth:    newAuth,
@@ -66,9 +72,9 @@ func TestGetSession(t *testing.T) {
 		{"success, good token", "goodToken", 1000, false},
 	}
 
-	th.Store.EXPECT().GetSession("badToken", gomock.Any()).Return(nil, errors.New("Invalid Token"))
-	th.Store.EXPECT().GetSession("goodToken", gomock.Any()).Return(mockSession, nil)
-	th.Store.EXPECT().RefreshSession(gomock.Any()).Return(nil)
+	th.Store.EXPECT().GetSessionWithUser(gomock.Any(), "badToken", gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("Invalid Token"))
+	th.Store.EXPECT().GetSessionWithUser(gomock.Any(), "goodToken", gomock.Any(), gomock.Any(), gomock.Any()).Return(mockSession, mockUser, nil)
+	th.Store.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).Return(nil)
 
 	for _, test := range testcases {
 		t.Run(test.title, func(t *testing.T) {
@@ -76,7 +82,7 @@ func TestGetSession(t *testing.T) {
 				th.Auth.config.SessionRefreshTime = test.refreshTime
 			}
 
-			session, err := th.Auth.GetSession(test.token)
+			session, err := th.Auth.GetSession(context.Background(), test.token)
 			if test.isError {
 				require.Error(t, err)
 			} else {
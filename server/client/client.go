@@ -7,6 +7,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/mattermost/focalboard/server/api"
@@ -263,6 +264,22 @@ func (c *Client) GetAllBlocksForBoard(boardID string) ([]*model.Block, *Response
 	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
+// GetAllBlocksForBoardPage returns a page of the blocks of a board and
+// the cursor of the next page, which is empty on the last one.
+func (c *Client) GetAllBlocksForBoardPage(boardID, cursor string, perPage int) ([]*model.Block, string, *Response) {
+	r, err := c.DoAPIGet(c.GetAllBlocksRoute(boardID)+"&"+cursorPageQuery(cursor, perPage), "")
+	if err != nil {
+		return nil, "", BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), r.Header.Get(api.HeaderNextCursor), BuildResponse(r)
+}
+
+func cursorPageQuery(cursor string, perPage int) string {
+	return fmt.Sprintf("cursor=%s&per_page=%d", url.QueryEscape(cursor), perPage)
+}
+
 const disableNotifyQueryParam = "disable_notify=true"
 
 func (c *Client) PatchBlock(boardID, blockID string, blockPatch *model.BlockPatch, disableNotify bool) (bool, *Response) {
@@ -320,6 +337,71 @@ func (c *Client) UndeleteBlock(boardID, blockID string) (bool, *Response) {
 	return true, BuildResponse(r)
 }
 
+func (c *Client) GetDeletedBlocksForBoard(boardID string) ([]*model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetBlocksRoute(boardID)+"/deleted", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) RestoreBlock(boardID, blockID string) (*model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/restore", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var block *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return block, BuildResponse(r)
+}
+
+func (c *Client) GetBlockHistory(boardID, blockID string) ([]*model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetBlockRoute(boardID, blockID)+"/history", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBlockDiff(boardID, blockID string, fromVersion, toVersion int64) (*model.BlockDiff, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/diff?from=%d&to=%d", c.GetBlockRoute(boardID, blockID), fromVersion, toVersion), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var diff *model.BlockDiff
+	if err := json.NewDecoder(r.Body).Decode(&diff); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return diff, BuildResponse(r)
+}
+
+func (c *Client) RevertBlock(boardID, blockID string, version int64) (*model.Block, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/revert?version=%d", c.GetBlockRoute(boardID, blockID), version), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var block *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return block, BuildResponse(r)
+}
+
 func (c *Client) InsertBlocks(boardID string, blocks []*model.Block, disableNotify bool) ([]*model.Block, *Response) {
 	var queryParams string
 	if disableNotify {
@@ -511,6 +593,89 @@ func (c *Client) PostSharing(sharing *model.Sharing) (bool, *Response) {
 	return true, BuildResponse(r)
 }
 
+func (c *Client) PostSharingSnapshot(boardID string) (*model.BoardSnapshot, *Response) {
+	r, err := c.DoAPIPost(c.GetSharingRoute(boardID)+"/snapshot", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var snapshot *model.BoardSnapshot
+	if err := json.NewDecoder(r.Body).Decode(&snapshot); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return snapshot, BuildResponse(r)
+}
+
+func (c *Client) PostSharingRotate(boardID string) (*model.Sharing, *Response) {
+	r, err := c.DoAPIPost(c.GetSharingRoute(boardID)+"/rotate", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	sharing := model.SharingFromJSON(r.Body)
+	return &sharing, BuildResponse(r)
+}
+
+func (c *Client) GetShareTokens(boardID string) ([]*model.ShareToken, *Response) {
+	r, err := c.DoAPIGet(c.GetSharingRoute(boardID)+"/tokens", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var shareTokens []*model.ShareToken
+	if err := json.NewDecoder(r.Body).Decode(&shareTokens); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return shareTokens, BuildResponse(r)
+}
+
+func (c *Client) CreateShareToken(boardID string, shareToken *model.ShareTokenWithPassword) (*model.ShareToken, *Response) {
+	r, err := c.DoAPIPost(c.GetSharingRoute(boardID)+"/tokens", toJSON(shareToken))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var shareTokenNew *model.ShareToken
+	if err := json.NewDecoder(r.Body).Decode(&shareTokenNew); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return shareTokenNew, BuildResponse(r)
+}
+
+func (c *Client) DeleteShareToken(boardID, shareTokenID string) *Response {
+	r, err := c.DoAPIDelete(c.GetSharingRoute(boardID)+"/tokens/"+shareTokenID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetSharedContent(token, password string) (*model.SharedContent, *Response) {
+	opt := func(r *http.Request) {
+		if password != "" {
+			r.Header.Set(api.HeaderSharePassword, password)
+		}
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodGet, c.APIURL+"/share/"+token, http.NoBody, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var content *model.SharedContent
+	if err := json.NewDecoder(r.Body).Decode(&content); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return content, BuildResponse(r)
+}
+
 func (c *Client) GetRegisterRoute() string {
 	return "/register"
 }
@@ -646,6 +811,36 @@ func (c *Client) UndeleteBoard(boardID string) (bool, *Response) {
 	return true, BuildResponse(r)
 }
 
+func (c *Client) ArchiveBoard(boardID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/archive", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) RestoreBoard(boardID string) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/restore", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetArchivedBoardsForTeam(teamID string) ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/archived", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+}
+
 func (c *Client) GetBoard(boardID, readToken string) (*model.Board, *Response) {
 	url := c.GetBoardRoute(boardID)
 	if readToken != "" {
@@ -686,6 +881,18 @@ func (c *Client) GetBoardsForTeam(teamID string) ([]*model.Board, *Response) {
 	return model.BoardsFromJSON(r.Body), BuildResponse(r)
 }
 
+// GetBoardsForTeamPage returns a page of the boards of a team and the
+// cursor of the next page, which is empty on the last one.
+func (c *Client) GetBoardsForTeamPage(teamID, cursor string, perPage int) ([]*model.Board, string, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards?"+cursorPageQuery(cursor, perPage), "")
+	if err != nil {
+		return nil, "", BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsFromJSON(r.Body), r.Header.Get(api.HeaderNextCursor), BuildResponse(r)
+}
+
 func (c *Client) SearchBoardsForTeam(teamID, term string) ([]*model.Board, *Response) {
 	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/search?q="+term, "")
 	if err != nil {
@@ -696,6 +903,16 @@ func (c *Client) SearchBoardsForTeam(teamID, term string) ([]*model.Board, *Resp
 	return model.BoardsFromJSON(r.Body), BuildResponse(r)
 }
 
+func (c *Client) SearchBlocksForTeam(teamID, term string) ([]*model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/blocks/search?q="+url.QueryEscape(term), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
 func (c *Client) GetMembersForBoard(boardID string) ([]*model.BoardMember, *Response) {
 	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/members", "")
 	if err != nil {
@@ -706,6 +923,18 @@ func (c *Client) GetMembersForBoard(boardID string) ([]*model.BoardMember, *Resp
 	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
 }
 
+// GetMembersForBoardPage returns a page of the members of a board and
+// the cursor of the next page, which is empty on the last one.
+func (c *Client) GetMembersForBoardPage(boardID, cursor string, perPage int) ([]*model.BoardMember, string, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/members?"+cursorPageQuery(cursor, perPage), "")
+	if err != nil {
+		return nil, "", BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMembersFromJSON(r.Body), r.Header.Get(api.HeaderNextCursor), BuildResponse(r)
+}
+
 func (c *Client) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, *Response) {
 	r, err := c.DoAPIPost(c.GetBoardRoute(member.BoardID)+"/members", toJSON(member))
 	if err != nil {
@@ -838,6 +1067,140 @@ func (c *Client) GetSubscriptions(subscriberID string) ([]*model.Subscription, *
 	return subs, BuildResponse(r)
 }
 
+func (c *Client) GetWebhooksRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/webhooks"
+}
+
+func (c *Client) GetWebhooks(boardID string) ([]*model.Webhook, *Response) {
+	r, err := c.DoAPIGet(c.GetWebhooksRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhooks []*model.Webhook
+	if err := json.NewDecoder(r.Body).Decode(&webhooks); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return webhooks, BuildResponse(r)
+}
+
+func (c *Client) CreateWebhook(boardID string, webhook *model.WebhookWithSecret) (*model.WebhookWithSecret, *Response) {
+	r, err := c.DoAPIPost(c.GetWebhooksRoute(boardID), toJSON(webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhookNew *model.WebhookWithSecret
+	if err := json.NewDecoder(r.Body).Decode(&webhookNew); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return webhookNew, BuildResponse(r)
+}
+
+func (c *Client) UpdateWebhook(boardID string, webhook *model.WebhookWithSecret) (*model.Webhook, *Response) {
+	r, err := c.DoAPIPut(c.GetWebhooksRoute(boardID)+"/"+webhook.ID, toJSON(webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhookUpdated *model.Webhook
+	if err := json.NewDecoder(r.Body).Decode(&webhookUpdated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return webhookUpdated, BuildResponse(r)
+}
+
+func (c *Client) DeleteWebhook(boardID, webhookID string) *Response {
+	r, err := c.DoAPIDelete(c.GetWebhooksRoute(boardID)+"/"+webhookID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetBoardCustomRolesRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/roles"
+}
+
+func (c *Client) GetBoardCustomRoles(boardID string) ([]*model.BoardCustomRole, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardCustomRolesRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var roles []*model.BoardCustomRole
+	if err := json.NewDecoder(r.Body).Decode(&roles); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return roles, BuildResponse(r)
+}
+
+func (c *Client) CreateBoardCustomRole(boardID string, role *model.BoardCustomRole) (*model.BoardCustomRole, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardCustomRolesRoute(boardID), toJSON(role))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var roleNew *model.BoardCustomRole
+	if err := json.NewDecoder(r.Body).Decode(&roleNew); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return roleNew, BuildResponse(r)
+}
+
+func (c *Client) UpdateBoardCustomRole(boardID string, role *model.BoardCustomRole) (*model.BoardCustomRole, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardCustomRolesRoute(boardID)+"/"+role.ID, toJSON(role))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var roleUpdated *model.BoardCustomRole
+	if err := json.NewDecoder(r.Body).Decode(&roleUpdated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return roleUpdated, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardCustomRole(boardID, roleID string) *Response {
+	r, err := c.DoAPIDelete(c.GetBoardCustomRolesRoute(boardID)+"/"+roleID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) SetMemberCustomRole(boardID, userID, roleID string) (*model.BoardMember, *Response) {
+	body := toJSON(map[string]string{"customRoleId": roleID})
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/members/"+userID+"/role", body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var member *model.BoardMember
+	if err := json.NewDecoder(r.Body).Decode(&member); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return member, BuildResponse(r)
+}
+
 func (c *Client) GetTemplatesForTeam(teamID string) ([]*model.Board, *Response) {
 	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/templates", "")
 	if err != nil {
@@ -887,6 +1250,36 @@ func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
 	return BuildResponse(r)
 }
 
+func (c *Client) ImportExternal(teamID, source string, data io.Reader) (*model.ImportExternalResult, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+	if err != nil {
+		return nil, &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return nil, &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/import/"+source, body, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.ImportExternalResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
 func (c *Client) GetLimits() (*model.BoardsCloudLimits, *Response) {
 	r, err := c.DoAPIGet("/limits", "")
 	if err != nil {
@@ -392,3 +392,129 @@ func TestUndeleteBlock(t *testing.T) {
 		require.Len(t, blocks, initialCount)
 	})
 }
+
+func TestBlocksTrash(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, []*model.Block{
+		{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "Trashed card"},
+	}, false)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 1)
+	blockID := newBlocks[0].ID
+
+	// this avoids triggering uniqueness constraint of
+	// id,insert_at on block history
+	time.Sleep(10 * time.Millisecond)
+
+	_, resp = th.Client.DeleteBlock(board.ID, blockID, false)
+	require.NoError(t, resp.Error)
+
+	t.Run("List the deleted blocks", func(t *testing.T) {
+		deleted, resp := th.Client.GetDeletedBlocksForBoard(board.ID)
+		require.NoError(t, resp.Error)
+		require.Len(t, deleted, 1)
+		require.Equal(t, blockID, deleted[0].ID)
+		require.Equal(t, "Trashed card", deleted[0].Title)
+	})
+
+	t.Run("Try to list or restore without permissions", func(t *testing.T) {
+		_, resp := th.Client2.GetDeletedBlocksForBoard(board.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.RestoreBlock(board.ID, blockID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("Try to restore a block from another board", func(t *testing.T) {
+		otherBoard := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+		_, resp := th.Client.RestoreBlock(otherBoard.ID, blockID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("Restore a block", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+
+		block, resp := th.Client.RestoreBlock(board.ID, blockID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, blockID, block.ID)
+		require.Equal(t, "Trashed card", block.Title)
+
+		deleted, resp := th.Client.GetDeletedBlocksForBoard(board.ID)
+		require.NoError(t, resp.Error)
+		require.Empty(t, deleted)
+
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 1)
+	})
+}
+
+func TestBlockDiffAndRevert(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, []*model.Block{
+		{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "First title"},
+	}, false)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 1)
+	blockID := newBlocks[0].ID
+
+	// this avoids triggering uniqueness constraint of
+	// id,insert_at on block history
+	time.Sleep(10 * time.Millisecond)
+
+	newTitle := "Second title"
+	_, resp = th.Client.PatchBlock(board.ID, blockID, &model.BlockPatch{
+		Title:         &newTitle,
+		UpdatedFields: map[string]interface{}{"icon": "💡"},
+	}, false)
+	require.NoError(t, resp.Error)
+
+	history, resp := th.Client.GetBlockHistory(board.ID, blockID)
+	require.NoError(t, resp.Error)
+	require.Len(t, history, 2)
+	latest, first := history[0], history[1]
+	require.Equal(t, "Second title", latest.Title)
+	require.Equal(t, "First title", first.Title)
+
+	t.Run("Diff two versions", func(t *testing.T) {
+		diff, resp := th.Client.GetBlockDiff(board.ID, blockID, first.UpdateAt, latest.UpdateAt)
+		require.NoError(t, resp.Error)
+		require.Equal(t, []model.BlockChange{
+			{Path: "fields.icon", NewValue: "💡"},
+			{Path: "title", OldValue: "First title", NewValue: "Second title"},
+		}, diff.Changes)
+	})
+
+	t.Run("Diff an unknown version", func(t *testing.T) {
+		_, resp := th.Client.GetBlockDiff(board.ID, blockID, 1, latest.UpdateAt)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("Try to revert without permissions", func(t *testing.T) {
+		_, resp := th.Client2.RevertBlock(board.ID, blockID, first.UpdateAt)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("Revert to the first version", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+
+		block, resp := th.Client.RevertBlock(board.ID, blockID, first.UpdateAt)
+		require.NoError(t, resp.Error)
+		require.Equal(t, "First title", block.Title)
+		require.NotContains(t, block.Fields, "icon")
+
+		history, resp := th.Client.GetBlockHistory(board.ID, blockID)
+		require.NoError(t, resp.Error)
+		require.Len(t, history, 3)
+		require.Equal(t, "First title", history[0].Title)
+	})
+}
@@ -0,0 +1,106 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardCustomRoles(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypePrivate)
+
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+		BoardID:      board.ID,
+		UserID:       th.GetUser2().ID,
+		SchemeEditor: true,
+	})
+	th.CheckOK(resp)
+
+	t.Run("create, update and delete a role", func(t *testing.T) {
+		role := &model.BoardCustomRole{
+			Name:        "Viewer",
+			Permissions: model.RolePermissionViewBoard,
+		}
+
+		created, resp := th.Client.CreateBoardCustomRole(board.ID, role)
+		th.CheckOK(resp)
+		require.NotEmpty(t, created.ID)
+		require.Equal(t, board.ID, created.BoardID)
+		require.Equal(t, th.GetUser1().ID, created.CreatedBy)
+
+		created.Name = "Commenter"
+		created.Permissions |= model.RolePermissionCommentBoardCards
+		updated, resp := th.Client.UpdateBoardCustomRole(board.ID, created)
+		th.CheckOK(resp)
+		require.Equal(t, "Commenter", updated.Name)
+
+		roles, resp := th.Client.GetBoardCustomRoles(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, roles, 1)
+		require.Equal(t, created.Permissions, roles[0].Permissions)
+
+		resp = th.Client.DeleteBoardCustomRole(board.ID, created.ID)
+		th.CheckOK(resp)
+
+		roles, resp = th.Client.GetBoardCustomRoles(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, roles)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		_, resp := th.Client.CreateBoardCustomRole(board.ID, &model.BoardCustomRole{})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a member gets the permissions of its custom role", func(t *testing.T) {
+		role, resp := th.Client.CreateBoardCustomRole(board.ID, &model.BoardCustomRole{
+			Name:        "Contributor",
+			Permissions: model.RolePermissionViewBoard | model.RolePermissionCreateBoardCards,
+		})
+		th.CheckOK(resp)
+
+		existing, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "existing"}, true)
+		th.CheckOK(resp)
+
+		member, resp := th.Client.SetMemberCustomRole(board.ID, th.GetUser2().ID, role.ID)
+		th.CheckOK(resp)
+		require.Equal(t, role.ID, member.CustomRoleID)
+
+		card, resp := th.Client2.CreateCard(board.ID, &model.Card{Title: "new"}, true)
+		th.CheckOK(resp)
+		require.NotNil(t, card)
+
+		_, resp = th.Client2.DeleteBlock(board.ID, existing.ID, true)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.GetBoardCustomRoles(board.ID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client2.CreateBoardCustomRole(board.ID, &model.BoardCustomRole{Name: "Admin", Permissions: model.RolePermissionAll})
+		th.CheckForbidden(resp)
+
+		// unassigning the role gives back the member scheme permissions
+		member, resp = th.Client.SetMemberCustomRole(board.ID, th.GetUser2().ID, "")
+		th.CheckOK(resp)
+		require.Empty(t, member.CustomRoleID)
+
+		_, resp = th.Client2.DeleteBlock(board.ID, existing.ID, true)
+		th.CheckOK(resp)
+	})
+
+	t.Run("a role of another board cannot be assigned", func(t *testing.T) {
+		other := th.CreateBoard("team-id", model.BoardTypePrivate)
+		role, resp := th.Client.CreateBoardCustomRole(other.ID, &model.BoardCustomRole{Name: "Other"})
+		th.CheckOK(resp)
+
+		_, resp = th.Client.SetMemberCustomRole(board.ID, th.GetUser2().ID, role.ID)
+		th.CheckBadRequest(resp)
+
+		resp = th.Client.DeleteBoardCustomRole(board.ID, role.ID)
+		th.CheckNotFound(resp)
+	})
+}
@@ -1,7 +1,10 @@
 package integrationtests
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 	"time"
@@ -29,7 +32,7 @@ func TestGetBoards(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		board, err := th.Server.App().CreateBoard(newBoard, "user-id", false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, "user-id", false)
 		require.NoError(t, err)
 		require.NotNil(t, board)
 
@@ -52,7 +55,7 @@ func TestGetBoards(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			Title:  "Board 1",
 		}
-		rBoard1, err := th.Server.App().CreateBoard(board1, user1.ID, true)
+		rBoard1, err := th.Server.App().CreateBoard(context.Background(), board1, user1.ID, true)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard1)
 
@@ -61,7 +64,7 @@ func TestGetBoards(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			Title:  "Board 2",
 		}
-		rBoard2, err := th.Server.App().CreateBoard(board2, user2.ID, false)
+		rBoard2, err := th.Server.App().CreateBoard(context.Background(), board2, user2.ID, false)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard2)
 
@@ -70,7 +73,7 @@ func TestGetBoards(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			Title:  "Board 3",
 		}
-		rBoard3, err := th.Server.App().CreateBoard(board3, user1.ID, true)
+		rBoard3, err := th.Server.App().CreateBoard(context.Background(), board3, user1.ID, true)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard3)
 
@@ -79,7 +82,7 @@ func TestGetBoards(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			Title:  "Board 4",
 		}
-		rBoard4, err := th.Server.App().CreateBoard(board4, user1.ID, false)
+		rBoard4, err := th.Server.App().CreateBoard(context.Background(), board4, user1.ID, false)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard4)
 
@@ -88,7 +91,7 @@ func TestGetBoards(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			Title:  "Board 5",
 		}
-		rBoard5, err := th.Server.App().CreateBoard(board5, user2.ID, true)
+		rBoard5, err := th.Server.App().CreateBoard(context.Background(), board5, user2.ID, true)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard5)
 
@@ -96,7 +99,7 @@ func TestGetBoards(t *testing.T) {
 			TeamID: otherTeamID,
 			Type:   model.BoardTypeOpen,
 		}
-		rBoard6, err := th.Server.App().CreateBoard(board6, user1.ID, true)
+		rBoard6, err := th.Server.App().CreateBoard(context.Background(), board6, user1.ID, true)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard6)
 
@@ -115,6 +118,62 @@ func TestGetBoards(t *testing.T) {
 		require.Len(t, boardsFromOtherTeam, 1)
 		require.Equal(t, rBoard6.ID, boardsFromOtherTeam[0].ID)
 	})
+
+	t.Run("should return the boards a page at a time", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		teamID := "0"
+		boardIDs := []string{}
+		for i := 0; i < 3; i++ {
+			board, err := th.Server.App().CreateBoard(context.Background(), &model.Board{TeamID: teamID, Type: model.BoardTypePrivate}, th.GetUser1().ID, true)
+			require.NoError(t, err)
+			boardIDs = append(boardIDs, board.ID)
+		}
+
+		firstPage, cursor, resp := th.Client.GetBoardsForTeamPage(teamID, "", 2)
+		th.CheckOK(resp)
+		require.Len(t, firstPage, 2)
+		require.Equal(t, firstPage[1].ID, cursor)
+
+		secondPage, cursor, resp := th.Client.GetBoardsForTeamPage(teamID, cursor, 2)
+		th.CheckOK(resp)
+		require.Len(t, secondPage, 1)
+		require.Empty(t, cursor)
+
+		fetchedBoardIDs := []string{}
+		for _, board := range append(firstPage, secondPage...) {
+			fetchedBoardIDs = append(fetchedBoardIDs, board.ID)
+		}
+		require.ElementsMatch(t, boardIDs, fetchedBoardIDs)
+	})
+
+	t.Run("a canceled request should cancel its queries", func(t *testing.T) {
+		th := SetupTestHelperWithToken(t).Start()
+		defer th.TearDown()
+
+		teamID := "0"
+		_, err := th.Server.App().CreateBoard(context.Background(), &model.Board{TeamID: teamID, Type: model.BoardTypeOpen}, model.SingleUser, true)
+		require.NoError(t, err)
+
+		newRequest := func(ctx context.Context) *http.Request {
+			r := httptest.NewRequest(http.MethodGet, client.APIURLSuffix+th.Client.GetTeamRoute(teamID)+"/boards", nil).WithContext(ctx)
+			r.Header.Set("Authorization", "Bearer "+th.Client.Token)
+			r.Header.Set("X-Requested-With", "XMLHttpRequest")
+			return r
+		}
+
+		rec := httptest.NewRecorder()
+		th.Server.GetRootRouter().ServeHTTP(rec, newRequest(context.Background()))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		rec = httptest.NewRecorder()
+		th.Server.GetRootRouter().ServeHTTP(rec, newRequest(ctx))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
 }
 
 func TestCreateBoard(t *testing.T) {
@@ -158,7 +217,7 @@ func TestCreateBoard(t *testing.T) {
 		require.Equal(t, me.ID, board.ModifiedBy)
 
 		t.Run("creating a board should make the creator an admin", func(t *testing.T) {
-			members, err := th.Server.App().GetMembersForBoard(board.ID)
+			members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 1)
 			require.Equal(t, me.ID, members[0].UserID)
@@ -213,7 +272,7 @@ func TestCreateBoard(t *testing.T) {
 		require.Equal(t, me.ID, board.ModifiedBy)
 
 		t.Run("creating a board should make the creator an admin", func(t *testing.T) {
-			members, err := th.Server.App().GetMembersForBoard(board.ID)
+			members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 1)
 			require.Equal(t, me.ID, members[0].UserID)
@@ -263,7 +322,7 @@ func TestCreateBoard(t *testing.T) {
 			th.CheckBadRequest(resp)
 			require.Nil(t, board)
 
-			boards, err := th.Server.App().GetBoardsForUserAndTeam(user1.ID, teamID, true)
+			boards, err := th.Server.App().GetBoardsForUserAndTeam(context.Background(), user1.ID, teamID, true)
 			require.NoError(t, err)
 			require.Empty(t, boards)
 		})
@@ -277,7 +336,7 @@ func TestCreateBoard(t *testing.T) {
 			th.CheckBadRequest(resp)
 			require.Nil(t, board)
 
-			boards, err := th.Server.App().GetBoardsForUserAndTeam(user1.ID, teamID, true)
+			boards, err := th.Server.App().GetBoardsForUserAndTeam(context.Background(), user1.ID, teamID, true)
 			require.NoError(t, err)
 			require.Empty(t, boards)
 		})
@@ -292,7 +351,7 @@ func TestCreateBoard(t *testing.T) {
 			th.CheckForbidden(resp)
 			require.Nil(t, board)
 
-			boards, err := th.Server.App().GetBoardsForUserAndTeam(user1.ID, teamID, true)
+			boards, err := th.Server.App().GetBoardsForUserAndTeam(context.Background(), user1.ID, teamID, true)
 			require.NoError(t, err)
 			require.Empty(t, boards)
 		})
@@ -326,7 +385,7 @@ func TestCreateBoardTemplate(t *testing.T) {
 		require.Equal(t, me.ID, board.ModifiedBy)
 
 		t.Run("creating a board template should make the creator an admin", func(t *testing.T) {
-			members, err := th.Server.App().GetMembersForBoard(board.ID)
+			members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 1)
 			require.Equal(t, me.ID, members[0].UserID)
@@ -382,7 +441,7 @@ func TestCreateBoardTemplate(t *testing.T) {
 		require.Equal(t, me.ID, board.ModifiedBy)
 
 		t.Run("creating a board template should make the creator an admin", func(t *testing.T) {
-			members, err := th.Server.App().GetMembersForBoard(board.ID)
+			members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 1)
 			require.Equal(t, me.ID, members[0].UserID)
@@ -473,6 +532,25 @@ func TestGetAllBlocksForBoard(t *testing.T) {
 
 		require.Equal(t, insertedBlockIDs, fetchedblockIDs)
 	})
+
+	t.Run("Fetch the blocks a page at a time", func(t *testing.T) {
+		firstPage, cursor, resp := th.Client.GetAllBlocksForBoardPage(board.ID, "", 2)
+		th.CheckOK(resp)
+		require.Len(t, firstPage, 2)
+		require.Equal(t, firstPage[1].ID, cursor)
+
+		secondPage, cursor, resp := th.Client.GetAllBlocksForBoardPage(board.ID, cursor, 2)
+		th.CheckOK(resp)
+		require.Len(t, secondPage, 1)
+		require.Empty(t, cursor)
+
+		allBlocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		require.ElementsMatch(t, allBlocks, append(firstPage, secondPage...))
+
+		_, _, resp = th.Client.GetAllBlocksForBoardPage(board.ID, "", -1)
+		th.CheckBadRequest(resp)
+	})
 }
 
 func TestSearchBoards(t *testing.T) {
@@ -498,7 +576,7 @@ func TestSearchBoards(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		rBoard1, err := th.Server.App().CreateBoard(board1, user1.ID, true)
+		rBoard1, err := th.Server.App().CreateBoard(context.Background(), board1, user1.ID, true)
 		require.NoError(t, err)
 
 		board2 := &model.Board{
@@ -506,7 +584,7 @@ func TestSearchBoards(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		rBoard2, err := th.Server.App().CreateBoard(board2, user1.ID, false)
+		rBoard2, err := th.Server.App().CreateBoard(context.Background(), board2, user1.ID, false)
 		require.NoError(t, err)
 
 		board3 := &model.Board{
@@ -514,7 +592,7 @@ func TestSearchBoards(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			TeamID: teamID,
 		}
-		rBoard3, err := th.Server.App().CreateBoard(board3, user1.ID, true)
+		rBoard3, err := th.Server.App().CreateBoard(context.Background(), board3, user1.ID, true)
 		require.NoError(t, err)
 
 		board4 := &model.Board{
@@ -522,7 +600,7 @@ func TestSearchBoards(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			TeamID: teamID,
 		}
-		_, err = th.Server.App().CreateBoard(board4, user1.ID, false)
+		_, err = th.Server.App().CreateBoard(context.Background(), board4, user1.ID, false)
 		require.NoError(t, err)
 
 		board5 := &model.Board{
@@ -530,7 +608,7 @@ func TestSearchBoards(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			TeamID: "other-team-id",
 		}
-		rBoard5, err := th.Server.App().CreateBoard(board5, user1.ID, true)
+		rBoard5, err := th.Server.App().CreateBoard(context.Background(), board5, user1.ID, true)
 		require.NoError(t, err)
 
 		testCases := []struct {
@@ -611,7 +689,7 @@ func TestGetBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		rBoard, err := th.Server.App().CreateBoard(board, th.GetUser1().ID, true)
+		rBoard, err := th.Server.App().CreateBoard(context.Background(), board, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		sharing := &model.Sharing{
@@ -658,7 +736,7 @@ func TestGetBoard(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, false)
 		require.NoError(t, err)
 
 		rBoard, resp := th.Client.GetBoard(board.ID, "")
@@ -675,7 +753,7 @@ func TestGetBoard(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		rBoard, resp := th.Client.GetBoard(board.ID, "")
@@ -693,7 +771,7 @@ func TestGetBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, false)
 		require.NoError(t, err)
 
 		rBoard, resp := th.Client.GetBoard(board.ID, "")
@@ -725,7 +803,7 @@ func TestGetBoardMetadata(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		rBoard, err := th.Server.App().CreateBoard(board, th.GetUser1().ID, true)
+		rBoard, err := th.Server.App().CreateBoard(context.Background(), board, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		// Check metadata
@@ -745,8 +823,8 @@ func TestGetBoardMetadata(t *testing.T) {
 			Title:   "Card 1",
 		}
 		time.Sleep(20 * time.Millisecond)
-		require.NoError(t, th.Server.App().InsertBlock(card1, th.GetUser2().ID))
-		rCard1, err := th.Server.App().GetBlockByID(card1.ID)
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), card1, th.GetUser2().ID))
+		rCard1, err := th.Server.App().GetBlockByID(context.Background(), card1.ID)
 		require.NoError(t, err)
 
 		// Check updated metadata
@@ -766,8 +844,8 @@ func TestGetBoardMetadata(t *testing.T) {
 			Title:   "Card 2",
 		}
 		time.Sleep(20 * time.Millisecond)
-		require.NoError(t, th.Server.App().InsertBlock(card2, th.GetUser1().ID))
-		rCard2, err := th.Server.App().GetBlockByID(card2.ID)
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), card2, th.GetUser1().ID))
+		rCard2, err := th.Server.App().GetBlockByID(context.Background(), card2.ID)
 		require.NoError(t, err)
 
 		// Check updated metadata
@@ -782,7 +860,7 @@ func TestGetBoardMetadata(t *testing.T) {
 		t.Run("After delete board", func(t *testing.T) {
 			// Delete board
 			time.Sleep(20 * time.Millisecond)
-			require.NoError(t, th.Server.App().DeleteBoard(rBoard.ID, th.GetUser1().ID))
+			require.NoError(t, th.Server.App().DeleteBoard(context.Background(), rBoard.ID, th.GetUser1().ID))
 
 			// Check updated metadata
 			boardMetadata, resp = th.Client.GetBoardMetadata(rBoard.ID, "")
@@ -807,7 +885,7 @@ func TestGetBoardMetadata(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		rBoard, err := th.Server.App().CreateBoard(board, th.GetUser1().ID, true)
+		rBoard, err := th.Server.App().CreateBoard(context.Background(), board, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		// Check metadata
@@ -828,7 +906,7 @@ func TestGetBoardMetadata(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		rBoard, err := th.Server.App().CreateBoard(board, th.GetUser1().ID, true)
+		rBoard, err := th.Server.App().CreateBoard(context.Background(), board, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		// Check metadata
@@ -851,7 +929,7 @@ func TestGetBoardMetadata(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		rBoard, err := th.Server.App().CreateBoard(board, userID, true)
+		rBoard, err := th.Server.App().CreateBoard(context.Background(), board, userID, true)
 		require.NoError(t, err)
 
 		sharing := &model.Sharing{
@@ -895,7 +973,7 @@ func TestPatchBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, "user-id", false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, "user-id", false)
 		require.NoError(t, err)
 
 		newTitle := "a new title 1"
@@ -905,7 +983,7 @@ func TestPatchBoard(t *testing.T) {
 		th.CheckUnauthorized(resp)
 		require.Nil(t, rBoard)
 
-		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Equal(t, initialTitle, dbBoard.Title)
 	})
@@ -933,7 +1011,7 @@ func TestPatchBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, user1.ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, user1.ID, true)
 		require.NoError(t, err)
 
 		var invalidPatchType model.BoardType = "invalid"
@@ -956,7 +1034,7 @@ func TestPatchBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, user1.ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, user1.ID, true)
 		require.NoError(t, err)
 
 		newTitle := "a new title"
@@ -968,6 +1046,37 @@ func TestPatchBoard(t *testing.T) {
 		require.Equal(t, newTitle, rBoard.Title)
 	})
 
+	t.Run("patch expecting a stale version of the board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		user1 := th.GetUser1()
+
+		newBoard := &model.Board{
+			Title:  "title",
+			Type:   model.BoardTypeOpen,
+			TeamID: teamID,
+		}
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, user1.ID, true)
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+
+		newTitle := "a new title"
+		rBoard, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{Title: &newTitle, ExpectedUpdateAt: board.UpdateAt})
+		th.CheckOK(resp)
+		require.Equal(t, newTitle, rBoard.Title)
+
+		staleTitle := "a stale title"
+		rBoard, resp = th.Client.PatchBoard(board.ID, &model.BoardPatch{Title: &staleTitle, ExpectedUpdateAt: board.UpdateAt})
+		th.CheckConflict(resp)
+		require.Nil(t, rBoard)
+
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Equal(t, newTitle, dbBoard.Title)
+	})
+
 	t.Run("valid patch on a board without permissions", func(t *testing.T) {
 		th := SetupTestHelper(t).InitBasic()
 		defer th.TearDown()
@@ -980,7 +1089,7 @@ func TestPatchBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, user1.ID, false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, user1.ID, false)
 		require.NoError(t, err)
 
 		newTitle := "a new title"
@@ -990,7 +1099,7 @@ func TestPatchBoard(t *testing.T) {
 		th.CheckForbidden(resp)
 		require.Nil(t, rBoard)
 
-		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Equal(t, initialTitle, dbBoard.Title)
 	})
@@ -1009,14 +1118,14 @@ func TestDeleteBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, "user-id", false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, "user-id", false)
 		require.NoError(t, err)
 
 		success, resp := th.Client.DeleteBoard(board.ID)
 		th.CheckUnauthorized(resp)
 		require.False(t, success)
 
-		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.NotNil(t, dbBoard)
 	})
@@ -1030,14 +1139,14 @@ func TestDeleteBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, "some-user-id", false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, "some-user-id", false)
 		require.NoError(t, err)
 
 		success, resp := th.Client.DeleteBoard(board.ID)
 		th.CheckForbidden(resp)
 		require.False(t, success)
 
-		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.NotNil(t, dbBoard)
 	})
@@ -1060,14 +1169,14 @@ func TestDeleteBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		success, resp := th.Client.DeleteBoard(board.ID)
 		th.CheckOK(resp)
 		require.True(t, success)
 
-		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, dbBoard)
@@ -1087,18 +1196,18 @@ func TestUndeleteBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, "user-id", false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, "user-id", false)
 		require.NoError(t, err)
 
 		time.Sleep(1 * time.Millisecond)
-		err = th.Server.App().DeleteBoard(newBoard.ID, "user-id")
+		err = th.Server.App().DeleteBoard(context.Background(), newBoard.ID, "user-id")
 		require.NoError(t, err)
 
 		success, resp := th.Client.UndeleteBoard(board.ID)
 		th.CheckUnauthorized(resp)
 		require.False(t, success)
 
-		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, dbBoard)
@@ -1113,18 +1222,18 @@ func TestUndeleteBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, "some-user-id", false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, "some-user-id", false)
 		require.NoError(t, err)
 
 		time.Sleep(1 * time.Millisecond)
-		err = th.Server.App().DeleteBoard(newBoard.ID, "some-user-id")
+		err = th.Server.App().DeleteBoard(context.Background(), newBoard.ID, "some-user-id")
 		require.NoError(t, err)
 
 		success, resp := th.Client.UndeleteBoard(board.ID)
 		th.CheckForbidden(resp)
 		require.False(t, success)
 
-		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, dbBoard)
@@ -1139,7 +1248,7 @@ func TestUndeleteBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, "some-user-id", false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, "some-user-id", false)
 		require.NoError(t, err)
 
 		newUser2Member := &model.BoardMember{
@@ -1147,18 +1256,18 @@ func TestUndeleteBoard(t *testing.T) {
 			BoardID:      board.ID,
 			SchemeEditor: true,
 		}
-		_, err = th.Server.App().AddMemberToBoard(newUser2Member)
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), newUser2Member, th.GetUser1().ID)
 		require.NoError(t, err)
 
 		time.Sleep(1 * time.Millisecond)
-		err = th.Server.App().DeleteBoard(newBoard.ID, "some-user-id")
+		err = th.Server.App().DeleteBoard(context.Background(), newBoard.ID, "some-user-id")
 		require.NoError(t, err)
 
 		success, resp := th.Client.UndeleteBoard(board.ID)
 		th.CheckForbidden(resp)
 		require.False(t, success)
 
-		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, dbBoard)
@@ -1182,23 +1291,116 @@ func TestUndeleteBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		time.Sleep(1 * time.Millisecond)
-		err = th.Server.App().DeleteBoard(newBoard.ID, "user-id")
+		err = th.Server.App().DeleteBoard(context.Background(), newBoard.ID, "user-id")
 		require.NoError(t, err)
 
 		success, resp := th.Client.UndeleteBoard(board.ID)
 		th.CheckOK(resp)
 		require.True(t, success)
 
-		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.NotNil(t, dbBoard)
 	})
 }
 
+func TestBoardsTrash(t *testing.T) {
+	t.Run("a user without permissions should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		success, resp := th.Client2.ArchiveBoard(board.ID)
+		th.CheckForbidden(resp)
+		require.False(t, success)
+
+		require.NoError(t, th.Server.App().ArchiveBoard(context.Background(), board.ID, th.GetUser1().ID))
+
+		restored, resp := th.Client2.RestoreBoard(board.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, restored)
+
+		archived, resp := th.Client2.GetArchivedBoardsForTeam(testTeamID)
+		th.CheckOK(resp)
+		require.Empty(t, archived)
+	})
+
+	t.Run("a board should be archived, listed and restored", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		time.Sleep(1 * time.Millisecond)
+		success, resp := th.Client.ArchiveBoard(board.ID)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		dbBoard, err := th.Server.App().GetBoard(context.Background(), board.ID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, dbBoard)
+
+		archived, resp := th.Client.GetArchivedBoardsForTeam(testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, archived, 1)
+		require.Equal(t, board.ID, archived[0].ID)
+
+		time.Sleep(1 * time.Millisecond)
+		restored, resp := th.Client.RestoreBoard(board.ID)
+		th.CheckOK(resp)
+		require.NotNil(t, restored)
+		require.Equal(t, board.ID, restored.ID)
+		require.Zero(t, restored.DeleteAt)
+
+		archived, resp = th.Client.GetArchivedBoardsForTeam(testTeamID)
+		th.CheckOK(resp)
+		require.Empty(t, archived)
+	})
+
+	t.Run("a deleted board should be listed in the trash and restored", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		time.Sleep(1 * time.Millisecond)
+		success, resp := th.Client.DeleteBoard(board.ID)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		archived, resp := th.Client.GetArchivedBoardsForTeam(testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, archived, 1)
+		require.Equal(t, board.ID, archived[0].ID)
+
+		time.Sleep(1 * time.Millisecond)
+		restored, resp := th.Client.RestoreBoard(board.ID)
+		th.CheckOK(resp)
+		require.NotNil(t, restored)
+		require.Equal(t, board.ID, restored.ID)
+
+		members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+	})
+
+	t.Run("restoring a board that isn't archived should fail", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		restored, resp := th.Client.RestoreBoard(board.ID)
+		th.CheckNotFound(resp)
+		require.Nil(t, restored)
+	})
+}
+
 func TestGetMembersForBoard(t *testing.T) {
 	teamID := testTeamID
 
@@ -1210,7 +1412,7 @@ func TestGetMembersForBoard(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, user1.ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, user1.ID, true)
 		require.NoError(t, err)
 
 		newUser2Member := &model.BoardMember{
@@ -1218,7 +1420,7 @@ func TestGetMembersForBoard(t *testing.T) {
 			BoardID:      board.ID,
 			SchemeEditor: true,
 		}
-		user2Member, err := th.Server.App().AddMemberToBoard(newUser2Member)
+		user2Member, err := th.Server.App().AddMemberToBoard(context.Background(), newUser2Member, th.GetUser1().ID)
 		require.NoError(t, err)
 		require.NotNil(t, user2Member)
 
@@ -1241,7 +1443,7 @@ func TestGetMembersForBoard(t *testing.T) {
 		defer th.TearDown()
 		board := createBoardWithUsers(th)
 
-		_ = th.Server.App().DeleteBoardMember(board.ID, th.GetUser2().ID)
+		_ = th.Server.App().DeleteBoardMember(context.Background(), board.ID, th.GetUser2().ID, th.GetUser1().ID)
 
 		members, resp := th.Client2.GetMembersForBoard(board.ID)
 		th.CheckForbidden(resp)
@@ -1266,6 +1468,27 @@ func TestGetMembersForBoard(t *testing.T) {
 		th.CheckOK(resp)
 		require.Len(t, members, 2)
 	})
+
+	t.Run("should return board members a page at a time", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		board := createBoardWithUsers(th)
+
+		firstPage, cursor, resp := th.Client.GetMembersForBoardPage(board.ID, "", 1)
+		th.CheckOK(resp)
+		require.Len(t, firstPage, 1)
+		require.Equal(t, firstPage[0].UserID, cursor)
+
+		secondPage, cursor, resp := th.Client.GetMembersForBoardPage(board.ID, cursor, 1)
+		th.CheckOK(resp)
+		require.Len(t, secondPage, 1)
+		require.Empty(t, cursor)
+
+		require.ElementsMatch(t,
+			[]string{th.GetUser1().ID, th.GetUser2().ID},
+			[]string{firstPage[0].UserID, secondPage[0].UserID},
+		)
+	})
 }
 
 func TestAddMember(t *testing.T) {
@@ -1281,7 +1504,7 @@ func TestAddMember(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, "user-id", false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, "user-id", false)
 		require.NoError(t, err)
 
 		newMember := &model.BoardMember{
@@ -1304,7 +1527,7 @@ func TestAddMember(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, "user-id", false)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, "user-id", false)
 		require.NoError(t, err)
 
 		newMember := &model.BoardMember{
@@ -1343,7 +1566,7 @@ func TestAddMember(t *testing.T) {
 				Type:   model.BoardTypePrivate,
 				TeamID: teamID,
 			}
-			board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+			board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 			require.NoError(t, err)
 
 			newMember := &model.BoardMember{
@@ -1371,7 +1594,7 @@ func TestAddMember(t *testing.T) {
 				Type:   model.BoardTypeOpen,
 				TeamID: teamID,
 			}
-			board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+			board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 			require.NoError(t, err)
 
 			newMember := &model.BoardMember{
@@ -1414,7 +1637,7 @@ func TestAddMember(t *testing.T) {
 				Type:   model.BoardTypePrivate,
 				TeamID: teamID,
 			}
-			board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+			board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 			require.NoError(t, err)
 
 			newMember := &model.BoardMember{
@@ -1444,7 +1667,7 @@ func TestAddMember(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		newMember := &model.BoardMember{
@@ -1454,7 +1677,7 @@ func TestAddMember(t *testing.T) {
 			SchemeEditor: true,
 		}
 
-		members, err := th.Server.App().GetMembersForBoard(board.ID)
+		members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Len(t, members, 1)
 		require.True(t, members[0].SchemeAdmin)
@@ -1465,7 +1688,7 @@ func TestAddMember(t *testing.T) {
 		require.True(t, member.SchemeAdmin)
 		require.True(t, member.SchemeEditor)
 
-		members, err = th.Server.App().GetMembersForBoard(board.ID)
+		members, err = th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Len(t, members, 1)
 		require.True(t, members[0].SchemeAdmin)
@@ -1485,7 +1708,7 @@ func TestUpdateMember(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		updatedMember := &model.BoardMember{
@@ -1509,7 +1732,7 @@ func TestUpdateMember(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		updatedMember := &model.BoardMember{
@@ -1547,7 +1770,7 @@ func TestUpdateMember(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		newUser2Member := &model.BoardMember{
@@ -1555,7 +1778,7 @@ func TestUpdateMember(t *testing.T) {
 			BoardID:      board.ID,
 			SchemeEditor: true,
 		}
-		user2Member, err := th.Server.App().AddMemberToBoard(newUser2Member)
+		user2Member, err := th.Server.App().AddMemberToBoard(context.Background(), newUser2Member, th.GetUser1().ID)
 		require.NoError(t, err)
 		require.NotNil(t, user2Member)
 		require.False(t, user2Member.SchemeAdmin)
@@ -1583,7 +1806,7 @@ func TestUpdateMember(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		memberUpdate := &model.BoardMember{
@@ -1596,7 +1819,7 @@ func TestUpdateMember(t *testing.T) {
 		th.CheckBadRequest(resp)
 		require.Nil(t, updatedUser1Member)
 
-		members, err := th.Server.App().GetMembersForBoard(board.ID)
+		members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Len(t, members, 1)
 		require.True(t, members[0].SchemeAdmin)
@@ -1612,7 +1835,7 @@ func TestUpdateMember(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, userAdmin, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, userAdmin, true)
 		require.NoError(t, err)
 
 		newGuestMember := &model.BoardMember{
@@ -1623,7 +1846,7 @@ func TestUpdateMember(t *testing.T) {
 			SchemeEditor:    true,
 			SchemeAdmin:     false,
 		}
-		guestMember, err := th.Server.App().AddMemberToBoard(newGuestMember)
+		guestMember, err := th.Server.App().AddMemberToBoard(context.Background(), newGuestMember, userAdmin)
 		require.NoError(t, err)
 		require.NotNil(t, guestMember)
 		require.True(t, guestMember.SchemeViewer)
@@ -1661,7 +1884,7 @@ func TestDeleteMember(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		member := &model.BoardMember{
@@ -1684,7 +1907,7 @@ func TestDeleteMember(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		member := &model.BoardMember{
@@ -1722,7 +1945,7 @@ func TestDeleteMember(t *testing.T) {
 				Type:   model.BoardTypePrivate,
 				TeamID: teamID,
 			}
-			board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+			board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 			require.NoError(t, err)
 
 			newUser2Member := &model.BoardMember{
@@ -1730,7 +1953,7 @@ func TestDeleteMember(t *testing.T) {
 				BoardID:      board.ID,
 				SchemeEditor: true,
 			}
-			user2Member, err := th.Server.App().AddMemberToBoard(newUser2Member)
+			user2Member, err := th.Server.App().AddMemberToBoard(context.Background(), newUser2Member, th.GetUser1().ID)
 			require.NoError(t, err)
 			require.NotNil(t, user2Member)
 			require.False(t, user2Member.SchemeAdmin)
@@ -1741,7 +1964,7 @@ func TestDeleteMember(t *testing.T) {
 				BoardID: board.ID,
 			}
 
-			members, err := th.Server.App().GetMembersForBoard(board.ID)
+			members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 2)
 
@@ -1749,7 +1972,7 @@ func TestDeleteMember(t *testing.T) {
 			th.CheckOK(resp)
 			require.True(t, success)
 
-			members, err = th.Server.App().GetMembersForBoard(board.ID)
+			members, err = th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 1)
 		})
@@ -1764,7 +1987,7 @@ func TestDeleteMember(t *testing.T) {
 				Type:   model.BoardTypePrivate,
 				TeamID: teamID,
 			}
-			board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+			board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 			require.NoError(t, err)
 
 			newUser2Member := &model.BoardMember{
@@ -1772,7 +1995,7 @@ func TestDeleteMember(t *testing.T) {
 				BoardID:      board.ID,
 				SchemeEditor: true,
 			}
-			user2Member, err := th.Server.App().AddMemberToBoard(newUser2Member)
+			user2Member, err := th.Server.App().AddMemberToBoard(context.Background(), newUser2Member, th.GetUser1().ID)
 			require.NoError(t, err)
 			require.NotNil(t, user2Member)
 			require.False(t, user2Member.SchemeAdmin)
@@ -1783,7 +2006,7 @@ func TestDeleteMember(t *testing.T) {
 				BoardID: board.ID,
 			}
 
-			members, err := th.Server.App().GetMembersForBoard(board.ID)
+			members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 2)
 
@@ -1792,7 +2015,7 @@ func TestDeleteMember(t *testing.T) {
 			th.CheckForbidden(resp)
 			require.False(t, success)
 
-			members, err = th.Server.App().GetMembersForBoard(board.ID)
+			members, err = th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 2)
 		})
@@ -1807,7 +2030,7 @@ func TestDeleteMember(t *testing.T) {
 				Type:   model.BoardTypePrivate,
 				TeamID: teamID,
 			}
-			board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+			board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 			require.NoError(t, err)
 
 			newUser2Member := &model.BoardMember{
@@ -1815,7 +2038,7 @@ func TestDeleteMember(t *testing.T) {
 				BoardID:      board.ID,
 				SchemeEditor: true,
 			}
-			user2Member, err := th.Server.App().AddMemberToBoard(newUser2Member)
+			user2Member, err := th.Server.App().AddMemberToBoard(context.Background(), newUser2Member, th.GetUser1().ID)
 			require.NoError(t, err)
 			require.NotNil(t, user2Member)
 			require.False(t, user2Member.SchemeAdmin)
@@ -1826,7 +2049,7 @@ func TestDeleteMember(t *testing.T) {
 				BoardID: board.ID,
 			}
 
-			members, err := th.Server.App().GetMembersForBoard(board.ID)
+			members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 2)
 
@@ -1834,7 +2057,7 @@ func TestDeleteMember(t *testing.T) {
 			th.CheckForbidden(resp)
 			require.False(t, success)
 
-			members, err = th.Server.App().GetMembersForBoard(board.ID)
+			members, err = th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 			require.NoError(t, err)
 			require.Len(t, members, 2)
 		})
@@ -1849,7 +2072,7 @@ func TestDeleteMember(t *testing.T) {
 			Type:   model.BoardTypePrivate,
 			TeamID: teamID,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 
 		memberToDelete := &model.BoardMember{
@@ -1861,7 +2084,7 @@ func TestDeleteMember(t *testing.T) {
 		th.CheckBadRequest(resp)
 		require.False(t, success)
 
-		members, err := th.Server.App().GetMembersForBoard(board.ID)
+		members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Len(t, members, 1)
 		require.True(t, members[0].SchemeAdmin)
@@ -1873,7 +2096,7 @@ func TestGetTemplates(t *testing.T) {
 		th := SetupTestHelper(t).InitBasic()
 		defer th.TearDown()
 
-		err := th.Server.App().InitTemplates()
+		err := th.Server.App().InitTemplates(context.Background())
 		require.NoError(t, err, "InitTemplates should not fail")
 
 		teamID := "my-team-id"
@@ -1968,7 +2191,7 @@ func TestDuplicateBoard(t *testing.T) {
 		}
 		th.Client.AddMemberToBoard(newUserMember)
 
-		members, err := th.Server.App().GetMembersForBoard(board.ID)
+		members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Len(t, members, 2)
 
@@ -1981,7 +2204,7 @@ func TestDuplicateBoard(t *testing.T) {
 		duplicateBoard := rBoardsAndBlock.Boards[0]
 		require.Equal(t, duplicateBoard.Type, model.BoardTypePrivate, "Duplicated board should be private")
 
-		members, err = th.Server.App().GetMembersForBoard(duplicateBoard.ID)
+		members, err = th.Server.App().GetMembersForBoard(context.Background(), duplicateBoard.ID)
 		require.NoError(t, err)
 		require.Len(t, members, 1, "Duplicated board should only have one member")
 		require.Equal(t, me.ID, members[0].UserID)
@@ -2053,7 +2276,7 @@ func TestDuplicateBoard(t *testing.T) {
 		}
 		th.Client.AddMemberToBoard(newUserMember)
 
-		members, err := th.Server.App().GetMembersForBoard(board.ID)
+		members, err := th.Server.App().GetMembersForBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Len(t, members, 2)
 
@@ -2068,7 +2291,7 @@ func TestDuplicateBoard(t *testing.T) {
 		require.Equal(t, duplicateBoard.Type, model.BoardTypePrivate, "Duplicated board should be private")
 		require.Equal(t, "Public board copy", duplicateBoard.Title)
 
-		members, err = th.Server.App().GetMembersForBoard(duplicateBoard.ID)
+		members, err = th.Server.App().GetMembersForBoard(context.Background(), duplicateBoard.ID)
 		require.NoError(t, err)
 		require.Len(t, members, 1, "Duplicated board should only have one member")
 		require.Equal(t, me.ID, members[0].UserID)
@@ -1,6 +1,7 @@
 package integrationtests
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -130,7 +131,7 @@ func TestCreateBoardsAndBlocks(t *testing.T) {
 			require.Equal(t, "public board", board1.Title)
 			require.Equal(t, model.BoardTypeOpen, board1.Type)
 			require.NotEqual(t, "board-id-1", board1.ID)
-			blocks1, err := th.Server.App().GetBlocksForBoard(board1.ID)
+			blocks1, err := th.Server.App().GetBlocksForBoard(context.Background(), board1.ID)
 			require.NoError(t, err)
 			require.Len(t, blocks1, 1)
 			require.Equal(t, "block 1", blocks1[0].Title)
@@ -144,18 +145,18 @@ func TestCreateBoardsAndBlocks(t *testing.T) {
 			require.Equal(t, "private board", board2.Title)
 			require.Equal(t, model.BoardTypePrivate, board2.Type)
 			require.NotEqual(t, "board-id-2", board2.ID)
-			blocks2, err := th.Server.App().GetBlocksForBoard(board2.ID)
+			blocks2, err := th.Server.App().GetBlocksForBoard(context.Background(), board2.ID)
 			require.NoError(t, err)
 			require.Len(t, blocks2, 1)
 			require.Equal(t, "block 2", blocks2[0].Title)
 
 			// user should be an admin of both newly created boards
 			user1 := th.GetUser1()
-			members1, err := th.Server.App().GetMembersForBoard(board1.ID)
+			members1, err := th.Server.App().GetMembersForBoard(context.Background(), board1.ID)
 			require.NoError(t, err)
 			require.Len(t, members1, 1)
 			require.Equal(t, user1.ID, members1[0].UserID)
-			members2, err := th.Server.App().GetMembersForBoard(board2.ID)
+			members2, err := th.Server.App().GetMembersForBoard(context.Background(), board2.ID)
 			require.NoError(t, err)
 			require.Len(t, members2, 1)
 			require.Equal(t, user1.ID, members2[0].UserID)
@@ -190,7 +191,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := th.Server.App().CreateBoard(newBoard1, userID, true)
+		board1, err := th.Server.App().CreateBoard(context.Background(), newBoard1, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board1)
 
@@ -199,7 +200,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := th.Server.App().CreateBoard(newBoard2, userID, true)
+		board2, err := th.Server.App().CreateBoard(context.Background(), newBoard2, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board2)
 
@@ -208,8 +209,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board1.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
-		block1, err := th.Server.App().GetBlockByID("block-id-1")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock1, userID))
+		block1, err := th.Server.App().GetBlockByID(context.Background(), "block-id-1")
 		require.NoError(t, err)
 		require.NotNil(t, block1)
 
@@ -218,8 +219,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board2.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
-		block2, err := th.Server.App().GetBlockByID("block-id-2")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock2, userID))
+		block2, err := th.Server.App().GetBlockByID(context.Background(), "block-id-2")
 		require.NoError(t, err)
 		require.NotNil(t, block2)
 
@@ -329,7 +330,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := th.Server.App().CreateBoard(newBoard1, userID, true)
+		board1, err := th.Server.App().CreateBoard(context.Background(), newBoard1, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board1)
 
@@ -338,7 +339,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := th.Server.App().CreateBoard(newBoard2, userID, false)
+		board2, err := th.Server.App().CreateBoard(context.Background(), newBoard2, userID, false)
 		require.NoError(t, err)
 		require.NotNil(t, board2)
 
@@ -347,8 +348,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board1.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
-		block1, err := th.Server.App().GetBlockByID("block-id-1")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock1, userID))
+		block1, err := th.Server.App().GetBlockByID(context.Background(), "block-id-1")
 		require.NoError(t, err)
 		require.NotNil(t, block1)
 
@@ -357,8 +358,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board2.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
-		block2, err := th.Server.App().GetBlockByID("block-id-2")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock2, userID))
+		block2, err := th.Server.App().GetBlockByID(context.Background(), "block-id-2")
 		require.NoError(t, err)
 		require.NotNil(t, block2)
 
@@ -393,7 +394,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := th.Server.App().CreateBoard(newBoard1, userID, true)
+		board1, err := th.Server.App().CreateBoard(context.Background(), newBoard1, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board1)
 
@@ -402,7 +403,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: "different-team-id",
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := th.Server.App().CreateBoard(newBoard2, userID, true)
+		board2, err := th.Server.App().CreateBoard(context.Background(), newBoard2, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board2)
 
@@ -411,8 +412,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board1.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
-		block1, err := th.Server.App().GetBlockByID("block-id-1")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock1, userID))
+		block1, err := th.Server.App().GetBlockByID(context.Background(), "block-id-1")
 		require.NoError(t, err)
 		require.NotNil(t, block1)
 
@@ -421,8 +422,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board2.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
-		block2, err := th.Server.App().GetBlockByID("block-id-2")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock2, userID))
+		block2, err := th.Server.App().GetBlockByID(context.Background(), "block-id-2")
 		require.NoError(t, err)
 		require.NotNil(t, block2)
 
@@ -457,7 +458,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := th.Server.App().CreateBoard(newBoard1, userID, true)
+		board1, err := th.Server.App().CreateBoard(context.Background(), newBoard1, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board1)
 
@@ -466,7 +467,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := th.Server.App().CreateBoard(newBoard2, userID, false)
+		board2, err := th.Server.App().CreateBoard(context.Background(), newBoard2, userID, false)
 		require.NoError(t, err)
 		require.NotNil(t, board2)
 
@@ -475,8 +476,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board1.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
-		block1, err := th.Server.App().GetBlockByID("block-id-1")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock1, userID))
+		block1, err := th.Server.App().GetBlockByID(context.Background(), "block-id-1")
 		require.NoError(t, err)
 		require.NotNil(t, block1)
 
@@ -485,8 +486,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board2.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
-		block2, err := th.Server.App().GetBlockByID("block-id-2")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock2, userID))
+		block2, err := th.Server.App().GetBlockByID(context.Background(), "block-id-2")
 		require.NoError(t, err)
 		require.NotNil(t, block2)
 
@@ -524,7 +525,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := th.Server.App().CreateBoard(newBoard1, userID, true)
+		board1, err := th.Server.App().CreateBoard(context.Background(), newBoard1, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board1)
 
@@ -533,7 +534,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := th.Server.App().CreateBoard(newBoard2, userID, true)
+		board2, err := th.Server.App().CreateBoard(context.Background(), newBoard2, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board2)
 
@@ -542,8 +543,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board1.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
-		block1, err := th.Server.App().GetBlockByID("block-id-1")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock1, userID))
+		block1, err := th.Server.App().GetBlockByID(context.Background(), "block-id-1")
 		require.NoError(t, err)
 		require.NotNil(t, block1)
 
@@ -552,8 +553,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board2.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
-		block2, err := th.Server.App().GetBlockByID("block-id-2")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock2, userID))
+		block2, err := th.Server.App().GetBlockByID(context.Background(), "block-id-2")
 		require.NoError(t, err)
 		require.NotNil(t, block2)
 
@@ -587,7 +588,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := th.Server.App().CreateBoard(newBoard1, userID, true)
+		board1, err := th.Server.App().CreateBoard(context.Background(), newBoard1, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board1)
 
@@ -596,7 +597,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := th.Server.App().CreateBoard(newBoard2, userID, true)
+		board2, err := th.Server.App().CreateBoard(context.Background(), newBoard2, userID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board2)
 
@@ -605,8 +606,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board1.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
-		block1, err := th.Server.App().GetBlockByID("block-id-1")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock1, userID))
+		block1, err := th.Server.App().GetBlockByID(context.Background(), "block-id-1")
 		require.NoError(t, err)
 		require.NotNil(t, block1)
 
@@ -615,8 +616,8 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 			BoardID: board2.ID,
 			Title:   initialTitle,
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
-		block2, err := th.Server.App().GetBlockByID("block-id-2")
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock2, userID))
+		block2, err := th.Server.App().GetBlockByID(context.Background(), "block-id-2")
 		require.NoError(t, err)
 		require.NotNil(t, block2)
 
@@ -640,17 +641,17 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		require.Len(t, bab.Blocks, 2)
 
 		// ensure that the entities have been updated
-		rBoard1, err := th.Server.App().GetBoard(board1.ID)
+		rBoard1, err := th.Server.App().GetBoard(context.Background(), board1.ID)
 		require.NoError(t, err)
 		require.Equal(t, newTitle, rBoard1.Title)
-		rBlock1, err := th.Server.App().GetBlockByID(block1.ID)
+		rBlock1, err := th.Server.App().GetBlockByID(context.Background(), block1.ID)
 		require.NoError(t, err)
 		require.Equal(t, newTitle, rBlock1.Title)
 
-		rBoard2, err := th.Server.App().GetBoard(board2.ID)
+		rBoard2, err := th.Server.App().GetBoard(context.Background(), board2.ID)
 		require.NoError(t, err)
 		require.Equal(t, newTitle, rBoard2.Title)
-		rBlock2, err := th.Server.App().GetBlockByID(block2.ID)
+		rBlock2, err := th.Server.App().GetBlockByID(context.Background(), block2.ID)
 		require.NoError(t, err)
 		require.Equal(t, newTitle, rBlock2.Title)
 	})
@@ -679,7 +680,7 @@ func TestDeleteBoardsAndBlocks(t *testing.T) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board)
 
@@ -688,8 +689,8 @@ func TestDeleteBoardsAndBlocks(t *testing.T) {
 			BoardID: board.ID,
 			Title:   "title",
 		}
-		require.NoError(t, th.Server.App().InsertBlock(newBlock, th.GetUser1().ID))
-		block, err := th.Server.App().GetBlockByID(newBlock.ID)
+		require.NoError(t, th.Server.App().InsertBlock(context.Background(), newBlock, th.GetUser1().ID))
+		block, err := th.Server.App().GetBlockByID(context.Background(), newBlock.ID)
 		require.NoError(t, err)
 		require.NotNil(t, block)
 
@@ -708,7 +709,7 @@ func TestDeleteBoardsAndBlocks(t *testing.T) {
 				TeamID: "another-team-id",
 				Type:   model.BoardTypeOpen,
 			}
-			otherTeamsBoard, err := th.Server.App().CreateBoard(newOtherTeamsBoard, th.GetUser1().ID, true)
+			otherTeamsBoard, err := th.Server.App().CreateBoard(context.Background(), newOtherTeamsBoard, th.GetUser1().ID, true)
 			require.NoError(t, err)
 			require.NotNil(t, board)
 
@@ -732,7 +733,7 @@ func TestDeleteBoardsAndBlocks(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: "team_id_1",
 		}
-		board1, err := th.Server.App().CreateBoard(newBoard1, th.GetUser1().ID, true)
+		board1, err := th.Server.App().CreateBoard(context.Background(), newBoard1, th.GetUser1().ID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board1)
 
@@ -741,7 +742,7 @@ func TestDeleteBoardsAndBlocks(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 			TeamID: "team_id_1",
 		}
-		board2, err := th.Server.App().CreateBoard(newBoard2, th.GetUser1().ID, false)
+		board2, err := th.Server.App().CreateBoard(context.Background(), newBoard2, th.GetUser1().ID, false)
 		require.NoError(t, err)
 		require.NotNil(t, board2)
 
@@ -770,23 +771,23 @@ func TestDeleteBoardsAndBlocks(t *testing.T) {
 			},
 		}
 
-		bab, err := th.Server.App().CreateBoardsAndBlocks(newBab, th.GetUser1().ID, true)
+		bab, err := th.Server.App().CreateBoardsAndBlocks(context.Background(), newBab, th.GetUser1().ID, true)
 		require.NoError(t, err)
 		require.Len(t, bab.Boards, 2)
 		require.Len(t, bab.Blocks, 2)
 
 		// ensure that the entities have been successfully created
-		board1, err := th.Server.App().GetBoard("board-id-1")
+		board1, err := th.Server.App().GetBoard(context.Background(), "board-id-1")
 		require.NoError(t, err)
 		require.NotNil(t, board1)
-		block1, err := th.Server.App().GetBlockByID("block-id-1")
+		block1, err := th.Server.App().GetBlockByID(context.Background(), "block-id-1")
 		require.NoError(t, err)
 		require.NotNil(t, block1)
 
-		board2, err := th.Server.App().GetBoard("board-id-2")
+		board2, err := th.Server.App().GetBoard(context.Background(), "board-id-2")
 		require.NoError(t, err)
 		require.NotNil(t, board2)
-		block2, err := th.Server.App().GetBlockByID("block-id-2")
+		block2, err := th.Server.App().GetBlockByID(context.Background(), "block-id-2")
 		require.NoError(t, err)
 		require.NotNil(t, block2)
 
@@ -801,20 +802,20 @@ func TestDeleteBoardsAndBlocks(t *testing.T) {
 		require.True(t, success)
 
 		// ensure that the entities have been successfully deleted
-		board1, err = th.Server.App().GetBoard("board-id-1")
+		board1, err = th.Server.App().GetBoard(context.Background(), "board-id-1")
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, board1)
-		block1, err = th.Server.App().GetBlockByID("block-id-1")
+		block1, err = th.Server.App().GetBlockByID(context.Background(), "block-id-1")
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, block1)
 
-		board2, err = th.Server.App().GetBoard("board-id-2")
+		board2, err = th.Server.App().GetBoard(context.Background(), "board-id-2")
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, board2)
-		block2, err = th.Server.App().GetBlockByID("block-id-2")
+		block2, err = th.Server.App().GetBlockByID(context.Background(), "block-id-2")
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, block2)
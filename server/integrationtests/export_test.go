@@ -2,6 +2,7 @@ package integrationtests
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -54,13 +55,43 @@ func TestExportBoard(t *testing.T) {
 		require.NoError(t, resp.Error)
 
 		// check for test card
-		boardsImported, err := th.Server.App().GetBoardsForUserAndTeam(th.GetUser1().ID, model.GlobalTeamID, true)
+		boardsImported, err := th.Server.App().GetBoardsForUserAndTeam(context.Background(), th.GetUser1().ID, model.GlobalTeamID, true)
 		require.NoError(t, err)
 		require.Len(t, boardsImported, 1)
 		boardImported := boardsImported[0]
-		blocksImported, err := th.Server.App().GetBlocksForBoard(boardImported.ID)
+		blocksImported, err := th.Server.App().GetBlocksForBoard(context.Background(), boardImported.ID)
 		require.NoError(t, err)
 		require.Len(t, blocksImported, 1)
 		require.Equal(t, block.Title, blocksImported[0].Title)
 	})
+
+	t.Run("export board with an archived card", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := th.CreateBoardAndCards("test-team", model.BoardTypeOpen, 2)
+		_, err := th.Server.App().ArchiveCard(context.Background(), cards[0].ID, th.GetUser1().ID)
+		require.NoError(t, err)
+
+		buf, resp := th.Client.ExportBoardArchive(board.ID)
+		th.CheckOK(resp)
+		require.NotNil(t, buf)
+
+		resp = th.Client.ImportArchive(model.GlobalTeamID, bytes.NewReader(buf))
+		th.CheckOK(resp)
+		require.NoError(t, resp.Error)
+
+		boardsImported, err := th.Server.App().GetBoardsForUserAndTeam(context.Background(), th.GetUser1().ID, model.GlobalTeamID, true)
+		require.NoError(t, err)
+		require.Len(t, boardsImported, 1)
+		blocksImported, err := th.Server.App().GetBlocksForBoard(context.Background(), boardsImported[0].ID)
+		require.NoError(t, err)
+		require.Len(t, blocksImported, 1)
+		require.Equal(t, cards[1].Title, blocksImported[0].Title)
+
+		archivedImported, err := th.Server.App().GetArchivedCards(context.Background(), boardsImported[0].ID)
+		require.NoError(t, err)
+		require.Len(t, archivedImported, 1)
+		require.Equal(t, cards[0].Title, archivedImported[0].Title)
+	})
 }
@@ -0,0 +1,55 @@
+package integrationtests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalImport(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("import a Trello export", func(t *testing.T) {
+		export := `{
+			"name": "Trello board",
+			"lists": [{"id": "l1", "name": "To Do"}],
+			"cards": [{"id": "c1", "name": "Card", "desc": "Text", "idList": "l1"}]
+		}`
+
+		result, resp := th.Client.ImportExternal("team-id", "trello", strings.NewReader(export))
+		th.CheckOK(resp)
+		require.Equal(t, "Trello board", result.Board.Title)
+		require.Equal(t, 3, result.BlocksImported)
+
+		blocks, resp := th.Client.GetAllBlocksForBoard(result.Board.ID)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 3)
+
+		member, err := th.Server.App().GetMemberForBoard(context.Background(), result.Board.ID, th.GetUser1().ID)
+		require.NoError(t, err)
+		require.True(t, member.SchemeAdmin)
+	})
+
+	t.Run("import an Asana export", func(t *testing.T) {
+		export := "Task ID,Name,Section/Column,Projects\n1,Task,Backlog,Asana project\n"
+
+		result, resp := th.Client.ImportExternal("team-id", "asana", strings.NewReader(export))
+		th.CheckOK(resp)
+		require.Equal(t, "Asana project", result.Board.Title)
+		require.Equal(t, model.BoardCreationSourceImport, result.Board.CreationSource)
+	})
+
+	t.Run("invalid export", func(t *testing.T) {
+		_, resp := th.Client.ImportExternal("team-id", "trello", strings.NewReader("not json"))
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("unsupported source", func(t *testing.T) {
+		_, resp := th.Client.ImportExternal("team-id", "jira", strings.NewReader("{}"))
+		th.CheckBadRequest(resp)
+	})
+}
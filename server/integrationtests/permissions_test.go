@@ -3,6 +3,7 @@ package integrationtests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -145,95 +146,95 @@ type TestData struct {
 }
 
 func setupData(t *testing.T, th *TestHelper) TestData {
-	customTemplate1, err := th.Server.App().CreateBoard(
+	customTemplate1, err := th.Server.App().CreateBoard(context.Background(),
 		&model.Board{Title: "Custom template 1", TeamID: "test-team", IsTemplate: true, Type: model.BoardTypeOpen, MinimumRole: "viewer"},
 		userAdminID,
 		true,
 	)
 	require.NoError(t, err)
-	err = th.Server.App().InsertBlock(&model.Block{ID: "block-1", Title: "Test", Type: "card", BoardID: customTemplate1.ID}, userAdminID)
+	err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-1", Title: "Test", Type: "card", BoardID: customTemplate1.ID}, userAdminID)
 	require.NoError(t, err)
-	customTemplate2, err := th.Server.App().CreateBoard(
+	customTemplate2, err := th.Server.App().CreateBoard(context.Background(),
 		&model.Board{Title: "Custom template 2", TeamID: "test-team", IsTemplate: true, Type: model.BoardTypePrivate, MinimumRole: "viewer"},
 		userAdminID,
 		true)
 	require.NoError(t, err)
-	err = th.Server.App().InsertBlock(&model.Block{ID: "block-2", Title: "Test", Type: "card", BoardID: customTemplate2.ID}, userAdminID)
+	err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-2", Title: "Test", Type: "card", BoardID: customTemplate2.ID}, userAdminID)
 	require.NoError(t, err)
 
-	board1, err := th.Server.App().CreateBoard(&model.Board{Title: "Board 1", TeamID: "test-team", Type: model.BoardTypeOpen, MinimumRole: "viewer"}, userAdminID, true)
+	board1, err := th.Server.App().CreateBoard(context.Background(), &model.Board{Title: "Board 1", TeamID: "test-team", Type: model.BoardTypeOpen, MinimumRole: "viewer"}, userAdminID, true)
 	require.NoError(t, err)
-	err = th.Server.App().InsertBlock(&model.Block{ID: "block-3", Title: "Test", Type: "card", BoardID: board1.ID}, userAdminID)
+	err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-3", Title: "Test", Type: "card", BoardID: board1.ID}, userAdminID)
 	require.NoError(t, err)
-	board2, err := th.Server.App().CreateBoard(&model.Board{Title: "Board 2", TeamID: "test-team", Type: model.BoardTypePrivate, MinimumRole: "viewer"}, userAdminID, true)
+	board2, err := th.Server.App().CreateBoard(context.Background(), &model.Board{Title: "Board 2", TeamID: "test-team", Type: model.BoardTypePrivate, MinimumRole: "viewer"}, userAdminID, true)
 	require.NoError(t, err)
 
-	rBoard2, err := th.Server.App().GetBoard(board2.ID)
+	rBoard2, err := th.Server.App().GetBoard(context.Background(), board2.ID)
 	require.NoError(t, err)
 	require.NotNil(t, rBoard2)
 	require.Equal(t, rBoard2, board2)
 
-	boardMember, err := th.Server.App().GetMemberForBoard(board2.ID, userAdminID)
+	boardMember, err := th.Server.App().GetMemberForBoard(context.Background(), board2.ID, userAdminID)
 	require.NoError(t, err)
 	require.NotNil(t, boardMember)
 	require.Equal(t, boardMember.UserID, userAdminID)
 	require.Equal(t, boardMember.BoardID, board2.ID)
 
-	err = th.Server.App().InsertBlock(&model.Block{ID: "block-4", Title: "Test", Type: "card", BoardID: board2.ID}, userAdminID)
+	err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-4", Title: "Test", Type: "card", BoardID: board2.ID}, userAdminID)
 	require.NoError(t, err)
 
-	err = th.Server.App().UpsertSharing(model.Sharing{ID: board2.ID, Enabled: true, Token: "valid", ModifiedBy: userAdminID, UpdateAt: model.GetMillis()})
+	err = th.Server.App().UpsertSharing(context.Background(), model.Sharing{ID: board2.ID, Enabled: true, Token: "valid", ModifiedBy: userAdminID, UpdateAt: model.GetMillis()})
 	require.NoError(t, err)
 
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: customTemplate1.ID, UserID: userViewerID, SchemeViewer: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: customTemplate1.ID, UserID: userViewerID, SchemeViewer: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: customTemplate2.ID, UserID: userViewerID, SchemeViewer: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: customTemplate2.ID, UserID: userViewerID, SchemeViewer: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: customTemplate1.ID, UserID: userCommenterID, SchemeCommenter: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: customTemplate1.ID, UserID: userCommenterID, SchemeCommenter: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: customTemplate2.ID, UserID: userCommenterID, SchemeCommenter: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: customTemplate2.ID, UserID: userCommenterID, SchemeCommenter: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: customTemplate1.ID, UserID: userEditorID, SchemeEditor: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: customTemplate1.ID, UserID: userEditorID, SchemeEditor: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: customTemplate2.ID, UserID: userEditorID, SchemeEditor: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: customTemplate2.ID, UserID: userEditorID, SchemeEditor: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: customTemplate1.ID, UserID: userAdminID, SchemeAdmin: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: customTemplate1.ID, UserID: userAdminID, SchemeAdmin: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: customTemplate2.ID, UserID: userAdminID, SchemeAdmin: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: customTemplate2.ID, UserID: userAdminID, SchemeAdmin: true}, userAdmin)
 	require.NoError(t, err)
 
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board1.ID, UserID: userViewerID, SchemeViewer: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: board1.ID, UserID: userViewerID, SchemeViewer: true}, userAdmin)
 	require.NoError(t, err)
 
-	boardMember, err = th.Server.App().GetMemberForBoard(board1.ID, userViewerID)
+	boardMember, err = th.Server.App().GetMemberForBoard(context.Background(), board1.ID, userViewerID)
 	require.NoError(t, err)
 	require.NotNil(t, boardMember)
 	require.Equal(t, boardMember.UserID, userViewerID)
 	require.Equal(t, boardMember.BoardID, board1.ID)
 
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board2.ID, UserID: userViewerID, SchemeViewer: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: board2.ID, UserID: userViewerID, SchemeViewer: true}, userAdmin)
 	require.NoError(t, err)
 
-	boardMember, err = th.Server.App().GetMemberForBoard(board2.ID, userViewerID)
+	boardMember, err = th.Server.App().GetMemberForBoard(context.Background(), board2.ID, userViewerID)
 	require.NoError(t, err)
 	require.NotNil(t, boardMember)
 	require.Equal(t, boardMember.UserID, userViewerID)
 	require.Equal(t, boardMember.BoardID, board2.ID)
 
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board1.ID, UserID: userCommenterID, SchemeCommenter: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: board1.ID, UserID: userCommenterID, SchemeCommenter: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board2.ID, UserID: userCommenterID, SchemeCommenter: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: board2.ID, UserID: userCommenterID, SchemeCommenter: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board1.ID, UserID: userEditorID, SchemeEditor: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: board1.ID, UserID: userEditorID, SchemeEditor: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board2.ID, UserID: userEditorID, SchemeEditor: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: board2.ID, UserID: userEditorID, SchemeEditor: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board1.ID, UserID: userAdminID, SchemeAdmin: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: board1.ID, UserID: userAdminID, SchemeAdmin: true}, userAdmin)
 	require.NoError(t, err)
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board2.ID, UserID: userAdminID, SchemeAdmin: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: board2.ID, UserID: userAdminID, SchemeAdmin: true}, userAdmin)
 	require.NoError(t, err)
 
-	_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: board2.ID, UserID: userGuestID, SchemeViewer: true})
+	_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: board2.ID, UserID: userGuestID, SchemeViewer: true}, userAdmin)
 	require.NoError(t, err)
 
 	return TestData{
@@ -396,6 +397,36 @@ func TestPermissionsSearchTeamBoards(t *testing.T) {
 	})
 }
 
+func TestPermissionsSearchTeamBlocks(t *testing.T) {
+	ttCases := []TestCase{
+		// Search blocks
+		{"/teams/test-team/blocks/search?q=test", methodGet, "", userAnon, http.StatusUnauthorized, 0},
+		{"/teams/test-team/blocks/search?q=test", methodGet, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/teams/test-team/blocks/search?q=test", methodGet, "", userTeamMember, http.StatusOK, 1},
+		{"/teams/test-team/blocks/search?q=test", methodGet, "", userViewer, http.StatusOK, 2},
+		{"/teams/test-team/blocks/search?q=test", methodGet, "", userCommenter, http.StatusOK, 2},
+		{"/teams/test-team/blocks/search?q=test", methodGet, "", userEditor, http.StatusOK, 2},
+		{"/teams/test-team/blocks/search?q=test", methodGet, "", userAdmin, http.StatusOK, 2},
+		{"/teams/test-team/blocks/search?q=test", methodGet, "", userGuest, http.StatusOK, 1},
+	}
+	t.Run("plugin", func(t *testing.T) {
+		th := SetupTestHelperPluginMode(t)
+		defer th.TearDown()
+		clients := setupClients(th)
+		testData := setupData(t, th)
+		runTestCases(t, ttCases, testData, clients)
+	})
+	t.Run("local", func(t *testing.T) {
+		th := SetupTestHelperLocalMode(t)
+		defer th.TearDown()
+		clients := setupLocalClients(th)
+		testData := setupData(t, th)
+		ttCases[1].expectedStatusCode = http.StatusOK
+		ttCases[1].totalResults = 1
+		runTestCases(t, ttCases, testData, clients)
+	})
+}
+
 func TestPermissionsSearchTeamLinkableBoards(t *testing.T) {
 	t.Run("plugin", func(t *testing.T) {
 		th := SetupTestHelperPluginMode(t)
@@ -435,7 +466,7 @@ func TestPermissionsSearchTeamLinkableBoards(t *testing.T) {
 
 func TestPermissionsGetTeamTemplates(t *testing.T) {
 	extraSetup := func(t *testing.T, th *TestHelper) {
-		err := th.Server.App().InitTemplates()
+		err := th.Server.App().InitTemplates(context.Background())
 		require.NoError(t, err, "InitTemplates should succeed")
 	}
 
@@ -1333,13 +1364,13 @@ func TestPermissionsPatchBoardBlock(t *testing.T) {
 
 func TestPermissionsDeleteBoardBlock(t *testing.T) {
 	extraSetup := func(t *testing.T, th *TestHelper, testData TestData) {
-		err := th.Server.App().InsertBlock(&model.Block{ID: "block-5", Title: "Test", Type: "card", BoardID: testData.publicTemplate.ID}, userAdmin)
+		err := th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-5", Title: "Test", Type: "card", BoardID: testData.publicTemplate.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().InsertBlock(&model.Block{ID: "block-6", Title: "Test", Type: "card", BoardID: testData.privateTemplate.ID}, userAdmin)
+		err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-6", Title: "Test", Type: "card", BoardID: testData.privateTemplate.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().InsertBlock(&model.Block{ID: "block-7", Title: "Test", Type: "card", BoardID: testData.publicBoard.ID}, userAdmin)
+		err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-7", Title: "Test", Type: "card", BoardID: testData.publicBoard.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().InsertBlock(&model.Block{ID: "block-8", Title: "Test", Type: "card", BoardID: testData.privateBoard.ID}, userAdmin)
+		err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-8", Title: "Test", Type: "card", BoardID: testData.privateBoard.ID}, userAdmin)
 		require.NoError(t, err)
 	}
 
@@ -1404,29 +1435,29 @@ func TestPermissionsDeleteBoardBlock(t *testing.T) {
 
 func TestPermissionsUndeleteBoardBlock(t *testing.T) {
 	extraSetup := func(t *testing.T, th *TestHelper, testData TestData) {
-		err := th.Server.App().InsertBlock(&model.Block{ID: "block-5", Title: "Test", Type: "card", BoardID: testData.publicTemplate.ID}, userAdmin)
+		err := th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-5", Title: "Test", Type: "card", BoardID: testData.publicTemplate.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().InsertBlock(&model.Block{ID: "block-6", Title: "Test", Type: "card", BoardID: testData.privateTemplate.ID}, userAdmin)
+		err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-6", Title: "Test", Type: "card", BoardID: testData.privateTemplate.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().InsertBlock(&model.Block{ID: "block-7", Title: "Test", Type: "card", BoardID: testData.publicBoard.ID}, userAdmin)
+		err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-7", Title: "Test", Type: "card", BoardID: testData.publicBoard.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().InsertBlock(&model.Block{ID: "block-8", Title: "Test", Type: "card", BoardID: testData.privateBoard.ID}, userAdmin)
+		err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-8", Title: "Test", Type: "card", BoardID: testData.privateBoard.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBlock("block-1", userAdmin)
+		err = th.Server.App().DeleteBlock(context.Background(), "block-1", userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBlock("block-2", userAdmin)
+		err = th.Server.App().DeleteBlock(context.Background(), "block-2", userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBlock("block-3", userAdmin)
+		err = th.Server.App().DeleteBlock(context.Background(), "block-3", userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBlock("block-4", userAdmin)
+		err = th.Server.App().DeleteBlock(context.Background(), "block-4", userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBlock("block-5", userAdmin)
+		err = th.Server.App().DeleteBlock(context.Background(), "block-5", userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBlock("block-6", userAdmin)
+		err = th.Server.App().DeleteBlock(context.Background(), "block-6", userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBlock("block-7", userAdmin)
+		err = th.Server.App().DeleteBlock(context.Background(), "block-7", userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBlock("block-8", userAdmin)
+		err = th.Server.App().DeleteBlock(context.Background(), "block-8", userAdmin)
 		require.NoError(t, err)
 	}
 
@@ -1491,13 +1522,13 @@ func TestPermissionsUndeleteBoardBlock(t *testing.T) {
 
 func TestPermissionsUndeleteBoard(t *testing.T) {
 	extraSetup := func(t *testing.T, th *TestHelper, testData TestData) {
-		err := th.Server.App().DeleteBoard(testData.publicBoard.ID, userAdmin)
+		err := th.Server.App().DeleteBoard(context.Background(), testData.publicBoard.ID, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBoard(testData.privateBoard.ID, userAdmin)
+		err = th.Server.App().DeleteBoard(context.Background(), testData.privateBoard.ID, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBoard(testData.publicTemplate.ID, userAdmin)
+		err = th.Server.App().DeleteBoard(context.Background(), testData.publicTemplate.ID, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().DeleteBoard(testData.privateTemplate.ID, userAdmin)
+		err = th.Server.App().DeleteBoard(context.Background(), testData.privateTemplate.ID, userAdmin)
 		require.NoError(t, err)
 	}
 
@@ -1559,13 +1590,13 @@ func TestPermissionsUndeleteBoard(t *testing.T) {
 
 func TestPermissionsDuplicateBoardBlock(t *testing.T) {
 	extraSetup := func(t *testing.T, th *TestHelper, testData TestData) {
-		err := th.Server.App().InsertBlock(&model.Block{ID: "block-5", Title: "Test", Type: "card", BoardID: testData.publicTemplate.ID}, userAdmin)
+		err := th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-5", Title: "Test", Type: "card", BoardID: testData.publicTemplate.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().InsertBlock(&model.Block{ID: "block-6", Title: "Test", Type: "card", BoardID: testData.privateTemplate.ID}, userAdmin)
+		err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-6", Title: "Test", Type: "card", BoardID: testData.privateTemplate.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().InsertBlock(&model.Block{ID: "block-7", Title: "Test", Type: "card", BoardID: testData.publicBoard.ID}, userAdmin)
+		err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-7", Title: "Test", Type: "card", BoardID: testData.publicBoard.ID}, userAdmin)
 		require.NoError(t, err)
-		err = th.Server.App().InsertBlock(&model.Block{ID: "block-8", Title: "Test", Type: "card", BoardID: testData.privateBoard.ID}, userAdmin)
+		err = th.Server.App().InsertBlock(context.Background(), &model.Block{ID: "block-8", Title: "Test", Type: "card", BoardID: testData.privateBoard.ID}, userAdmin)
 		require.NoError(t, err)
 	}
 
@@ -1829,13 +1860,13 @@ func TestPermissionsUpdateBoardMember(t *testing.T) {
 
 func TestPermissionsDeleteBoardMember(t *testing.T) {
 	extraSetup := func(t *testing.T, th *TestHelper, testData TestData) {
-		_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.publicBoard.ID, UserID: userTeamMemberID, SchemeViewer: true})
+		_, err := th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.publicBoard.ID, UserID: userTeamMemberID, SchemeViewer: true}, userAdmin)
 		require.NoError(t, err)
-		_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.privateBoard.ID, UserID: userTeamMemberID, SchemeViewer: true})
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.privateBoard.ID, UserID: userTeamMemberID, SchemeViewer: true}, userAdmin)
 		require.NoError(t, err)
-		_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.publicTemplate.ID, UserID: userTeamMemberID, SchemeViewer: true})
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.publicTemplate.ID, UserID: userTeamMemberID, SchemeViewer: true}, userAdmin)
 		require.NoError(t, err)
-		_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.privateTemplate.ID, UserID: userTeamMemberID, SchemeViewer: true})
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.privateTemplate.ID, UserID: userTeamMemberID, SchemeViewer: true}, userAdmin)
 		require.NoError(t, err)
 	}
 
@@ -1967,13 +1998,13 @@ func TestPermissionsJoinBoardAsMember(t *testing.T) {
 
 func TestPermissionsLeaveBoardAsMember(t *testing.T) {
 	extraSetup := func(t *testing.T, th *TestHelper, testData TestData) {
-		_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.publicBoard.ID, UserID: "not-real-user", SchemeAdmin: true})
+		_, err := th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.publicBoard.ID, UserID: "not-real-user", SchemeAdmin: true}, userAdmin)
 		require.NoError(t, err)
-		_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.privateBoard.ID, UserID: "not-real-user", SchemeAdmin: true})
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.privateBoard.ID, UserID: "not-real-user", SchemeAdmin: true}, userAdmin)
 		require.NoError(t, err)
-		_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.publicTemplate.ID, UserID: "not-real-user", SchemeAdmin: true})
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.publicTemplate.ID, UserID: "not-real-user", SchemeAdmin: true}, userAdmin)
 		require.NoError(t, err)
-		_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.privateTemplate.ID, UserID: "not-real-user", SchemeAdmin: true})
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.privateTemplate.ID, UserID: "not-real-user", SchemeAdmin: true}, userAdmin)
 		require.NoError(t, err)
 	}
 
@@ -2033,19 +2064,19 @@ func TestPermissionsLeaveBoardAsMember(t *testing.T) {
 
 	// Last admin leave should fail
 	extraSetup = func(t *testing.T, th *TestHelper, testData TestData) {
-		_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.publicBoard.ID, UserID: userAdminID, SchemeAdmin: true})
+		_, err := th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.publicBoard.ID, UserID: userAdminID, SchemeAdmin: true}, userAdmin)
 		require.NoError(t, err)
-		_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.privateBoard.ID, UserID: userAdminID, SchemeAdmin: true})
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.privateBoard.ID, UserID: userAdminID, SchemeAdmin: true}, userAdmin)
 		require.NoError(t, err)
-		_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.publicTemplate.ID, UserID: userAdminID, SchemeAdmin: true})
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.publicTemplate.ID, UserID: userAdminID, SchemeAdmin: true}, userAdmin)
 		require.NoError(t, err)
-		_, err = th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.privateTemplate.ID, UserID: userAdminID, SchemeAdmin: true})
+		_, err = th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.privateTemplate.ID, UserID: userAdminID, SchemeAdmin: true}, userAdmin)
 		require.NoError(t, err)
 
-		require.NoError(t, th.Server.App().DeleteBoardMember(testData.publicBoard.ID, "not-real-user"))
-		require.NoError(t, th.Server.App().DeleteBoardMember(testData.privateBoard.ID, "not-real-user"))
-		require.NoError(t, th.Server.App().DeleteBoardMember(testData.publicTemplate.ID, "not-real-user"))
-		require.NoError(t, th.Server.App().DeleteBoardMember(testData.privateTemplate.ID, "not-real-user"))
+		require.NoError(t, th.Server.App().DeleteBoardMember(context.Background(), testData.publicBoard.ID, "not-real-user", userAdmin))
+		require.NoError(t, th.Server.App().DeleteBoardMember(context.Background(), testData.privateBoard.ID, "not-real-user", userAdmin))
+		require.NoError(t, th.Server.App().DeleteBoardMember(context.Background(), testData.publicTemplate.ID, "not-real-user", userAdmin))
+		require.NoError(t, th.Server.App().DeleteBoardMember(context.Background(), testData.privateTemplate.ID, "not-real-user", userAdmin))
 	}
 
 	ttCases = []TestCase{
@@ -2742,7 +2773,7 @@ func TestPermissionsDeleteBoardsAndBlocks(t *testing.T) {
 		testData := setupData(t, th)
 		ttCases := ttCasesF(t, testData)
 
-		_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{BoardID: testData.publicBoard.ID, UserID: userGuestID, SchemeViewer: true})
+		_, err := th.Server.App().AddMemberToBoard(context.Background(), &model.BoardMember{BoardID: testData.publicBoard.ID, UserID: userGuestID, SchemeViewer: true}, userAdmin)
 		require.NoError(t, err)
 
 		runTestCases(t, ttCases, testData, clients)
@@ -3006,31 +3037,31 @@ func TestPermissionsUpdateCategory(t *testing.T) {
 	}
 
 	extraSetup := func(t *testing.T, th *TestHelper) map[string]string {
-		categoryNoTeamMember, err := th.Server.App().CreateCategory(
+		categoryNoTeamMember, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userNoTeamMemberID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryTeamMember, err := th.Server.App().CreateCategory(
+		categoryTeamMember, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userTeamMemberID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryViewer, err := th.Server.App().CreateCategory(
+		categoryViewer, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userViewerID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryCommenter, err := th.Server.App().CreateCategory(
+		categoryCommenter, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userCommenterID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryEditor, err := th.Server.App().CreateCategory(
+		categoryEditor, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userEditorID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryAdmin, err := th.Server.App().CreateCategory(
+		categoryAdmin, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userAdminID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryGuest, err := th.Server.App().CreateCategory(
+		categoryGuest, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userGuestID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
@@ -3089,31 +3120,31 @@ func TestPermissionsDeleteCategory(t *testing.T) {
 	}
 
 	extraSetup := func(t *testing.T, th *TestHelper) map[string]string {
-		categoryNoTeamMember, err := th.Server.App().CreateCategory(
+		categoryNoTeamMember, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userNoTeamMemberID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryTeamMember, err := th.Server.App().CreateCategory(
+		categoryTeamMember, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userTeamMemberID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryViewer, err := th.Server.App().CreateCategory(
+		categoryViewer, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userViewerID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryCommenter, err := th.Server.App().CreateCategory(
+		categoryCommenter, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userCommenterID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryEditor, err := th.Server.App().CreateCategory(
+		categoryEditor, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userEditorID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryAdmin, err := th.Server.App().CreateCategory(
+		categoryAdmin, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userAdminID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryGuest, err := th.Server.App().CreateCategory(
+		categoryGuest, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userGuestID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
@@ -3163,31 +3194,31 @@ func TestPermissionsUpdateCategoryBoard(t *testing.T) {
 	}
 
 	extraSetup := func(t *testing.T, th *TestHelper) map[string]string {
-		categoryNoTeamMember, err := th.Server.App().CreateCategory(
+		categoryNoTeamMember, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userNoTeamMemberID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryTeamMember, err := th.Server.App().CreateCategory(
+		categoryTeamMember, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userTeamMemberID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryViewer, err := th.Server.App().CreateCategory(
+		categoryViewer, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userViewerID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryCommenter, err := th.Server.App().CreateCategory(
+		categoryCommenter, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userCommenterID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryEditor, err := th.Server.App().CreateCategory(
+		categoryEditor, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userEditorID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryAdmin, err := th.Server.App().CreateCategory(
+		categoryAdmin, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userAdminID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		categoryGuest, err := th.Server.App().CreateCategory(
+		categoryGuest, err := th.Server.App().CreateCategory(context.Background(),
 			&model.Category{Name: "Test category", TeamID: "test-team", UserID: userGuestID, CreateAt: model.GetMillis(), UpdateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
@@ -3247,7 +3278,7 @@ func TestPermissionsGetFile(t *testing.T) {
 		clients := setupClients(th)
 		testData := setupData(t, th)
 
-		newFileID, err := th.Server.App().SaveFile(bytes.NewBuffer([]byte("test")), "test-team", testData.privateBoard.ID, "test.png")
+		newFileID, err := th.Server.App().SaveFile(context.Background(), bytes.NewBuffer([]byte("test")), "test-team", testData.privateBoard.ID, "test.png")
 		require.NoError(t, err)
 
 		ttCases := ttCasesF()
@@ -3262,7 +3293,7 @@ func TestPermissionsGetFile(t *testing.T) {
 		clients := setupLocalClients(th)
 		testData := setupData(t, th)
 
-		newFileID, err := th.Server.App().SaveFile(bytes.NewBuffer([]byte("test")), "test-team", testData.privateBoard.ID, "test.png")
+		newFileID, err := th.Server.App().SaveFile(context.Background(), bytes.NewBuffer([]byte("test")), "test-team", testData.privateBoard.ID, "test.png")
 		require.NoError(t, err)
 
 		ttCases := ttCasesF()
@@ -3369,35 +3400,35 @@ func TestPermissionsDeleteSubscription(t *testing.T) {
 	}
 
 	extraSetup := func(t *testing.T, th *TestHelper) {
-		_, err := th.Server.App().CreateSubscription(
+		_, err := th.Server.App().CreateSubscription(context.Background(),
 			&model.Subscription{BlockType: "card", BlockID: "block-3", SubscriberType: "user", SubscriberID: userNoTeamMemberID, CreateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		_, err = th.Server.App().CreateSubscription(
+		_, err = th.Server.App().CreateSubscription(context.Background(),
 			&model.Subscription{BlockType: "card", BlockID: "block-3", SubscriberType: "user", SubscriberID: userTeamMemberID, CreateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		_, err = th.Server.App().CreateSubscription(
+		_, err = th.Server.App().CreateSubscription(context.Background(),
 			&model.Subscription{BlockType: "card", BlockID: "block-3", SubscriberType: "user", SubscriberID: userViewerID, CreateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		_, err = th.Server.App().CreateSubscription(
+		_, err = th.Server.App().CreateSubscription(context.Background(),
 			&model.Subscription{BlockType: "card", BlockID: "block-3", SubscriberType: "user", SubscriberID: userCommenterID, CreateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		_, err = th.Server.App().CreateSubscription(
+		_, err = th.Server.App().CreateSubscription(context.Background(),
 			&model.Subscription{BlockType: "card", BlockID: "block-3", SubscriberType: "user", SubscriberID: userEditorID, CreateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		_, err = th.Server.App().CreateSubscription(
+		_, err = th.Server.App().CreateSubscription(context.Background(),
 			&model.Subscription{BlockType: "card", BlockID: "block-3", SubscriberType: "user", SubscriberID: userAdminID, CreateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		_, err = th.Server.App().CreateSubscription(
+		_, err = th.Server.App().CreateSubscription(context.Background(),
 			&model.Subscription{BlockType: "card", BlockID: "block-3", SubscriberType: "user", SubscriberID: userGuestID, CreateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
-		_, err = th.Server.App().CreateSubscription(
+		_, err = th.Server.App().CreateSubscription(context.Background(),
 			&model.Subscription{BlockType: "card", BlockID: "block-3", SubscriberType: "user", SubscriberID: "other", CreateAt: model.GetMillis()},
 		)
 		require.NoError(t, err)
@@ -3439,7 +3470,7 @@ func TestPermissionsOnboard(t *testing.T) {
 		clients := setupClients(th)
 		testData := setupData(t, th)
 
-		err := th.Server.App().InitTemplates()
+		err := th.Server.App().InitTemplates(context.Background())
 		require.NoError(t, err, "InitTemplates should not fail")
 
 		runTestCases(t, ttCases, testData, clients)
@@ -3450,7 +3481,7 @@ func TestPermissionsOnboard(t *testing.T) {
 		clients := setupLocalClients(th)
 		testData := setupData(t, th)
 
-		err := th.Server.App().InitTemplates()
+		err := th.Server.App().InitTemplates(context.Background())
 		require.NoError(t, err, "InitTemplates should not fail")
 
 		ttCases[1].expectedStatusCode = http.StatusOK
@@ -3558,13 +3589,13 @@ func TestPermissionsMinimumRolesApplied(t *testing.T) {
 				UpdateAt: model.GetMillis(),
 			}})
 		}
-		_, err := th.Server.App().PatchBoard(&model.BoardPatch{MinimumRole: &minimumRole}, testData.privateBoard.ID, userAdminID)
+		_, err := th.Server.App().PatchBoard(context.Background(), &model.BoardPatch{MinimumRole: &minimumRole}, testData.privateBoard.ID, userAdminID)
 		require.NoError(t, err)
-		_, err = th.Server.App().PatchBoard(&model.BoardPatch{MinimumRole: &minimumRole}, testData.publicBoard.ID, userAdminID)
+		_, err = th.Server.App().PatchBoard(context.Background(), &model.BoardPatch{MinimumRole: &minimumRole}, testData.publicBoard.ID, userAdminID)
 		require.NoError(t, err)
-		_, err = th.Server.App().PatchBoard(&model.BoardPatch{MinimumRole: &minimumRole}, testData.privateTemplate.ID, userAdminID)
+		_, err = th.Server.App().PatchBoard(context.Background(), &model.BoardPatch{MinimumRole: &minimumRole}, testData.privateTemplate.ID, userAdminID)
 		require.NoError(t, err)
-		_, err = th.Server.App().PatchBoard(&model.BoardPatch{MinimumRole: &minimumRole}, testData.publicTemplate.ID, userAdminID)
+		_, err = th.Server.App().PatchBoard(context.Background(), &model.BoardPatch{MinimumRole: &minimumRole}, testData.publicTemplate.ID, userAdminID)
 		require.NoError(t, err)
 
 		if minimumRole == "viewer" || minimumRole == "commenter" {
@@ -1,6 +1,7 @@
 package integrationtests
 
 import (
+	"context"
 	"errors"
 	"strings"
 
@@ -83,7 +84,7 @@ func NewPluginTestStore(innerStore store.Store) *PluginTestStore {
 	}
 }
 
-func (s *PluginTestStore) GetTeam(id string) (*model.Team, error) {
+func (s *PluginTestStore) GetTeam(ctx context.Context, id string) (*model.Team, error) {
 	switch id {
 	case "0":
 		return s.baseTeam, nil
@@ -97,7 +98,7 @@ func (s *PluginTestStore) GetTeam(id string) (*model.Team, error) {
 	return nil, errTestStore
 }
 
-func (s *PluginTestStore) GetTeamsForUser(userID string) ([]*model.Team, error) {
+func (s *PluginTestStore) GetTeamsForUser(ctx context.Context, userID string) ([]*model.Team, error) {
 	switch userID {
 	case "no-team-member":
 		return []*model.Team{}, nil
@@ -117,7 +118,7 @@ func (s *PluginTestStore) GetTeamsForUser(userID string) ([]*model.Team, error)
 	return nil, errTestStore
 }
 
-func (s *PluginTestStore) GetUserByID(userID string) (*model.User, error) {
+func (s *PluginTestStore) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
 	user := s.users[userID]
 	if user == nil {
 		return nil, errTestStore
@@ -125,7 +126,7 @@ func (s *PluginTestStore) GetUserByID(userID string) (*model.User, error) {
 	return user, nil
 }
 
-func (s *PluginTestStore) GetUserByEmail(email string) (*model.User, error) {
+func (s *PluginTestStore) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	for _, user := range s.users {
 		if user.Email == email {
 			return user, nil
@@ -134,7 +135,7 @@ func (s *PluginTestStore) GetUserByEmail(email string) (*model.User, error) {
 	return nil, errTestStore
 }
 
-func (s *PluginTestStore) GetUserByUsername(username string) (*model.User, error) {
+func (s *PluginTestStore) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 	for _, user := range s.users {
 		if user.Username == username {
 			return user, nil
@@ -143,7 +144,7 @@ func (s *PluginTestStore) GetUserByUsername(username string) (*model.User, error
 	return nil, errTestStore
 }
 
-func (s *PluginTestStore) GetUserPreferences(userID string) (mmModel.Preferences, error) {
+func (s *PluginTestStore) GetUserPreferences(ctx context.Context, userID string) (mmModel.Preferences, error) {
 	if userID == userTeamMember {
 		return mmModel.Preferences{{
 			UserId:   userTeamMember,
@@ -156,7 +157,7 @@ func (s *PluginTestStore) GetUserPreferences(userID string) (mmModel.Preferences
 	return nil, errTestStore
 }
 
-func (s *PluginTestStore) GetUsersByTeam(teamID string, asGuestID string) ([]*model.User, error) {
+func (s *PluginTestStore) GetUsersByTeam(ctx context.Context, teamID string, asGuestID string) ([]*model.User, error) {
 	if asGuestID == "guest" {
 		return []*model.User{
 			s.users["viewer"],
@@ -191,9 +192,9 @@ func (s *PluginTestStore) GetUsersByTeam(teamID string, asGuestID string) ([]*mo
 	return nil, errTestStore
 }
 
-func (s *PluginTestStore) SearchUsersByTeam(teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
+func (s *PluginTestStore) SearchUsersByTeam(ctx context.Context, teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
 	users := []*model.User{}
-	teamUsers, err := s.GetUsersByTeam(teamID, asGuestID)
+	teamUsers, err := s.GetUsersByTeam(ctx, teamID, asGuestID)
 	if err != nil {
 		return nil, err
 	}
@@ -209,19 +210,19 @@ func (s *PluginTestStore) SearchUsersByTeam(teamID string, searchQuery string, a
 	return users, nil
 }
 
-func (s *PluginTestStore) CanSeeUser(seerID string, seenID string) (bool, error) {
-	user, err := s.GetUserByID(seerID)
+func (s *PluginTestStore) CanSeeUser(ctx context.Context, seerID string, seenID string) (bool, error) {
+	user, err := s.GetUserByID(ctx, seerID)
 	if err != nil {
 		return false, err
 	}
 	if !user.IsGuest {
 		return true, nil
 	}
-	seerMembers, err := s.GetMembersForUser(seerID)
+	seerMembers, err := s.GetMembersForUser(context.Background(), seerID)
 	if err != nil {
 		return false, err
 	}
-	seenMembers, err := s.GetMembersForUser(seenID)
+	seenMembers, err := s.GetMembersForUser(context.Background(), seenID)
 	if err != nil {
 		return false, err
 	}
@@ -235,7 +236,7 @@ func (s *PluginTestStore) CanSeeUser(seerID string, seenID string) (bool, error)
 	return false, nil
 }
 
-func (s *PluginTestStore) SearchUserChannels(teamID, userID, query string) ([]*mmModel.Channel, error) {
+func (s *PluginTestStore) SearchUserChannels(ctx context.Context, teamID, userID, query string) ([]*mmModel.Channel, error) {
 	return []*mmModel.Channel{
 		{
 			TeamId:      teamID,
@@ -252,7 +253,7 @@ func (s *PluginTestStore) SearchUserChannels(teamID, userID, query string) ([]*m
 	}, nil
 }
 
-func (s *PluginTestStore) GetChannel(teamID, channel string) (*mmModel.Channel, error) {
+func (s *PluginTestStore) GetChannel(ctx context.Context, teamID, channel string) (*mmModel.Channel, error) {
 	if channel == "valid-channel-id" {
 		return &mmModel.Channel{
 			TeamId:      teamID,
@@ -271,13 +272,13 @@ func (s *PluginTestStore) GetChannel(teamID, channel string) (*mmModel.Channel,
 	return nil, errTestStore
 }
 
-func (s *PluginTestStore) SearchBoardsForUser(term string, userID string, includePublicBoards bool) ([]*model.Board, error) {
-	boards, err := s.Store.SearchBoardsForUser(term, userID, includePublicBoards)
+func (s *PluginTestStore) SearchBoardsForUser(ctx context.Context, term string, userID string, includePublicBoards bool) ([]*model.Board, error) {
+	boards, err := s.Store.SearchBoardsForUser(context.Background(), term, userID, includePublicBoards)
 	if err != nil {
 		return nil, err
 	}
 
-	teams, err := s.GetTeamsForUser(userID)
+	teams, err := s.GetTeamsForUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,125 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareTokens(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+	th.Server.Config().EnablePublicSharedBoards = true
+
+	board, cards := th.CreateBoardAndCards("team-id", model.BoardTypePrivate, 2)
+
+	t.Run("create, list and delete share tokens", func(t *testing.T) {
+		shareToken, resp := th.Client.CreateShareToken(board.ID, &model.ShareTokenWithPassword{})
+		th.CheckOK(resp)
+		require.Equal(t, model.ShareTokenScopeBoard, shareToken.Scope)
+		require.NotEmpty(t, shareToken.Token)
+		require.False(t, shareToken.HasPassword)
+
+		protected, resp := th.Client.CreateShareToken(board.ID, &model.ShareTokenWithPassword{Password: "secret"})
+		th.CheckOK(resp)
+		require.True(t, protected.HasPassword)
+
+		shareTokens, resp := th.Client.GetShareTokens(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, shareTokens, 2)
+
+		resp = th.Client.DeleteShareToken(board.ID, shareToken.ID)
+		th.CheckOK(resp)
+		resp = th.Client.DeleteShareToken(board.ID, protected.ID)
+		th.CheckOK(resp)
+
+		shareTokens, resp = th.Client.GetShareTokens(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, shareTokens)
+
+		_, resp = th.Client.GetSharedContent(shareToken.Token, "")
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("invalid share tokens", func(t *testing.T) {
+		_, resp := th.Client.CreateShareToken(board.ID, &model.ShareTokenWithPassword{
+			ShareToken: model.ShareToken{Scope: "column"},
+		})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.CreateShareToken(board.ID, &model.ShareTokenWithPassword{
+			ShareToken: model.ShareToken{Scope: model.ShareTokenScopeCard, CardID: "not-a-card"},
+		})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a non member cannot manage the share tokens", func(t *testing.T) {
+		_, resp := th.Client2.GetShareTokens(board.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.CreateShareToken(board.ID, &model.ShareTokenWithPassword{})
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("a board scoped token gives access to the board", func(t *testing.T) {
+		shareToken, resp := th.Client.CreateShareToken(board.ID, &model.ShareTokenWithPassword{})
+		th.CheckOK(resp)
+
+		content, resp := th.Client2.GetSharedContent(shareToken.Token, "")
+		th.CheckOK(resp)
+		require.Equal(t, model.ShareTokenScopeBoard, content.Scope)
+		require.Equal(t, board.ID, content.Board.ID)
+		require.Len(t, content.Blocks, 2)
+
+		// it can be used as a read token too
+		rBoard, resp := th.Client2.GetBoard(board.ID, shareToken.Token)
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, rBoard.ID)
+	})
+
+	t.Run("a card scoped token only gives access to the card", func(t *testing.T) {
+		shareToken, resp := th.Client.CreateShareToken(board.ID, &model.ShareTokenWithPassword{
+			ShareToken: model.ShareToken{Scope: model.ShareTokenScopeCard, CardID: cards[0].ID},
+		})
+		th.CheckOK(resp)
+
+		content, resp := th.Client2.GetSharedContent(shareToken.Token, "")
+		th.CheckOK(resp)
+		require.Equal(t, model.ShareTokenScopeCard, content.Scope)
+		require.Len(t, content.Blocks, 1)
+		require.Equal(t, cards[0].ID, content.Blocks[0].ID)
+
+		_, resp = th.Client2.GetBoard(board.ID, shareToken.Token)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("a password protected token requires the password", func(t *testing.T) {
+		shareToken, resp := th.Client.CreateShareToken(board.ID, &model.ShareTokenWithPassword{Password: "secret"})
+		th.CheckOK(resp)
+
+		_, resp = th.Client2.GetSharedContent(shareToken.Token, "")
+		th.CheckUnauthorized(resp)
+
+		_, resp = th.Client2.GetSharedContent(shareToken.Token, "wrong")
+		th.CheckUnauthorized(resp)
+
+		content, resp := th.Client2.GetSharedContent(shareToken.Token, "secret")
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, content.Board.ID)
+	})
+
+	t.Run("an expired token gives no access", func(t *testing.T) {
+		shareToken, resp := th.Client.CreateShareToken(board.ID, &model.ShareTokenWithPassword{
+			ShareToken: model.ShareToken{ExpireAt: utils.GetMillis() - 1000},
+		})
+		th.CheckOK(resp)
+
+		_, resp = th.Client2.GetSharedContent(shareToken.Token, "")
+		th.CheckNotFound(resp)
+
+		_, resp = th.Client2.GetBoard(board.ID, shareToken.Token)
+		th.CheckForbidden(resp)
+	})
+}
@@ -1,6 +1,7 @@
 package integrationtests
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -32,12 +33,12 @@ func TestSharing(t *testing.T) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		board, err := th.Server.App().CreateBoard(context.Background(), newBoard, th.GetUser1().ID, true)
 		require.NoError(t, err)
 		require.NotNil(t, board)
 		boardID = board.ID
 
-		s, err := th.Server.App().GetSharing(boardID)
+		s, err := th.Server.App().GetSharing(context.Background(), boardID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, s)
@@ -91,4 +92,70 @@ func TestSharing(t *testing.T) {
 			require.Equal(t, sharing.Token, token)
 		})
 	})
+	t.Run("POST sharing snapshot", func(t *testing.T) {
+		title := "snapshot title"
+		_, err := th.Server.App().PatchBoard(context.Background(), &model.BoardPatch{Title: &title}, boardID, th.GetUser1().ID)
+		require.NoError(t, err)
+
+		snapshot, resp := th.Client.PostSharingSnapshot(boardID)
+		require.NoError(t, resp.Error)
+		require.NotNil(t, snapshot)
+		require.Equal(t, boardID, snapshot.BoardID)
+		require.Equal(t, title, snapshot.Board.Title)
+
+		newTitle := "live title"
+		_, err = th.Server.App().PatchBoard(context.Background(), &model.BoardPatch{Title: &newTitle}, boardID, th.GetUser1().ID)
+		require.NoError(t, err)
+
+		t.Run("public viewers get the snapshot", func(t *testing.T) {
+			th.Logout(th.Client)
+			defer th.Login1()
+
+			board, resp := th.Client.GetBoard(boardID, token)
+			require.NoError(t, resp.Error)
+			require.Equal(t, title, board.Title)
+		})
+
+		t.Run("members get the live board", func(t *testing.T) {
+			board, resp := th.Client.GetBoard(boardID, "")
+			require.NoError(t, resp.Error)
+			require.Equal(t, newTitle, board.Title)
+		})
+
+		t.Run("non members can't publish a snapshot", func(t *testing.T) {
+			snapshot, resp := th.Client2.PostSharingSnapshot(boardID)
+			th.CheckForbidden(resp)
+			require.Nil(t, snapshot)
+		})
+	})
+	t.Run("POST sharing rotate", func(t *testing.T) {
+		before, err := th.Server.App().GetSharing(context.Background(), boardID)
+		require.NoError(t, err)
+
+		sharing, resp := th.Client.PostSharingRotate(boardID)
+		require.NoError(t, resp.Error)
+		require.NotNil(t, sharing)
+		require.NotEqual(t, token, sharing.Token)
+		require.True(t, sharing.Enabled)
+		require.GreaterOrEqual(t, sharing.UpdateAt, before.UpdateAt)
+
+		t.Run("the previous token is no longer valid", func(t *testing.T) {
+			th.Logout(th.Client)
+			defer th.Login1()
+
+			board, resp := th.Client.GetBoard(boardID, token)
+			require.Error(t, resp.Error)
+			require.Nil(t, board)
+
+			board, resp = th.Client.GetBoard(boardID, sharing.Token)
+			require.NoError(t, resp.Error)
+			require.NotNil(t, board)
+		})
+
+		t.Run("non members can't rotate the token", func(t *testing.T) {
+			sharing, resp := th.Client2.PostSharingRotate(boardID)
+			th.CheckForbidden(resp)
+			require.Nil(t, sharing)
+		})
+	})
 }
@@ -1,6 +1,8 @@
 package integrationtests
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/services/store"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
@@ -105,6 +107,6 @@ func NewTestProfessionalStore(store store.Store) *TestStore {
 	return testStore
 }
 
-func (s *TestStore) GetLicense() *mmModel.License {
+func (s *TestStore) GetLicense(ctx context.Context) *mmModel.License {
 	return s.license
 }
@@ -0,0 +1,63 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooks(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	t.Run("create, update and delete a webhook", func(t *testing.T) {
+		webhook := &model.WebhookWithSecret{
+			Webhook: model.Webhook{
+				URL:        "https://example.com/hook",
+				EventTypes: []string{model.WebhookEventBlockCreated},
+			},
+		}
+
+		created, resp := th.Client.CreateWebhook(board.ID, webhook)
+		th.CheckOK(resp)
+		require.NotEmpty(t, created.ID)
+		require.NotEmpty(t, created.Secret)
+		require.Equal(t, board.ID, created.BoardID)
+
+		webhooks, resp := th.Client.GetWebhooks(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, webhooks, 1)
+		require.Equal(t, created.ID, webhooks[0].ID)
+		require.Empty(t, webhooks[0].Secret)
+
+		created.URL = "https://example.com/other"
+		created.Secret = ""
+		updated, resp := th.Client.UpdateWebhook(board.ID, created)
+		th.CheckOK(resp)
+		require.Equal(t, "https://example.com/other", updated.URL)
+
+		resp = th.Client.DeleteWebhook(board.ID, created.ID)
+		th.CheckOK(resp)
+
+		webhooks, resp = th.Client.GetWebhooks(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, webhooks)
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		webhook := &model.WebhookWithSecret{
+			Webhook: model.Webhook{URL: "not a url"},
+		}
+
+		_, resp := th.Client.CreateWebhook(board.ID, webhook)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a non member cannot manage the webhooks", func(t *testing.T) {
+		_, resp := th.Client2.GetWebhooks(board.ID)
+		th.CheckForbidden(resp)
+	})
+}
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"strings"
+)
+
+// AuditAction is the kind of change recorded by an audit record.
+type AuditAction string
+
+const (
+	AuditActionInsertBlock   AuditAction = "insertBlock"
+	AuditActionPatchBlock    AuditAction = "patchBlock"
+	AuditActionDeleteBlock   AuditAction = "deleteBlock"
+	AuditActionUndeleteBlock AuditAction = "undeleteBlock"
+	AuditActionRestoreBlock  AuditAction = "restoreBlock"
+	AuditActionMoveBlock     AuditAction = "moveBlock"
+	AuditActionCopyBlock     AuditAction = "copyBlock"
+	AuditActionArchiveCard   AuditAction = "archiveCard"
+	AuditActionUnarchiveCard AuditAction = "unarchiveCard"
+
+	AuditActionCreateBoard   AuditAction = "createBoard"
+	AuditActionPatchBoard    AuditAction = "patchBoard"
+	AuditActionDeleteBoard   AuditAction = "deleteBoard"
+	AuditActionUndeleteBoard AuditAction = "undeleteBoard"
+	AuditActionArchiveBoard  AuditAction = "archiveBoard"
+	AuditActionRestoreBoard  AuditAction = "restoreBoard"
+	AuditActionMergeBoards   AuditAction = "mergeBoards"
+
+	AuditActionSaveMember   AuditAction = "saveMember"
+	AuditActionDeleteMember AuditAction = "deleteMember"
+
+	AuditActionCreateCustomRole AuditAction = "createCustomRole"
+	AuditActionUpdateCustomRole AuditAction = "updateCustomRole"
+	AuditActionDeleteCustomRole AuditAction = "deleteCustomRole"
+
+	AuditActionUpdateSharing      AuditAction = "updateSharing"
+	AuditActionRotateSharingToken AuditAction = "rotateSharingToken"
+	AuditActionCreateShareToken   AuditAction = "createShareToken"
+	AuditActionDeleteShareToken   AuditAction = "deleteShareToken"
+
+	AuditActionCreateWebhook AuditAction = "createWebhook"
+	AuditActionUpdateWebhook AuditAction = "updateWebhook"
+	AuditActionDeleteWebhook AuditAction = "deleteWebhook"
+)
+
+// AuditSummaryMaxLength is the maximum length of the summary of an audit
+// record, longer summaries are truncated.
+const AuditSummaryMaxLength = 1024
+
+// AuditRecord is an entry of the audit trail of the changes made to a
+// board and its blocks.
+// swagger:model
+type AuditRecord struct {
+	// The ID of the audit record
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the board the change was made to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the user that made the change
+	// required: true
+	ActorID string `json:"actorId"`
+
+	// The kind of change
+	// required: true
+	Action AuditAction `json:"action"`
+
+	// The ID of the changed board or block, of the user whose
+	// membership changed, or of the changed role, share token or webhook
+	// required: true
+	ResourceID string `json:"resourceId"`
+
+	// A short description of the change, like the fields it modified
+	// required: false
+	Summary string `json:"summary"`
+
+	// The time of the change in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// QueryAuditOptions are query options that can be passed to
+// GetAuditRecords.
+type QueryAuditOptions struct {
+	Since   int64       // if non-zero then only records created at or after this time are returned
+	Until   int64       // if non-zero then only records created before this time are returned
+	ActorID string      // if not empty then only records of changes made by this user are returned
+	Action  AuditAction // if not empty then only records of this kind of change are returned
+	Page    int         // page number to select when paginating
+	PerPage int         // number of records per page (default=-1, meaning unlimited)
+}
+
+// AuditChangedSummary returns the summary of a change that modified the
+// given fields.
+func AuditChangedSummary(fields []string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	return "changed: " + strings.Join(fields, ", ")
+}
+
+// AuditMemberSummary returns the summary of a change to a board
+// membership, listing the roles the member has after it.
+func AuditMemberSummary(member *BoardMember) string {
+	roles := []string{}
+	if member.SchemeAdmin {
+		roles = append(roles, string(BoardRoleAdmin))
+	}
+	if member.SchemeEditor {
+		roles = append(roles, string(BoardRoleEditor))
+	}
+	if member.SchemeCommenter {
+		roles = append(roles, string(BoardRoleCommenter))
+	}
+	if member.SchemeViewer {
+		roles = append(roles, string(BoardRoleViewer))
+	}
+	if member.CustomRoleID != "" {
+		roles = append(roles, "custom:"+member.CustomRoleID)
+	}
+	return "roles: " + strings.Join(roles, ", ")
+}
@@ -2,12 +2,18 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
+	"sort"
 	"strconv"
 
 	"github.com/mattermost/focalboard/server/services/audit"
 )
 
+// ErrBlockPatchConflict is returned when a block keeps changing while
+// a patch is being applied to it.
+var ErrBlockPatchConflict = errors.New("block changed while being patched")
+
 // Block is the basic data unit
 // swagger:model
 type Block struct {
@@ -63,11 +69,29 @@ type Block struct {
 	// required: true
 	BoardID string `json:"boardId"`
 
+	// The archived time in miliseconds since the current epoch. Set to indicate this card is archived
+	// required: false
+	ArchivedAt int64 `json:"archivedAt"`
+
+	// The position of the block among its siblings, lowest first
+	// required: false
+	SortOrder int64 `json:"sortOrder"`
+
 	// Indicates if the card is limited
 	// required: false
 	Limited bool `json:"limited,omitempty"`
 }
 
+// CommentWithAuthor is a comment block along with the user that created it.
+// swagger:model
+type CommentWithAuthor struct {
+	Block
+
+	// The user that created the comment, if it still exists
+	// required: false
+	Author *User `json:"author,omitempty"`
+}
+
 // BlockPatch is a patch for modify blocks
 // swagger:model
 type BlockPatch struct {
@@ -87,6 +111,10 @@ type BlockPatch struct {
 	// required: false
 	Title *string `json:"title"`
 
+	// The position of the block among its siblings
+	// required: false
+	SortOrder *int64 `json:"sortOrder"`
+
 	// The block updated fields
 	// required: false
 	UpdatedFields map[string]interface{} `json:"updatedFields"`
@@ -94,6 +122,63 @@ type BlockPatch struct {
 	// The block removed fields
 	// required: false
 	DeletedFields []string `json:"deletedFields"`
+
+	// The card property values to update, keyed by property ID. They
+	// are merged into the "properties" field, leaving the other
+	// property values untouched
+	// required: false
+	UpdatedProperties map[string]interface{} `json:"updatedProperties"`
+
+	// The IDs of the card property values to remove
+	// required: false
+	DeletedProperties []string `json:"deletedProperties"`
+
+	// The update time the block is expected to have. If set and the
+	// block has been updated since, the patch fails with a conflict
+	// required: false
+	ExpectedUpdateAt int64 `json:"expectedUpdateAt,omitempty"`
+}
+
+// HasFieldChanges returns true if the patch modifies the fields of the
+// block.
+func (p *BlockPatch) HasFieldChanges() bool {
+	return len(p.UpdatedFields) > 0 || len(p.DeletedFields) > 0 ||
+		len(p.UpdatedProperties) > 0 || len(p.DeletedProperties) > 0
+}
+
+// ChangedFields returns the sorted names of the block attributes, fields
+// and card property values the patch modifies.
+func (p *BlockPatch) ChangedFields() []string {
+	changed := []string{}
+	if p.ParentID != nil {
+		changed = append(changed, "parentId")
+	}
+	if p.Schema != nil {
+		changed = append(changed, "schema")
+	}
+	if p.Type != nil {
+		changed = append(changed, "type")
+	}
+	if p.Title != nil {
+		changed = append(changed, "title")
+	}
+	if p.SortOrder != nil {
+		changed = append(changed, "sortOrder")
+	}
+	for key := range p.UpdatedFields {
+		changed = append(changed, "fields."+key)
+	}
+	for _, key := range p.DeletedFields {
+		changed = append(changed, "fields."+key)
+	}
+	for id := range p.UpdatedProperties {
+		changed = append(changed, "properties."+id)
+	}
+	for _, id := range p.DeletedProperties {
+		changed = append(changed, "properties."+id)
+	}
+	sort.Strings(changed)
+	return changed
 }
 
 // BlockPatchBatch is a batch of IDs and patches for modify blocks
@@ -157,6 +242,32 @@ func (p *BlockPatch) Patch(block *Block) *Block {
 		block.Title = *p.Title
 	}
 
+	if p.SortOrder != nil {
+		block.SortOrder = *p.SortOrder
+	}
+
+	// property changes are applied before the field changes, so a
+	// patch replacing the whole "properties" field takes precedence
+	if len(p.UpdatedProperties) > 0 || len(p.DeletedProperties) > 0 {
+		if block.Fields == nil {
+			block.Fields = map[string]interface{}{}
+		}
+		properties, ok := block.Fields["properties"].(map[string]interface{})
+		if !ok {
+			properties = map[string]interface{}{}
+		}
+		for key, value := range p.UpdatedProperties {
+			properties[key] = value
+		}
+		for _, key := range p.DeletedProperties {
+			delete(properties, key)
+		}
+		block.Fields["properties"] = properties
+	}
+
+	if len(p.UpdatedFields) > 0 && block.Fields == nil {
+		block.Fields = map[string]interface{}{}
+	}
 	for key, field := range p.UpdatedFields {
 		block.Fields[key] = field
 	}
@@ -169,11 +280,30 @@ func (p *BlockPatch) Patch(block *Block) *Block {
 }
 
 type QueryBlocksOptions struct {
-	BoardID   string    // if not empty then filter for blocks belonging to specified board
-	ParentID  string    // if not empty then filter for blocks belonging to specified parent
-	BlockType BlockType // if not empty and not `TypeUnknown` then filter for records of specified block type
-	Page      int       // page number to select when paginating
-	PerPage   int       // number of blocks per page (default=-1, meaning unlimited)
+	BoardID    string      // if not empty then filter for blocks belonging to specified board
+	ParentID   string      // if not empty then filter for blocks belonging to specified parent
+	BlockType  BlockType   // if not empty and not `TypeUnknown` then filter for records of specified block type
+	BlockTypes []BlockType // if not empty then filter for records of any of the specified block types
+	Page       int         // page number to select when paginating
+	PerPage    int         // number of blocks per page (default=-1, meaning unlimited)
+
+	// keyset pagination for GetBlocksForBoard and GetBlocksWithType. The
+	// blocks are sorted by ID, or by update time and ID, and a page starts
+	// right after the last block of the previous one
+	AfterID         string // if not empty then select the blocks after the block with this ID
+	AfterUpdateAt   int64  // the update time of the AfterID block, when OrderByUpdateAt is set
+	OrderByUpdateAt bool   // if true then the pages are sorted by update time and ID instead of ID
+
+	IncludeArchived  bool // if true then archived cards are included in the results
+	OrderBySortOrder bool // if true then the blocks are sorted by their sort order
+}
+
+// SearchBlocksOptions are query options that can be passed to
+// SearchBlocksForUser.
+type SearchBlocksOptions struct {
+	IncludePublicBoards bool        // if true then open boards the user isn't a member of are searched too
+	BlockTypes          []BlockType // if not empty then only blocks of these types are returned
+	Limit               uint64      // if non-zero then limit the number of returned blocks
 }
 
 // QuerySubtreeOptions are query options that can be passed to GetSubTree methods.
@@ -181,13 +311,19 @@ type QuerySubtreeOptions struct {
 	BeforeUpdateAt int64  // if non-zero then filter for records with update_at less than BeforeUpdateAt
 	AfterUpdateAt  int64  // if non-zero then filter for records with update_at greater than AfterUpdateAt
 	Limit          uint64 // if non-zero then limit the number of returned records
+	IncludeDeleted bool   // if true then soft-deleted blocks and their children are included
+	MaxDepth       int    // if non-zero then limit the number of levels returned, the block itself being the first one
 }
 
 // QueryBlockHistoryOptions are query options that can be passed to GetBlockHistory.
 type QueryBlockHistoryOptions struct {
 	BeforeUpdateAt int64  // if non-zero then filter for records with update_at less than BeforeUpdateAt
 	AfterUpdateAt  int64  // if non-zero then filter for records with update_at greater than AfterUpdateAt
-	Limit          uint64 // if non-zero then limit the number of returned records
+	Since          int64  // if non-zero then only records updated at or after this time are returned
+	Before         int64  // if non-zero then only records updated before this time are returned
+	Limit          uint64 // if non-zero then limit the number of returned records, unless PerPage is set
+	Page           int    // page number to select when paginating
+	PerPage        int    // number of records per page (default=0, meaning unlimited)
 	Descending     bool   // if true then the records are sorted by insert_at in descending order
 }
 
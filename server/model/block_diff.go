@@ -0,0 +1,208 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"reflect"
+	"sort"
+)
+
+// BlockDiff is the list of changes between two versions of a block
+// swagger:model
+type BlockDiff struct {
+	// The id of the block
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The version the changes are computed from, which is the update
+	// time of its history entry
+	// required: true
+	FromVersion int64 `json:"fromVersion"`
+
+	// The version the changes are computed to, which is the update
+	// time of its history entry
+	// required: true
+	ToVersion int64 `json:"toVersion"`
+
+	// The changes, sorted by path
+	// required: true
+	Changes []BlockChange `json:"changes"`
+}
+
+// BlockChange is the change of a single value of a block
+// swagger:model
+type BlockChange struct {
+	// The path of the value, like "title" or "fields.properties.<id>"
+	// required: true
+	Path string `json:"path"`
+
+	// The value in the older version, null if it was added
+	// required: true
+	OldValue interface{} `json:"oldValue"`
+
+	// The value in the newer version, null if it was removed
+	// required: true
+	NewValue interface{} `json:"newValue"`
+}
+
+// DiffBlocks returns the changes needed to go from one version of a
+// block to another. Nested field maps are compared key by key, any
+// other value is compared as a whole.
+func DiffBlocks(from, to *Block) *BlockDiff {
+	diff := &BlockDiff{
+		BlockID:     to.ID,
+		FromVersion: from.UpdateAt,
+		ToVersion:   to.UpdateAt,
+		Changes:     []BlockChange{},
+	}
+
+	addChange := func(path string, oldValue, newValue interface{}) {
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diff.Changes = append(diff.Changes, BlockChange{Path: path, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	addChange("parentId", from.ParentID, to.ParentID)
+	addChange("schema", from.Schema, to.Schema)
+	addChange("type", from.Type, to.Type)
+	addChange("title", from.Title, to.Title)
+	addChange("sortOrder", from.SortOrder, to.SortOrder)
+	addChange("archivedAt", from.ArchivedAt, to.ArchivedAt)
+	diffFields(&diff.Changes, "fields", from.Fields, to.Fields)
+
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].Path < diff.Changes[j].Path
+	})
+
+	return diff
+}
+
+func diffFields(changes *[]BlockChange, path string, from, to map[string]interface{}) {
+	for key, oldValue := range from {
+		newValue, ok := to[key]
+		if !ok {
+			*changes = append(*changes, BlockChange{Path: path + "." + key, OldValue: oldValue})
+			continue
+		}
+
+		oldMap, oldIsMap := oldValue.(map[string]interface{})
+		newMap, newIsMap := newValue.(map[string]interface{})
+		if oldIsMap && newIsMap {
+			diffFields(changes, path+"."+key, oldMap, newMap)
+			continue
+		}
+
+		if !reflect.DeepEqual(oldValue, newValue) {
+			*changes = append(*changes, BlockChange{Path: path + "." + key, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	for key, newValue := range to {
+		if _, ok := from[key]; !ok {
+			*changes = append(*changes, BlockChange{Path: path + "." + key, NewValue: newValue})
+		}
+	}
+}
+
+// NewRevertPatch returns the patch that brings the current version of a
+// block back to a previous version. The patch fails if the block is
+// updated before it is applied.
+func NewRevertPatch(current, previous *Block) *BlockPatch {
+	patch := &BlockPatch{
+		ParentID:         &previous.ParentID,
+		Schema:           &previous.Schema,
+		Type:             &previous.Type,
+		Title:            &previous.Title,
+		SortOrder:        &previous.SortOrder,
+		UpdatedFields:    map[string]interface{}{},
+		DeletedFields:    []string{},
+		ExpectedUpdateAt: current.UpdateAt,
+	}
+
+	for key, value := range previous.Fields {
+		patch.UpdatedFields[key] = value
+	}
+
+	for key := range current.Fields {
+		if _, ok := previous.Fields[key]; !ok {
+			patch.DeletedFields = append(patch.DeletedFields, key)
+		}
+	}
+	sort.Strings(patch.DeletedFields)
+
+	return patch
+}
+
+// NewDeltaPatch returns the patch that turns one version of a block into
+// the next one, holding only the values that changed. Its expected update
+// time is the update time of the older version, so whoever applies it can
+// check that it has the version the patch is computed from. The second
+// result is false if the change can't be expressed as a patch, like when
+// the block is moved to another board, archived or deleted.
+func NewDeltaPatch(from, to *Block) (*BlockPatch, bool) {
+	if from.ID != to.ID || from.BoardID != to.BoardID ||
+		from.ArchivedAt != to.ArchivedAt || from.DeleteAt != to.DeleteAt {
+		return nil, false
+	}
+
+	patch := &BlockPatch{ExpectedUpdateAt: from.UpdateAt}
+	if from.ParentID != to.ParentID {
+		patch.ParentID = &to.ParentID
+	}
+	if from.Schema != to.Schema {
+		patch.Schema = &to.Schema
+	}
+	if from.Type != to.Type {
+		patch.Type = &to.Type
+	}
+	if from.Title != to.Title {
+		patch.Title = &to.Title
+	}
+	if from.SortOrder != to.SortOrder {
+		patch.SortOrder = &to.SortOrder
+	}
+
+	for key, newValue := range to.Fields {
+		oldValue, ok := from.Fields[key]
+		if ok && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		// the card property values are sent one by one, as a change
+		// usually touches a single one of them
+		oldProperties, oldIsMap := oldValue.(map[string]interface{})
+		newProperties, newIsMap := newValue.(map[string]interface{})
+		if key == "properties" && ok && oldIsMap && newIsMap {
+			for id, value := range newProperties {
+				if oldPropertyValue, ok := oldProperties[id]; !ok || !reflect.DeepEqual(oldPropertyValue, value) {
+					if patch.UpdatedProperties == nil {
+						patch.UpdatedProperties = map[string]interface{}{}
+					}
+					patch.UpdatedProperties[id] = value
+				}
+			}
+			for id := range oldProperties {
+				if _, ok := newProperties[id]; !ok {
+					patch.DeletedProperties = append(patch.DeletedProperties, id)
+				}
+			}
+			sort.Strings(patch.DeletedProperties)
+			continue
+		}
+
+		if patch.UpdatedFields == nil {
+			patch.UpdatedFields = map[string]interface{}{}
+		}
+		patch.UpdatedFields[key] = newValue
+	}
+
+	for key := range from.Fields {
+		if _, ok := to.Fields[key]; !ok {
+			patch.DeletedFields = append(patch.DeletedFields, key)
+		}
+	}
+	sort.Strings(patch.DeletedFields)
+
+	return patch, true
+}
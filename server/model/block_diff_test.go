@@ -0,0 +1,176 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffBlocks(t *testing.T) {
+	from := &Block{
+		ID:       "block-id",
+		Title:    "old title",
+		UpdateAt: 10,
+		Fields: map[string]interface{}{
+			"icon": "a",
+			"properties": map[string]interface{}{
+				"status":   "todo",
+				"priority": "high",
+			},
+			"contentOrder": []interface{}{"x", "y"},
+		},
+	}
+
+	t.Run("identical versions have no changes", func(t *testing.T) {
+		diff := DiffBlocks(from, from)
+		require.Equal(t, "block-id", diff.BlockID)
+		require.Empty(t, diff.Changes)
+	})
+
+	t.Run("changes are listed by path", func(t *testing.T) {
+		to := &Block{
+			ID:       "block-id",
+			Title:    "new title",
+			UpdateAt: 20,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"status":   "done",
+					"priority": "high",
+					"assignee": "user-id",
+				},
+				"contentOrder": []interface{}{"y", "x"},
+			},
+		}
+
+		diff := DiffBlocks(from, to)
+		require.Equal(t, int64(10), diff.FromVersion)
+		require.Equal(t, int64(20), diff.ToVersion)
+		require.Equal(t, []BlockChange{
+			{Path: "fields.contentOrder", OldValue: []interface{}{"x", "y"}, NewValue: []interface{}{"y", "x"}},
+			{Path: "fields.icon", OldValue: "a"},
+			{Path: "fields.properties.assignee", NewValue: "user-id"},
+			{Path: "fields.properties.status", OldValue: "todo", NewValue: "done"},
+			{Path: "title", OldValue: "old title", NewValue: "new title"},
+		}, diff.Changes)
+	})
+}
+
+func TestNewRevertPatch(t *testing.T) {
+	previous := &Block{
+		ID:        "block-id",
+		ParentID:  "parent-id",
+		Type:      TypeCard,
+		Title:     "old title",
+		SortOrder: 1,
+		UpdateAt:  10,
+		Fields:    map[string]interface{}{"icon": "a"},
+	}
+	current := &Block{
+		ID:        "block-id",
+		ParentID:  "parent-id",
+		Type:      TypeCard,
+		Title:     "new title",
+		SortOrder: 2,
+		UpdateAt:  20,
+		Fields:    map[string]interface{}{"icon": "b", "isTemplate": true},
+	}
+
+	patch := NewRevertPatch(current, previous)
+	require.Equal(t, int64(20), patch.ExpectedUpdateAt)
+	require.Equal(t, []string{"isTemplate"}, patch.DeletedFields)
+
+	reverted := patch.Patch(&Block{
+		ID:        current.ID,
+		ParentID:  current.ParentID,
+		Type:      current.Type,
+		Title:     current.Title,
+		SortOrder: current.SortOrder,
+		Fields:    map[string]interface{}{"icon": "b", "isTemplate": true},
+	})
+	require.Empty(t, DiffBlocks(previous, reverted).Changes)
+}
+
+func TestNewDeltaPatch(t *testing.T) {
+	from := &Block{
+		ID:        "block-id",
+		BoardID:   "board-id",
+		ParentID:  "board-id",
+		Type:      TypeCard,
+		Title:     "old title",
+		SortOrder: 1,
+		UpdateAt:  10,
+		Fields: map[string]interface{}{
+			"icon":        "a",
+			"description": "a long description",
+			"properties": map[string]interface{}{
+				"status":   "todo",
+				"priority": "high",
+			},
+		},
+	}
+
+	t.Run("only the changed values are patched", func(t *testing.T) {
+		to := &Block{
+			ID:        "block-id",
+			BoardID:   "board-id",
+			ParentID:  "board-id",
+			Type:      TypeCard,
+			Title:     "new title",
+			SortOrder: 1,
+			UpdateAt:  20,
+			Fields: map[string]interface{}{
+				"description": "a long description",
+				"isTemplate":  true,
+				"properties": map[string]interface{}{
+					"status":   "done",
+					"assignee": "user-id",
+				},
+			},
+		}
+
+		patch, ok := NewDeltaPatch(from, to)
+		require.True(t, ok)
+		require.Equal(t, int64(10), patch.ExpectedUpdateAt)
+		require.Equal(t, "new title", *patch.Title)
+		require.Nil(t, patch.ParentID)
+		require.Nil(t, patch.Type)
+		require.Nil(t, patch.SortOrder)
+		require.Equal(t, map[string]interface{}{"isTemplate": true}, patch.UpdatedFields)
+		require.Equal(t, []string{"icon"}, patch.DeletedFields)
+		require.Equal(t, map[string]interface{}{"status": "done", "assignee": "user-id"}, patch.UpdatedProperties)
+		require.Equal(t, []string{"priority"}, patch.DeletedProperties)
+
+		patched := patch.Patch(&Block{
+			ID:        from.ID,
+			BoardID:   from.BoardID,
+			ParentID:  from.ParentID,
+			Type:      from.Type,
+			Title:     from.Title,
+			SortOrder: from.SortOrder,
+			Fields: map[string]interface{}{
+				"icon":        "a",
+				"description": "a long description",
+				"properties":  map[string]interface{}{"status": "todo", "priority": "high"},
+			},
+		})
+		require.Empty(t, DiffBlocks(to, patched).Changes)
+	})
+
+	t.Run("identical versions give an empty patch", func(t *testing.T) {
+		patch, ok := NewDeltaPatch(from, from)
+		require.True(t, ok)
+		require.Empty(t, patch.ChangedFields())
+	})
+
+	t.Run("archiving or moving a block is not a patch", func(t *testing.T) {
+		archived := *from
+		archived.ArchivedAt = 20
+		_, ok := NewDeltaPatch(from, &archived)
+		require.False(t, ok)
+
+		moved := *from
+		moved.BoardID = "other-board-id"
+		_, ok = NewDeltaPatch(from, &moved)
+		require.False(t, ok)
+	})
+}
@@ -307,3 +307,86 @@ func TestStampModificationMetadata(t *testing.T) {
 		assert.NotEmpty(t, blocks[0].UpdateAt)
 	})
 }
+
+func TestBlockPatchProperties(t *testing.T) {
+	t.Run("merge properties", func(t *testing.T) {
+		block := &Block{
+			Fields: map[string]interface{}{
+				"icon":       "i",
+				"properties": map[string]interface{}{"prop1": "value 1", "prop2": "value 2"},
+			},
+		}
+		patch := &BlockPatch{
+			UpdatedProperties: map[string]interface{}{"prop2": "new value 2", "prop3": "value 3"},
+			DeletedProperties: []string{"prop1"},
+		}
+
+		require.True(t, patch.HasFieldChanges())
+		patch.Patch(block)
+		assert.Equal(t, "i", block.Fields["icon"])
+		assert.Equal(t, map[string]interface{}{"prop2": "new value 2", "prop3": "value 3"}, block.Fields["properties"])
+	})
+
+	t.Run("block without properties", func(t *testing.T) {
+		block := &Block{}
+		patch := &BlockPatch{
+			UpdatedProperties: map[string]interface{}{"prop1": "value 1"},
+		}
+
+		patch.Patch(block)
+		assert.Equal(t, map[string]interface{}{"prop1": "value 1"}, block.Fields["properties"])
+	})
+
+	t.Run("block without fields", func(t *testing.T) {
+		block := &Block{}
+		patch := &BlockPatch{
+			UpdatedFields: map[string]interface{}{"icon": "i"},
+		}
+
+		patch.Patch(block)
+		assert.Equal(t, map[string]interface{}{"icon": "i"}, block.Fields)
+	})
+
+	t.Run("field changes take precedence", func(t *testing.T) {
+		block := &Block{Fields: map[string]interface{}{}}
+		patch := &BlockPatch{
+			UpdatedFields:     map[string]interface{}{"properties": map[string]interface{}{}},
+			UpdatedProperties: map[string]interface{}{"prop1": "value 1"},
+		}
+
+		patch.Patch(block)
+		assert.Equal(t, map[string]interface{}{}, block.Fields["properties"])
+	})
+
+	t.Run("no field changes", func(t *testing.T) {
+		title := "title"
+		patch := &BlockPatch{Title: &title}
+		require.False(t, patch.HasFieldChanges())
+	})
+}
+
+func TestBlockPatchChangedFields(t *testing.T) {
+	t.Run("empty patch", func(t *testing.T) {
+		require.Empty(t, (&BlockPatch{}).ChangedFields())
+	})
+
+	t.Run("attributes, fields and properties", func(t *testing.T) {
+		title := "title"
+		patch := &BlockPatch{
+			Title:             &title,
+			UpdatedFields:     map[string]interface{}{"icon": "i"},
+			DeletedFields:     []string{"isTemplate"},
+			UpdatedProperties: map[string]interface{}{"prop1": "value 1"},
+			DeletedProperties: []string{"prop2"},
+		}
+
+		require.Equal(t,
+			[]string{"fields.icon", "fields.isTemplate", "properties.prop1", "properties.prop2", "title"},
+			patch.ChangedFields(),
+		)
+		require.Equal(t,
+			"changed: fields.icon, fields.isTemplate, properties.prop1, properties.prop2, title",
+			AuditChangedSummary(patch.ChangedFields()),
+		)
+	})
+}
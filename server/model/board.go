@@ -3,17 +3,39 @@ package model
 import (
 	"encoding/json"
 	"io"
+	"sort"
 	"time"
 )
 
 type BoardType string
 type BoardRole string
+type BoardCreationSource string
+type BoardSortBy string
 
 const (
 	BoardTypeOpen    BoardType = "O"
 	BoardTypePrivate BoardType = "P"
 )
 
+const (
+	BoardCreationSourceBlank     BoardCreationSource = "blank"
+	BoardCreationSourceTemplate  BoardCreationSource = "template"
+	BoardCreationSourceImport    BoardCreationSource = "import"
+	BoardCreationSourceDuplicate BoardCreationSource = "duplicate"
+)
+
+// TemplateCustomizedProperty is the board property that marks a default
+// template as customized, which keeps it when the default templates are
+// upgraded.
+const TemplateCustomizedProperty = "customized"
+
+const (
+	BoardSortByNone         BoardSortBy = ""
+	BoardSortByTitle        BoardSortBy = "title"
+	BoardSortByCreated      BoardSortBy = "created"
+	BoardSortByLastActivity BoardSortBy = "last-activity"
+)
+
 const (
 	BoardRoleNone      BoardRole = ""
 	BoardRoleViewer    BoardRole = "viewer"
@@ -96,6 +118,18 @@ type Board struct {
 	// The deleted time in miliseconds since the current epoch. Set to indicate this block is deleted
 	// required: false
 	DeleteAt int64 `json:"deleteAt"`
+
+	// How the board was created: blank, from a template, imported or duplicated
+	// required: false
+	CreationSource BoardCreationSource `json:"creationSource"`
+
+	// The ID of the template or board this board was created from, if any
+	// required: false
+	SourceID string `json:"sourceId"`
+
+	// Whether the requesting user has favorited the board. Only set when requested
+	// required: false
+	Favorite bool `json:"favorite,omitempty"`
 }
 
 // BoardPatch is a patch for modify boards
@@ -144,6 +178,11 @@ type BoardPatch struct {
 	// The board removed card properties
 	// required: false
 	DeletedCardProperties []string `json:"deletedCardProperties"`
+
+	// The update time the board is expected to have. If set and the
+	// board has been updated since, the patch fails with a conflict
+	// required: false
+	ExpectedUpdateAt int64 `json:"expectedUpdateAt,omitempty"`
 }
 
 // BoardMember stores the information of the membership of a user on a board
@@ -157,7 +196,8 @@ type BoardMember struct {
 	// required: true
 	UserID string `json:"userId"`
 
-	// The independent roles of the user on the board
+	// The role of the user on the board, one of viewer, commenter,
+	// editor or admin. Members saved without one are read as editor
 	// required: false
 	Roles string `json:"roles"`
 
@@ -184,6 +224,122 @@ type BoardMember struct {
 	// Marks the membership as generated by an access group
 	// required: true
 	Synthetic bool `json:"synthetic"`
+
+	// The last time the user opened the board in miliseconds since the current epoch. Zero if never opened
+	// required: false
+	LastViewedAt int64 `json:"lastViewedAt"`
+
+	// The ID of the custom role of the user on the board, if any. It
+	// replaces the permissions of the scheme flags
+	// required: false
+	CustomRoleID string `json:"customRoleId"`
+}
+
+// EffectiveRole returns the highest role of the member on the board,
+// taking into account the board minimum role.
+func (bm *BoardMember) EffectiveRole() BoardRole {
+	minimumRole := BoardRole(bm.MinimumRole)
+	switch {
+	case bm.SchemeAdmin || minimumRole == BoardRoleAdmin:
+		return BoardRoleAdmin
+	case bm.SchemeEditor || minimumRole == BoardRoleEditor:
+		return BoardRoleEditor
+	case bm.SchemeCommenter || minimumRole == BoardRoleCommenter:
+		return BoardRoleCommenter
+	case bm.SchemeViewer || minimumRole == BoardRoleViewer:
+		return BoardRoleViewer
+	default:
+		return BoardRoleNone
+	}
+}
+
+// SchemeRole returns the highest role granted by the member scheme
+// flags, without taking into account the board minimum role.
+func (bm *BoardMember) SchemeRole() BoardRole {
+	switch {
+	case bm.SchemeAdmin:
+		return BoardRoleAdmin
+	case bm.SchemeEditor:
+		return BoardRoleEditor
+	case bm.SchemeCommenter:
+		return BoardRoleCommenter
+	case bm.SchemeViewer:
+		return BoardRoleViewer
+	default:
+		return BoardRoleNone
+	}
+}
+
+// SetRole sets the member role and the scheme flags it implies, each
+// role including the permissions of the ones below it.
+func (bm *BoardMember) SetRole(role BoardRole) {
+	bm.Roles = string(role)
+	bm.SchemeAdmin = role == BoardRoleAdmin
+	bm.SchemeEditor = bm.SchemeAdmin || role == BoardRoleEditor
+	bm.SchemeCommenter = bm.SchemeEditor || role == BoardRoleCommenter
+	bm.SchemeViewer = bm.SchemeCommenter || role == BoardRoleViewer
+}
+
+// QueryBoardsOptions are query options that can be passed to
+// GetBoardsForUserAndTeamWithOptions.
+type QueryBoardsOptions struct {
+	IncludePublicBoards bool        // if true then open boards the user isn't a member of are included
+	SortBy              BoardSortBy // if not empty then sort by title, creation time or last activity
+	SortDescending      bool        // if true then sort in descending order
+	Page                int         // page number to select when paginating
+	PerPage             int         // number of boards per page (default=-1, meaning unlimited)
+	IncludeFavorite     bool        // if true then the Favorite field of the boards is set for the user
+	Type                BoardType   // if not empty then only boards of this type are returned
+	IncludeTemplates    bool        // if true then template boards are returned along with the regular ones
+	AfterID             string      // if not empty then select the boards after the board with this ID, boards being sorted by ID
+}
+
+func (o QueryBoardsOptions) IsValid() error {
+	switch o.SortBy {
+	case BoardSortByNone, BoardSortByTitle, BoardSortByCreated, BoardSortByLastActivity:
+	default:
+		return NewErrBadRequest("invalid board sort: " + string(o.SortBy))
+	}
+
+	if o.Type != "" && !IsBoardTypeValid(o.Type) {
+		return NewErrBadRequest("invalid board type: " + string(o.Type))
+	}
+
+	if o.Page < 0 {
+		return NewErrBadRequest("page cannot be negative")
+	}
+
+	if o.AfterID != "" && (o.SortBy != BoardSortByNone || o.Page != 0) {
+		return NewErrBadRequest("a board cursor cannot be combined with a sort or a page")
+	}
+
+	return nil
+}
+
+// QueryBoardMembersOptions are query options that can be passed to
+// GetMembersForBoardWithOptions. The members are sorted by user ID.
+type QueryBoardMembersOptions struct {
+	AfterUserID string // if not empty then select the members after the one with this user ID
+	PerPage     int    // number of members per page (default=0, meaning unlimited)
+}
+
+// BoardWithStats is a board along with the role of the requesting user
+// and the number of members and cards of the board
+// swagger:model
+type BoardWithStats struct {
+	Board
+
+	// The effective role of the requesting user on the board
+	// required: true
+	Role BoardRole `json:"role"`
+
+	// Number of members of the board
+	// required: true
+	MemberCount int64 `json:"memberCount"`
+
+	// Number of active cards of the board
+	// required: true
+	CardCount int64 `json:"cardCount"`
 }
 
 // BoardMetadata contains metadata for a Board
@@ -240,6 +396,41 @@ func BoardMetadataFromJSON(data io.Reader) *BoardMetadata {
 	return boardMetadata
 }
 
+// ChangedFields returns the sorted names of the board attributes the
+// patch modifies.
+func (p *BoardPatch) ChangedFields() []string {
+	changed := []string{}
+	if p.Type != nil {
+		changed = append(changed, "type")
+	}
+	if p.MinimumRole != nil {
+		changed = append(changed, "minimumRole")
+	}
+	if p.Title != nil {
+		changed = append(changed, "title")
+	}
+	if p.Description != nil {
+		changed = append(changed, "description")
+	}
+	if p.Icon != nil {
+		changed = append(changed, "icon")
+	}
+	if p.ShowDescription != nil {
+		changed = append(changed, "showDescription")
+	}
+	if p.ChannelID != nil {
+		changed = append(changed, "channelId")
+	}
+	if len(p.UpdatedProperties) > 0 || len(p.DeletedProperties) > 0 {
+		changed = append(changed, "properties")
+	}
+	if len(p.UpdatedCardProperties) > 0 || len(p.DeletedCardProperties) > 0 {
+		changed = append(changed, "cardProperties")
+	}
+	sort.Strings(changed)
+	return changed
+}
+
 // Patch returns an updated version of the board.
 func (p *BoardPatch) Patch(board *Board) *Board {
 	if p.Type != nil {
@@ -337,6 +528,10 @@ func IsBoardMinimumRoleValid(r BoardRole) bool {
 	return r == BoardRoleNone || r == BoardRoleAdmin || r == BoardRoleEditor || r == BoardRoleCommenter || r == BoardRoleViewer
 }
 
+func IsBoardMemberRoleValid(r BoardRole) bool {
+	return r == BoardRoleAdmin || r == BoardRoleEditor || r == BoardRoleCommenter || r == BoardRoleViewer
+}
+
 func (p *BoardPatch) IsValid() error {
 	if p.Type != nil && !IsBoardTypeValid(*p.Type) {
 		return InvalidBoardErr{"invalid-board-type"}
@@ -373,6 +568,47 @@ func (b *Board) IsValid() error {
 	return nil
 }
 
+// IsCustomizedTemplate returns true if the board is a template marked as
+// customized by its users.
+func (b *Board) IsCustomizedTemplate() bool {
+	if !b.IsTemplate {
+		return false
+	}
+	customized, _ := b.Properties[TemplateCustomizedProperty].(bool)
+	return customized
+}
+
+// SetCardPropertyOrder reorders the card properties of the board. The
+// property IDs must match the board's card properties exactly.
+func (b *Board) SetCardPropertyOrder(propertyIDs []string) error {
+	if len(propertyIDs) != len(b.CardProperties) {
+		return InvalidBoardErr{"invalid-card-property-order"}
+	}
+
+	cardPropertyMap := make(map[string]map[string]interface{}, len(b.CardProperties))
+	for _, prop := range b.CardProperties {
+		id, ok := prop["id"].(string)
+		if !ok {
+			return InvalidBoardErr{"invalid-card-property"}
+		}
+		cardPropertyMap[id] = prop
+	}
+
+	newCardProperties := make([]map[string]interface{}, 0, len(propertyIDs))
+	for _, id := range propertyIDs {
+		prop, ok := cardPropertyMap[id]
+		if !ok {
+			// unknown or duplicated property
+			return InvalidBoardErr{"invalid-card-property-order"}
+		}
+		newCardProperties = append(newCardProperties, prop)
+		delete(cardPropertyMap, id)
+	}
+
+	b.CardProperties = newCardProperties
+	return nil
+}
+
 // BoardMemberHistoryEntry stores the information of the membership of a user on a board
 // swagger:model
 type BoardMemberHistoryEntry struct {
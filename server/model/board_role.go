@@ -0,0 +1,103 @@
+package model
+
+import (
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// The permissions a custom board role can grant, as bits of its
+// permissions mask. Managing the board type, its roles and deleting the
+// board stay reserved to the board admins.
+const (
+	RolePermissionViewBoard int64 = 1 << iota
+	RolePermissionCommentBoardCards
+	RolePermissionCreateBoardCards
+	RolePermissionManageBoardCards
+	RolePermissionDeleteBoardCards
+	RolePermissionManageBoardProperties
+	RolePermissionShareBoard
+	RolePermissionDeleteOthersComments
+
+	RolePermissionAll = RolePermissionViewBoard | RolePermissionCommentBoardCards | RolePermissionCreateBoardCards |
+		RolePermissionManageBoardCards | RolePermissionDeleteBoardCards | RolePermissionManageBoardProperties |
+		RolePermissionShareBoard | RolePermissionDeleteOthersComments
+)
+
+const boardCustomRoleNameMaxLength = 100
+
+var rolePermissionBits = map[*mmModel.Permission]int64{
+	PermissionViewBoard:             RolePermissionViewBoard,
+	PermissionCommentBoardCards:     RolePermissionCommentBoardCards,
+	PermissionCreateBoardCards:      RolePermissionCreateBoardCards,
+	PermissionManageBoardCards:      RolePermissionManageBoardCards,
+	PermissionDeleteBoardCards:      RolePermissionDeleteBoardCards,
+	PermissionManageBoardProperties: RolePermissionManageBoardProperties,
+	PermissionShareBoard:            RolePermissionShareBoard,
+	PermissionDeleteOthersComments:  RolePermissionDeleteOthersComments,
+}
+
+// BoardCustomRole is a named role defined by the admins of a board, which
+// grants the members it is assigned to a set of permissions on the board
+// swagger:model
+type BoardCustomRole struct {
+	// The ID of the role
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the board the role belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The name of the role
+	// required: true
+	Name string `json:"name"`
+
+	// The permissions granted by the role, as a mask of RolePermission bits
+	// required: true
+	Permissions int64 `json:"permissions"`
+
+	// The ID of the user that created the role
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// IsValid validates a custom board role.
+func (r *BoardCustomRole) IsValid() error {
+	if r == nil {
+		return ErrInvalidBoardCustomRole{"cannot be nil"}
+	}
+	if r.BoardID == "" {
+		return ErrInvalidBoardCustomRole{"missing board id"}
+	}
+	if r.Name == "" {
+		return ErrInvalidBoardCustomRole{"missing name"}
+	}
+	if len(r.Name) > boardCustomRoleNameMaxLength {
+		return ErrInvalidBoardCustomRole{"name too long"}
+	}
+	if r.Permissions&^RolePermissionAll != 0 {
+		return ErrInvalidBoardCustomRole{"unknown permissions"}
+	}
+	return nil
+}
+
+// HasPermission returns true if the role grants the permission.
+func (r *BoardCustomRole) HasPermission(permission *mmModel.Permission) bool {
+	bit, ok := rolePermissionBits[permission]
+	return ok && r.Permissions&bit != 0
+}
+
+type ErrInvalidBoardCustomRole struct {
+	msg string
+}
+
+func (e ErrInvalidBoardCustomRole) Error() string {
+	return e.msg
+}
@@ -13,3 +13,27 @@ type BoardsStatistics struct {
 	// required: true
 	Cards int `json:"card_count"`
 }
+
+// BoardStats is the representation of the statistics of a single board
+// swagger:model
+type BoardStats struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The number of cards on the board
+	// required: true
+	CardCount int64 `json:"cardCount"`
+
+	// The number of blocks on the board, including cards
+	// required: true
+	BlockCount int64 `json:"blockCount"`
+
+	// Last time a block of the board was modified, in miliseconds since the current epoch
+	// required: true
+	LastModified int64 `json:"lastModified"`
+
+	// The number of distinct users that have created or modified blocks of the board
+	// required: true
+	ContributorCount int64 `json:"contributorCount"`
+}
@@ -38,6 +38,27 @@ type BoardsAndBlocks struct {
 	Blocks []*Block `json:"blocks"`
 }
 
+// DuplicateBoardOptions are the options used when duplicating a
+// board.
+type DuplicateBoardOptions struct {
+	// The team to create the copy in, or the team of the board if empty
+	ToTeam string
+
+	// Whether the copy is a template
+	AsTemplate bool
+
+	// Whether to copy the cards along with their content. Without
+	// them, only the structure of the board, like its views, is copied
+	IncludeCards bool
+
+	// Whether to copy the members of the board. The duplicating user
+	// is made an admin of the copy either way
+	IncludeMembers bool
+
+	// Whether to also copy the blocks that are marked as deleted
+	IncludeDeletedBlocks bool
+}
+
 func (bab *BoardsAndBlocks) IsValid() error {
 	if len(bab.Boards) == 0 {
 		return ErrNoBoardsInBoardsAndBlocks
@@ -149,6 +170,10 @@ func GenerateBoardsAndBlocksIDs(bab *BoardsAndBlocks, logger mlog.LoggerIFace) (
 	for _, board := range bab.Boards {
 		newID := utils.NewID(utils.IDTypeBoard)
 		for _, block := range blocksByBoard[board.ID] {
+			// top level blocks have the board as their parent
+			if block.ParentID == board.ID {
+				block.ParentID = newID
+			}
 			block.BoardID = newID
 			blocks = append(blocks, block)
 		}
@@ -84,6 +84,10 @@ type Card struct {
 	// The deleted time in milliseconds since the current epoch. Set to indicate this card is deleted
 	// required: false
 	DeleteAt int64 `json:"deleteAt"`
+
+	// The archived time in milliseconds since the current epoch. Set to indicate this card is archived
+	// required: false
+	ArchivedAt int64 `json:"archivedAt"`
 }
 
 // Populate populates a Card with default values.
@@ -214,6 +218,7 @@ func Card2Block(card *Card) *Block {
 		UpdateAt:   card.UpdateAt,
 		DeleteAt:   card.DeleteAt,
 		BoardID:    card.BoardID,
+		ArchivedAt: card.ArchivedAt,
 	}
 }
 
@@ -284,6 +289,7 @@ func Block2Card(block *Block) (*Card, error) {
 		CreateAt:     block.CreateAt,
 		UpdateAt:     block.UpdateAt,
 		DeleteAt:     block.DeleteAt,
+		ArchivedAt:   block.ArchivedAt,
 	}
 	card.Populate()
 	return card, nil
@@ -321,3 +327,72 @@ func CardPatch2BlockPatch(cardPatch *CardPatch) (*BlockPatch, error) {
 
 	return blockPatch, nil
 }
+
+// ReconcileContentOrder returns a card content order that only references
+// the given content block IDs. Unknown and repeated IDs are dropped, as
+// are the rows left empty, and the content blocks that are not referenced
+// are appended in the given order. Rows of several IDs are kept as rows.
+func ReconcileContentOrder(contentOrder []interface{}, contentIDs []string) []interface{} {
+	exists := make(map[string]bool, len(contentIDs))
+	for _, id := range contentIDs {
+		exists[id] = true
+	}
+
+	seen := make(map[string]bool, len(contentIDs))
+	keep := func(item interface{}) (string, bool) {
+		id, ok := item.(string)
+		if !ok || !exists[id] || seen[id] {
+			return "", false
+		}
+		seen[id] = true
+		return id, true
+	}
+
+	result := make([]interface{}, 0, len(contentIDs))
+	for _, item := range contentOrder {
+		switch v := item.(type) {
+		case string:
+			if id, ok := keep(v); ok {
+				result = append(result, id)
+			}
+		case []interface{}:
+			row := make([]interface{}, 0, len(v))
+			for _, rowItem := range v {
+				if id, ok := keep(rowItem); ok {
+					row = append(row, id)
+				}
+			}
+			if len(row) > 0 {
+				result = append(result, row)
+			}
+		}
+	}
+
+	for _, id := range contentIDs {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// FlattenContentOrder returns the content block IDs of a card content
+// order in display order.
+func FlattenContentOrder(contentOrder []interface{}) []string {
+	ids := make([]string, 0, len(contentOrder))
+	for _, item := range contentOrder {
+		switch v := item.(type) {
+		case string:
+			ids = append(ids, v)
+		case []interface{}:
+			for _, rowItem := range v {
+				if id, ok := rowItem.(string); ok {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	return ids
+}
@@ -82,3 +82,30 @@ const sampleBlockFieldsJSON = `
 	   "d9725d14-d5a8-48e5-8de1-6f8c004a9680":"3245a32d-f688-463b-87f4-8e7142c1b397"
 	}
 }`
+
+func TestReconcileContentOrder(t *testing.T) {
+	t.Run("drop missing and repeated ids", func(t *testing.T) {
+		contentOrder := []interface{}{"a", "missing", "b", "a", []interface{}{"c", "missing2"}, []interface{}{"missing3"}}
+		result := ReconcileContentOrder(contentOrder, []string{"a", "b", "c"})
+		require.Equal(t, []interface{}{"a", "b", []interface{}{"c"}}, result)
+	})
+
+	t.Run("append orphans in the given order", func(t *testing.T) {
+		contentOrder := []interface{}{"b"}
+		result := ReconcileContentOrder(contentOrder, []string{"c", "a", "b"})
+		require.Equal(t, []interface{}{"b", "c", "a"}, result)
+	})
+
+	t.Run("empty content order", func(t *testing.T) {
+		result := ReconcileContentOrder(nil, []string{"a"})
+		require.Equal(t, []interface{}{"a"}, result)
+
+		result = ReconcileContentOrder([]interface{}{"a"}, nil)
+		require.Empty(t, result)
+	})
+
+	t.Run("flatten content order", func(t *testing.T) {
+		contentOrder := []interface{}{"a", []interface{}{"b", "c"}, "d"}
+		require.Equal(t, []string{"a", "b", "c", "d"}, FlattenContentOrder(contentOrder))
+	})
+}
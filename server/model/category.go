@@ -52,6 +52,14 @@ type Category struct {
 	// Category's type
 	// required: true
 	Type string `json:"type"`
+
+	// The position of the category in the sidebar. Set when the category is created and changed by reordering the categories
+	// required: false
+	SortOrder int `json:"sortOrder"`
+
+	// The icon for this category
+	// required: false
+	Icon string `json:"icon"`
 }
 
 func (c *Category) Hydrate() {
@@ -87,6 +95,55 @@ func (c *Category) IsValid() error {
 	return nil
 }
 
+// CategoryTemplate is one of the categories every new user of a team
+// gets in their sidebar
+// swagger:model
+type CategoryTemplate struct {
+	// The id for this category template
+	// required: true
+	ID string `json:"id"`
+
+	// The team id for this category template
+	// required: true
+	TeamID string `json:"teamID"`
+
+	// The name of the categories created from this template
+	// required: true
+	Name string `json:"name"`
+
+	// The position of the categories created from this template in the sidebar
+	// required: false
+	SortOrder int `json:"sortOrder"`
+
+	// The icon of the categories created from this template
+	// required: false
+	Icon string `json:"icon"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (t *CategoryTemplate) IsValid() error {
+	if strings.TrimSpace(t.ID) == "" {
+		return NewErrInvalidCategory("category template ID cannot be empty")
+	}
+
+	if strings.TrimSpace(t.TeamID) == "" {
+		return NewErrInvalidCategory("category template team ID cannot be empty")
+	}
+
+	if strings.TrimSpace(t.Name) == "" {
+		return NewErrInvalidCategory("category template name cannot be empty")
+	}
+
+	return nil
+}
+
 func CategoryFromJSON(data io.Reader) *Category {
 	var category *Category
 	_ = json.NewDecoder(data).Decode(&category)
@@ -5,7 +5,7 @@ package model
 type CategoryBoards struct {
 	Category
 
-	// The IDs of boards in this category
+	// The IDs of boards in this category, in the user-defined order
 	// required: true
 	BoardIDs []string `json:"boardIDs"`
 }
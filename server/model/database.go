@@ -4,4 +4,5 @@ const (
 	SqliteDBType   = "sqlite3"
 	PostgresDBType = "postgres"
 	MysqlDBType    = "mysql"
+	MemoryDBType   = "memory"
 )
@@ -21,7 +21,12 @@ var (
 	ErrCategoryPermissionDenied = errors.New("category doesn't belong to user")
 	ErrCategoryDeleted          = errors.New("category is deleted")
 
+	ErrSharingExpired = errors.New("sharing is expired")
+
 	ErrBoardMemberIsLastAdmin = errors.New("cannot leave a board with no admins")
+	ErrBoardMemberLimit       = errors.New("board member limit reached")
+
+	ErrBoardMemberIsLastAdminRole = errors.New("cannot remove the admin role from the last admin of a board")
 
 	ErrRequestEntityTooLarge = errors.New("request entity too large")
 )
@@ -43,6 +48,83 @@ func (nf *ErrNotFound) Error() string {
 	return fmt.Sprintf("{%s} not found", nf.entity)
 }
 
+// ErrMemberBoardNotFound is an error type that can be returned by store
+// APIs when a board member references a board that doesn't exist.
+type ErrMemberBoardNotFound struct {
+	BoardID string
+}
+
+// NewErrMemberBoardNotFound creates a new ErrMemberBoardNotFound instance.
+func NewErrMemberBoardNotFound(boardID string) *ErrMemberBoardNotFound {
+	return &ErrMemberBoardNotFound{
+		BoardID: boardID,
+	}
+}
+
+func (e *ErrMemberBoardNotFound) Error() string {
+	return fmt.Sprintf("board %s of member not found", e.BoardID)
+}
+
+// ErrBlockLimitExceeded is an error type that can be returned by store
+// APIs when inserting blocks would exceed the block limit of a board.
+type ErrBlockLimitExceeded struct {
+	BoardID string
+	Count   int
+	Limit   int
+}
+
+// NewErrBlockLimitExceeded creates a new ErrBlockLimitExceeded instance.
+func NewErrBlockLimitExceeded(boardID string, count, limit int) *ErrBlockLimitExceeded {
+	return &ErrBlockLimitExceeded{
+		BoardID: boardID,
+		Count:   count,
+		Limit:   limit,
+	}
+}
+
+func (e *ErrBlockLimitExceeded) Error() string {
+	return fmt.Sprintf("board %s has %d blocks and a limit of %d blocks", e.BoardID, e.Count, e.Limit)
+}
+
+// ErrDuplicate is an error type that can be returned by store APIs
+// when an insert or update violates a unique constraint.
+type ErrDuplicate struct {
+	resource string
+}
+
+// NewErrDuplicate creates a new ErrDuplicate instance.
+func NewErrDuplicate(resource string) *ErrDuplicate {
+	return &ErrDuplicate{
+		resource: resource,
+	}
+}
+
+func (d *ErrDuplicate) Error() string {
+	return fmt.Sprintf("{%s} already exists", d.resource)
+}
+
+// ErrConflict is an error type that can be returned by store APIs
+// when an update expects a version of an entity that is no longer the
+// stored one, so the caller should refetch the entity and retry.
+type ErrConflict struct {
+	resource         string
+	expectedUpdateAt int64
+	updateAt         int64
+}
+
+// NewErrConflict creates a new ErrConflict instance.
+func NewErrConflict(resource string, expectedUpdateAt, updateAt int64) *ErrConflict {
+	return &ErrConflict{
+		resource:         resource,
+		expectedUpdateAt: expectedUpdateAt,
+		updateAt:         updateAt,
+	}
+}
+
+func (c *ErrConflict) Error() string {
+	return fmt.Sprintf("{%s} was updated at %d, expected %d", c.resource, c.updateAt, c.expectedUpdateAt)
+}
+
 // ErrNotAllFound is an error type that can be returned by store APIs
 // when a query that should fetch a certain amount of records
 // unexpectedly fetches less.
@@ -164,6 +246,8 @@ func (ni *ErrNotImplemented) Error() string {
 // - model.ErrAuthParam
 // - model.ErrInvalidCategory
 // - model.ErrBoardMemberIsLastAdmin
+// - model.ErrBoardMemberLimit
+// - model.ErrBlockLimitExceeded
 // - model.ErrBoardIDMismatch.
 func IsErrBadRequest(err error) bool {
 	if err == nil {
@@ -198,6 +282,17 @@ func IsErrBadRequest(err error) bool {
 		return true
 	}
 
+	// check if this is a model.ErrBoardMemberLimit
+	if errors.Is(err, ErrBoardMemberLimit) {
+		return true
+	}
+
+	// check if this is a model.ErrBlockLimitExceeded
+	var ble *ErrBlockLimitExceeded
+	if errors.As(err, &ble) {
+		return true
+	}
+
 	// check if this is a model.ErrBoardMemberIsLastAdmin
 	return errors.Is(err, ErrBoardIDMismatch)
 }
@@ -248,9 +343,11 @@ func IsErrForbidden(err error) bool {
 // IsErrNotFound returns true if `err` is or wraps one of:
 // - model.ErrNotFound
 // - model.ErrNotAllFound
+// - model.ErrMemberBoardNotFound
 // - sql.ErrNoRows
 // - mattermost-plugin-api/ErrNotFound.
 // - model.ErrCategoryDeleted.
+// - model.ErrSharingExpired.
 func IsErrNotFound(err error) bool {
 	if err == nil {
 		return false
@@ -268,6 +365,12 @@ func IsErrNotFound(err error) bool {
 		return true
 	}
 
+	// check if this is a model.ErrMemberBoardNotFound
+	var mbnf *ErrMemberBoardNotFound
+	if errors.As(err, &mbnf) {
+		return true
+	}
+
 	// check if this is a sql.ErrNotFound
 	if errors.Is(err, sql.ErrNoRows) {
 		return true
@@ -286,10 +389,45 @@ func IsErrNotFound(err error) bool {
 		}
 	}
 
+	// check if this is a model.ErrSharingExpired
+	if errors.Is(err, ErrSharingExpired) {
+		return true
+	}
+
 	// check if this is a model.ErrCategoryDeleted
 	return errors.Is(err, ErrCategoryDeleted)
 }
 
+// IsErrConflict returns true if `err` is or wraps one of:
+// - model.ErrDuplicate
+// - model.ErrConflict
+// - model.ErrBoardMemberIsLastAdminRole.
+func IsErrConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// check if this is a model.ErrDuplicate
+	if IsErrDuplicate(err) {
+		return true
+	}
+
+	// check if this is a model.ErrConflict
+	var c *ErrConflict
+	if errors.As(err, &c) {
+		return true
+	}
+
+	// check if this is a model.ErrBoardMemberIsLastAdminRole
+	return errors.Is(err, ErrBoardMemberIsLastAdminRole)
+}
+
+// IsErrDuplicate returns true if `err` is or wraps a model.ErrDuplicate.
+func IsErrDuplicate(err error) bool {
+	var d *ErrDuplicate
+	return errors.As(err, &d)
+}
+
 // IsErrRequestEntityTooLarge returns true if `err` is or wraps one of:
 // - model.ErrRequestEntityTooLarge.
 func IsErrRequestEntityTooLarge(err error) bool {
@@ -48,6 +48,44 @@ type ImportArchiveOptions struct {
 	BlockModifier BlockModifier
 }
 
+// ImportExternalOptions provides options when importing the export of
+// another tool.
+type ImportExternalOptions struct {
+	TeamID     string
+	ModifiedBy string
+
+	// BatchSize is the number of blocks written at a time.
+	BatchSize int
+
+	// Progress, if set, is called after each batch is written.
+	Progress func(ImportProgress)
+}
+
+// ImportProgress is the progress of an import.
+// swagger:model
+type ImportProgress struct {
+	// The number of blocks imported so far
+	// required: true
+	BlocksImported int `json:"blocksImported"`
+
+	// The total number of blocks to import
+	// required: true
+	BlocksTotal int `json:"blocksTotal"`
+}
+
+// ImportExternalResult is the outcome of importing the export of another
+// tool.
+// swagger:model
+type ImportExternalResult struct {
+	// The imported board
+	// required: true
+	Board *Board `json:"board"`
+
+	// The number of blocks imported
+	// required: true
+	BlocksImported int `json:"blocksImported"`
+}
+
 // ErrUnsupportedArchiveVersion is an error returned when trying to import an
 // archive with a version that this server does not support.
 type ErrUnsupportedArchiveVersion struct {
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/mattermost/focalboard/server/utils"
@@ -143,20 +144,37 @@ func (pd PropDef) ParseDate(s string) (string, error) {
 	return date, nil
 }
 
-// ParsePropertySchema parses a board block's `Fields` to extract the properties
-// schema for all cards within the board.
-// The result is provided as a map for quick lookup, and the original order is
-// preserved via the `Index` field.
-func ParsePropertySchema(board *Board) (PropSchema, error) {
-	schema := make(map[string]PropDef)
+// CardProperty is a card property definition of a board.
+// swagger:model
+type CardProperty struct {
+	// The ID of the property
+	// required: true
+	ID string `json:"id"`
 
-	for i, prop := range board.CardProperties {
-		pd := PropDef{
+	// The name of the property
+	// required: true
+	Name string `json:"name"`
+
+	// The type of the property (e.g. select, date, person)
+	// required: true
+	Type string `json:"type"`
+
+	// The options of the property, in order
+	// required: true
+	Options []PropDefOption `json:"options"`
+}
+
+// ParseCardProperties parses the card property definitions of a board,
+// preserving their order.
+func ParseCardProperties(board *Board) ([]CardProperty, error) {
+	properties := make([]CardProperty, 0, len(board.CardProperties))
+
+	for _, prop := range board.CardProperties {
+		cp := CardProperty{
 			ID:      getMapString("id", prop),
-			Index:   i,
 			Name:    getMapString("name", prop),
 			Type:    getMapString("type", prop),
-			Options: make(map[string]PropDefOption),
+			Options: []PropDefOption{},
 		}
 		optsIface, ok := prop["options"]
 		if ok {
@@ -169,15 +187,41 @@ func ParsePropertySchema(board *Board) (PropSchema, error) {
 				if !ok {
 					return nil, ErrInvalidPropSchema
 				}
-				po := PropDefOption{
+				cp.Options = append(cp.Options, PropDefOption{
 					ID:    getMapString("id", propOpt),
 					Index: j,
 					Value: getMapString("value", propOpt),
 					Color: getMapString("color", propOpt),
-				}
-				pd.Options[po.ID] = po
+				})
 			}
 		}
+		properties = append(properties, cp)
+	}
+	return properties, nil
+}
+
+// ParsePropertySchema parses a board block's `Fields` to extract the properties
+// schema for all cards within the board.
+// The result is provided as a map for quick lookup, and the original order is
+// preserved via the `Index` field.
+func ParsePropertySchema(board *Board) (PropSchema, error) {
+	properties, err := ParseCardProperties(board)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := make(map[string]PropDef)
+	for i, prop := range properties {
+		pd := PropDef{
+			ID:      prop.ID,
+			Index:   i,
+			Name:    prop.Name,
+			Type:    prop.Type,
+			Options: make(map[string]PropDefOption),
+		}
+		for _, po := range prop.Options {
+			pd.Options[po.ID] = po
+		}
 		schema[pd.ID] = pd
 	}
 	return schema, nil
@@ -243,3 +287,143 @@ func ParseProperties(block *Block, schema PropSchema, resolver PropValueResolver
 	}
 	return props, nil
 }
+
+// SchemaIssue is an inconsistency between a card property value and the
+// board's property definitions.
+type SchemaIssue struct {
+	CardID     string `json:"cardId"`
+	PropertyID string `json:"propertyId"`
+	Value      string `json:"value"`
+}
+
+// SchemaReport lists the inconsistencies between the property definitions of
+// a board and the property values of its cards.
+// swagger:model
+type SchemaReport struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The number of cards checked
+	// required: true
+	CardCount int `json:"cardCount"`
+
+	// Values referencing options that don't exist in their property definition
+	// required: true
+	MissingOptions []SchemaIssue `json:"missingOptions"`
+
+	// Values whose type doesn't match their property definition
+	// required: true
+	TypeMismatches []SchemaIssue `json:"typeMismatches"`
+
+	// Values of properties that are not defined on the board
+	// required: true
+	OrphanedProperties []SchemaIssue `json:"orphanedProperties"`
+}
+
+// HasIssues returns true if the report contains any inconsistency.
+func (r *SchemaReport) HasIssues() bool {
+	return len(r.MissingOptions) > 0 || len(r.TypeMismatches) > 0 || len(r.OrphanedProperties) > 0
+}
+
+// CheckCardsAgainstSchema reports the property values of the given cards that
+// are inconsistent with the schema. Empty values are not reported.
+func CheckCardsAgainstSchema(boardID string, schema PropSchema, cards []*Block) *SchemaReport {
+	report := &SchemaReport{
+		BoardID:            boardID,
+		MissingOptions:     []SchemaIssue{},
+		TypeMismatches:     []SchemaIssue{},
+		OrphanedProperties: []SchemaIssue{},
+	}
+
+	for _, card := range cards {
+		report.CardCount++
+
+		blockProps, ok := card.Fields["properties"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for propID, v := range blockProps {
+			if isEmptyPropValue(v) {
+				continue
+			}
+
+			issue := SchemaIssue{CardID: card.ID, PropertyID: propID, Value: fmt.Sprintf("%v", v)}
+
+			def, ok := schema[propID]
+			if !ok {
+				report.OrphanedProperties = append(report.OrphanedProperties, issue)
+				continue
+			}
+
+			var optionIDs []interface{}
+			switch def.Type {
+			case "select":
+				id, ok := v.(string)
+				if !ok {
+					report.TypeMismatches = append(report.TypeMismatches, issue)
+					continue
+				}
+				optionIDs = []interface{}{id}
+			case "multiSelect":
+				ids, ok := v.([]interface{})
+				if !ok {
+					report.TypeMismatches = append(report.TypeMismatches, issue)
+					continue
+				}
+				optionIDs = ids
+			case "multiPerson":
+				if _, ok := v.([]interface{}); !ok {
+					report.TypeMismatches = append(report.TypeMismatches, issue)
+				}
+				continue
+			default:
+				if _, ok := v.(string); !ok {
+					report.TypeMismatches = append(report.TypeMismatches, issue)
+				}
+				continue
+			}
+
+			for _, optIface := range optionIDs {
+				optID, ok := optIface.(string)
+				if !ok {
+					report.TypeMismatches = append(report.TypeMismatches, issue)
+					break
+				}
+				if _, ok := def.Options[optID]; !ok {
+					report.MissingOptions = append(report.MissingOptions, SchemaIssue{CardID: card.ID, PropertyID: propID, Value: optID})
+				}
+			}
+		}
+	}
+
+	sortSchemaIssues(report.MissingOptions)
+	sortSchemaIssues(report.TypeMismatches)
+	sortSchemaIssues(report.OrphanedProperties)
+	return report
+}
+
+func sortSchemaIssues(issues []SchemaIssue) {
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].CardID != issues[j].CardID {
+			return issues[i].CardID < issues[j].CardID
+		}
+		if issues[i].PropertyID != issues[j].PropertyID {
+			return issues[i].PropertyID < issues[j].PropertyID
+		}
+		return issues[i].Value < issues[j].Value
+	})
+}
+
+func isEmptyPropValue(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case []interface{}:
+		return len(val) == 0
+	}
+	return false
+}
@@ -44,6 +44,113 @@ func Test_parsePropertySchema(t *testing.T) {
 	})
 }
 
+func Test_parseCardProperties(t *testing.T) {
+	board := &Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		Title:  "Test Board",
+		TeamID: utils.NewID(utils.IDTypeTeam),
+	}
+
+	t.Run("no properties", func(t *testing.T) {
+		properties, err := ParseCardProperties(board)
+		require.NoError(t, err)
+		assert.NotNil(t, properties)
+		assert.Empty(t, properties)
+	})
+
+	t.Run("parse properties", func(t *testing.T) {
+		err := json.Unmarshal([]byte(cardPropertiesExample), &board.CardProperties)
+		require.NoError(t, err)
+
+		properties, err := ParseCardProperties(board)
+		require.NoError(t, err)
+		require.Len(t, properties, 6)
+
+		assert.Equal(t, "7c212e78-9345-4c60-81b5-0b0e37ce463f", properties[0].ID)
+		assert.Equal(t, "Type", properties[0].Name)
+		assert.Equal(t, "select", properties[0].Type)
+		require.Len(t, properties[0].Options, 3)
+		assert.Equal(t, PropDefOption{ID: "31da50ca-f1a9-4d21-8636-17dc387c1a23", Index: 0, Color: "propColorYellow", Value: "Ad Hoc"}, properties[0].Options[0])
+		assert.Equal(t, "Weekly Sync", properties[0].Options[2].Value)
+
+		assert.Equal(t, "Summary", properties[1].Name)
+		assert.NotNil(t, properties[1].Options)
+		assert.Empty(t, properties[1].Options)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		board.CardProperties = []map[string]interface{}{
+			{"id": "prop1", "name": "Prop", "type": "select", "options": "invalid"},
+		}
+
+		_, err := ParseCardProperties(board)
+		require.ErrorIs(t, err, ErrInvalidPropSchema)
+	})
+}
+
+func Test_checkCardsAgainstSchema(t *testing.T) {
+	board := &Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		Title:  "Test Board",
+		TeamID: utils.NewID(utils.IDTypeTeam),
+	}
+
+	err := json.Unmarshal([]byte(cardPropertiesExample), &board.CardProperties)
+	require.NoError(t, err)
+
+	schema, err := ParsePropertySchema(board)
+	require.NoError(t, err)
+
+	card := func(id string, properties map[string]interface{}) *Block {
+		return &Block{ID: id, BoardID: board.ID, Type: TypeCard, Fields: map[string]interface{}{"properties": properties}}
+	}
+
+	t.Run("consistent cards", func(t *testing.T) {
+		cards := []*Block{
+			card("card1", map[string]interface{}{
+				"7c212e78-9345-4c60-81b5-0b0e37ce463f": "31da50ca-f1a9-4d21-8636-17dc387c1a23",
+				"13d2394a-eb5e-4f22-8c22-6515ec41c4a4": "a summary",
+			}),
+			card("card2", map[string]interface{}{
+				"7c212e78-9345-4c60-81b5-0b0e37ce463f": "",
+				"orphaned-but-empty":                   []interface{}{},
+			}),
+			{ID: "card3", BoardID: board.ID, Type: TypeCard},
+		}
+
+		report := CheckCardsAgainstSchema(board.ID, schema, cards)
+		assert.Equal(t, board.ID, report.BoardID)
+		assert.Equal(t, 3, report.CardCount)
+		assert.False(t, report.HasIssues())
+	})
+
+	t.Run("inconsistent cards", func(t *testing.T) {
+		cards := []*Block{
+			card("card1", map[string]interface{}{
+				"7c212e78-9345-4c60-81b5-0b0e37ce463f": "deleted-option",
+				"13d2394a-eb5e-4f22-8c22-6515ec41c4a4": []interface{}{"not", "text"},
+			}),
+			card("card2", map[string]interface{}{
+				"7c212e78-9345-4c60-81b5-0b0e37ce463f": []interface{}{"31da50ca-f1a9-4d21-8636-17dc387c1a23"},
+				"orphaned-property":                    "value",
+			}),
+		}
+
+		report := CheckCardsAgainstSchema(board.ID, schema, cards)
+		assert.True(t, report.HasIssues())
+		assert.Equal(t, []SchemaIssue{
+			{CardID: "card1", PropertyID: "7c212e78-9345-4c60-81b5-0b0e37ce463f", Value: "deleted-option"},
+		}, report.MissingOptions)
+		assert.Equal(t, []SchemaIssue{
+			{CardID: "card1", PropertyID: "13d2394a-eb5e-4f22-8c22-6515ec41c4a4", Value: "[not text]"},
+			{CardID: "card2", PropertyID: "7c212e78-9345-4c60-81b5-0b0e37ce463f", Value: "[31da50ca-f1a9-4d21-8636-17dc387c1a23]"},
+		}, report.TypeMismatches)
+		assert.Equal(t, []SchemaIssue{
+			{CardID: "card2", PropertyID: "orphaned-property", Value: "value"},
+		}, report.OrphanedProperties)
+	})
+}
+
 const (
 	cardPropertiesExample = `[
 	   {
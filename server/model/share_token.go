@@ -0,0 +1,121 @@
+package model
+
+// The scopes of a share token.
+const (
+	ShareTokenScopeBoard = "board"
+	ShareTokenScopeCard  = "card"
+)
+
+// ShareToken is one of the read-only links of a board. Unlike the board
+// Sharing, a board can have several share tokens, each one with its own
+// expiry, optional password and scope
+// swagger:model
+type ShareToken struct {
+	// ID of the share token
+	// required: true
+	ID string `json:"id"`
+
+	// ID of the shared board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The token used in the share link
+	// required: true
+	Token string `json:"token"`
+
+	// The scope of the token, either "board" or "card"
+	// required: true
+	Scope string `json:"scope"`
+
+	// ID of the shared card, for card scoped tokens
+	// required: false
+	CardID string `json:"cardId,omitempty"`
+
+	// The bcrypt hash of the password protecting the token, if any
+	PasswordHash string `json:"-"`
+
+	// Whether the token is protected by a password
+	// required: true
+	HasPassword bool `json:"hasPassword"`
+
+	// Expiration time in miliseconds since the current epoch, zero if
+	// the token never expires
+	// required: false
+	ExpireAt int64 `json:"expireAt,omitempty"`
+
+	// ID of the user who created the token
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// Created time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// ShareTokenWithPassword is a share token along with the password that
+// protects it, as sent by the clients when creating the token
+// swagger:model
+type ShareTokenWithPassword struct {
+	ShareToken
+
+	// The password protecting the token, empty for no password
+	// required: false
+	Password string `json:"password,omitempty"`
+}
+
+// SharedContent is the content a share token gives access to
+// swagger:model
+type SharedContent struct {
+	// The scope of the share token
+	// required: true
+	Scope string `json:"scope"`
+
+	// The shared board
+	// required: true
+	Board *Board `json:"board"`
+
+	// The blocks of the board, or of the card and its contents for card
+	// scoped tokens
+	// required: true
+	Blocks []*Block `json:"blocks"`
+}
+
+// IsValid validates a share token.
+func (t *ShareToken) IsValid() error {
+	if t == nil {
+		return ErrInvalidShareToken{"cannot be nil"}
+	}
+	if t.BoardID == "" {
+		return ErrInvalidShareToken{"missing board id"}
+	}
+	switch t.Scope {
+	case ShareTokenScopeBoard:
+		if t.CardID != "" {
+			return ErrInvalidShareToken{"board scoped token cannot have a card id"}
+		}
+	case ShareTokenScopeCard:
+		if t.CardID == "" {
+			return ErrInvalidShareToken{"missing card id"}
+		}
+	default:
+		return ErrInvalidShareToken{"invalid scope"}
+	}
+	if t.ExpireAt < 0 {
+		return ErrInvalidShareToken{"invalid expiration time"}
+	}
+	return nil
+}
+
+// IsExpired returns true if the token has an expiration time and it is
+// not after the given time.
+func (t *ShareToken) IsExpired(now int64) bool {
+	return t.ExpireAt > 0 && t.ExpireAt <= now
+}
+
+type ErrInvalidShareToken struct {
+	msg string
+}
+
+func (e ErrInvalidShareToken) Error() string {
+	return e.msg
+}
@@ -27,6 +27,17 @@ type Sharing struct {
 	// Updated time in miliseconds since the current epoch
 	// required: true
 	UpdateAt int64 `json:"update_at,omitempty"`
+
+	// Expiration time in miliseconds since the current epoch, zero if
+	// the sharing never expires
+	// required: false
+	ExpireAt int64 `json:"expireAt,omitempty"`
+}
+
+// IsExpired returns true if the sharing has an expiration time and it
+// is not after the given time.
+func (s *Sharing) IsExpired(now int64) bool {
+	return s.ExpireAt > 0 && s.ExpireAt <= now
 }
 
 func SharingFromJSON(data io.Reader) Sharing {
@@ -34,3 +45,44 @@ func SharingFromJSON(data io.Reader) Sharing {
 	_ = json.NewDecoder(data).Decode(&sharing)
 	return sharing
 }
+
+// BoardSnapshot is a frozen copy of a board and its blocks, published to
+// be served to public viewers instead of the live board
+// swagger:model
+type BoardSnapshot struct {
+	// ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The board as it was when the snapshot was published
+	// required: true
+	Board *Board `json:"board"`
+
+	// The blocks of the board as they were when the snapshot was published
+	// required: true
+	Blocks []*Block `json:"blocks"`
+
+	// ID of the user who published the snapshot
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// Publication time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// FilterBlocks returns the blocks of the snapshot with the given parent
+// and type. Empty values match any parent or type.
+func (s *BoardSnapshot) FilterBlocks(parentID, blockType string) []*Block {
+	blocks := []*Block{}
+	for _, block := range s.Blocks {
+		if parentID != "" && block.ParentID != parentID {
+			continue
+		}
+		if blockType != "" && string(block.Type) != blockType {
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
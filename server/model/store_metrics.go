@@ -0,0 +1,25 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import "time"
+
+// StoreMetrics receives the duration and outcome of the store calls, to
+// report them as metrics or to log the slow ones.
+type StoreMetrics interface {
+	// ObserveQuery is called once a store method returns, with the name
+	// of the method, how long it took and the error it returned, if any.
+	ObserveQuery(method string, elapsed time.Duration, err error)
+}
+
+// CacheMetrics receives the outcome of the lookups of the store cache.
+type CacheMetrics interface {
+	// IncrementCacheHit is called when an entry of the given kind is
+	// found in the cache.
+	IncrementCacheHit(cache string)
+
+	// IncrementCacheMiss is called when an entry of the given kind has
+	// to be read from the store.
+	IncrementCacheMiss(cache string)
+}
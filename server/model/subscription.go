@@ -20,6 +20,24 @@ func (st SubscriberType) IsValid() bool {
 	return false
 }
 
+// SubscriptionEvents is a bitmask of the events a subscription notifies.
+type SubscriptionEvents int64
+
+const (
+	SubscriptionEventComments SubscriptionEvents = 1 << iota
+	SubscriptionEventStatusChange
+	SubscriptionEventAssignment
+	SubscriptionEventOtherChanges
+
+	SubscriptionEventsAll = SubscriptionEventComments | SubscriptionEventStatusChange |
+		SubscriptionEventAssignment | SubscriptionEventOtherChanges
+)
+
+// Has returns true if any of the events are part of the mask.
+func (e SubscriptionEvents) Has(events SubscriptionEvents) bool {
+	return e&events != 0
+}
+
 // Subscription is a subscription to a board, card, etc, for a user or channel.
 // swagger:model
 type Subscription struct {
@@ -50,6 +68,10 @@ type Subscription struct {
 	// DeleteAt is the timestamp this subscription was deleted in miliseconds since the current epoch, or zero if not deleted
 	// required: true
 	DeleteAt int64 `json:"deleteAt"`
+
+	// Events is the bitmask of the events the subscriber is notified of. Zero means all events
+	// required: false
+	Events SubscriptionEvents `json:"events"`
 }
 
 func (s *Subscription) IsValid() error {
@@ -68,6 +90,9 @@ func (s *Subscription) IsValid() error {
 	if !s.SubscriberType.IsValid() {
 		return ErrInvalidSubscription{"invalid subscriber type"}
 	}
+	if s.Events&^SubscriptionEventsAll != 0 {
+		return ErrInvalidSubscription{"invalid events"}
+	}
 	return nil
 }
 
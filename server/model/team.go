@@ -5,6 +5,21 @@ import (
 	"io"
 )
 
+const (
+	TeamRoleAdmin = "admin"
+	TeamRoleUser  = "user"
+	TeamRoleGuest = "guest"
+)
+
+// IsValidTeamRole checks if the role is one of the known team roles.
+func IsValidTeamRole(role string) bool {
+	switch role {
+	case TeamRoleAdmin, TeamRoleUser, TeamRoleGuest:
+		return true
+	}
+	return false
+}
+
 // Team is information global to a team
 // swagger:model
 type Team struct {
@@ -33,6 +48,17 @@ type Team struct {
 	UpdateAt int64 `json:"updateAt"`
 }
 
+// TeamWithCount is a team along with the number of boards the
+// requesting user is a member of in it
+// swagger:model
+type TeamWithCount struct {
+	Team
+
+	// Number of boards the user is a member of in the team
+	// required: true
+	BoardCount int64 `json:"boardCount"`
+}
+
 func TeamFromJSON(data io.Reader) *Team {
 	var team *Team
 	_ = json.NewDecoder(data).Decode(&team)
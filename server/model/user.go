@@ -10,6 +10,10 @@ const (
 	GlobalTeamID                  = "0"
 	SystemUserID                  = "system"
 	PreferencesCategoryFocalboard = "focalboard"
+
+	// MaxActiveUserCountDays is the longest range, in days, that active
+	// user counts can be bucketed by day for.
+	MaxActiveUserCountDays = 366
 )
 
 // User is a user
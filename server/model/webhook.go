@@ -0,0 +1,215 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+)
+
+// Webhook is an outbound webhook subscription for the events of a board.
+// swagger:model
+type Webhook struct {
+	// The ID of the webhook
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the board the webhook receives events for
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The URL events are sent to
+	// required: true
+	URL string `json:"url"`
+
+	// The secret used to sign the events. It is never serialized.
+	// required: false
+	Secret string `json:"-"`
+
+	// The event types the webhook receives (e.g. block.created)
+	// required: true
+	EventTypes []string `json:"eventTypes"`
+
+	// The ID of the user that created the webhook
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (w *Webhook) IsValid() error {
+	if w == nil {
+		return ErrInvalidWebhook{"cannot be nil"}
+	}
+	if w.BoardID == "" {
+		return ErrInvalidWebhook{"missing board id"}
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhook{"invalid url"}
+	}
+	if len(w.EventTypes) == 0 {
+		return ErrInvalidWebhook{"missing event types"}
+	}
+	return nil
+}
+
+// WebhookEvent types.
+const (
+	WebhookEventBlockCreated  = "block.created"
+	WebhookEventBlockUpdated  = "block.updated"
+	WebhookEventBlockDeleted  = "block.deleted"
+	WebhookEventMemberAdded   = "member.added"
+	WebhookEventMemberUpdated = "member.updated"
+	WebhookEventMemberRemoved = "member.removed"
+)
+
+// WebhookSignatureHeader is the header carrying the HMAC-SHA256 of the
+// delivered payload, keyed with the webhook secret.
+const WebhookSignatureHeader = "X-Focalboard-Signature"
+
+// WebhookEvent is the payload posted to a webhook URL
+// swagger:model
+type WebhookEvent struct {
+	// The ID of the delivery, the same across retries
+	// required: true
+	ID string `json:"id"`
+
+	// The type of the event (e.g. block.created)
+	// required: true
+	Type string `json:"type"`
+
+	// The ID of the board of the event
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the user that caused the event, if known
+	// required: false
+	UserID string `json:"userId"`
+
+	// The time of the event in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The block, or the board member, the event is about
+	// required: true
+	Data interface{} `json:"data"`
+}
+
+// WebhookWithSecret is a webhook along with its signing secret, which is
+// only sent back when the webhook is created or its secret is changed
+// swagger:model
+type WebhookWithSecret struct {
+	Webhook
+
+	// The secret used to sign the events. It is generated if empty when
+	// the webhook is created
+	// required: false
+	Secret string `json:"secret"`
+}
+
+// SignWebhookPayload returns the value of the signature header for a
+// payload, a hex encoded HMAC-SHA256 keyed with the webhook secret.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WantsEvent returns true if the webhook receives events of the given type.
+func (w *Webhook) WantsEvent(eventType string) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type ErrInvalidWebhook struct {
+	msg string
+}
+
+func (e ErrInvalidWebhook) Error() string {
+	return e.msg
+}
+
+// WebhookDeliveryMaxAttempts is the number of times a webhook delivery is
+// attempted before it stops being returned as pending.
+const WebhookDeliveryMaxAttempts = 10
+
+// WebhookDelivery is an outbound webhook event waiting to be, or already,
+// delivered.
+// swagger:model
+type WebhookDelivery struct {
+	// The ID of the delivery
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the webhook the event is delivered to
+	// required: true
+	WebhookID string `json:"webhookId"`
+
+	// The ID of the board the event belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The type of the event (e.g. block.created)
+	// required: true
+	EventType string `json:"eventType"`
+
+	// The JSON payload sent to the webhook target
+	// required: true
+	Payload string `json:"payload"`
+
+	// The number of delivery attempts made so far
+	// required: true
+	Attempts int `json:"attempts"`
+
+	// The timestamp after which the delivery can be attempted again, in miliseconds since the current epoch
+	// required: true
+	NextRetryAt int64 `json:"nextRetryAt"`
+
+	// The response status of the delivery, or zero if not delivered yet
+	// required: false
+	Status int `json:"status"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The delivery time in miliseconds since the current epoch, or zero if not delivered yet
+	// required: false
+	DeliveredAt int64 `json:"deliveredAt"`
+}
+
+func (d *WebhookDelivery) IsValid() error {
+	if d == nil {
+		return ErrInvalidWebhookDelivery{"cannot be nil"}
+	}
+	if d.BoardID == "" {
+		return ErrInvalidWebhookDelivery{"missing board id"}
+	}
+	if d.EventType == "" {
+		return ErrInvalidWebhookDelivery{"missing event type"}
+	}
+	return nil
+}
+
+type ErrInvalidWebhookDelivery struct {
+	msg string
+}
+
+func (e ErrInvalidWebhookDelivery) Error() string {
+	return e.msg
+}
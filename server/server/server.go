@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net"
@@ -23,8 +24,12 @@ import (
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/notify/notifylogger"
+	"github.com/mattermost/focalboard/server/services/ratelimit"
 	"github.com/mattermost/focalboard/server/services/scheduler"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/store/cachestore"
+	"github.com/mattermost/focalboard/server/services/store/memstore"
+	"github.com/mattermost/focalboard/server/services/store/metricsstore"
 	"github.com/mattermost/focalboard/server/services/store/sqlstore"
 	"github.com/mattermost/focalboard/server/services/telemetry"
 	"github.com/mattermost/focalboard/server/services/webhook"
@@ -38,8 +43,11 @@ import (
 )
 
 const (
-	cleanupSessionTaskFrequency = 10 * time.Minute
-	updateMetricsTaskFrequency  = 15 * time.Minute
+	cleanupSessionTaskFrequency  = 10 * time.Minute
+	cleanupSessionBatchSize      = 1000
+	updateMetricsTaskFrequency   = 15 * time.Minute
+	purgeTrashTaskFrequency      = 1 * time.Hour
+	deliverWebhooksTaskFrequency = 10 * time.Second
 
 	minSessionExpiryTime = int64(60 * 60 * 24 * 31) // 31 days
 
@@ -58,8 +66,11 @@ type Server struct {
 	metricsServer          *metrics.Service
 	metricsService         *metrics.Metrics
 	metricsUpdaterTask     *scheduler.ScheduledTask
+	purgeTrashTask         *scheduler.ScheduledTask
+	deliverWebhooksTask    *scheduler.ScheduledTask
 	auditService           *audit.Audit
 	notificationService    *notify.Service
+	rateLimiter            *ratelimit.Limiter
 	servicesStartStopMutex sync.Mutex
 
 	localRouter     *mux.Router
@@ -73,12 +84,48 @@ func New(params Params) (*Server, error) {
 		return nil, err
 	}
 
+	// Init metrics
+	instanceInfo := metrics.InstanceInfo{
+		Version:        appModel.CurrentVersion,
+		BuildNum:       appModel.BuildNumber,
+		Edition:        appModel.Edition,
+		InstallationID: os.Getenv("MM_CLOUD_INSTALLATION_ID"),
+	}
+	metricsService := metrics.NewMetrics(instanceInfo)
+
+	if params.Cfg.MaxBoardBlocks > 0 {
+		params.DBStore.SetBoardBlockLimit(params.Cfg.MaxBoardBlocks)
+	}
+
+	// the store calls are only timed when the metrics are exported
+	if params.Cfg.PrometheusAddress != "" {
+		params.DBStore = metricsstore.New(params.DBStore, metricsService)
+	}
+
+	// the cache wraps the timed store, so that only the reads that miss
+	// it are timed
+	if params.Cfg.StoreCache.Enable {
+		cacheStore, err := cachestore.New(params.DBStore, params.Cfg.StoreCache, metricsService, params.Logger)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize the store cache: %w", err)
+		}
+		params.DBStore = cacheStore
+	}
+
 	authenticator := auth.New(params.Cfg, params.DBStore, params.PermissionsService)
 
+	var rateLimiter *ratelimit.Limiter
+	if params.Cfg.RateLimit.Enable {
+		var err error
+		if rateLimiter, err = ratelimit.New(params.Cfg.RateLimit, params.Logger); err != nil {
+			return nil, fmt.Errorf("unable to initialize the rate limiter: %w", err)
+		}
+	}
+
 	// if no ws adapter is provided, we spin up a websocket server
 	wsAdapter := params.WSAdapter
 	if wsAdapter == nil {
-		wsAdapter = ws.NewServer(authenticator, params.SingleUserToken, params.Cfg.AuthMode == MattermostAuthMod, params.Logger, params.DBStore)
+		wsAdapter = ws.NewServer(authenticator, params.SingleUserToken, params.Cfg.AuthMode == MattermostAuthMod, params.Logger, params.DBStore, rateLimiter, metricsService)
 	}
 
 	filesBackendSettings := filestore.FileBackendSettings{}
@@ -105,15 +152,6 @@ func New(params Params) (*Server, error) {
 
 	webhookClient := webhook.NewClient(params.Cfg, params.Logger)
 
-	// Init metrics
-	instanceInfo := metrics.InstanceInfo{
-		Version:        appModel.CurrentVersion,
-		BuildNum:       appModel.BuildNumber,
-		Edition:        appModel.Edition,
-		InstallationID: os.Getenv("MM_CLOUD_INSTALLATION_ID"),
-	}
-	metricsService := metrics.NewMetrics(instanceInfo)
-
 	// Init audit
 	auditService, errAudit := audit.NewAudit()
 	if errAudit != nil {
@@ -143,14 +181,14 @@ func New(params Params) (*Server, error) {
 	}
 	app := app.New(params.Cfg, wsAdapter, appServices)
 
-	focalboardAPI := api.NewAPI(app, params.SingleUserToken, params.Cfg.AuthMode, params.PermissionsService, params.Logger, auditService, params.IsPlugin)
+	focalboardAPI := api.NewAPI(app, params.SingleUserToken, params.Cfg.AuthMode, params.PermissionsService, params.Logger, auditService, params.IsPlugin, rateLimiter, metricsService)
 
 	// Local router for admin APIs
 	localRouter := mux.NewRouter()
 	focalboardAPI.RegisterAdminRoutes(localRouter)
 
 	// Init team
-	if _, err := app.GetRootTeam(); err != nil {
+	if _, err := app.GetRootTeam(context.Background()); err != nil {
 		params.Logger.Error("Unable to get root team", mlog.Err(err))
 		return nil, err
 	}
@@ -163,7 +201,7 @@ func New(params Params) (*Server, error) {
 	}
 	webServer.AddRoutes(focalboardAPI)
 
-	settings, err := params.DBStore.GetSystemSettings()
+	settings, err := params.DBStore.GetSystemSettings(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +210,7 @@ func New(params Params) (*Server, error) {
 	telemetryID := settings["TelemetryID"]
 	if len(telemetryID) == 0 {
 		telemetryID = utils.NewID(utils.IDTypeNone)
-		if err = params.DBStore.SetSystemSetting("TelemetryID", telemetryID); err != nil {
+		if err = params.DBStore.SetSystemSetting(context.Background(), "TelemetryID", telemetryID); err != nil {
 			return nil, err
 		}
 	}
@@ -197,6 +235,7 @@ func New(params Params) (*Server, error) {
 		metricsService:      metricsService,
 		auditService:        auditService,
 		notificationService: notificationService,
+		rateLimiter:         rateLimiter,
 		logger:              params.Logger,
 		localRouter:         localRouter,
 		api:                 focalboardAPI,
@@ -209,6 +248,11 @@ func New(params Params) (*Server, error) {
 }
 
 func NewStore(config *config.Configuration, isSingleUser bool, logger mlog.LoggerIFace) (store.Store, error) {
+	if config.DBType == appModel.MemoryDBType {
+		logger.Warn("Using the memory store, the data will be lost when the server stops")
+		return memstore.New(logger), nil
+	}
+
 	sqlDB, err := sql.Open(config.DBType, config.DBConfigString)
 	if err != nil {
 		logger.Error("connectDatabase failed", mlog.Err(err))
@@ -260,14 +304,50 @@ func (s *Server) Start() error {
 				secondsAgo = s.config.SessionExpireTime
 			}
 
-			if err := s.store.CleanUpSessions(secondsAgo); err != nil {
-				s.logger.Error("Unable to clean up the sessions", mlog.Err(err))
+			idleTimeout := time.Duration(secondsAgo) * time.Second
+			maxLifetime := time.Duration(s.config.SessionMaxLifetime) * time.Second
+
+			deleted, err := s.store.CleanUpSessions(context.Background(), idleTimeout, maxLifetime, cleanupSessionBatchSize)
+			if err != nil {
+				s.logger.Error("Unable to clean up the sessions", mlog.Int64("deleted", deleted), mlog.Err(err))
+				return
 			}
+			s.logger.Debug("Cleaned up the sessions", mlog.Int64("deleted", deleted))
 		}, cleanupSessionTaskFrequency)
 	}
 
+	if s.config.TrashRetentionDays > 0 {
+		s.purgeTrashTask = scheduler.CreateRecurringTask("purgeTrash", func() {
+			olderThan := time.Now().AddDate(0, 0, -s.config.TrashRetentionDays)
+
+			boards, err := s.store.PurgeArchivedBoards(context.Background(), olderThan)
+			if err != nil {
+				s.logger.Error("Unable to purge the archived boards", mlog.Err(err))
+				return
+			}
+
+			blocks, err := s.store.PurgeDeletedBlocks(context.Background(), olderThan)
+			if err != nil {
+				s.logger.Error("Unable to purge the deleted blocks", mlog.Err(err))
+				return
+			}
+			s.logger.Debug("Purged the trash", mlog.Int("boards", boards), mlog.Int("blocks", blocks))
+		}, purgeTrashTaskFrequency)
+	}
+
+	s.deliverWebhooksTask = scheduler.CreateRecurringTask("deliverWebhooks", func() {
+		delivered, err := s.app.DeliverWebhooks(context.Background())
+		if err != nil {
+			s.logger.Error("Unable to deliver the webhooks", mlog.Err(err))
+			return
+		}
+		if delivered > 0 {
+			s.logger.Debug("Delivered the webhooks", mlog.Int("delivered", delivered))
+		}
+	}, deliverWebhooksTaskFrequency)
+
 	metricsUpdater := func() {
-		blockCounts, err := s.store.GetBlockCountsByType()
+		blockCounts, err := s.store.GetBlockCountsByType(context.Background())
 		if err != nil {
 			s.logger.Error("Error updating metrics", mlog.String("group", "blocks"), mlog.Err(err))
 			return
@@ -276,14 +356,14 @@ func (s *Server) Start() error {
 		for blockType, count := range blockCounts {
 			s.metricsService.ObserveBlockCount(blockType, count)
 		}
-		boardCount, err := s.store.GetBoardCount()
+		boardCount, err := s.store.GetBoardCount(context.Background())
 		if err != nil {
 			s.logger.Error("Error updating metrics", mlog.String("group", "boards"), mlog.Err(err))
 			return
 		}
 		s.logger.Log(mlog.LvlFBMetrics, "Board metrics collected", mlog.Int64("board_count", boardCount))
 		s.metricsService.ObserveBoardCount(boardCount)
-		teamCount, err := s.store.GetTeamCount()
+		teamCount, err := s.store.GetTeamCount(context.Background())
 		if err != nil {
 			s.logger.Error("Error updating metrics", mlog.String("group", "teams"), mlog.Err(err))
 			return
@@ -335,6 +415,14 @@ func (s *Server) Shutdown() error {
 		s.metricsUpdaterTask.Cancel()
 	}
 
+	if s.purgeTrashTask != nil {
+		s.purgeTrashTask.Cancel()
+	}
+
+	if s.deliverWebhooksTask != nil {
+		s.deliverWebhooksTask.Cancel()
+	}
+
 	if err := s.telemetry.Shutdown(); err != nil {
 		s.logger.Warn("Error occurred when shutting down telemetry", mlog.Err(err))
 	}
@@ -347,6 +435,12 @@ func (s *Server) Shutdown() error {
 		s.logger.Warn("Error occurred when shutting down notification service", mlog.Err(err))
 	}
 
+	if s.rateLimiter != nil {
+		if err := s.rateLimiter.Close(); err != nil {
+			s.logger.Warn("Error occurred when shutting down the rate limiter", mlog.Err(err))
+		}
+	}
+
 	s.app.Shutdown()
 
 	defer s.logger.Info("Server.Shutdown")
@@ -457,29 +551,29 @@ func initTelemetry(opts telemetryOptions) *telemetry.Service {
 		m := make(map[string]interface{})
 		var count int
 		var err error
-		if count, err = opts.app.GetRegisteredUserCount(); err != nil {
+		if count, err = opts.app.GetRegisteredUserCount(context.Background()); err != nil {
 			return nil, err
 		}
 		m["registered_users"] = count
 
-		if count, err = opts.app.GetDailyActiveUsers(); err != nil {
+		if count, err = opts.app.GetDailyActiveUsers(context.Background()); err != nil {
 			return nil, err
 		}
 		m["daily_active_users"] = count
 
-		if count, err = opts.app.GetWeeklyActiveUsers(); err != nil {
+		if count, err = opts.app.GetWeeklyActiveUsers(context.Background()); err != nil {
 			return nil, err
 		}
 		m["weekly_active_users"] = count
 
-		if count, err = opts.app.GetMonthlyActiveUsers(); err != nil {
+		if count, err = opts.app.GetMonthlyActiveUsers(context.Background()); err != nil {
 			return nil, err
 		}
 		m["monthly_active_users"] = count
 		return m, nil
 	})
 	telemetryService.RegisterTracker("blocks", func() (telemetry.Tracker, error) {
-		blockCounts, err := opts.app.GetBlockCountsByType()
+		blockCounts, err := opts.app.GetBlockCountsByType(context.Background())
 		if err != nil {
 			return nil, err
 		}
@@ -490,7 +584,7 @@ func initTelemetry(opts telemetryOptions) *telemetry.Service {
 		return m, nil
 	})
 	telemetryService.RegisterTracker("boards", func() (telemetry.Tracker, error) {
-		boardCount, err := opts.app.GetBoardCount()
+		boardCount, err := opts.app.GetBoardCount(context.Background())
 		if err != nil {
 			return nil, err
 		}
@@ -500,7 +594,7 @@ func initTelemetry(opts telemetryOptions) *telemetry.Service {
 		return m, nil
 	})
 	telemetryService.RegisterTracker("teams", func() (telemetry.Tracker, error) {
-		count, err := opts.app.GetTeamCount()
+		count, err := opts.app.GetTeamCount(context.Background())
 		if err != nil {
 			return nil, err
 		}
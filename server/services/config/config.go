@@ -11,6 +11,8 @@ const (
 	DefaultPort       = 8000
 )
 
+// AmazonS3Config holds the settings of the S3 files storage, which is used
+// when the files driver is "amazons3".
 type AmazonS3Config struct {
 	AccessKeyID     string
 	SecretAccessKey string
@@ -25,6 +27,40 @@ type AmazonS3Config struct {
 	Timeout         int64
 }
 
+// RateLimitConfig holds the settings of the API rate limiting. Rates are
+// in requests per second and bursts are the number of requests that can
+// be made at once, a zero rate disables the limit. The buckets are kept
+// in memory, or in Redis when the backend is "redis" so that they are
+// shared by several servers.
+type RateLimitConfig struct {
+	Enable            bool
+	Backend           string
+	PerUserRate       float64
+	PerUserBurst      int
+	PerIPRate         float64
+	PerIPBurst        int
+	TrustForwardedFor bool
+	RedisAddress      string
+	RedisPassword     string
+	RedisDB           int
+}
+
+// StoreCacheConfig holds the settings of the cache of the boards, blocks
+// and board members read from the store. The entries are kept in an
+// in-memory LRU of Size entries per kind, or in Redis when the backend
+// is "redis" so that they are shared, and invalidated, by several
+// servers. Entries expire after TTL seconds, a zero TTL keeps the
+// in-memory ones until they are evicted or invalidated.
+type StoreCacheConfig struct {
+	Enable        bool
+	Backend       string
+	Size          int
+	TTL           int
+	RedisAddress  string
+	RedisPassword string
+	RedisDB       int
+}
+
 // Configuration is the app configuration stored in a json file.
 type Configuration struct {
 	ServerRoot               string            `json:"serverRoot" mapstructure:"serverRoot"`
@@ -46,6 +82,7 @@ type Configuration struct {
 	Secret                   string            `json:"secret" mapstructure:"secret"`
 	SessionExpireTime        int64             `json:"session_expire_time" mapstructure:"session_expire_time"`
 	SessionRefreshTime       int64             `json:"session_refresh_time" mapstructure:"session_refresh_time"`
+	SessionMaxLifetime       int64             `json:"session_max_lifetime" mapstructure:"session_max_lifetime"`
 	LocalOnly                bool              `json:"localonly" mapstructure:"localonly"`
 	EnableLocalMode          bool              `json:"enableLocalMode" mapstructure:"enableLocalMode"`
 	LocalModeSocketLocation  string            `json:"localModeSocketLocation" mapstructure:"localModeSocketLocation"`
@@ -54,6 +91,11 @@ type Configuration struct {
 	EnableDataRetention      bool              `json:"enable_data_retention" mapstructure:"enable_data_retention"`
 	DataRetentionDays        int               `json:"data_retention_days" mapstructure:"data_retention_days"`
 	TeammateNameDisplay      string            `json:"teammate_name_display" mapstructure:"teammateNameDisplay"`
+	MaxBoardMembers          int               `json:"max_board_members" mapstructure:"max_board_members"`
+	MaxBoardBlocks           int               `json:"max_board_blocks" mapstructure:"max_board_blocks"`
+	TrashRetentionDays       int               `json:"trash_retention_days" mapstructure:"trash_retention_days"`
+	RateLimit                RateLimitConfig   `json:"ratelimit" mapstructure:"ratelimit"`
+	StoreCache               StoreCacheConfig  `json:"storecache" mapstructure:"storecache"`
 
 	AuthMode string `json:"authMode" mapstructure:"authMode"`
 
@@ -91,6 +133,7 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("WebhookUpdate", nil)
 	viper.SetDefault("SessionExpireTime", 60*60*24*30) // 30 days session lifetime
 	viper.SetDefault("SessionRefreshTime", 60*60*5)    // 5 minutes session refresh
+	viper.SetDefault("SessionMaxLifetime", 0)          // no absolute session lifetime
 	viper.SetDefault("LocalOnly", false)
 	viper.SetDefault("EnableLocalMode", false)
 	viper.SetDefault("LocalModeSocketLocation", "/var/tmp/focalboard_local.socket")
@@ -103,6 +146,19 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("DataRetentionDays", 365) // 1 year is default
 	viper.SetDefault("PrometheusAddress", "")
 	viper.SetDefault("TeammateNameDisplay", "username")
+	viper.SetDefault("MaxBoardMembers", 0)     // 0 means unlimited
+	viper.SetDefault("MaxBoardBlocks", 0)      // 0 means unlimited
+	viper.SetDefault("TrashRetentionDays", 30) // 0 means deleted boards and blocks are kept forever
+	viper.SetDefault("RateLimit.Enable", false)
+	viper.SetDefault("RateLimit.Backend", "memory")
+	viper.SetDefault("RateLimit.PerUserRate", 20)
+	viper.SetDefault("RateLimit.PerUserBurst", 100)
+	viper.SetDefault("RateLimit.PerIPRate", 50)
+	viper.SetDefault("RateLimit.PerIPBurst", 200)
+	viper.SetDefault("StoreCache.Enable", false)
+	viper.SetDefault("StoreCache.Backend", "memory")
+	viper.SetDefault("StoreCache.Size", 10000)
+	viper.SetDefault("StoreCache.TTL", 300)
 
 	err := viper.ReadInConfig() // Find and read the config file
 	if err != nil {             // Handle errors reading the config file
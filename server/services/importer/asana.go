@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+const asanaDefaultTitle = "Asana import"
+
+var ErrMissingAsanaColumn = errors.New("missing column in Asana export")
+
+// ImportAsanaCSV converts the CSV export of an Asana project. Its sections
+// become the options of a "Section" select property, its tags the options
+// of a "Tags" multi-select property and the completed tasks are marked in
+// a "Completed" checkbox property. Task notes become text blocks.
+func ImportAsanaCSV(r io.Reader, teamID string) (*model.BoardsAndBlocks, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read Asana export header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	if _, ok := columns["Name"]; !ok {
+		return nil, fmt.Errorf("%w: Name", ErrMissingAsanaColumn)
+	}
+
+	var rows [][]string
+	for {
+		row, errRead := reader.Read()
+		if errors.Is(errRead, io.EOF) {
+			break
+		}
+		if errRead != nil {
+			return nil, fmt.Errorf("cannot read Asana export: %w", errRead)
+		}
+		rows = append(rows, row)
+	}
+
+	get := func(row []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	title := asanaDefaultTitle
+	for _, row := range rows {
+		if projects := get(row, "Projects"); projects != "" {
+			title = strings.TrimSpace(strings.Split(projects, ",")[0])
+			break
+		}
+	}
+
+	b := newBuilder(teamID, title, "")
+
+	sections := newSelectProperty()
+	tags := newSelectProperty()
+	for _, row := range rows {
+		if section := get(row, "Section/Column"); section != "" {
+			sections.addOption(section, section)
+		}
+		for _, tag := range splitAsanaList(get(row, "Tags")) {
+			tags.addOption(tag, tag)
+		}
+	}
+	sections.id = b.addProperty("Section", "select", sections.options)
+	if len(tags.options) > 0 {
+		tags.id = b.addProperty("Tags", "multiSelect", tags.options)
+	}
+	completedID := b.addProperty("Completed", "checkbox", []map[string]interface{}{})
+
+	for _, row := range rows {
+		properties := map[string]interface{}{}
+		if optionID, ok := sections.optionIDs[get(row, "Section/Column")]; ok {
+			properties[sections.id] = optionID
+		}
+
+		rowTags := splitAsanaList(get(row, "Tags"))
+		if len(rowTags) > 0 {
+			optionIDs := make([]interface{}, 0, len(rowTags))
+			for _, tag := range rowTags {
+				optionIDs = append(optionIDs, tags.optionIDs[tag])
+			}
+			properties[tags.id] = optionIDs
+		}
+
+		if get(row, "Completed At") != "" {
+			properties[completedID] = "true"
+		}
+
+		var contents []*model.Block
+		if notes := get(row, "Notes"); notes != "" {
+			contents = append(contents, newTextBlock(notes))
+		}
+
+		b.addCard(get(row, "Name"), properties, contents...)
+	}
+
+	return b.result(), nil
+}
+
+// splitAsanaList splits a comma separated cell of an Asana export.
+func splitAsanaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
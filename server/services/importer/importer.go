@@ -0,0 +1,198 @@
+// Package importer converts the exports of other tools into boards and
+// blocks that can be created on the server.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// Source is the tool an export comes from.
+type Source string
+
+const (
+	SourceTrello Source = "trello"
+	SourceAsana  Source = "asana"
+)
+
+var ErrUnsupportedSource = errors.New("unsupported import source")
+
+var optionColors = []string{
+	"propColorGray",
+	"propColorBrown",
+	"propColorOrange",
+	"propColorYellow",
+	"propColorGreen",
+	"propColorBlue",
+	"propColorPurple",
+	"propColorPink",
+	"propColorRed",
+}
+
+// Import converts an export into a board of the team along with its
+// blocks. Parent blocks always come before their children, so the blocks
+// can be inserted in order.
+func Import(source Source, r io.Reader, teamID string) (*model.BoardsAndBlocks, error) {
+	switch source {
+	case SourceTrello:
+		return ImportTrello(r, teamID)
+	case SourceAsana:
+		return ImportAsanaCSV(r, teamID)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSource, source)
+	}
+}
+
+// builder accumulates the board and blocks of an import.
+type builder struct {
+	board  *model.Board
+	blocks []*model.Block
+	now    int64
+}
+
+func newBuilder(teamID, title, description string) *builder {
+	now := utils.GetMillis()
+	board := &model.Board{
+		ID:             utils.NewID(utils.IDTypeBoard),
+		TeamID:         teamID,
+		Type:           model.BoardTypePrivate,
+		MinimumRole:    model.BoardRoleNone,
+		Title:          title,
+		Description:    description,
+		Properties:     map[string]interface{}{},
+		CardProperties: []map[string]interface{}{},
+		CreationSource: model.BoardCreationSourceImport,
+		CreateAt:       now,
+		UpdateAt:       now,
+	}
+
+	b := &builder{board: board, now: now}
+	b.addBlock(&model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		ParentID: board.ID,
+		Type:     model.TypeView,
+		Title:    "Board View",
+		Fields: map[string]interface{}{
+			"viewType":           "board",
+			"sortOptions":        []interface{}{},
+			"visiblePropertyIds": []interface{}{},
+			"visibleOptionIds":   []interface{}{},
+			"hiddenOptionIds":    []interface{}{},
+			"collapsedOptionIds": []interface{}{},
+			"filter":             map[string]interface{}{"operation": "and", "filters": []interface{}{}},
+			"cardOrder":          []interface{}{},
+			"columnWidths":       map[string]interface{}{},
+			"columnCalculations": map[string]interface{}{},
+			"kanbanCalculations": map[string]interface{}{},
+			"defaultTemplateId":  "",
+		},
+	})
+	return b
+}
+
+// addProperty adds a card property to the board and returns its ID.
+func (b *builder) addProperty(name, propType string, options []map[string]interface{}) string {
+	id := utils.NewID(utils.IDTypeBlock)
+	b.board.CardProperties = append(b.board.CardProperties, map[string]interface{}{
+		"id":      id,
+		"name":    name,
+		"type":    propType,
+		"options": options,
+	})
+	return id
+}
+
+// selectProperty is a select property built from the named values of an
+// export, keyed by their IDs in it.
+type selectProperty struct {
+	id        string
+	optionIDs map[string]string
+	options   []map[string]interface{}
+}
+
+func newSelectProperty() *selectProperty {
+	return &selectProperty{
+		optionIDs: map[string]string{},
+		options:   []map[string]interface{}{},
+	}
+}
+
+// addOption adds an option for a value unless it already has one.
+func (p *selectProperty) addOption(key, value string) {
+	if _, ok := p.optionIDs[key]; ok {
+		return
+	}
+	optionID := utils.NewID(utils.IDTypeBlock)
+	p.optionIDs[key] = optionID
+	p.options = append(p.options, map[string]interface{}{
+		"id":    optionID,
+		"value": value,
+		"color": optionColors[len(p.options)%len(optionColors)],
+	})
+}
+
+// addCard adds a card with its content blocks, in order.
+func (b *builder) addCard(title string, properties map[string]interface{}, contents ...*model.Block) *model.Block {
+	contentOrder := make([]interface{}, 0, len(contents))
+	for _, content := range contents {
+		contentOrder = append(contentOrder, content.ID)
+	}
+
+	card := b.addBlock(&model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		ParentID: b.board.ID,
+		Type:     model.TypeCard,
+		Title:    title,
+		Fields: map[string]interface{}{
+			"icon":         "",
+			"properties":   properties,
+			"contentOrder": contentOrder,
+		},
+	})
+
+	for _, content := range contents {
+		content.ParentID = card.ID
+		b.addBlock(content)
+	}
+	return card
+}
+
+func (b *builder) addBlock(block *model.Block) *model.Block {
+	block.BoardID = b.board.ID
+	block.Schema = 1
+	block.CreateAt = b.now
+	block.UpdateAt = b.now
+	if block.Fields == nil {
+		block.Fields = map[string]interface{}{}
+	}
+	b.blocks = append(b.blocks, block)
+	return block
+}
+
+func newTextBlock(text string) *model.Block {
+	return &model.Block{
+		ID:    utils.NewID(utils.IDTypeBlock),
+		Type:  model.TypeText,
+		Title: text,
+	}
+}
+
+func newCheckboxBlock(text string, checked bool) *model.Block {
+	return &model.Block{
+		ID:     utils.NewID(utils.IDTypeBlock),
+		Type:   "checkbox",
+		Title:  text,
+		Fields: map[string]interface{}{"value": checked},
+	}
+}
+
+func (b *builder) result() *model.BoardsAndBlocks {
+	return &model.BoardsAndBlocks{
+		Boards: []*model.Board{b.board},
+		Blocks: b.blocks,
+	}
+}
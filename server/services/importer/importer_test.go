@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const trelloExport = `{
+	"name": "Roadmap",
+	"desc": "Next quarter",
+	"lists": [{"id": "l1", "name": "To Do"}, {"id": "l2", "name": "Done"}],
+	"labels": [{"id": "lb1", "name": "", "color": "red"}],
+	"cards": [
+		{"id": "c1", "name": "First", "desc": "Some text", "idList": "l1", "idLabels": ["lb1"], "idChecklists": ["ch1"]},
+		{"id": "c2", "name": "Archived", "idList": "l2", "closed": true}
+	],
+	"checklists": [{"id": "ch1", "checkItems": [{"name": "Item", "state": "complete"}]}]
+}`
+
+const asanaExport = "Task ID,Name,Section/Column,Completed At,Tags,Notes,Projects\n" +
+	"1,First,Backlog,,\"bug, ui\",Some notes,Website\n" +
+	"2,Second,Done,2022-01-01,,,Website\n"
+
+func findProperty(t *testing.T, board *model.Board, name string) map[string]interface{} {
+	for _, prop := range board.CardProperties {
+		if prop["name"] == name {
+			return prop
+		}
+	}
+	require.Failf(t, "missing property", "property %s not found", name)
+	return nil
+}
+
+func blocksOfType(blocks []*model.Block, blockType model.BlockType) []*model.Block {
+	var result []*model.Block
+	for _, block := range blocks {
+		if block.Type == blockType {
+			result = append(result, block)
+		}
+	}
+	return result
+}
+
+func TestImportTrello(t *testing.T) {
+	bab, err := Import(SourceTrello, strings.NewReader(trelloExport), "team-id")
+	require.NoError(t, err)
+	require.Len(t, bab.Boards, 1)
+	require.NoError(t, bab.IsValid())
+
+	board := bab.Boards[0]
+	assert.Equal(t, "Roadmap", board.Title)
+	assert.Equal(t, "team-id", board.TeamID)
+	assert.Equal(t, model.BoardCreationSourceImport, board.CreationSource)
+
+	lists := findProperty(t, board, "List")
+	assert.Len(t, lists["options"], 2)
+	labels := findProperty(t, board, "Labels")
+	assert.Equal(t, "red", labels["options"].([]map[string]interface{})[0]["value"])
+
+	cards := blocksOfType(bab.Blocks, model.TypeCard)
+	require.Len(t, cards, 1)
+	card := cards[0]
+	assert.Equal(t, "First", card.Title)
+	properties := card.Fields["properties"].(map[string]interface{})
+	assert.Equal(t, lists["options"].([]map[string]interface{})[0]["id"], properties[lists["id"].(string)])
+	assert.Len(t, properties[labels["id"].(string)], 1)
+
+	texts := blocksOfType(bab.Blocks, model.TypeText)
+	checkboxes := blocksOfType(bab.Blocks, "checkbox")
+	require.Len(t, texts, 1)
+	require.Len(t, checkboxes, 1)
+	assert.Equal(t, card.ID, texts[0].ParentID)
+	assert.Equal(t, true, checkboxes[0].Fields["value"])
+	assert.Equal(t, []interface{}{texts[0].ID, checkboxes[0].ID}, card.Fields["contentOrder"])
+
+	t.Run("invalid export", func(t *testing.T) {
+		_, err := Import(SourceTrello, strings.NewReader("not json"), "team-id")
+		require.Error(t, err)
+	})
+}
+
+func TestImportAsanaCSV(t *testing.T) {
+	bab, err := Import(SourceAsana, strings.NewReader(asanaExport), "team-id")
+	require.NoError(t, err)
+	require.NoError(t, bab.IsValid())
+
+	board := bab.Boards[0]
+	assert.Equal(t, "Website", board.Title)
+
+	sections := findProperty(t, board, "Section")
+	assert.Len(t, sections["options"], 2)
+	tags := findProperty(t, board, "Tags")
+	assert.Len(t, tags["options"], 2)
+	completed := findProperty(t, board, "Completed")
+
+	cards := blocksOfType(bab.Blocks, model.TypeCard)
+	require.Len(t, cards, 2)
+
+	first := cards[0].Fields["properties"].(map[string]interface{})
+	assert.Len(t, first[tags["id"].(string)], 2)
+	assert.NotContains(t, first, completed["id"])
+
+	second := cards[1].Fields["properties"].(map[string]interface{})
+	assert.Equal(t, "true", second[completed["id"].(string)])
+
+	texts := blocksOfType(bab.Blocks, model.TypeText)
+	require.Len(t, texts, 1)
+	assert.Equal(t, "Some notes", texts[0].Title)
+	assert.Equal(t, cards[0].ID, texts[0].ParentID)
+
+	t.Run("missing name column", func(t *testing.T) {
+		_, err := Import(SourceAsana, strings.NewReader("Task ID,Notes\n1,x\n"), "team-id")
+		require.ErrorIs(t, err, ErrMissingAsanaColumn)
+	})
+}
+
+func TestImportUnsupportedSource(t *testing.T) {
+	_, err := Import("jira", strings.NewReader(""), "team-id")
+	require.ErrorIs(t, err, ErrUnsupportedSource)
+}
@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// trelloBoard holds the parts of a Trello board JSON export that are
+// imported.
+type trelloBoard struct {
+	Name       string            `json:"name"`
+	Desc       string            `json:"desc"`
+	Lists      []trelloList      `json:"lists"`
+	Labels     []trelloLabel     `json:"labels"`
+	Cards      []trelloCard      `json:"cards"`
+	Checklists []trelloChecklist `json:"checklists"`
+}
+
+type trelloList struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Closed bool   `json:"closed"`
+}
+
+type trelloLabel struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type trelloCard struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Desc         string   `json:"desc"`
+	Closed       bool     `json:"closed"`
+	IDList       string   `json:"idList"`
+	IDLabels     []string `json:"idLabels"`
+	IDChecklists []string `json:"idChecklists"`
+}
+
+type trelloChecklist struct {
+	ID         string            `json:"id"`
+	CheckItems []trelloCheckItem `json:"checkItems"`
+}
+
+type trelloCheckItem struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// ImportTrello converts a Trello board JSON export. Its lists become the
+// options of a "List" select property and its labels the options of a
+// "Labels" multi-select property. Card descriptions become text blocks
+// and checklist items checkbox blocks. Archived cards are skipped.
+func ImportTrello(r io.Reader, teamID string) (*model.BoardsAndBlocks, error) {
+	var input trelloBoard
+	if err := json.NewDecoder(r).Decode(&input); err != nil {
+		return nil, fmt.Errorf("cannot decode Trello export: %w", err)
+	}
+
+	b := newBuilder(teamID, input.Name, input.Desc)
+
+	lists := newSelectProperty()
+	for _, list := range input.Lists {
+		lists.addOption(list.ID, list.Name)
+	}
+	lists.id = b.addProperty("List", "select", lists.options)
+
+	labels := newSelectProperty()
+	for _, label := range input.Labels {
+		name := label.Name
+		if name == "" {
+			name = label.Color
+		}
+		labels.addOption(label.ID, name)
+	}
+	if len(labels.options) > 0 {
+		labels.id = b.addProperty("Labels", "multiSelect", labels.options)
+	}
+
+	checklists := make(map[string]trelloChecklist, len(input.Checklists))
+	for _, checklist := range input.Checklists {
+		checklists[checklist.ID] = checklist
+	}
+
+	for _, card := range input.Cards {
+		if card.Closed {
+			continue
+		}
+
+		properties := map[string]interface{}{}
+		if optionID, ok := lists.optionIDs[card.IDList]; ok {
+			properties[lists.id] = optionID
+		}
+
+		cardLabels := make([]interface{}, 0, len(card.IDLabels))
+		for _, labelID := range card.IDLabels {
+			if optionID, ok := labels.optionIDs[labelID]; ok {
+				cardLabels = append(cardLabels, optionID)
+			}
+		}
+		if len(cardLabels) > 0 {
+			properties[labels.id] = cardLabels
+		}
+
+		var contents []*model.Block
+		if card.Desc != "" {
+			contents = append(contents, newTextBlock(card.Desc))
+		}
+		for _, checklistID := range card.IDChecklists {
+			for _, item := range checklists[checklistID].CheckItems {
+				contents = append(contents, newCheckboxBlock(item.Name, item.State == "complete"))
+			}
+		}
+
+		b.addCard(card.Name, properties, contents...)
+	}
+
+	return b.result(), nil
+}
@@ -2,6 +2,10 @@ package metrics
 
 import (
 	"os"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -13,6 +17,9 @@ const (
 	MetricsSubsystemBoards = "boards"
 	MetricsSubsystemTeams  = "teams"
 	MetricsSubsystemSystem = "system"
+	MetricsSubsystemStore  = "store"
+	MetricsSubsystemAPI    = "api"
+	MetricsSubsystemWS     = "websocket"
 
 	MetricsCloudInstallationLabel = "installationId"
 )
@@ -44,6 +51,16 @@ type Metrics struct {
 	teamCount  prometheus.Gauge
 
 	blockLastActivity prometheus.Gauge
+
+	storeQueryDuration   *prometheus.HistogramVec
+	storeQueryErrorCount *prometheus.CounterVec
+
+	storeCacheHitCount  *prometheus.CounterVec
+	storeCacheMissCount *prometheus.CounterVec
+
+	apiRequestDuration *prometheus.HistogramVec
+
+	websocketConnectionCount prometheus.Gauge
 }
 
 // NewMetrics Factory method to create a new metrics collector.
@@ -172,6 +189,62 @@ func NewMetrics(info InstanceInfo) *Metrics {
 	})
 	m.registry.MustRegister(m.blockLastActivity)
 
+	m.storeQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemStore,
+		Name:        "query_duration_seconds",
+		Help:        "Duration of the store method calls.",
+		Buckets:     prometheus.ExponentialBuckets(0.001, 4, 8),
+		ConstLabels: additionalLabels,
+	}, []string{"method"})
+	m.registry.MustRegister(m.storeQueryDuration)
+
+	m.storeQueryErrorCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemStore,
+		Name:        "query_errors_total",
+		Help:        "Total number of store method calls that failed.",
+		ConstLabels: additionalLabels,
+	}, []string{"method"})
+	m.registry.MustRegister(m.storeQueryErrorCount)
+
+	m.storeCacheHitCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemStore,
+		Name:        "cache_hits_total",
+		Help:        "Total number of store reads served by the cache.",
+		ConstLabels: additionalLabels,
+	}, []string{"cache"})
+	m.registry.MustRegister(m.storeCacheHitCount)
+
+	m.storeCacheMissCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemStore,
+		Name:        "cache_misses_total",
+		Help:        "Total number of cached store reads that missed the cache.",
+		ConstLabels: additionalLabels,
+	}, []string{"cache"})
+	m.registry.MustRegister(m.storeCacheMissCount)
+
+	m.apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemAPI,
+		Name:        "request_duration_seconds",
+		Help:        "Duration of the API requests.",
+		Buckets:     prometheus.ExponentialBuckets(0.005, 4, 8),
+		ConstLabels: additionalLabels,
+	}, []string{"handler", "method", "status_code"})
+	m.registry.MustRegister(m.apiRequestDuration)
+
+	m.websocketConnectionCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemWS,
+		Name:        "connections",
+		Help:        "Current number of websocket connections.",
+		ConstLabels: additionalLabels,
+	})
+	m.registry.MustRegister(m.websocketConnectionCount)
+
 	return m
 }
 
@@ -231,3 +304,42 @@ func (m *Metrics) ObserveTeamCount(count int64) {
 		m.teamCount.Set(float64(count))
 	}
 }
+
+// ObserveQuery records the duration of a store method call. Not found
+// errors are an expected outcome of lookups, so they aren't counted as
+// failures.
+func (m *Metrics) ObserveQuery(method string, elapsed time.Duration, err error) {
+	if m != nil {
+		m.storeQueryDuration.WithLabelValues(method).Observe(elapsed.Seconds())
+		if err != nil && !model.IsErrNotFound(err) {
+			m.storeQueryErrorCount.WithLabelValues(method).Inc()
+		}
+	}
+}
+
+func (m *Metrics) IncrementCacheHit(cache string) {
+	if m != nil {
+		m.storeCacheHitCount.WithLabelValues(cache).Inc()
+	}
+}
+
+func (m *Metrics) IncrementCacheMiss(cache string) {
+	if m != nil {
+		m.storeCacheMissCount.WithLabelValues(cache).Inc()
+	}
+}
+
+// ObserveAPIRequest records the duration of an API request. The
+// handler is the route template, so that requests to different
+// entities are aggregated.
+func (m *Metrics) ObserveAPIRequest(handler, method string, statusCode int, elapsed time.Duration) {
+	if m != nil {
+		m.apiRequestDuration.WithLabelValues(handler, method, strconv.Itoa(statusCode)).Observe(elapsed.Seconds())
+	}
+}
+
+func (m *Metrics) ObserveWebsocketConnections(count int) {
+	if m != nil {
+		m.websocketConnectionCount.Set(float64(count))
+	}
+}
@@ -15,13 +15,17 @@ type Service struct {
 }
 
 // NewMetricsServer factory method to create a new prometheus server.
+// The metrics are exposed under the /metrics path.
 func NewMetricsServer(address string, metricsService *Metrics, logger mlog.LoggerIFace) *Service {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metricsService.registry, promhttp.HandlerOpts{
+		ErrorLog: logger.StdLogger(mlog.LvlError),
+	}))
+
 	return &Service{
 		&http.Server{
-			Addr: address,
-			Handler: promhttp.HandlerFor(metricsService.registry, promhttp.HandlerOpts{
-				ErrorLog: logger.StdLogger(mlog.LvlError),
-			}),
+			Addr:    address,
+			Handler: mux,
 		},
 	}
 }
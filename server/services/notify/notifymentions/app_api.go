@@ -6,5 +6,5 @@ import "github.com/mattermost/focalboard/server/model"
 
 type AppAPI interface {
 	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
-	AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, error)
+	AddMemberToBoard(member *model.BoardMember, modifiedBy string) (*model.BoardMember, error)
 }
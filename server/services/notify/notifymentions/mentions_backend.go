@@ -198,7 +198,7 @@ func (b *Backend) deliverMentionNotification(username string, extract string, ev
 						evt.Board.MinimumRole == model.BoardRoleEditor,
 					SchemeEditor: evt.Board.MinimumRole == model.BoardRoleEditor,
 				}
-				if _, err = b.appAPI.AddMemberToBoard(newBoardMember); err != nil {
+				if _, err = b.appAPI.AddMemberToBoard(newBoardMember, evt.ModifiedBy.UserID); err != nil {
 					return "", fmt.Errorf("cannot add mentioned user %s to board %s: %w", mentionedUser.Id, evt.Board.ID, err)
 				}
 				b.logger.Debug("auto-added mentioned user to board",
@@ -19,6 +19,7 @@ type AppAPI interface {
 	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
 	GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error)
 	UpdateSubscribersNotifiedAt(blockID string, notifyAt int64) error
+	UpdateSubscriberNotifiedAt(blockID, subscriberID string, notifyAt int64) error
 
 	UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error)
 	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
@@ -215,9 +215,33 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 		return err
 	}
 
+	// find the new NotifiedAt based on the newest diff.
+	var notifiedAt int64
+	for _, d := range diffs {
+		if d.UpdateAt > notifiedAt {
+			notifiedAt = d.UpdateAt
+		}
+		for _, c := range d.Diffs {
+			if c.UpdateAt > notifiedAt {
+				notifiedAt = c.UpdateAt
+			}
+		}
+	}
+
 	merr := merror.New()
+	notified := make([]string, 0, len(subs))
 	if len(attachments) > 0 {
 		for _, sub := range subs {
+			// subscribers on a different schedule may have already been
+			// notified of these changes.
+			if sub.NotifiedAt >= notifiedAt {
+				n.logger.Debug("notifySubscribers - skipping already notified subscriber",
+					mlog.Any("hint", hint),
+					mlog.String("subscriber_id", sub.SubscriberID),
+				)
+				continue
+			}
+
 			// don't notify the author of their own changes.
 			authorName, isAuthor := diffAuthors[sub.SubscriberID]
 			if isAuthor && len(diffAuthors) == 1 {
@@ -226,6 +250,7 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 					mlog.String("author_id", sub.SubscriberID),
 					mlog.String("author_username", authorName),
 				)
+				notified = append(notified, sub.SubscriberID)
 				continue
 			}
 
@@ -236,6 +261,7 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 					mlog.String("subscriber_id", sub.SubscriberID),
 					mlog.String("board_id", board.ID),
 				)
+				notified = append(notified, sub.SubscriberID)
 				continue
 			}
 
@@ -249,32 +275,26 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 			if err = n.delivery.SubscriptionDeliverSlackAttachments(board.TeamID, sub.SubscriberID, sub.SubscriberType, attachments); err != nil {
 				merr.Append(fmt.Errorf("cannot deliver notification to subscriber %s [%s]: %w",
 					sub.SubscriberID, sub.SubscriberType, err))
+				continue
 			}
+			notified = append(notified, sub.SubscriberID)
 		}
 	} else {
 		n.logger.Debug("notifySubscribers - skip delivery; no chg",
 			mlog.Any("hint", hint),
 			mlog.String("modified_by_id", hint.ModifiedByID),
 		)
-	}
-
-	// find the new NotifiedAt based on the newest diff.
-	var notifiedAt int64
-	for _, d := range diffs {
-		if d.UpdateAt > notifiedAt {
-			notifiedAt = d.UpdateAt
-		}
-		for _, c := range d.Diffs {
-			if c.UpdateAt > notifiedAt {
-				notifiedAt = c.UpdateAt
-			}
+		for _, sub := range subs {
+			notified = append(notified, sub.SubscriberID)
 		}
 	}
 
-	// update the last notified_at for all subscribers since we at least attempted to notify all of them.
-	err = dg.store.UpdateSubscribersNotifiedAt(dg.hint.BlockID, notifiedAt)
-	if err != nil {
-		merr.Append(fmt.Errorf("could not update subscribers notified_at for block %s: %w", dg.hint.BlockID, err))
+	// update the last notified_at of each subscriber that was notified or
+	// skipped, so a failed delivery is retried with the next change.
+	for _, subscriberID := range notified {
+		if err = dg.store.UpdateSubscriberNotifiedAt(dg.hint.BlockID, subscriberID, notifiedAt); err != nil {
+			merr.Append(fmt.Errorf("could not update subscriber %s notified_at for block %s: %w", subscriberID, dg.hint.BlockID, err))
+		}
 	}
 
 	return merr.ErrorOrNil()
@@ -38,7 +38,7 @@ func (th *TestHelper) checkBoardPermissions(roleName string, member *model.Board
 	for _, p := range hasPermissionTo {
 		th.t.Run(roleName+" "+p.Id, func(t *testing.T) {
 			th.store.EXPECT().
-				GetMemberForBoard(member.BoardID, member.UserID).
+				GetMemberForBoard(gomock.Any(), member.BoardID, member.UserID).
 				Return(member, nil).
 				Times(1)
 
@@ -50,7 +50,7 @@ func (th *TestHelper) checkBoardPermissions(roleName string, member *model.Board
 	for _, p := range hasNotPermissionTo {
 		th.t.Run(roleName+" "+p.Id, func(t *testing.T) {
 			th.store.EXPECT().
-				GetMemberForBoard(member.BoardID, member.UserID).
+				GetMemberForBoard(gomock.Any(), member.BoardID, member.UserID).
 				Return(member, nil).
 				Times(1)
 
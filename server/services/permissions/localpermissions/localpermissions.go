@@ -4,6 +4,8 @@
 package localpermissions
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/permissions"
 
@@ -46,7 +48,7 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		return false
 	}
 
-	member, err := s.store.GetMemberForBoard(boardID, userID)
+	member, err := s.store.GetMemberForBoard(context.Background(), boardID, userID)
 	if model.IsErrNotFound(err) {
 		return false
 	}
@@ -59,6 +61,28 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		return false
 	}
 
+	if member.CustomRoleID != "" {
+		role, err := s.store.GetBoardCustomRole(context.Background(), member.CustomRoleID)
+		if err != nil && !model.IsErrNotFound(err) {
+			s.logger.Error("error getting custom role for board member",
+				mlog.String("boardID", boardID),
+				mlog.String("userID", userID),
+				mlog.Err(err),
+			)
+			return false
+		}
+		if role != nil && role.HasPermission(permission) {
+			return true
+		}
+
+		// the custom role replaces the scheme roles, leaving only the
+		// permissions of the board minimum role
+		member.SchemeAdmin = false
+		member.SchemeEditor = false
+		member.SchemeCommenter = false
+		member.SchemeViewer = false
+	}
+
 	switch member.MinimumRole {
 	case "admin":
 		member.SchemeAdmin = true
@@ -73,7 +97,7 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 	switch permission {
 	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard, model.PermissionDeleteOthersComments:
 		return member.SchemeAdmin
-	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties:
+	case model.PermissionManageBoardCards, model.PermissionCreateBoardCards, model.PermissionDeleteBoardCards, model.PermissionManageBoardProperties:
 		return member.SchemeAdmin || member.SchemeEditor
 	case model.PermissionCommentBoardCards:
 		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter
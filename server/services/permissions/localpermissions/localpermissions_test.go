@@ -11,6 +11,7 @@ import (
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,7 +44,7 @@ func TestHasPermissionToBoard(t *testing.T) {
 		boardID := "board-id"
 
 		th.store.EXPECT().
-			GetMemberForBoard(boardID, userID).
+			GetMemberForBoard(gomock.Any(), boardID, userID).
 			Return(nil, sql.ErrNoRows).
 			Times(1)
 
@@ -141,4 +142,34 @@ func TestHasPermissionToBoard(t *testing.T) {
 
 		th.checkBoardPermissions("viewer", member, hasPermissionTo, hasNotPermissionTo)
 	})
+
+	t.Run("board member with a custom role", func(t *testing.T) {
+		member := &model.BoardMember{
+			UserID:       "user-id",
+			BoardID:      "board-id",
+			SchemeEditor: true,
+			CustomRoleID: "role-id",
+		}
+		role := &model.BoardCustomRole{
+			ID:          "role-id",
+			BoardID:     "board-id",
+			Name:        "Contributor",
+			Permissions: model.RolePermissionViewBoard | model.RolePermissionCreateBoardCards,
+		}
+		th.store.EXPECT().GetBoardCustomRole(gomock.Any(), "role-id").Return(role, nil).AnyTimes()
+
+		hasPermissionTo := []*mmModel.Permission{
+			model.PermissionViewBoard,
+			model.PermissionCreateBoardCards,
+		}
+
+		hasNotPermissionTo := []*mmModel.Permission{
+			model.PermissionManageBoardRoles,
+			model.PermissionManageBoardCards,
+			model.PermissionDeleteBoardCards,
+			model.PermissionCommentBoardCards,
+		}
+
+		th.checkBoardPermissions("custom role", member, hasPermissionTo, hasNotPermissionTo)
+	})
 }
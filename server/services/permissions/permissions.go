@@ -5,6 +5,8 @@
 package permissions
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/model"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
@@ -18,7 +20,8 @@ type PermissionsService interface {
 }
 
 type Store interface {
-	GetBoard(boardID string) (*model.Board, error)
-	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
-	GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
+	GetBoard(ctx context.Context, boardID string) (*model.Board, error)
+	GetMemberForBoard(ctx context.Context, boardID, userID string) (*model.BoardMember, error)
+	GetBoardHistory(ctx context.Context, boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
+	GetBoardCustomRole(ctx context.Context, id string) (*model.BoardCustomRole, error)
 }
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxMemoryBuckets is the number of buckets above which the full ones,
+// which hold no state worth keeping, are dropped.
+const maxMemoryBuckets = 10000
+
+type memoryBucket struct {
+	tokens   float64
+	updateAt time.Time
+}
+
+// MemoryBackend keeps the token buckets in memory, which limits the
+// requests of a single server.
+type MemoryBackend struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	now     func() time.Time
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+func (b *MemoryBackend) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	bucket, ok := b.buckets[key]
+	if !ok {
+		if len(b.buckets) >= maxMemoryBuckets {
+			b.dropFullBuckets(now, limit)
+		}
+		bucket = &memoryBucket{tokens: float64(limit.Burst), updateAt: now}
+		b.buckets[key] = bucket
+	}
+
+	bucket.tokens = refill(bucket.tokens, now.Sub(bucket.updateAt), limit)
+	bucket.updateAt = now
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	return newResult(allowed, bucket.tokens, limit), nil
+}
+
+func (b *MemoryBackend) Close() error {
+	return nil
+}
+
+func (b *MemoryBackend) dropFullBuckets(now time.Time, limit Limit) {
+	for key, bucket := range b.buckets {
+		if refill(bucket.tokens, now.Sub(bucket.updateAt), limit) >= float64(limit.Burst) {
+			delete(b.buckets, key)
+		}
+	}
+}
+
+func refill(tokens float64, elapsed time.Duration, limit Limit) float64 {
+	return math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+}
@@ -0,0 +1,199 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+
+	HeaderLimit      = "X-RateLimit-Limit"
+	HeaderRemaining  = "X-RateLimit-Remaining"
+	HeaderReset      = "X-RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+var ErrUnknownBackend = errors.New("unknown rate limit backend")
+
+// Limit is the refill rate, in tokens per second, and the capacity of a
+// token bucket.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// IsEnabled returns true if the limit restricts anything.
+func (l Limit) IsEnabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+// newResult builds the result of a take that left the given number of
+// tokens in the bucket.
+func newResult(allowed bool, tokens float64, limit Limit) Result {
+	result := Result{
+		Allowed:    allowed,
+		Limit:      limit.Burst,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: secondsToDuration((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+	if !allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / limit.Rate)
+	}
+	return result
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// Backend stores the token buckets.
+type Backend interface {
+	// Take takes a token from the bucket of the key, if there is one left.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+	Close() error
+}
+
+// Limiter applies the per user and per IP rate limits of the
+// configuration to the requests.
+type Limiter struct {
+	backend           Backend
+	perUser           Limit
+	perIP             Limit
+	trustForwardedFor bool
+	logger            mlog.LoggerIFace
+}
+
+// New creates a limiter with the backend of the configuration.
+func New(cfg config.RateLimitConfig, logger mlog.LoggerIFace) (*Limiter, error) {
+	var backend Backend
+	switch cfg.Backend {
+	case BackendMemory, "":
+		backend = NewMemoryBackend()
+	case BackendRedis:
+		backend = NewRedisBackend(cfg.RedisAddress, cfg.RedisPassword, cfg.RedisDB)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBackend, cfg.Backend)
+	}
+
+	return NewWithBackend(backend, cfg, logger), nil
+}
+
+// NewWithBackend creates a limiter that stores its buckets in the given
+// backend.
+func NewWithBackend(backend Backend, cfg config.RateLimitConfig, logger mlog.LoggerIFace) *Limiter {
+	return &Limiter{
+		backend:           backend,
+		perUser:           Limit{Rate: cfg.PerUserRate, Burst: cfg.PerUserBurst},
+		perIP:             Limit{Rate: cfg.PerIPRate, Burst: cfg.PerIPBurst},
+		trustForwardedFor: cfg.TrustForwardedFor,
+		logger:            logger,
+	}
+}
+
+// AllowUser takes a token from the bucket of the user. If the request is
+// allowed the rate limit headers are set, otherwise a 429 response is
+// written and false is returned.
+func (l *Limiter) AllowUser(w http.ResponseWriter, r *http.Request, userID string) bool {
+	return l.allow(w, r, "user:"+userID, l.perUser)
+}
+
+// AllowIP takes a token from the bucket of the IP address the request
+// comes from. If the request is allowed the rate limit headers are set,
+// otherwise a 429 response is written and false is returned.
+func (l *Limiter) AllowIP(w http.ResponseWriter, r *http.Request) bool {
+	return l.allow(w, r, "ip:"+l.clientIP(r), l.perIP)
+}
+
+// IPMiddleware rate limits the requests by IP address.
+func (l *Limiter) IPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.AllowIP(w, r) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// Close releases the resources of the backend.
+func (l *Limiter) Close() error {
+	return l.backend.Close()
+}
+
+func (l *Limiter) allow(w http.ResponseWriter, r *http.Request, key string, limit Limit) bool {
+	if !limit.IsEnabled() {
+		return true
+	}
+
+	result, err := l.backend.Take(r.Context(), key, limit)
+	if err != nil {
+		// a failing backend must not take the API down with it
+		l.logger.Error("Cannot check the rate limit", mlog.String("key", key), mlog.Err(err))
+		return true
+	}
+
+	w.Header().Set(HeaderLimit, strconv.Itoa(result.Limit))
+	w.Header().Set(HeaderRemaining, strconv.Itoa(result.Remaining))
+	w.Header().Set(HeaderReset, strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+	if result.Allowed {
+		return true
+	}
+
+	l.logger.Debug("Rate limit exceeded", mlog.String("key", key), mlog.String("path", r.URL.Path))
+
+	w.Header().Set(HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(model.ErrorResponse{
+		Error:     "too many requests",
+		ErrorCode: http.StatusTooManyRequests,
+	})
+	if err != nil {
+		data = []byte("{}")
+	}
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write(data)
+	return false
+}
+
+// clientIP returns the IP address of the client, taken from the
+// X-Forwarded-For header when the server runs behind a trusted proxy.
+func (l *Limiter) clientIP(r *http.Request) string {
+	if l.trustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,142 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	now := time.Unix(1000, 0)
+	backend := NewMemoryBackend()
+	backend.now = func() time.Time { return now }
+	limit := Limit{Rate: 1, Burst: 2}
+
+	t.Run("the burst is allowed at once", func(t *testing.T) {
+		result, err := backend.Take(context.Background(), "key", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, result.Limit)
+		assert.Equal(t, 1, result.Remaining)
+
+		result, err = backend.Take(context.Background(), "key", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, 2*time.Second, result.ResetAfter)
+
+		result, err = backend.Take(context.Background(), "key", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, time.Second, result.RetryAfter)
+	})
+
+	t.Run("other keys have their own bucket", func(t *testing.T) {
+		result, err := backend.Take(context.Background(), "other-key", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("the bucket refills over time", func(t *testing.T) {
+		now = now.Add(1500 * time.Millisecond)
+
+		result, err := backend.Take(context.Background(), "key", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+
+		result, err = backend.Take(context.Background(), "key", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	})
+}
+
+func TestLimiter(t *testing.T) {
+	cfg := config.RateLimitConfig{
+		PerUserRate:  1,
+		PerUserBurst: 1,
+		PerIPRate:    1,
+		PerIPBurst:   2,
+	}
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlError)
+
+	newRequest := func(remoteAddr string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/api/v2/teams", nil)
+		r.RemoteAddr = remoteAddr
+		return r
+	}
+
+	t.Run("requests over the IP limit are rejected", func(t *testing.T) {
+		limiter := NewWithBackend(NewMemoryBackend(), cfg, logger)
+		handler := limiter.IPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newRequest("10.0.0.1:1234"))
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "2", w.Header().Get(HeaderLimit))
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest("10.0.0.1:5678"))
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "0", w.Header().Get(HeaderRemaining))
+		assert.Equal(t, "1", w.Header().Get(HeaderRetryAfter))
+		assert.Contains(t, w.Body.String(), "too many requests")
+
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest("10.0.0.2:1234"))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("the forwarded address is used behind a trusted proxy", func(t *testing.T) {
+		trustedCfg := cfg
+		trustedCfg.TrustForwardedFor = true
+		limiter := NewWithBackend(NewMemoryBackend(), trustedCfg, logger)
+
+		r := newRequest("10.0.0.1:1234")
+		r.Header.Set("X-Forwarded-For", "192.168.1.1, 10.0.0.1")
+		assert.Equal(t, "192.168.1.1", limiter.clientIP(r))
+
+		limiter = NewWithBackend(NewMemoryBackend(), cfg, logger)
+		assert.Equal(t, "10.0.0.1", limiter.clientIP(r))
+	})
+
+	t.Run("requests over the user limit are rejected", func(t *testing.T) {
+		limiter := NewWithBackend(NewMemoryBackend(), cfg, logger)
+
+		assert.True(t, limiter.AllowUser(httptest.NewRecorder(), newRequest("10.0.0.1:1234"), "user-id"))
+
+		w := httptest.NewRecorder()
+		assert.False(t, limiter.AllowUser(w, newRequest("10.0.0.2:1234"), "user-id"))
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+		assert.True(t, limiter.AllowUser(httptest.NewRecorder(), newRequest("10.0.0.1:1234"), "other-user-id"))
+	})
+
+	t.Run("a zero rate disables the limit", func(t *testing.T) {
+		limiter := NewWithBackend(NewMemoryBackend(), config.RateLimitConfig{}, logger)
+
+		for i := 0; i < 10; i++ {
+			w := httptest.NewRecorder()
+			assert.True(t, limiter.AllowIP(w, newRequest("10.0.0.1:1234")))
+			assert.Empty(t, w.Header().Get(HeaderLimit))
+		}
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		_, err := New(config.RateLimitConfig{Backend: "memcached"}, logger)
+		require.ErrorIs(t, err, ErrUnknownBackend)
+	})
+}
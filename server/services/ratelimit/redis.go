@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const redisKeyPrefix = "focalboard:ratelimit:"
+
+// takeScript refills and takes a token from a bucket atomically. The
+// tokens are returned as a string as Redis truncates Lua numbers to
+// integers.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+
+return {allowed, tostring(tokens)}
+`)
+
+// RedisBackend keeps the token buckets in Redis, which limits the
+// requests of all the servers sharing it.
+type RedisBackend struct {
+	client *redis.Client
+}
+
+func NewRedisBackend(address, password string, db int) *RedisBackend {
+	return &RedisBackend{
+		client: redis.NewClient(&redis.Options{
+			Addr:     address,
+			Password: password,
+			DB:       db,
+		}),
+	}
+}
+
+func (b *RedisBackend) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now().UnixMilli()
+	args := []interface{}{
+		strconv.FormatFloat(limit.Rate, 'f', -1, 64),
+		limit.Burst,
+		now,
+	}
+
+	reply, err := takeScript.Run(ctx, b.client, []string{redisKeyPrefix + key}, args...).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit script reply: %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	tokensReply, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensReply, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected rate limit script reply: %w", err)
+	}
+
+	return newResult(allowed == 1, math.Max(0, tokens), limit), nil
+}
+
+func (b *RedisBackend) Close() error {
+	return b.client.Close()
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cachestore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+var ErrUnknownBackend = errors.New("unknown store cache backend")
+
+var _ store.Store = (*CacheStore)(nil)
+
+// Cache keeps the serialized cache entries. The entries are grouped in
+// named caches, one per kind of entry, which can be purged at once.
+type Cache interface {
+	// Get returns the value of an entry, and false if it isn't cached.
+	Get(cache, key string) ([]byte, bool, error)
+	Set(cache, key string, value []byte) error
+	Delete(cache, key string) error
+	Purge(cache string) error
+	Close() error
+}
+
+// CacheStore is a store decorator that reads the boards, blocks and
+// board members through a cache, and invalidates their entries when the
+// methods that change them return. Which methods are cached and what
+// they invalidate is declared by the annotations of the Store interface.
+//
+// The entries are stored serialized, so the callers can't modify the
+// cached values through the returned ones.
+type CacheStore struct {
+	store   store.Store
+	cache   Cache
+	metrics model.CacheMetrics
+	logger  mlog.LoggerIFace
+}
+
+// New wraps a store with a cache configured by cfg.
+func New(s store.Store, cfg config.StoreCacheConfig, metrics model.CacheMetrics, logger mlog.LoggerIFace) (*CacheStore, error) {
+	ttl := time.Duration(cfg.TTL) * time.Second
+
+	var cache Cache
+	switch cfg.Backend {
+	case BackendMemory, "":
+		cache = NewMemoryCache(cfg.Size, ttl)
+	case BackendRedis:
+		cache = NewRedisCache(cfg.RedisAddress, cfg.RedisPassword, cfg.RedisDB, ttl)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBackend, cfg.Backend)
+	}
+
+	return NewWithCache(s, cache, metrics, logger), nil
+}
+
+// NewWithCache wraps a store with the given cache. The metrics can be
+// nil.
+func NewWithCache(s store.Store, cache Cache, metrics model.CacheMetrics, logger mlog.LoggerIFace) *CacheStore {
+	return &CacheStore{
+		store:   s,
+		cache:   cache,
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+func (s *CacheStore) Shutdown() error {
+	if err := s.cache.Close(); err != nil {
+		s.logger.Warn("Error closing the store cache", mlog.Err(err))
+	}
+	return s.store.Shutdown()
+}
+
+func (s *CacheStore) DBType() string {
+	return s.store.DBType()
+}
+
+func (s *CacheStore) SetBoardBlockLimit(limit int) {
+	s.store.SetBoardBlockLimit(limit)
+}
+
+func cacheKey(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+// get decodes a cached entry into value and returns true if it was
+// found. A failing cache is reported as a miss, so that the value is
+// read from the store instead.
+func (s *CacheStore) get(cache, key string, value interface{}) bool {
+	data, found, err := s.cache.Get(cache, key)
+	if err != nil {
+		s.logger.Warn("Error reading from the store cache", mlog.String("cache", cache), mlog.Err(err))
+		found = false
+	}
+
+	if found {
+		if err := json.Unmarshal(data, value); err != nil {
+			s.logger.Warn("Error decoding a store cache entry", mlog.String("cache", cache), mlog.Err(err))
+			found = false
+		}
+	}
+
+	if s.metrics != nil {
+		if found {
+			s.metrics.IncrementCacheHit(cache)
+		} else {
+			s.metrics.IncrementCacheMiss(cache)
+		}
+	}
+	return found
+}
+
+func (s *CacheStore) set(cache, key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		s.logger.Warn("Error encoding a store cache entry", mlog.String("cache", cache), mlog.Err(err))
+		return
+	}
+
+	if err := s.cache.Set(cache, key, data); err != nil {
+		s.logger.Warn("Error writing to the store cache", mlog.String("cache", cache), mlog.Err(err))
+	}
+}
+
+// invalidate removes an entry from the cache. Failing to do so would
+// leave a stale entry until it expires, so it is logged as an error.
+func (s *CacheStore) invalidate(cache, key string) {
+	if err := s.cache.Delete(cache, key); err != nil {
+		s.logger.Error("Error invalidating a store cache entry",
+			mlog.String("cache", cache),
+			mlog.String("key", key),
+			mlog.Err(err),
+		)
+	}
+}
+
+// purge removes all the entries of a cache, for the changes that can't
+// tell which entries they affect.
+func (s *CacheStore) purge(cache string) {
+	if err := s.cache.Purge(cache); err != nil {
+		s.logger.Error("Error purging the store cache", mlog.String("cache", cache), mlog.Err(err))
+	}
+}
@@ -0,0 +1,273 @@
+package cachestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/store/mockstore"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type testMetrics struct {
+	hits   map[string]int
+	misses map[string]int
+}
+
+func newTestMetrics() *testMetrics {
+	return &testMetrics{hits: map[string]int{}, misses: map[string]int{}}
+}
+
+func (m *testMetrics) IncrementCacheHit(cache string) {
+	m.hits[cache]++
+}
+
+func (m *testMetrics) IncrementCacheMiss(cache string) {
+	m.misses[cache]++
+}
+
+type failingCache struct {
+	*MemoryCache
+}
+
+func (c *failingCache) Get(cache, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("cache unavailable")
+}
+
+func setupTestStore(t *testing.T) (*CacheStore, *mockstore.MockStore, *testMetrics) {
+	ctrl := gomock.NewController(t)
+	mockStore := mockstore.NewMockStore(ctrl)
+	metrics := newTestMetrics()
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	return NewWithCache(mockStore, NewMemoryCache(0, 0), metrics, logger), mockStore, metrics
+}
+
+func TestCacheStore(t *testing.T) {
+	t.Run("reads are cached", func(t *testing.T) {
+		s, mockStore, metrics := setupTestStore(t)
+
+		board := &model.Board{ID: "board-id", Title: "title"}
+		mockStore.EXPECT().GetBoard(gomock.Any(), "board-id").Return(board, nil).Times(1)
+
+		for i := 0; i < 3; i++ {
+			got, err := s.GetBoard(context.Background(), "board-id")
+			require.NoError(t, err)
+			require.Equal(t, board, got)
+		}
+
+		require.Equal(t, 2, metrics.hits["board"])
+		require.Equal(t, 1, metrics.misses["board"])
+	})
+
+	t.Run("cached values are copies", func(t *testing.T) {
+		s, mockStore, _ := setupTestStore(t)
+
+		block := &model.Block{ID: "block-id", Title: "title", Fields: map[string]interface{}{"icon": "i"}}
+		mockStore.EXPECT().GetBlock(gomock.Any(), "block-id").Return(block, nil).Times(1)
+
+		got, err := s.GetBlock(context.Background(), "block-id")
+		require.NoError(t, err)
+		got.Title = "changed"
+
+		got, err = s.GetBlock(context.Background(), "block-id")
+		require.NoError(t, err)
+		require.Equal(t, "title", got.Title)
+		require.Equal(t, "i", got.Fields["icon"])
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		s, mockStore, _ := setupTestStore(t)
+
+		member := &model.BoardMember{BoardID: "board-id", UserID: "user-id", SchemeEditor: true}
+		gomock.InOrder(
+			mockStore.EXPECT().GetMemberForBoard(gomock.Any(), "board-id", "user-id").Return(nil, model.NewErrNotFound("member")),
+			mockStore.EXPECT().GetMemberForBoard(gomock.Any(), "board-id", "user-id").Return(member, nil),
+		)
+
+		_, err := s.GetMemberForBoard(context.Background(), "board-id", "user-id")
+		require.True(t, model.IsErrNotFound(err))
+
+		got, err := s.GetMemberForBoard(context.Background(), "board-id", "user-id")
+		require.NoError(t, err)
+		require.Equal(t, member, got)
+	})
+
+	t.Run("done contexts are not served from the cache", func(t *testing.T) {
+		s, mockStore, _ := setupTestStore(t)
+
+		mockStore.EXPECT().GetBoard(gomock.Any(), "board-id").Return(&model.Board{ID: "board-id"}, nil)
+		_, err := s.GetBoard(context.Background(), "board-id")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = s.GetBoard(ctx, "board-id")
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("writes invalidate the entries they change", func(t *testing.T) {
+		s, mockStore, _ := setupTestStore(t)
+
+		mockStore.EXPECT().GetBlock(gomock.Any(), "block-id").Return(&model.Block{ID: "block-id", Title: "old"}, nil)
+		mockStore.EXPECT().GetBlock(gomock.Any(), "other-id").Return(&model.Block{ID: "other-id"}, nil).Times(1)
+		_, err := s.GetBlock(context.Background(), "block-id")
+		require.NoError(t, err)
+		_, err = s.GetBlock(context.Background(), "other-id")
+		require.NoError(t, err)
+
+		title := "new"
+		mockStore.EXPECT().PatchBlock(gomock.Any(), "block-id", gomock.Any(), "user-id").Return(int64(1), nil)
+		_, err = s.PatchBlock(context.Background(), "block-id", &model.BlockPatch{Title: &title}, "user-id")
+		require.NoError(t, err)
+
+		mockStore.EXPECT().GetBlock(gomock.Any(), "block-id").Return(&model.Block{ID: "block-id", Title: "new"}, nil)
+		got, err := s.GetBlock(context.Background(), "block-id")
+		require.NoError(t, err)
+		require.Equal(t, "new", got.Title)
+
+		// the other blocks are still cached
+		_, err = s.GetBlock(context.Background(), "other-id")
+		require.NoError(t, err)
+	})
+
+	t.Run("bulk writes purge the whole cache", func(t *testing.T) {
+		s, mockStore, _ := setupTestStore(t)
+
+		member := &model.BoardMember{BoardID: "board-id", UserID: "user-id"}
+		mockStore.EXPECT().GetMemberForBoard(gomock.Any(), "board-id", "user-id").Return(member, nil).Times(2)
+		_, err := s.GetMemberForBoard(context.Background(), "board-id", "user-id")
+		require.NoError(t, err)
+
+		mockStore.EXPECT().DeleteBoardCustomRole(gomock.Any(), "role-id", "user-id").Return(nil)
+		require.NoError(t, s.DeleteBoardCustomRole(context.Background(), "role-id", "user-id"))
+
+		_, err = s.GetMemberForBoard(context.Background(), "board-id", "user-id")
+		require.NoError(t, err)
+	})
+
+	t.Run("board writes invalidate the members", func(t *testing.T) {
+		s, mockStore, _ := setupTestStore(t)
+
+		member := &model.BoardMember{BoardID: "board-id", UserID: "user-id", MinimumRole: "viewer"}
+		mockStore.EXPECT().GetMemberForBoard(gomock.Any(), "board-id", "user-id").Return(member, nil)
+		_, err := s.GetMemberForBoard(context.Background(), "board-id", "user-id")
+		require.NoError(t, err)
+
+		minimumRole := model.BoardRoleEditor
+		patch := &model.BoardPatch{MinimumRole: &minimumRole}
+		mockStore.EXPECT().PatchBoard(gomock.Any(), "board-id", patch, "user-id").Return(&model.Board{ID: "board-id", MinimumRole: minimumRole}, nil)
+		_, err = s.PatchBoard(context.Background(), "board-id", patch, "user-id")
+		require.NoError(t, err)
+
+		patched := &model.BoardMember{BoardID: "board-id", UserID: "user-id", MinimumRole: string(minimumRole)}
+		mockStore.EXPECT().GetMemberForBoard(gomock.Any(), "board-id", "user-id").Return(patched, nil)
+		got, err := s.GetMemberForBoard(context.Background(), "board-id", "user-id")
+		require.NoError(t, err)
+		require.Equal(t, "editor", got.MinimumRole)
+	})
+
+	t.Run("failed writes still invalidate", func(t *testing.T) {
+		s, mockStore, _ := setupTestStore(t)
+
+		mockStore.EXPECT().GetBoard(gomock.Any(), "board-id").Return(&model.Board{ID: "board-id"}, nil).Times(2)
+		_, err := s.GetBoard(context.Background(), "board-id")
+		require.NoError(t, err)
+
+		failure := errors.New("failure")
+		mockStore.EXPECT().DeleteBoard(gomock.Any(), "board-id", "user-id").Return(failure)
+		require.Same(t, failure, s.DeleteBoard(context.Background(), "board-id", "user-id"))
+
+		_, err = s.GetBoard(context.Background(), "board-id")
+		require.NoError(t, err)
+	})
+
+	t.Run("a failing cache falls back to the store", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mockstore.NewMockStore(ctrl)
+		metrics := newTestMetrics()
+		cache := &failingCache{MemoryCache: NewMemoryCache(0, 0)}
+		s := NewWithCache(mockStore, cache, metrics, mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+
+		mockStore.EXPECT().GetBoard(gomock.Any(), "board-id").Return(&model.Board{ID: "board-id"}, nil).Times(2)
+		for i := 0; i < 2; i++ {
+			_, err := s.GetBoard(context.Background(), "board-id")
+			require.NoError(t, err)
+		}
+		require.Equal(t, 2, metrics.misses["board"])
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		_, err := New(nil, config.StoreCacheConfig{Backend: "unknown"}, nil, nil)
+		require.ErrorIs(t, err, ErrUnknownBackend)
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	t.Run("least recently used entries are evicted", func(t *testing.T) {
+		c := NewMemoryCache(2, 0)
+		require.NoError(t, c.Set("block", "a", []byte("a")))
+		require.NoError(t, c.Set("block", "b", []byte("b")))
+
+		_, found, _ := c.Get("block", "a")
+		require.True(t, found)
+
+		require.NoError(t, c.Set("block", "c", []byte("c")))
+
+		_, found, _ = c.Get("block", "b")
+		require.False(t, found)
+		for _, key := range []string{"a", "c"} {
+			_, found, _ = c.Get("block", key)
+			require.True(t, found, key)
+		}
+	})
+
+	t.Run("each cache has its own size", func(t *testing.T) {
+		c := NewMemoryCache(1, 0)
+		require.NoError(t, c.Set("block", "a", []byte("a")))
+		require.NoError(t, c.Set("board", "a", []byte("a")))
+
+		_, found, _ := c.Get("block", "a")
+		require.True(t, found)
+	})
+
+	t.Run("entries expire", func(t *testing.T) {
+		now := time.Now()
+		c := NewMemoryCache(0, time.Minute)
+		c.now = func() time.Time { return now }
+
+		require.NoError(t, c.Set("board", "a", []byte("a")))
+		now = now.Add(59 * time.Second)
+		_, found, _ := c.Get("board", "a")
+		require.True(t, found)
+
+		now = now.Add(time.Second)
+		_, found, _ = c.Get("board", "a")
+		require.False(t, found)
+	})
+
+	t.Run("delete and purge", func(t *testing.T) {
+		c := NewMemoryCache(0, 0)
+		require.NoError(t, c.Set("board", "a", []byte("a")))
+		require.NoError(t, c.Set("board", "b", []byte("b")))
+		require.NoError(t, c.Set("member", "a", []byte("a")))
+
+		require.NoError(t, c.Delete("board", "a"))
+		_, found, _ := c.Get("board", "a")
+		require.False(t, found)
+		_, found, _ = c.Get("board", "b")
+		require.True(t, found)
+
+		require.NoError(t, c.Purge("board"))
+		_, found, _ = c.Get("board", "b")
+		require.False(t, found)
+		_, found, _ = c.Get("member", "a")
+		require.True(t, found)
+	})
+}
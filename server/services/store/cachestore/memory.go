@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cachestore
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// defaultMemoryCacheSize is the number of entries per cache used when no
+// size is configured.
+const defaultMemoryCacheSize = 10000
+
+type memoryEntry struct {
+	key      string
+	value    []byte
+	expireAt time.Time
+}
+
+// lru is a single cache, its most recently used entries at the front.
+type lru struct {
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// MemoryCache keeps the entries in memory, evicting the least recently
+// used ones once a cache holds size entries. It is only invalidated by
+// the changes made through this server.
+type MemoryCache struct {
+	mu     sync.Mutex
+	caches map[string]*lru
+	size   int
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewMemoryCache creates a cache holding up to size entries per cache,
+// which expire after ttl unless it is zero.
+func NewMemoryCache(size int, ttl time.Duration) *MemoryCache {
+	if size <= 0 {
+		size = defaultMemoryCacheSize
+	}
+	return &MemoryCache{
+		caches: make(map[string]*lru),
+		size:   size,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+func (c *MemoryCache) Get(cache, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l, ok := c.caches[cache]
+	if !ok {
+		return nil, false, nil
+	}
+
+	elem, ok := l.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	if !entry.expireAt.IsZero() && !c.now().Before(entry.expireAt) {
+		l.order.Remove(elem)
+		delete(l.entries, key)
+		return nil, false, nil
+	}
+
+	l.order.MoveToFront(elem)
+	return entry.value, true, nil
+}
+
+func (c *MemoryCache) Set(cache, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l, ok := c.caches[cache]
+	if !ok {
+		l = &lru{entries: make(map[string]*list.Element), order: list.New()}
+		c.caches[cache] = l
+	}
+
+	entry := &memoryEntry{key: key, value: value}
+	if c.ttl > 0 {
+		entry.expireAt = c.now().Add(c.ttl)
+	}
+
+	if elem, ok := l.entries[key]; ok {
+		elem.Value = entry
+		l.order.MoveToFront(elem)
+		return nil
+	}
+
+	l.entries[key] = l.order.PushFront(entry)
+	if l.order.Len() > c.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+func (c *MemoryCache) Delete(cache, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if l, ok := c.caches[cache]; ok {
+		if elem, ok := l.entries[key]; ok {
+			l.order.Remove(elem)
+			delete(l.entries, key)
+		}
+	}
+	return nil
+}
+
+func (c *MemoryCache) Purge(cache string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.caches, cache)
+	return nil
+}
+
+func (c *MemoryCache) Close() error {
+	return nil
+}
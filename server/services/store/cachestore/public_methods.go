@@ -0,0 +1,1061 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make generate" from the Store interface
+// DO NOT EDIT
+
+// The methods annotated with @cached are read through the cache, unless
+// their context is done, and the ones annotated with @invalidatesCache
+// invalidate the entries they change once they return. Every other
+// method is delegated to the wrapped store. Shutdown and DBType are
+// implemented by hand in cachestore.go
+
+package cachestore
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+func (s *CacheStore) AddUpdateCategoryBoard(ctx context.Context, userID string, categoryID string, blockID string) error {
+	return s.store.AddUpdateCategoryBoard(ctx, userID, categoryID, blockID)
+}
+
+func (s *CacheStore) ArchiveBoard(ctx context.Context, boardID string, userID string) error {
+	err := s.store.ArchiveBoard(ctx, boardID, userID)
+	s.invalidate("board", cacheKey(boardID))
+	s.purge("member")
+	return err
+}
+
+func (s *CacheStore) ArchiveCard(ctx context.Context, cardID string, userID string) error {
+	err := s.store.ArchiveCard(ctx, cardID, userID)
+	s.invalidate("block", cacheKey(cardID))
+	return err
+}
+
+func (s *CacheStore) CanSeeUser(ctx context.Context, seerID string, seenID string) (bool, error) {
+	return s.store.CanSeeUser(ctx, seerID, seenID)
+}
+
+func (s *CacheStore) CleanUpSessions(ctx context.Context, idleTimeout time.Duration, maxLifetime time.Duration, batchSize int64) (int64, error) {
+	return s.store.CleanUpSessions(ctx, idleTimeout, maxLifetime, batchSize)
+}
+
+func (s *CacheStore) ClearCategory(ctx context.Context, userID string, categoryID string) error {
+	return s.store.ClearCategory(ctx, userID, categoryID)
+}
+
+func (s *CacheStore) CompareAndSetSystemSetting(ctx context.Context, key string, expectedOld string, newValue string) (bool, error) {
+	return s.store.CompareAndSetSystemSetting(ctx, key, expectedOld, newValue)
+}
+
+func (s *CacheStore) CopyBlocks(ctx context.Context, blockIDs []string, targetBoardID string, userID string) (map[string]string, error) {
+	return s.store.CopyBlocks(ctx, blockIDs, targetBoardID, userID)
+}
+
+func (s *CacheStore) CountBoardsCreatedBetween(ctx context.Context, teamID string, start int64, end int64) (int64, error) {
+	return s.store.CountBoardsCreatedBetween(ctx, teamID, start, end)
+}
+
+func (s *CacheStore) CountBoardsCreatedBetweenAllTeams(ctx context.Context, start int64, end int64) (int64, error) {
+	return s.store.CountBoardsCreatedBetweenAllTeams(ctx, start, end)
+}
+
+func (s *CacheStore) CountCardsByPropertyGrouped(ctx context.Context, boardID string, propertyID string) (map[string]int64, error) {
+	return s.store.CountCardsByPropertyGrouped(ctx, boardID, propertyID)
+}
+
+func (s *CacheStore) CreateBoardComplete(ctx context.Context, board *model.Board, creatorID string, extraMembers []*model.BoardMember, categoryID string) (*model.Board, []*model.BoardMember, error) {
+	result, resultVar1, err := s.store.CreateBoardComplete(ctx, board, creatorID, extraMembers, categoryID)
+	s.invalidate("board", cacheKey(board.ID))
+	s.purge("member")
+	return result, resultVar1, err
+}
+
+func (s *CacheStore) CreateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole) error {
+	return s.store.CreateBoardCustomRole(ctx, role)
+}
+
+func (s *CacheStore) CreateBoardsAndBlocks(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	result, err := s.store.CreateBoardsAndBlocks(ctx, bab, userID)
+	s.purge("board")
+	s.purge("block")
+	return result, err
+}
+
+func (s *CacheStore) CreateBoardsAndBlocksWithAdmin(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	result, resultVar1, err := s.store.CreateBoardsAndBlocksWithAdmin(ctx, bab, userID)
+	s.purge("board")
+	s.purge("block")
+	s.purge("member")
+	return result, resultVar1, err
+}
+
+func (s *CacheStore) CreateCategory(ctx context.Context, category model.Category) error {
+	return s.store.CreateCategory(ctx, category)
+}
+
+func (s *CacheStore) CreateDefaultCategoriesForUser(ctx context.Context, userID string, teamID string) error {
+	return s.store.CreateDefaultCategoriesForUser(ctx, userID, teamID)
+}
+
+func (s *CacheStore) CreateSession(ctx context.Context, session *model.Session) error {
+	return s.store.CreateSession(ctx, session)
+}
+
+func (s *CacheStore) CreateShareToken(ctx context.Context, shareToken *model.ShareToken) error {
+	return s.store.CreateShareToken(ctx, shareToken)
+}
+
+func (s *CacheStore) CreateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	return s.store.CreateSubscription(ctx, sub)
+}
+
+func (s *CacheStore) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	return s.store.CreateUser(ctx, user)
+}
+
+func (s *CacheStore) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	return s.store.CreateWebhook(ctx, webhook)
+}
+
+func (s *CacheStore) DeactivateUser(ctx context.Context, userID string) error {
+	return s.store.DeactivateUser(ctx, userID)
+}
+
+func (s *CacheStore) DeleteBlock(ctx context.Context, blockID string, modifiedBy string) (int64, error) {
+	result, err := s.store.DeleteBlock(ctx, blockID, modifiedBy)
+	s.invalidate("block", cacheKey(blockID))
+	return result, err
+}
+
+func (s *CacheStore) DeleteBoard(ctx context.Context, boardID string, userID string) error {
+	err := s.store.DeleteBoard(ctx, boardID, userID)
+	s.invalidate("board", cacheKey(boardID))
+	s.purge("block")
+	s.purge("member")
+	return err
+}
+
+func (s *CacheStore) DeleteBoardCustomRole(ctx context.Context, id string, userID string) error {
+	err := s.store.DeleteBoardCustomRole(ctx, id, userID)
+	s.purge("member")
+	return err
+}
+
+func (s *CacheStore) DeleteBoardsAndBlocks(ctx context.Context, dbab *model.DeleteBoardsAndBlocks, userID string) error {
+	err := s.store.DeleteBoardsAndBlocks(ctx, dbab, userID)
+	s.purge("board")
+	s.purge("block")
+	s.purge("member")
+	return err
+}
+
+func (s *CacheStore) DeleteCategory(ctx context.Context, categoryID string, userID string, teamID string) error {
+	return s.store.DeleteCategory(ctx, categoryID, userID, teamID)
+}
+
+func (s *CacheStore) DeleteMember(ctx context.Context, boardID string, userID string, modifiedBy string) (int64, error) {
+	result, err := s.store.DeleteMember(ctx, boardID, userID, modifiedBy)
+	s.invalidate("member", cacheKey(boardID, userID))
+	return result, err
+}
+
+func (s *CacheStore) DeleteMembers(ctx context.Context, boardID string, userIDs []string, modifiedBy string) (int, error) {
+	result, err := s.store.DeleteMembers(ctx, boardID, userIDs, modifiedBy)
+	s.purge("member")
+	return result, err
+}
+
+func (s *CacheStore) DeleteNotificationHint(ctx context.Context, blockID string) error {
+	return s.store.DeleteNotificationHint(ctx, blockID)
+}
+
+func (s *CacheStore) DeleteNotificationHints(ctx context.Context, blockIDs []string) error {
+	return s.store.DeleteNotificationHints(ctx, blockIDs)
+}
+
+func (s *CacheStore) DeleteSession(ctx context.Context, sessionID string) error {
+	return s.store.DeleteSession(ctx, sessionID)
+}
+
+func (s *CacheStore) DeleteShareToken(ctx context.Context, boardID string, id string, userID string) error {
+	return s.store.DeleteShareToken(ctx, boardID, id, userID)
+}
+
+func (s *CacheStore) DeleteSubscription(ctx context.Context, blockID string, subscriberID string) (int64, error) {
+	return s.store.DeleteSubscription(ctx, blockID, subscriberID)
+}
+
+func (s *CacheStore) DeleteSubscriptionsForBlock(ctx context.Context, blockID string) error {
+	return s.store.DeleteSubscriptionsForBlock(ctx, blockID)
+}
+
+func (s *CacheStore) DeleteTeam(ctx context.Context, teamID string) (int, error) {
+	result, err := s.store.DeleteTeam(ctx, teamID)
+	s.purge("board")
+	s.purge("block")
+	s.purge("member")
+	return result, err
+}
+
+func (s *CacheStore) DeleteWebhook(ctx context.Context, id string, userID string) error {
+	return s.store.DeleteWebhook(ctx, id, userID)
+}
+
+func (s *CacheStore) DuplicateBlock(ctx context.Context, boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
+	return s.store.DuplicateBlock(ctx, boardID, blockID, userID, asTemplate)
+}
+
+func (s *CacheStore) DuplicateBoard(ctx context.Context, boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	return s.store.DuplicateBoard(ctx, boardID, userID, toTeam, asTemplate)
+}
+
+func (s *CacheStore) DuplicateBoardWithOptions(ctx context.Context, boardID string, userID string, opts model.DuplicateBoardOptions) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	return s.store.DuplicateBoardWithOptions(ctx, boardID, userID, opts)
+}
+
+func (s *CacheStore) FindDuplicateCategories(ctx context.Context, userID string, teamID string) (map[string][]model.Category, error) {
+	return s.store.FindDuplicateCategories(ctx, userID, teamID)
+}
+
+func (s *CacheStore) GetActiveUserCount(ctx context.Context, updatedSecondsAgo int64) (int, error) {
+	return s.store.GetActiveUserCount(ctx, updatedSecondsAgo)
+}
+
+func (s *CacheStore) GetActiveUserCountByTeam(ctx context.Context, teamID string, since int64) (int, error) {
+	return s.store.GetActiveUserCountByTeam(ctx, teamID, since)
+}
+
+func (s *CacheStore) GetActiveUserCountsByDay(ctx context.Context, teamID string, from int64, to int64) (map[int64]int, error) {
+	return s.store.GetActiveUserCountsByDay(ctx, teamID, from, to)
+}
+
+func (s *CacheStore) GetAllCategoriesForTeam(ctx context.Context, teamID string) ([]model.Category, error) {
+	return s.store.GetAllCategoriesForTeam(ctx, teamID)
+}
+
+func (s *CacheStore) GetAllTeams(ctx context.Context) ([]*model.Team, error) {
+	return s.store.GetAllTeams(ctx)
+}
+
+func (s *CacheStore) GetArchivedBoards(ctx context.Context, teamID string) ([]*model.Board, error) {
+	return s.store.GetArchivedBoards(ctx, teamID)
+}
+
+func (s *CacheStore) GetArchivedCards(ctx context.Context, boardID string) ([]*model.Block, error) {
+	return s.store.GetArchivedCards(ctx, boardID)
+}
+
+func (s *CacheStore) GetAuditRecords(ctx context.Context, boardID string, opts model.QueryAuditOptions) ([]*model.AuditRecord, error) {
+	return s.store.GetAuditRecords(ctx, boardID, opts)
+}
+
+func (s *CacheStore) GetBlock(ctx context.Context, blockID string) (*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key := cacheKey(blockID)
+	var result *model.Block
+	if s.get("block", key, &result) {
+		return result, nil
+	}
+
+	result, err := s.store.GetBlock(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	s.set("block", key, result)
+	return result, nil
+}
+
+func (s *CacheStore) GetBlockCountForTeam(ctx context.Context, teamID string) (int64, error) {
+	return s.store.GetBlockCountForTeam(ctx, teamID)
+}
+
+func (s *CacheStore) GetBlockCountsByType(ctx context.Context) (map[string]int64, error) {
+	return s.store.GetBlockCountsByType(ctx)
+}
+
+func (s *CacheStore) GetBlockCountsByTypeForBoard(ctx context.Context, boardID string) (map[string]int64, error) {
+	return s.store.GetBlockCountsByTypeForBoard(ctx, boardID)
+}
+
+func (s *CacheStore) GetBlockCountsForTeams(ctx context.Context, teamIDs []string) (map[string]int64, error) {
+	return s.store.GetBlockCountsForTeams(ctx, teamIDs)
+}
+
+func (s *CacheStore) GetBlockHistory(ctx context.Context, blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	return s.store.GetBlockHistory(ctx, blockID, opts)
+}
+
+func (s *CacheStore) GetBlockHistoryDescendants(ctx context.Context, boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	return s.store.GetBlockHistoryDescendants(ctx, boardID, opts)
+}
+
+func (s *CacheStore) GetBlockHistoryEntry(ctx context.Context, blockID string, version int64) (*model.Block, error) {
+	return s.store.GetBlockHistoryEntry(ctx, blockID, version)
+}
+
+func (s *CacheStore) GetBlocks(ctx context.Context, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	return s.store.GetBlocks(ctx, opts)
+}
+
+func (s *CacheStore) GetBlocksByIDs(ctx context.Context, ids []string) ([]*model.Block, error) {
+	return s.store.GetBlocksByIDs(ctx, ids)
+}
+
+func (s *CacheStore) GetBlocksForBoard(ctx context.Context, boardID string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	return s.store.GetBlocksForBoard(ctx, boardID, opts)
+}
+
+func (s *CacheStore) GetBlocksForBoardStream(ctx context.Context, boardID string, fn func(model.Block) error) error {
+	return s.store.GetBlocksForBoardStream(ctx, boardID, fn)
+}
+
+func (s *CacheStore) GetBlocksForBoards(ctx context.Context, boardIDs []string, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	return s.store.GetBlocksForBoards(ctx, boardIDs, opts)
+}
+
+func (s *CacheStore) GetBlocksMap(ctx context.Context, boardID string, ids []string) (map[string]*model.Block, error) {
+	return s.store.GetBlocksMap(ctx, boardID, ids)
+}
+
+func (s *CacheStore) GetBlocksWithParent(ctx context.Context, boardID string, parentID string) ([]*model.Block, error) {
+	return s.store.GetBlocksWithParent(ctx, boardID, parentID)
+}
+
+func (s *CacheStore) GetBlocksWithParentAndType(ctx context.Context, boardID string, parentID string, blockType string) ([]*model.Block, error) {
+	return s.store.GetBlocksWithParentAndType(ctx, boardID, parentID, blockType)
+}
+
+func (s *CacheStore) GetBlocksWithParentAndTypes(ctx context.Context, boardID string, parentID string, blockTypes []string) ([]*model.Block, error) {
+	return s.store.GetBlocksWithParentAndTypes(ctx, boardID, parentID, blockTypes)
+}
+
+func (s *CacheStore) GetBlocksWithType(ctx context.Context, boardID string, blockType string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	return s.store.GetBlocksWithType(ctx, boardID, blockType, opts)
+}
+
+func (s *CacheStore) GetBoard(ctx context.Context, id string) (*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key := cacheKey(id)
+	var result *model.Board
+	if s.get("board", key, &result) {
+		return result, nil
+	}
+
+	result, err := s.store.GetBoard(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.set("board", key, result)
+	return result, nil
+}
+
+func (s *CacheStore) GetBoardAndCard(ctx context.Context, block *model.Block) (*model.Board, *model.Block, error) {
+	return s.store.GetBoardAndCard(ctx, block)
+}
+
+func (s *CacheStore) GetBoardAndCardByID(ctx context.Context, blockID string) (*model.Board, *model.Block, error) {
+	return s.store.GetBoardAndCardByID(ctx, blockID)
+}
+
+func (s *CacheStore) GetBoardCardProperties(ctx context.Context, boardID string) ([]model.CardProperty, error) {
+	return s.store.GetBoardCardProperties(ctx, boardID)
+}
+
+func (s *CacheStore) GetBoardCount(ctx context.Context) (int64, error) {
+	return s.store.GetBoardCount(ctx)
+}
+
+func (s *CacheStore) GetBoardCustomRole(ctx context.Context, id string) (*model.BoardCustomRole, error) {
+	return s.store.GetBoardCustomRole(ctx, id)
+}
+
+func (s *CacheStore) GetBoardCustomRoles(ctx context.Context, boardID string) ([]*model.BoardCustomRole, error) {
+	return s.store.GetBoardCustomRoles(ctx, boardID)
+}
+
+func (s *CacheStore) GetBoardHistory(ctx context.Context, boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
+	return s.store.GetBoardHistory(ctx, boardID, opts)
+}
+
+func (s *CacheStore) GetBoardMemberCount(ctx context.Context, boardID string) (int, error) {
+	return s.store.GetBoardMemberCount(ctx, boardID)
+}
+
+func (s *CacheStore) GetBoardMemberHistory(ctx context.Context, boardID string, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
+	return s.store.GetBoardMemberHistory(ctx, boardID, userID, limit)
+}
+
+func (s *CacheStore) GetBoardSnapshot(ctx context.Context, boardID string) (*model.BoardSnapshot, error) {
+	return s.store.GetBoardSnapshot(ctx, boardID)
+}
+
+func (s *CacheStore) GetBoardStats(ctx context.Context, boardID string) (*model.BoardStats, error) {
+	return s.store.GetBoardStats(ctx, boardID)
+}
+
+func (s *CacheStore) GetBoardWithStats(ctx context.Context, boardID string, userID string) (*model.BoardWithStats, error) {
+	return s.store.GetBoardWithStats(ctx, boardID, userID)
+}
+
+func (s *CacheStore) GetBoards(ctx context.Context, ids []string) ([]*model.Board, error) {
+	return s.store.GetBoards(ctx, ids)
+}
+
+func (s *CacheStore) GetBoardsAdministeredByUser(ctx context.Context, userID string, teamID string) ([]*model.Board, error) {
+	return s.store.GetBoardsAdministeredByUser(ctx, userID, teamID)
+}
+
+func (s *CacheStore) GetBoardsCreatedFromTemplate(ctx context.Context, templateID string) ([]*model.Board, error) {
+	return s.store.GetBoardsCreatedFromTemplate(ctx, templateID)
+}
+
+func (s *CacheStore) GetBoardsForUserAndTeam(ctx context.Context, userID string, teamID string, includePublicBoards bool) ([]*model.Board, error) {
+	return s.store.GetBoardsForUserAndTeam(ctx, userID, teamID, includePublicBoards)
+}
+
+func (s *CacheStore) GetBoardsForUserAndTeamWithOptions(ctx context.Context, userID string, teamID string, opts model.QueryBoardsOptions) ([]*model.Board, error) {
+	return s.store.GetBoardsForUserAndTeamWithOptions(ctx, userID, teamID, opts)
+}
+
+func (s *CacheStore) GetBoardsInTeamByIds(ctx context.Context, boardIDs []string, teamID string) ([]*model.Board, error) {
+	return s.store.GetBoardsInTeamByIds(ctx, boardIDs, teamID)
+}
+
+func (s *CacheStore) GetBoardsModifiedSince(ctx context.Context, teamID string, userID string, since int64) ([]*model.Board, error) {
+	return s.store.GetBoardsModifiedSince(ctx, teamID, userID, since)
+}
+
+func (s *CacheStore) GetCardLimitTimestamp(ctx context.Context) (int64, error) {
+	return s.store.GetCardLimitTimestamp(ctx)
+}
+
+func (s *CacheStore) GetCardsMissingProperty(ctx context.Context, boardID string, propertyID string) ([]*model.Block, error) {
+	return s.store.GetCardsMissingProperty(ctx, boardID, propertyID)
+}
+
+func (s *CacheStore) GetCategory(ctx context.Context, id string) (*model.Category, error) {
+	return s.store.GetCategory(ctx, id)
+}
+
+func (s *CacheStore) GetCategoryForBoard(ctx context.Context, userID string, teamID string, boardID string) (*model.Category, error) {
+	return s.store.GetCategoryForBoard(ctx, userID, teamID, boardID)
+}
+
+func (s *CacheStore) GetChannel(ctx context.Context, teamID string, channelID string) (*mmModel.Channel, error) {
+	return s.store.GetChannel(ctx, teamID, channelID)
+}
+
+func (s *CacheStore) GetCloudLimits(ctx context.Context) (*mmModel.ProductLimits, error) {
+	return s.store.GetCloudLimits(ctx)
+}
+
+func (s *CacheStore) GetDefaultCategoryTemplates(ctx context.Context, teamID string) ([]model.CategoryTemplate, error) {
+	return s.store.GetDefaultCategoryTemplates(ctx, teamID)
+}
+
+func (s *CacheStore) GetDeletedBlocksForBoard(ctx context.Context, boardID string) ([]*model.Block, error) {
+	return s.store.GetDeletedBlocksForBoard(ctx, boardID)
+}
+
+func (s *CacheStore) GetDueNotificationHints(ctx context.Context, now int64, limit int) ([]*model.NotificationHint, error) {
+	return s.store.GetDueNotificationHints(ctx, now, limit)
+}
+
+func (s *CacheStore) GetFavoriteBoards(ctx context.Context, userID string, teamID string) ([]*model.Board, error) {
+	return s.store.GetFavoriteBoards(ctx, userID, teamID)
+}
+
+func (s *CacheStore) GetFileInfo(ctx context.Context, id string) (*mmModel.FileInfo, error) {
+	return s.store.GetFileInfo(ctx, id)
+}
+
+func (s *CacheStore) GetFileInfosForBoard(ctx context.Context, boardID string) ([]*mmModel.FileInfo, error) {
+	return s.store.GetFileInfosForBoard(ctx, boardID)
+}
+
+func (s *CacheStore) GetLastModifiedBlockForBoard(ctx context.Context, boardID string) (*model.Block, error) {
+	return s.store.GetLastModifiedBlockForBoard(ctx, boardID)
+}
+
+func (s *CacheStore) GetLicense(ctx context.Context) *mmModel.License {
+	return s.store.GetLicense(ctx)
+}
+
+func (s *CacheStore) GetMemberForBoard(ctx context.Context, boardID string, userID string) (*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key := cacheKey(boardID, userID)
+	var result *model.BoardMember
+	if s.get("member", key, &result) {
+		return result, nil
+	}
+
+	result, err := s.store.GetMemberForBoard(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.set("member", key, result)
+	return result, nil
+}
+
+func (s *CacheStore) GetMembersForBoard(ctx context.Context, boardID string) ([]*model.BoardMember, error) {
+	return s.store.GetMembersForBoard(ctx, boardID)
+}
+
+func (s *CacheStore) GetMembersForBoardWithOptions(ctx context.Context, boardID string, opts model.QueryBoardMembersOptions) ([]*model.BoardMember, bool, error) {
+	return s.store.GetMembersForBoardWithOptions(ctx, boardID, opts)
+}
+
+func (s *CacheStore) GetMembersForUser(ctx context.Context, userID string) ([]*model.BoardMember, error) {
+	return s.store.GetMembersForUser(ctx, userID)
+}
+
+func (s *CacheStore) GetNextNotificationHint(ctx context.Context, remove bool) (*model.NotificationHint, error) {
+	return s.store.GetNextNotificationHint(ctx, remove)
+}
+
+func (s *CacheStore) GetNotificationHint(ctx context.Context, blockID string) (*model.NotificationHint, error) {
+	return s.store.GetNotificationHint(ctx, blockID)
+}
+
+func (s *CacheStore) GetNotificationHints(ctx context.Context, limit int) ([]*model.NotificationHint, error) {
+	return s.store.GetNotificationHints(ctx, limit)
+}
+
+func (s *CacheStore) GetPendingWebhookDeliveries(ctx context.Context, limit int) ([]*model.WebhookDelivery, error) {
+	return s.store.GetPendingWebhookDeliveries(ctx, limit)
+}
+
+func (s *CacheStore) GetRecentComments(ctx context.Context, boardID string, limit int) ([]*model.CommentWithAuthor, error) {
+	return s.store.GetRecentComments(ctx, boardID, limit)
+}
+
+func (s *CacheStore) GetRecentlyViewedBoards(ctx context.Context, userID string, teamID string, limit int) ([]*model.Board, error) {
+	return s.store.GetRecentlyViewedBoards(ctx, userID, teamID, limit)
+}
+
+func (s *CacheStore) GetRegisteredUserCount(ctx context.Context) (int, error) {
+	return s.store.GetRegisteredUserCount(ctx)
+}
+
+func (s *CacheStore) GetSession(ctx context.Context, token string, expireTime int64) (*model.Session, error) {
+	return s.store.GetSession(ctx, token, expireTime)
+}
+
+func (s *CacheStore) GetSessionWithPolicy(ctx context.Context, token string, now int64, idleTimeout time.Duration, maxLifetime time.Duration) (*model.Session, error) {
+	return s.store.GetSessionWithPolicy(ctx, token, now, idleTimeout, maxLifetime)
+}
+
+func (s *CacheStore) GetSessionWithUser(ctx context.Context, token string, now int64, idleTimeout time.Duration, maxLifetime time.Duration) (*model.Session, *model.User, error) {
+	return s.store.GetSessionWithUser(ctx, token, now, idleTimeout, maxLifetime)
+}
+
+func (s *CacheStore) GetShareToken(ctx context.Context, token string) (*model.ShareToken, error) {
+	return s.store.GetShareToken(ctx, token)
+}
+
+func (s *CacheStore) GetShareTokensForBoard(ctx context.Context, boardID string) ([]*model.ShareToken, error) {
+	return s.store.GetShareTokensForBoard(ctx, boardID)
+}
+
+func (s *CacheStore) GetSharing(ctx context.Context, rootID string) (*model.Sharing, error) {
+	return s.store.GetSharing(ctx, rootID)
+}
+
+func (s *CacheStore) GetSharingForBoards(ctx context.Context, rootIDs []string) (map[string]*model.Sharing, error) {
+	return s.store.GetSharingForBoards(ctx, rootIDs)
+}
+
+func (s *CacheStore) GetSubTree(ctx context.Context, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	return s.store.GetSubTree(ctx, boardID, blockID, opts)
+}
+
+func (s *CacheStore) GetSubTree2(ctx context.Context, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	return s.store.GetSubTree2(ctx, boardID, blockID, opts)
+}
+
+func (s *CacheStore) GetSubscribersCountForBlock(ctx context.Context, blockID string) (int, error) {
+	return s.store.GetSubscribersCountForBlock(ctx, blockID)
+}
+
+func (s *CacheStore) GetSubscribersForBlock(ctx context.Context, blockID string, events model.SubscriptionEvents) ([]*model.Subscriber, error) {
+	return s.store.GetSubscribersForBlock(ctx, blockID, events)
+}
+
+func (s *CacheStore) GetSubscription(ctx context.Context, blockID string, subscriberID string) (*model.Subscription, error) {
+	return s.store.GetSubscription(ctx, blockID, subscriberID)
+}
+
+func (s *CacheStore) GetSubscriptions(ctx context.Context, subscriberID string) ([]*model.Subscription, error) {
+	return s.store.GetSubscriptions(ctx, subscriberID)
+}
+
+func (s *CacheStore) GetSystemSetting(ctx context.Context, key string) (string, error) {
+	return s.store.GetSystemSetting(ctx, key)
+}
+
+func (s *CacheStore) GetSystemSettingBool(ctx context.Context, key string) (bool, error) {
+	return s.store.GetSystemSettingBool(ctx, key)
+}
+
+func (s *CacheStore) GetSystemSettingInt(ctx context.Context, key string) (int, error) {
+	return s.store.GetSystemSettingInt(ctx, key)
+}
+
+func (s *CacheStore) GetSystemSettings(ctx context.Context) (map[string]string, error) {
+	return s.store.GetSystemSettings(ctx)
+}
+
+func (s *CacheStore) GetTeam(ctx context.Context, ID string) (*model.Team, error) {
+	return s.store.GetTeam(ctx, ID)
+}
+
+func (s *CacheStore) GetTeamBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	return s.store.GetTeamBoardsInsights(ctx, teamID, userID, since, offset, limit, boardIDs)
+}
+
+func (s *CacheStore) GetTeamBySignupToken(ctx context.Context, token string) (*model.Team, error) {
+	return s.store.GetTeamBySignupToken(ctx, token)
+}
+
+func (s *CacheStore) GetTeamCount(ctx context.Context) (int64, error) {
+	return s.store.GetTeamCount(ctx)
+}
+
+func (s *CacheStore) GetTeamsForUser(ctx context.Context, userID string) ([]*model.Team, error) {
+	return s.store.GetTeamsForUser(ctx, userID)
+}
+
+func (s *CacheStore) GetTeamsForUserWithBoardCounts(ctx context.Context, userID string) ([]model.TeamWithCount, error) {
+	return s.store.GetTeamsForUserWithBoardCounts(ctx, userID)
+}
+
+func (s *CacheStore) GetTemplateBoards(ctx context.Context, teamID string, userID string) ([]*model.Board, error) {
+	return s.store.GetTemplateBoards(ctx, teamID, userID)
+}
+
+func (s *CacheStore) GetTemplateBoardsByVersion(ctx context.Context, teamID string, version int) ([]*model.Board, error) {
+	return s.store.GetTemplateBoardsByVersion(ctx, teamID, version)
+}
+
+func (s *CacheStore) GetUsedCardsCount(ctx context.Context) (int, error) {
+	return s.store.GetUsedCardsCount(ctx)
+}
+
+func (s *CacheStore) GetUserBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	return s.store.GetUserBoardsInsights(ctx, teamID, userID, since, offset, limit, boardIDs)
+}
+
+func (s *CacheStore) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	return s.store.GetUserByEmail(ctx, email)
+}
+
+func (s *CacheStore) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	return s.store.GetUserByID(ctx, userID)
+}
+
+func (s *CacheStore) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	return s.store.GetUserByUsername(ctx, username)
+}
+
+func (s *CacheStore) GetUserCategoryBoards(ctx context.Context, userID string, teamID string) ([]model.CategoryBoards, error) {
+	return s.store.GetUserCategoryBoards(ctx, userID, teamID)
+}
+
+func (s *CacheStore) GetUserPreferences(ctx context.Context, userID string) (mmModel.Preferences, error) {
+	return s.store.GetUserPreferences(ctx, userID)
+}
+
+func (s *CacheStore) GetUserTimezone(ctx context.Context, userID string) (string, error) {
+	return s.store.GetUserTimezone(ctx, userID)
+}
+
+func (s *CacheStore) GetUsersByTeam(ctx context.Context, teamID string, asGuestID string) ([]*model.User, error) {
+	return s.store.GetUsersByTeam(ctx, teamID, asGuestID)
+}
+
+func (s *CacheStore) GetUsersByTeamWithRole(ctx context.Context, teamID string, role string, includeDeleted bool) ([]*model.User, error) {
+	return s.store.GetUsersByTeamWithRole(ctx, teamID, role, includeDeleted)
+}
+
+func (s *CacheStore) GetUsersList(ctx context.Context, userIDs []string) ([]*model.User, error) {
+	return s.store.GetUsersList(ctx, userIDs)
+}
+
+func (s *CacheStore) GetWebhook(ctx context.Context, id string) (*model.Webhook, error) {
+	return s.store.GetWebhook(ctx, id)
+}
+
+func (s *CacheStore) GetWebhooksForBoard(ctx context.Context, boardID string) ([]*model.Webhook, error) {
+	return s.store.GetWebhooksForBoard(ctx, boardID)
+}
+
+func (s *CacheStore) InsertAuditRecord(ctx context.Context, rec *model.AuditRecord) error {
+	return s.store.InsertAuditRecord(ctx, rec)
+}
+
+func (s *CacheStore) InsertBlock(ctx context.Context, block *model.Block, userID string) error {
+	err := s.store.InsertBlock(ctx, block, userID)
+	s.invalidate("block", cacheKey(block.ID))
+	return err
+}
+
+func (s *CacheStore) InsertBlocks(ctx context.Context, blocks []*model.Block, userID string) error {
+	err := s.store.InsertBlocks(ctx, blocks, userID)
+	s.purge("block")
+	return err
+}
+
+func (s *CacheStore) InsertBoard(ctx context.Context, board *model.Board, userID string) (*model.Board, error) {
+	result, err := s.store.InsertBoard(ctx, board, userID)
+	s.invalidate("board", cacheKey(board.ID))
+	return result, err
+}
+
+func (s *CacheStore) InsertBoardWithAdmin(ctx context.Context, board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
+	result, resultVar1, err := s.store.InsertBoardWithAdmin(ctx, board, userID)
+	s.invalidate("board", cacheKey(board.ID))
+	s.invalidate("member", cacheKey(board.ID, userID))
+	return result, resultVar1, err
+}
+
+func (s *CacheStore) MarkWebhookDelivered(ctx context.Context, id string, status int) error {
+	return s.store.MarkWebhookDelivered(ctx, id, status)
+}
+
+func (s *CacheStore) MergeBoards(ctx context.Context, sourceBoardID string, targetBoardID string, userID string) (map[string]string, error) {
+	result, err := s.store.MergeBoards(ctx, sourceBoardID, targetBoardID, userID)
+	s.purge("board")
+	s.purge("block")
+	s.purge("member")
+	return result, err
+}
+
+func (s *CacheStore) MergeCategories(ctx context.Context, userID string, primaryCategoryID string, mergeCategoryIDs []string) error {
+	return s.store.MergeCategories(ctx, userID, primaryCategoryID, mergeCategoryIDs)
+}
+
+func (s *CacheStore) MoveBlocks(ctx context.Context, blockIDs []string, targetBoardID string, userID string) error {
+	err := s.store.MoveBlocks(ctx, blockIDs, targetBoardID, userID)
+	s.purge("block")
+	return err
+}
+
+func (s *CacheStore) NormalizeContentOrder(ctx context.Context, cardID string) error {
+	err := s.store.NormalizeContentOrder(ctx, cardID)
+	s.invalidate("block", cacheKey(cardID))
+	return err
+}
+
+func (s *CacheStore) PatchBlock(ctx context.Context, blockID string, blockPatch *model.BlockPatch, userID string) (int64, error) {
+	result, err := s.store.PatchBlock(ctx, blockID, blockPatch, userID)
+	s.invalidate("block", cacheKey(blockID))
+	return result, err
+}
+
+func (s *CacheStore) PatchBlocks(ctx context.Context, blockPatches *model.BlockPatchBatch, userID string) error {
+	err := s.store.PatchBlocks(ctx, blockPatches, userID)
+	s.purge("block")
+	return err
+}
+
+func (s *CacheStore) PatchBoard(ctx context.Context, boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+	result, err := s.store.PatchBoard(ctx, boardID, boardPatch, userID)
+	s.invalidate("board", cacheKey(boardID))
+	s.purge("member")
+	return result, err
+}
+
+func (s *CacheStore) PatchBoardsAndBlocks(ctx context.Context, pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	result, err := s.store.PatchBoardsAndBlocks(ctx, pbab, userID)
+	s.purge("board")
+	s.purge("block")
+	s.purge("member")
+	return result, err
+}
+
+func (s *CacheStore) PatchUserPreferences(ctx context.Context, userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
+	return s.store.PatchUserPreferences(ctx, userID, patch)
+}
+
+func (s *CacheStore) PostMessage(ctx context.Context, message string, postType string, channelID string) error {
+	return s.store.PostMessage(ctx, message, postType, channelID)
+}
+
+func (s *CacheStore) PurgeArchivedBoards(ctx context.Context, olderThan time.Time) (int, error) {
+	result, err := s.store.PurgeArchivedBoards(ctx, olderThan)
+	s.purge("board")
+	s.purge("block")
+	s.purge("member")
+	return result, err
+}
+
+func (s *CacheStore) PurgeDeletedBlocks(ctx context.Context, olderThan time.Time) (int, error) {
+	return s.store.PurgeDeletedBlocks(ctx, olderThan)
+}
+
+func (s *CacheStore) ReactivateUser(ctx context.Context, userID string) error {
+	return s.store.ReactivateUser(ctx, userID)
+}
+
+func (s *CacheStore) RecordWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	return s.store.RecordWebhookDelivery(ctx, delivery)
+}
+
+func (s *CacheStore) RefreshSession(ctx context.Context, session *model.Session) error {
+	return s.store.RefreshSession(ctx, session)
+}
+
+func (s *CacheStore) ReinstallDefaultTemplates(ctx context.Context, teamID string, templates []*model.Board, userID string) error {
+	err := s.store.ReinstallDefaultTemplates(ctx, teamID, templates, userID)
+	s.purge("board")
+	s.purge("block")
+	s.purge("member")
+	return err
+}
+
+func (s *CacheStore) RemoveCategoryBoards(ctx context.Context, userID string, categoryID string, boardIDs []string) error {
+	return s.store.RemoveCategoryBoards(ctx, userID, categoryID, boardIDs)
+}
+
+func (s *CacheStore) RemoveDefaultTemplates(ctx context.Context, boards []*model.Board) error {
+	err := s.store.RemoveDefaultTemplates(ctx, boards)
+	s.purge("board")
+	s.purge("block")
+	return err
+}
+
+func (s *CacheStore) ReorderCategories(ctx context.Context, userID string, teamID string, categoryIDs []string) error {
+	return s.store.ReorderCategories(ctx, userID, teamID, categoryIDs)
+}
+
+func (s *CacheStore) ReorderCategoryBoards(ctx context.Context, userID string, categoryID string, boardIDs []string) error {
+	return s.store.ReorderCategoryBoards(ctx, userID, categoryID, boardIDs)
+}
+
+func (s *CacheStore) RestoreBlock(ctx context.Context, blockID string, userID string) (*model.Block, error) {
+	result, err := s.store.RestoreBlock(ctx, blockID, userID)
+	s.invalidate("block", cacheKey(blockID))
+	return result, err
+}
+
+func (s *CacheStore) RestoreBoard(ctx context.Context, boardID string, userID string) error {
+	err := s.store.RestoreBoard(ctx, boardID, userID)
+	s.invalidate("board", cacheKey(boardID))
+	s.purge("member")
+	return err
+}
+
+func (s *CacheStore) RotateSharingToken(ctx context.Context, rootID string, userID string) (*model.Sharing, error) {
+	return s.store.RotateSharingToken(ctx, rootID, userID)
+}
+
+func (s *CacheStore) RunDataRetention(ctx context.Context, globalRetentionDate int64, batchSize int64) (int64, error) {
+	result, err := s.store.RunDataRetention(ctx, globalRetentionDate, batchSize)
+	s.purge("board")
+	s.purge("block")
+	s.purge("member")
+	return result, err
+}
+
+func (s *CacheStore) SaveFileInfo(ctx context.Context, fileInfo *mmModel.FileInfo) error {
+	return s.store.SaveFileInfo(ctx, fileInfo)
+}
+
+func (s *CacheStore) SaveMember(ctx context.Context, bm *model.BoardMember, modifiedBy string) (*model.BoardMember, error) {
+	result, err := s.store.SaveMember(ctx, bm, modifiedBy)
+	s.invalidate("member", cacheKey(bm.BoardID, bm.UserID))
+	return result, err
+}
+
+func (s *CacheStore) SaveMemberWithLimit(ctx context.Context, bm *model.BoardMember, maxMembers int, modifiedBy string) (*model.BoardMember, error) {
+	result, err := s.store.SaveMemberWithLimit(ctx, bm, maxMembers, modifiedBy)
+	s.invalidate("member", cacheKey(bm.BoardID, bm.UserID))
+	return result, err
+}
+
+func (s *CacheStore) SaveMembers(ctx context.Context, members []*model.BoardMember, modifiedBy string) ([]*model.BoardMember, error) {
+	result, err := s.store.SaveMembers(ctx, members, modifiedBy)
+	s.purge("member")
+	return result, err
+}
+
+func (s *CacheStore) SearchBlocksForBoard(ctx context.Context, boardID string, term string, fields []string) ([]*model.Block, error) {
+	return s.store.SearchBlocksForBoard(ctx, boardID, term, fields)
+}
+
+func (s *CacheStore) SearchBlocksForUser(ctx context.Context, teamID string, term string, userID string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	return s.store.SearchBlocksForUser(ctx, teamID, term, userID, opts)
+}
+
+func (s *CacheStore) SearchBlocksInBoards(ctx context.Context, boardIDs []string, term string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	return s.store.SearchBlocksInBoards(ctx, boardIDs, term, opts)
+}
+
+func (s *CacheStore) SearchBoardsForUser(ctx context.Context, term string, userID string, includePublicBoards bool) ([]*model.Board, error) {
+	return s.store.SearchBoardsForUser(ctx, term, userID, includePublicBoards)
+}
+
+func (s *CacheStore) SearchBoardsForUserInTeam(ctx context.Context, teamID string, term string, userID string) ([]*model.Board, error) {
+	return s.store.SearchBoardsForUserInTeam(ctx, teamID, term, userID)
+}
+
+func (s *CacheStore) SearchUserChannels(ctx context.Context, teamID string, userID string, query string) ([]*mmModel.Channel, error) {
+	return s.store.SearchUserChannels(ctx, teamID, userID, query)
+}
+
+func (s *CacheStore) SearchUsersByTeam(ctx context.Context, teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
+	return s.store.SearchUsersByTeam(ctx, teamID, searchQuery, asGuestID, excludeBots)
+}
+
+func (s *CacheStore) SendMessage(ctx context.Context, message string, postType string, receipts []string) error {
+	return s.store.SendMessage(ctx, message, postType, receipts)
+}
+
+func (s *CacheStore) SetBoardFavorite(ctx context.Context, userID string, boardID string, favorite bool) error {
+	return s.store.SetBoardFavorite(ctx, userID, boardID, favorite)
+}
+
+func (s *CacheStore) SetBoardPropertyOrder(ctx context.Context, boardID string, propertyIDs []string, userID string) error {
+	err := s.store.SetBoardPropertyOrder(ctx, boardID, propertyIDs, userID)
+	s.invalidate("board", cacheKey(boardID))
+	s.purge("member")
+	return err
+}
+
+func (s *CacheStore) SetMemberCustomRole(ctx context.Context, boardID string, userID string, roleID string, modifiedBy string) error {
+	err := s.store.SetMemberCustomRole(ctx, boardID, userID, roleID, modifiedBy)
+	s.invalidate("member", cacheKey(boardID, userID))
+	return err
+}
+
+func (s *CacheStore) SetSystemSetting(ctx context.Context, key string, value string) error {
+	return s.store.SetSystemSetting(ctx, key, value)
+}
+
+func (s *CacheStore) UnarchiveCard(ctx context.Context, cardID string, userID string) error {
+	err := s.store.UnarchiveCard(ctx, cardID, userID)
+	s.invalidate("block", cacheKey(cardID))
+	return err
+}
+
+func (s *CacheStore) UndeleteBlock(ctx context.Context, blockID string, modifiedBy string) error {
+	err := s.store.UndeleteBlock(ctx, blockID, modifiedBy)
+	s.invalidate("block", cacheKey(blockID))
+	return err
+}
+
+func (s *CacheStore) UndeleteBoard(ctx context.Context, boardID string, modifiedBy string) error {
+	err := s.store.UndeleteBoard(ctx, boardID, modifiedBy)
+	s.invalidate("board", cacheKey(boardID))
+	s.purge("block")
+	s.purge("member")
+	return err
+}
+
+func (s *CacheStore) UpdateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole, userID string) error {
+	return s.store.UpdateBoardCustomRole(ctx, role, userID)
+}
+
+func (s *CacheStore) UpdateCardLimitTimestamp(ctx context.Context, cardLimit int) (int64, error) {
+	return s.store.UpdateCardLimitTimestamp(ctx, cardLimit)
+}
+
+func (s *CacheStore) UpdateCategory(ctx context.Context, category model.Category) error {
+	return s.store.UpdateCategory(ctx, category)
+}
+
+func (s *CacheStore) UpdateMemberLastViewed(ctx context.Context, boardID string, userID string, viewedAt int64) error {
+	err := s.store.UpdateMemberLastViewed(ctx, boardID, userID, viewedAt)
+	s.invalidate("member", cacheKey(boardID, userID))
+	return err
+}
+
+func (s *CacheStore) UpdateMemberRole(ctx context.Context, boardID string, userID string, role string, modifiedBy string) error {
+	err := s.store.UpdateMemberRole(ctx, boardID, userID, role, modifiedBy)
+	s.invalidate("member", cacheKey(boardID, userID))
+	return err
+}
+
+func (s *CacheStore) UpdateSession(ctx context.Context, session *model.Session) error {
+	return s.store.UpdateSession(ctx, session)
+}
+
+func (s *CacheStore) UpdateSubscriberNotifiedAt(ctx context.Context, blockID string, subscriberID string, notifiedAt int64) error {
+	return s.store.UpdateSubscriberNotifiedAt(ctx, blockID, subscriberID, notifiedAt)
+}
+
+func (s *CacheStore) UpdateSubscribersNotifiedAt(ctx context.Context, blockID string, notifiedAt int64) error {
+	return s.store.UpdateSubscribersNotifiedAt(ctx, blockID, notifiedAt)
+}
+
+func (s *CacheStore) UpdateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	return s.store.UpdateSubscription(ctx, sub)
+}
+
+func (s *CacheStore) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	return s.store.UpdateUser(ctx, user)
+}
+
+func (s *CacheStore) UpdateUserPassword(ctx context.Context, username string, password string) error {
+	return s.store.UpdateUserPassword(ctx, username, password)
+}
+
+func (s *CacheStore) UpdateUserPasswordByID(ctx context.Context, userID string, password string) error {
+	return s.store.UpdateUserPasswordByID(ctx, userID, password)
+}
+
+func (s *CacheStore) UpdateWebhook(ctx context.Context, webhook *model.Webhook, userID string) error {
+	return s.store.UpdateWebhook(ctx, webhook, userID)
+}
+
+func (s *CacheStore) UpgradeDefaultTemplates(ctx context.Context, teamID string, toVersion int, templates *model.BoardsAndBlocks) error {
+	err := s.store.UpgradeDefaultTemplates(ctx, teamID, toVersion, templates)
+	s.purge("board")
+	s.purge("block")
+	s.purge("member")
+	return err
+}
+
+func (s *CacheStore) UpsertBoardSnapshot(ctx context.Context, snapshot *model.BoardSnapshot) error {
+	return s.store.UpsertBoardSnapshot(ctx, snapshot)
+}
+
+func (s *CacheStore) UpsertDefaultCategoryTemplate(ctx context.Context, template model.CategoryTemplate) error {
+	return s.store.UpsertDefaultCategoryTemplate(ctx, template)
+}
+
+func (s *CacheStore) UpsertNotificationHint(ctx context.Context, hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
+	return s.store.UpsertNotificationHint(ctx, hint, notificationFreq)
+}
+
+func (s *CacheStore) UpsertSharing(ctx context.Context, sharing model.Sharing) error {
+	return s.store.UpsertSharing(ctx, sharing)
+}
+
+func (s *CacheStore) UpsertTeamSettings(ctx context.Context, team model.Team) error {
+	return s.store.UpsertTeamSettings(ctx, team)
+}
+
+func (s *CacheStore) UpsertTeamSignupToken(ctx context.Context, team model.Team) error {
+	return s.store.UpsertTeamSignupToken(ctx, team)
+}
+
+func (s *CacheStore) ValidateBoardSchema(ctx context.Context, boardID string) (*model.SchemaReport, error) {
+	return s.store.ValidateBoardSchema(ctx, boardID)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cachestore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	redisKeyPrefix = "focalboard:cache:"
+
+	// redisPurgeBatchSize is the number of keys scanned and deleted at
+	// once when purging a cache.
+	redisPurgeBatchSize = 1000
+)
+
+// RedisCache keeps the entries in Redis, where they are shared and
+// invalidated by all the servers using it.
+type RedisCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisCache creates a cache whose entries expire after ttl unless it
+// is zero.
+func NewRedisCache(address, password string, db int, ttl time.Duration) *RedisCache {
+	return &RedisCache{
+		client: redis.NewClient(&redis.Options{
+			Addr:     address,
+			Password: password,
+			DB:       db,
+		}),
+		ttl: ttl,
+	}
+}
+
+func redisKey(cache, key string) string {
+	return redisKeyPrefix + cache + ":" + key
+}
+
+func (c *RedisCache) Get(cache, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(context.Background(), redisKey(cache, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *RedisCache) Set(cache, key string, value []byte) error {
+	return c.client.Set(context.Background(), redisKey(cache, key), value, c.ttl).Err()
+}
+
+func (c *RedisCache) Delete(cache, key string) error {
+	return c.client.Del(context.Background(), redisKey(cache, key)).Err()
+}
+
+func (c *RedisCache) Purge(cache string) error {
+	ctx := context.Background()
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(ctx, cursor, redisKey(cache, "*"), redisPurgeBatchSize).Result()
+		if err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			if err := c.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
@@ -21,6 +21,8 @@ import (
 
 const (
 	WithTransactionComment = "@withTransaction"
+	CachedComment          = "@cached"
+	InvalidatesComment     = "@invalidatesCache"
 	ErrorType              = "error"
 	StringType             = "string"
 	IntType                = "int"
@@ -49,6 +51,15 @@ func main() {
 	if err := buildTransactionalStore(); err != nil {
 		log.Fatal(err)
 	}
+	if err := buildMetricsStore(); err != nil {
+		log.Fatal(err)
+	}
+	if err := buildCacheStore(); err != nil {
+		log.Fatal(err)
+	}
+	if err := buildMemStore(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func buildTransactionalStore() error {
@@ -64,15 +75,64 @@ func buildTransactionalStore() error {
 	return ioutil.WriteFile(path.Join("sqlstore/public_methods.go"), formatedCode, 0644) //nolint:gosec
 }
 
+func buildMetricsStore() error {
+	code, err := generateLayer("MetricsStore", "metrics_store.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("metricsstore/public_methods.go"), formatedCode, 0644) //nolint:gosec
+}
+
+func buildCacheStore() error {
+	code, err := generateLayer("CacheStore", "cache_store.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("cachestore/public_methods.go"), formatedCode, 0644) //nolint:gosec
+}
+
+func buildMemStore() error {
+	code, err := generateLayer("MemStore", "memory_store.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("memstore/public_methods.go"), formatedCode, 0644) //nolint:gosec
+}
+
 type methodParam struct {
 	Name string
 	Type string
 }
 
+// cacheInvalidation is a cache entry, or with no keys a whole cache,
+// invalidated by a store method. The keys are Go expressions built from
+// the parameters of the method.
+type cacheInvalidation struct {
+	Cache string
+	Keys  []string
+}
+
 type methodData struct {
 	Params          []methodParam
 	Results         []string
 	WithTransaction bool
+	Cache           string
+	Invalidations   []cacheInvalidation
 }
 
 type storeMetadata struct {
@@ -81,23 +141,50 @@ type storeMetadata struct {
 }
 
 var blacklistedStoreMethodNames = map[string]bool{
-	"Shutdown": true,
-	"DBType":   true,
+	"Shutdown":           true,
+	"DBType":             true,
+	"SetBoardBlockLimit": true,
+}
+
+// parseInvalidations parses the caches listed after an
+// @invalidatesCache annotation, as "cache" to invalidate a whole cache
+// or "cache:key1,key2" to invalidate a single entry.
+func parseInvalidations(text string) []cacheInvalidation {
+	invalidations := []cacheInvalidation{}
+	specs := strings.Fields(text[strings.Index(text, InvalidatesComment)+len(InvalidatesComment):])
+	for _, spec := range specs {
+		cache, keys, hasKeys := strings.Cut(spec, ":")
+		invalidation := cacheInvalidation{Cache: cache}
+		if hasKeys {
+			invalidation.Keys = strings.Split(keys, ",")
+		}
+		invalidations = append(invalidations, invalidation)
+	}
+	return invalidations
 }
 
 func extractMethodMetadata(method *ast.Field, src []byte) methodData {
 	params := []methodParam{}
 	results := []string{}
 	withTransaction := false
+	cache := ""
+	invalidations := []cacheInvalidation{}
 	ast.Inspect(method.Type, func(expr ast.Node) bool {
 		//nolint:gocritic
 		switch e := expr.(type) {
 		case *ast.FuncType:
 			if method.Doc != nil {
 				for _, comment := range method.Doc.List {
-					if strings.Contains(comment.Text, WithTransactionComment) {
+					switch {
+					case strings.Contains(comment.Text, WithTransactionComment):
 						withTransaction = true
-						break
+					case strings.Contains(comment.Text, InvalidatesComment):
+						invalidations = append(invalidations, parseInvalidations(comment.Text)...)
+					case strings.Contains(comment.Text, CachedComment):
+						fields := strings.Fields(comment.Text[strings.Index(comment.Text, CachedComment)+len(CachedComment):])
+						if len(fields) > 0 {
+							cache = fields[0]
+						}
 					}
 				}
 			}
@@ -113,10 +200,18 @@ func extractMethodMetadata(method *ast.Field, src []byte) methodData {
 					results = append(results, string(src[result.Type.Pos()-1:result.Type.End()-1]))
 				}
 			}
+			// don't descend into function types used as parameters
+			return false
 		}
 		return true
 	})
-	return methodData{Params: params, Results: results, WithTransaction: withTransaction}
+	return methodData{
+		Params:          params,
+		Results:         results,
+		WithTransaction: withTransaction,
+		Cache:           cache,
+		Invalidations:   invalidations,
+	}
 }
 
 func extractStoreMetadata() (*storeMetadata, error) {
@@ -213,6 +308,22 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 			return ""
 		},
 		"joinParams": func(params []methodParam) string {
+			paramsNames := make([]string, 0, len(params))
+			for _, param := range params {
+				// the context is passed to the private methods through
+				// their db runner
+				if param.Type == "context.Context" {
+					continue
+				}
+				tParams := ""
+				if strings.HasPrefix(param.Type, "...") {
+					tParams = "..."
+				}
+				paramsNames = append(paramsNames, param.Name+tParams)
+			}
+			return strings.Join(paramsNames, ", ")
+		},
+		"joinAllParams": func(params []methodParam) string {
 			paramsNames := make([]string, 0, len(params))
 			for _, param := range params {
 				tParams := ""
@@ -235,6 +346,19 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 			}
 			return strings.Join(paramsWithType, ", ")
 		},
+		"joinKeyParams": func(params []methodParam) string {
+			paramsNames := make([]string, 0, len(params))
+			for _, param := range params {
+				if param.Type == "context.Context" {
+					continue
+				}
+				paramsNames = append(paramsNames, param.Name)
+			}
+			return strings.Join(paramsNames, ", ")
+		},
+		"joinKeys": func(keys []string) string {
+			return strings.Join(keys, ", ")
+		},
 		"renameStoreMethod": func(methodName string) string {
 			return strings.ToLower(methodName[0:1]) + methodName[1:]
 		},
@@ -1,12 +1,15 @@
 package mattermostauthlayer
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"time"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 
@@ -14,6 +17,7 @@ import (
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/store/sqlstore"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -72,12 +76,12 @@ func (s *MattermostAuthLayer) Shutdown() error {
 	return s.Store.Shutdown()
 }
 
-func (s *MattermostAuthLayer) GetRegisteredUserCount() (int, error) {
+func (s *MattermostAuthLayer) GetRegisteredUserCount(ctx context.Context) (int, error) {
 	query := s.getQueryBuilder().
 		Select("count(*)").
 		From("Users").
 		Where(sq.Eq{"deleteAt": 0})
-	row := query.QueryRow()
+	row := query.QueryRowContext(ctx)
 
 	var count int
 	err := row.Scan(&count)
@@ -88,7 +92,7 @@ func (s *MattermostAuthLayer) GetRegisteredUserCount() (int, error) {
 	return count, nil
 }
 
-func (s *MattermostAuthLayer) GetUserByID(userID string) (*model.User, error) {
+func (s *MattermostAuthLayer) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
 	mmuser, err := s.servicesAPI.GetUserByID(userID)
 	if err != nil {
 		return nil, err
@@ -97,7 +101,7 @@ func (s *MattermostAuthLayer) GetUserByID(userID string) (*model.User, error) {
 	return &user, nil
 }
 
-func (s *MattermostAuthLayer) GetUserByEmail(email string) (*model.User, error) {
+func (s *MattermostAuthLayer) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	mmuser, err := s.servicesAPI.GetUserByEmail(email)
 	if err != nil {
 		return nil, err
@@ -106,7 +110,7 @@ func (s *MattermostAuthLayer) GetUserByEmail(email string) (*model.User, error)
 	return &user, nil
 }
 
-func (s *MattermostAuthLayer) GetUserByUsername(username string) (*model.User, error) {
+func (s *MattermostAuthLayer) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 	mmuser, err := s.servicesAPI.GetUserByUsername(username)
 	if err != nil {
 		return nil, err
@@ -115,24 +119,32 @@ func (s *MattermostAuthLayer) GetUserByUsername(username string) (*model.User, e
 	return &user, nil
 }
 
-func (s *MattermostAuthLayer) CreateUser(user *model.User) (*model.User, error) {
+func (s *MattermostAuthLayer) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
 	return nil, store.NewNotSupportedError("no user creation allowed from focalboard, create it using mattermost")
 }
 
-func (s *MattermostAuthLayer) UpdateUser(user *model.User) (*model.User, error) {
+func (s *MattermostAuthLayer) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
 	return nil, store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
-func (s *MattermostAuthLayer) UpdateUserPassword(username, password string) error {
+func (s *MattermostAuthLayer) DeactivateUser(ctx context.Context, userID string) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
-func (s *MattermostAuthLayer) UpdateUserPasswordByID(userID, password string) error {
+func (s *MattermostAuthLayer) ReactivateUser(ctx context.Context, userID string) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
-func (s *MattermostAuthLayer) PatchUserPreferences(userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
-	preferences, err := s.GetUserPreferences(userID)
+func (s *MattermostAuthLayer) UpdateUserPassword(ctx context.Context, username, password string) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
+func (s *MattermostAuthLayer) UpdateUserPasswordByID(ctx context.Context, userID, password string) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
+func (s *MattermostAuthLayer) PatchUserPreferences(ctx context.Context, userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
+	preferences, err := s.GetUserPreferences(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -214,18 +226,18 @@ func (s *MattermostAuthLayer) PatchUserPreferences(userID string, patch model.Us
 	return preferences, nil
 }
 
-func (s *MattermostAuthLayer) GetUserPreferences(userID string) (mmModel.Preferences, error) {
+func (s *MattermostAuthLayer) GetUserPreferences(ctx context.Context, userID string) (mmModel.Preferences, error) {
 	return s.servicesAPI.GetPreferencesForUser(userID)
 }
 
 // GetActiveUserCount returns the number of users with active sessions within N seconds ago.
-func (s *MattermostAuthLayer) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
+func (s *MattermostAuthLayer) GetActiveUserCount(ctx context.Context, updatedSecondsAgo int64) (int, error) {
 	query := s.getQueryBuilder().
 		Select("count(distinct userId)").
 		From("Sessions").
 		Where(sq.Gt{"LastActivityAt": utils.GetMillis() - utils.SecondsToMillis(updatedSecondsAgo)})
 
-	row := query.QueryRow()
+	row := query.QueryRowContext(ctx)
 
 	var count int
 	err := row.Scan(&count)
@@ -236,31 +248,128 @@ func (s *MattermostAuthLayer) GetActiveUserCount(updatedSecondsAgo int64) (int,
 	return count, nil
 }
 
-func (s *MattermostAuthLayer) GetSession(token string, expireTime int64) (*model.Session, error) {
+// GetActiveUserCountByTeam returns the number of members of a team with
+// sessions active since the given time.
+func (s *MattermostAuthLayer) GetActiveUserCountByTeam(ctx context.Context, teamID string, since int64) (int, error) {
+	query := s.getQueryBuilder().
+		Select("count(distinct s.UserId)").
+		From("Sessions as s").
+		Join("TeamMembers as tm ON tm.UserId = s.UserId").
+		Where(sq.Eq{"tm.TeamId": teamID}).
+		Where(sq.Eq{"tm.DeleteAt": 0}).
+		Where(sq.GtOrEq{"s.LastActivityAt": since})
+
+	row := query.QueryRowContext(ctx)
+
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetActiveUserCountsByDay returns the number of distinct members of a
+// team with sessions active on each day of the [from, to) range, keyed
+// by the start of the day in the server's timezone. Days without active
+// users are included with a zero count.
+func (s *MattermostAuthLayer) GetActiveUserCountsByDay(ctx context.Context, teamID string, from, to int64) (map[int64]int, error) {
+	dayStarts := utils.DayStartsInRange(from, to, time.Local)
+	if len(dayStarts) > model.MaxActiveUserCountDays {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("active user counts can't span more than %d days", model.MaxActiveUserCountDays))
+	}
+
+	counts := make(map[int64]int, len(dayStarts))
+	for _, dayStart := range dayStarts {
+		counts[dayStart] = 0
+	}
+	if len(dayStarts) == 0 {
+		return counts, nil
+	}
+
+	sessionsQuery := s.getQueryBuilder().
+		Select("s.UserId AS user_id").
+		Column(dayBucketExpr("s.LastActivityAt", dayStarts)).
+		From("Sessions as s").
+		Join("TeamMembers as tm ON tm.UserId = s.UserId").
+		Where(sq.Eq{"tm.TeamId": teamID}).
+		Where(sq.Eq{"tm.DeleteAt": 0}).
+		Where(sq.GtOrEq{"s.LastActivityAt": from}).
+		Where(sq.Lt{"s.LastActivityAt": to})
+
+	query := s.getQueryBuilder().
+		Select("day", "count(distinct user_id)").
+		FromSelect(sessionsQuery, "active_sessions").
+		GroupBy("day")
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var day int64
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, err
+		}
+		counts[day] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// dayBucketExpr returns an expression mapping a timestamp column to the
+// start of the day it falls in.
+func dayBucketExpr(column string, dayStarts []int64) string {
+	var sb strings.Builder
+	sb.WriteString("CASE")
+	for i := len(dayStarts) - 1; i >= 0; i-- {
+		fmt.Fprintf(&sb, " WHEN %s >= %d THEN %d", column, dayStarts[i], dayStarts[i])
+	}
+	sb.WriteString(" END AS day")
+	return sb.String()
+}
+
+func (s *MattermostAuthLayer) GetSession(ctx context.Context, token string, expireTime int64) (*model.Session, error) {
 	return nil, store.NewNotSupportedError("sessions not used when using mattermost")
 }
 
-func (s *MattermostAuthLayer) CreateSession(session *model.Session) error {
-	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+func (s *MattermostAuthLayer) GetSessionWithPolicy(ctx context.Context, token string, now int64, idleTimeout, maxLifetime time.Duration) (*model.Session, error) {
+	return nil, store.NewNotSupportedError("sessions not used when using mattermost")
 }
 
-func (s *MattermostAuthLayer) RefreshSession(session *model.Session) error {
+func (s *MattermostAuthLayer) GetSessionWithUser(ctx context.Context, token string, now int64, idleTimeout, maxLifetime time.Duration) (*model.Session, *model.User, error) {
+	return nil, nil, store.NewNotSupportedError("sessions not used when using mattermost")
+}
+
+func (s *MattermostAuthLayer) CreateSession(ctx context.Context, session *model.Session) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
-func (s *MattermostAuthLayer) UpdateSession(session *model.Session) error {
+func (s *MattermostAuthLayer) RefreshSession(ctx context.Context, session *model.Session) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
-func (s *MattermostAuthLayer) DeleteSession(sessionID string) error {
+func (s *MattermostAuthLayer) UpdateSession(ctx context.Context, session *model.Session) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
-func (s *MattermostAuthLayer) CleanUpSessions(expireTime int64) error {
+func (s *MattermostAuthLayer) DeleteSession(ctx context.Context, sessionID string) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
-func (s *MattermostAuthLayer) GetTeam(id string) (*model.Team, error) {
+func (s *MattermostAuthLayer) CleanUpSessions(ctx context.Context, idleTimeout, maxLifetime time.Duration, batchSize int64) (int64, error) {
+	return 0, store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
+func (s *MattermostAuthLayer) DeleteTeam(ctx context.Context, teamID string) (int, error) {
+	return 0, store.NewNotSupportedError("no team deletion allowed from focalboard, delete it using mattermost")
+}
+
+func (s *MattermostAuthLayer) GetTeam(ctx context.Context, id string) (*model.Team, error) {
 	if id == "0" {
 		team := model.Team{
 			ID:    id,
@@ -275,7 +384,7 @@ func (s *MattermostAuthLayer) GetTeam(id string) (*model.Team, error) {
 		From("Teams").
 		Where(sq.Eq{"ID": id})
 
-	row := query.QueryRow()
+	row := query.QueryRowContext(ctx)
 	var displayName string
 	err := row.Scan(&displayName)
 	if err != nil && !model.IsErrNotFound(err) {
@@ -290,7 +399,7 @@ func (s *MattermostAuthLayer) GetTeam(id string) (*model.Team, error) {
 }
 
 // GetTeamsForUser retrieves all the teams that the user is a member of.
-func (s *MattermostAuthLayer) GetTeamsForUser(userID string) ([]*model.Team, error) {
+func (s *MattermostAuthLayer) GetTeamsForUser(ctx context.Context, userID string) ([]*model.Team, error) {
 	query := s.getQueryBuilder().
 		Select("t.Id", "t.DisplayName").
 		From("Teams as t").
@@ -298,7 +407,7 @@ func (s *MattermostAuthLayer) GetTeamsForUser(userID string) ([]*model.Team, err
 		Where(sq.Eq{"tm.UserId": userID}).
 		Where(sq.Eq{"tm.DeleteAt": 0})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -322,6 +431,52 @@ func (s *MattermostAuthLayer) GetTeamsForUser(userID string) ([]*model.Team, err
 	return teams, nil
 }
 
+func (s *MattermostAuthLayer) GetTeamsForUserWithBoardCounts(ctx context.Context, userID string) ([]model.TeamWithCount, error) {
+	subquery, args, err := sq.Select("b.team_id", "COUNT(*) AS board_count").
+		From(s.tablePrefix + "boards AS b").
+		Join(s.tablePrefix + "board_members AS bm ON bm.board_id = b.id").
+		Where(sq.Eq{"bm.user_id": userID}).
+		Where(sq.Eq{"b.is_template": false}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0}).
+		GroupBy("b.team_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Select("t.Id", "t.DisplayName", "COALESCE(bc.board_count, 0)").
+		From("Teams as t").
+		Join("TeamMembers as tm on t.Id=tm.TeamId").
+		LeftJoin("("+subquery+") AS bc ON bc.team_id = t.Id", args...).
+		Where(sq.Eq{"tm.UserId": userID}).
+		Where(sq.Eq{"tm.DeleteAt": 0})
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	teams := []model.TeamWithCount{}
+	for rows.Next() {
+		var team model.TeamWithCount
+
+		err := rows.Scan(
+			&team.ID,
+			&team.Title,
+			&team.BoardCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
 func (s *MattermostAuthLayer) getQueryBuilder() sq.StatementBuilderType {
 	builder := sq.StatementBuilder
 	if s.dbType == model.PostgresDBType || s.dbType == model.SqliteDBType {
@@ -331,7 +486,7 @@ func (s *MattermostAuthLayer) getQueryBuilder() sq.StatementBuilderType {
 	return builder.RunWith(s.mmDB)
 }
 
-func (s *MattermostAuthLayer) GetUsersByTeam(teamID string, asGuestID string) ([]*model.User, error) {
+func (s *MattermostAuthLayer) GetUsersByTeam(ctx context.Context, teamID string, asGuestID string) ([]*model.User, error) {
 	query := s.getQueryBuilder().
 		Select("u.id", "u.username", "u.email", "u.nickname", "u.firstname", "u.lastname", "u.CreateAt as create_at", "u.UpdateAt as update_at",
 			"u.DeleteAt as delete_at", "b.UserId IS NOT NULL AS is_bot, u.roles = 'system_guest' as is_guest").
@@ -344,7 +499,7 @@ func (s *MattermostAuthLayer) GetUsersByTeam(teamID string, asGuestID string) ([
 			Join("TeamMembers as tm ON tm.UserID = u.id").
 			Where(sq.Eq{"tm.TeamId": teamID})
 	} else {
-		boards, err := s.GetBoardsForUserAndTeam(asGuestID, teamID, false)
+		boards, err := s.GetBoardsForUserAndTeam(ctx, asGuestID, teamID, false)
 		if err != nil {
 			return nil, err
 		}
@@ -358,7 +513,7 @@ func (s *MattermostAuthLayer) GetUsersByTeam(teamID string, asGuestID string) ([
 			Where(sq.Eq{"bm.BoardId": boardsIDs})
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -372,7 +527,44 @@ func (s *MattermostAuthLayer) GetUsersByTeam(teamID string, asGuestID string) ([
 	return users, nil
 }
 
-func (s *MattermostAuthLayer) GetUsersList(userIDs []string) ([]*model.User, error) {
+func (s *MattermostAuthLayer) GetUsersByTeamWithRole(ctx context.Context, teamID, role string, includeDeleted bool) ([]*model.User, error) {
+	query := s.getQueryBuilder().
+		Select("u.id", "u.username", "u.email", "u.nickname", "u.firstname", "u.lastname", "u.CreateAt as create_at", "u.UpdateAt as update_at",
+			"u.DeleteAt as delete_at", "b.UserId IS NOT NULL AS is_bot, u.roles = 'system_guest' as is_guest").
+		From("Users as u").
+		LeftJoin("Bots b ON ( b.UserID = u.id )").
+		Join("TeamMembers as tm ON tm.UserID = u.id").
+		Where(sq.Eq{"tm.TeamId": teamID}).
+		Where(sq.Eq{"tm.DeleteAt": 0}).
+		OrderBy("u.username", "u.id")
+
+	switch role {
+	case model.TeamRoleAdmin:
+		query = query.Where(sq.Eq{"tm.SchemeAdmin": true})
+	case model.TeamRoleUser:
+		query = query.
+			Where(sq.Eq{"tm.SchemeUser": true}).
+			Where(sq.Eq{"tm.SchemeAdmin": false})
+	case model.TeamRoleGuest:
+		query = query.Where(sq.Eq{"tm.SchemeGuest": true})
+	default:
+		return nil, model.NewErrBadRequest("invalid team role: " + role)
+	}
+
+	if !includeDeleted {
+		query = query.Where(sq.Eq{"u.DeleteAt": 0})
+	}
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.usersFromRows(rows)
+}
+
+func (s *MattermostAuthLayer) GetUsersList(ctx context.Context, userIDs []string) ([]*model.User, error) {
 	query := s.getQueryBuilder().
 		Select("u.id", "u.username", "u.email", "u.nickname", "u.firstname", "u.lastname", "u.CreateAt as create_at", "u.UpdateAt as update_at",
 			"u.DeleteAt as delete_at", "b.UserId IS NOT NULL AS is_bot, u.roles = 'system_guest' as is_guest").
@@ -380,7 +572,7 @@ func (s *MattermostAuthLayer) GetUsersList(userIDs []string) ([]*model.User, err
 		LeftJoin("Bots b ON ( b.UserId = u.id )").
 		Where(sq.Eq{"u.id": userIDs})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -398,7 +590,7 @@ func (s *MattermostAuthLayer) GetUsersList(userIDs []string) ([]*model.User, err
 	return users, nil
 }
 
-func (s *MattermostAuthLayer) SearchUsersByTeam(teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
+func (s *MattermostAuthLayer) SearchUsersByTeam(ctx context.Context, teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
 	query := s.getQueryBuilder().
 		Select("u.id", "u.username", "u.email", "u.nickname", "u.firstname", "u.lastname", "u.CreateAt as create_at", "u.UpdateAt as update_at",
 			"u.DeleteAt as delete_at", "b.UserId IS NOT NULL AS is_bot, u.roles = 'system_guest' as is_guest").
@@ -424,7 +616,7 @@ func (s *MattermostAuthLayer) SearchUsersByTeam(teamID string, searchQuery strin
 			Join("TeamMembers as tm ON tm.UserID = u.id").
 			Where(sq.Eq{"tm.TeamId": teamID})
 	} else {
-		boards, err := s.GetBoardsForUserAndTeam(asGuestID, teamID, false)
+		boards, err := s.GetBoardsForUserAndTeam(ctx, asGuestID, teamID, false)
 		if err != nil {
 			return nil, err
 		}
@@ -437,7 +629,7 @@ func (s *MattermostAuthLayer) SearchUsersByTeam(teamID string, searchQuery strin
 			Where(sq.Eq{"bm.board_id": boardsIDs})
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -523,7 +715,7 @@ func mmUserToFbUser(mmUser *mmModel.User) model.User {
 	}
 }
 
-func (s *MattermostAuthLayer) GetFileInfo(id string) (*mmModel.FileInfo, error) {
+func (s *MattermostAuthLayer) GetFileInfo(ctx context.Context, id string) (*mmModel.FileInfo, error) {
 	fileInfo, err := s.servicesAPI.GetFileInfo(id)
 	if err != nil {
 		// Not finding fileinfo is fine because we don't have data for
@@ -546,7 +738,7 @@ func (s *MattermostAuthLayer) GetFileInfo(id string) (*mmModel.FileInfo, error)
 	return fileInfo, nil
 }
 
-func (s *MattermostAuthLayer) SaveFileInfo(fileInfo *mmModel.FileInfo) error {
+func (s *MattermostAuthLayer) SaveFileInfo(ctx context.Context, fileInfo *mmModel.FileInfo) error {
 	query := s.getQueryBuilder().
 		Insert("FileInfo").
 		Columns(
@@ -594,7 +786,7 @@ func (s *MattermostAuthLayer) SaveFileInfo(fileInfo *mmModel.FileInfo) error {
 			false,
 		)
 
-	if _, err := query.Exec(); err != nil {
+	if _, err := query.ExecContext(ctx); err != nil {
 		s.logger.Error(
 			"failed to save fileinfo",
 			mlog.String("file_name", fileInfo.Name),
@@ -607,7 +799,7 @@ func (s *MattermostAuthLayer) SaveFileInfo(fileInfo *mmModel.FileInfo) error {
 	return nil
 }
 
-func (s *MattermostAuthLayer) GetLicense() *mmModel.License {
+func (s *MattermostAuthLayer) GetLicense(ctx context.Context) *mmModel.License {
 	return s.servicesAPI.GetLicense()
 }
 
@@ -651,7 +843,7 @@ func boardFields(prefix string) []string {
 // term that are either private and which the user is a member of, or
 // they're open, regardless of the user membership.
 // Search is case-insensitive.
-func (s *MattermostAuthLayer) SearchBoardsForUser(term, userID string, includePublicBoards bool) ([]*model.Board, error) {
+func (s *MattermostAuthLayer) SearchBoardsForUser(ctx context.Context, term, userID string, includePublicBoards bool) ([]*model.Board, error) {
 	query := s.getQueryBuilder().
 		Select(boardFields("b.")...).
 		Distinct().
@@ -660,6 +852,7 @@ func (s *MattermostAuthLayer) SearchBoardsForUser(term, userID string, includePu
 		LeftJoin("TeamMembers as tm on tm.teamid=b.team_id").
 		LeftJoin("ChannelMembers as cm on cm.channelId=b.channel_id").
 		Where(sq.Eq{"b.is_template": false}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0}).
 		Where(sq.Eq{"tm.userID": userID}).
 		Where(sq.Eq{"tm.deleteAt": 0})
 
@@ -676,7 +869,7 @@ func (s *MattermostAuthLayer) SearchBoardsForUser(term, userID string, includePu
 		})
 	}
 
-	user, err := s.GetUserByID(userID)
+	user, err := s.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -684,7 +877,7 @@ func (s *MattermostAuthLayer) SearchBoardsForUser(term, userID string, includePu
 	// but that introduces some tight coupling + fragility
 	if user.IsGuest {
 		var explicitMembers []*model.BoardMember
-		explicitMembers, err = s.Store.GetMembersForUser(userID)
+		explicitMembers, err = s.Store.GetMembersForUser(ctx, userID)
 		if err != nil {
 			s.logger.Error(`getMembersForUser ERROR`, mlog.Err(err))
 			return nil, err
@@ -707,13 +900,13 @@ func (s *MattermostAuthLayer) SearchBoardsForUser(term, userID string, includePu
 		conditions := sq.And{}
 
 		for _, word := range strings.Split(strings.TrimSpace(term), " ") {
-			conditions = append(conditions, sq.Like{"lower(b.title)": "%" + strings.ToLower(word) + "%"})
+			conditions = append(conditions, s.titleSearchCondition("b.title", word))
 		}
 
 		query = query.Where(conditions)
 	}
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		s.logger.Error(`searchBoardsForUser ERROR`, mlog.Err(err))
 		return nil, err
@@ -723,6 +916,21 @@ func (s *MattermostAuthLayer) SearchBoardsForUser(term, userID string, includePu
 	return s.boardsFromRows(rows)
 }
 
+// titleSearchCondition returns a case and accent insensitive match
+// of the word against the column. The unaccent extension can't be
+// assumed in the Mattermost database, so Postgres matches both the
+// word as is and without accents, ignoring case.
+func (s *MattermostAuthLayer) titleSearchCondition(column, word string) sq.Sqlizer {
+	if s.dbType == model.MysqlDBType {
+		return sq.Expr("CONVERT("+column+" USING utf8mb4) COLLATE utf8mb4_general_ci LIKE ?", "%"+word+"%")
+	}
+
+	return sq.Or{
+		sq.ILike{column: "%" + word + "%"},
+		sq.ILike{column: "%" + utils.NormalizeSearchTerm(word) + "%"},
+	}
+}
+
 func (s *MattermostAuthLayer) boardsFromRows(rows *sql.Rows) ([]*model.Board, error) {
 	boards := []*model.Board{}
 
@@ -772,7 +980,7 @@ func (s *MattermostAuthLayer) boardsFromRows(rows *sql.Rows) ([]*model.Board, er
 	return boards, nil
 }
 
-func (s *MattermostAuthLayer) GetCloudLimits() (*mmModel.ProductLimits, error) {
+func (s *MattermostAuthLayer) GetCloudLimits(ctx context.Context) (*mmModel.ProductLimits, error) {
 	return s.servicesAPI.GetCloudLimits()
 }
 
@@ -799,8 +1007,8 @@ func (s *MattermostAuthLayer) implicitBoardMembershipsFromRows(rows *sql.Rows) (
 	return boardMembers, nil
 }
 
-func (s *MattermostAuthLayer) GetMemberForBoard(boardID, userID string) (*model.BoardMember, error) {
-	bm, originalErr := s.Store.GetMemberForBoard(boardID, userID)
+func (s *MattermostAuthLayer) GetMemberForBoard(ctx context.Context, boardID, userID string) (*model.BoardMember, error) {
+	bm, originalErr := s.Store.GetMemberForBoard(ctx, boardID, userID)
 	// Explicit membership not found
 	if model.IsErrNotFound(originalErr) {
 		if userID == model.SystemUserID {
@@ -808,7 +1016,7 @@ func (s *MattermostAuthLayer) GetMemberForBoard(boardID, userID string) (*model.
 		}
 		var user *model.User
 		// No synthetic memberships for guests
-		user, err := s.GetUserByID(userID)
+		user, err := s.GetUserByID(ctx, userID)
 		if err != nil {
 			return nil, err
 		}
@@ -816,7 +1024,7 @@ func (s *MattermostAuthLayer) GetMemberForBoard(boardID, userID string) (*model.
 			return nil, model.NewErrNotFound("user is a guest")
 		}
 
-		b, boardErr := s.Store.GetBoard(boardID)
+		b, boardErr := s.Store.GetBoard(ctx, boardID)
 		if boardErr != nil {
 			return nil, boardErr
 		}
@@ -873,8 +1081,8 @@ func (s *MattermostAuthLayer) GetMemberForBoard(boardID, userID string) (*model.
 	return bm, nil
 }
 
-func (s *MattermostAuthLayer) GetMembersForUser(userID string) ([]*model.BoardMember, error) {
-	explicitMembers, err := s.Store.GetMembersForUser(userID)
+func (s *MattermostAuthLayer) GetMembersForUser(ctx context.Context, userID string) ([]*model.BoardMember, error) {
+	explicitMembers, err := s.Store.GetMembersForUser(ctx, userID)
 	if err != nil {
 		s.logger.Error(`getMembersForUser ERROR`, mlog.Err(err))
 		return nil, err
@@ -886,7 +1094,7 @@ func (s *MattermostAuthLayer) GetMembersForUser(userID string) ([]*model.BoardMe
 		Join("ChannelMembers AS CM ON B.channel_id=CM.channelId").
 		Where(sq.Eq{"CM.userID": userID})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		s.logger.Error(`getMembersForUser ERROR`, mlog.Err(err))
 		return nil, err
@@ -901,7 +1109,7 @@ func (s *MattermostAuthLayer) GetMembersForUser(userID string) ([]*model.BoardMe
 	}
 
 	// No synthetic memberships for guests
-	user, err := s.GetUserByID(userID)
+	user, err := s.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -922,8 +1130,8 @@ func (s *MattermostAuthLayer) GetMembersForUser(userID string) ([]*model.BoardMe
 	return members, nil
 }
 
-func (s *MattermostAuthLayer) GetMembersForBoard(boardID string) ([]*model.BoardMember, error) {
-	explicitMembers, err := s.Store.GetMembersForBoard(boardID)
+func (s *MattermostAuthLayer) GetMembersForBoard(ctx context.Context, boardID string) ([]*model.BoardMember, error) {
+	explicitMembers, err := s.Store.GetMembersForBoard(ctx, boardID)
 	if err != nil {
 		s.logger.Error(`getMembersForBoard ERROR`, mlog.Err(err))
 		return nil, err
@@ -939,7 +1147,7 @@ func (s *MattermostAuthLayer) GetMembersForBoard(boardID string) ([]*model.Board
 		// Filter out guests as they don't have synthetic membership
 		Where(sq.NotEq{"U.roles": "system_guest"})
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		s.logger.Error(`getMembersForBoard ERROR`, mlog.Err(err))
 		return nil, err
@@ -965,29 +1173,41 @@ func (s *MattermostAuthLayer) GetMembersForBoard(boardID string) ([]*model.Board
 	return members, nil
 }
 
-func (s *MattermostAuthLayer) GetBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*model.Board, error) {
-	members, err := s.GetMembersForUser(userID)
+// GetMembersForBoardWithOptions returns a page of the explicit and
+// channel members of a board, sorted by user ID.
+func (s *MattermostAuthLayer) GetMembersForBoardWithOptions(ctx context.Context, boardID string, opts model.QueryBoardMembersOptions) ([]*model.BoardMember, bool, error) {
+	allMembers, err := s.GetMembersForBoard(ctx, boardID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	boardIDs := []string{}
-	for _, m := range members {
-		boardIDs = append(boardIDs, m.BoardID)
-	}
+	sort.Slice(allMembers, func(i, j int) bool {
+		return allMembers[i].UserID < allMembers[j].UserID
+	})
 
-	if includePublicBoards {
-		var boards []*model.Board
-		boards, err = s.SearchBoardsForUserInTeam(teamID, "", userID)
-		if err != nil {
-			return nil, err
-		}
-		for _, b := range boards {
-			boardIDs = append(boardIDs, b.ID)
+	members := []*model.BoardMember{}
+	for _, m := range allMembers {
+		if m.UserID > opts.AfterUserID {
+			members = append(members, m)
 		}
 	}
 
-	boards, err := s.Store.GetBoardsInTeamByIds(boardIDs, teamID)
+	hasMore := false
+	if opts.PerPage > 0 && len(members) > opts.PerPage {
+		members = members[:opts.PerPage]
+		hasMore = true
+	}
+
+	return members, hasMore, nil
+}
+
+func (s *MattermostAuthLayer) GetBoardsForUserAndTeam(ctx context.Context, userID, teamID string, includePublicBoards bool) ([]*model.Board, error) {
+	boardIDs, err := s.boardIDsForUserAndTeam(ctx, userID, teamID, includePublicBoards)
+	if err != nil {
+		return nil, err
+	}
+
+	boards, err := s.Store.GetBoardsInTeamByIds(ctx, boardIDs, teamID)
 	// ToDo: check if the query is being used appropriately from the
 	//       interface, as we're getting ID sets on request that
 	//       return partial results that seem to be valid
@@ -1004,7 +1224,112 @@ func (s *MattermostAuthLayer) GetBoardsForUserAndTeam(userID, teamID string, inc
 	return boards, nil
 }
 
-func (s *MattermostAuthLayer) SearchUserChannels(teamID, userID, query string) ([]*mmModel.Channel, error) {
+func (s *MattermostAuthLayer) GetBoardsForUserAndTeamWithOptions(ctx context.Context, userID, teamID string, opts model.QueryBoardsOptions) ([]*model.Board, error) {
+	if err := opts.IsValid(); err != nil {
+		return nil, err
+	}
+
+	boardIDs, err := s.boardIDsForUserAndTeam(ctx, userID, teamID, opts.IncludePublicBoards)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(boardIDs) == 0 {
+		return []*model.Board{}, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select(boardFields("b.")...).
+		From(s.tablePrefix + "boards as b").
+		Where(sq.Eq{"b.team_id": teamID}).
+		Where(sq.Eq{"b.id": boardIDs}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0})
+
+	if !opts.IncludeTemplates {
+		query = query.Where(sq.Eq{"b.is_template": false})
+	}
+
+	if opts.Type != "" {
+		query = query.Where(sq.Eq{"b.type": opts.Type})
+	}
+
+	query = sqlstore.ApplyBoardsQueryOptions(query, s.tablePrefix, opts)
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		s.logger.Error(`GetBoardsForUserAndTeamWithOptions ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	boards, err := s.boardsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeFavorite {
+		favorites, err := s.Store.GetFavoriteBoards(ctx, userID, teamID)
+		if err != nil {
+			return nil, err
+		}
+
+		favoriteIDs := make(map[string]bool, len(favorites))
+		for _, board := range favorites {
+			favoriteIDs[board.ID] = true
+		}
+		for _, board := range boards {
+			board.Favorite = favoriteIDs[board.ID]
+		}
+	}
+
+	return boards, nil
+}
+
+func (s *MattermostAuthLayer) SearchBlocksForUser(ctx context.Context, teamID, term, userID string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	boards, err := s.GetBoardsForUserAndTeam(ctx, userID, teamID, opts.IncludePublicBoards)
+	if err != nil {
+		return nil, err
+	}
+
+	boardIDs := []string{}
+	for _, b := range boards {
+		if !b.IsTemplate {
+			boardIDs = append(boardIDs, b.ID)
+		}
+	}
+
+	return s.Store.SearchBlocksInBoards(ctx, boardIDs, term, opts)
+}
+
+// boardIDsForUserAndTeam returns the IDs of the boards the user is a
+// member of, explicitly or through a channel, and optionally of the
+// open boards of the team.
+func (s *MattermostAuthLayer) boardIDsForUserAndTeam(ctx context.Context, userID, teamID string, includePublicBoards bool) ([]string, error) {
+	members, err := s.GetMembersForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	boardIDs := []string{}
+	for _, m := range members {
+		boardIDs = append(boardIDs, m.BoardID)
+	}
+
+	if includePublicBoards {
+		var boards []*model.Board
+		boards, err = s.SearchBoardsForUserInTeam(ctx, teamID, "", userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range boards {
+			boardIDs = append(boardIDs, b.ID)
+		}
+	}
+
+	return boardIDs, nil
+}
+
+func (s *MattermostAuthLayer) SearchUserChannels(ctx context.Context, teamID, userID, query string) ([]*mmModel.Channel, error) {
 	channels, err := s.servicesAPI.GetChannelsForTeamForUser(teamID, userID, false)
 	if err != nil {
 		return nil, err
@@ -1027,7 +1352,7 @@ func (s *MattermostAuthLayer) SearchUserChannels(teamID, userID, query string) (
 	return result, nil
 }
 
-func (s *MattermostAuthLayer) GetChannel(teamID, channelID string) (*mmModel.Channel, error) {
+func (s *MattermostAuthLayer) GetChannel(ctx context.Context, teamID, channelID string) (*mmModel.Channel, error) {
 	channel, err := s.servicesAPI.GetChannelByID(channelID)
 	if err != nil {
 		return nil, err
@@ -1047,7 +1372,7 @@ func (s *MattermostAuthLayer) getBoardsBotID() (string, error) {
 	return boardsBotID, nil
 }
 
-func (s *MattermostAuthLayer) SendMessage(message, postType string, receipts []string) error {
+func (s *MattermostAuthLayer) SendMessage(ctx context.Context, message, postType string, receipts []string) error {
 	botID, err := s.getBoardsBotID()
 	if err != nil {
 		return err
@@ -1065,7 +1390,7 @@ func (s *MattermostAuthLayer) SendMessage(message, postType string, receipts []s
 			continue
 		}
 
-		if err := s.PostMessage(message, postType, channel.Id); err != nil {
+		if err := s.PostMessage(ctx, message, postType, channel.Id); err != nil {
 			s.logger.Error(
 				"failed to send message to receipt from SendMessage",
 				mlog.String("receipt", receipt),
@@ -1077,7 +1402,7 @@ func (s *MattermostAuthLayer) SendMessage(message, postType string, receipts []s
 	return nil
 }
 
-func (s *MattermostAuthLayer) PostMessage(message, postType, channelID string) error {
+func (s *MattermostAuthLayer) PostMessage(ctx context.Context, message, postType, channelID string) error {
 	botID, err := s.getBoardsBotID()
 	if err != nil {
 		return err
@@ -1099,7 +1424,7 @@ func (s *MattermostAuthLayer) PostMessage(message, postType, channelID string) e
 	return nil
 }
 
-func (s *MattermostAuthLayer) GetUserTimezone(userID string) (string, error) {
+func (s *MattermostAuthLayer) GetUserTimezone(ctx context.Context, userID string) (string, error) {
 	user, err := s.servicesAPI.GetUserByID(userID)
 	if err != nil {
 		return "", err
@@ -1108,7 +1433,7 @@ func (s *MattermostAuthLayer) GetUserTimezone(userID string) (string, error) {
 	return mmModel.GetPreferredTimezone(timezone), nil
 }
 
-func (s *MattermostAuthLayer) CanSeeUser(seerID string, seenID string) (bool, error) {
+func (s *MattermostAuthLayer) CanSeeUser(ctx context.Context, seerID string, seenID string) (bool, error) {
 	mmuser, appErr := s.servicesAPI.GetUserByID(seerID)
 	if appErr != nil {
 		return false, appErr
@@ -1133,7 +1458,7 @@ func (s *MattermostAuthLayer) CanSeeUser(seerID string, seenID string) (bool, er
 			},
 		}).Limit(1)
 
-	rows, err := query.Query()
+	rows, err := query.QueryContext(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -1159,7 +1484,7 @@ func (s *MattermostAuthLayer) CanSeeUser(seerID string, seenID string) (bool, er
 			},
 		}).Limit(1)
 
-	rows, err = query.Query()
+	rows, err = query.QueryContext(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func (s *MemStore) insertAuditRecord(record *model.AuditRecord) error {
+	if record.ID == "" {
+		record.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if record.CreateAt == 0 {
+		record.CreateAt = utils.GetMillis()
+	}
+	if len(record.Summary) > model.AuditSummaryMaxLength {
+		record.Summary = record.Summary[:model.AuditSummaryMaxLength]
+	}
+
+	stored := *record
+	s.data.auditRecords = append(s.data.auditRecords, &stored)
+	return nil
+}
+
+func (s *MemStore) auditChange(boardID, actorID string, action model.AuditAction, resourceID, summary string) error {
+	return s.insertAuditRecord(&model.AuditRecord{
+		BoardID:    boardID,
+		ActorID:    actorID,
+		Action:     action,
+		ResourceID: resourceID,
+		Summary:    summary,
+	})
+}
+
+func (s *MemStore) getAuditRecords(boardID string, opts model.QueryAuditOptions) ([]*model.AuditRecord, error) {
+	records := []*model.AuditRecord{}
+	for _, record := range s.data.auditRecords {
+		if boardID != "" && record.BoardID != boardID {
+			continue
+		}
+		if opts.ActorID != "" && record.ActorID != opts.ActorID {
+			continue
+		}
+		if opts.Action != "" && record.Action != opts.Action {
+			continue
+		}
+		if opts.Since != 0 && record.CreateAt < opts.Since {
+			continue
+		}
+		if opts.Until != 0 && record.CreateAt >= opts.Until {
+			continue
+		}
+		copied := *record
+		records = append(records, &copied)
+	}
+	// records created on the same millisecond keep their insertion order,
+	// which the random IDs the SQL store sorts them by can't provide
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreateAt < records[j].CreateAt
+	})
+
+	start, end := page(len(records), opts.Page*opts.PerPage, opts.PerPage)
+	return records[start:end], nil
+}
@@ -0,0 +1,1475 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const maxSearchDepth = 50
+
+type BoardIDNilError struct{}
+
+func (re BoardIDNilError) Error() string {
+	return "boardID is nil"
+}
+
+// blockFromRow returns a copy of the block of a row, as the SQL store
+// reads it back.
+func blockFromRow(row *blockRow) *model.Block {
+	block := row.Block
+	block.Fields = copyJSONMap(row.Fields)
+	if block.BoardID == "" {
+		block.BoardID = "0"
+	}
+	return &block
+}
+
+func blocksFromRows(rows []*blockRow) []*model.Block {
+	blocks := make([]*model.Block, 0, len(rows))
+	for _, row := range rows {
+		blocks = append(blocks, blockFromRow(row))
+	}
+	return blocks
+}
+
+// newBlockRow returns a new row for a block, with its fields normalized
+// the way they would be stored as JSON.
+func (s *MemStore) newBlockRow(block model.Block) (*blockRow, error) {
+	row := &blockRow{Block: block, seq: s.data.nextSeq(), insertAt: time.Now()}
+	row.Fields = nil
+	if err := normalizeJSON(block.Fields, &row.Fields); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// blockRows returns the live blocks that match the filter, in insertion
+// order.
+func (s *MemStore) blockRows(filter func(*blockRow) bool) []*blockRow {
+	rows := []*blockRow{}
+	for _, row := range s.data.blocks {
+		if filter == nil || filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+	return rows
+}
+
+// blockHistoryRows returns the block history entries that match the
+// filter, in insertion order.
+func (s *MemStore) blockHistoryRows(filter func(*blockRow) bool) []*blockRow {
+	rows := []*blockRow{}
+	for _, row := range s.data.blocksHistory {
+		if filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// updateBlockRow stores a copy of the row of a block, changed by fn.
+func (s *MemStore) updateBlockRow(row *blockRow, fn func(*blockRow)) {
+	updated := *row
+	fn(&updated)
+	s.data.blocks[row.ID] = &updated
+}
+
+func blocksFilter(opts model.QueryBlocksOptions) func(*blockRow) bool {
+	return func(row *blockRow) bool {
+		if opts.BoardID != "" && row.BoardID != opts.BoardID {
+			return false
+		}
+		if opts.ParentID != "" && row.ParentID != opts.ParentID {
+			return false
+		}
+		if opts.BlockType != "" && opts.BlockType != model.TypeUnknown && row.Type != opts.BlockType {
+			return false
+		}
+		if len(opts.BlockTypes) > 0 && !hasBlockType(opts.BlockTypes, row.Type) {
+			return false
+		}
+		if !opts.IncludeArchived && row.ArchivedAt != 0 {
+			return false
+		}
+		return true
+	}
+}
+
+func hasBlockType(types []model.BlockType, blockType model.BlockType) bool {
+	for _, t := range types {
+		if t == blockType {
+			return true
+		}
+	}
+	return false
+}
+
+func sortBlockRowsBySortOrder(rows []*blockRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].SortOrder != rows[j].SortOrder {
+			return rows[i].SortOrder < rows[j].SortOrder
+		}
+		if rows[i].CreateAt != rows[j].CreateAt {
+			return rows[i].CreateAt < rows[j].CreateAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+}
+
+func (s *MemStore) getBlocks(opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	rows := s.blockRows(blocksFilter(opts))
+	if opts.OrderBySortOrder {
+		sortBlockRowsBySortOrder(rows)
+	}
+
+	offset := 0
+	if opts.Page != 0 {
+		offset = opts.Page * opts.PerPage
+	}
+	start, end := page(len(rows), offset, opts.PerPage)
+
+	return blocksFromRows(rows[start:end]), nil
+}
+
+func (s *MemStore) getBlocksForBoards(boardIDs []string, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	blocks := []*model.Block{}
+	if len(boardIDs) == 0 {
+		return blocks, nil
+	}
+
+	ids := stringSet(boardIDs)
+	filter := blocksFilter(opts)
+	rows := s.blockRows(func(row *blockRow) bool {
+		return ids[row.BoardID] && filter(row)
+	})
+	if opts.OrderBySortOrder {
+		sortBlockRowsBySortOrder(rows)
+	}
+
+	return append(blocks, blocksFromRows(rows)...), nil
+}
+
+func (s *MemStore) getBlocksWithParentAndType(boardID, parentID string, blockType string) ([]*model.Block, error) {
+	opts := model.QueryBlocksOptions{
+		BoardID:   boardID,
+		ParentID:  parentID,
+		BlockType: model.BlockType(blockType),
+	}
+	return s.getBlocks(opts)
+}
+
+func (s *MemStore) getBlocksWithParentAndTypes(boardID, parentID string, blockTypes []string) ([]*model.Block, error) {
+	types := make([]model.BlockType, 0, len(blockTypes))
+	for _, blockType := range blockTypes {
+		types = append(types, model.BlockType(blockType))
+	}
+
+	opts := model.QueryBlocksOptions{
+		BoardID:    boardID,
+		ParentID:   parentID,
+		BlockTypes: types,
+	}
+	return s.getBlocks(opts)
+}
+
+func (s *MemStore) getBlocksWithParent(boardID, parentID string) ([]*model.Block, error) {
+	opts := model.QueryBlocksOptions{
+		BoardID:  boardID,
+		ParentID: parentID,
+	}
+	return s.getBlocks(opts)
+}
+
+func (s *MemStore) getBlocksByIDs(ids []string) ([]*model.Block, error) {
+	set := stringSet(ids)
+	blocks := blocksFromRows(s.blockRows(func(row *blockRow) bool {
+		return set[row.ID]
+	}))
+
+	if len(blocks) != len(ids) {
+		return blocks, model.NewErrNotAllFound("block", ids)
+	}
+
+	return blocks, nil
+}
+
+func (s *MemStore) getBlocksMap(boardID string, ids []string) (map[string]*model.Block, error) {
+	blocksMap := make(map[string]*model.Block, len(ids))
+	for _, id := range ids {
+		if row, ok := s.data.blocks[id]; ok && row.BoardID == boardID {
+			blocksMap[id] = blockFromRow(row)
+		}
+	}
+	return blocksMap, nil
+}
+
+func (s *MemStore) getBlocksWithType(boardID, blockType string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	opts.BoardID = boardID
+	opts.BlockType = model.BlockType(blockType)
+	return s.getBlocksPage(opts)
+}
+
+func (s *MemStore) getSubTree2(boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	opts.MaxDepth = 2
+	return s.getSubTree(boardID, blockID, opts)
+}
+
+func (s *MemStore) getSubTree(boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	tree := []*model.Block{}
+	if root, ok := s.data.blocks[blockID]; ok && root.BoardID == boardID {
+		if root.DeleteAt != 0 && !opts.IncludeDeleted {
+			return tree, nil
+		}
+		tree = append(tree, blockFromRow(root))
+	}
+
+	visited := map[string]bool{blockID: true}
+	parentIDs := []string{blockID}
+	for depth := 1; len(parentIDs) > 0 && (opts.MaxDepth == 0 || depth < opts.MaxDepth); depth++ {
+		parents := stringSet(parentIDs)
+		children := s.blockRows(func(row *blockRow) bool {
+			return row.BoardID == boardID && parents[row.ParentID]
+		})
+
+		parentIDs = nil
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+
+			if child.DeleteAt != 0 && !opts.IncludeDeleted {
+				continue
+			}
+			tree = append(tree, blockFromRow(child))
+			parentIDs = append(parentIDs, child.ID)
+		}
+	}
+
+	result := make([]*model.Block, 0, len(tree))
+	for _, block := range tree {
+		if opts.BeforeUpdateAt != 0 && block.UpdateAt > opts.BeforeUpdateAt {
+			continue
+		}
+		if opts.AfterUpdateAt != 0 && block.UpdateAt < opts.AfterUpdateAt {
+			continue
+		}
+		if opts.Limit != 0 && uint64(len(result)) >= opts.Limit {
+			break
+		}
+		result = append(result, block)
+	}
+
+	return result, nil
+}
+
+func (s *MemStore) getBlocksForBoard(boardID string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	opts.BoardID = boardID
+	return s.getBlocksPage(opts)
+}
+
+func (s *MemStore) getBlocksForBoardStream(boardID string, fn func(model.Block) error) error {
+	rows := s.blockRows(func(row *blockRow) bool { return row.BoardID == boardID })
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+
+	for _, row := range rows {
+		if err := fn(*blockFromRow(row)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getBlocksPage returns a page of the blocks of a board, sorted by ID or
+// by update time and ID, and whether there are more blocks after it.
+func (s *MemStore) getBlocksPage(opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	if opts.PerPage <= 0 && opts.AfterID == "" {
+		blocks, err := s.getBlocks(opts)
+		return blocks, false, err
+	}
+
+	filter := blocksFilter(model.QueryBlocksOptions{
+		BoardID:         opts.BoardID,
+		ParentID:        opts.ParentID,
+		BlockType:       opts.BlockType,
+		BlockTypes:      opts.BlockTypes,
+		IncludeArchived: opts.IncludeArchived,
+	})
+	rows := s.blockRows(func(row *blockRow) bool {
+		if row.BoardID != opts.BoardID || !filter(row) {
+			return false
+		}
+		if opts.AfterID == "" {
+			return true
+		}
+		if opts.OrderByUpdateAt {
+			return row.UpdateAt > opts.AfterUpdateAt ||
+				(row.UpdateAt == opts.AfterUpdateAt && row.ID > opts.AfterID)
+		}
+		return row.ID > opts.AfterID
+	})
+
+	sort.Slice(rows, func(i, j int) bool {
+		if opts.OrderByUpdateAt && rows[i].UpdateAt != rows[j].UpdateAt {
+			return rows[i].UpdateAt < rows[j].UpdateAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	hasMore := false
+	if opts.PerPage > 0 && len(rows) > opts.PerPage {
+		rows = rows[:opts.PerPage]
+		hasMore = true
+	}
+
+	return blocksFromRows(rows), hasMore, nil
+}
+
+func (s *MemStore) getLastModifiedBlockForBoard(boardID string) (*model.Block, error) {
+	var last *blockRow
+	for _, row := range s.blockRows(func(row *blockRow) bool {
+		return row.BoardID == boardID && row.DeleteAt == 0
+	}) {
+		if last == nil || row.UpdateAt > last.UpdateAt || (row.UpdateAt == last.UpdateAt && row.ID < last.ID) {
+			last = row
+		}
+	}
+
+	if last == nil {
+		return nil, model.NewErrNotFound("blocks for board ID=" + boardID)
+	}
+
+	return blockFromRow(last), nil
+}
+
+func (s *MemStore) insertBlock(block *model.Block, userID string) error {
+	if block.BoardID == "" {
+		return BoardIDNilError{}
+	}
+
+	if err := s.validateBlockReferences([]*model.Block{block}); err != nil {
+		return err
+	}
+
+	return s.withBoardBlockLimit([]*model.Block{block}, func() error {
+		return s.upsertBlock(block, userID)
+	})
+}
+
+func (s *MemStore) upsertBlock(block *model.Block, userID string) error {
+	existing := s.data.blocks[block.ID]
+
+	now := utils.GetMillis()
+	block.UpdateAt = now
+	block.ModifiedBy = userID
+
+	if existing != nil {
+		block.ArchivedAt = existing.ArchivedAt
+	}
+
+	if block.SortOrder == 0 {
+		if existing != nil {
+			block.SortOrder = existing.SortOrder
+		} else {
+			block.SortOrder = block.UpdateAt
+		}
+	}
+
+	if existing != nil {
+		// the block is only updated if it belongs to the same board
+		if existing.BoardID == block.BoardID {
+			var fields map[string]interface{}
+			if err := normalizeJSON(block.Fields, &fields); err != nil {
+				return err
+			}
+			s.updateBlockRow(existing, func(row *blockRow) {
+				row.ParentID = block.ParentID
+				row.ModifiedBy = block.ModifiedBy
+				row.Schema = block.Schema
+				row.Type = block.Type
+				row.Title = block.Title
+				row.Fields = fields
+				row.UpdateAt = block.UpdateAt
+				row.DeleteAt = block.DeleteAt
+				row.SortOrder = block.SortOrder
+			})
+		}
+	} else {
+		block.CreatedBy = userID
+		inserted := *block
+		inserted.CreateAt = now
+		row, err := s.newBlockRow(inserted)
+		if err != nil {
+			return err
+		}
+		s.data.blocks[block.ID] = row
+	}
+
+	if err := s.insertBlockHistory(block, userID); err != nil {
+		return err
+	}
+
+	return s.auditChange(block.BoardID, userID, model.AuditActionInsertBlock, block.ID, "type: "+string(block.Type))
+}
+
+// insertBlockHistory adds an entry for the block to its history.
+func (s *MemStore) insertBlockHistory(block *model.Block, userID string) error {
+	entry := *block
+	entry.CreatedBy = userID
+	entry.CreateAt = utils.GetMillis()
+
+	row, err := s.newBlockRow(entry)
+	if err != nil {
+		return err
+	}
+	s.data.blocksHistory = append(s.data.blocksHistory, row)
+	return nil
+}
+
+// reinsertBlockHistory adds an entry for the block to its history, keeping
+// the creation fields of the block.
+func (s *MemStore) reinsertBlockHistory(block model.Block) error {
+	row, err := s.newBlockRow(block)
+	if err != nil {
+		return err
+	}
+	s.data.blocksHistory = append(s.data.blocksHistory, row)
+	return nil
+}
+
+func (s *MemStore) patchBlock(blockID string, blockPatch *model.BlockPatch, userID string) (int64, error) {
+	existingBlock, err := s.getBlock(blockID)
+	if err != nil {
+		return 0, err
+	}
+
+	if blockPatch.ExpectedUpdateAt != 0 && existingBlock.UpdateAt != blockPatch.ExpectedUpdateAt {
+		return 0, model.NewErrConflict("block ID="+blockID, blockPatch.ExpectedUpdateAt, existingBlock.UpdateAt)
+	}
+
+	boardID := existingBlock.BoardID
+	block := blockPatch.Patch(existingBlock)
+
+	var fields map[string]interface{}
+	if err := normalizeJSON(block.Fields, &fields); err != nil {
+		return 0, err
+	}
+
+	if row, ok := s.data.blocks[blockID]; ok {
+		s.updateBlockRow(row, func(row *blockRow) {
+			row.ModifiedBy = userID
+			row.UpdateAt = utils.GetMillis()
+			if blockPatch.ParentID != nil {
+				row.ParentID = *blockPatch.ParentID
+			}
+			if blockPatch.Schema != nil {
+				row.Schema = *blockPatch.Schema
+			}
+			if blockPatch.Type != nil {
+				row.Type = *blockPatch.Type
+			}
+			if blockPatch.Title != nil {
+				row.Title = *blockPatch.Title
+			}
+			if blockPatch.SortOrder != nil {
+				row.SortOrder = *blockPatch.SortOrder
+			}
+			row.Fields = fields
+		})
+	}
+
+	block, err = s.getBlock(blockID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.insertBlockHistory(block, userID); err != nil {
+		return 0, err
+	}
+	if err := s.patchContentSortOrder(block, blockPatch); err != nil {
+		return 0, err
+	}
+
+	if err := s.auditChange(boardID, userID, model.AuditActionPatchBlock, blockID, model.AuditChangedSummary(blockPatch.ChangedFields())); err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+// patchContentSortOrder updates the sort order of the content blocks of
+// a card if the patch changed its content order.
+func (s *MemStore) patchContentSortOrder(block *model.Block, blockPatch *model.BlockPatch) error {
+	if block.Type != model.TypeCard {
+		return nil
+	}
+	if _, ok := blockPatch.UpdatedFields["contentOrder"]; !ok {
+		return nil
+	}
+	return s.updateContentSortOrder(block.BoardID, block.ID, contentOrderFromFields(block.Fields))
+}
+
+func (s *MemStore) patchBlocks(blockPatches *model.BlockPatchBatch, userID string) error {
+	for i, blockID := range blockPatches.BlockIDs {
+		_, err := s.patchBlock(blockID, &blockPatches.BlockPatches[i], userID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) insertBlocks(blocks []*model.Block, userID string) error {
+	for _, block := range blocks {
+		if block.BoardID == "" {
+			return BoardIDNilError{}
+		}
+	}
+
+	if err := s.validateBlockReferences(blocks); err != nil {
+		return err
+	}
+
+	return s.withBoardBlockLimit(blocks, func() error {
+		for i := range blocks {
+			if err := s.upsertBlock(blocks[i], userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// validateBlockReferences checks that the boards of the blocks exist, and
+// that their parents, if any, belong to the same board.
+func (s *MemStore) validateBlockReferences(blocks []*model.Block) error {
+	inBatch := make(map[string]string, len(blocks))
+	for _, block := range blocks {
+		inBatch[block.ID] = block.BoardID
+	}
+
+	for _, block := range blocks {
+		if _, ok := s.data.boards[block.BoardID]; !ok {
+			return model.NewErrNotFound(fmt.Sprintf("board ID=%s of block ID=%s", block.BoardID, block.ID))
+		}
+	}
+
+	for _, block := range blocks {
+		if block.ParentID == "" || block.ParentID == block.BoardID {
+			continue
+		}
+		parentBoardID, ok := inBatch[block.ParentID]
+		if !ok {
+			var parent *blockRow
+			if parent, ok = s.data.blocks[block.ParentID]; ok {
+				parentBoardID = parent.BoardID
+			}
+		}
+		if !ok || parentBoardID != block.BoardID {
+			return model.NewErrNotFound(fmt.Sprintf("parent block ID=%s of block ID=%s", block.ParentID, block.ID))
+		}
+	}
+
+	return nil
+}
+
+// withBoardBlockLimit runs insert if it doesn't take any of the boards
+// of the blocks over the block limit.
+func (s *MemStore) withBoardBlockLimit(blocks []*model.Block, insert func() error) error {
+	limit := int(atomic.LoadInt64(&s.boardBlockLimit))
+	if limit <= 0 {
+		return insert()
+	}
+
+	blockIDsByBoard := map[string][]string{}
+	for _, block := range blocks {
+		blockIDsByBoard[block.BoardID] = append(blockIDsByBoard[block.BoardID], block.ID)
+	}
+	boardIDs := make([]string, 0, len(blockIDsByBoard))
+	for boardID := range blockIDsByBoard {
+		boardIDs = append(boardIDs, boardID)
+	}
+	sort.Strings(boardIDs)
+
+	for _, boardID := range boardIDs {
+		count := 0
+		for _, row := range s.data.blocks {
+			if row.BoardID == boardID {
+				count++
+			}
+		}
+
+		newIDs := stringSet(blockIDsByBoard[boardID])
+		for id := range newIDs {
+			if row, ok := s.data.blocks[id]; ok && row.BoardID == boardID {
+				delete(newIDs, id)
+			}
+		}
+
+		if count+len(newIDs) > limit {
+			return model.NewErrBlockLimitExceeded(boardID, count, limit)
+		}
+	}
+
+	return insert()
+}
+
+func (s *MemStore) deleteBlock(blockID string, modifiedBy string) (int64, error) {
+	row, ok := s.data.blocks[blockID]
+	if !ok {
+		return 0, nil // deleting non-exiting block is not considered an error (for now)
+	}
+	block := blockFromRow(row)
+
+	now := utils.GetMillis()
+	entry := *block
+	entry.BoardID = row.BoardID
+	entry.ModifiedBy = modifiedBy
+	entry.UpdateAt = now
+	entry.DeleteAt = now
+	if err := s.reinsertBlockHistory(entry); err != nil {
+		return 0, err
+	}
+
+	delete(s.data.blocks, blockID)
+
+	if err := s.deleteSubscriptionsForBlock(blockID); err != nil {
+		return 0, err
+	}
+
+	s.deleteNotificationHintsForBlock(blockID)
+
+	s.setFileInfosDeleteAt(fileInfoIDsFromBlocks([]*model.Block{block}), now)
+
+	if err := s.auditChange(block.BoardID, modifiedBy, model.AuditActionDeleteBlock, blockID, "type: "+string(block.Type)); err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+func (s *MemStore) undeleteBlock(blockID string, modifiedBy string) error {
+	blocks, err := s.getBlockHistory(blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+	if err != nil {
+		return err
+	}
+
+	if len(blocks) == 0 {
+		return nil // undeleting non-exiting block is not considered an error (for now)
+	}
+	block := blocks[0]
+
+	if block.DeleteAt == 0 {
+		return nil // undeleting not deleted block is not considered an error (for now)
+	}
+
+	if err := s.reinsertBlock(block, modifiedBy, utils.GetMillis()); err != nil {
+		return err
+	}
+
+	return s.auditChange(block.BoardID, modifiedBy, model.AuditActionUndeleteBlock, blockID, "type: "+string(block.Type))
+}
+
+// reinsertBlock stores a block from its history again, in both the
+// blocks and their history.
+func (s *MemStore) reinsertBlock(block *model.Block, modifiedBy string, now int64) error {
+	restored := *block
+	restored.ModifiedBy = modifiedBy
+	restored.UpdateAt = now
+	restored.DeleteAt = 0
+
+	if err := s.reinsertBlockHistory(restored); err != nil {
+		return err
+	}
+
+	row, err := s.newBlockRow(restored)
+	if err != nil {
+		return err
+	}
+	s.data.blocks[block.ID] = row
+
+	s.setFileInfosDeleteAt(fileInfoIDsFromBlocks([]*model.Block{block}), 0)
+	return nil
+}
+
+func (s *MemStore) restoreBlock(blockID, userID string) (*model.Block, error) {
+	if row, ok := s.data.blocks[blockID]; ok {
+		return blockFromRow(row), nil
+	}
+
+	rows := s.blockHistoryRows(func(row *blockRow) bool {
+		return row.ID == blockID && row.DeleteAt == 0
+	})
+	if len(rows) == 0 {
+		return nil, model.NewErrNotFound("restorable history for block ID=" + blockID)
+	}
+
+	block := blockFromRow(rows[len(rows)-1])
+	now := utils.GetMillis()
+	if err := s.reinsertBlock(block, userID, now); err != nil {
+		return nil, err
+	}
+
+	block.ModifiedBy = userID
+	block.UpdateAt = now
+	block.DeleteAt = 0
+
+	if err := s.auditChange(block.BoardID, userID, model.AuditActionRestoreBlock, blockID, "type: "+string(block.Type)); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// deletedBlockRows returns the last history entries of the blocks that
+// are deleted and haven't been restored since.
+func (s *MemStore) deletedBlockRows(filter func(*blockRow) bool) []*blockRow {
+	last := map[string]*blockRow{}
+	for _, row := range s.data.blocksHistory {
+		last[row.ID] = row
+	}
+
+	rows := []*blockRow{}
+	for id, row := range last {
+		if _, ok := s.data.blocks[id]; ok || row.DeleteAt == 0 || !filter(row) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (s *MemStore) getDeletedBlocksForBoard(boardID string) ([]*model.Block, error) {
+	rows := s.deletedBlockRows(func(row *blockRow) bool { return row.BoardID == boardID })
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].DeleteAt != rows[j].DeleteAt {
+			return rows[i].DeleteAt > rows[j].DeleteAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	return blocksFromRows(rows), nil
+}
+
+func (s *MemStore) purgeDeletedBlocks(olderThan time.Time) (int, error) {
+	rows := s.deletedBlockRows(func(row *blockRow) bool {
+		return row.DeleteAt < olderThan.UnixMilli()
+	})
+
+	purged := map[string]bool{}
+	for _, row := range rows {
+		purged[row.ID] = true
+	}
+
+	history := make([]*blockRow, 0, len(s.data.blocksHistory))
+	for _, row := range s.data.blocksHistory {
+		if !purged[row.ID] {
+			history = append(history, row)
+		}
+	}
+	s.data.blocksHistory = history
+
+	return len(rows), nil
+}
+
+func (s *MemStore) moveBlocks(blockIDs []string, targetBoardID, userID string) error {
+	if _, err := s.getBoard(targetBoardID); err != nil {
+		return err
+	}
+
+	blocks, err := s.getBlocksWithDescendants(blockIDs)
+	if err != nil {
+		return err
+	}
+
+	moved := make(map[string]*model.Block, len(blocks))
+	for _, block := range blocks {
+		moved[block.ID] = block
+	}
+
+	for _, block := range blocks {
+		if block.ParentID == "" || block.ParentID == block.BoardID {
+			continue
+		}
+		if _, ok := moved[block.ParentID]; !ok {
+			return model.NewErrBadRequest(fmt.Sprintf("block %s can't be moved without its parent %s", block.ID, block.ParentID))
+		}
+	}
+
+	sourceBoards := make(map[string]string, len(blockIDs))
+	for _, id := range blockIDs {
+		if block, ok := moved[id]; ok {
+			sourceBoards[id] = block.BoardID
+		}
+	}
+
+	now := utils.GetMillis()
+	for _, block := range blocks {
+		if block.ParentID == block.BoardID {
+			block.ParentID = targetBoardID
+		}
+		block.BoardID = targetBoardID
+		block.ModifiedBy = userID
+		block.UpdateAt = now
+
+		if row, ok := s.data.blocks[block.ID]; ok {
+			s.updateBlockRow(row, func(row *blockRow) {
+				row.BoardID = block.BoardID
+				row.ParentID = block.ParentID
+				row.ModifiedBy = block.ModifiedBy
+				row.UpdateAt = block.UpdateAt
+			})
+		}
+
+		if err := s.insertBlockHistory(block, userID); err != nil {
+			return err
+		}
+	}
+
+	for id, sourceBoardID := range sourceBoards {
+		if err := s.auditChange(targetBoardID, userID, model.AuditActionMoveBlock, id, "from board: "+sourceBoardID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *MemStore) copyBlocks(blockIDs []string, targetBoardID, userID string) (map[string]string, error) {
+	if _, err := s.getBoard(targetBoardID); err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.getBlocksWithDescendants(blockIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	newIDs := make(map[string]string, len(blocks))
+	for _, block := range blocks {
+		newIDs[block.ID] = utils.NewID(model.BlockType2IDType(block.Type))
+	}
+
+	for _, block := range blocks {
+		if block.ParentID == "" || block.ParentID == block.BoardID {
+			continue
+		}
+		if _, ok := newIDs[block.ParentID]; !ok {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("block %s can't be copied without its parent %s", block.ID, block.ParentID))
+		}
+	}
+
+	for _, block := range blocks {
+		if newParentID, ok := newIDs[block.ParentID]; ok {
+			block.ParentID = newParentID
+		} else {
+			block.ParentID = targetBoardID
+		}
+		block.ID = newIDs[block.ID]
+		block.BoardID = targetBoardID
+		remapBlockReferences(block, newIDs)
+	}
+
+	if err := s.insertBlocks(blocks, userID); err != nil {
+		return nil, err
+	}
+
+	copied := make(map[string]bool, len(blockIDs))
+	for _, id := range blockIDs {
+		if copied[id] {
+			continue
+		}
+		copied[id] = true
+		if err := s.auditChange(targetBoardID, userID, model.AuditActionCopyBlock, newIDs[id], "from: "+id); err != nil {
+			return nil, err
+		}
+	}
+
+	return newIDs, nil
+}
+
+// remapBlockReferences replaces the IDs of copied blocks referenced by a
+// block with the IDs of the copies, dropping the references to blocks
+// that were not copied.
+func remapBlockReferences(block *model.Block, newIDs map[string]string) {
+	remapIDs := func(ids []interface{}) []interface{} {
+		remapped := make([]interface{}, 0, len(ids))
+		for _, value := range ids {
+			switch v := value.(type) {
+			case string:
+				if newID, ok := newIDs[v]; ok {
+					remapped = append(remapped, newID)
+				}
+			case []interface{}:
+				column := make([]interface{}, 0, len(v))
+				for _, id := range v {
+					if id, ok := id.(string); ok && newIDs[id] != "" {
+						column = append(column, newIDs[id])
+					}
+				}
+				if len(column) != 0 {
+					remapped = append(remapped, column)
+				}
+			}
+		}
+		return remapped
+	}
+
+	for _, fieldName := range []string{"contentOrder", "cardOrder"} {
+		if ids, ok := block.Fields[fieldName].([]interface{}); ok {
+			block.Fields[fieldName] = remapIDs(ids)
+		}
+	}
+
+	if templateID, ok := block.Fields["defaultTemplateId"].(string); ok && templateID != "" {
+		if newID, ok := newIDs[templateID]; ok {
+			block.Fields["defaultTemplateId"] = newID
+		} else {
+			delete(block.Fields, "defaultTemplateId")
+		}
+	}
+}
+
+// getBlocksWithDescendants returns the blocks with the given IDs followed
+// by all of their descendants.
+func (s *MemStore) getBlocksWithDescendants(blockIDs []string) ([]*model.Block, error) {
+	ids := uniqueStrings(blockIDs)
+	seen := stringSet(ids)
+
+	blocks, err := s.getBlocksByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for pending := blocks; len(pending) > 0; {
+		parentIDs := make(map[string]bool, len(pending))
+		for _, block := range pending {
+			parentIDs[block.ID] = true
+		}
+
+		children := s.blockRows(func(row *blockRow) bool { return parentIDs[row.ParentID] })
+
+		pending = nil
+		for _, child := range children {
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				block := blockFromRow(child)
+				blocks = append(blocks, block)
+				pending = append(pending, block)
+			}
+		}
+	}
+
+	return blocks, nil
+}
+
+func (s *MemStore) archiveCard(cardID, userID string) error {
+	return s.setCardArchivedAt(cardID, userID, utils.GetMillis())
+}
+
+func (s *MemStore) unarchiveCard(cardID, userID string) error {
+	return s.setCardArchivedAt(cardID, userID, 0)
+}
+
+func (s *MemStore) setCardArchivedAt(cardID, userID string, archivedAt int64) error {
+	card, err := s.getBlock(cardID)
+	if err != nil {
+		return err
+	}
+
+	if card.Type != model.TypeCard {
+		return model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", cardID))
+	}
+
+	if (card.ArchivedAt != 0) == (archivedAt != 0) {
+		return nil
+	}
+
+	now := utils.GetMillis()
+	row := s.data.blocks[cardID]
+	s.updateBlockRow(row, func(row *blockRow) {
+		row.ArchivedAt = archivedAt
+		row.ModifiedBy = userID
+		row.UpdateAt = now
+	})
+
+	entry := *card
+	entry.BoardID = row.BoardID
+	entry.ModifiedBy = userID
+	entry.UpdateAt = now
+	entry.ArchivedAt = archivedAt
+	if err := s.reinsertBlockHistory(entry); err != nil {
+		return err
+	}
+
+	action := model.AuditActionArchiveCard
+	if archivedAt == 0 {
+		action = model.AuditActionUnarchiveCard
+	}
+	return s.auditChange(row.BoardID, userID, action, cardID, "")
+}
+
+func (s *MemStore) getRecentComments(boardID string, limit int) ([]*model.CommentWithAuthor, error) {
+	rows := s.blockRows(func(row *blockRow) bool {
+		if row.BoardID != boardID || row.Type != model.TypeComment || row.DeleteAt != 0 {
+			return false
+		}
+		parent, ok := s.data.blocks[row.ParentID]
+		return ok && parent.DeleteAt == 0
+	})
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].CreateAt != rows[j].CreateAt {
+			return rows[i].CreateAt > rows[j].CreateAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	_, end := page(len(rows), 0, limit)
+	comments := make([]*model.CommentWithAuthor, 0, end)
+	for _, block := range blocksFromRows(rows[:end]) {
+		comment := &model.CommentWithAuthor{Block: *block}
+		if author, ok := s.data.users[block.CreatedBy]; ok {
+			comment.Author = &model.User{
+				ID:        author.ID,
+				Username:  author.Username,
+				Email:     author.Email,
+				Nickname:  author.Nickname,
+				FirstName: author.FirstName,
+				LastName:  author.LastName,
+				CreateAt:  author.CreateAt,
+				UpdateAt:  author.UpdateAt,
+				DeleteAt:  author.DeleteAt,
+			}
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+func (s *MemStore) getArchivedCards(boardID string) ([]*model.Block, error) {
+	rows := s.blockRows(func(row *blockRow) bool {
+		return row.BoardID == boardID && row.Type == model.TypeCard && row.ArchivedAt > 0
+	})
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].ArchivedAt != rows[j].ArchivedAt {
+			return rows[i].ArchivedAt > rows[j].ArchivedAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	return blocksFromRows(rows), nil
+}
+
+// activeCardRows returns the cards of a board that are neither deleted
+// nor archived.
+func (s *MemStore) activeCardRows(boardID string) []*blockRow {
+	return s.blockRows(func(row *blockRow) bool {
+		return row.BoardID == boardID && row.Type == model.TypeCard && row.DeleteAt == 0 && row.ArchivedAt == 0
+	})
+}
+
+func cardProperty(row *blockRow, propertyID string) (interface{}, bool) {
+	return jsonPath(row.Fields, []string{"properties", propertyID})
+}
+
+func (s *MemStore) countCardsByPropertyGrouped(boardID, propertyID string) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	for _, row := range s.activeCardRows(boardID) {
+		value, _ := cardProperty(row, propertyID)
+
+		values, ok := value.([]interface{})
+		if !ok || len(values) == 0 {
+			values = []interface{}{value}
+			if ok {
+				values = []interface{}{nil}
+			}
+		}
+
+		for _, v := range values {
+			text, _ := jsonText(v)
+			counts[text]++
+		}
+	}
+	return counts, nil
+}
+
+func (s *MemStore) getCardsMissingProperty(boardID, propertyID string) ([]*model.Block, error) {
+	rows := []*blockRow{}
+	for _, row := range s.activeCardRows(boardID) {
+		value, _ := cardProperty(row, propertyID)
+		if values, ok := value.([]interface{}); ok {
+			if len(values) == 0 {
+				rows = append(rows, row)
+			}
+			continue
+		}
+		if text, _ := jsonText(value); text == "" {
+			rows = append(rows, row)
+		}
+	}
+	return blocksFromRows(rows), nil
+}
+
+var blockFieldPathRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+func (s *MemStore) searchBlocksForBoard(boardID, term string, fields []string) ([]*model.Block, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return []*model.Block{}, nil
+	}
+
+	paths := make([][]string, 0, len(fields))
+	for _, field := range fields {
+		if !blockFieldPathRegexp.MatchString(field) {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("invalid field path %q", field))
+		}
+		paths = append(paths, strings.Split(field, "."))
+	}
+
+	rows := s.blockRows(func(row *blockRow) bool {
+		if row.BoardID != boardID || row.DeleteAt != 0 {
+			return false
+		}
+		if matchesSearchWord(row.Title, term) {
+			return true
+		}
+		for _, path := range paths {
+			value, _ := jsonPath(row.Fields, path)
+			if text, ok := jsonText(value); ok && matchesSearchWord(text, term) {
+				return true
+			}
+		}
+		return false
+	})
+	sortBlockRowsByUpdateAtDesc(rows)
+
+	return blocksFromRows(rows), nil
+}
+
+func sortBlockRowsByUpdateAtDesc(rows []*blockRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].UpdateAt != rows[j].UpdateAt {
+			return rows[i].UpdateAt > rows[j].UpdateAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+}
+
+func (s *MemStore) searchBlocksForUser(teamID, term, userID string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	boardIDs := map[string]bool{}
+	for _, board := range s.data.boards {
+		if board.TeamID != teamID || board.IsTemplate || board.DeleteAt != 0 {
+			continue
+		}
+		_, isMember := s.data.members[memberKey{boardID: board.ID, userID: userID}]
+		if isMember || (opts.IncludePublicBoards && board.Type == model.BoardTypeOpen) {
+			boardIDs[board.ID] = true
+		}
+	}
+
+	return s.searchBlocks(boardIDs, term, opts), nil
+}
+
+func (s *MemStore) searchBlocksInBoards(boardIDs []string, term string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	if len(boardIDs) == 0 {
+		return []*model.Block{}, nil
+	}
+	return s.searchBlocks(stringSet(boardIDs), term, opts), nil
+}
+
+func (s *MemStore) searchBlocks(boardIDs map[string]bool, term string, opts model.SearchBlocksOptions) []*model.Block {
+	words := strings.Fields(term)
+	if len(words) == 0 {
+		return []*model.Block{}
+	}
+
+	rows := s.blockRows(func(row *blockRow) bool {
+		if !boardIDs[row.BoardID] || row.DeleteAt != 0 {
+			return false
+		}
+		if len(opts.BlockTypes) > 0 && !hasBlockType(opts.BlockTypes, row.Type) {
+			return false
+		}
+		for _, word := range words {
+			if !matchesSearchWord(row.Title, word) {
+				return false
+			}
+		}
+		return true
+	})
+	sortBlockRowsByUpdateAtDesc(rows)
+
+	_, end := page(len(rows), 0, int(opts.Limit))
+	return blocksFromRows(rows[:end])
+}
+
+func (s *MemStore) getBlockCountsByType() (map[string]int64, error) {
+	m := make(map[string]int64)
+	for _, row := range s.data.blocks {
+		m[string(row.Type)]++
+	}
+	return m, nil
+}
+
+func (s *MemStore) getBlockCountsByTypeForBoard(boardID string) (map[string]int64, error) {
+	m := make(map[string]int64)
+	for _, row := range s.data.blocks {
+		if row.BoardID == boardID && row.DeleteAt == 0 {
+			m[string(row.Type)]++
+		}
+	}
+	return m, nil
+}
+
+func (s *MemStore) getBoardStats(boardID string) (*model.BoardStats, error) {
+	if _, ok := s.data.boards[boardID]; !ok {
+		return nil, model.NewErrNotFound("board ID=" + boardID)
+	}
+
+	stats := &model.BoardStats{BoardID: boardID}
+	for _, row := range s.data.blocks {
+		if row.BoardID != boardID || row.DeleteAt != 0 {
+			continue
+		}
+		if row.Type == model.TypeCard {
+			stats.CardCount++
+		}
+		stats.BlockCount++
+		if row.UpdateAt > stats.LastModified {
+			stats.LastModified = row.UpdateAt
+		}
+	}
+
+	contributors := map[string]bool{}
+	for _, row := range s.data.blocksHistory {
+		if row.BoardID == boardID {
+			contributors[row.ModifiedBy] = true
+		}
+	}
+	stats.ContributorCount = int64(len(contributors))
+
+	return stats, nil
+}
+
+func (s *MemStore) getBlockCountForTeam(teamID string) (int64, error) {
+	counts, err := s.getBlockCountsForTeams([]string{teamID})
+	if err != nil {
+		return 0, err
+	}
+	return counts[teamID], nil
+}
+
+func (s *MemStore) getBlockCountsForTeams(teamIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(teamIDs))
+	for _, teamID := range teamIDs {
+		counts[teamID] = 0
+	}
+
+	for _, row := range s.data.blocks {
+		if row.DeleteAt != 0 {
+			continue
+		}
+		board, ok := s.data.boards[row.BoardID]
+		if !ok || board.DeleteAt != 0 {
+			continue
+		}
+		if _, ok := counts[board.TeamID]; ok {
+			counts[board.TeamID]++
+		}
+	}
+
+	return counts, nil
+}
+
+func (s *MemStore) getBoardCount() (int64, error) {
+	var count int64
+	for _, board := range s.data.boards {
+		if board.DeleteAt == 0 && !board.IsTemplate {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *MemStore) countBoardsCreatedBetween(teamID string, start, end int64) (int64, error) {
+	return s.countBoardsCreatedBetweenForTeam(teamID, start, end)
+}
+
+func (s *MemStore) countBoardsCreatedBetweenAllTeams(start, end int64) (int64, error) {
+	return s.countBoardsCreatedBetweenForTeam("", start, end)
+}
+
+func (s *MemStore) countBoardsCreatedBetweenForTeam(teamID string, start, end int64) (int64, error) {
+	var count int64
+	for _, board := range s.data.boards {
+		if board.DeleteAt != 0 || board.IsTemplate || board.CreateAt < start || board.CreateAt >= end {
+			continue
+		}
+		if teamID == "" || board.TeamID == teamID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *MemStore) getBlock(blockID string) (*model.Block, error) {
+	row, ok := s.data.blocks[blockID]
+	if !ok {
+		return nil, model.NewErrNotFound("block ID=" + blockID)
+	}
+	return blockFromRow(row), nil
+}
+
+func (s *MemStore) getBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	rows := s.blockHistoryRows(func(row *blockRow) bool { return row.ID == blockID })
+	return blocksFromRows(applyBlockHistoryOptions(rows, opts)), nil
+}
+
+func (s *MemStore) getBlockHistoryEntry(blockID string, version int64) (*model.Block, error) {
+	rows := s.blockHistoryRows(func(row *blockRow) bool {
+		return row.ID == blockID && row.UpdateAt == version
+	})
+	if len(rows) == 0 {
+		return nil, model.NewErrNotFound(fmt.Sprintf("block history entry ID=%s version=%d", blockID, version))
+	}
+
+	return blockFromRow(rows[len(rows)-1]), nil
+}
+
+func (s *MemStore) getBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	rows := s.blockHistoryRows(func(row *blockRow) bool { return row.BoardID == boardID })
+	return blocksFromRows(applyBlockHistoryOptions(rows, opts)), nil
+}
+
+// applyBlockHistoryOptions filters, sorts and pages history entries that
+// are in insertion order.
+func applyBlockHistoryOptions(rows []*blockRow, opts model.QueryBlockHistoryOptions) []*blockRow {
+	filtered := make([]*blockRow, 0, len(rows))
+	for _, row := range rows {
+		if opts.BeforeUpdateAt != 0 && row.UpdateAt >= opts.BeforeUpdateAt {
+			continue
+		}
+		if opts.AfterUpdateAt != 0 && row.UpdateAt <= opts.AfterUpdateAt {
+			continue
+		}
+		if opts.Since != 0 && row.UpdateAt < opts.Since {
+			continue
+		}
+		if opts.Before != 0 && row.UpdateAt >= opts.Before {
+			continue
+		}
+		filtered = append(filtered, row)
+	}
+
+	if opts.Descending {
+		for i, j := 0, len(filtered)-1; i < j; i, j = i+1, j-1 {
+			filtered[i], filtered[j] = filtered[j], filtered[i]
+		}
+	}
+
+	var start, end int
+	if opts.PerPage > 0 {
+		start, end = page(len(filtered), opts.Page*opts.PerPage, opts.PerPage)
+	} else {
+		start, end = page(len(filtered), 0, int(opts.Limit))
+	}
+	return filtered[start:end]
+}
+
+func (s *MemStore) getBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error) {
+	opts := model.QueryBlockHistoryOptions{
+		Limit:      1,
+		Descending: true,
+	}
+
+	blocks, err := s.getBlockHistory(blockID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(blocks) == 0 {
+		return nil, nil, model.NewErrNotFound("block history BlockID=" + blockID)
+	}
+
+	return s.getBoardAndCard(blocks[0])
+}
+
+func (s *MemStore) getBoardAndCard(block *model.Block) (board *model.Board, card *model.Block, err error) {
+	var count int // don't let invalid blocks hierarchy cause infinite loop.
+	iter := block
+
+	opts := model.QueryBlockHistoryOptions{
+		Limit:      1,
+		Descending: true,
+	}
+
+	for {
+		count++
+		if card == nil && iter.Type == model.TypeCard {
+			card = iter
+		}
+
+		if iter.ParentID == "" || card != nil || count > maxSearchDepth {
+			break
+		}
+
+		blocks, err2 := s.getBlockHistory(iter.ParentID, opts)
+		if err2 != nil {
+			return nil, nil, err2
+		}
+		if len(blocks) == 0 {
+			return board, card, nil
+		}
+		iter = blocks[0]
+	}
+	board, err = s.getBoard(block.BoardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return board, card, nil
+}
+
+func (s *MemStore) duplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
+	blocks, err := s.getSubTree2(boardID, blockID, model.QuerySubtreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		message := fmt.Sprintf("block subtree BoardID=%s BlockID=%s", boardID, blockID)
+		return nil, model.NewErrNotFound(message)
+	}
+
+	var rootBlock *model.Block
+	allBlocks := []*model.Block{}
+	for _, block := range blocks {
+		if block.Type == model.TypeComment {
+			continue
+		}
+		if block.ID == blockID {
+			if block.Fields == nil {
+				block.Fields = make(map[string]interface{})
+			}
+			block.Fields["isTemplate"] = asTemplate
+			rootBlock = block
+		} else {
+			allBlocks = append(allBlocks, block)
+		}
+	}
+	allBlocks = append([]*model.Block{rootBlock}, allBlocks...)
+
+	allBlocks = model.GenerateBlockIDs(allBlocks, nil)
+	if err := s.insertBlocks(allBlocks, userID); err != nil {
+		return nil, err
+	}
+	return allBlocks, nil
+}
@@ -0,0 +1,1054 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	//nolint:gosec
+	"crypto/md5"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// boardFromRow returns a copy of the board of a row.
+func boardFromRow(row *boardRow) *model.Board {
+	board := row.Board
+	board.Properties = copyJSONMap(row.Properties)
+	board.CardProperties = copyJSONMaps(row.CardProperties)
+	board.Favorite = false
+	return &board
+}
+
+func boardsFromRows(rows []*boardRow) []*model.Board {
+	boards := make([]*model.Board, 0, len(rows))
+	for _, row := range rows {
+		boards = append(boards, boardFromRow(row))
+	}
+	return boards
+}
+
+// newBoardRow returns a new row for a board, with its properties
+// normalized the way they would be stored as JSON.
+func (s *MemStore) newBoardRow(board model.Board) (*boardRow, error) {
+	row := &boardRow{Board: board, seq: s.data.nextSeq(), insertAt: time.Now()}
+	row.Properties = nil
+	row.CardProperties = nil
+	row.Favorite = false
+	if err := normalizeJSON(board.Properties, &row.Properties); err != nil {
+		return nil, err
+	}
+	if err := normalizeJSON(board.CardProperties, &row.CardProperties); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// boardRows returns the boards that match the filter, archived ones
+// included, in insertion order.
+func (s *MemStore) boardRows(filter func(*boardRow) bool) []*boardRow {
+	rows := []*boardRow{}
+	for _, row := range s.data.boards {
+		if filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+	return rows
+}
+
+// insertBoardHistory adds an entry for the board to its history.
+func (s *MemStore) insertBoardHistory(board model.Board) error {
+	row, err := s.newBoardRow(board)
+	if err != nil {
+		return err
+	}
+	s.data.boardsHistory = append(s.data.boardsHistory, row)
+	return nil
+}
+
+func (s *MemStore) isMember(boardID, userID string) bool {
+	_, ok := s.data.members[memberKey{boardID: boardID, userID: userID}]
+	return ok
+}
+
+func (s *MemStore) getBoard(boardID string) (*model.Board, error) {
+	row, ok := s.data.boards[boardID]
+	if !ok || row.DeleteAt != 0 {
+		return nil, model.NewErrNotFound("boards")
+	}
+	return boardFromRow(row), nil
+}
+
+// getBoards returns the boards with the given IDs in the same order as
+// the IDs. IDs that are not found are skipped.
+func (s *MemStore) getBoards(boardIDs []string) ([]*model.Board, error) {
+	boards := make([]*model.Board, 0, len(boardIDs))
+	for _, id := range uniqueStrings(boardIDs) {
+		if row, ok := s.data.boards[id]; ok && row.DeleteAt == 0 {
+			boards = append(boards, boardFromRow(row))
+		}
+	}
+	return boards, nil
+}
+
+func (s *MemStore) getBoardCardProperties(boardID string) ([]model.CardProperty, error) {
+	board, err := s.getBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ParseCardProperties(board)
+}
+
+func (s *MemStore) setBoardPropertyOrder(boardID string, propertyIDs []string, userID string) error {
+	board, err := s.getBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	if err := board.SetCardPropertyOrder(propertyIDs); err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+
+	if _, _, err := s.upsertBoard(board, userID); err != nil {
+		return err
+	}
+
+	return s.auditChange(boardID, userID, model.AuditActionPatchBoard, boardID, model.AuditChangedSummary([]string{"cardProperties"}))
+}
+
+func (s *MemStore) getBoardWithStats(boardID, userID string) (*model.BoardWithStats, error) {
+	row, ok := s.data.boards[boardID]
+	memberRow, isMember := s.data.members[memberKey{boardID: boardID, userID: userID}]
+	if !ok || row.DeleteAt != 0 || !isMember {
+		return nil, model.NewErrNotFound("board ID=" + boardID + " for user ID=" + userID)
+	}
+
+	boardWithStats := model.BoardWithStats{Board: *boardFromRow(row)}
+	for key := range s.data.members {
+		if key.boardID == boardID {
+			boardWithStats.MemberCount++
+		}
+	}
+	boardWithStats.CardCount = int64(len(s.activeCardRows(boardID)))
+
+	member := memberRow.BoardMember
+	member.MinimumRole = string(row.MinimumRole)
+	boardWithStats.Role = member.EffectiveRole()
+
+	return &boardWithStats, nil
+}
+
+func (s *MemStore) validateBoardSchema(boardID string) (*model.SchemaReport, error) {
+	board, err := s.getBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := model.QueryBlocksOptions{
+		BoardID:         boardID,
+		BlockType:       model.TypeCard,
+		IncludeArchived: true,
+	}
+	cards, err := s.getBlocks(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.CheckCardsAgainstSchema(boardID, schema, cards), nil
+}
+
+func (s *MemStore) getBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*model.Board, error) {
+	opts := model.QueryBoardsOptions{
+		IncludePublicBoards: includePublicBoards,
+	}
+	return s.getBoardsForUserAndTeamWithOptions(userID, teamID, opts)
+}
+
+func (s *MemStore) getBoardsForUserAndTeamWithOptions(userID, teamID string, opts model.QueryBoardsOptions) ([]*model.Board, error) {
+	if err := opts.IsValid(); err != nil {
+		return nil, err
+	}
+
+	rows := s.boardRows(func(row *boardRow) bool {
+		if row.TeamID != teamID || row.DeleteAt != 0 {
+			return false
+		}
+		if !opts.IncludeTemplates && row.IsTemplate {
+			return false
+		}
+		if opts.Type != "" && row.Type != opts.Type {
+			return false
+		}
+		if opts.IncludePublicBoards && row.Type == model.BoardTypeOpen {
+			return true
+		}
+		return s.isMember(row.ID, userID)
+	})
+
+	boards := boardsFromRows(s.applyBoardsQueryOptions(rows, opts))
+
+	if opts.IncludeFavorite {
+		if err := s.markFavoriteBoards(userID, teamID, boards); err != nil {
+			return nil, err
+		}
+	}
+
+	return boards, nil
+}
+
+func (s *MemStore) getBoardsAdministeredByUser(userID, teamID string) ([]*model.Board, error) {
+	rows := s.boardRows(func(row *boardRow) bool {
+		if row.TeamID != teamID || row.IsTemplate || row.DeleteAt != 0 {
+			return false
+		}
+		member, ok := s.data.members[memberKey{boardID: row.ID, userID: userID}]
+		return ok && member.SchemeAdmin
+	})
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].CreateAt != rows[j].CreateAt {
+			return rows[i].CreateAt < rows[j].CreateAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	return boardsFromRows(rows), nil
+}
+
+// applyBoardsQueryOptions sorts and pages boards as per the options.
+// Boards are sorted by ID when the options don't set a sort, so the pages
+// are stable across calls, and AfterID then selects the page following
+// that board.
+func (s *MemStore) applyBoardsQueryOptions(rows []*boardRow, opts model.QueryBoardsOptions) []*boardRow {
+	var key func(*boardRow) interface{}
+	switch opts.SortBy {
+	case model.BoardSortByTitle:
+		key = func(row *boardRow) interface{} { return strings.ToLower(row.Title) }
+	case model.BoardSortByCreated:
+		key = func(row *boardRow) interface{} { return row.CreateAt }
+	case model.BoardSortByLastActivity:
+		lastActivity := map[string]int64{}
+		for _, block := range s.data.blocks {
+			if block.UpdateAt > lastActivity[block.BoardID] {
+				lastActivity[block.BoardID] = block.UpdateAt
+			}
+		}
+		key = func(row *boardRow) interface{} {
+			if activity, ok := lastActivity[row.ID]; ok {
+				return activity
+			}
+			return row.UpdateAt
+		}
+	default:
+		if opts.AfterID != "" {
+			filtered := make([]*boardRow, 0, len(rows))
+			for _, row := range rows {
+				if (opts.SortDescending && row.ID < opts.AfterID) || (!opts.SortDescending && row.ID > opts.AfterID) {
+					filtered = append(filtered, row)
+				}
+			}
+			rows = filtered
+		}
+	}
+
+	less := func(a, b interface{}) bool {
+		switch a := a.(type) {
+		case string:
+			return a < b.(string)
+		case int64:
+			return a < b.(int64)
+		}
+		return false
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if key != nil {
+			a, b := key(rows[i]), key(rows[j])
+			if a != b {
+				if opts.SortDescending {
+					return less(b, a)
+				}
+				return less(a, b)
+			}
+			return rows[i].ID < rows[j].ID
+		}
+		if opts.SortDescending {
+			return rows[i].ID > rows[j].ID
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	offset := 0
+	if opts.Page != 0 {
+		offset = opts.Page * opts.PerPage
+	}
+	start, end := page(len(rows), offset, opts.PerPage)
+	return rows[start:end]
+}
+
+func (s *MemStore) getBoardsModifiedSince(teamID, userID string, since int64) ([]*model.Board, error) {
+	canAccess := func(board model.Board) bool {
+		return board.Type == model.BoardTypeOpen || s.isMember(board.ID, userID)
+	}
+
+	boards := boardsFromRows(s.boardRows(func(row *boardRow) bool {
+		return row.TeamID == teamID && !row.IsTemplate && row.UpdateAt > since && canAccess(row.Board)
+	}))
+
+	// board members are kept after the board is deleted, so they
+	// can be used to check the access to the tombstones as well.
+	// A board can be deleted more than once if it was restored in
+	// between, so only its latest tombstone is kept
+	latestTombstones := map[string]*boardRow{}
+	for _, row := range s.data.boardsHistory {
+		if row.TeamID != teamID || row.IsTemplate || row.DeleteAt == 0 || row.UpdateAt <= since {
+			continue
+		}
+		if _, ok := s.data.boards[row.ID]; ok || !canAccess(row.Board) {
+			continue
+		}
+		if latest, ok := latestTombstones[row.ID]; !ok || row.UpdateAt > latest.UpdateAt {
+			latestTombstones[row.ID] = row
+		}
+	}
+	for _, tombstone := range latestTombstones {
+		boards = append(boards, boardFromRow(tombstone))
+	}
+
+	sort.Slice(boards, func(i, j int) bool {
+		if boards[i].UpdateAt == boards[j].UpdateAt {
+			return boards[i].ID < boards[j].ID
+		}
+		return boards[i].UpdateAt < boards[j].UpdateAt
+	})
+
+	return boards, nil
+}
+
+func (s *MemStore) getBoardsInTeamByIds(boardIDs []string, teamID string) ([]*model.Board, error) {
+	ids := stringSet(boardIDs)
+	boards := boardsFromRows(s.boardRows(func(row *boardRow) bool {
+		return ids[row.ID] && row.TeamID == teamID && row.DeleteAt == 0
+	}))
+
+	if len(boards) != len(boardIDs) {
+		return boards, model.NewErrNotAllFound("board", boardIDs)
+	}
+
+	return boards, nil
+}
+
+func (s *MemStore) insertBoard(board *model.Board, userID string) (*model.Board, error) {
+	board, created, err := s.upsertBoard(board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := model.AuditActionPatchBoard
+	if created {
+		action = model.AuditActionCreateBoard
+	}
+	if err := s.auditChange(board.ID, userID, action, board.ID, ""); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+func (s *MemStore) upsertBoard(board *model.Board, userID string) (*model.Board, bool, error) {
+	// Generate tracking IDs for in-built templates
+	if board.IsTemplate && board.TeamID == model.GlobalTeamID {
+		//nolint:gosec
+		// we don't need cryptographically secure hash, so MD5 is fine
+		board.Properties["trackingTemplateId"] = fmt.Sprintf("%x", md5.Sum([]byte(board.Title)))
+	}
+
+	existing, ok := s.data.boards[board.ID]
+	if ok && existing.DeleteAt != 0 {
+		return nil, false, fmt.Errorf("insertBoard error occurred while inserting board %s: %w", board.ID, model.NewErrDuplicate("board"))
+	}
+
+	now := utils.GetMillis()
+	board.ModifiedBy = userID
+	board.UpdateAt = now
+
+	if ok {
+		// the creation source is set only when the board is created
+		board.CreationSource = existing.CreationSource
+		board.SourceID = existing.SourceID
+
+		updated := *board
+		updated.CreatedBy = existing.CreatedBy
+		updated.CreateAt = existing.CreateAt
+		updated.TeamID = existing.TeamID
+		row, err := s.newBoardRow(updated)
+		if err != nil {
+			return nil, false, err
+		}
+		row.seq = existing.seq
+		row.insertAt = existing.insertAt
+		s.data.boards[board.ID] = row
+	} else {
+		board.CreatedBy = userID
+		board.CreateAt = now
+
+		if board.CreationSource == "" {
+			board.CreationSource = model.BoardCreationSourceBlank
+		}
+
+		row, err := s.newBoardRow(*board)
+		if err != nil {
+			return nil, false, err
+		}
+		s.data.boards[board.ID] = row
+	}
+
+	// writing board history
+	if err := s.insertBoardHistory(*board); err != nil {
+		return nil, false, fmt.Errorf("failed to insert board %s history: %w", board.ID, err)
+	}
+
+	return board, !ok, nil
+}
+
+func (s *MemStore) patchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+	existingBoard, err := s.getBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if boardPatch.ExpectedUpdateAt != 0 && existingBoard.UpdateAt != boardPatch.ExpectedUpdateAt {
+		return nil, model.NewErrConflict("board ID="+boardID, boardPatch.ExpectedUpdateAt, existingBoard.UpdateAt)
+	}
+
+	board := boardPatch.Patch(existingBoard)
+	board, _, err = s.upsertBoard(board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditChange(boardID, userID, model.AuditActionPatchBoard, boardID, model.AuditChangedSummary(boardPatch.ChangedFields())); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+func (s *MemStore) deleteBoard(boardID, userID string) error {
+	now := utils.GetMillis()
+
+	board, err := s.getBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	// writing board history
+	entry := *board
+	entry.ModifiedBy = userID
+	entry.UpdateAt = now
+	entry.DeleteAt = now
+	if err := s.insertBoardHistory(entry); err != nil {
+		return err
+	}
+
+	delete(s.data.boards, boardID)
+
+	if err := s.deleteSubscriptionsForBlock(boardID); err != nil {
+		return err
+	}
+
+	s.deleteNotificationHintsForBlock(boardID)
+	s.deleteWebhooksForBoard(boardID)
+	s.deleteBoardCustomRolesForBoard(boardID)
+	s.deleteShareTokensForBoard(boardID)
+
+	if err := s.deleteBoardSnapshot(boardID); err != nil {
+		return err
+	}
+
+	blocks, err := s.getBlocks(model.QueryBlocksOptions{BoardID: boardID, IncludeArchived: true})
+	if err != nil {
+		return err
+	}
+
+	s.setFileInfosDeleteAt(fileInfoIDsFromBlocks(blocks), now)
+
+	return s.auditChange(boardID, userID, model.AuditActionDeleteBoard, boardID, "")
+}
+
+func (s *MemStore) archiveBoard(boardID, userID string) error {
+	board, err := s.getBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.setBoardDeleteAt(board, userID, utils.GetMillis()); err != nil {
+		return err
+	}
+
+	return s.auditChange(boardID, userID, model.AuditActionArchiveBoard, boardID, "")
+}
+
+func (s *MemStore) getArchivedBoards(teamID string) ([]*model.Board, error) {
+	rows := s.boardRows(func(row *boardRow) bool {
+		return row.TeamID == teamID && row.DeleteAt > 0
+	})
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].DeleteAt != rows[j].DeleteAt {
+			return rows[i].DeleteAt > rows[j].DeleteAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	return boardsFromRows(rows), nil
+}
+
+func (s *MemStore) restoreBoard(boardID, userID string) error {
+	row, ok := s.data.boards[boardID]
+	if !ok || row.DeleteAt == 0 {
+		return model.NewErrNotFound("archived board ID=" + boardID)
+	}
+
+	if err := s.setBoardDeleteAt(boardFromRow(row), userID, 0); err != nil {
+		return err
+	}
+
+	return s.auditChange(boardID, userID, model.AuditActionRestoreBoard, boardID, "")
+}
+
+// setBoardDeleteAt updates the delete_at of a board and records the
+// change in the board history.
+func (s *MemStore) setBoardDeleteAt(board *model.Board, userID string, deleteAt int64) error {
+	now := utils.GetMillis()
+
+	if row, ok := s.data.boards[board.ID]; ok {
+		updated := *row
+		updated.ModifiedBy = userID
+		updated.UpdateAt = now
+		updated.DeleteAt = deleteAt
+		s.data.boards[board.ID] = &updated
+	}
+
+	entry := *board
+	entry.ModifiedBy = userID
+	entry.UpdateAt = now
+	entry.DeleteAt = deleteAt
+	return s.insertBoardHistory(entry)
+}
+
+func (s *MemStore) purgeArchivedBoards(olderThan time.Time) (int, error) {
+	boardIDs := []string{}
+	for _, row := range s.boardRows(func(row *boardRow) bool {
+		return row.DeleteAt > 0 && row.DeleteAt < olderThan.UnixMilli()
+	}) {
+		boardIDs = append(boardIDs, row.ID)
+	}
+
+	boards := stringSet(boardIDs)
+	s.deleteBoardSubscriptionsAndHints(boards)
+	s.deleteBoardsData(boards)
+
+	return len(boardIDs), nil
+}
+
+func (s *MemStore) insertBoardWithAdmin(board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
+	newBoard, err := s.insertBoard(board, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bm := &model.BoardMember{
+		BoardID:      newBoard.ID,
+		UserID:       newBoard.CreatedBy,
+		SchemeAdmin:  true,
+		SchemeEditor: true,
+	}
+
+	nbm, err := s.saveMember(bm, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot save member %s while inserting board %s: %w", bm.UserID, bm.BoardID, err)
+	}
+
+	return newBoard, nbm, nil
+}
+
+func (s *MemStore) createBoardComplete(board *model.Board, creatorID string, extraMembers []*model.BoardMember, categoryID string) (*model.Board, []*model.BoardMember, error) {
+	if categoryID != "" {
+		category, err := s.getCategory(categoryID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if category.UserID != creatorID {
+			return nil, nil, model.ErrCategoryPermissionDenied
+		}
+
+		if category.TeamID != board.TeamID {
+			return nil, nil, model.NewErrBadRequest("category and board must belong to the same team")
+		}
+	}
+
+	newBoard, adminMember, err := s.insertBoardWithAdmin(board, creatorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := []*model.BoardMember{adminMember}
+	for _, member := range extraMembers {
+		if member.UserID == creatorID {
+			// the creator is already an admin of the board
+			continue
+		}
+
+		member.BoardID = newBoard.ID
+		newMember, err := s.saveMember(member, creatorID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot save member %s while creating board %s: %w", member.UserID, newBoard.ID, err)
+		}
+		members = append(members, newMember)
+	}
+
+	if categoryID != "" {
+		if err := s.addUpdateCategoryBoard(creatorID, categoryID, newBoard.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return newBoard, members, nil
+}
+
+// memberFromRow returns a copy of the member of a row, with the minimum
+// role of its board.
+func (s *MemStore) memberFromRow(row *memberRow) *model.BoardMember {
+	member := row.BoardMember
+	member.MinimumRole = ""
+	if board, ok := s.data.boards[row.BoardID]; ok {
+		member.MinimumRole = string(board.MinimumRole)
+	}
+	if member.Roles == "" {
+		member.Roles = string(model.BoardRoleEditor)
+	}
+	return &member
+}
+
+// memberRows returns the members that match the filter, in insertion
+// order.
+func (s *MemStore) memberRows(filter func(*memberRow) bool) []*memberRow {
+	rows := []*memberRow{}
+	for _, row := range s.data.members {
+		if filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+	return rows
+}
+
+func (s *MemStore) membersFromRows(rows []*memberRow) []*model.BoardMember {
+	members := make([]*model.BoardMember, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, s.memberFromRow(row))
+	}
+	return members
+}
+
+func (s *MemStore) addMemberHistory(boardID, userID, action string) {
+	s.data.membersHistory = append(s.data.membersHistory, &model.BoardMemberHistoryEntry{
+		BoardID:  boardID,
+		UserID:   userID,
+		Action:   action,
+		InsertAt: time.Now().UTC(),
+	})
+}
+
+// upsertMember stores the role and scheme flags of a member, keeping the
+// rest of the fields of an existing one.
+func (s *MemStore) upsertMember(bm *model.BoardMember) {
+	key := memberKey{boardID: bm.BoardID, userID: bm.UserID}
+	row := &memberRow{}
+	if existing, ok := s.data.members[key]; ok {
+		*row = *existing
+	} else {
+		row.BoardID = bm.BoardID
+		row.UserID = bm.UserID
+		row.seq = s.data.nextSeq()
+		s.addMemberHistory(bm.BoardID, bm.UserID, "created")
+	}
+
+	row.Roles = string(bm.SchemeRole())
+	row.SchemeAdmin = bm.SchemeAdmin
+	row.SchemeEditor = bm.SchemeEditor
+	row.SchemeCommenter = bm.SchemeCommenter
+	row.SchemeViewer = bm.SchemeViewer
+	s.data.members[key] = row
+}
+
+func (s *MemStore) saveMember(bm *model.BoardMember, modifiedBy string) (*model.BoardMember, error) {
+	s.upsertMember(bm)
+
+	if err := s.auditChange(bm.BoardID, modifiedBy, model.AuditActionSaveMember, bm.UserID, model.AuditMemberSummary(bm)); err != nil {
+		return nil, err
+	}
+
+	return bm, nil
+}
+
+func (s *MemStore) saveMembers(members []*model.BoardMember, modifiedBy string) ([]*model.BoardMember, error) {
+	if len(members) == 0 {
+		return []*model.BoardMember{}, nil
+	}
+
+	// de-duplicate the batch, keeping the position of the first
+	// occurrence and the values of the last one
+	index := map[memberKey]int{}
+	batch := []*model.BoardMember{}
+	for _, bm := range members {
+		key := memberKey{boardID: bm.BoardID, userID: bm.UserID}
+		if i, ok := index[key]; ok {
+			batch[i] = bm
+			continue
+		}
+		index[key] = len(batch)
+		batch = append(batch, bm)
+	}
+
+	for _, bm := range batch {
+		if _, ok := s.data.boards[bm.BoardID]; !ok {
+			return nil, model.NewErrMemberBoardNotFound(bm.BoardID)
+		}
+	}
+
+	result := make([]*model.BoardMember, 0, len(batch))
+	for _, bm := range batch {
+		s.upsertMember(bm)
+		member := s.memberFromRow(s.data.members[memberKey{boardID: bm.BoardID, userID: bm.UserID}])
+		if err := s.auditChange(member.BoardID, modifiedBy, model.AuditActionSaveMember, member.UserID, model.AuditMemberSummary(member)); err != nil {
+			return nil, err
+		}
+		result = append(result, member)
+	}
+	return result, nil
+}
+
+func (s *MemStore) saveMemberWithLimit(bm *model.BoardMember, maxMembers int, modifiedBy string) (*model.BoardMember, error) {
+	if maxMembers <= 0 {
+		return s.saveMember(bm, modifiedBy)
+	}
+
+	if _, ok := s.data.boards[bm.BoardID]; !ok {
+		return nil, model.NewErrNotFound("board ID=" + bm.BoardID)
+	}
+
+	// existing members can always be updated
+	if !s.isMember(bm.BoardID, bm.UserID) {
+		count, err := s.getBoardMemberCount(bm.BoardID)
+		if err != nil {
+			return nil, err
+		}
+
+		if count >= maxMembers {
+			return nil, model.ErrBoardMemberLimit
+		}
+	}
+
+	return s.saveMember(bm, modifiedBy)
+}
+
+func (s *MemStore) getBoardMemberCount(boardID string) (int, error) {
+	count := 0
+	for key := range s.data.members {
+		if key.boardID == boardID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *MemStore) deleteMember(boardID, userID, modifiedBy string) (int64, error) {
+	key := memberKey{boardID: boardID, userID: userID}
+	if _, ok := s.data.members[key]; !ok {
+		return 0, nil
+	}
+
+	delete(s.data.members, key)
+	s.addMemberHistory(boardID, userID, "deleted")
+
+	if err := s.auditChange(boardID, modifiedBy, model.AuditActionDeleteMember, userID, ""); err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+func (s *MemStore) deleteMembers(boardID string, userIDs []string, modifiedBy string) (int, error) {
+	toRemove := stringSet(userIDs)
+	if len(toRemove) == 0 {
+		return 0, nil
+	}
+
+	found := 0
+	removesAdmin := false
+	remainingAdmin := false
+	for key, member := range s.data.members {
+		if key.boardID != boardID {
+			continue
+		}
+		if !toRemove[member.UserID] {
+			remainingAdmin = remainingAdmin || member.SchemeAdmin
+			continue
+		}
+		found++
+		removesAdmin = removesAdmin || member.SchemeAdmin
+	}
+
+	if found != len(toRemove) {
+		return 0, model.NewErrNotFound(fmt.Sprintf("board members BoardID=%s", boardID))
+	}
+	if removesAdmin && !remainingAdmin {
+		return 0, model.ErrBoardMemberIsLastAdmin
+	}
+
+	for _, userID := range uniqueStrings(userIDs) {
+		if _, err := s.deleteMember(boardID, userID, modifiedBy); err != nil {
+			return 0, err
+		}
+	}
+
+	now := utils.GetMillis()
+	for key, sub := range s.data.subscriptions {
+		if !toRemove[sub.SubscriberID] || sub.DeleteAt != 0 {
+			continue
+		}
+		if block, ok := s.data.blocks[sub.BlockID]; sub.BlockID != boardID && (!ok || block.BoardID != boardID) {
+			continue
+		}
+		deleted := *sub
+		deleted.DeleteAt = now
+		s.data.subscriptions[key] = &deleted
+	}
+
+	return len(toRemove), nil
+}
+
+func (s *MemStore) updateMemberRole(boardID, userID, role, modifiedBy string) error {
+	if !model.IsBoardMemberRoleValid(model.BoardRole(role)) {
+		return model.NewErrBadRequest(fmt.Sprintf("invalid board member role %q", role))
+	}
+
+	key := memberKey{boardID: boardID, userID: userID}
+	existing, ok := s.data.members[key]
+	if !ok {
+		return model.NewErrNotFound(fmt.Sprintf("board member BoardID=%s UserID=%s", boardID, userID))
+	}
+
+	adminCount := 0
+	for k, m := range s.data.members {
+		if k.boardID == boardID && m.SchemeAdmin {
+			adminCount++
+		}
+	}
+	if existing.SchemeAdmin && model.BoardRole(role) != model.BoardRoleAdmin && adminCount == 1 {
+		return model.ErrBoardMemberIsLastAdminRole
+	}
+
+	updated := *existing
+	updated.SetRole(model.BoardRole(role))
+	s.data.members[key] = &updated
+
+	return s.auditChange(boardID, modifiedBy, model.AuditActionSaveMember, userID, model.AuditMemberSummary(&updated.BoardMember))
+}
+
+func (s *MemStore) getMemberForBoard(boardID, userID string) (*model.BoardMember, error) {
+	row, ok := s.data.members[memberKey{boardID: boardID, userID: userID}]
+	if !ok {
+		message := fmt.Sprintf("board member BoardID=%s UserID=%s", boardID, userID)
+		return nil, model.NewErrNotFound(message)
+	}
+
+	return s.memberFromRow(row), nil
+}
+
+func (s *MemStore) getMembersForUser(userID string) ([]*model.BoardMember, error) {
+	return s.membersFromRows(s.memberRows(func(row *memberRow) bool {
+		return row.UserID == userID
+	})), nil
+}
+
+func (s *MemStore) getMembersForBoard(boardID string) ([]*model.BoardMember, error) {
+	return s.membersFromRows(s.memberRows(func(row *memberRow) bool {
+		return row.BoardID == boardID
+	})), nil
+}
+
+func (s *MemStore) getMembersForBoardWithOptions(boardID string, opts model.QueryBoardMembersOptions) ([]*model.BoardMember, bool, error) {
+	rows := s.memberRows(func(row *memberRow) bool {
+		return row.BoardID == boardID && (opts.AfterUserID == "" || row.UserID > opts.AfterUserID)
+	})
+	sort.Slice(rows, func(i, j int) bool { return rows[i].UserID < rows[j].UserID })
+
+	hasMore := false
+	if opts.PerPage > 0 && len(rows) > opts.PerPage {
+		rows = rows[:opts.PerPage]
+		hasMore = true
+	}
+
+	return s.membersFromRows(rows), hasMore, nil
+}
+
+func (s *MemStore) updateMemberLastViewed(boardID, userID string, viewedAt int64) error {
+	key := memberKey{boardID: boardID, userID: userID}
+	existing, ok := s.data.members[key]
+	if !ok {
+		return model.NewErrNotFound(fmt.Sprintf("board member BoardID=%s UserID=%s", boardID, userID))
+	}
+
+	updated := *existing
+	updated.LastViewedAt = viewedAt
+	s.data.members[key] = &updated
+
+	return nil
+}
+
+func (s *MemStore) getRecentlyViewedBoards(userID, teamID string, limit int) ([]*model.Board, error) {
+	lastViewed := map[string]int64{}
+	rows := s.boardRows(func(row *boardRow) bool {
+		if row.TeamID != teamID || row.IsTemplate || row.DeleteAt != 0 {
+			return false
+		}
+		member, ok := s.data.members[memberKey{boardID: row.ID, userID: userID}]
+		if ok {
+			lastViewed[row.ID] = member.LastViewedAt
+		}
+		return ok
+	})
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := lastViewed[rows[i].ID], lastViewed[rows[j].ID]
+		if (a == 0) != (b == 0) {
+			return b == 0
+		}
+		if a != b {
+			return a > b
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	_, end := page(len(rows), 0, limit)
+	return boardsFromRows(rows[:end]), nil
+}
+
+func (s *MemStore) searchBoardsForUser(term, userID string, includePublicBoards bool) ([]*model.Board, error) {
+	return boardsFromRows(s.boardRows(func(row *boardRow) bool {
+		if row.IsTemplate || row.DeleteAt != 0 {
+			return false
+		}
+		if !s.isMember(row.ID, userID) && !(includePublicBoards && row.Type == model.BoardTypeOpen) {
+			return false
+		}
+		return term == "" || matchesSearchWords(row.Title, term)
+	})), nil
+}
+
+func (s *MemStore) searchBoardsForUserInTeam(teamID, term, userID string) ([]*model.Board, error) {
+	return boardsFromRows(s.boardRows(func(row *boardRow) bool {
+		if row.IsTemplate || row.TeamID != teamID || row.DeleteAt != 0 {
+			return false
+		}
+		if row.Type != model.BoardTypeOpen && !(row.Type == model.BoardTypePrivate && s.isMember(row.ID, userID)) {
+			return false
+		}
+		return term == "" || matchesSearchWords(row.Title, term)
+	})), nil
+}
+
+func (s *MemStore) getBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
+	rows := []*boardRow{}
+	for _, row := range s.data.boardsHistory {
+		if row.ID != boardID {
+			continue
+		}
+		if opts.BeforeUpdateAt != 0 && row.UpdateAt >= opts.BeforeUpdateAt {
+			continue
+		}
+		if opts.AfterUpdateAt != 0 && row.UpdateAt <= opts.AfterUpdateAt {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if opts.Descending {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	_, end := page(len(rows), 0, int(opts.Limit))
+	return boardsFromRows(rows[:end]), nil
+}
+
+func (s *MemStore) undeleteBoard(boardID string, modifiedBy string) error {
+	if err := s.restoreBoard(boardID, modifiedBy); !model.IsErrNotFound(err) {
+		return err
+	}
+
+	boards, err := s.getBoardHistory(boardID, model.QueryBoardHistoryOptions{Limit: 1, Descending: true})
+	if err != nil {
+		return err
+	}
+
+	if len(boards) == 0 {
+		return nil // undeleting non-existing board is not considered an error (for now)
+	}
+	board := boards[0]
+
+	if board.DeleteAt == 0 {
+		return nil // undeleting not deleted board is not considered an error (for now)
+	}
+
+	if _, ok := s.data.boards[boardID]; ok {
+		return model.NewErrDuplicate("board")
+	}
+
+	board.ChannelID = ""
+	board.ModifiedBy = modifiedBy
+	board.UpdateAt = utils.GetMillis()
+	board.DeleteAt = 0
+
+	if err := s.insertBoardHistory(*board); err != nil {
+		return err
+	}
+
+	row, err := s.newBoardRow(*board)
+	if err != nil {
+		return err
+	}
+	s.data.boards[boardID] = row
+
+	return s.auditChange(boardID, modifiedBy, model.AuditActionUndeleteBoard, boardID, "")
+}
+
+func (s *MemStore) getBoardMemberHistory(boardID, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
+	entries := []*model.BoardMemberHistoryEntry{}
+	for i := len(s.data.membersHistory) - 1; i >= 0; i-- {
+		entry := s.data.membersHistory[i]
+		if entry.BoardID != boardID || entry.UserID != userID {
+			continue
+		}
+		if limit > 0 && uint64(len(entries)) >= limit {
+			break
+		}
+		copied := *entry
+		entries = append(entries, &copied)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// boardActivity is the activity on a board since a point in time.
+type boardActivity struct {
+	board       *boardRow
+	count       int
+	activeUsers []string
+}
+
+func (a *boardActivity) add(modifiedBy string) {
+	a.count++
+	for _, userID := range a.activeUsers {
+		if userID == modifiedBy {
+			return
+		}
+	}
+	a.activeUsers = append(a.activeUsers, modifiedBy)
+}
+
+func (a *boardActivity) isActiveUser(userID string) bool {
+	for _, activeUser := range a.activeUsers {
+		if activeUser == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// boardsActivity counts the changes made by users other than the system
+// on the live boards of a team, and on their blocks, since a point in
+// time. The result is sorted by decreasing activity.
+func (s *MemStore) boardsActivity(teamID string, since int64, boardIDs []string) []*boardActivity {
+	sinceTime := mmModel.GetTimeForMillis(since)
+	wanted := stringSet(boardIDs)
+	activities := map[string]*boardActivity{}
+
+	record := func(boardID, modifiedBy string, insertAt int64) {
+		board, ok := s.data.boards[boardID]
+		if !ok || board.TeamID != teamID || !wanted[boardID] || board.DeleteAt != 0 {
+			return
+		}
+		if modifiedBy == model.SystemUserID || insertAt <= sinceTime.UnixNano() {
+			return
+		}
+		activity, ok := activities[boardID]
+		if !ok {
+			activity = &boardActivity{board: board}
+			activities[boardID] = activity
+		}
+		activity.add(modifiedBy)
+	}
+
+	for _, row := range s.data.boardsHistory {
+		record(row.ID, row.ModifiedBy, row.insertAt.UnixNano())
+	}
+	for _, row := range s.data.blocksHistory {
+		record(row.BoardID, row.ModifiedBy, row.insertAt.UnixNano())
+	}
+
+	result := make([]*boardActivity, 0, len(activities))
+	for _, activity := range activities {
+		result = append(result, activity)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].count != result[j].count {
+			return result[i].count > result[j].count
+		}
+		return result[i].board.seq < result[j].board.seq
+	})
+
+	return result
+}
+
+func boardInsightsFromActivity(activities []*boardActivity) []*model.BoardInsight {
+	boardsInsights := make([]*model.BoardInsight, 0, len(activities))
+	for _, activity := range activities {
+		boardsInsights = append(boardsInsights, &model.BoardInsight{
+			BoardID:       activity.board.ID,
+			Icon:          activity.board.Icon,
+			Title:         activity.board.Title,
+			ActivityCount: strconv.Itoa(activity.count),
+			ActiveUsers:   strings.Join(activity.activeUsers, ","),
+			CreatedBy:     activity.board.CreatedBy,
+		})
+	}
+	return boardsInsights
+}
+
+func (s *MemStore) getTeamBoardsInsights(teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	activities := s.boardsActivity(teamID, since, boardIDs)
+
+	start, end := page(len(activities), offset, limit)
+	boardsInsights := boardInsightsFromActivity(activities[start:end])
+
+	return model.GetTopBoardInsightsListWithPagination(boardsInsights, limit), nil
+}
+
+func (s *MemStore) getUserBoardsInsights(teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	activities := []*boardActivity{}
+	for _, activity := range s.boardsActivity(teamID, since, boardIDs) {
+		if activity.board.CreatedBy == userID || activity.isActiveUser(userID) {
+			activities = append(activities, activity)
+		}
+	}
+
+	start, end := page(len(activities), offset, limit)
+	boardsInsights := boardInsightsFromActivity(activities[start:end])
+
+	return model.GetTopBoardInsightsListWithPagination(boardsInsights, limit), nil
+}
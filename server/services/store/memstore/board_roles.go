@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func (s *MemStore) createBoardCustomRole(role *model.BoardCustomRole) error {
+	if err := role.IsValid(); err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	if role.ID == "" {
+		role.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if _, ok := s.data.customRoles[role.ID]; ok {
+		return fmt.Errorf("board custom role ID=%s already exists", role.ID)
+	}
+	role.CreateAt = now
+	role.UpdateAt = now
+
+	stored := *role
+	s.data.customRoles[role.ID] = &stored
+
+	return s.auditChange(role.BoardID, role.CreatedBy, model.AuditActionCreateCustomRole, role.ID, "name: "+role.Name)
+}
+
+func (s *MemStore) getBoardCustomRole(id string) (*model.BoardCustomRole, error) {
+	stored, ok := s.data.customRoles[id]
+	if !ok {
+		return nil, model.NewErrNotFound("board custom role ID=" + id)
+	}
+
+	role := *stored
+	return &role, nil
+}
+
+func (s *MemStore) getBoardCustomRoles(boardID string) ([]*model.BoardCustomRole, error) {
+	roles := []*model.BoardCustomRole{}
+	for _, stored := range s.data.customRoles {
+		if stored.BoardID == boardID {
+			role := *stored
+			roles = append(roles, &role)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		if roles[i].Name != roles[j].Name {
+			return roles[i].Name < roles[j].Name
+		}
+		return roles[i].ID < roles[j].ID
+	})
+
+	return roles, nil
+}
+
+func (s *MemStore) updateBoardCustomRole(role *model.BoardCustomRole, userID string) error {
+	if err := role.IsValid(); err != nil {
+		return err
+	}
+
+	existing, ok := s.data.customRoles[role.ID]
+	if !ok || existing.BoardID != role.BoardID {
+		return model.NewErrNotFound("board custom role ID=" + role.ID)
+	}
+
+	role.UpdateAt = utils.GetMillis()
+
+	updated := *existing
+	updated.Name = role.Name
+	updated.Permissions = role.Permissions
+	updated.UpdateAt = role.UpdateAt
+	s.data.customRoles[role.ID] = &updated
+
+	return s.auditChange(role.BoardID, userID, model.AuditActionUpdateCustomRole, role.ID, "name: "+role.Name)
+}
+
+func (s *MemStore) deleteBoardCustomRole(id, userID string) error {
+	role, ok := s.data.customRoles[id]
+	if !ok {
+		return model.NewErrNotFound("board custom role ID=" + id)
+	}
+	delete(s.data.customRoles, id)
+
+	for key, member := range s.data.members {
+		if member.CustomRoleID == id {
+			updated := *member
+			updated.CustomRoleID = ""
+			s.data.members[key] = &updated
+		}
+	}
+
+	return s.auditChange(role.BoardID, userID, model.AuditActionDeleteCustomRole, id, "name: "+role.Name)
+}
+
+func (s *MemStore) deleteBoardCustomRolesForBoard(boardID string) {
+	for id, role := range s.data.customRoles {
+		if role.BoardID == boardID {
+			delete(s.data.customRoles, id)
+		}
+	}
+}
+
+func (s *MemStore) setMemberCustomRole(boardID, userID, roleID, modifiedBy string) error {
+	if roleID != "" {
+		role, err := s.getBoardCustomRole(roleID)
+		if err != nil {
+			return err
+		}
+		if role.BoardID != boardID {
+			return model.NewErrBadRequest("custom role does not belong to the board")
+		}
+	}
+
+	key := memberKey{boardID: boardID, userID: userID}
+	existing, ok := s.data.members[key]
+	if !ok {
+		return model.NewErrNotFound(fmt.Sprintf("board member BoardID=%s UserID=%s", boardID, userID))
+	}
+
+	updated := *existing
+	updated.CustomRoleID = roleID
+	s.data.members[key] = &updated
+
+	return s.auditChange(boardID, modifiedBy, model.AuditActionSaveMember, userID, model.AuditMemberSummary(&updated.BoardMember))
+}
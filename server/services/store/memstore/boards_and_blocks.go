@@ -0,0 +1,446 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+type BlockDoesntBelongToBoardsErr struct {
+	blockID string
+}
+
+func (e BlockDoesntBelongToBoardsErr) Error() string {
+	return fmt.Sprintf("block %s doesn't belong to any of the boards in the delete request", e.blockID)
+}
+
+func (s *MemStore) createBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	newBab, err := s.createBoardsAndBlocks(bab, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := []*model.BoardMember{}
+	for _, board := range newBab.Boards {
+		bm := &model.BoardMember{
+			BoardID:      board.ID,
+			UserID:       board.CreatedBy,
+			SchemeAdmin:  true,
+			SchemeEditor: true,
+		}
+
+		nbm, err := s.saveMember(bm, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		members = append(members, nbm)
+	}
+
+	return newBab, members, nil
+}
+
+func (s *MemStore) createBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	boards := []*model.Board{}
+	blocks := []*model.Block{}
+
+	for _, board := range bab.Boards {
+		newBoard, err := s.insertBoard(board, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		boards = append(boards, newBoard)
+	}
+
+	for _, block := range bab.Blocks {
+		if err := s.insertBlock(block, userID); err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return &model.BoardsAndBlocks{Boards: boards, Blocks: blocks}, nil
+}
+
+func (s *MemStore) patchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	bab := &model.BoardsAndBlocks{}
+	for i, boardID := range pbab.BoardIDs {
+		board, err := s.patchBoard(boardID, pbab.BoardPatches[i], userID)
+		if err != nil {
+			return nil, err
+		}
+		bab.Boards = append(bab.Boards, board)
+	}
+
+	for i, blockID := range pbab.BlockIDs {
+		if _, err := s.patchBlock(blockID, pbab.BlockPatches[i], userID); err != nil {
+			return nil, err
+		}
+		block, err := s.getBlock(blockID)
+		if err != nil {
+			return nil, err
+		}
+		bab.Blocks = append(bab.Blocks, block)
+	}
+
+	return bab, nil
+}
+
+// deleteBoardsAndBlocks deletes all the boards and blocks entities of
+// the DeleteBoardsAndBlocks struct, making sure that all the blocks
+// belong to the boards in the struct.
+func (s *MemStore) deleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error {
+	boardIDMap := map[string]bool{}
+	for _, boardID := range dbab.Boards {
+		if err := s.deleteBoard(boardID, userID); err != nil {
+			return err
+		}
+
+		boardIDMap[boardID] = true
+	}
+
+	for _, blockID := range dbab.Blocks {
+		block, err := s.getBlock(blockID)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := boardIDMap[block.BoardID]; !ok {
+			return BlockDoesntBelongToBoardsErr{blockID}
+		}
+
+		if _, err := s.deleteBlock(blockID, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *MemStore) duplicateBoard(boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	return s.duplicateBoardWithOptions(boardID, userID, model.DuplicateBoardOptions{
+		ToTeam:       toTeam,
+		AsTemplate:   asTemplate,
+		IncludeCards: true,
+	})
+}
+
+// duplicateBoardWithOptions copies a board and its blocks with new
+// IDs. The copy starts with a clean history, as only its creation is
+// recorded.
+func (s *MemStore) duplicateBoardWithOptions(boardID string, userID string, opts model.DuplicateBoardOptions) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	asTemplate := opts.AsTemplate
+
+	board, err := s.getBoard(boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// todo: server localization
+	if asTemplate == board.IsTemplate {
+		// board -> board or template -> template
+		board.Title += " copy"
+	} else if asTemplate {
+		// template from board
+		board.Title = "New board template"
+	}
+
+	// boards created from a template keep track of it, the rest
+	// of the copies keep track of their source board
+	if board.IsTemplate && !asTemplate {
+		board.CreationSource = model.BoardCreationSourceTemplate
+	} else {
+		board.CreationSource = model.BoardCreationSourceDuplicate
+	}
+	board.SourceID = board.ID
+
+	// make new board private
+	board.Type = "P"
+	board.IsTemplate = asTemplate
+	board.CreatedBy = userID
+	board.ChannelID = ""
+	board.CreateAt = 0
+	board.UpdateAt = 0
+	board.DeleteAt = 0
+
+	if opts.ToTeam != "" {
+		board.TeamID = opts.ToTeam
+	}
+
+	blocks, _, err := s.getBlocksForBoard(boardID, model.QueryBlocksOptions{IncludeArchived: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	if !opts.IncludeCards {
+		blocks = withoutCards(blocks)
+	}
+
+	newBlocks := []*model.Block{}
+	for _, b := range blocks {
+		if b.Type == model.TypeComment {
+			continue
+		}
+		if b.DeleteAt != 0 && !opts.IncludeDeletedBlocks {
+			continue
+		}
+
+		// the copy is created now, so it shouldn't carry the source's
+		// timestamps or authorship
+		b.CreatedBy = userID
+		b.CreateAt = 0
+		b.UpdateAt = 0
+		b.DeleteAt = 0
+		newBlocks = append(newBlocks, b)
+	}
+
+	bab := &model.BoardsAndBlocks{
+		Boards: []*model.Board{board},
+		Blocks: newBlocks,
+	}
+	bab, err = model.GenerateBoardsAndBlocksIDs(bab, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newBab, members, err := s.createBoardsAndBlocksWithAdmin(bab, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts.IncludeMembers {
+		copied, err := s.copyBoardMembers(boardID, newBab.Boards[0].ID, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		members = append(members, copied...)
+	}
+
+	return newBab, members, nil
+}
+
+// withoutCards returns the blocks that are neither cards nor part of
+// the content of a card.
+func withoutCards(blocks []*model.Block) []*model.Block {
+	parents := make(map[string]string, len(blocks))
+	cards := map[string]bool{}
+	for _, b := range blocks {
+		parents[b.ID] = b.ParentID
+		if b.Type == model.TypeCard {
+			cards[b.ID] = true
+		}
+	}
+
+	inCard := func(b *model.Block) bool {
+		// the depth is bounded by the number of blocks in case the
+		// parent references form a cycle
+		id := b.ID
+		for i := 0; i <= len(blocks) && id != ""; i++ {
+			if cards[id] {
+				return true
+			}
+			id = parents[id]
+		}
+		return false
+	}
+
+	result := make([]*model.Block, 0, len(blocks))
+	for _, b := range blocks {
+		if !inCard(b) {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// copyBoardMembers gives the members of a board the same roles on
+// another board. The user given as admin is left out, as they already
+// are an admin of the target board.
+func (s *MemStore) copyBoardMembers(fromBoardID, toBoardID, adminID string) ([]*model.BoardMember, error) {
+	members, err := s.getMembersForBoard(fromBoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := make([]*model.BoardMember, 0, len(members))
+	for _, member := range members {
+		if member.UserID == adminID {
+			continue
+		}
+
+		bm := &model.BoardMember{
+			BoardID:         toBoardID,
+			UserID:          member.UserID,
+			SchemeAdmin:     member.SchemeAdmin,
+			SchemeEditor:    member.SchemeEditor,
+			SchemeCommenter: member.SchemeCommenter,
+			SchemeViewer:    member.SchemeViewer,
+		}
+
+		nbm, err := s.saveMember(bm, adminID)
+		if err != nil {
+			return nil, err
+		}
+		copied = append(copied, nbm)
+	}
+	return copied, nil
+}
+
+// mergeBoards moves the blocks of the source board and their history
+// into the target board, adds the members of the source board to the
+// target one and archives the source board. The card properties of the
+// source board missing in the target one are added to it, so the moved
+// cards keep their values.
+//
+// Block IDs are unique across boards, so no block needs a new ID and
+// the returned map of old to new block IDs is always empty.
+func (s *MemStore) mergeBoards(sourceBoardID, targetBoardID, userID string) (map[string]string, error) {
+	if sourceBoardID == targetBoardID {
+		return nil, model.NewErrBadRequest("a board cannot be merged into itself")
+	}
+
+	source, err := s.getBoard(sourceBoardID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getBoard(targetBoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.TeamID != target.TeamID {
+		return nil, model.NewErrBadRequest("only boards of the same team can be merged")
+	}
+
+	// the moved blocks count as new blocks of the target board
+	movedBlocks := []*model.Block{}
+	for _, row := range s.blockRows(func(row *blockRow) bool { return row.BoardID == sourceBoardID }) {
+		movedBlocks = append(movedBlocks, &model.Block{ID: row.ID, BoardID: targetBoardID})
+	}
+
+	err = s.withBoardBlockLimit(movedBlocks, func() error {
+		s.moveBoardBlocks(sourceBoardID, targetBoardID, userID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mergeBoardMembers(sourceBoardID, targetBoardID, userID); err != nil {
+		return nil, err
+	}
+
+	targetProperties := map[string]bool{}
+	for _, property := range target.CardProperties {
+		if id, ok := property["id"].(string); ok {
+			targetProperties[id] = true
+		}
+	}
+	missingProperties := []map[string]interface{}{}
+	for _, property := range source.CardProperties {
+		if id, ok := property["id"].(string); ok && !targetProperties[id] {
+			missingProperties = append(missingProperties, property)
+		}
+	}
+	if len(missingProperties) > 0 {
+		patch := &model.BoardPatch{UpdatedCardProperties: missingProperties}
+		if _, err := s.patchBoard(targetBoardID, patch, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.archiveBoard(sourceBoardID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditChange(targetBoardID, userID, model.AuditActionMergeBoards, sourceBoardID, ""); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{}, nil
+}
+
+// moveBoardBlocks moves all the blocks of a board and their history to
+// another board. The top level blocks get the new board as parent.
+func (s *MemStore) moveBoardBlocks(fromBoardID, toBoardID, userID string) {
+	now := utils.GetMillis()
+
+	move := func(row *blockRow) *blockRow {
+		moved := *row
+		if moved.ParentID == fromBoardID {
+			moved.ParentID = toBoardID
+		}
+		moved.BoardID = toBoardID
+		return &moved
+	}
+
+	for id, row := range s.data.blocks {
+		if row.BoardID != fromBoardID {
+			continue
+		}
+		moved := move(row)
+		moved.ModifiedBy = userID
+		moved.UpdateAt = now
+		s.data.blocks[id] = moved
+	}
+
+	for i, row := range s.data.blocksHistory {
+		if row.BoardID == fromBoardID {
+			s.data.blocksHistory[i] = move(row)
+		}
+	}
+}
+
+// mergeBoardMembers adds the members of a board to another board. Users
+// that are members of both keep the higher of their two roles.
+func (s *MemStore) mergeBoardMembers(fromBoardID, toBoardID, userID string) error {
+	members, err := s.getMembersForBoard(fromBoardID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		existing, err := s.getMemberForBoard(toBoardID, member.UserID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return err
+		}
+
+		if existing != nil && boardRoleRank(existing.SchemeRole()) >= boardRoleRank(member.SchemeRole()) {
+			continue
+		}
+
+		bm := &model.BoardMember{
+			BoardID:         toBoardID,
+			UserID:          member.UserID,
+			SchemeAdmin:     member.SchemeAdmin,
+			SchemeEditor:    member.SchemeEditor,
+			SchemeCommenter: member.SchemeCommenter,
+			SchemeViewer:    member.SchemeViewer,
+		}
+		if _, err := s.saveMember(bm, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func boardRoleRank(role model.BoardRole) int {
+	switch role {
+	case model.BoardRoleAdmin:
+		return 4
+	case model.BoardRoleEditor:
+		return 3
+	case model.BoardRoleCommenter:
+		return 2
+	case model.BoardRoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
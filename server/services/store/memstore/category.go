@@ -0,0 +1,288 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// categoryRows returns copies of the categories that match the filter,
+// sorted by the less function and then by ID.
+func (s *MemStore) categoryRows(filter func(*model.Category) bool, less func(a, b *model.Category) bool) []model.Category {
+	rows := []*model.Category{}
+	for _, category := range s.data.categories {
+		if filter(category) {
+			rows = append(rows, category)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if less(rows[i], rows[j]) {
+			return true
+		}
+		if less(rows[j], rows[i]) {
+			return false
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	// like the SQL store, no categories are returned as nil
+	var categories []model.Category
+	for _, row := range rows {
+		categories = append(categories, *row)
+	}
+	return categories
+}
+
+func (s *MemStore) updateCategoryRow(id string, fn func(*model.Category)) {
+	updated := *s.data.categories[id]
+	fn(&updated)
+	s.data.categories[id] = &updated
+}
+
+func (s *MemStore) getCategory(id string) (*model.Category, error) {
+	category, ok := s.data.categories[id]
+	if !ok {
+		return nil, model.NewErrNotFound("category ID=" + id)
+	}
+
+	c := *category
+	return &c, nil
+}
+
+func (s *MemStore) createCategory(category model.Category) error {
+	sortOrder := -1
+	for _, c := range s.data.categories {
+		if c.UserID == category.UserID && c.TeamID == category.TeamID && c.DeleteAt == 0 && c.SortOrder > sortOrder {
+			sortOrder = c.SortOrder
+		}
+	}
+
+	category.SortOrder = sortOrder + 1
+	return s.insertCategory(category)
+}
+
+func (s *MemStore) insertCategory(category model.Category) error {
+	if _, ok := s.data.categories[category.ID]; ok {
+		return model.NewErrDuplicate("category")
+	}
+
+	s.data.categories[category.ID] = &category
+	return nil
+}
+
+func (s *MemStore) updateCategory(category model.Category) error {
+	existing, ok := s.data.categories[category.ID]
+	if !ok || existing.DeleteAt != 0 {
+		return nil
+	}
+
+	s.updateCategoryRow(category.ID, func(c *model.Category) {
+		c.Name = category.Name
+		c.UpdateAt = category.UpdateAt
+		c.Collapsed = category.Collapsed
+		c.Icon = category.Icon
+	})
+	return nil
+}
+
+func (s *MemStore) deleteCategory(categoryID, userID, teamID string) error {
+	existing, ok := s.data.categories[categoryID]
+	if !ok || existing.UserID != userID || existing.TeamID != teamID || existing.DeleteAt != 0 {
+		return nil
+	}
+
+	s.updateCategoryRow(categoryID, func(c *model.Category) {
+		c.DeleteAt = utils.GetMillis()
+	})
+	return nil
+}
+
+func (s *MemStore) getUserCategories(userID, teamID string) ([]model.Category, error) {
+	return s.categoryRows(
+		func(c *model.Category) bool {
+			return c.UserID == userID && c.TeamID == teamID && c.DeleteAt == 0
+		},
+		func(a, b *model.Category) bool {
+			if a.SortOrder != b.SortOrder {
+				return a.SortOrder < b.SortOrder
+			}
+			return a.CreateAt < b.CreateAt
+		},
+	), nil
+}
+
+func (s *MemStore) reorderCategories(userID, teamID string, categoryIDs []string) error {
+	categories, err := s.getUserCategories(userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		existing[category.ID] = true
+	}
+
+	seen := make(map[string]bool, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		if !existing[categoryID] {
+			return model.NewErrBadRequest("category " + categoryID + " is not a category of the user")
+		}
+		if seen[categoryID] {
+			return model.NewErrBadRequest("category " + categoryID + " is listed more than once")
+		}
+		seen[categoryID] = true
+	}
+
+	if len(categoryIDs) != len(categories) {
+		return model.NewErrBadRequest("the new order must include all the categories of the user")
+	}
+
+	now := utils.GetMillis()
+	for i, categoryID := range categoryIDs {
+		sortOrder := i
+		s.updateCategoryRow(categoryID, func(c *model.Category) {
+			c.SortOrder = sortOrder
+			c.UpdateAt = now
+		})
+	}
+
+	return nil
+}
+
+func (s *MemStore) getAllCategoriesForTeam(teamID string) ([]model.Category, error) {
+	return s.categoryRows(
+		func(c *model.Category) bool {
+			return c.TeamID == teamID && c.DeleteAt == 0
+		},
+		func(a, b *model.Category) bool {
+			if a.UserID != b.UserID {
+				return a.UserID < b.UserID
+			}
+			return a.CreateAt < b.CreateAt
+		},
+	), nil
+}
+
+func (s *MemStore) findDuplicateCategories(userID, teamID string) (map[string][]model.Category, error) {
+	categories := s.categoryRows(
+		func(c *model.Category) bool {
+			return c.UserID == userID && c.TeamID == teamID && c.DeleteAt == 0
+		},
+		func(a, b *model.Category) bool { return a.CreateAt < b.CreateAt },
+	)
+
+	byName := map[string][]model.Category{}
+	for _, category := range categories {
+		byName[category.Name] = append(byName[category.Name], category)
+	}
+
+	duplicates := map[string][]model.Category{}
+	for name, group := range byName {
+		if len(group) > 1 {
+			duplicates[name] = group
+		}
+	}
+
+	return duplicates, nil
+}
+
+func (s *MemStore) mergeCategories(userID, primaryCategoryID string, mergeCategoryIDs []string) error {
+	primary, err := s.getCategory(primaryCategoryID)
+	if err != nil {
+		return err
+	}
+
+	if primary.DeleteAt != 0 {
+		return model.NewErrNotFound("category ID=" + primaryCategoryID)
+	}
+
+	if primary.UserID != userID {
+		return model.ErrCategoryPermissionDenied
+	}
+
+	primaryBoardIDs, err := s.getCategoryBoardAttributes(primaryCategoryID)
+	if err != nil {
+		return err
+	}
+
+	inPrimary := stringSet(primaryBoardIDs)
+
+	// validate every category before merging any of them
+	merged := map[string]bool{}
+	categories := []*model.Category{}
+	for _, categoryID := range mergeCategoryIDs {
+		if categoryID == primaryCategoryID {
+			return model.NewErrBadRequest("a category cannot be merged into itself")
+		}
+		if merged[categoryID] {
+			continue
+		}
+		merged[categoryID] = true
+
+		category, err := s.getCategory(categoryID)
+		if err != nil {
+			return err
+		}
+
+		if category.DeleteAt != 0 {
+			return model.NewErrNotFound("category ID=" + categoryID)
+		}
+
+		if category.UserID != userID {
+			return model.ErrCategoryPermissionDenied
+		}
+
+		if category.TeamID != primary.TeamID {
+			return model.NewErrBadRequest("only categories of the same team can be merged")
+		}
+
+		if category.Type == model.CategoryTypeSystem && primary.Type != model.CategoryTypeSystem {
+			return model.NewErrBadRequest("the default category cannot be merged into a custom category")
+		}
+
+		categories = append(categories, category)
+	}
+
+	for _, category := range categories {
+		categoryID := category.ID
+
+		now := utils.GetMillis()
+		sortOrder := s.getNextCategoryBoardSortOrder(primaryCategoryID)
+
+		// the boards already in the primary category are left
+		// behind, and deleted along with the merged category
+		for _, row := range s.categoryBoardRows(func(row *categoryBoardRow) bool {
+			return row.userID == userID && row.categoryID == categoryID
+		}) {
+			if inPrimary[row.boardID] {
+				continue
+			}
+			inPrimary[row.boardID] = true
+
+			moved := *row
+			moved.categoryID = primaryCategoryID
+			moved.sortOrder = sortOrder
+			moved.updateAt = now
+			s.data.categoryBoards[row.id] = &moved
+			sortOrder++
+		}
+
+		for _, row := range s.categoryBoardRows(func(row *categoryBoardRow) bool {
+			return row.userID == userID && row.categoryID == categoryID
+		}) {
+			deleted := *row
+			deleted.deleteAt = now
+			s.data.categoryBoards[row.id] = &deleted
+		}
+
+		if err := s.deleteCategory(categoryID, userID, category.TeamID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
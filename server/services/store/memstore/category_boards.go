@@ -0,0 +1,276 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// categoryBoardRows returns the live category boards that match the
+// filter, in the order of their category.
+func (s *MemStore) categoryBoardRows(filter func(*categoryBoardRow) bool) []*categoryBoardRow {
+	rows := []*categoryBoardRow{}
+	for _, row := range s.data.categoryBoards {
+		if row.deleteAt == 0 && filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].sortOrder != rows[j].sortOrder {
+			return rows[i].sortOrder < rows[j].sortOrder
+		}
+		if rows[i].createAt != rows[j].createAt {
+			return rows[i].createAt < rows[j].createAt
+		}
+		return rows[i].id < rows[j].id
+	})
+	return rows
+}
+
+func (s *MemStore) getUserCategoryBoards(userID, teamID string) ([]model.CategoryBoards, error) {
+	categories, err := s.getUserCategories(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	userCategoryBoards := []model.CategoryBoards{}
+	for _, category := range categories {
+		boardIDs, err := s.getCategoryBoardAttributes(category.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		userCategoryBoard := model.CategoryBoards{
+			Category: category,
+			BoardIDs: boardIDs,
+		}
+
+		userCategoryBoards = append(userCategoryBoards, userCategoryBoard)
+	}
+
+	return userCategoryBoards, nil
+}
+
+func (s *MemStore) getCategoryBoardAttributes(categoryID string) ([]string, error) {
+	boardIDs := []string{}
+	for _, row := range s.categoryBoardRows(func(row *categoryBoardRow) bool { return row.categoryID == categoryID }) {
+		boardIDs = append(boardIDs, row.boardID)
+	}
+	return boardIDs, nil
+}
+
+func (s *MemStore) addUpdateCategoryBoard(userID, categoryID, boardID string) error {
+	if err := s.deleteUserCategoryBoard(userID, boardID); err != nil {
+		return err
+	}
+
+	if categoryID == "0" {
+		// category ID "0" means user wants to move board out of
+		// the custom category. Deleting the user-board-category
+		// mapping achieves this.
+		return nil
+	}
+
+	return s.addUserCategoryBoard(userID, categoryID, boardID)
+}
+
+func (s *MemStore) addUserCategoryBoard(userID, categoryID, boardID string) error {
+	now := utils.GetMillis()
+	row := &categoryBoardRow{
+		id:         utils.NewID(utils.IDTypeNone),
+		userID:     userID,
+		categoryID: categoryID,
+		boardID:    boardID,
+		createAt:   now,
+		updateAt:   now,
+		sortOrder:  s.getNextCategoryBoardSortOrder(categoryID),
+	}
+	s.data.categoryBoards[row.id] = row
+	return nil
+}
+
+// getNextCategoryBoardSortOrder returns the sort order that places a
+// board last in a category.
+func (s *MemStore) getNextCategoryBoardSortOrder(categoryID string) int64 {
+	sortOrder := int64(-1)
+	for _, row := range s.data.categoryBoards {
+		if row.categoryID == categoryID && row.deleteAt == 0 && row.sortOrder > sortOrder {
+			sortOrder = row.sortOrder
+		}
+	}
+	return sortOrder + 1
+}
+
+func (s *MemStore) reorderCategoryBoards(userID, categoryID string, boardIDs []string) error {
+	category, err := s.getCategory(categoryID)
+	if err != nil {
+		return err
+	}
+
+	if category.DeleteAt != 0 {
+		return model.NewErrNotFound("category ID=" + categoryID)
+	}
+
+	if category.UserID != userID {
+		return model.ErrCategoryPermissionDenied
+	}
+
+	categoryBoardIDs, err := s.getCategoryBoardAttributes(categoryID)
+	if err != nil {
+		return err
+	}
+
+	existing := stringSet(categoryBoardIDs)
+
+	seen := make(map[string]bool, len(boardIDs))
+	for _, boardID := range boardIDs {
+		if !existing[boardID] {
+			return model.NewErrBadRequest("board " + boardID + " is not in the category")
+		}
+		if seen[boardID] {
+			return model.NewErrBadRequest("board " + boardID + " is listed more than once")
+		}
+		seen[boardID] = true
+	}
+
+	if len(boardIDs) != len(categoryBoardIDs) {
+		return model.NewErrBadRequest("the new order must include all the boards of the category")
+	}
+
+	position := make(map[string]int64, len(boardIDs))
+	for i, boardID := range boardIDs {
+		position[boardID] = int64(i)
+	}
+
+	now := utils.GetMillis()
+	for _, row := range s.categoryBoardRows(func(row *categoryBoardRow) bool {
+		return row.userID == userID && row.categoryID == categoryID
+	}) {
+		updated := *row
+		updated.sortOrder = position[row.boardID]
+		updated.updateAt = now
+		s.data.categoryBoards[row.id] = &updated
+	}
+
+	return nil
+}
+
+func (s *MemStore) deleteUserCategoryBoard(userID, boardID string) error {
+	s.deleteCategoryBoardRows(func(row *categoryBoardRow) bool {
+		return row.userID == userID && row.boardID == boardID
+	})
+	return nil
+}
+
+// deleteCategoryBoardRows marks the live category boards that match the
+// filter as deleted.
+func (s *MemStore) deleteCategoryBoardRows(filter func(*categoryBoardRow) bool) {
+	now := utils.GetMillis()
+	for _, row := range s.categoryBoardRows(filter) {
+		deleted := *row
+		deleted.deleteAt = now
+		s.data.categoryBoards[row.id] = &deleted
+	}
+}
+
+func (s *MemStore) removeCategoryBoards(userID, categoryID string, boardIDs []string) error {
+	category, err := s.getCategory(categoryID)
+	if err != nil {
+		return err
+	}
+
+	if category.UserID != userID {
+		return model.ErrCategoryPermissionDenied
+	}
+
+	if category.Type == model.CategoryTypeSystem {
+		return model.NewErrBadRequest("boards cannot be removed from the default category")
+	}
+
+	categoryBoardIDs, err := s.getCategoryBoardAttributes(categoryID)
+	if err != nil {
+		return err
+	}
+
+	toRemove := stringSet(boardIDs)
+
+	removedBoardIDs := []string{}
+	for _, boardID := range categoryBoardIDs {
+		if toRemove[boardID] {
+			removedBoardIDs = append(removedBoardIDs, boardID)
+		}
+	}
+
+	if len(removedBoardIDs) == 0 {
+		return nil
+	}
+
+	removed := stringSet(removedBoardIDs)
+	s.deleteCategoryBoardRows(func(row *categoryBoardRow) bool {
+		return row.userID == userID && row.categoryID == categoryID && removed[row.boardID]
+	})
+
+	defaultCategoryID, err := s.getDefaultCategoryID(userID, category.TeamID)
+	if model.IsErrNotFound(err) {
+		// without a default category the boards are left
+		// uncategorized
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, boardID := range removedBoardIDs {
+		if err := s.addUserCategoryBoard(userID, defaultCategoryID, boardID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *MemStore) clearCategory(userID, categoryID string) error {
+	boardIDs, err := s.getCategoryBoardAttributes(categoryID)
+	if err != nil {
+		return err
+	}
+
+	return s.removeCategoryBoards(userID, categoryID, boardIDs)
+}
+
+func (s *MemStore) getDefaultCategoryID(userID, teamID string) (string, error) {
+	categories := s.categoryRows(
+		func(c *model.Category) bool {
+			return c.UserID == userID && c.TeamID == teamID && c.Type == model.CategoryTypeSystem && c.DeleteAt == 0
+		},
+		func(a, b *model.Category) bool { return a.CreateAt < b.CreateAt },
+	)
+	if len(categories) == 0 {
+		return "", model.NewErrNotFound("default category for user " + userID)
+	}
+
+	return categories[0].ID, nil
+}
+
+func (s *MemStore) getCategoryForBoard(userID, teamID, boardID string) (*model.Category, error) {
+	for _, row := range s.categoryBoardRows(func(row *categoryBoardRow) bool {
+		return row.userID == userID && row.boardID == boardID
+	}) {
+		category, ok := s.data.categories[row.categoryID]
+		if ok && category.TeamID == teamID && category.DeleteAt == 0 {
+			c := *category
+			return &c, nil
+		}
+	}
+
+	defaultCategoryID, err := s.getDefaultCategoryID(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getCategory(defaultCategoryID)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func (s *MemStore) getDefaultCategoryTemplates(teamID string) ([]model.CategoryTemplate, error) {
+	templates := []model.CategoryTemplate{}
+	for _, template := range s.data.categoryTemplates {
+		if template.TeamID == teamID {
+			templates = append(templates, *template)
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].SortOrder != templates[j].SortOrder {
+			return templates[i].SortOrder < templates[j].SortOrder
+		}
+		if templates[i].CreateAt != templates[j].CreateAt {
+			return templates[i].CreateAt < templates[j].CreateAt
+		}
+		return templates[i].ID < templates[j].ID
+	})
+
+	return templates, nil
+}
+
+func (s *MemStore) upsertDefaultCategoryTemplate(template model.CategoryTemplate) error {
+	if err := template.IsValid(); err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	template.CreateAt = now
+	template.UpdateAt = now
+	if existing, ok := s.data.categoryTemplates[template.ID]; ok {
+		template.CreateAt = existing.CreateAt
+	}
+
+	s.data.categoryTemplates[template.ID] = &template
+	return nil
+}
+
+func (s *MemStore) createDefaultCategoriesForUser(userID, teamID string) error {
+	templates, err := s.getDefaultCategoryTemplates(teamID)
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.getUserCategories(userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		existing[category.Name] = true
+	}
+
+	for _, template := range templates {
+		if existing[template.Name] {
+			continue
+		}
+
+		category := model.Category{
+			Name:      template.Name,
+			UserID:    userID,
+			TeamID:    teamID,
+			SortOrder: template.SortOrder,
+			Icon:      template.Icon,
+		}
+		category.Hydrate()
+
+		if err := s.insertCategory(category); err != nil {
+			return err
+		}
+		existing[template.Name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+var ErrInvalidCardLimitValue = errors.New("card limit value is invalid")
+
+// usedCardRows returns the live cards that are not part of a template.
+func (s *MemStore) usedCardRows() []*blockRow {
+	return s.blockRows(func(row *blockRow) bool {
+		if row.DeleteAt != 0 || row.Type != model.TypeCard {
+			return false
+		}
+		board, ok := s.data.boards[row.BoardID]
+		return ok && !board.IsTemplate
+	})
+}
+
+func (s *MemStore) getUsedCardsCount() (int, error) {
+	return len(s.usedCardRows()), nil
+}
+
+func (s *MemStore) getCardLimitTimestamp() (int64, error) {
+	value, ok := s.data.system[store.CardLimitTimestampSystemKey]
+	if !ok {
+		return 0, nil
+	}
+
+	cardLimitTimestamp, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, ErrInvalidCardLimitValue
+	}
+
+	return int64(cardLimitTimestamp), nil
+}
+
+func (s *MemStore) updateCardLimitTimestamp(cardLimit int) (int64, error) {
+	var value int64
+	if cardLimit != 0 {
+		rows := s.usedCardRows()
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].UpdateAt > rows[j].UpdateAt })
+		if len(rows) >= cardLimit {
+			value = rows[cardLimit-1].UpdateAt
+		}
+	}
+	s.data.system[store.CardLimitTimestampSystemKey] = strconv.FormatInt(value, 10)
+
+	return s.getCardLimitTimestamp()
+}
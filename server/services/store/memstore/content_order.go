@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func contentOrderFromFields(fields map[string]interface{}) []interface{} {
+	contentOrder, ok := fields["contentOrder"].([]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+	return contentOrder
+}
+
+func (s *MemStore) normalizeContentOrder(cardID string) error {
+	card, err := s.getBlock(cardID)
+	if err != nil {
+		return err
+	}
+
+	if card.Type != model.TypeCard {
+		return model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", cardID))
+	}
+
+	children, err := s.getBlocks(model.QueryBlocksOptions{
+		BoardID:          card.BoardID,
+		ParentID:         cardID,
+		IncludeArchived:  true,
+		OrderBySortOrder: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	contentIDs := make([]string, 0, len(children))
+	for _, child := range children {
+		if child.Type == model.TypeComment || child.DeleteAt != 0 {
+			continue
+		}
+		contentIDs = append(contentIDs, child.ID)
+	}
+
+	contentOrder := contentOrderFromFields(card.Fields)
+	normalized := model.ReconcileContentOrder(contentOrder, contentIDs)
+
+	oldJSON, err := json.Marshal(contentOrder)
+	if err != nil {
+		return err
+	}
+	newJSON, err := json.Marshal(normalized)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := card.Fields["contentOrder"]; !ok || string(oldJSON) != string(newJSON) {
+		if card.Fields == nil {
+			card.Fields = map[string]interface{}{}
+		}
+		card.Fields["contentOrder"] = normalized
+		card.UpdateAt = utils.GetMillis()
+
+		var fields map[string]interface{}
+		if err := normalizeJSON(card.Fields, &fields); err != nil {
+			return err
+		}
+		s.updateBlockRow(s.data.blocks[cardID], func(row *blockRow) {
+			row.Fields = fields
+			row.UpdateAt = card.UpdateAt
+		})
+
+		if err := s.insertBlockHistory(card, card.ModifiedBy); err != nil {
+			return err
+		}
+	}
+
+	return s.updateContentSortOrder(card.BoardID, cardID, normalized)
+}
+
+func (s *MemStore) updateContentSortOrder(boardID, cardID string, contentOrder []interface{}) error {
+	for i, contentID := range model.FlattenContentOrder(contentOrder) {
+		row, ok := s.data.blocks[contentID]
+		if !ok || row.BoardID != boardID || row.ParentID != cardID || row.SortOrder == int64(i+1) {
+			continue
+		}
+
+		sortOrder := int64(i + 1)
+		s.updateBlockRow(row, func(row *blockRow) {
+			row.SortOrder = sortOrder
+		})
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// data holds the tables of the store. The rows it holds are never
+// modified in place: every change stores a new row, so a shallow clone
+// of the tables is enough to restore them if a method fails.
+type data struct {
+	// seq numbers the inserted rows, standing in for the insertion
+	// order of the SQL tables
+	seq int64
+
+	blocks         map[string]*blockRow
+	blocksHistory  []*blockRow
+	boards         map[string]*boardRow
+	boardsHistory  []*boardRow
+	members        map[memberKey]*memberRow
+	membersHistory []*model.BoardMemberHistoryEntry
+	customRoles    map[string]*model.BoardCustomRole
+	favorites      map[memberKey]int64
+
+	users       map[string]*userRow
+	preferences map[preferenceKey]*preferenceRow
+	sessions    map[string]*sessionRow
+	teams       map[string]*teamRow
+	system      map[string]string
+
+	sharing     map[string]*model.Sharing
+	shareTokens map[string]*model.ShareToken
+	snapshots   map[string]*snapshotRow
+
+	categories        map[string]*model.Category
+	categoryBoards    map[string]*categoryBoardRow
+	categoryTemplates map[string]*model.CategoryTemplate
+
+	subscriptions     map[subscriptionKey]*model.Subscription
+	notificationHints map[string]*model.NotificationHint
+	webhooks          map[string]*model.Webhook
+	webhookDeliveries map[string]*model.WebhookDelivery
+	fileInfos         map[string]*fileInfoRow
+	auditRecords      []*model.AuditRecord
+}
+
+func newData() *data {
+	return &data{
+		blocks:            map[string]*blockRow{},
+		boards:            map[string]*boardRow{},
+		members:           map[memberKey]*memberRow{},
+		customRoles:       map[string]*model.BoardCustomRole{},
+		favorites:         map[memberKey]int64{},
+		users:             map[string]*userRow{},
+		preferences:       map[preferenceKey]*preferenceRow{},
+		sessions:          map[string]*sessionRow{},
+		teams:             map[string]*teamRow{},
+		system:            map[string]string{},
+		sharing:           map[string]*model.Sharing{},
+		shareTokens:       map[string]*model.ShareToken{},
+		snapshots:         map[string]*snapshotRow{},
+		categories:        map[string]*model.Category{},
+		categoryBoards:    map[string]*categoryBoardRow{},
+		categoryTemplates: map[string]*model.CategoryTemplate{},
+		subscriptions:     map[subscriptionKey]*model.Subscription{},
+		notificationHints: map[string]*model.NotificationHint{},
+		webhooks:          map[string]*model.Webhook{},
+		webhookDeliveries: map[string]*model.WebhookDelivery{},
+		fileInfos:         map[string]*fileInfoRow{},
+	}
+}
+
+// clone returns a copy of the tables that shares their rows.
+func (d *data) clone() *data {
+	c := &data{
+		seq:               d.seq,
+		blocks:            make(map[string]*blockRow, len(d.blocks)),
+		blocksHistory:     append([]*blockRow(nil), d.blocksHistory...),
+		boards:            make(map[string]*boardRow, len(d.boards)),
+		boardsHistory:     append([]*boardRow(nil), d.boardsHistory...),
+		members:           make(map[memberKey]*memberRow, len(d.members)),
+		membersHistory:    append([]*model.BoardMemberHistoryEntry(nil), d.membersHistory...),
+		customRoles:       make(map[string]*model.BoardCustomRole, len(d.customRoles)),
+		favorites:         make(map[memberKey]int64, len(d.favorites)),
+		users:             make(map[string]*userRow, len(d.users)),
+		preferences:       make(map[preferenceKey]*preferenceRow, len(d.preferences)),
+		sessions:          make(map[string]*sessionRow, len(d.sessions)),
+		teams:             make(map[string]*teamRow, len(d.teams)),
+		system:            make(map[string]string, len(d.system)),
+		sharing:           make(map[string]*model.Sharing, len(d.sharing)),
+		shareTokens:       make(map[string]*model.ShareToken, len(d.shareTokens)),
+		snapshots:         make(map[string]*snapshotRow, len(d.snapshots)),
+		categories:        make(map[string]*model.Category, len(d.categories)),
+		categoryBoards:    make(map[string]*categoryBoardRow, len(d.categoryBoards)),
+		categoryTemplates: make(map[string]*model.CategoryTemplate, len(d.categoryTemplates)),
+		subscriptions:     make(map[subscriptionKey]*model.Subscription, len(d.subscriptions)),
+		notificationHints: make(map[string]*model.NotificationHint, len(d.notificationHints)),
+		webhooks:          make(map[string]*model.Webhook, len(d.webhooks)),
+		webhookDeliveries: make(map[string]*model.WebhookDelivery, len(d.webhookDeliveries)),
+		fileInfos:         make(map[string]*fileInfoRow, len(d.fileInfos)),
+		auditRecords:      append([]*model.AuditRecord(nil), d.auditRecords...),
+	}
+
+	for k, v := range d.blocks {
+		c.blocks[k] = v
+	}
+	for k, v := range d.boards {
+		c.boards[k] = v
+	}
+	for k, v := range d.members {
+		c.members[k] = v
+	}
+	for k, v := range d.customRoles {
+		c.customRoles[k] = v
+	}
+	for k, v := range d.favorites {
+		c.favorites[k] = v
+	}
+	for k, v := range d.users {
+		c.users[k] = v
+	}
+	for k, v := range d.preferences {
+		c.preferences[k] = v
+	}
+	for k, v := range d.sessions {
+		c.sessions[k] = v
+	}
+	for k, v := range d.teams {
+		c.teams[k] = v
+	}
+	for k, v := range d.system {
+		c.system[k] = v
+	}
+	for k, v := range d.sharing {
+		c.sharing[k] = v
+	}
+	for k, v := range d.shareTokens {
+		c.shareTokens[k] = v
+	}
+	for k, v := range d.snapshots {
+		c.snapshots[k] = v
+	}
+	for k, v := range d.categories {
+		c.categories[k] = v
+	}
+	for k, v := range d.categoryBoards {
+		c.categoryBoards[k] = v
+	}
+	for k, v := range d.categoryTemplates {
+		c.categoryTemplates[k] = v
+	}
+	for k, v := range d.subscriptions {
+		c.subscriptions[k] = v
+	}
+	for k, v := range d.notificationHints {
+		c.notificationHints[k] = v
+	}
+	for k, v := range d.webhooks {
+		c.webhooks[k] = v
+	}
+	for k, v := range d.webhookDeliveries {
+		c.webhookDeliveries[k] = v
+	}
+	for k, v := range d.fileInfos {
+		c.fileInfos[k] = v
+	}
+
+	return c
+}
+
+// nextSeq returns the sequence number of a new row.
+func (d *data) nextSeq() int64 {
+	d.seq++
+	return d.seq
+}
+
+// blockRow is a row of the blocks and blocks_history tables.
+type blockRow struct {
+	model.Block
+	seq      int64
+	insertAt time.Time
+}
+
+// boardRow is a row of the boards and boards_history tables.
+type boardRow struct {
+	model.Board
+	seq      int64
+	insertAt time.Time
+}
+
+type memberKey struct {
+	boardID string
+	userID  string
+}
+
+// memberRow is a row of the board_members table. The minimum role of
+// the member comes from its board when it is read.
+type memberRow struct {
+	model.BoardMember
+	seq int64
+}
+
+type userRow struct {
+	model.User
+	seq int64
+}
+
+type preferenceKey struct {
+	userID   string
+	category string
+	name     string
+}
+
+type preferenceRow struct {
+	mmModel.Preference
+	seq int64
+}
+
+type sessionRow struct {
+	model.Session
+	seq int64
+}
+
+type teamRow struct {
+	model.Team
+	seq int64
+}
+
+// snapshotRow keeps the board and blocks of a snapshot serialized, as
+// the SQL store does.
+type snapshotRow struct {
+	boardID   string
+	data      string
+	createdBy string
+	createAt  int64
+}
+
+type categoryBoardRow struct {
+	id         string
+	userID     string
+	categoryID string
+	boardID    string
+	createAt   int64
+	updateAt   int64
+	deleteAt   int64
+	sortOrder  int64
+}
+
+type subscriptionKey struct {
+	blockID      string
+	subscriberID string
+}
+
+type fileInfoRow struct {
+	id        string
+	createAt  int64
+	name      string
+	extension string
+	size      int64
+	deleteAt  int64
+}
+
+// normalizeJSON round-trips a value through JSON into dest, so that the
+// stored maps hold the same types the SQL store reads back, and share
+// nothing with the value of the caller.
+func normalizeJSON(value interface{}, dest interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dest)
+}
+
+// copyJSONValue deep copies a value decoded from JSON.
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyJSONMap(v)
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, item := range v {
+			c[i] = copyJSONValue(item)
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+func copyJSONMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = copyJSONValue(v)
+	}
+	return c
+}
+
+func copyJSONMaps(maps []map[string]interface{}) []map[string]interface{} {
+	if maps == nil {
+		return nil
+	}
+	c := make([]map[string]interface{}, len(maps))
+	for i, m := range maps {
+		c[i] = copyJSONMap(m)
+	}
+	return c
+}
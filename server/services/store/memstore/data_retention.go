@@ -0,0 +1,147 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (s *MemStore) runDataRetention(globalRetentionDate int64, batchSize int64) (int64, error) {
+	s.logger.Info("Start Boards Data Retention",
+		mlog.String("Global Retention Date", time.Unix(globalRetentionDate/1000, 0).String()),
+		mlog.Int64("Raw Date", globalRetentionDate))
+
+	// boards are kept while any of their blocks was updated after
+	// the retention date, and the ones without blocks are kept
+	lastUpdate := map[string]int64{}
+	for _, block := range s.data.blocks {
+		if block.UpdateAt > lastUpdate[block.BoardID] {
+			lastUpdate[block.BoardID] = block.UpdateAt
+		}
+	}
+
+	deleteIDs := map[string]bool{}
+	for _, board := range s.data.boards {
+		maxDate, ok := lastUpdate[board.ID]
+		if ok && maxDate < globalRetentionDate && board.TeamID != model.GlobalTeamID && !board.IsTemplate {
+			deleteIDs[board.ID] = true
+		}
+	}
+
+	totalAffected := s.deleteBoardsData(deleteIDs)
+
+	s.logger.Info("Complete Boards Data Retention",
+		mlog.Int("Total deletion ids", len(deleteIDs)),
+		mlog.Int("TotalAffected", int(totalAffected)))
+	return totalAffected, nil
+}
+
+// deleteBoardsData permanently removes the boards with the given IDs
+// along with their blocks, members, sharing, category entries, webhooks,
+// snapshots and favorites, including their history, and returns the
+// number of rows removed.
+func (s *MemStore) deleteBoardsData(boardIDs map[string]bool) int64 {
+	if len(boardIDs) == 0 {
+		return 0
+	}
+
+	var affected int64
+
+	for id, row := range s.data.blocks {
+		if boardIDs[row.BoardID] {
+			delete(s.data.blocks, id)
+			affected++
+		}
+	}
+	blocksHistory := []*blockRow{}
+	for _, row := range s.data.blocksHistory {
+		if boardIDs[row.BoardID] {
+			affected++
+			continue
+		}
+		blocksHistory = append(blocksHistory, row)
+	}
+	s.data.blocksHistory = blocksHistory
+
+	for id := range s.data.boards {
+		if boardIDs[id] {
+			delete(s.data.boards, id)
+			affected++
+		}
+	}
+	boardsHistory := []*boardRow{}
+	for _, row := range s.data.boardsHistory {
+		if boardIDs[row.ID] {
+			affected++
+			continue
+		}
+		boardsHistory = append(boardsHistory, row)
+	}
+	s.data.boardsHistory = boardsHistory
+
+	for key := range s.data.members {
+		if boardIDs[key.boardID] {
+			delete(s.data.members, key)
+			affected++
+		}
+	}
+	membersHistory := []*model.BoardMemberHistoryEntry{}
+	for _, entry := range s.data.membersHistory {
+		if boardIDs[entry.BoardID] {
+			affected++
+			continue
+		}
+		membersHistory = append(membersHistory, entry)
+	}
+	s.data.membersHistory = membersHistory
+
+	for id := range s.data.sharing {
+		if boardIDs[id] {
+			delete(s.data.sharing, id)
+			affected++
+		}
+	}
+	for id, row := range s.data.categoryBoards {
+		if boardIDs[row.boardID] {
+			delete(s.data.categoryBoards, id)
+			affected++
+		}
+	}
+	for id, webhook := range s.data.webhooks {
+		if boardIDs[webhook.BoardID] {
+			delete(s.data.webhooks, id)
+			affected++
+		}
+	}
+	for id := range s.data.snapshots {
+		if boardIDs[id] {
+			delete(s.data.snapshots, id)
+			affected++
+		}
+	}
+	for key := range s.data.favorites {
+		if boardIDs[key.boardID] {
+			delete(s.data.favorites, key)
+			affected++
+		}
+	}
+	for id, role := range s.data.customRoles {
+		if boardIDs[role.BoardID] {
+			delete(s.data.customRoles, id)
+			affected++
+		}
+	}
+	for id, shareToken := range s.data.shareTokens {
+		if boardIDs[shareToken.BoardID] {
+			delete(s.data.shareTokens, id)
+			affected++
+		}
+	}
+
+	return affected
+}
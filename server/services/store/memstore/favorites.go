@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func (s *MemStore) setBoardFavorite(userID, boardID string, favorite bool) error {
+	key := memberKey{boardID: boardID, userID: userID}
+	if !favorite {
+		delete(s.data.favorites, key)
+		return nil
+	}
+
+	board, err := s.getBoard(boardID)
+	if model.IsErrNotFound(err) {
+		return model.NewErrPermission("access denied to board")
+	}
+	if err != nil {
+		return err
+	}
+
+	if board.Type != model.BoardTypeOpen && !s.isMember(boardID, userID) {
+		return model.NewErrPermission("access denied to board")
+	}
+
+	if _, ok := s.data.favorites[key]; !ok {
+		s.data.favorites[key] = utils.GetMillis()
+	}
+	return nil
+}
+
+func (s *MemStore) getFavoriteBoards(userID, teamID string) ([]*model.Board, error) {
+	rows := s.boardRows(func(row *boardRow) bool {
+		_, ok := s.data.favorites[memberKey{boardID: row.ID, userID: userID}]
+		return ok && row.TeamID == teamID && row.DeleteAt == 0
+	})
+	sort.SliceStable(rows, func(i, j int) bool {
+		a := s.data.favorites[memberKey{boardID: rows[i].ID, userID: userID}]
+		b := s.data.favorites[memberKey{boardID: rows[j].ID, userID: userID}]
+		if a != b {
+			return a > b
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	return boardsFromRows(rows), nil
+}
+
+func (s *MemStore) markFavoriteBoards(userID, teamID string, boards []*model.Board) error {
+	favorites, err := s.getFavoriteBoards(userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	favoriteIDs := make(map[string]bool, len(favorites))
+	for _, board := range favorites {
+		favoriteIDs[board.ID] = true
+	}
+
+	for _, board := range boards {
+		board.Favorite = favoriteIDs[board.ID]
+	}
+	return nil
+}
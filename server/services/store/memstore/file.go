@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+func fileInfoFromRow(row *fileInfoRow) *mmModel.FileInfo {
+	return &mmModel.FileInfo{
+		Id:        row.id,
+		CreateAt:  row.createAt,
+		DeleteAt:  row.deleteAt,
+		Name:      row.name,
+		Extension: row.extension,
+		Size:      row.size,
+	}
+}
+
+func (s *MemStore) saveFileInfo(fileInfo *mmModel.FileInfo) error {
+	s.data.fileInfos[fileInfo.Id] = &fileInfoRow{
+		id:        fileInfo.Id,
+		createAt:  fileInfo.CreateAt,
+		name:      fileInfo.Name,
+		extension: fileInfo.Extension,
+		size:      fileInfo.Size,
+		deleteAt:  fileInfo.DeleteAt,
+	}
+	return nil
+}
+
+func (s *MemStore) getFileInfo(id string) (*mmModel.FileInfo, error) {
+	row, ok := s.data.fileInfos[id]
+	if !ok || row.deleteAt != 0 {
+		return nil, model.NewErrNotFound("file info ID=" + id)
+	}
+
+	return fileInfoFromRow(row), nil
+}
+
+func fileInfoIDFromBlock(block *model.Block) string {
+	fileName, ok := block.Fields["fileId"].(string)
+	if !ok || len(fileName) < 2 {
+		return ""
+	}
+
+	return strings.Split(fileName, ".")[0][1:]
+}
+
+func fileInfoIDsFromBlocks(blocks []*model.Block) []string {
+	ids := []string{}
+	for _, block := range blocks {
+		if id := fileInfoIDFromBlock(block); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (s *MemStore) getFileInfosForBoard(boardID string) ([]*mmModel.FileInfo, error) {
+	blocks, err := s.getBlocks(model.QueryBlocksOptions{BoardID: boardID, IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []*fileInfoRow{}
+	for _, id := range uniqueStrings(fileInfoIDsFromBlocks(blocks)) {
+		if row, ok := s.data.fileInfos[id]; ok && row.deleteAt == 0 {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].createAt != rows[j].createAt {
+			return rows[i].createAt < rows[j].createAt
+		}
+		return rows[i].id < rows[j].id
+	})
+
+	fileInfos := make([]*mmModel.FileInfo, 0, len(rows))
+	for _, row := range rows {
+		fileInfos = append(fileInfos, fileInfoFromRow(row))
+	}
+	return fileInfos, nil
+}
+
+// setFileInfosDeleteAt marks the file infos as deleted, or restores them
+// if deleteAt is zero. Deleting a file info keeps its original deletion
+// time.
+func (s *MemStore) setFileInfosDeleteAt(ids []string, deleteAt int64) {
+	for _, id := range ids {
+		row, ok := s.data.fileInfos[id]
+		if !ok || (deleteAt != 0 && row.deleteAt != 0) {
+			continue
+		}
+
+		updated := *row
+		updated.deleteAt = deleteAt
+		s.data.fileInfos[id] = &updated
+	}
+}
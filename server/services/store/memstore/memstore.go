@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var _ store.Store = (*MemStore)(nil)
+
+var errUnsupportedOperation = errors.New("unsupported operation")
+
+// completedDataMigrations are the data migrations that the SQL store
+// records on a new database. A memory store never holds legacy data, so
+// it starts with them marked as complete.
+var completedDataMigrations = []string{
+	"UniqueIDsMigrationComplete",
+	"CategoryUuidIdMigrationComplete",
+}
+
+// MemStore is an in-memory implementation of the store, meant for tests
+// and demos. It follows the behavior of the SQL store on SQLite, and its
+// data is lost when the process exits.
+type MemStore struct {
+	mutex  sync.Mutex
+	data   *data
+	logger mlog.LoggerIFace
+
+	// boardBlockLimit is the maximum number of blocks of a board
+	boardBlockLimit int64
+}
+
+// New creates an empty in-memory store.
+func New(logger mlog.LoggerIFace) *MemStore {
+	d := newData()
+	for _, key := range completedDataMigrations {
+		d.system[key] = "true"
+	}
+
+	return &MemStore{
+		data:   d,
+		logger: logger,
+	}
+}
+
+// Shutdown is a no-op, as the store has no connection to close.
+func (s *MemStore) Shutdown() error {
+	return nil
+}
+
+// DBType returns model.MemoryDBType.
+func (s *MemStore) DBType() string {
+	return model.MemoryDBType
+}
+
+// SetBoardBlockLimit sets the maximum number of blocks of a board. A
+// limit of zero means unlimited.
+func (s *MemStore) SetBoardBlockLimit(limit int) {
+	atomic.StoreInt64(&s.boardBlockLimit, int64(limit))
+}
+
+func (s *MemStore) getLicense() *mmModel.License {
+	return nil
+}
+
+func (s *MemStore) getCloudLimits() (*mmModel.ProductLimits, error) {
+	return nil, nil
+}
+
+func (s *MemStore) searchUserChannels(teamID, userID, query string) ([]*mmModel.Channel, error) {
+	return nil, store.NewNotSupportedError("search user channels not supported on standalone mode")
+}
+
+func (s *MemStore) getChannel(teamID, channel string) (*mmModel.Channel, error) {
+	return nil, store.NewNotSupportedError("get channel not supported on standalone mode")
+}
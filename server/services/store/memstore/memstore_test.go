@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/store/storetests"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func SetupTests(t *testing.T) (store.Store, func()) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	store := New(logger)
+
+	tearDown := func() {
+		defer func() { _ = logger.Shutdown() }()
+		require.NoError(t, store.Shutdown())
+	}
+
+	return store, tearDown
+}
+
+func TestMemStore(t *testing.T) {
+	t.Run("BlocksStore", func(t *testing.T) { storetests.StoreTestBlocksStore(t, SetupTests) })
+	t.Run("SharingStore", func(t *testing.T) { storetests.StoreTestSharingStore(t, SetupTests) })
+	t.Run("SystemStore", func(t *testing.T) { storetests.StoreTestSystemStore(t, SetupTests) })
+	t.Run("UserStore", func(t *testing.T) { storetests.StoreTestUserStore(t, SetupTests) })
+	t.Run("SessionStore", func(t *testing.T) { storetests.StoreTestSessionStore(t, SetupTests) })
+	t.Run("TeamStore", func(t *testing.T) { storetests.StoreTestTeamStore(t, SetupTests) })
+	t.Run("BoardStore", func(t *testing.T) { storetests.StoreTestBoardStore(t, SetupTests) })
+	t.Run("BoardsAndBlocksStore", func(t *testing.T) { storetests.StoreTestBoardsAndBlocksStore(t, SetupTests) })
+	t.Run("SubscriptionStore", func(t *testing.T) { storetests.StoreTestSubscriptionsStore(t, SetupTests) })
+	t.Run("NotificationHintStore", func(t *testing.T) { storetests.StoreTestNotificationHintsStore(t, SetupTests) })
+	t.Run("DataRetention", func(t *testing.T) { storetests.StoreTestDataRetention(t, SetupTests) })
+	t.Run("CloudStore", func(t *testing.T) { storetests.StoreTestCloudStore(t, SetupTests) })
+	t.Run("StoreTestFileStore", func(t *testing.T) { storetests.StoreTestFileStore(t, SetupTests) })
+	t.Run("StoreTestCategoryStore", func(t *testing.T) { storetests.StoreTestCategoryStore(t, SetupTests) })
+	t.Run("StoreTestCategoryBoardsStore", func(t *testing.T) { storetests.StoreTestCategoryBoardsStore(t, SetupTests) })
+	t.Run("BoardsInsightsStore", func(t *testing.T) { storetests.StoreTestBoardsInsightsStore(t, SetupTests) })
+	t.Run("TemplatesStore", func(t *testing.T) { storetests.StoreTestTemplatesStore(t, SetupTests) })
+	t.Run("WebhooksStore", func(t *testing.T) { storetests.StoreTestWebhooksStore(t, SetupTests) })
+	t.Run("WebhookDeliveriesStore", func(t *testing.T) { storetests.StoreTestWebhookDeliveriesStore(t, SetupTests) })
+	t.Run("BoardCustomRolesStore", func(t *testing.T) { storetests.StoreTestBoardCustomRolesStore(t, SetupTests) })
+	t.Run("AuditStore", func(t *testing.T) { storetests.StoreTestAuditStore(t, SetupTests) })
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const notificationHintClaimLease = 5 * time.Minute
+
+// notificationHintRows returns the hints that match the filter, sorted
+// by the less function and then by block ID.
+func (s *MemStore) notificationHintRows(filter func(*model.NotificationHint) bool, less func(a, b *model.NotificationHint) bool) []*model.NotificationHint {
+	rows := []*model.NotificationHint{}
+	for _, hint := range s.data.notificationHints {
+		if filter(hint) {
+			rows = append(rows, hint)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if less(rows[i], rows[j]) {
+			return true
+		}
+		if less(rows[j], rows[i]) {
+			return false
+		}
+		return rows[i].BlockID < rows[j].BlockID
+	})
+	return rows
+}
+
+func copyNotificationHint(hint *model.NotificationHint) *model.NotificationHint {
+	c := *hint
+	return &c
+}
+
+func (s *MemStore) upsertNotificationHint(hint *model.NotificationHint, notifyFreq time.Duration) (*model.NotificationHint, error) {
+	if err := hint.IsValid(); err != nil {
+		return nil, err
+	}
+
+	hint.CreateAt = utils.GetMillis()
+
+	notifyAt := utils.GetMillisForTime(time.Now().Add(notifyFreq))
+	hint.NotifyAt = notifyAt
+
+	stored := copyNotificationHint(hint)
+	if existing, ok := s.data.notificationHints[hint.BlockID]; ok {
+		stored = copyNotificationHint(existing)
+		stored.NotifyAt = notifyAt
+	}
+	s.data.notificationHints[hint.BlockID] = stored
+
+	return hint, nil
+}
+
+func (s *MemStore) deleteNotificationHint(blockID string) error {
+	if _, ok := s.data.notificationHints[blockID]; !ok {
+		return model.NewErrNotFound("notification hint BlockID=" + blockID)
+	}
+
+	delete(s.data.notificationHints, blockID)
+	return nil
+}
+
+func (s *MemStore) deleteNotificationHintsForBlock(blockID string) {
+	delete(s.data.notificationHints, blockID)
+}
+
+func (s *MemStore) getNotificationHint(blockID string) (*model.NotificationHint, error) {
+	hint, ok := s.data.notificationHints[blockID]
+	if !ok {
+		return nil, model.NewErrNotFound("notification hint BlockID=" + blockID)
+	}
+	return copyNotificationHint(hint), nil
+}
+
+func (s *MemStore) getNextNotificationHint(remove bool) (*model.NotificationHint, error) {
+	hints := s.notificationHintRows(
+		func(*model.NotificationHint) bool { return true },
+		func(a, b *model.NotificationHint) bool { return a.NotifyAt < b.NotifyAt },
+	)
+	if len(hints) == 0 {
+		return nil, model.NewErrNotFound("next notification hint")
+	}
+
+	hint := hints[0]
+	if remove {
+		delete(s.data.notificationHints, hint.BlockID)
+	}
+
+	return copyNotificationHint(hint), nil
+}
+
+func (s *MemStore) getDueNotificationHints(now int64, limit int) ([]*model.NotificationHint, error) {
+	hints := s.notificationHintRows(
+		func(hint *model.NotificationHint) bool { return hint.NotifyAt <= now },
+		func(a, b *model.NotificationHint) bool { return a.NotifyAt < b.NotifyAt },
+	)
+	_, end := page(len(hints), 0, limit)
+
+	claimed := make([]*model.NotificationHint, 0, end)
+	for _, hint := range hints[:end] {
+		delete(s.data.notificationHints, hint.BlockID)
+		claimed = append(claimed, copyNotificationHint(hint))
+	}
+
+	return claimed, nil
+}
+
+func (s *MemStore) getNotificationHints(limit int) ([]*model.NotificationHint, error) {
+	now := utils.GetMillis()
+
+	hints := s.notificationHintRows(
+		func(hint *model.NotificationHint) bool { return hint.NotifyAt <= now },
+		func(a, b *model.NotificationHint) bool { return a.CreateAt < b.CreateAt },
+	)
+	_, end := page(len(hints), 0, limit)
+
+	leaseUntil := utils.GetMillisForTime(time.Now().Add(notificationHintClaimLease))
+
+	claimed := make([]*model.NotificationHint, 0, end)
+	for _, hint := range hints[:end] {
+		leased := copyNotificationHint(hint)
+		leased.NotifyAt = leaseUntil
+		s.data.notificationHints[hint.BlockID] = leased
+		claimed = append(claimed, copyNotificationHint(hint))
+	}
+
+	return claimed, nil
+}
+
+func (s *MemStore) deleteNotificationHints(blockIDs []string) error {
+	for _, blockID := range blockIDs {
+		delete(s.data.notificationHints, blockID)
+	}
+	return nil
+}
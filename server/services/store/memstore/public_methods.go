@@ -0,0 +1,2669 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make generate" from the Store interface
+// DO NOT EDIT
+
+// Every public method holds the lock of the store while it runs its
+// private method, so the private methods can call each other freely.
+// The methods annotated with @withTransaction restore the data as it
+// was before the call if their private method fails. Shutdown, DBType
+// and SetBoardBlockLimit are implemented by hand in memstore.go
+
+package memstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+func (s *MemStore) AddUpdateCategoryBoard(ctx context.Context, userID string, categoryID string, blockID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.addUpdateCategoryBoard(userID, categoryID, blockID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) ArchiveBoard(ctx context.Context, boardID string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.archiveBoard(boardID, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) ArchiveCard(ctx context.Context, cardID string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.archiveCard(cardID, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) CanSeeUser(ctx context.Context, seerID string, seenID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.canSeeUser(seerID, seenID)
+}
+
+func (s *MemStore) CleanUpSessions(ctx context.Context, idleTimeout time.Duration, maxLifetime time.Duration, batchSize int64) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cleanUpSessions(idleTimeout, maxLifetime, batchSize)
+}
+
+func (s *MemStore) ClearCategory(ctx context.Context, userID string, categoryID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.clearCategory(userID, categoryID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) CompareAndSetSystemSetting(ctx context.Context, key string, expectedOld string, newValue string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.compareAndSetSystemSetting(key, expectedOld, newValue)
+}
+
+func (s *MemStore) CopyBlocks(ctx context.Context, blockIDs []string, targetBoardID string, userID string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.copyBlocks(blockIDs, targetBoardID, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) CountBoardsCreatedBetween(ctx context.Context, teamID string, start int64, end int64) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.countBoardsCreatedBetween(teamID, start, end)
+}
+
+func (s *MemStore) CountBoardsCreatedBetweenAllTeams(ctx context.Context, start int64, end int64) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.countBoardsCreatedBetweenAllTeams(start, end)
+}
+
+func (s *MemStore) CountCardsByPropertyGrouped(ctx context.Context, boardID string, propertyID string) (map[string]int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.countCardsByPropertyGrouped(boardID, propertyID)
+}
+
+func (s *MemStore) CreateBoardComplete(ctx context.Context, board *model.Board, creatorID string, extraMembers []*model.BoardMember, categoryID string) (*model.Board, []*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, resultVar1, err := s.createBoardComplete(board, creatorID, extraMembers, categoryID)
+	if err != nil {
+		s.data = snapshot
+		return nil, nil, err
+	}
+	return result, resultVar1, nil
+}
+
+func (s *MemStore) CreateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.createBoardCustomRole(role)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) CreateBoardsAndBlocks(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.createBoardsAndBlocks(bab, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) CreateBoardsAndBlocksWithAdmin(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, resultVar1, err := s.createBoardsAndBlocksWithAdmin(bab, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, nil, err
+	}
+	return result, resultVar1, nil
+}
+
+func (s *MemStore) CreateCategory(ctx context.Context, category model.Category) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.createCategory(category)
+}
+
+func (s *MemStore) CreateDefaultCategoriesForUser(ctx context.Context, userID string, teamID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.createDefaultCategoriesForUser(userID, teamID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) CreateSession(ctx context.Context, session *model.Session) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.createSession(session)
+}
+
+func (s *MemStore) CreateShareToken(ctx context.Context, shareToken *model.ShareToken) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.createShareToken(shareToken)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) CreateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.createSubscription(sub)
+}
+
+func (s *MemStore) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.createUser(user)
+}
+
+func (s *MemStore) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.createWebhook(webhook)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) DeactivateUser(ctx context.Context, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.deactivateUser(userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) DeleteBlock(ctx context.Context, blockID string, modifiedBy string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.deleteBlock(blockID, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return 0, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) DeleteBoard(ctx context.Context, boardID string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.deleteBoard(boardID, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) DeleteBoardCustomRole(ctx context.Context, id string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.deleteBoardCustomRole(id, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) DeleteBoardsAndBlocks(ctx context.Context, dbab *model.DeleteBoardsAndBlocks, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.deleteBoardsAndBlocks(dbab, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) DeleteCategory(ctx context.Context, categoryID string, userID string, teamID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.deleteCategory(categoryID, userID, teamID)
+}
+
+func (s *MemStore) DeleteMember(ctx context.Context, boardID string, userID string, modifiedBy string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.deleteMember(boardID, userID, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return 0, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) DeleteMembers(ctx context.Context, boardID string, userIDs []string, modifiedBy string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.deleteMembers(boardID, userIDs, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return 0, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) DeleteNotificationHint(ctx context.Context, blockID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.deleteNotificationHint(blockID)
+}
+
+func (s *MemStore) DeleteNotificationHints(ctx context.Context, blockIDs []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.deleteNotificationHints(blockIDs)
+}
+
+func (s *MemStore) DeleteSession(ctx context.Context, sessionID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.deleteSession(sessionID)
+}
+
+func (s *MemStore) DeleteShareToken(ctx context.Context, boardID string, id string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.deleteShareToken(boardID, id, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) DeleteSubscription(ctx context.Context, blockID string, subscriberID string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.deleteSubscription(blockID, subscriberID)
+}
+
+func (s *MemStore) DeleteSubscriptionsForBlock(ctx context.Context, blockID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.deleteSubscriptionsForBlock(blockID)
+}
+
+func (s *MemStore) DeleteTeam(ctx context.Context, teamID string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.deleteTeam(teamID)
+	if err != nil {
+		s.data = snapshot
+		return 0, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) DeleteWebhook(ctx context.Context, id string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.deleteWebhook(id, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) DuplicateBlock(ctx context.Context, boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.duplicateBlock(boardID, blockID, userID, asTemplate)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) DuplicateBoard(ctx context.Context, boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, resultVar1, err := s.duplicateBoard(boardID, userID, toTeam, asTemplate)
+	if err != nil {
+		s.data = snapshot
+		return nil, nil, err
+	}
+	return result, resultVar1, nil
+}
+
+func (s *MemStore) DuplicateBoardWithOptions(ctx context.Context, boardID string, userID string, opts model.DuplicateBoardOptions) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, resultVar1, err := s.duplicateBoardWithOptions(boardID, userID, opts)
+	if err != nil {
+		s.data = snapshot
+		return nil, nil, err
+	}
+	return result, resultVar1, nil
+}
+
+func (s *MemStore) FindDuplicateCategories(ctx context.Context, userID string, teamID string) (map[string][]model.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.findDuplicateCategories(userID, teamID)
+}
+
+func (s *MemStore) GetActiveUserCount(ctx context.Context, updatedSecondsAgo int64) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getActiveUserCount(updatedSecondsAgo)
+}
+
+func (s *MemStore) GetActiveUserCountByTeam(ctx context.Context, teamID string, since int64) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getActiveUserCountByTeam(teamID, since)
+}
+
+func (s *MemStore) GetActiveUserCountsByDay(ctx context.Context, teamID string, from int64, to int64) (map[int64]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getActiveUserCountsByDay(teamID, from, to)
+}
+
+func (s *MemStore) GetAllCategoriesForTeam(ctx context.Context, teamID string) ([]model.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getAllCategoriesForTeam(teamID)
+}
+
+func (s *MemStore) GetAllTeams(ctx context.Context) ([]*model.Team, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getAllTeams()
+}
+
+func (s *MemStore) GetArchivedBoards(ctx context.Context, teamID string) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getArchivedBoards(teamID)
+}
+
+func (s *MemStore) GetArchivedCards(ctx context.Context, boardID string) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getArchivedCards(boardID)
+}
+
+func (s *MemStore) GetAuditRecords(ctx context.Context, boardID string, opts model.QueryAuditOptions) ([]*model.AuditRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getAuditRecords(boardID, opts)
+}
+
+func (s *MemStore) GetBlock(ctx context.Context, blockID string) (*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlock(blockID)
+}
+
+func (s *MemStore) GetBlockCountForTeam(ctx context.Context, teamID string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlockCountForTeam(teamID)
+}
+
+func (s *MemStore) GetBlockCountsByType(ctx context.Context) (map[string]int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlockCountsByType()
+}
+
+func (s *MemStore) GetBlockCountsByTypeForBoard(ctx context.Context, boardID string) (map[string]int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlockCountsByTypeForBoard(boardID)
+}
+
+func (s *MemStore) GetBlockCountsForTeams(ctx context.Context, teamIDs []string) (map[string]int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlockCountsForTeams(teamIDs)
+}
+
+func (s *MemStore) GetBlockHistory(ctx context.Context, blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlockHistory(blockID, opts)
+}
+
+func (s *MemStore) GetBlockHistoryDescendants(ctx context.Context, boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlockHistoryDescendants(boardID, opts)
+}
+
+func (s *MemStore) GetBlockHistoryEntry(ctx context.Context, blockID string, version int64) (*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlockHistoryEntry(blockID, version)
+}
+
+func (s *MemStore) GetBlocks(ctx context.Context, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocks(opts)
+}
+
+func (s *MemStore) GetBlocksByIDs(ctx context.Context, ids []string) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocksByIDs(ids)
+}
+
+func (s *MemStore) GetBlocksForBoard(ctx context.Context, boardID string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocksForBoard(boardID, opts)
+}
+
+func (s *MemStore) GetBlocksForBoardStream(ctx context.Context, boardID string, fn func(model.Block) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocksForBoardStream(boardID, fn)
+}
+
+func (s *MemStore) GetBlocksForBoards(ctx context.Context, boardIDs []string, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocksForBoards(boardIDs, opts)
+}
+
+func (s *MemStore) GetBlocksMap(ctx context.Context, boardID string, ids []string) (map[string]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocksMap(boardID, ids)
+}
+
+func (s *MemStore) GetBlocksWithParent(ctx context.Context, boardID string, parentID string) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocksWithParent(boardID, parentID)
+}
+
+func (s *MemStore) GetBlocksWithParentAndType(ctx context.Context, boardID string, parentID string, blockType string) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocksWithParentAndType(boardID, parentID, blockType)
+}
+
+func (s *MemStore) GetBlocksWithParentAndTypes(ctx context.Context, boardID string, parentID string, blockTypes []string) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocksWithParentAndTypes(boardID, parentID, blockTypes)
+}
+
+func (s *MemStore) GetBlocksWithType(ctx context.Context, boardID string, blockType string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBlocksWithType(boardID, blockType, opts)
+}
+
+func (s *MemStore) GetBoard(ctx context.Context, id string) (*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoard(id)
+}
+
+func (s *MemStore) GetBoardAndCard(ctx context.Context, block *model.Block) (*model.Board, *model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardAndCard(block)
+}
+
+func (s *MemStore) GetBoardAndCardByID(ctx context.Context, blockID string) (*model.Board, *model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardAndCardByID(blockID)
+}
+
+func (s *MemStore) GetBoardCardProperties(ctx context.Context, boardID string) ([]model.CardProperty, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardCardProperties(boardID)
+}
+
+func (s *MemStore) GetBoardCount(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardCount()
+}
+
+func (s *MemStore) GetBoardCustomRole(ctx context.Context, id string) (*model.BoardCustomRole, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardCustomRole(id)
+}
+
+func (s *MemStore) GetBoardCustomRoles(ctx context.Context, boardID string) ([]*model.BoardCustomRole, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardCustomRoles(boardID)
+}
+
+func (s *MemStore) GetBoardHistory(ctx context.Context, boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardHistory(boardID, opts)
+}
+
+func (s *MemStore) GetBoardMemberCount(ctx context.Context, boardID string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardMemberCount(boardID)
+}
+
+func (s *MemStore) GetBoardMemberHistory(ctx context.Context, boardID string, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardMemberHistory(boardID, userID, limit)
+}
+
+func (s *MemStore) GetBoardSnapshot(ctx context.Context, boardID string) (*model.BoardSnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardSnapshot(boardID)
+}
+
+func (s *MemStore) GetBoardStats(ctx context.Context, boardID string) (*model.BoardStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardStats(boardID)
+}
+
+func (s *MemStore) GetBoardWithStats(ctx context.Context, boardID string, userID string) (*model.BoardWithStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardWithStats(boardID, userID)
+}
+
+func (s *MemStore) GetBoards(ctx context.Context, ids []string) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoards(ids)
+}
+
+func (s *MemStore) GetBoardsAdministeredByUser(ctx context.Context, userID string, teamID string) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardsAdministeredByUser(userID, teamID)
+}
+
+func (s *MemStore) GetBoardsCreatedFromTemplate(ctx context.Context, templateID string) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardsCreatedFromTemplate(templateID)
+}
+
+func (s *MemStore) GetBoardsForUserAndTeam(ctx context.Context, userID string, teamID string, includePublicBoards bool) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardsForUserAndTeam(userID, teamID, includePublicBoards)
+}
+
+func (s *MemStore) GetBoardsForUserAndTeamWithOptions(ctx context.Context, userID string, teamID string, opts model.QueryBoardsOptions) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardsForUserAndTeamWithOptions(userID, teamID, opts)
+}
+
+func (s *MemStore) GetBoardsInTeamByIds(ctx context.Context, boardIDs []string, teamID string) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardsInTeamByIds(boardIDs, teamID)
+}
+
+func (s *MemStore) GetBoardsModifiedSince(ctx context.Context, teamID string, userID string, since int64) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getBoardsModifiedSince(teamID, userID, since)
+}
+
+func (s *MemStore) GetCardLimitTimestamp(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getCardLimitTimestamp()
+}
+
+func (s *MemStore) GetCardsMissingProperty(ctx context.Context, boardID string, propertyID string) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getCardsMissingProperty(boardID, propertyID)
+}
+
+func (s *MemStore) GetCategory(ctx context.Context, id string) (*model.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getCategory(id)
+}
+
+func (s *MemStore) GetCategoryForBoard(ctx context.Context, userID string, teamID string, boardID string) (*model.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getCategoryForBoard(userID, teamID, boardID)
+}
+
+func (s *MemStore) GetChannel(ctx context.Context, teamID string, channelID string) (*mmModel.Channel, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getChannel(teamID, channelID)
+}
+
+func (s *MemStore) GetCloudLimits(ctx context.Context) (*mmModel.ProductLimits, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getCloudLimits()
+}
+
+func (s *MemStore) GetDefaultCategoryTemplates(ctx context.Context, teamID string) ([]model.CategoryTemplate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getDefaultCategoryTemplates(teamID)
+}
+
+func (s *MemStore) GetDeletedBlocksForBoard(ctx context.Context, boardID string) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getDeletedBlocksForBoard(boardID)
+}
+
+func (s *MemStore) GetDueNotificationHints(ctx context.Context, now int64, limit int) ([]*model.NotificationHint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getDueNotificationHints(now, limit)
+}
+
+func (s *MemStore) GetFavoriteBoards(ctx context.Context, userID string, teamID string) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getFavoriteBoards(userID, teamID)
+}
+
+func (s *MemStore) GetFileInfo(ctx context.Context, id string) (*mmModel.FileInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getFileInfo(id)
+}
+
+func (s *MemStore) GetFileInfosForBoard(ctx context.Context, boardID string) ([]*mmModel.FileInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getFileInfosForBoard(boardID)
+}
+
+func (s *MemStore) GetLastModifiedBlockForBoard(ctx context.Context, boardID string) (*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getLastModifiedBlockForBoard(boardID)
+}
+
+func (s *MemStore) GetLicense(ctx context.Context) *mmModel.License {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getLicense()
+}
+
+func (s *MemStore) GetMemberForBoard(ctx context.Context, boardID string, userID string) (*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getMemberForBoard(boardID, userID)
+}
+
+func (s *MemStore) GetMembersForBoard(ctx context.Context, boardID string) ([]*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getMembersForBoard(boardID)
+}
+
+func (s *MemStore) GetMembersForBoardWithOptions(ctx context.Context, boardID string, opts model.QueryBoardMembersOptions) ([]*model.BoardMember, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getMembersForBoardWithOptions(boardID, opts)
+}
+
+func (s *MemStore) GetMembersForUser(ctx context.Context, userID string) ([]*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getMembersForUser(userID)
+}
+
+func (s *MemStore) GetNextNotificationHint(ctx context.Context, remove bool) (*model.NotificationHint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getNextNotificationHint(remove)
+}
+
+func (s *MemStore) GetNotificationHint(ctx context.Context, blockID string) (*model.NotificationHint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getNotificationHint(blockID)
+}
+
+func (s *MemStore) GetNotificationHints(ctx context.Context, limit int) ([]*model.NotificationHint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.getNotificationHints(limit)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) GetPendingWebhookDeliveries(ctx context.Context, limit int) ([]*model.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.getPendingWebhookDeliveries(limit)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) GetRecentComments(ctx context.Context, boardID string, limit int) ([]*model.CommentWithAuthor, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getRecentComments(boardID, limit)
+}
+
+func (s *MemStore) GetRecentlyViewedBoards(ctx context.Context, userID string, teamID string, limit int) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getRecentlyViewedBoards(userID, teamID, limit)
+}
+
+func (s *MemStore) GetRegisteredUserCount(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getRegisteredUserCount()
+}
+
+func (s *MemStore) GetSession(ctx context.Context, token string, expireTime int64) (*model.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSession(token, expireTime)
+}
+
+func (s *MemStore) GetSessionWithPolicy(ctx context.Context, token string, now int64, idleTimeout time.Duration, maxLifetime time.Duration) (*model.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSessionWithPolicy(token, now, idleTimeout, maxLifetime)
+}
+
+func (s *MemStore) GetSessionWithUser(ctx context.Context, token string, now int64, idleTimeout time.Duration, maxLifetime time.Duration) (*model.Session, *model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSessionWithUser(token, now, idleTimeout, maxLifetime)
+}
+
+func (s *MemStore) GetShareToken(ctx context.Context, token string) (*model.ShareToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getShareToken(token)
+}
+
+func (s *MemStore) GetShareTokensForBoard(ctx context.Context, boardID string) ([]*model.ShareToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getShareTokensForBoard(boardID)
+}
+
+func (s *MemStore) GetSharing(ctx context.Context, rootID string) (*model.Sharing, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSharing(rootID)
+}
+
+func (s *MemStore) GetSharingForBoards(ctx context.Context, rootIDs []string) (map[string]*model.Sharing, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSharingForBoards(rootIDs)
+}
+
+func (s *MemStore) GetSubTree(ctx context.Context, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSubTree(boardID, blockID, opts)
+}
+
+func (s *MemStore) GetSubTree2(ctx context.Context, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSubTree2(boardID, blockID, opts)
+}
+
+func (s *MemStore) GetSubscribersCountForBlock(ctx context.Context, blockID string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSubscribersCountForBlock(blockID)
+}
+
+func (s *MemStore) GetSubscribersForBlock(ctx context.Context, blockID string, events model.SubscriptionEvents) ([]*model.Subscriber, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSubscribersForBlock(blockID, events)
+}
+
+func (s *MemStore) GetSubscription(ctx context.Context, blockID string, subscriberID string) (*model.Subscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSubscription(blockID, subscriberID)
+}
+
+func (s *MemStore) GetSubscriptions(ctx context.Context, subscriberID string) ([]*model.Subscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSubscriptions(subscriberID)
+}
+
+func (s *MemStore) GetSystemSetting(ctx context.Context, key string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSystemSetting(key)
+}
+
+func (s *MemStore) GetSystemSettingBool(ctx context.Context, key string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSystemSettingBool(key)
+}
+
+func (s *MemStore) GetSystemSettingInt(ctx context.Context, key string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSystemSettingInt(key)
+}
+
+func (s *MemStore) GetSystemSettings(ctx context.Context) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getSystemSettings()
+}
+
+func (s *MemStore) GetTeam(ctx context.Context, ID string) (*model.Team, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getTeam(ID)
+}
+
+func (s *MemStore) GetTeamBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getTeamBoardsInsights(teamID, userID, since, offset, limit, boardIDs)
+}
+
+func (s *MemStore) GetTeamBySignupToken(ctx context.Context, token string) (*model.Team, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getTeamBySignupToken(token)
+}
+
+func (s *MemStore) GetTeamCount(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getTeamCount()
+}
+
+func (s *MemStore) GetTeamsForUser(ctx context.Context, userID string) ([]*model.Team, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getTeamsForUser(userID)
+}
+
+func (s *MemStore) GetTeamsForUserWithBoardCounts(ctx context.Context, userID string) ([]model.TeamWithCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getTeamsForUserWithBoardCounts(userID)
+}
+
+func (s *MemStore) GetTemplateBoards(ctx context.Context, teamID string, userID string) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getTemplateBoards(teamID, userID)
+}
+
+func (s *MemStore) GetTemplateBoardsByVersion(ctx context.Context, teamID string, version int) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getTemplateBoardsByVersion(teamID, version)
+}
+
+func (s *MemStore) GetUsedCardsCount(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUsedCardsCount()
+}
+
+func (s *MemStore) GetUserBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUserBoardsInsights(teamID, userID, since, offset, limit, boardIDs)
+}
+
+func (s *MemStore) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUserByEmail(email)
+}
+
+func (s *MemStore) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUserByID(userID)
+}
+
+func (s *MemStore) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUserByUsername(username)
+}
+
+func (s *MemStore) GetUserCategoryBoards(ctx context.Context, userID string, teamID string) ([]model.CategoryBoards, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUserCategoryBoards(userID, teamID)
+}
+
+func (s *MemStore) GetUserPreferences(ctx context.Context, userID string) (mmModel.Preferences, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUserPreferences(userID)
+}
+
+func (s *MemStore) GetUserTimezone(ctx context.Context, userID string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUserTimezone(userID)
+}
+
+func (s *MemStore) GetUsersByTeam(ctx context.Context, teamID string, asGuestID string) ([]*model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUsersByTeam(teamID, asGuestID)
+}
+
+func (s *MemStore) GetUsersByTeamWithRole(ctx context.Context, teamID string, role string, includeDeleted bool) ([]*model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUsersByTeamWithRole(teamID, role, includeDeleted)
+}
+
+func (s *MemStore) GetUsersList(ctx context.Context, userIDs []string) ([]*model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getUsersList(userIDs)
+}
+
+func (s *MemStore) GetWebhook(ctx context.Context, id string) (*model.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getWebhook(id)
+}
+
+func (s *MemStore) GetWebhooksForBoard(ctx context.Context, boardID string) ([]*model.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getWebhooksForBoard(boardID)
+}
+
+func (s *MemStore) InsertAuditRecord(ctx context.Context, rec *model.AuditRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.insertAuditRecord(rec)
+}
+
+func (s *MemStore) InsertBlock(ctx context.Context, block *model.Block, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.insertBlock(block, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) InsertBlocks(ctx context.Context, blocks []*model.Block, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.insertBlocks(blocks, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) InsertBoard(ctx context.Context, board *model.Board, userID string) (*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.insertBoard(board, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) InsertBoardWithAdmin(ctx context.Context, board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, resultVar1, err := s.insertBoardWithAdmin(board, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, nil, err
+	}
+	return result, resultVar1, nil
+}
+
+func (s *MemStore) MarkWebhookDelivered(ctx context.Context, id string, status int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.markWebhookDelivered(id, status)
+}
+
+func (s *MemStore) MergeBoards(ctx context.Context, sourceBoardID string, targetBoardID string, userID string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.mergeBoards(sourceBoardID, targetBoardID, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) MergeCategories(ctx context.Context, userID string, primaryCategoryID string, mergeCategoryIDs []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.mergeCategories(userID, primaryCategoryID, mergeCategoryIDs)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) MoveBlocks(ctx context.Context, blockIDs []string, targetBoardID string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.moveBlocks(blockIDs, targetBoardID, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) NormalizeContentOrder(ctx context.Context, cardID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.normalizeContentOrder(cardID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) PatchBlock(ctx context.Context, blockID string, blockPatch *model.BlockPatch, userID string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.patchBlock(blockID, blockPatch, userID)
+	if err != nil {
+		s.data = snapshot
+		return 0, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) PatchBlocks(ctx context.Context, blockPatches *model.BlockPatchBatch, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.patchBlocks(blockPatches, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) PatchBoard(ctx context.Context, boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.patchBoard(boardID, boardPatch, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) PatchBoardsAndBlocks(ctx context.Context, pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.patchBoardsAndBlocks(pbab, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) PatchUserPreferences(ctx context.Context, userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.patchUserPreferences(userID, patch)
+}
+
+func (s *MemStore) PostMessage(ctx context.Context, message string, postType string, channelID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.postMessage(message, postType, channelID)
+}
+
+func (s *MemStore) PurgeArchivedBoards(ctx context.Context, olderThan time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.purgeArchivedBoards(olderThan)
+	if err != nil {
+		s.data = snapshot
+		return 0, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) PurgeDeletedBlocks(ctx context.Context, olderThan time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.purgeDeletedBlocks(olderThan)
+	if err != nil {
+		s.data = snapshot
+		return 0, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) ReactivateUser(ctx context.Context, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.reactivateUser(userID)
+}
+
+func (s *MemStore) RecordWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.recordWebhookDelivery(delivery)
+}
+
+func (s *MemStore) RefreshSession(ctx context.Context, session *model.Session) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.refreshSession(session)
+}
+
+func (s *MemStore) ReinstallDefaultTemplates(ctx context.Context, teamID string, templates []*model.Board, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.reinstallDefaultTemplates(teamID, templates, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) RemoveCategoryBoards(ctx context.Context, userID string, categoryID string, boardIDs []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.removeCategoryBoards(userID, categoryID, boardIDs)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) RemoveDefaultTemplates(ctx context.Context, boards []*model.Board) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.removeDefaultTemplates(boards)
+}
+
+func (s *MemStore) ReorderCategories(ctx context.Context, userID string, teamID string, categoryIDs []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.reorderCategories(userID, teamID, categoryIDs)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) ReorderCategoryBoards(ctx context.Context, userID string, categoryID string, boardIDs []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.reorderCategoryBoards(userID, categoryID, boardIDs)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) RestoreBlock(ctx context.Context, blockID string, userID string) (*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.restoreBlock(blockID, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) RestoreBoard(ctx context.Context, boardID string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.restoreBoard(boardID, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) RotateSharingToken(ctx context.Context, rootID string, userID string) (*model.Sharing, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.rotateSharingToken(rootID, userID)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) RunDataRetention(ctx context.Context, globalRetentionDate int64, batchSize int64) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.runDataRetention(globalRetentionDate, batchSize)
+	if err != nil {
+		s.data = snapshot
+		return 0, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) SaveFileInfo(ctx context.Context, fileInfo *mmModel.FileInfo) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.saveFileInfo(fileInfo)
+}
+
+func (s *MemStore) SaveMember(ctx context.Context, bm *model.BoardMember, modifiedBy string) (*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.saveMember(bm, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) SaveMemberWithLimit(ctx context.Context, bm *model.BoardMember, maxMembers int, modifiedBy string) (*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.saveMemberWithLimit(bm, maxMembers, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) SaveMembers(ctx context.Context, members []*model.BoardMember, modifiedBy string) ([]*model.BoardMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	result, err := s.saveMembers(members, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MemStore) SearchBlocksForBoard(ctx context.Context, boardID string, term string, fields []string) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.searchBlocksForBoard(boardID, term, fields)
+}
+
+func (s *MemStore) SearchBlocksForUser(ctx context.Context, teamID string, term string, userID string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.searchBlocksForUser(teamID, term, userID, opts)
+}
+
+func (s *MemStore) SearchBlocksInBoards(ctx context.Context, boardIDs []string, term string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.searchBlocksInBoards(boardIDs, term, opts)
+}
+
+func (s *MemStore) SearchBoardsForUser(ctx context.Context, term string, userID string, includePublicBoards bool) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.searchBoardsForUser(term, userID, includePublicBoards)
+}
+
+func (s *MemStore) SearchBoardsForUserInTeam(ctx context.Context, teamID string, term string, userID string) ([]*model.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.searchBoardsForUserInTeam(teamID, term, userID)
+}
+
+func (s *MemStore) SearchUserChannels(ctx context.Context, teamID string, userID string, query string) ([]*mmModel.Channel, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.searchUserChannels(teamID, userID, query)
+}
+
+func (s *MemStore) SearchUsersByTeam(ctx context.Context, teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.searchUsersByTeam(teamID, searchQuery, asGuestID, excludeBots)
+}
+
+func (s *MemStore) SendMessage(ctx context.Context, message string, postType string, receipts []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.sendMessage(message, postType, receipts)
+}
+
+func (s *MemStore) SetBoardFavorite(ctx context.Context, userID string, boardID string, favorite bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.setBoardFavorite(userID, boardID, favorite)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) SetBoardPropertyOrder(ctx context.Context, boardID string, propertyIDs []string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.setBoardPropertyOrder(boardID, propertyIDs, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) SetMemberCustomRole(ctx context.Context, boardID string, userID string, roleID string, modifiedBy string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.setMemberCustomRole(boardID, userID, roleID, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) SetSystemSetting(ctx context.Context, key string, value string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.setSystemSetting(key, value)
+}
+
+func (s *MemStore) UnarchiveCard(ctx context.Context, cardID string, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.unarchiveCard(cardID, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) UndeleteBlock(ctx context.Context, blockID string, modifiedBy string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.undeleteBlock(blockID, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) UndeleteBoard(ctx context.Context, boardID string, modifiedBy string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.undeleteBoard(boardID, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) UpdateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.updateBoardCustomRole(role, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) UpdateCardLimitTimestamp(ctx context.Context, cardLimit int) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateCardLimitTimestamp(cardLimit)
+}
+
+func (s *MemStore) UpdateCategory(ctx context.Context, category model.Category) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateCategory(category)
+}
+
+func (s *MemStore) UpdateMemberLastViewed(ctx context.Context, boardID string, userID string, viewedAt int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateMemberLastViewed(boardID, userID, viewedAt)
+}
+
+func (s *MemStore) UpdateMemberRole(ctx context.Context, boardID string, userID string, role string, modifiedBy string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.updateMemberRole(boardID, userID, role, modifiedBy)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) UpdateSession(ctx context.Context, session *model.Session) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateSession(session)
+}
+
+func (s *MemStore) UpdateSubscriberNotifiedAt(ctx context.Context, blockID string, subscriberID string, notifiedAt int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateSubscriberNotifiedAt(blockID, subscriberID, notifiedAt)
+}
+
+func (s *MemStore) UpdateSubscribersNotifiedAt(ctx context.Context, blockID string, notifiedAt int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateSubscribersNotifiedAt(blockID, notifiedAt)
+}
+
+func (s *MemStore) UpdateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateSubscription(sub)
+}
+
+func (s *MemStore) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateUser(user)
+}
+
+func (s *MemStore) UpdateUserPassword(ctx context.Context, username string, password string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateUserPassword(username, password)
+}
+
+func (s *MemStore) UpdateUserPasswordByID(ctx context.Context, userID string, password string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updateUserPasswordByID(userID, password)
+}
+
+func (s *MemStore) UpdateWebhook(ctx context.Context, webhook *model.Webhook, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.updateWebhook(webhook, userID)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) UpgradeDefaultTemplates(ctx context.Context, teamID string, toVersion int, templates *model.BoardsAndBlocks) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.upgradeDefaultTemplates(teamID, toVersion, templates)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) UpsertBoardSnapshot(ctx context.Context, snapshot *model.BoardSnapshot) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.upsertBoardSnapshot(snapshot)
+}
+
+func (s *MemStore) UpsertDefaultCategoryTemplate(ctx context.Context, template model.CategoryTemplate) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.upsertDefaultCategoryTemplate(template)
+}
+
+func (s *MemStore) UpsertNotificationHint(ctx context.Context, hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.upsertNotificationHint(hint, notificationFreq)
+}
+
+func (s *MemStore) UpsertSharing(ctx context.Context, sharing model.Sharing) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := s.data.clone()
+	err := s.upsertSharing(sharing)
+	if err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemStore) UpsertTeamSettings(ctx context.Context, team model.Team) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.upsertTeamSettings(team)
+}
+
+func (s *MemStore) UpsertTeamSignupToken(ctx context.Context, team model.Team) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.upsertTeamSignupToken(team)
+}
+
+func (s *MemStore) ValidateBoardSchema(ctx context.Context, boardID string) (*model.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.validateBoardSchema(boardID)
+}
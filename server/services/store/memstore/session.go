@@ -0,0 +1,238 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func sessionFromRow(row *sessionRow) *model.Session {
+	session := row.Session
+	session.Props = copyJSONMap(row.Props)
+	return &session
+}
+
+// sessionRows returns the sessions that match the filter, in insertion
+// order.
+func (s *MemStore) sessionRows(filter func(*sessionRow) bool) []*sessionRow {
+	rows := []*sessionRow{}
+	for _, row := range s.data.sessions {
+		if filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+	return rows
+}
+
+func (s *MemStore) isDeactivatedUser(userID string) bool {
+	row, ok := s.data.users[userID]
+	return ok && row.DeleteAt > 0
+}
+
+// countActiveUsers returns the number of distinct users, other than the
+// deactivated ones, with a session that matches the filter.
+func (s *MemStore) countActiveUsers(filter func(*sessionRow) bool) int {
+	userIDs := map[string]bool{}
+	for _, row := range s.data.sessions {
+		if filter(row) && !s.isDeactivatedUser(row.UserID) {
+			userIDs[row.UserID] = true
+		}
+	}
+	return len(userIDs)
+}
+
+func (s *MemStore) getActiveUserCount(updatedSecondsAgo int64) (int, error) {
+	since := utils.GetMillis() - utils.SecondsToMillis(updatedSecondsAgo)
+	return s.countActiveUsers(func(row *sessionRow) bool { return row.UpdateAt > since }), nil
+}
+
+func (s *MemStore) getActiveUserCountByTeam(_ string, since int64) (int, error) {
+	return s.countActiveUsers(func(row *sessionRow) bool { return row.UpdateAt >= since }), nil
+}
+
+func (s *MemStore) getActiveUserCountsByDay(_ string, from, to int64) (map[int64]int, error) {
+	dayStarts := utils.DayStartsInRange(from, to, time.Local)
+	if len(dayStarts) > model.MaxActiveUserCountDays {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("active user counts can't span more than %d days", model.MaxActiveUserCountDays))
+	}
+
+	counts := make(map[int64]int, len(dayStarts))
+	for _, dayStart := range dayStarts {
+		counts[dayStart] = 0
+	}
+	if len(dayStarts) == 0 {
+		return counts, nil
+	}
+
+	activeUsers := map[int64]map[string]bool{}
+	for _, row := range s.data.sessions {
+		if row.UpdateAt < from || row.UpdateAt >= to || s.isDeactivatedUser(row.UserID) {
+			continue
+		}
+
+		// the day of a session is the last day that starts before
+		// its last update
+		i := sort.Search(len(dayStarts), func(i int) bool { return dayStarts[i] > row.UpdateAt }) - 1
+		if i < 0 {
+			continue
+		}
+		if activeUsers[dayStarts[i]] == nil {
+			activeUsers[dayStarts[i]] = map[string]bool{}
+		}
+		activeUsers[dayStarts[i]][row.UserID] = true
+	}
+
+	for day, userIDs := range activeUsers {
+		counts[day] = len(userIDs)
+	}
+
+	return counts, nil
+}
+
+func (s *MemStore) getSession(token string, expireTimeSeconds int64) (*model.Session, error) {
+	since := utils.GetMillis() - utils.SecondsToMillis(expireTimeSeconds)
+	rows := s.sessionRows(func(row *sessionRow) bool {
+		return row.Token == token && row.UpdateAt > since
+	})
+	if len(rows) == 0 {
+		return nil, model.NewErrNotFound("session")
+	}
+
+	// like the SQL store, the timestamps are left out
+	session := sessionFromRow(rows[0])
+	session.CreateAt = 0
+	session.UpdateAt = 0
+	return session, nil
+}
+
+func (s *MemStore) getSessionWithPolicy(token string, now int64, idleTimeout, maxLifetime time.Duration) (*model.Session, error) {
+	rows := s.sessionRows(func(row *sessionRow) bool {
+		if row.Token != token {
+			return false
+		}
+		if idleTimeout > 0 && row.UpdateAt <= now-idleTimeout.Milliseconds() {
+			return false
+		}
+		if maxLifetime > 0 && row.CreateAt <= now-maxLifetime.Milliseconds() {
+			return false
+		}
+		return true
+	})
+	if len(rows) == 0 {
+		return nil, model.NewErrNotFound("session")
+	}
+
+	return sessionFromRow(rows[0]), nil
+}
+
+func (s *MemStore) getSessionWithUser(token string, now int64, idleTimeout, maxLifetime time.Duration) (*model.Session, *model.User, error) {
+	for _, row := range s.sessionRows(func(row *sessionRow) bool {
+		if row.Token != token {
+			return false
+		}
+		if idleTimeout > 0 && row.UpdateAt <= now-idleTimeout.Milliseconds() {
+			return false
+		}
+		if maxLifetime > 0 && row.CreateAt <= now-maxLifetime.Milliseconds() {
+			return false
+		}
+		return true
+	}) {
+		user, ok := s.data.users[row.UserID]
+		if ok && user.DeleteAt == 0 {
+			return sessionFromRow(row), userFromRow(user), nil
+		}
+	}
+
+	return nil, nil, model.NewErrNotFound("session with user")
+}
+
+func (s *MemStore) createSession(session *model.Session) error {
+	if _, ok := s.data.sessions[session.ID]; ok {
+		return model.NewErrDuplicate("session")
+	}
+
+	now := utils.GetMillis()
+	row := &sessionRow{Session: *session, seq: s.data.nextSeq()}
+	row.Props = nil
+	if err := normalizeJSON(session.Props, &row.Props); err != nil {
+		return err
+	}
+	row.CreateAt = now
+	row.UpdateAt = now
+	s.data.sessions[session.ID] = row
+
+	return nil
+}
+
+// updateSessionRows applies a change to the sessions with a token.
+func (s *MemStore) updateSessionRows(token string, fn func(*sessionRow)) {
+	for _, row := range s.sessionRows(func(row *sessionRow) bool { return row.Token == token }) {
+		updated := *row
+		fn(&updated)
+		s.data.sessions[row.ID] = &updated
+	}
+}
+
+func (s *MemStore) refreshSession(session *model.Session) error {
+	now := utils.GetMillis()
+	s.updateSessionRows(session.Token, func(row *sessionRow) {
+		row.UpdateAt = now
+	})
+	return nil
+}
+
+func (s *MemStore) updateSession(session *model.Session) error {
+	now := utils.GetMillis()
+
+	var props map[string]interface{}
+	if err := normalizeJSON(session.Props, &props); err != nil {
+		return err
+	}
+
+	s.updateSessionRows(session.Token, func(row *sessionRow) {
+		row.UpdateAt = now
+		row.Props = props
+	})
+	return nil
+}
+
+func (s *MemStore) deleteUserSessions(userID string) error {
+	for id, row := range s.data.sessions {
+		if row.UserID == userID {
+			delete(s.data.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) deleteSession(sessionID string) error {
+	delete(s.data.sessions, sessionID)
+	return nil
+}
+
+// cleanUpSessions removes the sessions of deactivated users, and the
+// ones that are idle or too old. All of them are removed at once, as
+// there is no database to keep responsive.
+func (s *MemStore) cleanUpSessions(idleTimeout, maxLifetime time.Duration, _ int64) (int64, error) {
+	now := utils.GetMillis()
+
+	var total int64
+	for _, row := range s.sessionRows(func(row *sessionRow) bool {
+		return s.isDeactivatedUser(row.UserID) ||
+			(idleTimeout > 0 && row.UpdateAt < now-idleTimeout.Milliseconds()) ||
+			(maxLifetime > 0 && row.CreateAt < now-maxLifetime.Milliseconds())
+	}) {
+		delete(s.data.sessions, row.ID)
+		total++
+	}
+
+	return total, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func shareTokenFromRow(row *model.ShareToken) *model.ShareToken {
+	shareToken := *row
+	shareToken.HasPassword = shareToken.PasswordHash != ""
+	return &shareToken
+}
+
+func (s *MemStore) createShareToken(shareToken *model.ShareToken) error {
+	if err := shareToken.IsValid(); err != nil {
+		return err
+	}
+
+	shareToken.ID = utils.NewID(utils.IDTypeNone)
+	shareToken.Token = utils.NewID(utils.IDTypeToken)
+	shareToken.CreateAt = utils.GetMillis()
+	shareToken.HasPassword = shareToken.PasswordHash != ""
+
+	stored := *shareToken
+	s.data.shareTokens[shareToken.ID] = &stored
+
+	return s.auditChange(shareToken.BoardID, shareToken.CreatedBy, model.AuditActionCreateShareToken, shareToken.ID, "scope: "+string(shareToken.Scope))
+}
+
+func (s *MemStore) getShareToken(token string) (*model.ShareToken, error) {
+	for _, shareToken := range s.data.shareTokens {
+		if shareToken.Token == token {
+			return shareTokenFromRow(shareToken), nil
+		}
+	}
+
+	return nil, model.NewErrNotFound("share token")
+}
+
+func (s *MemStore) getShareTokensForBoard(boardID string) ([]*model.ShareToken, error) {
+	shareTokens := []*model.ShareToken{}
+	for _, shareToken := range s.data.shareTokens {
+		if shareToken.BoardID == boardID {
+			shareTokens = append(shareTokens, shareTokenFromRow(shareToken))
+		}
+	}
+	sort.Slice(shareTokens, func(i, j int) bool {
+		if shareTokens[i].CreateAt != shareTokens[j].CreateAt {
+			return shareTokens[i].CreateAt < shareTokens[j].CreateAt
+		}
+		return shareTokens[i].ID < shareTokens[j].ID
+	})
+
+	return shareTokens, nil
+}
+
+func (s *MemStore) deleteShareToken(boardID, id, userID string) error {
+	shareToken, ok := s.data.shareTokens[id]
+	if !ok || shareToken.BoardID != boardID {
+		return model.NewErrNotFound("share token ID=" + id)
+	}
+
+	delete(s.data.shareTokens, id)
+
+	return s.auditChange(boardID, userID, model.AuditActionDeleteShareToken, id, "")
+}
+
+func (s *MemStore) deleteShareTokensForBoard(boardID string) {
+	for id, shareToken := range s.data.shareTokens {
+		if shareToken.BoardID == boardID {
+			delete(s.data.shareTokens, id)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func (s *MemStore) upsertSharing(sharing model.Sharing) error {
+	sharing.UpdateAt = utils.GetMillis()
+	s.data.sharing[sharing.ID] = &sharing
+
+	return s.auditChange(sharing.ID, sharing.ModifiedBy, model.AuditActionUpdateSharing, sharing.ID, fmt.Sprintf("enabled: %t", sharing.Enabled))
+}
+
+func (s *MemStore) getSharing(boardID string) (*model.Sharing, error) {
+	stored, ok := s.data.sharing[boardID]
+	if !ok {
+		return nil, model.NewErrNotFound("sharing ID=" + boardID)
+	}
+
+	if stored.IsExpired(utils.GetMillis()) {
+		return nil, model.ErrSharingExpired
+	}
+
+	sharing := *stored
+	return &sharing, nil
+}
+
+func (s *MemStore) rotateSharingToken(rootID, userID string) (*model.Sharing, error) {
+	stored, ok := s.data.sharing[rootID]
+	if !ok {
+		return nil, model.NewErrNotFound("sharing ID=" + rootID)
+	}
+
+	updated := *stored
+	updated.Token = utils.NewID(utils.IDTypeToken)
+	updated.ModifiedBy = userID
+	updated.UpdateAt = utils.GetMillis()
+	s.data.sharing[rootID] = &updated
+
+	if err := s.auditChange(rootID, userID, model.AuditActionRotateSharingToken, rootID, ""); err != nil {
+		return nil, err
+	}
+
+	return s.getSharing(rootID)
+}
+
+// getSharingForBoards returns the enabled and not expired sharing of the
+// boards, by board ID.
+func (s *MemStore) getSharingForBoards(rootIDs []string) (map[string]*model.Sharing, error) {
+	now := utils.GetMillis()
+	sharingMap := make(map[string]*model.Sharing, len(rootIDs))
+	for _, rootID := range rootIDs {
+		stored, ok := s.data.sharing[rootID]
+		if !ok || !stored.Enabled || stored.IsExpired(now) {
+			continue
+		}
+
+		sharing := *stored
+		sharingMap[rootID] = &sharing
+	}
+
+	return sharingMap, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"encoding/json"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+type boardSnapshotData struct {
+	Board  *model.Board   `json:"board"`
+	Blocks []*model.Block `json:"blocks"`
+}
+
+func (s *MemStore) upsertBoardSnapshot(snapshot *model.BoardSnapshot) error {
+	data, err := json.Marshal(boardSnapshotData{Board: snapshot.Board, Blocks: snapshot.Blocks})
+	if err != nil {
+		return err
+	}
+
+	snapshot.CreateAt = utils.GetMillis()
+
+	s.data.snapshots[snapshot.BoardID] = &snapshotRow{
+		boardID:   snapshot.BoardID,
+		data:      string(data),
+		createdBy: snapshot.CreatedBy,
+		createAt:  snapshot.CreateAt,
+	}
+	return nil
+}
+
+func (s *MemStore) getBoardSnapshot(boardID string) (*model.BoardSnapshot, error) {
+	row, ok := s.data.snapshots[boardID]
+	if !ok {
+		return nil, model.NewErrNotFound("board snapshot BoardID=" + boardID)
+	}
+
+	var content boardSnapshotData
+	if err := json.Unmarshal([]byte(row.data), &content); err != nil {
+		return nil, err
+	}
+
+	snapshot := &model.BoardSnapshot{
+		BoardID:   row.boardID,
+		Board:     content.Board,
+		Blocks:    content.Blocks,
+		CreatedBy: row.createdBy,
+		CreateAt:  row.createAt,
+	}
+	if snapshot.Blocks == nil {
+		snapshot.Blocks = []*model.Block{}
+	}
+
+	return snapshot, nil
+}
+
+func (s *MemStore) deleteBoardSnapshot(boardID string) error {
+	delete(s.data.snapshots, boardID)
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// subscriptionFromRow returns a copy of a stored subscription. The
+// subscriptions stored before the events were added get all of them.
+func subscriptionFromRow(row *model.Subscription) *model.Subscription {
+	sub := *row
+	if sub.Events == 0 {
+		sub.Events = model.SubscriptionEventsAll
+	}
+	return &sub
+}
+
+// subscriptionRows returns the live subscriptions that match the filter,
+// sorted by block and subscriber.
+func (s *MemStore) subscriptionRows(filter func(*model.Subscription) bool) []*model.Subscription {
+	rows := []*model.Subscription{}
+	for _, sub := range s.data.subscriptions {
+		if sub.DeleteAt == 0 && filter(sub) {
+			rows = append(rows, sub)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].BlockID != rows[j].BlockID {
+			return rows[i].BlockID < rows[j].BlockID
+		}
+		return rows[i].SubscriberID < rows[j].SubscriberID
+	})
+	return rows
+}
+
+// isArchivedBlock reports whether the ID belongs to an archived block.
+func (s *MemStore) isArchivedBlock(blockID string) bool {
+	row, ok := s.data.blocks[blockID]
+	return ok && row.ArchivedAt > 0
+}
+
+func (s *MemStore) createSubscription(sub *model.Subscription) (*model.Subscription, error) {
+	if err := sub.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	subAdd := *sub
+	subAdd.NotifiedAt = now // notified_at set so first notification doesn't pick up all history
+	subAdd.CreateAt = now
+	subAdd.DeleteAt = 0
+
+	key := subscriptionKey{blockID: sub.BlockID, subscriberID: sub.SubscriberID}
+	stored := subAdd
+	if existing, ok := s.data.subscriptions[key]; ok {
+		stored = *existing
+		stored.DeleteAt = 0
+		stored.NotifiedAt = now
+		stored.Events = subAdd.Events
+	}
+	s.data.subscriptions[key] = &stored
+
+	if subAdd.Events == 0 {
+		subAdd.Events = model.SubscriptionEventsAll
+	}
+	return &subAdd, nil
+}
+
+func (s *MemStore) updateSubscription(sub *model.Subscription) (*model.Subscription, error) {
+	if err := sub.IsValid(); err != nil {
+		return nil, err
+	}
+
+	key := subscriptionKey{blockID: sub.BlockID, subscriberID: sub.SubscriberID}
+	if existing, ok := s.data.subscriptions[key]; ok && existing.DeleteAt == 0 {
+		updated := *existing
+		updated.Events = sub.Events
+		s.data.subscriptions[key] = &updated
+	}
+
+	return s.getSubscription(sub.BlockID, sub.SubscriberID)
+}
+
+func (s *MemStore) deleteSubscription(blockID string, subscriberID string) (int64, error) {
+	key := subscriptionKey{blockID: blockID, subscriberID: subscriberID}
+	existing, ok := s.data.subscriptions[key]
+	if !ok || existing.DeleteAt != 0 {
+		return 0, nil
+	}
+
+	deleted := *existing
+	deleted.DeleteAt = model.GetMillis()
+	s.data.subscriptions[key] = &deleted
+
+	return 1, nil
+}
+
+func (s *MemStore) deleteSubscriptionsForBlock(blockID string) error {
+	for key := range s.data.subscriptions {
+		if key.blockID == blockID {
+			delete(s.data.subscriptions, key)
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) getSubscription(blockID string, subscriberID string) (*model.Subscription, error) {
+	sub, ok := s.data.subscriptions[subscriptionKey{blockID: blockID, subscriberID: subscriberID}]
+	if !ok || sub.DeleteAt != 0 {
+		message := fmt.Sprintf("subscription BlockID=%s SubscriberID=%s", blockID, subscriberID)
+		return nil, model.NewErrNotFound(message)
+	}
+	return subscriptionFromRow(sub), nil
+}
+
+func (s *MemStore) getSubscriptions(subscriberID string) ([]*model.Subscription, error) {
+	rows := s.subscriptionRows(func(sub *model.Subscription) bool {
+		return sub.SubscriberID == subscriberID
+	})
+
+	subscriptions := make([]*model.Subscription, 0, len(rows))
+	for _, row := range rows {
+		subscriptions = append(subscriptions, subscriptionFromRow(row))
+	}
+	return subscriptions, nil
+}
+
+func (s *MemStore) getSubscribersForBlock(blockID string, events model.SubscriptionEvents) ([]*model.Subscriber, error) {
+	rows := s.subscriptionRows(func(sub *model.Subscription) bool {
+		if sub.BlockID != blockID || s.isArchivedBlock(sub.BlockID) {
+			return false
+		}
+		return events == 0 || sub.Events == 0 || sub.Events&events != 0
+	})
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].NotifiedAt < rows[j].NotifiedAt })
+
+	subscribers := make([]*model.Subscriber, 0, len(rows))
+	for _, row := range rows {
+		subscribers = append(subscribers, &model.Subscriber{
+			SubscriberType: row.SubscriberType,
+			SubscriberID:   row.SubscriberID,
+			NotifiedAt:     row.NotifiedAt,
+		})
+	}
+	return subscribers, nil
+}
+
+func (s *MemStore) getSubscribersCountForBlock(blockID string) (int, error) {
+	rows := s.subscriptionRows(func(sub *model.Subscription) bool {
+		return sub.BlockID == blockID && !s.isArchivedBlock(sub.BlockID)
+	})
+	return len(rows), nil
+}
+
+func (s *MemStore) updateSubscribersNotifiedAt(blockID string, notifiedAt int64) error {
+	for _, row := range s.subscriptionRows(func(sub *model.Subscription) bool { return sub.BlockID == blockID }) {
+		s.setSubscriptionNotifiedAt(row, notifiedAt)
+	}
+	return nil
+}
+
+func (s *MemStore) updateSubscriberNotifiedAt(blockID, subscriberID string, notifiedAt int64) error {
+	sub, ok := s.data.subscriptions[subscriptionKey{blockID: blockID, subscriberID: subscriberID}]
+	if ok && sub.DeleteAt == 0 {
+		s.setSubscriptionNotifiedAt(sub, notifiedAt)
+	}
+	return nil
+}
+
+func (s *MemStore) setSubscriptionNotifiedAt(sub *model.Subscription, notifiedAt int64) {
+	updated := *sub
+	updated.NotifiedAt = notifiedAt
+	s.data.subscriptions[subscriptionKey{blockID: sub.BlockID, subscriberID: sub.SubscriberID}] = &updated
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func (s *MemStore) getSystemSetting(key string) (string, error) {
+	return s.data.system[key], nil
+}
+
+func (s *MemStore) getSystemSettingBool(key string) (bool, error) {
+	value, err := s.getSystemSetting(key)
+	if err != nil || value == "" {
+		return false, err
+	}
+
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("system setting %s is not a valid boolean: %w", key, err)
+	}
+	return result, nil
+}
+
+func (s *MemStore) getSystemSettingInt(key string) (int, error) {
+	value, err := s.getSystemSetting(key)
+	if err != nil || value == "" {
+		return 0, err
+	}
+
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("system setting %s is not a valid integer: %w", key, err)
+	}
+	return result, nil
+}
+
+func (s *MemStore) getSystemSettings() (map[string]string, error) {
+	results := make(map[string]string, len(s.data.system))
+	for id, value := range s.data.system {
+		results[id] = value
+	}
+	return results, nil
+}
+
+func (s *MemStore) setSystemSetting(id, value string) error {
+	s.data.system[id] = value
+	return nil
+}
+
+// compareAndSetSystemSetting sets a setting to newValue only if its
+// current value is expectedOld, and reports whether it did. A missing
+// setting matches an empty expectedOld.
+func (s *MemStore) compareAndSetSystemSetting(key, expectedOld, newValue string) (bool, error) {
+	if expectedOld == newValue {
+		return false, nil
+	}
+
+	value, ok := s.data.system[key]
+	if (ok && value != expectedOld) || (!ok && expectedOld != "") {
+		return false, nil
+	}
+
+	s.data.system[key] = newValue
+	return true, nil
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// teamFromRow returns the stored fields of a team.
+func teamFromRow(row *teamRow) *model.Team {
+	return &model.Team{
+		ID:          row.ID,
+		SignupToken: row.SignupToken,
+		Settings:    copyJSONMap(row.Settings),
+		ModifiedBy:  row.ModifiedBy,
+		UpdateAt:    row.UpdateAt,
+	}
+}
+
+// teamRows returns the teams that match the filter, in insertion order.
+func (s *MemStore) teamRows(filter func(*teamRow) bool) []*teamRow {
+	rows := []*teamRow{}
+	for _, row := range s.data.teams {
+		if filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+	return rows
+}
+
+// upsertTeamRow applies a change to a team, creating it if needed. New
+// teams have no settings, which read back as an empty object.
+func (s *MemStore) upsertTeamRow(teamID string, fn func(row *teamRow, isNew bool)) {
+	row := &teamRow{}
+	existing, ok := s.data.teams[teamID]
+	if ok {
+		*row = *existing
+	} else {
+		row.ID = teamID
+		row.Settings = map[string]interface{}{}
+		row.seq = s.data.nextSeq()
+	}
+	fn(row, !ok)
+	s.data.teams[teamID] = row
+}
+
+func (s *MemStore) upsertTeamSignupToken(team model.Team) error {
+	now := utils.GetMillis()
+	s.upsertTeamRow(team.ID, func(row *teamRow, _ bool) {
+		row.SignupToken = team.SignupToken
+		row.ModifiedBy = team.ModifiedBy
+		row.UpdateAt = now
+	})
+	return nil
+}
+
+func (s *MemStore) upsertTeamSettings(team model.Team) error {
+	now := utils.GetMillis()
+
+	var settings map[string]interface{}
+	if err := normalizeJSON(team.Settings, &settings); err != nil {
+		return err
+	}
+
+	s.upsertTeamRow(team.ID, func(row *teamRow, isNew bool) {
+		if isNew {
+			row.SignupToken = utils.NewID(utils.IDTypeToken)
+		}
+		row.Settings = settings
+		row.ModifiedBy = team.ModifiedBy
+		row.UpdateAt = now
+	})
+	return nil
+}
+
+func (s *MemStore) getTeam(id string) (*model.Team, error) {
+	row, ok := s.data.teams[id]
+	if !ok {
+		return nil, model.NewErrNotFound("team ID=" + id)
+	}
+
+	return teamFromRow(row), nil
+}
+
+func (s *MemStore) getTeamBySignupToken(token string) (*model.Team, error) {
+	if token == "" {
+		return nil, model.NewErrNotFound("team with empty signup token")
+	}
+
+	rows := s.teamRows(func(row *teamRow) bool { return row.SignupToken == token })
+	if len(rows) == 0 {
+		return nil, model.NewErrNotFound("team with signup token")
+	}
+
+	return teamFromRow(rows[0]), nil
+}
+
+func (s *MemStore) getTeamsForUser(_ string) ([]*model.Team, error) {
+	return s.getAllTeams()
+}
+
+func (s *MemStore) getTeamsForUserWithBoardCounts(userID string) ([]model.TeamWithCount, error) {
+	boardCounts := map[string]int64{}
+	for _, board := range s.data.boards {
+		if !board.IsTemplate && s.isMember(board.ID, userID) {
+			boardCounts[board.TeamID]++
+		}
+	}
+
+	teams := []model.TeamWithCount{}
+	for _, row := range s.teamRows(func(*teamRow) bool { return true }) {
+		teams = append(teams, model.TeamWithCount{
+			Team:       *teamFromRow(row),
+			BoardCount: boardCounts[row.ID],
+		})
+	}
+
+	return teams, nil
+}
+
+func (s *MemStore) getTeamCount() (int64, error) {
+	return int64(len(s.data.teams)), nil
+}
+
+func (s *MemStore) getAllTeams() ([]*model.Team, error) {
+	teams := []*model.Team{}
+	for _, row := range s.teamRows(func(*teamRow) bool { return true }) {
+		teams = append(teams, teamFromRow(row))
+	}
+	return teams, nil
+}
+
+// deleteTeam removes a team along with its boards, including the deleted
+// ones, their data, and the categories of the team, and returns the
+// number of boards removed.
+func (s *MemStore) deleteTeam(teamID string) (int, error) {
+	if _, ok := s.data.teams[teamID]; !ok {
+		return 0, model.NewErrNotFound("team ID=" + teamID)
+	}
+
+	boardIDs := s.getTeamBoardIDs(teamID)
+	boards := stringSet(boardIDs)
+
+	s.deleteBoardSubscriptionsAndHints(boards)
+	s.deleteBoardsData(boards)
+
+	for id, delivery := range s.data.webhookDeliveries {
+		if boards[delivery.BoardID] {
+			delete(s.data.webhookDeliveries, id)
+		}
+	}
+
+	auditRecords := []*model.AuditRecord{}
+	for _, record := range s.data.auditRecords {
+		if !boards[record.BoardID] {
+			auditRecords = append(auditRecords, record)
+		}
+	}
+	s.data.auditRecords = auditRecords
+
+	for id, row := range s.data.categoryBoards {
+		if category, ok := s.data.categories[row.categoryID]; ok && category.TeamID == teamID {
+			delete(s.data.categoryBoards, id)
+		}
+	}
+	for id, category := range s.data.categories {
+		if category.TeamID == teamID {
+			delete(s.data.categories, id)
+		}
+	}
+	for id, template := range s.data.categoryTemplates {
+		if template.TeamID == teamID {
+			delete(s.data.categoryTemplates, id)
+		}
+	}
+	delete(s.data.teams, teamID)
+
+	return len(boardIDs), nil
+}
+
+// getTeamBoardIDs returns the IDs of the boards of a team, including the
+// ones that only remain in the board history.
+func (s *MemStore) getTeamBoardIDs(teamID string) []string {
+	boardIDs := []string{}
+	for _, row := range s.boardRows(func(row *boardRow) bool { return row.TeamID == teamID }) {
+		boardIDs = append(boardIDs, row.ID)
+	}
+	for _, row := range s.data.boardsHistory {
+		if row.TeamID == teamID {
+			boardIDs = append(boardIDs, row.ID)
+		}
+	}
+	return uniqueStrings(boardIDs)
+}
+
+// deleteBoardSubscriptionsAndHints removes the subscriptions and the
+// notification hints of the boards and their blocks.
+func (s *MemStore) deleteBoardSubscriptionsAndHints(boardIDs map[string]bool) {
+	inBoards := func(blockID string) bool {
+		if boardIDs[blockID] {
+			return true
+		}
+		block, ok := s.data.blocks[blockID]
+		return ok && boardIDs[block.BoardID]
+	}
+
+	for key := range s.data.subscriptions {
+		if inBoards(key.blockID) {
+			delete(s.data.subscriptions, key)
+		}
+	}
+	for blockID := range s.data.notificationHints {
+		if inBoards(blockID) {
+			delete(s.data.notificationHints, blockID)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (s *MemStore) removeDefaultTemplates(boards []*model.Board) error {
+	count := 0
+	for _, board := range boards {
+		if board.CreatedBy != model.SystemUserID {
+			continue
+		}
+		if row, ok := s.data.boards[board.ID]; ok && row.IsTemplate {
+			delete(s.data.boards, board.ID)
+		}
+
+		for id, row := range s.data.blocks {
+			if row.ParentID == board.ID || row.BoardID == board.ID {
+				delete(s.data.blocks, id)
+			}
+		}
+
+		s.logger.Trace("removed default template block",
+			mlog.String("board_id", board.ID),
+		)
+		count++
+	}
+
+	s.logger.Debug("Removed default templates", mlog.Int("count", count))
+
+	return nil
+}
+
+func (s *MemStore) reinstallDefaultTemplates(teamID string, templates []*model.Board, userID string) error {
+	existingTemplates, err := s.getDefaultTemplateBoards(teamID)
+	if err != nil {
+		return fmt.Errorf("cannot fetch default templates for team %s: %w", teamID, err)
+	}
+
+	if err := s.removeDefaultTemplates(existingTemplates); err != nil {
+		return err
+	}
+
+	for _, template := range templates {
+		template.TeamID = teamID
+		template.IsTemplate = true
+		if template.Properties == nil {
+			template.Properties = map[string]interface{}{}
+		}
+
+		if _, err := s.insertBoard(template, model.SystemUserID); err != nil {
+			return fmt.Errorf("cannot insert default template %s: %w", template.ID, err)
+		}
+
+		if row, ok := s.data.boards[template.ID]; ok {
+			updated := *row
+			updated.ModifiedBy = userID
+			s.data.boards[template.ID] = &updated
+		}
+		template.ModifiedBy = userID
+	}
+
+	s.logger.Debug("Reinstalled default templates",
+		mlog.String("team_id", teamID),
+		mlog.String("user_id", userID),
+		mlog.Int("count", len(templates)),
+	)
+
+	return nil
+}
+
+func (s *MemStore) upgradeDefaultTemplates(teamID string, toVersion int, templates *model.BoardsAndBlocks) error {
+	existingTemplates, err := s.getTemplateBoards(teamID, "")
+	if err != nil {
+		return fmt.Errorf("cannot fetch default templates for team %s: %w", teamID, err)
+	}
+
+	outdatedTemplates := []*model.Board{}
+	for _, template := range existingTemplates {
+		if template.CreatedBy != model.SystemUserID || template.IsCustomizedTemplate() {
+			continue
+		}
+		if template.TemplateVersion < toVersion {
+			outdatedTemplates = append(outdatedTemplates, template)
+		}
+	}
+
+	if len(outdatedTemplates) == 0 {
+		s.logger.Debug("Default templates are up to date",
+			mlog.String("team_id", teamID),
+			mlog.Int("version", toVersion),
+		)
+		return nil
+	}
+
+	if err := s.removeDefaultTemplates(outdatedTemplates); err != nil {
+		return err
+	}
+
+	for _, template := range templates.Boards {
+		template.TeamID = teamID
+		template.IsTemplate = true
+		template.TemplateVersion = toVersion
+		if template.Properties == nil {
+			template.Properties = map[string]interface{}{}
+		}
+	}
+
+	if _, err := s.createBoardsAndBlocks(templates, model.SystemUserID); err != nil {
+		return fmt.Errorf("cannot insert default templates for team %s: %w", teamID, err)
+	}
+
+	s.logger.Debug("Upgraded default templates",
+		mlog.String("team_id", teamID),
+		mlog.Int("version", toVersion),
+		mlog.Int("removed", len(outdatedTemplates)),
+		mlog.Int("inserted", len(templates.Boards)),
+	)
+
+	return nil
+}
+
+func (s *MemStore) getTemplateBoardsByVersion(teamID string, version int) ([]*model.Board, error) {
+	rows := s.boardRows(func(row *boardRow) bool {
+		return row.IsTemplate && row.TeamID == teamID && row.TemplateVersion == version
+	})
+	return boardsFromRows(rows), nil
+}
+
+// getDefaultTemplateBoards returns the default templates of a team,
+// whatever their type.
+func (s *MemStore) getDefaultTemplateBoards(teamID string) ([]*model.Board, error) {
+	rows := s.boardRows(func(row *boardRow) bool {
+		return row.IsTemplate && row.TeamID == teamID && row.CreatedBy == model.SystemUserID
+	})
+	return boardsFromRows(rows), nil
+}
+
+// getTemplateBoards returns the templates of a team that the user is a
+// member of, or that are open.
+func (s *MemStore) getTemplateBoards(teamID, userID string) ([]*model.Board, error) {
+	rows := s.boardRows(func(row *boardRow) bool {
+		if !row.IsTemplate || row.TeamID != teamID {
+			return false
+		}
+		return s.isMember(row.ID, userID) || row.Type == model.BoardTypeOpen
+	})
+	return boardsFromRows(rows), nil
+}
+
+func (s *MemStore) getBoardsCreatedFromTemplate(templateID string) ([]*model.Board, error) {
+	rows := s.boardRows(func(row *boardRow) bool {
+		return row.SourceID == templateID && row.CreationSource == model.BoardCreationSourceTemplate
+	})
+	return boardsFromRows(rows), nil
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+type UserNotFoundError struct {
+	id string
+}
+
+func (unf UserNotFoundError) Error() string {
+	return fmt.Sprintf("user not found (%s)", unf.id)
+}
+
+// userFromRow returns the stored fields of a user.
+func userFromRow(row *userRow) *model.User {
+	return &model.User{
+		ID:          row.ID,
+		Username:    row.Username,
+		Email:       row.Email,
+		Password:    row.Password,
+		MfaSecret:   row.MfaSecret,
+		AuthService: row.AuthService,
+		AuthData:    row.AuthData,
+		CreateAt:    row.CreateAt,
+		UpdateAt:    row.UpdateAt,
+		DeleteAt:    row.DeleteAt,
+	}
+}
+
+// userRows returns the users that match the filter, in insertion order.
+func (s *MemStore) userRows(filter func(*userRow) bool) []*userRow {
+	rows := []*userRow{}
+	for _, row := range s.data.users {
+		if filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+	return rows
+}
+
+func usersFromRows(rows []*userRow) []*model.User {
+	users := make([]*model.User, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, userFromRow(row))
+	}
+	return users
+}
+
+// hasUniqueUserField reports whether another user already has the
+// username or email of a user. Empty values are never taken, as they are
+// stored as NULL by the SQL store.
+func (s *MemStore) hasUniqueUserField(user *model.User) bool {
+	for _, row := range s.data.users {
+		if row.ID == user.ID {
+			continue
+		}
+		if (user.Username != "" && row.Username == user.Username) || (user.Email != "" && row.Email == user.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MemStore) updateUserRow(row *userRow, fn func(*userRow)) {
+	updated := *row
+	fn(&updated)
+	s.data.users[row.ID] = &updated
+}
+
+func (s *MemStore) getRegisteredUserCount() (int, error) {
+	return len(s.userRows(func(row *userRow) bool { return row.DeleteAt == 0 })), nil
+}
+
+func (s *MemStore) getUserByID(userID string) (*model.User, error) {
+	row, ok := s.data.users[userID]
+	if !ok {
+		return nil, model.NewErrNotFound("user")
+	}
+	return userFromRow(row), nil
+}
+
+func (s *MemStore) getUsersList(userIDs []string) ([]*model.User, error) {
+	ids := stringSet(userIDs)
+	users := usersFromRows(s.userRows(func(row *userRow) bool { return ids[row.ID] }))
+	if len(users) == 0 {
+		return nil, model.NewErrNotFound("user")
+	}
+
+	if len(users) != len(userIDs) {
+		return users, model.NewErrNotAllFound("user", userIDs)
+	}
+
+	return users, nil
+}
+
+func (s *MemStore) getUserByEmail(email string) (*model.User, error) {
+	rows := s.userRows(func(row *userRow) bool { return row.Email == email && row.DeleteAt == 0 })
+	if len(rows) == 0 {
+		return nil, model.NewErrNotFound("user")
+	}
+	return userFromRow(rows[0]), nil
+}
+
+func (s *MemStore) getUserByUsername(username string) (*model.User, error) {
+	rows := s.userRows(func(row *userRow) bool { return row.Username == username && row.DeleteAt == 0 })
+	if len(rows) == 0 {
+		return nil, model.NewErrNotFound("user")
+	}
+	return userFromRow(rows[0]), nil
+}
+
+func (s *MemStore) createUser(user *model.User) (*model.User, error) {
+	now := utils.GetMillis()
+	user.CreateAt = now
+	user.UpdateAt = now
+	user.DeleteAt = 0
+
+	if _, ok := s.data.users[user.ID]; ok || s.hasUniqueUserField(user) {
+		return nil, model.NewErrDuplicate("user")
+	}
+
+	row := &userRow{seq: s.data.nextSeq()}
+	row.User = *userFromRow(&userRow{User: *user})
+	s.data.users[user.ID] = row
+
+	return user, nil
+}
+
+func (s *MemStore) updateUser(user *model.User) (*model.User, error) {
+	now := utils.GetMillis()
+	user.UpdateAt = now
+
+	row, ok := s.data.users[user.ID]
+	if !ok {
+		return nil, UserNotFoundError{user.ID}
+	}
+
+	if s.hasUniqueUserField(user) {
+		return nil, model.NewErrDuplicate("user")
+	}
+
+	s.updateUserRow(row, func(row *userRow) {
+		row.Username = user.Username
+		row.Email = user.Email
+		row.UpdateAt = user.UpdateAt
+	})
+
+	return user, nil
+}
+
+func (s *MemStore) deactivateUser(userID string) error {
+	row, ok := s.data.users[userID]
+	if !ok {
+		return model.NewErrNotFound("user")
+	}
+
+	if row.DeleteAt == 0 {
+		now := utils.GetMillis()
+		s.updateUserRow(row, func(row *userRow) {
+			row.DeleteAt = now
+			row.UpdateAt = now
+		})
+	}
+
+	// the sessions of the user are removed, as they can't be used
+	// anymore
+	return s.deleteUserSessions(userID)
+}
+
+func (s *MemStore) reactivateUser(userID string) error {
+	row, ok := s.data.users[userID]
+	if !ok {
+		return model.NewErrNotFound("user ID=" + userID)
+	}
+
+	now := utils.GetMillis()
+	s.updateUserRow(row, func(row *userRow) {
+		row.DeleteAt = 0
+		row.UpdateAt = now
+	})
+
+	return nil
+}
+
+func (s *MemStore) updateUserPassword(username, password string) error {
+	rows := s.userRows(func(row *userRow) bool { return row.Username == username })
+	if len(rows) == 0 {
+		return UserNotFoundError{username}
+	}
+
+	now := utils.GetMillis()
+	for _, row := range rows {
+		s.updateUserRow(row, func(row *userRow) {
+			row.Password = password
+			row.UpdateAt = now
+		})
+	}
+
+	return nil
+}
+
+func (s *MemStore) updateUserPasswordByID(userID, password string) error {
+	row, ok := s.data.users[userID]
+	if !ok {
+		return UserNotFoundError{userID}
+	}
+
+	now := utils.GetMillis()
+	s.updateUserRow(row, func(row *userRow) {
+		row.Password = password
+		row.UpdateAt = now
+	})
+
+	return nil
+}
+
+func (s *MemStore) getUsersByTeam(_ string, _ string) ([]*model.User, error) {
+	return usersFromRows(s.userRows(func(row *userRow) bool { return row.DeleteAt == 0 })), nil
+}
+
+// getUsersByTeamWithRole returns the users with a team role. Every user
+// belongs to the single team of the standalone mode as a regular user.
+func (s *MemStore) getUsersByTeamWithRole(_ string, role string, includeDeleted bool) ([]*model.User, error) {
+	if !model.IsValidTeamRole(role) {
+		return nil, model.NewErrBadRequest("invalid team role: " + role)
+	}
+
+	if role != model.TeamRoleUser {
+		return []*model.User{}, nil
+	}
+
+	rows := s.userRows(func(row *userRow) bool { return includeDeleted || row.DeleteAt == 0 })
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Username != rows[j].Username {
+			return rows[i].Username < rows[j].Username
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	return usersFromRows(rows), nil
+}
+
+func (s *MemStore) searchUsersByTeam(_ string, searchQuery string, _ string, _ bool) ([]*model.User, error) {
+	// LIKE on SQLite is only case insensitive for ASCII characters
+	query := asciiLower(searchQuery)
+	rows := s.userRows(func(row *userRow) bool {
+		return row.DeleteAt == 0 && row.Username != "" && strings.Contains(asciiLower(row.Username), query)
+	})
+
+	_, end := page(len(rows), 0, 10)
+	return usersFromRows(rows[:end]), nil
+}
+
+func asciiLower(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return r
+	}, value)
+}
+
+func (s *MemStore) patchUserPreferences(userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
+	preferences, err := s.getUserPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(patch.UpdatedFields) > 0 {
+		for key, value := range patch.UpdatedFields {
+			preference := mmModel.Preference{
+				UserId:   userID,
+				Category: model.PreferencesCategoryFocalboard,
+				Name:     key,
+				Value:    value,
+			}
+
+			if err := s.updateUserPreference(preference); err != nil {
+				return nil, err
+			}
+
+			newPreferences := mmModel.Preferences{}
+			for _, existingPreference := range preferences {
+				if preference.Name != existingPreference.Name {
+					newPreferences = append(newPreferences, existingPreference)
+				}
+			}
+			newPreferences = append(newPreferences, preference)
+			preferences = newPreferences
+		}
+	}
+
+	if len(patch.DeletedFields) > 0 {
+		for _, key := range patch.DeletedFields {
+			preference := mmModel.Preference{
+				UserId:   userID,
+				Category: model.PreferencesCategoryFocalboard,
+				Name:     key,
+			}
+
+			if err := s.deleteUserPreference(preference); err != nil {
+				return nil, err
+			}
+
+			newPreferences := mmModel.Preferences{}
+			for _, existingPreference := range preferences {
+				if preference.Name != existingPreference.Name {
+					newPreferences = append(newPreferences, existingPreference)
+				}
+			}
+			preferences = newPreferences
+		}
+	}
+
+	return preferences, nil
+}
+
+func (s *MemStore) updateUserPreference(preference mmModel.Preference) error {
+	key := preferenceKey{userID: preference.UserId, category: preference.Category, name: preference.Name}
+	row := &preferenceRow{Preference: preference}
+	if existing, ok := s.data.preferences[key]; ok {
+		row.seq = existing.seq
+	} else {
+		row.seq = s.data.nextSeq()
+	}
+	s.data.preferences[key] = row
+
+	return nil
+}
+
+func (s *MemStore) deleteUserPreference(preference mmModel.Preference) error {
+	delete(s.data.preferences, preferenceKey{userID: preference.UserId, category: preference.Category, name: preference.Name})
+	return nil
+}
+
+func (s *MemStore) canSeeUser(seerID string, seenID string) (bool, error) {
+	return true, nil
+}
+
+func (s *MemStore) sendMessage(message, postType string, receipts []string) error {
+	return errUnsupportedOperation
+}
+
+func (s *MemStore) postMessage(message, postType string, channel string) error {
+	return errUnsupportedOperation
+}
+
+func (s *MemStore) getUserTimezone(_ string) (string, error) {
+	return "", errUnsupportedOperation
+}
+
+func (s *MemStore) getUserPreferences(userID string) (mmModel.Preferences, error) {
+	rows := []*preferenceRow{}
+	for key, row := range s.data.preferences {
+		if key.userID == userID && key.category == model.PreferencesCategoryFocalboard {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+
+	preferences := mmModel.Preferences{}
+	for _, row := range rows {
+		preferences = append(preferences, row.Preference)
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// page returns the bounds of the page of a list of the given length, as
+// OFFSET and LIMIT would select it. A limit of zero means no limit.
+func page(length, offset, limit int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > length {
+		offset = length
+	}
+	end := length
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return offset, end
+}
+
+// matchesSearchWord reports whether a value contains a search word, the
+// way the SQL store matches titles on SQLite.
+func matchesSearchWord(value, word string) bool {
+	value = strings.ToLower(value)
+	return strings.Contains(value, strings.ToLower(word)) ||
+		strings.Contains(value, utils.NormalizeSearchTerm(word))
+}
+
+// matchesSearchWords reports whether a value contains every word of a
+// search term.
+func matchesSearchWords(value, term string) bool {
+	for _, word := range strings.Split(strings.TrimSpace(term), " ") {
+		if !matchesSearchWord(value, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonText returns a JSON value as text, as json_extract does on SQLite.
+// The second result is false for null values.
+func jsonText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case bool:
+		if v {
+			return "1", true
+		}
+		return "0", true
+	case float64:
+		if v == float64(int64(v)) {
+			return strconv.FormatInt(int64(v), 10), true
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	}
+}
+
+// jsonPath returns the value at a path of a JSON object, if any.
+func jsonPath(object map[string]interface{}, segments []string) (interface{}, bool) {
+	var value interface{} = object
+	for _, segment := range segments {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[segment]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// uniqueStrings returns the values without duplicates, in their order.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
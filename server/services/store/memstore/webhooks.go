@@ -0,0 +1,203 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package memstore
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	webhookDeliveryRetryBase = 30 * time.Second
+	webhookDeliveryRetryMax  = time.Hour
+)
+
+func copyWebhook(webhook *model.Webhook) *model.Webhook {
+	c := *webhook
+	if webhook.EventTypes != nil {
+		c.EventTypes = append([]string{}, webhook.EventTypes...)
+	}
+	return &c
+}
+
+func (s *MemStore) createWebhook(webhook *model.Webhook) error {
+	if err := webhook.IsValid(); err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	if webhook.ID == "" {
+		webhook.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = utils.NewID(utils.IDTypeToken)
+	}
+	webhook.CreateAt = now
+	webhook.UpdateAt = now
+
+	if _, ok := s.data.webhooks[webhook.ID]; ok {
+		return model.NewErrDuplicate("webhook")
+	}
+
+	s.data.webhooks[webhook.ID] = copyWebhook(webhook)
+
+	return s.auditChange(webhook.BoardID, webhook.CreatedBy, model.AuditActionCreateWebhook, webhook.ID, "url: "+webhook.URL)
+}
+
+func (s *MemStore) getWebhooksForBoard(boardID string) ([]*model.Webhook, error) {
+	webhooks := []*model.Webhook{}
+	for _, webhook := range s.data.webhooks {
+		if webhook.BoardID == boardID {
+			webhooks = append(webhooks, copyWebhook(webhook))
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		if webhooks[i].CreateAt != webhooks[j].CreateAt {
+			return webhooks[i].CreateAt < webhooks[j].CreateAt
+		}
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks, nil
+}
+
+func (s *MemStore) getWebhook(id string) (*model.Webhook, error) {
+	webhook, ok := s.data.webhooks[id]
+	if !ok {
+		return nil, model.NewErrNotFound("webhook ID=" + id)
+	}
+
+	return copyWebhook(webhook), nil
+}
+
+func (s *MemStore) updateWebhook(webhook *model.Webhook, userID string) error {
+	if err := webhook.IsValid(); err != nil {
+		return err
+	}
+
+	webhook.UpdateAt = utils.GetMillis()
+
+	existing, ok := s.data.webhooks[webhook.ID]
+	if !ok || existing.BoardID != webhook.BoardID {
+		return model.NewErrNotFound("webhook ID=" + webhook.ID)
+	}
+
+	updated := copyWebhook(existing)
+	updated.URL = webhook.URL
+	updated.EventTypes = append([]string{}, webhook.EventTypes...)
+	updated.UpdateAt = webhook.UpdateAt
+	if webhook.Secret != "" {
+		updated.Secret = webhook.Secret
+	}
+	s.data.webhooks[webhook.ID] = updated
+
+	return s.auditChange(webhook.BoardID, userID, model.AuditActionUpdateWebhook, webhook.ID, "url: "+webhook.URL)
+}
+
+func (s *MemStore) deleteWebhook(id, userID string) error {
+	webhook, ok := s.data.webhooks[id]
+	if !ok {
+		return model.NewErrNotFound("webhook ID=" + id)
+	}
+
+	delete(s.data.webhooks, id)
+
+	return s.auditChange(webhook.BoardID, userID, model.AuditActionDeleteWebhook, id, "url: "+webhook.URL)
+}
+
+func (s *MemStore) deleteWebhooksForBoard(boardID string) {
+	for id, webhook := range s.data.webhooks {
+		if webhook.BoardID == boardID {
+			delete(s.data.webhooks, id)
+		}
+	}
+}
+
+func webhookDeliveryRetryDelay(attempts int) time.Duration {
+	delay := webhookDeliveryRetryBase
+	for i := 1; i < attempts && delay < webhookDeliveryRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > webhookDeliveryRetryMax {
+		delay = webhookDeliveryRetryMax
+	}
+	return delay
+}
+
+func (s *MemStore) recordWebhookDelivery(delivery *model.WebhookDelivery) error {
+	if err := delivery.IsValid(); err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	if delivery.ID == "" {
+		delivery.ID = utils.NewID(utils.IDTypeNone)
+	}
+	delivery.Attempts = 0
+	delivery.Status = 0
+	delivery.DeliveredAt = 0
+	delivery.CreateAt = now
+	delivery.UpdateAt = now
+	if delivery.NextRetryAt == 0 {
+		delivery.NextRetryAt = now
+	}
+
+	if _, ok := s.data.webhookDeliveries[delivery.ID]; ok {
+		return model.NewErrDuplicate("webhook delivery")
+	}
+
+	stored := *delivery
+	s.data.webhookDeliveries[delivery.ID] = &stored
+	return nil
+}
+
+func (s *MemStore) getPendingWebhookDeliveries(limit int) ([]*model.WebhookDelivery, error) {
+	now := utils.GetMillis()
+
+	deliveries := []*model.WebhookDelivery{}
+	for _, delivery := range s.data.webhookDeliveries {
+		if delivery.DeliveredAt == 0 && delivery.NextRetryAt <= now && delivery.Attempts < model.WebhookDeliveryMaxAttempts {
+			c := *delivery
+			deliveries = append(deliveries, &c)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		if deliveries[i].NextRetryAt != deliveries[j].NextRetryAt {
+			return deliveries[i].NextRetryAt < deliveries[j].NextRetryAt
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	_, end := page(len(deliveries), 0, limit)
+	deliveries = deliveries[:end]
+
+	for _, delivery := range deliveries {
+		delivery.Attempts++
+		delivery.NextRetryAt = utils.GetMillisForTime(time.Now().Add(webhookDeliveryRetryDelay(delivery.Attempts)))
+		delivery.UpdateAt = now
+
+		stored := *delivery
+		s.data.webhookDeliveries[delivery.ID] = &stored
+	}
+
+	return deliveries, nil
+}
+
+func (s *MemStore) markWebhookDelivered(id string, status int) error {
+	existing, ok := s.data.webhookDeliveries[id]
+	if !ok {
+		return model.NewErrNotFound("webhook delivery ID=" + id)
+	}
+
+	now := utils.GetMillis()
+	updated := *existing
+	updated.Status = status
+	updated.DeliveredAt = now
+	updated.UpdateAt = now
+	s.data.webhookDeliveries[id] = &updated
+
+	return nil
+}
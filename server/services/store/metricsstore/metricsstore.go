@@ -0,0 +1,41 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package metricsstore
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+var _ store.Store = (*MetricsStore)(nil)
+
+// MetricsStore is a store decorator that times every call to the
+// wrapped store and reports it to a StoreMetrics sink. The results and
+// errors of the wrapped store are returned unchanged.
+type MetricsStore struct {
+	store   store.Store
+	metrics model.StoreMetrics
+}
+
+// New wraps a store so that its calls are reported to metrics. Without
+// a metrics sink the store is returned as is, so that it adds no
+// overhead.
+func New(s store.Store, metrics model.StoreMetrics) store.Store {
+	if metrics == nil {
+		return s
+	}
+	return &MetricsStore{store: s, metrics: metrics}
+}
+
+func (s *MetricsStore) Shutdown() error {
+	return s.store.Shutdown()
+}
+
+func (s *MetricsStore) DBType() string {
+	return s.store.DBType()
+}
+
+func (s *MetricsStore) SetBoardBlockLimit(limit int) {
+	s.store.SetBoardBlockLimit(limit)
+}
@@ -0,0 +1,93 @@
+package metricsstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store/mockstore"
+)
+
+type observedQuery struct {
+	method  string
+	elapsed time.Duration
+	err     error
+}
+
+type testMetrics struct {
+	queries []observedQuery
+}
+
+func (m *testMetrics) ObserveQuery(method string, elapsed time.Duration, err error) {
+	m.queries = append(m.queries, observedQuery{method: method, elapsed: elapsed, err: err})
+}
+
+func TestMetricsStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("without metrics the store is not wrapped", func(t *testing.T) {
+		mockStore := mockstore.NewMockStore(ctrl)
+		require.Same(t, mockStore, New(mockStore, nil))
+	})
+
+	t.Run("calls are delegated and observed", func(t *testing.T) {
+		mockStore := mockstore.NewMockStore(ctrl)
+		metrics := &testMetrics{}
+		s := New(mockStore, metrics)
+
+		board := &model.Board{ID: "board-id"}
+		mockStore.EXPECT().GetBoard(gomock.Any(), "board-id").DoAndReturn(func(context.Context, string) (*model.Board, error) {
+			time.Sleep(time.Millisecond)
+			return board, nil
+		})
+
+		got, err := s.GetBoard(context.Background(), "board-id")
+		require.NoError(t, err)
+		require.Same(t, board, got)
+
+		require.Len(t, metrics.queries, 1)
+		require.Equal(t, "GetBoard", metrics.queries[0].method)
+		require.GreaterOrEqual(t, metrics.queries[0].elapsed, time.Millisecond)
+		require.NoError(t, metrics.queries[0].err)
+	})
+
+	t.Run("errors are passed through unchanged", func(t *testing.T) {
+		mockStore := mockstore.NewMockStore(ctrl)
+		metrics := &testMetrics{}
+		s := New(mockStore, metrics)
+
+		notFound := model.NewErrNotFound("board")
+		mockStore.EXPECT().GetBoard(gomock.Any(), "missing").Return(nil, notFound)
+
+		_, err := s.GetBoard(context.Background(), "missing")
+		require.True(t, model.IsErrNotFound(err))
+		require.Same(t, notFound, err)
+
+		failure := errors.New("failure")
+		mockStore.EXPECT().DeleteBoard(gomock.Any(), "board-id", "user-id").Return(failure)
+
+		err = s.DeleteBoard(context.Background(), "board-id", "user-id")
+		require.Same(t, failure, err)
+
+		require.Len(t, metrics.queries, 2)
+		require.Same(t, notFound, metrics.queries[0].err)
+		require.Same(t, failure, metrics.queries[1].err)
+	})
+
+	t.Run("hand written methods are delegated", func(t *testing.T) {
+		mockStore := mockstore.NewMockStore(ctrl)
+		s := New(mockStore, &testMetrics{})
+
+		mockStore.EXPECT().DBType().Return(model.SqliteDBType)
+		mockStore.EXPECT().Shutdown().Return(nil)
+
+		require.Equal(t, model.SqliteDBType, s.DBType())
+		require.NoError(t, s.Shutdown())
+	})
+}
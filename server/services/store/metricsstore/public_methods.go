@@ -0,0 +1,1567 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make generate" from the Store interface
+// DO NOT EDIT
+
+// Every method of the Store interface is timed and delegated to the
+// wrapped store. Shutdown and DBType are implemented by hand in
+// metricsstore.go
+
+package metricsstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+func (s *MetricsStore) AddUpdateCategoryBoard(ctx context.Context, userID string, categoryID string, blockID string) error {
+	callStart := time.Now()
+	err := s.store.AddUpdateCategoryBoard(ctx, userID, categoryID, blockID)
+	s.metrics.ObserveQuery("AddUpdateCategoryBoard", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) ArchiveBoard(ctx context.Context, boardID string, userID string) error {
+	callStart := time.Now()
+	err := s.store.ArchiveBoard(ctx, boardID, userID)
+	s.metrics.ObserveQuery("ArchiveBoard", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) ArchiveCard(ctx context.Context, cardID string, userID string) error {
+	callStart := time.Now()
+	err := s.store.ArchiveCard(ctx, cardID, userID)
+	s.metrics.ObserveQuery("ArchiveCard", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) CanSeeUser(ctx context.Context, seerID string, seenID string) (bool, error) {
+	callStart := time.Now()
+	result, err := s.store.CanSeeUser(ctx, seerID, seenID)
+	s.metrics.ObserveQuery("CanSeeUser", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) CleanUpSessions(ctx context.Context, idleTimeout time.Duration, maxLifetime time.Duration, batchSize int64) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.CleanUpSessions(ctx, idleTimeout, maxLifetime, batchSize)
+	s.metrics.ObserveQuery("CleanUpSessions", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) ClearCategory(ctx context.Context, userID string, categoryID string) error {
+	callStart := time.Now()
+	err := s.store.ClearCategory(ctx, userID, categoryID)
+	s.metrics.ObserveQuery("ClearCategory", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) CompareAndSetSystemSetting(ctx context.Context, key string, expectedOld string, newValue string) (bool, error) {
+	callStart := time.Now()
+	result, err := s.store.CompareAndSetSystemSetting(ctx, key, expectedOld, newValue)
+	s.metrics.ObserveQuery("CompareAndSetSystemSetting", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) CopyBlocks(ctx context.Context, blockIDs []string, targetBoardID string, userID string) (map[string]string, error) {
+	callStart := time.Now()
+	result, err := s.store.CopyBlocks(ctx, blockIDs, targetBoardID, userID)
+	s.metrics.ObserveQuery("CopyBlocks", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) CountBoardsCreatedBetween(ctx context.Context, teamID string, start int64, end int64) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.CountBoardsCreatedBetween(ctx, teamID, start, end)
+	s.metrics.ObserveQuery("CountBoardsCreatedBetween", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) CountBoardsCreatedBetweenAllTeams(ctx context.Context, start int64, end int64) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.CountBoardsCreatedBetweenAllTeams(ctx, start, end)
+	s.metrics.ObserveQuery("CountBoardsCreatedBetweenAllTeams", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) CountCardsByPropertyGrouped(ctx context.Context, boardID string, propertyID string) (map[string]int64, error) {
+	callStart := time.Now()
+	result, err := s.store.CountCardsByPropertyGrouped(ctx, boardID, propertyID)
+	s.metrics.ObserveQuery("CountCardsByPropertyGrouped", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) CreateBoardComplete(ctx context.Context, board *model.Board, creatorID string, extraMembers []*model.BoardMember, categoryID string) (*model.Board, []*model.BoardMember, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.CreateBoardComplete(ctx, board, creatorID, extraMembers, categoryID)
+	s.metrics.ObserveQuery("CreateBoardComplete", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) CreateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole) error {
+	callStart := time.Now()
+	err := s.store.CreateBoardCustomRole(ctx, role)
+	s.metrics.ObserveQuery("CreateBoardCustomRole", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) CreateBoardsAndBlocks(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	callStart := time.Now()
+	result, err := s.store.CreateBoardsAndBlocks(ctx, bab, userID)
+	s.metrics.ObserveQuery("CreateBoardsAndBlocks", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) CreateBoardsAndBlocksWithAdmin(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.CreateBoardsAndBlocksWithAdmin(ctx, bab, userID)
+	s.metrics.ObserveQuery("CreateBoardsAndBlocksWithAdmin", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) CreateCategory(ctx context.Context, category model.Category) error {
+	callStart := time.Now()
+	err := s.store.CreateCategory(ctx, category)
+	s.metrics.ObserveQuery("CreateCategory", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) CreateDefaultCategoriesForUser(ctx context.Context, userID string, teamID string) error {
+	callStart := time.Now()
+	err := s.store.CreateDefaultCategoriesForUser(ctx, userID, teamID)
+	s.metrics.ObserveQuery("CreateDefaultCategoriesForUser", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) CreateSession(ctx context.Context, session *model.Session) error {
+	callStart := time.Now()
+	err := s.store.CreateSession(ctx, session)
+	s.metrics.ObserveQuery("CreateSession", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) CreateShareToken(ctx context.Context, shareToken *model.ShareToken) error {
+	callStart := time.Now()
+	err := s.store.CreateShareToken(ctx, shareToken)
+	s.metrics.ObserveQuery("CreateShareToken", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) CreateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	callStart := time.Now()
+	result, err := s.store.CreateSubscription(ctx, sub)
+	s.metrics.ObserveQuery("CreateSubscription", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	callStart := time.Now()
+	result, err := s.store.CreateUser(ctx, user)
+	s.metrics.ObserveQuery("CreateUser", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	callStart := time.Now()
+	err := s.store.CreateWebhook(ctx, webhook)
+	s.metrics.ObserveQuery("CreateWebhook", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeactivateUser(ctx context.Context, userID string) error {
+	callStart := time.Now()
+	err := s.store.DeactivateUser(ctx, userID)
+	s.metrics.ObserveQuery("DeactivateUser", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteBlock(ctx context.Context, blockID string, modifiedBy string) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.DeleteBlock(ctx, blockID, modifiedBy)
+	s.metrics.ObserveQuery("DeleteBlock", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) DeleteBoard(ctx context.Context, boardID string, userID string) error {
+	callStart := time.Now()
+	err := s.store.DeleteBoard(ctx, boardID, userID)
+	s.metrics.ObserveQuery("DeleteBoard", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteBoardCustomRole(ctx context.Context, id string, userID string) error {
+	callStart := time.Now()
+	err := s.store.DeleteBoardCustomRole(ctx, id, userID)
+	s.metrics.ObserveQuery("DeleteBoardCustomRole", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteBoardsAndBlocks(ctx context.Context, dbab *model.DeleteBoardsAndBlocks, userID string) error {
+	callStart := time.Now()
+	err := s.store.DeleteBoardsAndBlocks(ctx, dbab, userID)
+	s.metrics.ObserveQuery("DeleteBoardsAndBlocks", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteCategory(ctx context.Context, categoryID string, userID string, teamID string) error {
+	callStart := time.Now()
+	err := s.store.DeleteCategory(ctx, categoryID, userID, teamID)
+	s.metrics.ObserveQuery("DeleteCategory", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteMember(ctx context.Context, boardID string, userID string, modifiedBy string) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.DeleteMember(ctx, boardID, userID, modifiedBy)
+	s.metrics.ObserveQuery("DeleteMember", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) DeleteMembers(ctx context.Context, boardID string, userIDs []string, modifiedBy string) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.DeleteMembers(ctx, boardID, userIDs, modifiedBy)
+	s.metrics.ObserveQuery("DeleteMembers", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) DeleteNotificationHint(ctx context.Context, blockID string) error {
+	callStart := time.Now()
+	err := s.store.DeleteNotificationHint(ctx, blockID)
+	s.metrics.ObserveQuery("DeleteNotificationHint", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteNotificationHints(ctx context.Context, blockIDs []string) error {
+	callStart := time.Now()
+	err := s.store.DeleteNotificationHints(ctx, blockIDs)
+	s.metrics.ObserveQuery("DeleteNotificationHints", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteSession(ctx context.Context, sessionID string) error {
+	callStart := time.Now()
+	err := s.store.DeleteSession(ctx, sessionID)
+	s.metrics.ObserveQuery("DeleteSession", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteShareToken(ctx context.Context, boardID string, id string, userID string) error {
+	callStart := time.Now()
+	err := s.store.DeleteShareToken(ctx, boardID, id, userID)
+	s.metrics.ObserveQuery("DeleteShareToken", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteSubscription(ctx context.Context, blockID string, subscriberID string) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.DeleteSubscription(ctx, blockID, subscriberID)
+	s.metrics.ObserveQuery("DeleteSubscription", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) DeleteSubscriptionsForBlock(ctx context.Context, blockID string) error {
+	callStart := time.Now()
+	err := s.store.DeleteSubscriptionsForBlock(ctx, blockID)
+	s.metrics.ObserveQuery("DeleteSubscriptionsForBlock", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DeleteTeam(ctx context.Context, teamID string) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.DeleteTeam(ctx, teamID)
+	s.metrics.ObserveQuery("DeleteTeam", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) DeleteWebhook(ctx context.Context, id string, userID string) error {
+	callStart := time.Now()
+	err := s.store.DeleteWebhook(ctx, id, userID)
+	s.metrics.ObserveQuery("DeleteWebhook", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) DuplicateBlock(ctx context.Context, boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.DuplicateBlock(ctx, boardID, blockID, userID, asTemplate)
+	s.metrics.ObserveQuery("DuplicateBlock", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) DuplicateBoard(ctx context.Context, boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.DuplicateBoard(ctx, boardID, userID, toTeam, asTemplate)
+	s.metrics.ObserveQuery("DuplicateBoard", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) DuplicateBoardWithOptions(ctx context.Context, boardID string, userID string, opts model.DuplicateBoardOptions) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.DuplicateBoardWithOptions(ctx, boardID, userID, opts)
+	s.metrics.ObserveQuery("DuplicateBoardWithOptions", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) FindDuplicateCategories(ctx context.Context, userID string, teamID string) (map[string][]model.Category, error) {
+	callStart := time.Now()
+	result, err := s.store.FindDuplicateCategories(ctx, userID, teamID)
+	s.metrics.ObserveQuery("FindDuplicateCategories", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetActiveUserCount(ctx context.Context, updatedSecondsAgo int64) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.GetActiveUserCount(ctx, updatedSecondsAgo)
+	s.metrics.ObserveQuery("GetActiveUserCount", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetActiveUserCountByTeam(ctx context.Context, teamID string, since int64) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.GetActiveUserCountByTeam(ctx, teamID, since)
+	s.metrics.ObserveQuery("GetActiveUserCountByTeam", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetActiveUserCountsByDay(ctx context.Context, teamID string, from int64, to int64) (map[int64]int, error) {
+	callStart := time.Now()
+	result, err := s.store.GetActiveUserCountsByDay(ctx, teamID, from, to)
+	s.metrics.ObserveQuery("GetActiveUserCountsByDay", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetAllCategoriesForTeam(ctx context.Context, teamID string) ([]model.Category, error) {
+	callStart := time.Now()
+	result, err := s.store.GetAllCategoriesForTeam(ctx, teamID)
+	s.metrics.ObserveQuery("GetAllCategoriesForTeam", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetAllTeams(ctx context.Context) ([]*model.Team, error) {
+	callStart := time.Now()
+	result, err := s.store.GetAllTeams(ctx)
+	s.metrics.ObserveQuery("GetAllTeams", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetArchivedBoards(ctx context.Context, teamID string) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetArchivedBoards(ctx, teamID)
+	s.metrics.ObserveQuery("GetArchivedBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetArchivedCards(ctx context.Context, boardID string) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetArchivedCards(ctx, boardID)
+	s.metrics.ObserveQuery("GetArchivedCards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetAuditRecords(ctx context.Context, boardID string, opts model.QueryAuditOptions) ([]*model.AuditRecord, error) {
+	callStart := time.Now()
+	result, err := s.store.GetAuditRecords(ctx, boardID, opts)
+	s.metrics.ObserveQuery("GetAuditRecords", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlock(ctx context.Context, blockID string) (*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlock(ctx, blockID)
+	s.metrics.ObserveQuery("GetBlock", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlockCountForTeam(ctx context.Context, teamID string) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlockCountForTeam(ctx, teamID)
+	s.metrics.ObserveQuery("GetBlockCountForTeam", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlockCountsByType(ctx context.Context) (map[string]int64, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlockCountsByType(ctx)
+	s.metrics.ObserveQuery("GetBlockCountsByType", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlockCountsByTypeForBoard(ctx context.Context, boardID string) (map[string]int64, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlockCountsByTypeForBoard(ctx, boardID)
+	s.metrics.ObserveQuery("GetBlockCountsByTypeForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlockCountsForTeams(ctx context.Context, teamIDs []string) (map[string]int64, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlockCountsForTeams(ctx, teamIDs)
+	s.metrics.ObserveQuery("GetBlockCountsForTeams", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlockHistory(ctx context.Context, blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlockHistory(ctx, blockID, opts)
+	s.metrics.ObserveQuery("GetBlockHistory", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlockHistoryDescendants(ctx context.Context, boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlockHistoryDescendants(ctx, boardID, opts)
+	s.metrics.ObserveQuery("GetBlockHistoryDescendants", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlockHistoryEntry(ctx context.Context, blockID string, version int64) (*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlockHistoryEntry(ctx, blockID, version)
+	s.metrics.ObserveQuery("GetBlockHistoryEntry", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlocks(ctx context.Context, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlocks(ctx, opts)
+	s.metrics.ObserveQuery("GetBlocks", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlocksByIDs(ctx context.Context, ids []string) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlocksByIDs(ctx, ids)
+	s.metrics.ObserveQuery("GetBlocksByIDs", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlocksForBoard(ctx context.Context, boardID string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.GetBlocksForBoard(ctx, boardID, opts)
+	s.metrics.ObserveQuery("GetBlocksForBoard", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) GetBlocksForBoardStream(ctx context.Context, boardID string, fn func(model.Block) error) error {
+	callStart := time.Now()
+	err := s.store.GetBlocksForBoardStream(ctx, boardID, fn)
+	s.metrics.ObserveQuery("GetBlocksForBoardStream", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) GetBlocksForBoards(ctx context.Context, boardIDs []string, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlocksForBoards(ctx, boardIDs, opts)
+	s.metrics.ObserveQuery("GetBlocksForBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlocksMap(ctx context.Context, boardID string, ids []string) (map[string]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlocksMap(ctx, boardID, ids)
+	s.metrics.ObserveQuery("GetBlocksMap", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlocksWithParent(ctx context.Context, boardID string, parentID string) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlocksWithParent(ctx, boardID, parentID)
+	s.metrics.ObserveQuery("GetBlocksWithParent", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlocksWithParentAndType(ctx context.Context, boardID string, parentID string, blockType string) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlocksWithParentAndType(ctx, boardID, parentID, blockType)
+	s.metrics.ObserveQuery("GetBlocksWithParentAndType", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlocksWithParentAndTypes(ctx context.Context, boardID string, parentID string, blockTypes []string) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBlocksWithParentAndTypes(ctx, boardID, parentID, blockTypes)
+	s.metrics.ObserveQuery("GetBlocksWithParentAndTypes", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBlocksWithType(ctx context.Context, boardID string, blockType string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.GetBlocksWithType(ctx, boardID, blockType, opts)
+	s.metrics.ObserveQuery("GetBlocksWithType", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) GetBoard(ctx context.Context, id string) (*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoard(ctx, id)
+	s.metrics.ObserveQuery("GetBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardAndCard(ctx context.Context, block *model.Block) (*model.Board, *model.Block, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.GetBoardAndCard(ctx, block)
+	s.metrics.ObserveQuery("GetBoardAndCard", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) GetBoardAndCardByID(ctx context.Context, blockID string) (*model.Board, *model.Block, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.GetBoardAndCardByID(ctx, blockID)
+	s.metrics.ObserveQuery("GetBoardAndCardByID", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) GetBoardCardProperties(ctx context.Context, boardID string) ([]model.CardProperty, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardCardProperties(ctx, boardID)
+	s.metrics.ObserveQuery("GetBoardCardProperties", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardCount(ctx context.Context) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardCount(ctx)
+	s.metrics.ObserveQuery("GetBoardCount", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardCustomRole(ctx context.Context, id string) (*model.BoardCustomRole, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardCustomRole(ctx, id)
+	s.metrics.ObserveQuery("GetBoardCustomRole", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardCustomRoles(ctx context.Context, boardID string) ([]*model.BoardCustomRole, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardCustomRoles(ctx, boardID)
+	s.metrics.ObserveQuery("GetBoardCustomRoles", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardHistory(ctx context.Context, boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardHistory(ctx, boardID, opts)
+	s.metrics.ObserveQuery("GetBoardHistory", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardMemberCount(ctx context.Context, boardID string) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardMemberCount(ctx, boardID)
+	s.metrics.ObserveQuery("GetBoardMemberCount", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardMemberHistory(ctx context.Context, boardID string, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardMemberHistory(ctx, boardID, userID, limit)
+	s.metrics.ObserveQuery("GetBoardMemberHistory", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardSnapshot(ctx context.Context, boardID string) (*model.BoardSnapshot, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardSnapshot(ctx, boardID)
+	s.metrics.ObserveQuery("GetBoardSnapshot", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardStats(ctx context.Context, boardID string) (*model.BoardStats, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardStats(ctx, boardID)
+	s.metrics.ObserveQuery("GetBoardStats", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardWithStats(ctx context.Context, boardID string, userID string) (*model.BoardWithStats, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardWithStats(ctx, boardID, userID)
+	s.metrics.ObserveQuery("GetBoardWithStats", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoards(ctx context.Context, ids []string) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoards(ctx, ids)
+	s.metrics.ObserveQuery("GetBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardsAdministeredByUser(ctx context.Context, userID string, teamID string) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardsAdministeredByUser(ctx, userID, teamID)
+	s.metrics.ObserveQuery("GetBoardsAdministeredByUser", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardsCreatedFromTemplate(ctx context.Context, templateID string) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardsCreatedFromTemplate(ctx, templateID)
+	s.metrics.ObserveQuery("GetBoardsCreatedFromTemplate", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardsForUserAndTeam(ctx context.Context, userID string, teamID string, includePublicBoards bool) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardsForUserAndTeam(ctx, userID, teamID, includePublicBoards)
+	s.metrics.ObserveQuery("GetBoardsForUserAndTeam", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardsForUserAndTeamWithOptions(ctx context.Context, userID string, teamID string, opts model.QueryBoardsOptions) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardsForUserAndTeamWithOptions(ctx, userID, teamID, opts)
+	s.metrics.ObserveQuery("GetBoardsForUserAndTeamWithOptions", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardsInTeamByIds(ctx context.Context, boardIDs []string, teamID string) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardsInTeamByIds(ctx, boardIDs, teamID)
+	s.metrics.ObserveQuery("GetBoardsInTeamByIds", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetBoardsModifiedSince(ctx context.Context, teamID string, userID string, since int64) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetBoardsModifiedSince(ctx, teamID, userID, since)
+	s.metrics.ObserveQuery("GetBoardsModifiedSince", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetCardLimitTimestamp(ctx context.Context) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.GetCardLimitTimestamp(ctx)
+	s.metrics.ObserveQuery("GetCardLimitTimestamp", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetCardsMissingProperty(ctx context.Context, boardID string, propertyID string) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetCardsMissingProperty(ctx, boardID, propertyID)
+	s.metrics.ObserveQuery("GetCardsMissingProperty", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetCategory(ctx context.Context, id string) (*model.Category, error) {
+	callStart := time.Now()
+	result, err := s.store.GetCategory(ctx, id)
+	s.metrics.ObserveQuery("GetCategory", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetCategoryForBoard(ctx context.Context, userID string, teamID string, boardID string) (*model.Category, error) {
+	callStart := time.Now()
+	result, err := s.store.GetCategoryForBoard(ctx, userID, teamID, boardID)
+	s.metrics.ObserveQuery("GetCategoryForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetChannel(ctx context.Context, teamID string, channelID string) (*mmModel.Channel, error) {
+	callStart := time.Now()
+	result, err := s.store.GetChannel(ctx, teamID, channelID)
+	s.metrics.ObserveQuery("GetChannel", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetCloudLimits(ctx context.Context) (*mmModel.ProductLimits, error) {
+	callStart := time.Now()
+	result, err := s.store.GetCloudLimits(ctx)
+	s.metrics.ObserveQuery("GetCloudLimits", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetDefaultCategoryTemplates(ctx context.Context, teamID string) ([]model.CategoryTemplate, error) {
+	callStart := time.Now()
+	result, err := s.store.GetDefaultCategoryTemplates(ctx, teamID)
+	s.metrics.ObserveQuery("GetDefaultCategoryTemplates", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetDeletedBlocksForBoard(ctx context.Context, boardID string) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetDeletedBlocksForBoard(ctx, boardID)
+	s.metrics.ObserveQuery("GetDeletedBlocksForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetDueNotificationHints(ctx context.Context, now int64, limit int) ([]*model.NotificationHint, error) {
+	callStart := time.Now()
+	result, err := s.store.GetDueNotificationHints(ctx, now, limit)
+	s.metrics.ObserveQuery("GetDueNotificationHints", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetFavoriteBoards(ctx context.Context, userID string, teamID string) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetFavoriteBoards(ctx, userID, teamID)
+	s.metrics.ObserveQuery("GetFavoriteBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetFileInfo(ctx context.Context, id string) (*mmModel.FileInfo, error) {
+	callStart := time.Now()
+	result, err := s.store.GetFileInfo(ctx, id)
+	s.metrics.ObserveQuery("GetFileInfo", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetFileInfosForBoard(ctx context.Context, boardID string) ([]*mmModel.FileInfo, error) {
+	callStart := time.Now()
+	result, err := s.store.GetFileInfosForBoard(ctx, boardID)
+	s.metrics.ObserveQuery("GetFileInfosForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetLastModifiedBlockForBoard(ctx context.Context, boardID string) (*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetLastModifiedBlockForBoard(ctx, boardID)
+	s.metrics.ObserveQuery("GetLastModifiedBlockForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetLicense(ctx context.Context) *mmModel.License {
+	callStart := time.Now()
+	result := s.store.GetLicense(ctx)
+	s.metrics.ObserveQuery("GetLicense", time.Since(callStart), nil)
+	return result
+}
+
+func (s *MetricsStore) GetMemberForBoard(ctx context.Context, boardID string, userID string) (*model.BoardMember, error) {
+	callStart := time.Now()
+	result, err := s.store.GetMemberForBoard(ctx, boardID, userID)
+	s.metrics.ObserveQuery("GetMemberForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetMembersForBoard(ctx context.Context, boardID string) ([]*model.BoardMember, error) {
+	callStart := time.Now()
+	result, err := s.store.GetMembersForBoard(ctx, boardID)
+	s.metrics.ObserveQuery("GetMembersForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetMembersForBoardWithOptions(ctx context.Context, boardID string, opts model.QueryBoardMembersOptions) ([]*model.BoardMember, bool, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.GetMembersForBoardWithOptions(ctx, boardID, opts)
+	s.metrics.ObserveQuery("GetMembersForBoardWithOptions", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) GetMembersForUser(ctx context.Context, userID string) ([]*model.BoardMember, error) {
+	callStart := time.Now()
+	result, err := s.store.GetMembersForUser(ctx, userID)
+	s.metrics.ObserveQuery("GetMembersForUser", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetNextNotificationHint(ctx context.Context, remove bool) (*model.NotificationHint, error) {
+	callStart := time.Now()
+	result, err := s.store.GetNextNotificationHint(ctx, remove)
+	s.metrics.ObserveQuery("GetNextNotificationHint", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetNotificationHint(ctx context.Context, blockID string) (*model.NotificationHint, error) {
+	callStart := time.Now()
+	result, err := s.store.GetNotificationHint(ctx, blockID)
+	s.metrics.ObserveQuery("GetNotificationHint", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetNotificationHints(ctx context.Context, limit int) ([]*model.NotificationHint, error) {
+	callStart := time.Now()
+	result, err := s.store.GetNotificationHints(ctx, limit)
+	s.metrics.ObserveQuery("GetNotificationHints", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetPendingWebhookDeliveries(ctx context.Context, limit int) ([]*model.WebhookDelivery, error) {
+	callStart := time.Now()
+	result, err := s.store.GetPendingWebhookDeliveries(ctx, limit)
+	s.metrics.ObserveQuery("GetPendingWebhookDeliveries", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetRecentComments(ctx context.Context, boardID string, limit int) ([]*model.CommentWithAuthor, error) {
+	callStart := time.Now()
+	result, err := s.store.GetRecentComments(ctx, boardID, limit)
+	s.metrics.ObserveQuery("GetRecentComments", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetRecentlyViewedBoards(ctx context.Context, userID string, teamID string, limit int) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetRecentlyViewedBoards(ctx, userID, teamID, limit)
+	s.metrics.ObserveQuery("GetRecentlyViewedBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetRegisteredUserCount(ctx context.Context) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.GetRegisteredUserCount(ctx)
+	s.metrics.ObserveQuery("GetRegisteredUserCount", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSession(ctx context.Context, token string, expireTime int64) (*model.Session, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSession(ctx, token, expireTime)
+	s.metrics.ObserveQuery("GetSession", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSessionWithPolicy(ctx context.Context, token string, now int64, idleTimeout time.Duration, maxLifetime time.Duration) (*model.Session, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSessionWithPolicy(ctx, token, now, idleTimeout, maxLifetime)
+	s.metrics.ObserveQuery("GetSessionWithPolicy", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSessionWithUser(ctx context.Context, token string, now int64, idleTimeout time.Duration, maxLifetime time.Duration) (*model.Session, *model.User, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.GetSessionWithUser(ctx, token, now, idleTimeout, maxLifetime)
+	s.metrics.ObserveQuery("GetSessionWithUser", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) GetShareToken(ctx context.Context, token string) (*model.ShareToken, error) {
+	callStart := time.Now()
+	result, err := s.store.GetShareToken(ctx, token)
+	s.metrics.ObserveQuery("GetShareToken", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetShareTokensForBoard(ctx context.Context, boardID string) ([]*model.ShareToken, error) {
+	callStart := time.Now()
+	result, err := s.store.GetShareTokensForBoard(ctx, boardID)
+	s.metrics.ObserveQuery("GetShareTokensForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSharing(ctx context.Context, rootID string) (*model.Sharing, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSharing(ctx, rootID)
+	s.metrics.ObserveQuery("GetSharing", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSharingForBoards(ctx context.Context, rootIDs []string) (map[string]*model.Sharing, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSharingForBoards(ctx, rootIDs)
+	s.metrics.ObserveQuery("GetSharingForBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSubTree(ctx context.Context, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSubTree(ctx, boardID, blockID, opts)
+	s.metrics.ObserveQuery("GetSubTree", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSubTree2(ctx context.Context, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSubTree2(ctx, boardID, blockID, opts)
+	s.metrics.ObserveQuery("GetSubTree2", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSubscribersCountForBlock(ctx context.Context, blockID string) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSubscribersCountForBlock(ctx, blockID)
+	s.metrics.ObserveQuery("GetSubscribersCountForBlock", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSubscribersForBlock(ctx context.Context, blockID string, events model.SubscriptionEvents) ([]*model.Subscriber, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSubscribersForBlock(ctx, blockID, events)
+	s.metrics.ObserveQuery("GetSubscribersForBlock", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSubscription(ctx context.Context, blockID string, subscriberID string) (*model.Subscription, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSubscription(ctx, blockID, subscriberID)
+	s.metrics.ObserveQuery("GetSubscription", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSubscriptions(ctx context.Context, subscriberID string) ([]*model.Subscription, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSubscriptions(ctx, subscriberID)
+	s.metrics.ObserveQuery("GetSubscriptions", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSystemSetting(ctx context.Context, key string) (string, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSystemSetting(ctx, key)
+	s.metrics.ObserveQuery("GetSystemSetting", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSystemSettingBool(ctx context.Context, key string) (bool, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSystemSettingBool(ctx, key)
+	s.metrics.ObserveQuery("GetSystemSettingBool", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSystemSettingInt(ctx context.Context, key string) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSystemSettingInt(ctx, key)
+	s.metrics.ObserveQuery("GetSystemSettingInt", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetSystemSettings(ctx context.Context) (map[string]string, error) {
+	callStart := time.Now()
+	result, err := s.store.GetSystemSettings(ctx)
+	s.metrics.ObserveQuery("GetSystemSettings", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetTeam(ctx context.Context, ID string) (*model.Team, error) {
+	callStart := time.Now()
+	result, err := s.store.GetTeam(ctx, ID)
+	s.metrics.ObserveQuery("GetTeam", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetTeamBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	callStart := time.Now()
+	result, err := s.store.GetTeamBoardsInsights(ctx, teamID, userID, since, offset, limit, boardIDs)
+	s.metrics.ObserveQuery("GetTeamBoardsInsights", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetTeamBySignupToken(ctx context.Context, token string) (*model.Team, error) {
+	callStart := time.Now()
+	result, err := s.store.GetTeamBySignupToken(ctx, token)
+	s.metrics.ObserveQuery("GetTeamBySignupToken", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetTeamCount(ctx context.Context) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.GetTeamCount(ctx)
+	s.metrics.ObserveQuery("GetTeamCount", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetTeamsForUser(ctx context.Context, userID string) ([]*model.Team, error) {
+	callStart := time.Now()
+	result, err := s.store.GetTeamsForUser(ctx, userID)
+	s.metrics.ObserveQuery("GetTeamsForUser", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetTeamsForUserWithBoardCounts(ctx context.Context, userID string) ([]model.TeamWithCount, error) {
+	callStart := time.Now()
+	result, err := s.store.GetTeamsForUserWithBoardCounts(ctx, userID)
+	s.metrics.ObserveQuery("GetTeamsForUserWithBoardCounts", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetTemplateBoards(ctx context.Context, teamID string, userID string) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetTemplateBoards(ctx, teamID, userID)
+	s.metrics.ObserveQuery("GetTemplateBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetTemplateBoardsByVersion(ctx context.Context, teamID string, version int) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.GetTemplateBoardsByVersion(ctx, teamID, version)
+	s.metrics.ObserveQuery("GetTemplateBoardsByVersion", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUsedCardsCount(ctx context.Context) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUsedCardsCount(ctx)
+	s.metrics.ObserveQuery("GetUsedCardsCount", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUserBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUserBoardsInsights(ctx, teamID, userID, since, offset, limit, boardIDs)
+	s.metrics.ObserveQuery("GetUserBoardsInsights", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUserByEmail(ctx, email)
+	s.metrics.ObserveQuery("GetUserByEmail", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUserByID(ctx, userID)
+	s.metrics.ObserveQuery("GetUserByID", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUserByUsername(ctx, username)
+	s.metrics.ObserveQuery("GetUserByUsername", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUserCategoryBoards(ctx context.Context, userID string, teamID string) ([]model.CategoryBoards, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUserCategoryBoards(ctx, userID, teamID)
+	s.metrics.ObserveQuery("GetUserCategoryBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUserPreferences(ctx context.Context, userID string) (mmModel.Preferences, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUserPreferences(ctx, userID)
+	s.metrics.ObserveQuery("GetUserPreferences", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUserTimezone(ctx context.Context, userID string) (string, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUserTimezone(ctx, userID)
+	s.metrics.ObserveQuery("GetUserTimezone", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUsersByTeam(ctx context.Context, teamID string, asGuestID string) ([]*model.User, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUsersByTeam(ctx, teamID, asGuestID)
+	s.metrics.ObserveQuery("GetUsersByTeam", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUsersByTeamWithRole(ctx context.Context, teamID string, role string, includeDeleted bool) ([]*model.User, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUsersByTeamWithRole(ctx, teamID, role, includeDeleted)
+	s.metrics.ObserveQuery("GetUsersByTeamWithRole", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetUsersList(ctx context.Context, userIDs []string) ([]*model.User, error) {
+	callStart := time.Now()
+	result, err := s.store.GetUsersList(ctx, userIDs)
+	s.metrics.ObserveQuery("GetUsersList", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetWebhook(ctx context.Context, id string) (*model.Webhook, error) {
+	callStart := time.Now()
+	result, err := s.store.GetWebhook(ctx, id)
+	s.metrics.ObserveQuery("GetWebhook", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) GetWebhooksForBoard(ctx context.Context, boardID string) ([]*model.Webhook, error) {
+	callStart := time.Now()
+	result, err := s.store.GetWebhooksForBoard(ctx, boardID)
+	s.metrics.ObserveQuery("GetWebhooksForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) InsertAuditRecord(ctx context.Context, rec *model.AuditRecord) error {
+	callStart := time.Now()
+	err := s.store.InsertAuditRecord(ctx, rec)
+	s.metrics.ObserveQuery("InsertAuditRecord", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) InsertBlock(ctx context.Context, block *model.Block, userID string) error {
+	callStart := time.Now()
+	err := s.store.InsertBlock(ctx, block, userID)
+	s.metrics.ObserveQuery("InsertBlock", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) InsertBlocks(ctx context.Context, blocks []*model.Block, userID string) error {
+	callStart := time.Now()
+	err := s.store.InsertBlocks(ctx, blocks, userID)
+	s.metrics.ObserveQuery("InsertBlocks", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) InsertBoard(ctx context.Context, board *model.Board, userID string) (*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.InsertBoard(ctx, board, userID)
+	s.metrics.ObserveQuery("InsertBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) InsertBoardWithAdmin(ctx context.Context, board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
+	callStart := time.Now()
+	result, resultVar1, err := s.store.InsertBoardWithAdmin(ctx, board, userID)
+	s.metrics.ObserveQuery("InsertBoardWithAdmin", time.Since(callStart), err)
+	return result, resultVar1, err
+}
+
+func (s *MetricsStore) MarkWebhookDelivered(ctx context.Context, id string, status int) error {
+	callStart := time.Now()
+	err := s.store.MarkWebhookDelivered(ctx, id, status)
+	s.metrics.ObserveQuery("MarkWebhookDelivered", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) MergeBoards(ctx context.Context, sourceBoardID string, targetBoardID string, userID string) (map[string]string, error) {
+	callStart := time.Now()
+	result, err := s.store.MergeBoards(ctx, sourceBoardID, targetBoardID, userID)
+	s.metrics.ObserveQuery("MergeBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) MergeCategories(ctx context.Context, userID string, primaryCategoryID string, mergeCategoryIDs []string) error {
+	callStart := time.Now()
+	err := s.store.MergeCategories(ctx, userID, primaryCategoryID, mergeCategoryIDs)
+	s.metrics.ObserveQuery("MergeCategories", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) MoveBlocks(ctx context.Context, blockIDs []string, targetBoardID string, userID string) error {
+	callStart := time.Now()
+	err := s.store.MoveBlocks(ctx, blockIDs, targetBoardID, userID)
+	s.metrics.ObserveQuery("MoveBlocks", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) NormalizeContentOrder(ctx context.Context, cardID string) error {
+	callStart := time.Now()
+	err := s.store.NormalizeContentOrder(ctx, cardID)
+	s.metrics.ObserveQuery("NormalizeContentOrder", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) PatchBlock(ctx context.Context, blockID string, blockPatch *model.BlockPatch, userID string) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.PatchBlock(ctx, blockID, blockPatch, userID)
+	s.metrics.ObserveQuery("PatchBlock", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) PatchBlocks(ctx context.Context, blockPatches *model.BlockPatchBatch, userID string) error {
+	callStart := time.Now()
+	err := s.store.PatchBlocks(ctx, blockPatches, userID)
+	s.metrics.ObserveQuery("PatchBlocks", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) PatchBoard(ctx context.Context, boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.PatchBoard(ctx, boardID, boardPatch, userID)
+	s.metrics.ObserveQuery("PatchBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) PatchBoardsAndBlocks(ctx context.Context, pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	callStart := time.Now()
+	result, err := s.store.PatchBoardsAndBlocks(ctx, pbab, userID)
+	s.metrics.ObserveQuery("PatchBoardsAndBlocks", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) PatchUserPreferences(ctx context.Context, userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
+	callStart := time.Now()
+	result, err := s.store.PatchUserPreferences(ctx, userID, patch)
+	s.metrics.ObserveQuery("PatchUserPreferences", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) PostMessage(ctx context.Context, message string, postType string, channelID string) error {
+	callStart := time.Now()
+	err := s.store.PostMessage(ctx, message, postType, channelID)
+	s.metrics.ObserveQuery("PostMessage", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) PurgeArchivedBoards(ctx context.Context, olderThan time.Time) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.PurgeArchivedBoards(ctx, olderThan)
+	s.metrics.ObserveQuery("PurgeArchivedBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) PurgeDeletedBlocks(ctx context.Context, olderThan time.Time) (int, error) {
+	callStart := time.Now()
+	result, err := s.store.PurgeDeletedBlocks(ctx, olderThan)
+	s.metrics.ObserveQuery("PurgeDeletedBlocks", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) ReactivateUser(ctx context.Context, userID string) error {
+	callStart := time.Now()
+	err := s.store.ReactivateUser(ctx, userID)
+	s.metrics.ObserveQuery("ReactivateUser", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) RecordWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	callStart := time.Now()
+	err := s.store.RecordWebhookDelivery(ctx, delivery)
+	s.metrics.ObserveQuery("RecordWebhookDelivery", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) RefreshSession(ctx context.Context, session *model.Session) error {
+	callStart := time.Now()
+	err := s.store.RefreshSession(ctx, session)
+	s.metrics.ObserveQuery("RefreshSession", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) ReinstallDefaultTemplates(ctx context.Context, teamID string, templates []*model.Board, userID string) error {
+	callStart := time.Now()
+	err := s.store.ReinstallDefaultTemplates(ctx, teamID, templates, userID)
+	s.metrics.ObserveQuery("ReinstallDefaultTemplates", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) RemoveCategoryBoards(ctx context.Context, userID string, categoryID string, boardIDs []string) error {
+	callStart := time.Now()
+	err := s.store.RemoveCategoryBoards(ctx, userID, categoryID, boardIDs)
+	s.metrics.ObserveQuery("RemoveCategoryBoards", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) RemoveDefaultTemplates(ctx context.Context, boards []*model.Board) error {
+	callStart := time.Now()
+	err := s.store.RemoveDefaultTemplates(ctx, boards)
+	s.metrics.ObserveQuery("RemoveDefaultTemplates", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) ReorderCategories(ctx context.Context, userID string, teamID string, categoryIDs []string) error {
+	callStart := time.Now()
+	err := s.store.ReorderCategories(ctx, userID, teamID, categoryIDs)
+	s.metrics.ObserveQuery("ReorderCategories", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) ReorderCategoryBoards(ctx context.Context, userID string, categoryID string, boardIDs []string) error {
+	callStart := time.Now()
+	err := s.store.ReorderCategoryBoards(ctx, userID, categoryID, boardIDs)
+	s.metrics.ObserveQuery("ReorderCategoryBoards", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) RestoreBlock(ctx context.Context, blockID string, userID string) (*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.RestoreBlock(ctx, blockID, userID)
+	s.metrics.ObserveQuery("RestoreBlock", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) RestoreBoard(ctx context.Context, boardID string, userID string) error {
+	callStart := time.Now()
+	err := s.store.RestoreBoard(ctx, boardID, userID)
+	s.metrics.ObserveQuery("RestoreBoard", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) RotateSharingToken(ctx context.Context, rootID string, userID string) (*model.Sharing, error) {
+	callStart := time.Now()
+	result, err := s.store.RotateSharingToken(ctx, rootID, userID)
+	s.metrics.ObserveQuery("RotateSharingToken", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) RunDataRetention(ctx context.Context, globalRetentionDate int64, batchSize int64) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.RunDataRetention(ctx, globalRetentionDate, batchSize)
+	s.metrics.ObserveQuery("RunDataRetention", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SaveFileInfo(ctx context.Context, fileInfo *mmModel.FileInfo) error {
+	callStart := time.Now()
+	err := s.store.SaveFileInfo(ctx, fileInfo)
+	s.metrics.ObserveQuery("SaveFileInfo", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) SaveMember(ctx context.Context, bm *model.BoardMember, modifiedBy string) (*model.BoardMember, error) {
+	callStart := time.Now()
+	result, err := s.store.SaveMember(ctx, bm, modifiedBy)
+	s.metrics.ObserveQuery("SaveMember", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SaveMemberWithLimit(ctx context.Context, bm *model.BoardMember, maxMembers int, modifiedBy string) (*model.BoardMember, error) {
+	callStart := time.Now()
+	result, err := s.store.SaveMemberWithLimit(ctx, bm, maxMembers, modifiedBy)
+	s.metrics.ObserveQuery("SaveMemberWithLimit", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SaveMembers(ctx context.Context, members []*model.BoardMember, modifiedBy string) ([]*model.BoardMember, error) {
+	callStart := time.Now()
+	result, err := s.store.SaveMembers(ctx, members, modifiedBy)
+	s.metrics.ObserveQuery("SaveMembers", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SearchBlocksForBoard(ctx context.Context, boardID string, term string, fields []string) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.SearchBlocksForBoard(ctx, boardID, term, fields)
+	s.metrics.ObserveQuery("SearchBlocksForBoard", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SearchBlocksForUser(ctx context.Context, teamID string, term string, userID string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.SearchBlocksForUser(ctx, teamID, term, userID, opts)
+	s.metrics.ObserveQuery("SearchBlocksForUser", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SearchBlocksInBoards(ctx context.Context, boardIDs []string, term string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	callStart := time.Now()
+	result, err := s.store.SearchBlocksInBoards(ctx, boardIDs, term, opts)
+	s.metrics.ObserveQuery("SearchBlocksInBoards", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SearchBoardsForUser(ctx context.Context, term string, userID string, includePublicBoards bool) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.SearchBoardsForUser(ctx, term, userID, includePublicBoards)
+	s.metrics.ObserveQuery("SearchBoardsForUser", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SearchBoardsForUserInTeam(ctx context.Context, teamID string, term string, userID string) ([]*model.Board, error) {
+	callStart := time.Now()
+	result, err := s.store.SearchBoardsForUserInTeam(ctx, teamID, term, userID)
+	s.metrics.ObserveQuery("SearchBoardsForUserInTeam", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SearchUserChannels(ctx context.Context, teamID string, userID string, query string) ([]*mmModel.Channel, error) {
+	callStart := time.Now()
+	result, err := s.store.SearchUserChannels(ctx, teamID, userID, query)
+	s.metrics.ObserveQuery("SearchUserChannels", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SearchUsersByTeam(ctx context.Context, teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
+	callStart := time.Now()
+	result, err := s.store.SearchUsersByTeam(ctx, teamID, searchQuery, asGuestID, excludeBots)
+	s.metrics.ObserveQuery("SearchUsersByTeam", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) SendMessage(ctx context.Context, message string, postType string, receipts []string) error {
+	callStart := time.Now()
+	err := s.store.SendMessage(ctx, message, postType, receipts)
+	s.metrics.ObserveQuery("SendMessage", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) SetBoardFavorite(ctx context.Context, userID string, boardID string, favorite bool) error {
+	callStart := time.Now()
+	err := s.store.SetBoardFavorite(ctx, userID, boardID, favorite)
+	s.metrics.ObserveQuery("SetBoardFavorite", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) SetBoardPropertyOrder(ctx context.Context, boardID string, propertyIDs []string, userID string) error {
+	callStart := time.Now()
+	err := s.store.SetBoardPropertyOrder(ctx, boardID, propertyIDs, userID)
+	s.metrics.ObserveQuery("SetBoardPropertyOrder", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) SetMemberCustomRole(ctx context.Context, boardID string, userID string, roleID string, modifiedBy string) error {
+	callStart := time.Now()
+	err := s.store.SetMemberCustomRole(ctx, boardID, userID, roleID, modifiedBy)
+	s.metrics.ObserveQuery("SetMemberCustomRole", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) SetSystemSetting(ctx context.Context, key string, value string) error {
+	callStart := time.Now()
+	err := s.store.SetSystemSetting(ctx, key, value)
+	s.metrics.ObserveQuery("SetSystemSetting", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UnarchiveCard(ctx context.Context, cardID string, userID string) error {
+	callStart := time.Now()
+	err := s.store.UnarchiveCard(ctx, cardID, userID)
+	s.metrics.ObserveQuery("UnarchiveCard", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UndeleteBlock(ctx context.Context, blockID string, modifiedBy string) error {
+	callStart := time.Now()
+	err := s.store.UndeleteBlock(ctx, blockID, modifiedBy)
+	s.metrics.ObserveQuery("UndeleteBlock", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UndeleteBoard(ctx context.Context, boardID string, modifiedBy string) error {
+	callStart := time.Now()
+	err := s.store.UndeleteBoard(ctx, boardID, modifiedBy)
+	s.metrics.ObserveQuery("UndeleteBoard", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole, userID string) error {
+	callStart := time.Now()
+	err := s.store.UpdateBoardCustomRole(ctx, role, userID)
+	s.metrics.ObserveQuery("UpdateBoardCustomRole", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateCardLimitTimestamp(ctx context.Context, cardLimit int) (int64, error) {
+	callStart := time.Now()
+	result, err := s.store.UpdateCardLimitTimestamp(ctx, cardLimit)
+	s.metrics.ObserveQuery("UpdateCardLimitTimestamp", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) UpdateCategory(ctx context.Context, category model.Category) error {
+	callStart := time.Now()
+	err := s.store.UpdateCategory(ctx, category)
+	s.metrics.ObserveQuery("UpdateCategory", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateMemberLastViewed(ctx context.Context, boardID string, userID string, viewedAt int64) error {
+	callStart := time.Now()
+	err := s.store.UpdateMemberLastViewed(ctx, boardID, userID, viewedAt)
+	s.metrics.ObserveQuery("UpdateMemberLastViewed", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateMemberRole(ctx context.Context, boardID string, userID string, role string, modifiedBy string) error {
+	callStart := time.Now()
+	err := s.store.UpdateMemberRole(ctx, boardID, userID, role, modifiedBy)
+	s.metrics.ObserveQuery("UpdateMemberRole", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateSession(ctx context.Context, session *model.Session) error {
+	callStart := time.Now()
+	err := s.store.UpdateSession(ctx, session)
+	s.metrics.ObserveQuery("UpdateSession", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateSubscriberNotifiedAt(ctx context.Context, blockID string, subscriberID string, notifiedAt int64) error {
+	callStart := time.Now()
+	err := s.store.UpdateSubscriberNotifiedAt(ctx, blockID, subscriberID, notifiedAt)
+	s.metrics.ObserveQuery("UpdateSubscriberNotifiedAt", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateSubscribersNotifiedAt(ctx context.Context, blockID string, notifiedAt int64) error {
+	callStart := time.Now()
+	err := s.store.UpdateSubscribersNotifiedAt(ctx, blockID, notifiedAt)
+	s.metrics.ObserveQuery("UpdateSubscribersNotifiedAt", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	callStart := time.Now()
+	result, err := s.store.UpdateSubscription(ctx, sub)
+	s.metrics.ObserveQuery("UpdateSubscription", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	callStart := time.Now()
+	result, err := s.store.UpdateUser(ctx, user)
+	s.metrics.ObserveQuery("UpdateUser", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) UpdateUserPassword(ctx context.Context, username string, password string) error {
+	callStart := time.Now()
+	err := s.store.UpdateUserPassword(ctx, username, password)
+	s.metrics.ObserveQuery("UpdateUserPassword", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateUserPasswordByID(ctx context.Context, userID string, password string) error {
+	callStart := time.Now()
+	err := s.store.UpdateUserPasswordByID(ctx, userID, password)
+	s.metrics.ObserveQuery("UpdateUserPasswordByID", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpdateWebhook(ctx context.Context, webhook *model.Webhook, userID string) error {
+	callStart := time.Now()
+	err := s.store.UpdateWebhook(ctx, webhook, userID)
+	s.metrics.ObserveQuery("UpdateWebhook", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpgradeDefaultTemplates(ctx context.Context, teamID string, toVersion int, templates *model.BoardsAndBlocks) error {
+	callStart := time.Now()
+	err := s.store.UpgradeDefaultTemplates(ctx, teamID, toVersion, templates)
+	s.metrics.ObserveQuery("UpgradeDefaultTemplates", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpsertBoardSnapshot(ctx context.Context, snapshot *model.BoardSnapshot) error {
+	callStart := time.Now()
+	err := s.store.UpsertBoardSnapshot(ctx, snapshot)
+	s.metrics.ObserveQuery("UpsertBoardSnapshot", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpsertDefaultCategoryTemplate(ctx context.Context, template model.CategoryTemplate) error {
+	callStart := time.Now()
+	err := s.store.UpsertDefaultCategoryTemplate(ctx, template)
+	s.metrics.ObserveQuery("UpsertDefaultCategoryTemplate", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpsertNotificationHint(ctx context.Context, hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
+	callStart := time.Now()
+	result, err := s.store.UpsertNotificationHint(ctx, hint, notificationFreq)
+	s.metrics.ObserveQuery("UpsertNotificationHint", time.Since(callStart), err)
+	return result, err
+}
+
+func (s *MetricsStore) UpsertSharing(ctx context.Context, sharing model.Sharing) error {
+	callStart := time.Now()
+	err := s.store.UpsertSharing(ctx, sharing)
+	s.metrics.ObserveQuery("UpsertSharing", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpsertTeamSettings(ctx context.Context, team model.Team) error {
+	callStart := time.Now()
+	err := s.store.UpsertTeamSettings(ctx, team)
+	s.metrics.ObserveQuery("UpsertTeamSettings", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) UpsertTeamSignupToken(ctx context.Context, team model.Team) error {
+	callStart := time.Now()
+	err := s.store.UpsertTeamSignupToken(ctx, team)
+	s.metrics.ObserveQuery("UpsertTeamSignupToken", time.Since(callStart), err)
+	return err
+}
+
+func (s *MetricsStore) ValidateBoardSchema(ctx context.Context, boardID string) (*model.SchemaReport, error) {
+	callStart := time.Now()
+	result, err := s.store.ValidateBoardSchema(ctx, boardID)
+	s.metrics.ObserveQuery("ValidateBoardSchema", time.Since(callStart), err)
+	return result, err
+}
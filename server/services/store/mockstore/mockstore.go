@@ -5,6 +5,7 @@
 package mockstore
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -37,67 +38,215 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 }
 
 // AddUpdateCategoryBoard mocks base method.
-func (m *MockStore) AddUpdateCategoryBoard(arg0, arg1, arg2 string) error {
+func (m *MockStore) AddUpdateCategoryBoard(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUpdateCategoryBoard", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "AddUpdateCategoryBoard", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddUpdateCategoryBoard indicates an expected call of AddUpdateCategoryBoard.
-func (mr *MockStoreMockRecorder) AddUpdateCategoryBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddUpdateCategoryBoard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpdateCategoryBoard", reflect.TypeOf((*MockStore)(nil).AddUpdateCategoryBoard), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpdateCategoryBoard", reflect.TypeOf((*MockStore)(nil).AddUpdateCategoryBoard), arg0, arg1, arg2, arg3)
+}
+
+// ArchiveBoard mocks base method.
+func (m *MockStore) ArchiveBoard(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveBoard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveBoard indicates an expected call of ArchiveBoard.
+func (mr *MockStoreMockRecorder) ArchiveBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveBoard", reflect.TypeOf((*MockStore)(nil).ArchiveBoard), arg0, arg1, arg2)
+}
+
+// ArchiveCard mocks base method.
+func (m *MockStore) ArchiveCard(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveCard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveCard indicates an expected call of ArchiveCard.
+func (mr *MockStoreMockRecorder) ArchiveCard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveCard", reflect.TypeOf((*MockStore)(nil).ArchiveCard), arg0, arg1, arg2)
 }
 
 // CanSeeUser mocks base method.
-func (m *MockStore) CanSeeUser(arg0, arg1 string) (bool, error) {
+func (m *MockStore) CanSeeUser(arg0 context.Context, arg1, arg2 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CanSeeUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "CanSeeUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CanSeeUser indicates an expected call of CanSeeUser.
-func (mr *MockStoreMockRecorder) CanSeeUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) CanSeeUser(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanSeeUser", reflect.TypeOf((*MockStore)(nil).CanSeeUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanSeeUser", reflect.TypeOf((*MockStore)(nil).CanSeeUser), arg0, arg1, arg2)
 }
 
 // CleanUpSessions mocks base method.
-func (m *MockStore) CleanUpSessions(arg0 int64) error {
+func (m *MockStore) CleanUpSessions(arg0 context.Context, arg1, arg2 time.Duration, arg3 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CleanUpSessions", arg0)
+	ret := m.ctrl.Call(m, "CleanUpSessions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanUpSessions indicates an expected call of CleanUpSessions.
+func (mr *MockStoreMockRecorder) CleanUpSessions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUpSessions", reflect.TypeOf((*MockStore)(nil).CleanUpSessions), arg0, arg1, arg2, arg3)
+}
+
+// ClearCategory mocks base method.
+func (m *MockStore) ClearCategory(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCategory", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CleanUpSessions indicates an expected call of CleanUpSessions.
-func (mr *MockStoreMockRecorder) CleanUpSessions(arg0 interface{}) *gomock.Call {
+// ClearCategory indicates an expected call of ClearCategory.
+func (mr *MockStoreMockRecorder) ClearCategory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCategory", reflect.TypeOf((*MockStore)(nil).ClearCategory), arg0, arg1, arg2)
+}
+
+// CompareAndSetSystemSetting mocks base method.
+func (m *MockStore) CompareAndSetSystemSetting(arg0 context.Context, arg1, arg2, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndSetSystemSetting", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSetSystemSetting indicates an expected call of CompareAndSetSystemSetting.
+func (mr *MockStoreMockRecorder) CompareAndSetSystemSetting(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSetSystemSetting", reflect.TypeOf((*MockStore)(nil).CompareAndSetSystemSetting), arg0, arg1, arg2, arg3)
+}
+
+// CopyBlocks mocks base method.
+func (m *MockStore) CopyBlocks(arg0 context.Context, arg1 []string, arg2, arg3 string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyBlocks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyBlocks indicates an expected call of CopyBlocks.
+func (mr *MockStoreMockRecorder) CopyBlocks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyBlocks", reflect.TypeOf((*MockStore)(nil).CopyBlocks), arg0, arg1, arg2, arg3)
+}
+
+// CountBoardsCreatedBetween mocks base method.
+func (m *MockStore) CountBoardsCreatedBetween(arg0 context.Context, arg1 string, arg2, arg3 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBoardsCreatedBetween", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBoardsCreatedBetween indicates an expected call of CountBoardsCreatedBetween.
+func (mr *MockStoreMockRecorder) CountBoardsCreatedBetween(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBoardsCreatedBetween", reflect.TypeOf((*MockStore)(nil).CountBoardsCreatedBetween), arg0, arg1, arg2, arg3)
+}
+
+// CountBoardsCreatedBetweenAllTeams mocks base method.
+func (m *MockStore) CountBoardsCreatedBetweenAllTeams(arg0 context.Context, arg1, arg2 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBoardsCreatedBetweenAllTeams", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBoardsCreatedBetweenAllTeams indicates an expected call of CountBoardsCreatedBetweenAllTeams.
+func (mr *MockStoreMockRecorder) CountBoardsCreatedBetweenAllTeams(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBoardsCreatedBetweenAllTeams", reflect.TypeOf((*MockStore)(nil).CountBoardsCreatedBetweenAllTeams), arg0, arg1, arg2)
+}
+
+// CountCardsByPropertyGrouped mocks base method.
+func (m *MockStore) CountCardsByPropertyGrouped(arg0 context.Context, arg1, arg2 string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCardsByPropertyGrouped", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCardsByPropertyGrouped indicates an expected call of CountCardsByPropertyGrouped.
+func (mr *MockStoreMockRecorder) CountCardsByPropertyGrouped(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCardsByPropertyGrouped", reflect.TypeOf((*MockStore)(nil).CountCardsByPropertyGrouped), arg0, arg1, arg2)
+}
+
+// CreateBoardComplete mocks base method.
+func (m *MockStore) CreateBoardComplete(arg0 context.Context, arg1 *model.Board, arg2 string, arg3 []*model.BoardMember, arg4 string) (*model.Board, []*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardComplete", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].([]*model.BoardMember)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateBoardComplete indicates an expected call of CreateBoardComplete.
+func (mr *MockStoreMockRecorder) CreateBoardComplete(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardComplete", reflect.TypeOf((*MockStore)(nil).CreateBoardComplete), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateBoardCustomRole mocks base method.
+func (m *MockStore) CreateBoardCustomRole(arg0 context.Context, arg1 *model.BoardCustomRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardCustomRole", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBoardCustomRole indicates an expected call of CreateBoardCustomRole.
+func (mr *MockStoreMockRecorder) CreateBoardCustomRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUpSessions", reflect.TypeOf((*MockStore)(nil).CleanUpSessions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardCustomRole", reflect.TypeOf((*MockStore)(nil).CreateBoardCustomRole), arg0, arg1)
 }
 
 // CreateBoardsAndBlocks mocks base method.
-func (m *MockStore) CreateBoardsAndBlocks(arg0 *model.BoardsAndBlocks, arg1 string) (*model.BoardsAndBlocks, error) {
+func (m *MockStore) CreateBoardsAndBlocks(arg0 context.Context, arg1 *model.BoardsAndBlocks, arg2 string) (*model.BoardsAndBlocks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBoardsAndBlocks", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateBoardsAndBlocks", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.BoardsAndBlocks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateBoardsAndBlocks indicates an expected call of CreateBoardsAndBlocks.
-func (mr *MockStoreMockRecorder) CreateBoardsAndBlocks(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) CreateBoardsAndBlocks(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).CreateBoardsAndBlocks), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).CreateBoardsAndBlocks), arg0, arg1, arg2)
 }
 
 // CreateBoardsAndBlocksWithAdmin mocks base method.
-func (m *MockStore) CreateBoardsAndBlocksWithAdmin(arg0 *model.BoardsAndBlocks, arg1 string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+func (m *MockStore) CreateBoardsAndBlocksWithAdmin(arg0 context.Context, arg1 *model.BoardsAndBlocks, arg2 string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBoardsAndBlocksWithAdmin", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateBoardsAndBlocksWithAdmin", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.BoardsAndBlocks)
 	ret1, _ := ret[1].([]*model.BoardMember)
 	ret2, _ := ret[2].(error)
@@ -105,67 +254,109 @@ func (m *MockStore) CreateBoardsAndBlocksWithAdmin(arg0 *model.BoardsAndBlocks,
 }
 
 // CreateBoardsAndBlocksWithAdmin indicates an expected call of CreateBoardsAndBlocksWithAdmin.
-func (mr *MockStoreMockRecorder) CreateBoardsAndBlocksWithAdmin(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) CreateBoardsAndBlocksWithAdmin(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardsAndBlocksWithAdmin", reflect.TypeOf((*MockStore)(nil).CreateBoardsAndBlocksWithAdmin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardsAndBlocksWithAdmin", reflect.TypeOf((*MockStore)(nil).CreateBoardsAndBlocksWithAdmin), arg0, arg1, arg2)
 }
 
 // CreateCategory mocks base method.
-func (m *MockStore) CreateCategory(arg0 model.Category) error {
+func (m *MockStore) CreateCategory(arg0 context.Context, arg1 model.Category) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCategory", arg0)
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateCategory indicates an expected call of CreateCategory.
-func (mr *MockStoreMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
+}
+
+// CreateDefaultCategoriesForUser mocks base method.
+func (m *MockStore) CreateDefaultCategoriesForUser(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDefaultCategoriesForUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDefaultCategoriesForUser indicates an expected call of CreateDefaultCategoriesForUser.
+func (mr *MockStoreMockRecorder) CreateDefaultCategoriesForUser(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDefaultCategoriesForUser", reflect.TypeOf((*MockStore)(nil).CreateDefaultCategoriesForUser), arg0, arg1, arg2)
 }
 
 // CreateSession mocks base method.
-func (m *MockStore) CreateSession(arg0 *model.Session) error {
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 *model.Session) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", arg0)
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateShareToken mocks base method.
+func (m *MockStore) CreateShareToken(arg0 context.Context, arg1 *model.ShareToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShareToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShareToken indicates an expected call of CreateShareToken.
+func (mr *MockStoreMockRecorder) CreateShareToken(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShareToken", reflect.TypeOf((*MockStore)(nil).CreateShareToken), arg0, arg1)
 }
 
 // CreateSubscription mocks base method.
-func (m *MockStore) CreateSubscription(arg0 *model.Subscription) (*model.Subscription, error) {
+func (m *MockStore) CreateSubscription(arg0 context.Context, arg1 *model.Subscription) (*model.Subscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSubscription", arg0)
+	ret := m.ctrl.Call(m, "CreateSubscription", arg0, arg1)
 	ret0, _ := ret[0].(*model.Subscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSubscription indicates an expected call of CreateSubscription.
-func (mr *MockStoreMockRecorder) CreateSubscription(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) CreateSubscription(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockStore)(nil).CreateSubscription), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockStore)(nil).CreateSubscription), arg0, arg1)
 }
 
 // CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 *model.User) (*model.User, error) {
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 *model.User) (*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0)
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
 	ret0, _ := ret[0].(*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockStore) CreateWebhook(arg0 context.Context, arg1 *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockStoreMockRecorder) CreateWebhook(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockStore)(nil).CreateWebhook), arg0, arg1)
 }
 
 // DBType mocks base method.
@@ -182,137 +373,254 @@ func (mr *MockStoreMockRecorder) DBType() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBType", reflect.TypeOf((*MockStore)(nil).DBType))
 }
 
-// DeleteBlock mocks base method.
-func (m *MockStore) DeleteBlock(arg0, arg1 string) error {
+// DeactivateUser mocks base method.
+func (m *MockStore) DeactivateUser(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBlock", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeactivateUser", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockStoreMockRecorder) DeactivateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockStore)(nil).DeactivateUser), arg0, arg1)
+}
+
+// DeleteBlock mocks base method.
+func (m *MockStore) DeleteBlock(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DeleteBlock indicates an expected call of DeleteBlock.
-func (mr *MockStoreMockRecorder) DeleteBlock(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DeleteBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockStore)(nil).DeleteBlock), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockStore)(nil).DeleteBlock), arg0, arg1, arg2)
 }
 
 // DeleteBoard mocks base method.
-func (m *MockStore) DeleteBoard(arg0, arg1 string) error {
+func (m *MockStore) DeleteBoard(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBoard", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteBoard", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteBoard indicates an expected call of DeleteBoard.
-func (mr *MockStoreMockRecorder) DeleteBoard(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DeleteBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*MockStore)(nil).DeleteBoard), arg0, arg1, arg2)
+}
+
+// DeleteBoardCustomRole mocks base method.
+func (m *MockStore) DeleteBoardCustomRole(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardCustomRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardCustomRole indicates an expected call of DeleteBoardCustomRole.
+func (mr *MockStoreMockRecorder) DeleteBoardCustomRole(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*MockStore)(nil).DeleteBoard), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardCustomRole", reflect.TypeOf((*MockStore)(nil).DeleteBoardCustomRole), arg0, arg1, arg2)
 }
 
 // DeleteBoardsAndBlocks mocks base method.
-func (m *MockStore) DeleteBoardsAndBlocks(arg0 *model.DeleteBoardsAndBlocks, arg1 string) error {
+func (m *MockStore) DeleteBoardsAndBlocks(arg0 context.Context, arg1 *model.DeleteBoardsAndBlocks, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBoardsAndBlocks", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteBoardsAndBlocks", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteBoardsAndBlocks indicates an expected call of DeleteBoardsAndBlocks.
-func (mr *MockStoreMockRecorder) DeleteBoardsAndBlocks(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DeleteBoardsAndBlocks(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBoardsAndBlocks), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBoardsAndBlocks), arg0, arg1, arg2)
 }
 
 // DeleteCategory mocks base method.
-func (m *MockStore) DeleteCategory(arg0, arg1, arg2 string) error {
+func (m *MockStore) DeleteCategory(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1, arg2, arg3)
 }
 
 // DeleteMember mocks base method.
-func (m *MockStore) DeleteMember(arg0, arg1 string) error {
+func (m *MockStore) DeleteMember(arg0 context.Context, arg1, arg2, arg3 string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMember", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteMember", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteMember indicates an expected call of DeleteMember.
-func (mr *MockStoreMockRecorder) DeleteMember(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DeleteMember(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockStore)(nil).DeleteMember), arg0, arg1, arg2, arg3)
+}
+
+// DeleteMembers mocks base method.
+func (m *MockStore) DeleteMembers(arg0 context.Context, arg1 string, arg2 []string, arg3 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMembers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMembers indicates an expected call of DeleteMembers.
+func (mr *MockStoreMockRecorder) DeleteMembers(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockStore)(nil).DeleteMember), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMembers", reflect.TypeOf((*MockStore)(nil).DeleteMembers), arg0, arg1, arg2, arg3)
 }
 
 // DeleteNotificationHint mocks base method.
-func (m *MockStore) DeleteNotificationHint(arg0 string) error {
+func (m *MockStore) DeleteNotificationHint(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNotificationHint", arg0)
+	ret := m.ctrl.Call(m, "DeleteNotificationHint", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteNotificationHint indicates an expected call of DeleteNotificationHint.
-func (mr *MockStoreMockRecorder) DeleteNotificationHint(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DeleteNotificationHint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationHint", reflect.TypeOf((*MockStore)(nil).DeleteNotificationHint), arg0, arg1)
+}
+
+// DeleteNotificationHints mocks base method.
+func (m *MockStore) DeleteNotificationHints(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotificationHints", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotificationHints indicates an expected call of DeleteNotificationHints.
+func (mr *MockStoreMockRecorder) DeleteNotificationHints(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationHint", reflect.TypeOf((*MockStore)(nil).DeleteNotificationHint), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationHints", reflect.TypeOf((*MockStore)(nil).DeleteNotificationHints), arg0, arg1)
 }
 
 // DeleteSession mocks base method.
-func (m *MockStore) DeleteSession(arg0 string) error {
+func (m *MockStore) DeleteSession(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSession", arg0)
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSession indicates an expected call of DeleteSession.
-func (mr *MockStoreMockRecorder) DeleteSession(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DeleteSession(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), arg0, arg1)
 }
 
-// DeleteSubscription mocks base method.
-func (m *MockStore) DeleteSubscription(arg0, arg1 string) error {
+// DeleteShareToken mocks base method.
+func (m *MockStore) DeleteShareToken(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSubscription", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteShareToken", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// DeleteShareToken indicates an expected call of DeleteShareToken.
+func (mr *MockStoreMockRecorder) DeleteShareToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShareToken", reflect.TypeOf((*MockStore)(nil).DeleteShareToken), arg0, arg1, arg2, arg3)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockStore) DeleteSubscription(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DeleteSubscription indicates an expected call of DeleteSubscription.
-func (mr *MockStoreMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DeleteSubscription(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStore)(nil).DeleteSubscription), arg0, arg1, arg2)
+}
+
+// DeleteSubscriptionsForBlock mocks base method.
+func (m *MockStore) DeleteSubscriptionsForBlock(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriptionsForBlock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscriptionsForBlock indicates an expected call of DeleteSubscriptionsForBlock.
+func (mr *MockStoreMockRecorder) DeleteSubscriptionsForBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptionsForBlock", reflect.TypeOf((*MockStore)(nil).DeleteSubscriptionsForBlock), arg0, arg1)
+}
+
+// DeleteTeam mocks base method.
+func (m *MockStore) DeleteTeam(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeam", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTeam indicates an expected call of DeleteTeam.
+func (mr *MockStoreMockRecorder) DeleteTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockStore)(nil).DeleteTeam), arg0, arg1)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockStore) DeleteWebhook(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockStoreMockRecorder) DeleteWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStore)(nil).DeleteSubscription), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockStore)(nil).DeleteWebhook), arg0, arg1, arg2)
 }
 
 // DuplicateBlock mocks base method.
-func (m *MockStore) DuplicateBlock(arg0, arg1, arg2 string, arg3 bool) ([]*model.Block, error) {
+func (m *MockStore) DuplicateBlock(arg0 context.Context, arg1, arg2, arg3 string, arg4 bool) ([]*model.Block, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DuplicateBlock", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "DuplicateBlock", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].([]*model.Block)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DuplicateBlock indicates an expected call of DuplicateBlock.
-func (mr *MockStoreMockRecorder) DuplicateBlock(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DuplicateBlock(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBlock", reflect.TypeOf((*MockStore)(nil).DuplicateBlock), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBlock", reflect.TypeOf((*MockStore)(nil).DuplicateBlock), arg0, arg1, arg2, arg3, arg4)
 }
 
 // DuplicateBoard mocks base method.
-func (m *MockStore) DuplicateBoard(arg0, arg1, arg2 string, arg3 bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+func (m *MockStore) DuplicateBoard(arg0 context.Context, arg1, arg2, arg3 string, arg4 bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DuplicateBoard", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "DuplicateBoard", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*model.BoardsAndBlocks)
 	ret1, _ := ret[1].([]*model.BoardMember)
 	ret2, _ := ret[2].(error)
@@ -320,1156 +628,2358 @@ func (m *MockStore) DuplicateBoard(arg0, arg1, arg2 string, arg3 bool) (*model.B
 }
 
 // DuplicateBoard indicates an expected call of DuplicateBoard.
-func (mr *MockStoreMockRecorder) DuplicateBoard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) DuplicateBoard(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBoard", reflect.TypeOf((*MockStore)(nil).DuplicateBoard), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DuplicateBoardWithOptions mocks base method.
+func (m *MockStore) DuplicateBoardWithOptions(arg0 context.Context, arg1, arg2 string, arg3 model.DuplicateBoardOptions) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuplicateBoardWithOptions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.BoardsAndBlocks)
+	ret1, _ := ret[1].([]*model.BoardMember)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DuplicateBoardWithOptions indicates an expected call of DuplicateBoardWithOptions.
+func (mr *MockStoreMockRecorder) DuplicateBoardWithOptions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBoardWithOptions", reflect.TypeOf((*MockStore)(nil).DuplicateBoardWithOptions), arg0, arg1, arg2, arg3)
+}
+
+// FindDuplicateCategories mocks base method.
+func (m *MockStore) FindDuplicateCategories(arg0 context.Context, arg1, arg2 string) (map[string][]model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicateCategories", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string][]model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicateCategories indicates an expected call of FindDuplicateCategories.
+func (mr *MockStoreMockRecorder) FindDuplicateCategories(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBoard", reflect.TypeOf((*MockStore)(nil).DuplicateBoard), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicateCategories", reflect.TypeOf((*MockStore)(nil).FindDuplicateCategories), arg0, arg1, arg2)
 }
 
 // GetActiveUserCount mocks base method.
-func (m *MockStore) GetActiveUserCount(arg0 int64) (int, error) {
+func (m *MockStore) GetActiveUserCount(arg0 context.Context, arg1 int64) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActiveUserCount", arg0)
+	ret := m.ctrl.Call(m, "GetActiveUserCount", arg0, arg1)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetActiveUserCount indicates an expected call of GetActiveUserCount.
-func (mr *MockStoreMockRecorder) GetActiveUserCount(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) GetActiveUserCount(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserCount", reflect.TypeOf((*MockStore)(nil).GetActiveUserCount), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserCount", reflect.TypeOf((*MockStore)(nil).GetActiveUserCount), arg0, arg1)
 }
 
-// GetAllTeams mocks base method.
-func (m *MockStore) GetAllTeams() ([]*model.Team, error) {
+// GetActiveUserCountByTeam mocks base method.
+func (m *MockStore) GetActiveUserCountByTeam(arg0 context.Context, arg1 string, arg2 int64) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTeams")
-	ret0, _ := ret[0].([]*model.Team)
+	ret := m.ctrl.Call(m, "GetActiveUserCountByTeam", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllTeams indicates an expected call of GetAllTeams.
-func (mr *MockStoreMockRecorder) GetAllTeams() *gomock.Call {
+// GetActiveUserCountByTeam indicates an expected call of GetActiveUserCountByTeam.
+func (mr *MockStoreMockRecorder) GetActiveUserCountByTeam(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTeams", reflect.TypeOf((*MockStore)(nil).GetAllTeams))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserCountByTeam", reflect.TypeOf((*MockStore)(nil).GetActiveUserCountByTeam), arg0, arg1, arg2)
 }
 
-// GetBlock mocks base method.
-func (m *MockStore) GetBlock(arg0 string) (*model.Block, error) {
+// GetActiveUserCountsByDay mocks base method.
+func (m *MockStore) GetActiveUserCountsByDay(arg0 context.Context, arg1 string, arg2, arg3 int64) (map[int64]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlock", arg0)
-	ret0, _ := ret[0].(*model.Block)
+	ret := m.ctrl.Call(m, "GetActiveUserCountsByDay", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[int64]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlock indicates an expected call of GetBlock.
-func (mr *MockStoreMockRecorder) GetBlock(arg0 interface{}) *gomock.Call {
+// GetActiveUserCountsByDay indicates an expected call of GetActiveUserCountsByDay.
+func (mr *MockStoreMockRecorder) GetActiveUserCountsByDay(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockStore)(nil).GetBlock), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserCountsByDay", reflect.TypeOf((*MockStore)(nil).GetActiveUserCountsByDay), arg0, arg1, arg2, arg3)
 }
 
-// GetBlockCountsByType mocks base method.
-func (m *MockStore) GetBlockCountsByType() (map[string]int64, error) {
+// GetAllCategoriesForTeam mocks base method.
+func (m *MockStore) GetAllCategoriesForTeam(arg0 context.Context, arg1 string) ([]model.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlockCountsByType")
-	ret0, _ := ret[0].(map[string]int64)
+	ret := m.ctrl.Call(m, "GetAllCategoriesForTeam", arg0, arg1)
+	ret0, _ := ret[0].([]model.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlockCountsByType indicates an expected call of GetBlockCountsByType.
-func (mr *MockStoreMockRecorder) GetBlockCountsByType() *gomock.Call {
+// GetAllCategoriesForTeam indicates an expected call of GetAllCategoriesForTeam.
+func (mr *MockStoreMockRecorder) GetAllCategoriesForTeam(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCountsByType", reflect.TypeOf((*MockStore)(nil).GetBlockCountsByType))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategoriesForTeam", reflect.TypeOf((*MockStore)(nil).GetAllCategoriesForTeam), arg0, arg1)
 }
 
-// GetBlockHistory mocks base method.
-func (m *MockStore) GetBlockHistory(arg0 string, arg1 model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+// GetAllTeams mocks base method.
+func (m *MockStore) GetAllTeams(arg0 context.Context) ([]*model.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlockHistory", arg0, arg1)
-	ret0, _ := ret[0].([]*model.Block)
+	ret := m.ctrl.Call(m, "GetAllTeams", arg0)
+	ret0, _ := ret[0].([]*model.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlockHistory indicates an expected call of GetBlockHistory.
-func (mr *MockStoreMockRecorder) GetBlockHistory(arg0, arg1 interface{}) *gomock.Call {
+// GetAllTeams indicates an expected call of GetAllTeams.
+func (mr *MockStoreMockRecorder) GetAllTeams(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHistory", reflect.TypeOf((*MockStore)(nil).GetBlockHistory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTeams", reflect.TypeOf((*MockStore)(nil).GetAllTeams), arg0)
 }
 
-// GetBlockHistoryDescendants mocks base method.
-func (m *MockStore) GetBlockHistoryDescendants(arg0 string, arg1 model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+// GetArchivedBoards mocks base method.
+func (m *MockStore) GetArchivedBoards(arg0 context.Context, arg1 string) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlockHistoryDescendants", arg0, arg1)
-	ret0, _ := ret[0].([]*model.Block)
+	ret := m.ctrl.Call(m, "GetArchivedBoards", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Board)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlockHistoryDescendants indicates an expected call of GetBlockHistoryDescendants.
-func (mr *MockStoreMockRecorder) GetBlockHistoryDescendants(arg0, arg1 interface{}) *gomock.Call {
+// GetArchivedBoards indicates an expected call of GetArchivedBoards.
+func (mr *MockStoreMockRecorder) GetArchivedBoards(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHistoryDescendants", reflect.TypeOf((*MockStore)(nil).GetBlockHistoryDescendants), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedBoards", reflect.TypeOf((*MockStore)(nil).GetArchivedBoards), arg0, arg1)
 }
 
-// GetBlocks mocks base method.
-func (m *MockStore) GetBlocks(arg0 model.QueryBlocksOptions) ([]*model.Block, error) {
+// GetArchivedCards mocks base method.
+func (m *MockStore) GetArchivedCards(arg0 context.Context, arg1 string) ([]*model.Block, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlocks", arg0)
+	ret := m.ctrl.Call(m, "GetArchivedCards", arg0, arg1)
 	ret0, _ := ret[0].([]*model.Block)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlocks indicates an expected call of GetBlocks.
-func (mr *MockStoreMockRecorder) GetBlocks(arg0 interface{}) *gomock.Call {
+// GetArchivedCards indicates an expected call of GetArchivedCards.
+func (mr *MockStoreMockRecorder) GetArchivedCards(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockStore)(nil).GetBlocks), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedCards", reflect.TypeOf((*MockStore)(nil).GetArchivedCards), arg0, arg1)
 }
 
-// GetBlocksByIDs mocks base method.
-func (m *MockStore) GetBlocksByIDs(arg0 []string) ([]*model.Block, error) {
+// GetAuditRecords mocks base method.
+func (m *MockStore) GetAuditRecords(arg0 context.Context, arg1 string, arg2 model.QueryAuditOptions) ([]*model.AuditRecord, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlocksByIDs", arg0)
-	ret0, _ := ret[0].([]*model.Block)
+	ret := m.ctrl.Call(m, "GetAuditRecords", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.AuditRecord)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlocksByIDs indicates an expected call of GetBlocksByIDs.
-func (mr *MockStoreMockRecorder) GetBlocksByIDs(arg0 interface{}) *gomock.Call {
+// GetAuditRecords indicates an expected call of GetAuditRecords.
+func (mr *MockStoreMockRecorder) GetAuditRecords(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditRecords", reflect.TypeOf((*MockStore)(nil).GetAuditRecords), arg0, arg1, arg2)
+}
+
+// GetBlock mocks base method.
+func (m *MockStore) GetBlock(arg0 context.Context, arg1 string) (*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlock", arg0, arg1)
+	ret0, _ := ret[0].(*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlock indicates an expected call of GetBlock.
+func (mr *MockStoreMockRecorder) GetBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockStore)(nil).GetBlock), arg0, arg1)
+}
+
+// GetBlockCountForTeam mocks base method.
+func (m *MockStore) GetBlockCountForTeam(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockCountForTeam", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockCountForTeam indicates an expected call of GetBlockCountForTeam.
+func (mr *MockStoreMockRecorder) GetBlockCountForTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCountForTeam", reflect.TypeOf((*MockStore)(nil).GetBlockCountForTeam), arg0, arg1)
+}
+
+// GetBlockCountsByType mocks base method.
+func (m *MockStore) GetBlockCountsByType(arg0 context.Context) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockCountsByType", arg0)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockCountsByType indicates an expected call of GetBlockCountsByType.
+func (mr *MockStoreMockRecorder) GetBlockCountsByType(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCountsByType", reflect.TypeOf((*MockStore)(nil).GetBlockCountsByType), arg0)
+}
+
+// GetBlockCountsByTypeForBoard mocks base method.
+func (m *MockStore) GetBlockCountsByTypeForBoard(arg0 context.Context, arg1 string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockCountsByTypeForBoard", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockCountsByTypeForBoard indicates an expected call of GetBlockCountsByTypeForBoard.
+func (mr *MockStoreMockRecorder) GetBlockCountsByTypeForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCountsByTypeForBoard", reflect.TypeOf((*MockStore)(nil).GetBlockCountsByTypeForBoard), arg0, arg1)
+}
+
+// GetBlockCountsForTeams mocks base method.
+func (m *MockStore) GetBlockCountsForTeams(arg0 context.Context, arg1 []string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockCountsForTeams", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockCountsForTeams indicates an expected call of GetBlockCountsForTeams.
+func (mr *MockStoreMockRecorder) GetBlockCountsForTeams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCountsForTeams", reflect.TypeOf((*MockStore)(nil).GetBlockCountsForTeams), arg0, arg1)
+}
+
+// GetBlockHistory mocks base method.
+func (m *MockStore) GetBlockHistory(arg0 context.Context, arg1 string, arg2 model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHistory indicates an expected call of GetBlockHistory.
+func (mr *MockStoreMockRecorder) GetBlockHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHistory", reflect.TypeOf((*MockStore)(nil).GetBlockHistory), arg0, arg1, arg2)
+}
+
+// GetBlockHistoryDescendants mocks base method.
+func (m *MockStore) GetBlockHistoryDescendants(arg0 context.Context, arg1 string, arg2 model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockHistoryDescendants", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHistoryDescendants indicates an expected call of GetBlockHistoryDescendants.
+func (mr *MockStoreMockRecorder) GetBlockHistoryDescendants(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHistoryDescendants", reflect.TypeOf((*MockStore)(nil).GetBlockHistoryDescendants), arg0, arg1, arg2)
+}
+
+// GetBlockHistoryEntry mocks base method.
+func (m *MockStore) GetBlockHistoryEntry(arg0 context.Context, arg1 string, arg2 int64) (*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockHistoryEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHistoryEntry indicates an expected call of GetBlockHistoryEntry.
+func (mr *MockStoreMockRecorder) GetBlockHistoryEntry(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHistoryEntry", reflect.TypeOf((*MockStore)(nil).GetBlockHistoryEntry), arg0, arg1, arg2)
+}
+
+// GetBlocks mocks base method.
+func (m *MockStore) GetBlocks(arg0 context.Context, arg1 model.QueryBlocksOptions) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocks", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocks indicates an expected call of GetBlocks.
+func (mr *MockStoreMockRecorder) GetBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockStore)(nil).GetBlocks), arg0, arg1)
+}
+
+// GetBlocksByIDs mocks base method.
+func (m *MockStore) GetBlocksByIDs(arg0 context.Context, arg1 []string) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksByIDs indicates an expected call of GetBlocksByIDs.
+func (mr *MockStoreMockRecorder) GetBlocksByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksByIDs", reflect.TypeOf((*MockStore)(nil).GetBlocksByIDs), arg0, arg1)
+}
+
+// GetBlocksForBoard mocks base method.
+func (m *MockStore) GetBlocksForBoard(arg0 context.Context, arg1 string, arg2 model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksForBoard", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBlocksForBoard indicates an expected call of GetBlocksForBoard.
+func (mr *MockStoreMockRecorder) GetBlocksForBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksForBoard", reflect.TypeOf((*MockStore)(nil).GetBlocksForBoard), arg0, arg1, arg2)
+}
+
+// GetBlocksForBoardStream mocks base method.
+func (m *MockStore) GetBlocksForBoardStream(arg0 context.Context, arg1 string, arg2 func(model.Block) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksForBoardStream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetBlocksForBoardStream indicates an expected call of GetBlocksForBoardStream.
+func (mr *MockStoreMockRecorder) GetBlocksForBoardStream(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksForBoardStream", reflect.TypeOf((*MockStore)(nil).GetBlocksForBoardStream), arg0, arg1, arg2)
+}
+
+// GetBlocksForBoards mocks base method.
+func (m *MockStore) GetBlocksForBoards(arg0 context.Context, arg1 []string, arg2 model.QueryBlocksOptions) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksForBoards", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksForBoards indicates an expected call of GetBlocksForBoards.
+func (mr *MockStoreMockRecorder) GetBlocksForBoards(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksForBoards", reflect.TypeOf((*MockStore)(nil).GetBlocksForBoards), arg0, arg1, arg2)
+}
+
+// GetBlocksMap mocks base method.
+func (m *MockStore) GetBlocksMap(arg0 context.Context, arg1 string, arg2 []string) (map[string]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksMap", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksMap indicates an expected call of GetBlocksMap.
+func (mr *MockStoreMockRecorder) GetBlocksMap(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksMap", reflect.TypeOf((*MockStore)(nil).GetBlocksMap), arg0, arg1, arg2)
+}
+
+// GetBlocksWithParent mocks base method.
+func (m *MockStore) GetBlocksWithParent(arg0 context.Context, arg1, arg2 string) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksWithParent", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksWithParent indicates an expected call of GetBlocksWithParent.
+func (mr *MockStoreMockRecorder) GetBlocksWithParent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithParent", reflect.TypeOf((*MockStore)(nil).GetBlocksWithParent), arg0, arg1, arg2)
+}
+
+// GetBlocksWithParentAndType mocks base method.
+func (m *MockStore) GetBlocksWithParentAndType(arg0 context.Context, arg1, arg2, arg3 string) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksWithParentAndType", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksWithParentAndType indicates an expected call of GetBlocksWithParentAndType.
+func (mr *MockStoreMockRecorder) GetBlocksWithParentAndType(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithParentAndType", reflect.TypeOf((*MockStore)(nil).GetBlocksWithParentAndType), arg0, arg1, arg2, arg3)
+}
+
+// GetBlocksWithParentAndTypes mocks base method.
+func (m *MockStore) GetBlocksWithParentAndTypes(arg0 context.Context, arg1, arg2 string, arg3 []string) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksWithParentAndTypes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksWithParentAndTypes indicates an expected call of GetBlocksWithParentAndTypes.
+func (mr *MockStoreMockRecorder) GetBlocksWithParentAndTypes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithParentAndTypes", reflect.TypeOf((*MockStore)(nil).GetBlocksWithParentAndTypes), arg0, arg1, arg2, arg3)
+}
+
+// GetBlocksWithType mocks base method.
+func (m *MockStore) GetBlocksWithType(arg0 context.Context, arg1, arg2 string, arg3 model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksWithType", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBlocksWithType indicates an expected call of GetBlocksWithType.
+func (mr *MockStoreMockRecorder) GetBlocksWithType(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithType", reflect.TypeOf((*MockStore)(nil).GetBlocksWithType), arg0, arg1, arg2, arg3)
+}
+
+// GetBoard mocks base method.
+func (m *MockStore) GetBoard(arg0 context.Context, arg1 string) (*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", arg0, arg1)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockStoreMockRecorder) GetBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockStore)(nil).GetBoard), arg0, arg1)
+}
+
+// GetBoardAndCard mocks base method.
+func (m *MockStore) GetBoardAndCard(arg0 context.Context, arg1 *model.Block) (*model.Board, *model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardAndCard", arg0, arg1)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(*model.Block)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBoardAndCard indicates an expected call of GetBoardAndCard.
+func (mr *MockStoreMockRecorder) GetBoardAndCard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAndCard", reflect.TypeOf((*MockStore)(nil).GetBoardAndCard), arg0, arg1)
+}
+
+// GetBoardAndCardByID mocks base method.
+func (m *MockStore) GetBoardAndCardByID(arg0 context.Context, arg1 string) (*model.Board, *model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardAndCardByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(*model.Block)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBoardAndCardByID indicates an expected call of GetBoardAndCardByID.
+func (mr *MockStoreMockRecorder) GetBoardAndCardByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAndCardByID", reflect.TypeOf((*MockStore)(nil).GetBoardAndCardByID), arg0, arg1)
+}
+
+// GetBoardCardProperties mocks base method.
+func (m *MockStore) GetBoardCardProperties(arg0 context.Context, arg1 string) ([]model.CardProperty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCardProperties", arg0, arg1)
+	ret0, _ := ret[0].([]model.CardProperty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCardProperties indicates an expected call of GetBoardCardProperties.
+func (mr *MockStoreMockRecorder) GetBoardCardProperties(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCardProperties", reflect.TypeOf((*MockStore)(nil).GetBoardCardProperties), arg0, arg1)
+}
+
+// GetBoardCount mocks base method.
+func (m *MockStore) GetBoardCount(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCount", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCount indicates an expected call of GetBoardCount.
+func (mr *MockStoreMockRecorder) GetBoardCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCount", reflect.TypeOf((*MockStore)(nil).GetBoardCount), arg0)
+}
+
+// GetBoardCustomRole mocks base method.
+func (m *MockStore) GetBoardCustomRole(arg0 context.Context, arg1 string) (*model.BoardCustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCustomRole", arg0, arg1)
+	ret0, _ := ret[0].(*model.BoardCustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCustomRole indicates an expected call of GetBoardCustomRole.
+func (mr *MockStoreMockRecorder) GetBoardCustomRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCustomRole", reflect.TypeOf((*MockStore)(nil).GetBoardCustomRole), arg0, arg1)
+}
+
+// GetBoardCustomRoles mocks base method.
+func (m *MockStore) GetBoardCustomRoles(arg0 context.Context, arg1 string) ([]*model.BoardCustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCustomRoles", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BoardCustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCustomRoles indicates an expected call of GetBoardCustomRoles.
+func (mr *MockStoreMockRecorder) GetBoardCustomRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCustomRoles", reflect.TypeOf((*MockStore)(nil).GetBoardCustomRoles), arg0, arg1)
+}
+
+// GetBoardHistory mocks base method.
+func (m *MockStore) GetBoardHistory(arg0 context.Context, arg1 string, arg2 model.QueryBoardHistoryOptions) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardHistory indicates an expected call of GetBoardHistory.
+func (mr *MockStoreMockRecorder) GetBoardHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardHistory", reflect.TypeOf((*MockStore)(nil).GetBoardHistory), arg0, arg1, arg2)
+}
+
+// GetBoardMemberCount mocks base method.
+func (m *MockStore) GetBoardMemberCount(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardMemberCount", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardMemberCount indicates an expected call of GetBoardMemberCount.
+func (mr *MockStoreMockRecorder) GetBoardMemberCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberCount", reflect.TypeOf((*MockStore)(nil).GetBoardMemberCount), arg0, arg1)
+}
+
+// GetBoardMemberHistory mocks base method.
+func (m *MockStore) GetBoardMemberHistory(arg0 context.Context, arg1, arg2 string, arg3 uint64) ([]*model.BoardMemberHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardMemberHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.BoardMemberHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardMemberHistory indicates an expected call of GetBoardMemberHistory.
+func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2, arg3)
+}
+
+// GetBoardSnapshot mocks base method.
+func (m *MockStore) GetBoardSnapshot(arg0 context.Context, arg1 string) (*model.BoardSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(*model.BoardSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardSnapshot indicates an expected call of GetBoardSnapshot.
+func (mr *MockStoreMockRecorder) GetBoardSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardSnapshot", reflect.TypeOf((*MockStore)(nil).GetBoardSnapshot), arg0, arg1)
+}
+
+// GetBoardStats mocks base method.
+func (m *MockStore) GetBoardStats(arg0 context.Context, arg1 string) (*model.BoardStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardStats", arg0, arg1)
+	ret0, _ := ret[0].(*model.BoardStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardStats indicates an expected call of GetBoardStats.
+func (mr *MockStoreMockRecorder) GetBoardStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardStats", reflect.TypeOf((*MockStore)(nil).GetBoardStats), arg0, arg1)
+}
+
+// GetBoardWithStats mocks base method.
+func (m *MockStore) GetBoardWithStats(arg0 context.Context, arg1, arg2 string) (*model.BoardWithStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardWithStats", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.BoardWithStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardWithStats indicates an expected call of GetBoardWithStats.
+func (mr *MockStoreMockRecorder) GetBoardWithStats(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardWithStats", reflect.TypeOf((*MockStore)(nil).GetBoardWithStats), arg0, arg1, arg2)
+}
+
+// GetBoards mocks base method.
+func (m *MockStore) GetBoards(arg0 context.Context, arg1 []string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoards", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoards indicates an expected call of GetBoards.
+func (mr *MockStoreMockRecorder) GetBoards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoards", reflect.TypeOf((*MockStore)(nil).GetBoards), arg0, arg1)
+}
+
+// GetBoardsAdministeredByUser mocks base method.
+func (m *MockStore) GetBoardsAdministeredByUser(arg0 context.Context, arg1, arg2 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsAdministeredByUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsAdministeredByUser indicates an expected call of GetBoardsAdministeredByUser.
+func (mr *MockStoreMockRecorder) GetBoardsAdministeredByUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsAdministeredByUser", reflect.TypeOf((*MockStore)(nil).GetBoardsAdministeredByUser), arg0, arg1, arg2)
+}
+
+// GetBoardsCreatedFromTemplate mocks base method.
+func (m *MockStore) GetBoardsCreatedFromTemplate(arg0 context.Context, arg1 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsCreatedFromTemplate", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsCreatedFromTemplate indicates an expected call of GetBoardsCreatedFromTemplate.
+func (mr *MockStoreMockRecorder) GetBoardsCreatedFromTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsCreatedFromTemplate", reflect.TypeOf((*MockStore)(nil).GetBoardsCreatedFromTemplate), arg0, arg1)
+}
+
+// GetBoardsForUserAndTeam mocks base method.
+func (m *MockStore) GetBoardsForUserAndTeam(arg0 context.Context, arg1, arg2 string, arg3 bool) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsForUserAndTeam", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsForUserAndTeam indicates an expected call of GetBoardsForUserAndTeam.
+func (mr *MockStoreMockRecorder) GetBoardsForUserAndTeam(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForUserAndTeam", reflect.TypeOf((*MockStore)(nil).GetBoardsForUserAndTeam), arg0, arg1, arg2, arg3)
+}
+
+// GetBoardsForUserAndTeamWithOptions mocks base method.
+func (m *MockStore) GetBoardsForUserAndTeamWithOptions(arg0 context.Context, arg1, arg2 string, arg3 model.QueryBoardsOptions) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsForUserAndTeamWithOptions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsForUserAndTeamWithOptions indicates an expected call of GetBoardsForUserAndTeamWithOptions.
+func (mr *MockStoreMockRecorder) GetBoardsForUserAndTeamWithOptions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForUserAndTeamWithOptions", reflect.TypeOf((*MockStore)(nil).GetBoardsForUserAndTeamWithOptions), arg0, arg1, arg2, arg3)
+}
+
+// GetBoardsInTeamByIds mocks base method.
+func (m *MockStore) GetBoardsInTeamByIds(arg0 context.Context, arg1 []string, arg2 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsInTeamByIds", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsInTeamByIds indicates an expected call of GetBoardsInTeamByIds.
+func (mr *MockStoreMockRecorder) GetBoardsInTeamByIds(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsInTeamByIds", reflect.TypeOf((*MockStore)(nil).GetBoardsInTeamByIds), arg0, arg1, arg2)
+}
+
+// GetBoardsModifiedSince mocks base method.
+func (m *MockStore) GetBoardsModifiedSince(arg0 context.Context, arg1, arg2 string, arg3 int64) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsModifiedSince", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsModifiedSince indicates an expected call of GetBoardsModifiedSince.
+func (mr *MockStoreMockRecorder) GetBoardsModifiedSince(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsModifiedSince", reflect.TypeOf((*MockStore)(nil).GetBoardsModifiedSince), arg0, arg1, arg2, arg3)
+}
+
+// GetCardLimitTimestamp mocks base method.
+func (m *MockStore) GetCardLimitTimestamp(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardLimitTimestamp", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardLimitTimestamp indicates an expected call of GetCardLimitTimestamp.
+func (mr *MockStoreMockRecorder) GetCardLimitTimestamp(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).GetCardLimitTimestamp), arg0)
+}
+
+// GetCardsMissingProperty mocks base method.
+func (m *MockStore) GetCardsMissingProperty(arg0 context.Context, arg1, arg2 string) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsMissingProperty", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsMissingProperty indicates an expected call of GetCardsMissingProperty.
+func (mr *MockStoreMockRecorder) GetCardsMissingProperty(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsMissingProperty", reflect.TypeOf((*MockStore)(nil).GetCardsMissingProperty), arg0, arg1, arg2)
+}
+
+// GetCategory mocks base method.
+func (m *MockStore) GetCategory(arg0 context.Context, arg1 string) (*model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
+	ret0, _ := ret[0].(*model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+}
+
+// GetCategoryForBoard mocks base method.
+func (m *MockStore) GetCategoryForBoard(arg0 context.Context, arg1, arg2, arg3 string) (*model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryForBoard", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryForBoard indicates an expected call of GetCategoryForBoard.
+func (mr *MockStoreMockRecorder) GetCategoryForBoard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryForBoard", reflect.TypeOf((*MockStore)(nil).GetCategoryForBoard), arg0, arg1, arg2, arg3)
+}
+
+// GetChannel mocks base method.
+func (m *MockStore) GetChannel(arg0 context.Context, arg1, arg2 string) (*model0.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model0.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannel indicates an expected call of GetChannel.
+func (mr *MockStoreMockRecorder) GetChannel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockStore)(nil).GetChannel), arg0, arg1, arg2)
+}
+
+// GetCloudLimits mocks base method.
+func (m *MockStore) GetCloudLimits(arg0 context.Context) (*model0.ProductLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCloudLimits", arg0)
+	ret0, _ := ret[0].(*model0.ProductLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCloudLimits indicates an expected call of GetCloudLimits.
+func (mr *MockStoreMockRecorder) GetCloudLimits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCloudLimits", reflect.TypeOf((*MockStore)(nil).GetCloudLimits), arg0)
+}
+
+// GetDefaultCategoryTemplates mocks base method.
+func (m *MockStore) GetDefaultCategoryTemplates(arg0 context.Context, arg1 string) ([]model.CategoryTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultCategoryTemplates", arg0, arg1)
+	ret0, _ := ret[0].([]model.CategoryTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultCategoryTemplates indicates an expected call of GetDefaultCategoryTemplates.
+func (mr *MockStoreMockRecorder) GetDefaultCategoryTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultCategoryTemplates", reflect.TypeOf((*MockStore)(nil).GetDefaultCategoryTemplates), arg0, arg1)
+}
+
+// GetDeletedBlocksForBoard mocks base method.
+func (m *MockStore) GetDeletedBlocksForBoard(arg0 context.Context, arg1 string) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedBlocksForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedBlocksForBoard indicates an expected call of GetDeletedBlocksForBoard.
+func (mr *MockStoreMockRecorder) GetDeletedBlocksForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedBlocksForBoard", reflect.TypeOf((*MockStore)(nil).GetDeletedBlocksForBoard), arg0, arg1)
+}
+
+// GetDueNotificationHints mocks base method.
+func (m *MockStore) GetDueNotificationHints(arg0 context.Context, arg1 int64, arg2 int) ([]*model.NotificationHint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueNotificationHints", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.NotificationHint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueNotificationHints indicates an expected call of GetDueNotificationHints.
+func (mr *MockStoreMockRecorder) GetDueNotificationHints(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueNotificationHints", reflect.TypeOf((*MockStore)(nil).GetDueNotificationHints), arg0, arg1, arg2)
+}
+
+// GetFavoriteBoards mocks base method.
+func (m *MockStore) GetFavoriteBoards(arg0 context.Context, arg1, arg2 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavoriteBoards", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavoriteBoards indicates an expected call of GetFavoriteBoards.
+func (mr *MockStoreMockRecorder) GetFavoriteBoards(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavoriteBoards", reflect.TypeOf((*MockStore)(nil).GetFavoriteBoards), arg0, arg1, arg2)
+}
+
+// GetFileInfo mocks base method.
+func (m *MockStore) GetFileInfo(arg0 context.Context, arg1 string) (*model0.FileInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileInfo", arg0, arg1)
+	ret0, _ := ret[0].(*model0.FileInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileInfo indicates an expected call of GetFileInfo.
+func (mr *MockStoreMockRecorder) GetFileInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileInfo", reflect.TypeOf((*MockStore)(nil).GetFileInfo), arg0, arg1)
+}
+
+// GetFileInfosForBoard mocks base method.
+func (m *MockStore) GetFileInfosForBoard(arg0 context.Context, arg1 string) ([]*model0.FileInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileInfosForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]*model0.FileInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileInfosForBoard indicates an expected call of GetFileInfosForBoard.
+func (mr *MockStoreMockRecorder) GetFileInfosForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileInfosForBoard", reflect.TypeOf((*MockStore)(nil).GetFileInfosForBoard), arg0, arg1)
+}
+
+// GetLastModifiedBlockForBoard mocks base method.
+func (m *MockStore) GetLastModifiedBlockForBoard(arg0 context.Context, arg1 string) (*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastModifiedBlockForBoard", arg0, arg1)
+	ret0, _ := ret[0].(*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastModifiedBlockForBoard indicates an expected call of GetLastModifiedBlockForBoard.
+func (mr *MockStoreMockRecorder) GetLastModifiedBlockForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastModifiedBlockForBoard", reflect.TypeOf((*MockStore)(nil).GetLastModifiedBlockForBoard), arg0, arg1)
+}
+
+// GetLicense mocks base method.
+func (m *MockStore) GetLicense(arg0 context.Context) *model0.License {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLicense", arg0)
+	ret0, _ := ret[0].(*model0.License)
+	return ret0
+}
+
+// GetLicense indicates an expected call of GetLicense.
+func (mr *MockStoreMockRecorder) GetLicense(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLicense", reflect.TypeOf((*MockStore)(nil).GetLicense), arg0)
+}
+
+// GetMemberForBoard mocks base method.
+func (m *MockStore) GetMemberForBoard(arg0 context.Context, arg1, arg2 string) (*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberForBoard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberForBoard indicates an expected call of GetMemberForBoard.
+func (mr *MockStoreMockRecorder) GetMemberForBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberForBoard", reflect.TypeOf((*MockStore)(nil).GetMemberForBoard), arg0, arg1, arg2)
+}
+
+// GetMembersForBoard mocks base method.
+func (m *MockStore) GetMembersForBoard(arg0 context.Context, arg1 string) ([]*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembersForBoard indicates an expected call of GetMembersForBoard.
+func (mr *MockStoreMockRecorder) GetMembersForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForBoard", reflect.TypeOf((*MockStore)(nil).GetMembersForBoard), arg0, arg1)
+}
+
+// GetMembersForBoardWithOptions mocks base method.
+func (m *MockStore) GetMembersForBoardWithOptions(arg0 context.Context, arg1 string, arg2 model.QueryBoardMembersOptions) ([]*model.BoardMember, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersForBoardWithOptions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.BoardMember)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMembersForBoardWithOptions indicates an expected call of GetMembersForBoardWithOptions.
+func (mr *MockStoreMockRecorder) GetMembersForBoardWithOptions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForBoardWithOptions", reflect.TypeOf((*MockStore)(nil).GetMembersForBoardWithOptions), arg0, arg1, arg2)
+}
+
+// GetMembersForUser mocks base method.
+func (m *MockStore) GetMembersForUser(arg0 context.Context, arg1 string) ([]*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersForUser", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembersForUser indicates an expected call of GetMembersForUser.
+func (mr *MockStoreMockRecorder) GetMembersForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForUser", reflect.TypeOf((*MockStore)(nil).GetMembersForUser), arg0, arg1)
+}
+
+// GetNextNotificationHint mocks base method.
+func (m *MockStore) GetNextNotificationHint(arg0 context.Context, arg1 bool) (*model.NotificationHint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextNotificationHint", arg0, arg1)
+	ret0, _ := ret[0].(*model.NotificationHint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextNotificationHint indicates an expected call of GetNextNotificationHint.
+func (mr *MockStoreMockRecorder) GetNextNotificationHint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNextNotificationHint), arg0, arg1)
+}
+
+// GetNotificationHint mocks base method.
+func (m *MockStore) GetNotificationHint(arg0 context.Context, arg1 string) (*model.NotificationHint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationHint", arg0, arg1)
+	ret0, _ := ret[0].(*model.NotificationHint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationHint indicates an expected call of GetNotificationHint.
+func (mr *MockStoreMockRecorder) GetNotificationHint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0, arg1)
+}
+
+// GetNotificationHints mocks base method.
+func (m *MockStore) GetNotificationHints(arg0 context.Context, arg1 int) ([]*model.NotificationHint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationHints", arg0, arg1)
+	ret0, _ := ret[0].([]*model.NotificationHint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationHints indicates an expected call of GetNotificationHints.
+func (mr *MockStoreMockRecorder) GetNotificationHints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHints", reflect.TypeOf((*MockStore)(nil).GetNotificationHints), arg0, arg1)
+}
+
+// GetPendingWebhookDeliveries mocks base method.
+func (m *MockStore) GetPendingWebhookDeliveries(arg0 context.Context, arg1 int) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingWebhookDeliveries indicates an expected call of GetPendingWebhookDeliveries.
+func (mr *MockStoreMockRecorder) GetPendingWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).GetPendingWebhookDeliveries), arg0, arg1)
+}
+
+// GetRecentComments mocks base method.
+func (m *MockStore) GetRecentComments(arg0 context.Context, arg1 string, arg2 int) ([]*model.CommentWithAuthor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentComments", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.CommentWithAuthor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentComments indicates an expected call of GetRecentComments.
+func (mr *MockStoreMockRecorder) GetRecentComments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentComments", reflect.TypeOf((*MockStore)(nil).GetRecentComments), arg0, arg1, arg2)
+}
+
+// GetRecentlyViewedBoards mocks base method.
+func (m *MockStore) GetRecentlyViewedBoards(arg0 context.Context, arg1, arg2 string, arg3 int) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentlyViewedBoards", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentlyViewedBoards indicates an expected call of GetRecentlyViewedBoards.
+func (mr *MockStoreMockRecorder) GetRecentlyViewedBoards(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentlyViewedBoards", reflect.TypeOf((*MockStore)(nil).GetRecentlyViewedBoards), arg0, arg1, arg2, arg3)
+}
+
+// GetRegisteredUserCount mocks base method.
+func (m *MockStore) GetRegisteredUserCount(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegisteredUserCount", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegisteredUserCount indicates an expected call of GetRegisteredUserCount.
+func (mr *MockStoreMockRecorder) GetRegisteredUserCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegisteredUserCount", reflect.TypeOf((*MockStore)(nil).GetRegisteredUserCount), arg0)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 string, arg2 int64) (*model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1, arg2)
+}
+
+// GetSessionWithPolicy mocks base method.
+func (m *MockStore) GetSessionWithPolicy(arg0 context.Context, arg1 string, arg2 int64, arg3, arg4 time.Duration) (*model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionWithPolicy", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionWithPolicy indicates an expected call of GetSessionWithPolicy.
+func (mr *MockStoreMockRecorder) GetSessionWithPolicy(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionWithPolicy", reflect.TypeOf((*MockStore)(nil).GetSessionWithPolicy), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetSessionWithUser mocks base method.
+func (m *MockStore) GetSessionWithUser(arg0 context.Context, arg1 string, arg2 int64, arg3, arg4 time.Duration) (*model.Session, *model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionWithUser", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.Session)
+	ret1, _ := ret[1].(*model.User)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSessionWithUser indicates an expected call of GetSessionWithUser.
+func (mr *MockStoreMockRecorder) GetSessionWithUser(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionWithUser", reflect.TypeOf((*MockStore)(nil).GetSessionWithUser), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetShareToken mocks base method.
+func (m *MockStore) GetShareToken(arg0 context.Context, arg1 string) (*model.ShareToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareToken", arg0, arg1)
+	ret0, _ := ret[0].(*model.ShareToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareToken indicates an expected call of GetShareToken.
+func (mr *MockStoreMockRecorder) GetShareToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareToken", reflect.TypeOf((*MockStore)(nil).GetShareToken), arg0, arg1)
+}
+
+// GetShareTokensForBoard mocks base method.
+func (m *MockStore) GetShareTokensForBoard(arg0 context.Context, arg1 string) ([]*model.ShareToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareTokensForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ShareToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareTokensForBoard indicates an expected call of GetShareTokensForBoard.
+func (mr *MockStoreMockRecorder) GetShareTokensForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareTokensForBoard", reflect.TypeOf((*MockStore)(nil).GetShareTokensForBoard), arg0, arg1)
+}
+
+// GetSharing mocks base method.
+func (m *MockStore) GetSharing(arg0 context.Context, arg1 string) (*model.Sharing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharing", arg0, arg1)
+	ret0, _ := ret[0].(*model.Sharing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharing indicates an expected call of GetSharing.
+func (mr *MockStoreMockRecorder) GetSharing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharing", reflect.TypeOf((*MockStore)(nil).GetSharing), arg0, arg1)
+}
+
+// GetSharingForBoards mocks base method.
+func (m *MockStore) GetSharingForBoards(arg0 context.Context, arg1 []string) (map[string]*model.Sharing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharingForBoards", arg0, arg1)
+	ret0, _ := ret[0].(map[string]*model.Sharing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharingForBoards indicates an expected call of GetSharingForBoards.
+func (mr *MockStoreMockRecorder) GetSharingForBoards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharingForBoards", reflect.TypeOf((*MockStore)(nil).GetSharingForBoards), arg0, arg1)
+}
+
+// GetSubTree mocks base method.
+func (m *MockStore) GetSubTree(arg0 context.Context, arg1, arg2 string, arg3 model.QuerySubtreeOptions) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubTree", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubTree indicates an expected call of GetSubTree.
+func (mr *MockStoreMockRecorder) GetSubTree(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubTree", reflect.TypeOf((*MockStore)(nil).GetSubTree), arg0, arg1, arg2, arg3)
+}
+
+// GetSubTree2 mocks base method.
+func (m *MockStore) GetSubTree2(arg0 context.Context, arg1, arg2 string, arg3 model.QuerySubtreeOptions) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubTree2", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubTree2 indicates an expected call of GetSubTree2.
+func (mr *MockStoreMockRecorder) GetSubTree2(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubTree2", reflect.TypeOf((*MockStore)(nil).GetSubTree2), arg0, arg1, arg2, arg3)
+}
+
+// GetSubscribersCountForBlock mocks base method.
+func (m *MockStore) GetSubscribersCountForBlock(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribersCountForBlock", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribersCountForBlock indicates an expected call of GetSubscribersCountForBlock.
+func (mr *MockStoreMockRecorder) GetSubscribersCountForBlock(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksByIDs", reflect.TypeOf((*MockStore)(nil).GetBlocksByIDs), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribersCountForBlock", reflect.TypeOf((*MockStore)(nil).GetSubscribersCountForBlock), arg0, arg1)
 }
 
-// GetBlocksForBoard mocks base method.
-func (m *MockStore) GetBlocksForBoard(arg0 string) ([]*model.Block, error) {
+// GetSubscribersForBlock mocks base method.
+func (m *MockStore) GetSubscribersForBlock(arg0 context.Context, arg1 string, arg2 model.SubscriptionEvents) ([]*model.Subscriber, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlocksForBoard", arg0)
-	ret0, _ := ret[0].([]*model.Block)
+	ret := m.ctrl.Call(m, "GetSubscribersForBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Subscriber)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlocksForBoard indicates an expected call of GetBlocksForBoard.
-func (mr *MockStoreMockRecorder) GetBlocksForBoard(arg0 interface{}) *gomock.Call {
+// GetSubscribersForBlock indicates an expected call of GetSubscribersForBlock.
+func (mr *MockStoreMockRecorder) GetSubscribersForBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksForBoard", reflect.TypeOf((*MockStore)(nil).GetBlocksForBoard), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribersForBlock", reflect.TypeOf((*MockStore)(nil).GetSubscribersForBlock), arg0, arg1, arg2)
 }
 
-// GetBlocksWithParent mocks base method.
-func (m *MockStore) GetBlocksWithParent(arg0, arg1 string) ([]*model.Block, error) {
+// GetSubscription mocks base method.
+func (m *MockStore) GetSubscription(arg0 context.Context, arg1, arg2 string) (*model.Subscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlocksWithParent", arg0, arg1)
-	ret0, _ := ret[0].([]*model.Block)
+	ret := m.ctrl.Call(m, "GetSubscription", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Subscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlocksWithParent indicates an expected call of GetBlocksWithParent.
-func (mr *MockStoreMockRecorder) GetBlocksWithParent(arg0, arg1 interface{}) *gomock.Call {
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockStoreMockRecorder) GetSubscription(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithParent", reflect.TypeOf((*MockStore)(nil).GetBlocksWithParent), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockStore)(nil).GetSubscription), arg0, arg1, arg2)
 }
 
-// GetBlocksWithParentAndType mocks base method.
-func (m *MockStore) GetBlocksWithParentAndType(arg0, arg1, arg2 string) ([]*model.Block, error) {
+// GetSubscriptions mocks base method.
+func (m *MockStore) GetSubscriptions(arg0 context.Context, arg1 string) ([]*model.Subscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlocksWithParentAndType", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]*model.Block)
+	ret := m.ctrl.Call(m, "GetSubscriptions", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Subscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlocksWithParentAndType indicates an expected call of GetBlocksWithParentAndType.
-func (mr *MockStoreMockRecorder) GetBlocksWithParentAndType(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetSubscriptions indicates an expected call of GetSubscriptions.
+func (mr *MockStoreMockRecorder) GetSubscriptions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithParentAndType", reflect.TypeOf((*MockStore)(nil).GetBlocksWithParentAndType), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockStore)(nil).GetSubscriptions), arg0, arg1)
 }
 
-// GetBlocksWithType mocks base method.
-func (m *MockStore) GetBlocksWithType(arg0, arg1 string) ([]*model.Block, error) {
+// GetSystemSetting mocks base method.
+func (m *MockStore) GetSystemSetting(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlocksWithType", arg0, arg1)
-	ret0, _ := ret[0].([]*model.Block)
+	ret := m.ctrl.Call(m, "GetSystemSetting", arg0, arg1)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBlocksWithType indicates an expected call of GetBlocksWithType.
-func (mr *MockStoreMockRecorder) GetBlocksWithType(arg0, arg1 interface{}) *gomock.Call {
+// GetSystemSetting indicates an expected call of GetSystemSetting.
+func (mr *MockStoreMockRecorder) GetSystemSetting(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithType", reflect.TypeOf((*MockStore)(nil).GetBlocksWithType), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemSetting", reflect.TypeOf((*MockStore)(nil).GetSystemSetting), arg0, arg1)
 }
 
-// GetBoard mocks base method.
-func (m *MockStore) GetBoard(arg0 string) (*model.Board, error) {
+// GetSystemSettingBool mocks base method.
+func (m *MockStore) GetSystemSettingBool(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoard", arg0)
-	ret0, _ := ret[0].(*model.Board)
+	ret := m.ctrl.Call(m, "GetSystemSettingBool", arg0, arg1)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoard indicates an expected call of GetBoard.
-func (mr *MockStoreMockRecorder) GetBoard(arg0 interface{}) *gomock.Call {
+// GetSystemSettingBool indicates an expected call of GetSystemSettingBool.
+func (mr *MockStoreMockRecorder) GetSystemSettingBool(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockStore)(nil).GetBoard), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemSettingBool", reflect.TypeOf((*MockStore)(nil).GetSystemSettingBool), arg0, arg1)
 }
 
-// GetBoardAndCard mocks base method.
-func (m *MockStore) GetBoardAndCard(arg0 *model.Block) (*model.Board, *model.Block, error) {
+// GetSystemSettingInt mocks base method.
+func (m *MockStore) GetSystemSettingInt(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardAndCard", arg0)
-	ret0, _ := ret[0].(*model.Board)
-	ret1, _ := ret[1].(*model.Block)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GetSystemSettingInt", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetBoardAndCard indicates an expected call of GetBoardAndCard.
-func (mr *MockStoreMockRecorder) GetBoardAndCard(arg0 interface{}) *gomock.Call {
+// GetSystemSettingInt indicates an expected call of GetSystemSettingInt.
+func (mr *MockStoreMockRecorder) GetSystemSettingInt(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAndCard", reflect.TypeOf((*MockStore)(nil).GetBoardAndCard), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemSettingInt", reflect.TypeOf((*MockStore)(nil).GetSystemSettingInt), arg0, arg1)
 }
 
-// GetBoardAndCardByID mocks base method.
-func (m *MockStore) GetBoardAndCardByID(arg0 string) (*model.Board, *model.Block, error) {
+// GetSystemSettings mocks base method.
+func (m *MockStore) GetSystemSettings(arg0 context.Context) (map[string]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardAndCardByID", arg0)
-	ret0, _ := ret[0].(*model.Board)
-	ret1, _ := ret[1].(*model.Block)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GetSystemSettings", arg0)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetBoardAndCardByID indicates an expected call of GetBoardAndCardByID.
-func (mr *MockStoreMockRecorder) GetBoardAndCardByID(arg0 interface{}) *gomock.Call {
+// GetSystemSettings indicates an expected call of GetSystemSettings.
+func (mr *MockStoreMockRecorder) GetSystemSettings(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAndCardByID", reflect.TypeOf((*MockStore)(nil).GetBoardAndCardByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemSettings", reflect.TypeOf((*MockStore)(nil).GetSystemSettings), arg0)
 }
 
-// GetBoardCount mocks base method.
-func (m *MockStore) GetBoardCount() (int64, error) {
+// GetTeam mocks base method.
+func (m *MockStore) GetTeam(arg0 context.Context, arg1 string) (*model.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardCount")
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetTeam", arg0, arg1)
+	ret0, _ := ret[0].(*model.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardCount indicates an expected call of GetBoardCount.
-func (mr *MockStoreMockRecorder) GetBoardCount() *gomock.Call {
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockStoreMockRecorder) GetTeam(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCount", reflect.TypeOf((*MockStore)(nil).GetBoardCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockStore)(nil).GetTeam), arg0, arg1)
 }
 
-// GetBoardHistory mocks base method.
-func (m *MockStore) GetBoardHistory(arg0 string, arg1 model.QueryBoardHistoryOptions) ([]*model.Board, error) {
+// GetTeamBoardsInsights mocks base method.
+func (m *MockStore) GetTeamBoardsInsights(arg0 context.Context, arg1, arg2 string, arg3 int64, arg4, arg5 int, arg6 []string) (*model.BoardInsightsList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardHistory", arg0, arg1)
-	ret0, _ := ret[0].([]*model.Board)
+	ret := m.ctrl.Call(m, "GetTeamBoardsInsights", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*model.BoardInsightsList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardHistory indicates an expected call of GetBoardHistory.
-func (mr *MockStoreMockRecorder) GetBoardHistory(arg0, arg1 interface{}) *gomock.Call {
+// GetTeamBoardsInsights indicates an expected call of GetTeamBoardsInsights.
+func (mr *MockStoreMockRecorder) GetTeamBoardsInsights(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardHistory", reflect.TypeOf((*MockStore)(nil).GetBoardHistory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamBoardsInsights", reflect.TypeOf((*MockStore)(nil).GetTeamBoardsInsights), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
-// GetBoardMemberHistory mocks base method.
-func (m *MockStore) GetBoardMemberHistory(arg0, arg1 string, arg2 uint64) ([]*model.BoardMemberHistoryEntry, error) {
+// GetTeamBySignupToken mocks base method.
+func (m *MockStore) GetTeamBySignupToken(arg0 context.Context, arg1 string) (*model.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardMemberHistory", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]*model.BoardMemberHistoryEntry)
+	ret := m.ctrl.Call(m, "GetTeamBySignupToken", arg0, arg1)
+	ret0, _ := ret[0].(*model.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardMemberHistory indicates an expected call of GetBoardMemberHistory.
-func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetTeamBySignupToken indicates an expected call of GetTeamBySignupToken.
+func (mr *MockStoreMockRecorder) GetTeamBySignupToken(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamBySignupToken", reflect.TypeOf((*MockStore)(nil).GetTeamBySignupToken), arg0, arg1)
 }
 
-// GetBoardsForUserAndTeam mocks base method.
-func (m *MockStore) GetBoardsForUserAndTeam(arg0, arg1 string, arg2 bool) ([]*model.Board, error) {
+// GetTeamCount mocks base method.
+func (m *MockStore) GetTeamCount(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardsForUserAndTeam", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]*model.Board)
+	ret := m.ctrl.Call(m, "GetTeamCount", arg0)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardsForUserAndTeam indicates an expected call of GetBoardsForUserAndTeam.
-func (mr *MockStoreMockRecorder) GetBoardsForUserAndTeam(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetTeamCount indicates an expected call of GetTeamCount.
+func (mr *MockStoreMockRecorder) GetTeamCount(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForUserAndTeam", reflect.TypeOf((*MockStore)(nil).GetBoardsForUserAndTeam), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamCount", reflect.TypeOf((*MockStore)(nil).GetTeamCount), arg0)
 }
 
-// GetBoardsInTeamByIds mocks base method.
-func (m *MockStore) GetBoardsInTeamByIds(arg0 []string, arg1 string) ([]*model.Board, error) {
+// GetTeamsForUser mocks base method.
+func (m *MockStore) GetTeamsForUser(arg0 context.Context, arg1 string) ([]*model.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardsInTeamByIds", arg0, arg1)
-	ret0, _ := ret[0].([]*model.Board)
+	ret := m.ctrl.Call(m, "GetTeamsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardsInTeamByIds indicates an expected call of GetBoardsInTeamByIds.
-func (mr *MockStoreMockRecorder) GetBoardsInTeamByIds(arg0, arg1 interface{}) *gomock.Call {
+// GetTeamsForUser indicates an expected call of GetTeamsForUser.
+func (mr *MockStoreMockRecorder) GetTeamsForUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsInTeamByIds", reflect.TypeOf((*MockStore)(nil).GetBoardsInTeamByIds), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamsForUser", reflect.TypeOf((*MockStore)(nil).GetTeamsForUser), arg0, arg1)
 }
 
-// GetCardLimitTimestamp mocks base method.
-func (m *MockStore) GetCardLimitTimestamp() (int64, error) {
+// GetTeamsForUserWithBoardCounts mocks base method.
+func (m *MockStore) GetTeamsForUserWithBoardCounts(arg0 context.Context, arg1 string) ([]model.TeamWithCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCardLimitTimestamp")
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetTeamsForUserWithBoardCounts", arg0, arg1)
+	ret0, _ := ret[0].([]model.TeamWithCount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCardLimitTimestamp indicates an expected call of GetCardLimitTimestamp.
-func (mr *MockStoreMockRecorder) GetCardLimitTimestamp() *gomock.Call {
+// GetTeamsForUserWithBoardCounts indicates an expected call of GetTeamsForUserWithBoardCounts.
+func (mr *MockStoreMockRecorder) GetTeamsForUserWithBoardCounts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).GetCardLimitTimestamp))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamsForUserWithBoardCounts", reflect.TypeOf((*MockStore)(nil).GetTeamsForUserWithBoardCounts), arg0, arg1)
 }
 
-// GetCategory mocks base method.
-func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
+// GetTemplateBoards mocks base method.
+func (m *MockStore) GetTemplateBoards(arg0 context.Context, arg1, arg2 string) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategory", arg0)
-	ret0, _ := ret[0].(*model.Category)
+	ret := m.ctrl.Call(m, "GetTemplateBoards", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Board)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategory indicates an expected call of GetCategory.
-func (mr *MockStoreMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
+// GetTemplateBoards indicates an expected call of GetTemplateBoards.
+func (mr *MockStoreMockRecorder) GetTemplateBoards(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateBoards", reflect.TypeOf((*MockStore)(nil).GetTemplateBoards), arg0, arg1, arg2)
 }
 
-// GetChannel mocks base method.
-func (m *MockStore) GetChannel(arg0, arg1 string) (*model0.Channel, error) {
+// GetTemplateBoardsByVersion mocks base method.
+func (m *MockStore) GetTemplateBoardsByVersion(arg0 context.Context, arg1 string, arg2 int) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChannel", arg0, arg1)
-	ret0, _ := ret[0].(*model0.Channel)
+	ret := m.ctrl.Call(m, "GetTemplateBoardsByVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Board)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetChannel indicates an expected call of GetChannel.
-func (mr *MockStoreMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call {
+// GetTemplateBoardsByVersion indicates an expected call of GetTemplateBoardsByVersion.
+func (mr *MockStoreMockRecorder) GetTemplateBoardsByVersion(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockStore)(nil).GetChannel), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateBoardsByVersion", reflect.TypeOf((*MockStore)(nil).GetTemplateBoardsByVersion), arg0, arg1, arg2)
 }
 
-// GetCloudLimits mocks base method.
-func (m *MockStore) GetCloudLimits() (*model0.ProductLimits, error) {
+// GetUsedCardsCount mocks base method.
+func (m *MockStore) GetUsedCardsCount(arg0 context.Context) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCloudLimits")
-	ret0, _ := ret[0].(*model0.ProductLimits)
+	ret := m.ctrl.Call(m, "GetUsedCardsCount", arg0)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCloudLimits indicates an expected call of GetCloudLimits.
-func (mr *MockStoreMockRecorder) GetCloudLimits() *gomock.Call {
+// GetUsedCardsCount indicates an expected call of GetUsedCardsCount.
+func (mr *MockStoreMockRecorder) GetUsedCardsCount(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCloudLimits", reflect.TypeOf((*MockStore)(nil).GetCloudLimits))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedCardsCount", reflect.TypeOf((*MockStore)(nil).GetUsedCardsCount), arg0)
 }
 
-// GetFileInfo mocks base method.
-func (m *MockStore) GetFileInfo(arg0 string) (*model0.FileInfo, error) {
+// GetUserBoardsInsights mocks base method.
+func (m *MockStore) GetUserBoardsInsights(arg0 context.Context, arg1, arg2 string, arg3 int64, arg4, arg5 int, arg6 []string) (*model.BoardInsightsList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileInfo", arg0)
-	ret0, _ := ret[0].(*model0.FileInfo)
+	ret := m.ctrl.Call(m, "GetUserBoardsInsights", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*model.BoardInsightsList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFileInfo indicates an expected call of GetFileInfo.
-func (mr *MockStoreMockRecorder) GetFileInfo(arg0 interface{}) *gomock.Call {
+// GetUserBoardsInsights indicates an expected call of GetUserBoardsInsights.
+func (mr *MockStoreMockRecorder) GetUserBoardsInsights(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileInfo", reflect.TypeOf((*MockStore)(nil).GetFileInfo), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBoardsInsights", reflect.TypeOf((*MockStore)(nil).GetUserBoardsInsights), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
-// GetLicense mocks base method.
-func (m *MockStore) GetLicense() *model0.License {
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLicense")
-	ret0, _ := ret[0].(*model0.License)
-	return ret0
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetLicense indicates an expected call of GetLicense.
-func (mr *MockStoreMockRecorder) GetLicense() *gomock.Call {
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLicense", reflect.TypeOf((*MockStore)(nil).GetLicense))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
 }
 
-// GetMemberForBoard mocks base method.
-func (m *MockStore) GetMemberForBoard(arg0, arg1 string) (*model.BoardMember, error) {
+// GetUserByID mocks base method.
+func (m *MockStore) GetUserByID(arg0 context.Context, arg1 string) (*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMemberForBoard", arg0, arg1)
-	ret0, _ := ret[0].(*model.BoardMember)
+	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMemberForBoard indicates an expected call of GetMemberForBoard.
-func (mr *MockStoreMockRecorder) GetMemberForBoard(arg0, arg1 interface{}) *gomock.Call {
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockStoreMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberForBoard", reflect.TypeOf((*MockStore)(nil).GetMemberForBoard), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockStore)(nil).GetUserByID), arg0, arg1)
 }
 
-// GetMembersForBoard mocks base method.
-func (m *MockStore) GetMembersForBoard(arg0 string) ([]*model.BoardMember, error) {
+// GetUserByUsername mocks base method.
+func (m *MockStore) GetUserByUsername(arg0 context.Context, arg1 string) (*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMembersForBoard", arg0)
-	ret0, _ := ret[0].([]*model.BoardMember)
+	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMembersForBoard indicates an expected call of GetMembersForBoard.
-func (mr *MockStoreMockRecorder) GetMembersForBoard(arg0 interface{}) *gomock.Call {
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockStoreMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForBoard", reflect.TypeOf((*MockStore)(nil).GetMembersForBoard), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), arg0, arg1)
 }
 
-// GetMembersForUser mocks base method.
-func (m *MockStore) GetMembersForUser(arg0 string) ([]*model.BoardMember, error) {
+// GetUserCategoryBoards mocks base method.
+func (m *MockStore) GetUserCategoryBoards(arg0 context.Context, arg1, arg2 string) ([]model.CategoryBoards, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMembersForUser", arg0)
-	ret0, _ := ret[0].([]*model.BoardMember)
+	ret := m.ctrl.Call(m, "GetUserCategoryBoards", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.CategoryBoards)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMembersForUser indicates an expected call of GetMembersForUser.
-func (mr *MockStoreMockRecorder) GetMembersForUser(arg0 interface{}) *gomock.Call {
+// GetUserCategoryBoards indicates an expected call of GetUserCategoryBoards.
+func (mr *MockStoreMockRecorder) GetUserCategoryBoards(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForUser", reflect.TypeOf((*MockStore)(nil).GetMembersForUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCategoryBoards", reflect.TypeOf((*MockStore)(nil).GetUserCategoryBoards), arg0, arg1, arg2)
 }
 
-// GetNextNotificationHint mocks base method.
-func (m *MockStore) GetNextNotificationHint(arg0 bool) (*model.NotificationHint, error) {
+// GetUserPreferences mocks base method.
+func (m *MockStore) GetUserPreferences(arg0 context.Context, arg1 string) (model0.Preferences, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNextNotificationHint", arg0)
-	ret0, _ := ret[0].(*model.NotificationHint)
+	ret := m.ctrl.Call(m, "GetUserPreferences", arg0, arg1)
+	ret0, _ := ret[0].(model0.Preferences)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNextNotificationHint indicates an expected call of GetNextNotificationHint.
-func (mr *MockStoreMockRecorder) GetNextNotificationHint(arg0 interface{}) *gomock.Call {
+// GetUserPreferences indicates an expected call of GetUserPreferences.
+func (mr *MockStoreMockRecorder) GetUserPreferences(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNextNotificationHint), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPreferences", reflect.TypeOf((*MockStore)(nil).GetUserPreferences), arg0, arg1)
 }
 
-// GetNotificationHint mocks base method.
-func (m *MockStore) GetNotificationHint(arg0 string) (*model.NotificationHint, error) {
+// GetUserTimezone mocks base method.
+func (m *MockStore) GetUserTimezone(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNotificationHint", arg0)
-	ret0, _ := ret[0].(*model.NotificationHint)
+	ret := m.ctrl.Call(m, "GetUserTimezone", arg0, arg1)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNotificationHint indicates an expected call of GetNotificationHint.
-func (mr *MockStoreMockRecorder) GetNotificationHint(arg0 interface{}) *gomock.Call {
+// GetUserTimezone indicates an expected call of GetUserTimezone.
+func (mr *MockStoreMockRecorder) GetUserTimezone(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimezone", reflect.TypeOf((*MockStore)(nil).GetUserTimezone), arg0, arg1)
 }
 
-// GetRegisteredUserCount mocks base method.
-func (m *MockStore) GetRegisteredUserCount() (int, error) {
+// GetUsersByTeam mocks base method.
+func (m *MockStore) GetUsersByTeam(arg0 context.Context, arg1, arg2 string) ([]*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRegisteredUserCount")
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetUsersByTeam", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRegisteredUserCount indicates an expected call of GetRegisteredUserCount.
-func (mr *MockStoreMockRecorder) GetRegisteredUserCount() *gomock.Call {
+// GetUsersByTeam indicates an expected call of GetUsersByTeam.
+func (mr *MockStoreMockRecorder) GetUsersByTeam(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegisteredUserCount", reflect.TypeOf((*MockStore)(nil).GetRegisteredUserCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByTeam", reflect.TypeOf((*MockStore)(nil).GetUsersByTeam), arg0, arg1, arg2)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 string, arg1 int64) (*model.Session, error) {
+// GetUsersByTeamWithRole mocks base method.
+func (m *MockStore) GetUsersByTeamWithRole(arg0 context.Context, arg1, arg2 string, arg3 bool) ([]*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
-	ret0, _ := ret[0].(*model.Session)
+	ret := m.ctrl.Call(m, "GetUsersByTeamWithRole", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+// GetUsersByTeamWithRole indicates an expected call of GetUsersByTeamWithRole.
+func (mr *MockStoreMockRecorder) GetUsersByTeamWithRole(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByTeamWithRole", reflect.TypeOf((*MockStore)(nil).GetUsersByTeamWithRole), arg0, arg1, arg2, arg3)
 }
 
-// GetSharing mocks base method.
-func (m *MockStore) GetSharing(arg0 string) (*model.Sharing, error) {
+// GetUsersList mocks base method.
+func (m *MockStore) GetUsersList(arg0 context.Context, arg1 []string) ([]*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSharing", arg0)
-	ret0, _ := ret[0].(*model.Sharing)
+	ret := m.ctrl.Call(m, "GetUsersList", arg0, arg1)
+	ret0, _ := ret[0].([]*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSharing indicates an expected call of GetSharing.
-func (mr *MockStoreMockRecorder) GetSharing(arg0 interface{}) *gomock.Call {
+// GetUsersList indicates an expected call of GetUsersList.
+func (mr *MockStoreMockRecorder) GetUsersList(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharing", reflect.TypeOf((*MockStore)(nil).GetSharing), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersList", reflect.TypeOf((*MockStore)(nil).GetUsersList), arg0, arg1)
 }
 
-// GetSubTree2 mocks base method.
-func (m *MockStore) GetSubTree2(arg0, arg1 string, arg2 model.QuerySubtreeOptions) ([]*model.Block, error) {
+// GetWebhook mocks base method.
+func (m *MockStore) GetWebhook(arg0 context.Context, arg1 string) (*model.Webhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubTree2", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]*model.Block)
+	ret := m.ctrl.Call(m, "GetWebhook", arg0, arg1)
+	ret0, _ := ret[0].(*model.Webhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSubTree2 indicates an expected call of GetSubTree2.
-func (mr *MockStoreMockRecorder) GetSubTree2(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockStoreMockRecorder) GetWebhook(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubTree2", reflect.TypeOf((*MockStore)(nil).GetSubTree2), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockStore)(nil).GetWebhook), arg0, arg1)
 }
 
-// GetSubscribersCountForBlock mocks base method.
-func (m *MockStore) GetSubscribersCountForBlock(arg0 string) (int, error) {
+// GetWebhooksForBoard mocks base method.
+func (m *MockStore) GetWebhooksForBoard(arg0 context.Context, arg1 string) ([]*model.Webhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubscribersCountForBlock", arg0)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetWebhooksForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Webhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSubscribersCountForBlock indicates an expected call of GetSubscribersCountForBlock.
-func (mr *MockStoreMockRecorder) GetSubscribersCountForBlock(arg0 interface{}) *gomock.Call {
+// GetWebhooksForBoard indicates an expected call of GetWebhooksForBoard.
+func (mr *MockStoreMockRecorder) GetWebhooksForBoard(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribersCountForBlock", reflect.TypeOf((*MockStore)(nil).GetSubscribersCountForBlock), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksForBoard", reflect.TypeOf((*MockStore)(nil).GetWebhooksForBoard), arg0, arg1)
 }
 
-// GetSubscribersForBlock mocks base method.
-func (m *MockStore) GetSubscribersForBlock(arg0 string) ([]*model.Subscriber, error) {
+// InsertAuditRecord mocks base method.
+func (m *MockStore) InsertAuditRecord(arg0 context.Context, arg1 *model.AuditRecord) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubscribersForBlock", arg0)
-	ret0, _ := ret[0].([]*model.Subscriber)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "InsertAuditRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetSubscribersForBlock indicates an expected call of GetSubscribersForBlock.
-func (mr *MockStoreMockRecorder) GetSubscribersForBlock(arg0 interface{}) *gomock.Call {
+// InsertAuditRecord indicates an expected call of InsertAuditRecord.
+func (mr *MockStoreMockRecorder) InsertAuditRecord(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribersForBlock", reflect.TypeOf((*MockStore)(nil).GetSubscribersForBlock), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuditRecord", reflect.TypeOf((*MockStore)(nil).InsertAuditRecord), arg0, arg1)
 }
 
-// GetSubscription mocks base method.
-func (m *MockStore) GetSubscription(arg0, arg1 string) (*model.Subscription, error) {
+// InsertBlock mocks base method.
+func (m *MockStore) InsertBlock(arg0 context.Context, arg1 *model.Block, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubscription", arg0, arg1)
-	ret0, _ := ret[0].(*model.Subscription)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "InsertBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetSubscription indicates an expected call of GetSubscription.
-func (mr *MockStoreMockRecorder) GetSubscription(arg0, arg1 interface{}) *gomock.Call {
+// InsertBlock indicates an expected call of InsertBlock.
+func (mr *MockStoreMockRecorder) InsertBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockStore)(nil).GetSubscription), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBlock", reflect.TypeOf((*MockStore)(nil).InsertBlock), arg0, arg1, arg2)
 }
 
-// GetSubscriptions mocks base method.
-func (m *MockStore) GetSubscriptions(arg0 string) ([]*model.Subscription, error) {
+// InsertBlocks mocks base method.
+func (m *MockStore) InsertBlocks(arg0 context.Context, arg1 []*model.Block, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubscriptions", arg0)
-	ret0, _ := ret[0].([]*model.Subscription)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "InsertBlocks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetSubscriptions indicates an expected call of GetSubscriptions.
-func (mr *MockStoreMockRecorder) GetSubscriptions(arg0 interface{}) *gomock.Call {
+// InsertBlocks indicates an expected call of InsertBlocks.
+func (mr *MockStoreMockRecorder) InsertBlocks(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockStore)(nil).GetSubscriptions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBlocks", reflect.TypeOf((*MockStore)(nil).InsertBlocks), arg0, arg1, arg2)
 }
 
-// GetSystemSetting mocks base method.
-func (m *MockStore) GetSystemSetting(arg0 string) (string, error) {
+// InsertBoard mocks base method.
+func (m *MockStore) InsertBoard(arg0 context.Context, arg1 *model.Board, arg2 string) (*model.Board, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSystemSetting", arg0)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "InsertBoard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Board)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSystemSetting indicates an expected call of GetSystemSetting.
-func (mr *MockStoreMockRecorder) GetSystemSetting(arg0 interface{}) *gomock.Call {
+// InsertBoard indicates an expected call of InsertBoard.
+func (mr *MockStoreMockRecorder) InsertBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemSetting", reflect.TypeOf((*MockStore)(nil).GetSystemSetting), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoard", reflect.TypeOf((*MockStore)(nil).InsertBoard), arg0, arg1, arg2)
 }
 
-// GetSystemSettings mocks base method.
-func (m *MockStore) GetSystemSettings() (map[string]string, error) {
+// InsertBoardWithAdmin mocks base method.
+func (m *MockStore) InsertBoardWithAdmin(arg0 context.Context, arg1 *model.Board, arg2 string) (*model.Board, *model.BoardMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSystemSettings")
-	ret0, _ := ret[0].(map[string]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "InsertBoardWithAdmin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(*model.BoardMember)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetSystemSettings indicates an expected call of GetSystemSettings.
-func (mr *MockStoreMockRecorder) GetSystemSettings() *gomock.Call {
+// InsertBoardWithAdmin indicates an expected call of InsertBoardWithAdmin.
+func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemSettings", reflect.TypeOf((*MockStore)(nil).GetSystemSettings))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1, arg2)
 }
 
-// GetTeam mocks base method.
-func (m *MockStore) GetTeam(arg0 string) (*model.Team, error) {
+// MarkWebhookDelivered mocks base method.
+func (m *MockStore) MarkWebhookDelivered(arg0 context.Context, arg1 string, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTeam", arg0)
-	ret0, _ := ret[0].(*model.Team)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "MarkWebhookDelivered", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTeam indicates an expected call of GetTeam.
-func (mr *MockStoreMockRecorder) GetTeam(arg0 interface{}) *gomock.Call {
+// MarkWebhookDelivered indicates an expected call of MarkWebhookDelivered.
+func (mr *MockStoreMockRecorder) MarkWebhookDelivered(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockStore)(nil).GetTeam), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWebhookDelivered", reflect.TypeOf((*MockStore)(nil).MarkWebhookDelivered), arg0, arg1, arg2)
 }
 
-// GetTeamBoardsInsights mocks base method.
-func (m *MockStore) GetTeamBoardsInsights(arg0, arg1 string, arg2 int64, arg3, arg4 int, arg5 []string) (*model.BoardInsightsList, error) {
+// MergeBoards mocks base method.
+func (m *MockStore) MergeBoards(arg0 context.Context, arg1, arg2, arg3 string) (map[string]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTeamBoardsInsights", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].(*model.BoardInsightsList)
+	ret := m.ctrl.Call(m, "MergeBoards", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[string]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTeamBoardsInsights indicates an expected call of GetTeamBoardsInsights.
-func (mr *MockStoreMockRecorder) GetTeamBoardsInsights(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+// MergeBoards indicates an expected call of MergeBoards.
+func (mr *MockStoreMockRecorder) MergeBoards(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamBoardsInsights", reflect.TypeOf((*MockStore)(nil).GetTeamBoardsInsights), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeBoards", reflect.TypeOf((*MockStore)(nil).MergeBoards), arg0, arg1, arg2, arg3)
 }
 
-// GetTeamCount mocks base method.
-func (m *MockStore) GetTeamCount() (int64, error) {
+// MergeCategories mocks base method.
+func (m *MockStore) MergeCategories(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTeamCount")
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "MergeCategories", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTeamCount indicates an expected call of GetTeamCount.
-func (mr *MockStoreMockRecorder) GetTeamCount() *gomock.Call {
+// MergeCategories indicates an expected call of MergeCategories.
+func (mr *MockStoreMockRecorder) MergeCategories(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamCount", reflect.TypeOf((*MockStore)(nil).GetTeamCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCategories", reflect.TypeOf((*MockStore)(nil).MergeCategories), arg0, arg1, arg2, arg3)
 }
 
-// GetTeamsForUser mocks base method.
-func (m *MockStore) GetTeamsForUser(arg0 string) ([]*model.Team, error) {
+// MoveBlocks mocks base method.
+func (m *MockStore) MoveBlocks(arg0 context.Context, arg1 []string, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTeamsForUser", arg0)
-	ret0, _ := ret[0].([]*model.Team)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "MoveBlocks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTeamsForUser indicates an expected call of GetTeamsForUser.
-func (mr *MockStoreMockRecorder) GetTeamsForUser(arg0 interface{}) *gomock.Call {
+// MoveBlocks indicates an expected call of MoveBlocks.
+func (mr *MockStoreMockRecorder) MoveBlocks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamsForUser", reflect.TypeOf((*MockStore)(nil).GetTeamsForUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveBlocks", reflect.TypeOf((*MockStore)(nil).MoveBlocks), arg0, arg1, arg2, arg3)
 }
 
-// GetTemplateBoards mocks base method.
-func (m *MockStore) GetTemplateBoards(arg0, arg1 string) ([]*model.Board, error) {
+// NormalizeContentOrder mocks base method.
+func (m *MockStore) NormalizeContentOrder(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTemplateBoards", arg0, arg1)
-	ret0, _ := ret[0].([]*model.Board)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "NormalizeContentOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTemplateBoards indicates an expected call of GetTemplateBoards.
-func (mr *MockStoreMockRecorder) GetTemplateBoards(arg0, arg1 interface{}) *gomock.Call {
+// NormalizeContentOrder indicates an expected call of NormalizeContentOrder.
+func (mr *MockStoreMockRecorder) NormalizeContentOrder(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateBoards", reflect.TypeOf((*MockStore)(nil).GetTemplateBoards), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeContentOrder", reflect.TypeOf((*MockStore)(nil).NormalizeContentOrder), arg0, arg1)
 }
 
-// GetUsedCardsCount mocks base method.
-func (m *MockStore) GetUsedCardsCount() (int, error) {
+// PatchBlock mocks base method.
+func (m *MockStore) PatchBlock(arg0 context.Context, arg1 string, arg2 *model.BlockPatch, arg3 string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsedCardsCount")
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "PatchBlock", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUsedCardsCount indicates an expected call of GetUsedCardsCount.
-func (mr *MockStoreMockRecorder) GetUsedCardsCount() *gomock.Call {
+// PatchBlock indicates an expected call of PatchBlock.
+func (mr *MockStoreMockRecorder) PatchBlock(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedCardsCount", reflect.TypeOf((*MockStore)(nil).GetUsedCardsCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBlock", reflect.TypeOf((*MockStore)(nil).PatchBlock), arg0, arg1, arg2, arg3)
 }
 
-// GetUserBoardsInsights mocks base method.
-func (m *MockStore) GetUserBoardsInsights(arg0, arg1 string, arg2 int64, arg3, arg4 int, arg5 []string) (*model.BoardInsightsList, error) {
+// PatchBlocks mocks base method.
+func (m *MockStore) PatchBlocks(arg0 context.Context, arg1 *model.BlockPatchBatch, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserBoardsInsights", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].(*model.BoardInsightsList)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "PatchBlocks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserBoardsInsights indicates an expected call of GetUserBoardsInsights.
-func (mr *MockStoreMockRecorder) GetUserBoardsInsights(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+// PatchBlocks indicates an expected call of PatchBlocks.
+func (mr *MockStoreMockRecorder) PatchBlocks(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBoardsInsights", reflect.TypeOf((*MockStore)(nil).GetUserBoardsInsights), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBlocks", reflect.TypeOf((*MockStore)(nil).PatchBlocks), arg0, arg1, arg2)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockStore) GetUserByEmail(arg0 string) (*model.User, error) {
+// PatchBoard mocks base method.
+func (m *MockStore) PatchBoard(arg0 context.Context, arg1 string, arg2 *model.BoardPatch, arg3 string) (*model.Board, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", arg0)
-	ret0, _ := ret[0].(*model.User)
+	ret := m.ctrl.Call(m, "PatchBoard", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Board)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockStoreMockRecorder) GetUserByEmail(arg0 interface{}) *gomock.Call {
+// PatchBoard indicates an expected call of PatchBoard.
+func (mr *MockStoreMockRecorder) PatchBoard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBoard", reflect.TypeOf((*MockStore)(nil).PatchBoard), arg0, arg1, arg2, arg3)
 }
 
-// GetUserByID mocks base method.
-func (m *MockStore) GetUserByID(arg0 string) (*model.User, error) {
+// PatchBoardsAndBlocks mocks base method.
+func (m *MockStore) PatchBoardsAndBlocks(arg0 context.Context, arg1 *model.PatchBoardsAndBlocks, arg2 string) (*model.BoardsAndBlocks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", arg0)
-	ret0, _ := ret[0].(*model.User)
+	ret := m.ctrl.Call(m, "PatchBoardsAndBlocks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.BoardsAndBlocks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockStoreMockRecorder) GetUserByID(arg0 interface{}) *gomock.Call {
+// PatchBoardsAndBlocks indicates an expected call of PatchBoardsAndBlocks.
+func (mr *MockStoreMockRecorder) PatchBoardsAndBlocks(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockStore)(nil).GetUserByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).PatchBoardsAndBlocks), arg0, arg1, arg2)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockStore) GetUserByUsername(arg0 string) (*model.User, error) {
+// PatchUserPreferences mocks base method.
+func (m *MockStore) PatchUserPreferences(arg0 context.Context, arg1 string, arg2 model.UserPreferencesPatch) (model0.Preferences, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUsername", arg0)
-	ret0, _ := ret[0].(*model.User)
+	ret := m.ctrl.Call(m, "PatchUserPreferences", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model0.Preferences)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByUsername indicates an expected call of GetUserByUsername.
-func (mr *MockStoreMockRecorder) GetUserByUsername(arg0 interface{}) *gomock.Call {
+// PatchUserPreferences indicates an expected call of PatchUserPreferences.
+func (mr *MockStoreMockRecorder) PatchUserPreferences(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUserPreferences", reflect.TypeOf((*MockStore)(nil).PatchUserPreferences), arg0, arg1, arg2)
 }
 
-// GetUserCategoryBoards mocks base method.
-func (m *MockStore) GetUserCategoryBoards(arg0, arg1 string) ([]model.CategoryBoards, error) {
+// PostMessage mocks base method.
+func (m *MockStore) PostMessage(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserCategoryBoards", arg0, arg1)
-	ret0, _ := ret[0].([]model.CategoryBoards)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "PostMessage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserCategoryBoards indicates an expected call of GetUserCategoryBoards.
-func (mr *MockStoreMockRecorder) GetUserCategoryBoards(arg0, arg1 interface{}) *gomock.Call {
+// PostMessage indicates an expected call of PostMessage.
+func (mr *MockStoreMockRecorder) PostMessage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCategoryBoards", reflect.TypeOf((*MockStore)(nil).GetUserCategoryBoards), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostMessage", reflect.TypeOf((*MockStore)(nil).PostMessage), arg0, arg1, arg2, arg3)
 }
 
-// GetUserPreferences mocks base method.
-func (m *MockStore) GetUserPreferences(arg0 string) (model0.Preferences, error) {
+// PurgeArchivedBoards mocks base method.
+func (m *MockStore) PurgeArchivedBoards(arg0 context.Context, arg1 time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserPreferences", arg0)
-	ret0, _ := ret[0].(model0.Preferences)
+	ret := m.ctrl.Call(m, "PurgeArchivedBoards", arg0, arg1)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserPreferences indicates an expected call of GetUserPreferences.
-func (mr *MockStoreMockRecorder) GetUserPreferences(arg0 interface{}) *gomock.Call {
+// PurgeArchivedBoards indicates an expected call of PurgeArchivedBoards.
+func (mr *MockStoreMockRecorder) PurgeArchivedBoards(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPreferences", reflect.TypeOf((*MockStore)(nil).GetUserPreferences), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeArchivedBoards", reflect.TypeOf((*MockStore)(nil).PurgeArchivedBoards), arg0, arg1)
 }
 
-// GetUserTimezone mocks base method.
-func (m *MockStore) GetUserTimezone(arg0 string) (string, error) {
+// PurgeDeletedBlocks mocks base method.
+func (m *MockStore) PurgeDeletedBlocks(arg0 context.Context, arg1 time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserTimezone", arg0)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "PurgeDeletedBlocks", arg0, arg1)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserTimezone indicates an expected call of GetUserTimezone.
-func (mr *MockStoreMockRecorder) GetUserTimezone(arg0 interface{}) *gomock.Call {
+// PurgeDeletedBlocks indicates an expected call of PurgeDeletedBlocks.
+func (mr *MockStoreMockRecorder) PurgeDeletedBlocks(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimezone", reflect.TypeOf((*MockStore)(nil).GetUserTimezone), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBlocks", reflect.TypeOf((*MockStore)(nil).PurgeDeletedBlocks), arg0, arg1)
 }
 
-// GetUsersByTeam mocks base method.
-func (m *MockStore) GetUsersByTeam(arg0, arg1 string) ([]*model.User, error) {
+// ReactivateUser mocks base method.
+func (m *MockStore) ReactivateUser(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersByTeam", arg0, arg1)
-	ret0, _ := ret[0].([]*model.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ReactivateUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUsersByTeam indicates an expected call of GetUsersByTeam.
-func (mr *MockStoreMockRecorder) GetUsersByTeam(arg0, arg1 interface{}) *gomock.Call {
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockStoreMockRecorder) ReactivateUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByTeam", reflect.TypeOf((*MockStore)(nil).GetUsersByTeam), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockStore)(nil).ReactivateUser), arg0, arg1)
 }
 
-// GetUsersList mocks base method.
-func (m *MockStore) GetUsersList(arg0 []string) ([]*model.User, error) {
+// RecordWebhookDelivery mocks base method.
+func (m *MockStore) RecordWebhookDelivery(arg0 context.Context, arg1 *model.WebhookDelivery) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersList", arg0)
-	ret0, _ := ret[0].([]*model.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RecordWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUsersList indicates an expected call of GetUsersList.
-func (mr *MockStoreMockRecorder) GetUsersList(arg0 interface{}) *gomock.Call {
+// RecordWebhookDelivery indicates an expected call of RecordWebhookDelivery.
+func (mr *MockStoreMockRecorder) RecordWebhookDelivery(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersList", reflect.TypeOf((*MockStore)(nil).GetUsersList), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDelivery", reflect.TypeOf((*MockStore)(nil).RecordWebhookDelivery), arg0, arg1)
 }
 
-// InsertBlock mocks base method.
-func (m *MockStore) InsertBlock(arg0 *model.Block, arg1 string) error {
+// RefreshSession mocks base method.
+func (m *MockStore) RefreshSession(arg0 context.Context, arg1 *model.Session) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertBlock", arg0, arg1)
+	ret := m.ctrl.Call(m, "RefreshSession", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertBlock indicates an expected call of InsertBlock.
-func (mr *MockStoreMockRecorder) InsertBlock(arg0, arg1 interface{}) *gomock.Call {
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockStoreMockRecorder) RefreshSession(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBlock", reflect.TypeOf((*MockStore)(nil).InsertBlock), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockStore)(nil).RefreshSession), arg0, arg1)
 }
 
-// InsertBlocks mocks base method.
-func (m *MockStore) InsertBlocks(arg0 []*model.Block, arg1 string) error {
+// ReinstallDefaultTemplates mocks base method.
+func (m *MockStore) ReinstallDefaultTemplates(arg0 context.Context, arg1 string, arg2 []*model.Board, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertBlocks", arg0, arg1)
+	ret := m.ctrl.Call(m, "ReinstallDefaultTemplates", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertBlocks indicates an expected call of InsertBlocks.
-func (mr *MockStoreMockRecorder) InsertBlocks(arg0, arg1 interface{}) *gomock.Call {
+// ReinstallDefaultTemplates indicates an expected call of ReinstallDefaultTemplates.
+func (mr *MockStoreMockRecorder) ReinstallDefaultTemplates(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBlocks", reflect.TypeOf((*MockStore)(nil).InsertBlocks), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReinstallDefaultTemplates", reflect.TypeOf((*MockStore)(nil).ReinstallDefaultTemplates), arg0, arg1, arg2, arg3)
 }
 
-// InsertBoard mocks base method.
-func (m *MockStore) InsertBoard(arg0 *model.Board, arg1 string) (*model.Board, error) {
+// RemoveCategoryBoards mocks base method.
+func (m *MockStore) RemoveCategoryBoards(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertBoard", arg0, arg1)
-	ret0, _ := ret[0].(*model.Board)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RemoveCategoryBoards", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// InsertBoard indicates an expected call of InsertBoard.
-func (mr *MockStoreMockRecorder) InsertBoard(arg0, arg1 interface{}) *gomock.Call {
+// RemoveCategoryBoards indicates an expected call of RemoveCategoryBoards.
+func (mr *MockStoreMockRecorder) RemoveCategoryBoards(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoard", reflect.TypeOf((*MockStore)(nil).InsertBoard), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCategoryBoards", reflect.TypeOf((*MockStore)(nil).RemoveCategoryBoards), arg0, arg1, arg2, arg3)
 }
 
-// InsertBoardWithAdmin mocks base method.
-func (m *MockStore) InsertBoardWithAdmin(arg0 *model.Board, arg1 string) (*model.Board, *model.BoardMember, error) {
+// RemoveDefaultTemplates mocks base method.
+func (m *MockStore) RemoveDefaultTemplates(arg0 context.Context, arg1 []*model.Board) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertBoardWithAdmin", arg0, arg1)
-	ret0, _ := ret[0].(*model.Board)
-	ret1, _ := ret[1].(*model.BoardMember)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "RemoveDefaultTemplates", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// InsertBoardWithAdmin indicates an expected call of InsertBoardWithAdmin.
-func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1 interface{}) *gomock.Call {
+// RemoveDefaultTemplates indicates an expected call of RemoveDefaultTemplates.
+func (mr *MockStoreMockRecorder) RemoveDefaultTemplates(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDefaultTemplates", reflect.TypeOf((*MockStore)(nil).RemoveDefaultTemplates), arg0, arg1)
 }
 
-// PatchBlock mocks base method.
-func (m *MockStore) PatchBlock(arg0 string, arg1 *model.BlockPatch, arg2 string) error {
+// ReorderCategories mocks base method.
+func (m *MockStore) ReorderCategories(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PatchBlock", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ReorderCategories", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PatchBlock indicates an expected call of PatchBlock.
-func (mr *MockStoreMockRecorder) PatchBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+// ReorderCategories indicates an expected call of ReorderCategories.
+func (mr *MockStoreMockRecorder) ReorderCategories(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBlock", reflect.TypeOf((*MockStore)(nil).PatchBlock), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderCategories", reflect.TypeOf((*MockStore)(nil).ReorderCategories), arg0, arg1, arg2, arg3)
 }
 
-// PatchBlocks mocks base method.
-func (m *MockStore) PatchBlocks(arg0 *model.BlockPatchBatch, arg1 string) error {
+// ReorderCategoryBoards mocks base method.
+func (m *MockStore) ReorderCategoryBoards(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PatchBlocks", arg0, arg1)
+	ret := m.ctrl.Call(m, "ReorderCategoryBoards", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PatchBlocks indicates an expected call of PatchBlocks.
-func (mr *MockStoreMockRecorder) PatchBlocks(arg0, arg1 interface{}) *gomock.Call {
+// ReorderCategoryBoards indicates an expected call of ReorderCategoryBoards.
+func (mr *MockStoreMockRecorder) ReorderCategoryBoards(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBlocks", reflect.TypeOf((*MockStore)(nil).PatchBlocks), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderCategoryBoards", reflect.TypeOf((*MockStore)(nil).ReorderCategoryBoards), arg0, arg1, arg2, arg3)
 }
 
-// PatchBoard mocks base method.
-func (m *MockStore) PatchBoard(arg0 string, arg1 *model.BoardPatch, arg2 string) (*model.Board, error) {
+// RestoreBlock mocks base method.
+func (m *MockStore) RestoreBlock(arg0 context.Context, arg1, arg2 string) (*model.Block, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PatchBoard", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*model.Board)
+	ret := m.ctrl.Call(m, "RestoreBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Block)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PatchBoard indicates an expected call of PatchBoard.
-func (mr *MockStoreMockRecorder) PatchBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+// RestoreBlock indicates an expected call of RestoreBlock.
+func (mr *MockStoreMockRecorder) RestoreBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBoard", reflect.TypeOf((*MockStore)(nil).PatchBoard), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBlock", reflect.TypeOf((*MockStore)(nil).RestoreBlock), arg0, arg1, arg2)
 }
 
-// PatchBoardsAndBlocks mocks base method.
-func (m *MockStore) PatchBoardsAndBlocks(arg0 *model.PatchBoardsAndBlocks, arg1 string) (*model.BoardsAndBlocks, error) {
+// RestoreBoard mocks base method.
+func (m *MockStore) RestoreBoard(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PatchBoardsAndBlocks", arg0, arg1)
-	ret0, _ := ret[0].(*model.BoardsAndBlocks)
+	ret := m.ctrl.Call(m, "RestoreBoard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreBoard indicates an expected call of RestoreBoard.
+func (mr *MockStoreMockRecorder) RestoreBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBoard", reflect.TypeOf((*MockStore)(nil).RestoreBoard), arg0, arg1, arg2)
+}
+
+// RotateSharingToken mocks base method.
+func (m *MockStore) RotateSharingToken(arg0 context.Context, arg1, arg2 string) (*model.Sharing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSharingToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Sharing)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PatchBoardsAndBlocks indicates an expected call of PatchBoardsAndBlocks.
-func (mr *MockStoreMockRecorder) PatchBoardsAndBlocks(arg0, arg1 interface{}) *gomock.Call {
+// RotateSharingToken indicates an expected call of RotateSharingToken.
+func (mr *MockStoreMockRecorder) RotateSharingToken(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).PatchBoardsAndBlocks), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSharingToken", reflect.TypeOf((*MockStore)(nil).RotateSharingToken), arg0, arg1, arg2)
 }
 
-// PatchUserPreferences mocks base method.
-func (m *MockStore) PatchUserPreferences(arg0 string, arg1 model.UserPreferencesPatch) (model0.Preferences, error) {
+// RunDataRetention mocks base method.
+func (m *MockStore) RunDataRetention(arg0 context.Context, arg1, arg2 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PatchUserPreferences", arg0, arg1)
-	ret0, _ := ret[0].(model0.Preferences)
+	ret := m.ctrl.Call(m, "RunDataRetention", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PatchUserPreferences indicates an expected call of PatchUserPreferences.
-func (mr *MockStoreMockRecorder) PatchUserPreferences(arg0, arg1 interface{}) *gomock.Call {
+// RunDataRetention indicates an expected call of RunDataRetention.
+func (mr *MockStoreMockRecorder) RunDataRetention(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUserPreferences", reflect.TypeOf((*MockStore)(nil).PatchUserPreferences), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDataRetention", reflect.TypeOf((*MockStore)(nil).RunDataRetention), arg0, arg1, arg2)
 }
 
-// PostMessage mocks base method.
-func (m *MockStore) PostMessage(arg0, arg1, arg2 string) error {
+// SaveFileInfo mocks base method.
+func (m *MockStore) SaveFileInfo(arg0 context.Context, arg1 *model0.FileInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PostMessage", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "SaveFileInfo", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PostMessage indicates an expected call of PostMessage.
-func (mr *MockStoreMockRecorder) PostMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+// SaveFileInfo indicates an expected call of SaveFileInfo.
+func (mr *MockStoreMockRecorder) SaveFileInfo(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostMessage", reflect.TypeOf((*MockStore)(nil).PostMessage), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFileInfo", reflect.TypeOf((*MockStore)(nil).SaveFileInfo), arg0, arg1)
 }
 
-// RefreshSession mocks base method.
-func (m *MockStore) RefreshSession(arg0 *model.Session) error {
+// SaveMember mocks base method.
+func (m *MockStore) SaveMember(arg0 context.Context, arg1 *model.BoardMember, arg2 string) (*model.BoardMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshSession", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SaveMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RefreshSession indicates an expected call of RefreshSession.
-func (mr *MockStoreMockRecorder) RefreshSession(arg0 interface{}) *gomock.Call {
+// SaveMember indicates an expected call of SaveMember.
+func (mr *MockStoreMockRecorder) SaveMember(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockStore)(nil).RefreshSession), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMember", reflect.TypeOf((*MockStore)(nil).SaveMember), arg0, arg1, arg2)
 }
 
-// RemoveDefaultTemplates mocks base method.
-func (m *MockStore) RemoveDefaultTemplates(arg0 []*model.Board) error {
+// SaveMemberWithLimit mocks base method.
+func (m *MockStore) SaveMemberWithLimit(arg0 context.Context, arg1 *model.BoardMember, arg2 int, arg3 string) (*model.BoardMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveDefaultTemplates", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SaveMemberWithLimit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RemoveDefaultTemplates indicates an expected call of RemoveDefaultTemplates.
-func (mr *MockStoreMockRecorder) RemoveDefaultTemplates(arg0 interface{}) *gomock.Call {
+// SaveMemberWithLimit indicates an expected call of SaveMemberWithLimit.
+func (mr *MockStoreMockRecorder) SaveMemberWithLimit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDefaultTemplates", reflect.TypeOf((*MockStore)(nil).RemoveDefaultTemplates), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMemberWithLimit", reflect.TypeOf((*MockStore)(nil).SaveMemberWithLimit), arg0, arg1, arg2, arg3)
 }
 
-// RunDataRetention mocks base method.
-func (m *MockStore) RunDataRetention(arg0, arg1 int64) (int64, error) {
+// SaveMembers mocks base method.
+func (m *MockStore) SaveMembers(arg0 context.Context, arg1 []*model.BoardMember, arg2 string) ([]*model.BoardMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RunDataRetention", arg0, arg1)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "SaveMembers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.BoardMember)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RunDataRetention indicates an expected call of RunDataRetention.
-func (mr *MockStoreMockRecorder) RunDataRetention(arg0, arg1 interface{}) *gomock.Call {
+// SaveMembers indicates an expected call of SaveMembers.
+func (mr *MockStoreMockRecorder) SaveMembers(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDataRetention", reflect.TypeOf((*MockStore)(nil).RunDataRetention), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMembers", reflect.TypeOf((*MockStore)(nil).SaveMembers), arg0, arg1, arg2)
 }
 
-// SaveFileInfo mocks base method.
-func (m *MockStore) SaveFileInfo(arg0 *model0.FileInfo) error {
+// SearchBlocksForBoard mocks base method.
+func (m *MockStore) SearchBlocksForBoard(arg0 context.Context, arg1, arg2 string, arg3 []string) ([]*model.Block, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveFileInfo", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SearchBlocksForBoard", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SaveFileInfo indicates an expected call of SaveFileInfo.
-func (mr *MockStoreMockRecorder) SaveFileInfo(arg0 interface{}) *gomock.Call {
+// SearchBlocksForBoard indicates an expected call of SearchBlocksForBoard.
+func (mr *MockStoreMockRecorder) SearchBlocksForBoard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFileInfo", reflect.TypeOf((*MockStore)(nil).SaveFileInfo), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBlocksForBoard", reflect.TypeOf((*MockStore)(nil).SearchBlocksForBoard), arg0, arg1, arg2, arg3)
 }
 
-// SaveMember mocks base method.
-func (m *MockStore) SaveMember(arg0 *model.BoardMember) (*model.BoardMember, error) {
+// SearchBlocksForUser mocks base method.
+func (m *MockStore) SearchBlocksForUser(arg0 context.Context, arg1, arg2, arg3 string, arg4 model.SearchBlocksOptions) ([]*model.Block, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveMember", arg0)
-	ret0, _ := ret[0].(*model.BoardMember)
+	ret := m.ctrl.Call(m, "SearchBlocksForUser", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*model.Block)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SaveMember indicates an expected call of SaveMember.
-func (mr *MockStoreMockRecorder) SaveMember(arg0 interface{}) *gomock.Call {
+// SearchBlocksForUser indicates an expected call of SearchBlocksForUser.
+func (mr *MockStoreMockRecorder) SearchBlocksForUser(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMember", reflect.TypeOf((*MockStore)(nil).SaveMember), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBlocksForUser", reflect.TypeOf((*MockStore)(nil).SearchBlocksForUser), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SearchBlocksInBoards mocks base method.
+func (m *MockStore) SearchBlocksInBoards(arg0 context.Context, arg1 []string, arg2 string, arg3 model.SearchBlocksOptions) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBlocksInBoards", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBlocksInBoards indicates an expected call of SearchBlocksInBoards.
+func (mr *MockStoreMockRecorder) SearchBlocksInBoards(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBlocksInBoards", reflect.TypeOf((*MockStore)(nil).SearchBlocksInBoards), arg0, arg1, arg2, arg3)
 }
 
 // SearchBoardsForUser mocks base method.
-func (m *MockStore) SearchBoardsForUser(arg0, arg1 string, arg2 bool) ([]*model.Board, error) {
+func (m *MockStore) SearchBoardsForUser(arg0 context.Context, arg1, arg2 string, arg3 bool) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchBoardsForUser", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "SearchBoardsForUser", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*model.Board)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchBoardsForUser indicates an expected call of SearchBoardsForUser.
-func (mr *MockStoreMockRecorder) SearchBoardsForUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) SearchBoardsForUser(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBoardsForUser", reflect.TypeOf((*MockStore)(nil).SearchBoardsForUser), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBoardsForUser", reflect.TypeOf((*MockStore)(nil).SearchBoardsForUser), arg0, arg1, arg2, arg3)
 }
 
 // SearchBoardsForUserInTeam mocks base method.
-func (m *MockStore) SearchBoardsForUserInTeam(arg0, arg1, arg2 string) ([]*model.Board, error) {
+func (m *MockStore) SearchBoardsForUserInTeam(arg0 context.Context, arg1, arg2, arg3 string) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchBoardsForUserInTeam", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "SearchBoardsForUserInTeam", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*model.Board)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchBoardsForUserInTeam indicates an expected call of SearchBoardsForUserInTeam.
-func (mr *MockStoreMockRecorder) SearchBoardsForUserInTeam(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) SearchBoardsForUserInTeam(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBoardsForUserInTeam", reflect.TypeOf((*MockStore)(nil).SearchBoardsForUserInTeam), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBoardsForUserInTeam", reflect.TypeOf((*MockStore)(nil).SearchBoardsForUserInTeam), arg0, arg1, arg2, arg3)
 }
 
 // SearchUserChannels mocks base method.
-func (m *MockStore) SearchUserChannels(arg0, arg1, arg2 string) ([]*model0.Channel, error) {
+func (m *MockStore) SearchUserChannels(arg0 context.Context, arg1, arg2, arg3 string) ([]*model0.Channel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUserChannels", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "SearchUserChannels", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*model0.Channel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchUserChannels indicates an expected call of SearchUserChannels.
-func (mr *MockStoreMockRecorder) SearchUserChannels(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) SearchUserChannels(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUserChannels", reflect.TypeOf((*MockStore)(nil).SearchUserChannels), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUserChannels", reflect.TypeOf((*MockStore)(nil).SearchUserChannels), arg0, arg1, arg2, arg3)
 }
 
 // SearchUsersByTeam mocks base method.
-func (m *MockStore) SearchUsersByTeam(arg0, arg1, arg2 string, arg3 bool) ([]*model.User, error) {
+func (m *MockStore) SearchUsersByTeam(arg0 context.Context, arg1, arg2, arg3 string, arg4 bool) ([]*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUsersByTeam", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "SearchUsersByTeam", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].([]*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchUsersByTeam indicates an expected call of SearchUsersByTeam.
-func (mr *MockStoreMockRecorder) SearchUsersByTeam(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) SearchUsersByTeam(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersByTeam", reflect.TypeOf((*MockStore)(nil).SearchUsersByTeam), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersByTeam", reflect.TypeOf((*MockStore)(nil).SearchUsersByTeam), arg0, arg1, arg2, arg3, arg4)
 }
 
 // SendMessage mocks base method.
-func (m *MockStore) SendMessage(arg0, arg1 string, arg2 []string) error {
+func (m *MockStore) SendMessage(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendMessage", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "SendMessage", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendMessage indicates an expected call of SendMessage.
-func (mr *MockStoreMockRecorder) SendMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) SendMessage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockStore)(nil).SendMessage), arg0, arg1, arg2, arg3)
+}
+
+// SetBoardBlockLimit mocks base method.
+func (m *MockStore) SetBoardBlockLimit(arg0 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBoardBlockLimit", arg0)
+}
+
+// SetBoardBlockLimit indicates an expected call of SetBoardBlockLimit.
+func (mr *MockStoreMockRecorder) SetBoardBlockLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoardBlockLimit", reflect.TypeOf((*MockStore)(nil).SetBoardBlockLimit), arg0)
+}
+
+// SetBoardFavorite mocks base method.
+func (m *MockStore) SetBoardFavorite(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBoardFavorite", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBoardFavorite indicates an expected call of SetBoardFavorite.
+func (mr *MockStoreMockRecorder) SetBoardFavorite(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoardFavorite", reflect.TypeOf((*MockStore)(nil).SetBoardFavorite), arg0, arg1, arg2, arg3)
+}
+
+// SetBoardPropertyOrder mocks base method.
+func (m *MockStore) SetBoardPropertyOrder(arg0 context.Context, arg1 string, arg2 []string, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBoardPropertyOrder", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBoardPropertyOrder indicates an expected call of SetBoardPropertyOrder.
+func (mr *MockStoreMockRecorder) SetBoardPropertyOrder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoardPropertyOrder", reflect.TypeOf((*MockStore)(nil).SetBoardPropertyOrder), arg0, arg1, arg2, arg3)
+}
+
+// SetMemberCustomRole mocks base method.
+func (m *MockStore) SetMemberCustomRole(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMemberCustomRole", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMemberCustomRole indicates an expected call of SetMemberCustomRole.
+func (mr *MockStoreMockRecorder) SetMemberCustomRole(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockStore)(nil).SendMessage), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberCustomRole", reflect.TypeOf((*MockStore)(nil).SetMemberCustomRole), arg0, arg1, arg2, arg3, arg4)
 }
 
 // SetSystemSetting mocks base method.
-func (m *MockStore) SetSystemSetting(arg0, arg1 string) error {
+func (m *MockStore) SetSystemSetting(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetSystemSetting", arg0, arg1)
+	ret := m.ctrl.Call(m, "SetSystemSetting", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetSystemSetting indicates an expected call of SetSystemSetting.
-func (mr *MockStoreMockRecorder) SetSystemSetting(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) SetSystemSetting(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSystemSetting", reflect.TypeOf((*MockStore)(nil).SetSystemSetting), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSystemSetting", reflect.TypeOf((*MockStore)(nil).SetSystemSetting), arg0, arg1, arg2)
 }
 
 // Shutdown mocks base method.
@@ -1486,187 +2996,343 @@ func (mr *MockStoreMockRecorder) Shutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockStore)(nil).Shutdown))
 }
 
+// UnarchiveCard mocks base method.
+func (m *MockStore) UnarchiveCard(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveCard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnarchiveCard indicates an expected call of UnarchiveCard.
+func (mr *MockStoreMockRecorder) UnarchiveCard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveCard", reflect.TypeOf((*MockStore)(nil).UnarchiveCard), arg0, arg1, arg2)
+}
+
 // UndeleteBlock mocks base method.
-func (m *MockStore) UndeleteBlock(arg0, arg1 string) error {
+func (m *MockStore) UndeleteBlock(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UndeleteBlock", arg0, arg1)
+	ret := m.ctrl.Call(m, "UndeleteBlock", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UndeleteBlock indicates an expected call of UndeleteBlock.
-func (mr *MockStoreMockRecorder) UndeleteBlock(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UndeleteBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBlock", reflect.TypeOf((*MockStore)(nil).UndeleteBlock), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBlock", reflect.TypeOf((*MockStore)(nil).UndeleteBlock), arg0, arg1, arg2)
 }
 
 // UndeleteBoard mocks base method.
-func (m *MockStore) UndeleteBoard(arg0, arg1 string) error {
+func (m *MockStore) UndeleteBoard(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UndeleteBoard", arg0, arg1)
+	ret := m.ctrl.Call(m, "UndeleteBoard", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UndeleteBoard indicates an expected call of UndeleteBoard.
-func (mr *MockStoreMockRecorder) UndeleteBoard(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UndeleteBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBoard", reflect.TypeOf((*MockStore)(nil).UndeleteBoard), arg0, arg1, arg2)
+}
+
+// UpdateBoardCustomRole mocks base method.
+func (m *MockStore) UpdateBoardCustomRole(arg0 context.Context, arg1 *model.BoardCustomRole, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardCustomRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBoardCustomRole indicates an expected call of UpdateBoardCustomRole.
+func (mr *MockStoreMockRecorder) UpdateBoardCustomRole(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBoard", reflect.TypeOf((*MockStore)(nil).UndeleteBoard), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardCustomRole", reflect.TypeOf((*MockStore)(nil).UpdateBoardCustomRole), arg0, arg1, arg2)
 }
 
 // UpdateCardLimitTimestamp mocks base method.
-func (m *MockStore) UpdateCardLimitTimestamp(arg0 int) (int64, error) {
+func (m *MockStore) UpdateCardLimitTimestamp(arg0 context.Context, arg1 int) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCardLimitTimestamp", arg0)
+	ret := m.ctrl.Call(m, "UpdateCardLimitTimestamp", arg0, arg1)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateCardLimitTimestamp indicates an expected call of UpdateCardLimitTimestamp.
-func (mr *MockStoreMockRecorder) UpdateCardLimitTimestamp(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpdateCardLimitTimestamp(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).UpdateCardLimitTimestamp), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).UpdateCardLimitTimestamp), arg0, arg1)
 }
 
 // UpdateCategory mocks base method.
-func (m *MockStore) UpdateCategory(arg0 model.Category) error {
+func (m *MockStore) UpdateCategory(arg0 context.Context, arg1 model.Category) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCategory", arg0)
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCategory indicates an expected call of UpdateCategory.
-func (mr *MockStoreMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0, arg1)
+}
+
+// UpdateMemberLastViewed mocks base method.
+func (m *MockStore) UpdateMemberLastViewed(arg0 context.Context, arg1, arg2 string, arg3 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberLastViewed", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberLastViewed indicates an expected call of UpdateMemberLastViewed.
+func (mr *MockStoreMockRecorder) UpdateMemberLastViewed(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberLastViewed", reflect.TypeOf((*MockStore)(nil).UpdateMemberLastViewed), arg0, arg1, arg2, arg3)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockStore) UpdateMemberRole(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockStoreMockRecorder) UpdateMemberRole(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockStore)(nil).UpdateMemberRole), arg0, arg1, arg2, arg3, arg4)
 }
 
 // UpdateSession mocks base method.
-func (m *MockStore) UpdateSession(arg0 *model.Session) error {
+func (m *MockStore) UpdateSession(arg0 context.Context, arg1 *model.Session) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSession", arg0)
+	ret := m.ctrl.Call(m, "UpdateSession", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateSession indicates an expected call of UpdateSession.
-func (mr *MockStoreMockRecorder) UpdateSession(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpdateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSession", reflect.TypeOf((*MockStore)(nil).UpdateSession), arg0, arg1)
+}
+
+// UpdateSubscriberNotifiedAt mocks base method.
+func (m *MockStore) UpdateSubscriberNotifiedAt(arg0 context.Context, arg1, arg2 string, arg3 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriberNotifiedAt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscriberNotifiedAt indicates an expected call of UpdateSubscriberNotifiedAt.
+func (mr *MockStoreMockRecorder) UpdateSubscriberNotifiedAt(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSession", reflect.TypeOf((*MockStore)(nil).UpdateSession), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriberNotifiedAt", reflect.TypeOf((*MockStore)(nil).UpdateSubscriberNotifiedAt), arg0, arg1, arg2, arg3)
 }
 
 // UpdateSubscribersNotifiedAt mocks base method.
-func (m *MockStore) UpdateSubscribersNotifiedAt(arg0 string, arg1 int64) error {
+func (m *MockStore) UpdateSubscribersNotifiedAt(arg0 context.Context, arg1 string, arg2 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSubscribersNotifiedAt", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateSubscribersNotifiedAt", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateSubscribersNotifiedAt indicates an expected call of UpdateSubscribersNotifiedAt.
-func (mr *MockStoreMockRecorder) UpdateSubscribersNotifiedAt(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpdateSubscribersNotifiedAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscribersNotifiedAt", reflect.TypeOf((*MockStore)(nil).UpdateSubscribersNotifiedAt), arg0, arg1, arg2)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockStore) UpdateSubscription(arg0 context.Context, arg1 *model.Subscription) (*model.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", arg0, arg1)
+	ret0, _ := ret[0].(*model.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockStoreMockRecorder) UpdateSubscription(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscribersNotifiedAt", reflect.TypeOf((*MockStore)(nil).UpdateSubscribersNotifiedAt), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockStore)(nil).UpdateSubscription), arg0, arg1)
 }
 
 // UpdateUser mocks base method.
-func (m *MockStore) UpdateUser(arg0 *model.User) (*model.User, error) {
+func (m *MockStore) UpdateUser(arg0 context.Context, arg1 *model.User) (*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", arg0)
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
 	ret0, _ := ret[0].(*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockStoreMockRecorder) UpdateUser(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0, arg1)
 }
 
 // UpdateUserPassword mocks base method.
-func (m *MockStore) UpdateUserPassword(arg0, arg1 string) error {
+func (m *MockStore) UpdateUserPassword(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUserPassword indicates an expected call of UpdateUserPassword.
-func (mr *MockStoreMockRecorder) UpdateUserPassword(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpdateUserPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), arg0, arg1, arg2)
 }
 
 // UpdateUserPasswordByID mocks base method.
-func (m *MockStore) UpdateUserPasswordByID(arg0, arg1 string) error {
+func (m *MockStore) UpdateUserPasswordByID(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserPasswordByID", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateUserPasswordByID", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUserPasswordByID indicates an expected call of UpdateUserPasswordByID.
-func (mr *MockStoreMockRecorder) UpdateUserPasswordByID(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpdateUserPasswordByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPasswordByID", reflect.TypeOf((*MockStore)(nil).UpdateUserPasswordByID), arg0, arg1, arg2)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockStore) UpdateWebhook(arg0 context.Context, arg1 *model.Webhook, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockStoreMockRecorder) UpdateWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPasswordByID", reflect.TypeOf((*MockStore)(nil).UpdateUserPasswordByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockStore)(nil).UpdateWebhook), arg0, arg1, arg2)
+}
+
+// UpgradeDefaultTemplates mocks base method.
+func (m *MockStore) UpgradeDefaultTemplates(arg0 context.Context, arg1 string, arg2 int, arg3 *model.BoardsAndBlocks) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeDefaultTemplates", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeDefaultTemplates indicates an expected call of UpgradeDefaultTemplates.
+func (mr *MockStoreMockRecorder) UpgradeDefaultTemplates(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeDefaultTemplates", reflect.TypeOf((*MockStore)(nil).UpgradeDefaultTemplates), arg0, arg1, arg2, arg3)
+}
+
+// UpsertBoardSnapshot mocks base method.
+func (m *MockStore) UpsertBoardSnapshot(arg0 context.Context, arg1 *model.BoardSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertBoardSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertBoardSnapshot indicates an expected call of UpsertBoardSnapshot.
+func (mr *MockStoreMockRecorder) UpsertBoardSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBoardSnapshot", reflect.TypeOf((*MockStore)(nil).UpsertBoardSnapshot), arg0, arg1)
+}
+
+// UpsertDefaultCategoryTemplate mocks base method.
+func (m *MockStore) UpsertDefaultCategoryTemplate(arg0 context.Context, arg1 model.CategoryTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDefaultCategoryTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertDefaultCategoryTemplate indicates an expected call of UpsertDefaultCategoryTemplate.
+func (mr *MockStoreMockRecorder) UpsertDefaultCategoryTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDefaultCategoryTemplate", reflect.TypeOf((*MockStore)(nil).UpsertDefaultCategoryTemplate), arg0, arg1)
 }
 
 // UpsertNotificationHint mocks base method.
-func (m *MockStore) UpsertNotificationHint(arg0 *model.NotificationHint, arg1 time.Duration) (*model.NotificationHint, error) {
+func (m *MockStore) UpsertNotificationHint(arg0 context.Context, arg1 *model.NotificationHint, arg2 time.Duration) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertNotificationHint", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpsertNotificationHint", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.NotificationHint)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpsertNotificationHint indicates an expected call of UpsertNotificationHint.
-func (mr *MockStoreMockRecorder) UpsertNotificationHint(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpsertNotificationHint(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationHint", reflect.TypeOf((*MockStore)(nil).UpsertNotificationHint), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationHint", reflect.TypeOf((*MockStore)(nil).UpsertNotificationHint), arg0, arg1, arg2)
 }
 
 // UpsertSharing mocks base method.
-func (m *MockStore) UpsertSharing(arg0 model.Sharing) error {
+func (m *MockStore) UpsertSharing(arg0 context.Context, arg1 model.Sharing) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertSharing", arg0)
+	ret := m.ctrl.Call(m, "UpsertSharing", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpsertSharing indicates an expected call of UpsertSharing.
-func (mr *MockStoreMockRecorder) UpsertSharing(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpsertSharing(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSharing", reflect.TypeOf((*MockStore)(nil).UpsertSharing), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSharing", reflect.TypeOf((*MockStore)(nil).UpsertSharing), arg0, arg1)
 }
 
 // UpsertTeamSettings mocks base method.
-func (m *MockStore) UpsertTeamSettings(arg0 model.Team) error {
+func (m *MockStore) UpsertTeamSettings(arg0 context.Context, arg1 model.Team) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertTeamSettings", arg0)
+	ret := m.ctrl.Call(m, "UpsertTeamSettings", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpsertTeamSettings indicates an expected call of UpsertTeamSettings.
-func (mr *MockStoreMockRecorder) UpsertTeamSettings(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpsertTeamSettings(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTeamSettings", reflect.TypeOf((*MockStore)(nil).UpsertTeamSettings), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTeamSettings", reflect.TypeOf((*MockStore)(nil).UpsertTeamSettings), arg0, arg1)
 }
 
 // UpsertTeamSignupToken mocks base method.
-func (m *MockStore) UpsertTeamSignupToken(arg0 model.Team) error {
+func (m *MockStore) UpsertTeamSignupToken(arg0 context.Context, arg1 model.Team) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertTeamSignupToken", arg0)
+	ret := m.ctrl.Call(m, "UpsertTeamSignupToken", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpsertTeamSignupToken indicates an expected call of UpsertTeamSignupToken.
-func (mr *MockStoreMockRecorder) UpsertTeamSignupToken(arg0 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpsertTeamSignupToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTeamSignupToken", reflect.TypeOf((*MockStore)(nil).UpsertTeamSignupToken), arg0, arg1)
+}
+
+// ValidateBoardSchema mocks base method.
+func (m *MockStore) ValidateBoardSchema(arg0 context.Context, arg1 string) (*model.SchemaReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBoardSchema", arg0, arg1)
+	ret0, _ := ret[0].(*model.SchemaReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateBoardSchema indicates an expected call of ValidateBoardSchema.
+func (mr *MockStoreMockRecorder) ValidateBoardSchema(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTeamSignupToken", reflect.TypeOf((*MockStore)(nil).UpsertTeamSignupToken), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBoardSchema", reflect.TypeOf((*MockStore)(nil).ValidateBoardSchema), arg0, arg1)
 }
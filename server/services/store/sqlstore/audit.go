@@ -0,0 +1,146 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var auditRecordFields = []string{
+	"id",
+	"board_id",
+	"COALESCE(actor_id, '')",
+	"action",
+	"resource_id",
+	"COALESCE(summary, '')",
+	"create_at",
+}
+
+func (s *SQLStore) auditRecordsFromRows(rows *sql.Rows) ([]*model.AuditRecord, error) {
+	records := []*model.AuditRecord{}
+
+	for rows.Next() {
+		var record model.AuditRecord
+		err := rows.Scan(
+			&record.ID,
+			&record.BoardID,
+			&record.ActorID,
+			&record.Action,
+			&record.ResourceID,
+			&record.Summary,
+			&record.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+// insertAuditRecord appends a record to the audit trail. The mutating
+// methods write their record as their last step, so the record is only
+// kept if the change it describes succeeded.
+func (s *SQLStore) insertAuditRecord(db sq.BaseRunner, record *model.AuditRecord) error {
+	if record.ID == "" {
+		record.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if record.CreateAt == 0 {
+		record.CreateAt = utils.GetMillis()
+	}
+	if len(record.Summary) > model.AuditSummaryMaxLength {
+		record.Summary = record.Summary[:model.AuditSummaryMaxLength]
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"audit_records").
+		Columns(
+			"id",
+			"board_id",
+			"actor_id",
+			"action",
+			"resource_id",
+			"summary",
+			"create_at",
+		).
+		Values(
+			record.ID,
+			record.BoardID,
+			record.ActorID,
+			record.Action,
+			record.ResourceID,
+			record.Summary,
+			record.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("insertAuditRecord error",
+			mlog.String("boardID", record.BoardID),
+			mlog.String("resourceID", record.ResourceID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// auditChange records the change of a board or block by a user.
+func (s *SQLStore) auditChange(db sq.BaseRunner, boardID, actorID string, action model.AuditAction, resourceID, summary string) error {
+	return s.insertAuditRecord(db, &model.AuditRecord{
+		BoardID:    boardID,
+		ActorID:    actorID,
+		Action:     action,
+		ResourceID: resourceID,
+		Summary:    summary,
+	})
+}
+
+// getAuditRecords returns the audit trail of a board, oldest first. An
+// empty boardID returns the audit trail of all the boards.
+func (s *SQLStore) getAuditRecords(db sq.BaseRunner, boardID string, opts model.QueryAuditOptions) ([]*model.AuditRecord, error) {
+	query := s.getQueryBuilder(db).
+		Select(auditRecordFields...).
+		From(s.tablePrefix+"audit_records").
+		OrderBy("create_at", "id")
+
+	if boardID != "" {
+		query = query.Where(sq.Eq{"board_id": boardID})
+	}
+
+	if opts.ActorID != "" {
+		query = query.Where(sq.Eq{"actor_id": opts.ActorID})
+	}
+
+	if opts.Action != "" {
+		query = query.Where(sq.Eq{"action": opts.Action})
+	}
+
+	if opts.Since != 0 {
+		query = query.Where(sq.GtOrEq{"create_at": opts.Since})
+	}
+
+	if opts.Until != 0 {
+		query = query.Where(sq.Lt{"create_at": opts.Until})
+	}
+
+	if opts.Page != 0 {
+		query = query.Offset(uint64(opts.Page * opts.PerPage))
+	}
+
+	if opts.PerPage > 0 {
+		query = query.Limit(uint64(opts.PerPage))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getAuditRecords error", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.auditRecordsFromRows(rows)
+}
@@ -3,7 +3,14 @@ package sqlstore
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
 
 	"github.com/mattermost/focalboard/server/utils"
 
@@ -18,6 +25,14 @@ import (
 const (
 	maxSearchDepth = 50
 	descClause     = " DESC "
+
+	// maxBlockIDsPerQuery limits the number of IDs sent in a single
+	// IN clause
+	maxBlockIDsPerQuery = 500
+
+	// maxPatchBlockAttempts is the number of times a patch is applied
+	// on SQLite before giving up when the block keeps changing
+	maxPatchBlockAttempts = 3
 )
 
 type BoardIDNilError struct{}
@@ -37,40 +52,36 @@ func (s *SQLStore) timestampToCharField(name string, as string) string {
 	}
 }
 
-func (s *SQLStore) blockFields() []string {
+func (s *SQLStore) blockFields(prefix string) []string {
 	return []string{
-		"id",
-		"parent_id",
-		"created_by",
-		"modified_by",
-		s.escapeField("schema"),
-		"type",
-		"title",
-		"COALESCE(fields, '{}')",
-		s.timestampToCharField("insert_at", "insertAt"),
-		"create_at",
-		"update_at",
-		"delete_at",
-		"COALESCE(board_id, '0')",
+		prefix + "id",
+		prefix + "parent_id",
+		prefix + "created_by",
+		prefix + "modified_by",
+		prefix + s.escapeField("schema"),
+		prefix + "type",
+		prefix + "title",
+		"COALESCE(" + prefix + "fields, '{}')",
+		s.timestampToCharField(prefix+"insert_at", "insertAt"),
+		prefix + "create_at",
+		prefix + "update_at",
+		prefix + "delete_at",
+		"COALESCE(" + prefix + "board_id, '0')",
+		"COALESCE(" + prefix + "archived_at, 0)",
+		"COALESCE(" + prefix + "sort_order, 0)",
 	}
 }
 
 func (s *SQLStore) getBlocks(db sq.BaseRunner, opts model.QueryBlocksOptions) ([]*model.Block, error) {
 	query := s.getQueryBuilder(db).
-		Select(s.blockFields()...).
+		Select(s.blockFields("")...).
 		From(s.tablePrefix + "blocks")
 
 	if opts.BoardID != "" {
 		query = query.Where(sq.Eq{"board_id": opts.BoardID})
 	}
 
-	if opts.ParentID != "" {
-		query = query.Where(sq.Eq{"parent_id": opts.ParentID})
-	}
-
-	if opts.BlockType != "" && opts.BlockType != model.TypeUnknown {
-		query = query.Where(sq.Eq{"type": opts.BlockType})
-	}
+	query = applyBlocksFilterOptions(query, opts)
 
 	if opts.Page != 0 {
 		query = query.Offset(uint64(opts.Page * opts.PerPage))
@@ -91,6 +102,69 @@ func (s *SQLStore) getBlocks(db sq.BaseRunner, opts model.QueryBlocksOptions) ([
 	return s.blocksFromRows(rows)
 }
 
+// applyBlocksFilterOptions adds the parent, type, archived and ordering
+// options to a blocks query.
+func applyBlocksFilterOptions(query sq.SelectBuilder, opts model.QueryBlocksOptions) sq.SelectBuilder {
+	if opts.ParentID != "" {
+		query = query.Where(sq.Eq{"parent_id": opts.ParentID})
+	}
+
+	if opts.BlockType != "" && opts.BlockType != model.TypeUnknown {
+		query = query.Where(sq.Eq{"type": opts.BlockType})
+	}
+
+	if len(opts.BlockTypes) > 0 {
+		query = query.Where(sq.Eq{"type": opts.BlockTypes})
+	}
+
+	if !opts.IncludeArchived {
+		query = query.Where(sq.Eq{"COALESCE(archived_at, 0)": 0})
+	}
+
+	if opts.OrderBySortOrder {
+		query = query.OrderBy("sort_order", "create_at", "id")
+	}
+
+	return query
+}
+
+// getBlocksForBoards returns the blocks of several boards at once. The
+// BoardID and paging options are ignored; the board ID of each block can
+// be used to regroup them. Callers are responsible for checking access to
+// the boards.
+func (s *SQLStore) getBlocksForBoards(db sq.BaseRunner, boardIDs []string, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	blocks := []*model.Block{}
+
+	for start := 0; start < len(boardIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(boardIDs) {
+			end = len(boardIDs)
+		}
+
+		query := s.getQueryBuilder(db).
+			Select(s.blockFields("")...).
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"board_id": boardIDs[start:end]})
+
+		query = applyBlocksFilterOptions(query, opts)
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error(`getBlocksForBoards ERROR`, mlog.Err(err))
+			return nil, err
+		}
+
+		chunk, err := s.blocksFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, chunk...)
+	}
+
+	return blocks, nil
+}
+
 func (s *SQLStore) getBlocksWithParentAndType(db sq.BaseRunner, boardID, parentID string, blockType string) ([]*model.Block, error) {
 	opts := model.QueryBlocksOptions{
 		BoardID:   boardID,
@@ -100,6 +174,22 @@ func (s *SQLStore) getBlocksWithParentAndType(db sq.BaseRunner, boardID, parentI
 	return s.getBlocks(db, opts)
 }
 
+// getBlocksWithParentAndTypes returns the children of a block that are of
+// any of the given types. If no types are given, all children are returned.
+func (s *SQLStore) getBlocksWithParentAndTypes(db sq.BaseRunner, boardID, parentID string, blockTypes []string) ([]*model.Block, error) {
+	types := make([]model.BlockType, 0, len(blockTypes))
+	for _, blockType := range blockTypes {
+		types = append(types, model.BlockType(blockType))
+	}
+
+	opts := model.QueryBlocksOptions{
+		BoardID:    boardID,
+		ParentID:   parentID,
+		BlockTypes: types,
+	}
+	return s.getBlocks(db, opts)
+}
+
 func (s *SQLStore) getBlocksWithParent(db sq.BaseRunner, boardID, parentID string) ([]*model.Block, error) {
 	opts := model.QueryBlocksOptions{
 		BoardID:  boardID,
@@ -110,7 +200,7 @@ func (s *SQLStore) getBlocksWithParent(db sq.BaseRunner, boardID, parentID strin
 
 func (s *SQLStore) getBlocksByIDs(db sq.BaseRunner, ids []string) ([]*model.Block, error) {
 	query := s.getQueryBuilder(db).
-		Select(s.blockFields()...).
+		Select(s.blockFields("")...).
 		From(s.tablePrefix + "blocks").
 		Where(sq.Eq{"id": ids})
 
@@ -134,99 +224,350 @@ func (s *SQLStore) getBlocksByIDs(db sq.BaseRunner, ids []string) ([]*model.Bloc
 	return blocks, nil
 }
 
-func (s *SQLStore) getBlocksWithType(db sq.BaseRunner, boardID, blockType string) ([]*model.Block, error) {
-	opts := model.QueryBlocksOptions{
-		BoardID:   boardID,
-		BlockType: model.BlockType(blockType),
+// getBlocksMap returns the blocks of a board with the given IDs keyed
+// by ID. IDs that are not found are absent from the map.
+func (s *SQLStore) getBlocksMap(db sq.BaseRunner, boardID string, ids []string) (map[string]*model.Block, error) {
+	blocksMap := make(map[string]*model.Block, len(ids))
+
+	for start := 0; start < len(ids); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		query := s.getQueryBuilder(db).
+			Select(s.blockFields("")...).
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"board_id": boardID}).
+			Where(sq.Eq{"id": ids[start:end]})
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error(`GetBlocksMap ERROR`, mlog.String("boardID", boardID), mlog.Err(err))
+			return nil, err
+		}
+
+		blocks, err := s.blocksFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range blocks {
+			blocksMap[block.ID] = block
+		}
 	}
-	return s.getBlocks(db, opts)
+
+	return blocksMap, nil
+}
+
+// getBlocksWithType returns the blocks of a board with the given type.
+// See getBlocksPage for the pagination options.
+func (s *SQLStore) getBlocksWithType(db sq.BaseRunner, boardID, blockType string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	opts.BoardID = boardID
+	opts.BlockType = model.BlockType(blockType)
+	return s.getBlocksPage(db, opts)
 }
 
 // getSubTree2 returns blocks within 2 levels of the given blockID.
+// Soft-deleted blocks are excluded unless opts.IncludeDeleted is set.
 func (s *SQLStore) getSubTree2(db sq.BaseRunner, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	opts.MaxDepth = 2
+	return s.getSubTree(db, boardID, blockID, opts)
+}
+
+// getSubTree returns the block with the given ID and its descendants,
+// level by level, down to opts.MaxDepth levels or the whole subtree if
+// MaxDepth is zero. Each block is only visited once, so a parent cycle
+// in corrupted data can't loop forever or return duplicates.
+// Soft-deleted blocks and their descendants are excluded unless
+// opts.IncludeDeleted is set.
+func (s *SQLStore) getSubTree(db sq.BaseRunner, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	roots, err := s.getBlocksMap(db, boardID, []string{blockID})
+	if err != nil {
+		return nil, err
+	}
+
+	tree := []*model.Block{}
+	if root, ok := roots[blockID]; ok {
+		if root.DeleteAt != 0 && !opts.IncludeDeleted {
+			return tree, nil
+		}
+		tree = append(tree, root)
+	}
+
+	visited := map[string]bool{blockID: true}
+	parentIDs := []string{blockID}
+	for depth := 1; len(parentIDs) > 0 && (opts.MaxDepth == 0 || depth < opts.MaxDepth); depth++ {
+		children, err := s.getSubTreeLevel(db, boardID, parentIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		parentIDs = nil
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+
+			if child.DeleteAt != 0 && !opts.IncludeDeleted {
+				continue
+			}
+			tree = append(tree, child)
+			parentIDs = append(parentIDs, child.ID)
+		}
+	}
+
+	result := make([]*model.Block, 0, len(tree))
+	for _, block := range tree {
+		if opts.BeforeUpdateAt != 0 && block.UpdateAt > opts.BeforeUpdateAt {
+			continue
+		}
+		if opts.AfterUpdateAt != 0 && block.UpdateAt < opts.AfterUpdateAt {
+			continue
+		}
+		if opts.Limit != 0 && uint64(len(result)) >= opts.Limit {
+			break
+		}
+		result = append(result, block)
+	}
+
+	return result, nil
+}
+
+// getSubTreeLevel returns the blocks of a board whose parent is one of
+// the given blocks.
+func (s *SQLStore) getSubTreeLevel(db sq.BaseRunner, boardID string, parentIDs []string) ([]*model.Block, error) {
+	children := []*model.Block{}
+
+	for start := 0; start < len(parentIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(parentIDs) {
+			end = len(parentIDs)
+		}
+
+		query := s.getQueryBuilder(db).
+			Select(s.blockFields("")...).
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"board_id": boardID}).
+			Where(sq.Eq{"parent_id": parentIDs[start:end]}).
+			OrderBy("insert_at, update_at")
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error(`getSubTree ERROR`, mlog.Err(err))
+			return nil, err
+		}
+
+		blocks, err := s.blocksFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, blocks...)
+	}
+
+	return children, nil
+}
+
+// getBlocksForBoard returns the blocks of a board. See getBlocksPage for
+// the pagination options.
+func (s *SQLStore) getBlocksForBoard(db sq.BaseRunner, boardID string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	opts.BoardID = boardID
+	return s.getBlocksPage(db, opts)
+}
+
+// getBlocksForBoardStream calls fn with each block of a board, archived
+// blocks included, in ID order. The blocks are read from the rows cursor
+// one at a time, so memory usage doesn't grow with the size of the board.
+// Iteration stops at the first error returned by fn, which is returned
+// as is.
+func (s *SQLStore) getBlocksForBoardStream(db sq.BaseRunner, boardID string, fn func(model.Block) error) error {
 	query := s.getQueryBuilder(db).
-		Select(s.blockFields()...).
+		Select(s.blockFields("")...).
 		From(s.tablePrefix + "blocks").
-		Where(sq.Or{sq.Eq{"id": blockID}, sq.Eq{"parent_id": blockID}}).
 		Where(sq.Eq{"board_id": boardID}).
-		OrderBy("insert_at, update_at")
+		OrderBy("id")
 
-	if opts.BeforeUpdateAt != 0 {
-		query = query.Where(sq.LtOrEq{"update_at": opts.BeforeUpdateAt})
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBlocksForBoardStream ERROR`, mlog.String("boardID", boardID), mlog.Err(err))
+		return err
 	}
+	defer s.CloseRows(rows)
 
-	if opts.AfterUpdateAt != 0 {
-		query = query.Where(sq.GtOrEq{"update_at": opts.AfterUpdateAt})
+	for rows.Next() {
+		block, err := s.blockFromRow(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(*block); err != nil {
+			return err
+		}
 	}
 
-	if opts.Limit != 0 {
-		query = query.Limit(opts.Limit)
+	return rows.Err()
+}
+
+// getBlocksPage returns a page of the blocks matching the options, and
+// whether there are more blocks after it. Without a PerPage or an
+// AfterID, all the blocks are returned.
+//
+// Pages are selected with keyset pagination: the blocks are sorted by ID,
+// or by update time and ID if OrderByUpdateAt is set, and the next page
+// is requested by passing the ID (and update time) of the last block of
+// the previous page as AfterID (and AfterUpdateAt). Blocks inserted
+// between requests never shift the pages already read.
+func (s *SQLStore) getBlocksPage(db sq.BaseRunner, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	if opts.PerPage <= 0 && opts.AfterID == "" {
+		blocks, err := s.getBlocks(db, opts)
+		return blocks, false, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": opts.BoardID})
+
+	// the keyset ordering replaces the sort order
+	opts.OrderBySortOrder = false
+	query = applyBlocksFilterOptions(query, opts)
+
+	if opts.OrderByUpdateAt {
+		if opts.AfterID != "" {
+			query = query.Where(sq.Or{
+				sq.Gt{"update_at": opts.AfterUpdateAt},
+				sq.And{sq.Eq{"update_at": opts.AfterUpdateAt}, sq.Gt{"id": opts.AfterID}},
+			})
+		}
+		query = query.OrderBy("update_at", "id")
+	} else {
+		if opts.AfterID != "" {
+			query = query.Where(sq.Gt{"id": opts.AfterID})
+		}
+		query = query.OrderBy("id")
+	}
+
+	// one more block is fetched to know if there is a next page
+	if opts.PerPage > 0 {
+		query = query.Limit(uint64(opts.PerPage + 1))
 	}
 
 	rows, err := query.Query()
 	if err != nil {
-		s.logger.Error(`getSubTree ERROR`, mlog.Err(err))
+		s.logger.Error(`getBlocksPage ERROR`, mlog.Err(err))
+		return nil, false, err
+	}
+	defer s.CloseRows(rows)
+
+	blocks, err := s.blocksFromRows(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := false
+	if opts.PerPage > 0 && len(blocks) > opts.PerPage {
+		blocks = blocks[:opts.PerPage]
+		hasMore = true
+	}
+
+	return blocks, hasMore, nil
+}
 
+// getLastModifiedBlockForBoard returns the most recently updated
+// non-deleted block of a board.
+func (s *SQLStore) getLastModifiedBlockForBoard(db sq.BaseRunner, boardID string) (*model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"delete_at": 0}).
+		OrderBy("update_at DESC", "id").
+		Limit(1)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getLastModifiedBlockForBoard ERROR`, mlog.String("boardID", boardID), mlog.Err(err))
 		return nil, err
 	}
 	defer s.CloseRows(rows)
 
-	return s.blocksFromRows(rows)
-}
+	blocks, err := s.blocksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
 
-func (s *SQLStore) getBlocksForBoard(db sq.BaseRunner, boardID string) ([]*model.Block, error) {
-	opts := model.QueryBlocksOptions{
-		BoardID: boardID,
+	if len(blocks) == 0 {
+		return nil, model.NewErrNotFound("blocks for board ID=" + boardID)
 	}
-	return s.getBlocks(db, opts)
+
+	return blocks[0], nil
 }
 
 func (s *SQLStore) blocksFromRows(rows *sql.Rows) ([]*model.Block, error) {
 	results := []*model.Block{}
 
 	for rows.Next() {
-		var block model.Block
-		var fieldsJSON string
-		var modifiedBy sql.NullString
-		var insertAt sql.NullString
-
-		err := rows.Scan(
-			&block.ID,
-			&block.ParentID,
-			&block.CreatedBy,
-			&modifiedBy,
-			&block.Schema,
-			&block.Type,
-			&block.Title,
-			&fieldsJSON,
-			&insertAt,
-			&block.CreateAt,
-			&block.UpdateAt,
-			&block.DeleteAt,
-			&block.BoardID)
+		block, err := s.blockFromRow(rows)
 		if err != nil {
-			// handle this error
-			s.logger.Error(`ERROR blocksFromRows`, mlog.Err(err))
-
 			return nil, err
 		}
 
-		if modifiedBy.Valid {
-			block.ModifiedBy = modifiedBy.String
-		}
+		results = append(results, block)
+	}
 
-		err = json.Unmarshal([]byte(fieldsJSON), &block.Fields)
-		if err != nil {
-			// handle this error
-			s.logger.Error(`ERROR blocksFromRows fields`, mlog.Err(err))
+	return results, nil
+}
 
-			return nil, err
-		}
+// blockFromRow scans the block at the current position of rows. The
+// columns selected after the block fields, if any, are scanned into
+// extraDest.
+func (s *SQLStore) blockFromRow(rows *sql.Rows, extraDest ...interface{}) (*model.Block, error) {
+	var block model.Block
+	var fieldsJSON string
+	var modifiedBy sql.NullString
+	var insertAt sql.NullString
+
+	dest := []interface{}{
+		&block.ID,
+		&block.ParentID,
+		&block.CreatedBy,
+		&modifiedBy,
+		&block.Schema,
+		&block.Type,
+		&block.Title,
+		&fieldsJSON,
+		&insertAt,
+		&block.CreateAt,
+		&block.UpdateAt,
+		&block.DeleteAt,
+		&block.BoardID,
+		&block.ArchivedAt,
+		&block.SortOrder,
+	}
+	err := rows.Scan(append(dest, extraDest...)...)
+	if err != nil {
+		// handle this error
+		s.logger.Error(`ERROR blocksFromRows`, mlog.Err(err))
 
-		results = append(results, &block)
+		return nil, err
 	}
 
-	return results, nil
+	if modifiedBy.Valid {
+		block.ModifiedBy = modifiedBy.String
+	}
+
+	err = json.Unmarshal([]byte(fieldsJSON), &block.Fields)
+	if err != nil {
+		// handle this error
+		s.logger.Error(`ERROR blocksFromRows fields`, mlog.Err(err))
+
+		return nil, err
+	}
+
+	return &block, nil
 }
 
 func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID string) error {
@@ -234,6 +575,18 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 		return BoardIDNilError{}
 	}
 
+	if err := s.validateBlockReferences(db, []*model.Block{block}); err != nil {
+		return err
+	}
+
+	return s.withBoardBlockLimit(db, []*model.Block{block}, func() error {
+		return s.upsertBlock(db, block, userID)
+	})
+}
+
+// upsertBlock inserts the block, or updates it if it already exists, and
+// writes its history.
+func (s *SQLStore) upsertBlock(db sq.BaseRunner, block *model.Block, userID string) error {
 	fieldsJSON, err := json.Marshal(block.Fields)
 	if err != nil {
 		return err
@@ -247,6 +600,22 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 	block.UpdateAt = utils.GetMillis()
 	block.ModifiedBy = userID
 
+	// the archived state can only be changed through ArchiveCard and
+	// UnarchiveCard, so updates keep the stored value
+	if existingBlock != nil {
+		block.ArchivedAt = existingBlock.ArchivedAt
+	}
+
+	// blocks without a sort order are placed after their existing
+	// siblings, and updates without one keep the stored value
+	if block.SortOrder == 0 {
+		if existingBlock != nil {
+			block.SortOrder = existingBlock.SortOrder
+		} else {
+			block.SortOrder = block.UpdateAt
+		}
+	}
+
 	insertQuery := s.getQueryBuilder(db).Insert("").
 		Columns(
 			"channel_id",
@@ -262,6 +631,8 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 			"update_at",
 			"delete_at",
 			"board_id",
+			"archived_at",
+			"sort_order",
 		)
 
 	insertQueryValues := map[string]interface{}{
@@ -278,6 +649,8 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 		"create_at":             utils.GetMillis(),
 		"update_at":             block.UpdateAt,
 		"board_id":              block.BoardID,
+		"archived_at":           block.ArchivedAt,
+		"sort_order":            block.SortOrder,
 	}
 
 	if existingBlock != nil {
@@ -292,7 +665,8 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 			Set("title", block.Title).
 			Set("fields", fieldsJSON).
 			Set("update_at", block.UpdateAt).
-			Set("delete_at", block.DeleteAt)
+			Set("delete_at", block.DeleteAt).
+			Set("sort_order", block.SortOrder)
 
 		if _, err := query.Exec(); err != nil {
 			s.logger.Error(`InsertBlock error occurred while updating existing block`, mlog.String("blockID", block.ID), mlog.Err(err))
@@ -313,60 +687,566 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 		return err
 	}
 
-	return nil
+	return s.auditChange(db, block.BoardID, userID, model.AuditActionInsertBlock, block.ID, "type: "+string(block.Type))
 }
 
-func (s *SQLStore) patchBlock(db sq.BaseRunner, blockID string, blockPatch *model.BlockPatch, userID string) error {
-	existingBlock, err := s.getBlock(db, blockID)
+func (s *SQLStore) insertBlockHistory(db sq.BaseRunner, block *model.Block, userID string) error {
+	fieldsJSON, err := json.Marshal(block.Fields)
 	if err != nil {
 		return err
 	}
 
-	block := blockPatch.Patch(existingBlock)
-	return s.insertBlock(db, block, userID)
-}
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix + "blocks_history").
+		SetMap(map[string]interface{}{
+			"channel_id":            "",
+			"id":                    block.ID,
+			"parent_id":             block.ParentID,
+			s.escapeField("schema"): block.Schema,
+			"type":                  block.Type,
+			"title":                 block.Title,
+			"fields":                fieldsJSON,
+			"delete_at":             block.DeleteAt,
+			"created_by":            userID,
+			"modified_by":           block.ModifiedBy,
+			"create_at":             utils.GetMillis(),
+			"update_at":             block.UpdateAt,
+			"board_id":              block.BoardID,
+			"archived_at":           block.ArchivedAt,
+			"sort_order":            block.SortOrder,
+		})
 
-func (s *SQLStore) patchBlocks(db sq.BaseRunner, blockPatches *model.BlockPatchBatch, userID string) error {
-	for i, blockID := range blockPatches.BlockIDs {
-		err := s.patchBlock(db, blockID, &blockPatches.BlockPatches[i], userID)
-		if err != nil {
-			return err
-		}
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("insertBlockHistory error", mlog.String("blockID", block.ID), mlog.Err(err))
+		return err
 	}
+
 	return nil
 }
 
-func (s *SQLStore) insertBlocks(db sq.BaseRunner, blocks []*model.Block, userID string) error {
-	for _, block := range blocks {
-		if block.BoardID == "" {
-			return BoardIDNilError{}
-		}
+// patchBlock applies a patch to a block. The field and property changes
+// of the patch are merged into the stored fields, so concurrent patches
+// of different keys don't overwrite each other:
+//   - on Postgres the fields are updated in place with jsonb_set
+//   - on MySQL the fields are updated in place with JSON_SET
+//   - on SQLite the block is read, patched and written back only if it
+//     hasn't been updated in the meantime, retrying otherwise
+//
+// If the patch has an expected update time, the block is only patched
+// if it hasn't been updated since, as part of the same update statement,
+// and a conflict error is returned otherwise.
+//
+// It returns the number of blocks patched.
+func (s *SQLStore) patchBlock(db sq.BaseRunner, blockID string, blockPatch *model.BlockPatch, userID string) (int64, error) {
+	existingBlock, err := s.getBlock(db, blockID)
+	if err != nil {
+		return 0, err
 	}
-	for i := range blocks {
-		err := s.insertBlock(db, blocks[i], userID)
-		if err != nil {
-			return err
-		}
+
+	if blockPatch.ExpectedUpdateAt != 0 && existingBlock.UpdateAt != blockPatch.ExpectedUpdateAt {
+		return 0, model.NewErrConflict("block ID="+blockID, blockPatch.ExpectedUpdateAt, existingBlock.UpdateAt)
 	}
-	return nil
-}
 
-func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy string) error {
-	block, err := s.getBlock(db, blockID)
-	if model.IsErrNotFound(err) {
-		s.logger.Warn("deleteBlock block not found", mlog.String("block_id", blockID))
-		return nil // deleting non-exiting block is not considered an error (for now)
+	var count int64
+	if s.dbType == model.SqliteDBType {
+		count, err = s.patchBlockReadMergeWrite(db, existingBlock, blockPatch, userID)
+	} else {
+		count, err = s.patchBlockInPlace(db, existingBlock, blockPatch, userID)
 	}
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	fieldsJSON, err := json.Marshal(block.Fields)
+	if err := s.auditChange(db, existingBlock.BoardID, userID, model.AuditActionPatchBlock, blockID, model.AuditChangedSummary(blockPatch.ChangedFields())); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// patchBlockInPlace applies a patch to a block by merging the field
+// changes into the stored fields in the database.
+func (s *SQLStore) patchBlockInPlace(db sq.BaseRunner, existingBlock *model.Block, blockPatch *model.BlockPatch, userID string) (int64, error) {
+	blockID := existingBlock.ID
+
+	query := s.patchBlockQuery(db, existingBlock, blockPatch, userID)
+	if blockPatch.HasFieldChanges() {
+		fieldsExpr, err := s.patchBlockFieldsExpr(blockPatch)
+		if err != nil {
+			return 0, err
+		}
+		query = query.Set("fields", fieldsExpr)
+	}
+
+	result, err := query.Exec()
 	if err != nil {
-		return err
+		s.logger.Error("patchBlock error", mlog.String("blockID", blockID), mlog.Err(err))
+		return 0, err
 	}
 
-	now := utils.GetMillis()
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	block, err := s.getBlock(db, blockID)
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 0 && blockPatch.ExpectedUpdateAt != 0 {
+		return 0, model.NewErrConflict("block ID="+blockID, blockPatch.ExpectedUpdateAt, block.UpdateAt)
+	}
+
+	if err := s.insertBlockHistory(db, block, userID); err != nil {
+		return 0, err
+	}
+
+	if err := s.patchContentSortOrder(db, block, blockPatch); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// patchBlockReadMergeWrite applies a patch to a block by writing back
+// the patched block, guarded by the update time of the block that was
+// read.
+func (s *SQLStore) patchBlockReadMergeWrite(db sq.BaseRunner, existingBlock *model.Block, blockPatch *model.BlockPatch, userID string) (int64, error) {
+	for attempt := 1; ; attempt++ {
+		readUpdateAt := existingBlock.UpdateAt
+		block := blockPatch.Patch(existingBlock)
+
+		fieldsJSON, err := json.Marshal(block.Fields)
+		if err != nil {
+			return 0, err
+		}
+
+		query := s.patchBlockQuery(db, block, blockPatch, userID).
+			Set("fields", fieldsJSON).
+			Where(sq.Eq{"update_at": readUpdateAt})
+
+		result, err := query.Exec()
+		if err != nil {
+			s.logger.Error("patchBlock error", mlog.String("blockID", block.ID), mlog.Err(err))
+			return 0, err
+		}
+
+		count, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if count > 0 {
+			block, err = s.getBlock(db, block.ID)
+			if err != nil {
+				return 0, err
+			}
+			if err := s.insertBlockHistory(db, block, userID); err != nil {
+				return 0, err
+			}
+			if err := s.patchContentSortOrder(db, block, blockPatch); err != nil {
+				return 0, err
+			}
+			return count, nil
+		}
+
+		if attempt >= maxPatchBlockAttempts {
+			return 0, model.ErrBlockPatchConflict
+		}
+
+		existingBlock, err = s.getBlock(db, block.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		// the caller expects the version it read, so retrying with a
+		// newer one would overwrite changes it hasn't seen
+		if blockPatch.ExpectedUpdateAt != 0 {
+			return 0, model.NewErrConflict("block ID="+block.ID, blockPatch.ExpectedUpdateAt, existingBlock.UpdateAt)
+		}
+	}
+}
+
+// patchContentSortOrder keeps the sort order of the content blocks of a
+// card in line with its content order when a patch changes it.
+func (s *SQLStore) patchContentSortOrder(db sq.BaseRunner, block *model.Block, blockPatch *model.BlockPatch) error {
+	if block.Type != model.TypeCard {
+		return nil
+	}
+	if _, ok := blockPatch.UpdatedFields["contentOrder"]; !ok {
+		return nil
+	}
+	return s.updateContentSortOrder(db, block.BoardID, block.ID, contentOrderFromFields(block.Fields))
+}
+
+// patchBlockQuery returns the update query for the patched columns of a
+// block, not including its fields.
+func (s *SQLStore) patchBlockQuery(db sq.BaseRunner, block *model.Block, blockPatch *model.BlockPatch, userID string) sq.UpdateBuilder {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"blocks").
+		Where(sq.Eq{"id": block.ID}).
+		Where(sq.Eq{"board_id": block.BoardID}).
+		Set("modified_by", userID).
+		Set("update_at", utils.GetMillis())
+
+	if blockPatch.ExpectedUpdateAt != 0 {
+		query = query.Where(sq.Eq{"update_at": blockPatch.ExpectedUpdateAt})
+	}
+	if blockPatch.ParentID != nil {
+		query = query.Set("parent_id", *blockPatch.ParentID)
+	}
+	if blockPatch.Schema != nil {
+		query = query.Set(s.escapeField("schema"), *blockPatch.Schema)
+	}
+	if blockPatch.Type != nil {
+		query = query.Set("type", *blockPatch.Type)
+	}
+	if blockPatch.Title != nil {
+		query = query.Set("title", *blockPatch.Title)
+	}
+	if blockPatch.SortOrder != nil {
+		query = query.Set("sort_order", *blockPatch.SortOrder)
+	}
+
+	return query
+}
+
+// patchBlockFieldsExpr returns the expression merging the field and
+// property changes of a patch into the stored fields of a block, for
+// the databases that can update JSON in place. As in BlockPatch.Patch,
+// the property changes are applied first.
+func (s *SQLStore) patchBlockFieldsExpr(blockPatch *model.BlockPatch) (sq.Sqlizer, error) {
+	var expr, setExpr, removeExpr, keyPath, propertyPath string
+	switch s.dbType {
+	case model.PostgresDBType:
+		expr = "COALESCE(fields::jsonb, '{}'::jsonb)"
+		if len(blockPatch.UpdatedProperties) > 0 || len(blockPatch.DeletedProperties) > 0 {
+			expr = "jsonb_set(" + expr + ", '{properties}', COALESCE(fields::jsonb->'properties', '{}'::jsonb))"
+		}
+		setExpr = "jsonb_set(%s, %s, ?::jsonb)"
+		removeExpr = "(%s #- %s)"
+		keyPath = "ARRAY[?::text]"
+		propertyPath = "ARRAY['properties', ?::text]"
+	case model.MysqlDBType:
+		expr = "COALESCE(NULLIF(fields, ''), '{}')"
+		if len(blockPatch.UpdatedProperties) > 0 || len(blockPatch.DeletedProperties) > 0 {
+			expr = "JSON_SET(" + expr + ", '$.properties', COALESCE(JSON_EXTRACT(NULLIF(fields, ''), '$.properties'), JSON_OBJECT()))"
+		}
+		setExpr = "JSON_SET(%s, %s, CAST(? AS JSON))"
+		removeExpr = "JSON_REMOVE(%s, %s)"
+		keyPath = "CONCAT('$.', JSON_QUOTE(?))"
+		propertyPath = "CONCAT('$.properties.', JSON_QUOTE(?))"
+	default:
+		return nil, fmt.Errorf("patchBlockFieldsExpr unsupported database type %s", s.dbType)
+	}
+
+	args := []interface{}{}
+	set := func(path string, values map[string]interface{}) error {
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			value, err := json.Marshal(values[key])
+			if err != nil {
+				return err
+			}
+			expr = fmt.Sprintf(setExpr, expr, path)
+			args = append(args, key, string(value))
+		}
+		return nil
+	}
+	remove := func(path string, keys []string) {
+		for _, key := range keys {
+			expr = fmt.Sprintf(removeExpr, expr, path)
+			args = append(args, key)
+		}
+	}
+
+	if err := set(propertyPath, blockPatch.UpdatedProperties); err != nil {
+		return nil, err
+	}
+	remove(propertyPath, blockPatch.DeletedProperties)
+	if err := set(keyPath, blockPatch.UpdatedFields); err != nil {
+		return nil, err
+	}
+	remove(keyPath, blockPatch.DeletedFields)
+
+	if s.dbType == model.PostgresDBType {
+		// the fields column is stored as JSON
+		expr += "::json"
+	}
+
+	return sq.Expr(expr, args...), nil
+}
+
+func (s *SQLStore) patchBlocks(db sq.BaseRunner, blockPatches *model.BlockPatchBatch, userID string) error {
+	for i, blockID := range blockPatches.BlockIDs {
+		_, err := s.patchBlock(db, blockID, &blockPatches.BlockPatches[i], userID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) insertBlocks(db sq.BaseRunner, blocks []*model.Block, userID string) error {
+	for _, block := range blocks {
+		if block.BoardID == "" {
+			return BoardIDNilError{}
+		}
+	}
+
+	if err := s.validateBlockReferences(db, blocks); err != nil {
+		return err
+	}
+
+	return s.withBoardBlockLimit(db, blocks, func() error {
+		for i := range blocks {
+			if err := s.upsertBlock(db, blocks[i], userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// validateBlockReferences checks that the boards of the blocks exist,
+// and that their parents are either their board, another block of the
+// batch or an existing block, always on the same board.
+func (s *SQLStore) validateBlockReferences(db sq.BaseRunner, blocks []*model.Block) error {
+	boardIDs := map[string]bool{}
+	inBatch := make(map[string]string, len(blocks))
+	for _, block := range blocks {
+		boardIDs[block.BoardID] = true
+		inBatch[block.ID] = block.BoardID
+	}
+
+	existingBoardIDs, err := s.getExistingBoardIDs(db, boardIDs)
+	if err != nil {
+		return err
+	}
+
+	parentIDs := map[string]bool{}
+	for _, block := range blocks {
+		if !existingBoardIDs[block.BoardID] {
+			return model.NewErrNotFound(fmt.Sprintf("board ID=%s of block ID=%s", block.BoardID, block.ID))
+		}
+		if block.ParentID == "" || block.ParentID == block.BoardID {
+			continue
+		}
+		if _, ok := inBatch[block.ParentID]; !ok {
+			parentIDs[block.ParentID] = true
+		}
+	}
+
+	parentBoardIDs := map[string]string{}
+	if len(parentIDs) > 0 {
+		ids := make([]string, 0, len(parentIDs))
+		for id := range parentIDs {
+			ids = append(ids, id)
+		}
+
+		query := s.getQueryBuilder(db).
+			Select("id", "board_id").
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"id": ids})
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error("validateBlockReferences ERROR", mlog.Err(err))
+			return err
+		}
+		defer s.CloseRows(rows)
+
+		for rows.Next() {
+			var id, boardID string
+			if err := rows.Scan(&id, &boardID); err != nil {
+				return err
+			}
+			parentBoardIDs[id] = boardID
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	for _, block := range blocks {
+		if block.ParentID == "" || block.ParentID == block.BoardID {
+			continue
+		}
+		parentBoardID, ok := inBatch[block.ParentID]
+		if !ok {
+			parentBoardID, ok = parentBoardIDs[block.ParentID]
+		}
+		if !ok || parentBoardID != block.BoardID {
+			return model.NewErrNotFound(fmt.Sprintf("parent block ID=%s of block ID=%s", block.ParentID, block.ID))
+		}
+	}
+
+	return nil
+}
+
+// getExistingBoardIDs returns which of the board IDs belong to existing
+// boards.
+func (s *SQLStore) getExistingBoardIDs(db sq.BaseRunner, ids map[string]bool) (map[string]bool, error) {
+	idList := make([]string, 0, len(ids))
+	for id := range ids {
+		idList = append(idList, id)
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"id": idList})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getExistingBoardIDs ERROR", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	existing := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		existing[id] = true
+	}
+	return existing, rows.Err()
+}
+
+// withBoardBlockLimit runs the insertion of the blocks only if it doesn't
+// take any of their boards over the board block limit. Blocks that
+// already exist don't count as new blocks.
+func (s *SQLStore) withBoardBlockLimit(db sq.BaseRunner, blocks []*model.Block, insert func() error) error {
+	limit := int(atomic.LoadInt64(&s.boardBlockLimit))
+	if limit <= 0 {
+		return insert()
+	}
+
+	blockIDsByBoard := map[string][]string{}
+	for _, block := range blocks {
+		blockIDsByBoard[block.BoardID] = append(blockIDsByBoard[block.BoardID], block.ID)
+	}
+	boardIDs := make([]string, 0, len(blockIDsByBoard))
+	for boardID := range blockIDsByBoard {
+		boardIDs = append(boardIDs, boardID)
+	}
+	sort.Strings(boardIDs)
+
+	// lock the board rows so concurrent insertions into the same boards
+	// wait for this transaction before counting the blocks
+	if s.dbType == model.SqliteDBType {
+		s.blockLimitMutex.Lock()
+		defer s.blockLimitMutex.Unlock()
+	} else {
+		lockQuery := s.getQueryBuilder(db).
+			Select("id").
+			From(s.tablePrefix + "boards").
+			Where(sq.Eq{"id": boardIDs}).
+			OrderBy("id").
+			Suffix("FOR UPDATE")
+
+		rows, err := lockQuery.Query()
+		if err != nil {
+			s.logger.Error("withBoardBlockLimit lock ERROR", mlog.Err(err))
+			return err
+		}
+		s.CloseRows(rows)
+	}
+
+	for _, boardID := range boardIDs {
+		count, newCount, err := s.countBoardBlocks(db, boardID, blockIDsByBoard[boardID])
+		if err != nil {
+			return err
+		}
+		if count+newCount > limit {
+			return model.NewErrBlockLimitExceeded(boardID, count, limit)
+		}
+	}
+
+	return insert()
+}
+
+// countBoardBlocks returns the number of blocks of a board, and how many
+// of the given block IDs are not among them.
+func (s *SQLStore) countBoardBlocks(db sq.BaseRunner, boardID string, blockIDs []string) (int, int, error) {
+	var count int
+	err := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		s.logger.Error("countBoardBlocks ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+		return 0, 0, err
+	}
+
+	newIDs := make(map[string]bool, len(blockIDs))
+	for _, id := range blockIDs {
+		newIDs[id] = true
+	}
+
+	ids := make([]string, 0, len(newIDs))
+	for id := range newIDs {
+		ids = append(ids, id)
+	}
+
+	for start := 0; start < len(ids); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		rows, err := s.getQueryBuilder(db).
+			Select("id").
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"board_id": boardID}).
+			Where(sq.Eq{"id": ids[start:end]}).
+			Query()
+		if err != nil {
+			s.logger.Error("countBoardBlocks ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+			return 0, 0, err
+		}
+
+		existingIDs, err := idsFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, id := range existingIDs {
+			delete(newIDs, id)
+		}
+	}
+
+	return count, len(newIDs), nil
+}
+
+// deleteBlock deletes a block and returns the number of blocks
+// deleted, which is zero if the block doesn't exist.
+func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy string) (int64, error) {
+	block, err := s.getBlock(db, blockID)
+	if model.IsErrNotFound(err) {
+		s.logger.Warn("deleteBlock block not found", mlog.String("block_id", blockID))
+		return 0, nil // deleting non-exiting block is not considered an error (for now)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	fieldsJSON, err := json.Marshal(block.Fields)
+	if err != nil {
+		return 0, err
+	}
+
+	now := utils.GetMillis()
 	insertQuery := s.getQueryBuilder(db).Insert(s.tablePrefix+"blocks_history").
 		Columns(
 			"board_id",
@@ -381,6 +1261,8 @@ func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy stri
 			"update_at",
 			"delete_at",
 			"created_by",
+			"archived_at",
+			"sort_order",
 		).
 		Values(
 			block.BoardID,
@@ -395,93 +1277,1057 @@ func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy stri
 			now,
 			now,
 			block.CreatedBy,
+			block.ArchivedAt,
+			block.SortOrder,
 		)
 
-	if _, err := insertQuery.Exec(); err != nil {
-		return err
+	if _, err := insertQuery.Exec(); err != nil {
+		return 0, err
+	}
+
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "blocks").
+		Where(sq.Eq{"id": blockID})
+
+	result, err := deleteQuery.Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	// a deleted block should not generate notifications anymore
+	if err := s.deleteSubscriptionsForBlock(db, blockID); err != nil {
+		return 0, err
+	}
+
+	if err := s.deleteNotificationHintsForBlock(db, blockID); err != nil {
+		return 0, err
+	}
+
+	if err := s.setFileInfosDeleteAt(db, fileInfoIDsFromBlocks([]*model.Block{block}), now); err != nil {
+		return 0, err
+	}
+
+	if err := s.auditChange(db, block.BoardID, modifiedBy, model.AuditActionDeleteBlock, blockID, "type: "+string(block.Type)); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *SQLStore) undeleteBlock(db sq.BaseRunner, blockID string, modifiedBy string) error {
+	blocks, err := s.getBlockHistory(db, blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+	if err != nil {
+		return err
+	}
+
+	if len(blocks) == 0 {
+		s.logger.Warn("undeleteBlock block not found", mlog.String("block_id", blockID))
+		return nil // undeleting non-exiting block is not considered an error (for now)
+	}
+	block := blocks[0]
+
+	if block.DeleteAt == 0 {
+		s.logger.Warn("undeleteBlock block not deleted", mlog.String("block_id", block.ID))
+		return nil // undeleting not deleted block is not considered an error (for now)
+	}
+
+	if err := s.reinsertBlock(db, block, modifiedBy, utils.GetMillis()); err != nil {
+		return err
+	}
+
+	return s.auditChange(db, block.BoardID, modifiedBy, model.AuditActionUndeleteBlock, blockID, "type: "+string(block.Type))
+}
+
+// reinsertBlock writes a block snapshot taken from the history back as
+// a live block, together with the history entry recording it.
+func (s *SQLStore) reinsertBlock(db sq.BaseRunner, block *model.Block, modifiedBy string, now int64) error {
+	fieldsJSON, err := json.Marshal(block.Fields)
+	if err != nil {
+		return err
+	}
+
+	columns := []string{
+		"board_id",
+		"channel_id",
+		"id",
+		"parent_id",
+		s.escapeField("schema"),
+		"type",
+		"title",
+		"fields",
+		"modified_by",
+		"create_at",
+		"update_at",
+		"delete_at",
+		"created_by",
+		"archived_at",
+		"sort_order",
+	}
+
+	values := []interface{}{
+		block.BoardID,
+		"",
+		block.ID,
+		block.ParentID,
+		block.Schema,
+		block.Type,
+		block.Title,
+		fieldsJSON,
+		modifiedBy,
+		block.CreateAt,
+		now,
+		0,
+		block.CreatedBy,
+		block.ArchivedAt,
+		block.SortOrder,
+	}
+	insertHistoryQuery := s.getQueryBuilder(db).Insert(s.tablePrefix + "blocks_history").
+		Columns(columns...).
+		Values(values...)
+	insertQuery := s.getQueryBuilder(db).Insert(s.tablePrefix + "blocks").
+		Columns(columns...).
+		Values(values...)
+
+	if _, err := insertHistoryQuery.Exec(); err != nil {
+		return err
+	}
+
+	if _, err := insertQuery.Exec(); err != nil {
+		return err
+	}
+
+	return s.setFileInfosDeleteAt(db, fileInfoIDsFromBlocks([]*model.Block{block}), 0)
+}
+
+// restoreBlock brings a deleted block back from its most recent
+// non-deleted history entry. Restoring a live block is a no-op.
+func (s *SQLStore) restoreBlock(db sq.BaseRunner, blockID, userID string) (*model.Block, error) {
+	block, err := s.getBlock(db, blockID)
+	if err == nil {
+		return block, nil
+	}
+	if !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix+"blocks_history").
+		Where(sq.Eq{"id": blockID}).
+		Where(sq.Eq{"delete_at": 0}).
+		OrderBy("insert_at DESC", "update_at DESC").
+		Limit(1)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`restoreBlock ERROR`, mlog.String("block_id", blockID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	snapshots, err := s.blocksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, model.NewErrNotFound("restorable history for block ID=" + blockID)
+	}
+
+	block = snapshots[0]
+	now := utils.GetMillis()
+	if err := s.reinsertBlock(db, block, userID, now); err != nil {
+		return nil, err
+	}
+
+	block.ModifiedBy = userID
+	block.UpdateAt = now
+	block.DeleteAt = 0
+
+	if err := s.auditChange(db, block.BoardID, userID, model.AuditActionRestoreBlock, blockID, "type: "+string(block.Type)); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// deletedBlocksCondition matches the history entries recording the
+// deletion of blocks that are still deleted.
+func (s *SQLStore) deletedBlocksCondition() sq.Sqlizer {
+	history := s.tablePrefix + "blocks_history"
+	return sq.And{
+		sq.Gt{"delete_at": 0},
+		sq.Expr("NOT EXISTS (SELECT 1 FROM " + s.tablePrefix + "blocks AS b WHERE b.id = " + history + ".id)"),
+		sq.Expr("NOT EXISTS (SELECT 1 FROM " + history + " AS newer WHERE newer.id = " + history + ".id AND newer.insert_at > " + history + ".insert_at)"),
+	}
+}
+
+// getDeletedBlocksForBoard returns the blocks of a board that are
+// deleted, as they were when deleted, most recently deleted first.
+func (s *SQLStore) getDeletedBlocksForBoard(db sq.BaseRunner, boardID string) ([]*model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix+"blocks_history").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(s.deletedBlocksCondition()).
+		OrderBy("delete_at DESC", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getDeletedBlocksForBoard ERROR`, mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// purgeDeletedBlocks permanently removes the history of the blocks
+// deleted before olderThan, so they can't be restored anymore, and
+// returns how many were purged.
+func (s *SQLStore) purgeDeletedBlocks(db sq.BaseRunner, olderThan time.Time) (int, error) {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "blocks_history").
+		Where(s.deletedBlocksCondition()).
+		Where(sq.Lt{"delete_at": olderThan.UnixMilli()})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`purgeDeletedBlocks ERROR`, mlog.Err(err))
+		return 0, err
+	}
+	blockIDs, err := idsFromRows(rows)
+	s.CloseRows(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	for start := 0; start < len(blockIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(blockIDs) {
+			end = len(blockIDs)
+		}
+
+		deleteQuery := s.getQueryBuilder(db).
+			Delete(s.tablePrefix + "blocks_history").
+			Where(sq.Eq{"id": blockIDs[start:end]})
+
+		if _, err := deleteQuery.Exec(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(blockIDs), nil
+}
+
+// moveBlocks moves blocks, along with all their descendants, to another
+// board. Blocks at the top level of their board are attached to the top
+// level of the target board, and a block can't be moved without its
+// parent block as that would orphan it.
+func (s *SQLStore) moveBlocks(db sq.BaseRunner, blockIDs []string, targetBoardID, userID string) error {
+	if _, err := s.getBoard(db, targetBoardID); err != nil {
+		return err
+	}
+
+	blocks, err := s.getBlocksWithDescendants(db, blockIDs)
+	if err != nil {
+		return err
+	}
+
+	moved := make(map[string]*model.Block, len(blocks))
+	for _, block := range blocks {
+		moved[block.ID] = block
+	}
+
+	for _, block := range moved {
+		if block.ParentID == "" || block.ParentID == block.BoardID {
+			continue
+		}
+		if _, ok := moved[block.ParentID]; !ok {
+			return model.NewErrBadRequest(fmt.Sprintf("block %s can't be moved without its parent %s", block.ID, block.ParentID))
+		}
+	}
+
+	// the moves are recorded on the target board, for the requested
+	// blocks only
+	sourceBoards := make(map[string]string, len(blockIDs))
+	for _, id := range blockIDs {
+		if block, ok := moved[id]; ok {
+			sourceBoards[id] = block.BoardID
+		}
+	}
+
+	now := utils.GetMillis()
+	for _, block := range moved {
+		if block.ParentID == block.BoardID {
+			block.ParentID = targetBoardID
+		}
+		block.BoardID = targetBoardID
+		block.ModifiedBy = userID
+		block.UpdateAt = now
+
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"blocks").
+			Set("board_id", block.BoardID).
+			Set("parent_id", block.ParentID).
+			Set("modified_by", block.ModifiedBy).
+			Set("update_at", block.UpdateAt).
+			Where(sq.Eq{"id": block.ID})
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("moveBlocks error", mlog.String("blockID", block.ID), mlog.Err(err))
+			return err
+		}
+
+		if err := s.insertBlockHistory(db, block, userID); err != nil {
+			return err
+		}
+	}
+
+	for id, sourceBoardID := range sourceBoards {
+		if err := s.auditChange(db, targetBoardID, userID, model.AuditActionMoveBlock, id, "from board: "+sourceBoardID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyBlocks copies the given blocks and their descendants into the
+// target board with new IDs, and returns the new ID of each copied block
+// keyed by its original ID. References between the copied blocks are
+// rewritten to the new IDs, and references to blocks that are not copied
+// are removed.
+func (s *SQLStore) copyBlocks(db sq.BaseRunner, blockIDs []string, targetBoardID, userID string) (map[string]string, error) {
+	if _, err := s.getBoard(db, targetBoardID); err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.getBlocksWithDescendants(db, blockIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	newIDs := make(map[string]string, len(blocks))
+	for _, block := range blocks {
+		newIDs[block.ID] = utils.NewID(model.BlockType2IDType(block.Type))
+	}
+
+	for _, block := range blocks {
+		if block.ParentID == "" || block.ParentID == block.BoardID {
+			continue
+		}
+		if _, ok := newIDs[block.ParentID]; !ok {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("block %s can't be copied without its parent %s", block.ID, block.ParentID))
+		}
+	}
+
+	for _, block := range blocks {
+		if newParentID, ok := newIDs[block.ParentID]; ok {
+			block.ParentID = newParentID
+		} else {
+			block.ParentID = targetBoardID
+		}
+		block.ID = newIDs[block.ID]
+		block.BoardID = targetBoardID
+		remapBlockReferences(block, newIDs)
+	}
+
+	if err := s.insertBlocks(db, blocks, userID); err != nil {
+		return nil, err
+	}
+
+	// the copies are recorded as insertions too, this records where the
+	// requested blocks were copied from
+	copied := make(map[string]bool, len(blockIDs))
+	for _, id := range blockIDs {
+		if copied[id] {
+			continue
+		}
+		copied[id] = true
+		if err := s.auditChange(db, targetBoardID, userID, model.AuditActionCopyBlock, newIDs[id], "from: "+id); err != nil {
+			return nil, err
+		}
+	}
+
+	return newIDs, nil
+}
+
+// remapBlockReferences rewrites the block IDs referenced in the fields of
+// a block to the new IDs, and removes the references to blocks without a
+// new ID.
+func remapBlockReferences(block *model.Block, newIDs map[string]string) {
+	remapIDs := func(ids []interface{}) []interface{} {
+		remapped := make([]interface{}, 0, len(ids))
+		for _, value := range ids {
+			switch v := value.(type) {
+			case string:
+				if newID, ok := newIDs[v]; ok {
+					remapped = append(remapped, newID)
+				}
+			case []interface{}:
+				column := make([]interface{}, 0, len(v))
+				for _, id := range v {
+					if id, ok := id.(string); ok && newIDs[id] != "" {
+						column = append(column, newIDs[id])
+					}
+				}
+				if len(column) != 0 {
+					remapped = append(remapped, column)
+				}
+			}
+		}
+		return remapped
+	}
+
+	for _, fieldName := range []string{"contentOrder", "cardOrder"} {
+		if ids, ok := block.Fields[fieldName].([]interface{}); ok {
+			block.Fields[fieldName] = remapIDs(ids)
+		}
+	}
+
+	if templateID, ok := block.Fields["defaultTemplateId"].(string); ok && templateID != "" {
+		if newID, ok := newIDs[templateID]; ok {
+			block.Fields["defaultTemplateId"] = newID
+		} else {
+			delete(block.Fields, "defaultTemplateId")
+		}
+	}
+}
+
+// getBlocksWithDescendants returns the blocks with the given IDs followed
+// by all their descendants, with each block placed after its parent.
+func (s *SQLStore) getBlocksWithDescendants(db sq.BaseRunner, blockIDs []string) ([]*model.Block, error) {
+	ids := make([]string, 0, len(blockIDs))
+	seen := make(map[string]bool, len(blockIDs))
+	for _, id := range blockIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	blocks, err := s.getBlocksByIDs(db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for pending := blocks; len(pending) > 0; {
+		parentIDs := make([]string, 0, len(pending))
+		for _, block := range pending {
+			parentIDs = append(parentIDs, block.ID)
+		}
+
+		children, err := s.getChildBlocks(db, parentIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		pending = nil
+		for _, child := range children {
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				blocks = append(blocks, child)
+				pending = append(pending, child)
+			}
+		}
+	}
+
+	return blocks, nil
+}
+
+// getChildBlocks returns the blocks whose parent is one of the given
+// blocks.
+func (s *SQLStore) getChildBlocks(db sq.BaseRunner, parentIDs []string) ([]*model.Block, error) {
+	children := []*model.Block{}
+
+	for start := 0; start < len(parentIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(parentIDs) {
+			end = len(parentIDs)
+		}
+
+		query := s.getQueryBuilder(db).
+			Select(s.blockFields("")...).
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"parent_id": parentIDs[start:end]})
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error(`getChildBlocks ERROR`, mlog.Err(err))
+			return nil, err
+		}
+
+		blocks, err := s.blocksFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, blocks...)
+	}
+
+	return children, nil
+}
+
+func (s *SQLStore) archiveCard(db sq.BaseRunner, cardID, userID string) error {
+	return s.setCardArchivedAt(db, cardID, userID, utils.GetMillis())
+}
+
+func (s *SQLStore) unarchiveCard(db sq.BaseRunner, cardID, userID string) error {
+	return s.setCardArchivedAt(db, cardID, userID, 0)
+}
+
+// setCardArchivedAt updates the archived state of a card and writes
+// the change to the card history.
+func (s *SQLStore) setCardArchivedAt(db sq.BaseRunner, cardID, userID string, archivedAt int64) error {
+	card, err := s.getBlock(db, cardID)
+	if err != nil {
+		return err
+	}
+
+	if card.Type != model.TypeCard {
+		return model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", cardID))
+	}
+
+	if (card.ArchivedAt != 0) == (archivedAt != 0) {
+		// the card is already in the requested state
+		return nil
+	}
+
+	fieldsJSON, err := json.Marshal(card.Fields)
+	if err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"blocks").
+		Where(sq.Eq{"id": cardID}).
+		Set("archived_at", archivedAt).
+		Set("modified_by", userID).
+		Set("update_at", now)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error(`setCardArchivedAt ERROR`, mlog.String("cardID", cardID), mlog.Err(err))
+		return err
+	}
+
+	insertHistoryQuery := s.getQueryBuilder(db).Insert(s.tablePrefix+"blocks_history").
+		Columns(
+			"board_id",
+			"channel_id",
+			"id",
+			"parent_id",
+			s.escapeField("schema"),
+			"type",
+			"title",
+			"fields",
+			"modified_by",
+			"create_at",
+			"update_at",
+			"delete_at",
+			"created_by",
+			"archived_at",
+			"sort_order",
+		).
+		Values(
+			card.BoardID,
+			"",
+			card.ID,
+			card.ParentID,
+			card.Schema,
+			card.Type,
+			card.Title,
+			fieldsJSON,
+			userID,
+			card.CreateAt,
+			now,
+			card.DeleteAt,
+			card.CreatedBy,
+			archivedAt,
+			card.SortOrder,
+		)
+
+	if _, err := insertHistoryQuery.Exec(); err != nil {
+		return err
+	}
+
+	action := model.AuditActionArchiveCard
+	if archivedAt == 0 {
+		action = model.AuditActionUnarchiveCard
+	}
+	return s.auditChange(db, card.BoardID, userID, action, cardID, "")
+}
+
+// commentAuthorFields returns the fields of the comment authors, and
+// the join that selects them. In plugin mode the users are the ones of
+// the Mattermost server.
+func (s *SQLStore) commentAuthorFields() ([]string, string) {
+	if s.isPlugin {
+		return []string{
+			"u.Id", "u.Username", "u.Email", "u.Nickname", "u.FirstName", "u.LastName",
+			"u.CreateAt", "u.UpdateAt", "u.DeleteAt",
+		}, "Users AS u ON u.Id = c.created_by"
+	}
+	return []string{
+		"u.id", "COALESCE(u.username, '')", "COALESCE(u.email, '')", "''", "''", "''",
+		"u.create_at", "u.update_at", "u.delete_at",
+	}, s.tablePrefix + "users AS u ON u.id = c.created_by"
+}
+
+// getRecentComments returns the latest comments of a board, newest
+// first, along with their authors. Comments on deleted cards are not
+// returned, and authors that no longer exist are left empty.
+func (s *SQLStore) getRecentComments(db sq.BaseRunner, boardID string, limit int) ([]*model.CommentWithAuthor, error) {
+	authorFields, authorJoin := s.commentAuthorFields()
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("c.")...).
+		Columns(authorFields...).
+		From(s.tablePrefix+"blocks AS c").
+		LeftJoin(authorJoin).
+		Where(sq.Eq{"c.board_id": boardID}).
+		Where(sq.Eq{"c.type": model.TypeComment}).
+		Where(sq.Eq{"c.delete_at": 0}).
+		Where("EXISTS (SELECT 1 FROM "+s.tablePrefix+"blocks AS p WHERE p.id = c.parent_id AND p.delete_at = 0)").
+		OrderBy("c.create_at DESC", "c.id")
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getRecentComments ERROR`, mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	comments := []*model.CommentWithAuthor{}
+	for rows.Next() {
+		var authorID, username, email, nickname, firstName, lastName sql.NullString
+		var createAt, updateAt, deleteAt sql.NullInt64
+
+		block, err := s.blockFromRow(rows,
+			&authorID, &username, &email, &nickname, &firstName, &lastName,
+			&createAt, &updateAt, &deleteAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		comment := &model.CommentWithAuthor{Block: *block}
+		if authorID.Valid {
+			comment.Author = &model.User{
+				ID:        authorID.String,
+				Username:  username.String,
+				Email:     email.String,
+				Nickname:  nickname.String,
+				FirstName: firstName.String,
+				LastName:  lastName.String,
+				CreateAt:  createAt.Int64,
+				UpdateAt:  updateAt.Int64,
+				DeleteAt:  deleteAt.Int64,
+			}
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+// getArchivedCards returns the archived cards of a board, the most
+// recently archived first.
+func (s *SQLStore) getArchivedCards(db sq.BaseRunner, boardID string) ([]*model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Gt{"COALESCE(archived_at, 0)": 0}).
+		OrderBy("archived_at DESC", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getArchivedCards ERROR`, mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// propertyValuesJoin returns a join clause that expands the values of
+// a card property into one row per value, along with the column that
+// holds the value. Multi-select properties produce a row per selected
+// value, and unset properties a single NULL row. MySQL 5.7 has no
+// JSON_TABLE to expand the values, so it isn't supported here.
+func (s *SQLStore) propertyValuesJoin(propertyID string) (string, string, []interface{}) {
+	switch s.dbType {
+	case model.PostgresDBType:
+		prop := "b.fields->'properties'->?::text"
+		return "CROSS JOIN LATERAL json_array_elements_text(" +
+			"CASE WHEN json_typeof(" + prop + ") = 'array' THEN " +
+			"CASE WHEN json_array_length(" + prop + ") > 0 THEN " + prop + " ELSE json_build_array(NULL::text) END " +
+			"ELSE json_build_array(b.fields->'properties'->>?::text) END" +
+			") AS pv(prop_value)", "pv.prop_value", []interface{}{propertyID, propertyID, propertyID, propertyID}
+	default:
+		path := "'$.properties.' || json_quote(?)"
+		return "CROSS JOIN json_each(" +
+			"CASE WHEN json_type(b.fields, " + path + ") = 'array' THEN " +
+			"CASE WHEN json_array_length(b.fields, " + path + ") > 0 THEN json_extract(b.fields, " + path + ") ELSE json_array(NULL) END " +
+			"ELSE json_array(json_extract(b.fields, " + path + ")) END" +
+			") AS pv", "pv.value", []interface{}{propertyID, propertyID, propertyID, propertyID}
+	}
+}
+
+// countCardsByPropertyGrouped returns the number of active cards of a
+// board for each value of a property. Cards without a value are
+// counted under the empty string, and multi-select cards count toward
+// each of their values.
+func (s *SQLStore) countCardsByPropertyGrouped(db sq.BaseRunner, boardID, propertyID string) (map[string]int64, error) {
+	if s.dbType == model.MysqlDBType {
+		return s.countCardsByRawPropertyValue(db, boardID, propertyID)
+	}
+
+	join, valueColumn, args := s.propertyValuesJoin(propertyID)
+	value := "COALESCE(" + valueColumn + ", '')"
+
+	query := s.getQueryBuilder(db).
+		Select(
+			value+" AS prop_value",
+			"COUNT(*) AS count",
+		).
+		From(s.tablePrefix+"blocks AS b").
+		JoinClause(join, args...).
+		Where(sq.Eq{"b.board_id": boardID}).
+		Where(sq.Eq{"b.type": model.TypeCard}).
+		Where(sq.Eq{"b.delete_at": 0}).
+		Where(sq.Eq{"COALESCE(b.archived_at, 0)": 0}).
+		GroupBy(value)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`CountCardsByPropertyGrouped ERROR`,
+			mlog.String("boardID", boardID),
+			mlog.String("propertyID", propertyID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var value string
+		var count int64
+
+		if err := rows.Scan(&value, &count); err != nil {
+			s.logger.Error("Failed to fetch card count", mlog.Err(err))
+			return nil, err
+		}
+		counts[value] += count
+	}
+	return counts, nil
+}
+
+// countCardsByRawPropertyValue is the MySQL version of
+// countCardsByPropertyGrouped. The cards are grouped by the JSON value
+// of the property, and the multi-select values are expanded here.
+func (s *SQLStore) countCardsByRawPropertyValue(db sq.BaseRunner, boardID, propertyID string) (map[string]int64, error) {
+	query := s.getQueryBuilder(db).
+		Select().
+		Column(sq.Expr("COALESCE(CAST(JSON_EXTRACT(fields, CONCAT('$.properties.', JSON_QUOTE(?))) AS CHAR), '') AS prop_value", propertyID)).
+		Column("COUNT(*) AS count").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(sq.Eq{"COALESCE(archived_at, 0)": 0}).
+		GroupBy("prop_value")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`CountCardsByPropertyGrouped ERROR`,
+			mlog.String("boardID", boardID),
+			mlog.String("propertyID", propertyID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var rawValue string
+		var count int64
+
+		if err := rows.Scan(&rawValue, &count); err != nil {
+			s.logger.Error("Failed to fetch card count", mlog.Err(err))
+			return nil, err
+		}
+		for _, value := range expandPropertyValue(rawValue) {
+			counts[value] += count
+		}
+	}
+	return counts, nil
+}
+
+// expandPropertyValue returns the text values of a JSON encoded card
+// property, one per selected value for multi-select properties. Unset
+// properties and empty selections have a single empty value.
+func expandPropertyValue(rawValue string) []string {
+	var values []json.RawMessage
+	if err := json.Unmarshal([]byte(rawValue), &values); err != nil {
+		return []string{propertyValueText(json.RawMessage(rawValue))}
+	}
+	if len(values) == 0 {
+		return []string{""}
+	}
+
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		texts = append(texts, propertyValueText(value))
+	}
+	return texts
+}
+
+// propertyValueText returns the text of a JSON encoded scalar, as the
+// ->> operator of Postgres does.
+func propertyValueText(value json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		return text
+	}
+	if string(value) == "null" {
+		return ""
+	}
+	return string(value)
+}
+
+// propertyEmptyCondition returns a condition matching blocks whose
+// property is absent, null, an empty string or an empty array.
+func (s *SQLStore) propertyEmptyCondition(propertyID string) (string, []interface{}) {
+	switch s.dbType {
+	case model.PostgresDBType:
+		prop := "fields->'properties'->?::text"
+		return "COALESCE(CASE WHEN json_typeof(" + prop + ") = 'array' THEN json_array_length(" + prop + ") " +
+			"ELSE length(fields->'properties'->>?::text) END, 0) = 0", []interface{}{propertyID, propertyID, propertyID}
+	case model.MysqlDBType:
+		path := "CONCAT('$.properties.', JSON_QUOTE(?))"
+		return "COALESCE(CASE WHEN JSON_TYPE(JSON_EXTRACT(fields, " + path + ")) = 'ARRAY' THEN JSON_LENGTH(fields, " + path + ") " +
+			"WHEN JSON_TYPE(JSON_EXTRACT(fields, " + path + ")) = 'NULL' THEN 0 " +
+			"ELSE LENGTH(JSON_UNQUOTE(JSON_EXTRACT(fields, " + path + "))) END, 0) = 0", []interface{}{propertyID, propertyID, propertyID, propertyID}
+	default:
+		path := "'$.properties.' || json_quote(?)"
+		return "COALESCE(CASE WHEN json_type(fields, " + path + ") = 'array' THEN json_array_length(fields, " + path + ") " +
+			"ELSE length(json_extract(fields, " + path + ")) END, 0) = 0", []interface{}{propertyID, propertyID, propertyID}
+	}
+}
+
+// getCardsMissingProperty returns the active cards of a board that
+// have no value for a property.
+func (s *SQLStore) getCardsMissingProperty(db sq.BaseRunner, boardID, propertyID string) ([]*model.Block, error) {
+	condition, args := s.propertyEmptyCondition(propertyID)
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(sq.Eq{"COALESCE(archived_at, 0)": 0}).
+		Where(condition, args...)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`GetCardsMissingProperty ERROR`,
+			mlog.String("boardID", boardID),
+			mlog.String("propertyID", propertyID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+var blockFieldPathRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// blockFieldExpr returns a SQL expression extracting the text value at
+// a dot separated path of the block fields, e.g. "properties.<id>".
+func (s *SQLStore) blockFieldExpr(path string) (string, error) {
+	if !blockFieldPathRegexp.MatchString(path) {
+		return "", model.NewErrBadRequest(fmt.Sprintf("invalid field path %q", path))
 	}
+	segments := strings.Split(path, ".")
 
-	deleteQuery := s.getQueryBuilder(db).
-		Delete(s.tablePrefix + "blocks").
-		Where(sq.Eq{"id": blockID})
+	switch s.dbType {
+	case model.PostgresDBType:
+		return "(fields #>> '{" + strings.Join(segments, ",") + "}')", nil
+	case model.MysqlDBType:
+		return `JSON_UNQUOTE(JSON_EXTRACT(fields, '$."` + strings.Join(segments, `"."`) + `"'))`, nil
+	default:
+		return `json_extract(fields, '$."` + strings.Join(segments, `"."`) + `"')`, nil
+	}
+}
 
-	if _, err := deleteQuery.Exec(); err != nil {
-		return err
+// searchBlocksForBoard returns the active blocks of a board whose title,
+// or any of the given field paths, contains the term. Matching is case
+// insensitive and uses the same LIKE based conditions as board search.
+func (s *SQLStore) searchBlocksForBoard(db sq.BaseRunner, boardID, term string, fields []string) ([]*model.Block, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return []*model.Block{}, nil
 	}
 
-	return nil
-}
+	conditions := sq.Or{s.titleSearchCondition("title", term)}
+	for _, field := range fields {
+		expr, err := s.blockFieldExpr(field)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, s.titleSearchCondition(expr, term))
+	}
 
-func (s *SQLStore) undeleteBlock(db sq.BaseRunner, blockID string, modifiedBy string) error {
-	blocks, err := s.getBlockHistory(db, blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(conditions).
+		OrderBy("update_at DESC", "id")
+
+	rows, err := query.Query()
 	if err != nil {
-		return err
+		s.logger.Error(`SearchBlocksForBoard ERROR`,
+			mlog.String("boardID", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
 	}
+	defer s.CloseRows(rows)
 
-	if len(blocks) == 0 {
-		s.logger.Warn("undeleteBlock block not found", mlog.String("block_id", blockID))
-		return nil // undeleting non-exiting block is not considered an error (for now)
-	}
-	block := blocks[0]
+	return s.blocksFromRows(rows)
+}
 
-	if block.DeleteAt == 0 {
-		s.logger.Warn("undeleteBlock block not deleted", mlog.String("block_id", block.ID))
-		return nil // undeleting not deleted block is not considered an error (for now)
+// searchBlocksForUser returns the active blocks whose title matches the
+// term, in the boards of a team that the user is a member of and,
+// optionally, in its open boards.
+func (s *SQLStore) searchBlocksForUser(db sq.BaseRunner, teamID, term, userID string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	memberCondition := sq.Expr("EXISTS (SELECT 1 FROM "+s.tablePrefix+"board_members AS bm WHERE bm.board_id = b.id AND bm.user_id = ?)", userID)
+
+	boardsQuery := sq.Select("b.id").
+		From(s.tablePrefix + "boards AS b").
+		Where(sq.Eq{"b.team_id": teamID}).
+		Where(sq.Eq{"b.is_template": false}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0})
+
+	if opts.IncludePublicBoards {
+		boardsQuery = boardsQuery.Where(sq.Or{sq.Eq{"b.type": model.BoardTypeOpen}, memberCondition})
+	} else {
+		boardsQuery = boardsQuery.Where(memberCondition)
 	}
 
-	fieldsJSON, err := json.Marshal(block.Fields)
+	boardsSQL, boardsArgs, err := boardsQuery.ToSql()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	now := utils.GetMillis()
-	columns := []string{
-		"board_id",
-		"channel_id",
-		"id",
-		"parent_id",
-		s.escapeField("schema"),
-		"type",
-		"title",
-		"fields",
-		"modified_by",
-		"create_at",
-		"update_at",
-		"delete_at",
-		"created_by",
+	return s.searchBlocks(db, sq.Expr("board_id IN ("+boardsSQL+")", boardsArgs...), term, opts)
+}
+
+// searchBlocksInBoards returns the active blocks of the given boards whose
+// title matches the term.
+func (s *SQLStore) searchBlocksInBoards(db sq.BaseRunner, boardIDs []string, term string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	if len(boardIDs) == 0 {
+		return []*model.Block{}, nil
+	}
+	return s.searchBlocks(db, sq.Eq{"board_id": boardIDs}, term, opts)
+}
+
+// searchBlocks runs a full text search of the term on the title of the
+// blocks matching boardCondition, most recently updated first. Card
+// descriptions and comments are blocks of their own, so they are found
+// by their title too.
+func (s *SQLStore) searchBlocks(db sq.BaseRunner, boardCondition sq.Sqlizer, term string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return []*model.Block{}, nil
 	}
 
-	values := []interface{}{
-		block.BoardID,
-		"",
-		block.ID,
-		block.ParentID,
-		block.Schema,
-		block.Type,
-		block.Title,
-		fieldsJSON,
-		modifiedBy,
-		block.CreateAt,
-		now,
-		0,
-		block.CreatedBy,
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix+"blocks").
+		Where(boardCondition).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(s.fullTextSearchCondition("title", term)).
+		OrderBy("update_at DESC", "id")
+
+	if len(opts.BlockTypes) > 0 {
+		query = query.Where(sq.Eq{"type": opts.BlockTypes})
 	}
-	insertHistoryQuery := s.getQueryBuilder(db).Insert(s.tablePrefix + "blocks_history").
-		Columns(columns...).
-		Values(values...)
-	insertQuery := s.getQueryBuilder(db).Insert(s.tablePrefix + "blocks").
-		Columns(columns...).
-		Values(values...)
 
-	if _, err := insertHistoryQuery.Exec(); err != nil {
-		return err
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
 	}
 
-	if _, err := insertQuery.Exec(); err != nil {
-		return err
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`searchBlocks ERROR`, mlog.Err(err))
+		return nil, err
 	}
+	defer s.CloseRows(rows)
 
-	return nil
+	return s.blocksFromRows(rows)
+}
+
+// fullTextSearchCondition matches the blocks where column contains every
+// word of the term, using the full text index on the block titles. On
+// SQLite the index is the blocks_title_fts table, matched by the rowid
+// of the blocks.
+func (s *SQLStore) fullTextSearchCondition(column, term string) sq.Sqlizer {
+	switch s.dbType {
+	case model.SqliteDBType:
+		// every word is required and matched as a prefix, quoted so
+		// that it can't be read as an FTS5 operator
+		words := []string{}
+		for _, word := range strings.Fields(term) {
+			if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) < 0 {
+				continue
+			}
+			words = append(words, `"`+strings.ReplaceAll(word, `"`, `""`)+`"*`)
+		}
+		if len(words) > 0 {
+			ftsTable := s.tablePrefix + "blocks_title_fts"
+			return sq.Expr("rowid IN (SELECT rowid FROM "+ftsTable+" WHERE "+ftsTable+" MATCH ?)", strings.Join(words, " AND "))
+		}
+	case model.PostgresDBType:
+		return sq.Expr("to_tsvector('simple', COALESCE("+column+", '')) @@ plainto_tsquery('simple', ?)", term)
+	case model.MysqlDBType:
+		// every word is required and matched as a prefix, once stripped
+		// of the boolean mode operators
+		words := []string{}
+		for _, word := range strings.Fields(term) {
+			word = strings.Trim(word, `+-<>()~*"@`)
+			if word != "" {
+				words = append(words, "+"+word+"*")
+			}
+		}
+		if len(words) > 0 {
+			return sq.Expr("MATCH("+column+") AGAINST(? IN BOOLEAN MODE)", strings.Join(words, " "))
+		}
+	}
+
+	conditions := sq.And{}
+	for _, word := range strings.Fields(term) {
+		conditions = append(conditions, s.titleSearchCondition(column, word))
+	}
+	return conditions
 }
 
 func (s *SQLStore) getBlockCountsByType(db sq.BaseRunner) (map[string]int64, error) {
@@ -517,6 +2363,130 @@ func (s *SQLStore) getBlockCountsByType(db sq.BaseRunner) (map[string]int64, err
 	return m, nil
 }
 
+// getBlockCountsByTypeForBoard returns the number of non-deleted blocks of
+// a board, keyed by block type.
+func (s *SQLStore) getBlockCountsByTypeForBoard(db sq.BaseRunner, boardID string) (map[string]int64, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"type",
+			"COUNT(*) AS count",
+		).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"delete_at": 0}).
+		GroupBy("type")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBlockCountsByTypeForBoard ERROR`, mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	m := make(map[string]int64)
+
+	for rows.Next() {
+		var blockType string
+		var count int64
+
+		if err := rows.Scan(&blockType, &count); err != nil {
+			s.logger.Error("Failed to fetch board block count", mlog.Err(err))
+			return nil, err
+		}
+		m[blockType] = count
+	}
+	return m, nil
+}
+
+// getBoardStats returns the block statistics of a board in a single
+// query. A board without blocks gets zeroed statistics, and a board that
+// doesn't exist a not found error.
+func (s *SQLStore) getBoardStats(db sq.BaseRunner, boardID string) (*model.BoardStats, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"b.id",
+			"COALESCE(SUM(CASE WHEN bl.type = '"+string(model.TypeCard)+"' THEN 1 ELSE 0 END), 0)",
+			"COUNT(bl.id)",
+			"COALESCE(MAX(bl.update_at), 0)",
+			"(SELECT COUNT(DISTINCT bh.modified_by) FROM "+s.tablePrefix+"blocks_history AS bh WHERE bh.board_id = b.id)",
+		).
+		From(s.tablePrefix + "boards AS b").
+		LeftJoin(s.tablePrefix + "blocks AS bl ON bl.board_id = b.id AND bl.delete_at = 0").
+		Where(sq.Eq{"b.id": boardID}).
+		GroupBy("b.id")
+
+	stats := &model.BoardStats{}
+	err := query.QueryRow().Scan(
+		&stats.BoardID,
+		&stats.CardCount,
+		&stats.BlockCount,
+		&stats.LastModified,
+		&stats.ContributorCount,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NewErrNotFound("board ID=" + boardID)
+	}
+	if err != nil {
+		s.logger.Error(`getBoardStats ERROR`, mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// getBlockCountForTeam returns the number of non-deleted blocks of the
+// non-deleted boards of a team.
+func (s *SQLStore) getBlockCountForTeam(db sq.BaseRunner, teamID string) (int64, error) {
+	counts, err := s.getBlockCountsForTeams(db, []string{teamID})
+	if err != nil {
+		return 0, err
+	}
+	return counts[teamID], nil
+}
+
+// getBlockCountsForTeams returns the number of non-deleted blocks of the
+// non-deleted boards of each of the given teams, keyed by team ID.
+// Teams without blocks have a zero count.
+func (s *SQLStore) getBlockCountsForTeams(db sq.BaseRunner, teamIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(teamIDs))
+	if len(teamIDs) == 0 {
+		return counts, nil
+	}
+
+	for _, teamID := range teamIDs {
+		counts[teamID] = 0
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("b.team_id", "COUNT(*) AS count").
+		From(s.tablePrefix + "blocks AS bl").
+		Join(s.tablePrefix + "boards AS b ON b.id = bl.board_id").
+		Where(sq.Eq{"b.team_id": teamIDs}).
+		Where(sq.Eq{"b.delete_at": 0}).
+		Where(sq.Eq{"bl.delete_at": 0}).
+		GroupBy("b.team_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBlockCountsForTeams ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var teamID string
+		var count int64
+
+		if err := rows.Scan(&teamID, &count); err != nil {
+			s.logger.Error("Failed to fetch team block count", mlog.Err(err))
+			return nil, err
+		}
+		counts[teamID] = count
+	}
+
+	return counts, nil
+}
+
 func (s *SQLStore) getBoardCount(db sq.BaseRunner) (int64, error) {
 	query := s.getQueryBuilder(db).
 		Select("COUNT(*) AS count").
@@ -535,9 +2505,51 @@ func (s *SQLStore) getBoardCount(db sq.BaseRunner) (int64, error) {
 	return count, nil
 }
 
+// countBoardsCreatedBetween returns the number of non-template boards of
+// a team created in the [start, end) window that aren't deleted.
+func (s *SQLStore) countBoardsCreatedBetween(db sq.BaseRunner, teamID string, start, end int64) (int64, error) {
+	return s.countBoardsCreatedBetweenForTeam(db, teamID, start, end)
+}
+
+// countBoardsCreatedBetweenAllTeams returns the number of non-template
+// boards created in the [start, end) window that aren't deleted.
+func (s *SQLStore) countBoardsCreatedBetweenAllTeams(db sq.BaseRunner, start, end int64) (int64, error) {
+	return s.countBoardsCreatedBetweenForTeam(db, "", start, end)
+}
+
+// countBoardsCreatedBetweenForTeam counts the boards created in a time
+// window, for all teams if teamID is empty.
+func (s *SQLStore) countBoardsCreatedBetweenForTeam(db sq.BaseRunner, teamID string, start, end int64) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*) AS count").
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"delete_at": 0}).
+		Where(sq.Eq{"is_template": false}).
+		Where(sq.GtOrEq{"create_at": start}).
+		Where(sq.Lt{"create_at": end})
+
+	if teamID != "" {
+		query = query.Where(sq.Eq{"team_id": teamID})
+	}
+
+	row := query.QueryRow()
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		s.logger.Error("Failed to count boards created in window",
+			mlog.String("team_id", teamID),
+			mlog.Err(err),
+		)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (s *SQLStore) getBlock(db sq.BaseRunner, blockID string) (*model.Block, error) {
 	query := s.getQueryBuilder(db).
-		Select(s.blockFields()...).
+		Select(s.blockFields("")...).
 		From(s.tablePrefix + "blocks").
 		Where(sq.Eq{"id": blockID})
 
@@ -561,29 +2573,60 @@ func (s *SQLStore) getBlock(db sq.BaseRunner, blockID string) (*model.Block, err
 }
 
 func (s *SQLStore) getBlockHistory(db sq.BaseRunner, blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
-	var order string
-	if opts.Descending {
-		order = descClause
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"id": blockID})
+	query = applyBlockHistoryOptions(query, opts)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`GetBlockHistory ERROR`, mlog.Err(err))
+		return nil, err
 	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
 
+// getBlockHistoryEntry returns the version of a block saved at the given
+// update time. When several entries share it, the last one saved wins.
+func (s *SQLStore) getBlockHistoryEntry(db sq.BaseRunner, blockID string, version int64) (*model.Block, error) {
 	query := s.getQueryBuilder(db).
-		Select(s.blockFields()...).
+		Select(s.blockFields("")...).
 		From(s.tablePrefix + "blocks_history").
 		Where(sq.Eq{"id": blockID}).
-		OrderBy("insert_at " + order + ", update_at" + order)
+		Where(sq.Eq{"update_at": version}).
+		OrderBy("insert_at DESC").
+		Limit(1)
 
-	if opts.BeforeUpdateAt != 0 {
-		query = query.Where(sq.Lt{"update_at": opts.BeforeUpdateAt})
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBlockHistoryEntry ERROR`, mlog.String("block_id", blockID), mlog.Err(err))
+		return nil, err
 	}
+	defer s.CloseRows(rows)
 
-	if opts.AfterUpdateAt != 0 {
-		query = query.Where(sq.Gt{"update_at": opts.AfterUpdateAt})
+	blocks, err := s.blocksFromRows(rows)
+	if err != nil {
+		return nil, err
 	}
-
-	if opts.Limit != 0 {
-		query = query.Limit(opts.Limit)
+	if len(blocks) == 0 {
+		return nil, model.NewErrNotFound(fmt.Sprintf("block history entry ID=%s version=%d", blockID, version))
 	}
 
+	return blocks[0], nil
+}
+
+// getBlockHistoryDescendants returns the history of all the blocks of a
+// board, merged in a single timeline.
+func (s *SQLStore) getBlockHistoryDescendants(db sq.BaseRunner, boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"board_id": boardID})
+	query = applyBlockHistoryOptions(query, opts)
+
 	rows, err := query.Query()
 	if err != nil {
 		s.logger.Error(`GetBlockHistory ERROR`, mlog.Err(err))
@@ -594,17 +2637,15 @@ func (s *SQLStore) getBlockHistory(db sq.BaseRunner, blockID string, opts model.
 	return s.blocksFromRows(rows)
 }
 
-func (s *SQLStore) getBlockHistoryDescendants(db sq.BaseRunner, boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+// applyBlockHistoryOptions adds the order, time range and pagination of
+// the options to a query on the blocks history. Records are sorted by
+// insert_at, then by update_at and ID so pages are stable.
+func applyBlockHistoryOptions(query sq.SelectBuilder, opts model.QueryBlockHistoryOptions) sq.SelectBuilder {
 	var order string
 	if opts.Descending {
 		order = descClause
 	}
-
-	query := s.getQueryBuilder(db).
-		Select(s.blockFields()...).
-		From(s.tablePrefix + "blocks_history").
-		Where(sq.Eq{"board_id": boardID}).
-		OrderBy("insert_at " + order + ", update_at" + order)
+	query = query.OrderBy("insert_at " + order + ", update_at" + order + ", id" + order)
 
 	if opts.BeforeUpdateAt != 0 {
 		query = query.Where(sq.Lt{"update_at": opts.BeforeUpdateAt})
@@ -614,18 +2655,24 @@ func (s *SQLStore) getBlockHistoryDescendants(db sq.BaseRunner, boardID string,
 		query = query.Where(sq.Gt{"update_at": opts.AfterUpdateAt})
 	}
 
-	if opts.Limit != 0 {
-		query = query.Limit(opts.Limit)
+	if opts.Since != 0 {
+		query = query.Where(sq.GtOrEq{"update_at": opts.Since})
 	}
 
-	rows, err := query.Query()
-	if err != nil {
-		s.logger.Error(`GetBlockHistory ERROR`, mlog.Err(err))
-		return nil, err
+	if opts.Before != 0 {
+		query = query.Where(sq.Lt{"update_at": opts.Before})
 	}
-	defer s.CloseRows(rows)
 
-	return s.blocksFromRows(rows)
+	if opts.PerPage > 0 {
+		query = query.Limit(uint64(opts.PerPage))
+		if opts.Page != 0 {
+			query = query.Offset(uint64(opts.Page * opts.PerPage))
+		}
+	} else if opts.Limit != 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	return query
 }
 
 // getBoardAndCardByID returns the first parent of type `card` and first parent of type `board` for the block specified by ID.
@@ -0,0 +1,83 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlocksForBoardStreamLargeBoard(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	const boardID = "board-id"
+	const blockCount = 50000
+	const batchSize = 50 // keeps each statement within the SQLite limit of 999 variables
+
+	// the synthetic blocks are inserted directly in a single transaction,
+	// as going through InsertBlocks one block at a time is too slow for a
+	// board this size
+	tx, err := sqlStore.db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+
+	now := utils.GetMillis()
+	for start := 0; start < blockCount; start += batchSize {
+		query := sqlStore.getQueryBuilder(tx).
+			Insert(sqlStore.tablePrefix+"blocks").
+			Columns("channel_id", "id", "parent_id", "created_by", "modified_by", sqlStore.escapeField("schema"),
+				"type", "title", "fields", "create_at", "update_at", "delete_at", "board_id", "archived_at", "sort_order")
+
+		for i := start; i < start+batchSize; i++ {
+			query = query.Values("", fmt.Sprintf("block-%06d", i), boardID, "user-id", "user-id", 1,
+				model.TypeCard, "", "{}", now, now, 0, boardID, 0, now)
+		}
+
+		_, err = query.Exec()
+		require.NoError(t, err)
+	}
+	require.NoError(t, tx.Commit())
+
+	// the callback only keeps a count, so the blocks are never
+	// materialized all at once
+	count := 0
+	lastID := ""
+	err = store.GetBlocksForBoardStream(context.Background(), boardID, func(block model.Block) error {
+		if block.ID <= lastID {
+			return fmt.Errorf("block %s streamed out of order", block.ID)
+		}
+		lastID = block.ID
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, blockCount, count)
+	require.Equal(t, fmt.Sprintf("block-%06d", blockCount-1), lastID)
+}
+
+func TestExpandPropertyValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rawValue string
+		expected []string
+	}{
+		{"unset", "", []string{""}},
+		{"null", "null", []string{""}},
+		{"text", `"option-1"`, []string{"option-1"}},
+		{"number", "42", []string{"42"}},
+		{"empty selection", "[]", []string{""}},
+		{"multi-select", `["option-1", "option-2"]`, []string{"option-1", "option-2"}},
+		{"null in a selection", `["option-1", null]`, []string{"option-1", ""}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, expandPropertyValue(tc.rawValue))
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"crypto/md5"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -37,6 +39,8 @@ func boardFields(prefix string) []string {
 		"create_at",
 		"update_at",
 		"delete_at",
+		"COALESCE(creation_source, '')",
+		"COALESCE(source_id, '')",
 	}
 
 	if prefix == "" {
@@ -74,6 +78,8 @@ func boardHistoryFields() []string {
 		"COALESCE(create_at, 0)",
 		"COALESCE(update_at, 0)",
 		"COALESCE(delete_at, 0)",
+		"COALESCE(creation_source, '')",
+		"COALESCE(source_id, '')",
 	}
 
 	return fields
@@ -83,63 +89,80 @@ var boardMemberFields = []string{
 	"COALESCE(B.minimum_role, '')",
 	"BM.board_id",
 	"BM.user_id",
-	"BM.roles",
+	"COALESCE(NULLIF(BM.roles, ''), 'editor')",
 	"BM.scheme_admin",
 	"BM.scheme_editor",
 	"BM.scheme_commenter",
 	"BM.scheme_viewer",
+	"COALESCE(BM.last_viewed_at, 0)",
+	"COALESCE(BM.custom_role_id, '')",
 }
 
 func (s *SQLStore) boardsFromRows(rows *sql.Rows) ([]*model.Board, error) {
 	boards := []*model.Board{}
 
 	for rows.Next() {
-		var board model.Board
-		var propertiesBytes []byte
-		var cardPropertiesBytes []byte
-
-		err := rows.Scan(
-			&board.ID,
-			&board.TeamID,
-			&board.ChannelID,
-			&board.CreatedBy,
-			&board.ModifiedBy,
-			&board.Type,
-			&board.MinimumRole,
-			&board.Title,
-			&board.Description,
-			&board.Icon,
-			&board.ShowDescription,
-			&board.IsTemplate,
-			&board.TemplateVersion,
-			&propertiesBytes,
-			&cardPropertiesBytes,
-			&board.CreateAt,
-			&board.UpdateAt,
-			&board.DeleteAt,
-		)
-		if err != nil {
-			s.logger.Error("boardsFromRows scan error", mlog.Err(err))
-			return nil, err
-		}
-
-		err = json.Unmarshal(propertiesBytes, &board.Properties)
-		if err != nil {
-			s.logger.Error("board properties unmarshal error", mlog.Err(err))
-			return nil, err
-		}
-		err = json.Unmarshal(cardPropertiesBytes, &board.CardProperties)
+		board, err := s.scanBoard(rows)
 		if err != nil {
-			s.logger.Error("board card properties unmarshal error", mlog.Err(err))
 			return nil, err
 		}
 
-		boards = append(boards, &board)
+		boards = append(boards, board)
 	}
 
 	return boards, nil
 }
 
+// scanBoard scans the board fields of the current row, followed by
+// any extra columns into the extra destinations.
+func (s *SQLStore) scanBoard(rows *sql.Rows, extra ...interface{}) (*model.Board, error) {
+	var board model.Board
+	var propertiesBytes []byte
+	var cardPropertiesBytes []byte
+
+	dest := []interface{}{
+		&board.ID,
+		&board.TeamID,
+		&board.ChannelID,
+		&board.CreatedBy,
+		&board.ModifiedBy,
+		&board.Type,
+		&board.MinimumRole,
+		&board.Title,
+		&board.Description,
+		&board.Icon,
+		&board.ShowDescription,
+		&board.IsTemplate,
+		&board.TemplateVersion,
+		&propertiesBytes,
+		&cardPropertiesBytes,
+		&board.CreateAt,
+		&board.UpdateAt,
+		&board.DeleteAt,
+		&board.CreationSource,
+		&board.SourceID,
+	}
+
+	err := rows.Scan(append(dest, extra...)...)
+	if err != nil {
+		s.logger.Error("boardsFromRows scan error", mlog.Err(err))
+		return nil, err
+	}
+
+	err = json.Unmarshal(propertiesBytes, &board.Properties)
+	if err != nil {
+		s.logger.Error("board properties unmarshal error", mlog.Err(err))
+		return nil, err
+	}
+	err = json.Unmarshal(cardPropertiesBytes, &board.CardProperties)
+	if err != nil {
+		s.logger.Error("board card properties unmarshal error", mlog.Err(err))
+		return nil, err
+	}
+
+	return &board, nil
+}
+
 func (s *SQLStore) boardMembersFromRows(rows *sql.Rows) ([]*model.BoardMember, error) {
 	boardMembers := []*model.BoardMember{}
 
@@ -155,6 +178,8 @@ func (s *SQLStore) boardMembersFromRows(rows *sql.Rows) ([]*model.BoardMember, e
 			&boardMember.SchemeEditor,
 			&boardMember.SchemeCommenter,
 			&boardMember.SchemeViewer,
+			&boardMember.LastViewedAt,
+			&boardMember.CustomRoleID,
 		)
 		if err != nil {
 			return nil, err
@@ -216,7 +241,8 @@ func (s *SQLStore) getBoardsByCondition(db sq.BaseRunner, conditions ...interfac
 func (s *SQLStore) getBoardsFieldsByCondition(db sq.BaseRunner, fields []string, conditions ...interface{}) ([]*model.Board, error) {
 	query := s.getQueryBuilder(db).
 		Select(fields...).
-		From(s.tablePrefix + "boards")
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"COALESCE(delete_at, 0)": 0})
 	for _, c := range conditions {
 		query = query.Where(c)
 	}
@@ -244,26 +270,227 @@ func (s *SQLStore) getBoard(db sq.BaseRunner, boardID string) (*model.Board, err
 	return s.getBoardByCondition(db, sq.Eq{"id": boardID})
 }
 
+// getBoards returns the boards with the given IDs in the same order as
+// the IDs. IDs that are not found are skipped.
+func (s *SQLStore) getBoards(db sq.BaseRunner, boardIDs []string) ([]*model.Board, error) {
+	boardsMap := make(map[string]*model.Board, len(boardIDs))
+
+	for start := 0; start < len(boardIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(boardIDs) {
+			end = len(boardIDs)
+		}
+
+		query := s.getQueryBuilder(db).
+			Select(boardFields("")...).
+			From(s.tablePrefix + "boards").
+			Where(sq.Eq{"id": boardIDs[start:end]}).
+			Where(sq.Eq{"COALESCE(delete_at, 0)": 0})
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error(`getBoards ERROR`, mlog.Err(err))
+			return nil, err
+		}
+
+		boards, err := s.boardsFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, board := range boards {
+			boardsMap[board.ID] = board
+		}
+	}
+
+	boards := make([]*model.Board, 0, len(boardsMap))
+	for _, id := range boardIDs {
+		if board, ok := boardsMap[id]; ok {
+			boards = append(boards, board)
+			// IDs passed more than once are returned only once
+			delete(boardsMap, id)
+		}
+	}
+
+	return boards, nil
+}
+
+// getBoardCardProperties returns the card property definitions of a
+// board.
+func (s *SQLStore) getBoardCardProperties(db sq.BaseRunner, boardID string) ([]model.CardProperty, error) {
+	board, err := s.getBoard(db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ParseCardProperties(board)
+}
+
+// setBoardPropertyOrder reorders the card properties of a board. The
+// property IDs must match the board's card properties exactly.
+func (s *SQLStore) setBoardPropertyOrder(db sq.BaseRunner, boardID string, propertyIDs []string, userID string) error {
+	board, err := s.getBoard(db, boardID)
+	if err != nil {
+		return err
+	}
+
+	if err := board.SetCardPropertyOrder(propertyIDs); err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+
+	if _, _, err := s.upsertBoard(db, board, userID); err != nil {
+		return err
+	}
+
+	return s.auditChange(db, boardID, userID, model.AuditActionPatchBoard, boardID, model.AuditChangedSummary([]string{"cardProperties"}))
+}
+
+// getBoardWithStats returns a board along with the effective role of
+// the user, and its member and card counts. If the user isn't a member
+// of the board, it returns a not found error.
+func (s *SQLStore) getBoardWithStats(db sq.BaseRunner, boardID, userID string) (*model.BoardWithStats, error) {
+	memberCount := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "board_members AS mc").
+		Where("mc.board_id = b.id")
+	memberCountSQL, _, err := memberCount.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	cardCount := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "blocks AS cc").
+		Where("cc.board_id = b.id").
+		Where("cc.type = 'card'").
+		Where("cc.delete_at = 0").
+		Where("COALESCE(cc.archived_at, 0) = 0")
+	cardCountSQL, _, err := cardCount.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := boardFields("b.")
+	fields = append(fields,
+		"bm.scheme_admin",
+		"bm.scheme_editor",
+		"bm.scheme_commenter",
+		"bm.scheme_viewer",
+		"("+memberCountSQL+") AS member_count",
+		"("+cardCountSQL+") AS card_count",
+	)
+
+	query := s.getQueryBuilder(db).
+		Select(fields...).
+		From(s.tablePrefix + "boards AS b").
+		Join(s.tablePrefix + "board_members AS bm ON bm.board_id = b.id").
+		Where(sq.Eq{"b.id": boardID}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0}).
+		Where(sq.Eq{"bm.user_id": userID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardWithStats ERROR`, mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	if !rows.Next() {
+		return nil, model.NewErrNotFound("board ID=" + boardID + " for user ID=" + userID)
+	}
+
+	var member model.BoardMember
+	var boardWithStats model.BoardWithStats
+	board, err := s.scanBoard(rows,
+		&member.SchemeAdmin,
+		&member.SchemeEditor,
+		&member.SchemeCommenter,
+		&member.SchemeViewer,
+		&boardWithStats.MemberCount,
+		&boardWithStats.CardCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	member.MinimumRole = string(board.MinimumRole)
+	boardWithStats.Board = *board
+	boardWithStats.Role = member.EffectiveRole()
+
+	return &boardWithStats, nil
+}
+
+// validateBoardSchema checks the property values of every card of a board,
+// including archived ones, against the board's property definitions. It
+// doesn't modify anything.
+func (s *SQLStore) validateBoardSchema(db sq.BaseRunner, boardID string) (*model.SchemaReport, error) {
+	board, err := s.getBoard(db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := model.QueryBlocksOptions{
+		BoardID:         boardID,
+		BlockType:       model.TypeCard,
+		IncludeArchived: true,
+	}
+	cards, err := s.getBlocks(db, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.CheckCardsAgainstSchema(boardID, schema, cards), nil
+}
+
 func (s *SQLStore) getBoardsForUserAndTeam(db sq.BaseRunner, userID, teamID string, includePublicBoards bool) ([]*model.Board, error) {
+	opts := model.QueryBoardsOptions{
+		IncludePublicBoards: includePublicBoards,
+	}
+	return s.getBoardsForUserAndTeamWithOptions(db, userID, teamID, opts)
+}
+
+// getBoardsForUserAndTeamWithOptions returns the boards of a team that the
+// user is a member of, and optionally the open ones, filtered, sorted and
+// paginated as per the options. Templates are left out unless the options
+// include them.
+func (s *SQLStore) getBoardsForUserAndTeamWithOptions(db sq.BaseRunner, userID, teamID string, opts model.QueryBoardsOptions) ([]*model.Board, error) {
+	if err := opts.IsValid(); err != nil {
+		return nil, err
+	}
+
+	isMember := sq.Expr("EXISTS (SELECT 1 FROM "+s.tablePrefix+"board_members AS bm WHERE bm.board_id = b.id AND bm.user_id = ?)", userID)
+
 	query := s.getQueryBuilder(db).
 		Select(boardFields("b.")...).
-		Distinct().
 		From(s.tablePrefix + "boards as b").
-		LeftJoin(s.tablePrefix + "board_members as bm on b.id=bm.board_id").
 		Where(sq.Eq{"b.team_id": teamID}).
-		Where(sq.Eq{"b.is_template": false})
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0})
 
-	if includePublicBoards {
+	if !opts.IncludeTemplates {
+		query = query.Where(sq.Eq{"b.is_template": false})
+	}
+
+	if opts.Type != "" {
+		query = query.Where(sq.Eq{"b.type": opts.Type})
+	}
+
+	if opts.IncludePublicBoards {
 		query = query.Where(sq.Or{
 			sq.Eq{"b.type": model.BoardTypeOpen},
-			sq.Eq{"bm.user_id": userID},
+			isMember,
 		})
 	} else {
-		query = query.Where(sq.Or{
-			sq.Eq{"bm.user_id": userID},
-		})
+		query = query.Where(isMember)
 	}
 
+	query = ApplyBoardsQueryOptions(query, s.tablePrefix, opts)
+
 	rows, err := query.Query()
 	if err != nil {
 		s.logger.Error(`getBoardsForUserAndTeam ERROR`, mlog.Err(err))
@@ -271,15 +498,172 @@ func (s *SQLStore) getBoardsForUserAndTeam(db sq.BaseRunner, userID, teamID stri
 	}
 	defer s.CloseRows(rows)
 
+	boards, err := s.boardsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeFavorite {
+		if err := s.markFavoriteBoards(db, userID, teamID, boards); err != nil {
+			return nil, err
+		}
+	}
+
+	return boards, nil
+}
+
+// getBoardsAdministeredByUser returns the boards of a team where the
+// user has the admin role.
+func (s *SQLStore) getBoardsAdministeredByUser(db sq.BaseRunner, userID, teamID string) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("b.")...).
+		From(s.tablePrefix+"boards as b").
+		Join(s.tablePrefix+"board_members as bm on b.id = bm.board_id").
+		Where(sq.Eq{"bm.user_id": userID}).
+		Where(sq.Eq{"bm.scheme_admin": true}).
+		Where(sq.Eq{"b.team_id": teamID}).
+		Where(sq.Eq{"b.is_template": false}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0}).
+		OrderBy("b.create_at", "b.id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsAdministeredByUser ERROR`, mlog.String("userID", userID), mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
 	return s.boardsFromRows(rows)
 }
 
+// ApplyBoardsQueryOptions adds the sorting and pagination of the options
+// to a query selecting boards as `b`. Boards are sorted by ID when the
+// options don't set a sort, so the pages are stable across calls, and
+// AfterID then selects the page following that board. It is also used
+// by the mattermostauthlayer, so both list the boards the same way.
+func ApplyBoardsQueryOptions(query sq.SelectBuilder, tablePrefix string, opts model.QueryBoardsOptions) sq.SelectBuilder {
+	direction := " ASC"
+	if opts.SortDescending {
+		direction = " DESC"
+	}
+
+	switch opts.SortBy {
+	case model.BoardSortByTitle:
+		query = query.OrderBy("LOWER(b.title)"+direction, "b.id")
+	case model.BoardSortByCreated:
+		query = query.OrderBy("b.create_at"+direction, "b.id")
+	case model.BoardSortByLastActivity:
+		query = query.
+			LeftJoin("(SELECT board_id, MAX(update_at) AS last_activity FROM "+tablePrefix+"blocks GROUP BY board_id) AS la ON la.board_id = b.id").
+			OrderBy("COALESCE(la.last_activity, b.update_at)"+direction, "b.id")
+	default:
+		if opts.AfterID != "" {
+			if opts.SortDescending {
+				query = query.Where(sq.Lt{"b.id": opts.AfterID})
+			} else {
+				query = query.Where(sq.Gt{"b.id": opts.AfterID})
+			}
+		}
+		query = query.OrderBy("b.id" + direction)
+	}
+
+	if opts.Page != 0 {
+		query = query.Offset(uint64(opts.Page * opts.PerPage))
+	}
+
+	if opts.PerPage > 0 {
+		query = query.Limit(uint64(opts.PerPage))
+	}
+
+	return query
+}
+
+// getBoardsModifiedSince returns the boards of a team that the user
+// can access and that were updated after the since timestamp, ordered
+// by update_at. Deleted boards are returned as tombstones, with their
+// DeleteAt set, so clients can remove them.
+func (s *SQLStore) getBoardsModifiedSince(db sq.BaseRunner, teamID, userID string, since int64) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("b.")...).
+		Distinct().
+		From(s.tablePrefix + "boards as b").
+		LeftJoin(s.tablePrefix + "board_members as bm on b.id=bm.board_id").
+		Where(sq.Eq{"b.team_id": teamID}).
+		Where(sq.Eq{"b.is_template": false}).
+		Where(sq.Gt{"b.update_at": since}).
+		Where(sq.Or{
+			sq.Eq{"b.type": model.BoardTypeOpen},
+			sq.Eq{"bm.user_id": userID},
+		})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsModifiedSince ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	boards, err := s.boardsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// board members are kept after the board is deleted, so they
+	// can be used to check the access to the tombstones as well
+	tombstonesQuery := s.getQueryBuilder(db).
+		Select(boardHistoryFields()...).
+		From(s.tablePrefix + "boards_history").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"is_template": false}).
+		Where(sq.Gt{"delete_at": 0}).
+		Where(sq.Gt{"update_at": since}).
+		Where("id NOT IN (SELECT id FROM " + s.tablePrefix + "boards)").
+		Where(sq.Or{
+			sq.Eq{"type": model.BoardTypeOpen},
+			sq.Expr("id IN (SELECT board_id FROM "+s.tablePrefix+"board_members WHERE user_id = ?)", userID),
+		})
+
+	tombstoneRows, err := tombstonesQuery.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsModifiedSince tombstones ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(tombstoneRows)
+
+	tombstones, err := s.boardsFromRows(tombstoneRows)
+	if err != nil {
+		return nil, err
+	}
+
+	// a board can be deleted more than once if it was restored in
+	// between, so only its latest tombstone is kept
+	latestTombstones := map[string]*model.Board{}
+	for _, tombstone := range tombstones {
+		if latest, ok := latestTombstones[tombstone.ID]; !ok || tombstone.UpdateAt > latest.UpdateAt {
+			latestTombstones[tombstone.ID] = tombstone
+		}
+	}
+	for _, tombstone := range latestTombstones {
+		boards = append(boards, tombstone)
+	}
+
+	sort.Slice(boards, func(i, j int) bool {
+		if boards[i].UpdateAt == boards[j].UpdateAt {
+			return boards[i].ID < boards[j].ID
+		}
+		return boards[i].UpdateAt < boards[j].UpdateAt
+	})
+
+	return boards, nil
+}
+
 func (s *SQLStore) getBoardsInTeamByIds(db sq.BaseRunner, boardIDs []string, teamID string) ([]*model.Board, error) {
 	query := s.getQueryBuilder(db).
 		Select(boardFields("b.")...).
 		From(s.tablePrefix + "boards as b").
 		Where(sq.Eq{"b.team_id": teamID}).
-		Where(sq.Eq{"b.id": boardIDs})
+		Where(sq.Eq{"b.id": boardIDs}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0})
 
 	rows, err := query.Query()
 	if err != nil {
@@ -304,7 +688,28 @@ func (s *SQLStore) getBoardsInTeamByIds(db sq.BaseRunner, boardIDs []string, tea
 	return boards, nil
 }
 
+// insertBoard inserts the board, or updates it if it already exists,
+// and records the change in the audit trail.
 func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID string) (*model.Board, error) {
+	board, created, err := s.upsertBoard(db, board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := model.AuditActionPatchBoard
+	if created {
+		action = model.AuditActionCreateBoard
+	}
+	if err := s.auditChange(db, board.ID, userID, action, board.ID, ""); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+// upsertBoard inserts the board, or updates it if it already exists, and
+// writes its history. It returns whether the board was created.
+func (s *SQLStore) upsertBoard(db sq.BaseRunner, board *model.Board, userID string) (*model.Board, bool, error) {
 	// Generate tracking IDs for in-built templates
 	if board.IsTemplate && board.TeamID == model.GlobalTeamID {
 		//nolint:gosec
@@ -320,7 +725,7 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 			mlog.String("board.Properties", fmt.Sprintf("%v", board.Properties)),
 			mlog.Err(err),
 		)
-		return nil, err
+		return nil, false, err
 	}
 
 	cardPropertiesBytes, err := s.MarshalJSONB(board.CardProperties)
@@ -331,12 +736,12 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 			mlog.String("board.CardProperties", fmt.Sprintf("%v", board.CardProperties)),
 			mlog.Err(err),
 		)
-		return nil, err
+		return nil, false, err
 	}
 
 	existingBoard, err := s.getBoard(db, board.ID)
 	if err != nil && !model.IsErrNotFound(err) {
-		return nil, fmt.Errorf("insertBoard error occurred while fetching existing board %s: %w", board.ID, err)
+		return nil, false, fmt.Errorf("insertBoard error occurred while fetching existing board %s: %w", board.ID, err)
 	}
 
 	insertQuery := s.getQueryBuilder(db).Insert("").
@@ -365,9 +770,17 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 		"create_at":        board.CreateAt,
 		"update_at":        board.UpdateAt,
 		"delete_at":        board.DeleteAt,
+		"creation_source":  board.CreationSource,
+		"source_id":        board.SourceID,
 	}
 
 	if existingBoard != nil {
+		// the creation source is set only when the board is created
+		board.CreationSource = existingBoard.CreationSource
+		board.SourceID = existingBoard.SourceID
+		insertQueryValues["creation_source"] = board.CreationSource
+		insertQueryValues["source_id"] = board.SourceID
+
 		query := s.getQueryBuilder(db).Update(s.tablePrefix+"boards").
 			Where(sq.Eq{"id": board.ID}).
 			Set("modified_by", board.ModifiedBy).
@@ -387,7 +800,7 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 
 		if _, err := query.Exec(); err != nil {
 			s.logger.Error(`InsertBoard error occurred while updating existing board`, mlog.String("boardID", board.ID), mlog.Err(err))
-			return nil, fmt.Errorf("insertBoard error occurred while updating existing board %s: %w", board.ID, err)
+			return nil, false, fmt.Errorf("insertBoard error occurred while updating existing board %s: %w", board.ID, err)
 		}
 	} else {
 		board.CreatedBy = userID
@@ -395,9 +808,14 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 		insertQueryValues["created_by"] = board.CreatedBy
 		insertQueryValues["create_at"] = board.CreateAt
 
+		if board.CreationSource == "" {
+			board.CreationSource = model.BoardCreationSourceBlank
+			insertQueryValues["creation_source"] = board.CreationSource
+		}
+
 		query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "boards")
 		if _, err := query.Exec(); err != nil {
-			return nil, fmt.Errorf("insertBoard error occurred while inserting board %s: %w", board.ID, err)
+			return nil, false, fmt.Errorf("insertBoard error occurred while inserting board %s: %w", board.ID, duplicateError(err, "board"))
 		}
 	}
 
@@ -405,20 +823,56 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 	query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "boards_history")
 	if _, err := query.Exec(); err != nil {
 		s.logger.Error("failed to insert board history", mlog.String("board_id", board.ID), mlog.Err(err))
-		return nil, fmt.Errorf("failed to insert board %s history: %w", board.ID, err)
+		return nil, false, fmt.Errorf("failed to insert board %s history: %w", board.ID, err)
 	}
 
-	return board, nil
+	return board, existingBoard == nil, nil
 }
 
+// patchBoard applies a patch to a board. If the patch has an expected
+// update time, the board row is locked before it is read, and a
+// conflict error is returned if it has been updated since.
 func (s *SQLStore) patchBoard(db sq.BaseRunner, boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+	if boardPatch.ExpectedUpdateAt != 0 {
+		if s.dbType == model.SqliteDBType {
+			s.boardPatchMutex.Lock()
+			defer s.boardPatchMutex.Unlock()
+		} else {
+			lockQuery := s.getQueryBuilder(db).
+				Select("id").
+				From(s.tablePrefix + "boards").
+				Where(sq.Eq{"id": boardID}).
+				Suffix("FOR UPDATE")
+
+			rows, err := lockQuery.Query()
+			if err != nil {
+				s.logger.Error("patchBoard lock ERROR", mlog.Err(err))
+				return nil, err
+			}
+			s.CloseRows(rows)
+		}
+	}
+
 	existingBoard, err := s.getBoard(db, boardID)
 	if err != nil {
 		return nil, err
 	}
 
+	if boardPatch.ExpectedUpdateAt != 0 && existingBoard.UpdateAt != boardPatch.ExpectedUpdateAt {
+		return nil, model.NewErrConflict("board ID="+boardID, boardPatch.ExpectedUpdateAt, existingBoard.UpdateAt)
+	}
+
 	board := boardPatch.Patch(existingBoard)
-	return s.insertBoard(db, board, userID)
+	board, _, err = s.upsertBoard(db, board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditChange(db, boardID, userID, model.AuditActionPatchBoard, boardID, model.AuditChangedSummary(boardPatch.ChangedFields())); err != nil {
+		return nil, err
+	}
+
+	return board, nil
 }
 
 func (s *SQLStore) deleteBoard(db sq.BaseRunner, boardID, userID string) error {
@@ -457,27 +911,227 @@ func (s *SQLStore) deleteBoard(db sq.BaseRunner, boardID, userID string) error {
 		"create_at":        board.CreateAt,
 		"update_at":        now,
 		"delete_at":        now,
+		"creation_source":  board.CreationSource,
+		"source_id":        board.SourceID,
+	}
+
+	// writing board history
+	insertQuery := s.getQueryBuilder(db).Insert("").
+		Columns(boardHistoryFields()...)
+
+	query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "boards_history")
+	if _, err := query.Exec(); err != nil {
+		return err
+	}
+
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "boards").
+		Where(sq.Eq{"id": boardID}).
+		Where(sq.Eq{"COALESCE(team_id, '0')": board.TeamID})
+
+	if _, err := deleteQuery.Exec(); err != nil {
+		return err
+	}
+
+	if err := s.deleteSubscriptionsForBlock(db, boardID); err != nil {
+		return err
+	}
+
+	if err := s.deleteNotificationHintsForBlock(db, boardID); err != nil {
+		return err
+	}
+
+	if err := s.deleteWebhooksForBoard(db, boardID); err != nil {
+		return err
+	}
+
+	if err := s.deleteBoardCustomRolesForBoard(db, boardID); err != nil {
+		return err
+	}
+
+	if err := s.deleteShareTokensForBoard(db, boardID); err != nil {
+		return err
+	}
+
+	if err := s.deleteBoardSnapshot(db, boardID); err != nil {
+		return err
+	}
+
+	blocks, err := s.getBlocks(db, model.QueryBlocksOptions{BoardID: boardID, IncludeArchived: true})
+	if err != nil {
+		return err
+	}
+
+	if err := s.setFileInfosDeleteAt(db, fileInfoIDsFromBlocks(blocks), now); err != nil {
+		return err
+	}
+
+	return s.auditChange(db, boardID, userID, model.AuditActionDeleteBoard, boardID, "")
+}
+
+// archiveBoard soft deletes a board by setting its delete_at, keeping
+// its rows until the board is restored or purged.
+func (s *SQLStore) archiveBoard(db sq.BaseRunner, boardID, userID string) error {
+	board, err := s.getBoard(db, boardID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.setBoardDeleteAt(db, board, userID, utils.GetMillis()); err != nil {
+		return err
+	}
+
+	return s.auditChange(db, boardID, userID, model.AuditActionArchiveBoard, boardID, "")
+}
+
+// getArchivedBoards returns the archived boards of a team, most recently
+// archived first.
+func (s *SQLStore) getArchivedBoards(db sq.BaseRunner, teamID string) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("")...).
+		From(s.tablePrefix+"boards").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Gt{"delete_at": 0}).
+		OrderBy("delete_at DESC", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getArchivedBoards ERROR`, mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
+
+// restoreBoard brings an archived board back.
+func (s *SQLStore) restoreBoard(db sq.BaseRunner, boardID, userID string) error {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("")...).
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"id": boardID}).
+		Where(sq.Gt{"delete_at": 0})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`restoreBoard ERROR`, mlog.String("boardID", boardID), mlog.Err(err))
+		return err
+	}
+	defer s.CloseRows(rows)
+
+	boards, err := s.boardsFromRows(rows)
+	if err != nil {
+		return err
+	}
+	if len(boards) == 0 {
+		return model.NewErrNotFound("archived board ID=" + boardID)
+	}
+
+	if err := s.setBoardDeleteAt(db, boards[0], userID, 0); err != nil {
+		return err
+	}
+
+	return s.auditChange(db, boardID, userID, model.AuditActionRestoreBoard, boardID, "")
+}
+
+// setBoardDeleteAt updates the delete_at of a board and records the
+// change in the board history.
+func (s *SQLStore) setBoardDeleteAt(db sq.BaseRunner, board *model.Board, userID string, deleteAt int64) error {
+	propertiesBytes, err := s.MarshalJSONB(board.Properties)
+	if err != nil {
+		return err
+	}
+	cardPropertiesBytes, err := s.MarshalJSONB(board.CardProperties)
+	if err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"boards").
+		Set("modified_by", userID).
+		Set("update_at", now).
+		Set("delete_at", deleteAt).
+		Where(sq.Eq{"id": board.ID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("setBoardDeleteAt error", mlog.String("boardID", board.ID), mlog.Err(err))
+		return err
+	}
+
+	historyQuery := s.getQueryBuilder(db).
+		Insert(s.tablePrefix + "boards_history").
+		SetMap(map[string]interface{}{
+			"id":               board.ID,
+			"team_id":          board.TeamID,
+			"channel_id":       board.ChannelID,
+			"created_by":       board.CreatedBy,
+			"modified_by":      userID,
+			"type":             board.Type,
+			"minimum_role":     board.MinimumRole,
+			"title":            board.Title,
+			"description":      board.Description,
+			"icon":             board.Icon,
+			"show_description": board.ShowDescription,
+			"is_template":      board.IsTemplate,
+			"template_version": board.TemplateVersion,
+			"properties":       propertiesBytes,
+			"card_properties":  cardPropertiesBytes,
+			"create_at":        board.CreateAt,
+			"update_at":        now,
+			"delete_at":        deleteAt,
+			"creation_source":  board.CreationSource,
+			"source_id":        board.SourceID,
+		})
+
+	if _, err := historyQuery.Exec(); err != nil {
+		s.logger.Error("failed to insert board history", mlog.String("board_id", board.ID), mlog.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+// purgeArchivedBoards permanently removes the boards archived before
+// olderThan along with all their data, returning how many were purged.
+func (s *SQLStore) purgeArchivedBoards(db sq.BaseRunner, olderThan time.Time) (int, error) {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "boards").
+		Where(sq.Gt{"delete_at": 0}).
+		Where(sq.Lt{"delete_at": olderThan.UnixMilli()})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`purgeArchivedBoards ERROR`, mlog.Err(err))
+		return 0, err
+	}
+	boardIDs, err := idsFromRows(rows)
+	s.CloseRows(rows)
+	if err != nil {
+		return 0, err
 	}
 
-	// writing board history
-	insertQuery := s.getQueryBuilder(db).Insert("").
-		Columns(boardHistoryFields()...)
+	for start := 0; start < len(boardIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(boardIDs) {
+			end = len(boardIDs)
+		}
 
-	query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "boards_history")
-	if _, err := query.Exec(); err != nil {
-		return err
-	}
+		chunk := boardIDs[start:end]
 
-	deleteQuery := s.getQueryBuilder(db).
-		Delete(s.tablePrefix + "boards").
-		Where(sq.Eq{"id": boardID}).
-		Where(sq.Eq{"COALESCE(team_id, '0')": board.TeamID})
+		if err := s.deleteBoardSubscriptionsAndHints(db, chunk); err != nil {
+			return 0, err
+		}
 
-	if _, err := deleteQuery.Exec(); err != nil {
-		return err
+		for _, table := range boardDataTables {
+			if _, err := s.genericRetentionPoliciesDeletion(db, table, chunk, 0); err != nil {
+				return 0, err
+			}
+		}
 	}
 
-	return nil
+	return len(boardIDs), nil
 }
 
 func (s *SQLStore) insertBoardWithAdmin(db sq.BaseRunner, board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
@@ -493,7 +1147,7 @@ func (s *SQLStore) insertBoardWithAdmin(db sq.BaseRunner, board *model.Board, us
 		SchemeEditor: true,
 	}
 
-	nbm, err := s.saveMember(db, bm)
+	nbm, err := s.saveMember(db, bm, userID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("cannot save member %s while inserting board %s: %w", bm.UserID, bm.BoardID, err)
 	}
@@ -501,11 +1155,59 @@ func (s *SQLStore) insertBoardWithAdmin(db sq.BaseRunner, board *model.Board, us
 	return newBoard, nbm, nil
 }
 
-func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember) (*model.BoardMember, error) {
+// createBoardComplete inserts a board with its creator as admin, adds
+// the extra members and places the board in the creator's category.
+// An empty categoryID leaves the board uncategorized.
+func (s *SQLStore) createBoardComplete(db sq.BaseRunner, board *model.Board, creatorID string, extraMembers []*model.BoardMember, categoryID string) (*model.Board, []*model.BoardMember, error) {
+	if categoryID != "" {
+		category, err := s.getCategory(db, categoryID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if category.UserID != creatorID {
+			return nil, nil, model.ErrCategoryPermissionDenied
+		}
+
+		if category.TeamID != board.TeamID {
+			return nil, nil, model.NewErrBadRequest("category and board must belong to the same team")
+		}
+	}
+
+	newBoard, adminMember, err := s.insertBoardWithAdmin(db, board, creatorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := []*model.BoardMember{adminMember}
+	for _, member := range extraMembers {
+		if member.UserID == creatorID {
+			// the creator is already an admin of the board
+			continue
+		}
+
+		member.BoardID = newBoard.ID
+		newMember, err := s.saveMember(db, member, creatorID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot save member %s while creating board %s: %w", member.UserID, newBoard.ID, err)
+		}
+		members = append(members, newMember)
+	}
+
+	if categoryID != "" {
+		if err := s.addUpdateCategoryBoard(db, creatorID, categoryID, newBoard.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return newBoard, members, nil
+}
+
+func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember, modifiedBy string) (*model.BoardMember, error) {
 	queryValues := map[string]interface{}{
 		"board_id":         bm.BoardID,
 		"user_id":          bm.UserID,
-		"roles":            "",
+		"roles":            string(bm.SchemeRole()),
 		"scheme_admin":     bm.SchemeAdmin,
 		"scheme_editor":    bm.SchemeEditor,
 		"scheme_commenter": bm.SchemeCommenter,
@@ -523,12 +1225,12 @@ func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember) (*model.B
 
 	if s.dbType == model.MysqlDBType {
 		query = query.Suffix(
-			"ON DUPLICATE KEY UPDATE scheme_admin = ?, scheme_editor = ?, scheme_commenter = ?, scheme_viewer = ?",
-			bm.SchemeAdmin, bm.SchemeEditor, bm.SchemeCommenter, bm.SchemeViewer)
+			"ON DUPLICATE KEY UPDATE roles = ?, scheme_admin = ?, scheme_editor = ?, scheme_commenter = ?, scheme_viewer = ?",
+			string(bm.SchemeRole()), bm.SchemeAdmin, bm.SchemeEditor, bm.SchemeCommenter, bm.SchemeViewer)
 	} else {
 		query = query.Suffix(
 			`ON CONFLICT (board_id, user_id)
-             DO UPDATE SET scheme_admin = EXCLUDED.scheme_admin, scheme_editor = EXCLUDED.scheme_editor,
+             DO UPDATE SET roles = EXCLUDED.roles, scheme_admin = EXCLUDED.scheme_admin, scheme_editor = EXCLUDED.scheme_editor,
 			   scheme_commenter = EXCLUDED.scheme_commenter, scheme_viewer = EXCLUDED.scheme_viewer`,
 		)
 	}
@@ -548,10 +1250,279 @@ func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember) (*model.B
 		}
 	}
 
+	if err := s.auditChange(db, bm.BoardID, modifiedBy, model.AuditActionSaveMember, bm.UserID, model.AuditMemberSummary(bm)); err != nil {
+		return nil, err
+	}
+
 	return bm, nil
 }
 
-func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID string) error {
+// saveMembersBatchSize is the number of members upserted by each
+// statement of saveMembers, which keeps the statements within the SQLite
+// limit of 999 variables.
+const saveMembersBatchSize = 100
+
+// saveMembers upserts a batch of members with multi-row statements and
+// returns them as stored. When the batch holds the same member more than
+// once, the last one wins. If a member references a board that doesn't
+// exist nothing is saved, and an ErrMemberBoardNotFound with the first
+// such board is returned.
+func (s *SQLStore) saveMembers(db sq.BaseRunner, members []*model.BoardMember, modifiedBy string) ([]*model.BoardMember, error) {
+	if len(members) == 0 {
+		return []*model.BoardMember{}, nil
+	}
+
+	memberKey := func(boardID, userID string) string {
+		return boardID + "/" + userID
+	}
+
+	// de-duplicate the batch, keeping the position of the first
+	// occurrence and the values of the last one
+	index := map[string]int{}
+	batch := []*model.BoardMember{}
+	boardIDs := []string{}
+	userIDs := []string{}
+	seenBoards := map[string]bool{}
+	seenUsers := map[string]bool{}
+	for _, bm := range members {
+		key := memberKey(bm.BoardID, bm.UserID)
+		if i, ok := index[key]; ok {
+			batch[i] = bm
+			continue
+		}
+		index[key] = len(batch)
+		batch = append(batch, bm)
+
+		if !seenBoards[bm.BoardID] {
+			seenBoards[bm.BoardID] = true
+			boardIDs = append(boardIDs, bm.BoardID)
+		}
+		if !seenUsers[bm.UserID] {
+			seenUsers[bm.UserID] = true
+			userIDs = append(userIDs, bm.UserID)
+		}
+	}
+
+	existingBoards := map[string]bool{}
+	for start := 0; start < len(boardIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(boardIDs) {
+			end = len(boardIDs)
+		}
+
+		query := s.getQueryBuilder(db).
+			Select("id").
+			From(s.tablePrefix + "boards").
+			Where(sq.Eq{"id": boardIDs[start:end]})
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error(`saveMembers ERROR`, mlog.Err(err))
+			return nil, err
+		}
+		ids, err := idsFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			existingBoards[id] = true
+		}
+	}
+	for _, boardID := range boardIDs {
+		if !existingBoards[boardID] {
+			return nil, model.NewErrMemberBoardNotFound(boardID)
+		}
+	}
+
+	// the members that already exist don't get a history entry
+	oldMembers, err := s.getMembersForBoardsAndUsers(db, boardIDs, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	existingMembers := map[string]bool{}
+	for _, bm := range oldMembers {
+		existingMembers[memberKey(bm.BoardID, bm.UserID)] = true
+	}
+
+	for start := 0; start < len(batch); start += saveMembersBatchSize {
+		end := start + saveMembersBatchSize
+		if end > len(batch) {
+			end = len(batch)
+		}
+
+		query := s.getQueryBuilder(db).
+			Insert(s.tablePrefix+"board_members").
+			Columns("board_id", "user_id", "roles", "scheme_admin", "scheme_editor", "scheme_commenter", "scheme_viewer")
+
+		history := s.getQueryBuilder(db).
+			Insert(s.tablePrefix+"board_members_history").
+			Columns("board_id", "user_id", "action")
+		newMembers := 0
+
+		for _, bm := range batch[start:end] {
+			query = query.Values(bm.BoardID, bm.UserID, string(bm.SchemeRole()), bm.SchemeAdmin, bm.SchemeEditor, bm.SchemeCommenter, bm.SchemeViewer)
+
+			if !existingMembers[memberKey(bm.BoardID, bm.UserID)] {
+				history = history.Values(bm.BoardID, bm.UserID, "created")
+				newMembers++
+			}
+		}
+
+		if s.dbType == model.MysqlDBType {
+			query = query.Suffix(
+				`ON DUPLICATE KEY UPDATE roles = VALUES(roles), scheme_admin = VALUES(scheme_admin), scheme_editor = VALUES(scheme_editor),
+				   scheme_commenter = VALUES(scheme_commenter), scheme_viewer = VALUES(scheme_viewer)`,
+			)
+		} else {
+			query = query.Suffix(
+				`ON CONFLICT (board_id, user_id)
+				 DO UPDATE SET roles = EXCLUDED.roles, scheme_admin = EXCLUDED.scheme_admin, scheme_editor = EXCLUDED.scheme_editor,
+				   scheme_commenter = EXCLUDED.scheme_commenter, scheme_viewer = EXCLUDED.scheme_viewer`,
+			)
+		}
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error(`saveMembers ERROR`, mlog.Err(err))
+			return nil, err
+		}
+
+		if newMembers > 0 {
+			if _, err := history.Exec(); err != nil {
+				s.logger.Error(`saveMembers history ERROR`, mlog.Err(err))
+				return nil, err
+			}
+		}
+	}
+
+	stored, err := s.getMembersForBoardsAndUsers(db, boardIDs, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.BoardMember, len(batch))
+	for _, bm := range stored {
+		if i, ok := index[memberKey(bm.BoardID, bm.UserID)]; ok {
+			result[i] = bm
+		}
+	}
+
+	for _, bm := range result {
+		if err := s.auditChange(db, bm.BoardID, modifiedBy, model.AuditActionSaveMember, bm.UserID, model.AuditMemberSummary(bm)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// getMembersForBoardsAndUsers returns the members of any of the boards
+// that are any of the users.
+func (s *SQLStore) getMembersForBoardsAndUsers(db sq.BaseRunner, boardIDs, userIDs []string) ([]*model.BoardMember, error) {
+	members := []*model.BoardMember{}
+	for start := 0; start < len(boardIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(boardIDs) {
+			end = len(boardIDs)
+		}
+
+		for userStart := 0; userStart < len(userIDs); userStart += maxBlockIDsPerQuery {
+			userEnd := userStart + maxBlockIDsPerQuery
+			if userEnd > len(userIDs) {
+				userEnd = len(userIDs)
+			}
+
+			query := s.getQueryBuilder(db).
+				Select(boardMemberFields...).
+				From(s.tablePrefix + "board_members AS BM").
+				LeftJoin(s.tablePrefix + "boards AS B ON B.id=BM.board_id").
+				Where(sq.Eq{"BM.board_id": boardIDs[start:end]}).
+				Where(sq.Eq{"BM.user_id": userIDs[userStart:userEnd]})
+
+			rows, err := query.Query()
+			if err != nil {
+				s.logger.Error(`getMembersForBoardsAndUsers ERROR`, mlog.Err(err))
+				return nil, err
+			}
+
+			chunk, err := s.boardMembersFromRows(rows)
+			s.CloseRows(rows)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, chunk...)
+		}
+	}
+	return members, nil
+}
+
+// saveMemberWithLimit saves the member only if the board has less than
+// maxMembers members, or if the member already exists. A maxMembers of
+// zero means no limit.
+func (s *SQLStore) saveMemberWithLimit(db sq.BaseRunner, bm *model.BoardMember, maxMembers int, modifiedBy string) (*model.BoardMember, error) {
+	if maxMembers <= 0 {
+		return s.saveMember(db, bm, modifiedBy)
+	}
+
+	// lock the board row so concurrent additions to the same board
+	// wait for this transaction before counting the members
+	lockQuery := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"id": bm.BoardID})
+	if s.dbType == model.SqliteDBType {
+		s.memberLimitMutex.Lock()
+		defer s.memberLimitMutex.Unlock()
+	} else {
+		lockQuery = lockQuery.Suffix("FOR UPDATE")
+	}
+
+	var lockedID string
+	if err := lockQuery.QueryRow().Scan(&lockedID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrNotFound("board ID=" + bm.BoardID)
+		}
+		s.logger.Error("saveMemberWithLimit lock ERROR", mlog.String("boardID", bm.BoardID), mlog.Err(err))
+		return nil, err
+	}
+
+	_, err := s.getMemberForBoard(db, bm.BoardID, bm.UserID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	// existing members can always be updated
+	if model.IsErrNotFound(err) {
+		count, err := s.getBoardMemberCount(db, bm.BoardID)
+		if err != nil {
+			return nil, err
+		}
+
+		if count >= maxMembers {
+			return nil, model.ErrBoardMemberLimit
+		}
+	}
+
+	return s.saveMember(db, bm, modifiedBy)
+}
+
+func (s *SQLStore) getBoardMemberCount(db sq.BaseRunner, boardID string) (int, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "board_members").
+		Where(sq.Eq{"board_id": boardID})
+
+	var count int
+	if err := query.QueryRow().Scan(&count); err != nil {
+		s.logger.Error("getBoardMemberCount ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// deleteMember removes a user from a board and returns the number of
+// memberships removed, which is zero if the user isn't a member.
+func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID, modifiedBy string) (int64, error) {
 	deleteQuery := s.getQueryBuilder(db).
 		Delete(s.tablePrefix + "board_members").
 		Where(sq.Eq{"board_id": boardID}).
@@ -559,12 +1530,12 @@ func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID string) error
 
 	result, err := deleteQuery.Exec()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	if rowsAffected > 0 {
@@ -574,11 +1545,137 @@ func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID string) error
 			Values(boardID, userID, "deleted")
 
 		if _, err := addToMembersHistory.Exec(); err != nil {
-			return err
+			return 0, err
+		}
+
+		if err := s.auditChange(db, boardID, modifiedBy, model.AuditActionDeleteMember, userID, ""); err != nil {
+			return 0, err
 		}
 	}
 
-	return nil
+	return rowsAffected, nil
+}
+
+// deleteMembers removes several users from a board along with their
+// subscriptions to the board and its blocks, and returns the number of
+// memberships removed. Every user must be a member of the board, and the
+// board must keep at least one admin, otherwise nothing is removed.
+func (s *SQLStore) deleteMembers(db sq.BaseRunner, boardID string, userIDs []string, modifiedBy string) (int, error) {
+	toRemove := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		toRemove[userID] = true
+	}
+	if len(toRemove) == 0 {
+		return 0, nil
+	}
+
+	members, err := s.getMembersForBoard(db, boardID)
+	if err != nil {
+		return 0, err
+	}
+
+	found := 0
+	removesAdmin := false
+	remainingAdmin := false
+	for _, member := range members {
+		if !toRemove[member.UserID] {
+			remainingAdmin = remainingAdmin || member.SchemeAdmin
+			continue
+		}
+		found++
+		removesAdmin = removesAdmin || member.SchemeAdmin
+	}
+
+	// validate everything before removing anything, as the removal is
+	// not always run in a transaction
+	if found != len(toRemove) {
+		return 0, model.NewErrNotFound(fmt.Sprintf("board members BoardID=%s", boardID))
+	}
+	if removesAdmin && !remainingAdmin {
+		return 0, model.ErrBoardMemberIsLastAdmin
+	}
+
+	removedIDs := make([]string, 0, len(toRemove))
+	for userID := range toRemove {
+		if _, err := s.deleteMember(db, boardID, userID, modifiedBy); err != nil {
+			return 0, err
+		}
+		removedIDs = append(removedIDs, userID)
+	}
+
+	deleteSubscriptions := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"subscriptions").
+		Set("delete_at", utils.GetMillis()).
+		Where(sq.Eq{"subscriber_id": removedIDs}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(sq.Or{
+			sq.Eq{"block_id": boardID},
+			sq.Expr("block_id IN (SELECT id FROM "+s.tablePrefix+"blocks WHERE board_id = ?)", boardID),
+		})
+
+	if _, err := deleteSubscriptions.Exec(); err != nil {
+		s.logger.Error("Cannot delete the subscriptions of removed board members",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return 0, err
+	}
+
+	return len(removedIDs), nil
+}
+
+// updateMemberRole changes the role of an existing board member, refusing
+// to take the admin role away from the last admin of the board.
+func (s *SQLStore) updateMemberRole(db sq.BaseRunner, boardID, userID, role, modifiedBy string) error {
+	if !model.IsBoardMemberRoleValid(model.BoardRole(role)) {
+		return model.NewErrBadRequest(fmt.Sprintf("invalid board member role %q", role))
+	}
+
+	members, err := s.getMembersForBoard(db, boardID)
+	if err != nil {
+		return err
+	}
+
+	var member *model.BoardMember
+	adminCount := 0
+	for _, m := range members {
+		if m.UserID == userID {
+			member = m
+		}
+		if m.SchemeAdmin {
+			adminCount++
+		}
+	}
+
+	if member == nil {
+		return model.NewErrNotFound(fmt.Sprintf("board member BoardID=%s UserID=%s", boardID, userID))
+	}
+	if member.SchemeAdmin && model.BoardRole(role) != model.BoardRoleAdmin && adminCount == 1 {
+		return model.ErrBoardMemberIsLastAdminRole
+	}
+
+	member.SetRole(model.BoardRole(role))
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_members").
+		Set("roles", member.Roles).
+		Set("scheme_admin", member.SchemeAdmin).
+		Set("scheme_editor", member.SchemeEditor).
+		Set("scheme_commenter", member.SchemeCommenter).
+		Set("scheme_viewer", member.SchemeViewer).
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"user_id": userID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("updateMemberRole error",
+			mlog.String("board_id", boardID),
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	return s.auditChange(db, boardID, modifiedBy, model.AuditActionSaveMember, userID, model.AuditMemberSummary(member))
 }
 
 func (s *SQLStore) getMemberForBoard(db sq.BaseRunner, boardID, userID string) (*model.BoardMember, error) {
@@ -648,6 +1745,134 @@ func (s *SQLStore) getMembersForBoard(db sq.BaseRunner, boardID string) ([]*mode
 	return s.boardMembersFromRows(rows)
 }
 
+// getMembersForBoardWithOptions returns a page of the members of a board
+// sorted by user ID, and whether there are more members after it.
+func (s *SQLStore) getMembersForBoardWithOptions(db sq.BaseRunner, boardID string, opts model.QueryBoardMembersOptions) ([]*model.BoardMember, bool, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardMemberFields...).
+		From(s.tablePrefix + "board_members AS BM").
+		LeftJoin(s.tablePrefix + "boards AS B ON B.id=BM.board_id").
+		Where(sq.Eq{"BM.board_id": boardID}).
+		OrderBy("BM.user_id")
+
+	if opts.AfterUserID != "" {
+		query = query.Where(sq.Gt{"BM.user_id": opts.AfterUserID})
+	}
+
+	// one more member is fetched to know if there is a next page
+	if opts.PerPage > 0 {
+		query = query.Limit(uint64(opts.PerPage + 1))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getMembersForBoardWithOptions ERROR`, mlog.Err(err))
+		return nil, false, err
+	}
+	defer s.CloseRows(rows)
+
+	members, err := s.boardMembersFromRows(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := false
+	if opts.PerPage > 0 && len(members) > opts.PerPage {
+		members = members[:opts.PerPage]
+		hasMore = true
+	}
+
+	return members, hasMore, nil
+}
+
+// updateMemberLastViewed sets when the member last opened the board.
+func (s *SQLStore) updateMemberLastViewed(db sq.BaseRunner, boardID, userID string, viewedAt int64) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_members").
+		Set("last_viewed_at", viewedAt).
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"user_id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error(`updateMemberLastViewed ERROR`, mlog.String("boardID", boardID), mlog.String("userID", userID), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound(fmt.Sprintf("board member BoardID=%s UserID=%s", boardID, userID))
+	}
+
+	return nil
+}
+
+// getRecentlyViewedBoards returns the boards of a team the user is a
+// member of, most recently viewed first. Boards the user never opened
+// come last.
+func (s *SQLStore) getRecentlyViewedBoards(db sq.BaseRunner, userID, teamID string, limit int) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("b.")...).
+		From(s.tablePrefix+"boards as b").
+		Join(s.tablePrefix+"board_members as bm on b.id = bm.board_id").
+		Where(sq.Eq{"bm.user_id": userID}).
+		Where(sq.Eq{"b.team_id": teamID}).
+		Where(sq.Eq{"b.is_template": false}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0}).
+		OrderBy(
+			"CASE WHEN COALESCE(bm.last_viewed_at, 0) = 0 THEN 1 ELSE 0 END",
+			"bm.last_viewed_at DESC",
+			"b.id",
+		)
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getRecentlyViewedBoards ERROR`, mlog.String("userID", userID), mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
+
+// titleSearchCondition returns a case and accent insensitive match
+// of the word against the column:
+//   - Postgres uses unaccent and ILIKE if the unaccent extension is
+//     installed. Otherwise it matches the word as is and without
+//     accents using ILIKE, so only accented words match unaccented
+//     titles.
+//   - MySQL uses an accent and case insensitive collation.
+//   - SQLite matches the word as is and without accents against the
+//     lower-cased title, so "café" matches "Cafe", but "cafe" doesn't
+//     match "Café", as SQLite has no built-in way to strip the accents
+//     of the stored titles, and its lower function only supports ASCII.
+func (s *SQLStore) titleSearchCondition(column, word string) sq.Sqlizer {
+	switch s.dbType {
+	case model.PostgresDBType:
+		if s.hasUnaccent {
+			return sq.Expr("unaccent("+column+") ILIKE unaccent(?)", "%"+word+"%")
+		}
+		return sq.Or{
+			sq.ILike{column: "%" + word + "%"},
+			sq.ILike{column: "%" + utils.NormalizeSearchTerm(word) + "%"},
+		}
+	case model.MysqlDBType:
+		return sq.Expr("CONVERT("+column+" USING utf8mb4) COLLATE utf8mb4_general_ci LIKE ?", "%"+word+"%")
+	default:
+		return sq.Or{
+			sq.Like{"lower(" + column + ")": "%" + strings.ToLower(word) + "%"},
+			sq.Like{"lower(" + column + ")": "%" + utils.NormalizeSearchTerm(word) + "%"},
+		}
+	}
+}
+
 // searchBoardsForUser returns all boards that match with the
 // term that are either private and which the user is a member of, or
 // they're open, regardless of the user membership.
@@ -658,7 +1883,8 @@ func (s *SQLStore) searchBoardsForUser(db sq.BaseRunner, term, userID string, in
 		Distinct().
 		From(s.tablePrefix + "boards as b").
 		LeftJoin(s.tablePrefix + "board_members as bm on b.id=bm.board_id").
-		Where(sq.Eq{"b.is_template": false})
+		Where(sq.Eq{"b.is_template": false}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0})
 
 	if includePublicBoards {
 		query = query.Where(sq.Or{
@@ -681,7 +1907,7 @@ func (s *SQLStore) searchBoardsForUser(db sq.BaseRunner, term, userID string, in
 		conditions := sq.And{}
 
 		for _, word := range strings.Split(strings.TrimSpace(term), " ") {
-			conditions = append(conditions, sq.Like{"lower(b.title)": "%" + strings.ToLower(word) + "%"})
+			conditions = append(conditions, s.titleSearchCondition("b.title", word))
 		}
 
 		query = query.Where(conditions)
@@ -709,6 +1935,7 @@ func (s *SQLStore) searchBoardsForUserInTeam(db sq.BaseRunner, teamID, term, use
 		LeftJoin(s.tablePrefix + "board_members as bm on b.id=bm.board_id").
 		Where(sq.Eq{"b.is_template": false}).
 		Where(sq.Eq{"b.team_id": teamID}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0}).
 		Where(sq.Or{
 			sq.Eq{"b.type": model.BoardTypeOpen},
 			sq.And{
@@ -727,7 +1954,7 @@ func (s *SQLStore) searchBoardsForUserInTeam(db sq.BaseRunner, teamID, term, use
 		conditions := sq.And{}
 
 		for _, word := range strings.Split(strings.TrimSpace(term), " ") {
-			conditions = append(conditions, sq.Like{"lower(b.title)": "%" + strings.ToLower(word) + "%"})
+			conditions = append(conditions, s.titleSearchCondition("b.title", word))
 		}
 
 		query = query.Where(conditions)
@@ -778,6 +2005,12 @@ func (s *SQLStore) getBoardHistory(db sq.BaseRunner, boardID string, opts model.
 }
 
 func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy string) error {
+	// boards deleted through the app are kept in the trash, so
+	// undeleting them restores them
+	if err := s.restoreBoard(db, boardID, modifiedBy); !model.IsErrNotFound(err) {
+		return err
+	}
+
 	boards, err := s.getBoardHistory(db, boardID, model.QueryBoardHistoryOptions{Limit: 1, Descending: true})
 	if err != nil {
 		return err
@@ -824,6 +2057,8 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		"create_at",
 		"update_at",
 		"delete_at",
+		"creation_source",
+		"source_id",
 	}
 
 	values := []interface{}{
@@ -845,6 +2080,8 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		board.CreateAt,
 		now,
 		0,
+		board.CreationSource,
+		board.SourceID,
 	}
 	insertHistoryQuery := s.getQueryBuilder(db).Insert(s.tablePrefix + "boards_history").
 		Columns(columns...).
@@ -861,7 +2098,7 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		return err
 	}
 
-	return nil
+	return s.auditChange(db, boardID, modifiedBy, model.AuditActionUndeleteBoard, boardID, "")
 }
 
 func (s *SQLStore) getBoardMemberHistory(db sq.BaseRunner, boardID, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
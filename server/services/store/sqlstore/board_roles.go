@@ -0,0 +1,257 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var boardCustomRoleFields = []string{
+	"id",
+	"board_id",
+	"name",
+	"permissions",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) boardCustomRolesFromRows(rows *sql.Rows) ([]*model.BoardCustomRole, error) {
+	roles := []*model.BoardCustomRole{}
+
+	for rows.Next() {
+		var role model.BoardCustomRole
+		err := rows.Scan(
+			&role.ID,
+			&role.BoardID,
+			&role.Name,
+			&role.Permissions,
+			&role.CreatedBy,
+			&role.CreateAt,
+			&role.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+	return roles, nil
+}
+
+// createBoardCustomRole creates a custom role for a board.
+func (s *SQLStore) createBoardCustomRole(db sq.BaseRunner, role *model.BoardCustomRole) error {
+	if err := role.IsValid(); err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	if role.ID == "" {
+		role.ID = utils.NewID(utils.IDTypeNone)
+	}
+	role.CreateAt = now
+	role.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_custom_roles").
+		Columns(boardCustomRoleFields...).
+		Values(
+			role.ID,
+			role.BoardID,
+			role.Name,
+			role.Permissions,
+			role.CreatedBy,
+			role.CreateAt,
+			role.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create board custom role",
+			mlog.String("board_id", role.BoardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	return s.auditChange(db, role.BoardID, role.CreatedBy, model.AuditActionCreateCustomRole, role.ID, "name: "+role.Name)
+}
+
+// getBoardCustomRole returns a custom board role by its ID.
+func (s *SQLStore) getBoardCustomRole(db sq.BaseRunner, id string) (*model.BoardCustomRole, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardCustomRoleFields...).
+		From(s.tablePrefix + "board_custom_roles").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board custom role", mlog.String("id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	roles, err := s.boardCustomRolesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return nil, model.NewErrNotFound("board custom role ID=" + id)
+	}
+
+	return roles[0], nil
+}
+
+// getBoardCustomRoles returns the custom roles of a board, by name.
+func (s *SQLStore) getBoardCustomRoles(db sq.BaseRunner, boardID string) ([]*model.BoardCustomRole, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardCustomRoleFields...).
+		From(s.tablePrefix+"board_custom_roles").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("name", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board custom roles",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardCustomRolesFromRows(rows)
+}
+
+// updateBoardCustomRole updates the name and permissions of a custom
+// board role.
+func (s *SQLStore) updateBoardCustomRole(db sq.BaseRunner, role *model.BoardCustomRole, userID string) error {
+	if err := role.IsValid(); err != nil {
+		return err
+	}
+
+	role.UpdateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_custom_roles").
+		Set("name", role.Name).
+		Set("permissions", role.Permissions).
+		Set("update_at", role.UpdateAt).
+		Where(sq.Eq{"id": role.ID}).
+		Where(sq.Eq{"board_id": role.BoardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update board custom role",
+			mlog.String("id", role.ID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("board custom role ID=" + role.ID)
+	}
+
+	return s.auditChange(db, role.BoardID, userID, model.AuditActionUpdateCustomRole, role.ID, "name: "+role.Name)
+}
+
+// deleteBoardCustomRole deletes a custom board role and unassigns it
+// from the members that had it.
+func (s *SQLStore) deleteBoardCustomRole(db sq.BaseRunner, id, userID string) error {
+	role, err := s.getBoardCustomRole(db, id)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_custom_roles").
+		Where(sq.Eq{"id": id})
+
+	if _, err := query.Exec(); err != nil {
+		return err
+	}
+
+	unassignQuery := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_members").
+		Set("custom_role_id", "").
+		Where(sq.Eq{"custom_role_id": id})
+
+	if _, err := unassignQuery.Exec(); err != nil {
+		s.logger.Error("Cannot unassign deleted board custom role",
+			mlog.String("id", id),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	return s.auditChange(db, role.BoardID, userID, model.AuditActionDeleteCustomRole, id, "name: "+role.Name)
+}
+
+// deleteBoardCustomRolesForBoard deletes the custom roles of a board, if
+// any.
+func (s *SQLStore) deleteBoardCustomRolesForBoard(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_custom_roles").
+		Where(sq.Eq{"board_id": boardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot delete board custom roles for board",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// setMemberCustomRole assigns a custom role to a board member, or
+// unassigns it if the role ID is empty. The role must belong to the
+// board of the member.
+func (s *SQLStore) setMemberCustomRole(db sq.BaseRunner, boardID, userID, roleID, modifiedBy string) error {
+	if roleID != "" {
+		role, err := s.getBoardCustomRole(db, roleID)
+		if err != nil {
+			return err
+		}
+		if role.BoardID != boardID {
+			return model.NewErrBadRequest("custom role does not belong to the board")
+		}
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_members").
+		Set("custom_role_id", roleID).
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"user_id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error(`setMemberCustomRole ERROR`, mlog.String("boardID", boardID), mlog.String("userID", userID), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound(fmt.Sprintf("board member BoardID=%s UserID=%s", boardID, userID))
+	}
+
+	member, err := s.getMemberForBoard(db, boardID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.auditChange(db, boardID, modifiedBy, model.AuditActionSaveMember, userID, model.AuditMemberSummary(member))
+}
@@ -5,6 +5,9 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 type BlockDoesntBelongToBoardsErr struct {
@@ -30,7 +33,7 @@ func (s *SQLStore) createBoardsAndBlocksWithAdmin(db sq.BaseRunner, bab *model.B
 			SchemeEditor: true,
 		}
 
-		nbm, err := s.saveMember(db, bm)
+		nbm, err := s.saveMember(db, bm, userID)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -83,7 +86,7 @@ func (s *SQLStore) patchBoardsAndBlocks(db sq.BaseRunner, pbab *model.PatchBoard
 	}
 
 	for i, blockID := range pbab.BlockIDs {
-		if err := s.patchBlock(db, blockID, pbab.BlockPatches[i], userID); err != nil {
+		if _, err := s.patchBlock(db, blockID, pbab.BlockPatches[i], userID); err != nil {
 			return nil, err
 		}
 		block, err := s.getBlock(db, blockID)
@@ -119,7 +122,7 @@ func (s *SQLStore) deleteBoardsAndBlocks(db sq.BaseRunner, dbab *model.DeleteBoa
 			return BlockDoesntBelongToBoardsErr{blockID}
 		}
 
-		if err := s.deleteBlock(db, blockID, userID); err != nil {
+		if _, err := s.deleteBlock(db, blockID, userID); err != nil {
 			return err
 		}
 	}
@@ -128,6 +131,18 @@ func (s *SQLStore) deleteBoardsAndBlocks(db sq.BaseRunner, dbab *model.DeleteBoa
 }
 
 func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	return s.duplicateBoardWithOptions(db, boardID, userID, model.DuplicateBoardOptions{
+		ToTeam:       toTeam,
+		AsTemplate:   asTemplate,
+		IncludeCards: true,
+	})
+}
+
+// duplicateBoardWithOptions copies a board and its blocks with new
+// IDs. The copy starts with a clean history, as only its creation is
+// recorded.
+func (s *SQLStore) duplicateBoardWithOptions(db sq.BaseRunner, boardID string, userID string, opts model.DuplicateBoardOptions) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	asTemplate := opts.AsTemplate
 	bab := &model.BoardsAndBlocks{
 		Boards: []*model.Board{},
 		Blocks: []*model.Block{},
@@ -147,26 +162,53 @@ func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID strin
 		board.Title = "New board template"
 	}
 
+	// boards created from a template keep track of it, the rest
+	// of the copies keep track of their source board
+	if board.IsTemplate && !asTemplate {
+		board.CreationSource = model.BoardCreationSourceTemplate
+	} else {
+		board.CreationSource = model.BoardCreationSourceDuplicate
+	}
+	board.SourceID = board.ID
+
 	// make new board private
 	board.Type = "P"
 	board.IsTemplate = asTemplate
 	board.CreatedBy = userID
 	board.ChannelID = ""
+	board.CreateAt = 0
+	board.UpdateAt = 0
+	board.DeleteAt = 0
 
-	if toTeam != "" {
-		board.TeamID = toTeam
+	if opts.ToTeam != "" {
+		board.TeamID = opts.ToTeam
 	}
 
 	bab.Boards = []*model.Board{board}
-	blocks, err := s.getBlocksForBoard(db, boardID)
+	blocks, _, err := s.getBlocksForBoard(db, boardID, model.QueryBlocksOptions{IncludeArchived: true})
 	if err != nil {
 		return nil, nil, err
 	}
+	if !opts.IncludeCards {
+		blocks = withoutCards(blocks)
+	}
+
 	newBlocks := []*model.Block{}
 	for _, b := range blocks {
-		if b.Type != model.TypeComment {
-			newBlocks = append(newBlocks, b)
+		if b.Type == model.TypeComment {
+			continue
+		}
+		if b.DeleteAt != 0 && !opts.IncludeDeletedBlocks {
+			continue
 		}
+
+		// the copy is created now, so it shouldn't carry the source's
+		// timestamps or authorship
+		b.CreatedBy = userID
+		b.CreateAt = 0
+		b.UpdateAt = 0
+		b.DeleteAt = 0
+		newBlocks = append(newBlocks, b)
 	}
 	bab.Blocks = newBlocks
 
@@ -175,5 +217,259 @@ func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID strin
 		return nil, nil, err
 	}
 
-	return s.createBoardsAndBlocksWithAdmin(db, bab, userID)
+	newBab, members, err := s.createBoardsAndBlocksWithAdmin(db, bab, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts.IncludeMembers {
+		copied, err := s.copyBoardMembers(db, boardID, newBab.Boards[0].ID, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		members = append(members, copied...)
+	}
+
+	return newBab, members, nil
+}
+
+// withoutCards returns the blocks that are neither cards nor part of
+// the content of a card.
+func withoutCards(blocks []*model.Block) []*model.Block {
+	parents := make(map[string]string, len(blocks))
+	cards := map[string]bool{}
+	for _, b := range blocks {
+		parents[b.ID] = b.ParentID
+		if b.Type == model.TypeCard {
+			cards[b.ID] = true
+		}
+	}
+
+	inCard := func(b *model.Block) bool {
+		// the depth is bounded by the number of blocks in case the
+		// parent references form a cycle
+		id := b.ID
+		for i := 0; i <= len(blocks) && id != ""; i++ {
+			if cards[id] {
+				return true
+			}
+			id = parents[id]
+		}
+		return false
+	}
+
+	result := make([]*model.Block, 0, len(blocks))
+	for _, b := range blocks {
+		if !inCard(b) {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// copyBoardMembers gives the members of a board the same roles on
+// another board. The user given as admin is left out, as they already
+// are an admin of the target board.
+func (s *SQLStore) copyBoardMembers(db sq.BaseRunner, fromBoardID, toBoardID, adminID string) ([]*model.BoardMember, error) {
+	members, err := s.getMembersForBoard(db, fromBoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := make([]*model.BoardMember, 0, len(members))
+	for _, member := range members {
+		if member.UserID == adminID {
+			continue
+		}
+
+		bm := &model.BoardMember{
+			BoardID:         toBoardID,
+			UserID:          member.UserID,
+			SchemeAdmin:     member.SchemeAdmin,
+			SchemeEditor:    member.SchemeEditor,
+			SchemeCommenter: member.SchemeCommenter,
+			SchemeViewer:    member.SchemeViewer,
+		}
+
+		nbm, err := s.saveMember(db, bm, adminID)
+		if err != nil {
+			return nil, err
+		}
+		copied = append(copied, nbm)
+	}
+	return copied, nil
+}
+
+// mergeBoards moves the blocks of the source board and their history
+// into the target board, adds the members of the source board to the
+// target one and archives the source board. The card properties of the
+// source board missing in the target one are added to it, so the moved
+// cards keep their values.
+//
+// Block IDs are unique across boards, so no block needs a new ID and
+// the returned map of old to new block IDs is always empty.
+func (s *SQLStore) mergeBoards(db sq.BaseRunner, sourceBoardID, targetBoardID, userID string) (map[string]string, error) {
+	if sourceBoardID == targetBoardID {
+		return nil, model.NewErrBadRequest("a board cannot be merged into itself")
+	}
+
+	source, err := s.getBoard(db, sourceBoardID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getBoard(db, targetBoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.TeamID != target.TeamID {
+		return nil, model.NewErrBadRequest("only boards of the same team can be merged")
+	}
+
+	rows, err := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": sourceBoardID}).
+		Query()
+	if err != nil {
+		s.logger.Error("mergeBoards ERROR", mlog.String("boardID", sourceBoardID), mlog.Err(err))
+		return nil, err
+	}
+	blockIDs, err := idsFromRows(rows)
+	s.CloseRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// the moved blocks count as new blocks of the target board
+	movedBlocks := make([]*model.Block, 0, len(blockIDs))
+	for _, blockID := range blockIDs {
+		movedBlocks = append(movedBlocks, &model.Block{ID: blockID, BoardID: targetBoardID})
+	}
+
+	err = s.withBoardBlockLimit(db, movedBlocks, func() error {
+		return s.moveBoardBlocks(db, sourceBoardID, targetBoardID, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mergeBoardMembers(db, sourceBoardID, targetBoardID, userID); err != nil {
+		return nil, err
+	}
+
+	targetProperties := map[string]bool{}
+	for _, property := range target.CardProperties {
+		if id, ok := property["id"].(string); ok {
+			targetProperties[id] = true
+		}
+	}
+	missingProperties := []map[string]interface{}{}
+	for _, property := range source.CardProperties {
+		if id, ok := property["id"].(string); ok && !targetProperties[id] {
+			missingProperties = append(missingProperties, property)
+		}
+	}
+	if len(missingProperties) > 0 {
+		patch := &model.BoardPatch{UpdatedCardProperties: missingProperties}
+		if _, err := s.patchBoard(db, targetBoardID, patch, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.archiveBoard(db, sourceBoardID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditChange(db, targetBoardID, userID, model.AuditActionMergeBoards, sourceBoardID, ""); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{}, nil
+}
+
+// moveBoardBlocks moves all the blocks of a board and their history to
+// another board. The top level blocks get the new board as parent.
+func (s *SQLStore) moveBoardBlocks(db sq.BaseRunner, fromBoardID, toBoardID, userID string) error {
+	now := utils.GetMillis()
+
+	for _, table := range []string{"blocks", "blocks_history"} {
+		reparentQuery := s.getQueryBuilder(db).
+			Update(s.tablePrefix+table).
+			Set("parent_id", toBoardID).
+			Where(sq.Eq{"board_id": fromBoardID}).
+			Where(sq.Eq{"parent_id": fromBoardID})
+
+		if _, err := reparentQuery.Exec(); err != nil {
+			s.logger.Error("moveBoardBlocks reparent error", mlog.String("table", table), mlog.String("boardID", fromBoardID), mlog.Err(err))
+			return err
+		}
+
+		moveQuery := s.getQueryBuilder(db).
+			Update(s.tablePrefix+table).
+			Set("board_id", toBoardID).
+			Where(sq.Eq{"board_id": fromBoardID})
+
+		if table == "blocks" {
+			moveQuery = moveQuery.
+				Set("modified_by", userID).
+				Set("update_at", now)
+		}
+
+		if _, err := moveQuery.Exec(); err != nil {
+			s.logger.Error("moveBoardBlocks move error", mlog.String("table", table), mlog.String("boardID", fromBoardID), mlog.Err(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeBoardMembers adds the members of a board to another board. Users
+// that are members of both keep the higher of their two roles.
+func (s *SQLStore) mergeBoardMembers(db sq.BaseRunner, fromBoardID, toBoardID, userID string) error {
+	members, err := s.getMembersForBoard(db, fromBoardID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		existing, err := s.getMemberForBoard(db, toBoardID, member.UserID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return err
+		}
+
+		if existing != nil && boardRoleRank(existing.SchemeRole()) >= boardRoleRank(member.SchemeRole()) {
+			continue
+		}
+
+		bm := &model.BoardMember{
+			BoardID:         toBoardID,
+			UserID:          member.UserID,
+			SchemeAdmin:     member.SchemeAdmin,
+			SchemeEditor:    member.SchemeEditor,
+			SchemeCommenter: member.SchemeCommenter,
+			SchemeViewer:    member.SchemeViewer,
+		}
+		if _, err := s.saveMember(db, bm, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func boardRoleRank(role model.BoardRole) int {
+	switch role {
+	case model.BoardRoleAdmin:
+		return 4
+	case model.BoardRoleEditor:
+		return 3
+	case model.BoardRoleCommenter:
+		return 2
+	case model.BoardRoleViewer:
+		return 1
+	default:
+		return 0
+	}
 }
@@ -2,6 +2,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/focalboard/server/model"
@@ -10,9 +11,39 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+func categoryFields(prefix string) []string {
+	fields := []string{
+		"id",
+		"name",
+		"user_id",
+		"team_id",
+		"create_at",
+		"update_at",
+		"delete_at",
+		"collapsed",
+		"type",
+		"COALESCE(sort_order, 0)",
+		"COALESCE(icon, '')",
+	}
+
+	if prefix == "" {
+		return fields
+	}
+
+	prefixedFields := make([]string, len(fields))
+	for i, field := range fields {
+		if strings.HasPrefix(field, "COALESCE(") {
+			prefixedFields[i] = strings.Replace(field, "COALESCE(", "COALESCE("+prefix, 1)
+		} else {
+			prefixedFields[i] = prefix + field
+		}
+	}
+	return prefixedFields
+}
+
 func (s *SQLStore) getCategory(db sq.BaseRunner, id string) (*model.Category, error) {
 	query := s.getQueryBuilder(db).
-		Select("id", "name", "user_id", "team_id", "create_at", "update_at", "delete_at", "collapsed", "type").
+		Select(categoryFields("")...).
 		From(s.tablePrefix + "categories").
 		Where(sq.Eq{"id": id})
 
@@ -35,7 +66,32 @@ func (s *SQLStore) getCategory(db sq.BaseRunner, id string) (*model.Category, er
 	return &categories[0], nil
 }
 
+// createCategory inserts the category after the other categories of
+// the user in the team.
 func (s *SQLStore) createCategory(db sq.BaseRunner, category model.Category) error {
+	var sortOrder int
+	err := s.getQueryBuilder(db).
+		Select("COALESCE(MAX(sort_order), -1) + 1").
+		From(s.tablePrefix + "categories").
+		Where(sq.Eq{
+			"user_id":   category.UserID,
+			"team_id":   category.TeamID,
+			"delete_at": 0,
+		}).
+		QueryRow().
+		Scan(&sortOrder)
+	if err != nil {
+		s.logger.Error("Error getting category sort order", mlog.String("category name", category.Name), mlog.Err(err))
+		return err
+	}
+
+	category.SortOrder = sortOrder
+	return s.insertCategory(db, category)
+}
+
+// insertCategory inserts the category with the sort order it already
+// has.
+func (s *SQLStore) insertCategory(db sq.BaseRunner, category model.Category) error {
 	query := s.getQueryBuilder(db).
 		Insert(s.tablePrefix+"categories").
 		Columns(
@@ -48,6 +104,8 @@ func (s *SQLStore) createCategory(db sq.BaseRunner, category model.Category) err
 			"delete_at",
 			"collapsed",
 			"type",
+			"sort_order",
+			"icon",
 		).
 		Values(
 			category.ID,
@@ -59,12 +117,14 @@ func (s *SQLStore) createCategory(db sq.BaseRunner, category model.Category) err
 			category.DeleteAt,
 			category.Collapsed,
 			category.Type,
+			category.SortOrder,
+			category.Icon,
 		)
 
 	_, err := query.Exec()
 	if err != nil {
 		s.logger.Error("Error creating category", mlog.String("category name", category.Name), mlog.Err(err))
-		return err
+		return duplicateError(err, "category")
 	}
 	return nil
 }
@@ -75,6 +135,7 @@ func (s *SQLStore) updateCategory(db sq.BaseRunner, category model.Category) err
 		Set("name", category.Name).
 		Set("update_at", category.UpdateAt).
 		Set("collapsed", category.Collapsed).
+		Set("icon", category.Icon).
 		Where(sq.Eq{
 			"id":        category.ID,
 			"delete_at": 0,
@@ -115,19 +176,89 @@ func (s *SQLStore) deleteCategory(db sq.BaseRunner, categoryID, userID, teamID s
 
 func (s *SQLStore) getUserCategories(db sq.BaseRunner, userID, teamID string) ([]model.Category, error) {
 	query := s.getQueryBuilder(db).
-		Select("id", "name", "user_id", "team_id", "create_at", "update_at", "delete_at", "collapsed", "type").
-		From(s.tablePrefix + "categories").
+		Select(categoryFields("")...).
+		From(s.tablePrefix+"categories").
 		Where(sq.Eq{
 			"user_id":   userID,
 			"team_id":   teamID,
 			"delete_at": 0,
-		})
+		}).
+		OrderBy("sort_order", "create_at", "id")
 
 	rows, err := query.Query()
 	if err != nil {
 		s.logger.Error("getUserCategories error", mlog.Err(err))
 		return nil, err
 	}
+	defer s.CloseRows(rows)
+
+	return s.categoriesFromRows(rows)
+}
+
+// reorderCategories sets the sort order of the categories of a user in a
+// team to their position in categoryIDs, which must list each of them
+// exactly once.
+func (s *SQLStore) reorderCategories(db sq.BaseRunner, userID, teamID string, categoryIDs []string) error {
+	categories, err := s.getUserCategories(db, userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		existing[category.ID] = true
+	}
+
+	seen := make(map[string]bool, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		if !existing[categoryID] {
+			return model.NewErrBadRequest("category " + categoryID + " is not a category of the user")
+		}
+		if seen[categoryID] {
+			return model.NewErrBadRequest("category " + categoryID + " is listed more than once")
+		}
+		seen[categoryID] = true
+	}
+
+	if len(categoryIDs) != len(categories) {
+		return model.NewErrBadRequest("the new order must include all the categories of the user")
+	}
+
+	now := utils.GetMillis()
+	for i, categoryID := range categoryIDs {
+		_, err := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"categories").
+			Set("sort_order", i).
+			Set("update_at", now).
+			Where(sq.Eq{"id": categoryID}).
+			Exec()
+		if err != nil {
+			s.logger.Error("reorderCategories error", mlog.String("categoryID", categoryID), mlog.Err(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getAllCategoriesForTeam returns the categories of all the users of
+// a team. It is intended for admin tooling only.
+func (s *SQLStore) getAllCategoriesForTeam(db sq.BaseRunner, teamID string) ([]model.Category, error) {
+	query := s.getQueryBuilder(db).
+		Select(categoryFields("")...).
+		From(s.tablePrefix+"categories").
+		Where(sq.Eq{
+			"team_id":   teamID,
+			"delete_at": 0,
+		}).
+		OrderBy("user_id", "create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getAllCategoriesForTeam error", mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
 
 	return s.categoriesFromRows(rows)
 }
@@ -147,6 +278,8 @@ func (s *SQLStore) categoriesFromRows(rows *sql.Rows) ([]model.Category, error)
 			&category.DeleteAt,
 			&category.Collapsed,
 			&category.Type,
+			&category.SortOrder,
+			&category.Icon,
 		)
 
 		if err != nil {
@@ -159,3 +292,183 @@ func (s *SQLStore) categoriesFromRows(rows *sql.Rows) ([]model.Category, error)
 
 	return categories, nil
 }
+
+// findDuplicateCategories returns the categories of a user that share
+// their name with another category of the same team, grouped by name.
+// The categories of each group are sorted oldest first.
+func (s *SQLStore) findDuplicateCategories(db sq.BaseRunner, userID, teamID string) (map[string][]model.Category, error) {
+	query := s.getQueryBuilder(db).
+		Select(categoryFields("")...).
+		From(s.tablePrefix+"categories").
+		Where(sq.Eq{
+			"user_id":   userID,
+			"team_id":   teamID,
+			"delete_at": 0,
+		}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("findDuplicateCategories error", mlog.String("userID", userID), mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	categories, err := s.categoriesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string][]model.Category{}
+	for _, category := range categories {
+		byName[category.Name] = append(byName[category.Name], category)
+	}
+
+	duplicates := map[string][]model.Category{}
+	for name, group := range byName {
+		if len(group) > 1 {
+			duplicates[name] = group
+		}
+	}
+
+	return duplicates, nil
+}
+
+// mergeCategories moves the boards of the merged categories into the
+// primary one and deletes the merged categories. The boards already in
+// the primary category keep their place and the moved boards are
+// listed after them, in their original order.
+func (s *SQLStore) mergeCategories(db sq.BaseRunner, userID, primaryCategoryID string, mergeCategoryIDs []string) error {
+	primary, err := s.getCategory(db, primaryCategoryID)
+	if err != nil {
+		return err
+	}
+
+	if primary.DeleteAt != 0 {
+		return model.NewErrNotFound("category ID=" + primaryCategoryID)
+	}
+
+	if primary.UserID != userID {
+		return model.ErrCategoryPermissionDenied
+	}
+
+	primaryBoardIDs, err := s.getCategoryBoardAttributes(db, primaryCategoryID)
+	if err != nil {
+		return err
+	}
+
+	inPrimary := map[string]bool{}
+	for _, boardID := range primaryBoardIDs {
+		inPrimary[boardID] = true
+	}
+
+	// all the categories are checked before changing anything, as
+	// SQLite doesn't run the merge in a transaction
+	merged := map[string]bool{}
+	categories := []*model.Category{}
+	for _, categoryID := range mergeCategoryIDs {
+		if categoryID == primaryCategoryID {
+			return model.NewErrBadRequest("a category cannot be merged into itself")
+		}
+		if merged[categoryID] {
+			continue
+		}
+		merged[categoryID] = true
+
+		category, err := s.getCategory(db, categoryID)
+		if err != nil {
+			return err
+		}
+
+		if category.DeleteAt != 0 {
+			return model.NewErrNotFound("category ID=" + categoryID)
+		}
+
+		if category.UserID != userID {
+			return model.ErrCategoryPermissionDenied
+		}
+
+		if category.TeamID != primary.TeamID {
+			return model.NewErrBadRequest("only categories of the same team can be merged")
+		}
+
+		if category.Type == model.CategoryTypeSystem && primary.Type != model.CategoryTypeSystem {
+			return model.NewErrBadRequest("the default category cannot be merged into a custom category")
+		}
+
+		categories = append(categories, category)
+	}
+
+	for _, category := range categories {
+		categoryID := category.ID
+
+		boardIDs, err := s.getCategoryBoardAttributes(db, categoryID)
+		if err != nil {
+			return err
+		}
+
+		movedBoardIDs := []string{}
+		for _, boardID := range boardIDs {
+			if !inPrimary[boardID] {
+				movedBoardIDs = append(movedBoardIDs, boardID)
+				inPrimary[boardID] = true
+			}
+		}
+
+		now := utils.GetMillis()
+
+		sortOrder, err := s.getNextCategoryBoardSortOrder(db, primaryCategoryID)
+		if err != nil {
+			return err
+		}
+
+		for _, boardID := range movedBoardIDs {
+			_, err = s.getQueryBuilder(db).
+				Update(s.tablePrefix+"category_boards").
+				Set("category_id", primaryCategoryID).
+				Set("sort_order", sortOrder).
+				Set("update_at", now).
+				Where(sq.Eq{
+					"user_id":     userID,
+					"category_id": categoryID,
+					"board_id":    boardID,
+					"delete_at":   0,
+				}).Exec()
+			if err != nil {
+				s.logger.Error(
+					"mergeCategories move boards error",
+					mlog.String("userID", userID),
+					mlog.String("categoryID", categoryID),
+					mlog.Err(err),
+				)
+				return err
+			}
+			sortOrder++
+		}
+
+		// the boards left are already in the primary category
+		_, err = s.getQueryBuilder(db).
+			Update(s.tablePrefix+"category_boards").
+			Set("delete_at", now).
+			Where(sq.Eq{
+				"user_id":     userID,
+				"category_id": categoryID,
+				"delete_at":   0,
+			}).Exec()
+		if err != nil {
+			s.logger.Error(
+				"mergeCategories delete boards error",
+				mlog.String("userID", userID),
+				mlog.String("categoryID", categoryID),
+				mlog.Err(err),
+			)
+			return err
+		}
+
+		if err := s.deleteCategory(db, categoryID, userID, category.TeamID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
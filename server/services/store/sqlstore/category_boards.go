@@ -2,6 +2,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"errors"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/focalboard/server/model"
@@ -34,14 +35,17 @@ func (s *SQLStore) getUserCategoryBoards(db sq.BaseRunner, userID, teamID string
 	return userCategoryBoards, nil
 }
 
+// getCategoryBoardAttributes returns the IDs of the boards of a
+// category in their sort order.
 func (s *SQLStore) getCategoryBoardAttributes(db sq.BaseRunner, categoryID string) ([]string, error) {
 	query := s.getQueryBuilder(db).
 		Select("board_id").
-		From(s.tablePrefix + "category_boards").
+		From(s.tablePrefix+"category_boards").
 		Where(sq.Eq{
 			"category_id": categoryID,
 			"delete_at":   0,
-		})
+		}).
+		OrderBy("sort_order", "create_at", "id")
 
 	rows, err := query.Query()
 	if err != nil {
@@ -67,8 +71,14 @@ func (s *SQLStore) addUpdateCategoryBoard(db sq.BaseRunner, userID, categoryID,
 	return s.addUserCategoryBoard(db, userID, categoryID, boardID)
 }
 
+// addUserCategoryBoard adds the board at the end of the category.
 func (s *SQLStore) addUserCategoryBoard(db sq.BaseRunner, userID, categoryID, boardID string) error {
-	_, err := s.getQueryBuilder(db).
+	sortOrder, err := s.getNextCategoryBoardSortOrder(db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.getQueryBuilder(db).
 		Insert(s.tablePrefix+"category_boards").
 		Columns(
 			"id",
@@ -78,6 +88,7 @@ func (s *SQLStore) addUserCategoryBoard(db sq.BaseRunner, userID, categoryID, bo
 			"create_at",
 			"update_at",
 			"delete_at",
+			"sort_order",
 		).
 		Values(
 			utils.NewID(utils.IDTypeNone),
@@ -87,6 +98,7 @@ func (s *SQLStore) addUserCategoryBoard(db sq.BaseRunner, userID, categoryID, bo
 			utils.GetMillis(),
 			utils.GetMillis(),
 			0,
+			sortOrder,
 		).Exec()
 
 	if err != nil {
@@ -96,6 +108,95 @@ func (s *SQLStore) addUserCategoryBoard(db sq.BaseRunner, userID, categoryID, bo
 	return nil
 }
 
+// getNextCategoryBoardSortOrder returns the sort order that places a
+// board after all the boards of the category.
+func (s *SQLStore) getNextCategoryBoardSortOrder(db sq.BaseRunner, categoryID string) (int64, error) {
+	var sortOrder int64
+	err := s.getQueryBuilder(db).
+		Select("COALESCE(MAX(sort_order), -1) + 1").
+		From(s.tablePrefix + "category_boards").
+		Where(sq.Eq{
+			"category_id": categoryID,
+			"delete_at":   0,
+		}).
+		QueryRow().
+		Scan(&sortOrder)
+	if err != nil {
+		s.logger.Error("getNextCategoryBoardSortOrder error", mlog.String("categoryID", categoryID), mlog.Err(err))
+		return 0, err
+	}
+
+	return sortOrder, nil
+}
+
+// reorderCategoryBoards sets the sort order of the boards of a user's
+// category to their position in boardIDs, which must list each of them
+// exactly once.
+func (s *SQLStore) reorderCategoryBoards(db sq.BaseRunner, userID, categoryID string, boardIDs []string) error {
+	category, err := s.getCategory(db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	if category.DeleteAt != 0 {
+		return model.NewErrNotFound("category ID=" + categoryID)
+	}
+
+	if category.UserID != userID {
+		return model.ErrCategoryPermissionDenied
+	}
+
+	categoryBoardIDs, err := s.getCategoryBoardAttributes(db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(categoryBoardIDs))
+	for _, boardID := range categoryBoardIDs {
+		existing[boardID] = true
+	}
+
+	seen := make(map[string]bool, len(boardIDs))
+	for _, boardID := range boardIDs {
+		if !existing[boardID] {
+			return model.NewErrBadRequest("board " + boardID + " is not in the category")
+		}
+		if seen[boardID] {
+			return model.NewErrBadRequest("board " + boardID + " is listed more than once")
+		}
+		seen[boardID] = true
+	}
+
+	if len(boardIDs) != len(categoryBoardIDs) {
+		return model.NewErrBadRequest("the new order must include all the boards of the category")
+	}
+
+	now := utils.GetMillis()
+	for i, boardID := range boardIDs {
+		_, err := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"category_boards").
+			Set("sort_order", i).
+			Set("update_at", now).
+			Where(sq.Eq{
+				"user_id":     userID,
+				"category_id": categoryID,
+				"board_id":    boardID,
+				"delete_at":   0,
+			}).Exec()
+		if err != nil {
+			s.logger.Error(
+				"reorderCategoryBoards error",
+				mlog.String("categoryID", categoryID),
+				mlog.String("boardID", boardID),
+				mlog.Err(err),
+			)
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *SQLStore) deleteUserCategoryBoard(db sq.BaseRunner, userID, boardID string) error {
 	_, err := s.getQueryBuilder(db).
 		Update(s.tablePrefix+"category_boards").
@@ -119,6 +220,158 @@ func (s *SQLStore) deleteUserCategoryBoard(db sq.BaseRunner, userID, boardID str
 	return nil
 }
 
+// removeCategoryBoards removes the given boards from a user's
+// category and moves them to the user's default category.
+func (s *SQLStore) removeCategoryBoards(db sq.BaseRunner, userID, categoryID string, boardIDs []string) error {
+	category, err := s.getCategory(db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	if category.UserID != userID {
+		return model.ErrCategoryPermissionDenied
+	}
+
+	if category.Type == model.CategoryTypeSystem {
+		return model.NewErrBadRequest("boards cannot be removed from the default category")
+	}
+
+	// only the boards that currently belong to the category are moved
+	categoryBoardIDs, err := s.getCategoryBoardAttributes(db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	toRemove := map[string]bool{}
+	for _, boardID := range boardIDs {
+		toRemove[boardID] = true
+	}
+
+	removedBoardIDs := []string{}
+	for _, boardID := range categoryBoardIDs {
+		if toRemove[boardID] {
+			removedBoardIDs = append(removedBoardIDs, boardID)
+		}
+	}
+
+	if len(removedBoardIDs) == 0 {
+		return nil
+	}
+
+	_, err = s.getQueryBuilder(db).
+		Update(s.tablePrefix+"category_boards").
+		Set("delete_at", utils.GetMillis()).
+		Where(sq.Eq{
+			"user_id":     userID,
+			"category_id": categoryID,
+			"board_id":    removedBoardIDs,
+			"delete_at":   0,
+		}).Exec()
+
+	if err != nil {
+		s.logger.Error(
+			"removeCategoryBoards delete error",
+			mlog.String("userID", userID),
+			mlog.String("categoryID", categoryID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	defaultCategoryID, err := s.getDefaultCategoryID(db, userID, category.TeamID)
+	if model.IsErrNotFound(err) {
+		// the default category will pick the boards up when it gets
+		// created
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, boardID := range removedBoardIDs {
+		if err := s.addUserCategoryBoard(db, userID, defaultCategoryID, boardID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clearCategory moves all the boards of a user's category to the
+// user's default category without deleting the category itself.
+func (s *SQLStore) clearCategory(db sq.BaseRunner, userID, categoryID string) error {
+	boardIDs, err := s.getCategoryBoardAttributes(db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	return s.removeCategoryBoards(db, userID, categoryID, boardIDs)
+}
+
+func (s *SQLStore) getDefaultCategoryID(db sq.BaseRunner, userID, teamID string) (string, error) {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "categories").
+		Where(sq.Eq{
+			"user_id":   userID,
+			"team_id":   teamID,
+			"type":      model.CategoryTypeSystem,
+			"delete_at": 0,
+		})
+
+	var categoryID string
+	err := query.QueryRow().Scan(&categoryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", model.NewErrNotFound("default category for user " + userID)
+	}
+	if err != nil {
+		s.logger.Error("getDefaultCategoryID error", mlog.String("userID", userID), mlog.String("teamID", teamID), mlog.Err(err))
+		return "", err
+	}
+
+	return categoryID, nil
+}
+
+// getCategoryForBoard returns the category a board is assigned to for a
+// user in a team, or the user's default category if the board isn't
+// assigned to any.
+func (s *SQLStore) getCategoryForBoard(db sq.BaseRunner, userID, teamID, boardID string) (*model.Category, error) {
+	query := s.getQueryBuilder(db).
+		Select(categoryFields("c.")...).
+		From(s.tablePrefix + "category_boards AS cb").
+		Join(s.tablePrefix + "categories AS c ON c.id = cb.category_id").
+		Where(sq.Eq{
+			"cb.user_id":   userID,
+			"cb.board_id":  boardID,
+			"cb.delete_at": 0,
+			"c.team_id":    teamID,
+			"c.delete_at":  0,
+		})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCategoryForBoard error", mlog.String("userID", userID), mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	categories, err := s.categoriesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categories) > 0 {
+		return &categories[0], nil
+	}
+
+	defaultCategoryID, err := s.getDefaultCategoryID(db, userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getCategory(db, defaultCategoryID)
+}
+
 func (s *SQLStore) categoryBoardsFromRows(rows *sql.Rows) ([]string, error) {
 	blocks := []string{}
 
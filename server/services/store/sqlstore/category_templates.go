@@ -0,0 +1,154 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (s *SQLStore) getDefaultCategoryTemplates(db sq.BaseRunner, teamID string) ([]model.CategoryTemplate, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"id",
+			"team_id",
+			"name",
+			"COALESCE(sort_order, 0)",
+			"COALESCE(icon, '')",
+			"create_at",
+			"update_at",
+		).
+		From(s.tablePrefix+"category_templates").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("sort_order", "create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getDefaultCategoryTemplates error", mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.categoryTemplatesFromRows(rows)
+}
+
+// upsertDefaultCategoryTemplate creates the category template or
+// updates it if a template with the same ID already exists.
+func (s *SQLStore) upsertDefaultCategoryTemplate(db sq.BaseRunner, template model.CategoryTemplate) error {
+	if err := template.IsValid(); err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"category_templates").
+		Columns(
+			"id",
+			"team_id",
+			"name",
+			"sort_order",
+			"icon",
+			"create_at",
+			"update_at",
+		).
+		Values(
+			template.ID,
+			template.TeamID,
+			template.Name,
+			template.SortOrder,
+			template.Icon,
+			now,
+			now,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix(
+			"ON DUPLICATE KEY UPDATE team_id = ?, name = ?, sort_order = ?, icon = ?, update_at = ?",
+			template.TeamID, template.Name, template.SortOrder, template.Icon, now,
+		)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (id)
+			 DO UPDATE SET team_id = EXCLUDED.team_id, name = EXCLUDED.name, sort_order = EXCLUDED.sort_order,
+			 icon = EXCLUDED.icon, update_at = EXCLUDED.update_at`,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("upsertDefaultCategoryTemplate error", mlog.String("templateID", template.ID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// createDefaultCategoriesForUser creates a category for the user for
+// each of the default category templates of the team, keeping the
+// sort order and icon of the template. Templates that the user already
+// has a category with the same name for are skipped, so calling it
+// again doesn't duplicate the categories.
+func (s *SQLStore) createDefaultCategoriesForUser(db sq.BaseRunner, userID, teamID string) error {
+	templates, err := s.getDefaultCategoryTemplates(db, teamID)
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.getUserCategories(db, userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		existing[category.Name] = true
+	}
+
+	for _, template := range templates {
+		if existing[template.Name] {
+			continue
+		}
+
+		category := model.Category{
+			Name:      template.Name,
+			UserID:    userID,
+			TeamID:    teamID,
+			SortOrder: template.SortOrder,
+			Icon:      template.Icon,
+		}
+		category.Hydrate()
+
+		if err := s.insertCategory(db, category); err != nil {
+			return err
+		}
+		existing[template.Name] = true
+	}
+
+	return nil
+}
+
+func (s *SQLStore) categoryTemplatesFromRows(rows *sql.Rows) ([]model.CategoryTemplate, error) {
+	templates := []model.CategoryTemplate{}
+
+	for rows.Next() {
+		var template model.CategoryTemplate
+		err := rows.Scan(
+			&template.ID,
+			&template.TeamID,
+			&template.Name,
+			&template.SortOrder,
+			&template.Icon,
+			&template.CreateAt,
+			&template.UpdateAt,
+		)
+		if err != nil {
+			s.logger.Error("categoryTemplatesFromRows row parsing error", mlog.Err(err))
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
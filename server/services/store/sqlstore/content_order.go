@@ -0,0 +1,124 @@
+package sqlstore
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// contentOrderFromFields returns the content order stored in the fields
+// of a card. Content orders of an unexpected type are ignored.
+func contentOrderFromFields(fields map[string]interface{}) []interface{} {
+	contentOrder, ok := fields["contentOrder"].([]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+	return contentOrder
+}
+
+// normalizeContentOrder reconciles the content order of a card with the
+// content blocks that actually exist, dropping the IDs of missing blocks
+// and appending the blocks that are not referenced, in their sort order.
+// The sort order of the content blocks is then updated to match.
+func (s *SQLStore) normalizeContentOrder(db sq.BaseRunner, cardID string) error {
+	card, err := s.getBlock(db, cardID)
+	if err != nil {
+		return err
+	}
+
+	if card.Type != model.TypeCard {
+		return model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", cardID))
+	}
+
+	children, err := s.getBlocks(db, model.QueryBlocksOptions{
+		BoardID:          card.BoardID,
+		ParentID:         cardID,
+		IncludeArchived:  true,
+		OrderBySortOrder: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	contentIDs := make([]string, 0, len(children))
+	for _, child := range children {
+		// comments are children of the card but not part of its content
+		if child.Type == model.TypeComment || child.DeleteAt != 0 {
+			continue
+		}
+		contentIDs = append(contentIDs, child.ID)
+	}
+
+	contentOrder := contentOrderFromFields(card.Fields)
+	normalized := model.ReconcileContentOrder(contentOrder, contentIDs)
+
+	oldJSON, err := json.Marshal(contentOrder)
+	if err != nil {
+		return err
+	}
+	newJSON, err := json.Marshal(normalized)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := card.Fields["contentOrder"]; !ok || string(oldJSON) != string(newJSON) {
+		if card.Fields == nil {
+			card.Fields = map[string]interface{}{}
+		}
+		card.Fields["contentOrder"] = normalized
+		card.UpdateAt = utils.GetMillis()
+
+		fieldsJSON, err := json.Marshal(card.Fields)
+		if err != nil {
+			return err
+		}
+
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"blocks").
+			Where(sq.Eq{"id": cardID}).
+			Set("fields", fieldsJSON).
+			Set("update_at", card.UpdateAt)
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("normalizeContentOrder error", mlog.String("cardID", cardID), mlog.Err(err))
+			return err
+		}
+
+		if err := s.insertBlockHistory(db, card, card.ModifiedBy); err != nil {
+			return err
+		}
+	}
+
+	return s.updateContentSortOrder(db, card.BoardID, cardID, normalized)
+}
+
+// updateContentSortOrder sets the sort order of the content blocks of a
+// card to their position in the given content order.
+func (s *SQLStore) updateContentSortOrder(db sq.BaseRunner, boardID, cardID string, contentOrder []interface{}) error {
+	for i, contentID := range model.FlattenContentOrder(contentOrder) {
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"blocks").
+			Set("sort_order", i+1).
+			Where(sq.Eq{
+				"id":        contentID,
+				"board_id":  boardID,
+				"parent_id": cardID,
+			}).
+			Where(sq.NotEq{"sort_order": i + 1})
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("updateContentSortOrder error",
+				mlog.String("cardID", cardID),
+				mlog.String("contentID", contentID),
+				mlog.Err(err),
+			)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// contextQueryer is implemented by both *sql.DB and *sql.Tx.
+type contextQueryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// contextRunner is a query runner that runs every query with a context,
+// so the queries of a store method are cancelled along with it.
+type contextRunner struct {
+	ctx context.Context
+	db  contextQueryer
+}
+
+func (r contextRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return r.db.ExecContext(r.ctx, query, args...)
+}
+
+func (r contextRunner) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.db.QueryContext(r.ctx, query, args...)
+}
+
+func (r contextRunner) QueryRow(query string, args ...interface{}) *sql.Row {
+	return r.db.QueryRowContext(r.ctx, query, args...)
+}
+
+// withContext returns a runner that runs the queries of db with ctx.
+func withContext(ctx context.Context, db contextQueryer) sq.BaseRunner {
+	return contextRunner{ctx: ctx, db: db}
+}
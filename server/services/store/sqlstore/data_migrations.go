@@ -68,7 +68,7 @@ func (s *SQLStore) getBlocksWithSameID(db sq.BaseRunner) ([]*model.Block, error)
 }
 
 func (s *SQLStore) RunUniqueIDsMigration() error {
-	setting, err := s.GetSystemSetting(UniqueIDsMigrationKey)
+	setting, err := s.GetSystemSetting(context.Background(), UniqueIDsMigrationKey)
 	if err != nil {
 		return fmt.Errorf("cannot get migration state: %w", err)
 	}
@@ -135,7 +135,7 @@ func (s *SQLStore) RunUniqueIDsMigration() error {
 // because of the preexisting purpose of this migration, and has been
 // preserved for compatibility with already migrated instances.
 func (s *SQLStore) RunCategoryUUIDIDMigration() error {
-	setting, err := s.GetSystemSetting(CategoryUUIDIDMigrationKey)
+	setting, err := s.GetSystemSetting(context.Background(), CategoryUUIDIDMigrationKey)
 	if err != nil {
 		return fmt.Errorf("cannot get migration state: %w", err)
 	}
@@ -355,7 +355,7 @@ func (s *SQLStore) RunTeamLessBoardsMigration() error {
 		return nil
 	}
 
-	setting, err := s.GetSystemSetting(TeamLessBoardsMigrationKey)
+	setting, err := s.GetSystemSetting(context.Background(), TeamLessBoardsMigrationKey)
 	if err != nil {
 		return fmt.Errorf("cannot get teamless boards migration state: %w", err)
 	}
@@ -557,7 +557,7 @@ func (s *SQLStore) RunDeletedMembershipBoardsMigration() error {
 		return nil
 	}
 
-	setting, err := s.GetSystemSetting(DeletedMembershipBoardsMigrationKey)
+	setting, err := s.GetSystemSetting(context.Background(), DeletedMembershipBoardsMigrationKey)
 	if err != nil {
 		return fmt.Errorf("cannot get deleted membership boards migration state: %w", err)
 	}
@@ -574,7 +574,7 @@ func (s *SQLStore) RunDeletedMembershipBoardsMigration() error {
 
 	if len(boards) == 0 {
 		s.logger.Debug("No boards with owner not anymore on their team found, marking runDeletedMembershipBoardsMigration as done")
-		if sErr := s.SetSystemSetting(DeletedMembershipBoardsMigrationKey, strconv.FormatBool(true)); sErr != nil {
+		if sErr := s.SetSystemSetting(context.Background(), DeletedMembershipBoardsMigrationKey, strconv.FormatBool(true)); sErr != nil {
 			return fmt.Errorf("cannot mark migration as completed: %w", sErr)
 		}
 		return nil
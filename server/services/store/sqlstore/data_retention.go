@@ -23,53 +23,80 @@ type RetentionTableDeletionInfo struct {
 	BoardIDColumn string
 }
 
+// boardDataTables are the tables holding the data of a board, which is
+// removed when a board is permanently deleted.
+var boardDataTables = []RetentionTableDeletionInfo{
+	{
+		Table:         "blocks",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "blocks_history",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "boards",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "id",
+	},
+	{
+		Table:         "boards_history",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "id",
+	},
+	{
+		Table:         "board_members",
+		PrimaryKeys:   []string{"board_id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "board_members_history",
+		PrimaryKeys:   []string{"board_id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "sharing",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "id",
+	},
+	{
+		Table:         "category_boards",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "webhooks",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "board_snapshots",
+		PrimaryKeys:   []string{"board_id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "board_favorites",
+		PrimaryKeys:   []string{"board_id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "board_custom_roles",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "share_tokens",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "board_id",
+	},
+}
+
 func (s *SQLStore) runDataRetention(db sq.BaseRunner, globalRetentionDate int64, batchSize int64) (int64, error) {
 	s.logger.Info("Start Boards Data Retention",
 		mlog.String("Global Retention Date", time.Unix(globalRetentionDate/1000, 0).String()),
 		mlog.Int64("Raw Date", globalRetentionDate))
-	deleteTables := []RetentionTableDeletionInfo{
-		{
-			Table:         "blocks",
-			PrimaryKeys:   []string{"id"},
-			BoardIDColumn: "board_id",
-		},
-		{
-			Table:         "blocks_history",
-			PrimaryKeys:   []string{"id"},
-			BoardIDColumn: "board_id",
-		},
-		{
-			Table:         "boards",
-			PrimaryKeys:   []string{"id"},
-			BoardIDColumn: "id",
-		},
-		{
-			Table:         "boards_history",
-			PrimaryKeys:   []string{"id"},
-			BoardIDColumn: "id",
-		},
-		{
-			Table:         "board_members",
-			PrimaryKeys:   []string{"board_id"},
-			BoardIDColumn: "board_id",
-		},
-		{
-			Table:         "board_members_history",
-			PrimaryKeys:   []string{"board_id"},
-			BoardIDColumn: "board_id",
-		},
-		{
-			Table:         "sharing",
-			PrimaryKeys:   []string{"id"},
-			BoardIDColumn: "id",
-		},
-		{
-			Table:         "category_boards",
-			PrimaryKeys:   []string{"id"},
-			BoardIDColumn: "board_id",
-		},
-	}
-
 	subBuilder := s.getQueryBuilder(db).
 		Select("board_id, MAX(update_at) AS maxDate").
 		From(s.tablePrefix + "blocks").
@@ -98,7 +125,7 @@ func (s *SQLStore) runDataRetention(db sq.BaseRunner, globalRetentionDate int64,
 
 	totalAffected := 0
 	if len(deleteIds) > 0 {
-		for _, table := range deleteTables {
+		for _, table := range boardDataTables {
 			affected, err := s.genericRetentionPoliciesDeletion(db, table, deleteIds, batchSize)
 			if err != nil {
 				return int64(totalAffected), err
@@ -171,7 +198,7 @@ func (s *SQLStore) genericRetentionPoliciesDeletion(
 			return 0, errors.Wrap(err, "failed to get rows affected for "+info.Table)
 		}
 		totalRowsAffected += batchRowsAffected
-		if batchRowsAffected != batchSize {
+		if batchRowsAffected == 0 || batchRowsAffected != batchSize {
 			break
 		}
 	}
@@ -0,0 +1,101 @@
+package sqlstore
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// setBoardFavorite adds or removes a board from the favorites of a user.
+// Only boards the user can access can be favorited, and removing a board
+// that isn't a favorite is a no-op.
+func (s *SQLStore) setBoardFavorite(db sq.BaseRunner, userID, boardID string, favorite bool) error {
+	if !favorite {
+		query := s.getQueryBuilder(db).
+			Delete(s.tablePrefix + "board_favorites").
+			Where(sq.Eq{"user_id": userID}).
+			Where(sq.Eq{"board_id": boardID})
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("setBoardFavorite delete error", mlog.String("boardID", boardID), mlog.Err(err))
+			return err
+		}
+		return nil
+	}
+
+	board, err := s.getBoard(db, boardID)
+	if model.IsErrNotFound(err) {
+		return model.NewErrPermission("access denied to board")
+	}
+	if err != nil {
+		return err
+	}
+
+	if board.Type != model.BoardTypeOpen {
+		if _, err := s.getMemberForBoard(db, boardID, userID); model.IsErrNotFound(err) {
+			return model.NewErrPermission("access denied to board")
+		} else if err != nil {
+			return err
+		}
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_favorites").
+		Columns("user_id", "board_id", "create_at").
+		Values(userID, boardID, utils.GetMillis())
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE user_id = user_id")
+	} else {
+		query = query.Suffix("ON CONFLICT (user_id, board_id) DO NOTHING")
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("setBoardFavorite insert error", mlog.String("boardID", boardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getFavoriteBoards returns the live boards of a team that the user has
+// favorited, most recently favorited first.
+func (s *SQLStore) getFavoriteBoards(db sq.BaseRunner, userID, teamID string) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("b.")...).
+		From(s.tablePrefix+"boards as b").
+		Join(s.tablePrefix+"board_favorites as bf on bf.board_id = b.id").
+		Where(sq.Eq{"bf.user_id": userID}).
+		Where(sq.Eq{"b.team_id": teamID}).
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0}).
+		OrderBy("bf.create_at DESC", "b.id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getFavoriteBoards ERROR`, mlog.String("userID", userID), mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
+
+// markFavoriteBoards sets the Favorite field of the boards favorited by
+// the user.
+func (s *SQLStore) markFavoriteBoards(db sq.BaseRunner, userID, teamID string, boards []*model.Board) error {
+	favorites, err := s.getFavoriteBoards(db, userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	favoriteIDs := make(map[string]bool, len(favorites))
+	for _, board := range favorites {
+		favoriteIDs[board.ID] = true
+	}
+
+	for _, board := range boards {
+		board.Favorite = favoriteIDs[board.ID]
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package sqlstore
 import (
 	"database/sql"
 	"errors"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -59,7 +60,8 @@ func (s *SQLStore) getFileInfo(db sq.BaseRunner, id string) (*mmModel.FileInfo,
 			"archived",
 		).
 		From(s.tablePrefix + "file_info").
-		Where(sq.Eq{"Id": id})
+		Where(sq.Eq{"Id": id}).
+		Where(sq.Eq{"COALESCE(delete_at, 0)": 0})
 
 	row := query.QueryRow()
 
@@ -86,3 +88,120 @@ func (s *SQLStore) getFileInfo(db sq.BaseRunner, id string) (*mmModel.FileInfo,
 
 	return &fileInfo, nil
 }
+
+// fileInfoIDFromBlock returns the ID of the file info of the file a
+// block references, if any. File names are in the format
+// 7<file info ID>.<extension>.
+func fileInfoIDFromBlock(block *model.Block) string {
+	fileName, ok := block.Fields["fileId"].(string)
+	if !ok || len(fileName) < 2 {
+		return ""
+	}
+
+	return strings.Split(fileName, ".")[0][1:]
+}
+
+// fileInfoIDsFromBlocks returns the IDs of the file infos of the files
+// the blocks reference.
+func fileInfoIDsFromBlocks(blocks []*model.Block) []string {
+	ids := []string{}
+	for _, block := range blocks {
+		if id := fileInfoIDFromBlock(block); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// getFileInfosForBoard returns the file infos of the files referenced by
+// the blocks of a board, skipping the deleted ones.
+func (s *SQLStore) getFileInfosForBoard(db sq.BaseRunner, boardID string) ([]*mmModel.FileInfo, error) {
+	blocks, err := s.getBlocks(db, model.QueryBlocksOptions{BoardID: boardID, IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := fileInfoIDsFromBlocks(blocks)
+	fileInfos := []*mmModel.FileInfo{}
+
+	for start := 0; start < len(ids); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		query := s.getQueryBuilder(db).
+			Select(
+				"id",
+				"create_at",
+				"delete_at",
+				"name",
+				"extension",
+				"size",
+				"archived",
+			).
+			From(s.tablePrefix+"file_info").
+			Where(sq.Eq{"id": ids[start:end]}).
+			Where(sq.Eq{"COALESCE(delete_at, 0)": 0}).
+			OrderBy("create_at", "id")
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error("getFileInfosForBoard error", mlog.String("boardID", boardID), mlog.Err(err))
+			return nil, err
+		}
+
+		for rows.Next() {
+			fileInfo := mmModel.FileInfo{}
+			var deleteAt sql.NullInt64
+			var archived sql.NullBool
+			err := rows.Scan(
+				&fileInfo.Id,
+				&fileInfo.CreateAt,
+				&deleteAt,
+				&fileInfo.Name,
+				&fileInfo.Extension,
+				&fileInfo.Size,
+				&archived,
+			)
+			if err != nil {
+				s.CloseRows(rows)
+				return nil, err
+			}
+			fileInfo.DeleteAt = deleteAt.Int64
+			fileInfo.Archived = archived.Bool
+			fileInfos = append(fileInfos, &fileInfo)
+		}
+		s.CloseRows(rows)
+	}
+
+	return fileInfos, nil
+}
+
+// setFileInfosDeleteAt marks the file infos as deleted at the given time,
+// or restores them if deleteAt is zero. Deleted file infos are kept so
+// the files can be recovered until a cleanup job reclaims them.
+func (s *SQLStore) setFileInfosDeleteAt(db sq.BaseRunner, ids []string, deleteAt int64) error {
+	for start := 0; start < len(ids); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"file_info").
+			Set("delete_at", deleteAt).
+			Where(sq.Eq{"id": ids[start:end]})
+
+		if deleteAt != 0 {
+			query = query.Where(sq.Eq{"COALESCE(delete_at, 0)": 0})
+		}
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("setFileInfosDeleteAt error", mlog.Err(err))
+			return err
+		}
+	}
+
+	return nil
+}
@@ -36,6 +36,8 @@ func legacyBoardFields(prefix string) []string {
 		"create_at",
 		"update_at",
 		"delete_at",
+		"''", // substitute for creation_source column.
+		"''", // substitute for source_id column.
 	}
 
 	if prefix == "" {
@@ -0,0 +1,11 @@
+{{if .mysql}}
+DROP INDEX idx_boards_source_id ON {{.prefix}}boards;
+{{else}}
+DROP INDEX idx_boards_source_id;
+{{end}}
+
+ALTER TABLE {{.prefix}}boards DROP COLUMN creation_source;
+ALTER TABLE {{.prefix}}boards DROP COLUMN source_id;
+
+ALTER TABLE {{.prefix}}boards_history DROP COLUMN creation_source;
+ALTER TABLE {{.prefix}}boards_history DROP COLUMN source_id;
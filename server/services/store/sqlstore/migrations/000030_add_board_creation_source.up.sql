@@ -0,0 +1,7 @@
+ALTER TABLE {{.prefix}}boards ADD COLUMN creation_source varchar(64);
+ALTER TABLE {{.prefix}}boards ADD COLUMN source_id varchar(36);
+
+ALTER TABLE {{.prefix}}boards_history ADD COLUMN creation_source varchar(64);
+ALTER TABLE {{.prefix}}boards_history ADD COLUMN source_id varchar(36);
+
+CREATE INDEX idx_boards_source_id ON {{.prefix}}boards(source_id);
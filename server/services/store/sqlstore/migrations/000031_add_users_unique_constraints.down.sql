@@ -0,0 +1,10 @@
+{{if .mysql}}
+DROP INDEX idx_users_username ON {{.prefix}}users;
+DROP INDEX idx_users_email ON {{.prefix}}users;
+{{else}}
+DROP INDEX idx_users_username;
+DROP INDEX idx_users_email;
+{{end}}
+
+UPDATE {{.prefix}}users SET username = '' WHERE username IS NULL;
+UPDATE {{.prefix}}users SET email = '' WHERE email IS NULL;
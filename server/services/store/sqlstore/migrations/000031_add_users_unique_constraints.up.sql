@@ -0,0 +1,34 @@
+UPDATE {{.prefix}}users SET username = NULL WHERE username = '';
+UPDATE {{.prefix}}users SET email = NULL WHERE email = '';
+
+-- existing installs may already have duplicate usernames or emails. The
+-- oldest user keeps the value, and the later duplicates are suffixed
+-- with their ID so the unique indexes can be created
+{{if .mysql}}
+UPDATE {{.prefix}}users AS U
+  INNER JOIN {{.prefix}}users AS D ON D.username = U.username
+    AND (COALESCE(D.create_at, 0) < COALESCE(U.create_at, 0) OR (COALESCE(D.create_at, 0) = COALESCE(U.create_at, 0) AND D.id < U.id))
+  SET U.username = CONCAT(SUBSTR(U.username, 1, 60), '-', U.id);
+
+UPDATE {{.prefix}}users AS U
+  INNER JOIN {{.prefix}}users AS D ON D.email = U.email
+    AND (COALESCE(D.create_at, 0) < COALESCE(U.create_at, 0) OR (COALESCE(D.create_at, 0) = COALESCE(U.create_at, 0) AND D.id < U.id))
+  SET U.email = CONCAT(SUBSTR(U.email, 1, 200), '-', U.id);
+{{else}}
+UPDATE {{.prefix}}users SET username = SUBSTR(username, 1, 60) || '-' || id
+  WHERE EXISTS (
+    SELECT 1 FROM {{.prefix}}users AS D
+    WHERE D.username = {{.prefix}}users.username
+      AND (COALESCE(D.create_at, 0) < COALESCE({{.prefix}}users.create_at, 0) OR (COALESCE(D.create_at, 0) = COALESCE({{.prefix}}users.create_at, 0) AND D.id < {{.prefix}}users.id))
+  );
+
+UPDATE {{.prefix}}users SET email = SUBSTR(email, 1, 200) || '-' || id
+  WHERE EXISTS (
+    SELECT 1 FROM {{.prefix}}users AS D
+    WHERE D.email = {{.prefix}}users.email
+      AND (COALESCE(D.create_at, 0) < COALESCE({{.prefix}}users.create_at, 0) OR (COALESCE(D.create_at, 0) = COALESCE({{.prefix}}users.create_at, 0) AND D.id < {{.prefix}}users.id))
+  );
+{{end}}
+
+CREATE UNIQUE INDEX idx_users_username ON {{.prefix}}users(username);
+CREATE UNIQUE INDEX idx_users_email ON {{.prefix}}users(email);
@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}blocks DROP COLUMN archived_at;
+ALTER TABLE {{.prefix}}blocks_history DROP COLUMN archived_at;
@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}blocks ADD COLUMN archived_at BIGINT DEFAULT 0;
+ALTER TABLE {{.prefix}}blocks_history ADD COLUMN archived_at BIGINT DEFAULT 0;
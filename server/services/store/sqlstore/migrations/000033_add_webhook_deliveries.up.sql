@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}webhook_deliveries (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload {{if .mysql}}LONGTEXT{{else}}TEXT{{end}},
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_retry_at BIGINT NOT NULL DEFAULT 0,
+    status INTEGER NOT NULL DEFAULT 0,
+    create_at BIGINT,
+    update_at BIGINT,
+    delivered_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_webhookdeliveries_delivered_at_next_retry_at ON {{.prefix}}webhook_deliveries(delivered_at, next_retry_at);
+CREATE INDEX idx_webhookdeliveries_board_id ON {{.prefix}}webhook_deliveries(board_id);
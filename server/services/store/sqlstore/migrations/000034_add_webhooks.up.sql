@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}webhooks (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(255),
+    event_types TEXT,
+    created_by VARCHAR(36),
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_webhooks_board_id ON {{.prefix}}webhooks(board_id);
@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}blocks DROP COLUMN sort_order;
+ALTER TABLE {{.prefix}}blocks_history DROP COLUMN sort_order;
@@ -0,0 +1,5 @@
+ALTER TABLE {{.prefix}}blocks ADD COLUMN sort_order BIGINT DEFAULT 0;
+ALTER TABLE {{.prefix}}blocks_history ADD COLUMN sort_order BIGINT DEFAULT 0;
+
+{{- /* existing blocks keep their creation order until their card content order is normalized */ -}}
+UPDATE {{.prefix}}blocks SET sort_order = create_at;
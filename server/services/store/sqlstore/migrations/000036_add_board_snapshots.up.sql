@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_snapshots (
+    board_id VARCHAR(36) NOT NULL,
+    data {{if .mysql}}LONGTEXT{{else}}TEXT{{end}},
+    created_by VARCHAR(36),
+    create_at BIGINT,
+    PRIMARY KEY (board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
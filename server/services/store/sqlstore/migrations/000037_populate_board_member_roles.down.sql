@@ -0,0 +1 @@
+UPDATE {{.prefix}}board_members SET roles = '';
@@ -0,0 +1,8 @@
+UPDATE {{.prefix}}board_members SET roles = CASE
+    WHEN scheme_admin THEN 'admin'
+    WHEN scheme_editor THEN 'editor'
+    WHEN scheme_commenter THEN 'commenter'
+    WHEN scheme_viewer THEN 'viewer'
+    ELSE 'editor'
+END
+WHERE roles IS NULL OR roles = '';
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_favorites (
+    user_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (user_id, board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
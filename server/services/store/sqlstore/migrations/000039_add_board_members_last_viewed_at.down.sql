@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}board_members DROP COLUMN last_viewed_at;
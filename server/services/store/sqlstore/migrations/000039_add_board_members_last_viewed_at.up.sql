@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}board_members ADD COLUMN last_viewed_at BIGINT DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}categories DROP COLUMN sort_order;
+ALTER TABLE {{.prefix}}categories DROP COLUMN icon;
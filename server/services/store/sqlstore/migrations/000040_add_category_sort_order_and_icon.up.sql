@@ -0,0 +1,5 @@
+ALTER TABLE {{.prefix}}categories ADD COLUMN sort_order BIGINT DEFAULT 0;
+ALTER TABLE {{.prefix}}categories ADD COLUMN icon VARCHAR(256) DEFAULT '';
+
+{{- /* existing categories keep their creation order until they are reordered */ -}}
+UPDATE {{.prefix}}categories SET sort_order = create_at;
@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}category_boards DROP COLUMN sort_order;
@@ -0,0 +1,4 @@
+ALTER TABLE {{.prefix}}category_boards ADD COLUMN sort_order BIGINT DEFAULT 0;
+
+{{- /* existing boards keep the order they were added to their category in */ -}}
+UPDATE {{.prefix}}category_boards SET sort_order = create_at;
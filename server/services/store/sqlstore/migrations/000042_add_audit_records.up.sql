@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}audit_records (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    actor_id VARCHAR(36),
+    action VARCHAR(64) NOT NULL,
+    resource_id VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_auditrecords_board_id_create_at ON {{.prefix}}audit_records(board_id, create_at);
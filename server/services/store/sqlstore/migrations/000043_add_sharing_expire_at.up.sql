@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}sharing ADD COLUMN expire_at BIGINT DEFAULT 0;
@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}category_templates;
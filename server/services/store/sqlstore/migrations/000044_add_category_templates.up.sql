@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}category_templates (
+    id VARCHAR(36) NOT NULL,
+    team_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    sort_order BIGINT DEFAULT 0,
+    icon VARCHAR(256) DEFAULT '',
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_categorytemplates_team_id ON {{.prefix}}category_templates(team_id);
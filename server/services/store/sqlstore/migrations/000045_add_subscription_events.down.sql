@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}subscriptions DROP COLUMN events;
@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}subscriptions ADD COLUMN events BIGINT DEFAULT 0;
@@ -0,0 +1,7 @@
+{{if .postgres}}
+DROP INDEX idx_blocks_title_fts;
+{{end}}
+
+{{if .mysql}}
+DROP INDEX idx_blocks_title_fts ON {{.prefix}}blocks;
+{{end}}
@@ -0,0 +1,8 @@
+{{- /* full text index used by block search, SQLite falls back to LIKE matching */ -}}
+{{if .postgres}}
+CREATE INDEX idx_blocks_title_fts ON {{.prefix}}blocks USING GIN (to_tsvector('simple', COALESCE(title, '')));
+{{end}}
+
+{{if .mysql}}
+CREATE FULLTEXT INDEX idx_blocks_title_fts ON {{.prefix}}blocks (title);
+{{end}}
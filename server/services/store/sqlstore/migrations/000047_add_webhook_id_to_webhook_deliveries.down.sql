@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}webhook_deliveries DROP COLUMN webhook_id;
@@ -0,0 +1,3 @@
+ALTER TABLE {{.prefix}}board_members DROP COLUMN custom_role_id;
+
+DROP TABLE {{.prefix}}board_custom_roles;
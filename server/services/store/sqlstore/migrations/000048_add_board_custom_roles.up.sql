@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_custom_roles (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    permissions BIGINT DEFAULT 0,
+    created_by VARCHAR(36),
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_boardcustomroles_board_id ON {{.prefix}}board_custom_roles(board_id);
+
+ALTER TABLE {{.prefix}}board_members ADD COLUMN custom_role_id VARCHAR(36) DEFAULT '';
@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}share_tokens;
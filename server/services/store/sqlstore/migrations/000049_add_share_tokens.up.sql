@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}share_tokens (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    token VARCHAR(100) NOT NULL,
+    scope VARCHAR(10) NOT NULL,
+    card_id VARCHAR(36) DEFAULT '',
+    password_hash VARCHAR(128) DEFAULT '',
+    expire_at BIGINT DEFAULT 0,
+    created_by VARCHAR(36),
+    create_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE UNIQUE INDEX idx_sharetokens_token ON {{.prefix}}share_tokens(token);
+CREATE INDEX idx_sharetokens_board_id ON {{.prefix}}share_tokens(board_id);
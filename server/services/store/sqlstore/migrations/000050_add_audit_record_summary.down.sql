@@ -0,0 +1,7 @@
+{{if .mysql}}
+DROP INDEX idx_auditrecords_create_at ON {{.prefix}}audit_records;
+{{else}}
+DROP INDEX idx_auditrecords_create_at;
+{{end}}
+
+ALTER TABLE {{.prefix}}audit_records DROP COLUMN summary;
@@ -0,0 +1,3 @@
+ALTER TABLE {{.prefix}}audit_records ADD COLUMN summary VARCHAR(1024) DEFAULT '';
+
+CREATE INDEX idx_auditrecords_create_at ON {{.prefix}}audit_records(create_at);
@@ -0,0 +1,5 @@
+{{if .mysql}}
+DROP INDEX idx_blocks_board_id_type_create_at ON {{.prefix}}blocks;
+{{else}}
+DROP INDEX idx_blocks_board_id_type_create_at;
+{{end}}
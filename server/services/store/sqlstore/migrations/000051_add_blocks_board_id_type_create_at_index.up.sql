@@ -0,0 +1,6 @@
+{{- /* recent comments of a board are listed by type and create time */ -}}
+{{if .mysql}}
+CREATE INDEX idx_blocks_board_id_type_create_at ON {{.prefix}}blocks (board_id, type(32), create_at);
+{{else}}
+CREATE INDEX idx_blocks_board_id_type_create_at ON {{.prefix}}blocks (board_id, type, create_at);
+{{end}}
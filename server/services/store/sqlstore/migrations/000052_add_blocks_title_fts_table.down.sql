@@ -0,0 +1,6 @@
+{{if .sqlite}}
+DROP TRIGGER IF EXISTS {{.prefix}}blocks_title_fts_update;
+DROP TRIGGER IF EXISTS {{.prefix}}blocks_title_fts_delete;
+DROP TRIGGER IF EXISTS {{.prefix}}blocks_title_fts_insert;
+DROP TABLE IF EXISTS {{.prefix}}blocks_title_fts;
+{{end}}
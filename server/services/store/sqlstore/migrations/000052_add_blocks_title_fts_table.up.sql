@@ -0,0 +1,24 @@
+{{- /* SQLite keeps the full text index of the block titles in an FTS5 table, synced by triggers */ -}}
+{{if .sqlite}}
+CREATE VIRTUAL TABLE IF NOT EXISTS {{.prefix}}blocks_title_fts USING fts5(
+    title,
+    content='{{.prefix}}blocks',
+    content_rowid='rowid',
+    tokenize='unicode61 remove_diacritics 2'
+);
+
+INSERT INTO {{.prefix}}blocks_title_fts ({{.prefix}}blocks_title_fts) VALUES ('rebuild');
+
+CREATE TRIGGER IF NOT EXISTS {{.prefix}}blocks_title_fts_insert AFTER INSERT ON {{.prefix}}blocks BEGIN
+    INSERT INTO {{.prefix}}blocks_title_fts (rowid, title) VALUES (new.rowid, new.title);
+END;
+
+CREATE TRIGGER IF NOT EXISTS {{.prefix}}blocks_title_fts_delete AFTER DELETE ON {{.prefix}}blocks BEGIN
+    INSERT INTO {{.prefix}}blocks_title_fts ({{.prefix}}blocks_title_fts, rowid, title) VALUES ('delete', old.rowid, old.title);
+END;
+
+CREATE TRIGGER IF NOT EXISTS {{.prefix}}blocks_title_fts_update AFTER UPDATE OF title ON {{.prefix}}blocks BEGIN
+    INSERT INTO {{.prefix}}blocks_title_fts ({{.prefix}}blocks_title_fts, rowid, title) VALUES ('delete', old.rowid, old.title);
+    INSERT INTO {{.prefix}}blocks_title_fts (rowid, title) VALUES (new.rowid, new.title);
+END;
+{{end}}
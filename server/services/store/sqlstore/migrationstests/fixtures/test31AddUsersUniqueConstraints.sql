@@ -0,0 +1,8 @@
+INSERT INTO focalboard_users
+(id, username, email, create_at)
+VALUES
+('user-1', 'johndoe', 'john@example.com', 100),
+('user-2', 'johndoe', 'other@example.com', 200),
+('user-3', 'janedoe', 'john@example.com', 300),
+('user-4', '', '', 400),
+('user-5', '', '', 500);
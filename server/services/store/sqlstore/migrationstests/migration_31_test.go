@@ -0,0 +1,52 @@
+package migrationstests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test31AddUsersUniqueConstraints(t *testing.T) {
+	t.Run("should rename the duplicated usernames and emails", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.f.MigrateToStep(30).
+			ExecFile("./fixtures/test31AddUsersUniqueConstraints.sql")
+
+		// we apply the migration
+		th.f.MigrateToStep(31)
+
+		users := []struct {
+			ID       string
+			Username *string
+			Email    *string
+		}{}
+		err := th.f.DB().Select(&users, "SELECT id, username, email FROM focalboard_users ORDER BY id")
+		require.NoError(t, err)
+		require.Len(t, users, 5)
+
+		value := func(s *string) string {
+			if s == nil {
+				return ""
+			}
+			return *s
+		}
+
+		// the oldest users keep their values
+		require.Equal(t, "johndoe", value(users[0].Username))
+		require.Equal(t, "john@example.com", value(users[0].Email))
+
+		require.Equal(t, "johndoe-user-2", value(users[1].Username))
+		require.Equal(t, "other@example.com", value(users[1].Email))
+
+		require.Equal(t, "janedoe", value(users[2].Username))
+		require.Equal(t, "john@example.com-user-3", value(users[2].Email))
+
+		// empty values aren't duplicates
+		for _, user := range users[3:] {
+			require.Nil(t, user.Username)
+			require.Nil(t, user.Email)
+		}
+	})
+}
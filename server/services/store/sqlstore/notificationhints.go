@@ -15,6 +15,11 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+// notificationHintClaimLease is how long a hint fetched by getNotificationHints
+// stays hidden from other workers. A worker that fails to delete the hint in
+// time, e.g. because it crashed, leaves it to be picked up again.
+const notificationHintClaimLease = 5 * time.Minute
+
 var notificationHintFields = []string{
 	"block_type",
 	"block_id",
@@ -108,6 +113,24 @@ func (s *SQLStore) deleteNotificationHint(db sq.BaseRunner, blockID string) erro
 	return nil
 }
 
+// deleteNotificationHintsForBlock deletes the notification hint for the
+// specified block if there is one. Unlike deleteNotificationHint, a
+// missing hint is not considered an error.
+func (s *SQLStore) deleteNotificationHintsForBlock(db sq.BaseRunner, blockID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "notification_hints").
+		Where(sq.Eq{"block_id": blockID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot delete notification hints for block",
+			mlog.String("block_id", blockID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
 // getNotificationHint fetches the notification hint for the specified block.
 func (s *SQLStore) getNotificationHint(db sq.BaseRunner, blockID string) (*model.NotificationHint, error) {
 	query := s.getQueryBuilder(db).
@@ -192,3 +215,154 @@ func (s *SQLStore) getNextNotificationHint(db sq.BaseRunner, remove bool) (*mode
 
 	return hint, nil
 }
+
+// getDueNotificationHints claims up to limit notification hints scheduled
+// at or before now, oldest first. As with getNextNotificationHint, claimed
+// hints are removed from the database, and hints that another node
+// removed concurrently are left out of the results.
+func (s *SQLStore) getDueNotificationHints(db sq.BaseRunner, now int64, limit int) ([]*model.NotificationHint, error) {
+	selectQuery := s.getQueryBuilder(db).
+		Select(notificationHintFields...).
+		From(s.tablePrefix+"notification_hints").
+		Where(sq.LtOrEq{"notify_at": now}).
+		OrderBy("notify_at", "block_id")
+
+	if limit > 0 {
+		selectQuery = selectQuery.Limit(uint64(limit))
+	}
+
+	rows, err := selectQuery.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch due notification hints",
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	hints, err := s.notificationHintFromRows(rows)
+	s.CloseRows(rows)
+	if err != nil {
+		s.logger.Error("Cannot get due notification hints",
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	claimed := make([]*model.NotificationHint, 0, len(hints))
+	for _, hint := range hints {
+		deleteQuery := s.getQueryBuilder(db).
+			Delete(s.tablePrefix + "notification_hints").
+			Where(sq.Eq{"block_id": hint.BlockID}).
+			Where(sq.Eq{"notify_at": hint.NotifyAt})
+
+		result, err := deleteQuery.Exec()
+		if err != nil {
+			return nil, fmt.Errorf("cannot delete while getting due notification hints: %w", err)
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("cannot verify delete while getting due notification hints: %w", err)
+		}
+		if count == 0 {
+			// another node has grabbed this hint, or it has been
+			// rescheduled; either way it's not ours to process
+			continue
+		}
+		claimed = append(claimed, hint)
+	}
+
+	return claimed, nil
+}
+
+// getNotificationHints fetches up to limit notification hints that are due,
+// oldest first. The fetched hints are leased to the caller by pushing their
+// notify_at forward, so concurrent workers never receive the same hint; the
+// caller removes them with deleteNotificationHints once processed.
+func (s *SQLStore) getNotificationHints(db sq.BaseRunner, limit int) ([]*model.NotificationHint, error) {
+	now := utils.GetMillis()
+
+	selectQuery := s.getQueryBuilder(db).
+		Select(notificationHintFields...).
+		From(s.tablePrefix+"notification_hints").
+		Where(sq.LtOrEq{"notify_at": now}).
+		OrderBy("create_at", "block_id")
+
+	if limit > 0 {
+		selectQuery = selectQuery.Limit(uint64(limit))
+	}
+
+	// SQLite serializes writers, so only the other databases need to lock
+	// the claimed rows
+	if s.dbType != model.SqliteDBType {
+		selectQuery = selectQuery.Suffix("FOR UPDATE SKIP LOCKED")
+	}
+
+	rows, err := selectQuery.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch notification hints",
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	hints, err := s.notificationHintFromRows(rows)
+	s.CloseRows(rows)
+	if err != nil {
+		s.logger.Error("Cannot get notification hints",
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	leaseUntil := utils.GetMillisForTime(time.Now().Add(notificationHintClaimLease))
+
+	claimed := make([]*model.NotificationHint, 0, len(hints))
+	for _, hint := range hints {
+		claimQuery := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"notification_hints").
+			Set("notify_at", leaseUntil).
+			Where(sq.Eq{"block_id": hint.BlockID}).
+			Where(sq.Eq{"notify_at": hint.NotifyAt})
+
+		result, err := claimQuery.Exec()
+		if err != nil {
+			return nil, fmt.Errorf("cannot claim while getting notification hints: %w", err)
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("cannot verify claim while getting notification hints: %w", err)
+		}
+		if count == 0 {
+			// another worker has claimed this hint, or it has been
+			// rescheduled; either way it's not ours to process
+			continue
+		}
+		claimed = append(claimed, hint)
+	}
+
+	return claimed, nil
+}
+
+// deleteNotificationHints removes the notification hints of the given blocks.
+// Block IDs without a hint are ignored.
+func (s *SQLStore) deleteNotificationHints(db sq.BaseRunner, blockIDs []string) error {
+	for start := 0; start < len(blockIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(blockIDs) {
+			end = len(blockIDs)
+		}
+
+		query := s.getQueryBuilder(db).
+			Delete(s.tablePrefix + "notification_hints").
+			Where(sq.Eq{"block_id": blockIDs[start:end]})
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("Cannot delete notification hints",
+				mlog.Int("count", end-start),
+				mlog.Err(err),
+			)
+			return err
+		}
+	}
+	return nil
+}
@@ -8,7 +8,8 @@
 // prefix it with a @withTransaction comment if you need it to be
 // transactional and then add a private method in the store itself
 // with db sq.BaseRunner as the first parameter before running `make
-// generate`
+// generate`. The context of the public method is carried by the db
+// runner, so the private method doesn't take one
 
 package sqlstore
 
@@ -22,15 +23,15 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, blockID string) error {
+func (s *SQLStore) AddUpdateCategoryBoard(ctx context.Context, userID string, categoryID string, blockID string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.addUpdateCategoryBoard(s.db, userID, categoryID, blockID)
+		return s.addUpdateCategoryBoard(withContext(ctx, s.db), userID, categoryID, blockID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
 		return txErr
 	}
-	err := s.addUpdateCategoryBoard(tx, userID, categoryID, blockID)
+	err := s.addUpdateCategoryBoard(withContext(ctx, tx), userID, categoryID, blockID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "AddUpdateCategoryBoard"))
@@ -46,96 +47,168 @@ func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, bloc
 
 }
 
-func (s *SQLStore) CanSeeUser(seerID string, seenID string) (bool, error) {
-	return s.canSeeUser(s.db, seerID, seenID)
+func (s *SQLStore) ArchiveBoard(ctx context.Context, boardID string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.archiveBoard(withContext(ctx, s.db), boardID, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.archiveBoard(withContext(ctx, tx), boardID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "ArchiveBoard"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) ArchiveCard(ctx context.Context, cardID string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.archiveCard(withContext(ctx, s.db), cardID, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.archiveCard(withContext(ctx, tx), cardID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "ArchiveCard"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) CanSeeUser(ctx context.Context, seerID string, seenID string) (bool, error) {
+	return s.canSeeUser(withContext(ctx, s.db), seerID, seenID)
 
 }
 
-func (s *SQLStore) CleanUpSessions(expireTime int64) error {
-	return s.cleanUpSessions(s.db, expireTime)
+func (s *SQLStore) CleanUpSessions(ctx context.Context, idleTimeout time.Duration, maxLifetime time.Duration, batchSize int64) (int64, error) {
+	return s.cleanUpSessions(withContext(ctx, s.db), idleTimeout, maxLifetime, batchSize)
 
 }
 
-func (s *SQLStore) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+func (s *SQLStore) ClearCategory(ctx context.Context, userID string, categoryID string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.createBoardsAndBlocks(s.db, bab, userID)
+		return s.clearCategory(withContext(ctx, s.db), userID, categoryID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
-		return nil, txErr
+		return txErr
 	}
-	result, err := s.createBoardsAndBlocks(tx, bab, userID)
+	err := s.clearCategory(withContext(ctx, tx), userID, categoryID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocks"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "ClearCategory"))
 		}
-		return nil, err
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
-	return result, nil
+	return nil
+
+}
+
+func (s *SQLStore) CompareAndSetSystemSetting(ctx context.Context, key string, expectedOld string, newValue string) (bool, error) {
+	return s.compareAndSetSystemSetting(withContext(ctx, s.db), key, expectedOld, newValue)
 
 }
 
-func (s *SQLStore) CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+func (s *SQLStore) CopyBlocks(ctx context.Context, blockIDs []string, targetBoardID string, userID string) (map[string]string, error) {
 	if s.dbType == model.SqliteDBType {
-		return s.createBoardsAndBlocksWithAdmin(s.db, bab, userID)
+		return s.copyBlocks(withContext(ctx, s.db), blockIDs, targetBoardID, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
-		return nil, nil, txErr
+		return nil, txErr
 	}
-	result, resultVar1, err := s.createBoardsAndBlocksWithAdmin(tx, bab, userID)
+	result, err := s.copyBlocks(withContext(ctx, tx), blockIDs, targetBoardID, userID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocksWithAdmin"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CopyBlocks"))
 		}
-		return nil, nil, err
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return result, resultVar1, nil
+	return result, nil
 
 }
 
-func (s *SQLStore) CreateCategory(category model.Category) error {
-	return s.createCategory(s.db, category)
+func (s *SQLStore) CountBoardsCreatedBetween(ctx context.Context, teamID string, start int64, end int64) (int64, error) {
+	return s.countBoardsCreatedBetween(withContext(ctx, s.db), teamID, start, end)
 
 }
 
-func (s *SQLStore) CreateSession(session *model.Session) error {
-	return s.createSession(s.db, session)
+func (s *SQLStore) CountBoardsCreatedBetweenAllTeams(ctx context.Context, start int64, end int64) (int64, error) {
+	return s.countBoardsCreatedBetweenAllTeams(withContext(ctx, s.db), start, end)
 
 }
 
-func (s *SQLStore) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
-	return s.createSubscription(s.db, sub)
+func (s *SQLStore) CountCardsByPropertyGrouped(ctx context.Context, boardID string, propertyID string) (map[string]int64, error) {
+	return s.countCardsByPropertyGrouped(withContext(ctx, s.db), boardID, propertyID)
 
 }
 
-func (s *SQLStore) CreateUser(user *model.User) (*model.User, error) {
-	return s.createUser(s.db, user)
+func (s *SQLStore) CreateBoardComplete(ctx context.Context, board *model.Board, creatorID string, extraMembers []*model.BoardMember, categoryID string) (*model.Board, []*model.BoardMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.createBoardComplete(withContext(ctx, s.db), board, creatorID, extraMembers, categoryID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, nil, txErr
+	}
+	result, resultVar1, err := s.createBoardComplete(withContext(ctx, tx), board, creatorID, extraMembers, categoryID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardComplete"))
+		}
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return result, resultVar1, nil
 
 }
 
-func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
+func (s *SQLStore) CreateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole) error {
 	if s.dbType == model.SqliteDBType {
-		return s.deleteBlock(s.db, blockID, modifiedBy)
+		return s.createBoardCustomRole(withContext(ctx, s.db), role)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
 		return txErr
 	}
-	err := s.deleteBlock(tx, blockID, modifiedBy)
+	err := s.createBoardCustomRole(withContext(ctx, tx), role)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBlock"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardCustomRole"))
 		}
 		return err
 	}
@@ -148,42 +221,71 @@ func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
 
 }
 
-func (s *SQLStore) DeleteBoard(boardID string, userID string) error {
+func (s *SQLStore) CreateBoardsAndBlocks(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
 	if s.dbType == model.SqliteDBType {
-		return s.deleteBoard(s.db, boardID, userID)
+		return s.createBoardsAndBlocks(withContext(ctx, s.db), bab, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
-		return txErr
+		return nil, txErr
 	}
-	err := s.deleteBoard(tx, boardID, userID)
+	result, err := s.createBoardsAndBlocks(withContext(ctx, tx), bab, userID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoard"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocks"))
 		}
-		return err
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return result, nil
+
+}
+
+func (s *SQLStore) CreateBoardsAndBlocksWithAdmin(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.createBoardsAndBlocksWithAdmin(withContext(ctx, s.db), bab, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, nil, txErr
+	}
+	result, resultVar1, err := s.createBoardsAndBlocksWithAdmin(withContext(ctx, tx), bab, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocksWithAdmin"))
+		}
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return result, resultVar1, nil
+
+}
+
+func (s *SQLStore) CreateCategory(ctx context.Context, category model.Category) error {
+	return s.createCategory(withContext(ctx, s.db), category)
 
 }
 
-func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error {
+func (s *SQLStore) CreateDefaultCategoriesForUser(ctx context.Context, userID string, teamID string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.deleteBoardsAndBlocks(s.db, dbab, userID)
+		return s.createDefaultCategoriesForUser(withContext(ctx, s.db), userID, teamID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
 		return txErr
 	}
-	err := s.deleteBoardsAndBlocks(tx, dbab, userID)
+	err := s.createDefaultCategoriesForUser(withContext(ctx, tx), userID, teamID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardsAndBlocks"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateDefaultCategoriesForUser"))
 		}
 		return err
 	}
@@ -196,371 +298,1696 @@ func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, user
 
 }
 
-func (s *SQLStore) DeleteCategory(categoryID string, userID string, teamID string) error {
-	return s.deleteCategory(s.db, categoryID, userID, teamID)
+func (s *SQLStore) CreateSession(ctx context.Context, session *model.Session) error {
+	return s.createSession(withContext(ctx, s.db), session)
 
 }
 
-func (s *SQLStore) DeleteMember(boardID string, userID string) error {
-	return s.deleteMember(s.db, boardID, userID)
+func (s *SQLStore) CreateShareToken(ctx context.Context, shareToken *model.ShareToken) error {
+	if s.dbType == model.SqliteDBType {
+		return s.createShareToken(withContext(ctx, s.db), shareToken)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.createShareToken(withContext(ctx, tx), shareToken)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateShareToken"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) DeleteNotificationHint(blockID string) error {
-	return s.deleteNotificationHint(s.db, blockID)
+	return nil
 
 }
 
-func (s *SQLStore) DeleteSession(sessionID string) error {
-	return s.deleteSession(s.db, sessionID)
+func (s *SQLStore) CreateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	return s.createSubscription(withContext(ctx, s.db), sub)
 
 }
 
-func (s *SQLStore) DeleteSubscription(blockID string, subscriberID string) error {
-	return s.deleteSubscription(s.db, blockID, subscriberID)
+func (s *SQLStore) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	return s.createUser(withContext(ctx, s.db), user)
 
 }
 
-func (s *SQLStore) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
+func (s *SQLStore) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
 	if s.dbType == model.SqliteDBType {
-		return s.duplicateBlock(s.db, boardID, blockID, userID, asTemplate)
+		return s.createWebhook(withContext(ctx, s.db), webhook)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
-		return nil, txErr
+		return txErr
 	}
-	result, err := s.duplicateBlock(tx, boardID, blockID, userID, asTemplate)
+	err := s.createWebhook(withContext(ctx, tx), webhook)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBlock"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateWebhook"))
 		}
-		return nil, err
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
-	return result, nil
+	return nil
 
 }
 
-func (s *SQLStore) DuplicateBoard(boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+func (s *SQLStore) DeactivateUser(ctx context.Context, userID string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.duplicateBoard(s.db, boardID, userID, toTeam, asTemplate)
+		return s.deactivateUser(withContext(ctx, s.db), userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
-		return nil, nil, txErr
+		return txErr
 	}
-	result, resultVar1, err := s.duplicateBoard(tx, boardID, userID, toTeam, asTemplate)
+	err := s.deactivateUser(withContext(ctx, tx), userID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBoard"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeactivateUser"))
 		}
-		return nil, nil, err
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	return result, resultVar1, nil
+	return nil
 
 }
 
-func (s *SQLStore) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
-	return s.getActiveUserCount(s.db, updatedSecondsAgo)
+func (s *SQLStore) DeleteBlock(ctx context.Context, blockID string, modifiedBy string) (int64, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBlock(withContext(ctx, s.db), blockID, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return 0, txErr
+	}
+	result, err := s.deleteBlock(withContext(ctx, tx), blockID, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBlock"))
+		}
+		return 0, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
 
-func (s *SQLStore) GetAllTeams() ([]*model.Team, error) {
-	return s.getAllTeams(s.db)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetBlock(blockID string) (*model.Block, error) {
-	return s.getBlock(s.db, blockID)
+func (s *SQLStore) DeleteBoard(ctx context.Context, boardID string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBoard(withContext(ctx, s.db), boardID, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteBoard(withContext(ctx, tx), boardID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoard"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) GetBlockCountsByType() (map[string]int64, error) {
-	return s.getBlockCountsByType(s.db)
+	return nil
 
 }
 
-func (s *SQLStore) GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
-	return s.getBlockHistory(s.db, blockID, opts)
+func (s *SQLStore) DeleteBoardCustomRole(ctx context.Context, id string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBoardCustomRole(withContext(ctx, s.db), id, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteBoardCustomRole(withContext(ctx, tx), id, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardCustomRole"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
-	return s.getBlockHistoryDescendants(s.db, boardID, opts)
+	return nil
 
 }
 
-func (s *SQLStore) GetBlocks(opts model.QueryBlocksOptions) ([]*model.Block, error) {
-	return s.getBlocks(s.db, opts)
+func (s *SQLStore) DeleteBoardsAndBlocks(ctx context.Context, dbab *model.DeleteBoardsAndBlocks, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBoardsAndBlocks(withContext(ctx, s.db), dbab, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteBoardsAndBlocks(withContext(ctx, tx), dbab, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardsAndBlocks"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
 
 }
 
-func (s *SQLStore) GetBlocksByIDs(ids []string) ([]*model.Block, error) {
-	return s.getBlocksByIDs(s.db, ids)
+func (s *SQLStore) DeleteCategory(ctx context.Context, categoryID string, userID string, teamID string) error {
+	return s.deleteCategory(withContext(ctx, s.db), categoryID, userID, teamID)
 
 }
 
-func (s *SQLStore) GetBlocksForBoard(boardID string) ([]*model.Block, error) {
-	return s.getBlocksForBoard(s.db, boardID)
+func (s *SQLStore) DeleteMember(ctx context.Context, boardID string, userID string, modifiedBy string) (int64, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteMember(withContext(ctx, s.db), boardID, userID, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return 0, txErr
+	}
+	result, err := s.deleteMember(withContext(ctx, tx), boardID, userID, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteMember"))
+		}
+		return 0, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
 
-func (s *SQLStore) GetBlocksWithParent(boardID string, parentID string) ([]*model.Block, error) {
-	return s.getBlocksWithParent(s.db, boardID, parentID)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetBlocksWithParentAndType(boardID string, parentID string, blockType string) ([]*model.Block, error) {
-	return s.getBlocksWithParentAndType(s.db, boardID, parentID, blockType)
+func (s *SQLStore) DeleteMembers(ctx context.Context, boardID string, userIDs []string, modifiedBy string) (int, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteMembers(withContext(ctx, s.db), boardID, userIDs, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return 0, txErr
+	}
+	result, err := s.deleteMembers(withContext(ctx, tx), boardID, userIDs, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteMembers"))
+		}
+		return 0, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
 
-func (s *SQLStore) GetBlocksWithType(boardID string, blockType string) ([]*model.Block, error) {
-	return s.getBlocksWithType(s.db, boardID, blockType)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetBoard(id string) (*model.Board, error) {
-	return s.getBoard(s.db, id)
+func (s *SQLStore) DeleteNotificationHint(ctx context.Context, blockID string) error {
+	return s.deleteNotificationHint(withContext(ctx, s.db), blockID)
 
 }
 
-func (s *SQLStore) GetBoardAndCard(block *model.Block) (*model.Board, *model.Block, error) {
-	return s.getBoardAndCard(s.db, block)
+func (s *SQLStore) DeleteNotificationHints(ctx context.Context, blockIDs []string) error {
+	return s.deleteNotificationHints(withContext(ctx, s.db), blockIDs)
 
 }
 
-func (s *SQLStore) GetBoardAndCardByID(blockID string) (*model.Board, *model.Block, error) {
-	return s.getBoardAndCardByID(s.db, blockID)
+func (s *SQLStore) DeleteSession(ctx context.Context, sessionID string) error {
+	return s.deleteSession(withContext(ctx, s.db), sessionID)
 
 }
 
-func (s *SQLStore) GetBoardCount() (int64, error) {
-	return s.getBoardCount(s.db)
+func (s *SQLStore) DeleteShareToken(ctx context.Context, boardID string, id string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteShareToken(withContext(ctx, s.db), boardID, id, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteShareToken(withContext(ctx, tx), boardID, id, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteShareToken"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
-	return s.getBoardHistory(s.db, boardID, opts)
+	return nil
 
 }
 
-func (s *SQLStore) GetBoardMemberHistory(boardID string, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
-	return s.getBoardMemberHistory(s.db, boardID, userID, limit)
+func (s *SQLStore) DeleteSubscription(ctx context.Context, blockID string, subscriberID string) (int64, error) {
+	return s.deleteSubscription(withContext(ctx, s.db), blockID, subscriberID)
 
 }
 
-func (s *SQLStore) GetBoardsForUserAndTeam(userID string, teamID string, includePublicBoards bool) ([]*model.Board, error) {
-	return s.getBoardsForUserAndTeam(s.db, userID, teamID, includePublicBoards)
+func (s *SQLStore) DeleteSubscriptionsForBlock(ctx context.Context, blockID string) error {
+	return s.deleteSubscriptionsForBlock(withContext(ctx, s.db), blockID)
 
 }
 
-func (s *SQLStore) GetBoardsInTeamByIds(boardIDs []string, teamID string) ([]*model.Board, error) {
-	return s.getBoardsInTeamByIds(s.db, boardIDs, teamID)
+func (s *SQLStore) DeleteTeam(ctx context.Context, teamID string) (int, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteTeam(withContext(ctx, s.db), teamID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return 0, txErr
+	}
+	result, err := s.deleteTeam(withContext(ctx, tx), teamID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteTeam"))
+		}
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) DeleteWebhook(ctx context.Context, id string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteWebhook(withContext(ctx, s.db), id, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteWebhook(withContext(ctx, tx), id, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteWebhook"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) DuplicateBlock(ctx context.Context, boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.duplicateBlock(withContext(ctx, s.db), boardID, blockID, userID, asTemplate)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.duplicateBlock(withContext(ctx, tx), boardID, blockID, userID, asTemplate)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBlock"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) DuplicateBoard(ctx context.Context, boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.duplicateBoard(withContext(ctx, s.db), boardID, userID, toTeam, asTemplate)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, nil, txErr
+	}
+	result, resultVar1, err := s.duplicateBoard(withContext(ctx, tx), boardID, userID, toTeam, asTemplate)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBoard"))
+		}
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return result, resultVar1, nil
+
+}
+
+func (s *SQLStore) DuplicateBoardWithOptions(ctx context.Context, boardID string, userID string, opts model.DuplicateBoardOptions) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.duplicateBoardWithOptions(withContext(ctx, s.db), boardID, userID, opts)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, nil, txErr
+	}
+	result, resultVar1, err := s.duplicateBoardWithOptions(withContext(ctx, tx), boardID, userID, opts)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBoardWithOptions"))
+		}
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return result, resultVar1, nil
+
+}
+
+func (s *SQLStore) FindDuplicateCategories(ctx context.Context, userID string, teamID string) (map[string][]model.Category, error) {
+	return s.findDuplicateCategories(withContext(ctx, s.db), userID, teamID)
+
+}
+
+func (s *SQLStore) GetActiveUserCount(ctx context.Context, updatedSecondsAgo int64) (int, error) {
+	return s.getActiveUserCount(withContext(ctx, s.db), updatedSecondsAgo)
+
+}
+
+func (s *SQLStore) GetActiveUserCountByTeam(ctx context.Context, teamID string, since int64) (int, error) {
+	return s.getActiveUserCountByTeam(withContext(ctx, s.db), teamID, since)
+
+}
+
+func (s *SQLStore) GetActiveUserCountsByDay(ctx context.Context, teamID string, from int64, to int64) (map[int64]int, error) {
+	return s.getActiveUserCountsByDay(withContext(ctx, s.db), teamID, from, to)
+
+}
+
+func (s *SQLStore) GetAllCategoriesForTeam(ctx context.Context, teamID string) ([]model.Category, error) {
+	return s.getAllCategoriesForTeam(withContext(ctx, s.db), teamID)
+
+}
+
+func (s *SQLStore) GetAllTeams(ctx context.Context) ([]*model.Team, error) {
+	return s.getAllTeams(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetArchivedBoards(ctx context.Context, teamID string) ([]*model.Board, error) {
+	return s.getArchivedBoards(withContext(ctx, s.db), teamID)
+
+}
+
+func (s *SQLStore) GetArchivedCards(ctx context.Context, boardID string) ([]*model.Block, error) {
+	return s.getArchivedCards(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetAuditRecords(ctx context.Context, boardID string, opts model.QueryAuditOptions) ([]*model.AuditRecord, error) {
+	return s.getAuditRecords(withContext(ctx, s.db), boardID, opts)
+
+}
+
+func (s *SQLStore) GetBlock(ctx context.Context, blockID string) (*model.Block, error) {
+	return s.getBlock(withContext(ctx, s.db), blockID)
+
+}
+
+func (s *SQLStore) GetBlockCountForTeam(ctx context.Context, teamID string) (int64, error) {
+	return s.getBlockCountForTeam(withContext(ctx, s.db), teamID)
+
+}
+
+func (s *SQLStore) GetBlockCountsByType(ctx context.Context) (map[string]int64, error) {
+	return s.getBlockCountsByType(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetBlockCountsByTypeForBoard(ctx context.Context, boardID string) (map[string]int64, error) {
+	return s.getBlockCountsByTypeForBoard(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetBlockCountsForTeams(ctx context.Context, teamIDs []string) (map[string]int64, error) {
+	return s.getBlockCountsForTeams(withContext(ctx, s.db), teamIDs)
+
+}
+
+func (s *SQLStore) GetBlockHistory(ctx context.Context, blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	return s.getBlockHistory(withContext(ctx, s.db), blockID, opts)
+
+}
+
+func (s *SQLStore) GetBlockHistoryDescendants(ctx context.Context, boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	return s.getBlockHistoryDescendants(withContext(ctx, s.db), boardID, opts)
+
+}
+
+func (s *SQLStore) GetBlockHistoryEntry(ctx context.Context, blockID string, version int64) (*model.Block, error) {
+	return s.getBlockHistoryEntry(withContext(ctx, s.db), blockID, version)
+
+}
+
+func (s *SQLStore) GetBlocks(ctx context.Context, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	return s.getBlocks(withContext(ctx, s.db), opts)
+
+}
+
+func (s *SQLStore) GetBlocksByIDs(ctx context.Context, ids []string) ([]*model.Block, error) {
+	return s.getBlocksByIDs(withContext(ctx, s.db), ids)
+
+}
+
+func (s *SQLStore) GetBlocksForBoard(ctx context.Context, boardID string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	return s.getBlocksForBoard(withContext(ctx, s.db), boardID, opts)
+
+}
+
+func (s *SQLStore) GetBlocksForBoardStream(ctx context.Context, boardID string, fn func(model.Block) error) error {
+	return s.getBlocksForBoardStream(withContext(ctx, s.db), boardID, fn)
+
+}
+
+func (s *SQLStore) GetBlocksForBoards(ctx context.Context, boardIDs []string, opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	return s.getBlocksForBoards(withContext(ctx, s.db), boardIDs, opts)
+
+}
+
+func (s *SQLStore) GetBlocksMap(ctx context.Context, boardID string, ids []string) (map[string]*model.Block, error) {
+	return s.getBlocksMap(withContext(ctx, s.db), boardID, ids)
+
+}
+
+func (s *SQLStore) GetBlocksWithParent(ctx context.Context, boardID string, parentID string) ([]*model.Block, error) {
+	return s.getBlocksWithParent(withContext(ctx, s.db), boardID, parentID)
+
+}
+
+func (s *SQLStore) GetBlocksWithParentAndType(ctx context.Context, boardID string, parentID string, blockType string) ([]*model.Block, error) {
+	return s.getBlocksWithParentAndType(withContext(ctx, s.db), boardID, parentID, blockType)
+
+}
+
+func (s *SQLStore) GetBlocksWithParentAndTypes(ctx context.Context, boardID string, parentID string, blockTypes []string) ([]*model.Block, error) {
+	return s.getBlocksWithParentAndTypes(withContext(ctx, s.db), boardID, parentID, blockTypes)
+
+}
+
+func (s *SQLStore) GetBlocksWithType(ctx context.Context, boardID string, blockType string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error) {
+	return s.getBlocksWithType(withContext(ctx, s.db), boardID, blockType, opts)
+
+}
+
+func (s *SQLStore) GetBoard(ctx context.Context, id string) (*model.Board, error) {
+	return s.getBoard(withContext(ctx, s.db), id)
+
+}
+
+func (s *SQLStore) GetBoardAndCard(ctx context.Context, block *model.Block) (*model.Board, *model.Block, error) {
+	return s.getBoardAndCard(withContext(ctx, s.db), block)
+
+}
+
+func (s *SQLStore) GetBoardAndCardByID(ctx context.Context, blockID string) (*model.Board, *model.Block, error) {
+	return s.getBoardAndCardByID(withContext(ctx, s.db), blockID)
+
+}
+
+func (s *SQLStore) GetBoardCardProperties(ctx context.Context, boardID string) ([]model.CardProperty, error) {
+	return s.getBoardCardProperties(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetBoardCount(ctx context.Context) (int64, error) {
+	return s.getBoardCount(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetBoardCustomRole(ctx context.Context, id string) (*model.BoardCustomRole, error) {
+	return s.getBoardCustomRole(withContext(ctx, s.db), id)
+
+}
+
+func (s *SQLStore) GetBoardCustomRoles(ctx context.Context, boardID string) ([]*model.BoardCustomRole, error) {
+	return s.getBoardCustomRoles(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetBoardHistory(ctx context.Context, boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
+	return s.getBoardHistory(withContext(ctx, s.db), boardID, opts)
+
+}
+
+func (s *SQLStore) GetBoardMemberCount(ctx context.Context, boardID string) (int, error) {
+	return s.getBoardMemberCount(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetBoardMemberHistory(ctx context.Context, boardID string, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
+	return s.getBoardMemberHistory(withContext(ctx, s.db), boardID, userID, limit)
+
+}
+
+func (s *SQLStore) GetBoardSnapshot(ctx context.Context, boardID string) (*model.BoardSnapshot, error) {
+	return s.getBoardSnapshot(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetBoardStats(ctx context.Context, boardID string) (*model.BoardStats, error) {
+	return s.getBoardStats(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetBoardWithStats(ctx context.Context, boardID string, userID string) (*model.BoardWithStats, error) {
+	return s.getBoardWithStats(withContext(ctx, s.db), boardID, userID)
+
+}
+
+func (s *SQLStore) GetBoards(ctx context.Context, ids []string) ([]*model.Board, error) {
+	return s.getBoards(withContext(ctx, s.db), ids)
+
+}
+
+func (s *SQLStore) GetBoardsAdministeredByUser(ctx context.Context, userID string, teamID string) ([]*model.Board, error) {
+	return s.getBoardsAdministeredByUser(withContext(ctx, s.db), userID, teamID)
+
+}
+
+func (s *SQLStore) GetBoardsCreatedFromTemplate(ctx context.Context, templateID string) ([]*model.Board, error) {
+	return s.getBoardsCreatedFromTemplate(withContext(ctx, s.db), templateID)
+
+}
+
+func (s *SQLStore) GetBoardsForUserAndTeam(ctx context.Context, userID string, teamID string, includePublicBoards bool) ([]*model.Board, error) {
+	return s.getBoardsForUserAndTeam(withContext(ctx, s.db), userID, teamID, includePublicBoards)
+
+}
+
+func (s *SQLStore) GetBoardsForUserAndTeamWithOptions(ctx context.Context, userID string, teamID string, opts model.QueryBoardsOptions) ([]*model.Board, error) {
+	return s.getBoardsForUserAndTeamWithOptions(withContext(ctx, s.db), userID, teamID, opts)
+
+}
+
+func (s *SQLStore) GetBoardsInTeamByIds(ctx context.Context, boardIDs []string, teamID string) ([]*model.Board, error) {
+	return s.getBoardsInTeamByIds(withContext(ctx, s.db), boardIDs, teamID)
+
+}
+
+func (s *SQLStore) GetBoardsModifiedSince(ctx context.Context, teamID string, userID string, since int64) ([]*model.Board, error) {
+	return s.getBoardsModifiedSince(withContext(ctx, s.db), teamID, userID, since)
+
+}
+
+func (s *SQLStore) GetCardLimitTimestamp(ctx context.Context) (int64, error) {
+	return s.getCardLimitTimestamp(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetCardsMissingProperty(ctx context.Context, boardID string, propertyID string) ([]*model.Block, error) {
+	return s.getCardsMissingProperty(withContext(ctx, s.db), boardID, propertyID)
+
+}
+
+func (s *SQLStore) GetCategory(ctx context.Context, id string) (*model.Category, error) {
+	return s.getCategory(withContext(ctx, s.db), id)
+
+}
+
+func (s *SQLStore) GetCategoryForBoard(ctx context.Context, userID string, teamID string, boardID string) (*model.Category, error) {
+	return s.getCategoryForBoard(withContext(ctx, s.db), userID, teamID, boardID)
+
+}
+
+func (s *SQLStore) GetChannel(ctx context.Context, teamID string, channelID string) (*mmModel.Channel, error) {
+	return s.getChannel(withContext(ctx, s.db), teamID, channelID)
+
+}
+
+func (s *SQLStore) GetCloudLimits(ctx context.Context) (*mmModel.ProductLimits, error) {
+	return s.getCloudLimits(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetDefaultCategoryTemplates(ctx context.Context, teamID string) ([]model.CategoryTemplate, error) {
+	return s.getDefaultCategoryTemplates(withContext(ctx, s.db), teamID)
+
+}
+
+func (s *SQLStore) GetDeletedBlocksForBoard(ctx context.Context, boardID string) ([]*model.Block, error) {
+	return s.getDeletedBlocksForBoard(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetDueNotificationHints(ctx context.Context, now int64, limit int) ([]*model.NotificationHint, error) {
+	return s.getDueNotificationHints(withContext(ctx, s.db), now, limit)
+
+}
+
+func (s *SQLStore) GetFavoriteBoards(ctx context.Context, userID string, teamID string) ([]*model.Board, error) {
+	return s.getFavoriteBoards(withContext(ctx, s.db), userID, teamID)
+
+}
+
+func (s *SQLStore) GetFileInfo(ctx context.Context, id string) (*mmModel.FileInfo, error) {
+	return s.getFileInfo(withContext(ctx, s.db), id)
+
+}
+
+func (s *SQLStore) GetFileInfosForBoard(ctx context.Context, boardID string) ([]*mmModel.FileInfo, error) {
+	return s.getFileInfosForBoard(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetLastModifiedBlockForBoard(ctx context.Context, boardID string) (*model.Block, error) {
+	return s.getLastModifiedBlockForBoard(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetLicense(ctx context.Context) *mmModel.License {
+	return s.getLicense(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetMemberForBoard(ctx context.Context, boardID string, userID string) (*model.BoardMember, error) {
+	return s.getMemberForBoard(withContext(ctx, s.db), boardID, userID)
+
+}
+
+func (s *SQLStore) GetMembersForBoard(ctx context.Context, boardID string) ([]*model.BoardMember, error) {
+	return s.getMembersForBoard(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetMembersForBoardWithOptions(ctx context.Context, boardID string, opts model.QueryBoardMembersOptions) ([]*model.BoardMember, bool, error) {
+	return s.getMembersForBoardWithOptions(withContext(ctx, s.db), boardID, opts)
+
+}
+
+func (s *SQLStore) GetMembersForUser(ctx context.Context, userID string) ([]*model.BoardMember, error) {
+	return s.getMembersForUser(withContext(ctx, s.db), userID)
+
+}
+
+func (s *SQLStore) GetNextNotificationHint(ctx context.Context, remove bool) (*model.NotificationHint, error) {
+	return s.getNextNotificationHint(withContext(ctx, s.db), remove)
+
+}
+
+func (s *SQLStore) GetNotificationHint(ctx context.Context, blockID string) (*model.NotificationHint, error) {
+	return s.getNotificationHint(withContext(ctx, s.db), blockID)
+
+}
+
+func (s *SQLStore) GetNotificationHints(ctx context.Context, limit int) ([]*model.NotificationHint, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.getNotificationHints(withContext(ctx, s.db), limit)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.getNotificationHints(withContext(ctx, tx), limit)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "GetNotificationHints"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) GetPendingWebhookDeliveries(ctx context.Context, limit int) ([]*model.WebhookDelivery, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.getPendingWebhookDeliveries(withContext(ctx, s.db), limit)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.getPendingWebhookDeliveries(withContext(ctx, tx), limit)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "GetPendingWebhookDeliveries"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) GetRecentComments(ctx context.Context, boardID string, limit int) ([]*model.CommentWithAuthor, error) {
+	return s.getRecentComments(withContext(ctx, s.db), boardID, limit)
+
+}
+
+func (s *SQLStore) GetRecentlyViewedBoards(ctx context.Context, userID string, teamID string, limit int) ([]*model.Board, error) {
+	return s.getRecentlyViewedBoards(withContext(ctx, s.db), userID, teamID, limit)
+
+}
+
+func (s *SQLStore) GetRegisteredUserCount(ctx context.Context) (int, error) {
+	return s.getRegisteredUserCount(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetSession(ctx context.Context, token string, expireTime int64) (*model.Session, error) {
+	return s.getSession(withContext(ctx, s.db), token, expireTime)
+
+}
+
+func (s *SQLStore) GetSessionWithPolicy(ctx context.Context, token string, now int64, idleTimeout time.Duration, maxLifetime time.Duration) (*model.Session, error) {
+	return s.getSessionWithPolicy(withContext(ctx, s.db), token, now, idleTimeout, maxLifetime)
+
+}
+
+func (s *SQLStore) GetSessionWithUser(ctx context.Context, token string, now int64, idleTimeout time.Duration, maxLifetime time.Duration) (*model.Session, *model.User, error) {
+	return s.getSessionWithUser(withContext(ctx, s.db), token, now, idleTimeout, maxLifetime)
+
+}
+
+func (s *SQLStore) GetShareToken(ctx context.Context, token string) (*model.ShareToken, error) {
+	return s.getShareToken(withContext(ctx, s.db), token)
+
+}
+
+func (s *SQLStore) GetShareTokensForBoard(ctx context.Context, boardID string) ([]*model.ShareToken, error) {
+	return s.getShareTokensForBoard(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) GetSharing(ctx context.Context, rootID string) (*model.Sharing, error) {
+	return s.getSharing(withContext(ctx, s.db), rootID)
+
+}
+
+func (s *SQLStore) GetSharingForBoards(ctx context.Context, rootIDs []string) (map[string]*model.Sharing, error) {
+	return s.getSharingForBoards(withContext(ctx, s.db), rootIDs)
+
+}
+
+func (s *SQLStore) GetSubTree(ctx context.Context, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	return s.getSubTree(withContext(ctx, s.db), boardID, blockID, opts)
+
+}
+
+func (s *SQLStore) GetSubTree2(ctx context.Context, boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
+	return s.getSubTree2(withContext(ctx, s.db), boardID, blockID, opts)
+
+}
+
+func (s *SQLStore) GetSubscribersCountForBlock(ctx context.Context, blockID string) (int, error) {
+	return s.getSubscribersCountForBlock(withContext(ctx, s.db), blockID)
+
+}
+
+func (s *SQLStore) GetSubscribersForBlock(ctx context.Context, blockID string, events model.SubscriptionEvents) ([]*model.Subscriber, error) {
+	return s.getSubscribersForBlock(withContext(ctx, s.db), blockID, events)
+
+}
+
+func (s *SQLStore) GetSubscription(ctx context.Context, blockID string, subscriberID string) (*model.Subscription, error) {
+	return s.getSubscription(withContext(ctx, s.db), blockID, subscriberID)
+
+}
+
+func (s *SQLStore) GetSubscriptions(ctx context.Context, subscriberID string) ([]*model.Subscription, error) {
+	return s.getSubscriptions(withContext(ctx, s.db), subscriberID)
+
+}
+
+func (s *SQLStore) GetSystemSetting(ctx context.Context, key string) (string, error) {
+	return s.getSystemSetting(withContext(ctx, s.db), key)
+
+}
+
+func (s *SQLStore) GetSystemSettingBool(ctx context.Context, key string) (bool, error) {
+	return s.getSystemSettingBool(withContext(ctx, s.db), key)
+
+}
+
+func (s *SQLStore) GetSystemSettingInt(ctx context.Context, key string) (int, error) {
+	return s.getSystemSettingInt(withContext(ctx, s.db), key)
+
+}
+
+func (s *SQLStore) GetSystemSettings(ctx context.Context) (map[string]string, error) {
+	return s.getSystemSettings(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetTeam(ctx context.Context, ID string) (*model.Team, error) {
+	return s.getTeam(withContext(ctx, s.db), ID)
+
+}
+
+func (s *SQLStore) GetTeamBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	return s.getTeamBoardsInsights(withContext(ctx, s.db), teamID, userID, since, offset, limit, boardIDs)
+
+}
+
+func (s *SQLStore) GetTeamBySignupToken(ctx context.Context, token string) (*model.Team, error) {
+	return s.getTeamBySignupToken(withContext(ctx, s.db), token)
+
+}
+
+func (s *SQLStore) GetTeamCount(ctx context.Context) (int64, error) {
+	return s.getTeamCount(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetTeamsForUser(ctx context.Context, userID string) ([]*model.Team, error) {
+	return s.getTeamsForUser(withContext(ctx, s.db), userID)
+
+}
+
+func (s *SQLStore) GetTeamsForUserWithBoardCounts(ctx context.Context, userID string) ([]model.TeamWithCount, error) {
+	return s.getTeamsForUserWithBoardCounts(withContext(ctx, s.db), userID)
+
+}
+
+func (s *SQLStore) GetTemplateBoards(ctx context.Context, teamID string, userID string) ([]*model.Board, error) {
+	return s.getTemplateBoards(withContext(ctx, s.db), teamID, userID)
+
+}
+
+func (s *SQLStore) GetTemplateBoardsByVersion(ctx context.Context, teamID string, version int) ([]*model.Board, error) {
+	return s.getTemplateBoardsByVersion(withContext(ctx, s.db), teamID, version)
+
+}
+
+func (s *SQLStore) GetUsedCardsCount(ctx context.Context) (int, error) {
+	return s.getUsedCardsCount(withContext(ctx, s.db))
+
+}
+
+func (s *SQLStore) GetUserBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
+	return s.getUserBoardsInsights(withContext(ctx, s.db), teamID, userID, since, offset, limit, boardIDs)
+
+}
+
+func (s *SQLStore) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	return s.getUserByEmail(withContext(ctx, s.db), email)
+
+}
+
+func (s *SQLStore) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	return s.getUserByID(withContext(ctx, s.db), userID)
+
+}
+
+func (s *SQLStore) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	return s.getUserByUsername(withContext(ctx, s.db), username)
+
+}
+
+func (s *SQLStore) GetUserCategoryBoards(ctx context.Context, userID string, teamID string) ([]model.CategoryBoards, error) {
+	return s.getUserCategoryBoards(withContext(ctx, s.db), userID, teamID)
+
+}
+
+func (s *SQLStore) GetUserPreferences(ctx context.Context, userID string) (mmModel.Preferences, error) {
+	return s.getUserPreferences(withContext(ctx, s.db), userID)
+
+}
+
+func (s *SQLStore) GetUserTimezone(ctx context.Context, userID string) (string, error) {
+	return s.getUserTimezone(withContext(ctx, s.db), userID)
+
+}
+
+func (s *SQLStore) GetUsersByTeam(ctx context.Context, teamID string, asGuestID string) ([]*model.User, error) {
+	return s.getUsersByTeam(withContext(ctx, s.db), teamID, asGuestID)
+
+}
+
+func (s *SQLStore) GetUsersByTeamWithRole(ctx context.Context, teamID string, role string, includeDeleted bool) ([]*model.User, error) {
+	return s.getUsersByTeamWithRole(withContext(ctx, s.db), teamID, role, includeDeleted)
+
+}
+
+func (s *SQLStore) GetUsersList(ctx context.Context, userIDs []string) ([]*model.User, error) {
+	return s.getUsersList(withContext(ctx, s.db), userIDs)
+
+}
+
+func (s *SQLStore) GetWebhook(ctx context.Context, id string) (*model.Webhook, error) {
+	return s.getWebhook(withContext(ctx, s.db), id)
+
+}
+
+func (s *SQLStore) GetWebhooksForBoard(ctx context.Context, boardID string) ([]*model.Webhook, error) {
+	return s.getWebhooksForBoard(withContext(ctx, s.db), boardID)
+
+}
+
+func (s *SQLStore) InsertAuditRecord(ctx context.Context, rec *model.AuditRecord) error {
+	return s.insertAuditRecord(withContext(ctx, s.db), rec)
+
+}
+
+func (s *SQLStore) InsertBlock(ctx context.Context, block *model.Block, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.insertBlock(withContext(ctx, s.db), block, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.insertBlock(withContext(ctx, tx), block, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlock"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) InsertBlocks(ctx context.Context, blocks []*model.Block, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.insertBlocks(withContext(ctx, s.db), blocks, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.insertBlocks(withContext(ctx, tx), blocks, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlocks"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) InsertBoard(ctx context.Context, board *model.Board, userID string) (*model.Board, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.insertBoard(withContext(ctx, s.db), board, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.insertBoard(withContext(ctx, tx), board, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBoard"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) InsertBoardWithAdmin(ctx context.Context, board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.insertBoardWithAdmin(withContext(ctx, s.db), board, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, nil, txErr
+	}
+	result, resultVar1, err := s.insertBoardWithAdmin(withContext(ctx, tx), board, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBoardWithAdmin"))
+		}
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return result, resultVar1, nil
+
+}
+
+func (s *SQLStore) MarkWebhookDelivered(ctx context.Context, id string, status int) error {
+	return s.markWebhookDelivered(withContext(ctx, s.db), id, status)
+
+}
+
+func (s *SQLStore) MergeBoards(ctx context.Context, sourceBoardID string, targetBoardID string, userID string) (map[string]string, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.mergeBoards(withContext(ctx, s.db), sourceBoardID, targetBoardID, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.mergeBoards(withContext(ctx, tx), sourceBoardID, targetBoardID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MergeBoards"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) MergeCategories(ctx context.Context, userID string, primaryCategoryID string, mergeCategoryIDs []string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.mergeCategories(withContext(ctx, s.db), userID, primaryCategoryID, mergeCategoryIDs)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.mergeCategories(withContext(ctx, tx), userID, primaryCategoryID, mergeCategoryIDs)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MergeCategories"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) MoveBlocks(ctx context.Context, blockIDs []string, targetBoardID string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.moveBlocks(withContext(ctx, s.db), blockIDs, targetBoardID, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.moveBlocks(withContext(ctx, tx), blockIDs, targetBoardID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveBlocks"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) NormalizeContentOrder(ctx context.Context, cardID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.normalizeContentOrder(withContext(ctx, s.db), cardID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.normalizeContentOrder(withContext(ctx, tx), cardID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "NormalizeContentOrder"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) PatchBlock(ctx context.Context, blockID string, blockPatch *model.BlockPatch, userID string) (int64, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.patchBlock(withContext(ctx, s.db), blockID, blockPatch, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return 0, txErr
+	}
+	result, err := s.patchBlock(withContext(ctx, tx), blockID, blockPatch, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlock"))
+		}
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) PatchBlocks(ctx context.Context, blockPatches *model.BlockPatchBatch, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.patchBlocks(withContext(ctx, s.db), blockPatches, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.patchBlocks(withContext(ctx, tx), blockPatches, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlocks"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) PatchBoard(ctx context.Context, boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.patchBoard(withContext(ctx, s.db), boardID, boardPatch, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.patchBoard(withContext(ctx, tx), boardID, boardPatch, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoard"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) PatchBoardsAndBlocks(ctx context.Context, pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.patchBoardsAndBlocks(withContext(ctx, s.db), pbab, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.patchBoardsAndBlocks(withContext(ctx, tx), pbab, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoardsAndBlocks"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) PatchUserPreferences(ctx context.Context, userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
+	return s.patchUserPreferences(withContext(ctx, s.db), userID, patch)
+
+}
+
+func (s *SQLStore) PostMessage(ctx context.Context, message string, postType string, channelID string) error {
+	return s.postMessage(withContext(ctx, s.db), message, postType, channelID)
+
+}
+
+func (s *SQLStore) PurgeArchivedBoards(ctx context.Context, olderThan time.Time) (int, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.purgeArchivedBoards(withContext(ctx, s.db), olderThan)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return 0, txErr
+	}
+	result, err := s.purgeArchivedBoards(withContext(ctx, tx), olderThan)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PurgeArchivedBoards"))
+		}
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) PurgeDeletedBlocks(ctx context.Context, olderThan time.Time) (int, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.purgeDeletedBlocks(withContext(ctx, s.db), olderThan)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return 0, txErr
+	}
+	result, err := s.purgeDeletedBlocks(withContext(ctx, tx), olderThan)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PurgeDeletedBlocks"))
+		}
+		return 0, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
 
-func (s *SQLStore) GetCardLimitTimestamp() (int64, error) {
-	return s.getCardLimitTimestamp(s.db)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetCategory(id string) (*model.Category, error) {
-	return s.getCategory(s.db, id)
+func (s *SQLStore) ReactivateUser(ctx context.Context, userID string) error {
+	return s.reactivateUser(withContext(ctx, s.db), userID)
 
 }
 
-func (s *SQLStore) GetChannel(teamID string, channelID string) (*mmModel.Channel, error) {
-	return s.getChannel(s.db, teamID, channelID)
+func (s *SQLStore) RecordWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	return s.recordWebhookDelivery(withContext(ctx, s.db), delivery)
 
 }
 
-func (s *SQLStore) GetCloudLimits() (*mmModel.ProductLimits, error) {
-	return s.getCloudLimits(s.db)
+func (s *SQLStore) RefreshSession(ctx context.Context, session *model.Session) error {
+	return s.refreshSession(withContext(ctx, s.db), session)
 
 }
 
-func (s *SQLStore) GetFileInfo(id string) (*mmModel.FileInfo, error) {
-	return s.getFileInfo(s.db, id)
+func (s *SQLStore) ReinstallDefaultTemplates(ctx context.Context, teamID string, templates []*model.Board, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.reinstallDefaultTemplates(withContext(ctx, s.db), teamID, templates, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.reinstallDefaultTemplates(withContext(ctx, tx), teamID, templates, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "ReinstallDefaultTemplates"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) GetLicense() *mmModel.License {
-	return s.getLicense(s.db)
+	return nil
 
 }
 
-func (s *SQLStore) GetMemberForBoard(boardID string, userID string) (*model.BoardMember, error) {
-	return s.getMemberForBoard(s.db, boardID, userID)
+func (s *SQLStore) RemoveCategoryBoards(ctx context.Context, userID string, categoryID string, boardIDs []string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.removeCategoryBoards(withContext(ctx, s.db), userID, categoryID, boardIDs)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.removeCategoryBoards(withContext(ctx, tx), userID, categoryID, boardIDs)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RemoveCategoryBoards"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) GetMembersForBoard(boardID string) ([]*model.BoardMember, error) {
-	return s.getMembersForBoard(s.db, boardID)
+	return nil
 
 }
 
-func (s *SQLStore) GetMembersForUser(userID string) ([]*model.BoardMember, error) {
-	return s.getMembersForUser(s.db, userID)
+func (s *SQLStore) RemoveDefaultTemplates(ctx context.Context, boards []*model.Board) error {
+	return s.removeDefaultTemplates(withContext(ctx, s.db), boards)
 
 }
 
-func (s *SQLStore) GetNextNotificationHint(remove bool) (*model.NotificationHint, error) {
-	return s.getNextNotificationHint(s.db, remove)
+func (s *SQLStore) ReorderCategories(ctx context.Context, userID string, teamID string, categoryIDs []string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.reorderCategories(withContext(ctx, s.db), userID, teamID, categoryIDs)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.reorderCategories(withContext(ctx, tx), userID, teamID, categoryIDs)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "ReorderCategories"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) GetNotificationHint(blockID string) (*model.NotificationHint, error) {
-	return s.getNotificationHint(s.db, blockID)
+	return nil
 
 }
 
-func (s *SQLStore) GetRegisteredUserCount() (int, error) {
-	return s.getRegisteredUserCount(s.db)
+func (s *SQLStore) ReorderCategoryBoards(ctx context.Context, userID string, categoryID string, boardIDs []string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.reorderCategoryBoards(withContext(ctx, s.db), userID, categoryID, boardIDs)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.reorderCategoryBoards(withContext(ctx, tx), userID, categoryID, boardIDs)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "ReorderCategoryBoards"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) GetSession(token string, expireTime int64) (*model.Session, error) {
-	return s.getSession(s.db, token, expireTime)
+	return nil
 
 }
 
-func (s *SQLStore) GetSharing(rootID string) (*model.Sharing, error) {
-	return s.getSharing(s.db, rootID)
+func (s *SQLStore) RestoreBlock(ctx context.Context, blockID string, userID string) (*model.Block, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.restoreBlock(withContext(ctx, s.db), blockID, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.restoreBlock(withContext(ctx, tx), blockID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RestoreBlock"))
+		}
+		return nil, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
-func (s *SQLStore) GetSubTree2(boardID string, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error) {
-	return s.getSubTree2(s.db, boardID, blockID, opts)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetSubscribersCountForBlock(blockID string) (int, error) {
-	return s.getSubscribersCountForBlock(s.db, blockID)
+func (s *SQLStore) RestoreBoard(ctx context.Context, boardID string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.restoreBoard(withContext(ctx, s.db), boardID, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.restoreBoard(withContext(ctx, tx), boardID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RestoreBoard"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error) {
-	return s.getSubscribersForBlock(s.db, blockID)
+	return nil
 
 }
 
-func (s *SQLStore) GetSubscription(blockID string, subscriberID string) (*model.Subscription, error) {
-	return s.getSubscription(s.db, blockID, subscriberID)
+func (s *SQLStore) RotateSharingToken(ctx context.Context, rootID string, userID string) (*model.Sharing, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.rotateSharingToken(withContext(ctx, s.db), rootID, userID)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.rotateSharingToken(withContext(ctx, tx), rootID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RotateSharingToken"))
+		}
+		return nil, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
-func (s *SQLStore) GetSubscriptions(subscriberID string) ([]*model.Subscription, error) {
-	return s.getSubscriptions(s.db, subscriberID)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetSystemSetting(key string) (string, error) {
-	return s.getSystemSetting(s.db, key)
+func (s *SQLStore) RunDataRetention(ctx context.Context, globalRetentionDate int64, batchSize int64) (int64, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.runDataRetention(withContext(ctx, s.db), globalRetentionDate, batchSize)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return 0, txErr
+	}
+	result, err := s.runDataRetention(withContext(ctx, tx), globalRetentionDate, batchSize)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RunDataRetention"))
+		}
+		return 0, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
 
-func (s *SQLStore) GetSystemSettings() (map[string]string, error) {
-	return s.getSystemSettings(s.db)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetTeam(ID string) (*model.Team, error) {
-	return s.getTeam(s.db, ID)
+func (s *SQLStore) SaveFileInfo(ctx context.Context, fileInfo *mmModel.FileInfo) error {
+	return s.saveFileInfo(withContext(ctx, s.db), fileInfo)
 
 }
 
-func (s *SQLStore) GetTeamBoardsInsights(teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
-	return s.getTeamBoardsInsights(s.db, teamID, userID, since, offset, limit, boardIDs)
+func (s *SQLStore) SaveMember(ctx context.Context, bm *model.BoardMember, modifiedBy string) (*model.BoardMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.saveMember(withContext(ctx, s.db), bm, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.saveMember(withContext(ctx, tx), bm, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveMember"))
+		}
+		return nil, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
-func (s *SQLStore) GetTeamCount() (int64, error) {
-	return s.getTeamCount(s.db)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetTeamsForUser(userID string) ([]*model.Team, error) {
-	return s.getTeamsForUser(s.db, userID)
+func (s *SQLStore) SaveMemberWithLimit(ctx context.Context, bm *model.BoardMember, maxMembers int, modifiedBy string) (*model.BoardMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.saveMemberWithLimit(withContext(ctx, s.db), bm, maxMembers, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.saveMemberWithLimit(withContext(ctx, tx), bm, maxMembers, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveMemberWithLimit"))
+		}
+		return nil, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
-func (s *SQLStore) GetTemplateBoards(teamID string, userID string) ([]*model.Board, error) {
-	return s.getTemplateBoards(s.db, teamID, userID)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetUsedCardsCount() (int, error) {
-	return s.getUsedCardsCount(s.db)
+func (s *SQLStore) SaveMembers(ctx context.Context, members []*model.BoardMember, modifiedBy string) ([]*model.BoardMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.saveMembers(withContext(ctx, s.db), members, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.saveMembers(withContext(ctx, tx), members, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveMembers"))
+		}
+		return nil, err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
-func (s *SQLStore) GetUserBoardsInsights(teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
-	return s.getUserBoardsInsights(s.db, teamID, userID, since, offset, limit, boardIDs)
+	return result, nil
 
 }
 
-func (s *SQLStore) GetUserByEmail(email string) (*model.User, error) {
-	return s.getUserByEmail(s.db, email)
+func (s *SQLStore) SearchBlocksForBoard(ctx context.Context, boardID string, term string, fields []string) ([]*model.Block, error) {
+	return s.searchBlocksForBoard(withContext(ctx, s.db), boardID, term, fields)
 
 }
 
-func (s *SQLStore) GetUserByID(userID string) (*model.User, error) {
-	return s.getUserByID(s.db, userID)
+func (s *SQLStore) SearchBlocksForUser(ctx context.Context, teamID string, term string, userID string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	return s.searchBlocksForUser(withContext(ctx, s.db), teamID, term, userID, opts)
 
 }
 
-func (s *SQLStore) GetUserByUsername(username string) (*model.User, error) {
-	return s.getUserByUsername(s.db, username)
+func (s *SQLStore) SearchBlocksInBoards(ctx context.Context, boardIDs []string, term string, opts model.SearchBlocksOptions) ([]*model.Block, error) {
+	return s.searchBlocksInBoards(withContext(ctx, s.db), boardIDs, term, opts)
 
 }
 
-func (s *SQLStore) GetUserCategoryBoards(userID string, teamID string) ([]model.CategoryBoards, error) {
-	return s.getUserCategoryBoards(s.db, userID, teamID)
+func (s *SQLStore) SearchBoardsForUser(ctx context.Context, term string, userID string, includePublicBoards bool) ([]*model.Board, error) {
+	return s.searchBoardsForUser(withContext(ctx, s.db), term, userID, includePublicBoards)
 
 }
 
-func (s *SQLStore) GetUserPreferences(userID string) (mmModel.Preferences, error) {
-	return s.getUserPreferences(s.db, userID)
+func (s *SQLStore) SearchBoardsForUserInTeam(ctx context.Context, teamID string, term string, userID string) ([]*model.Board, error) {
+	return s.searchBoardsForUserInTeam(withContext(ctx, s.db), teamID, term, userID)
 
 }
 
-func (s *SQLStore) GetUserTimezone(userID string) (string, error) {
-	return s.getUserTimezone(s.db, userID)
+func (s *SQLStore) SearchUserChannels(ctx context.Context, teamID string, userID string, query string) ([]*mmModel.Channel, error) {
+	return s.searchUserChannels(withContext(ctx, s.db), teamID, userID, query)
 
 }
 
-func (s *SQLStore) GetUsersByTeam(teamID string, asGuestID string) ([]*model.User, error) {
-	return s.getUsersByTeam(s.db, teamID, asGuestID)
+func (s *SQLStore) SearchUsersByTeam(ctx context.Context, teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
+	return s.searchUsersByTeam(withContext(ctx, s.db), teamID, searchQuery, asGuestID, excludeBots)
 
 }
 
-func (s *SQLStore) GetUsersList(userIDs []string) ([]*model.User, error) {
-	return s.getUsersList(s.db, userIDs)
+func (s *SQLStore) SendMessage(ctx context.Context, message string, postType string, receipts []string) error {
+	return s.sendMessage(withContext(ctx, s.db), message, postType, receipts)
 
 }
 
-func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
+func (s *SQLStore) SetBoardFavorite(ctx context.Context, userID string, boardID string, favorite bool) error {
 	if s.dbType == model.SqliteDBType {
-		return s.insertBlock(s.db, block, userID)
+		return s.setBoardFavorite(withContext(ctx, s.db), userID, boardID, favorite)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
 		return txErr
 	}
-	err := s.insertBlock(tx, block, userID)
+	err := s.setBoardFavorite(withContext(ctx, tx), userID, boardID, favorite)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlock"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SetBoardFavorite"))
 		}
 		return err
 	}
@@ -573,18 +2000,18 @@ func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
 
 }
 
-func (s *SQLStore) InsertBlocks(blocks []*model.Block, userID string) error {
+func (s *SQLStore) SetBoardPropertyOrder(ctx context.Context, boardID string, propertyIDs []string, userID string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.insertBlocks(s.db, blocks, userID)
+		return s.setBoardPropertyOrder(withContext(ctx, s.db), boardID, propertyIDs, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
 		return txErr
 	}
-	err := s.insertBlocks(tx, blocks, userID)
+	err := s.setBoardPropertyOrder(withContext(ctx, tx), boardID, propertyIDs, userID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlocks"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SetBoardPropertyOrder"))
 		}
 		return err
 	}
@@ -597,47 +2024,47 @@ func (s *SQLStore) InsertBlocks(blocks []*model.Block, userID string) error {
 
 }
 
-func (s *SQLStore) InsertBoard(board *model.Board, userID string) (*model.Board, error) {
-	return s.insertBoard(s.db, board, userID)
-
-}
-
-func (s *SQLStore) InsertBoardWithAdmin(board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
+func (s *SQLStore) SetMemberCustomRole(ctx context.Context, boardID string, userID string, roleID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.insertBoardWithAdmin(s.db, board, userID)
+		return s.setMemberCustomRole(withContext(ctx, s.db), boardID, userID, roleID, modifiedBy)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
-		return nil, nil, txErr
+		return txErr
 	}
-	result, resultVar1, err := s.insertBoardWithAdmin(tx, board, userID)
+	err := s.setMemberCustomRole(withContext(ctx, tx), boardID, userID, roleID, modifiedBy)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBoardWithAdmin"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SetMemberCustomRole"))
 		}
-		return nil, nil, err
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	return result, resultVar1, nil
+	return nil
+
+}
+
+func (s *SQLStore) SetSystemSetting(ctx context.Context, key string, value string) error {
+	return s.setSystemSetting(withContext(ctx, s.db), key, value)
 
 }
 
-func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
+func (s *SQLStore) UnarchiveCard(ctx context.Context, cardID string, userID string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.patchBlock(s.db, blockID, blockPatch, userID)
+		return s.unarchiveCard(withContext(ctx, s.db), cardID, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
 		return txErr
 	}
-	err := s.patchBlock(tx, blockID, blockPatch, userID)
+	err := s.unarchiveCard(withContext(ctx, tx), cardID, userID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlock"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UnarchiveCard"))
 		}
 		return err
 	}
@@ -650,18 +2077,18 @@ func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, user
 
 }
 
-func (s *SQLStore) PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error {
+func (s *SQLStore) UndeleteBlock(ctx context.Context, blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.patchBlocks(s.db, blockPatches, userID)
+		return s.undeleteBlock(withContext(ctx, s.db), blockID, modifiedBy)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
 		return txErr
 	}
-	err := s.patchBlocks(tx, blockPatches, userID)
+	err := s.undeleteBlock(withContext(ctx, tx), blockID, modifiedBy)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlocks"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBlock"))
 		}
 		return err
 	}
@@ -674,150 +2101,140 @@ func (s *SQLStore) PatchBlocks(blockPatches *model.BlockPatchBatch, userID strin
 
 }
 
-func (s *SQLStore) PatchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+func (s *SQLStore) UndeleteBoard(ctx context.Context, boardID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.patchBoard(s.db, boardID, boardPatch, userID)
+		return s.undeleteBoard(withContext(ctx, s.db), boardID, modifiedBy)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
-		return nil, txErr
+		return txErr
 	}
-	result, err := s.patchBoard(tx, boardID, boardPatch, userID)
+	err := s.undeleteBoard(withContext(ctx, tx), boardID, modifiedBy)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoard"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBoard"))
 		}
-		return nil, err
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
-	return result, nil
+	return nil
 
 }
 
-func (s *SQLStore) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+func (s *SQLStore) UpdateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole, userID string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.patchBoardsAndBlocks(s.db, pbab, userID)
+		return s.updateBoardCustomRole(withContext(ctx, s.db), role, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
-		return nil, txErr
+		return txErr
 	}
-	result, err := s.patchBoardsAndBlocks(tx, pbab, userID)
+	err := s.updateBoardCustomRole(withContext(ctx, tx), role, userID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoardsAndBlocks"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UpdateBoardCustomRole"))
 		}
-		return nil, err
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
-	return result, nil
-
-}
-
-func (s *SQLStore) PatchUserPreferences(userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
-	return s.patchUserPreferences(s.db, userID, patch)
+	return nil
 
 }
 
-func (s *SQLStore) PostMessage(message string, postType string, channelID string) error {
-	return s.postMessage(s.db, message, postType, channelID)
+func (s *SQLStore) UpdateCardLimitTimestamp(ctx context.Context, cardLimit int) (int64, error) {
+	return s.updateCardLimitTimestamp(withContext(ctx, s.db), cardLimit)
 
 }
 
-func (s *SQLStore) RefreshSession(session *model.Session) error {
-	return s.refreshSession(s.db, session)
+func (s *SQLStore) UpdateCategory(ctx context.Context, category model.Category) error {
+	return s.updateCategory(withContext(ctx, s.db), category)
 
 }
 
-func (s *SQLStore) RemoveDefaultTemplates(boards []*model.Board) error {
-	return s.removeDefaultTemplates(s.db, boards)
+func (s *SQLStore) UpdateMemberLastViewed(ctx context.Context, boardID string, userID string, viewedAt int64) error {
+	return s.updateMemberLastViewed(withContext(ctx, s.db), boardID, userID, viewedAt)
 
 }
 
-func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error) {
+func (s *SQLStore) UpdateMemberRole(ctx context.Context, boardID string, userID string, role string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.runDataRetention(s.db, globalRetentionDate, batchSize)
+		return s.updateMemberRole(withContext(ctx, s.db), boardID, userID, role, modifiedBy)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
-		return 0, txErr
+		return txErr
 	}
-	result, err := s.runDataRetention(tx, globalRetentionDate, batchSize)
+	err := s.updateMemberRole(withContext(ctx, tx), boardID, userID, role, modifiedBy)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RunDataRetention"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UpdateMemberRole"))
 		}
-		return 0, err
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return 0, err
+		return err
 	}
 
-	return result, nil
-
-}
-
-func (s *SQLStore) SaveFileInfo(fileInfo *mmModel.FileInfo) error {
-	return s.saveFileInfo(s.db, fileInfo)
+	return nil
 
 }
 
-func (s *SQLStore) SaveMember(bm *model.BoardMember) (*model.BoardMember, error) {
-	return s.saveMember(s.db, bm)
+func (s *SQLStore) UpdateSession(ctx context.Context, session *model.Session) error {
+	return s.updateSession(withContext(ctx, s.db), session)
 
 }
 
-func (s *SQLStore) SearchBoardsForUser(term string, userID string, includePublicBoards bool) ([]*model.Board, error) {
-	return s.searchBoardsForUser(s.db, term, userID, includePublicBoards)
+func (s *SQLStore) UpdateSubscriberNotifiedAt(ctx context.Context, blockID string, subscriberID string, notifiedAt int64) error {
+	return s.updateSubscriberNotifiedAt(withContext(ctx, s.db), blockID, subscriberID, notifiedAt)
 
 }
 
-func (s *SQLStore) SearchBoardsForUserInTeam(teamID string, term string, userID string) ([]*model.Board, error) {
-	return s.searchBoardsForUserInTeam(s.db, teamID, term, userID)
+func (s *SQLStore) UpdateSubscribersNotifiedAt(ctx context.Context, blockID string, notifiedAt int64) error {
+	return s.updateSubscribersNotifiedAt(withContext(ctx, s.db), blockID, notifiedAt)
 
 }
 
-func (s *SQLStore) SearchUserChannels(teamID string, userID string, query string) ([]*mmModel.Channel, error) {
-	return s.searchUserChannels(s.db, teamID, userID, query)
+func (s *SQLStore) UpdateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	return s.updateSubscription(withContext(ctx, s.db), sub)
 
 }
 
-func (s *SQLStore) SearchUsersByTeam(teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
-	return s.searchUsersByTeam(s.db, teamID, searchQuery, asGuestID, excludeBots)
+func (s *SQLStore) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	return s.updateUser(withContext(ctx, s.db), user)
 
 }
 
-func (s *SQLStore) SendMessage(message string, postType string, receipts []string) error {
-	return s.sendMessage(s.db, message, postType, receipts)
+func (s *SQLStore) UpdateUserPassword(ctx context.Context, username string, password string) error {
+	return s.updateUserPassword(withContext(ctx, s.db), username, password)
 
 }
 
-func (s *SQLStore) SetSystemSetting(key string, value string) error {
-	return s.setSystemSetting(s.db, key, value)
+func (s *SQLStore) UpdateUserPasswordByID(ctx context.Context, userID string, password string) error {
+	return s.updateUserPasswordByID(withContext(ctx, s.db), userID, password)
 
 }
 
-func (s *SQLStore) UndeleteBlock(blockID string, modifiedBy string) error {
+func (s *SQLStore) UpdateWebhook(ctx context.Context, webhook *model.Webhook, userID string) error {
 	if s.dbType == model.SqliteDBType {
-		return s.undeleteBlock(s.db, blockID, modifiedBy)
+		return s.updateWebhook(withContext(ctx, s.db), webhook, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
 		return txErr
 	}
-	err := s.undeleteBlock(tx, blockID, modifiedBy)
+	err := s.updateWebhook(withContext(ctx, tx), webhook, userID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBlock"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UpdateWebhook"))
 		}
 		return err
 	}
@@ -830,18 +2247,18 @@ func (s *SQLStore) UndeleteBlock(blockID string, modifiedBy string) error {
 
 }
 
-func (s *SQLStore) UndeleteBoard(boardID string, modifiedBy string) error {
+func (s *SQLStore) UpgradeDefaultTemplates(ctx context.Context, teamID string, toVersion int, templates *model.BoardsAndBlocks) error {
 	if s.dbType == model.SqliteDBType {
-		return s.undeleteBoard(s.db, boardID, modifiedBy)
+		return s.upgradeDefaultTemplates(withContext(ctx, s.db), teamID, toVersion, templates)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	tx, txErr := s.db.BeginTx(ctx, nil)
 	if txErr != nil {
 		return txErr
 	}
-	err := s.undeleteBoard(tx, boardID, modifiedBy)
+	err := s.upgradeDefaultTemplates(withContext(ctx, tx), teamID, toVersion, templates)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBoard"))
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UpgradeDefaultTemplates"))
 		}
 		return err
 	}
@@ -854,57 +2271,56 @@ func (s *SQLStore) UndeleteBoard(boardID string, modifiedBy string) error {
 
 }
 
-func (s *SQLStore) UpdateCardLimitTimestamp(cardLimit int) (int64, error) {
-	return s.updateCardLimitTimestamp(s.db, cardLimit)
-
-}
-
-func (s *SQLStore) UpdateCategory(category model.Category) error {
-	return s.updateCategory(s.db, category)
-
-}
-
-func (s *SQLStore) UpdateSession(session *model.Session) error {
-	return s.updateSession(s.db, session)
-
-}
-
-func (s *SQLStore) UpdateSubscribersNotifiedAt(blockID string, notifiedAt int64) error {
-	return s.updateSubscribersNotifiedAt(s.db, blockID, notifiedAt)
+func (s *SQLStore) UpsertBoardSnapshot(ctx context.Context, snapshot *model.BoardSnapshot) error {
+	return s.upsertBoardSnapshot(withContext(ctx, s.db), snapshot)
 
 }
 
-func (s *SQLStore) UpdateUser(user *model.User) (*model.User, error) {
-	return s.updateUser(s.db, user)
+func (s *SQLStore) UpsertDefaultCategoryTemplate(ctx context.Context, template model.CategoryTemplate) error {
+	return s.upsertDefaultCategoryTemplate(withContext(ctx, s.db), template)
 
 }
 
-func (s *SQLStore) UpdateUserPassword(username string, password string) error {
-	return s.updateUserPassword(s.db, username, password)
+func (s *SQLStore) UpsertNotificationHint(ctx context.Context, hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
+	return s.upsertNotificationHint(withContext(ctx, s.db), hint, notificationFreq)
 
 }
 
-func (s *SQLStore) UpdateUserPasswordByID(userID string, password string) error {
-	return s.updateUserPasswordByID(s.db, userID, password)
+func (s *SQLStore) UpsertSharing(ctx context.Context, sharing model.Sharing) error {
+	if s.dbType == model.SqliteDBType {
+		return s.upsertSharing(withContext(ctx, s.db), sharing)
+	}
+	tx, txErr := s.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.upsertSharing(withContext(ctx, tx), sharing)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UpsertSharing"))
+		}
+		return err
+	}
 
-}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-func (s *SQLStore) UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
-	return s.upsertNotificationHint(s.db, hint, notificationFreq)
+	return nil
 
 }
 
-func (s *SQLStore) UpsertSharing(sharing model.Sharing) error {
-	return s.upsertSharing(s.db, sharing)
+func (s *SQLStore) UpsertTeamSettings(ctx context.Context, team model.Team) error {
+	return s.upsertTeamSettings(withContext(ctx, s.db), team)
 
 }
 
-func (s *SQLStore) UpsertTeamSettings(team model.Team) error {
-	return s.upsertTeamSettings(s.db, team)
+func (s *SQLStore) UpsertTeamSignupToken(ctx context.Context, team model.Team) error {
+	return s.upsertTeamSignupToken(withContext(ctx, s.db), team)
 
 }
 
-func (s *SQLStore) UpsertTeamSignupToken(team model.Team) error {
-	return s.upsertTeamSignupToken(s.db, team)
+func (s *SQLStore) ValidateBoardSchema(ctx context.Context, boardID string) (*model.SchemaReport, error) {
+	return s.validateBoardSchema(withContext(ctx, s.db), boardID)
 
 }
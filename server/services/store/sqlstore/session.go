@@ -1,7 +1,12 @@
 package sqlstore
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/focalboard/server/model"
@@ -13,7 +18,8 @@ func (s *SQLStore) getActiveUserCount(db sq.BaseRunner, updatedSecondsAgo int64)
 	query := s.getQueryBuilder(db).
 		Select("count(distinct user_id)").
 		From(s.tablePrefix + "sessions").
-		Where(sq.Gt{"update_at": utils.GetMillis() - utils.SecondsToMillis(updatedSecondsAgo)})
+		Where(sq.Gt{"update_at": utils.GetMillis() - utils.SecondsToMillis(updatedSecondsAgo)}).
+		Where("user_id NOT IN (" + s.deactivatedUserIDs() + ")")
 
 	row := query.QueryRow()
 
@@ -26,6 +32,90 @@ func (s *SQLStore) getActiveUserCount(db sq.BaseRunner, updatedSecondsAgo int64)
 	return count, nil
 }
 
+// getActiveUserCountByTeam returns the number of users with sessions
+// active since the given time. In standalone mode there is a single
+// team, so every user is a member of it.
+func (s *SQLStore) getActiveUserCountByTeam(db sq.BaseRunner, _ string, since int64) (int, error) {
+	query := s.getQueryBuilder(db).
+		Select("count(distinct user_id)").
+		From(s.tablePrefix + "sessions").
+		Where(sq.GtOrEq{"update_at": since}).
+		Where("user_id NOT IN (" + s.deactivatedUserIDs() + ")")
+
+	row := query.QueryRow()
+
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// getActiveUserCountsByDay returns the number of distinct users with
+// sessions active on each day of the [from, to) range, keyed by the
+// start of the day in the server's timezone. Days without active users
+// are included with a zero count.
+func (s *SQLStore) getActiveUserCountsByDay(db sq.BaseRunner, _ string, from, to int64) (map[int64]int, error) {
+	dayStarts := utils.DayStartsInRange(from, to, time.Local)
+	if len(dayStarts) > model.MaxActiveUserCountDays {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("active user counts can't span more than %d days", model.MaxActiveUserCountDays))
+	}
+
+	counts := make(map[int64]int, len(dayStarts))
+	for _, dayStart := range dayStarts {
+		counts[dayStart] = 0
+	}
+	if len(dayStarts) == 0 {
+		return counts, nil
+	}
+
+	sessionsQuery := s.getQueryBuilder(db).
+		Select("user_id").
+		Column(dayBucketExpr("update_at", dayStarts)).
+		From(s.tablePrefix + "sessions").
+		Where(sq.GtOrEq{"update_at": from}).
+		Where(sq.Lt{"update_at": to}).
+		Where("user_id NOT IN (" + s.deactivatedUserIDs() + ")")
+
+	query := s.getQueryBuilder(db).
+		Select("day", "count(distinct user_id)").
+		FromSelect(sessionsQuery, "active_sessions").
+		GroupBy("day")
+
+	rows, err := query.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var day int64
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, err
+		}
+		counts[day] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// dayBucketExpr returns an expression mapping a timestamp column to the
+// start of the day it falls in. The day starts are computed beforehand
+// so the buckets follow the timezone and DST changes of the server
+// rather than fixed 24 hour intervals.
+func dayBucketExpr(column string, dayStarts []int64) string {
+	var sb strings.Builder
+	sb.WriteString("CASE")
+	for i := len(dayStarts) - 1; i >= 0; i-- {
+		fmt.Fprintf(&sb, " WHEN %s >= %d THEN %d", column, dayStarts[i], dayStarts[i])
+	}
+	sb.WriteString(" END AS day")
+	return sb.String()
+}
+
 func (s *SQLStore) getSession(db sq.BaseRunner, token string, expireTimeSeconds int64) (*model.Session, error) {
 	query := s.getQueryBuilder(db).
 		Select("id", "token", "user_id", "auth_service", "props").
@@ -50,6 +140,113 @@ func (s *SQLStore) getSession(db sq.BaseRunner, token string, expireTimeSeconds
 	return &session, nil
 }
 
+// getSessionWithPolicy returns the session for the token unless it has been
+// idle for longer than idleTimeout, measured from its last refresh, or has
+// existed for longer than maxLifetime, measured from its creation. A zero
+// duration disables the corresponding bound.
+func (s *SQLStore) getSessionWithPolicy(db sq.BaseRunner, token string, now int64, idleTimeout, maxLifetime time.Duration) (*model.Session, error) {
+	query := s.getQueryBuilder(db).
+		Select("id", "token", "user_id", "auth_service", "props", "create_at", "update_at").
+		From(s.tablePrefix + "sessions").
+		Where(sq.Eq{"token": token})
+
+	if idleTimeout > 0 {
+		query = query.Where(sq.Gt{"update_at": now - idleTimeout.Milliseconds()})
+	}
+	if maxLifetime > 0 {
+		query = query.Where(sq.Gt{"create_at": now - maxLifetime.Milliseconds()})
+	}
+
+	row := query.QueryRow()
+	session := model.Session{}
+
+	var propsBytes []byte
+	err := row.Scan(
+		&session.ID,
+		&session.Token,
+		&session.UserID,
+		&session.AuthService,
+		&propsBytes,
+		&session.CreateAt,
+		&session.UpdateAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NewErrNotFound("session")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(propsBytes, &session.Props)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// getSessionWithUser returns a valid session along with its user in a
+// single query. If the session has been idle for longer than
+// idleTimeout, is older than maxLifetime or the user is deactivated,
+// it returns a not found error. A zero duration disables its check.
+func (s *SQLStore) getSessionWithUser(db sq.BaseRunner, token string, now int64, idleTimeout, maxLifetime time.Duration) (*model.Session, *model.User, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"s.id", "s.token", "s.user_id", "s.auth_service", "s.props", "s.create_at", "s.update_at",
+			"u.id", "COALESCE(u.username, '')", "COALESCE(u.email, '')", "u.password", "u.mfa_secret", "u.auth_service", "u.auth_data",
+			"u.create_at", "u.update_at", "u.delete_at",
+		).
+		From(s.tablePrefix + "sessions AS s").
+		Join(s.tablePrefix + "users AS u ON u.id = s.user_id").
+		Where(sq.Eq{"s.token": token}).
+		Where(sq.Eq{"u.delete_at": 0})
+
+	if idleTimeout > 0 {
+		query = query.Where(sq.Gt{"s.update_at": now - idleTimeout.Milliseconds()})
+	}
+	if maxLifetime > 0 {
+		query = query.Where(sq.Gt{"s.create_at": now - maxLifetime.Milliseconds()})
+	}
+
+	row := query.QueryRow()
+	session := model.Session{}
+	user := model.User{}
+
+	var propsBytes []byte
+	err := row.Scan(
+		&session.ID,
+		&session.Token,
+		&session.UserID,
+		&session.AuthService,
+		&propsBytes,
+		&session.CreateAt,
+		&session.UpdateAt,
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.Password,
+		&user.MfaSecret,
+		&user.AuthService,
+		&user.AuthData,
+		&user.CreateAt,
+		&user.UpdateAt,
+		&user.DeleteAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, model.NewErrNotFound("session with user")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = json.Unmarshal(propsBytes, &session.Props)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &session, &user, nil
+}
+
 func (s *SQLStore) createSession(db sq.BaseRunner, session *model.Session) error {
 	now := utils.GetMillis()
 
@@ -94,18 +291,78 @@ func (s *SQLStore) updateSession(db sq.BaseRunner, session *model.Session) error
 	return err
 }
 
-func (s *SQLStore) deleteSession(db sq.BaseRunner, sessionID string) error {
+// deleteUserSessions removes all the sessions of a user.
+func (s *SQLStore) deleteUserSessions(db sq.BaseRunner, userID string) error {
 	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
-		Where(sq.Eq{"id": sessionID})
+		Where(sq.Eq{"user_id": userID})
 
 	_, err := query.Exec()
 	return err
 }
 
-func (s *SQLStore) cleanUpSessions(db sq.BaseRunner, expireTimeSeconds int64) error {
+// deactivatedUserIDs returns a subquery selecting the IDs of deactivated users.
+func (s *SQLStore) deactivatedUserIDs() string {
+	return "SELECT id FROM " + s.tablePrefix + "users WHERE delete_at > 0"
+}
+
+func (s *SQLStore) deleteSession(db sq.BaseRunner, sessionID string) error {
 	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
-		Where(sq.Lt{"update_at": utils.GetMillis() - utils.SecondsToMillis(expireTimeSeconds)})
+		Where(sq.Eq{"id": sessionID})
 
 	_, err := query.Exec()
 	return err
 }
+
+// cleanUpSessions removes the sessions that have been idle for longer than
+// idleTimeout or have existed for longer than maxLifetime, along with the
+// sessions of deactivated users. A zero duration disables the corresponding
+// bound. The sessions are deleted in statements of at most batchSize rows,
+// or all at once if batchSize is zero, and the number of sessions deleted
+// is returned. The expiry bounds are computed once, so sessions that
+// expire while the clean up runs are left for the next one.
+func (s *SQLStore) cleanUpSessions(db sq.BaseRunner, idleTimeout, maxLifetime time.Duration, batchSize int64) (int64, error) {
+	now := utils.GetMillis()
+
+	expired := sq.Or{sq.Expr("user_id IN (" + s.deactivatedUserIDs() + ")")}
+	if idleTimeout > 0 {
+		expired = append(expired, sq.Lt{"update_at": now - idleTimeout.Milliseconds()})
+	}
+	if maxLifetime > 0 {
+		expired = append(expired, sq.Lt{"create_at": now - maxLifetime.Milliseconds()})
+	}
+	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
+		Where(expired)
+
+	if batchSize > 0 {
+		if s.dbType == model.MysqlDBType {
+			query = query.Limit(uint64(batchSize))
+		} else {
+			// SQLite and Postgres don't support DELETE with LIMIT
+			idsQuery := s.getQueryBuilder(db).
+				Select("id").
+				From(s.tablePrefix + "sessions").
+				Where(expired).
+				Limit(uint64(batchSize))
+			query = s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
+				Where(idsQuery.Prefix("id IN (").Suffix(")"))
+		}
+	}
+
+	var total int64
+	for {
+		result, err := query.Exec()
+		if err != nil {
+			return total, err
+		}
+
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+
+		if batchSize <= 0 || deleted < batchSize {
+			return total, nil
+		}
+	}
+}
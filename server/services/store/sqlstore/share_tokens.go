@@ -0,0 +1,176 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var shareTokenFields = []string{
+	"id",
+	"board_id",
+	"token",
+	"scope",
+	"card_id",
+	"password_hash",
+	"expire_at",
+	"created_by",
+	"create_at",
+}
+
+func (s *SQLStore) shareTokensFromRows(rows *sql.Rows) ([]*model.ShareToken, error) {
+	shareTokens := []*model.ShareToken{}
+
+	for rows.Next() {
+		var shareToken model.ShareToken
+		err := rows.Scan(
+			&shareToken.ID,
+			&shareToken.BoardID,
+			&shareToken.Token,
+			&shareToken.Scope,
+			&shareToken.CardID,
+			&shareToken.PasswordHash,
+			&shareToken.ExpireAt,
+			&shareToken.CreatedBy,
+			&shareToken.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		shareToken.HasPassword = shareToken.PasswordHash != ""
+		shareTokens = append(shareTokens, &shareToken)
+	}
+	return shareTokens, nil
+}
+
+// createShareToken creates a share token for a board, generating its ID
+// and token.
+func (s *SQLStore) createShareToken(db sq.BaseRunner, shareToken *model.ShareToken) error {
+	if err := shareToken.IsValid(); err != nil {
+		return err
+	}
+
+	shareToken.ID = utils.NewID(utils.IDTypeNone)
+	shareToken.Token = utils.NewID(utils.IDTypeToken)
+	shareToken.CreateAt = utils.GetMillis()
+	shareToken.HasPassword = shareToken.PasswordHash != ""
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"share_tokens").
+		Columns(shareTokenFields...).
+		Values(
+			shareToken.ID,
+			shareToken.BoardID,
+			shareToken.Token,
+			shareToken.Scope,
+			shareToken.CardID,
+			shareToken.PasswordHash,
+			shareToken.ExpireAt,
+			shareToken.CreatedBy,
+			shareToken.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create share token",
+			mlog.String("board_id", shareToken.BoardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	return s.auditChange(db, shareToken.BoardID, shareToken.CreatedBy, model.AuditActionCreateShareToken, shareToken.ID, "scope: "+string(shareToken.Scope))
+}
+
+// getShareToken returns a share token by its token. Expired tokens are
+// returned too, it is up to the caller to check the expiration time.
+func (s *SQLStore) getShareToken(db sq.BaseRunner, token string) (*model.ShareToken, error) {
+	query := s.getQueryBuilder(db).
+		Select(shareTokenFields...).
+		From(s.tablePrefix + "share_tokens").
+		Where(sq.Eq{"token": token})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch share token", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	shareTokens, err := s.shareTokensFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(shareTokens) == 0 {
+		return nil, model.NewErrNotFound("share token")
+	}
+
+	return shareTokens[0], nil
+}
+
+// getShareTokensForBoard returns the share tokens of a board, oldest
+// first, including the expired ones.
+func (s *SQLStore) getShareTokensForBoard(db sq.BaseRunner, boardID string) ([]*model.ShareToken, error) {
+	query := s.getQueryBuilder(db).
+		Select(shareTokenFields...).
+		From(s.tablePrefix+"share_tokens").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch share tokens for board",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.shareTokensFromRows(rows)
+}
+
+// deleteShareToken deletes a share token of a board, which revokes the
+// links built with it.
+func (s *SQLStore) deleteShareToken(db sq.BaseRunner, boardID, id, userID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "share_tokens").
+		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"board_id": boardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("share token ID=" + id)
+	}
+
+	return s.auditChange(db, boardID, userID, model.AuditActionDeleteShareToken, id, "")
+}
+
+// deleteShareTokensForBoard deletes the share tokens of a board, if any.
+func (s *SQLStore) deleteShareTokensForBoard(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "share_tokens").
+		Where(sq.Eq{"board_id": boardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot delete share tokens for board",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
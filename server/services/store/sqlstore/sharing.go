@@ -1,10 +1,15 @@
 package sqlstore
 
 import (
+	"database/sql"
+	"fmt"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 
 	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error {
@@ -18,6 +23,7 @@ func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error
 			"token",
 			"modified_by",
 			"update_at",
+			"expire_at",
 		).
 		Values(
 			sharing.ID,
@@ -25,19 +31,23 @@ func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error
 			sharing.Token,
 			sharing.ModifiedBy,
 			now,
+			sharing.ExpireAt,
 		)
 	if s.dbType == model.MysqlDBType {
-		query = query.Suffix("ON DUPLICATE KEY UPDATE enabled = ?, token = ?, modified_by = ?, update_at = ?",
-			sharing.Enabled, sharing.Token, sharing.ModifiedBy, now)
+		query = query.Suffix("ON DUPLICATE KEY UPDATE enabled = ?, token = ?, modified_by = ?, update_at = ?, expire_at = ?",
+			sharing.Enabled, sharing.Token, sharing.ModifiedBy, now, sharing.ExpireAt)
 	} else {
 		query = query.Suffix(
 			`ON CONFLICT (id)
-			 DO UPDATE SET enabled = EXCLUDED.enabled, token = EXCLUDED.token, modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at`,
+			 DO UPDATE SET enabled = EXCLUDED.enabled, token = EXCLUDED.token, modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at, expire_at = EXCLUDED.expire_at`,
 		)
 	}
 
-	_, err := query.Exec()
-	return err
+	if _, err := query.Exec(); err != nil {
+		return err
+	}
+
+	return s.auditChange(db, sharing.ID, sharing.ModifiedBy, model.AuditActionUpdateSharing, sharing.ID, fmt.Sprintf("enabled: %t", sharing.Enabled))
 }
 
 func (s *SQLStore) getSharing(db sq.BaseRunner, boardID string) (*model.Sharing, error) {
@@ -48,6 +58,7 @@ func (s *SQLStore) getSharing(db sq.BaseRunner, boardID string) (*model.Sharing,
 			"token",
 			"modified_by",
 			"update_at",
+			"expire_at",
 		).
 		From(s.tablePrefix + "sharing").
 		Where(sq.Eq{"id": boardID})
@@ -60,10 +71,113 @@ func (s *SQLStore) getSharing(db sq.BaseRunner, boardID string) (*model.Sharing,
 		&sharing.Token,
 		&sharing.ModifiedBy,
 		&sharing.UpdateAt,
+		&sharing.ExpireAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if sharing.IsExpired(utils.GetMillis()) {
+		return nil, model.ErrSharingExpired
+	}
+
 	return &sharing, nil
 }
+
+// rotateSharingToken replaces the token of a board's sharing with a new
+// one, which invalidates the links built with the previous token.
+func (s *SQLStore) rotateSharingToken(db sq.BaseRunner, rootID, userID string) (*model.Sharing, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"sharing").
+		Set("token", utils.NewID(utils.IDTypeToken)).
+		Set("modified_by", userID).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Eq{"id": rootID}).
+		Exec()
+	if err != nil {
+		s.logger.Error("rotateSharingToken ERROR", mlog.String("rootID", rootID), mlog.Err(err))
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, model.NewErrNotFound("sharing ID=" + rootID)
+	}
+
+	if err := s.auditChange(db, rootID, userID, model.AuditActionRotateSharingToken, rootID, ""); err != nil {
+		return nil, err
+	}
+
+	return s.getSharing(db, rootID)
+}
+
+// getSharingForBoards returns the enabled, unexpired sharing records of
+// the given boards keyed by board ID. Boards that are not shared are
+// absent from the map.
+func (s *SQLStore) getSharingForBoards(db sq.BaseRunner, rootIDs []string) (map[string]*model.Sharing, error) {
+	sharingMap := make(map[string]*model.Sharing, len(rootIDs))
+
+	for start := 0; start < len(rootIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(rootIDs) {
+			end = len(rootIDs)
+		}
+
+		query := s.getQueryBuilder(db).
+			Select(
+				"id",
+				"enabled",
+				"token",
+				"modified_by",
+				"update_at",
+				"expire_at",
+			).
+			From(s.tablePrefix + "sharing").
+			Where(sq.Eq{"id": rootIDs[start:end]}).
+			Where(sq.Eq{"enabled": true})
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error("getSharingForBoards ERROR", mlog.Err(err))
+			return nil, err
+		}
+
+		err = s.sharingFromRows(rows, sharingMap)
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sharingMap, nil
+}
+
+func (s *SQLStore) sharingFromRows(rows *sql.Rows, sharingMap map[string]*model.Sharing) error {
+	now := utils.GetMillis()
+	for rows.Next() {
+		sharing := model.Sharing{}
+		err := rows.Scan(
+			&sharing.ID,
+			&sharing.Enabled,
+			&sharing.Token,
+			&sharing.ModifiedBy,
+			&sharing.UpdateAt,
+			&sharing.ExpireAt,
+		)
+		if err != nil {
+			s.logger.Error("sharingFromRows row scan error", mlog.Err(err))
+			return err
+		}
+
+		if sharing.IsExpired(now) {
+			continue
+		}
+
+		sharingMap[sharing.ID] = &sharing
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,128 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// boardSnapshotData is the serialized content of a board snapshot.
+type boardSnapshotData struct {
+	Board  *model.Board   `json:"board"`
+	Blocks []*model.Block `json:"blocks"`
+}
+
+// upsertBoardSnapshot stores the published snapshot of a board, replacing
+// the previous one if any.
+func (s *SQLStore) upsertBoardSnapshot(db sq.BaseRunner, snapshot *model.BoardSnapshot) error {
+	data, err := json.Marshal(boardSnapshotData{Board: snapshot.Board, Blocks: snapshot.Blocks})
+	if err != nil {
+		return err
+	}
+
+	snapshot.CreateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_snapshots").
+		Columns(
+			"board_id",
+			"data",
+			"created_by",
+			"create_at",
+		).
+		Values(
+			snapshot.BoardID,
+			string(data),
+			snapshot.CreatedBy,
+			snapshot.CreateAt,
+		)
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE data = ?, created_by = ?, create_at = ?",
+			string(data), snapshot.CreatedBy, snapshot.CreateAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (board_id)
+			 DO UPDATE SET data = EXCLUDED.data, created_by = EXCLUDED.created_by, create_at = EXCLUDED.create_at`,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot store board snapshot",
+			mlog.String("board_id", snapshot.BoardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getBoardSnapshot returns the published snapshot of a board.
+func (s *SQLStore) getBoardSnapshot(db sq.BaseRunner, boardID string) (*model.BoardSnapshot, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"board_id",
+			"data",
+			"created_by",
+			"create_at",
+		).
+		From(s.tablePrefix + "board_snapshots").
+		Where(sq.Eq{"board_id": boardID})
+
+	snapshot := model.BoardSnapshot{}
+	var data string
+	err := query.QueryRow().Scan(
+		&snapshot.BoardID,
+		&data,
+		&snapshot.CreatedBy,
+		&snapshot.CreateAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NewErrNotFound("board snapshot BoardID=" + boardID)
+	}
+	if err != nil {
+		s.logger.Error("Cannot fetch board snapshot",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	var content boardSnapshotData
+	if err := json.Unmarshal([]byte(data), &content); err != nil {
+		s.logger.Error("Cannot unmarshal board snapshot",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	snapshot.Board = content.Board
+	snapshot.Blocks = content.Blocks
+	if snapshot.Blocks == nil {
+		snapshot.Blocks = []*model.Block{}
+	}
+
+	return &snapshot, nil
+}
+
+// deleteBoardSnapshot deletes the published snapshot of a board, if any.
+func (s *SQLStore) deleteBoardSnapshot(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_snapshots").
+		Where(sq.Eq{"board_id": boardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot delete board snapshot",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -28,6 +30,20 @@ type SQLStore struct {
 	NewMutexFn       MutexFactory
 	servicesAPI      servicesAPI
 	isBinaryParam    bool
+	hasUnaccent      bool
+
+	// memberLimitMutex serializes member additions with a limit on
+	// SQLite, where the store doesn't use transactions
+	memberLimitMutex sync.Mutex
+
+	// boardBlockLimit is the maximum number of blocks of a board, and
+	// blockLimitMutex serializes block insertions with a limit on SQLite
+	boardBlockLimit int64
+	blockLimitMutex sync.Mutex
+
+	// boardPatchMutex serializes the board patches that expect a
+	// version on SQLite
+	boardPatchMutex sync.Mutex
 }
 
 // MutexFactory is used by the store in plugin mode to generate
@@ -68,6 +84,8 @@ func New(params Params) (*SQLStore, error) {
 			return nil, mErr
 		}
 	}
+
+	store.hasUnaccent = store.computeHasUnaccent()
 	return store, nil
 }
 
@@ -101,6 +119,24 @@ func (s *SQLStore) computeBinaryParam() (bool, error) {
 	return url.Query().Get("binary_parameters") == "yes", nil
 }
 
+// computeHasUnaccent returns whether the unaccent extension is
+// installed when using Postgres. The extension needs to be created by
+// a database administrator, so the search falls back to a case-only
+// insensitive match if it's missing.
+func (s *SQLStore) computeHasUnaccent() bool {
+	if s.dbType != model.PostgresDBType {
+		return false
+	}
+
+	var hasUnaccent bool
+	row := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM pg_extension WHERE extname = 'unaccent')")
+	if err := row.Scan(&hasUnaccent); err != nil {
+		s.logger.Warn("cannot check for the unaccent extension", mlog.Err(err))
+		return false
+	}
+	return hasUnaccent
+}
+
 // Shutdown close the connection with the store.
 func (s *SQLStore) Shutdown() error {
 	return s.db.Close()
@@ -113,6 +149,12 @@ func (s *SQLStore) DBHandle() *sql.DB {
 	return s.db
 }
 
+// SetBoardBlockLimit sets the maximum number of blocks of a board. A
+// limit of zero means unlimited.
+func (s *SQLStore) SetBoardBlockLimit(limit int) {
+	atomic.StoreInt64(&s.boardBlockLimit, int64(limit))
+}
+
 // DBType returns the DB driver used for the store.
 func (s *SQLStore) DBType() string {
 	return s.dbType
@@ -7,8 +7,12 @@ import (
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/store/cachestore"
 	"github.com/mattermost/focalboard/server/services/store/storetests"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 func TestSQLStore(t *testing.T) {
@@ -28,6 +32,29 @@ func TestSQLStore(t *testing.T) {
 	t.Run("StoreTestCategoryStore", func(t *testing.T) { storetests.StoreTestCategoryStore(t, SetupTests) })
 	t.Run("StoreTestCategoryBoardsStore", func(t *testing.T) { storetests.StoreTestCategoryBoardsStore(t, SetupTests) })
 	t.Run("BoardsInsightsStore", func(t *testing.T) { storetests.StoreTestBoardsInsightsStore(t, SetupTests) })
+	t.Run("TemplatesStore", func(t *testing.T) { storetests.StoreTestTemplatesStore(t, SetupTests) })
+	t.Run("WebhooksStore", func(t *testing.T) { storetests.StoreTestWebhooksStore(t, SetupTests) })
+	t.Run("WebhookDeliveriesStore", func(t *testing.T) { storetests.StoreTestWebhookDeliveriesStore(t, SetupTests) })
+	t.Run("BoardCustomRolesStore", func(t *testing.T) { storetests.StoreTestBoardCustomRolesStore(t, SetupTests) })
+	t.Run("AuditStore", func(t *testing.T) { storetests.StoreTestAuditStore(t, SetupTests) })
+}
+
+// TestCacheStore runs the tests of the cached entities through the store
+// cache, to check that the changes invalidate the entries they affect.
+func TestCacheStore(t *testing.T) {
+	setupCached := func(t *testing.T) (store.Store, func()) {
+		sqlStore, tearDown := SetupTests(t)
+		logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+		return cachestore.NewWithCache(sqlStore, cachestore.NewMemoryCache(0, 0), nil, logger), tearDown
+	}
+
+	t.Run("BlocksStore", func(t *testing.T) { storetests.StoreTestBlocksStore(t, setupCached) })
+	t.Run("TeamStore", func(t *testing.T) { storetests.StoreTestTeamStore(t, setupCached) })
+	t.Run("BoardStore", func(t *testing.T) { storetests.StoreTestBoardStore(t, setupCached) })
+	t.Run("BoardsAndBlocksStore", func(t *testing.T) { storetests.StoreTestBoardsAndBlocksStore(t, setupCached) })
+	t.Run("DataRetention", func(t *testing.T) { storetests.StoreTestDataRetention(t, setupCached) })
+	t.Run("TemplatesStore", func(t *testing.T) { storetests.StoreTestTemplatesStore(t, setupCached) })
+	t.Run("BoardCustomRolesStore", func(t *testing.T) { storetests.StoreTestBoardCustomRolesStore(t, setupCached) })
 }
 
 //  tests for  utility functions inside sqlstore.go
@@ -21,6 +21,7 @@ var subscriptionFields = []string{
 	"notified_at",
 	"create_at",
 	"delete_at",
+	"events",
 }
 
 func valuesForSubscription(sub *model.Subscription) []interface{} {
@@ -32,6 +33,16 @@ func valuesForSubscription(sub *model.Subscription) []interface{} {
 		sub.NotifiedAt,
 		sub.CreateAt,
 		sub.DeleteAt,
+		sub.Events,
+	}
+}
+
+// subscriptionEventsCondition matches the subscriptions that notify any
+// of the events. Subscriptions without stored events notify them all.
+func subscriptionEventsCondition(events model.SubscriptionEvents) sq.Sqlizer {
+	return sq.Or{
+		sq.Eq{"events": 0},
+		sq.Expr("(events & ?) <> 0", events),
 	}
 }
 
@@ -48,10 +59,14 @@ func (s *SQLStore) subscriptionsFromRows(rows *sql.Rows) ([]*model.Subscription,
 			&sub.NotifiedAt,
 			&sub.CreateAt,
 			&sub.DeleteAt,
+			&sub.Events,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if sub.Events == 0 {
+			sub.Events = model.SubscriptionEventsAll
+		}
 		subscriptions = append(subscriptions, &sub)
 	}
 	return subscriptions, nil
@@ -77,9 +92,9 @@ func (s *SQLStore) createSubscription(db sq.BaseRunner, sub *model.Subscription)
 		Values(valuesForSubscription(&subAdd)...)
 
 	if s.dbType == model.MysqlDBType {
-		query = query.Suffix("ON DUPLICATE KEY UPDATE delete_at = 0, notified_at = ?", now)
+		query = query.Suffix("ON DUPLICATE KEY UPDATE delete_at = 0, notified_at = ?, events = ?", now, subAdd.Events)
 	} else {
-		query = query.Suffix("ON CONFLICT (block_id,subscriber_id) DO UPDATE SET delete_at = 0, notified_at = ?", now)
+		query = query.Suffix("ON CONFLICT (block_id,subscriber_id) DO UPDATE SET delete_at = 0, notified_at = ?, events = ?", now, subAdd.Events)
 	}
 
 	if _, err := query.Exec(); err != nil {
@@ -90,34 +105,77 @@ func (s *SQLStore) createSubscription(db sq.BaseRunner, sub *model.Subscription)
 		)
 		return nil, err
 	}
+
+	if subAdd.Events == 0 {
+		subAdd.Events = model.SubscriptionEventsAll
+	}
 	return &subAdd, nil
 }
 
-// deleteSubscription soft deletes the subscription for a specific block and subscriber.
-func (s *SQLStore) deleteSubscription(db sq.BaseRunner, blockID string, subscriberID string) error {
+// updateSubscription changes the events notified by an existing
+// subscription and returns the updated subscription.
+func (s *SQLStore) updateSubscription(db sq.BaseRunner, sub *model.Subscription) (*model.Subscription, error) {
+	if err := sub.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"subscriptions").
+		Set("events", sub.Events).
+		Where(sq.Eq{"block_id": sub.BlockID}).
+		Where(sq.Eq{"subscriber_id": sub.SubscriberID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot update subscription",
+			mlog.String("block_id", sub.BlockID),
+			mlog.String("subscriber_id", sub.SubscriberID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	// the subscription is read back instead of checking the affected
+	// rows, as MySQL doesn't count the rows that didn't change
+	return s.getSubscription(db, sub.BlockID, sub.SubscriberID)
+}
+
+// deleteSubscription soft deletes the subscription for a specific block
+// and subscriber, and returns the number of subscriptions deleted, which
+// is zero if there is no such subscription.
+func (s *SQLStore) deleteSubscription(db sq.BaseRunner, blockID string, subscriberID string) (int64, error) {
 	now := model.GetMillis()
 
 	query := s.getQueryBuilder(db).
 		Update(s.tablePrefix+"subscriptions").
 		Set("delete_at", now).
 		Where(sq.Eq{"block_id": blockID}).
-		Where(sq.Eq{"subscriber_id": subscriberID})
+		Where(sq.Eq{"subscriber_id": subscriberID}).
+		Where(sq.Eq{"delete_at": 0})
 
 	result, err := query.Exec()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	count, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+	return result.RowsAffected()
+}
 
-	if count == 0 {
-		message := fmt.Sprintf("subscription BlockID=%s SubscriberID=%s", blockID, subscriberID)
-		return model.NewErrNotFound(message)
-	}
+// deleteSubscriptionsForBlock removes every subscription for a block,
+// regardless of the subscriber. It is used to clean up after a block
+// has been deleted.
+func (s *SQLStore) deleteSubscriptionsForBlock(db sq.BaseRunner, blockID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "subscriptions").
+		Where(sq.Eq{"block_id": blockID})
 
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot delete subscriptions for block",
+			mlog.String("block_id", blockID),
+			mlog.Err(err),
+		)
+		return err
+	}
 	return nil
 }
 
@@ -178,8 +236,16 @@ func (s *SQLStore) getSubscriptions(db sq.BaseRunner, subscriberID string) ([]*m
 	return s.subscriptionsFromRows(rows)
 }
 
-// getSubscribersForBlock fetches all subscribers for a block.
-func (s *SQLStore) getSubscribersForBlock(db sq.BaseRunner, blockID string) ([]*model.Subscriber, error) {
+// notArchivedBlockCondition filters out the subscriptions of archived
+// cards, so they are paused while the card is archived.
+func (s *SQLStore) notArchivedBlockCondition() string {
+	return "block_id NOT IN (SELECT id FROM " + s.tablePrefix + "blocks WHERE COALESCE(archived_at, 0) > 0)"
+}
+
+// getSubscribersForBlock fetches all subscribers for a block. If events
+// is not zero, only the subscribers notified of any of them are
+// returned.
+func (s *SQLStore) getSubscribersForBlock(db sq.BaseRunner, blockID string, events model.SubscriptionEvents) ([]*model.Subscriber, error) {
 	query := s.getQueryBuilder(db).
 		Select(
 			"subscriber_type",
@@ -189,8 +255,13 @@ func (s *SQLStore) getSubscribersForBlock(db sq.BaseRunner, blockID string) ([]*
 		From(s.tablePrefix + "subscriptions").
 		Where(sq.Eq{"block_id": blockID}).
 		Where(sq.Eq{"delete_at": 0}).
+		Where(s.notArchivedBlockCondition()).
 		OrderBy("notified_at")
 
+	if events != 0 {
+		query = query.Where(subscriptionEventsCondition(events))
+	}
+
 	rows, err := query.Query()
 	if err != nil {
 		s.logger.Error("Cannot fetch subscribers for block",
@@ -224,7 +295,8 @@ func (s *SQLStore) getSubscribersCountForBlock(db sq.BaseRunner, blockID string)
 		Select("count(subscriber_id)").
 		From(s.tablePrefix + "subscriptions").
 		Where(sq.Eq{"block_id": blockID}).
-		Where(sq.Eq{"delete_at": 0})
+		Where(sq.Eq{"delete_at": 0}).
+		Where(s.notArchivedBlockCondition())
 
 	row := query.QueryRow()
 
@@ -257,3 +329,23 @@ func (s *SQLStore) updateSubscribersNotifiedAt(db sq.BaseRunner, blockID string,
 	}
 	return nil
 }
+
+// updateSubscriberNotifiedAt updates the notified_at field of a single subscriber for a block.
+func (s *SQLStore) updateSubscriberNotifiedAt(db sq.BaseRunner, blockID, subscriberID string, notifiedAt int64) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"subscriptions").
+		Set("notified_at", notifiedAt).
+		Where(sq.Eq{"block_id": blockID}).
+		Where(sq.Eq{"subscriber_id": subscriberID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("UpdateSubscriberNotifiedAt error occurred while updating subscriber",
+			mlog.String("blockID", blockID),
+			mlog.String("subscriberID", subscriberID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
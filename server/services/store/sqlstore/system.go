@@ -1,6 +1,9 @@
 package sqlstore
 
 import (
+	"fmt"
+	"strconv"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/focalboard/server/model"
 )
@@ -21,6 +24,36 @@ func (s *SQLStore) getSystemSetting(db sq.BaseRunner, key string) (string, error
 	return result, nil
 }
 
+// getSystemSettingBool returns a setting parsed as a boolean. A missing
+// setting is false.
+func (s *SQLStore) getSystemSettingBool(db sq.BaseRunner, key string) (bool, error) {
+	value, err := s.getSystemSetting(db, key)
+	if err != nil || value == "" {
+		return false, err
+	}
+
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("system setting %s is not a valid boolean: %w", key, err)
+	}
+	return result, nil
+}
+
+// getSystemSettingInt returns a setting parsed as an integer. A missing
+// setting is 0.
+func (s *SQLStore) getSystemSettingInt(db sq.BaseRunner, key string) (int, error) {
+	value, err := s.getSystemSetting(db, key)
+	if err != nil || value == "" {
+		return 0, err
+	}
+
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("system setting %s is not a valid integer: %w", key, err)
+	}
+	return result, nil
+}
+
 func (s *SQLStore) getSystemSettings(db sq.BaseRunner) (map[string]string, error) {
 	query := s.getQueryBuilder(db).Select("*").From(s.tablePrefix + "system_settings")
 
@@ -63,3 +96,55 @@ func (s *SQLStore) setSystemSetting(db sq.BaseRunner, id, value string) error {
 
 	return nil
 }
+
+// compareAndSetSystemSetting sets a setting to newValue only if its
+// current value is expectedOld, returning whether it was changed. A
+// missing setting matches an empty expectedOld.
+func (s *SQLStore) compareAndSetSystemSetting(db sq.BaseRunner, key, expectedOld, newValue string) (bool, error) {
+	if expectedOld == newValue {
+		return false, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"system_settings").
+		Set("value", newValue).
+		Where(sq.Eq{"id": key}).
+		Where(sq.Eq{"value": expectedOld})
+
+	result, err := query.Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected > 0 || expectedOld != "" {
+		return affected > 0, nil
+	}
+
+	// the setting doesn't exist yet, so it is inserted unless another
+	// writer got there first.
+	insertQuery := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"system_settings").
+		Columns("id", "value").
+		Values(key, newValue)
+
+	if s.dbType == model.MysqlDBType {
+		insertQuery = insertQuery.Suffix("ON DUPLICATE KEY UPDATE id = id")
+	} else {
+		insertQuery = insertQuery.Suffix("ON CONFLICT (id) DO NOTHING")
+	}
+
+	result, err = insertQuery.Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err = result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -127,10 +127,105 @@ func (s *SQLStore) getTeam(db sq.BaseRunner, id string) (*model.Team, error) {
 	return &team, nil
 }
 
+// getTeamBySignupToken returns the team with the given signup token. An
+// empty token never matches a team.
+func (s *SQLStore) getTeamBySignupToken(db sq.BaseRunner, token string) (*model.Team, error) {
+	if token == "" {
+		return nil, model.NewErrNotFound("team with empty signup token")
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(teamFields...).
+		From(s.tablePrefix + "teams").
+		Where(sq.Eq{"signup_token": token})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("ERROR GetTeamBySignupToken", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	teams, err := s.teamsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(teams) == 0 {
+		return nil, model.NewErrNotFound("team with signup token")
+	}
+
+	return teams[0], nil
+}
+
 func (s *SQLStore) getTeamsForUser(db sq.BaseRunner, _ string) ([]*model.Team, error) {
 	return s.getAllTeams(db)
 }
 
+// boardCountSubquery returns a derived table with the number of
+// non-template boards the user is a member of, grouped by team.
+func (s *SQLStore) boardCountSubquery(userID string) sq.SelectBuilder {
+	return sq.Select("b.team_id", "COUNT(*) AS board_count").
+		From(s.tablePrefix + "boards AS b").
+		Join(s.tablePrefix + "board_members AS bm ON bm.board_id = b.id").
+		Where(sq.Eq{"bm.user_id": userID}).
+		Where(sq.Eq{"b.is_template": false}).
+		GroupBy("b.team_id")
+}
+
+func (s *SQLStore) getTeamsForUserWithBoardCounts(db sq.BaseRunner, userID string) ([]model.TeamWithCount, error) {
+	subquery, args, err := s.boardCountSubquery(userID).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(
+			"t.id",
+			"t.signup_token",
+			"COALESCE(t.settings, '{}')",
+			"t.modified_by",
+			"t.update_at",
+			"COALESCE(bc.board_count, 0)",
+		).
+		From(s.tablePrefix+"teams AS t").
+		LeftJoin("("+subquery+") AS bc ON bc.team_id = t.id", args...)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("ERROR GetTeamsForUserWithBoardCounts", mlog.String("userID", userID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	teams := []model.TeamWithCount{}
+	for rows.Next() {
+		var team model.TeamWithCount
+		var settingsBytes []byte
+
+		err := rows.Scan(
+			&team.ID,
+			&team.SignupToken,
+			&settingsBytes,
+			&team.ModifiedBy,
+			&team.UpdateAt,
+			&team.BoardCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(settingsBytes, &team.Settings)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
 func (s *SQLStore) getTeamCount(db sq.BaseRunner) (int64, error) {
 	query := s.getQueryBuilder(db).
 		Select(
@@ -203,3 +298,158 @@ func (s *SQLStore) getAllTeams(db sq.BaseRunner) ([]*model.Team, error) {
 
 	return teams, nil
 }
+
+// teamBoardTables are the tables keyed by board ID that are removed
+// along with boardDataTables when a whole team is deleted.
+var teamBoardTables = []RetentionTableDeletionInfo{
+	{
+		Table:         "webhook_deliveries",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "board_id",
+	},
+	{
+		Table:         "audit_records",
+		PrimaryKeys:   []string{"id"},
+		BoardIDColumn: "board_id",
+	},
+}
+
+// deleteTeam permanently removes a team together with its boards and
+// every row tied to them, returning how many boards were removed.
+func (s *SQLStore) deleteTeam(db sq.BaseRunner, teamID string) (int, error) {
+	var count int64
+	err := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "teams").
+		Where(sq.Eq{"id": teamID}).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, model.NewErrNotFound("team ID=" + teamID)
+	}
+
+	boardIDs, err := s.getTeamBoardIDs(db, teamID)
+	if err != nil {
+		return 0, err
+	}
+
+	tables := append(append([]RetentionTableDeletionInfo{}, boardDataTables...), teamBoardTables...)
+	for start := 0; start < len(boardIDs); start += maxBlockIDsPerQuery {
+		end := start + maxBlockIDsPerQuery
+		if end > len(boardIDs) {
+			end = len(boardIDs)
+		}
+		chunk := boardIDs[start:end]
+
+		// subscriptions and hints are keyed by block, so they have to go
+		// before the blocks they point to.
+		if err := s.deleteBoardSubscriptionsAndHints(db, chunk); err != nil {
+			return 0, err
+		}
+
+		for _, table := range tables {
+			if _, err := s.genericRetentionPoliciesDeletion(db, table, chunk, 0); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	categoriesQuery := sq.Select("id").
+		From(s.tablePrefix + "categories").
+		Where(sq.Eq{"team_id": teamID})
+	categoriesSQL, categoriesArgs, err := categoriesQuery.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	deleteQueries := []sq.DeleteBuilder{
+		s.getQueryBuilder(db).
+			Delete(s.tablePrefix+"category_boards").
+			Where("category_id IN ("+categoriesSQL+")", categoriesArgs...),
+		s.getQueryBuilder(db).
+			Delete(s.tablePrefix + "categories").
+			Where(sq.Eq{"team_id": teamID}),
+		s.getQueryBuilder(db).
+			Delete(s.tablePrefix + "category_templates").
+			Where(sq.Eq{"team_id": teamID}),
+		s.getQueryBuilder(db).
+			Delete(s.tablePrefix + "teams").
+			Where(sq.Eq{"id": teamID}),
+	}
+	for _, query := range deleteQueries {
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("deleteTeam ERROR", mlog.String("teamID", teamID), mlog.Err(err))
+			return 0, err
+		}
+	}
+
+	return len(boardIDs), nil
+}
+
+// getTeamBoardIDs returns the IDs of every board of a team, including
+// the ones that only remain in the boards history.
+func (s *SQLStore) getTeamBoardIDs(db sq.BaseRunner, teamID string) ([]string, error) {
+	seen := map[string]bool{}
+	boardIDs := []string{}
+
+	for _, table := range []string{"boards", "boards_history"} {
+		query := s.getQueryBuilder(db).
+			Select("DISTINCT id").
+			From(s.tablePrefix + table).
+			Where(sq.Eq{"team_id": teamID})
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error("getTeamBoardIDs ERROR", mlog.String("teamID", teamID), mlog.Err(err))
+			return nil, err
+		}
+		ids, err := idsFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				boardIDs = append(boardIDs, id)
+			}
+		}
+	}
+
+	return boardIDs, nil
+}
+
+// deleteBoardSubscriptionsAndHints removes the subscriptions and
+// notification hints of the given boards and of the blocks in them.
+func (s *SQLStore) deleteBoardSubscriptionsAndHints(db sq.BaseRunner, boardIDs []string) error {
+	blocksQuery := sq.Select("id").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardIDs})
+	blocksSQL, blocksArgs, err := blocksQuery.ToSql()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{"subscriptions", "notification_hints"} {
+		query := s.getQueryBuilder(db).
+			Delete(s.tablePrefix + table).
+			Where(sq.Or{
+				sq.Eq{"block_id": boardIDs},
+				sq.Expr("block_id IN ("+blocksSQL+")", blocksArgs...),
+			})
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("Cannot delete board subscriptions and hints",
+				mlog.String("table", table),
+				mlog.Int("count", len(boardIDs)),
+				mlog.Err(err),
+			)
+			return err
+		}
+	}
+	return nil
+}
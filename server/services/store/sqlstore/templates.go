@@ -54,6 +54,146 @@ func (s *SQLStore) removeDefaultTemplates(db sq.BaseRunner, boards []*model.Boar
 	return nil
 }
 
+// reinstallDefaultTemplates removes the default templates of a team
+// and inserts the given templates in their place. The new templates
+// are created by the system user so they are recognized as default
+// templates.
+func (s *SQLStore) reinstallDefaultTemplates(db sq.BaseRunner, teamID string, templates []*model.Board, userID string) error {
+	existingTemplates, err := s.getDefaultTemplateBoards(db, teamID)
+	if err != nil {
+		return fmt.Errorf("cannot fetch default templates for team %s: %w", teamID, err)
+	}
+
+	if err := s.removeDefaultTemplates(db, existingTemplates); err != nil {
+		return err
+	}
+
+	for _, template := range templates {
+		template.TeamID = teamID
+		template.IsTemplate = true
+		if template.Properties == nil {
+			template.Properties = map[string]interface{}{}
+		}
+
+		if _, err := s.insertBoard(db, template, model.SystemUserID); err != nil {
+			return fmt.Errorf("cannot insert default template %s: %w", template.ID, err)
+		}
+
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"boards").
+			Set("modified_by", userID).
+			Where(sq.Eq{"id": template.ID})
+
+		if _, err := query.Exec(); err != nil {
+			return fmt.Errorf("cannot update default template %s: %w", template.ID, err)
+		}
+		template.ModifiedBy = userID
+	}
+
+	s.logger.Debug("Reinstalled default templates",
+		mlog.String("team_id", teamID),
+		mlog.String("user_id", userID),
+		mlog.Int("count", len(templates)),
+	)
+
+	return nil
+}
+
+// upgradeDefaultTemplates replaces the default templates of a team with
+// a version below toVersion by the given templates. Default templates
+// marked as customized are kept, and nothing is done if no default
+// template needs to be upgraded.
+func (s *SQLStore) upgradeDefaultTemplates(db sq.BaseRunner, teamID string, toVersion int, templates *model.BoardsAndBlocks) error {
+	existingTemplates, err := s.getTemplateBoards(db, teamID, "")
+	if err != nil {
+		return fmt.Errorf("cannot fetch default templates for team %s: %w", teamID, err)
+	}
+
+	outdatedTemplates := []*model.Board{}
+	for _, template := range existingTemplates {
+		if template.CreatedBy != model.SystemUserID || template.IsCustomizedTemplate() {
+			continue
+		}
+		if template.TemplateVersion < toVersion {
+			outdatedTemplates = append(outdatedTemplates, template)
+		}
+	}
+
+	if len(outdatedTemplates) == 0 {
+		s.logger.Debug("Default templates are up to date",
+			mlog.String("team_id", teamID),
+			mlog.Int("version", toVersion),
+		)
+		return nil
+	}
+
+	if err := s.removeDefaultTemplates(db, outdatedTemplates); err != nil {
+		return err
+	}
+
+	for _, template := range templates.Boards {
+		template.TeamID = teamID
+		template.IsTemplate = true
+		template.TemplateVersion = toVersion
+		if template.Properties == nil {
+			template.Properties = map[string]interface{}{}
+		}
+	}
+
+	if _, err := s.createBoardsAndBlocks(db, templates, model.SystemUserID); err != nil {
+		return fmt.Errorf("cannot insert default templates for team %s: %w", teamID, err)
+	}
+
+	s.logger.Debug("Upgraded default templates",
+		mlog.String("team_id", teamID),
+		mlog.Int("version", toVersion),
+		mlog.Int("removed", len(outdatedTemplates)),
+		mlog.Int("inserted", len(templates.Boards)),
+	)
+
+	return nil
+}
+
+// getTemplateBoardsByVersion fetches the template boards of a team with
+// the given template version.
+func (s *SQLStore) getTemplateBoardsByVersion(db sq.BaseRunner, teamID string, version int) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("")...).
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"is_template": true}).
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"template_version": version})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getTemplateBoardsByVersion ERROR`, mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
+
+// getDefaultTemplateBoards fetches the default templates of a team,
+// whatever their type.
+func (s *SQLStore) getDefaultTemplateBoards(db sq.BaseRunner, teamID string) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("")...).
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"is_template": true}).
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"created_by": model.SystemUserID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getDefaultTemplateBoards ERROR`, mlog.String("teamID", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
+
 // getTemplateBoards fetches all template boards .
 func (s *SQLStore) getTemplateBoards(db sq.BaseRunner, teamID, userID string) ([]*model.Board, error) {
 	query := s.getQueryBuilder(db).
@@ -87,3 +227,22 @@ func (s *SQLStore) getTemplateBoards(db sq.BaseRunner, teamID, userID string) ([
 
 	return userTemplates, nil
 }
+
+// getBoardsCreatedFromTemplate fetches the boards that were created
+// using the given template.
+func (s *SQLStore) getBoardsCreatedFromTemplate(db sq.BaseRunner, templateID string) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("")...).
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"source_id": templateID}).
+		Where(sq.Eq{"creation_source": model.BoardCreationSourceTemplate})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsCreatedFromTemplate ERROR`, mlog.String("templateID", templateID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
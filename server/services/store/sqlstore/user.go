@@ -57,22 +57,23 @@ func (s *SQLStore) getUserByCondition(db sq.BaseRunner, condition sq.Eq) (*model
 	return users[0], nil
 }
 
+var userFields = []string{
+	"id",
+	"COALESCE(username, '')",
+	"COALESCE(email, '')",
+	"password",
+	"mfa_secret",
+	"auth_service",
+	"auth_data",
+	"create_at",
+	"update_at",
+	"delete_at",
+}
+
 func (s *SQLStore) getUsersByCondition(db sq.BaseRunner, condition interface{}, limit uint64) ([]*model.User, error) {
 	query := s.getQueryBuilder(db).
-		Select(
-			"id",
-			"username",
-			"email",
-			"password",
-			"mfa_secret",
-			"auth_service",
-			"auth_data",
-			"create_at",
-			"update_at",
-			"delete_at",
-		).
+		Select(userFields...).
 		From(s.tablePrefix + "users").
-		Where(sq.Eq{"delete_at": 0}).
 		Where(condition)
 
 	if limit != 0 {
@@ -98,6 +99,8 @@ func (s *SQLStore) getUsersByCondition(db sq.BaseRunner, condition interface{},
 	return users, nil
 }
 
+// getUserByID returns the user with the given ID, including deactivated
+// users so that authored content can still be attributed to them.
 func (s *SQLStore) getUserByID(db sq.BaseRunner, userID string) (*model.User, error) {
 	return s.getUserByCondition(db, sq.Eq{"id": userID})
 }
@@ -116,11 +119,11 @@ func (s *SQLStore) getUsersList(db sq.BaseRunner, userIDs []string) ([]*model.Us
 }
 
 func (s *SQLStore) getUserByEmail(db sq.BaseRunner, email string) (*model.User, error) {
-	return s.getUserByCondition(db, sq.Eq{"email": email})
+	return s.getUserByCondition(db, sq.Eq{"email": email, "delete_at": 0})
 }
 
 func (s *SQLStore) getUserByUsername(db sq.BaseRunner, username string) (*model.User, error) {
-	return s.getUserByCondition(db, sq.Eq{"username": username})
+	return s.getUserByCondition(db, sq.Eq{"username": username, "delete_at": 0})
 }
 
 func (s *SQLStore) createUser(db sq.BaseRunner, user *model.User) (*model.User, error) {
@@ -131,10 +134,14 @@ func (s *SQLStore) createUser(db sq.BaseRunner, user *model.User) (*model.User,
 
 	query := s.getQueryBuilder(db).Insert(s.tablePrefix+"users").
 		Columns("id", "username", "email", "password", "mfa_secret", "auth_service", "auth_data", "create_at", "update_at", "delete_at").
-		Values(user.ID, user.Username, user.Email, user.Password, user.MfaSecret, user.AuthService, user.AuthData, user.CreateAt, user.UpdateAt, user.DeleteAt)
+		Values(user.ID, nullIfEmpty(user.Username), nullIfEmpty(user.Email), user.Password, user.MfaSecret, user.AuthService, user.AuthData, user.CreateAt, user.UpdateAt, user.DeleteAt)
 
-	_, err := query.Exec()
-	return user, err
+	// the unique indexes on username and email guarantee that
+	// concurrent signups can't create duplicated accounts
+	if _, err := query.Exec(); err != nil {
+		return nil, duplicateError(err, "user")
+	}
+	return user, nil
 }
 
 func (s *SQLStore) updateUser(db sq.BaseRunner, user *model.User) (*model.User, error) {
@@ -142,14 +149,14 @@ func (s *SQLStore) updateUser(db sq.BaseRunner, user *model.User) (*model.User,
 	user.UpdateAt = now
 
 	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
-		Set("username", user.Username).
-		Set("email", user.Email).
+		Set("username", nullIfEmpty(user.Username)).
+		Set("email", nullIfEmpty(user.Email)).
 		Set("update_at", user.UpdateAt).
 		Where(sq.Eq{"id": user.ID})
 
 	result, err := query.Exec()
 	if err != nil {
-		return nil, err
+		return nil, duplicateError(err, "user")
 	}
 
 	rowCount, err := result.RowsAffected()
@@ -164,6 +171,62 @@ func (s *SQLStore) updateUser(db sq.BaseRunner, user *model.User) (*model.User,
 	return user, nil
 }
 
+// deactivateUser disables the account of a user while keeping their authored
+// blocks and board memberships. The sessions of the user are removed so
+// that they are logged out right away.
+func (s *SQLStore) deactivateUser(db sq.BaseRunner, userID string) error {
+	now := utils.GetMillis()
+
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("delete_at", now).
+		Set("update_at", now).
+		Where(sq.Eq{"id": userID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		// the user is either missing or already deactivated
+		if _, err := s.getUserByID(db, userID); err != nil {
+			return err
+		}
+	}
+
+	return s.deleteUserSessions(db, userID)
+}
+
+// reactivateUser enables the account of a deactivated user again.
+func (s *SQLStore) reactivateUser(db sq.BaseRunner, userID string) error {
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("delete_at", 0).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Eq{"id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		return model.NewErrNotFound("user ID=" + userID)
+	}
+
+	return nil
+}
+
 func (s *SQLStore) updateUserPassword(db sq.BaseRunner, username, password string) error {
 	now := utils.GetMillis()
 
@@ -215,7 +278,7 @@ func (s *SQLStore) updateUserPasswordByID(db sq.BaseRunner, userID, password str
 }
 
 func (s *SQLStore) getUsersByTeam(db sq.BaseRunner, _ string, _ string) ([]*model.User, error) {
-	users, err := s.getUsersByCondition(db, nil, 0)
+	users, err := s.getUsersByCondition(db, sq.Eq{"delete_at": 0}, 0)
 	if model.IsErrNotFound(err) {
 		return []*model.User{}, nil
 	}
@@ -223,8 +286,42 @@ func (s *SQLStore) getUsersByTeam(db sq.BaseRunner, _ string, _ string) ([]*mode
 	return users, err
 }
 
+// getUsersByTeamWithRole returns the users of a team with the given
+// role. In standalone mode there is a single team and no team roles,
+// so every user is a regular member of it.
+func (s *SQLStore) getUsersByTeamWithRole(db sq.BaseRunner, _ string, role string, includeDeleted bool) ([]*model.User, error) {
+	if !model.IsValidTeamRole(role) {
+		return nil, model.NewErrBadRequest("invalid team role: " + role)
+	}
+
+	if role != model.TeamRoleUser {
+		return []*model.User{}, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(userFields...).
+		From(s.tablePrefix+"users").
+		OrderBy("username", "id")
+
+	if !includeDeleted {
+		query = query.Where(sq.Eq{"delete_at": 0})
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getUsersByTeamWithRole ERROR`, mlog.String("role", role), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.usersFromRows(rows)
+}
+
 func (s *SQLStore) searchUsersByTeam(db sq.BaseRunner, _ string, searchQuery string, _ string, _ bool) ([]*model.User, error) {
-	users, err := s.getUsersByCondition(db, &sq.Like{"username": "%" + searchQuery + "%"}, 10)
+	users, err := s.getUsersByCondition(db, sq.And{
+		sq.Like{"username": "%" + searchQuery + "%"},
+		sq.Eq{"delete_at": 0},
+	}, 10)
 	if model.IsErrNotFound(err) {
 		return []*model.User{}, nil
 	}
@@ -3,17 +3,27 @@ package sqlstore
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"strings"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+const (
+	postgresUniqueViolation = "23505"
+	mysqlDuplicateEntry     = 1062
+)
+
 func (s *SQLStore) CloseRows(rows *sql.Rows) {
 	if err := rows.Close(); err != nil {
 		s.logger.Error("error closing MattermostAuthLayer row set", mlog.Err(err))
@@ -24,6 +34,45 @@ func (s *SQLStore) IsErrNotFound(err error) bool {
 	return model.IsErrNotFound(err)
 }
 
+// isUniqueConstraintError returns true if the error was caused by a
+// unique constraint violation in any of the supported databases.
+func isUniqueConstraintError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == postgresUniqueViolation
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+
+	return false
+}
+
+// duplicateError translates a unique constraint violation into a
+// model.ErrDuplicate for the resource, returning any other error as is.
+func duplicateError(err error, resource string) error {
+	if isUniqueConstraintError(err) {
+		return model.NewErrDuplicate(resource)
+	}
+	return err
+}
+
+// nullIfEmpty stores empty strings as NULL, so they don't collide in
+// unique indexes.
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
 func (s *SQLStore) MarshalJSONB(data interface{}) ([]byte, error) {
 	b, err := json.Marshal(data)
 	if err != nil {
@@ -0,0 +1,435 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	webhookDeliveryRetryBase = 30 * time.Second
+	webhookDeliveryRetryMax  = time.Hour
+)
+
+var webhookFields = []string{
+	"id",
+	"board_id",
+	"url",
+	"secret",
+	"event_types",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) webhooksFromRows(rows *sql.Rows) ([]*model.Webhook, error) {
+	webhooks := []*model.Webhook{}
+
+	for rows.Next() {
+		var webhook model.Webhook
+		var eventTypes sql.NullString
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.BoardID,
+			&webhook.URL,
+			&webhook.Secret,
+			&eventTypes,
+			&webhook.CreatedBy,
+			&webhook.CreateAt,
+			&webhook.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook.EventTypes = []string{}
+		if eventTypes.Valid && eventTypes.String != "" {
+			if err := json.Unmarshal([]byte(eventTypes.String), &webhook.EventTypes); err != nil {
+				s.logger.Error("webhooksFromRows unmarshal event types error", mlog.String("id", webhook.ID), mlog.Err(err))
+				return nil, err
+			}
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+	return webhooks, nil
+}
+
+// createWebhook registers a webhook for a board. A signing secret is
+// generated if the webhook has none.
+func (s *SQLStore) createWebhook(db sq.BaseRunner, webhook *model.Webhook) error {
+	if err := webhook.IsValid(); err != nil {
+		return err
+	}
+
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	if webhook.ID == "" {
+		webhook.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = utils.NewID(utils.IDTypeToken)
+	}
+	webhook.CreateAt = now
+	webhook.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"webhooks").
+		Columns(webhookFields...).
+		Values(
+			webhook.ID,
+			webhook.BoardID,
+			webhook.URL,
+			webhook.Secret,
+			string(eventTypes),
+			webhook.CreatedBy,
+			webhook.CreateAt,
+			webhook.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create webhook",
+			mlog.String("board_id", webhook.BoardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	return s.auditChange(db, webhook.BoardID, webhook.CreatedBy, model.AuditActionCreateWebhook, webhook.ID, "url: "+webhook.URL)
+}
+
+// getWebhooksForBoard returns the webhooks registered for a board.
+func (s *SQLStore) getWebhooksForBoard(db sq.BaseRunner, boardID string) ([]*model.Webhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookFields...).
+		From(s.tablePrefix+"webhooks").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch webhooks for board",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.webhooksFromRows(rows)
+}
+
+// getWebhook returns a webhook by its ID.
+func (s *SQLStore) getWebhook(db sq.BaseRunner, id string) (*model.Webhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookFields...).
+		From(s.tablePrefix + "webhooks").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch webhook", mlog.String("id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	webhooks, err := s.webhooksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) == 0 {
+		return nil, model.NewErrNotFound("webhook ID=" + id)
+	}
+
+	return webhooks[0], nil
+}
+
+// updateWebhook updates the URL and event types of a webhook, and its
+// secret if one is provided.
+func (s *SQLStore) updateWebhook(db sq.BaseRunner, webhook *model.Webhook, userID string) error {
+	if err := webhook.IsValid(); err != nil {
+		return err
+	}
+
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	webhook.UpdateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"webhooks").
+		Set("url", webhook.URL).
+		Set("event_types", string(eventTypes)).
+		Set("update_at", webhook.UpdateAt).
+		Where(sq.Eq{"id": webhook.ID}).
+		Where(sq.Eq{"board_id": webhook.BoardID})
+
+	if webhook.Secret != "" {
+		query = query.Set("secret", webhook.Secret)
+	}
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update webhook",
+			mlog.String("id", webhook.ID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("webhook ID=" + webhook.ID)
+	}
+
+	return s.auditChange(db, webhook.BoardID, userID, model.AuditActionUpdateWebhook, webhook.ID, "url: "+webhook.URL)
+}
+
+// deleteWebhook deletes a webhook.
+func (s *SQLStore) deleteWebhook(db sq.BaseRunner, id, userID string) error {
+	webhook, err := s.getWebhook(db, id)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "webhooks").
+		Where(sq.Eq{"id": id})
+
+	if _, err := query.Exec(); err != nil {
+		return err
+	}
+
+	return s.auditChange(db, webhook.BoardID, userID, model.AuditActionDeleteWebhook, id, "url: "+webhook.URL)
+}
+
+// deleteWebhooksForBoard deletes the webhooks of a board, if any.
+func (s *SQLStore) deleteWebhooksForBoard(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "webhooks").
+		Where(sq.Eq{"board_id": boardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot delete webhooks for board",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+var webhookDeliveryFields = []string{
+	"id",
+	"webhook_id",
+	"board_id",
+	"event_type",
+	"payload",
+	"attempts",
+	"next_retry_at",
+	"status",
+	"create_at",
+	"update_at",
+	"delivered_at",
+}
+
+func (s *SQLStore) webhookDeliveriesFromRows(rows *sql.Rows) ([]*model.WebhookDelivery, error) {
+	deliveries := []*model.WebhookDelivery{}
+
+	for rows.Next() {
+		var delivery model.WebhookDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.BoardID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Attempts,
+			&delivery.NextRetryAt,
+			&delivery.Status,
+			&delivery.CreateAt,
+			&delivery.UpdateAt,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+// webhookDeliveryRetryDelay returns how long to wait before retrying a
+// delivery that has been attempted the given number of times.
+func webhookDeliveryRetryDelay(attempts int) time.Duration {
+	delay := webhookDeliveryRetryBase
+	for i := 1; i < attempts && delay < webhookDeliveryRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > webhookDeliveryRetryMax {
+		delay = webhookDeliveryRetryMax
+	}
+	return delay
+}
+
+// recordWebhookDelivery queues a webhook delivery. Deliveries without a
+// next retry time are due immediately.
+func (s *SQLStore) recordWebhookDelivery(db sq.BaseRunner, delivery *model.WebhookDelivery) error {
+	if err := delivery.IsValid(); err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	if delivery.ID == "" {
+		delivery.ID = utils.NewID(utils.IDTypeNone)
+	}
+	delivery.Attempts = 0
+	delivery.Status = 0
+	delivery.DeliveredAt = 0
+	delivery.CreateAt = now
+	delivery.UpdateAt = now
+	if delivery.NextRetryAt == 0 {
+		delivery.NextRetryAt = now
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"webhook_deliveries").
+		Columns(webhookDeliveryFields...).
+		Values(
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.BoardID,
+			delivery.EventType,
+			delivery.Payload,
+			delivery.Attempts,
+			delivery.NextRetryAt,
+			delivery.Status,
+			delivery.CreateAt,
+			delivery.UpdateAt,
+			delivery.DeliveredAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot record webhook delivery",
+			mlog.String("board_id", delivery.BoardID),
+			mlog.String("event_type", delivery.EventType),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getPendingWebhookDeliveries claims up to limit deliveries that are due,
+// oldest first. Claimed deliveries have their attempt count increased and
+// their next retry time pushed back, so concurrent workers don't pick
+// them up again unless they are not marked as delivered in time.
+func (s *SQLStore) getPendingWebhookDeliveries(db sq.BaseRunner, limit int) ([]*model.WebhookDelivery, error) {
+	now := utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Select(webhookDeliveryFields...).
+		From(s.tablePrefix+"webhook_deliveries").
+		Where(sq.Eq{"delivered_at": 0}).
+		Where(sq.LtOrEq{"next_retry_at": now}).
+		Where(sq.Lt{"attempts": model.WebhookDeliveryMaxAttempts}).
+		OrderBy("next_retry_at", "id")
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	// SQLite serializes writers, so only the other databases need to lock
+	// the claimed rows
+	if s.dbType != model.SqliteDBType {
+		query = query.Suffix("FOR UPDATE SKIP LOCKED")
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch pending webhook deliveries", mlog.Err(err))
+		return nil, err
+	}
+
+	deliveries, err := s.webhookDeliveriesFromRows(rows)
+	s.CloseRows(rows)
+	if err != nil {
+		s.logger.Error("Cannot get pending webhook deliveries", mlog.Err(err))
+		return nil, err
+	}
+
+	for _, delivery := range deliveries {
+		delivery.Attempts++
+		delivery.NextRetryAt = utils.GetMillisForTime(time.Now().Add(webhookDeliveryRetryDelay(delivery.Attempts)))
+		delivery.UpdateAt = now
+
+		claimQuery := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"webhook_deliveries").
+			Set("attempts", delivery.Attempts).
+			Set("next_retry_at", delivery.NextRetryAt).
+			Set("update_at", delivery.UpdateAt).
+			Where(sq.Eq{"id": delivery.ID})
+
+		if _, err := claimQuery.Exec(); err != nil {
+			s.logger.Error("Cannot claim webhook delivery",
+				mlog.String("id", delivery.ID),
+				mlog.Err(err),
+			)
+			return nil, err
+		}
+	}
+
+	return deliveries, nil
+}
+
+// markWebhookDelivered marks a webhook delivery as done, recording the
+// response status of the target.
+func (s *SQLStore) markWebhookDelivered(db sq.BaseRunner, id string, status int) error {
+	now := utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"webhook_deliveries").
+		Set("status", status).
+		Set("delivered_at", now).
+		Set("update_at", now).
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot mark webhook delivery as delivered",
+			mlog.String("id", id),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("webhook delivery ID=" + id)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@
 package store
 
 import (
+	"context"
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -14,157 +15,369 @@ const CardLimitTimestampSystemKey = "card_limit_timestamp"
 
 // Store represents the abstraction of the data storage.
 type Store interface {
-	GetBlocks(opts model.QueryBlocksOptions) ([]*model.Block, error)
-	GetBlocksWithParentAndType(boardID, parentID string, blockType string) ([]*model.Block, error)
-	GetBlocksWithParent(boardID, parentID string) ([]*model.Block, error)
-	GetBlocksByIDs(ids []string) ([]*model.Block, error)
-	GetBlocksWithType(boardID, blockType string) ([]*model.Block, error)
-	GetSubTree2(boardID, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error)
-	GetBlocksForBoard(boardID string) ([]*model.Block, error)
+	GetBlocks(ctx context.Context, opts model.QueryBlocksOptions) ([]*model.Block, error)
+	GetBlocksForBoards(ctx context.Context, boardIDs []string, opts model.QueryBlocksOptions) ([]*model.Block, error)
+	GetBlocksWithParentAndType(ctx context.Context, boardID, parentID string, blockType string) ([]*model.Block, error)
+	GetBlocksWithParentAndTypes(ctx context.Context, boardID, parentID string, blockTypes []string) ([]*model.Block, error)
+	GetRecentComments(ctx context.Context, boardID string, limit int) ([]*model.CommentWithAuthor, error)
+	GetBlocksWithParent(ctx context.Context, boardID, parentID string) ([]*model.Block, error)
+	GetBlocksByIDs(ctx context.Context, ids []string) ([]*model.Block, error)
+	GetBlocksMap(ctx context.Context, boardID string, ids []string) (map[string]*model.Block, error)
+	GetBlocksWithType(ctx context.Context, boardID, blockType string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error)
+	GetSubTree2(ctx context.Context, boardID, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error)
+	GetSubTree(ctx context.Context, boardID, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error)
+	GetBlocksForBoard(ctx context.Context, boardID string, opts model.QueryBlocksOptions) ([]*model.Block, bool, error)
+	GetBlocksForBoardStream(ctx context.Context, boardID string, fn func(model.Block) error) error
+	GetLastModifiedBlockForBoard(ctx context.Context, boardID string) (*model.Block, error)
 	// @withTransaction
-	InsertBlock(block *model.Block, userID string) error
+	// @invalidatesCache block:cardID
+	ArchiveCard(ctx context.Context, cardID, userID string) error
 	// @withTransaction
-	DeleteBlock(blockID string, modifiedBy string) error
+	// @invalidatesCache block:cardID
+	UnarchiveCard(ctx context.Context, cardID, userID string) error
+	GetArchivedCards(ctx context.Context, boardID string) ([]*model.Block, error)
+	CountCardsByPropertyGrouped(ctx context.Context, boardID, propertyID string) (map[string]int64, error)
+	GetCardsMissingProperty(ctx context.Context, boardID, propertyID string) ([]*model.Block, error)
+	SearchBlocksForBoard(ctx context.Context, boardID, term string, fields []string) ([]*model.Block, error)
+	SearchBlocksForUser(ctx context.Context, teamID, term, userID string, opts model.SearchBlocksOptions) ([]*model.Block, error)
+	SearchBlocksInBoards(ctx context.Context, boardIDs []string, term string, opts model.SearchBlocksOptions) ([]*model.Block, error)
 	// @withTransaction
-	InsertBlocks(blocks []*model.Block, userID string) error
+	// @invalidatesCache block:block.ID
+	InsertBlock(ctx context.Context, block *model.Block, userID string) error
 	// @withTransaction
-	UndeleteBlock(blockID string, modifiedBy string) error
+	// @invalidatesCache block:blockID
+	DeleteBlock(ctx context.Context, blockID string, modifiedBy string) (int64, error)
 	// @withTransaction
-	UndeleteBoard(boardID string, modifiedBy string) error
-	GetBlockCountsByType() (map[string]int64, error)
-	GetBoardCount() (int64, error)
-	GetBlock(blockID string) (*model.Block, error)
+	// @invalidatesCache block
+	InsertBlocks(ctx context.Context, blocks []*model.Block, userID string) error
 	// @withTransaction
-	PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error
-	GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
-	GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
-	GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
-	GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error)
-	GetBoardAndCard(block *model.Block) (board *model.Board, card *model.Block, err error)
+	// @invalidatesCache block:blockID
+	UndeleteBlock(ctx context.Context, blockID string, modifiedBy string) error
 	// @withTransaction
-	DuplicateBoard(boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error)
+	// @invalidatesCache block:blockID
+	RestoreBlock(ctx context.Context, blockID, userID string) (*model.Block, error)
+	GetDeletedBlocksForBoard(ctx context.Context, boardID string) ([]*model.Block, error)
 	// @withTransaction
-	DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error)
+	PurgeDeletedBlocks(ctx context.Context, olderThan time.Time) (int, error)
 	// @withTransaction
-	PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error
+	// @invalidatesCache block
+	MoveBlocks(ctx context.Context, blockIDs []string, targetBoardID, userID string) error
+	// @withTransaction
+	CopyBlocks(ctx context.Context, blockIDs []string, targetBoardID, userID string) (map[string]string, error)
+	// @withTransaction
+	// @invalidatesCache board block member
+	MergeBoards(ctx context.Context, sourceBoardID, targetBoardID, userID string) (map[string]string, error)
+	// @withTransaction
+	// @invalidatesCache board:boardID block member
+	UndeleteBoard(ctx context.Context, boardID string, modifiedBy string) error
+	GetBlockCountsByType(ctx context.Context) (map[string]int64, error)
+	GetBlockCountsByTypeForBoard(ctx context.Context, boardID string) (map[string]int64, error)
+	GetBoardStats(ctx context.Context, boardID string) (*model.BoardStats, error)
+	GetBlockCountForTeam(ctx context.Context, teamID string) (int64, error)
+	GetBlockCountsForTeams(ctx context.Context, teamIDs []string) (map[string]int64, error)
+	GetBoardCount(ctx context.Context) (int64, error)
+	CountBoardsCreatedBetween(ctx context.Context, teamID string, start, end int64) (int64, error)
+	CountBoardsCreatedBetweenAllTeams(ctx context.Context, start, end int64) (int64, error)
+	// @cached block
+	GetBlock(ctx context.Context, blockID string) (*model.Block, error)
+	// @withTransaction
+	// @invalidatesCache block:blockID
+	PatchBlock(ctx context.Context, blockID string, blockPatch *model.BlockPatch, userID string) (int64, error)
+	GetBlockHistory(ctx context.Context, blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
+	GetBlockHistoryEntry(ctx context.Context, blockID string, version int64) (*model.Block, error)
+	GetBlockHistoryDescendants(ctx context.Context, boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
+	GetBoardHistory(ctx context.Context, boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
+	GetBoardAndCardByID(ctx context.Context, blockID string) (board *model.Board, card *model.Block, err error)
+	GetBoardAndCard(ctx context.Context, block *model.Block) (board *model.Board, card *model.Block, err error)
+	// @withTransaction
+	DuplicateBoard(ctx context.Context, boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error)
+	// @withTransaction
+	DuplicateBoardWithOptions(ctx context.Context, boardID string, userID string, opts model.DuplicateBoardOptions) (*model.BoardsAndBlocks, []*model.BoardMember, error)
+	// @withTransaction
+	DuplicateBlock(ctx context.Context, boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error)
+	// @withTransaction
+	// @invalidatesCache block
+	PatchBlocks(ctx context.Context, blockPatches *model.BlockPatchBatch, userID string) error
+	// @withTransaction
+	// @invalidatesCache block:cardID
+	NormalizeContentOrder(ctx context.Context, cardID string) error
 
 	Shutdown() error
 
-	GetSystemSetting(key string) (string, error)
-	GetSystemSettings() (map[string]string, error)
-	SetSystemSetting(key, value string) error
+	GetSystemSetting(ctx context.Context, key string) (string, error)
+	GetSystemSettingBool(ctx context.Context, key string) (bool, error)
+	GetSystemSettingInt(ctx context.Context, key string) (int, error)
+	GetSystemSettings(ctx context.Context) (map[string]string, error)
+	SetSystemSetting(ctx context.Context, key, value string) error
+	CompareAndSetSystemSetting(ctx context.Context, key, expectedOld, newValue string) (bool, error)
 
-	GetRegisteredUserCount() (int, error)
-	GetUserByID(userID string) (*model.User, error)
-	GetUsersList(userIDs []string) ([]*model.User, error)
-	GetUserByEmail(email string) (*model.User, error)
-	GetUserByUsername(username string) (*model.User, error)
-	CreateUser(user *model.User) (*model.User, error)
-	UpdateUser(user *model.User) (*model.User, error)
-	UpdateUserPassword(username, password string) error
-	UpdateUserPasswordByID(userID, password string) error
-	GetUsersByTeam(teamID string, asGuestID string) ([]*model.User, error)
-	SearchUsersByTeam(teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error)
-	PatchUserPreferences(userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error)
-	GetUserPreferences(userID string) (mmModel.Preferences, error)
+	GetRegisteredUserCount(ctx context.Context) (int, error)
+	GetUserByID(ctx context.Context, userID string) (*model.User, error)
+	GetUsersList(ctx context.Context, userIDs []string) ([]*model.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
+	CreateUser(ctx context.Context, user *model.User) (*model.User, error)
+	UpdateUser(ctx context.Context, user *model.User) (*model.User, error)
+	UpdateUserPassword(ctx context.Context, username, password string) error
+	UpdateUserPasswordByID(ctx context.Context, userID, password string) error
+	// @withTransaction
+	DeactivateUser(ctx context.Context, userID string) error
+	ReactivateUser(ctx context.Context, userID string) error
+	GetUsersByTeam(ctx context.Context, teamID string, asGuestID string) ([]*model.User, error)
+	GetUsersByTeamWithRole(ctx context.Context, teamID, role string, includeDeleted bool) ([]*model.User, error)
+	SearchUsersByTeam(ctx context.Context, teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error)
+	PatchUserPreferences(ctx context.Context, userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error)
+	GetUserPreferences(ctx context.Context, userID string) (mmModel.Preferences, error)
 
-	GetActiveUserCount(updatedSecondsAgo int64) (int, error)
-	GetSession(token string, expireTime int64) (*model.Session, error)
-	CreateSession(session *model.Session) error
-	RefreshSession(session *model.Session) error
-	UpdateSession(session *model.Session) error
-	DeleteSession(sessionID string) error
-	CleanUpSessions(expireTime int64) error
+	GetActiveUserCount(ctx context.Context, updatedSecondsAgo int64) (int, error)
+	GetActiveUserCountByTeam(ctx context.Context, teamID string, since int64) (int, error)
+	GetActiveUserCountsByDay(ctx context.Context, teamID string, from, to int64) (map[int64]int, error)
+	GetSession(ctx context.Context, token string, expireTime int64) (*model.Session, error)
+	GetSessionWithPolicy(ctx context.Context, token string, now int64, idleTimeout, maxLifetime time.Duration) (*model.Session, error)
+	GetSessionWithUser(ctx context.Context, token string, now int64, idleTimeout, maxLifetime time.Duration) (*model.Session, *model.User, error)
+	CreateSession(ctx context.Context, session *model.Session) error
+	RefreshSession(ctx context.Context, session *model.Session) error
+	UpdateSession(ctx context.Context, session *model.Session) error
+	DeleteSession(ctx context.Context, sessionID string) error
+	CleanUpSessions(ctx context.Context, idleTimeout, maxLifetime time.Duration, batchSize int64) (int64, error)
 
-	UpsertSharing(sharing model.Sharing) error
-	GetSharing(rootID string) (*model.Sharing, error)
+	// @withTransaction
+	UpsertSharing(ctx context.Context, sharing model.Sharing) error
+	GetSharing(ctx context.Context, rootID string) (*model.Sharing, error)
+	GetSharingForBoards(ctx context.Context, rootIDs []string) (map[string]*model.Sharing, error)
+	// @withTransaction
+	RotateSharingToken(ctx context.Context, rootID, userID string) (*model.Sharing, error)
+	UpsertBoardSnapshot(ctx context.Context, snapshot *model.BoardSnapshot) error
+	GetBoardSnapshot(ctx context.Context, boardID string) (*model.BoardSnapshot, error)
+	// @withTransaction
+	CreateShareToken(ctx context.Context, shareToken *model.ShareToken) error
+	GetShareToken(ctx context.Context, token string) (*model.ShareToken, error)
+	GetShareTokensForBoard(ctx context.Context, boardID string) ([]*model.ShareToken, error)
+	// @withTransaction
+	DeleteShareToken(ctx context.Context, boardID, id, userID string) error
 
-	UpsertTeamSignupToken(team model.Team) error
-	UpsertTeamSettings(team model.Team) error
-	GetTeam(ID string) (*model.Team, error)
-	GetTeamsForUser(userID string) ([]*model.Team, error)
-	GetAllTeams() ([]*model.Team, error)
-	GetTeamCount() (int64, error)
+	UpsertTeamSignupToken(ctx context.Context, team model.Team) error
+	UpsertTeamSettings(ctx context.Context, team model.Team) error
+	GetTeam(ctx context.Context, ID string) (*model.Team, error)
+	GetTeamBySignupToken(ctx context.Context, token string) (*model.Team, error)
+	GetTeamsForUser(ctx context.Context, userID string) ([]*model.Team, error)
+	GetTeamsForUserWithBoardCounts(ctx context.Context, userID string) ([]model.TeamWithCount, error)
+	GetAllTeams(ctx context.Context) ([]*model.Team, error)
+	GetTeamCount(ctx context.Context) (int64, error)
+	// @withTransaction
+	// @invalidatesCache board block member
+	DeleteTeam(ctx context.Context, teamID string) (int, error)
 
-	InsertBoard(board *model.Board, userID string) (*model.Board, error)
 	// @withTransaction
-	InsertBoardWithAdmin(board *model.Board, userID string) (*model.Board, *model.BoardMember, error)
+	// @invalidatesCache board:board.ID
+	InsertBoard(ctx context.Context, board *model.Board, userID string) (*model.Board, error)
+	// @withTransaction
+	// @invalidatesCache board:board.ID member:board.ID,userID
+	InsertBoardWithAdmin(ctx context.Context, board *model.Board, userID string) (*model.Board, *model.BoardMember, error)
+	// @withTransaction
+	// @invalidatesCache board:board.ID member
+	CreateBoardComplete(ctx context.Context, board *model.Board, creatorID string, extraMembers []*model.BoardMember, categoryID string) (*model.Board, []*model.BoardMember, error)
+	// @withTransaction
+	// @invalidatesCache board:boardID member
+	PatchBoard(ctx context.Context, boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error)
+	// @cached board
+	GetBoard(ctx context.Context, id string) (*model.Board, error)
+	GetBoards(ctx context.Context, ids []string) ([]*model.Board, error)
+	GetBoardWithStats(ctx context.Context, boardID, userID string) (*model.BoardWithStats, error)
+	ValidateBoardSchema(ctx context.Context, boardID string) (*model.SchemaReport, error)
+	GetBoardsForUserAndTeam(ctx context.Context, userID, teamID string, includePublicBoards bool) ([]*model.Board, error)
+	GetBoardsForUserAndTeamWithOptions(ctx context.Context, userID, teamID string, opts model.QueryBoardsOptions) ([]*model.Board, error)
+	GetBoardsAdministeredByUser(ctx context.Context, userID, teamID string) ([]*model.Board, error)
+	GetBoardCardProperties(ctx context.Context, boardID string) ([]model.CardProperty, error)
+	// @withTransaction
+	// @invalidatesCache board:boardID member
+	SetBoardPropertyOrder(ctx context.Context, boardID string, propertyIDs []string, userID string) error
+	GetBoardsModifiedSince(ctx context.Context, teamID, userID string, since int64) ([]*model.Board, error)
+	// @withTransaction
+	SetBoardFavorite(ctx context.Context, userID, boardID string, favorite bool) error
+	GetFavoriteBoards(ctx context.Context, userID, teamID string) ([]*model.Board, error)
+	GetBoardsInTeamByIds(ctx context.Context, boardIDs []string, teamID string) ([]*model.Board, error)
 	// @withTransaction
-	PatchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error)
-	GetBoard(id string) (*model.Board, error)
-	GetBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*model.Board, error)
-	GetBoardsInTeamByIds(boardIDs []string, teamID string) ([]*model.Board, error)
+	// @invalidatesCache board:boardID block member
+	DeleteBoard(ctx context.Context, boardID, userID string) error
 	// @withTransaction
-	DeleteBoard(boardID, userID string) error
+	// @invalidatesCache board:boardID member
+	ArchiveBoard(ctx context.Context, boardID, userID string) error
+	GetArchivedBoards(ctx context.Context, teamID string) ([]*model.Board, error)
+	// @withTransaction
+	// @invalidatesCache board:boardID member
+	RestoreBoard(ctx context.Context, boardID, userID string) error
+	// @withTransaction
+	// @invalidatesCache board block member
+	PurgeArchivedBoards(ctx context.Context, olderThan time.Time) (int, error)
 
-	SaveMember(bm *model.BoardMember) (*model.BoardMember, error)
-	DeleteMember(boardID, userID string) error
-	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
-	GetBoardMemberHistory(boardID, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error)
-	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
-	GetMembersForUser(userID string) ([]*model.BoardMember, error)
-	CanSeeUser(seerID string, seenID string) (bool, error)
-	SearchBoardsForUser(term, userID string, includePublicBoards bool) ([]*model.Board, error)
-	SearchBoardsForUserInTeam(teamID, term, userID string) ([]*model.Board, error)
+	// @withTransaction
+	// @invalidatesCache member:bm.BoardID,bm.UserID
+	SaveMember(ctx context.Context, bm *model.BoardMember, modifiedBy string) (*model.BoardMember, error)
+	// @withTransaction
+	// @invalidatesCache member
+	SaveMembers(ctx context.Context, members []*model.BoardMember, modifiedBy string) ([]*model.BoardMember, error)
+	// @withTransaction
+	// @invalidatesCache member:bm.BoardID,bm.UserID
+	SaveMemberWithLimit(ctx context.Context, bm *model.BoardMember, maxMembers int, modifiedBy string) (*model.BoardMember, error)
+	GetBoardMemberCount(ctx context.Context, boardID string) (int, error)
+	// @withTransaction
+	// @invalidatesCache member:boardID,userID
+	DeleteMember(ctx context.Context, boardID, userID, modifiedBy string) (int64, error)
+	// @withTransaction
+	// @invalidatesCache member
+	DeleteMembers(ctx context.Context, boardID string, userIDs []string, modifiedBy string) (int, error)
+	// @withTransaction
+	// @invalidatesCache member:boardID,userID
+	UpdateMemberRole(ctx context.Context, boardID, userID, role, modifiedBy string) error
+	// the members carry the minimum role of their board, so the board
+	// writes invalidate the member cache too
+	// @cached member
+	GetMemberForBoard(ctx context.Context, boardID, userID string) (*model.BoardMember, error)
+	GetBoardMemberHistory(ctx context.Context, boardID, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error)
+	GetMembersForBoard(ctx context.Context, boardID string) ([]*model.BoardMember, error)
+	GetMembersForBoardWithOptions(ctx context.Context, boardID string, opts model.QueryBoardMembersOptions) ([]*model.BoardMember, bool, error)
+	GetMembersForUser(ctx context.Context, userID string) ([]*model.BoardMember, error)
+	// @invalidatesCache member:boardID,userID
+	UpdateMemberLastViewed(ctx context.Context, boardID, userID string, viewedAt int64) error
+	// @withTransaction
+	// @invalidatesCache member:boardID,userID
+	SetMemberCustomRole(ctx context.Context, boardID, userID, roleID, modifiedBy string) error
 
 	// @withTransaction
-	CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error)
+	CreateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole) error
+	GetBoardCustomRole(ctx context.Context, id string) (*model.BoardCustomRole, error)
+	GetBoardCustomRoles(ctx context.Context, boardID string) ([]*model.BoardCustomRole, error)
+	// @withTransaction
+	UpdateBoardCustomRole(ctx context.Context, role *model.BoardCustomRole, userID string) error
+	// @withTransaction
+	// @invalidatesCache member
+	DeleteBoardCustomRole(ctx context.Context, id, userID string) error
+
+	GetRecentlyViewedBoards(ctx context.Context, userID, teamID string, limit int) ([]*model.Board, error)
+	CanSeeUser(ctx context.Context, seerID string, seenID string) (bool, error)
+	SearchBoardsForUser(ctx context.Context, term, userID string, includePublicBoards bool) ([]*model.Board, error)
+	SearchBoardsForUserInTeam(ctx context.Context, teamID, term, userID string) ([]*model.Board, error)
+
 	// @withTransaction
-	CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error)
+	// @invalidatesCache board block member
+	CreateBoardsAndBlocksWithAdmin(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error)
 	// @withTransaction
-	PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error)
+	// @invalidatesCache board block
+	CreateBoardsAndBlocks(ctx context.Context, bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error)
 	// @withTransaction
-	DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error
+	// @invalidatesCache board block member
+	PatchBoardsAndBlocks(ctx context.Context, pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error)
+	// @withTransaction
+	// @invalidatesCache board block member
+	DeleteBoardsAndBlocks(ctx context.Context, dbab *model.DeleteBoardsAndBlocks, userID string) error
+
+	GetCategory(ctx context.Context, id string) (*model.Category, error)
+	CreateCategory(ctx context.Context, category model.Category) error
+	UpdateCategory(ctx context.Context, category model.Category) error
+	DeleteCategory(ctx context.Context, categoryID, userID, teamID string) error
 
-	GetCategory(id string) (*model.Category, error)
-	CreateCategory(category model.Category) error
-	UpdateCategory(category model.Category) error
-	DeleteCategory(categoryID, userID, teamID string) error
+	GetUserCategoryBoards(ctx context.Context, userID, teamID string) ([]model.CategoryBoards, error)
+	GetCategoryForBoard(ctx context.Context, userID, teamID, boardID string) (*model.Category, error)
+	GetAllCategoriesForTeam(ctx context.Context, teamID string) ([]model.Category, error)
+	FindDuplicateCategories(ctx context.Context, userID, teamID string) (map[string][]model.Category, error)
 
-	GetUserCategoryBoards(userID, teamID string) ([]model.CategoryBoards, error)
+	GetDefaultCategoryTemplates(ctx context.Context, teamID string) ([]model.CategoryTemplate, error)
+	UpsertDefaultCategoryTemplate(ctx context.Context, template model.CategoryTemplate) error
+	// @withTransaction
+	CreateDefaultCategoriesForUser(ctx context.Context, userID, teamID string) error
 
-	GetFileInfo(id string) (*mmModel.FileInfo, error)
-	SaveFileInfo(fileInfo *mmModel.FileInfo) error
+	GetFileInfo(ctx context.Context, id string) (*mmModel.FileInfo, error)
+	SaveFileInfo(ctx context.Context, fileInfo *mmModel.FileInfo) error
+	GetFileInfosForBoard(ctx context.Context, boardID string) ([]*mmModel.FileInfo, error)
 
 	// @withTransaction
-	AddUpdateCategoryBoard(userID, categoryID, blockID string) error
+	AddUpdateCategoryBoard(ctx context.Context, userID, categoryID, blockID string) error
+	// @withTransaction
+	ReorderCategoryBoards(ctx context.Context, userID, categoryID string, boardIDs []string) error
+	// @withTransaction
+	RemoveCategoryBoards(ctx context.Context, userID, categoryID string, boardIDs []string) error
+	// @withTransaction
+	ClearCategory(ctx context.Context, userID, categoryID string) error
+	// @withTransaction
+	MergeCategories(ctx context.Context, userID, primaryCategoryID string, mergeCategoryIDs []string) error
+	// @withTransaction
+	ReorderCategories(ctx context.Context, userID, teamID string, categoryIDs []string) error
+
+	CreateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error)
+	DeleteSubscription(ctx context.Context, blockID string, subscriberID string) (int64, error)
+	DeleteSubscriptionsForBlock(ctx context.Context, blockID string) error
+	GetSubscription(ctx context.Context, blockID string, subscriberID string) (*model.Subscription, error)
+	GetSubscriptions(ctx context.Context, subscriberID string) ([]*model.Subscription, error)
+	UpdateSubscription(ctx context.Context, sub *model.Subscription) (*model.Subscription, error)
+	GetSubscribersForBlock(ctx context.Context, blockID string, events model.SubscriptionEvents) ([]*model.Subscriber, error)
+	GetSubscribersCountForBlock(ctx context.Context, blockID string) (int, error)
+	UpdateSubscribersNotifiedAt(ctx context.Context, blockID string, notifiedAt int64) error
+	UpdateSubscriberNotifiedAt(ctx context.Context, blockID, subscriberID string, notifiedAt int64) error
 
-	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
-	DeleteSubscription(blockID string, subscriberID string) error
-	GetSubscription(blockID string, subscriberID string) (*model.Subscription, error)
-	GetSubscriptions(subscriberID string) ([]*model.Subscription, error)
-	GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error)
-	GetSubscribersCountForBlock(blockID string) (int, error)
-	UpdateSubscribersNotifiedAt(blockID string, notifiedAt int64) error
+	UpsertNotificationHint(ctx context.Context, hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error)
+	DeleteNotificationHint(ctx context.Context, blockID string) error
+	GetNotificationHint(ctx context.Context, blockID string) (*model.NotificationHint, error)
+	GetNextNotificationHint(ctx context.Context, remove bool) (*model.NotificationHint, error)
+	GetDueNotificationHints(ctx context.Context, now int64, limit int) ([]*model.NotificationHint, error)
+	// @withTransaction
+	GetNotificationHints(ctx context.Context, limit int) ([]*model.NotificationHint, error)
+	DeleteNotificationHints(ctx context.Context, blockIDs []string) error
+
+	// @withTransaction
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) error
+	GetWebhook(ctx context.Context, id string) (*model.Webhook, error)
+	GetWebhooksForBoard(ctx context.Context, boardID string) ([]*model.Webhook, error)
+	// @withTransaction
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook, userID string) error
+	// @withTransaction
+	DeleteWebhook(ctx context.Context, id, userID string) error
+	RecordWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+	// @withTransaction
+	GetPendingWebhookDeliveries(ctx context.Context, limit int) ([]*model.WebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, id string, status int) error
 
-	UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error)
-	DeleteNotificationHint(blockID string) error
-	GetNotificationHint(blockID string) (*model.NotificationHint, error)
-	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
+	InsertAuditRecord(ctx context.Context, rec *model.AuditRecord) error
+	GetAuditRecords(ctx context.Context, boardID string, opts model.QueryAuditOptions) ([]*model.AuditRecord, error)
 
-	RemoveDefaultTemplates(boards []*model.Board) error
-	GetTemplateBoards(teamID, userID string) ([]*model.Board, error)
+	// @invalidatesCache board block
+	RemoveDefaultTemplates(ctx context.Context, boards []*model.Board) error
+	// @withTransaction
+	// @invalidatesCache board block member
+	ReinstallDefaultTemplates(ctx context.Context, teamID string, templates []*model.Board, userID string) error
+	GetTemplateBoards(ctx context.Context, teamID, userID string) ([]*model.Board, error)
+	GetTemplateBoardsByVersion(ctx context.Context, teamID string, version int) ([]*model.Board, error)
+	// @withTransaction
+	// @invalidatesCache board block member
+	UpgradeDefaultTemplates(ctx context.Context, teamID string, toVersion int, templates *model.BoardsAndBlocks) error
+	GetBoardsCreatedFromTemplate(ctx context.Context, templateID string) ([]*model.Board, error)
 
 	// @withTransaction
-	RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error)
+	// @invalidatesCache board block member
+	RunDataRetention(ctx context.Context, globalRetentionDate int64, batchSize int64) (int64, error)
 
-	GetUsedCardsCount() (int, error)
-	GetCardLimitTimestamp() (int64, error)
-	UpdateCardLimitTimestamp(cardLimit int) (int64, error)
+	GetUsedCardsCount(ctx context.Context) (int, error)
+	GetCardLimitTimestamp(ctx context.Context) (int64, error)
+	UpdateCardLimitTimestamp(ctx context.Context, cardLimit int) (int64, error)
 
 	DBType() string
 
-	GetLicense() *mmModel.License
-	GetCloudLimits() (*mmModel.ProductLimits, error)
-	SearchUserChannels(teamID, userID, query string) ([]*mmModel.Channel, error)
-	GetChannel(teamID, channelID string) (*mmModel.Channel, error)
-	PostMessage(message, postType, channelID string) error
-	SendMessage(message, postType string, receipts []string) error
+	// SetBoardBlockLimit sets the maximum number of blocks of a board,
+	// which block insertions can't exceed. A limit of zero means
+	// unlimited.
+	SetBoardBlockLimit(limit int)
+
+	GetLicense(ctx context.Context) *mmModel.License
+	GetCloudLimits(ctx context.Context) (*mmModel.ProductLimits, error)
+	SearchUserChannels(ctx context.Context, teamID, userID, query string) ([]*mmModel.Channel, error)
+	GetChannel(ctx context.Context, teamID, channelID string) (*mmModel.Channel, error)
+	PostMessage(ctx context.Context, message, postType, channelID string) error
+	SendMessage(ctx context.Context, message, postType string, receipts []string) error
 
 	// Insights
-	GetTeamBoardsInsights(teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error)
-	GetUserBoardsInsights(teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error)
-	GetUserTimezone(userID string) (string, error)
+	GetTeamBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error)
+	GetUserBoardsInsights(ctx context.Context, teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error)
+	GetUserTimezone(ctx context.Context, userID string) (string, error)
 }
 
 type NotSupportedError struct {
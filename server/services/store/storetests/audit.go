@@ -0,0 +1,574 @@
+package storetests
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestAuditStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("MutationsAreAudited", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMutationsAreAudited(t, store)
+	})
+
+	t.Run("EveryMutationIsAudited", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testEveryMutationIsAudited(t, store)
+	})
+
+	t.Run("GetAuditRecords", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetAuditRecords(t, store)
+	})
+}
+
+func auditActions(t *testing.T, store store.Store, boardID string) []string {
+	records, err := store.GetAuditRecords(context.Background(), boardID, model.QueryAuditOptions{})
+	require.NoError(t, err)
+
+	actions := make([]string, 0, len(records))
+	for _, record := range records {
+		actions = append(actions, string(record.Action)+":"+record.ResourceID+":"+record.ActorID)
+	}
+	return actions
+}
+
+func testMutationsAreAudited(t *testing.T, store store.Store) {
+	board := &model.Board{ID: "board-id", TeamID: testTeamID, Type: model.BoardTypeOpen}
+	_, _, err := store.InsertBoardWithAdmin(context.Background(), board, testUserID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"createBoard:board-id:user-id",
+		"saveMember:user-id:user-id",
+	}, auditActions(t, store, board.ID))
+	time.Sleep(1 * time.Millisecond)
+
+	t.Run("block mutations", func(t *testing.T) {
+		block := &model.Block{ID: "card-id", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard}
+		require.NoError(t, store.InsertBlock(context.Background(), block, "user-1"))
+
+		title := "new title"
+		_, err := store.PatchBlock(context.Background(), block.ID, &model.BlockPatch{Title: &title}, "user-2")
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Millisecond)
+		_, err = store.DeleteBlock(context.Background(), block.ID, "user-3")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			"insertBlock:card-id:user-1",
+			"patchBlock:card-id:user-2",
+			"deleteBlock:card-id:user-3",
+		}, auditActions(t, store, board.ID)[2:])
+
+		require.Equal(t, []string{
+			"type: card",
+			"changed: title",
+			"type: card",
+		}, auditSummaries(t, store, board.ID)[2:])
+	})
+
+	t.Run("failed mutations aren't audited", func(t *testing.T) {
+		title := "new title"
+		_, err := store.PatchBlock(context.Background(), "nonexistent", &model.BlockPatch{Title: &title}, "user-4")
+		require.Error(t, err)
+
+		err = store.InsertBlock(context.Background(), &model.Block{ID: "no-board", Type: model.TypeCard}, "user-4")
+		require.Error(t, err)
+
+		_, err = store.PatchBoard(context.Background(), "nonexistent", &model.BoardPatch{}, "user-4")
+		require.Error(t, err)
+
+		require.Len(t, auditActions(t, store, board.ID), 5)
+		require.Empty(t, auditActions(t, store, "nonexistent"))
+	})
+
+	t.Run("board mutations", func(t *testing.T) {
+		title := "new board title"
+		_, err := store.PatchBoard(context.Background(), board.ID, &model.BoardPatch{Title: &title}, "user-5")
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, store.DeleteBoard(context.Background(), board.ID, "user-6"))
+
+		actions := auditActions(t, store, board.ID)
+		require.Len(t, actions, 7)
+		require.Equal(t, []string{
+			"patchBoard:board-id:user-5",
+			"deleteBoard:board-id:user-6",
+		}, actions[5:])
+		require.Equal(t, "changed: title", auditSummaries(t, store, board.ID)[5])
+	})
+}
+
+// auditedMethods maps the Store methods that change a board, its
+// content or who can access it to the action they're audited with.
+var auditedMethods = map[string]model.AuditAction{
+	"InsertBlock":                    model.AuditActionInsertBlock,
+	"InsertBlocks":                   model.AuditActionInsertBlock,
+	"DuplicateBlock":                 model.AuditActionInsertBlock,
+	"PatchBlock":                     model.AuditActionPatchBlock,
+	"PatchBlocks":                    model.AuditActionPatchBlock,
+	"DeleteBlock":                    model.AuditActionDeleteBlock,
+	"UndeleteBlock":                  model.AuditActionUndeleteBlock,
+	"RestoreBlock":                   model.AuditActionRestoreBlock,
+	"MoveBlocks":                     model.AuditActionMoveBlock,
+	"CopyBlocks":                     model.AuditActionCopyBlock,
+	"ArchiveCard":                    model.AuditActionArchiveCard,
+	"UnarchiveCard":                  model.AuditActionUnarchiveCard,
+	"InsertBoard":                    model.AuditActionCreateBoard,
+	"InsertBoardWithAdmin":           model.AuditActionCreateBoard,
+	"CreateBoardComplete":            model.AuditActionCreateBoard,
+	"CreateBoardsAndBlocks":          model.AuditActionCreateBoard,
+	"CreateBoardsAndBlocksWithAdmin": model.AuditActionCreateBoard,
+	"DuplicateBoard":                 model.AuditActionCreateBoard,
+	"DuplicateBoardWithOptions":      model.AuditActionCreateBoard,
+	"PatchBoard":                     model.AuditActionPatchBoard,
+	"PatchBoardsAndBlocks":           model.AuditActionPatchBoard,
+	"SetBoardPropertyOrder":          model.AuditActionPatchBoard,
+	"DeleteBoard":                    model.AuditActionDeleteBoard,
+	"DeleteBoardsAndBlocks":          model.AuditActionDeleteBoard,
+	"UndeleteBoard":                  model.AuditActionUndeleteBoard,
+	"ArchiveBoard":                   model.AuditActionArchiveBoard,
+	"RestoreBoard":                   model.AuditActionRestoreBoard,
+	"MergeBoards":                    model.AuditActionMergeBoards,
+	"SaveMember":                     model.AuditActionSaveMember,
+	"SaveMembers":                    model.AuditActionSaveMember,
+	"SaveMemberWithLimit":            model.AuditActionSaveMember,
+	"UpdateMemberRole":               model.AuditActionSaveMember,
+	"SetMemberCustomRole":            model.AuditActionSaveMember,
+	"DeleteMember":                   model.AuditActionDeleteMember,
+	"DeleteMembers":                  model.AuditActionDeleteMember,
+	"CreateBoardCustomRole":          model.AuditActionCreateCustomRole,
+	"UpdateBoardCustomRole":          model.AuditActionUpdateCustomRole,
+	"DeleteBoardCustomRole":          model.AuditActionDeleteCustomRole,
+	"UpsertSharing":                  model.AuditActionUpdateSharing,
+	"RotateSharingToken":             model.AuditActionRotateSharingToken,
+	"CreateShareToken":               model.AuditActionCreateShareToken,
+	"DeleteShareToken":               model.AuditActionDeleteShareToken,
+	"CreateWebhook":                  model.AuditActionCreateWebhook,
+	"UpdateWebhook":                  model.AuditActionUpdateWebhook,
+	"DeleteWebhook":                  model.AuditActionDeleteWebhook,
+}
+
+// unauditedMethods are the Store methods that write without changing
+// what a board holds or who can access it: per user state, sessions,
+// system maintenance and the audit trail itself.
+var unauditedMethods = []string{
+	"AddUpdateCategoryBoard", "CleanUpSessions", "ClearCategory",
+	"CompareAndSetSystemSetting", "CreateCategory", "CreateDefaultCategoriesForUser",
+	"CreateSession", "CreateSubscription", "CreateUser", "DeactivateUser",
+	"DeleteCategory", "DeleteNotificationHint", "DeleteNotificationHints",
+	"DeleteSession", "DeleteSubscription", "DeleteSubscriptionsForBlock",
+	"DeleteTeam", "InsertAuditRecord", "MarkWebhookDelivered", "MergeCategories",
+	"NormalizeContentOrder", "PatchUserPreferences", "PostMessage",
+	"PurgeArchivedBoards", "PurgeDeletedBlocks", "ReactivateUser",
+	"RecordWebhookDelivery", "RefreshSession", "ReinstallDefaultTemplates",
+	"RemoveCategoryBoards", "RemoveDefaultTemplates", "ReorderCategories",
+	"ReorderCategoryBoards", "RunDataRetention", "SaveFileInfo", "SendMessage",
+	"SetBoardBlockLimit", "SetBoardFavorite", "SetSystemSetting",
+	"UpdateCardLimitTimestamp", "UpdateCategory", "UpdateMemberLastViewed",
+	"UpdateSession", "UpdateSubscriberNotifiedAt", "UpdateSubscribersNotifiedAt",
+	"UpdateSubscription", "UpdateUser", "UpdateUserPassword", "UpdateUserPasswordByID",
+	"UpgradeDefaultTemplates", "UpsertBoardSnapshot", "UpsertDefaultCategoryTemplate",
+	"UpsertNotificationHint", "UpsertTeamSettings", "UpsertTeamSignupToken",
+}
+
+func isReadMethod(name string) bool {
+	for _, prefix := range []string{"Get", "Search", "Count", "Find", "CanSee", "Validate"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return name == "DBType" || name == "Shutdown"
+}
+
+func testEveryMutationIsAudited(t *testing.T, store store.Store) {
+	const actorID = "actor-id"
+	ctx := context.Background()
+
+	t.Run("every mutating method is classified", func(t *testing.T) {
+		unaudited := map[string]bool{}
+		for _, name := range unauditedMethods {
+			unaudited[name] = true
+		}
+
+		storeType := reflect.TypeOf(&store).Elem()
+		for i := 0; i < storeType.NumMethod(); i++ {
+			name := storeType.Method(i).Name
+			if isReadMethod(name) || unaudited[name] {
+				continue
+			}
+			_, ok := auditedMethods[name]
+			require.Truef(t, ok, "%s must be audited or listed in unauditedMethods", name)
+		}
+	})
+
+	boardCount := 0
+	newBoard := func(t *testing.T) *model.Board {
+		boardCount++
+		board := &model.Board{ID: fmt.Sprintf("audited-board-%d", boardCount), TeamID: testTeamID, Type: model.BoardTypeOpen}
+		_, _, err := store.InsertBoardWithAdmin(ctx, board, testUserID)
+		require.NoError(t, err)
+		return board
+	}
+
+	cardCount := 0
+	newCard := func(t *testing.T, boardID string) *model.Block {
+		cardCount++
+		card := &model.Block{ID: fmt.Sprintf("audited-card-%d", cardCount), BoardID: boardID, ParentID: boardID, Type: model.TypeCard}
+		require.NoError(t, store.InsertBlock(ctx, card, testUserID))
+		// the history is keyed by the time of the change
+		time.Sleep(1 * time.Millisecond)
+		return card
+	}
+
+	newMember := func(t *testing.T, boardID string) *model.BoardMember {
+		member, err := store.SaveMember(ctx, &model.BoardMember{BoardID: boardID, UserID: "member-id", SchemeViewer: true}, testUserID)
+		require.NoError(t, err)
+		time.Sleep(1 * time.Millisecond)
+		return member
+	}
+
+	newRole := func(t *testing.T, boardID string) *model.BoardCustomRole {
+		role := &model.BoardCustomRole{BoardID: boardID, Name: "Role", Permissions: model.RolePermissionViewBoard, CreatedBy: actorID}
+		require.NoError(t, store.CreateBoardCustomRole(ctx, role))
+		return role
+	}
+
+	newWebhook := func(t *testing.T, boardID string) *model.Webhook {
+		webhook := &model.Webhook{BoardID: boardID, URL: "https://example.com/hook", EventTypes: []string{"card.created"}, CreatedBy: actorID}
+		require.NoError(t, store.CreateWebhook(ctx, webhook))
+		return webhook
+	}
+
+	title := "audited title"
+	mutations := map[string]func(t *testing.T) error{
+		"InsertBlock": func(t *testing.T) error {
+			board := newBoard(t)
+			return store.InsertBlock(ctx, &model.Block{ID: "inserted-card", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard}, actorID)
+		},
+		"InsertBlocks": func(t *testing.T) error {
+			board := newBoard(t)
+			return store.InsertBlocks(ctx, []*model.Block{{ID: "inserted-cards", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard}}, actorID)
+		},
+		"DuplicateBlock": func(t *testing.T) error {
+			board := newBoard(t)
+			_, err := store.DuplicateBlock(ctx, board.ID, newCard(t, board.ID).ID, actorID, false)
+			return err
+		},
+		"PatchBlock": func(t *testing.T) error {
+			board := newBoard(t)
+			_, err := store.PatchBlock(ctx, newCard(t, board.ID).ID, &model.BlockPatch{Title: &title}, actorID)
+			return err
+		},
+		"PatchBlocks": func(t *testing.T) error {
+			board := newBoard(t)
+			return store.PatchBlocks(ctx, &model.BlockPatchBatch{
+				BlockIDs:     []string{newCard(t, board.ID).ID},
+				BlockPatches: []model.BlockPatch{{Title: &title}},
+			}, actorID)
+		},
+		"DeleteBlock": func(t *testing.T) error {
+			board := newBoard(t)
+			_, err := store.DeleteBlock(ctx, newCard(t, board.ID).ID, actorID)
+			return err
+		},
+		"UndeleteBlock": func(t *testing.T) error {
+			board := newBoard(t)
+			card := newCard(t, board.ID)
+			_, err := store.DeleteBlock(ctx, card.ID, testUserID)
+			require.NoError(t, err)
+			time.Sleep(1 * time.Millisecond)
+			return store.UndeleteBlock(ctx, card.ID, actorID)
+		},
+		"RestoreBlock": func(t *testing.T) error {
+			board := newBoard(t)
+			card := newCard(t, board.ID)
+			_, err := store.DeleteBlock(ctx, card.ID, testUserID)
+			require.NoError(t, err)
+			time.Sleep(1 * time.Millisecond)
+			_, err = store.RestoreBlock(ctx, card.ID, actorID)
+			return err
+		},
+		"MoveBlocks": func(t *testing.T) error {
+			card := newCard(t, newBoard(t).ID)
+			return store.MoveBlocks(ctx, []string{card.ID}, newBoard(t).ID, actorID)
+		},
+		"CopyBlocks": func(t *testing.T) error {
+			card := newCard(t, newBoard(t).ID)
+			_, err := store.CopyBlocks(ctx, []string{card.ID}, newBoard(t).ID, actorID)
+			return err
+		},
+		"ArchiveCard": func(t *testing.T) error {
+			return store.ArchiveCard(ctx, newCard(t, newBoard(t).ID).ID, actorID)
+		},
+		"UnarchiveCard": func(t *testing.T) error {
+			card := newCard(t, newBoard(t).ID)
+			require.NoError(t, store.ArchiveCard(ctx, card.ID, testUserID))
+			time.Sleep(1 * time.Millisecond)
+			return store.UnarchiveCard(ctx, card.ID, actorID)
+		},
+		"InsertBoard": func(t *testing.T) error {
+			_, err := store.InsertBoard(ctx, &model.Board{ID: "inserted-board", TeamID: testTeamID, Type: model.BoardTypeOpen}, actorID)
+			return err
+		},
+		"InsertBoardWithAdmin": func(t *testing.T) error {
+			_, _, err := store.InsertBoardWithAdmin(ctx, &model.Board{ID: "inserted-admin-board", TeamID: testTeamID, Type: model.BoardTypeOpen}, actorID)
+			return err
+		},
+		"CreateBoardComplete": func(t *testing.T) error {
+			_, _, err := store.CreateBoardComplete(ctx, &model.Board{ID: "complete-board", TeamID: testTeamID, Type: model.BoardTypeOpen}, actorID, nil, "")
+			return err
+		},
+		"CreateBoardsAndBlocks": func(t *testing.T) error {
+			_, err := store.CreateBoardsAndBlocks(ctx, &model.BoardsAndBlocks{
+				Boards: []*model.Board{{ID: "created-board", TeamID: testTeamID, Type: model.BoardTypeOpen}},
+			}, actorID)
+			return err
+		},
+		"CreateBoardsAndBlocksWithAdmin": func(t *testing.T) error {
+			_, _, err := store.CreateBoardsAndBlocksWithAdmin(ctx, &model.BoardsAndBlocks{
+				Boards: []*model.Board{{ID: "created-admin-board", TeamID: testTeamID, Type: model.BoardTypeOpen}},
+			}, actorID)
+			return err
+		},
+		"DuplicateBoard": func(t *testing.T) error {
+			board := newBoard(t)
+			newCard(t, board.ID)
+			_, _, err := store.DuplicateBoard(ctx, board.ID, actorID, "", false)
+			return err
+		},
+		"DuplicateBoardWithOptions": func(t *testing.T) error {
+			board := newBoard(t)
+			newCard(t, board.ID)
+			_, _, err := store.DuplicateBoardWithOptions(ctx, board.ID, actorID, model.DuplicateBoardOptions{IncludeCards: true})
+			return err
+		},
+		"PatchBoard": func(t *testing.T) error {
+			_, err := store.PatchBoard(ctx, newBoard(t).ID, &model.BoardPatch{Title: &title}, actorID)
+			return err
+		},
+		"PatchBoardsAndBlocks": func(t *testing.T) error {
+			_, err := store.PatchBoardsAndBlocks(ctx, &model.PatchBoardsAndBlocks{
+				BoardIDs:     []string{newBoard(t).ID},
+				BoardPatches: []*model.BoardPatch{{Title: &title}},
+			}, actorID)
+			return err
+		},
+		"SetBoardPropertyOrder": func(t *testing.T) error {
+			return store.SetBoardPropertyOrder(ctx, newBoard(t).ID, []string{}, actorID)
+		},
+		"DeleteBoard": func(t *testing.T) error {
+			return store.DeleteBoard(ctx, newBoard(t).ID, actorID)
+		},
+		"DeleteBoardsAndBlocks": func(t *testing.T) error {
+			return store.DeleteBoardsAndBlocks(ctx, &model.DeleteBoardsAndBlocks{Boards: []string{newBoard(t).ID}}, actorID)
+		},
+		"UndeleteBoard": func(t *testing.T) error {
+			board := newBoard(t)
+			require.NoError(t, store.DeleteBoard(ctx, board.ID, testUserID))
+			return store.UndeleteBoard(ctx, board.ID, actorID)
+		},
+		"ArchiveBoard": func(t *testing.T) error {
+			return store.ArchiveBoard(ctx, newBoard(t).ID, actorID)
+		},
+		"RestoreBoard": func(t *testing.T) error {
+			board := newBoard(t)
+			require.NoError(t, store.ArchiveBoard(ctx, board.ID, testUserID))
+			return store.RestoreBoard(ctx, board.ID, actorID)
+		},
+		"MergeBoards": func(t *testing.T) error {
+			_, err := store.MergeBoards(ctx, newBoard(t).ID, newBoard(t).ID, actorID)
+			return err
+		},
+		"SaveMember": func(t *testing.T) error {
+			_, err := store.SaveMember(ctx, &model.BoardMember{BoardID: newBoard(t).ID, UserID: "member-id", SchemeViewer: true}, actorID)
+			return err
+		},
+		"SaveMembers": func(t *testing.T) error {
+			_, err := store.SaveMembers(ctx, []*model.BoardMember{{BoardID: newBoard(t).ID, UserID: "member-id", SchemeViewer: true}}, actorID)
+			return err
+		},
+		"SaveMemberWithLimit": func(t *testing.T) error {
+			_, err := store.SaveMemberWithLimit(ctx, &model.BoardMember{BoardID: newBoard(t).ID, UserID: "member-id", SchemeViewer: true}, 0, actorID)
+			return err
+		},
+		"UpdateMemberRole": func(t *testing.T) error {
+			member := newMember(t, newBoard(t).ID)
+			return store.UpdateMemberRole(ctx, member.BoardID, member.UserID, string(model.BoardRoleEditor), actorID)
+		},
+		"SetMemberCustomRole": func(t *testing.T) error {
+			member := newMember(t, newBoard(t).ID)
+			return store.SetMemberCustomRole(ctx, member.BoardID, member.UserID, newRole(t, member.BoardID).ID, actorID)
+		},
+		"DeleteMember": func(t *testing.T) error {
+			member := newMember(t, newBoard(t).ID)
+			_, err := store.DeleteMember(ctx, member.BoardID, member.UserID, actorID)
+			return err
+		},
+		"DeleteMembers": func(t *testing.T) error {
+			member := newMember(t, newBoard(t).ID)
+			_, err := store.DeleteMembers(ctx, member.BoardID, []string{member.UserID}, actorID)
+			return err
+		},
+		"CreateBoardCustomRole": func(t *testing.T) error {
+			newRole(t, newBoard(t).ID)
+			return nil
+		},
+		"UpdateBoardCustomRole": func(t *testing.T) error {
+			role := newRole(t, newBoard(t).ID)
+			role.Name = "Renamed role"
+			return store.UpdateBoardCustomRole(ctx, role, actorID)
+		},
+		"DeleteBoardCustomRole": func(t *testing.T) error {
+			return store.DeleteBoardCustomRole(ctx, newRole(t, newBoard(t).ID).ID, actorID)
+		},
+		"UpsertSharing": func(t *testing.T) error {
+			return store.UpsertSharing(ctx, model.Sharing{ID: newBoard(t).ID, Enabled: true, Token: "token", ModifiedBy: actorID})
+		},
+		"RotateSharingToken": func(t *testing.T) error {
+			board := newBoard(t)
+			require.NoError(t, store.UpsertSharing(ctx, model.Sharing{ID: board.ID, Enabled: true, Token: "token", ModifiedBy: testUserID}))
+			_, err := store.RotateSharingToken(ctx, board.ID, actorID)
+			return err
+		},
+		"CreateShareToken": func(t *testing.T) error {
+			return store.CreateShareToken(ctx, &model.ShareToken{BoardID: newBoard(t).ID, Scope: model.ShareTokenScopeBoard, CreatedBy: actorID})
+		},
+		"DeleteShareToken": func(t *testing.T) error {
+			shareToken := &model.ShareToken{BoardID: newBoard(t).ID, Scope: model.ShareTokenScopeBoard, CreatedBy: testUserID}
+			require.NoError(t, store.CreateShareToken(ctx, shareToken))
+			return store.DeleteShareToken(ctx, shareToken.BoardID, shareToken.ID, actorID)
+		},
+		"CreateWebhook": func(t *testing.T) error {
+			newWebhook(t, newBoard(t).ID)
+			return nil
+		},
+		"UpdateWebhook": func(t *testing.T) error {
+			webhook := newWebhook(t, newBoard(t).ID)
+			webhook.URL = "https://example.com/other-hook"
+			return store.UpdateWebhook(ctx, webhook, actorID)
+		},
+		"DeleteWebhook": func(t *testing.T) error {
+			return store.DeleteWebhook(ctx, newWebhook(t, newBoard(t).ID).ID, actorID)
+		},
+	}
+
+	for name, action := range auditedMethods {
+		mutate, ok := mutations[name]
+		require.Truef(t, ok, "%s has no audit test", name)
+
+		t.Run(name, func(t *testing.T) {
+			opts := model.QueryAuditOptions{ActorID: actorID, Action: action}
+			before := auditResourceIDs(t, store, "", opts)
+			require.NoError(t, mutate(t))
+			require.Greater(t, len(auditResourceIDs(t, store, "", opts)), len(before), "%s should record a %s audit", name, action)
+		})
+	}
+}
+
+func auditSummaries(t *testing.T, store store.Store, boardID string) []string {
+	records, err := store.GetAuditRecords(context.Background(), boardID, model.QueryAuditOptions{})
+	require.NoError(t, err)
+
+	summaries := make([]string, 0, len(records))
+	for _, record := range records {
+		summaries = append(summaries, record.Summary)
+	}
+	return summaries
+}
+
+func testGetAuditRecords(t *testing.T, store store.Store) {
+	boardID := "board-id"
+	for i, createAt := range []int64{100, 200, 300, 400} {
+		record := &model.AuditRecord{
+			BoardID:    boardID,
+			ActorID:    testUserID,
+			Action:     model.AuditActionPatchBlock,
+			ResourceID: []string{"block-1", "block-2", "block-3", "block-4"}[i],
+			CreateAt:   createAt,
+		}
+		require.NoError(t, store.InsertAuditRecord(context.Background(), record))
+		require.NotEmpty(t, record.ID)
+	}
+
+	resourceIDs := func(t *testing.T, opts model.QueryAuditOptions) []string {
+		return auditResourceIDs(t, store, boardID, opts)
+	}
+
+	t.Run("all records", func(t *testing.T) {
+		require.Equal(t, []string{"block-1", "block-2", "block-3", "block-4"}, resourceIDs(t, model.QueryAuditOptions{}))
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		require.Equal(t, []string{"block-2", "block-3"}, resourceIDs(t, model.QueryAuditOptions{Since: 200, Until: 400}))
+		require.Equal(t, []string{"block-3", "block-4"}, resourceIDs(t, model.QueryAuditOptions{Since: 300}))
+		require.Equal(t, []string{"block-1"}, resourceIDs(t, model.QueryAuditOptions{Until: 200}))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		require.Equal(t, []string{"block-1", "block-2"}, resourceIDs(t, model.QueryAuditOptions{PerPage: 2}))
+		require.Equal(t, []string{"block-3", "block-4"}, resourceIDs(t, model.QueryAuditOptions{Page: 1, PerPage: 2}))
+		require.Empty(t, resourceIDs(t, model.QueryAuditOptions{Page: 2, PerPage: 2}))
+	})
+
+	t.Run("other boards", func(t *testing.T) {
+		require.Empty(t, auditResourceIDs(t, store, "other-board-id", model.QueryAuditOptions{}))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		record := &model.AuditRecord{
+			BoardID:    "other-board-id",
+			ActorID:    "other-user-id",
+			Action:     model.AuditActionSaveMember,
+			ResourceID: testUserID,
+			Summary:    "roles: viewer",
+			CreateAt:   250,
+		}
+		require.NoError(t, store.InsertAuditRecord(context.Background(), record))
+
+		require.Equal(t, []string{"block-1", "block-2", "block-3", "block-4"}, resourceIDs(t, model.QueryAuditOptions{ActorID: testUserID}))
+		require.Empty(t, resourceIDs(t, model.QueryAuditOptions{ActorID: "other-user-id"}))
+		require.Empty(t, resourceIDs(t, model.QueryAuditOptions{Action: model.AuditActionSaveMember}))
+
+		records, err := store.GetAuditRecords(context.Background(), "", model.QueryAuditOptions{ActorID: "other-user-id"})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, "roles: viewer", records[0].Summary)
+	})
+
+	t.Run("all boards", func(t *testing.T) {
+		require.Equal(t,
+			[]string{"block-1", "block-2", testUserID, "block-3", "block-4"},
+			auditResourceIDs(t, store, "", model.QueryAuditOptions{}),
+		)
+		require.Equal(t,
+			[]string{"block-2", testUserID},
+			auditResourceIDs(t, store, "", model.QueryAuditOptions{Since: 200, Until: 300}),
+		)
+	})
+}
+
+func auditResourceIDs(t *testing.T, store store.Store, boardID string, opts model.QueryAuditOptions) []string {
+	records, err := store.GetAuditRecords(context.Background(), boardID, opts)
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ResourceID)
+	}
+	return ids
+}
@@ -1,6 +1,10 @@
 package storetests
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -39,6 +43,11 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testPatchBlocks(t, store)
 	})
+	t.Run("PatchBlockExpectedUpdateAt", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testPatchBlockExpectedUpdateAt(t, store)
+	})
 	t.Run("DeleteBlock", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -49,11 +58,56 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testUndeleteBlock(t, store)
 	})
+	t.Run("RestoreBlock", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRestoreBlock(t, store)
+	})
+	t.Run("GetDeletedBlocksForBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDeletedBlocksForBoard(t, store)
+	})
+	t.Run("PurgeDeletedBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testPurgeDeletedBlocks(t, store)
+	})
+	t.Run("GetBlockHistoryEntry", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlockHistoryEntry(t, store)
+	})
+	t.Run("MoveBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveBlocks(t, store)
+	})
+	t.Run("BoardBlockLimit", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testBoardBlockLimit(t, store)
+	})
+	t.Run("CopyBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCopyBlocks(t, store)
+	})
 	t.Run("GetSubTree2", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
 		testGetSubTree2(t, store)
 	})
+	t.Run("GetSubTree", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetSubTree(t, store)
+	})
+	t.Run("GetSubTree2WithDeletedBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetSubTree2WithDeletedBlocks(t, store)
+	})
 	t.Run("GetBlocks", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -64,6 +118,21 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetBlock(t, store)
 	})
+	t.Run("GetBlocksForBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlocksForBoards(t, store)
+	})
+	t.Run("GetBlocksForBoardPaginated", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlocksForBoardPaginated(t, store)
+	})
+	t.Run("GetBlocksMap", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlocksMap(t, store)
+	})
 	t.Run("DuplicateBlock", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -74,13 +143,69 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetBlockMetadata(t, store)
 	})
+	t.Run("ArchiveCard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testArchiveCard(t, store)
+	})
+	t.Run("CountCardsByPropertyGrouped", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCountCardsByPropertyGrouped(t, store)
+	})
+	t.Run("GetCardsMissingProperty", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardsMissingProperty(t, store)
+	})
+	t.Run("SearchBlocksForBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSearchBlocksForBoard(t, store)
+	})
+	t.Run("SearchBlocksForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSearchBlocksForUser(t, store)
+	})
+	t.Run("GetRecentComments", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetRecentComments(t, store)
+	})
+	t.Run("GetBlockCountsForTeams", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlockCountsForTeams(t, store)
+	})
+	t.Run("GetBoardStats", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardStats(t, store)
+	})
+	t.Run("GetBlocksForBoardStream", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlocksForBoardStream(t, store)
+	})
+	t.Run("GetLastModifiedBlockForBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetLastModifiedBlockForBoard(t, store)
+	})
+	t.Run("NormalizeContentOrder", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testNormalizeContentOrder(t, store)
+	})
 }
 
 func testInsertBlock(t *testing.T, store store.Store) {
 	userID := testUserID
 	boardID := testBoardID
+	InsertBoardsIfMissing(t, store, userID, boardID, "board-id-1", "id-test")
 
-	blocks, errBlocks := store.GetBlocksForBoard(boardID)
+	blocks, _, errBlocks := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, errBlocks)
 	initialCount := len(blocks)
 
@@ -91,10 +216,10 @@ func testInsertBlock(t *testing.T, store store.Store) {
 			ModifiedBy: userID,
 		}
 
-		err := store.InsertBlock(block, "user-id-1")
+		err := store.InsertBlock(context.Background(), block, "user-id-1")
 		require.NoError(t, err)
 
-		blocks, err := store.GetBlocksForBoard(boardID)
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 		require.NoError(t, err)
 		require.Len(t, blocks, initialCount+1)
 	})
@@ -106,10 +231,10 @@ func testInsertBlock(t *testing.T, store store.Store) {
 			ModifiedBy: userID,
 		}
 
-		err := store.InsertBlock(block, "user-id-1")
+		err := store.InsertBlock(context.Background(), block, "user-id-1")
 		require.Error(t, err)
 
-		blocks, err := store.GetBlocksForBoard(boardID)
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 		require.NoError(t, err)
 		require.Len(t, blocks, initialCount+1)
 	})
@@ -122,10 +247,10 @@ func testInsertBlock(t *testing.T, store store.Store) {
 			Fields:     map[string]interface{}{"no-serialiable-value": t.Run},
 		}
 
-		err := store.InsertBlock(block, "user-id-1")
+		err := store.InsertBlock(context.Background(), block, "user-id-1")
 		require.Error(t, err)
 
-		blocks, err := store.GetBlocksForBoard(boardID)
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 		require.NoError(t, err)
 		require.Len(t, blocks, initialCount+1)
 	})
@@ -135,7 +260,7 @@ func testInsertBlock(t *testing.T, store store.Store) {
 			BoardID: testBoardID,
 		}
 
-		err := store.InsertBlock(block, "user-id-2")
+		err := store.InsertBlock(context.Background(), block, "user-id-2")
 		require.NoError(t, err)
 		require.Equal(t, "user-id-2", block.CreatedBy)
 	})
@@ -148,7 +273,7 @@ func testInsertBlock(t *testing.T, store store.Store) {
 		}
 
 		// inserting
-		err := store.InsertBlock(block, "user-id-2")
+		err := store.InsertBlock(context.Background(), block, "user-id-2")
 		require.NoError(t, err)
 
 		// created by populated from user id for new blocks
@@ -165,7 +290,7 @@ func testInsertBlock(t *testing.T, store store.Store) {
 			CreatedBy: "user-id-3",
 			Title:     "New Title",
 		}
-		err = store.InsertBlock(newBlock, "user-id-4")
+		err = store.InsertBlock(context.Background(), newBlock, "user-id-4")
 		require.NoError(t, err)
 		// created by is not altered for existing blocks
 		require.Equal(t, "user-id-3", newBlock.CreatedBy)
@@ -190,11 +315,11 @@ func testInsertBlock(t *testing.T, store store.Store) {
 		}
 
 		// inserting
-		err := store.InsertBlock(block, "user-id-1")
+		err := store.InsertBlock(context.Background(), block, "user-id-1")
 		require.NoError(t, err)
 		expectedTime := time.Now()
 
-		retrievedBlock, err := store.GetBlock("id-10")
+		retrievedBlock, err := store.GetBlock(context.Background(), "id-10")
 		assert.NoError(t, err)
 		assert.NotNil(t, retrievedBlock)
 		assert.Equal(t, "board-id-1", retrievedBlock.BoardID)
@@ -203,12 +328,56 @@ func testInsertBlock(t *testing.T, store store.Store) {
 		assert.WithinDurationf(t, expectedTime, utils.GetTimeForMillis(retrievedBlock.CreateAt), 1*time.Second, "create time should be current time")
 		assert.WithinDurationf(t, expectedTime, utils.GetTimeForMillis(retrievedBlock.UpdateAt), 1*time.Second, "update time should be current time")
 	})
+
+	t.Run("nonexistent board", func(t *testing.T) {
+		block := &model.Block{ID: "id-missing-board", BoardID: "nonexistent-board", ParentID: "nonexistent-board"}
+
+		err := store.InsertBlock(context.Background(), block, userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Contains(t, err.Error(), "board ID=nonexistent-board")
+
+		err = store.InsertBlocks(context.Background(), []*model.Block{block}, userID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("nonexistent parent", func(t *testing.T) {
+		block := &model.Block{ID: "id-missing-parent", BoardID: boardID, ParentID: "nonexistent-block"}
+
+		err := store.InsertBlock(context.Background(), block, userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Contains(t, err.Error(), "parent block ID=nonexistent-block")
+
+		_, err = store.GetBlock(context.Background(), block.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("parent on another board", func(t *testing.T) {
+		block := &model.Block{ID: "id-other-board-parent", BoardID: boardID, ParentID: "id-10"}
+
+		err := store.InsertBlock(context.Background(), block, userID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("parent in the same batch", func(t *testing.T) {
+		blocks := []*model.Block{
+			{ID: "batch-child", BoardID: boardID, ParentID: "batch-parent"},
+			{ID: "batch-parent", BoardID: boardID, ParentID: boardID},
+			{ID: "batch-no-parent", BoardID: boardID},
+		}
+
+		err := store.InsertBlocks(context.Background(), blocks, userID)
+		require.NoError(t, err)
+
+		child, err := store.GetBlock(context.Background(), "batch-child")
+		require.NoError(t, err)
+		require.Equal(t, "batch-parent", child.ParentID)
+	})
 }
 
 func testInsertBlocks(t *testing.T, store store.Store) {
 	userID := testUserID
 
-	blocks, errBlocks := store.GetBlocksForBoard("id-test")
+	blocks, _, errBlocks := store.GetBlocksForBoard(context.Background(), "id-test", model.QueryBlocksOptions{})
 	require.NoError(t, errBlocks)
 	initialCount := len(blocks)
 
@@ -228,10 +397,10 @@ func testInsertBlocks(t *testing.T, store store.Store) {
 		newBlocks := []*model.Block{validBlock, invalidBlock}
 
 		time.Sleep(1 * time.Millisecond)
-		err := store.InsertBlocks(newBlocks, "user-id-1")
+		err := store.InsertBlocks(context.Background(), newBlocks, "user-id-1")
 		require.Error(t, err)
 
-		blocks, err := store.GetBlocksForBoard("id-test")
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), "id-test", model.QueryBlocksOptions{})
 		require.NoError(t, err)
 		// no blocks should have been inserted
 		require.Len(t, blocks, initialCount)
@@ -241,6 +410,7 @@ func testInsertBlocks(t *testing.T, store store.Store) {
 func testPatchBlock(t *testing.T, store store.Store) {
 	userID := testUserID
 	boardID := "board-id-1"
+	InsertBoardsIfMissing(t, store, userID, boardID)
 
 	block := &model.Block{
 		ID:         "id-test",
@@ -250,20 +420,21 @@ func testPatchBlock(t *testing.T, store store.Store) {
 		Fields:     map[string]interface{}{"test": "test value", "test2": "test value 2"},
 	}
 
-	err := store.InsertBlock(block, "user-id-1")
+	err := store.InsertBlock(context.Background(), block, "user-id-1")
 	require.NoError(t, err)
 
-	blocks, errBlocks := store.GetBlocksForBoard(boardID)
+	blocks, _, errBlocks := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, errBlocks)
 	initialCount := len(blocks)
 
 	t.Run("not existing block id", func(t *testing.T) {
-		err := store.PatchBlock("invalid-block-id", &model.BlockPatch{}, "user-id-1")
+		count, err := store.PatchBlock(context.Background(), "invalid-block-id", &model.BlockPatch{}, "user-id-1")
+		require.Zero(t, count)
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 		require.True(t, model.IsErrNotFound(err))
 
-		blocks, err := store.GetBlocksForBoard(boardID)
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 		require.NoError(t, err)
 		require.Len(t, blocks, initialCount)
 	})
@@ -273,10 +444,10 @@ func testPatchBlock(t *testing.T, store store.Store) {
 			UpdatedFields: map[string]interface{}{"no-serialiable-value": t.Run},
 		}
 
-		err := store.PatchBlock("id-test", blockPatch, "user-id-1")
+		_, err := store.PatchBlock(context.Background(), "id-test", blockPatch, "user-id-1")
 		require.Error(t, err)
 
-		blocks, err := store.GetBlocksForBoard(boardID)
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 		require.NoError(t, err)
 		require.Len(t, blocks, initialCount)
 	})
@@ -291,10 +462,11 @@ func testPatchBlock(t *testing.T, store store.Store) {
 		time.Sleep(1 * time.Millisecond)
 
 		// inserting
-		err := store.PatchBlock("id-test", &blockPatch, "user-id-2")
+		count, err := store.PatchBlock(context.Background(), "id-test", &blockPatch, "user-id-2")
 		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
 
-		retrievedBlock, err := store.GetBlock("id-test")
+		retrievedBlock, err := store.GetBlock(context.Background(), "id-test")
 		require.NoError(t, err)
 
 		// created by populated from user id for new blocks
@@ -311,10 +483,10 @@ func testPatchBlock(t *testing.T, store store.Store) {
 		time.Sleep(1 * time.Millisecond)
 
 		// inserting
-		err := store.PatchBlock("id-test", blockPatch, "user-id-2")
+		_, err := store.PatchBlock(context.Background(), "id-test", blockPatch, "user-id-2")
 		require.NoError(t, err)
 
-		retrievedBlock, err := store.GetBlock("id-test")
+		retrievedBlock, err := store.GetBlock(context.Background(), "id-test")
 		require.NoError(t, err)
 
 		// created by populated from user id for new blocks
@@ -333,10 +505,10 @@ func testPatchBlock(t *testing.T, store store.Store) {
 		time.Sleep(1 * time.Millisecond)
 
 		// inserting
-		err := store.PatchBlock("id-test", blockPatch, "user-id-2")
+		_, err := store.PatchBlock(context.Background(), "id-test", blockPatch, "user-id-2")
 		require.NoError(t, err)
 
-		retrievedBlock, err := store.GetBlock("id-test")
+		retrievedBlock, err := store.GetBlock(context.Background(), "id-test")
 		require.NoError(t, err)
 
 		// created by populated from user id for new blocks
@@ -345,9 +517,160 @@ func testPatchBlock(t *testing.T, store store.Store) {
 		require.Equal(t, "test value 2", retrievedBlock.Fields["test2"])
 		require.Equal(t, nil, retrievedBlock.Fields["test3"])
 	})
+
+	t.Run("update block properties", func(t *testing.T) {
+		blockPatch := &model.BlockPatch{
+			UpdatedProperties: map[string]interface{}{"prop1": "value 1", "prop2": "value 2"},
+		}
+
+		// Wait for not colliding the ID+insert_at key
+		time.Sleep(1 * time.Millisecond)
+
+		_, err := store.PatchBlock(context.Background(), "id-test", blockPatch, "user-id-2")
+		require.NoError(t, err)
+
+		blockPatch = &model.BlockPatch{
+			UpdatedProperties: map[string]interface{}{"prop2": "new value 2", "prop3": []interface{}{"value 3"}},
+		}
+
+		time.Sleep(1 * time.Millisecond)
+
+		_, err = store.PatchBlock(context.Background(), "id-test", blockPatch, "user-id-1")
+		require.NoError(t, err)
+
+		retrievedBlock, err := store.GetBlock(context.Background(), "id-test")
+		require.NoError(t, err)
+
+		require.Equal(t, "user-id-1", retrievedBlock.ModifiedBy)
+		require.Equal(t, "test value 2", retrievedBlock.Fields["test2"])
+		require.Equal(t, map[string]interface{}{
+			"prop1": "value 1",
+			"prop2": "new value 2",
+			"prop3": []interface{}{"value 3"},
+		}, retrievedBlock.Fields["properties"])
+	})
+
+	t.Run("remove block properties", func(t *testing.T) {
+		blockPatch := &model.BlockPatch{
+			DeletedProperties: []string{"prop1", "prop100"},
+		}
+
+		// Wait for not colliding the ID+insert_at key
+		time.Sleep(1 * time.Millisecond)
+
+		_, err := store.PatchBlock(context.Background(), "id-test", blockPatch, "user-id-2")
+		require.NoError(t, err)
+
+		retrievedBlock, err := store.GetBlock(context.Background(), "id-test")
+		require.NoError(t, err)
+
+		require.Equal(t, map[string]interface{}{
+			"prop2": "new value 2",
+			"prop3": []interface{}{"value 3"},
+		}, retrievedBlock.Fields["properties"])
+
+		history, err := store.GetBlockHistory(context.Background(), "id-test", model.QueryBlockHistoryOptions{Descending: true, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, retrievedBlock.Fields, history[0].Fields)
+	})
+}
+
+func testPatchBlockExpectedUpdateAt(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "block-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "title 1"},
+		{ID: "block-2", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "title 2"},
+	}, userID)
+
+	getBlock := func(t *testing.T, blockID string) *model.Block {
+		block, err := store.GetBlock(context.Background(), blockID)
+		require.NoError(t, err)
+		return block
+	}
+
+	t.Run("should apply a patch expecting the current version", func(t *testing.T) {
+		block := getBlock(t, "block-1")
+		time.Sleep(1 * time.Millisecond)
+
+		title := "new title"
+		count, err := store.PatchBlock(context.Background(), block.ID, &model.BlockPatch{Title: &title, ExpectedUpdateAt: block.UpdateAt}, userID)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
+
+		patched := getBlock(t, block.ID)
+		require.Equal(t, title, patched.Title)
+		require.Greater(t, patched.UpdateAt, block.UpdateAt)
+	})
+
+	t.Run("should return a conflict if the block was updated since", func(t *testing.T) {
+		block := getBlock(t, "block-1")
+		time.Sleep(1 * time.Millisecond)
+
+		title := "newer title"
+		_, err := store.PatchBlock(context.Background(), block.ID, &model.BlockPatch{Title: &title}, userID)
+		require.NoError(t, err)
+
+		staleTitle := "stale title"
+		count, err := store.PatchBlock(context.Background(), block.ID, &model.BlockPatch{Title: &staleTitle, ExpectedUpdateAt: block.UpdateAt}, userID)
+		var conflictErr *model.ErrConflict
+		require.ErrorAs(t, err, &conflictErr)
+		require.True(t, model.IsErrConflict(err))
+		require.Zero(t, count)
+		require.Equal(t, title, getBlock(t, block.ID).Title)
+	})
+
+	t.Run("should return a conflict from a batch with a stale block", func(t *testing.T) {
+		block1 := getBlock(t, "block-1")
+		block2 := getBlock(t, "block-2")
+		time.Sleep(1 * time.Millisecond)
+
+		title := "batch title"
+		batch := &model.BlockPatchBatch{
+			BlockIDs: []string{block1.ID, block2.ID},
+			BlockPatches: []model.BlockPatch{
+				{Title: &title, ExpectedUpdateAt: block1.UpdateAt},
+				{Title: &title, ExpectedUpdateAt: block2.UpdateAt - 1},
+			},
+		}
+		err := store.PatchBlocks(context.Background(), batch, userID)
+		require.True(t, model.IsErrConflict(err))
+		require.NotEqual(t, title, getBlock(t, block2.ID).Title)
+	})
+
+	t.Run("only one of concurrent patches expecting the same version is applied", func(t *testing.T) {
+		block := getBlock(t, "block-2")
+		time.Sleep(1 * time.Millisecond)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 10)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				title := fmt.Sprintf("title %d", i)
+				_, errs[i] = store.PatchBlock(context.Background(), block.ID, &model.BlockPatch{Title: &title, ExpectedUpdateAt: block.UpdateAt}, userID)
+			}(i)
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			require.True(t, model.IsErrConflict(err), "unexpected error: %v", err)
+		}
+		require.Equal(t, 1, succeeded)
+	})
 }
 
 func testPatchBlocks(t *testing.T, store store.Store) {
+	InsertBoardsIfMissing(t, store, "user-id-1", "id-test", "id-test2")
+
 	block := &model.Block{
 		ID:      "id-test",
 		BoardID: "id-test",
@@ -361,7 +684,7 @@ func testPatchBlocks(t *testing.T, store store.Store) {
 	}
 
 	insertBlocks := []*model.Block{block, block2}
-	err := store.InsertBlocks(insertBlocks, "user-id-1")
+	err := store.InsertBlocks(context.Background(), insertBlocks, "user-id-1")
 	require.NoError(t, err)
 
 	t.Run("successful updated existing blocks", func(t *testing.T) {
@@ -378,14 +701,14 @@ func testPatchBlocks(t *testing.T, store store.Store) {
 		blockPatches := []model.BlockPatch{blockPatch, blockPatch2}
 
 		time.Sleep(1 * time.Millisecond)
-		err := store.PatchBlocks(&model.BlockPatchBatch{BlockIDs: blockIds, BlockPatches: blockPatches}, "user-id-1")
+		err := store.PatchBlocks(context.Background(), &model.BlockPatchBatch{BlockIDs: blockIds, BlockPatches: blockPatches}, "user-id-1")
 		require.NoError(t, err)
 
-		retrievedBlock, err := store.GetBlock("id-test")
+		retrievedBlock, err := store.GetBlock(context.Background(), "id-test")
 		require.NoError(t, err)
 		require.Equal(t, title, retrievedBlock.Title)
 
-		retrievedBlock2, err := store.GetBlock("id-test2")
+		retrievedBlock2, err := store.GetBlock(context.Background(), "id-test2")
 		require.NoError(t, err)
 		require.Equal(t, title, retrievedBlock2.Title)
 	})
@@ -408,11 +731,11 @@ func testPatchBlocks(t *testing.T, store store.Store) {
 		blockPatches := []model.BlockPatch{blockPatch, blockPatch2}
 
 		time.Sleep(1 * time.Millisecond)
-		err := store.PatchBlocks(&model.BlockPatchBatch{BlockIDs: blockIds, BlockPatches: blockPatches}, "user-id-1")
+		err := store.PatchBlocks(context.Background(), &model.BlockPatchBatch{BlockIDs: blockIds, BlockPatches: blockPatches}, "user-id-1")
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 
-		retrievedBlock, err := store.GetBlock("id-test")
+		retrievedBlock, err := store.GetBlock(context.Background(), "id-test")
 		require.NoError(t, err)
 		require.NotEqual(t, title, retrievedBlock.Title)
 	})
@@ -460,7 +783,7 @@ var (
 
 func testGetSubTree2(t *testing.T, store store.Store) {
 	boardID := testBoardID
-	blocks, err := store.GetBlocksForBoard(boardID)
+	blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, err)
 	initialCount := len(blocks)
 
@@ -468,12 +791,12 @@ func testGetSubTree2(t *testing.T, store store.Store) {
 	time.Sleep(1 * time.Millisecond)
 	defer DeleteBlocks(t, store, subtreeSampleBlocks, "test")
 
-	blocks, err = store.GetBlocksForBoard(boardID)
+	blocks, _, err = store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, err)
 	require.Len(t, blocks, initialCount+6)
 
 	t.Run("from root id", func(t *testing.T) {
-		blocks, err = store.GetSubTree2(boardID, "parent", model.QuerySubtreeOptions{})
+		blocks, err = store.GetSubTree2(context.Background(), boardID, "parent", model.QuerySubtreeOptions{})
 		require.NoError(t, err)
 		require.Len(t, blocks, 3)
 		require.True(t, ContainsBlockWithID(blocks, "parent"))
@@ -482,7 +805,7 @@ func testGetSubTree2(t *testing.T, store store.Store) {
 	})
 
 	t.Run("from child id", func(t *testing.T) {
-		blocks, err = store.GetSubTree2(boardID, "child1", model.QuerySubtreeOptions{})
+		blocks, err = store.GetSubTree2(context.Background(), boardID, "child1", model.QuerySubtreeOptions{})
 		require.NoError(t, err)
 		require.Len(t, blocks, 2)
 		require.True(t, ContainsBlockWithID(blocks, "child1"))
@@ -490,17 +813,126 @@ func testGetSubTree2(t *testing.T, store store.Store) {
 	})
 
 	t.Run("from not existing id", func(t *testing.T) {
-		blocks, err = store.GetSubTree2(boardID, "not-exists", model.QuerySubtreeOptions{})
+		blocks, err = store.GetSubTree2(context.Background(), boardID, "not-exists", model.QuerySubtreeOptions{})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+}
+
+func testGetSubTree(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	InsertBlocks(t, store, subtreeSampleBlocks, "user-id-1")
+
+	blockIDs := func(blocks []*model.Block) []string {
+		ids := make([]string, 0, len(blocks))
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	t.Run("unlimited depth", func(t *testing.T) {
+		blocks, err := store.GetSubTree(context.Background(), boardID, "parent", model.QuerySubtreeOptions{})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"parent", "child1", "child2", "grandchild1", "grandchild2", "greatgrandchild1"}, blockIDs(blocks))
+	})
+
+	t.Run("limited depth", func(t *testing.T) {
+		blocks, err := store.GetSubTree(context.Background(), boardID, "parent", model.QuerySubtreeOptions{MaxDepth: 3})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"parent", "child1", "child2", "grandchild1", "grandchild2"}, blockIDs(blocks))
+
+		blocks, err = store.GetSubTree(context.Background(), boardID, "parent", model.QuerySubtreeOptions{MaxDepth: 1})
+		require.NoError(t, err)
+		require.Equal(t, []string{"parent"}, blockIDs(blocks))
+	})
+
+	t.Run("children of the board", func(t *testing.T) {
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "top-level", BoardID: boardID, ParentID: boardID},
+		}, testUserID)
+
+		blocks, err := store.GetSubTree(context.Background(), boardID, boardID, model.QuerySubtreeOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"top-level"}, blockIDs(blocks))
+	})
+
+	t.Run("parent cycles", func(t *testing.T) {
+		// the cycles reference each other, so they need to be inserted
+		// in a single batch
+		err := store.InsertBlocks(context.Background(), []*model.Block{
+			{ID: "cycle-a", BoardID: boardID, ParentID: "cycle-c"},
+			{ID: "cycle-b", BoardID: boardID, ParentID: "cycle-a"},
+			{ID: "cycle-c", BoardID: boardID, ParentID: "cycle-b"},
+			{ID: "cycle-child", BoardID: boardID, ParentID: "cycle-b"},
+			{ID: "self-parent", BoardID: boardID, ParentID: "self-parent"},
+		}, testUserID)
+		require.NoError(t, err)
+
+		blocks, err := store.GetSubTree(context.Background(), boardID, "cycle-a", model.QuerySubtreeOptions{})
+		require.NoError(t, err)
+		require.Len(t, blocks, 4)
+		require.ElementsMatch(t, []string{"cycle-a", "cycle-b", "cycle-c", "cycle-child"}, blockIDs(blocks))
+
+		blocks, err = store.GetSubTree(context.Background(), boardID, "self-parent", model.QuerySubtreeOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"self-parent"}, blockIDs(blocks))
+	})
+}
+
+func testGetSubTree2WithDeletedBlocks(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	now := utils.GetMillis()
+
+	blocks := []*model.Block{
+		{ID: "parent", BoardID: boardID, Type: model.TypeCard},
+		{ID: "child1", BoardID: boardID, ParentID: "parent", Type: model.TypeText},
+		{ID: "child2", BoardID: boardID, ParentID: "parent", Type: model.TypeText, DeleteAt: now},
+		{ID: "grandchild1", BoardID: boardID, ParentID: "child2", Type: model.TypeText},
+		{ID: "deleted_parent", BoardID: boardID, Type: model.TypeCard, DeleteAt: now},
+		{ID: "child3", BoardID: boardID, ParentID: "deleted_parent", Type: model.TypeText},
+	}
+	InsertBlocks(t, store, blocks, testUserID)
+
+	t.Run("deleted children are excluded", func(t *testing.T) {
+		blocks, err := store.GetSubTree2(context.Background(), boardID, "parent", model.QuerySubtreeOptions{})
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		require.True(t, ContainsBlockWithID(blocks, "parent"))
+		require.True(t, ContainsBlockWithID(blocks, "child1"))
+	})
+
+	t.Run("children of a deleted block are excluded", func(t *testing.T) {
+		blocks, err := store.GetSubTree2(context.Background(), boardID, "child2", model.QuerySubtreeOptions{})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+
+		blocks, err = store.GetSubTree2(context.Background(), boardID, "deleted_parent", model.QuerySubtreeOptions{})
 		require.NoError(t, err)
 		require.Empty(t, blocks)
 	})
+
+	t.Run("include deleted blocks", func(t *testing.T) {
+		opts := model.QuerySubtreeOptions{IncludeDeleted: true}
+
+		blocks, err := store.GetSubTree2(context.Background(), boardID, "parent", opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+		require.True(t, ContainsBlockWithID(blocks, "child2"))
+
+		blocks, err = store.GetSubTree2(context.Background(), boardID, "deleted_parent", opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		require.True(t, ContainsBlockWithID(blocks, "deleted_parent"))
+		require.True(t, ContainsBlockWithID(blocks, "child3"))
+	})
 }
 
 func testDeleteBlock(t *testing.T, store store.Store) {
 	userID := testUserID
 	boardID := testBoardID
 
-	blocks, err := store.GetBlocksForBoard(boardID)
+	blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, err)
 	initialCount := len(blocks)
 
@@ -524,33 +956,36 @@ func testDeleteBlock(t *testing.T, store store.Store) {
 	InsertBlocks(t, store, blocksToInsert, "user-id-1")
 	defer DeleteBlocks(t, store, blocksToInsert, "test")
 
-	blocks, err = store.GetBlocksForBoard(boardID)
+	blocks, _, err = store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, err)
 	require.Len(t, blocks, initialCount+3)
 
 	t.Run("existing id", func(t *testing.T) {
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err := store.DeleteBlock("block1", userID)
+		count, err := store.DeleteBlock(context.Background(), "block1", userID)
 		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
 	})
 
 	t.Run("existing id multiple times", func(t *testing.T) {
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err := store.DeleteBlock("block1", userID)
+		_, err := store.DeleteBlock(context.Background(), "block1", userID)
 		require.NoError(t, err)
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err = store.DeleteBlock("block1", userID)
+		count, err := store.DeleteBlock(context.Background(), "block1", userID)
 		require.NoError(t, err)
+		require.Zero(t, count)
 	})
 
 	t.Run("from not existing id", func(t *testing.T) {
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err := store.DeleteBlock("not-exists", userID)
+		count, err := store.DeleteBlock(context.Background(), "not-exists", userID)
 		require.NoError(t, err)
+		require.Zero(t, count)
 	})
 }
 
@@ -558,7 +993,7 @@ func testUndeleteBlock(t *testing.T, store store.Store) {
 	boardID := testBoardID
 	userID := testUserID
 
-	blocks, err := store.GetBlocksForBoard(boardID)
+	blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, err)
 	initialCount := len(blocks)
 
@@ -582,26 +1017,26 @@ func testUndeleteBlock(t *testing.T, store store.Store) {
 	InsertBlocks(t, store, blocksToInsert, "user-id-1")
 	defer DeleteBlocks(t, store, blocksToInsert, "test")
 
-	blocks, err = store.GetBlocksForBoard(boardID)
+	blocks, _, err = store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, err)
 	require.Len(t, blocks, initialCount+3)
 
 	t.Run("existing id", func(t *testing.T) {
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err := store.DeleteBlock("block1", userID)
+		_, err := store.DeleteBlock(context.Background(), "block1", userID)
 		require.NoError(t, err)
 
-		block, err := store.GetBlock("block1")
+		block, err := store.GetBlock(context.Background(), "block1")
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 		require.Nil(t, block)
 
 		time.Sleep(1 * time.Millisecond)
-		err = store.UndeleteBlock("block1", userID)
+		err = store.UndeleteBlock(context.Background(), "block1", userID)
 		require.NoError(t, err)
 
-		block, err = store.GetBlock("block1")
+		block, err = store.GetBlock(context.Background(), "block1")
 		require.NoError(t, err)
 		require.NotNil(t, block)
 	})
@@ -609,29 +1044,29 @@ func testUndeleteBlock(t *testing.T, store store.Store) {
 	t.Run("existing id multiple times", func(t *testing.T) {
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err := store.DeleteBlock("block1", userID)
+		_, err := store.DeleteBlock(context.Background(), "block1", userID)
 		require.NoError(t, err)
 
-		block, err := store.GetBlock("block1")
+		block, err := store.GetBlock(context.Background(), "block1")
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 		require.Nil(t, block)
 
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err = store.UndeleteBlock("block1", userID)
+		err = store.UndeleteBlock(context.Background(), "block1", userID)
 		require.NoError(t, err)
 
-		block, err = store.GetBlock("block1")
+		block, err = store.GetBlock(context.Background(), "block1")
 		require.NoError(t, err)
 		require.NotNil(t, block)
 
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err = store.UndeleteBlock("block1", userID)
+		err = store.UndeleteBlock(context.Background(), "block1", userID)
 		require.NoError(t, err)
 
-		block, err = store.GetBlock("block1")
+		block, err = store.GetBlock(context.Background(), "block1")
 		require.NoError(t, err)
 		require.NotNil(t, block)
 	})
@@ -639,239 +1074,477 @@ func testUndeleteBlock(t *testing.T, store store.Store) {
 	t.Run("from not existing id", func(t *testing.T) {
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err := store.UndeleteBlock("not-exists", userID)
+		err := store.UndeleteBlock(context.Background(), "not-exists", userID)
 		require.NoError(t, err)
 
-		block, err := store.GetBlock("not-exists")
+		block, err := store.GetBlock(context.Background(), "not-exists")
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 		require.Nil(t, block)
 	})
 }
 
-func testGetBlocks(t *testing.T, store store.Store) {
+func testRestoreBlock(t *testing.T, store store.Store) {
 	boardID := testBoardID
-	blocks, err := store.GetBlocksForBoard(boardID)
-	require.NoError(t, err)
+	userID := testUserID
 
-	blocksToInsert := []*model.Block{
-		{
-			ID:         "block1",
-			BoardID:    boardID,
-			ParentID:   "",
-			ModifiedBy: testUserID,
-			Type:       "test",
-		},
-		{
-			ID:         "block2",
-			BoardID:    boardID,
-			ParentID:   "block1",
-			ModifiedBy: testUserID,
-			Type:       "test",
-		},
-		{
-			ID:         "block3",
-			BoardID:    boardID,
-			ParentID:   "block1",
-			ModifiedBy: testUserID,
-			Type:       "test",
-		},
-		{
-			ID:         "block4",
-			BoardID:    boardID,
-			ParentID:   "block1",
-			ModifiedBy: testUserID,
-			Type:       "test2",
-		},
-		{
-			ID:         "block5",
-			BoardID:    boardID,
-			ParentID:   "block2",
-			ModifiedBy: testUserID,
-			Type:       "test",
-		},
-	}
-	InsertBlocks(t, store, blocksToInsert, "user-id-1")
-	defer DeleteBlocks(t, store, blocksToInsert, "test")
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "card-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "original"},
+	}, userID)
 
-	t.Run("not existing parent", func(t *testing.T) {
-		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksWithParentAndType(boardID, "not-exists", "test")
+	// Wait for not colliding the ID+insert_at key
+	time.Sleep(1 * time.Millisecond)
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "card-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "edited"},
+	}, userID)
+
+	t.Run("live block", func(t *testing.T) {
+		block, err := store.RestoreBlock(context.Background(), "card-1", "restorer")
 		require.NoError(t, err)
-		require.Empty(t, blocks)
+		require.Equal(t, "edited", block.Title)
+		require.Equal(t, userID, block.ModifiedBy)
 	})
 
-	t.Run("not existing type", func(t *testing.T) {
+	t.Run("deleted block", func(t *testing.T) {
 		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksWithParentAndType(boardID, "block1", "not-existing")
+		_, err := store.DeleteBlock(context.Background(), "card-1", userID)
 		require.NoError(t, err)
-		require.Empty(t, blocks)
-	})
 
-	t.Run("valid parent and type", func(t *testing.T) {
 		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksWithParentAndType(boardID, "block1", "test")
+		block, err := store.RestoreBlock(context.Background(), "card-1", "restorer")
 		require.NoError(t, err)
-		require.Len(t, blocks, 2)
-	})
+		require.Equal(t, "edited", block.Title)
+		require.Equal(t, "restorer", block.ModifiedBy)
+		require.Zero(t, block.DeleteAt)
 
-	t.Run("not existing parent", func(t *testing.T) {
-		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksWithParent(boardID, "not-exists")
+		live, err := store.GetBlock(context.Background(), "card-1")
 		require.NoError(t, err)
-		require.Empty(t, blocks)
-	})
+		require.Equal(t, "edited", live.Title)
+		require.Equal(t, "restorer", live.ModifiedBy)
 
-	t.Run("valid parent", func(t *testing.T) {
-		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksWithParent(boardID, "block1")
+		history, err := store.GetBlockHistory(context.Background(), "card-1", model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
 		require.NoError(t, err)
-		require.Len(t, blocks, 3)
+		require.Len(t, history, 1)
+		require.Equal(t, "restorer", history[0].ModifiedBy)
+		require.Zero(t, history[0].DeleteAt)
 	})
 
-	t.Run("not existing type", func(t *testing.T) {
+	t.Run("block of a deleted board", func(t *testing.T) {
+		board, err := store.InsertBoard(context.Background(), &model.Board{ID: "board-to-delete", TeamID: testTeamID, Type: model.BoardTypeOpen}, userID)
+		require.NoError(t, err)
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "card-2", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard, Title: "orphan"},
+		}, userID)
+
 		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksWithType(boardID, "not-exists")
+		require.NoError(t, store.DeleteBoardsAndBlocks(context.Background(), &model.DeleteBoardsAndBlocks{
+			Boards: []string{board.ID},
+			Blocks: []string{"card-2"},
+		}, userID))
+
+		time.Sleep(1 * time.Millisecond)
+		block, err := store.RestoreBlock(context.Background(), "card-2", "restorer")
 		require.NoError(t, err)
-		require.Empty(t, blocks)
+		require.Equal(t, "orphan", block.Title)
+		require.Equal(t, board.ID, block.BoardID)
+
+		live, err := store.GetBlock(context.Background(), "card-2")
+		require.NoError(t, err)
+		require.Equal(t, "orphan", live.Title)
+
+		_, err = store.GetBoard(context.Background(), board.ID)
+		require.True(t, model.IsErrNotFound(err), "restoring a block should not restore its board")
 	})
 
-	t.Run("valid type", func(t *testing.T) {
-		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksWithType(boardID, "test")
+	t.Run("no restorable history", func(t *testing.T) {
+		block, err := store.RestoreBlock(context.Background(), "not-exists", "restorer")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, block)
+	})
+}
+
+func testGetDeletedBlocksForBoard(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	userID := testUserID
+
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "card-live", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "live"},
+		{ID: "card-deleted", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "deleted"},
+		{ID: "card-restored", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "restored"},
+		{ID: "card-other-board", BoardID: "other-board", ParentID: "other-board", Type: model.TypeCard, Title: "elsewhere"},
+	}, userID)
+
+	// Wait for not colliding the ID+insert_at key
+	time.Sleep(1 * time.Millisecond)
+	for _, blockID := range []string{"card-deleted", "card-restored", "card-other-board"} {
+		_, err := store.DeleteBlock(context.Background(), blockID, userID)
 		require.NoError(t, err)
-		require.Len(t, blocks, 4)
+	}
+
+	time.Sleep(1 * time.Millisecond)
+	_, err := store.RestoreBlock(context.Background(), "card-restored", userID)
+	require.NoError(t, err)
+
+	t.Run("only the blocks still deleted", func(t *testing.T) {
+		blocks, err := store.GetDeletedBlocksForBoard(context.Background(), boardID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		require.Equal(t, "card-deleted", blocks[0].ID)
+		require.Equal(t, "deleted", blocks[0].Title)
+		require.NotZero(t, blocks[0].DeleteAt)
 	})
 
-	t.Run("not existing board", func(t *testing.T) {
-		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksForBoard("not-exists")
+	t.Run("board without deleted blocks", func(t *testing.T) {
+		blocks, err := store.GetDeletedBlocksForBoard(context.Background(), "empty-board")
 		require.NoError(t, err)
 		require.Empty(t, blocks)
 	})
+}
 
-	t.Run("all blocks of the a board", func(t *testing.T) {
-		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksForBoard(boardID)
+func testPurgeDeletedBlocks(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	userID := testUserID
+
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "card-live", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "live"},
+		{ID: "card-old", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "old"},
+	}, userID)
+
+	// Wait for not colliding the ID+insert_at key
+	time.Sleep(1 * time.Millisecond)
+	_, err := store.DeleteBlock(context.Background(), "card-old", userID)
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Millisecond)
+	cutoff := time.Now()
+	time.Sleep(1 * time.Millisecond)
+
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "card-recent", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "recent"},
+	}, userID)
+	time.Sleep(1 * time.Millisecond)
+	_, err = store.DeleteBlock(context.Background(), "card-recent", userID)
+	require.NoError(t, err)
+
+	purged, err := store.PurgeDeletedBlocks(context.Background(), cutoff)
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	history, err := store.GetBlockHistory(context.Background(), "card-old", model.QueryBlockHistoryOptions{})
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	_, err = store.RestoreBlock(context.Background(), "card-old", userID)
+	require.True(t, model.IsErrNotFound(err))
+
+	blocks, err := store.GetDeletedBlocksForBoard(context.Background(), boardID)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, "card-recent", blocks[0].ID)
+
+	history, err = store.GetBlockHistory(context.Background(), "card-live", model.QueryBlockHistoryOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, history)
+}
+
+func testGetBlockHistoryEntry(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	userID := testUserID
+
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "card-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "first"},
+	}, userID)
+	first, err := store.GetBlock(context.Background(), "card-1")
+	require.NoError(t, err)
+
+	// Wait for not colliding the ID+insert_at key
+	time.Sleep(1 * time.Millisecond)
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "card-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "second"},
+	}, userID)
+	second, err := store.GetBlock(context.Background(), "card-1")
+	require.NoError(t, err)
+	require.NotEqual(t, first.UpdateAt, second.UpdateAt)
+
+	t.Run("existing versions", func(t *testing.T) {
+		entry, err := store.GetBlockHistoryEntry(context.Background(), "card-1", first.UpdateAt)
 		require.NoError(t, err)
-		require.Len(t, blocks, 5)
-	})
+		require.Equal(t, "first", entry.Title)
 
-	t.Run("several blocks by ids", func(t *testing.T) {
-		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksByIDs([]string{"block2", "block4"})
+		entry, err = store.GetBlockHistoryEntry(context.Background(), "card-1", second.UpdateAt)
 		require.NoError(t, err)
-		require.Len(t, blocks, 2)
+		require.Equal(t, "second", entry.Title)
 	})
 
-	t.Run("blocks by ids where some are not found", func(t *testing.T) {
-		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksByIDs([]string{"block2", "blockNonexistent"})
-		var naf *model.ErrNotAllFound
-		require.ErrorAs(t, err, &naf)
+	t.Run("unknown version", func(t *testing.T) {
+		entry, err := store.GetBlockHistoryEntry(context.Background(), "card-1", 1)
 		require.True(t, model.IsErrNotFound(err))
-		require.Len(t, blocks, 1)
-	})
+		require.Nil(t, entry)
 
-	t.Run("blocks by ids where none are found", func(t *testing.T) {
-		time.Sleep(1 * time.Millisecond)
-		blocks, err = store.GetBlocksByIDs([]string{"blockNonexistent1", "blockNonexistent2"})
-		var naf *model.ErrNotAllFound
-		require.ErrorAs(t, err, &naf)
+		entry, err = store.GetBlockHistoryEntry(context.Background(), "not-exists", first.UpdateAt)
 		require.True(t, model.IsErrNotFound(err))
-		require.Empty(t, blocks)
+		require.Nil(t, entry)
 	})
 }
 
-func testGetBlock(t *testing.T, store store.Store) {
-	t.Run("get a block", func(t *testing.T) {
-		block := &model.Block{
-			ID:         "block-id-10",
-			BoardID:    "board-id-1",
-			ModifiedBy: "user-id-1",
+func testMoveBlocks(t *testing.T, store store.Store) {
+	userID := testUserID
+	sourceBoardID := "source-board"
+
+	for _, boardID := range []string{sourceBoardID, "target-board"} {
+		_, err := store.InsertBoard(context.Background(), &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}, userID)
+		require.NoError(t, err)
+	}
+
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "card-1", BoardID: sourceBoardID, ParentID: sourceBoardID, Type: model.TypeCard},
+		{ID: "text-1", BoardID: sourceBoardID, ParentID: "card-1", Type: model.TypeText},
+		{ID: "comment-1", BoardID: sourceBoardID, ParentID: "card-1", Type: model.TypeComment},
+		{ID: "card-2", BoardID: sourceBoardID, ParentID: sourceBoardID, Type: model.TypeCard},
+		{ID: "text-2", BoardID: sourceBoardID, ParentID: "card-2", Type: model.TypeText},
+	}, userID)
+
+	boardIDs := func(t *testing.T, ids ...string) map[string]string {
+		result := map[string]string{}
+		for _, id := range ids {
+			block, err := store.GetBlock(context.Background(), id)
+			require.NoError(t, err)
+			result[id] = block.BoardID
 		}
+		return result
+	}
+
+	t.Run("nonexistent target board", func(t *testing.T) {
+		err := store.MoveBlocks(context.Background(), []string{"card-1"}, "not-exists", userID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("nonexistent block", func(t *testing.T) {
+		err := store.MoveBlocks(context.Background(), []string{"card-1", "not-exists"}, "target-board", userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Equal(t, sourceBoardID, boardIDs(t, "card-1")["card-1"])
+	})
 
-		err := store.InsertBlock(block, "user-id-1")
+	t.Run("child without its parent", func(t *testing.T) {
+		err := store.MoveBlocks(context.Background(), []string{"text-2"}, "target-board", userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Equal(t, sourceBoardID, boardIDs(t, "text-2")["text-2"])
+	})
+
+	t.Run("card with its descendants", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		err := store.MoveBlocks(context.Background(), []string{"card-1"}, "target-board", "mover")
 		require.NoError(t, err)
 
-		fetchedBlock, err := store.GetBlock("block-id-10")
+		require.Equal(t, map[string]string{
+			"card-1":    "target-board",
+			"text-1":    "target-board",
+			"comment-1": "target-board",
+			"card-2":    sourceBoardID,
+			"text-2":    sourceBoardID,
+		}, boardIDs(t, "card-1", "text-1", "comment-1", "card-2", "text-2"))
+
+		card, err := store.GetBlock(context.Background(), "card-1")
 		require.NoError(t, err)
-		require.NotNil(t, fetchedBlock)
-		require.Equal(t, "block-id-10", fetchedBlock.ID)
-		require.Equal(t, "board-id-1", fetchedBlock.BoardID)
-		require.Equal(t, "user-id-1", fetchedBlock.CreatedBy)
-		require.Equal(t, "user-id-1", fetchedBlock.ModifiedBy)
-		assert.WithinDurationf(t, time.Now(), utils.GetTimeForMillis(fetchedBlock.CreateAt), 1*time.Second, "create time should be current time")
-		assert.WithinDurationf(t, time.Now(), utils.GetTimeForMillis(fetchedBlock.UpdateAt), 1*time.Second, "update time should be current time")
-	})
+		require.Equal(t, "target-board", card.ParentID)
+		require.Equal(t, "mover", card.ModifiedBy)
 
-	t.Run("get a non-existing block", func(t *testing.T) {
-		fetchedBlock, err := store.GetBlock("non-existing-id")
-		var nf *model.ErrNotFound
-		require.ErrorAs(t, err, &nf)
-		require.Nil(t, fetchedBlock)
+		text, err := store.GetBlock(context.Background(), "text-1")
+		require.NoError(t, err)
+		require.Equal(t, "card-1", text.ParentID)
+
+		history, err := store.GetBlockHistory(context.Background(), "text-1", model.QueryBlockHistoryOptions{Descending: true})
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.Equal(t, "target-board", history[0].BoardID)
+		require.Equal(t, "mover", history[0].ModifiedBy)
+		require.Equal(t, sourceBoardID, history[1].BoardID)
 	})
 }
 
-func testDuplicateBlock(t *testing.T, store store.Store) {
-	blocksToInsert := subtreeSampleBlocks
-	blocksToInsert = append(blocksToInsert,
-		&model.Block{
-			ID:         "grandchild1a",
-			BoardID:    testBoardID,
-			ParentID:   "child1",
-			ModifiedBy: testUserID,
-			Type:       model.TypeComment,
-		},
-		&model.Block{
-			ID:         "grandchild2a",
-			BoardID:    testBoardID,
-			ParentID:   "child2",
-			ModifiedBy: testUserID,
-			Type:       model.TypeComment,
-		},
-	)
+func testBoardBlockLimit(t *testing.T, store store.Store) {
+	userID := testUserID
+	defer store.SetBoardBlockLimit(0)
 
-	InsertBlocks(t, store, blocksToInsert, "user-id-1")
-	time.Sleep(1 * time.Millisecond)
-	defer DeleteBlocks(t, store, subtreeSampleBlocks, "test")
+	countBlocks := func(t *testing.T, boardID string) int {
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		return len(blocks)
+	}
 
-	t.Run("duplicate existing block as no template", func(t *testing.T) {
-		blocks, err := store.DuplicateBlock(testBoardID, "child1", testUserID, false)
+	boardID := "limited-board"
+	_, err := store.InsertBoard(context.Background(), &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}, userID)
+	require.NoError(t, err)
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "block-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		{ID: "block-2", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+	}, userID)
+
+	store.SetBoardBlockLimit(3)
+
+	t.Run("inserting over the limit fails", func(t *testing.T) {
+		err := store.InsertBlocks(context.Background(), []*model.Block{
+			{ID: "block-3", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+			{ID: "block-4", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		}, userID)
+		var limitErr *model.ErrBlockLimitExceeded
+		require.ErrorAs(t, err, &limitErr)
+		require.Equal(t, boardID, limitErr.BoardID)
+		require.Equal(t, 2, limitErr.Count)
+		require.Equal(t, 3, limitErr.Limit)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Equal(t, 2, countBlocks(t, boardID))
+	})
+
+	t.Run("updating existing blocks doesn't count as new blocks", func(t *testing.T) {
+		err := store.InsertBlocks(context.Background(), []*model.Block{
+			{ID: "block-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "updated"},
+			{ID: "block-2", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "updated"},
+			{ID: "block-3", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		}, userID)
+		require.NoError(t, err)
+		require.Equal(t, 3, countBlocks(t, boardID))
+
+		err = store.InsertBlock(context.Background(), &model.Block{ID: "block-4", BoardID: boardID, ParentID: boardID, Type: model.TypeCard}, userID)
+		var limitErr *model.ErrBlockLimitExceeded
+		require.ErrorAs(t, err, &limitErr)
+		require.Equal(t, 3, limitErr.Count)
+
+		err = store.InsertBlock(context.Background(), &model.Block{ID: "block-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "updated again"}, userID)
 		require.NoError(t, err)
-		require.Len(t, blocks, 2)
-		require.Equal(t, false, blocks[0].Fields["isTemplate"])
 	})
 
-	t.Run("duplicate existing block as template", func(t *testing.T) {
-		blocks, err := store.DuplicateBlock(testBoardID, "child1", testUserID, true)
+	t.Run("concurrent inserts don't exceed the limit", func(t *testing.T) {
+		otherBoardID := "concurrent-board"
+		_, err := store.InsertBoard(context.Background(), &model.Board{ID: otherBoardID, TeamID: testTeamID, Type: model.BoardTypeOpen}, userID)
 		require.NoError(t, err)
-		require.Len(t, blocks, 2)
-		require.Equal(t, true, blocks[0].Fields["isTemplate"])
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "concurrent-1", BoardID: otherBoardID, ParentID: otherBoardID, Type: model.TypeCard},
+			{ID: "concurrent-2", BoardID: otherBoardID, ParentID: otherBoardID, Type: model.TypeCard},
+		}, userID)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 10)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				block := &model.Block{ID: fmt.Sprintf("new-block-%d", i), BoardID: otherBoardID, ParentID: otherBoardID, Type: model.TypeCard}
+				if i%2 == 0 {
+					errs[i] = store.InsertBlock(context.Background(), block, userID)
+				} else {
+					errs[i] = store.InsertBlocks(context.Background(), []*model.Block{block}, userID)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			var limitErr *model.ErrBlockLimitExceeded
+			require.ErrorAs(t, err, &limitErr)
+		}
+		require.Equal(t, 1, succeeded)
+		require.Equal(t, 3, countBlocks(t, otherBoardID))
 	})
 
-	t.Run("duplicate not existing block", func(t *testing.T) {
-		blocks, err := store.DuplicateBlock(testBoardID, "not-existing-id", testUserID, false)
-		require.Error(t, err)
-		require.Nil(t, blocks)
+	t.Run("a zero limit means unlimited", func(t *testing.T) {
+		store.SetBoardBlockLimit(0)
+		err := store.InsertBlock(context.Background(), &model.Block{ID: "block-4", BoardID: boardID, ParentID: boardID, Type: model.TypeCard}, userID)
+		require.NoError(t, err)
+		require.Equal(t, 4, countBlocks(t, boardID))
 	})
+}
 
-	t.Run("duplicate not existing board", func(t *testing.T) {
-		blocks, err := store.DuplicateBlock("not-existing-board", "not-existing-id", testUserID, false)
-		require.Error(t, err)
-		require.Nil(t, blocks)
+func testCopyBlocks(t *testing.T, store store.Store) {
+	userID := testUserID
+	sourceBoardID := "source-board"
+	targetBoardID := "target-board"
+
+	for _, boardID := range []string{sourceBoardID, targetBoardID} {
+		_, err := store.InsertBoard(context.Background(), &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}, userID)
+		require.NoError(t, err)
+	}
+
+	InsertBlocks(t, store, []*model.Block{
+		{
+			ID:       "card-1",
+			BoardID:  sourceBoardID,
+			ParentID: sourceBoardID,
+			Type:     model.TypeCard,
+			Fields:   map[string]interface{}{"contentOrder": []interface{}{"text-1", []interface{}{"image-1", "text-2"}, "text-2"}},
+		},
+		{ID: "text-1", BoardID: sourceBoardID, ParentID: "card-1", Type: model.TypeText, Title: "text 1"},
+		{ID: "image-1", BoardID: sourceBoardID, ParentID: "card-1", Type: model.TypeImage},
+		{ID: "card-2", BoardID: sourceBoardID, ParentID: sourceBoardID, Type: model.TypeCard},
+		{ID: "text-2", BoardID: sourceBoardID, ParentID: "card-2", Type: model.TypeText},
+	}, userID)
+
+	targetBlocks := func(t *testing.T) []*model.Block {
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), targetBoardID, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		return blocks
+	}
+
+	t.Run("nonexistent target board", func(t *testing.T) {
+		newIDs, err := store.CopyBlocks(context.Background(), []string{"card-1"}, "not-exists", userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, newIDs)
 	})
 
-	t.Run("not matching board/block", func(t *testing.T) {
-		blocks, err := store.DuplicateBlock("other-id", "child1", testUserID, false)
-		require.Error(t, err)
-		require.Nil(t, blocks)
+	t.Run("nonexistent block", func(t *testing.T) {
+		_, err := store.CopyBlocks(context.Background(), []string{"card-1", "not-exists"}, targetBoardID, userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Empty(t, targetBlocks(t))
+	})
+
+	t.Run("child without its parent", func(t *testing.T) {
+		_, err := store.CopyBlocks(context.Background(), []string{"card-1", "text-2"}, targetBoardID, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Empty(t, targetBlocks(t))
+	})
+
+	t.Run("card with its descendants", func(t *testing.T) {
+		newIDs, err := store.CopyBlocks(context.Background(), []string{"card-1", "text-1"}, targetBoardID, "copier")
+		require.NoError(t, err)
+		require.Len(t, newIDs, 3)
+		for _, id := range []string{"card-1", "text-1", "image-1"} {
+			require.NotEmpty(t, newIDs[id])
+			require.NotEqual(t, id, newIDs[id])
+		}
+		require.Len(t, targetBlocks(t), 3)
+
+		card, err := store.GetBlock(context.Background(), newIDs["card-1"])
+		require.NoError(t, err)
+		require.Equal(t, targetBoardID, card.BoardID)
+		require.Equal(t, targetBoardID, card.ParentID)
+		require.Equal(t, "copier", card.ModifiedBy)
+
+		// text-2 belongs to another card and isn't copied, so the
+		// references to it are removed
+		require.Equal(t, []interface{}{newIDs["text-1"], []interface{}{newIDs["image-1"]}}, card.Fields["contentOrder"])
+
+		text, err := store.GetBlock(context.Background(), newIDs["text-1"])
+		require.NoError(t, err)
+		require.Equal(t, targetBoardID, text.BoardID)
+		require.Equal(t, newIDs["card-1"], text.ParentID)
+		require.Equal(t, "text 1", text.Title)
+
+		// the originals are kept
+		original, err := store.GetBlock(context.Background(), "card-1")
+		require.NoError(t, err)
+		require.Equal(t, sourceBoardID, original.BoardID)
+		require.Equal(t, []interface{}{"text-1", []interface{}{"image-1", "text-2"}, "text-2"}, original.Fields["contentOrder"])
 	})
 }
 
-func testGetBlockMetadata(t *testing.T, store store.Store) {
+func testGetBlocks(t *testing.T, store store.Store) {
 	boardID := testBoardID
-	blocks, err := store.GetBlocksForBoard(boardID)
+	blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, err)
 
 	blocksToInsert := []*model.Block{
@@ -911,153 +1584,1384 @@ func testGetBlockMetadata(t *testing.T, store store.Store) {
 			Type:       "test",
 		},
 	}
-
-	for _, v := range blocksToInsert {
-		time.Sleep(20 * time.Millisecond)
-		subBlocks := []*model.Block{v}
-		InsertBlocks(t, store, subBlocks, testUserID)
-	}
+	InsertBlocks(t, store, blocksToInsert, "user-id-1")
 	defer DeleteBlocks(t, store, blocksToInsert, "test")
 
-	t.Run("get full block history", func(t *testing.T) {
-		opts := model.QueryBlockHistoryOptions{
-			Descending: false,
-		}
-		blocks, err = store.GetBlockHistoryDescendants(boardID, opts)
+	t.Run("not existing parent", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksWithParentAndType(context.Background(), boardID, "not-exists", "test")
 		require.NoError(t, err)
-		require.Len(t, blocks, 5)
-		expectedBlock := blocksToInsert[0]
-		block := blocks[0]
-
-		require.Equal(t, expectedBlock.ID, block.ID)
+		require.Empty(t, blocks)
 	})
 
-	t.Run("get full block history descending", func(t *testing.T) {
-		opts := model.QueryBlockHistoryOptions{
-			Descending: true,
-		}
-		blocks, err = store.GetBlockHistoryDescendants(boardID, opts)
+	t.Run("not existing type", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksWithParentAndType(context.Background(), boardID, "block1", "not-existing")
 		require.NoError(t, err)
-		require.Len(t, blocks, 5)
-		expectedBlock := blocksToInsert[len(blocksToInsert)-1]
-		block := blocks[0]
+		require.Empty(t, blocks)
+	})
 
-		require.Equal(t, expectedBlock.ID, block.ID)
+	t.Run("valid parent and type", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksWithParentAndType(context.Background(), boardID, "block1", "test")
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
 	})
 
-	t.Run("get limited block history", func(t *testing.T) {
-		opts := model.QueryBlockHistoryOptions{
-			Limit:      3,
-			Descending: false,
-		}
-		blocks, err = store.GetBlockHistoryDescendants(boardID, opts)
+	t.Run("valid parent and types", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksWithParentAndTypes(context.Background(), boardID, "block1", []string{"test", "test2"})
 		require.NoError(t, err)
 		require.Len(t, blocks, 3)
-	})
 
-	t.Run("get first block history", func(t *testing.T) {
-		opts := model.QueryBlockHistoryOptions{
-			Limit:      1,
-			Descending: false,
-		}
-		blocks, err = store.GetBlockHistoryDescendants(boardID, opts)
+		blocks, err = store.GetBlocksWithParentAndTypes(context.Background(), boardID, "block1", []string{"test2", "not-existing"})
 		require.NoError(t, err)
 		require.Len(t, blocks, 1)
-		expectedBlock := blocksToInsert[0]
-		block := blocks[0]
+		require.Equal(t, "block4", blocks[0].ID)
 
-		require.Equal(t, expectedBlock.ID, block.ID)
+		blocks, err = store.GetBlocksWithParentAndTypes(context.Background(), boardID, "block1", []string{"not-existing"})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
 	})
 
-	t.Run("get last block history", func(t *testing.T) {
-		opts := model.QueryBlockHistoryOptions{
-			Limit:      1,
-			Descending: true,
-		}
-		blocks, err = store.GetBlockHistoryDescendants(boardID, opts)
+	t.Run("valid parent and no types", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksWithParentAndTypes(context.Background(), boardID, "block1", nil)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+	})
+
+	t.Run("not existing parent", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksWithParent(context.Background(), boardID, "not-exists")
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("valid parent", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksWithParent(context.Background(), boardID, "block1")
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+	})
+
+	t.Run("not existing type", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, _, err = store.GetBlocksWithType(context.Background(), boardID, "not-exists", model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("valid type", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, _, err = store.GetBlocksWithType(context.Background(), boardID, "test", model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Len(t, blocks, 4)
+	})
+
+	t.Run("not existing board", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, _, err = store.GetBlocksForBoard(context.Background(), "not-exists", model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("all blocks of the a board", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, _, err = store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Len(t, blocks, 5)
+	})
+
+	t.Run("several blocks by ids", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksByIDs(context.Background(), []string{"block2", "block4"})
 		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+	})
+
+	t.Run("blocks by ids where some are not found", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksByIDs(context.Background(), []string{"block2", "blockNonexistent"})
+		var naf *model.ErrNotAllFound
+		require.ErrorAs(t, err, &naf)
+		require.True(t, model.IsErrNotFound(err))
 		require.Len(t, blocks, 1)
-		expectedBlock := blocksToInsert[len(blocksToInsert)-1]
-		block := blocks[0]
+	})
 
-		require.Equal(t, expectedBlock.ID, block.ID)
+	t.Run("blocks by ids where none are found", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksByIDs(context.Background(), []string{"blockNonexistent1", "blockNonexistent2"})
+		var naf *model.ErrNotAllFound
+		require.ErrorAs(t, err, &naf)
+		require.True(t, model.IsErrNotFound(err))
+		require.Empty(t, blocks)
 	})
+}
 
-	t.Run("get block history after updateAt", func(t *testing.T) {
-		rBlock, err2 := store.GetBlock("block3")
-		require.NoError(t, err2)
-		require.NotZero(t, rBlock.UpdateAt)
+func testGetBlock(t *testing.T, store store.Store) {
+	InsertBoardsIfMissing(t, store, "user-id-1", "board-id-1")
 
-		opts := model.QueryBlockHistoryOptions{
-			AfterUpdateAt: rBlock.UpdateAt,
-			Descending:    false,
+	t.Run("get a block", func(t *testing.T) {
+		block := &model.Block{
+			ID:         "block-id-10",
+			BoardID:    "board-id-1",
+			ModifiedBy: "user-id-1",
 		}
-		blocks, err = store.GetBlockHistoryDescendants(boardID, opts)
+
+		err := store.InsertBlock(context.Background(), block, "user-id-1")
 		require.NoError(t, err)
-		require.Len(t, blocks, 2)
-		expectedBlock := blocksToInsert[3]
-		block := blocks[0]
 
-		require.Equal(t, expectedBlock.ID, block.ID)
+		fetchedBlock, err := store.GetBlock(context.Background(), "block-id-10")
+		require.NoError(t, err)
+		require.NotNil(t, fetchedBlock)
+		require.Equal(t, "block-id-10", fetchedBlock.ID)
+		require.Equal(t, "board-id-1", fetchedBlock.BoardID)
+		require.Equal(t, "user-id-1", fetchedBlock.CreatedBy)
+		require.Equal(t, "user-id-1", fetchedBlock.ModifiedBy)
+		assert.WithinDurationf(t, time.Now(), utils.GetTimeForMillis(fetchedBlock.CreateAt), 1*time.Second, "create time should be current time")
+		assert.WithinDurationf(t, time.Now(), utils.GetTimeForMillis(fetchedBlock.UpdateAt), 1*time.Second, "update time should be current time")
 	})
 
-	t.Run("get block history before updateAt", func(t *testing.T) {
-		rBlock, err2 := store.GetBlock("block3")
-		require.NoError(t, err2)
-		require.NotZero(t, rBlock.UpdateAt)
+	t.Run("get a non-existing block", func(t *testing.T) {
+		fetchedBlock, err := store.GetBlock(context.Background(), "non-existing-id")
+		var nf *model.ErrNotFound
+		require.ErrorAs(t, err, &nf)
+		require.Nil(t, fetchedBlock)
+	})
 
-		opts := model.QueryBlockHistoryOptions{
-			BeforeUpdateAt: rBlock.UpdateAt,
-			Descending:     true,
+	t.Run("with a cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		fetchedBlock, err := store.GetBlock(ctx, "block-id-10")
+		require.ErrorIs(t, err, context.Canceled)
+		require.Nil(t, fetchedBlock)
+
+		block := &model.Block{
+			ID:         "block-id-11",
+			BoardID:    "board-id-1",
+			ModifiedBy: "user-id-1",
+		}
+		err = store.InsertBlock(ctx, block, "user-id-1")
+		require.ErrorIs(t, err, context.Canceled)
+
+		_, err = store.GetBlock(context.Background(), "block-id-11")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetBlocksMap(t *testing.T, store store.Store) {
+	t.Run("no ids", func(t *testing.T) {
+		blocksMap, err := store.GetBlocksMap(context.Background(), testBoardID, []string{})
+		require.NoError(t, err)
+		require.Empty(t, blocksMap)
+	})
+
+	t.Run("found and missing blocks", func(t *testing.T) {
+		blocks := []*model.Block{
+			{ID: "block-1", BoardID: testBoardID, ModifiedBy: testUserID},
+			{ID: "block-2", BoardID: testBoardID, ModifiedBy: testUserID},
+			{ID: "block-3", BoardID: "other-board-id", ModifiedBy: testUserID},
+		}
+		InsertBlocks(t, store, blocks, testUserID)
+
+		blocksMap, err := store.GetBlocksMap(context.Background(), testBoardID, []string{"block-1", "block-2", "block-3", "nonexistent"})
+		require.NoError(t, err)
+		require.Len(t, blocksMap, 2)
+		require.Equal(t, "block-1", blocksMap["block-1"].ID)
+		require.Equal(t, "block-2", blocksMap["block-2"].ID)
+		require.NotContains(t, blocksMap, "block-3")
+		require.NotContains(t, blocksMap, "nonexistent")
+	})
+
+	t.Run("more ids than a single query allows", func(t *testing.T) {
+		blocks := make([]*model.Block, 0, 600)
+		ids := make([]string, 0, 600)
+		for i := 0; i < 600; i++ {
+			id := utils.NewID(utils.IDTypeBlock)
+			blocks = append(blocks, &model.Block{ID: id, BoardID: "chunked-board-id", ModifiedBy: testUserID})
+			ids = append(ids, id)
 		}
-		blocks, err = store.GetBlockHistoryDescendants(boardID, opts)
+		InsertBlocks(t, store, blocks, testUserID)
+
+		blocksMap, err := store.GetBlocksMap(context.Background(), "chunked-board-id", ids)
+		require.NoError(t, err)
+		require.Len(t, blocksMap, 600)
+	})
+}
+
+func testDuplicateBlock(t *testing.T, store store.Store) {
+	blocksToInsert := subtreeSampleBlocks
+	blocksToInsert = append(blocksToInsert,
+		&model.Block{
+			ID:         "grandchild1a",
+			BoardID:    testBoardID,
+			ParentID:   "child1",
+			ModifiedBy: testUserID,
+			Type:       model.TypeComment,
+		},
+		&model.Block{
+			ID:         "grandchild2a",
+			BoardID:    testBoardID,
+			ParentID:   "child2",
+			ModifiedBy: testUserID,
+			Type:       model.TypeComment,
+		},
+	)
+
+	InsertBlocks(t, store, blocksToInsert, "user-id-1")
+	time.Sleep(1 * time.Millisecond)
+	defer DeleteBlocks(t, store, subtreeSampleBlocks, "test")
+
+	t.Run("duplicate existing block as no template", func(t *testing.T) {
+		blocks, err := store.DuplicateBlock(context.Background(), testBoardID, "child1", testUserID, false)
 		require.NoError(t, err)
 		require.Len(t, blocks, 2)
-		expectedBlock := blocksToInsert[1]
-		block := blocks[0]
+		require.Equal(t, false, blocks[0].Fields["isTemplate"])
+	})
 
-		require.Equal(t, expectedBlock.ID, block.ID)
+	t.Run("duplicate existing block as template", func(t *testing.T) {
+		blocks, err := store.DuplicateBlock(context.Background(), testBoardID, "child1", testUserID, true)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		require.Equal(t, true, blocks[0].Fields["isTemplate"])
 	})
 
-	t.Run("get full block history after delete", func(t *testing.T) {
+	t.Run("duplicate not existing block", func(t *testing.T) {
+		blocks, err := store.DuplicateBlock(context.Background(), testBoardID, "not-existing-id", testUserID, false)
+		require.Error(t, err)
+		require.Nil(t, blocks)
+	})
+
+	t.Run("duplicate not existing board", func(t *testing.T) {
+		blocks, err := store.DuplicateBlock(context.Background(), "not-existing-board", "not-existing-id", testUserID, false)
+		require.Error(t, err)
+		require.Nil(t, blocks)
+	})
+
+	t.Run("not matching board/block", func(t *testing.T) {
+		blocks, err := store.DuplicateBlock(context.Background(), "other-id", "child1", testUserID, false)
+		require.Error(t, err)
+		require.Nil(t, blocks)
+	})
+}
+
+func testGetBlockMetadata(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
+	require.NoError(t, err)
+
+	blocksToInsert := []*model.Block{
+		{
+			ID:         "block1",
+			BoardID:    boardID,
+			ParentID:   "",
+			ModifiedBy: testUserID,
+			Type:       "test",
+		},
+		{
+			ID:         "block2",
+			BoardID:    boardID,
+			ParentID:   "block1",
+			ModifiedBy: testUserID,
+			Type:       "test",
+		},
+		{
+			ID:         "block3",
+			BoardID:    boardID,
+			ParentID:   "block1",
+			ModifiedBy: testUserID,
+			Type:       "test",
+		},
+		{
+			ID:         "block4",
+			BoardID:    boardID,
+			ParentID:   "block1",
+			ModifiedBy: testUserID,
+			Type:       "test2",
+		},
+		{
+			ID:         "block5",
+			BoardID:    boardID,
+			ParentID:   "block2",
+			ModifiedBy: testUserID,
+			Type:       "test",
+		},
+	}
+
+	for _, v := range blocksToInsert {
 		time.Sleep(20 * time.Millisecond)
-		err = store.DeleteBlock(blocksToInsert[0].ID, testUserID)
-		require.NoError(t, err)
+		subBlocks := []*model.Block{v}
+		InsertBlocks(t, store, subBlocks, testUserID)
+	}
+	defer DeleteBlocks(t, store, blocksToInsert, "test")
 
+	t.Run("get full block history", func(t *testing.T) {
 		opts := model.QueryBlockHistoryOptions{
-			Descending: true,
+			Descending: false,
 		}
-		blocks, err = store.GetBlockHistoryDescendants(boardID, opts)
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
 		require.NoError(t, err)
-		require.Len(t, blocks, 6)
+		require.Len(t, blocks, 5)
 		expectedBlock := blocksToInsert[0]
 		block := blocks[0]
 
 		require.Equal(t, expectedBlock.ID, block.ID)
 	})
 
-	t.Run("get full block history after undelete", func(t *testing.T) {
-		time.Sleep(20 * time.Millisecond)
-		err = store.UndeleteBlock(blocksToInsert[0].ID, testUserID)
-		require.NoError(t, err)
-
+	t.Run("get full block history descending", func(t *testing.T) {
 		opts := model.QueryBlockHistoryOptions{
 			Descending: true,
 		}
-		blocks, err = store.GetBlockHistoryDescendants(boardID, opts)
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
 		require.NoError(t, err)
-		require.Len(t, blocks, 7)
-		expectedBlock := blocksToInsert[0]
+		require.Len(t, blocks, 5)
+		expectedBlock := blocksToInsert[len(blocksToInsert)-1]
 		block := blocks[0]
 
 		require.Equal(t, expectedBlock.ID, block.ID)
 	})
 
-	t.Run("get block history of a board with no history", func(t *testing.T) {
-		opts := model.QueryBlockHistoryOptions{}
-
-		blocks, err = store.GetBlockHistoryDescendants("nonexistent-board-id", opts)
+	t.Run("get limited block history", func(t *testing.T) {
+		opts := model.QueryBlockHistoryOptions{
+			Limit:      3,
+			Descending: false,
+		}
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
 		require.NoError(t, err)
-		require.Empty(t, blocks)
+		require.Len(t, blocks, 3)
+	})
+
+	t.Run("get first block history", func(t *testing.T) {
+		opts := model.QueryBlockHistoryOptions{
+			Limit:      1,
+			Descending: false,
+		}
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		expectedBlock := blocksToInsert[0]
+		block := blocks[0]
+
+		require.Equal(t, expectedBlock.ID, block.ID)
+	})
+
+	t.Run("get last block history", func(t *testing.T) {
+		opts := model.QueryBlockHistoryOptions{
+			Limit:      1,
+			Descending: true,
+		}
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		expectedBlock := blocksToInsert[len(blocksToInsert)-1]
+		block := blocks[0]
+
+		require.Equal(t, expectedBlock.ID, block.ID)
+	})
+
+	t.Run("get block history after updateAt", func(t *testing.T) {
+		rBlock, err2 := store.GetBlock(context.Background(), "block3")
+		require.NoError(t, err2)
+		require.NotZero(t, rBlock.UpdateAt)
+
+		opts := model.QueryBlockHistoryOptions{
+			AfterUpdateAt: rBlock.UpdateAt,
+			Descending:    false,
+		}
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		expectedBlock := blocksToInsert[3]
+		block := blocks[0]
+
+		require.Equal(t, expectedBlock.ID, block.ID)
+	})
+
+	t.Run("get block history before updateAt", func(t *testing.T) {
+		rBlock, err2 := store.GetBlock(context.Background(), "block3")
+		require.NoError(t, err2)
+		require.NotZero(t, rBlock.UpdateAt)
+
+		opts := model.QueryBlockHistoryOptions{
+			BeforeUpdateAt: rBlock.UpdateAt,
+			Descending:     true,
+		}
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		expectedBlock := blocksToInsert[1]
+		block := blocks[0]
+
+		require.Equal(t, expectedBlock.ID, block.ID)
+	})
+
+	t.Run("get block history in a time range", func(t *testing.T) {
+		block2, err2 := store.GetBlock(context.Background(), "block2")
+		require.NoError(t, err2)
+		block4, err2 := store.GetBlock(context.Background(), "block4")
+		require.NoError(t, err2)
+
+		opts := model.QueryBlockHistoryOptions{
+			Since:      block2.UpdateAt,
+			Before:     block4.UpdateAt,
+			Descending: true,
+		}
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		require.Equal(t, "block3", blocks[0].ID)
+		require.Equal(t, "block2", blocks[1].ID)
+	})
+
+	t.Run("get block history in pages", func(t *testing.T) {
+		ids := []string{}
+		for page := 0; page < 3; page++ {
+			opts := model.QueryBlockHistoryOptions{
+				Page:       page,
+				PerPage:    2,
+				Limit:      1,
+				Descending: true,
+			}
+			blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
+			require.NoError(t, err)
+			for _, block := range blocks {
+				ids = append(ids, block.ID)
+			}
+		}
+		require.Equal(t, []string{"block5", "block4", "block3", "block2", "block1"}, ids)
+
+		history, err2 := store.GetBlockHistory(context.Background(), "block1", model.QueryBlockHistoryOptions{Page: 1, PerPage: 1})
+		require.NoError(t, err2)
+		require.Empty(t, history)
+	})
+
+	t.Run("get full block history after delete", func(t *testing.T) {
+		time.Sleep(20 * time.Millisecond)
+		_, err = store.DeleteBlock(context.Background(), blocksToInsert[0].ID, testUserID)
+		require.NoError(t, err)
+
+		opts := model.QueryBlockHistoryOptions{
+			Descending: true,
+		}
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 6)
+		expectedBlock := blocksToInsert[0]
+		block := blocks[0]
+
+		require.Equal(t, expectedBlock.ID, block.ID)
+	})
+
+	t.Run("get full block history after undelete", func(t *testing.T) {
+		time.Sleep(20 * time.Millisecond)
+		err = store.UndeleteBlock(context.Background(), blocksToInsert[0].ID, testUserID)
+		require.NoError(t, err)
+
+		opts := model.QueryBlockHistoryOptions{
+			Descending: true,
+		}
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 7)
+		expectedBlock := blocksToInsert[0]
+		block := blocks[0]
+
+		require.Equal(t, expectedBlock.ID, block.ID)
+	})
+
+	t.Run("get block history of a board with no history", func(t *testing.T) {
+		opts := model.QueryBlockHistoryOptions{}
+
+		blocks, err = store.GetBlockHistoryDescendants(context.Background(), "nonexistent-board-id", opts)
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+}
+
+func testArchiveCard(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	blocks := []*model.Block{
+		{ID: "card1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		{ID: "card2", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		{ID: "text1", BoardID: boardID, ParentID: "card1", Type: model.TypeText},
+	}
+	InsertBlocks(t, store, blocks, userID)
+
+	t.Run("archived cards are excluded from the listings", func(t *testing.T) {
+		err := store.ArchiveCard(context.Background(), "card1", userID)
+		require.NoError(t, err)
+
+		card, err := store.GetBlock(context.Background(), "card1")
+		require.NoError(t, err)
+		require.NotZero(t, card.ArchivedAt)
+		require.Zero(t, card.DeleteAt)
+
+		cards, _, err := store.GetBlocksWithType(context.Background(), boardID, string(model.TypeCard), model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Equal(t, "card2", cards[0].ID)
+
+		cards, err = store.GetBlocks(context.Background(), model.QueryBlocksOptions{BoardID: boardID, BlockType: model.TypeCard, IncludeArchived: true})
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+
+		archived, err := store.GetArchivedCards(context.Background(), boardID)
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		require.Equal(t, "card1", archived[0].ID)
+	})
+
+	t.Run("updating an archived card keeps it archived", func(t *testing.T) {
+		title := "New title"
+		_, err := store.PatchBlock(context.Background(), "card1", &model.BlockPatch{Title: &title}, userID)
+		require.NoError(t, err)
+
+		card, err := store.GetBlock(context.Background(), "card1")
+		require.NoError(t, err)
+		require.Equal(t, title, card.Title)
+		require.NotZero(t, card.ArchivedAt)
+	})
+
+	t.Run("archiving pauses the card subscriptions", func(t *testing.T) {
+		_, err := store.CreateSubscription(context.Background(), &model.Subscription{
+			BlockType:      model.TypeCard,
+			BlockID:        "card1",
+			SubscriberType: model.SubTypeUser,
+			SubscriberID:   userID,
+		})
+		require.NoError(t, err)
+
+		subscribers, err := store.GetSubscribersForBlock(context.Background(), "card1", model.SubscriptionEventsAll)
+		require.NoError(t, err)
+		require.Empty(t, subscribers)
+
+		count, err := store.GetSubscribersCountForBlock(context.Background(), "card1")
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		err = store.UnarchiveCard(context.Background(), "card1", userID)
+		require.NoError(t, err)
+
+		subscribers, err = store.GetSubscribersForBlock(context.Background(), "card1", model.SubscriptionEventsAll)
+		require.NoError(t, err)
+		require.Len(t, subscribers, 1)
+	})
+
+	t.Run("unarchived cards are listed again", func(t *testing.T) {
+		card, err := store.GetBlock(context.Background(), "card1")
+		require.NoError(t, err)
+		require.Zero(t, card.ArchivedAt)
+
+		cards, _, err := store.GetBlocksWithType(context.Background(), boardID, string(model.TypeCard), model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+
+		archived, err := store.GetArchivedCards(context.Background(), boardID)
+		require.NoError(t, err)
+		require.Empty(t, archived)
+	})
+
+	t.Run("only cards can be archived", func(t *testing.T) {
+		err := store.ArchiveCard(context.Background(), "text1", userID)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("nonexistent card", func(t *testing.T) {
+		err := store.ArchiveCard(context.Background(), "nonexistent", userID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testCountCardsByPropertyGrouped(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	cardWithProperties := func(id string, properties map[string]interface{}) *model.Block {
+		return &model.Block{
+			ID:       id,
+			BoardID:  boardID,
+			ParentID: boardID,
+			Type:     model.TypeCard,
+			Fields:   map[string]interface{}{"properties": properties},
+		}
+	}
+
+	blocks := []*model.Block{
+		cardWithProperties("card1", map[string]interface{}{"status": "todo", "tags": []string{"a", "b"}}),
+		cardWithProperties("card2", map[string]interface{}{"status": "todo", "tags": []string{"a"}}),
+		cardWithProperties("card3", map[string]interface{}{"status": "done", "tags": []string{}}),
+		cardWithProperties("card4", map[string]interface{}{}),
+		cardWithProperties("card-deleted", map[string]interface{}{"status": "todo"}),
+		cardWithProperties("card-archived", map[string]interface{}{"status": "done"}),
+		cardWithProperties("card-other-board", map[string]interface{}{"status": "todo"}),
+		{ID: "text1", BoardID: boardID, ParentID: "card1", Type: model.TypeText, Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "todo"}}},
+	}
+	blocks[6].BoardID = "other-board"
+	blocks[6].ParentID = "other-board"
+	InsertBlocks(t, store, blocks, userID)
+
+	_, err := store.DeleteBlock(context.Background(), "card-deleted", userID)
+	require.NoError(t, err)
+	require.NoError(t, store.ArchiveCard(context.Background(), "card-archived", userID))
+
+	t.Run("select property", func(t *testing.T) {
+		counts, err := store.CountCardsByPropertyGrouped(context.Background(), boardID, "status")
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{"todo": 2, "done": 1, "": 1}, counts)
+	})
+
+	t.Run("multi-select property", func(t *testing.T) {
+		counts, err := store.CountCardsByPropertyGrouped(context.Background(), boardID, "tags")
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{"a": 2, "b": 1, "": 2}, counts)
+	})
+
+	t.Run("nonexistent property", func(t *testing.T) {
+		counts, err := store.CountCardsByPropertyGrouped(context.Background(), boardID, "nonexistent")
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{"": 4}, counts)
+	})
+
+	t.Run("board without cards", func(t *testing.T) {
+		counts, err := store.CountCardsByPropertyGrouped(context.Background(), "empty-board", "status")
+		require.NoError(t, err)
+		require.Empty(t, counts)
+	})
+}
+
+func testGetCardsMissingProperty(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	cardWithProperties := func(id string, properties map[string]interface{}) *model.Block {
+		return &model.Block{
+			ID:       id,
+			BoardID:  boardID,
+			ParentID: boardID,
+			Type:     model.TypeCard,
+			Fields:   map[string]interface{}{"properties": properties},
+		}
+	}
+
+	blocks := []*model.Block{
+		cardWithProperties("card-set", map[string]interface{}{"due": "1650000000000", "assignee": []string{"user-1"}}),
+		cardWithProperties("card-empty", map[string]interface{}{"due": "", "assignee": []string{}}),
+		cardWithProperties("card-null", map[string]interface{}{"due": nil, "assignee": nil}),
+		cardWithProperties("card-absent", map[string]interface{}{}),
+		cardWithProperties("card-deleted", map[string]interface{}{}),
+		cardWithProperties("card-other-board", map[string]interface{}{}),
+		{ID: "text1", BoardID: boardID, ParentID: "card-set", Type: model.TypeText},
+	}
+	blocks[5].BoardID = "other-board"
+	blocks[5].ParentID = "other-board"
+	InsertBlocks(t, store, blocks, userID)
+
+	_, err := store.DeleteBlock(context.Background(), "card-deleted", userID)
+	require.NoError(t, err)
+
+	getIDs := func(blocks []*model.Block) []string {
+		ids := make([]string, 0, len(blocks))
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	t.Run("select property", func(t *testing.T) {
+		cards, err := store.GetCardsMissingProperty(context.Background(), boardID, "due")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"card-empty", "card-null", "card-absent"}, getIDs(cards))
+	})
+
+	t.Run("multi-select property", func(t *testing.T) {
+		cards, err := store.GetCardsMissingProperty(context.Background(), boardID, "assignee")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"card-empty", "card-null", "card-absent"}, getIDs(cards))
+	})
+
+	t.Run("nonexistent property", func(t *testing.T) {
+		cards, err := store.GetCardsMissingProperty(context.Background(), boardID, "nonexistent")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"card-set", "card-empty", "card-null", "card-absent"}, getIDs(cards))
+	})
+
+	t.Run("board without cards", func(t *testing.T) {
+		cards, err := store.GetCardsMissingProperty(context.Background(), "empty-board", "due")
+		require.NoError(t, err)
+		require.Empty(t, cards)
+	})
+}
+
+func testSearchBlocksForBoard(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	blocks := []*model.Block{
+		{ID: "card-title", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "Quarterly Roadmap"},
+		{
+			ID: "card-property", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "Unrelated",
+			Fields: map[string]interface{}{"properties": map[string]interface{}{"notes": "see the ROADMAP doc"}},
+		},
+		{
+			ID: "card-description", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "Other",
+			Fields: map[string]interface{}{"description": "roadmap draft"},
+		},
+		{ID: "card-deleted", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "Deleted roadmap"},
+		{ID: "card-other-board", BoardID: "other-board", ParentID: "other-board", Type: model.TypeCard, Title: "Roadmap elsewhere"},
+	}
+	InsertBlocks(t, store, blocks, userID)
+
+	_, err := store.DeleteBlock(context.Background(), "card-deleted", userID)
+	require.NoError(t, err)
+
+	getIDs := func(blocks []*model.Block) []string {
+		ids := make([]string, 0, len(blocks))
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	t.Run("title only, case insensitive", func(t *testing.T) {
+		found, err := store.SearchBlocksForBoard(context.Background(), boardID, "roadMAP", nil)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"card-title"}, getIDs(found))
+	})
+
+	t.Run("title and fields", func(t *testing.T) {
+		found, err := store.SearchBlocksForBoard(context.Background(), boardID, "roadmap", []string{"properties.notes", "description"})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"card-title", "card-property", "card-description"}, getIDs(found))
+	})
+
+	t.Run("empty term returns nothing", func(t *testing.T) {
+		found, err := store.SearchBlocksForBoard(context.Background(), boardID, "  ", []string{"description"})
+		require.NoError(t, err)
+		require.Empty(t, found)
+	})
+
+	t.Run("other board", func(t *testing.T) {
+		found, err := store.SearchBlocksForBoard(context.Background(), "other-board", "roadmap", nil)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"card-other-board"}, getIDs(found))
+	})
+
+	t.Run("invalid field path", func(t *testing.T) {
+		found, err := store.SearchBlocksForBoard(context.Background(), boardID, "roadmap", []string{"notes'); DROP TABLE blocks; --"})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, found)
+	})
+}
+
+func testSearchBlocksForUser(t *testing.T, store store.Store) {
+	userID := testUserID
+	otherUserID := "other-user-id"
+
+	boards := []*model.Board{
+		{ID: "open-board", TeamID: testTeamID, Type: model.BoardTypeOpen},
+		{ID: "private-board", TeamID: testTeamID, Type: model.BoardTypePrivate},
+		{ID: "other-private-board", TeamID: testTeamID, Type: model.BoardTypePrivate},
+		{ID: "template-board", TeamID: testTeamID, Type: model.BoardTypeOpen, IsTemplate: true},
+		{ID: "other-team-board", TeamID: "other-team-id", Type: model.BoardTypeOpen},
+	}
+	for _, board := range boards {
+		_, err := store.InsertBoard(context.Background(), board, otherUserID)
+		require.NoError(t, err)
+	}
+	_, err := store.SaveMember(context.Background(), &model.BoardMember{BoardID: "private-board", UserID: userID, SchemeEditor: true}, "user-id")
+	require.NoError(t, err)
+
+	blocks := []*model.Block{
+		{ID: "open-card", BoardID: "open-board", ParentID: "open-board", Type: model.TypeCard, Title: "Quarterly roadmap"},
+		{ID: "open-comment", BoardID: "open-board", ParentID: "open-card", Type: model.TypeComment, Title: "The ROADMAP needs a quarterly review"},
+		{ID: "open-text", BoardID: "open-board", ParentID: "open-card", Type: model.TypeText, Title: "Roadmap for the year"},
+		{ID: "private-text", BoardID: "private-board", ParentID: "private-board", Type: model.TypeText, Title: "Private roadmap notes"},
+		{ID: "other-private-card", BoardID: "other-private-board", ParentID: "other-private-board", Type: model.TypeCard, Title: "Hidden roadmap"},
+		{ID: "template-card", BoardID: "template-board", ParentID: "template-board", Type: model.TypeCard, Title: "Template roadmap"},
+		{ID: "other-team-card", BoardID: "other-team-board", ParentID: "other-team-board", Type: model.TypeCard, Title: "Roadmap elsewhere"},
+		{ID: "deleted-card", BoardID: "open-board", ParentID: "open-board", Type: model.TypeCard, Title: "Deleted roadmap"},
+	}
+	InsertBlocks(t, store, blocks, userID)
+
+	time.Sleep(1 * time.Millisecond)
+	_, err = store.DeleteBlock(context.Background(), "deleted-card", userID)
+	require.NoError(t, err)
+
+	getIDs := func(blocks []*model.Block) []string {
+		ids := make([]string, 0, len(blocks))
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	t.Run("open boards and memberships of the team", func(t *testing.T) {
+		found, err := store.SearchBlocksForUser(context.Background(), testTeamID, "roadmap", userID, model.SearchBlocksOptions{IncludePublicBoards: true})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"open-card", "open-comment", "open-text", "private-text"}, getIDs(found))
+	})
+
+	t.Run("memberships only", func(t *testing.T) {
+		found, err := store.SearchBlocksForUser(context.Background(), testTeamID, "roadmap", userID, model.SearchBlocksOptions{})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"private-text"}, getIDs(found))
+	})
+
+	t.Run("every word must match", func(t *testing.T) {
+		found, err := store.SearchBlocksForUser(context.Background(), testTeamID, "quarterly roadmap", userID, model.SearchBlocksOptions{IncludePublicBoards: true})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"open-card", "open-comment"}, getIDs(found))
+	})
+
+	t.Run("filter by block type", func(t *testing.T) {
+		opts := model.SearchBlocksOptions{IncludePublicBoards: true, BlockTypes: []model.BlockType{model.TypeComment, model.TypeText}}
+		found, err := store.SearchBlocksForUser(context.Background(), testTeamID, "roadmap", userID, opts)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"open-comment", "open-text", "private-text"}, getIDs(found))
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		found, err := store.SearchBlocksForUser(context.Background(), testTeamID, "roadmap", userID, model.SearchBlocksOptions{IncludePublicBoards: true, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+	})
+
+	t.Run("empty term returns nothing", func(t *testing.T) {
+		found, err := store.SearchBlocksForUser(context.Background(), testTeamID, "  ", userID, model.SearchBlocksOptions{})
+		require.NoError(t, err)
+		require.Empty(t, found)
+	})
+
+	t.Run("renamed blocks are found by their new title", func(t *testing.T) {
+		title := "Quarterly planning"
+		_, err := store.PatchBlock(context.Background(), "open-text", &model.BlockPatch{Title: &title}, userID)
+		require.NoError(t, err)
+
+		found, err := store.SearchBlocksForUser(context.Background(), testTeamID, "planning", userID, model.SearchBlocksOptions{IncludePublicBoards: true})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"open-text"}, getIDs(found))
+
+		found, err = store.SearchBlocksForUser(context.Background(), testTeamID, "roadmap", userID, model.SearchBlocksOptions{IncludePublicBoards: true})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"open-card", "open-comment", "private-text"}, getIDs(found))
+	})
+
+	t.Run("within a list of boards", func(t *testing.T) {
+		found, err := store.SearchBlocksInBoards(context.Background(), []string{"other-private-board", "other-team-board"}, "roadmap", model.SearchBlocksOptions{})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"other-private-card", "other-team-card"}, getIDs(found))
+
+		found, err = store.SearchBlocksInBoards(context.Background(), []string{}, "roadmap", model.SearchBlocksOptions{})
+		require.NoError(t, err)
+		require.Empty(t, found)
+	})
+}
+
+func testGetRecentComments(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	author, err := store.CreateUser(context.Background(), &model.User{
+		ID:       userID,
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password",
+	})
+	require.NoError(t, err)
+
+	blocks := []*model.Block{
+		{ID: "card1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		{ID: "card-deleted", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		{ID: "comment1", BoardID: boardID, ParentID: "card1", Type: model.TypeComment},
+		{ID: "comment3", BoardID: boardID, ParentID: "card1", Type: model.TypeComment},
+		{ID: "comment2", BoardID: boardID, ParentID: "card1", Type: model.TypeComment},
+		{ID: "comment-on-deleted", BoardID: boardID, ParentID: "card-deleted", Type: model.TypeComment},
+		{ID: "other-card", BoardID: "other-board", ParentID: "other-board", Type: model.TypeCard},
+		{ID: "comment-other-board", BoardID: "other-board", ParentID: "other-card", Type: model.TypeComment},
+		{ID: "text1", BoardID: boardID, ParentID: "card1", Type: model.TypeText},
+	}
+	// insert the blocks one by one so they get increasing create times
+	for _, block := range blocks {
+		InsertBlocks(t, store, []*model.Block{block}, userID)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// deleting a card keeps its children in the blocks table
+	_, err = store.DeleteBlock(context.Background(), "card-deleted", userID)
+	require.NoError(t, err)
+
+	getIDs := func(comments []*model.CommentWithAuthor) []string {
+		ids := make([]string, 0, len(comments))
+		for _, comment := range comments {
+			ids = append(ids, comment.ID)
+		}
+		return ids
+	}
+
+	t.Run("all comments", func(t *testing.T) {
+		comments, err := store.GetRecentComments(context.Background(), boardID, 0)
+		require.NoError(t, err)
+		require.Equal(t, []string{"comment2", "comment3", "comment1"}, getIDs(comments))
+	})
+
+	t.Run("limited comments", func(t *testing.T) {
+		comments, err := store.GetRecentComments(context.Background(), boardID, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"comment2", "comment3"}, getIDs(comments))
+	})
+
+	t.Run("board without comments", func(t *testing.T) {
+		comments, err := store.GetRecentComments(context.Background(), "empty-board", 10)
+		require.NoError(t, err)
+		require.Empty(t, comments)
+	})
+
+	t.Run("comment authors", func(t *testing.T) {
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "comment-without-author", BoardID: boardID, ParentID: "card1", Type: model.TypeComment},
+		}, "nonexistent-user")
+
+		comments, err := store.GetRecentComments(context.Background(), boardID, 0)
+		require.NoError(t, err)
+		require.Len(t, comments, 4)
+
+		// authors that no longer exist are left empty
+		require.Equal(t, "comment-without-author", comments[0].ID)
+		require.Nil(t, comments[0].Author)
+
+		for _, comment := range comments[1:] {
+			require.NotNil(t, comment.Author)
+			require.Equal(t, author.ID, comment.Author.ID)
+			require.Equal(t, author.Username, comment.Author.Username)
+			require.Equal(t, author.Email, comment.Author.Email)
+			require.Empty(t, comment.Author.Password)
+		}
+	})
+}
+
+func testGetBlockCountsForTeams(t *testing.T, store store.Store) {
+	t.Run("no blocks", func(t *testing.T) {
+		count, err := store.GetBlockCountForTeam(context.Background(), testTeamID)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		counts, err := store.GetBlockCountsForTeams(context.Background(), []string{})
+		require.NoError(t, err)
+		require.Empty(t, counts)
+	})
+
+	t.Run("count blocks per team", func(t *testing.T) {
+		boards := []*model.Board{
+			{ID: "board-1", TeamID: "team-1", Type: model.BoardTypeOpen},
+			{ID: "board-2", TeamID: "team-1", Type: model.BoardTypeOpen},
+			{ID: "board-3", TeamID: "team-2", Type: model.BoardTypeOpen},
+		}
+		for _, board := range boards {
+			_, err := store.InsertBoard(context.Background(), board, testUserID)
+			require.NoError(t, err)
+		}
+
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "block-1", BoardID: "board-1", ParentID: "board-1", Type: model.TypeCard},
+			{ID: "block-2", BoardID: "board-1", ParentID: "block-1", Type: model.TypeText},
+			{ID: "block-3", BoardID: "board-2", ParentID: "board-2", Type: model.TypeCard},
+			{ID: "block-4", BoardID: "board-2", ParentID: "board-2", Type: model.TypeCard},
+			{ID: "block-5", BoardID: "board-3", ParentID: "board-3", Type: model.TypeCard},
+		}, testUserID)
+
+		_, err := store.DeleteBlock(context.Background(), "block-4", testUserID)
+		require.NoError(t, err)
+
+		count, err := store.GetBlockCountForTeam(context.Background(), "team-1")
+		require.NoError(t, err)
+		require.Equal(t, int64(3), count)
+
+		counts, err := store.GetBlockCountsForTeams(context.Background(), []string{"team-1", "team-2", "team-3"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{"team-1": 3, "team-2": 1, "team-3": 0}, counts)
+	})
+}
+
+func testGetBoardStats(t *testing.T, store store.Store) {
+	board := &model.Board{ID: "board-1", TeamID: testTeamID, Type: model.BoardTypeOpen}
+	_, err := store.InsertBoard(context.Background(), board, testUserID)
+	require.NoError(t, err)
+
+	t.Run("nonexistent board", func(t *testing.T) {
+		_, err := store.GetBoardStats(context.Background(), "nonexistent-board")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("empty board", func(t *testing.T) {
+		stats, err := store.GetBoardStats(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Equal(t, &model.BoardStats{BoardID: board.ID}, stats)
+
+		counts, err := store.GetBlockCountsByTypeForBoard(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Empty(t, counts)
+	})
+
+	t.Run("board with blocks", func(t *testing.T) {
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "block-1", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+			{ID: "block-2", BoardID: board.ID, ParentID: "block-1", Type: model.TypeText},
+			{ID: "block-3", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+		}, "user-id-1")
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "block-4", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+			{ID: "other-block", BoardID: "other-board", ParentID: "other-board", Type: model.TypeCard},
+		}, "user-id-2")
+
+		time.Sleep(2 * time.Millisecond)
+		_, err := store.DeleteBlock(context.Background(), "block-4", "user-id-2")
+		require.NoError(t, err)
+
+		time.Sleep(2 * time.Millisecond)
+		title := "new title"
+		_, err = store.PatchBlock(context.Background(), "block-2", &model.BlockPatch{Title: &title}, "user-id-3")
+		require.NoError(t, err)
+
+		block, err := store.GetBlock(context.Background(), "block-2")
+		require.NoError(t, err)
+
+		stats, err := store.GetBoardStats(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Equal(t, board.ID, stats.BoardID)
+		require.Equal(t, int64(2), stats.CardCount)
+		require.Equal(t, int64(3), stats.BlockCount)
+		require.Equal(t, block.UpdateAt, stats.LastModified)
+		require.Equal(t, int64(3), stats.ContributorCount)
+
+		counts, err := store.GetBlockCountsByTypeForBoard(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{string(model.TypeCard): 2, string(model.TypeText): 1}, counts)
+	})
+}
+
+func testGetBlocksForBoardStream(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	InsertBoardsIfMissing(t, store, testUserID, boardID)
+
+	t.Run("empty board", func(t *testing.T) {
+		calls := 0
+		err := store.GetBlocksForBoardStream(context.Background(), boardID, func(model.Block) error {
+			calls++
+			return nil
+		})
+		require.NoError(t, err)
+		require.Zero(t, calls)
+	})
+
+	t.Run("stream the blocks of a board", func(t *testing.T) {
+		const blockCount = 200
+
+		blocks := make([]*model.Block, 0, blockCount)
+		for i := 0; i < blockCount; i++ {
+			blocks = append(blocks, &model.Block{
+				ID:       fmt.Sprintf("block-%06d", i),
+				BoardID:  boardID,
+				ParentID: boardID,
+				Type:     model.TypeCard,
+			})
+		}
+		require.NoError(t, store.InsertBlocks(context.Background(), blocks, testUserID))
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "other-block", BoardID: "other-board", ParentID: "other-board", Type: model.TypeCard},
+		}, testUserID)
+
+		count := 0
+		lastID := ""
+		err := store.GetBlocksForBoardStream(context.Background(), boardID, func(block model.Block) error {
+			require.Equal(t, boardID, block.BoardID)
+			require.Greater(t, block.ID, lastID)
+			lastID = block.ID
+			count++
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, blockCount, count)
+		require.Equal(t, fmt.Sprintf("block-%06d", blockCount-1), lastID)
+	})
+
+	t.Run("callback error stops the stream", func(t *testing.T) {
+		errStop := errors.New("stop")
+
+		count := 0
+		err := store.GetBlocksForBoardStream(context.Background(), boardID, func(model.Block) error {
+			count++
+			if count == 10 {
+				return errStop
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Equal(t, 10, count)
+
+		// the rows of the stopped stream are closed, so the store
+		// keeps working
+		block, err := store.GetBlock(context.Background(), "block-000000")
+		require.NoError(t, err)
+		require.Equal(t, boardID, block.BoardID)
+	})
+}
+
+func testGetLastModifiedBlockForBoard(t *testing.T, store store.Store) {
+	boardID := testBoardID
+
+	t.Run("no blocks", func(t *testing.T) {
+		_, err := store.GetLastModifiedBlockForBoard(context.Background(), boardID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("most recently modified block", func(t *testing.T) {
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "block-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+			{ID: "block-2", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		}, "user-id-1")
+
+		time.Sleep(2 * time.Millisecond)
+		title := "new title"
+		_, err := store.PatchBlock(context.Background(), "block-1", &model.BlockPatch{Title: &title}, "user-id-2")
+		require.NoError(t, err)
+
+		block, err := store.GetLastModifiedBlockForBoard(context.Background(), boardID)
+		require.NoError(t, err)
+		require.Equal(t, "block-1", block.ID)
+		require.Equal(t, "user-id-2", block.ModifiedBy)
+	})
+}
+
+func testNormalizeContentOrder(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	InsertBoardsIfMissing(t, store, testUserID, boardID)
+
+	getContentIDs := func(t *testing.T) []string {
+		blocks, err := store.GetBlocks(context.Background(), model.QueryBlocksOptions{
+			BoardID:          boardID,
+			ParentID:         "card",
+			BlockTypes:       []model.BlockType{model.TypeText, model.TypeImage},
+			OrderBySortOrder: true,
+		})
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(blocks))
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	card := &model.Block{
+		ID:       "card",
+		BoardID:  boardID,
+		ParentID: boardID,
+		Type:     model.TypeCard,
+		Fields: map[string]interface{}{
+			"contentOrder": []interface{}{"text2", "missing", []interface{}{"image1", "text1"}},
+		},
+	}
+	require.NoError(t, store.InsertBlock(context.Background(), card, testUserID))
+
+	for _, block := range []*model.Block{
+		{ID: "text1", BoardID: boardID, ParentID: "card", Type: model.TypeText},
+		{ID: "text2", BoardID: boardID, ParentID: "card", Type: model.TypeText},
+		{ID: "image1", BoardID: boardID, ParentID: "card", Type: model.TypeImage},
+		{ID: "orphan", BoardID: boardID, ParentID: "card", Type: model.TypeText},
+		{ID: "comment", BoardID: boardID, ParentID: "card", Type: model.TypeComment},
+	} {
+		require.NoError(t, store.InsertBlock(context.Background(), block, testUserID))
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	t.Run("new blocks are sorted by insertion", func(t *testing.T) {
+		require.Equal(t, []string{"text1", "text2", "image1", "orphan"}, getContentIDs(t))
+	})
+
+	t.Run("normalize content order", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, store.NormalizeContentOrder(context.Background(), "card"))
+
+		card, err := store.GetBlock(context.Background(), "card")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"text2", []interface{}{"image1", "text1"}, "orphan"}, card.Fields["contentOrder"])
+		require.Equal(t, []string{"text2", "image1", "text1", "orphan"}, getContentIDs(t))
+
+		// normalizing again changes nothing
+		require.NoError(t, store.NormalizeContentOrder(context.Background(), "card"))
+		normalized, err := store.GetBlock(context.Background(), "card")
+		require.NoError(t, err)
+		require.Equal(t, card.UpdateAt, normalized.UpdateAt)
+	})
+
+	t.Run("patching the content order updates the sort order", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		patch := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{
+				"contentOrder": []interface{}{"orphan", "text1", "text2", "image1"},
+			},
+		}
+		_, err := store.PatchBlock(context.Background(), "card", patch, testUserID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"orphan", "text1", "text2", "image1"}, getContentIDs(t))
+	})
+
+	t.Run("normalize a block that is not a card", func(t *testing.T) {
+		err := store.NormalizeContentOrder(context.Background(), "text1")
+		require.True(t, model.IsErrBadRequest(err))
+
+		err = store.NormalizeContentOrder(context.Background(), "nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetBlocksForBoards(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	blocks := []*model.Block{
+		{ID: "card1", BoardID: "board1", ParentID: "board1", Type: model.TypeCard},
+		{ID: "text1", BoardID: "board1", ParentID: "card1", Type: model.TypeText},
+		{ID: "card2", BoardID: "board2", ParentID: "board2", Type: model.TypeCard},
+		{ID: "card3", BoardID: "board2", ParentID: "board2", Type: model.TypeCard},
+		{ID: "card4", BoardID: "board3", ParentID: "board3", Type: model.TypeCard},
+	}
+	InsertBlocks(t, store, blocks, userID)
+	require.NoError(t, store.ArchiveCard(context.Background(), "card3", userID))
+
+	blockIDsByBoard := func(blocks []*model.Block) map[string][]string {
+		ids := map[string][]string{}
+		for _, block := range blocks {
+			ids[block.BoardID] = append(ids[block.BoardID], block.ID)
+		}
+		return ids
+	}
+
+	t.Run("get the blocks of several boards", func(t *testing.T) {
+		blocks, err := store.GetBlocksForBoards(context.Background(), []string{"board1", "board2"}, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		ids := blockIDsByBoard(blocks)
+		require.Len(t, ids, 2)
+		require.ElementsMatch(t, []string{"card1", "text1"}, ids["board1"])
+		require.ElementsMatch(t, []string{"card2"}, ids["board2"])
+	})
+
+	t.Run("filter by type and include archived cards", func(t *testing.T) {
+		opts := model.QueryBlocksOptions{BlockType: model.TypeCard, IncludeArchived: true}
+		blocks, err := store.GetBlocksForBoards(context.Background(), []string{"board1", "board2", "board3"}, opts)
+		require.NoError(t, err)
+		ids := blockIDsByBoard(blocks)
+		require.ElementsMatch(t, []string{"card1"}, ids["board1"])
+		require.ElementsMatch(t, []string{"card2", "card3"}, ids["board2"])
+		require.ElementsMatch(t, []string{"card4"}, ids["board3"])
+	})
+
+	t.Run("no boards", func(t *testing.T) {
+		blocks, err := store.GetBlocksForBoards(context.Background(), []string{}, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+}
+
+func testGetBlocksForBoardPaginated(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+	InsertBoardsIfMissing(t, store, userID, boardID)
+
+	blocks := []*model.Block{
+		{ID: "block-b", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		{ID: "block-d", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		{ID: "block-f", BoardID: boardID, ParentID: boardID, Type: model.TypeText},
+		{ID: "block-h", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		{ID: "block-j", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+	}
+	for _, block := range blocks {
+		require.NoError(t, store.InsertBlock(context.Background(), block, userID))
+		// make the update times distinct
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	blockIDs := func(blocks []*model.Block) []string {
+		ids := make([]string, 0, len(blocks))
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	t.Run("without options all the blocks are returned", func(t *testing.T) {
+		blocks, hasMore, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Len(t, blocks, 5)
+	})
+
+	t.Run("pages are stable when blocks are inserted between calls", func(t *testing.T) {
+		opts := model.QueryBlocksOptions{PerPage: 2}
+		page1, hasMore, err := store.GetBlocksForBoard(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.True(t, hasMore)
+		require.Equal(t, []string{"block-b", "block-d"}, blockIDs(page1))
+
+		// a block before the cursor doesn't shift the next pages and one
+		// after it shows up in them
+		InsertBlocks(t, store, []*model.Block{
+			{ID: "block-a", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+			{ID: "block-g", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+		}, userID)
+
+		opts.AfterID = page1[len(page1)-1].ID
+		page2, hasMore, err := store.GetBlocksForBoard(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.True(t, hasMore)
+		require.Equal(t, []string{"block-f", "block-g"}, blockIDs(page2))
+
+		opts.AfterID = page2[len(page2)-1].ID
+		page3, hasMore, err := store.GetBlocksForBoard(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Equal(t, []string{"block-h", "block-j"}, blockIDs(page3))
+
+		opts.AfterID = page3[len(page3)-1].ID
+		page4, hasMore, err := store.GetBlocksForBoard(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Empty(t, page4)
+	})
+
+	t.Run("pages sorted by update time", func(t *testing.T) {
+		opts := model.QueryBlocksOptions{PerPage: 3, OrderByUpdateAt: true}
+		page1, hasMore, err := store.GetBlocksForBoard(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.True(t, hasMore)
+		require.Equal(t, []string{"block-b", "block-d", "block-f"}, blockIDs(page1))
+
+		last := page1[len(page1)-1]
+		opts.AfterID = last.ID
+		opts.AfterUpdateAt = last.UpdateAt
+		opts.PerPage = 10
+		page2, hasMore, err := store.GetBlocksForBoard(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Len(t, page2, 4)
+		require.Equal(t, []string{"block-h", "block-j"}, blockIDs(page2)[:2])
+		require.ElementsMatch(t, []string{"block-a", "block-g"}, blockIDs(page2)[2:])
+	})
+
+	t.Run("pages of the blocks with a type", func(t *testing.T) {
+		opts := model.QueryBlocksOptions{PerPage: 4}
+		page1, hasMore, err := store.GetBlocksWithType(context.Background(), boardID, model.TypeCard, opts)
+		require.NoError(t, err)
+		require.True(t, hasMore)
+		require.Equal(t, []string{"block-a", "block-b", "block-d", "block-g"}, blockIDs(page1))
+
+		opts.AfterID = page1[len(page1)-1].ID
+		page2, hasMore, err := store.GetBlocksWithType(context.Background(), boardID, model.TypeCard, opts)
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Equal(t, []string{"block-h", "block-j"}, blockIDs(page2))
 	})
 }
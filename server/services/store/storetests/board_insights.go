@@ -1,6 +1,7 @@
 package storetests
 
 import (
+	"context"
 	"strconv"
 	"testing"
 
@@ -47,7 +48,7 @@ func getBoardsInsightsTest(t *testing.T, store store.Store) {
 		},
 	}
 
-	bab, err := store.CreateBoardsAndBlocks(newBab, userID)
+	bab, err := store.CreateBoardsAndBlocks(context.Background(), newBab, userID)
 	require.Nil(t, err)
 	require.NotNil(t, bab)
 
@@ -57,7 +58,7 @@ func getBoardsInsightsTest(t *testing.T, store store.Store) {
 			{ID: "block-id-14", BoardID: "board-id-1", Type: model.TypeCard},
 		},
 	}
-	bab, err = store.CreateBoardsAndBlocks(newBab, testInsightsUserID1)
+	bab, err = store.CreateBoardsAndBlocks(context.Background(), newBab, testInsightsUserID1)
 	require.Nil(t, err)
 	require.NotNil(t, bab)
 	bm := &model.BoardMember{
@@ -66,13 +67,13 @@ func getBoardsInsightsTest(t *testing.T, store store.Store) {
 		SchemeAdmin: true,
 	}
 
-	_, _ = store.SaveMember(bm)
+	_, _ = store.SaveMember(context.Background(), bm, "user-id")
 
-	boardsUser1, _ := store.GetBoardsForUserAndTeam(testUserID, testTeamID, true)
-	boardsUser2, _ := store.GetBoardsForUserAndTeam(testInsightsUserID1, testTeamID, true)
+	boardsUser1, _ := store.GetBoardsForUserAndTeam(context.Background(), testUserID, testTeamID, true)
+	boardsUser2, _ := store.GetBoardsForUserAndTeam(context.Background(), testInsightsUserID1, testTeamID, true)
 	t.Run("team insights", func(t *testing.T) {
 		boardIDs := []string{boardsUser1[0].ID, boardsUser1[1].ID, boardsUser1[2].ID}
-		topTeamBoards, err := store.GetTeamBoardsInsights(testTeamID, testUserID,
+		topTeamBoards, err := store.GetTeamBoardsInsights(context.Background(), testTeamID, testUserID,
 			0, 0, 10, boardIDs)
 		require.NoError(t, err)
 		require.Len(t, topTeamBoards.Items, 3)
@@ -85,13 +86,13 @@ func getBoardsInsightsTest(t *testing.T, store store.Store) {
 
 	t.Run("user insights", func(t *testing.T) {
 		boardIDs := []string{boardsUser1[0].ID, boardsUser1[1].ID, boardsUser1[2].ID}
-		topUser1Boards, err := store.GetUserBoardsInsights(testTeamID, testUserID, 0, 0, 10, boardIDs)
+		topUser1Boards, err := store.GetUserBoardsInsights(context.Background(), testTeamID, testUserID, 0, 0, 10, boardIDs)
 		require.NoError(t, err)
 		require.Len(t, topUser1Boards.Items, 3)
 		require.Equal(t, topUser1Boards.Items[0].Icon, "💬")
 		require.Equal(t, topUser1Boards.Items[0].BoardID, "board-id-1")
 		boardIDs = []string{boardsUser2[0].ID, boardsUser2[1].ID}
-		topUser2Boards, err := store.GetUserBoardsInsights(testTeamID, testInsightsUserID1, 0, 0, 10, boardIDs)
+		topUser2Boards, err := store.GetUserBoardsInsights(context.Background(), testTeamID, testInsightsUserID1, 0, 0, 10, boardIDs)
 		require.NoError(t, err)
 		require.Len(t, topUser2Boards.Items, 1)
 		require.Equal(t, topUser2Boards.Items[0].BoardID, "board-id-1")
@@ -0,0 +1,171 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestBoardCustomRolesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateBoardCustomRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateBoardCustomRole(t, store)
+	})
+
+	t.Run("UpdateBoardCustomRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateBoardCustomRole(t, store)
+	})
+
+	t.Run("SetMemberCustomRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSetMemberCustomRole(t, store)
+	})
+}
+
+func testCreateBoardCustomRole(t *testing.T, store store.Store) {
+	t.Run("create roles", func(t *testing.T) {
+		editor := &model.BoardCustomRole{
+			BoardID:     testBoardID,
+			Name:        "Editor",
+			Permissions: model.RolePermissionViewBoard | model.RolePermissionManageBoardCards,
+			CreatedBy:   testUserID,
+		}
+		require.NoError(t, store.CreateBoardCustomRole(context.Background(), editor), "create role should not error")
+		assert.NotEmpty(t, editor.ID)
+		assert.NotZero(t, editor.CreateAt)
+
+		contributor := &model.BoardCustomRole{
+			BoardID:     testBoardID,
+			Name:        "Contributor",
+			Permissions: model.RolePermissionViewBoard | model.RolePermissionCreateBoardCards,
+			CreatedBy:   testUserID,
+		}
+		require.NoError(t, store.CreateBoardCustomRole(context.Background(), contributor), "create role should not error")
+
+		roles, err := store.GetBoardCustomRoles(context.Background(), testBoardID)
+		require.NoError(t, err, "get roles should not error")
+		require.Len(t, roles, 2)
+		assert.Equal(t, "Contributor", roles[0].Name)
+		assert.Equal(t, "Editor", roles[1].Name)
+
+		roles, err = store.GetBoardCustomRoles(context.Background(), "other-board")
+		require.NoError(t, err, "get roles should not error")
+		assert.Empty(t, roles)
+
+		fetched, err := store.GetBoardCustomRole(context.Background(), editor.ID)
+		require.NoError(t, err, "get role should not error")
+		assert.Equal(t, editor.Permissions, fetched.Permissions)
+		assert.True(t, fetched.HasPermission(model.PermissionManageBoardCards))
+		assert.False(t, fetched.HasPermission(model.PermissionDeleteBoardCards))
+
+		_, err = store.GetBoardCustomRole(context.Background(), "bogus")
+		assert.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		var errInvalid model.ErrInvalidBoardCustomRole
+
+		err := store.CreateBoardCustomRole(context.Background(), &model.BoardCustomRole{BoardID: testBoardID})
+		require.ErrorAs(t, err, &errInvalid)
+
+		err = store.CreateBoardCustomRole(context.Background(), &model.BoardCustomRole{BoardID: testBoardID, Name: "Role", Permissions: 1 << 40})
+		require.ErrorAs(t, err, &errInvalid)
+	})
+}
+
+func testUpdateBoardCustomRole(t *testing.T, store store.Store) {
+	role := &model.BoardCustomRole{
+		BoardID:     testBoardID,
+		Name:        "Viewer",
+		Permissions: model.RolePermissionViewBoard,
+		CreatedBy:   testUserID,
+	}
+	require.NoError(t, store.CreateBoardCustomRole(context.Background(), role))
+
+	t.Run("update role", func(t *testing.T) {
+		update := &model.BoardCustomRole{
+			ID:          role.ID,
+			BoardID:     testBoardID,
+			Name:        "Commenter",
+			Permissions: model.RolePermissionViewBoard | model.RolePermissionCommentBoardCards,
+		}
+		require.NoError(t, store.UpdateBoardCustomRole(context.Background(), update, "user-id"), "update role should not error")
+
+		fetched, err := store.GetBoardCustomRole(context.Background(), role.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Commenter", fetched.Name)
+		assert.True(t, fetched.HasPermission(model.PermissionCommentBoardCards))
+		assert.Equal(t, testUserID, fetched.CreatedBy)
+	})
+
+	t.Run("update role of another board", func(t *testing.T) {
+		update := &model.BoardCustomRole{ID: role.ID, BoardID: "other-board", Name: "Role"}
+		err := store.UpdateBoardCustomRole(context.Background(), update, "user-id")
+		assert.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testSetMemberCustomRole(t *testing.T, store store.Store) {
+	role := &model.BoardCustomRole{
+		BoardID:     testBoardID,
+		Name:        "Viewer",
+		Permissions: model.RolePermissionViewBoard,
+		CreatedBy:   testUserID,
+	}
+	require.NoError(t, store.CreateBoardCustomRole(context.Background(), role))
+
+	_, err := store.SaveMember(context.Background(), &model.BoardMember{
+		BoardID:      testBoardID,
+		UserID:       testUserID,
+		SchemeEditor: true,
+	}, "user-id")
+	require.NoError(t, err)
+
+	t.Run("assign role", func(t *testing.T) {
+		require.NoError(t, store.SetMemberCustomRole(context.Background(), testBoardID, testUserID, role.ID, "user-id"))
+
+		member, err := store.GetMemberForBoard(context.Background(), testBoardID, testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, role.ID, member.CustomRoleID)
+		assert.True(t, member.SchemeEditor)
+	})
+
+	t.Run("assign role of another board", func(t *testing.T) {
+		other := &model.BoardCustomRole{BoardID: "other-board", Name: "Other", CreatedBy: testUserID}
+		require.NoError(t, store.CreateBoardCustomRole(context.Background(), other))
+
+		err := store.SetMemberCustomRole(context.Background(), testBoardID, testUserID, other.ID, "user-id")
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("assign role to a non member", func(t *testing.T) {
+		err := store.SetMemberCustomRole(context.Background(), testBoardID, "not-a-member", role.ID, "user-id")
+		assert.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("delete assigned role", func(t *testing.T) {
+		require.NoError(t, store.DeleteBoardCustomRole(context.Background(), role.ID, "user-id"))
+
+		member, err := store.GetMemberForBoard(context.Background(), testBoardID, testUserID)
+		require.NoError(t, err)
+		assert.Empty(t, member.CustomRoleID)
+
+		_, err = store.GetBoardCustomRole(context.Background(), role.ID)
+		assert.True(t, model.IsErrNotFound(err))
+
+		err = store.DeleteBoardCustomRole(context.Background(), role.ID, "user-id")
+		assert.True(t, model.IsErrNotFound(err))
+	})
+}
@@ -1,6 +1,9 @@
 package storetests
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,11 +20,26 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetBoard(t, store)
 	})
+	t.Run("GetBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoards(t, store)
+	})
 	t.Run("GetBoardsForUserAndTeam", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
 		testGetBoardsForUserAndTeam(t, store)
 	})
+	t.Run("GetBoardsForUserAndTeamWithOptions", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsForUserAndTeamWithOptions(t, store)
+	})
+	t.Run("GetBoardsAdministeredByUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsAdministeredByUser(t, store)
+	})
 	t.Run("GetBoardsInTeamByIds", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -37,6 +55,11 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testPatchBoard(t, store)
 	})
+	t.Run("PatchBoardExpectedUpdateAt", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testPatchBoardExpectedUpdateAt(t, store)
+	})
 	t.Run("DeleteBoard", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -57,6 +80,21 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testSaveMember(t, store)
 	})
+	t.Run("SaveMembers", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveMembers(t, store)
+	})
+	t.Run("SaveMemberWithLimit", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveMemberWithLimit(t, store)
+	})
+	t.Run("GetBoardMemberCount", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardMemberCount(t, store)
+	})
 	t.Run("GetMemberForBoard", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -72,6 +110,21 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetMembersForUser(t, store)
 	})
+	t.Run("DeleteMembers", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteMembers(t, store)
+	})
+	t.Run("UpdateMemberRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateMemberRole(t, store)
+	})
+	t.Run("UpdateMemberLastViewed", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateMemberLastViewed(t, store)
+	})
 	t.Run("DeleteMember", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -97,6 +150,51 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetBoardCount(t, store)
 	})
+	t.Run("GetBoardsModifiedSince", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsModifiedSince(t, store)
+	})
+	t.Run("CreateBoardComplete", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateBoardComplete(t, store)
+	})
+	t.Run("GetBoardWithStats", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardWithStats(t, store)
+	})
+	t.Run("ValidateBoardSchema", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testValidateBoardSchema(t, store)
+	})
+	t.Run("GetBoardCardProperties", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardCardProperties(t, store)
+	})
+	t.Run("CountBoardsCreatedBetween", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCountBoardsCreatedBetween(t, store)
+	})
+	t.Run("SetBoardPropertyOrder", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSetBoardPropertyOrder(t, store)
+	})
+	t.Run("ArchiveBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testArchiveBoard(t, store)
+	})
+	t.Run("SetBoardFavorite", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSetBoardFavorite(t, store)
+	})
 }
 
 func testGetBoard(t *testing.T, store store.Store) {
@@ -109,10 +207,10 @@ func testGetBoard(t *testing.T, store store.Store) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		_, err := store.InsertBoard(board, userID)
+		_, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 
-		rBoard, err := store.GetBoard(board.ID)
+		rBoard, err := store.GetBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Equal(t, board.ID, rBoard.ID)
 		require.Equal(t, board.TeamID, rBoard.TeamID)
@@ -121,10 +219,12 @@ func testGetBoard(t *testing.T, store store.Store) {
 		require.Equal(t, board.Type, rBoard.Type)
 		require.NotZero(t, rBoard.CreateAt)
 		require.NotZero(t, rBoard.UpdateAt)
+		require.Equal(t, model.BoardCreationSourceBlank, rBoard.CreationSource)
+		require.Empty(t, rBoard.SourceID)
 	})
 
 	t.Run("nonexisting board", func(t *testing.T) {
-		rBoard, err := store.GetBoard("nonexistent-id")
+		rBoard, err := store.GetBoard(context.Background(), "nonexistent-id")
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
@@ -132,11 +232,56 @@ func testGetBoard(t *testing.T, store store.Store) {
 	})
 }
 
+func testGetBoards(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	for _, id := range []string{"board-id-1", "board-id-2", "board-id-3"} {
+		_, err := store.InsertBoard(context.Background(), &model.Board{ID: id, TeamID: testTeamID, Type: model.BoardTypeOpen}, userID)
+		require.NoError(t, err)
+	}
+
+	t.Run("should return the boards in the order of the IDs", func(t *testing.T) {
+		boards, err := store.GetBoards(context.Background(), []string{"board-id-3", "board-id-1", "board-id-2"})
+		require.NoError(t, err)
+		require.Len(t, boards, 3)
+		require.Equal(t, "board-id-3", boards[0].ID)
+		require.Equal(t, "board-id-1", boards[1].ID)
+		require.Equal(t, "board-id-2", boards[2].ID)
+	})
+
+	t.Run("should skip the IDs that don't exist", func(t *testing.T) {
+		boards, err := store.GetBoards(context.Background(), []string{"nonexistent-id", "board-id-2", "board-id-2"})
+		require.NoError(t, err)
+		require.Len(t, boards, 1)
+		require.Equal(t, "board-id-2", boards[0].ID)
+	})
+
+	t.Run("should return an empty list if no IDs are given", func(t *testing.T) {
+		boards, err := store.GetBoards(context.Background(), []string{})
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+
+	t.Run("should work with more IDs than fit in one query", func(t *testing.T) {
+		ids := []string{"board-id-1"}
+		for i := 0; i < 1200; i++ {
+			ids = append(ids, fmt.Sprintf("nonexistent-id-%d", i))
+		}
+		ids = append(ids, "board-id-3")
+
+		boards, err := store.GetBoards(context.Background(), ids)
+		require.NoError(t, err)
+		require.Len(t, boards, 2)
+		require.Equal(t, "board-id-1", boards[0].ID)
+		require.Equal(t, "board-id-3", boards[1].ID)
+	})
+}
+
 func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 	userID := "user-id-1"
 
 	t.Run("should return empty list if no results are found", func(t *testing.T) {
-		boards, err := store.GetBoardsForUserAndTeam(testUserID, testTeamID, true)
+		boards, err := store.GetBoardsForUserAndTeam(context.Background(), testUserID, testTeamID, true)
 		require.NoError(t, err)
 		require.Empty(t, boards)
 	})
@@ -151,7 +296,7 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 			TeamID: teamID1,
 			Type:   model.BoardTypeOpen,
 		}
-		rBoard1, _, err := store.InsertBoardWithAdmin(board1, userID)
+		rBoard1, _, err := store.InsertBoardWithAdmin(context.Background(), board1, userID)
 		require.NoError(t, err)
 
 		board2 := &model.Board{
@@ -159,7 +304,7 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 			TeamID: teamID1,
 			Type:   model.BoardTypePrivate,
 		}
-		rBoard2, _, err := store.InsertBoardWithAdmin(board2, userID)
+		rBoard2, _, err := store.InsertBoardWithAdmin(context.Background(), board2, userID)
 		require.NoError(t, err)
 
 		board3 := &model.Board{
@@ -167,7 +312,7 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 			TeamID: teamID1,
 			Type:   model.BoardTypeOpen,
 		}
-		rBoard3, err := store.InsertBoard(board3, "other-user")
+		rBoard3, err := store.InsertBoard(context.Background(), board3, "other-user")
 		require.NoError(t, err)
 
 		board4 := &model.Board{
@@ -175,7 +320,7 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 			TeamID: teamID1,
 			Type:   model.BoardTypePrivate,
 		}
-		_, err = store.InsertBoard(board4, "other-user")
+		_, err = store.InsertBoard(context.Background(), board4, "other-user")
 		require.NoError(t, err)
 
 		// team 2 boards
@@ -184,7 +329,7 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 			TeamID: teamID2,
 			Type:   model.BoardTypeOpen,
 		}
-		_, _, err = store.InsertBoardWithAdmin(board5, userID)
+		_, _, err = store.InsertBoardWithAdmin(context.Background(), board5, userID)
 		require.NoError(t, err)
 
 		board6 := &model.Board{
@@ -192,11 +337,11 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 			TeamID: teamID1,
 			Type:   model.BoardTypePrivate,
 		}
-		_, err = store.InsertBoard(board6, "other-user")
+		_, err = store.InsertBoard(context.Background(), board6, "other-user")
 		require.NoError(t, err)
 
 		t.Run("should only find the two boards that the user is a member of for team 1 plus the one open board", func(t *testing.T) {
-			boards, err := store.GetBoardsForUserAndTeam(userID, teamID1, true)
+			boards, err := store.GetBoardsForUserAndTeam(context.Background(), userID, teamID1, true)
 			require.NoError(t, err)
 			require.ElementsMatch(t, []*model.Board{
 				rBoard1,
@@ -206,7 +351,7 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 		})
 
 		t.Run("should only find the two boards that the user is a member of for team 1", func(t *testing.T) {
-			boards, err := store.GetBoardsForUserAndTeam(userID, teamID1, false)
+			boards, err := store.GetBoardsForUserAndTeam(context.Background(), userID, teamID1, false)
 			require.NoError(t, err)
 			require.ElementsMatch(t, []*model.Board{
 				rBoard1,
@@ -215,7 +360,7 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 		})
 
 		t.Run("should only find the board that the user is a member of for team 2", func(t *testing.T) {
-			boards, err := store.GetBoardsForUserAndTeam(userID, teamID2, true)
+			boards, err := store.GetBoardsForUserAndTeam(context.Background(), userID, teamID2, true)
 			require.NoError(t, err)
 			require.Len(t, boards, 1)
 			require.Equal(t, board5.ID, boards[0].ID)
@@ -223,6 +368,182 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 	})
 }
 
+func testGetBoardsForUserAndTeamWithOptions(t *testing.T, store store.Store) {
+	userID := testUserID
+	teamID := testTeamID
+
+	boards := []*model.Board{
+		{ID: "board-b", TeamID: teamID, Type: model.BoardTypeOpen, Title: "banana"},
+		{ID: "board-c", TeamID: teamID, Type: model.BoardTypePrivate, Title: "Cherry"},
+		{ID: "board-a", TeamID: teamID, Type: model.BoardTypePrivate, Title: "apple"},
+	}
+	for _, board := range boards {
+		_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+		require.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// an open board that the user isn't a member of
+	_, err := store.InsertBoard(context.Background(), &model.Board{ID: "board-d", TeamID: teamID, Type: model.BoardTypeOpen, Title: "date"}, "other-user")
+	require.NoError(t, err)
+
+	// a template of the user
+	_, _, err = store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: "template-e", TeamID: teamID, Type: model.BoardTypeOpen, Title: "elderberry", IsTemplate: true}, userID)
+	require.NoError(t, err)
+
+	// board-c gets the most recent activity
+	time.Sleep(2 * time.Millisecond)
+	InsertBlocks(t, store, []*model.Block{{ID: "card1", BoardID: "board-c", ParentID: "board-c", Type: model.TypeCard}}, userID)
+
+	getIDs := func(boards []*model.Board) []string {
+		ids := make([]string, 0, len(boards))
+		for _, board := range boards {
+			ids = append(ids, board.ID)
+		}
+		return ids
+	}
+
+	testCases := []struct {
+		name     string
+		opts     model.QueryBoardsOptions
+		expected []string
+	}{
+		{
+			name:     "title ascending",
+			opts:     model.QueryBoardsOptions{SortBy: model.BoardSortByTitle},
+			expected: []string{"board-a", "board-b", "board-c"},
+		},
+		{
+			name:     "title descending with public boards",
+			opts:     model.QueryBoardsOptions{IncludePublicBoards: true, SortBy: model.BoardSortByTitle, SortDescending: true},
+			expected: []string{"board-d", "board-c", "board-b", "board-a"},
+		},
+		{
+			name:     "created ascending",
+			opts:     model.QueryBoardsOptions{SortBy: model.BoardSortByCreated},
+			expected: []string{"board-b", "board-c", "board-a"},
+		},
+		{
+			name:     "last activity descending",
+			opts:     model.QueryBoardsOptions{SortBy: model.BoardSortByLastActivity, SortDescending: true},
+			expected: []string{"board-c", "board-a", "board-b"},
+		},
+		{
+			name:     "paginated",
+			opts:     model.QueryBoardsOptions{SortBy: model.BoardSortByTitle, Page: 1, PerPage: 2},
+			expected: []string{"board-c"},
+		},
+		{
+			name:     "private boards",
+			opts:     model.QueryBoardsOptions{Type: model.BoardTypePrivate},
+			expected: []string{"board-a", "board-c"},
+		},
+		{
+			name:     "open boards with public boards",
+			opts:     model.QueryBoardsOptions{IncludePublicBoards: true, Type: model.BoardTypeOpen},
+			expected: []string{"board-b", "board-d"},
+		},
+		{
+			name:     "including templates",
+			opts:     model.QueryBoardsOptions{IncludeTemplates: true, SortBy: model.BoardSortByTitle},
+			expected: []string{"board-a", "board-b", "board-c", "template-e"},
+		},
+		{
+			name:     "paginated without sort",
+			opts:     model.QueryBoardsOptions{IncludePublicBoards: true, Page: 1, PerPage: 2},
+			expected: []string{"board-c", "board-d"},
+		},
+		{
+			name:     "after a cursor",
+			opts:     model.QueryBoardsOptions{IncludePublicBoards: true, AfterID: "board-b", PerPage: 2},
+			expected: []string{"board-c", "board-d"},
+		},
+		{
+			name:     "after a cursor descending",
+			opts:     model.QueryBoardsOptions{AfterID: "board-c", SortDescending: true},
+			expected: []string{"board-b", "board-a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			boards, err := store.GetBoardsForUserAndTeamWithOptions(context.Background(), userID, teamID, tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, getIDs(boards))
+		})
+	}
+
+	t.Run("no sort", func(t *testing.T) {
+		boards, err := store.GetBoardsForUserAndTeamWithOptions(context.Background(), userID, teamID, model.QueryBoardsOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"board-a", "board-b", "board-c"}, getIDs(boards))
+
+		// the call without options leaves the templates out too
+		boards, err = store.GetBoardsForUserAndTeam(context.Background(), userID, teamID, true)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"board-a", "board-b", "board-c", "board-d"}, getIDs(boards))
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, opts := range []model.QueryBoardsOptions{
+			{SortBy: "invalid"},
+			{Type: "invalid"},
+			{Page: -1},
+			{AfterID: "board-a", SortBy: model.BoardSortByTitle},
+			{AfterID: "board-a", Page: 1},
+		} {
+			boards, err := store.GetBoardsForUserAndTeamWithOptions(context.Background(), userID, teamID, opts)
+			require.True(t, model.IsErrBadRequest(err), "options %+v should be rejected", opts)
+			require.Nil(t, boards)
+		}
+	})
+}
+
+func testGetBoardsAdministeredByUser(t *testing.T, store store.Store) {
+	userID := testUserID
+	teamID := testTeamID
+
+	t.Run("no boards", func(t *testing.T) {
+		boards, err := store.GetBoardsAdministeredByUser(context.Background(), userID, teamID)
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+
+	t.Run("only the boards the user administers", func(t *testing.T) {
+		for _, boardID := range []string{"board-1", "board-2", "board-3"} {
+			_, _, err := store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: boardID, TeamID: teamID, Type: model.BoardTypeOpen}, userID)
+			require.NoError(t, err)
+			time.Sleep(1 * time.Millisecond)
+		}
+
+		// a board in another team
+		_, _, err := store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: "board-4", TeamID: "other-team", Type: model.BoardTypeOpen}, userID)
+		require.NoError(t, err)
+
+		// a board where the user is only an editor
+		_, _, err = store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: "board-5", TeamID: teamID, Type: model.BoardTypeOpen}, "other-user")
+		require.NoError(t, err)
+		_, err = store.SaveMember(context.Background(), &model.BoardMember{BoardID: "board-5", UserID: userID, SchemeEditor: true}, "user-id")
+		require.NoError(t, err)
+
+		// a template
+		_, _, err = store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: "board-6", TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true}, userID)
+		require.NoError(t, err)
+
+		// a board where the user isn't an admin anymore
+		_, err = store.SaveMember(context.Background(), &model.BoardMember{BoardID: "board-2", UserID: userID, SchemeEditor: true}, "user-id")
+		require.NoError(t, err)
+
+		// a deleted board
+		require.NoError(t, store.DeleteBoard(context.Background(), "board-3", userID))
+
+		boards, err := store.GetBoardsAdministeredByUser(context.Background(), userID, teamID)
+		require.NoError(t, err)
+		require.Len(t, boards, 1)
+		require.Equal(t, "board-1", boards[0].ID)
+	})
+}
+
 func testGetBoardsInTeamByIds(t *testing.T, store store.Store) {
 	t.Run("should return err not all found if one or more of the ids are not found", func(t *testing.T) {
 		for _, boardID := range []string{"board-id-1", "board-id-2"} {
@@ -231,7 +552,7 @@ func testGetBoardsInTeamByIds(t *testing.T, store store.Store) {
 				TeamID: testTeamID,
 				Type:   model.BoardTypeOpen,
 			}
-			rBoard, _, err := store.InsertBoardWithAdmin(board, testUserID)
+			rBoard, _, err := store.InsertBoardWithAdmin(context.Background(), board, testUserID)
 			require.NoError(t, err)
 			require.NotNil(t, rBoard)
 		}
@@ -264,7 +585,7 @@ func testGetBoardsInTeamByIds(t *testing.T, store store.Store) {
 
 		for _, tc := range testCases {
 			t.Run(tc.Name, func(t *testing.T) {
-				boards, err := store.GetBoardsInTeamByIds(tc.BoardIDs, testTeamID)
+				boards, err := store.GetBoardsInTeamByIds(context.Background(), tc.BoardIDs, testTeamID)
 				if tc.ExpectedError {
 					var naf *model.ErrNotAllFound
 					require.ErrorAs(t, err, &naf)
@@ -288,7 +609,7 @@ func testInsertBoard(t *testing.T, store store.Store) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.Equal(t, board.ID, newBoard.ID)
 		require.Equal(t, newBoard.Type, model.BoardTypeOpen)
@@ -306,7 +627,7 @@ func testInsertBoard(t *testing.T, store store.Store) {
 			Type:   model.BoardTypePrivate,
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.Equal(t, board.ID, newBoard.ID)
 		require.Equal(t, newBoard.Type, model.BoardTypePrivate)
@@ -324,10 +645,10 @@ func testInsertBoard(t *testing.T, store store.Store) {
 			Properties: map[string]interface{}{"no-serializable-value": t.Run},
 		}
 
-		_, err := store.InsertBoard(board, userID)
+		_, err := store.InsertBoard(context.Background(), board, userID)
 		require.Error(t, err)
 
-		rBoard, err := store.GetBoard(board.ID)
+		rBoard, err := store.GetBoard(context.Background(), board.ID)
 		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
 		require.Nil(t, rBoard)
 	})
@@ -342,7 +663,7 @@ func testInsertBoard(t *testing.T, store store.Store) {
 		// wait to avoid hitting pk uniqueness constraint in history
 		time.Sleep(10 * time.Millisecond)
 
-		newBoard, err := store.InsertBoard(board, "user2")
+		newBoard, err := store.InsertBoard(context.Background(), board, "user2")
 		require.NoError(t, err)
 		require.Equal(t, "New title", newBoard.Title)
 		require.Equal(t, "user2", newBoard.ModifiedBy)
@@ -355,7 +676,7 @@ func testInsertBoard(t *testing.T, store store.Store) {
 			Type:  model.BoardTypeOpen,
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.Equal(t, model.BoardTypeOpen, newBoard.Type)
 
@@ -367,12 +688,89 @@ func testInsertBoard(t *testing.T, store store.Store) {
 		// wait to avoid hitting pk uniqueness constraint in history
 		time.Sleep(10 * time.Millisecond)
 
-		modifiedBoard, err := store.InsertBoard(boardUpdate, userID)
+		modifiedBoard, err := store.InsertBoard(context.Background(), boardUpdate, userID)
 		require.NoError(t, err)
 		require.Equal(t, model.BoardTypePrivate, modifiedBoard.Type)
 	})
 }
 
+func testPatchBoardExpectedUpdateAt(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	insertBoard := func(t *testing.T) *model.Board {
+		board := &model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+			Title:  "A simple title",
+		}
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
+		require.NoError(t, err)
+
+		// wait to avoid hitting pk uniqueness constraint in history
+		time.Sleep(10 * time.Millisecond)
+		return newBoard
+	}
+
+	t.Run("should apply a patch expecting the current version", func(t *testing.T) {
+		board := insertBoard(t)
+
+		newTitle := "A new title"
+		patch := &model.BoardPatch{Title: &newTitle, ExpectedUpdateAt: board.UpdateAt}
+		patchedBoard, err := store.PatchBoard(context.Background(), board.ID, patch, userID)
+		require.NoError(t, err)
+		require.Equal(t, newTitle, patchedBoard.Title)
+		require.Greater(t, patchedBoard.UpdateAt, board.UpdateAt)
+	})
+
+	t.Run("should return a conflict if the board was updated since", func(t *testing.T) {
+		board := insertBoard(t)
+
+		newTitle := "A new title"
+		_, err := store.PatchBoard(context.Background(), board.ID, &model.BoardPatch{Title: &newTitle}, userID)
+		require.NoError(t, err)
+
+		staleTitle := "A stale title"
+		patch := &model.BoardPatch{Title: &staleTitle, ExpectedUpdateAt: board.UpdateAt}
+		patchedBoard, err := store.PatchBoard(context.Background(), board.ID, patch, userID)
+		var conflictErr *model.ErrConflict
+		require.ErrorAs(t, err, &conflictErr)
+		require.True(t, model.IsErrConflict(err))
+		require.Nil(t, patchedBoard)
+
+		rBoard, err := store.GetBoard(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Equal(t, newTitle, rBoard.Title)
+	})
+
+	t.Run("only one of concurrent patches expecting the same version is applied", func(t *testing.T) {
+		board := insertBoard(t)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 10)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				title := fmt.Sprintf("Title %d", i)
+				patch := &model.BoardPatch{Title: &title, ExpectedUpdateAt: board.UpdateAt}
+				_, errs[i] = store.PatchBoard(context.Background(), board.ID, patch, userID)
+			}(i)
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			require.True(t, model.IsErrConflict(err), "unexpected error: %v", err)
+		}
+		require.Equal(t, 1, succeeded)
+	})
+}
+
 func testPatchBoard(t *testing.T, store store.Store) {
 	userID := testUserID
 
@@ -380,7 +778,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 		newTitle := "A new title"
 		patch := &model.BoardPatch{Title: &newTitle}
 
-		board, err := store.PatchBoard("nonexistent-board-id", patch, userID)
+		board, err := store.PatchBoard(context.Background(), "nonexistent-board-id", patch, userID)
 		require.Error(t, err)
 		require.Nil(t, board)
 	})
@@ -397,7 +795,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 			Description: "A simple description",
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.NotNil(t, newBoard)
 		require.Equal(t, userID, newBoard.CreatedBy)
@@ -408,12 +806,16 @@ func testPatchBoard(t *testing.T, store store.Store) {
 		newTitle := "A new title"
 		newDescription := "A new description"
 		patch := &model.BoardPatch{Title: &newTitle, Description: &newDescription}
-		patchedBoard, err := store.PatchBoard(boardID, patch, userID2)
+		patchedBoard, err := store.PatchBoard(context.Background(), boardID, patch, userID2)
 		require.NoError(t, err)
 		require.Equal(t, newTitle, patchedBoard.Title)
 		require.Equal(t, newDescription, patchedBoard.Description)
 		require.Equal(t, userID, patchedBoard.CreatedBy)
 		require.Equal(t, userID2, patchedBoard.ModifiedBy)
+
+		rBoard, err := store.GetBoard(context.Background(), boardID)
+		require.NoError(t, err)
+		require.Equal(t, userID2, rBoard.ModifiedBy)
 	})
 
 	t.Run("should correctly update the board properties", func(t *testing.T) {
@@ -429,7 +831,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 			},
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.NotNil(t, newBoard)
 		require.Equal(t, "1", newBoard.Properties["one"].(string))
@@ -442,7 +844,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 			UpdatedProperties: map[string]interface{}{"three": "3"},
 			DeletedProperties: []string{"one"},
 		}
-		patchedBoard, err := store.PatchBoard(boardID, patch, userID)
+		patchedBoard, err := store.PatchBoard(context.Background(), boardID, patch, userID)
 		require.NoError(t, err)
 		require.NotContains(t, patchedBoard.Properties, "one")
 		require.Equal(t, "2", patchedBoard.Properties["two"].(string))
@@ -458,7 +860,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.NotNil(t, newBoard)
 		require.Equal(t, newBoard.Type, model.BoardTypeOpen)
@@ -468,7 +870,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 
 		newType := model.BoardTypePrivate
 		patch := &model.BoardPatch{Type: &newType}
-		patchedBoard, err := store.PatchBoard(boardID, patch, userID)
+		patchedBoard, err := store.PatchBoard(context.Background(), boardID, patch, userID)
 		require.NoError(t, err)
 		require.Equal(t, model.BoardTypePrivate, patchedBoard.Type)
 	})
@@ -486,7 +888,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 			CardProperties: cardProperties,
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.NotNil(t, newBoard)
 		require.Equal(t, newBoard.Type, model.BoardTypeOpen)
@@ -498,7 +900,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 
 		newType := model.BoardTypePrivate
 		patch := &model.BoardPatch{Type: &newType}
-		patchedBoard, err := store.PatchBoard(boardID, patch, userID)
+		patchedBoard, err := store.PatchBoard(context.Background(), boardID, patch, userID)
 		require.NoError(t, err)
 		require.Equal(t, model.BoardTypePrivate, patchedBoard.Type)
 		require.Equal(t, properties, patchedBoard.Properties)
@@ -519,7 +921,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 			CardProperties: cardProperties,
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.NotNil(t, newBoard)
 		require.Equal(t, newBoard.Type, model.BoardTypeOpen)
@@ -534,7 +936,7 @@ func testPatchBoard(t *testing.T, store store.Store) {
 			UpdatedCardProperties: []map[string]interface{}{newProp1},
 			DeletedCardProperties: []string{"prop2"},
 		}
-		patchedBoard, err := store.PatchBoard(boardID, patch, userID)
+		patchedBoard, err := store.PatchBoard(context.Background(), boardID, patch, userID)
 		require.NoError(t, err)
 		require.ElementsMatch(t, expectedCardProperties, patchedBoard.CardProperties)
 	})
@@ -544,7 +946,7 @@ func testDeleteBoard(t *testing.T, store store.Store) {
 	userID := testUserID
 
 	t.Run("should return an error if the board doesn't exist", func(t *testing.T) {
-		require.Error(t, store.DeleteBoard("nonexistent-board-id", userID))
+		require.Error(t, store.DeleteBoard(context.Background(), "nonexistent-board-id", userID))
 	})
 
 	t.Run("should correctly delete the board", func(t *testing.T) {
@@ -556,20 +958,20 @@ func testDeleteBoard(t *testing.T, store store.Store) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.NotNil(t, newBoard)
 
-		rBoard, err := store.GetBoard(boardID)
+		rBoard, err := store.GetBoard(context.Background(), boardID)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard)
 
 		// wait to avoid hitting pk uniqueness constraint in history
 		time.Sleep(10 * time.Millisecond)
 
-		require.NoError(t, store.DeleteBoard(boardID, userID))
+		require.NoError(t, store.DeleteBoard(context.Background(), boardID, userID))
 
-		r2Board, err := store.GetBoard(boardID)
+		r2Board, err := store.GetBoard(context.Background(), boardID)
 		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
 		require.Nil(t, r2Board)
 	})
@@ -587,7 +989,7 @@ func testInsertBoardWithAdmin(t *testing.T, store store.Store) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		newBoard, newMember, err := store.InsertBoardWithAdmin(board, userID)
+		newBoard, newMember, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.NotNil(t, newBoard)
 		require.Equal(t, userID, newBoard.CreatedBy)
@@ -611,18 +1013,18 @@ func testSaveMember(t *testing.T, store store.Store) {
 			SchemeAdmin: true,
 		}
 
-		memberHistory, err := store.GetBoardMemberHistory(boardID, userID, 0)
+		memberHistory, err := store.GetBoardMemberHistory(context.Background(), boardID, userID, 0)
 		require.NoError(t, err)
 		initialMemberHistory := len(memberHistory)
 
-		nbm, err := store.SaveMember(bm)
+		nbm, err := store.SaveMember(context.Background(), bm, "user-id")
 		require.NoError(t, err)
 		require.Equal(t, userID, nbm.UserID)
 		require.Equal(t, boardID, nbm.BoardID)
 
 		require.True(t, nbm.SchemeAdmin)
 
-		memberHistory, err = store.GetBoardMemberHistory(boardID, userID, 0)
+		memberHistory, err = store.GetBoardMemberHistory(context.Background(), boardID, userID, 0)
 		require.NoError(t, err)
 		require.Len(t, memberHistory, initialMemberHistory+1)
 	})
@@ -635,11 +1037,11 @@ func testSaveMember(t *testing.T, store store.Store) {
 			SchemeViewer: true,
 		}
 
-		memberHistory, err := store.GetBoardMemberHistory(boardID, userID, 0)
+		memberHistory, err := store.GetBoardMemberHistory(context.Background(), boardID, userID, 0)
 		require.NoError(t, err)
 		initialMemberHistory := len(memberHistory)
 
-		nbm, err := store.SaveMember(bm)
+		nbm, err := store.SaveMember(context.Background(), bm, "user-id")
 		require.NoError(t, err)
 		require.Equal(t, userID, nbm.UserID)
 		require.Equal(t, boardID, nbm.BoardID)
@@ -648,102 +1050,537 @@ func testSaveMember(t *testing.T, store store.Store) {
 		require.True(t, nbm.SchemeEditor)
 		require.True(t, nbm.SchemeViewer)
 
-		memberHistory, err = store.GetBoardMemberHistory(boardID, userID, 0)
+		memberHistory, err = store.GetBoardMemberHistory(context.Background(), boardID, userID, 0)
 		require.NoError(t, err)
 		require.Len(t, memberHistory, initialMemberHistory)
 	})
 
 	t.Run("should return empty list if no results are found", func(t *testing.T) {
-		memberHistory, err := store.GetBoardMemberHistory(boardID, "nonexistent-user", 0)
+		memberHistory, err := store.GetBoardMemberHistory(context.Background(), boardID, "nonexistent-user", 0)
 		require.NoError(t, err)
 		require.Empty(t, memberHistory)
 	})
 }
 
-func testGetMemberForBoard(t *testing.T, store store.Store) {
-	userID := testUserID
-	boardID := testBoardID
+func testSaveMembers(t *testing.T, store store.Store) {
+	for _, boardID := range []string{"board-id-1", "board-id-2"} {
+		_, err := store.InsertBoard(context.Background(), &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}, testUserID)
+		require.NoError(t, err)
+	}
 
-	t.Run("should return an error not found for nonexisting membership", func(t *testing.T) {
-		bm, err := store.GetMemberForBoard(boardID, userID)
-		var nf *model.ErrNotFound
-		require.ErrorAs(t, err, &nf)
-		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
-		require.Nil(t, bm)
+	t.Run("empty batch", func(t *testing.T) {
+		members, err := store.SaveMembers(context.Background(), nil, "user-id")
+		require.NoError(t, err)
+		require.Empty(t, members)
 	})
 
-	t.Run("should return the membership if exists", func(t *testing.T) {
-		bm := &model.BoardMember{
-			UserID:      userID,
-			BoardID:     boardID,
-			SchemeAdmin: true,
-		}
+	t.Run("should create and update members in a batch", func(t *testing.T) {
+		_, err := store.SaveMember(context.Background(), &model.BoardMember{BoardID: "board-id-1", UserID: "user-1", SchemeViewer: true}, "user-id")
+		require.NoError(t, err)
 
-		nbm, err := store.SaveMember(bm)
+		members, err := store.SaveMembers(context.Background(), []*model.BoardMember{
+			{BoardID: "board-id-1", UserID: "user-1", SchemeEditor: true},
+			{BoardID: "board-id-1", UserID: "user-2", SchemeViewer: true},
+			{BoardID: "board-id-2", UserID: "user-1", SchemeAdmin: true},
+		}, "user-id")
 		require.NoError(t, err)
-		require.NotNil(t, nbm)
+		require.Len(t, members, 3)
+
+		require.Equal(t, "user-1", members[0].UserID)
+		require.True(t, members[0].SchemeEditor)
+		require.Equal(t, string(model.BoardRoleEditor), members[0].Roles)
+		require.Equal(t, "user-2", members[1].UserID)
+		require.Equal(t, string(model.BoardRoleViewer), members[1].Roles)
+		require.Equal(t, "board-id-2", members[2].BoardID)
+		require.True(t, members[2].SchemeAdmin)
 
-		rbm, err := store.GetMemberForBoard(boardID, userID)
+		member, err := store.GetMemberForBoard(context.Background(), "board-id-1", "user-1")
 		require.NoError(t, err)
-		require.NotNil(t, rbm)
-		require.Equal(t, userID, rbm.UserID)
-		require.Equal(t, boardID, rbm.BoardID)
-		require.True(t, rbm.SchemeAdmin)
-	})
-}
+		require.True(t, member.SchemeEditor)
+		require.False(t, member.SchemeViewer)
 
-func testGetMembersForBoard(t *testing.T, store store.Store) {
-	t.Run("should return empty list if there are no members on a board", func(t *testing.T) {
-		members, err := store.GetMembersForBoard(testBoardID)
+		// only the new members get a history entry
+		history, err := store.GetBoardMemberHistory(context.Background(), "board-id-1", "user-1", 0)
 		require.NoError(t, err)
-		require.Empty(t, members)
+		require.Len(t, history, 1)
+		history, err = store.GetBoardMemberHistory(context.Background(), "board-id-1", "user-2", 0)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
 	})
 
-	t.Run("should return the members of the board", func(t *testing.T) {
-		boardID1 := "board-id-1"
-		boardID2 := "board-id-2"
-
-		userID1 := "user-id-11"
-		userID2 := "user-id-12"
-		userID3 := "user-id-13"
-
-		bm1 := &model.BoardMember{BoardID: boardID1, UserID: userID1, SchemeAdmin: true}
-		_, err1 := store.SaveMember(bm1)
-		require.NoError(t, err1)
+	t.Run("duplicates in the batch are saved once, the last one winning", func(t *testing.T) {
+		members, err := store.SaveMembers(context.Background(), []*model.BoardMember{
+			{BoardID: "board-id-2", UserID: "user-3", SchemeViewer: true},
+			{BoardID: "board-id-2", UserID: "user-4", SchemeViewer: true},
+			{BoardID: "board-id-2", UserID: "user-3", SchemeAdmin: true},
+		}, "user-id")
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		require.Equal(t, "user-3", members[0].UserID)
+		require.True(t, members[0].SchemeAdmin)
+		require.Equal(t, "user-4", members[1].UserID)
 
-		bm2 := &model.BoardMember{BoardID: boardID1, UserID: userID2, SchemeEditor: true}
-		_, err2 := store.SaveMember(bm2)
-		require.NoError(t, err2)
+		history, err := store.GetBoardMemberHistory(context.Background(), "board-id-2", "user-3", 0)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+	})
 
-		bm3 := &model.BoardMember{BoardID: boardID2, UserID: userID3, SchemeAdmin: true}
-		_, err3 := store.SaveMember(bm3)
-		require.NoError(t, err3)
+	t.Run("a nonexistent board fails the whole batch", func(t *testing.T) {
+		_, err := store.SaveMembers(context.Background(), []*model.BoardMember{
+			{BoardID: "board-id-1", UserID: "user-5", SchemeViewer: true},
+			{BoardID: "nonexistent-board", UserID: "user-5", SchemeViewer: true},
+		}, "user-id")
+		var boardErr *model.ErrMemberBoardNotFound
+		require.ErrorAs(t, err, &boardErr)
+		require.Equal(t, "nonexistent-board", boardErr.BoardID)
+		require.True(t, model.IsErrNotFound(err))
+
+		_, err = store.GetMemberForBoard(context.Background(), "board-id-1", "user-5")
+		require.True(t, model.IsErrNotFound(err))
+	})
 
-		getMemberIDs := func(members []*model.BoardMember) []string {
-			ids := make([]string, len(members))
-			for i, member := range members {
-				ids[i] = member.UserID
-			}
-			return ids
+	t.Run("large batch", func(t *testing.T) {
+		batch := []*model.BoardMember{}
+		for i := 0; i < 250; i++ {
+			batch = append(batch, &model.BoardMember{BoardID: "board-id-1", UserID: fmt.Sprintf("batch-user-%d", i), SchemeEditor: true})
 		}
 
-		board1Members, err := store.GetMembersForBoard(boardID1)
+		members, err := store.SaveMembers(context.Background(), batch, "user-id")
 		require.NoError(t, err)
-		require.Len(t, board1Members, 2)
-		require.ElementsMatch(t, []string{userID1, userID2}, getMemberIDs(board1Members))
+		require.Len(t, members, 250)
 
-		board2Members, err := store.GetMembersForBoard(boardID2)
+		count, err := store.GetBoardMemberCount(context.Background(), "board-id-1")
 		require.NoError(t, err)
-		require.Len(t, board2Members, 1)
-		require.ElementsMatch(t, []string{userID3}, getMemberIDs(board2Members))
+		require.GreaterOrEqual(t, count, 250)
 	})
 }
 
-func testGetMembersForUser(t *testing.T, store store.Store) {
-	t.Run("should return empty list if there are no memberships for a user", func(t *testing.T) {
-		members, err := store.GetMembersForUser(testUserID)
-		require.NoError(t, err)
-		require.Empty(t, members)
+func testSaveMemberWithLimit(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	_, err := store.InsertBoard(context.Background(), &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}, testUserID)
+	require.NoError(t, err)
+
+	t.Run("should fail for a nonexistent board", func(t *testing.T) {
+		_, err := store.SaveMemberWithLimit(context.Background(), &model.BoardMember{UserID: "user-1", BoardID: "nonexistent-board", SchemeViewer: true}, 2, "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("should save members up to the limit", func(t *testing.T) {
+		for _, userID := range []string{"user-1", "user-2"} {
+			_, err := store.SaveMemberWithLimit(context.Background(), &model.BoardMember{UserID: userID, BoardID: boardID, SchemeViewer: true}, 2, "user-id")
+			require.NoError(t, err)
+		}
+
+		_, err := store.SaveMemberWithLimit(context.Background(), &model.BoardMember{UserID: "user-3", BoardID: boardID, SchemeViewer: true}, 2, "user-id")
+		require.ErrorIs(t, err, model.ErrBoardMemberLimit)
+
+		count, err := store.GetBoardMemberCount(context.Background(), boardID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("should allow updating an existing member when the limit is reached", func(t *testing.T) {
+		bm, err := store.SaveMemberWithLimit(context.Background(), &model.BoardMember{UserID: "user-1", BoardID: boardID, SchemeEditor: true}, 2, "user-id")
+		require.NoError(t, err)
+		require.True(t, bm.SchemeEditor)
+
+		member, err := store.GetMemberForBoard(context.Background(), boardID, "user-1")
+		require.NoError(t, err)
+		require.True(t, member.SchemeEditor)
+	})
+
+	t.Run("a zero limit should mean unlimited", func(t *testing.T) {
+		_, err := store.SaveMemberWithLimit(context.Background(), &model.BoardMember{UserID: "user-3", BoardID: boardID, SchemeViewer: true}, 0, "user-id")
+		require.NoError(t, err)
+
+		count, err := store.GetBoardMemberCount(context.Background(), boardID)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("concurrent additions should not exceed the limit", func(t *testing.T) {
+		otherBoardID := "concurrent-board"
+		limit := 3
+		_, err := store.InsertBoard(context.Background(), &model.Board{ID: otherBoardID, TeamID: testTeamID, Type: model.BoardTypeOpen}, testUserID)
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				bm := &model.BoardMember{UserID: fmt.Sprintf("user-%d", i), BoardID: otherBoardID, SchemeViewer: true}
+				_, _ = store.SaveMemberWithLimit(context.Background(), bm, limit, "user-id")
+			}(i)
+		}
+		wg.Wait()
+
+		count, err := store.GetBoardMemberCount(context.Background(), otherBoardID)
+		require.NoError(t, err)
+		require.Equal(t, limit, count)
+	})
+}
+
+func testGetBoardMemberCount(t *testing.T, store store.Store) {
+	t.Run("should return zero for a board without members", func(t *testing.T) {
+		count, err := store.GetBoardMemberCount(context.Background(), "nonexistent-board")
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+
+	t.Run("should count the board members", func(t *testing.T) {
+		for _, userID := range []string{"user-1", "user-2", "user-3"} {
+			_, err := store.SaveMember(context.Background(), &model.BoardMember{UserID: userID, BoardID: testBoardID, SchemeViewer: true}, "user-id")
+			require.NoError(t, err)
+		}
+
+		count, err := store.GetBoardMemberCount(context.Background(), testBoardID)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		_, err = store.DeleteMember(context.Background(), testBoardID, "user-2", "user-id")
+		require.NoError(t, err)
+
+		count, err = store.GetBoardMemberCount(context.Background(), testBoardID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+}
+
+func testGetMemberForBoard(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	t.Run("should return an error not found for nonexisting membership", func(t *testing.T) {
+		bm, err := store.GetMemberForBoard(context.Background(), boardID, userID)
+		var nf *model.ErrNotFound
+		require.ErrorAs(t, err, &nf)
+		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
+		require.Nil(t, bm)
+	})
+
+	t.Run("should return the membership if exists", func(t *testing.T) {
+		bm := &model.BoardMember{
+			UserID:      userID,
+			BoardID:     boardID,
+			SchemeAdmin: true,
+		}
+
+		nbm, err := store.SaveMember(context.Background(), bm, "user-id")
+		require.NoError(t, err)
+		require.NotNil(t, nbm)
+
+		rbm, err := store.GetMemberForBoard(context.Background(), boardID, userID)
+		require.NoError(t, err)
+		require.NotNil(t, rbm)
+		require.Equal(t, userID, rbm.UserID)
+		require.Equal(t, boardID, rbm.BoardID)
+		require.True(t, rbm.SchemeAdmin)
+	})
+}
+
+func testGetMembersForBoard(t *testing.T, store store.Store) {
+	t.Run("should return empty list if there are no members on a board", func(t *testing.T) {
+		members, err := store.GetMembersForBoard(context.Background(), testBoardID)
+		require.NoError(t, err)
+		require.Empty(t, members)
+	})
+
+	t.Run("should return the members of the board", func(t *testing.T) {
+		boardID1 := "board-id-1"
+		boardID2 := "board-id-2"
+
+		userID1 := "user-id-11"
+		userID2 := "user-id-12"
+		userID3 := "user-id-13"
+
+		bm1 := &model.BoardMember{BoardID: boardID1, UserID: userID1, SchemeAdmin: true}
+		_, err1 := store.SaveMember(context.Background(), bm1, "user-id")
+		require.NoError(t, err1)
+
+		bm2 := &model.BoardMember{BoardID: boardID1, UserID: userID2, SchemeEditor: true}
+		_, err2 := store.SaveMember(context.Background(), bm2, "user-id")
+		require.NoError(t, err2)
+
+		bm3 := &model.BoardMember{BoardID: boardID2, UserID: userID3, SchemeAdmin: true}
+		_, err3 := store.SaveMember(context.Background(), bm3, "user-id")
+		require.NoError(t, err3)
+
+		getMemberIDs := func(members []*model.BoardMember) []string {
+			ids := make([]string, len(members))
+			for i, member := range members {
+				ids[i] = member.UserID
+			}
+			return ids
+		}
+
+		board1Members, err := store.GetMembersForBoard(context.Background(), boardID1)
+		require.NoError(t, err)
+		require.Len(t, board1Members, 2)
+		require.ElementsMatch(t, []string{userID1, userID2}, getMemberIDs(board1Members))
+
+		board2Members, err := store.GetMembersForBoard(context.Background(), boardID2)
+		require.NoError(t, err)
+		require.Len(t, board2Members, 1)
+		require.ElementsMatch(t, []string{userID3}, getMemberIDs(board2Members))
+	})
+
+	t.Run("should return the members of the board a page at a time", func(t *testing.T) {
+		boardID := "board-id-3"
+		for _, userID := range []string{"user-id-23", "user-id-21", "user-id-24", "user-id-22"} {
+			_, err := store.SaveMember(context.Background(), &model.BoardMember{BoardID: boardID, UserID: userID, SchemeEditor: true}, "user-id")
+			require.NoError(t, err)
+		}
+
+		getMemberIDs := func(members []*model.BoardMember) []string {
+			ids := make([]string, len(members))
+			for i, member := range members {
+				ids[i] = member.UserID
+			}
+			return ids
+		}
+
+		opts := model.QueryBoardMembersOptions{PerPage: 3}
+		members, hasMore, err := store.GetMembersForBoardWithOptions(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.True(t, hasMore)
+		require.Equal(t, []string{"user-id-21", "user-id-22", "user-id-23"}, getMemberIDs(members))
+
+		opts.AfterUserID = members[len(members)-1].UserID
+		members, hasMore, err = store.GetMembersForBoardWithOptions(context.Background(), boardID, opts)
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Equal(t, []string{"user-id-24"}, getMemberIDs(members))
+
+		members, hasMore, err = store.GetMembersForBoardWithOptions(context.Background(), boardID, model.QueryBoardMembersOptions{})
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Len(t, members, 4)
+	})
+}
+
+func testGetMembersForUser(t *testing.T, store store.Store) {
+	t.Run("should return empty list if there are no memberships for a user", func(t *testing.T) {
+		members, err := store.GetMembersForUser(context.Background(), testUserID)
+		require.NoError(t, err)
+		require.Empty(t, members)
+	})
+}
+
+func testUpdateMemberLastViewed(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	boardIDs := []string{"board-1", "board-2", "board-3"}
+	for _, boardID := range boardIDs {
+		board := &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}
+		_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+		require.NoError(t, err)
+	}
+
+	otherTeamBoard := &model.Board{ID: "board-other-team", TeamID: "other-team", Type: model.BoardTypeOpen}
+	_, _, err := store.InsertBoardWithAdmin(context.Background(), otherTeamBoard, userID)
+	require.NoError(t, err)
+
+	recentIDs := func(t *testing.T, limit int) []string {
+		boards, err := store.GetRecentlyViewedBoards(context.Background(), userID, testTeamID, limit)
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(boards))
+		for _, board := range boards {
+			ids = append(ids, board.ID)
+		}
+		return ids
+	}
+
+	t.Run("members start without a last viewed time", func(t *testing.T) {
+		member, err := store.GetMemberForBoard(context.Background(), "board-1", userID)
+		require.NoError(t, err)
+		require.Zero(t, member.LastViewedAt)
+
+		require.Equal(t, boardIDs, recentIDs(t, 0))
+	})
+
+	t.Run("update the last viewed time", func(t *testing.T) {
+		require.NoError(t, store.UpdateMemberLastViewed(context.Background(), "board-2", userID, 1000))
+		require.NoError(t, store.UpdateMemberLastViewed(context.Background(), "board-3", userID, 2000))
+		require.NoError(t, store.UpdateMemberLastViewed(context.Background(), otherTeamBoard.ID, userID, 3000))
+
+		member, err := store.GetMemberForBoard(context.Background(), "board-3", userID)
+		require.NoError(t, err)
+		require.Equal(t, int64(2000), member.LastViewedAt)
+
+		members, err := store.GetMembersForBoard(context.Background(), "board-2")
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		require.Equal(t, int64(1000), members[0].LastViewedAt)
+
+		// boards never viewed come last
+		require.Equal(t, []string{"board-3", "board-2", "board-1"}, recentIDs(t, 0))
+		require.Equal(t, []string{"board-3", "board-2"}, recentIDs(t, 2))
+	})
+
+	t.Run("saving the member keeps the last viewed time", func(t *testing.T) {
+		_, err := store.SaveMember(context.Background(), &model.BoardMember{BoardID: "board-2", UserID: userID, SchemeEditor: true}, "user-id")
+		require.NoError(t, err)
+
+		member, err := store.GetMemberForBoard(context.Background(), "board-2", userID)
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), member.LastViewedAt)
+	})
+
+	t.Run("should fail if the user is not a member", func(t *testing.T) {
+		err := store.UpdateMemberLastViewed(context.Background(), "board-1", "not-a-member", 1000)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testUpdateMemberRole(t *testing.T, store store.Store) {
+	boardID := testBoardID
+
+	members := []*model.BoardMember{
+		{BoardID: boardID, UserID: "admin-1", SchemeAdmin: true, SchemeEditor: true, SchemeCommenter: true, SchemeViewer: true},
+		{BoardID: boardID, UserID: "editor-1", SchemeEditor: true, SchemeCommenter: true, SchemeViewer: true},
+		{BoardID: boardID, UserID: "viewer-1", SchemeViewer: true},
+	}
+	for _, member := range members {
+		_, err := store.SaveMember(context.Background(), member, "user-id")
+		require.NoError(t, err)
+	}
+
+	t.Run("saved members should have their role", func(t *testing.T) {
+		roles := map[string]string{}
+		boardMembers, err := store.GetMembersForBoard(context.Background(), boardID)
+		require.NoError(t, err)
+		for _, member := range boardMembers {
+			roles[member.UserID] = member.Roles
+		}
+		require.Equal(t, map[string]string{"admin-1": "admin", "editor-1": "editor", "viewer-1": "viewer"}, roles)
+	})
+
+	t.Run("should update the role and the scheme flags", func(t *testing.T) {
+		require.NoError(t, store.UpdateMemberRole(context.Background(), boardID, "viewer-1", string(model.BoardRoleCommenter), "user-id"))
+
+		member, err := store.GetMemberForBoard(context.Background(), boardID, "viewer-1")
+		require.NoError(t, err)
+		require.Equal(t, "commenter", member.Roles)
+		require.False(t, member.SchemeAdmin)
+		require.False(t, member.SchemeEditor)
+		require.True(t, member.SchemeCommenter)
+		require.True(t, member.SchemeViewer)
+	})
+
+	t.Run("should fail for an invalid role", func(t *testing.T) {
+		err := store.UpdateMemberRole(context.Background(), boardID, "viewer-1", "owner", "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("should fail if the user is not a member", func(t *testing.T) {
+		err := store.UpdateMemberRole(context.Background(), boardID, "not-a-member", string(model.BoardRoleEditor), "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("should not downgrade the last admin", func(t *testing.T) {
+		err := store.UpdateMemberRole(context.Background(), boardID, "admin-1", string(model.BoardRoleEditor), "user-id")
+		require.ErrorIs(t, err, model.ErrBoardMemberIsLastAdminRole)
+
+		member, err := store.GetMemberForBoard(context.Background(), boardID, "admin-1")
+		require.NoError(t, err)
+		require.True(t, member.SchemeAdmin)
+		require.Equal(t, "admin", member.Roles)
+	})
+
+	t.Run("should downgrade an admin once there is another one", func(t *testing.T) {
+		require.NoError(t, store.UpdateMemberRole(context.Background(), boardID, "editor-1", string(model.BoardRoleAdmin), "user-id"))
+		require.NoError(t, store.UpdateMemberRole(context.Background(), boardID, "admin-1", string(model.BoardRoleEditor), "user-id"))
+
+		member, err := store.GetMemberForBoard(context.Background(), boardID, "admin-1")
+		require.NoError(t, err)
+		require.False(t, member.SchemeAdmin)
+		require.True(t, member.SchemeEditor)
+		require.Equal(t, "editor", member.Roles)
+	})
+}
+
+func testDeleteMembers(t *testing.T, store store.Store) {
+	boardID := testBoardID
+
+	members := []*model.BoardMember{
+		{BoardID: boardID, UserID: "admin-1", SchemeAdmin: true},
+		{BoardID: boardID, UserID: "admin-2", SchemeAdmin: true},
+		{BoardID: boardID, UserID: "editor-1", SchemeEditor: true},
+		{BoardID: boardID, UserID: "editor-2", SchemeEditor: true},
+	}
+	for _, member := range members {
+		_, err := store.SaveMember(context.Background(), member, "user-id")
+		require.NoError(t, err)
+	}
+
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "card-1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+	}, testUserID)
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "other-card", BoardID: "other-board", ParentID: "other-board", Type: model.TypeCard},
+	}, testUserID)
+
+	for _, blockID := range []string{"card-1", "other-card"} {
+		_, err := store.CreateSubscription(context.Background(), &model.Subscription{
+			BlockType:      model.TypeCard,
+			BlockID:        blockID,
+			SubscriberType: model.SubTypeUser,
+			SubscriberID:   "editor-1",
+		})
+		require.NoError(t, err)
+	}
+
+	memberIDs := func(t *testing.T) []string {
+		members, err := store.GetMembersForBoard(context.Background(), boardID)
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(members))
+		for _, member := range members {
+			ids = append(ids, member.UserID)
+		}
+		return ids
+	}
+
+	t.Run("should fail if a user is not a member", func(t *testing.T) {
+		count, err := store.DeleteMembers(context.Background(), boardID, []string{"editor-1", "not-a-member"}, "user-id")
+		require.True(t, model.IsErrNotFound(err))
+		require.Zero(t, count)
+		require.ElementsMatch(t, []string{"admin-1", "admin-2", "editor-1", "editor-2"}, memberIDs(t))
+	})
+
+	t.Run("should fail if no admin would remain", func(t *testing.T) {
+		count, err := store.DeleteMembers(context.Background(), boardID, []string{"admin-1", "admin-2"}, "user-id")
+		require.ErrorIs(t, err, model.ErrBoardMemberIsLastAdmin)
+		require.Zero(t, count)
+		require.ElementsMatch(t, []string{"admin-1", "admin-2", "editor-1", "editor-2"}, memberIDs(t))
+	})
+
+	t.Run("should delete the members and their subscriptions to the board", func(t *testing.T) {
+		count, err := store.DeleteMembers(context.Background(), boardID, []string{"admin-1", "editor-1", "editor-1"}, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		require.ElementsMatch(t, []string{"admin-2", "editor-2"}, memberIDs(t))
+
+		subs, err := store.GetSubscriptions(context.Background(), "editor-1")
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		require.Equal(t, "other-card", subs[0].BlockID)
+
+		history, err := store.GetBoardMemberHistory(context.Background(), boardID, "editor-1", 0)
+		require.NoError(t, err)
+		actions := make([]string, 0, len(history))
+		for _, entry := range history {
+			actions = append(actions, entry.Action)
+		}
+		require.Contains(t, actions, "deleted")
+	})
+
+	t.Run("should do nothing without users", func(t *testing.T) {
+		count, err := store.DeleteMembers(context.Background(), boardID, []string{}, "user-id")
+		require.NoError(t, err)
+		require.Zero(t, count)
 	})
 }
 
@@ -752,13 +1589,15 @@ func testDeleteMember(t *testing.T, store store.Store) {
 	boardID := testBoardID
 
 	t.Run("should return nil if deleting a nonexistent member", func(t *testing.T) {
-		memberHistory, err := store.GetBoardMemberHistory(boardID, userID, 0)
+		memberHistory, err := store.GetBoardMemberHistory(context.Background(), boardID, userID, 0)
 		require.NoError(t, err)
 		initialMemberHistory := len(memberHistory)
 
-		require.NoError(t, store.DeleteMember(boardID, userID))
+		count, err := store.DeleteMember(context.Background(), boardID, userID, "user-id")
+		require.NoError(t, err)
+		require.Zero(t, count)
 
-		memberHistory, err = store.GetBoardMemberHistory(boardID, userID, 0)
+		memberHistory, err = store.GetBoardMemberHistory(context.Background(), boardID, userID, 0)
 		require.NoError(t, err)
 		require.Len(t, memberHistory, initialMemberHistory)
 	})
@@ -770,21 +1609,23 @@ func testDeleteMember(t *testing.T, store store.Store) {
 			SchemeAdmin: true,
 		}
 
-		nbm, err := store.SaveMember(bm)
+		nbm, err := store.SaveMember(context.Background(), bm, "user-id")
 		require.NoError(t, err)
 		require.NotNil(t, nbm)
 
-		memberHistory, err := store.GetBoardMemberHistory(boardID, userID, 0)
+		memberHistory, err := store.GetBoardMemberHistory(context.Background(), boardID, userID, 0)
 		require.NoError(t, err)
 		initialMemberHistory := len(memberHistory)
 
-		require.NoError(t, store.DeleteMember(boardID, userID))
+		count, err := store.DeleteMember(context.Background(), boardID, userID, "user-id")
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
 
-		rbm, err := store.GetMemberForBoard(boardID, userID)
+		rbm, err := store.GetMemberForBoard(context.Background(), boardID, userID)
 		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
 		require.Nil(t, rbm)
 
-		memberHistory, err = store.GetBoardMemberHistory(boardID, userID, 0)
+		memberHistory, err = store.GetBoardMemberHistory(context.Background(), boardID, userID, 0)
 		require.NoError(t, err)
 		require.Len(t, memberHistory, initialMemberHistory+1)
 	})
@@ -796,7 +1637,7 @@ func testSearchBoardsForUser(t *testing.T, store store.Store) {
 	userID := "user-id-1"
 
 	t.Run("should return empty if user is not a member of any board and there are no public boards on the team", func(t *testing.T) {
-		boards, err := store.SearchBoardsForUser("", userID, true)
+		boards, err := store.SearchBoardsForUser(context.Background(), "", userID, true)
 		require.NoError(t, err)
 		require.Empty(t, boards)
 	})
@@ -807,7 +1648,7 @@ func testSearchBoardsForUser(t *testing.T, store store.Store) {
 		Type:   model.BoardTypeOpen,
 		Title:  "Public Board with admin",
 	}
-	_, _, err := store.InsertBoardWithAdmin(board1, userID)
+	_, _, err := store.InsertBoardWithAdmin(context.Background(), board1, userID)
 	require.NoError(t, err)
 
 	board2 := &model.Board{
@@ -816,7 +1657,7 @@ func testSearchBoardsForUser(t *testing.T, store store.Store) {
 		Type:   model.BoardTypeOpen,
 		Title:  "Public Board",
 	}
-	_, err = store.InsertBoard(board2, userID)
+	_, err = store.InsertBoard(context.Background(), board2, userID)
 	require.NoError(t, err)
 
 	board3 := &model.Board{
@@ -825,7 +1666,7 @@ func testSearchBoardsForUser(t *testing.T, store store.Store) {
 		Type:   model.BoardTypePrivate,
 		Title:  "Private Board with admin",
 	}
-	_, _, err = store.InsertBoardWithAdmin(board3, userID)
+	_, _, err = store.InsertBoardWithAdmin(context.Background(), board3, userID)
 	require.NoError(t, err)
 
 	board4 := &model.Board{
@@ -834,7 +1675,7 @@ func testSearchBoardsForUser(t *testing.T, store store.Store) {
 		Type:   model.BoardTypePrivate,
 		Title:  "Private Board",
 	}
-	_, err = store.InsertBoard(board4, userID)
+	_, err = store.InsertBoard(context.Background(), board4, userID)
 	require.NoError(t, err)
 
 	board5 := &model.Board{
@@ -843,7 +1684,7 @@ func testSearchBoardsForUser(t *testing.T, store store.Store) {
 		Type:   model.BoardTypeOpen,
 		Title:  "Public Board with admin in team 2",
 	}
-	_, _, err = store.InsertBoardWithAdmin(board5, userID)
+	_, _, err = store.InsertBoardWithAdmin(context.Background(), board5, userID)
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -906,7 +1747,7 @@ func testSearchBoardsForUser(t *testing.T, store store.Store) {
 
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			boards, err := store.SearchBoardsForUser(tc.Term, tc.UserID, tc.IncludePublic)
+			boards, err := store.SearchBoardsForUser(context.Background(), tc.Term, tc.UserID, tc.IncludePublic)
 			require.NoError(t, err)
 
 			boardIDs := []string{}
@@ -920,10 +1761,44 @@ func testSearchBoardsForUser(t *testing.T, store store.Store) {
 
 func testSearchBoardsForUserInTeam(t *testing.T, store store.Store) {
 	t.Run("should return empty list if there are no resutls", func(t *testing.T) {
-		boards, err := store.SearchBoardsForUserInTeam("nonexistent-team-id", "", testUserID)
+		boards, err := store.SearchBoardsForUserInTeam(context.Background(), "nonexistent-team-id", "", testUserID)
 		require.NoError(t, err)
 		require.Empty(t, boards)
 	})
+
+	t.Run("should ignore case and accents of the term", func(t *testing.T) {
+		boards := []*model.Board{
+			{ID: "board-design", TeamID: testTeamID, Type: model.BoardTypeOpen, Title: "Product design"},
+			{ID: "board-cafe", TeamID: testTeamID, Type: model.BoardTypeOpen, Title: "Cafe menu"},
+			{ID: "board-other", TeamID: testTeamID, Type: model.BoardTypeOpen, Title: "Roadmap"},
+		}
+		for _, board := range boards {
+			_, err := store.InsertBoard(context.Background(), board, testUserID)
+			require.NoError(t, err)
+		}
+
+		testCases := []struct {
+			term       string
+			expectedID string
+		}{
+			{term: "DESIGN", expectedID: "board-design"},
+			{term: "pRoDuCt", expectedID: "board-design"},
+			{term: "café", expectedID: "board-cafe"},
+			{term: "CAFÉ MENU", expectedID: "board-cafe"},
+		}
+
+		for _, tc := range testCases {
+			found, err := store.SearchBoardsForUserInTeam(context.Background(), testTeamID, tc.term, testUserID)
+			require.NoError(t, err)
+			require.Len(t, found, 1, "term %q", tc.term)
+			require.Equal(t, tc.expectedID, found[0].ID, "term %q", tc.term)
+
+			found, err = store.SearchBoardsForUser(context.Background(), tc.term, testUserID, true)
+			require.NoError(t, err)
+			require.Len(t, found, 1, "term %q", tc.term)
+			require.Equal(t, tc.expectedID, found[0].ID, "term %q", tc.term)
+		}
+	})
 }
 
 func testUndeleteBoard(t *testing.T, store store.Store) {
@@ -952,24 +1827,24 @@ func testUndeleteBoard(t *testing.T, store store.Store) {
 			},
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.NotNil(t, newBoard)
 
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err = store.DeleteBoard(boardID, userID)
+		err = store.DeleteBoard(context.Background(), boardID, userID)
 		require.NoError(t, err)
 
-		board, err = store.GetBoard(boardID)
+		board, err = store.GetBoard(context.Background(), boardID)
 		require.Error(t, err)
 		require.Nil(t, board)
 
 		time.Sleep(1 * time.Millisecond)
-		err = store.UndeleteBoard(boardID, userID)
+		err = store.UndeleteBoard(context.Background(), boardID, userID)
 		require.NoError(t, err)
 
-		board, err = store.GetBoard(boardID)
+		board, err = store.GetBoard(context.Background(), boardID)
 		require.NoError(t, err)
 		require.NotNil(t, board)
 
@@ -997,46 +1872,79 @@ func testUndeleteBoard(t *testing.T, store store.Store) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		newBoard, err := store.InsertBoard(board, userID)
+		newBoard, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 		require.NotNil(t, newBoard)
 
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err = store.DeleteBoard(boardID, userID)
+		err = store.DeleteBoard(context.Background(), boardID, userID)
 		require.NoError(t, err)
 
-		board, err = store.GetBoard(boardID)
+		board, err = store.GetBoard(context.Background(), boardID)
 		require.Error(t, err)
 		require.Nil(t, board)
 
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err = store.UndeleteBoard(boardID, userID)
+		err = store.UndeleteBoard(context.Background(), boardID, userID)
 		require.NoError(t, err)
 
-		board, err = store.GetBoard(boardID)
+		board, err = store.GetBoard(context.Background(), boardID)
 		require.NoError(t, err)
 		require.NotNil(t, board)
 
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err = store.UndeleteBoard(boardID, userID)
+		err = store.UndeleteBoard(context.Background(), boardID, userID)
 		require.NoError(t, err)
 
-		board, err = store.GetBoard(boardID)
+		board, err = store.GetBoard(context.Background(), boardID)
 		require.NoError(t, err)
 		require.NotNil(t, board)
 	})
 
-	t.Run("from not existing id", func(t *testing.T) {
+	t.Run("archived board", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+
+		board := &model.Board{
+			ID:     boardID,
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+			Title:  "Archived board",
+		}
+
+		_, err := store.InsertBoard(context.Background(), board, userID)
+		require.NoError(t, err)
+
 		// Wait for not colliding the ID+insert_at key
 		time.Sleep(1 * time.Millisecond)
-		err := store.UndeleteBoard("not-exists", userID)
+		require.NoError(t, store.ArchiveBoard(context.Background(), boardID, userID))
+
+		time.Sleep(1 * time.Millisecond)
+		err = store.UndeleteBoard(context.Background(), boardID, userID)
 		require.NoError(t, err)
 
-		block, err := store.GetBoard("not-exists")
-		require.Error(t, err)
+		board, err = store.GetBoard(context.Background(), boardID)
+		require.NoError(t, err)
+		require.Equal(t, "Archived board", board.Title)
+		require.Zero(t, board.DeleteAt)
+
+		archived, err := store.GetArchivedBoards(context.Background(), testTeamID)
+		require.NoError(t, err)
+		for _, archivedBoard := range archived {
+			require.NotEqual(t, boardID, archivedBoard.ID)
+		}
+	})
+
+	t.Run("from not existing id", func(t *testing.T) {
+		// Wait for not colliding the ID+insert_at key
+		time.Sleep(1 * time.Millisecond)
+		err := store.UndeleteBoard(context.Background(), "not-exists", userID)
+		require.NoError(t, err)
+
+		block, err := store.GetBoard(context.Background(), "not-exists")
+		require.Error(t, err)
 		require.Nil(t, block)
 	})
 }
@@ -1054,7 +1962,7 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		rBoard1, err := store.InsertBoard(board, userID)
+		rBoard1, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 
 		opts := model.QueryBoardHistoryOptions{
@@ -1062,7 +1970,7 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 			Descending: false,
 		}
 
-		boards, err := store.GetBoardHistory(board.ID, opts)
+		boards, err := store.GetBoardHistory(context.Background(), board.ID, opts)
 		require.NoError(t, err)
 		require.Len(t, boards, 1)
 
@@ -1073,11 +1981,11 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 		newTitle := "Board: A new title"
 		newDescription := "A new description"
 		patch := &model.BoardPatch{Title: &newTitle, Description: &newDescription}
-		patchedBoard, err := store.PatchBoard(boardID, patch, userID2)
+		patchedBoard, err := store.PatchBoard(context.Background(), boardID, patch, userID2)
 		require.NoError(t, err)
 
 		// Updated history
-		boards, err = store.GetBoardHistory(board.ID, opts)
+		boards, err = store.GetBoardHistory(context.Background(), board.ID, opts)
 		require.NoError(t, err)
 		require.Len(t, boards, 2)
 		require.Equal(t, boards[0].Title, originalTitle)
@@ -1085,7 +1993,7 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 		require.Equal(t, boards[1].Description, newDescription)
 
 		// Check history against latest board
-		rBoard2, err := store.GetBoard(board.ID)
+		rBoard2, err := store.GetBoard(context.Background(), board.ID)
 		require.NoError(t, err)
 		require.Equal(t, rBoard2.Title, newTitle)
 		require.Equal(t, rBoard2.Title, boards[1].Title)
@@ -1101,7 +2009,7 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 
 		newTitle2 := "Board: A new title 2"
 		patch2 := &model.BoardPatch{Title: &newTitle2}
-		patchBoard2, err := store.PatchBoard(boardID, patch2, userID2)
+		patchBoard2, err := store.PatchBoard(context.Background(), boardID, patch2, userID2)
 		require.NoError(t, err)
 
 		// Updated history
@@ -1109,7 +2017,7 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 			Limit:      1,
 			Descending: true,
 		}
-		boards, err = store.GetBoardHistory(board.ID, opts)
+		boards, err = store.GetBoardHistory(context.Background(), board.ID, opts)
 		require.NoError(t, err)
 		require.Len(t, boards, 1)
 		require.Equal(t, boards[0].Title, newTitle2)
@@ -1117,7 +2025,7 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 
 		// Delete board
 		time.Sleep(10 * time.Millisecond)
-		err = store.DeleteBoard(boardID, userID)
+		err = store.DeleteBoard(context.Background(), boardID, userID)
 		require.NoError(t, err)
 
 		// Updated history after delete
@@ -1125,7 +2033,7 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 			Limit:      0,
 			Descending: true,
 		}
-		boards, err = store.GetBoardHistory(board.ID, opts)
+		boards, err = store.GetBoardHistory(context.Background(), board.ID, opts)
 		require.NoError(t, err)
 		require.Len(t, boards, 4)
 		require.NotZero(t, boards[0].UpdateAt)
@@ -1139,7 +2047,7 @@ func testGetBoardHistory(t *testing.T, store store.Store) {
 			Limit:      0,
 			Descending: false,
 		}
-		boards, err := store.GetBoardHistory("nonexistent-id", opts)
+		boards, err := store.GetBoardHistory(context.Background(), "nonexistent-id", opts)
 		require.NoError(t, err)
 		require.Len(t, boards, 0)
 	})
@@ -1149,7 +2057,7 @@ func testGetBoardCount(t *testing.T, store store.Store) {
 	userID := testUserID
 
 	t.Run("test GetBoardCount", func(t *testing.T) {
-		originalCount, err := store.GetBoardCount()
+		originalCount, err := store.GetBoardCount(context.Background())
 		require.NoError(t, err)
 
 		title := "Board: original title"
@@ -1161,11 +2069,702 @@ func testGetBoardCount(t *testing.T, store store.Store) {
 			Type:   model.BoardTypeOpen,
 		}
 
-		_, err = store.InsertBoard(board, userID)
+		_, err = store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 
-		newCount, err := store.GetBoardCount()
+		newCount, err := store.GetBoardCount(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, originalCount+1, newCount)
 	})
 }
+
+func testCountBoardsCreatedBetween(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	insertBoards := func(t *testing.T, boards ...*model.Board) {
+		for _, board := range boards {
+			board.Type = model.BoardTypeOpen
+			_, err := store.InsertBoard(context.Background(), board, userID)
+			require.NoError(t, err)
+		}
+	}
+
+	insertBoards(t, &model.Board{ID: "board-before", TeamID: testTeamID})
+	time.Sleep(10 * time.Millisecond)
+
+	start := utils.GetMillis()
+	insertBoards(t,
+		&model.Board{ID: "board-1", TeamID: testTeamID},
+		&model.Board{ID: "board-2", TeamID: testTeamID},
+		&model.Board{ID: "board-template", TeamID: testTeamID, IsTemplate: true},
+		&model.Board{ID: "board-deleted", TeamID: testTeamID},
+		&model.Board{ID: "board-other-team", TeamID: "other-team"},
+	)
+	require.NoError(t, store.DeleteBoard(context.Background(), "board-deleted", userID))
+	end := utils.GetMillis() + 1
+	time.Sleep(10 * time.Millisecond)
+
+	insertBoards(t, &model.Board{ID: "board-after", TeamID: testTeamID})
+
+	t.Run("count boards created in a team", func(t *testing.T) {
+		count, err := store.CountBoardsCreatedBetween(context.Background(), testTeamID, start, end)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), count)
+
+		count, err = store.CountBoardsCreatedBetween(context.Background(), "other-team", start, end)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+
+		count, err = store.CountBoardsCreatedBetween(context.Background(), testTeamID, 0, start)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+	})
+
+	t.Run("count boards created in all teams", func(t *testing.T) {
+		count, err := store.CountBoardsCreatedBetweenAllTeams(context.Background(), start, end)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), count)
+
+		count, err = store.CountBoardsCreatedBetweenAllTeams(context.Background(), 0, utils.GetMillis()+1)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), count)
+	})
+}
+
+func testGetBoardsModifiedSince(t *testing.T, store store.Store) {
+	userID := testUserID
+	otherUserID := utils.NewID(utils.IDTypeUser)
+
+	unchanged := &model.Board{ID: "board-unchanged", TeamID: testTeamID, Type: model.BoardTypeOpen}
+	patched := &model.Board{ID: "board-patched", TeamID: testTeamID, Type: model.BoardTypePrivate}
+	deleted := &model.Board{ID: "board-deleted", TeamID: testTeamID, Type: model.BoardTypePrivate}
+	for _, board := range []*model.Board{unchanged, patched, deleted} {
+		_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+		require.NoError(t, err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	since := utils.GetMillis()
+	time.Sleep(10 * time.Millisecond)
+
+	t.Run("no changes", func(t *testing.T) {
+		boards, err := store.GetBoardsModifiedSince(context.Background(), testTeamID, userID, since)
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+
+	newTitle := "Patched title"
+	_, err := store.PatchBoard(context.Background(), patched.ID, &model.BoardPatch{Title: &newTitle}, userID)
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+	err = store.DeleteBoard(context.Background(), deleted.ID, userID)
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+	_, _, err = store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: "board-new", TeamID: testTeamID, Type: model.BoardTypeOpen}, userID)
+	require.NoError(t, err)
+
+	// boards that the user can't access or from other teams
+	// shouldn't be returned
+	_, _, err = store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: "board-other-private", TeamID: testTeamID, Type: model.BoardTypePrivate}, otherUserID)
+	require.NoError(t, err)
+	_, _, err = store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: "board-other-team", TeamID: "other-team", Type: model.BoardTypeOpen}, userID)
+	require.NoError(t, err)
+
+	t.Run("modified, deleted and new boards in update order", func(t *testing.T) {
+		boards, err := store.GetBoardsModifiedSince(context.Background(), testTeamID, userID, since)
+		require.NoError(t, err)
+		require.Len(t, boards, 3)
+
+		require.Equal(t, patched.ID, boards[0].ID)
+		require.Equal(t, newTitle, boards[0].Title)
+		require.Zero(t, boards[0].DeleteAt)
+
+		require.Equal(t, deleted.ID, boards[1].ID)
+		require.NotZero(t, boards[1].DeleteAt)
+
+		require.Equal(t, "board-new", boards[2].ID)
+		require.Zero(t, boards[2].DeleteAt)
+
+		for i := 1; i < len(boards); i++ {
+			require.LessOrEqual(t, boards[i-1].UpdateAt, boards[i].UpdateAt)
+		}
+	})
+
+	t.Run("tombstones are only returned to users with access", func(t *testing.T) {
+		boards, err := store.GetBoardsModifiedSince(context.Background(), testTeamID, otherUserID, since)
+		require.NoError(t, err)
+
+		boardIDs := []string{}
+		for _, board := range boards {
+			boardIDs = append(boardIDs, board.ID)
+		}
+		require.ElementsMatch(t, []string{"board-new", "board-other-private"}, boardIDs)
+	})
+
+	t.Run("restored boards don't return tombstones", func(t *testing.T) {
+		err := store.UndeleteBoard(context.Background(), deleted.ID, userID)
+		require.NoError(t, err)
+
+		boards, err := store.GetBoardsModifiedSince(context.Background(), testTeamID, userID, since)
+		require.NoError(t, err)
+
+		count := 0
+		for _, board := range boards {
+			if board.ID == deleted.ID {
+				count++
+				require.Zero(t, board.DeleteAt)
+			}
+		}
+		require.Equal(t, 1, count)
+	})
+}
+
+func testCreateBoardComplete(t *testing.T, store store.Store) {
+	userID := testUserID
+	now := utils.GetMillis()
+
+	category := model.Category{
+		ID:       "category_id",
+		Name:     "Category",
+		UserID:   userID,
+		TeamID:   testTeamID,
+		CreateAt: now,
+		UpdateAt: now,
+		Type:     model.CategoryTypeCustom,
+	}
+	require.NoError(t, store.CreateCategory(context.Background(), category))
+
+	t.Run("creates the board, its members and its category", func(t *testing.T) {
+		board := &model.Board{ID: "board_id_1", TeamID: testTeamID, Type: model.BoardTypePrivate}
+		extraMembers := []*model.BoardMember{
+			{UserID: "user_id_2", SchemeEditor: true},
+			{UserID: "user_id_3", SchemeViewer: true},
+			// the creator is skipped, as they're added as admin
+			{UserID: userID, SchemeViewer: true},
+		}
+
+		newBoard, members, err := store.CreateBoardComplete(context.Background(), board, userID, extraMembers, category.ID)
+		require.NoError(t, err)
+		require.Equal(t, board.ID, newBoard.ID)
+		require.Len(t, members, 3)
+		require.Equal(t, userID, members[0].UserID)
+		require.True(t, members[0].SchemeAdmin)
+
+		boardMembers, err := store.GetMembersForBoard(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Len(t, boardMembers, 3)
+
+		categoryBoards, err := store.GetUserCategoryBoards(context.Background(), userID, testTeamID)
+		require.NoError(t, err)
+		require.Len(t, categoryBoards, 1)
+		require.Equal(t, category.ID, categoryBoards[0].ID)
+		require.Contains(t, categoryBoards[0].BoardIDs, board.ID)
+	})
+
+	t.Run("without category", func(t *testing.T) {
+		board := &model.Board{ID: "board_id_2", TeamID: testTeamID, Type: model.BoardTypeOpen}
+
+		newBoard, members, err := store.CreateBoardComplete(context.Background(), board, userID, nil, "")
+		require.NoError(t, err)
+		require.NotNil(t, newBoard)
+		require.Len(t, members, 1)
+	})
+
+	t.Run("a category from another user fails before creating the board", func(t *testing.T) {
+		otherCategory := model.Category{
+			ID:       "other_category_id",
+			Name:     "Other category",
+			UserID:   "user_id_2",
+			TeamID:   testTeamID,
+			CreateAt: now,
+			UpdateAt: now,
+			Type:     model.CategoryTypeCustom,
+		}
+		require.NoError(t, store.CreateCategory(context.Background(), otherCategory))
+
+		board := &model.Board{ID: "board_id_3", TeamID: testTeamID, Type: model.BoardTypeOpen}
+		newBoard, members, err := store.CreateBoardComplete(context.Background(), board, userID, nil, otherCategory.ID)
+		require.ErrorIs(t, err, model.ErrCategoryPermissionDenied)
+		require.Nil(t, newBoard)
+		require.Nil(t, members)
+
+		_, err = store.GetBoard(context.Background(), board.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("nonexistent category", func(t *testing.T) {
+		board := &model.Board{ID: "board_id_4", TeamID: testTeamID, Type: model.BoardTypeOpen}
+		_, _, err := store.CreateBoardComplete(context.Background(), board, userID, nil, "nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+
+		_, err = store.GetBoard(context.Background(), board.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetBoardWithStats(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	board := &model.Board{ID: "board_id", TeamID: testTeamID, Type: model.BoardTypeOpen, Title: "Board"}
+	_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+	require.NoError(t, err)
+
+	_, err = store.SaveMember(context.Background(), &model.BoardMember{BoardID: board.ID, UserID: "viewer_id", SchemeViewer: true}, "user-id")
+	require.NoError(t, err)
+
+	blocks := []*model.Block{
+		{ID: "card1", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+		{ID: "card2", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+		{ID: "card-archived", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+		{ID: "text1", BoardID: board.ID, ParentID: "card1", Type: model.TypeText},
+	}
+	InsertBlocks(t, store, blocks, userID)
+	require.NoError(t, store.ArchiveCard(context.Background(), "card-archived", userID))
+
+	t.Run("admin member", func(t *testing.T) {
+		boardWithStats, err := store.GetBoardWithStats(context.Background(), board.ID, userID)
+		require.NoError(t, err)
+		require.Equal(t, board.ID, boardWithStats.ID)
+		require.Equal(t, board.Title, boardWithStats.Title)
+		require.Equal(t, model.BoardRoleAdmin, boardWithStats.Role)
+		require.Equal(t, int64(2), boardWithStats.MemberCount)
+		require.Equal(t, int64(2), boardWithStats.CardCount)
+	})
+
+	t.Run("the minimum role raises the member role", func(t *testing.T) {
+		boardWithStats, err := store.GetBoardWithStats(context.Background(), board.ID, "viewer_id")
+		require.NoError(t, err)
+		require.Equal(t, model.BoardRoleViewer, boardWithStats.Role)
+
+		minimumRole := model.BoardRoleEditor
+		_, err = store.PatchBoard(context.Background(), board.ID, &model.BoardPatch{MinimumRole: &minimumRole}, userID)
+		require.NoError(t, err)
+
+		boardWithStats, err = store.GetBoardWithStats(context.Background(), board.ID, "viewer_id")
+		require.NoError(t, err)
+		require.Equal(t, model.BoardRoleEditor, boardWithStats.Role)
+	})
+
+	t.Run("non member", func(t *testing.T) {
+		boardWithStats, err := store.GetBoardWithStats(context.Background(), board.ID, "non_member_id")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, boardWithStats)
+	})
+
+	t.Run("nonexistent board", func(t *testing.T) {
+		boardWithStats, err := store.GetBoardWithStats(context.Background(), "nonexistent", userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, boardWithStats)
+	})
+}
+
+func testValidateBoardSchema(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	board := &model.Board{
+		ID:     "board_id",
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+			{
+				"id":   "tags",
+				"name": "Tags",
+				"type": "multiSelect",
+				"options": []interface{}{
+					map[string]interface{}{"id": "tag1", "value": "Tag 1"},
+				},
+			},
+			{"id": "summary", "name": "Summary", "type": "text"},
+		},
+	}
+	_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+	require.NoError(t, err)
+
+	cardWithProperties := func(id, boardID string, properties map[string]interface{}) *model.Block {
+		return &model.Block{
+			ID:       id,
+			BoardID:  boardID,
+			ParentID: boardID,
+			Type:     model.TypeCard,
+			Fields:   map[string]interface{}{"properties": properties},
+		}
+	}
+
+	blocks := []*model.Block{
+		cardWithProperties("card-valid", board.ID, map[string]interface{}{"status": "todo", "tags": []string{"tag1"}, "summary": "text"}),
+		cardWithProperties("card-missing-option", board.ID, map[string]interface{}{"status": "removed", "tags": []string{"tag1", "tag2"}}),
+		cardWithProperties("card-type-mismatch", board.ID, map[string]interface{}{"summary": []string{"a"}}),
+		cardWithProperties("card-orphaned", board.ID, map[string]interface{}{"priority": "high"}),
+		cardWithProperties("card-other-board", "other-board", map[string]interface{}{"priority": "high"}),
+	}
+	InsertBlocks(t, store, blocks, userID)
+
+	t.Run("report inconsistencies", func(t *testing.T) {
+		report, err := store.ValidateBoardSchema(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Equal(t, board.ID, report.BoardID)
+		require.Equal(t, 4, report.CardCount)
+		require.True(t, report.HasIssues())
+
+		require.Equal(t, []model.SchemaIssue{
+			{CardID: "card-missing-option", PropertyID: "status", Value: "removed"},
+			{CardID: "card-missing-option", PropertyID: "tags", Value: "tag2"},
+		}, report.MissingOptions)
+		require.Equal(t, []model.SchemaIssue{
+			{CardID: "card-type-mismatch", PropertyID: "summary", Value: "[a]"},
+		}, report.TypeMismatches)
+		require.Equal(t, []model.SchemaIssue{
+			{CardID: "card-orphaned", PropertyID: "priority", Value: "high"},
+		}, report.OrphanedProperties)
+	})
+
+	t.Run("nonexistent board", func(t *testing.T) {
+		report, err := store.ValidateBoardSchema(context.Background(), "nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, report)
+	})
+}
+
+func testGetBoardCardProperties(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	t.Run("board without properties", func(t *testing.T) {
+		board := &model.Board{ID: "board_without_properties", TeamID: testTeamID, Type: model.BoardTypeOpen}
+		_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+		require.NoError(t, err)
+
+		properties, err := store.GetBoardCardProperties(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.NotNil(t, properties)
+		require.Empty(t, properties)
+	})
+
+	t.Run("board with properties", func(t *testing.T) {
+		board := &model.Board{
+			ID:     "board_with_properties",
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+			CardProperties: []map[string]interface{}{
+				{
+					"id":   "status",
+					"name": "Status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "todo", "value": "To do", "color": "propColorRed"},
+						map[string]interface{}{"id": "done", "value": "Done", "color": "propColorGreen"},
+					},
+				},
+				{"id": "summary", "name": "Summary", "type": "text"},
+			},
+		}
+		_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+		require.NoError(t, err)
+
+		properties, err := store.GetBoardCardProperties(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Equal(t, []model.CardProperty{
+			{
+				ID:   "status",
+				Name: "Status",
+				Type: "select",
+				Options: []model.PropDefOption{
+					{ID: "todo", Index: 0, Value: "To do", Color: "propColorRed"},
+					{ID: "done", Index: 1, Value: "Done", Color: "propColorGreen"},
+				},
+			},
+			{ID: "summary", Name: "Summary", Type: "text", Options: []model.PropDefOption{}},
+		}, properties)
+	})
+
+	t.Run("nonexistent board", func(t *testing.T) {
+		_, err := store.GetBoardCardProperties(context.Background(), "nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testSetBoardPropertyOrder(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	board := &model.Board{
+		ID:     "board_id",
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select"},
+			{"id": "summary", "name": "Summary", "type": "text"},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+		},
+	}
+	_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+	require.NoError(t, err)
+
+	propertyIDs := func(t *testing.T) []string {
+		properties, err := store.GetBoardCardProperties(context.Background(), board.ID)
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(properties))
+		for _, property := range properties {
+			ids = append(ids, property.ID)
+		}
+		return ids
+	}
+
+	t.Run("set the property order", func(t *testing.T) {
+		err := store.SetBoardPropertyOrder(context.Background(), board.ID, []string{"estimate", "status", "summary"}, "other_user")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"estimate", "status", "summary"}, propertyIDs(t))
+
+		rBoard, err := store.GetBoard(context.Background(), board.ID)
+		require.NoError(t, err)
+		require.Len(t, rBoard.CardProperties, 3)
+		require.Equal(t, "estimate", rBoard.CardProperties[0]["id"])
+		require.Equal(t, "Estimate", rBoard.CardProperties[0]["name"])
+		require.Equal(t, "other_user", rBoard.ModifiedBy)
+	})
+
+	t.Run("invalid property orders", func(t *testing.T) {
+		invalidOrders := [][]string{
+			{"estimate", "status"},
+			{"estimate", "status", "summary", "unknown"},
+			{"estimate", "status", "unknown"},
+			{"estimate", "status", "status"},
+		}
+		for _, order := range invalidOrders {
+			err := store.SetBoardPropertyOrder(context.Background(), board.ID, order, userID)
+			require.True(t, model.IsErrBadRequest(err), "order %v should be rejected", order)
+		}
+
+		require.Equal(t, []string{"estimate", "status", "summary"}, propertyIDs(t))
+	})
+
+	t.Run("nonexistent board", func(t *testing.T) {
+		err := store.SetBoardPropertyOrder(context.Background(), "nonexistent", []string{}, userID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testArchiveBoard(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	archiveBoard := func(t *testing.T, boardID, title string) *model.Board {
+		board := &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen, Title: title}
+		_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+		require.NoError(t, err)
+		InsertBlocks(t, store, []*model.Block{{ID: boardID + "-card", BoardID: boardID, ParentID: boardID, Type: model.TypeCard}}, userID)
+
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, store.ArchiveBoard(context.Background(), boardID, userID))
+		return board
+	}
+
+	t.Run("archived boards are hidden from regular reads", func(t *testing.T) {
+		board := archiveBoard(t, "board-hidden", "Hidden board")
+
+		_, err := store.GetBoard(context.Background(), board.ID)
+		require.True(t, model.IsErrNotFound(err))
+
+		boards, err := store.GetBoardsForUserAndTeam(context.Background(), userID, testTeamID, true)
+		require.NoError(t, err)
+		for _, b := range boards {
+			require.NotEqual(t, board.ID, b.ID)
+		}
+
+		boards, err = store.SearchBoardsForUser(context.Background(), "Hidden", userID, true)
+		require.NoError(t, err)
+		require.Empty(t, boards)
+
+		// the board data is kept
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), board.ID, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+	})
+
+	t.Run("archived boards are listed by team", func(t *testing.T) {
+		archived, err := store.GetArchivedBoards(context.Background(), testTeamID)
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		require.Equal(t, "board-hidden", archived[0].ID)
+		require.NotZero(t, archived[0].DeleteAt)
+
+		archived, err = store.GetArchivedBoards(context.Background(), "other-team")
+		require.NoError(t, err)
+		require.Empty(t, archived)
+	})
+
+	t.Run("restore an archived board", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, store.RestoreBoard(context.Background(), "board-hidden", "other-user"))
+
+		board, err := store.GetBoard(context.Background(), "board-hidden")
+		require.NoError(t, err)
+		require.Zero(t, board.DeleteAt)
+		require.Equal(t, "other-user", board.ModifiedBy)
+
+		archived, err := store.GetArchivedBoards(context.Background(), testTeamID)
+		require.NoError(t, err)
+		require.Empty(t, archived)
+	})
+
+	t.Run("nonexistent or not archived boards", func(t *testing.T) {
+		require.True(t, model.IsErrNotFound(store.ArchiveBoard(context.Background(), "nonexistent", userID)))
+		require.True(t, model.IsErrNotFound(store.RestoreBoard(context.Background(), "nonexistent", userID)))
+		require.True(t, model.IsErrNotFound(store.RestoreBoard(context.Background(), "board-hidden", userID)))
+	})
+
+	t.Run("purge boards archived before the cutoff", func(t *testing.T) {
+		oldBoard := archiveBoard(t, "board-old", "Old board")
+		for _, blockID := range []string{oldBoard.ID, oldBoard.ID + "-card"} {
+			_, err := store.CreateSubscription(context.Background(), &model.Subscription{
+				BlockType:      model.TypeCard,
+				BlockID:        blockID,
+				SubscriberType: model.SubTypeUser,
+				SubscriberID:   userID,
+			})
+			require.NoError(t, err)
+
+			_, err = store.UpsertNotificationHint(context.Background(), &model.NotificationHint{
+				BlockType:    model.TypeCard,
+				BlockID:      blockID,
+				ModifiedByID: userID,
+			}, time.Second*15)
+			require.NoError(t, err)
+		}
+		time.Sleep(2 * time.Millisecond)
+		cutoff := time.Now()
+		time.Sleep(2 * time.Millisecond)
+		recentBoard := archiveBoard(t, "board-recent", "Recent board")
+
+		count, err := store.PurgeArchivedBoards(context.Background(), cutoff)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		archived, err := store.GetArchivedBoards(context.Background(), testTeamID)
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		require.Equal(t, recentBoard.ID, archived[0].ID)
+
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), oldBoard.ID, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+
+		history, err := store.GetBoardHistory(context.Background(), oldBoard.ID, model.QueryBoardHistoryOptions{})
+		require.NoError(t, err)
+		require.Empty(t, history)
+
+		for _, blockID := range []string{oldBoard.ID, oldBoard.ID + "-card"} {
+			_, err = store.GetSubscription(context.Background(), blockID, userID)
+			require.True(t, model.IsErrNotFound(err))
+
+			_, err = store.GetNotificationHint(context.Background(), blockID)
+			require.True(t, model.IsErrNotFound(err))
+		}
+
+		// live boards are never purged
+		count, err = store.PurgeArchivedBoards(context.Background(), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		_, err = store.GetBoard(context.Background(), "board-hidden")
+		require.NoError(t, err)
+	})
+}
+
+func testSetBoardFavorite(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	openBoard := &model.Board{ID: "board-open", TeamID: testTeamID, Type: model.BoardTypeOpen, Title: "Open"}
+	_, _, err := store.InsertBoardWithAdmin(context.Background(), openBoard, "other-user")
+	require.NoError(t, err)
+
+	privateBoard := &model.Board{ID: "board-private", TeamID: testTeamID, Type: model.BoardTypePrivate, Title: "Private"}
+	_, _, err = store.InsertBoardWithAdmin(context.Background(), privateBoard, userID)
+	require.NoError(t, err)
+
+	otherPrivateBoard := &model.Board{ID: "board-other-private", TeamID: testTeamID, Type: model.BoardTypePrivate, Title: "Other private"}
+	_, _, err = store.InsertBoardWithAdmin(context.Background(), otherPrivateBoard, "other-user")
+	require.NoError(t, err)
+
+	favoriteIDs := func(t *testing.T) []string {
+		boards, err := store.GetFavoriteBoards(context.Background(), userID, testTeamID)
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(boards))
+		for _, board := range boards {
+			ids = append(ids, board.ID)
+		}
+		return ids
+	}
+
+	t.Run("favorite accessible boards", func(t *testing.T) {
+		require.NoError(t, store.SetBoardFavorite(context.Background(), userID, privateBoard.ID, true))
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, store.SetBoardFavorite(context.Background(), userID, openBoard.ID, true))
+		// favoriting twice is a no-op
+		require.NoError(t, store.SetBoardFavorite(context.Background(), userID, openBoard.ID, true))
+
+		require.Equal(t, []string{openBoard.ID, privateBoard.ID}, favoriteIDs(t))
+
+		boards, err := store.GetFavoriteBoards(context.Background(), "other-user", testTeamID)
+		require.NoError(t, err)
+		require.Empty(t, boards)
+
+		boards, err = store.GetFavoriteBoards(context.Background(), userID, "other-team")
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+
+	t.Run("inaccessible boards can't be favorited", func(t *testing.T) {
+		err := store.SetBoardFavorite(context.Background(), userID, otherPrivateBoard.ID, true)
+		require.True(t, model.IsErrForbidden(err))
+
+		err = store.SetBoardFavorite(context.Background(), userID, "nonexistent", true)
+		require.True(t, model.IsErrForbidden(err))
+
+		require.Equal(t, []string{openBoard.ID, privateBoard.ID}, favoriteIDs(t))
+	})
+
+	t.Run("boards carry the favorite flag when requested", func(t *testing.T) {
+		opts := model.QueryBoardsOptions{IncludePublicBoards: true, SortBy: model.BoardSortByTitle, IncludeFavorite: true}
+		boards, err := store.GetBoardsForUserAndTeamWithOptions(context.Background(), userID, testTeamID, opts)
+		require.NoError(t, err)
+		require.Len(t, boards, 2)
+		for _, board := range boards {
+			require.True(t, board.Favorite, board.ID)
+		}
+
+		opts.IncludeFavorite = false
+		boards, err = store.GetBoardsForUserAndTeamWithOptions(context.Background(), userID, testTeamID, opts)
+		require.NoError(t, err)
+		require.Len(t, boards, 2)
+		for _, board := range boards {
+			require.False(t, board.Favorite, board.ID)
+		}
+	})
+
+	t.Run("unfavorite boards", func(t *testing.T) {
+		require.NoError(t, store.SetBoardFavorite(context.Background(), userID, openBoard.ID, false))
+		require.Equal(t, []string{privateBoard.ID}, favoriteIDs(t))
+
+		// unfavoriting a board that isn't a favorite is a no-op
+		require.NoError(t, store.SetBoardFavorite(context.Background(), userID, openBoard.ID, false))
+		require.NoError(t, store.SetBoardFavorite(context.Background(), userID, "nonexistent", false))
+		require.Equal(t, []string{privateBoard.ID}, favoriteIDs(t))
+	})
+
+	t.Run("deleted boards aren't returned", func(t *testing.T) {
+		require.NoError(t, store.DeleteBoard(context.Background(), privateBoard.ID, userID))
+		require.Empty(t, favoriteIDs(t))
+	})
+}
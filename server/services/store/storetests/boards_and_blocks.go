@@ -1,6 +1,7 @@
 package storetests
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -34,13 +35,23 @@ func StoreTestBoardsAndBlocksStore(t *testing.T, setup func(t *testing.T) (store
 		defer tearDown()
 		testDuplicateBoard(t, store)
 	})
+	t.Run("DuplicateBoardWithOptions", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDuplicateBoardWithOptions(t, store)
+	})
+	t.Run("MergeBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMergeBoards(t, store)
+	})
 }
 
 func testCreateBoardsAndBlocks(t *testing.T, store store.Store) {
 	teamID := testTeamID
 	userID := testUserID
 
-	boards, err := store.GetBoardsForUserAndTeam(userID, teamID, true)
+	boards, err := store.GetBoardsForUserAndTeam(context.Background(), userID, teamID, true)
 	require.Nil(t, err)
 	require.Empty(t, boards)
 
@@ -57,7 +68,7 @@ func testCreateBoardsAndBlocks(t *testing.T, store store.Store) {
 			},
 		}
 
-		bab, err := store.CreateBoardsAndBlocks(newBab, userID)
+		bab, err := store.CreateBoardsAndBlocks(context.Background(), newBab, userID)
 		require.Nil(t, err)
 		require.NotNil(t, bab)
 		require.Len(t, bab.Boards, 3)
@@ -90,7 +101,7 @@ func testCreateBoardsAndBlocks(t *testing.T, store store.Store) {
 			},
 		}
 
-		bab, members, err := store.CreateBoardsAndBlocksWithAdmin(newBab, userID)
+		bab, members, err := store.CreateBoardsAndBlocksWithAdmin(context.Background(), newBab, userID)
 		require.Nil(t, err)
 		require.NotNil(t, bab)
 		require.Len(t, bab.Boards, 3)
@@ -132,15 +143,38 @@ func testCreateBoardsAndBlocks(t *testing.T, store store.Store) {
 			},
 		}
 
-		bab, err := store.CreateBoardsAndBlocks(newBab, userID)
+		bab, err := store.CreateBoardsAndBlocks(context.Background(), newBab, userID)
 		require.Error(t, err)
 		require.Nil(t, bab)
 
-		bab, members, err := store.CreateBoardsAndBlocksWithAdmin(newBab, userID)
+		bab, members, err := store.CreateBoardsAndBlocksWithAdmin(context.Background(), newBab, userID)
 		require.Error(t, err)
 		require.Empty(t, bab)
 		require.Empty(t, members)
 	})
+
+	t.Run("with a cancelled context, nothing should be saved", func(t *testing.T) {
+		newBab := &model.BoardsAndBlocks{
+			Boards: []*model.Board{
+				{ID: "board-id-10", TeamID: teamID, Type: model.BoardTypeOpen},
+			},
+			Blocks: []*model.Block{
+				{ID: "block-id-7", BoardID: "board-id-10", Type: model.TypeCard},
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		bab, err := store.CreateBoardsAndBlocks(ctx, newBab, userID)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Nil(t, bab)
+
+		_, err = store.GetBoard(context.Background(), "board-id-10")
+		require.True(t, model.IsErrNotFound(err))
+		_, err = store.GetBlock(context.Background(), "block-id-7")
+		require.True(t, model.IsErrNotFound(err))
+	})
 }
 
 func testPatchBoardsAndBlocks(t *testing.T, store store.Store) {
@@ -161,7 +195,7 @@ func testPatchBoardsAndBlocks(t *testing.T, store store.Store) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		_, err := store.InsertBoard(board, userID)
+		_, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 
 		block := &model.Block{
@@ -169,7 +203,7 @@ func testPatchBoardsAndBlocks(t *testing.T, store store.Store) {
 			BoardID: "board-id-1",
 			Title:   initialTitle,
 		}
-		require.NoError(t, store.InsertBlock(block, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block, userID))
 
 		// apply the patches
 		pbab := &model.PatchBoardsAndBlocks{
@@ -186,16 +220,16 @@ func testPatchBoardsAndBlocks(t *testing.T, store store.Store) {
 
 		time.Sleep(10 * time.Millisecond)
 
-		bab, err := store.PatchBoardsAndBlocks(pbab, userID)
+		bab, err := store.PatchBoardsAndBlocks(context.Background(), pbab, userID)
 		require.Error(t, err)
 		require.Nil(t, bab)
 
 		// check that things have changed
-		rBoard, err := store.GetBoard("board-id-1")
+		rBoard, err := store.GetBoard(context.Background(), "board-id-1")
 		require.NoError(t, err)
 		require.Equal(t, initialTitle, rBoard.Title)
 
-		rBlock, err := store.GetBlock("block-id-1")
+		rBlock, err := store.GetBlock(context.Background(), "block-id-1")
 		require.NoError(t, err)
 		require.Equal(t, initialTitle, rBlock.Title)
 	})
@@ -213,7 +247,7 @@ func testPatchBoardsAndBlocks(t *testing.T, store store.Store) {
 			},
 		}
 
-		rBab, err := store.CreateBoardsAndBlocks(newBab, userID)
+		rBab, err := store.CreateBoardsAndBlocks(context.Background(), newBab, userID)
 		require.Nil(t, err)
 		require.NotNil(t, rBab)
 		require.Len(t, rBab.Boards, 3)
@@ -239,27 +273,27 @@ func testPatchBoardsAndBlocks(t *testing.T, store store.Store) {
 
 		time.Sleep(10 * time.Millisecond)
 
-		bab, err := store.PatchBoardsAndBlocks(pbab, userID)
+		bab, err := store.PatchBoardsAndBlocks(context.Background(), pbab, userID)
 		require.NoError(t, err)
 		require.NotNil(t, bab)
 		require.Len(t, bab.Boards, 2)
 		require.Len(t, bab.Blocks, 2)
 
 		// check that things have changed
-		board1, err := store.GetBoard("board-id-1")
+		board1, err := store.GetBoard(context.Background(), "board-id-1")
 		require.NoError(t, err)
 		require.Equal(t, newDescription, board1.Description)
 
-		board3, err := store.GetBoard("board-id-3")
+		board3, err := store.GetBoard(context.Background(), "board-id-3")
 		require.NoError(t, err)
 		require.Equal(t, newTitle, board3.Title)
 		require.Equal(t, newDescription, board3.Description)
 
-		block1, err := store.GetBlock("block-id-1")
+		block1, err := store.GetBlock(context.Background(), "block-id-1")
 		require.NoError(t, err)
 		require.Equal(t, newTitle, block1.Title)
 
-		block2, err := store.GetBlock("block-id-2")
+		block2, err := store.GetBlock(context.Background(), "block-id-2")
 		require.NoError(t, err)
 		require.Equal(t, newSchema, block2.Schema)
 	})
@@ -279,40 +313,48 @@ func testDeleteBoardsAndBlocks(t *testing.T, store store.Store) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := store.InsertBoard(newBoard1, userID)
+		board1, err := store.InsertBoard(context.Background(), newBoard1, userID)
 		require.NoError(t, err)
 
 		block1 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board1.ID,
 		}
-		require.NoError(t, store.InsertBlock(block1, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block1, userID))
 
 		block2 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board1.ID,
 		}
-		require.NoError(t, store.InsertBlock(block2, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block2, userID))
 
 		newBoard2 := &model.Board{
 			ID:     utils.NewID(utils.IDTypeBoard),
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := store.InsertBoard(newBoard2, userID)
+		board2, err := store.InsertBoard(context.Background(), newBoard2, userID)
 		require.NoError(t, err)
 
 		block3 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board2.ID,
 		}
-		require.NoError(t, store.InsertBlock(block3, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block3, userID))
+
+		newBoard3 := &model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: teamID,
+			Type:   model.BoardTypeOpen,
+		}
+		board3, err := store.InsertBoard(context.Background(), newBoard3, userID)
+		require.NoError(t, err)
 
 		block4 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
-			BoardID: "different-board-id",
+			BoardID: board3.ID,
 		}
-		require.NoError(t, store.InsertBlock(block4, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block4, userID))
 
 		dbab := &model.DeleteBoardsAndBlocks{
 			Boards: []string{board1.ID, board2.ID},
@@ -322,26 +364,26 @@ func testDeleteBoardsAndBlocks(t *testing.T, store store.Store) {
 		time.Sleep(10 * time.Millisecond)
 
 		expectedErrorMsg := fmt.Sprintf("block %s doesn't belong to any of the boards in the delete request", block4.ID)
-		require.EqualError(t, store.DeleteBoardsAndBlocks(dbab, userID), expectedErrorMsg)
+		require.EqualError(t, store.DeleteBoardsAndBlocks(context.Background(), dbab, userID), expectedErrorMsg)
 
 		// all the entities should still exist
-		rBoard1, err := store.GetBoard(board1.ID)
+		rBoard1, err := store.GetBoard(context.Background(), board1.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard1)
-		rBlock1, err := store.GetBlock(block1.ID)
+		rBlock1, err := store.GetBlock(context.Background(), block1.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock1)
-		rBlock2, err := store.GetBlock(block2.ID)
+		rBlock2, err := store.GetBlock(context.Background(), block2.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock2)
 
-		rBoard2, err := store.GetBoard(board2.ID)
+		rBoard2, err := store.GetBoard(context.Background(), board2.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard2)
-		rBlock3, err := store.GetBlock(block3.ID)
+		rBlock3, err := store.GetBlock(context.Background(), block3.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock3)
-		rBlock4, err := store.GetBlock(block4.ID)
+		rBlock4, err := store.GetBlock(context.Background(), block4.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock4)
 	})
@@ -356,40 +398,40 @@ func testDeleteBoardsAndBlocks(t *testing.T, store store.Store) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := store.InsertBoard(newBoard1, userID)
+		board1, err := store.InsertBoard(context.Background(), newBoard1, userID)
 		require.NoError(t, err)
 
 		block1 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board1.ID,
 		}
-		require.NoError(t, store.InsertBlock(block1, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block1, userID))
 
 		block2 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board1.ID,
 		}
-		require.NoError(t, store.InsertBlock(block2, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block2, userID))
 
 		newBoard2 := &model.Board{
 			ID:     utils.NewID(utils.IDTypeBoard),
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := store.InsertBoard(newBoard2, userID)
+		board2, err := store.InsertBoard(context.Background(), newBoard2, userID)
 		require.NoError(t, err)
 
 		block3 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board2.ID,
 		}
-		require.NoError(t, store.InsertBlock(block3, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block3, userID))
 
 		block4 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board2.ID,
 		}
-		require.NoError(t, store.InsertBlock(block4, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block4, userID))
 
 		dbab := &model.DeleteBoardsAndBlocks{
 			Boards: []string{board1.ID, board2.ID, "a nonexistent board ID"},
@@ -398,26 +440,26 @@ func testDeleteBoardsAndBlocks(t *testing.T, store store.Store) {
 
 		time.Sleep(10 * time.Millisecond)
 
-		require.True(t, model.IsErrNotFound(store.DeleteBoardsAndBlocks(dbab, userID)))
+		require.True(t, model.IsErrNotFound(store.DeleteBoardsAndBlocks(context.Background(), dbab, userID)))
 
 		// all the entities should still exist
-		rBoard1, err := store.GetBoard(board1.ID)
+		rBoard1, err := store.GetBoard(context.Background(), board1.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard1)
-		rBlock1, err := store.GetBlock(block1.ID)
+		rBlock1, err := store.GetBlock(context.Background(), block1.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock1)
-		rBlock2, err := store.GetBlock(block2.ID)
+		rBlock2, err := store.GetBlock(context.Background(), block2.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock2)
 
-		rBoard2, err := store.GetBoard(board2.ID)
+		rBoard2, err := store.GetBoard(context.Background(), board2.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard2)
-		rBlock3, err := store.GetBlock(block3.ID)
+		rBlock3, err := store.GetBlock(context.Background(), block3.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock3)
-		rBlock4, err := store.GetBlock(block4.ID)
+		rBlock4, err := store.GetBlock(context.Background(), block4.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock4)
 	})
@@ -432,40 +474,40 @@ func testDeleteBoardsAndBlocks(t *testing.T, store store.Store) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := store.InsertBoard(newBoard1, userID)
+		board1, err := store.InsertBoard(context.Background(), newBoard1, userID)
 		require.NoError(t, err)
 
 		block1 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board1.ID,
 		}
-		require.NoError(t, store.InsertBlock(block1, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block1, userID))
 
 		block2 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board1.ID,
 		}
-		require.NoError(t, store.InsertBlock(block2, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block2, userID))
 
 		newBoard2 := &model.Board{
 			ID:     utils.NewID(utils.IDTypeBoard),
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := store.InsertBoard(newBoard2, userID)
+		board2, err := store.InsertBoard(context.Background(), newBoard2, userID)
 		require.NoError(t, err)
 
 		block3 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board2.ID,
 		}
-		require.NoError(t, store.InsertBlock(block3, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block3, userID))
 
 		block4 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board2.ID,
 		}
-		require.NoError(t, store.InsertBlock(block4, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block4, userID))
 
 		dbab := &model.DeleteBoardsAndBlocks{
 			Boards: []string{board1.ID, board2.ID},
@@ -474,26 +516,26 @@ func testDeleteBoardsAndBlocks(t *testing.T, store store.Store) {
 
 		time.Sleep(10 * time.Millisecond)
 
-		require.True(t, model.IsErrNotFound(store.DeleteBoardsAndBlocks(dbab, userID)))
+		require.True(t, model.IsErrNotFound(store.DeleteBoardsAndBlocks(context.Background(), dbab, userID)))
 
 		// all the entities should still exist
-		rBoard1, err := store.GetBoard(board1.ID)
+		rBoard1, err := store.GetBoard(context.Background(), board1.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard1)
-		rBlock1, err := store.GetBlock(block1.ID)
+		rBlock1, err := store.GetBlock(context.Background(), block1.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock1)
-		rBlock2, err := store.GetBlock(block2.ID)
+		rBlock2, err := store.GetBlock(context.Background(), block2.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock2)
 
-		rBoard2, err := store.GetBoard(board2.ID)
+		rBoard2, err := store.GetBoard(context.Background(), board2.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBoard2)
-		rBlock3, err := store.GetBlock(block3.ID)
+		rBlock3, err := store.GetBlock(context.Background(), block3.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock3)
-		rBlock4, err := store.GetBlock(block4.ID)
+		rBlock4, err := store.GetBlock(context.Background(), block4.ID)
 		require.NoError(t, err)
 		require.NotNil(t, rBlock4)
 	})
@@ -504,40 +546,40 @@ func testDeleteBoardsAndBlocks(t *testing.T, store store.Store) {
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board1, err := store.InsertBoard(newBoard1, userID)
+		board1, err := store.InsertBoard(context.Background(), newBoard1, userID)
 		require.NoError(t, err)
 
 		block1 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board1.ID,
 		}
-		require.NoError(t, store.InsertBlock(block1, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block1, userID))
 
 		block2 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board1.ID,
 		}
-		require.NoError(t, store.InsertBlock(block2, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block2, userID))
 
 		newBoard2 := &model.Board{
 			ID:     utils.NewID(utils.IDTypeBoard),
 			TeamID: teamID,
 			Type:   model.BoardTypeOpen,
 		}
-		board2, err := store.InsertBoard(newBoard2, userID)
+		board2, err := store.InsertBoard(context.Background(), newBoard2, userID)
 		require.NoError(t, err)
 
 		block3 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board2.ID,
 		}
-		require.NoError(t, store.InsertBlock(block3, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block3, userID))
 
 		block4 := &model.Block{
 			ID:      utils.NewID(utils.IDTypeBlock),
 			BoardID: board2.ID,
 		}
-		require.NoError(t, store.InsertBlock(block4, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), block4, userID))
 
 		dbab := &model.DeleteBoardsAndBlocks{
 			Boards: []string{board1.ID, board2.ID},
@@ -546,30 +588,30 @@ func testDeleteBoardsAndBlocks(t *testing.T, store store.Store) {
 
 		time.Sleep(10 * time.Millisecond)
 
-		require.NoError(t, store.DeleteBoardsAndBlocks(dbab, userID))
+		require.NoError(t, store.DeleteBoardsAndBlocks(context.Background(), dbab, userID))
 
-		rBoard1, err := store.GetBoard(board1.ID)
+		rBoard1, err := store.GetBoard(context.Background(), board1.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, rBoard1)
-		rBlock1, err := store.GetBlock(block1.ID)
+		rBlock1, err := store.GetBlock(context.Background(), block1.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, rBlock1)
-		rBlock2, err := store.GetBlock(block2.ID)
+		rBlock2, err := store.GetBlock(context.Background(), block2.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, rBlock2)
 
-		rBoard2, err := store.GetBoard(board2.ID)
+		rBoard2, err := store.GetBoard(context.Background(), board2.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, rBoard2)
-		rBlock3, err := store.GetBlock(block3.ID)
+		rBlock3, err := store.GetBlock(context.Background(), block3.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, rBlock3)
-		rBlock4, err := store.GetBlock(block4.ID)
+		rBlock4, err := store.GetBlock(context.Background(), block4.ID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, rBlock4)
@@ -593,14 +635,14 @@ func testDuplicateBoard(t *testing.T, store store.Store) {
 		},
 	}
 
-	bab, err := store.CreateBoardsAndBlocks(newBab, userID)
+	bab, err := store.CreateBoardsAndBlocks(context.Background(), newBab, userID)
 	require.Nil(t, err)
 	require.NotNil(t, bab)
 	require.Len(t, bab.Boards, 3)
 	require.Len(t, bab.Blocks, 3)
 
 	t.Run("duplicate existing board as no template", func(t *testing.T) {
-		bab, members, err := store.DuplicateBoard("board-id-1", userID, teamID, false)
+		bab, members, err := store.DuplicateBoard(context.Background(), "board-id-1", userID, teamID, false)
 		require.NoError(t, err)
 		require.Len(t, members, 1)
 		require.Len(t, bab.Boards, 1)
@@ -610,7 +652,7 @@ func testDuplicateBoard(t *testing.T, store store.Store) {
 	})
 
 	t.Run("duplicate existing board as template", func(t *testing.T) {
-		bab, members, err := store.DuplicateBoard("board-id-1", userID, teamID, true)
+		bab, members, err := store.DuplicateBoard(context.Background(), "board-id-1", userID, teamID, true)
 		require.NoError(t, err)
 		require.Len(t, members, 1)
 		require.Len(t, bab.Boards, 1)
@@ -619,10 +661,303 @@ func testDuplicateBoard(t *testing.T, store store.Store) {
 		require.Equal(t, "", bab.Boards[0].ChannelID)
 	})
 
+	t.Run("duplicate should exclude deleted blocks", func(t *testing.T) {
+		deletedBlock := &model.Block{ID: "block-id-2a", BoardID: "board-id-2", Type: model.TypeCard, DeleteAt: utils.GetMillis()}
+		require.NoError(t, store.InsertBlock(context.Background(), deletedBlock, userID))
+
+		bab, _, err := store.DuplicateBoard(context.Background(), "board-id-2", userID, teamID, false)
+		require.NoError(t, err)
+		require.Len(t, bab.Blocks, 1)
+		require.Zero(t, bab.Blocks[0].DeleteAt)
+
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), bab.Boards[0].ID, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+	})
+
+	t.Run("duplicate should keep archived cards", func(t *testing.T) {
+		archivedCard := &model.Block{ID: "block-id-3", BoardID: "board-id-3", Type: model.TypeCard}
+		require.NoError(t, store.InsertBlock(context.Background(), archivedCard, userID))
+		require.NoError(t, store.ArchiveCard(context.Background(), "block-id-3", userID))
+
+		bab, _, err := store.DuplicateBoard(context.Background(), "board-id-3", userID, teamID, false)
+		require.NoError(t, err)
+		require.Len(t, bab.Blocks, 1)
+		require.NotZero(t, bab.Blocks[0].ArchivedAt)
+
+		cards, err := store.GetArchivedCards(context.Background(), bab.Boards[0].ID)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+	})
+
+	t.Run("duplicate should start with a clean history", func(t *testing.T) {
+		title := "patched title"
+		_, err := store.PatchBlock(context.Background(), "block-id-1", &model.BlockPatch{Title: &title}, userID)
+		require.NoError(t, err)
+		_, err = store.PatchBoard(context.Background(), "board-id-1", &model.BoardPatch{Title: &title}, userID)
+		require.NoError(t, err)
+
+		bab, _, err := store.DuplicateBoard(context.Background(), "board-id-1", userID, teamID, false)
+		require.NoError(t, err)
+
+		boardHistory, err := store.GetBoardHistory(context.Background(), bab.Boards[0].ID, model.QueryBoardHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, boardHistory, 1)
+
+		blockHistory, err := store.GetBlockHistory(context.Background(), bab.Blocks[0].ID, model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, blockHistory, 1)
+		require.Equal(t, userID, blockHistory[0].CreatedBy)
+	})
+
+	t.Run("duplicate with a cancelled context", func(t *testing.T) {
+		boards, err := store.GetBoardsForUserAndTeam(context.Background(), userID, teamID, true)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		bab, members, err := store.DuplicateBoard(ctx, "board-id-1", userID, teamID, false)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Nil(t, members)
+		require.Nil(t, bab)
+
+		boardsAfter, err := store.GetBoardsForUserAndTeam(context.Background(), userID, teamID, true)
+		require.NoError(t, err)
+		require.Len(t, boardsAfter, len(boards))
+	})
+
 	t.Run("duplicate not existing board", func(t *testing.T) {
-		bab, members, err := store.DuplicateBoard("not-existing-id", userID, teamID, false)
+		bab, members, err := store.DuplicateBoard(context.Background(), "not-existing-id", userID, teamID, false)
 		require.Error(t, err)
 		require.Nil(t, members)
 		require.Nil(t, bab)
 	})
 }
+
+func testDuplicateBoardWithOptions(t *testing.T, store store.Store) {
+	teamID := testTeamID
+	userID := testUserID
+
+	newBab := &model.BoardsAndBlocks{
+		Boards: []*model.Board{
+			{ID: "board-id-1", TeamID: teamID, Type: model.BoardTypeOpen},
+		},
+		Blocks: []*model.Block{
+			{ID: "view-id-1", BoardID: "board-id-1", ParentID: "board-id-1", Type: model.TypeView},
+			{ID: "card-id-1", BoardID: "board-id-1", ParentID: "board-id-1", Type: model.TypeCard},
+			{ID: "text-id-1", BoardID: "board-id-1", ParentID: "card-id-1", Type: model.TypeText},
+			{ID: "card-id-2", BoardID: "board-id-1", ParentID: "board-id-1", Type: model.TypeCard},
+		},
+	}
+	_, err := store.CreateBoardsAndBlocks(context.Background(), newBab, userID)
+	require.NoError(t, err)
+
+	for _, bm := range []*model.BoardMember{
+		{BoardID: "board-id-1", UserID: userID, SchemeAdmin: true},
+		{BoardID: "board-id-1", UserID: "editor-id", SchemeEditor: true},
+		{BoardID: "board-id-1", UserID: "viewer-id", SchemeViewer: true},
+	} {
+		_, err = store.SaveMember(context.Background(), bm, "user-id")
+		require.NoError(t, err)
+	}
+
+	memberRoles := func(members []*model.BoardMember) map[string]string {
+		roles := map[string]string{}
+		for _, member := range members {
+			roles[member.UserID] = string(member.SchemeRole())
+		}
+		return roles
+	}
+
+	t.Run("structure only", func(t *testing.T) {
+		bab, members, err := store.DuplicateBoardWithOptions(context.Background(), "board-id-1", "other-user-id", model.DuplicateBoardOptions{})
+		require.NoError(t, err)
+		require.Len(t, bab.Boards, 1)
+		require.Len(t, bab.Blocks, 1)
+		require.EqualValues(t, model.TypeView, bab.Blocks[0].Type)
+		require.Equal(t, map[string]string{"other-user-id": string(model.BoardRoleAdmin)}, memberRoles(members))
+	})
+
+	t.Run("cards without members", func(t *testing.T) {
+		bab, members, err := store.DuplicateBoardWithOptions(context.Background(), "board-id-1", "other-user-id", model.DuplicateBoardOptions{
+			IncludeCards: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, bab.Blocks, 4)
+		require.Len(t, members, 1)
+
+		boardMembers, err := store.GetMembersForBoard(context.Background(), bab.Boards[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"other-user-id": string(model.BoardRoleAdmin)}, memberRoles(boardMembers))
+	})
+
+	t.Run("everything including members", func(t *testing.T) {
+		bab, members, err := store.DuplicateBoardWithOptions(context.Background(), "board-id-1", "other-user-id", model.DuplicateBoardOptions{
+			IncludeCards:   true,
+			IncludeMembers: true,
+			AsTemplate:     true,
+		})
+		require.NoError(t, err)
+		require.True(t, bab.Boards[0].IsTemplate)
+		require.Len(t, bab.Blocks, 4)
+
+		expected := map[string]string{
+			"other-user-id": string(model.BoardRoleAdmin),
+			userID:          string(model.BoardRoleAdmin),
+			"editor-id":     string(model.BoardRoleEditor),
+			"viewer-id":     string(model.BoardRoleViewer),
+		}
+		require.Equal(t, expected, memberRoles(members))
+
+		boardMembers, err := store.GetMembersForBoard(context.Background(), bab.Boards[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, expected, memberRoles(boardMembers))
+	})
+
+	t.Run("the duplicating user stays admin", func(t *testing.T) {
+		_, members, err := store.DuplicateBoardWithOptions(context.Background(), "board-id-1", "editor-id", model.DuplicateBoardOptions{
+			IncludeMembers: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, string(model.BoardRoleAdmin), memberRoles(members)["editor-id"])
+		require.Len(t, members, 3)
+	})
+}
+
+func testMergeBoards(t *testing.T, store store.Store) {
+	userID := testUserID
+	sourceBoardID := "source-board"
+	targetBoardID := "target-board"
+
+	_, err := store.InsertBoard(context.Background(), &model.Board{
+		ID:     sourceBoardID,
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select"},
+			{"id": "priority", "name": "Priority", "type": "select"},
+		},
+	}, userID)
+	require.NoError(t, err)
+	_, err = store.InsertBoard(context.Background(), &model.Board{
+		ID:     targetBoardID,
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select"},
+		},
+	}, userID)
+	require.NoError(t, err)
+
+	InsertBlocks(t, store, []*model.Block{
+		{ID: "source-view", BoardID: sourceBoardID, ParentID: sourceBoardID, Type: model.TypeView},
+		{ID: "source-card", BoardID: sourceBoardID, ParentID: sourceBoardID, Type: model.TypeCard},
+		{ID: "source-text", BoardID: sourceBoardID, ParentID: "source-card", Type: model.TypeText},
+		{ID: "target-card", BoardID: targetBoardID, ParentID: targetBoardID, Type: model.TypeCard},
+	}, userID)
+
+	members := []*model.BoardMember{
+		{BoardID: sourceBoardID, UserID: "user-only-source", SchemeViewer: true},
+		{BoardID: sourceBoardID, UserID: "user-higher-source", SchemeAdmin: true, SchemeEditor: true},
+		{BoardID: sourceBoardID, UserID: "user-higher-target", SchemeViewer: true},
+		{BoardID: targetBoardID, UserID: "user-higher-source", SchemeCommenter: true},
+		{BoardID: targetBoardID, UserID: "user-higher-target", SchemeEditor: true},
+	}
+	for _, member := range members {
+		_, err := store.SaveMember(context.Background(), member, "user-id")
+		require.NoError(t, err)
+	}
+
+	blockIDs := func(t *testing.T, boardID string) []string {
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
+		require.NoError(t, err)
+
+		ids := []string{}
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	t.Run("merge a board into itself", func(t *testing.T) {
+		newIDs, err := store.MergeBoards(context.Background(), sourceBoardID, sourceBoardID, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, newIDs)
+	})
+
+	t.Run("nonexistent boards", func(t *testing.T) {
+		_, err := store.MergeBoards(context.Background(), "nonexistent-board", targetBoardID, userID)
+		require.True(t, model.IsErrNotFound(err))
+
+		_, err = store.MergeBoards(context.Background(), sourceBoardID, "nonexistent-board", userID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("boards of different teams", func(t *testing.T) {
+		_, err := store.InsertBoard(context.Background(), &model.Board{ID: "other-team-board", TeamID: "other-team", Type: model.BoardTypeOpen}, userID)
+		require.NoError(t, err)
+
+		_, err = store.MergeBoards(context.Background(), sourceBoardID, "other-team-board", userID)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("merging over the block limit", func(t *testing.T) {
+		store.SetBoardBlockLimit(3)
+		defer store.SetBoardBlockLimit(0)
+
+		_, err := store.MergeBoards(context.Background(), sourceBoardID, targetBoardID, userID)
+		var limitErr *model.ErrBlockLimitExceeded
+		require.ErrorAs(t, err, &limitErr)
+		require.ElementsMatch(t, []string{"source-view", "source-card", "source-text"}, blockIDs(t, sourceBoardID))
+		require.ElementsMatch(t, []string{"target-card"}, blockIDs(t, targetBoardID))
+	})
+
+	t.Run("merge the boards", func(t *testing.T) {
+		newIDs, err := store.MergeBoards(context.Background(), sourceBoardID, targetBoardID, userID)
+		require.NoError(t, err)
+		require.Empty(t, newIDs)
+
+		require.Empty(t, blockIDs(t, sourceBoardID))
+		require.ElementsMatch(t, []string{"source-view", "source-card", "source-text", "target-card"}, blockIDs(t, targetBoardID))
+
+		card, err := store.GetBlock(context.Background(), "source-card")
+		require.NoError(t, err)
+		require.Equal(t, targetBoardID, card.BoardID)
+		require.Equal(t, targetBoardID, card.ParentID)
+
+		text, err := store.GetBlock(context.Background(), "source-text")
+		require.NoError(t, err)
+		require.Equal(t, "source-card", text.ParentID)
+
+		history, err := store.GetBlockHistory(context.Background(), "source-card", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.NotEmpty(t, history)
+		for _, block := range history {
+			require.Equal(t, targetBoardID, block.BoardID)
+			require.Equal(t, targetBoardID, block.ParentID)
+		}
+
+		expectedRoles := map[string]model.BoardRole{
+			"user-only-source":   model.BoardRoleViewer,
+			"user-higher-source": model.BoardRoleAdmin,
+			"user-higher-target": model.BoardRoleEditor,
+		}
+		for memberID, role := range expectedRoles {
+			member, err := store.GetMemberForBoard(context.Background(), targetBoardID, memberID)
+			require.NoError(t, err)
+			require.Equal(t, role, member.SchemeRole(), "role of %s", memberID)
+		}
+
+		target, err := store.GetBoard(context.Background(), targetBoardID)
+		require.NoError(t, err)
+		require.Len(t, target.CardProperties, 2)
+		require.Equal(t, "status", target.CardProperties[0]["id"])
+		require.Equal(t, "priority", target.CardProperties[1]["id"])
+
+		archived, err := store.GetArchivedBoards(context.Background(), testTeamID)
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		require.Equal(t, sourceBoardID, archived[0].ID)
+	})
+}
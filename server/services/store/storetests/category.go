@@ -1,6 +1,7 @@
 package storetests
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -30,6 +31,31 @@ func StoreTestCategoryStore(t *testing.T, setup func(t *testing.T) (store.Store,
 		defer tearDown()
 		testGetUserCategories(t, store)
 	})
+	t.Run("GetAllCategoriesForTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetAllCategoriesForTeam(t, store)
+	})
+	t.Run("FindDuplicateCategories", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testFindDuplicateCategories(t, store)
+	})
+	t.Run("MergeCategories", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMergeCategories(t, store)
+	})
+	t.Run("ReorderCategories", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testReorderCategories(t, store)
+	})
+	t.Run("DefaultCategoryTemplates", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDefaultCategoryTemplates(t, store)
+	})
 }
 
 func testGetCreateCategory(t *testing.T, store store.Store) {
@@ -46,10 +72,10 @@ func testGetCreateCategory(t *testing.T, store store.Store) {
 			Collapsed: false,
 		}
 
-		err := store.CreateCategory(category)
+		err := store.CreateCategory(context.Background(), category)
 		assert.NoError(t, err)
 
-		createdCategory, err := store.GetCategory("category_id_1")
+		createdCategory, err := store.GetCategory(context.Background(), "category_id_1")
 		assert.NoError(t, err)
 		assert.Equal(t, "Category", createdCategory.Name)
 		assert.Equal(t, "user_id_1", createdCategory.UserID)
@@ -70,10 +96,10 @@ func testGetCreateCategory(t *testing.T, store store.Store) {
 			Collapsed: true,
 		}
 
-		err := store.CreateCategory(category)
+		err := store.CreateCategory(context.Background(), category)
 		assert.NoError(t, err)
 
-		createdCategory, err := store.GetCategory("category_id_2")
+		createdCategory, err := store.GetCategory(context.Background(), "category_id_2")
 		assert.NoError(t, err)
 		assert.Equal(t, "Category", createdCategory.Name)
 		assert.Equal(t, "user_id_1", createdCategory.UserID)
@@ -81,8 +107,23 @@ func testGetCreateCategory(t *testing.T, store store.Store) {
 		assert.Equal(t, true, createdCategory.Collapsed)
 	})
 
+	t.Run("save category with an existing ID", func(t *testing.T) {
+		now := utils.GetMillis()
+		category := model.Category{
+			ID:       "category_id_1",
+			Name:     "Duplicate",
+			UserID:   "user_id_1",
+			TeamID:   "team_id_1",
+			CreateAt: now,
+			UpdateAt: now,
+		}
+
+		err := store.CreateCategory(context.Background(), category)
+		assert.True(t, model.IsErrDuplicate(err))
+	})
+
 	t.Run("get nonexistent category", func(t *testing.T) {
-		category, err := store.GetCategory("nonexistent")
+		category, err := store.GetCategory(context.Background(), "nonexistent")
 		assert.Error(t, err)
 		var nf *model.ErrNotFound
 		assert.ErrorAs(t, err, &nf)
@@ -103,7 +144,7 @@ func testUpdateCategory(t *testing.T, store store.Store) {
 		Collapsed: false,
 	}
 
-	err := store.CreateCategory(category)
+	err := store.CreateCategory(context.Background(), category)
 	assert.NoError(t, err)
 
 	updateNow := utils.GetMillis()
@@ -118,10 +159,10 @@ func testUpdateCategory(t *testing.T, store store.Store) {
 		Collapsed: true,
 	}
 
-	err = store.UpdateCategory(updatedCategory)
+	err = store.UpdateCategory(context.Background(), updatedCategory)
 	assert.NoError(t, err)
 
-	fetchedCategory, err := store.GetCategory("category_id_1")
+	fetchedCategory, err := store.GetCategory(context.Background(), "category_id_1")
 	assert.NoError(t, err)
 	assert.Equal(t, "category_id_1", fetchedCategory.ID)
 	assert.Equal(t, "Category 1 New", fetchedCategory.Name)
@@ -129,10 +170,10 @@ func testUpdateCategory(t *testing.T, store store.Store) {
 
 	// now lets try to un-collapse the same category
 	updatedCategory.Collapsed = false
-	err = store.UpdateCategory(updatedCategory)
+	err = store.UpdateCategory(context.Background(), updatedCategory)
 	assert.NoError(t, err)
 
-	fetchedCategory, err = store.GetCategory("category_id_1")
+	fetchedCategory, err = store.GetCategory(context.Background(), "category_id_1")
 	assert.NoError(t, err)
 	assert.Equal(t, "category_id_1", fetchedCategory.ID)
 	assert.Equal(t, "Category 1 New", fetchedCategory.Name)
@@ -152,13 +193,13 @@ func testDeleteCategory(t *testing.T, store store.Store) {
 		Collapsed: false,
 	}
 
-	err := store.CreateCategory(category)
+	err := store.CreateCategory(context.Background(), category)
 	assert.NoError(t, err)
 
-	err = store.DeleteCategory("category_id_1", "user_id_1", "team_id_1")
+	err = store.DeleteCategory(context.Background(), "category_id_1", "user_id_1", "team_id_1")
 	assert.NoError(t, err)
 
-	deletedCategory, err := store.GetCategory("category_id_1")
+	deletedCategory, err := store.GetCategory(context.Background(), "category_id_1")
 	assert.NoError(t, err)
 	assert.Equal(t, "category_id_1", deletedCategory.ID)
 	assert.Equal(t, "Category 1", deletedCategory.Name)
@@ -178,7 +219,7 @@ func testGetUserCategories(t *testing.T, store store.Store) {
 		DeleteAt:  0,
 		Collapsed: false,
 	}
-	err := store.CreateCategory(category1)
+	err := store.CreateCategory(context.Background(), category1)
 	assert.NoError(t, err)
 
 	category2 := model.Category{
@@ -191,7 +232,7 @@ func testGetUserCategories(t *testing.T, store store.Store) {
 		DeleteAt:  0,
 		Collapsed: false,
 	}
-	err = store.CreateCategory(category2)
+	err = store.CreateCategory(context.Background(), category2)
 	assert.NoError(t, err)
 
 	category3 := model.Category{
@@ -204,10 +245,303 @@ func testGetUserCategories(t *testing.T, store store.Store) {
 		DeleteAt:  0,
 		Collapsed: false,
 	}
-	err = store.CreateCategory(category3)
+	err = store.CreateCategory(context.Background(), category3)
 	assert.NoError(t, err)
 
-	userCategories, err := store.GetUserCategoryBoards("user_id_1", "team_id_1")
+	userCategories, err := store.GetUserCategoryBoards(context.Background(), "user_id_1", "team_id_1")
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(userCategories))
 }
+
+func testGetAllCategoriesForTeam(t *testing.T, store store.Store) {
+	t.Run("no categories", func(t *testing.T) {
+		categories, err := store.GetAllCategoriesForTeam(context.Background(), "team_id_1")
+		assert.NoError(t, err)
+		assert.Empty(t, categories)
+	})
+
+	t.Run("categories from all users of the team", func(t *testing.T) {
+		now := utils.GetMillis()
+		categories := []model.Category{
+			{ID: "category_id_1", Name: "Category 1", UserID: "user_id_1", TeamID: "team_id_1"},
+			{ID: "category_id_2", Name: "Category 2", UserID: "user_id_2", TeamID: "team_id_1"},
+			{ID: "category_id_3", Name: "Category 3", UserID: "user_id_2", TeamID: "team_id_1"},
+			{ID: "category_id_4", Name: "Category 4", UserID: "user_id_1", TeamID: "team_id_2"},
+		}
+		for _, category := range categories {
+			category.CreateAt = now
+			category.UpdateAt = now
+			err := store.CreateCategory(context.Background(), category)
+			assert.NoError(t, err)
+		}
+
+		err := store.DeleteCategory(context.Background(), "category_id_3", "user_id_2", "team_id_1")
+		assert.NoError(t, err)
+
+		teamCategories, err := store.GetAllCategoriesForTeam(context.Background(), "team_id_1")
+		assert.NoError(t, err)
+		assert.Len(t, teamCategories, 2)
+		assert.Equal(t, "category_id_1", teamCategories[0].ID)
+		assert.Equal(t, "category_id_2", teamCategories[1].ID)
+	})
+}
+
+func createDuplicateCategories(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	categories := []model.Category{
+		{ID: "default_category_id", Name: "Boards", UserID: "user_id_1", TeamID: "team_id_1", Type: model.CategoryTypeSystem},
+		{ID: "category_id_1", Name: "Projects", UserID: "user_id_1", TeamID: "team_id_1", Type: model.CategoryTypeCustom},
+		{ID: "category_id_2", Name: "Projects", UserID: "user_id_1", TeamID: "team_id_1", Type: model.CategoryTypeCustom},
+		{ID: "category_id_3", Name: "Projects", UserID: "user_id_1", TeamID: "team_id_1", Type: model.CategoryTypeCustom},
+		{ID: "category_id_4", Name: "Other", UserID: "user_id_1", TeamID: "team_id_1", Type: model.CategoryTypeCustom},
+		{ID: "category_id_5", Name: "Projects", UserID: "user_id_2", TeamID: "team_id_1", Type: model.CategoryTypeCustom},
+		{ID: "category_id_6", Name: "Projects", UserID: "user_id_1", TeamID: "team_id_2", Type: model.CategoryTypeCustom},
+	}
+	for i, category := range categories {
+		category.CreateAt = now + int64(i)
+		category.UpdateAt = now + int64(i)
+		assert.NoError(t, store.CreateCategory(context.Background(), category))
+	}
+}
+
+func testFindDuplicateCategories(t *testing.T, store store.Store) {
+	t.Run("no categories", func(t *testing.T) {
+		duplicates, err := store.FindDuplicateCategories(context.Background(), "user_id_1", "team_id_1")
+		assert.NoError(t, err)
+		assert.Empty(t, duplicates)
+	})
+
+	t.Run("same-named categories", func(t *testing.T) {
+		createDuplicateCategories(t, store)
+		assert.NoError(t, store.DeleteCategory(context.Background(), "category_id_3", "user_id_1", "team_id_1"))
+
+		duplicates, err := store.FindDuplicateCategories(context.Background(), "user_id_1", "team_id_1")
+		assert.NoError(t, err)
+		assert.Len(t, duplicates, 1)
+		assert.Len(t, duplicates["Projects"], 2)
+		assert.Equal(t, "category_id_1", duplicates["Projects"][0].ID)
+		assert.Equal(t, "category_id_2", duplicates["Projects"][1].ID)
+	})
+}
+
+func testMergeCategories(t *testing.T, store store.Store) {
+	createDuplicateCategories(t, store)
+
+	assert.NoError(t, store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_1", "board_1"))
+	assert.NoError(t, store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_2", "board_2"))
+	assert.NoError(t, store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_2", "board_3"))
+	assert.NoError(t, store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_3", "board_4"))
+	assert.NoError(t, store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "default_category_id", "board_5"))
+
+	t.Run("merge into itself", func(t *testing.T) {
+		err := store.MergeCategories(context.Background(), "user_id_1", "category_id_1", []string{"category_id_1"})
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("merge another user's category", func(t *testing.T) {
+		err := store.MergeCategories(context.Background(), "user_id_1", "category_id_1", []string{"category_id_5"})
+		assert.ErrorIs(t, err, model.ErrCategoryPermissionDenied)
+
+		err = store.MergeCategories(context.Background(), "user_id_2", "category_id_1", []string{"category_id_2"})
+		assert.ErrorIs(t, err, model.ErrCategoryPermissionDenied)
+	})
+
+	t.Run("merge a category of another team", func(t *testing.T) {
+		err := store.MergeCategories(context.Background(), "user_id_1", "category_id_1", []string{"category_id_6"})
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("merge the default category", func(t *testing.T) {
+		err := store.MergeCategories(context.Background(), "user_id_1", "category_id_1", []string{"default_category_id"})
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("failed merges change nothing", func(t *testing.T) {
+		err := store.MergeCategories(context.Background(), "user_id_1", "category_id_1", []string{"category_id_2", "nonexistent_category"})
+		assert.True(t, model.IsErrNotFound(err))
+
+		assert.ElementsMatch(t, []string{"board_1"}, getCategoryBoardIDs(t, store, "category_id_1"))
+		assert.ElementsMatch(t, []string{"board_2", "board_3"}, getCategoryBoardIDs(t, store, "category_id_2"))
+	})
+
+	t.Run("merge categories", func(t *testing.T) {
+		err := store.MergeCategories(context.Background(), "user_id_1", "category_id_1", []string{"category_id_2", "category_id_3"})
+		assert.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"board_1", "board_2", "board_3", "board_4"}, getCategoryBoardIDs(t, store, "category_id_1"))
+		assert.ElementsMatch(t, []string{"board_5"}, getCategoryBoardIDs(t, store, "default_category_id"))
+
+		for _, categoryID := range []string{"category_id_2", "category_id_3"} {
+			category, err := store.GetCategory(context.Background(), categoryID)
+			assert.NoError(t, err)
+			assert.NotZero(t, category.DeleteAt)
+		}
+
+		duplicates, err := store.FindDuplicateCategories(context.Background(), "user_id_1", "team_id_1")
+		assert.NoError(t, err)
+		assert.Empty(t, duplicates)
+	})
+}
+
+func testReorderCategories(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	for _, categoryID := range []string{"category_id_1", "category_id_2", "category_id_3"} {
+		category := model.Category{
+			ID:       categoryID,
+			Name:     categoryID,
+			UserID:   "user_id_1",
+			TeamID:   "team_id_1",
+			CreateAt: now,
+			UpdateAt: now,
+			Type:     model.CategoryTypeCustom,
+		}
+		assert.NoError(t, store.CreateCategory(context.Background(), category))
+	}
+
+	categoryIDs := func(t *testing.T) []string {
+		categoryBoards, err := store.GetUserCategoryBoards(context.Background(), "user_id_1", "team_id_1")
+		assert.NoError(t, err)
+
+		ids := []string{}
+		for _, categoryBoard := range categoryBoards {
+			ids = append(ids, categoryBoard.ID)
+		}
+		return ids
+	}
+
+	t.Run("new categories are added at the end", func(t *testing.T) {
+		assert.Equal(t, []string{"category_id_1", "category_id_2", "category_id_3"}, categoryIDs(t))
+
+		category, err := store.GetCategory(context.Background(), "category_id_3")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, category.SortOrder)
+	})
+
+	t.Run("reorder the categories", func(t *testing.T) {
+		newOrder := []string{"category_id_3", "category_id_1", "category_id_2"}
+		assert.NoError(t, store.ReorderCategories(context.Background(), "user_id_1", "team_id_1", newOrder))
+		assert.Equal(t, newOrder, categoryIDs(t))
+
+		// submitting the same order again changes nothing
+		assert.NoError(t, store.ReorderCategories(context.Background(), "user_id_1", "team_id_1", newOrder))
+		assert.Equal(t, newOrder, categoryIDs(t))
+	})
+
+	t.Run("invalid orders", func(t *testing.T) {
+		invalidOrders := [][]string{
+			{"category_id_3", "category_id_1"},
+			{"category_id_3", "category_id_1", "category_id_1"},
+			{"category_id_3", "category_id_1", "category_id_2", "unknown"},
+		}
+		for _, order := range invalidOrders {
+			err := store.ReorderCategories(context.Background(), "user_id_1", "team_id_1", order)
+			assert.True(t, model.IsErrBadRequest(err), "order %v should be rejected", order)
+		}
+
+		err := store.ReorderCategories(context.Background(), "user_id_2", "team_id_1", []string{"category_id_3", "category_id_1", "category_id_2"})
+		assert.True(t, model.IsErrBadRequest(err))
+
+		assert.Equal(t, []string{"category_id_3", "category_id_1", "category_id_2"}, categoryIDs(t))
+	})
+
+	t.Run("persist the icon and collapsed state", func(t *testing.T) {
+		category, err := store.GetCategory(context.Background(), "category_id_1")
+		assert.NoError(t, err)
+
+		category.Icon = "🚀"
+		category.Collapsed = true
+		assert.NoError(t, store.UpdateCategory(context.Background(), *category))
+
+		category, err = store.GetCategory(context.Background(), "category_id_1")
+		assert.NoError(t, err)
+		assert.Equal(t, "🚀", category.Icon)
+		assert.True(t, category.Collapsed)
+		assert.Equal(t, 1, category.SortOrder)
+	})
+
+	t.Run("categories created after a reorder go last", func(t *testing.T) {
+		category := model.Category{
+			ID:       "category_id_4",
+			Name:     "category_id_4",
+			UserID:   "user_id_1",
+			TeamID:   "team_id_1",
+			CreateAt: now,
+			UpdateAt: now,
+			Icon:     "📁",
+			Type:     model.CategoryTypeCustom,
+		}
+		assert.NoError(t, store.CreateCategory(context.Background(), category))
+
+		assert.Equal(t, []string{"category_id_3", "category_id_1", "category_id_2", "category_id_4"}, categoryIDs(t))
+
+		created, err := store.GetCategory(context.Background(), "category_id_4")
+		assert.NoError(t, err)
+		assert.Equal(t, "📁", created.Icon)
+	})
+}
+
+func testDefaultCategoryTemplates(t *testing.T, store store.Store) {
+	t.Run("no templates", func(t *testing.T) {
+		templates, err := store.GetDefaultCategoryTemplates(context.Background(), "team_id_1")
+		assert.NoError(t, err)
+		assert.Empty(t, templates)
+
+		assert.NoError(t, store.CreateDefaultCategoriesForUser(context.Background(), "user_id_1", "team_id_1"))
+		categoryBoards, err := store.GetUserCategoryBoards(context.Background(), "user_id_1", "team_id_1")
+		assert.NoError(t, err)
+		assert.Empty(t, categoryBoards)
+	})
+
+	t.Run("upsert templates", func(t *testing.T) {
+		for _, template := range []model.CategoryTemplate{
+			{ID: "template_id_1", TeamID: "team_id_1", Name: "Projects", SortOrder: 2, Icon: "📁"},
+			{ID: "template_id_2", TeamID: "team_id_1", Name: "Personal", SortOrder: 1, Icon: "🏠"},
+			{ID: "template_id_3", TeamID: "team_id_2", Name: "Other team", SortOrder: 0},
+		} {
+			assert.NoError(t, store.UpsertDefaultCategoryTemplate(context.Background(), template))
+		}
+
+		updated := model.CategoryTemplate{ID: "template_id_1", TeamID: "team_id_1", Name: "Work", SortOrder: 3, Icon: "💼"}
+		assert.NoError(t, store.UpsertDefaultCategoryTemplate(context.Background(), updated))
+
+		templates, err := store.GetDefaultCategoryTemplates(context.Background(), "team_id_1")
+		assert.NoError(t, err)
+		assert.Len(t, templates, 2)
+		assert.Equal(t, "Personal", templates[0].Name)
+		assert.Equal(t, "Work", templates[1].Name)
+		assert.Equal(t, 3, templates[1].SortOrder)
+		assert.Equal(t, "💼", templates[1].Icon)
+		assert.NotZero(t, templates[1].CreateAt)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		err := store.UpsertDefaultCategoryTemplate(context.Background(), model.CategoryTemplate{ID: "template_id_4", TeamID: "team_id_1"})
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("create the default categories for a user", func(t *testing.T) {
+		assert.NoError(t, store.CreateDefaultCategoriesForUser(context.Background(), "user_id_1", "team_id_1"))
+
+		categoryBoards, err := store.GetUserCategoryBoards(context.Background(), "user_id_1", "team_id_1")
+		assert.NoError(t, err)
+		assert.Len(t, categoryBoards, 2)
+		assert.Equal(t, "Personal", categoryBoards[0].Name)
+		assert.Equal(t, 1, categoryBoards[0].SortOrder)
+		assert.Equal(t, "🏠", categoryBoards[0].Icon)
+		assert.Equal(t, "Work", categoryBoards[1].Name)
+		assert.Equal(t, 3, categoryBoards[1].SortOrder)
+		assert.Equal(t, "💼", categoryBoards[1].Icon)
+		assert.Equal(t, model.CategoryTypeCustom, categoryBoards[1].Type)
+
+		// creating them again doesn't duplicate them
+		assert.NoError(t, store.CreateDefaultCategoriesForUser(context.Background(), "user_id_1", "team_id_1"))
+		categoryBoards, err = store.GetUserCategoryBoards(context.Background(), "user_id_1", "team_id_1")
+		assert.NoError(t, err)
+		assert.Len(t, categoryBoards, 2)
+
+		// other users of the team are not affected
+		categoryBoards, err = store.GetUserCategoryBoards(context.Background(), "user_id_2", "team_id_1")
+		assert.NoError(t, err)
+		assert.Empty(t, categoryBoards)
+	})
+}
@@ -1,6 +1,7 @@
 package storetests
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -15,6 +16,30 @@ func StoreTestCategoryBoardsStore(t *testing.T, setup func(t *testing.T) (store.
 		defer tearDown()
 		testGetUserCategoryBoards(t, store)
 	})
+
+	t.Run("RemoveCategoryBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRemoveCategoryBoards(t, store)
+	})
+
+	t.Run("ClearCategory", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClearCategory(t, store)
+	})
+
+	t.Run("GetCategoryForBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCategoryForBoard(t, store)
+	})
+
+	t.Run("ReorderCategoryBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testReorderCategoryBoards(t, store)
+	})
 }
 
 func testGetUserCategoryBoards(t *testing.T, store store.Store) {
@@ -29,7 +54,7 @@ func testGetUserCategoryBoards(t *testing.T, store store.Store) {
 		DeleteAt:  0,
 		Collapsed: false,
 	}
-	err := store.CreateCategory(category1)
+	err := store.CreateCategory(context.Background(), category1)
 	assert.NoError(t, err)
 
 	category2 := model.Category{
@@ -42,7 +67,7 @@ func testGetUserCategoryBoards(t *testing.T, store store.Store) {
 		DeleteAt:  0,
 		Collapsed: false,
 	}
-	err = store.CreateCategory(category2)
+	err = store.CreateCategory(context.Background(), category2)
 	assert.NoError(t, err)
 
 	category3 := model.Category{
@@ -55,24 +80,24 @@ func testGetUserCategoryBoards(t *testing.T, store store.Store) {
 		DeleteAt:  0,
 		Collapsed: false,
 	}
-	err = store.CreateCategory(category3)
+	err = store.CreateCategory(context.Background(), category3)
 	assert.NoError(t, err)
 
 	// Adding Board 1 and Board 2 to Category 1
 	// The boards don't need to exists in DB for this test
-	err = store.AddUpdateCategoryBoard("user_id_1", "category_id_1", "board_1")
+	err = store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_1", "board_1")
 	assert.NoError(t, err)
 
-	err = store.AddUpdateCategoryBoard("user_id_1", "category_id_1", "board_2")
+	err = store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_1", "board_2")
 	assert.NoError(t, err)
 
 	// Adding Board 3 to Category 2
-	err = store.AddUpdateCategoryBoard("user_id_1", "category_id_2", "board_3")
+	err = store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_2", "board_3")
 	assert.NoError(t, err)
 
 	// we'll leave category 3 empty
 
-	userCategoryBoards, err := store.GetUserCategoryBoards("user_id_1", "team_id_1")
+	userCategoryBoards, err := store.GetUserCategoryBoards(context.Background(), "user_id_1", "team_id_1")
 	assert.NoError(t, err)
 
 	// we created 3 categories for the user
@@ -103,8 +128,160 @@ func testGetUserCategoryBoards(t *testing.T, store store.Store) {
 	assert.Equal(t, 0, len(category3BoardCategory.BoardIDs))
 
 	t.Run("get empty category boards", func(t *testing.T) {
-		userCategoryBoards, err := store.GetUserCategoryBoards("nonexistent-user-id", "nonexistent-team-id")
+		userCategoryBoards, err := store.GetUserCategoryBoards(context.Background(), "nonexistent-user-id", "nonexistent-team-id")
 		assert.NoError(t, err)
 		assert.Empty(t, userCategoryBoards)
 	})
 }
+
+func createCategoriesForRemoval(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	categories := []model.Category{
+		{ID: "default_category_id", Name: "Boards", UserID: "user_id_1", TeamID: "team_id_1", Type: model.CategoryTypeSystem},
+		{ID: "category_id_1", Name: "Category 1", UserID: "user_id_1", TeamID: "team_id_1", Type: model.CategoryTypeCustom},
+	}
+	for _, category := range categories {
+		category.CreateAt = now
+		category.UpdateAt = now
+		assert.NoError(t, store.CreateCategory(context.Background(), category))
+	}
+
+	for _, boardID := range []string{"board_1", "board_2", "board_3"} {
+		assert.NoError(t, store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_1", boardID))
+	}
+}
+
+func getCategoryBoardIDs(t *testing.T, store store.Store, categoryID string) []string {
+	userCategoryBoards, err := store.GetUserCategoryBoards(context.Background(), "user_id_1", "team_id_1")
+	assert.NoError(t, err)
+
+	for _, categoryBoards := range userCategoryBoards {
+		if categoryBoards.ID == categoryID {
+			return categoryBoards.BoardIDs
+		}
+	}
+	return nil
+}
+
+func testRemoveCategoryBoards(t *testing.T, store store.Store) {
+	createCategoriesForRemoval(t, store)
+
+	t.Run("remove some boards", func(t *testing.T) {
+		err := store.RemoveCategoryBoards(context.Background(), "user_id_1", "category_id_1", []string{"board_1", "board_3", "nonexistent_board"})
+		assert.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"board_2"}, getCategoryBoardIDs(t, store, "category_id_1"))
+		assert.ElementsMatch(t, []string{"board_1", "board_3"}, getCategoryBoardIDs(t, store, "default_category_id"))
+	})
+
+	t.Run("remove boards from another user's category", func(t *testing.T) {
+		err := store.RemoveCategoryBoards(context.Background(), "user_id_2", "category_id_1", []string{"board_2"})
+		assert.ErrorIs(t, err, model.ErrCategoryPermissionDenied)
+		assert.ElementsMatch(t, []string{"board_2"}, getCategoryBoardIDs(t, store, "category_id_1"))
+	})
+
+	t.Run("remove boards from the default category", func(t *testing.T) {
+		err := store.RemoveCategoryBoards(context.Background(), "user_id_1", "default_category_id", []string{"board_1"})
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func testClearCategory(t *testing.T, store store.Store) {
+	createCategoriesForRemoval(t, store)
+
+	err := store.ClearCategory(context.Background(), "user_id_1", "category_id_1")
+	assert.NoError(t, err)
+
+	assert.Empty(t, getCategoryBoardIDs(t, store, "category_id_1"))
+	assert.ElementsMatch(t, []string{"board_1", "board_2", "board_3"}, getCategoryBoardIDs(t, store, "default_category_id"))
+
+	category, err := store.GetCategory(context.Background(), "category_id_1")
+	assert.NoError(t, err)
+	assert.Zero(t, category.DeleteAt)
+}
+
+func testGetCategoryForBoard(t *testing.T, store store.Store) {
+	t.Run("no default category", func(t *testing.T) {
+		category, err := store.GetCategoryForBoard(context.Background(), "user_id_1", "team_id_1", "board_1")
+		assert.True(t, model.IsErrNotFound(err))
+		assert.Nil(t, category)
+	})
+
+	createCategoriesForRemoval(t, store)
+
+	t.Run("assigned board", func(t *testing.T) {
+		category, err := store.GetCategoryForBoard(context.Background(), "user_id_1", "team_id_1", "board_1")
+		assert.NoError(t, err)
+		assert.Equal(t, "category_id_1", category.ID)
+		assert.Equal(t, "Category 1", category.Name)
+	})
+
+	t.Run("unassigned board", func(t *testing.T) {
+		category, err := store.GetCategoryForBoard(context.Background(), "user_id_1", "team_id_1", "board_4")
+		assert.NoError(t, err)
+		assert.Equal(t, "default_category_id", category.ID)
+		assert.Equal(t, model.CategoryTypeSystem, category.Type)
+	})
+
+	t.Run("board removed from its category", func(t *testing.T) {
+		assert.NoError(t, store.RemoveCategoryBoards(context.Background(), "user_id_1", "category_id_1", []string{"board_2"}))
+
+		category, err := store.GetCategoryForBoard(context.Background(), "user_id_1", "team_id_1", "board_2")
+		assert.NoError(t, err)
+		assert.Equal(t, "default_category_id", category.ID)
+	})
+
+	t.Run("category of another team", func(t *testing.T) {
+		category, err := store.GetCategoryForBoard(context.Background(), "user_id_1", "team_id_2", "board_1")
+		assert.True(t, model.IsErrNotFound(err))
+		assert.Nil(t, category)
+	})
+}
+
+func testReorderCategoryBoards(t *testing.T, store store.Store) {
+	createCategoriesForRemoval(t, store)
+
+	t.Run("boards are listed in the order they were added", func(t *testing.T) {
+		assert.Equal(t, []string{"board_1", "board_2", "board_3"}, getCategoryBoardIDs(t, store, "category_id_1"))
+	})
+
+	t.Run("reorder the boards", func(t *testing.T) {
+		newOrder := []string{"board_3", "board_1", "board_2"}
+		assert.NoError(t, store.ReorderCategoryBoards(context.Background(), "user_id_1", "category_id_1", newOrder))
+		assert.Equal(t, newOrder, getCategoryBoardIDs(t, store, "category_id_1"))
+
+		// submitting the same order again changes nothing
+		assert.NoError(t, store.ReorderCategoryBoards(context.Background(), "user_id_1", "category_id_1", newOrder))
+		assert.Equal(t, newOrder, getCategoryBoardIDs(t, store, "category_id_1"))
+	})
+
+	t.Run("invalid orders", func(t *testing.T) {
+		invalidOrders := [][]string{
+			{"board_3", "board_1"},
+			{"board_3", "board_1", "board_1"},
+			{"board_3", "board_1", "board_2", "board_4"},
+		}
+		for _, order := range invalidOrders {
+			err := store.ReorderCategoryBoards(context.Background(), "user_id_1", "category_id_1", order)
+			assert.True(t, model.IsErrBadRequest(err), "order %v should be rejected", order)
+		}
+
+		err := store.ReorderCategoryBoards(context.Background(), "user_id_2", "category_id_1", []string{"board_3", "board_1", "board_2"})
+		assert.ErrorIs(t, err, model.ErrCategoryPermissionDenied)
+
+		err = store.ReorderCategoryBoards(context.Background(), "user_id_1", "nonexistent", []string{})
+		assert.True(t, model.IsErrNotFound(err))
+
+		assert.Equal(t, []string{"board_3", "board_1", "board_2"}, getCategoryBoardIDs(t, store, "category_id_1"))
+	})
+
+	t.Run("boards moved to a category are added at the end", func(t *testing.T) {
+		assert.NoError(t, store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "default_category_id", "board_4"))
+		assert.NoError(t, store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_1", "board_4"))
+		assert.Equal(t, []string{"board_3", "board_1", "board_2", "board_4"}, getCategoryBoardIDs(t, store, "category_id_1"))
+
+		assert.NoError(t, store.RemoveCategoryBoards(context.Background(), "user_id_1", "category_id_1", []string{"board_1"}))
+		assert.NoError(t, store.AddUpdateCategoryBoard(context.Background(), "user_id_1", "category_id_1", "board_1"))
+		assert.Equal(t, []string{"board_3", "board_2", "board_4", "board_1"}, getCategoryBoardIDs(t, store, "category_id_1"))
+	})
+}
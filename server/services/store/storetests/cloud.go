@@ -4,6 +4,7 @@
 package storetests
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -36,7 +37,7 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 	userID := "user-id"
 
 	t.Run("should return zero when no cards have been created", func(t *testing.T) {
-		count, err := store.GetUsedCardsCount()
+		count, err := store.GetUsedCardsCount(context.Background())
 		require.NoError(t, err)
 		require.Zero(t, count)
 	})
@@ -55,7 +56,7 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 				Type:   boardType,
 			}
 
-			_, err := store.InsertBoard(board, userID)
+			_, err := store.InsertBoard(context.Background(), board, userID)
 			require.NoError(t, err)
 		}
 
@@ -67,7 +68,7 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 				BoardID:  "board1",
 				Type:     model.TypeCard,
 			}
-			require.NoError(t, store.InsertBlock(card, userID))
+			require.NoError(t, store.InsertBlock(context.Background(), card, userID))
 		}
 
 		// board 2 has two cards
@@ -78,10 +79,10 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 				BoardID:  "board2",
 				Type:     model.TypeCard,
 			}
-			require.NoError(t, store.InsertBlock(card, userID))
+			require.NoError(t, store.InsertBlock(context.Background(), card, userID))
 		}
 
-		count, err := store.GetUsedCardsCount()
+		count, err := store.GetUsedCardsCount(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, 5, count)
 	})
@@ -94,7 +95,7 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 			BoardID:  "board1",
 			Type:     model.TypeText,
 		}
-		require.NoError(t, store.InsertBlock(text, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), text, userID))
 
 		view := &model.Block{
 			ID:       "view-id",
@@ -102,10 +103,10 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 			BoardID:  "board1",
 			Type:     model.TypeView,
 		}
-		require.NoError(t, store.InsertBlock(view, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), view, userID))
 
 		// and count should not change
-		count, err := store.GetUsedCardsCount()
+		count, err := store.GetUsedCardsCount(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, 5, count)
 	})
@@ -113,15 +114,14 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 	t.Run("should not take into account cards belonging to templates", func(t *testing.T) {
 		// we add a template with cards
 		templateID := "template-id"
-		boardTemplate := &model.Block{
-			ID:      templateID,
-			BoardID: templateID,
-			Type:    model.TypeBoard,
-			Fields: map[string]interface{}{
-				"isTemplate": true,
-			},
+		boardTemplate := &model.Board{
+			ID:         templateID,
+			TeamID:     testTeamID,
+			Type:       model.BoardTypeOpen,
+			IsTemplate: true,
 		}
-		require.NoError(t, store.InsertBlock(boardTemplate, userID))
+		_, err := store.InsertBoard(context.Background(), boardTemplate, userID)
+		require.NoError(t, err)
 
 		for _, cardID := range []string{"card6", "card7", "card8"} {
 			card := &model.Block{
@@ -130,11 +130,11 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 				BoardID:  templateID,
 				Type:     model.TypeCard,
 			}
-			require.NoError(t, store.InsertBlock(card, userID))
+			require.NoError(t, store.InsertBlock(context.Background(), card, userID))
 		}
 
 		// and count should still be the same
-		count, err := store.GetUsedCardsCount()
+		count, err := store.GetUsedCardsCount(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, 5, count)
 	})
@@ -148,18 +148,18 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 			Type:     model.TypeCard,
 			DeleteAt: utils.GetMillis(),
 		}
-		require.NoError(t, store.InsertBlock(card9, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), card9, userID))
 
 		// and count should still be the same
-		count, err := store.GetUsedCardsCount()
+		count, err := store.GetUsedCardsCount(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, 5, count)
 	})
 
 	t.Run("should not take into account cards from deleted boards", func(t *testing.T) {
-		require.NoError(t, store.DeleteBoard("board2", "user-id"))
+		require.NoError(t, store.DeleteBoard(context.Background(), "board2", "user-id"))
 
-		count, err := store.GetUsedCardsCount()
+		count, err := store.GetUsedCardsCount(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, 3, count)
 	})
@@ -167,27 +167,27 @@ func testGetUsedCardsCount(t *testing.T, store storeservice.Store) {
 
 func testGetCardLimitTimestamp(t *testing.T, store storeservice.Store) {
 	t.Run("should return 0 if there is no entry in the database", func(t *testing.T) {
-		rawValue, err := store.GetSystemSetting(storeservice.CardLimitTimestampSystemKey)
+		rawValue, err := store.GetSystemSetting(context.Background(), storeservice.CardLimitTimestampSystemKey)
 		require.NoError(t, err)
 		require.Equal(t, "", rawValue)
 
-		cardLimitTimestamp, err := store.GetCardLimitTimestamp()
+		cardLimitTimestamp, err := store.GetCardLimitTimestamp(context.Background())
 		require.NoError(t, err)
 		require.Zero(t, cardLimitTimestamp)
 	})
 
 	t.Run("should return an int64 representation of the value", func(t *testing.T) {
-		require.NoError(t, store.SetSystemSetting(storeservice.CardLimitTimestampSystemKey, "1234"))
+		require.NoError(t, store.SetSystemSetting(context.Background(), storeservice.CardLimitTimestampSystemKey, "1234"))
 
-		cardLimitTimestamp, err := store.GetCardLimitTimestamp()
+		cardLimitTimestamp, err := store.GetCardLimitTimestamp(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, int64(1234), cardLimitTimestamp)
 	})
 
 	t.Run("should return an invalid value error if the value is not a number", func(t *testing.T) {
-		require.NoError(t, store.SetSystemSetting(storeservice.CardLimitTimestampSystemKey, "abc"))
+		require.NoError(t, store.SetSystemSetting(context.Background(), storeservice.CardLimitTimestampSystemKey, "abc"))
 
-		cardLimitTimestamp, err := store.GetCardLimitTimestamp()
+		cardLimitTimestamp, err := store.GetCardLimitTimestamp(context.Background())
 		require.ErrorContains(t, err, "card limit value is invalid")
 		require.Zero(t, cardLimitTimestamp)
 	})
@@ -209,7 +209,7 @@ func testUpdateCardLimitTimestamp(t *testing.T, store storeservice.Store) {
 			Type:   boardType,
 		}
 
-		_, err := store.InsertBoard(board, userID)
+		_, err := store.InsertBoard(context.Background(), board, userID)
 		require.NoError(t, err)
 	}
 
@@ -221,7 +221,7 @@ func testUpdateCardLimitTimestamp(t *testing.T, store storeservice.Store) {
 			BoardID:  "board1",
 			Type:     model.TypeCard,
 		}
-		require.NoError(t, store.InsertBlock(card, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), card, userID))
 		time.Sleep(10 * time.Millisecond)
 	}
 
@@ -233,38 +233,38 @@ func testUpdateCardLimitTimestamp(t *testing.T, store storeservice.Store) {
 			BoardID:  "board2",
 			Type:     model.TypeCard,
 		}
-		require.NoError(t, store.InsertBlock(card, userID))
+		require.NoError(t, store.InsertBlock(context.Background(), card, userID))
 		time.Sleep(10 * time.Millisecond)
 	}
 
 	t.Run("should set the timestamp to zero if the card limit is zero", func(t *testing.T) {
-		cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(0)
+		cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(context.Background(), 0)
 		require.NoError(t, err)
 		require.Zero(t, cardLimitTimestamp)
 
-		cardLimitTimestampStr, err := store.GetSystemSetting(storeservice.CardLimitTimestampSystemKey)
+		cardLimitTimestampStr, err := store.GetSystemSetting(context.Background(), storeservice.CardLimitTimestampSystemKey)
 		require.NoError(t, err)
 		require.Equal(t, "0", cardLimitTimestampStr)
 	})
 
 	t.Run("should correctly modify the limit several times in a row", func(t *testing.T) {
-		cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(0)
+		cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(context.Background(), 0)
 		require.NoError(t, err)
 		require.Zero(t, cardLimitTimestamp)
 
-		cardLimitTimestamp, err = store.UpdateCardLimitTimestamp(10)
+		cardLimitTimestamp, err = store.UpdateCardLimitTimestamp(context.Background(), 10)
 		require.NoError(t, err)
 		require.NotZero(t, cardLimitTimestamp)
 
-		cardLimitTimestampStr, err := store.GetSystemSetting(storeservice.CardLimitTimestampSystemKey)
+		cardLimitTimestampStr, err := store.GetSystemSetting(context.Background(), storeservice.CardLimitTimestampSystemKey)
 		require.NoError(t, err)
 		require.NotEqual(t, "0", cardLimitTimestampStr)
 
-		cardLimitTimestamp, err = store.UpdateCardLimitTimestamp(0)
+		cardLimitTimestamp, err = store.UpdateCardLimitTimestamp(context.Background(), 0)
 		require.NoError(t, err)
 		require.Zero(t, cardLimitTimestamp)
 
-		cardLimitTimestampStr, err = store.GetSystemSetting(storeservice.CardLimitTimestampSystemKey)
+		cardLimitTimestampStr, err = store.GetSystemSetting(context.Background(), storeservice.CardLimitTimestampSystemKey)
 		require.NoError(t, err)
 		require.Equal(t, "0", cardLimitTimestampStr)
 	})
@@ -272,12 +272,12 @@ func testUpdateCardLimitTimestamp(t *testing.T, store storeservice.Store) {
 	t.Run("should set the correct timestamp", func(t *testing.T) {
 		t.Run("limit 10", func(t *testing.T) {
 			// we fetch the first block
-			card1, err := store.GetBlock("card1")
+			card1, err := store.GetBlock(context.Background(), "card1")
 			require.NoError(t, err)
 
 			// and assert that if the limit is 10, the stored
 			// timestamp corresponds to the card's update_at
-			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(10)
+			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(context.Background(), 10)
 			require.NoError(t, err)
 			require.Equal(t, card1.UpdateAt, cardLimitTimestamp)
 		})
@@ -285,45 +285,46 @@ func testUpdateCardLimitTimestamp(t *testing.T, store storeservice.Store) {
 		t.Run("limit 5", func(t *testing.T) {
 			// if the limit is 5, the timestamp should be the one from
 			// the sixth card (the first five are older and out of the
-			card6, err := store.GetBlock("card6")
+			card6, err := store.GetBlock(context.Background(), "card6")
 			require.NoError(t, err)
 
-			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(5)
+			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(context.Background(), 5)
 			require.NoError(t, err)
 			require.Equal(t, card6.UpdateAt, cardLimitTimestamp)
 		})
 
 		t.Run("limit should be zero if we have less cards than the limit", func(t *testing.T) {
-			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(100)
+			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(context.Background(), 100)
 			require.NoError(t, err)
 			require.Zero(t, cardLimitTimestamp)
 		})
 
 		t.Run("we update the first inserted card and assert that with limit 1 that's the limit that is set", func(t *testing.T) {
 			time.Sleep(10 * time.Millisecond)
-			card1, err := store.GetBlock("card1")
+			card1, err := store.GetBlock(context.Background(), "card1")
 			require.NoError(t, err)
 
 			card1.Title = "New title"
-			require.NoError(t, store.InsertBlock(card1, userID))
+			require.NoError(t, store.InsertBlock(context.Background(), card1, userID))
 
-			newCard1, err := store.GetBlock("card1")
+			newCard1, err := store.GetBlock(context.Background(), "card1")
 			require.NoError(t, err)
 
-			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(1)
+			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(context.Background(), 1)
 			require.NoError(t, err)
 			require.Equal(t, newCard1.UpdateAt, cardLimitTimestamp)
 		})
 
 		t.Run("limit should stop applying if we remove the last card", func(t *testing.T) {
-			initialCardLimitTimestamp, err := store.GetCardLimitTimestamp()
+			initialCardLimitTimestamp, err := store.GetCardLimitTimestamp(context.Background())
 			require.NoError(t, err)
 			require.NotZero(t, initialCardLimitTimestamp)
 
 			time.Sleep(10 * time.Millisecond)
-			require.NoError(t, store.DeleteBlock("card1", userID))
+			_, err = store.DeleteBlock(context.Background(), "card1", userID)
+			require.NoError(t, err)
 
-			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(10)
+			cardLimitTimestamp, err := store.UpdateCardLimitTimestamp(context.Background(), 10)
 			require.NoError(t, err)
 			require.Zero(t, cardLimitTimestamp)
 		})
@@ -3,6 +3,7 @@
 package storetests
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -29,7 +30,7 @@ func StoreTestDataRetention(t *testing.T, setup func(t *testing.T) (store.Store,
 			UserID: testUserID,
 			TeamID: testTeamID,
 		}
-		err := store.CreateCategory(category)
+		err := store.CreateCategory(context.Background(), category)
 		require.NoError(t, err)
 
 		testRunDataRetention(t, store, 0)
@@ -45,7 +46,7 @@ func LoadData(t *testing.T, store store.Store) {
 		ModifiedBy: testUserID,
 		TeamID:     testTeamID,
 	}
-	board, err := store.InsertBoard(&validBoard, testUserID)
+	board, err := store.InsertBoard(context.Background(), &validBoard, testUserID)
 	require.NoError(t, err)
 
 	validBlock := &model.Block{
@@ -73,7 +74,7 @@ func LoadData(t *testing.T, store store.Store) {
 
 	newBlocks := []*model.Block{validBlock, validBlock2, validBlock3, validBlock4}
 
-	err = store.InsertBlocks(newBlocks, testUserID)
+	err = store.InsertBlocks(context.Background(), newBlocks, testUserID)
 	require.NoError(t, err)
 
 	member := &model.BoardMember{
@@ -81,7 +82,7 @@ func LoadData(t *testing.T, store store.Store) {
 		BoardID:     boardID,
 		SchemeAdmin: true,
 	}
-	_, err = store.SaveMember(member)
+	_, err = store.SaveMember(context.Background(), member, "user-id")
 	require.NoError(t, err)
 
 	sharing := model.Sharing{
@@ -89,50 +90,50 @@ func LoadData(t *testing.T, store store.Store) {
 		Enabled: true,
 		Token:   "testToken",
 	}
-	err = store.UpsertSharing(sharing)
+	err = store.UpsertSharing(context.Background(), sharing)
 	require.NoError(t, err)
 
-	err = store.AddUpdateCategoryBoard(testUserID, categoryID, boardID)
+	err = store.AddUpdateCategoryBoard(context.Background(), testUserID, categoryID, boardID)
 	require.NoError(t, err)
 }
 
 func testRunDataRetention(t *testing.T, store store.Store, batchSize int) {
 	LoadData(t, store)
 
-	blocks, err := store.GetBlocksForBoard(boardID)
+	blocks, _, err := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 	require.NoError(t, err)
 	require.Len(t, blocks, 4)
 	initialCount := len(blocks)
 
 	t.Run("test no deletions", func(t *testing.T) {
-		deletions, err := store.RunDataRetention(utils.GetMillisForTime(time.Now().Add(-time.Hour*1)), int64(batchSize))
+		deletions, err := store.RunDataRetention(context.Background(), utils.GetMillisForTime(time.Now().Add(-time.Hour*1)), int64(batchSize))
 		require.NoError(t, err)
 		require.Equal(t, int64(0), deletions)
 	})
 
 	t.Run("test all deletions", func(t *testing.T) {
-		deletions, err := store.RunDataRetention(utils.GetMillisForTime(time.Now().Add(time.Hour*1)), int64(batchSize))
+		deletions, err := store.RunDataRetention(context.Background(), utils.GetMillisForTime(time.Now().Add(time.Hour*1)), int64(batchSize))
 		require.NoError(t, err)
 		require.True(t, deletions > int64(initialCount))
 
 		// expect all blocks to be deleted.
-		blocks, errBlocks := store.GetBlocksForBoard(boardID)
+		blocks, _, errBlocks := store.GetBlocksForBoard(context.Background(), boardID, model.QueryBlocksOptions{})
 		require.NoError(t, errBlocks)
 		require.Equal(t, 0, len(blocks))
 
 		// GetMemberForBoard throws error on now rows found
-		member, err := store.GetMemberForBoard(boardID, testUserID)
+		member, err := store.GetMemberForBoard(context.Background(), boardID, testUserID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err), err)
 		require.Nil(t, member)
 
 		// GetSharing throws error on now rows found
-		sharing, err := store.GetSharing(boardID)
+		sharing, err := store.GetSharing(context.Background(), boardID)
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err), err)
 		require.Nil(t, sharing)
 
-		category, err := store.GetUserCategoryBoards(boardID, testTeamID)
+		category, err := store.GetUserCategoryBoards(context.Background(), boardID, testTeamID)
 		require.NoError(t, err)
 		require.Empty(t, category)
 	})
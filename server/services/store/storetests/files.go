@@ -1,7 +1,9 @@
 package storetests
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
@@ -25,10 +27,10 @@ func StoreTestFileStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 			DeleteAt:  0,
 		}
 
-		err := sqlStore.SaveFileInfo(fileInfo)
+		err := sqlStore.SaveFileInfo(context.Background(), fileInfo)
 		require.NoError(t, err)
 
-		retrievedFileInfo, err := sqlStore.GetFileInfo("file_info_1")
+		retrievedFileInfo, err := sqlStore.GetFileInfo(context.Background(), "file_info_1")
 		require.NoError(t, err)
 		require.Equal(t, "file_info_1", retrievedFileInfo.Id)
 		require.Equal(t, "Dunder Mifflin Sales Report 2022", retrievedFileInfo.Name)
@@ -39,10 +41,65 @@ func StoreTestFileStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 	})
 
 	t.Run("should return an error on not found", func(t *testing.T) {
-		fileInfo, err := sqlStore.GetFileInfo("nonexistent")
+		fileInfo, err := sqlStore.GetFileInfo(context.Background(), "nonexistent")
 		require.Error(t, err)
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 		require.Nil(t, fileInfo)
 	})
+
+	t.Run("should list and soft delete the file infos of a board", func(t *testing.T) {
+		boardID := "board-with-files"
+		for _, id := range []string{"file_info_2", "file_info_3", "file_info_unreferenced"} {
+			fileInfo := &mmModel.FileInfo{Id: id, CreateAt: utils.GetMillis(), Name: id, Extension: ".png", Size: 10}
+			require.NoError(t, sqlStore.SaveFileInfo(context.Background(), fileInfo))
+		}
+
+		blocks := []*model.Block{
+			{ID: "card", BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+			{ID: "image-1", BoardID: boardID, ParentID: "card", Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "7file_info_2.png"}},
+			{ID: "image-2", BoardID: boardID, ParentID: "card", Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "7file_info_3.png"}},
+		}
+		InsertBlocks(t, sqlStore, blocks, testUserID)
+
+		fileInfoIDs := func(t *testing.T) []string {
+			fileInfos, err := sqlStore.GetFileInfosForBoard(context.Background(), boardID)
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, fileInfo := range fileInfos {
+				ids = append(ids, fileInfo.Id)
+			}
+			return ids
+		}
+
+		require.ElementsMatch(t, []string{"file_info_2", "file_info_3"}, fileInfoIDs(t))
+
+		// deleting a block marks its file info deleted
+		_, err := sqlStore.DeleteBlock(context.Background(), "image-1", testUserID)
+		require.NoError(t, err)
+		_, err = sqlStore.GetFileInfo(context.Background(), "file_info_2")
+		require.True(t, model.IsErrNotFound(err))
+		require.Equal(t, []string{"file_info_3"}, fileInfoIDs(t))
+
+		// undeleting the block recovers it
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, sqlStore.UndeleteBlock(context.Background(), "image-1", testUserID))
+		_, err = sqlStore.GetFileInfo(context.Background(), "file_info_2")
+		require.NoError(t, err)
+
+		board := &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}
+		_, err = sqlStore.InsertBoard(context.Background(), board, testUserID)
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, sqlStore.DeleteBoard(context.Background(), boardID, testUserID))
+		for _, id := range []string{"file_info_2", "file_info_3"} {
+			_, err = sqlStore.GetFileInfo(context.Background(), id)
+			require.True(t, model.IsErrNotFound(err), id)
+		}
+
+		_, err = sqlStore.GetFileInfo(context.Background(), "file_info_unreferenced")
+		require.NoError(t, err)
+	})
 }
@@ -1,6 +1,7 @@
 package storetests
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -8,16 +9,42 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// InsertBoardsIfMissing inserts an open board for each of the IDs that
+// doesn't belong to an existing board, as blocks can only be inserted
+// into existing boards.
+func InsertBoardsIfMissing(t *testing.T, s store.Store, userID string, boardIDs ...string) {
+	for _, boardID := range boardIDs {
+		_, err := s.GetBoard(context.Background(), boardID)
+		if err == nil {
+			continue
+		}
+		require.True(t, model.IsErrNotFound(err))
+
+		board := &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}
+		_, err = s.InsertBoard(context.Background(), board, userID)
+		require.NoError(t, err)
+	}
+}
+
+// InsertBoardsForBlocks inserts the boards of the blocks that don't
+// exist yet.
+func InsertBoardsForBlocks(t *testing.T, s store.Store, blocks []*model.Block, userID string) {
+	for _, block := range blocks {
+		InsertBoardsIfMissing(t, s, userID, block.BoardID)
+	}
+}
+
 func InsertBlocks(t *testing.T, s store.Store, blocks []*model.Block, userID string) {
+	InsertBoardsForBlocks(t, s, blocks, userID)
 	for i := range blocks {
-		err := s.InsertBlock(blocks[i], userID)
+		err := s.InsertBlock(context.Background(), blocks[i], userID)
 		require.NoError(t, err)
 	}
 }
 
 func DeleteBlocks(t *testing.T, s store.Store, blocks []*model.Block, modifiedBy string) {
 	for _, block := range blocks {
-		err := s.DeleteBlock(block.ID, modifiedBy)
+		_, err := s.DeleteBlock(context.Background(), block.ID, modifiedBy)
 		require.NoError(t, err)
 	}
 }
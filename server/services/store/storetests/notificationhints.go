@@ -4,6 +4,8 @@
 package storetests
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -39,6 +41,18 @@ func StoreTestNotificationHintsStore(t *testing.T, setup func(t *testing.T) (sto
 		defer tearDown()
 		testGetNextNotificationHint(t, store)
 	})
+
+	t.Run("GetDueNotificationHints", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDueNotificationHints(t, store)
+	})
+
+	t.Run("GetNotificationHints", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetNotificationHints(t, store)
+	})
 }
 
 func testUpsertNotificationHint(t *testing.T, store store.Store) {
@@ -49,7 +63,7 @@ func testUpsertNotificationHint(t *testing.T, store store.Store) {
 			ModifiedByID: utils.NewID(utils.IDTypeUser),
 		}
 
-		hintNew, err := store.UpsertNotificationHint(hint, time.Second*15)
+		hintNew, err := store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 		require.NoError(t, err, "upsert notification hint should not error")
 		assert.Equal(t, hint.BlockID, hintNew.BlockID)
 		assert.NoError(t, hintNew.IsValid())
@@ -61,7 +75,7 @@ func testUpsertNotificationHint(t *testing.T, store store.Store) {
 			BlockID:      utils.NewID(utils.IDTypeBlock),
 			ModifiedByID: utils.NewID(utils.IDTypeUser),
 		}
-		hintNew, err := store.UpsertNotificationHint(hint, time.Second*15)
+		hintNew, err := store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 		require.NoError(t, err, "upsert notification hint should not error")
 
 		// sleep a short time so the notify_at timestamps won't collide
@@ -72,7 +86,7 @@ func testUpsertNotificationHint(t *testing.T, store store.Store) {
 			BlockID:      hintNew.BlockID,
 			ModifiedByID: hintNew.ModifiedByID,
 		}
-		hintDup, err := store.UpsertNotificationHint(hint, time.Second*15)
+		hintDup, err := store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 
 		require.NoError(t, err, "upsert notification hint should not error")
 		// notify_at should be updated
@@ -82,22 +96,22 @@ func testUpsertNotificationHint(t *testing.T, store store.Store) {
 	t.Run("invalid notification hint", func(t *testing.T) {
 		hint := &model.NotificationHint{}
 
-		_, err := store.UpsertNotificationHint(hint, time.Second*15)
+		_, err := store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 		assert.ErrorAs(t, err, &model.ErrInvalidNotificationHint{}, "invalid notification hint should error")
 
 		hint.BlockType = "board"
-		_, err = store.UpsertNotificationHint(hint, time.Second*15)
+		_, err = store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 		assert.ErrorAs(t, err, &model.ErrInvalidNotificationHint{}, "invalid notification hint should error")
 
-		_, err = store.UpsertNotificationHint(hint, time.Second*15)
+		_, err = store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 		assert.ErrorAs(t, err, &model.ErrInvalidNotificationHint{}, "invalid notification hint should error")
 
 		hint.ModifiedByID = utils.NewID(utils.IDTypeUser)
-		_, err = store.UpsertNotificationHint(hint, time.Second*15)
+		_, err = store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 		assert.ErrorAs(t, err, &model.ErrInvalidNotificationHint{}, "invalid notification hint should error")
 
 		hint.BlockID = utils.NewID(utils.IDTypeBlock)
-		hintNew, err := store.UpsertNotificationHint(hint, time.Second*15)
+		hintNew, err := store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 		assert.NoError(t, err, "valid notification hint should not error")
 		assert.NoError(t, hintNew.IsValid(), "created notification hint should be valid")
 	})
@@ -110,26 +124,26 @@ func testDeleteNotificationHint(t *testing.T, store store.Store) {
 			BlockID:      utils.NewID(utils.IDTypeBlock),
 			ModifiedByID: utils.NewID(utils.IDTypeUser),
 		}
-		hintNew, err := store.UpsertNotificationHint(hint, time.Second*15)
+		hintNew, err := store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 		require.NoError(t, err, "create notification hint should not error")
 
 		// check the notification hint exists
-		hint, err = store.GetNotificationHint(hintNew.BlockID)
+		hint, err = store.GetNotificationHint(context.Background(), hintNew.BlockID)
 		require.NoError(t, err, "get notification hint should not error")
 		assert.Equal(t, hintNew.BlockID, hint.BlockID)
 		assert.Equal(t, hintNew.CreateAt, hint.CreateAt)
 
-		err = store.DeleteNotificationHint(hintNew.BlockID)
+		err = store.DeleteNotificationHint(context.Background(), hintNew.BlockID)
 		require.NoError(t, err, "delete notification hint should not error")
 
 		// check the notification hint was deleted
-		hint, err = store.GetNotificationHint(hintNew.BlockID)
+		hint, err = store.GetNotificationHint(context.Background(), hintNew.BlockID)
 		require.True(t, model.IsErrNotFound(err), "error should be of type store.ErrNotFound")
 		assert.Nil(t, hint)
 	})
 
 	t.Run("delete non-existent notification hint", func(t *testing.T) {
-		err := store.DeleteNotificationHint("bogus")
+		err := store.DeleteNotificationHint(context.Background(), "bogus")
 		require.True(t, model.IsErrNotFound(err), "error should be of type store.ErrNotFound")
 	})
 }
@@ -141,17 +155,17 @@ func testGetNotificationHint(t *testing.T, store store.Store) {
 			BlockID:      utils.NewID(utils.IDTypeBlock),
 			ModifiedByID: utils.NewID(utils.IDTypeUser),
 		}
-		hintNew, err := store.UpsertNotificationHint(hint, time.Second*15)
+		hintNew, err := store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 		require.NoError(t, err, "create notification hint should not error")
 
 		// make sure notification hint can be fetched
-		hint, err = store.GetNotificationHint(hintNew.BlockID)
+		hint, err = store.GetNotificationHint(context.Background(), hintNew.BlockID)
 		require.NoError(t, err, "get notification hint should not error")
 		assert.Equal(t, hintNew, hint)
 	})
 
 	t.Run("get non-existent notification hint", func(t *testing.T) {
-		hint, err := store.GetNotificationHint("bogus")
+		hint, err := store.GetNotificationHint(context.Background(), "bogus")
 		require.True(t, model.IsErrNotFound(err), "error should be of type store.ErrNotFound")
 		assert.Nil(t, hint, "hint should be nil")
 	})
@@ -170,7 +184,7 @@ func testGetNextNotificationHint(t *testing.T, store store.Store) {
 				BlockID:      utils.NewID(utils.IDTypeBlock),
 				ModifiedByID: modifiedBy,
 			}
-			hintNew, err := store.UpsertNotificationHint(hint, time.Second*15)
+			hintNew, err := store.UpsertNotificationHint(context.Background(), hint, time.Second*15)
 			require.NoError(t, err, "create notification hint should not error")
 
 			ids[i] = hintNew.BlockID
@@ -180,14 +194,14 @@ func testGetNextNotificationHint(t *testing.T, store store.Store) {
 		// check the hints come back in the right order
 		notifyAt := utils.GetMillisForTime(time.Now().Add(time.Millisecond * 50))
 		for i := 0; i < loops; i++ {
-			hint, err := store.GetNextNotificationHint(false)
+			hint, err := store.GetNextNotificationHint(context.Background(), false)
 			require.NoError(t, err, "get next notification hint should not error")
 			require.NotNil(t, hint, "get next notification hint should not return nil")
 			assert.Equal(t, ids[i], hint.BlockID)
 			assert.Less(t, notifyAt, hint.NotifyAt)
 			notifyAt = hint.NotifyAt
 
-			err = store.DeleteNotificationHint(hint.BlockID)
+			err = store.DeleteNotificationHint(context.Background(), hint.BlockID)
 			require.NoError(t, err, "delete notification hint should not error")
 		}
 	})
@@ -198,17 +212,17 @@ func testGetNextNotificationHint(t *testing.T, store store.Store) {
 		require.NoError(t, err, "emptying notification hint table should not error")
 
 		for {
-			hint, err2 := store.GetNextNotificationHint(false)
+			hint, err2 := store.GetNextNotificationHint(context.Background(), false)
 			if model.IsErrNotFound(err2) {
 				break
 			}
 			require.NoError(t, err2, "get next notification hint should not error")
 
-			err2 = store.DeleteNotificationHint(hint.BlockID)
+			err2 = store.DeleteNotificationHint(context.Background(), hint.BlockID)
 			require.NoError(t, err2, "delete notification hint should not error")
 		}
 
-		_, err = store.GetNextNotificationHint(false)
+		_, err = store.GetNextNotificationHint(context.Background(), false)
 		require.True(t, model.IsErrNotFound(err), "error should be of type store.ErrNotFound")
 	})
 
@@ -222,23 +236,161 @@ func testGetNextNotificationHint(t *testing.T, store store.Store) {
 			BlockID:      utils.NewID(utils.IDTypeBlock),
 			ModifiedByID: utils.NewID(utils.IDTypeUser),
 		}
-		hintNew, err := store.UpsertNotificationHint(hint, time.Second*1)
+		hintNew, err := store.UpsertNotificationHint(context.Background(), hint, time.Second*1)
 		require.NoError(t, err, "create notification hint should not error")
 
-		hintDeleted, err := store.GetNextNotificationHint(true)
+		hintDeleted, err := store.GetNextNotificationHint(context.Background(), true)
 		require.NoError(t, err, "get next notification hint should not error")
 		require.NotNil(t, hintDeleted, "get next notification hint should not return nil")
 		assert.Equal(t, hintNew.BlockID, hintDeleted.BlockID)
 
 		// should be no hint left
-		_, err = store.GetNextNotificationHint(false)
+		_, err = store.GetNextNotificationHint(context.Background(), false)
 		require.True(t, model.IsErrNotFound(err), "error should be of type store.ErrNotFound")
 	})
 }
 
+func testGetDueNotificationHints(t *testing.T, store store.Store) {
+	t.Run("get due notification hints", func(t *testing.T) {
+		ids := []string{}
+		for i := 0; i < 3; i++ {
+			hint := &model.NotificationHint{
+				BlockType:    model.TypeCard,
+				BlockID:      utils.NewID(utils.IDTypeBlock),
+				ModifiedByID: utils.NewID(utils.IDTypeUser),
+			}
+			hintNew, err := store.UpsertNotificationHint(context.Background(), hint, time.Millisecond*10)
+			require.NoError(t, err, "create notification hint should not error")
+
+			ids = append(ids, hintNew.BlockID)
+			time.Sleep(time.Millisecond * 20) // ensure next timestamp is unique
+		}
+
+		// a hint that is not due yet
+		later := &model.NotificationHint{
+			BlockType:    model.TypeCard,
+			BlockID:      utils.NewID(utils.IDTypeBlock),
+			ModifiedByID: utils.NewID(utils.IDTypeUser),
+		}
+		_, err := store.UpsertNotificationHint(context.Background(), later, time.Hour)
+		require.NoError(t, err, "create notification hint should not error")
+
+		now := utils.GetMillis()
+
+		hints, err := store.GetDueNotificationHints(context.Background(), now, 2)
+		require.NoError(t, err, "get due notification hints should not error")
+		require.Len(t, hints, 2)
+		assert.Equal(t, ids[0], hints[0].BlockID)
+		assert.Equal(t, ids[1], hints[1].BlockID)
+
+		// claimed hints are not returned again
+		hints, err = store.GetDueNotificationHints(context.Background(), now, 10)
+		require.NoError(t, err, "get due notification hints should not error")
+		require.Len(t, hints, 1)
+		assert.Equal(t, ids[2], hints[0].BlockID)
+
+		hints, err = store.GetDueNotificationHints(context.Background(), now, 10)
+		require.NoError(t, err, "get due notification hints should not error")
+		assert.Empty(t, hints)
+
+		hint, err := store.GetNotificationHint(context.Background(), later.BlockID)
+		require.NoError(t, err, "get notification hint should not error")
+		assert.Equal(t, later.BlockID, hint.BlockID)
+	})
+}
+
+func testGetNotificationHints(t *testing.T, store store.Store) {
+	createDueHints := func(t *testing.T, count int) []string {
+		ids := []string{}
+		for i := 0; i < count; i++ {
+			hint := &model.NotificationHint{
+				BlockType:    model.TypeCard,
+				BlockID:      utils.NewID(utils.IDTypeBlock),
+				ModifiedByID: utils.NewID(utils.IDTypeUser),
+			}
+			hintNew, err := store.UpsertNotificationHint(context.Background(), hint, time.Millisecond)
+			require.NoError(t, err, "create notification hint should not error")
+
+			ids = append(ids, hintNew.BlockID)
+			time.Sleep(time.Millisecond * 2) // ensure next timestamp is unique
+		}
+		time.Sleep(time.Millisecond * 5) // ensure all hints are due
+		return ids
+	}
+
+	hintBlockIDs := func(hints []*model.NotificationHint) []string {
+		ids := make([]string, 0, len(hints))
+		for _, hint := range hints {
+			ids = append(ids, hint.BlockID)
+		}
+		return ids
+	}
+
+	t.Run("workers get disjoint hints", func(t *testing.T) {
+		ids := createDueHints(t, 5)
+
+		first, err := store.GetNotificationHints(context.Background(), 3)
+		require.NoError(t, err, "get notification hints should not error")
+		assert.Equal(t, ids[:3], hintBlockIDs(first))
+
+		second, err := store.GetNotificationHints(context.Background(), 3)
+		require.NoError(t, err, "get notification hints should not error")
+		assert.Equal(t, ids[3:], hintBlockIDs(second))
+
+		// leased hints are not handed out again until deleted or expired
+		hints, err := store.GetNotificationHints(context.Background(), 10)
+		require.NoError(t, err, "get notification hints should not error")
+		assert.Empty(t, hints)
+
+		err = store.DeleteNotificationHints(context.Background(), ids)
+		require.NoError(t, err, "delete notification hints should not error")
+		for _, id := range ids {
+			_, err = store.GetNotificationHint(context.Background(), id)
+			assert.True(t, model.IsErrNotFound(err), "error should be of type store.ErrNotFound")
+		}
+	})
+
+	t.Run("concurrent workers get disjoint hints", func(t *testing.T) {
+		ids := createDueHints(t, 10)
+
+		var wg sync.WaitGroup
+		results := make([][]*model.NotificationHint, 2)
+		errs := make([]error, 2)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = store.GetNotificationHints(context.Background(), 10)
+			}(i)
+		}
+		wg.Wait()
+
+		seen := map[string]bool{}
+		for i := range results {
+			require.NoError(t, errs[i], "get notification hints should not error")
+			for _, hint := range results[i] {
+				assert.False(t, seen[hint.BlockID], "hint %s handed to both workers", hint.BlockID)
+				seen[hint.BlockID] = true
+			}
+		}
+		assert.Len(t, seen, len(ids))
+
+		err := store.DeleteNotificationHints(context.Background(), ids)
+		require.NoError(t, err, "delete notification hints should not error")
+	})
+
+	t.Run("delete notification hints ignores missing hints", func(t *testing.T) {
+		err := store.DeleteNotificationHints(context.Background(), []string{utils.NewID(utils.IDTypeBlock)})
+		require.NoError(t, err, "delete notification hints should not error")
+
+		err = store.DeleteNotificationHints(context.Background(), nil)
+		require.NoError(t, err, "delete notification hints should not error")
+	})
+}
+
 func emptyNotificationHintTable(store store.Store) error {
 	for {
-		hint, err := store.GetNextNotificationHint(false)
+		hint, err := store.GetNextNotificationHint(context.Background(), false)
 		if model.IsErrNotFound(err) {
 			break
 		}
@@ -247,7 +399,7 @@ func emptyNotificationHintTable(store store.Store) error {
 			return err
 		}
 
-		err = store.DeleteNotificationHint(hint.BlockID)
+		err = store.DeleteNotificationHint(context.Background(), hint.BlockID)
 		if err != nil {
 			return err
 		}
@@ -1,12 +1,14 @@
 package storetests
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,11 +25,41 @@ func StoreTestSessionStore(t *testing.T, setup func(t *testing.T) (store.Store,
 		testGetActiveUserCount(t, store)
 	})
 
+	t.Run("GetActiveUserCountByTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetActiveUserCountByTeam(t, store)
+	})
+
+	t.Run("GetActiveUserCountsByDay", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetActiveUserCountsByDay(t, store)
+	})
+
 	t.Run("UpdateSession", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
 		testUpdateSession(t, store)
 	})
+
+	t.Run("GetSessionWithUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetSessionWithUser(t, store)
+	})
+
+	t.Run("GetSessionWithPolicy", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetSessionWithPolicy(t, store)
+	})
+
+	t.Run("CleanUpSessions", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCleanUpSessions(t, store)
+	})
 }
 
 func testCreateAndGetAndDeleteSession(t *testing.T, store store.Store) {
@@ -37,32 +69,32 @@ func testCreateAndGetAndDeleteSession(t *testing.T, store store.Store) {
 	}
 
 	t.Run("CreateAndGetSession", func(t *testing.T) {
-		err := store.CreateSession(session)
+		err := store.CreateSession(context.Background(), session)
 		require.NoError(t, err)
 
-		got, err := store.GetSession(session.Token, 60*60)
+		got, err := store.GetSession(context.Background(), session.Token, 60*60)
 		require.NoError(t, err)
 		require.Equal(t, session, got)
 	})
 
 	t.Run("Get nonexistent session", func(t *testing.T) {
-		got, err := store.GetSession("nonexistent-token", 60*60)
+		got, err := store.GetSession(context.Background(), "nonexistent-token", 60*60)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, got)
 	})
 
 	t.Run("DeleteAndGetSession", func(t *testing.T) {
-		err := store.DeleteSession(session.ID)
+		err := store.DeleteSession(context.Background(), session.ID)
 		require.NoError(t, err)
 
-		_, err = store.GetSession(session.Token, 60*60)
+		_, err = store.GetSession(context.Background(), session.Token, 60*60)
 		require.Error(t, err)
 	})
 }
 
 func testGetActiveUserCount(t *testing.T, store store.Store) {
 	t.Run("no active user", func(t *testing.T) {
-		count, err := store.GetActiveUserCount(60)
+		count, err := store.GetActiveUserCount(context.Background(), 60)
 		require.NoError(t, err)
 		require.Equal(t, 0, count)
 	})
@@ -76,16 +108,92 @@ func testGetActiveUserCount(t *testing.T, store store.Store) {
 				UserID: fmt.Sprintf("user-id-%d", i),
 				Token:  fmt.Sprintf("token-%d", i),
 			}
-			err := store.CreateSession(session)
+			err := store.CreateSession(context.Background(), session)
 			require.NoError(t, err)
 		}
 
-		got, err := store.GetActiveUserCount(60)
+		got, err := store.GetActiveUserCount(context.Background(), 60)
 		require.NoError(t, err)
 		require.Equal(t, count, got)
 	})
 }
 
+func createActiveUserSessions(t *testing.T, store store.Store, userIDs ...string) {
+	for i, userID := range userIDs {
+		session := &model.Session{
+			ID:     fmt.Sprintf("id-%d", i),
+			UserID: userID,
+			Token:  fmt.Sprintf("token-%d", i),
+		}
+		require.NoError(t, store.CreateSession(context.Background(), session))
+	}
+}
+
+func testGetActiveUserCountByTeam(t *testing.T, store store.Store) {
+	since := utils.GetMillis() - time.Minute.Milliseconds()
+
+	t.Run("no active user", func(t *testing.T) {
+		count, err := store.GetActiveUserCountByTeam(context.Background(), testTeamID, since)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+
+	createActiveUserSessions(t, store, "user-id-1", "user-id-2", "user-id-1")
+
+	t.Run("counts distinct active users", func(t *testing.T) {
+		count, err := store.GetActiveUserCountByTeam(context.Background(), testTeamID, since)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("ignores sessions older than since", func(t *testing.T) {
+		later := utils.GetMillis() + time.Minute.Milliseconds()
+		count, err := store.GetActiveUserCountByTeam(context.Background(), testTeamID, later)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
+
+func testGetActiveUserCountsByDay(t *testing.T, store store.Store) {
+	createActiveUserSessions(t, store, "user-id-1", "user-id-2", "user-id-1")
+
+	now := utils.GetMillis()
+	from := now - (48 * time.Hour).Milliseconds()
+	to := now + 1
+	dayStarts := utils.DayStartsInRange(from, to, time.Local)
+	require.Len(t, dayStarts, 3)
+
+	t.Run("buckets distinct users by day including empty days", func(t *testing.T) {
+		counts, err := store.GetActiveUserCountsByDay(context.Background(), testTeamID, from, to)
+		require.NoError(t, err)
+		require.Equal(t, map[int64]int{
+			dayStarts[0]: 0,
+			dayStarts[1]: 0,
+			dayStarts[2]: 2,
+		}, counts)
+	})
+
+	t.Run("day buckets start at local midnight", func(t *testing.T) {
+		for _, dayStart := range dayStarts {
+			day := utils.GetTimeForMillis(dayStart).In(time.Local)
+			require.Zero(t, day.Hour())
+			require.Zero(t, day.Minute())
+		}
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		counts, err := store.GetActiveUserCountsByDay(context.Background(), testTeamID, now, now)
+		require.NoError(t, err)
+		require.Empty(t, counts)
+	})
+
+	t.Run("range too long", func(t *testing.T) {
+		longFrom := now - (model.MaxActiveUserCountDays+1)*(24*time.Hour).Milliseconds()
+		_, err := store.GetActiveUserCountsByDay(context.Background(), testTeamID, longFrom, now)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
 func testUpdateSession(t *testing.T, store store.Store) {
 	session := &model.Session{
 		ID:    "session-id",
@@ -93,15 +201,236 @@ func testUpdateSession(t *testing.T, store store.Store) {
 		Props: map[string]interface{}{"field1": "A"},
 	}
 
-	err := store.CreateSession(session)
+	err := store.CreateSession(context.Background(), session)
 	require.NoError(t, err)
 
 	// update session
 	session.Props["field1"] = "B"
-	err = store.UpdateSession(session)
+	err = store.UpdateSession(context.Background(), session)
 	require.NoError(t, err)
 
-	got, err := store.GetSession(session.Token, 60)
+	got, err := store.GetSession(context.Background(), session.Token, 60)
 	require.NoError(t, err)
 	require.Equal(t, session, got)
 }
+
+func testGetSessionWithUser(t *testing.T, store store.Store) {
+	user := &model.User{
+		ID:       "user-id",
+		Username: "username",
+		Email:    "user@example.com",
+	}
+	_, err := store.CreateUser(context.Background(), user)
+	require.NoError(t, err)
+
+	session := &model.Session{
+		ID:     "session-id",
+		Token:  "token",
+		UserID: user.ID,
+		Props:  map[string]interface{}{"field": "value"},
+	}
+	require.NoError(t, store.CreateSession(context.Background(), session))
+
+	t.Run("Valid session", func(t *testing.T) {
+		gotSession, gotUser, err := store.GetSessionWithUser(context.Background(), session.Token, utils.GetMillis(), time.Hour, 24*time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, session.ID, gotSession.ID)
+		require.Equal(t, session.Props, gotSession.Props)
+		require.Equal(t, user.ID, gotUser.ID)
+		require.Equal(t, user.Username, gotUser.Username)
+		require.Equal(t, user.Email, gotUser.Email)
+	})
+
+	t.Run("Nonexistent session", func(t *testing.T) {
+		gotSession, gotUser, err := store.GetSessionWithUser(context.Background(), "nonexistent-token", utils.GetMillis(), time.Hour, 24*time.Hour)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, gotSession)
+		require.Nil(t, gotUser)
+	})
+
+	t.Run("Idle timeout exceeded", func(t *testing.T) {
+		later := utils.GetMillis() + (2 * time.Hour).Milliseconds()
+		_, _, err := store.GetSessionWithUser(context.Background(), session.Token, later, time.Hour, 24*time.Hour)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("Max lifetime exceeded", func(t *testing.T) {
+		later := utils.GetMillis() + (2 * time.Hour).Milliseconds()
+		_, _, err := store.GetSessionWithUser(context.Background(), session.Token, later, 0, time.Hour)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("Zero durations disable the bounds", func(t *testing.T) {
+		later := utils.GetMillis() + (48 * time.Hour).Milliseconds()
+		_, _, err := store.GetSessionWithUser(context.Background(), session.Token, later, 0, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("Session without user", func(t *testing.T) {
+		orphanSession := &model.Session{
+			ID:     "orphan-session-id",
+			Token:  "orphan-token",
+			UserID: "nonexistent-user",
+			Props:  map[string]interface{}{},
+		}
+		require.NoError(t, store.CreateSession(context.Background(), orphanSession))
+
+		_, _, err := store.GetSessionWithUser(context.Background(), orphanSession.Token, utils.GetMillis(), time.Hour, 24*time.Hour)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("Session of a deactivated user", func(t *testing.T) {
+		deactivatedUser := &model.User{
+			ID:       "deactivated-user-id",
+			Username: "deactivated-username",
+			Email:    "deactivated@example.com",
+		}
+		_, err := store.CreateUser(context.Background(), deactivatedUser)
+		require.NoError(t, err)
+		require.NoError(t, store.DeactivateUser(context.Background(), deactivatedUser.ID))
+
+		// deactivating the user deletes its sessions, so the session
+		// is created afterwards to check that the user is filtered out
+		deactivatedSession := &model.Session{
+			ID:     "deactivated-session-id",
+			Token:  "deactivated-token",
+			UserID: deactivatedUser.ID,
+			Props:  map[string]interface{}{},
+		}
+		require.NoError(t, store.CreateSession(context.Background(), deactivatedSession))
+
+		gotSession, gotUser, err := store.GetSessionWithUser(context.Background(), deactivatedSession.Token, utils.GetMillis(), time.Hour, 24*time.Hour)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, gotSession)
+		require.Nil(t, gotUser)
+	})
+}
+
+func testGetSessionWithPolicy(t *testing.T, store store.Store) {
+	session := &model.Session{
+		ID:    "session-id",
+		Token: "token",
+		Props: map[string]interface{}{},
+	}
+	require.NoError(t, store.CreateSession(context.Background(), session))
+
+	t.Run("Valid session", func(t *testing.T) {
+		got, err := store.GetSessionWithPolicy(context.Background(), session.Token, utils.GetMillis(), time.Hour, 24*time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, session.ID, got.ID)
+		require.NotZero(t, got.CreateAt)
+		require.Equal(t, got.CreateAt, got.UpdateAt)
+	})
+
+	t.Run("Nonexistent session", func(t *testing.T) {
+		got, err := store.GetSessionWithPolicy(context.Background(), "nonexistent-token", utils.GetMillis(), time.Hour, 24*time.Hour)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, got)
+	})
+
+	t.Run("Idle timeout exceeded", func(t *testing.T) {
+		later := utils.GetMillis() + (2 * time.Hour).Milliseconds()
+		_, err := store.GetSessionWithPolicy(context.Background(), session.Token, later, time.Hour, 24*time.Hour)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("Zero durations disable the bounds", func(t *testing.T) {
+		later := utils.GetMillis() + (48 * time.Hour).Milliseconds()
+		_, err := store.GetSessionWithPolicy(context.Background(), session.Token, later, 0, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("Refresh bumps only the idle clock", func(t *testing.T) {
+		before, err := store.GetSessionWithPolicy(context.Background(), session.Token, utils.GetMillis(), time.Hour, 24*time.Hour)
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.RefreshSession(context.Background(), session))
+
+		after, err := store.GetSessionWithPolicy(context.Background(), session.Token, utils.GetMillis(), time.Hour, 24*time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, before.CreateAt, after.CreateAt)
+		require.Greater(t, after.UpdateAt, before.UpdateAt)
+
+		// a refreshed session still expires once its max lifetime is over
+		maxLifetime := time.Duration(after.UpdateAt-after.CreateAt) * time.Millisecond
+		_, err = store.GetSessionWithPolicy(context.Background(), session.Token, after.UpdateAt, time.Hour, maxLifetime)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testCleanUpSessions(t *testing.T, store store.Store) {
+	oldSession := &model.Session{
+		ID:    "old-session-id",
+		Token: "old-token",
+		Props: map[string]interface{}{},
+	}
+	require.NoError(t, store.CreateSession(context.Background(), oldSession))
+
+	time.Sleep(100 * time.Millisecond)
+
+	newSession := &model.Session{
+		ID:    "new-session-id",
+		Token: "new-token",
+		Props: map[string]interface{}{},
+	}
+	require.NoError(t, store.CreateSession(context.Background(), newSession))
+
+	// refreshing keeps the old session out of the idle timeout, but not
+	// out of the max lifetime
+	require.NoError(t, store.RefreshSession(context.Background(), oldSession))
+
+	t.Run("Idle timeout keeps refreshed sessions", func(t *testing.T) {
+		_, err := store.CleanUpSessions(context.Background(), 50*time.Millisecond, 0, 0)
+		require.NoError(t, err)
+
+		_, err = store.GetSessionWithPolicy(context.Background(), oldSession.Token, utils.GetMillis(), 0, 0)
+		require.NoError(t, err)
+		_, err = store.GetSessionWithPolicy(context.Background(), newSession.Token, utils.GetMillis(), 0, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("Max lifetime purges old sessions", func(t *testing.T) {
+		deleted, err := store.CleanUpSessions(context.Background(), time.Hour, 50*time.Millisecond, 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+
+		_, err = store.GetSessionWithPolicy(context.Background(), oldSession.Token, utils.GetMillis(), 0, 0)
+		require.True(t, model.IsErrNotFound(err))
+		_, err = store.GetSessionWithPolicy(context.Background(), newSession.Token, utils.GetMillis(), 0, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("Batched clean up removes all the expired sessions", func(t *testing.T) {
+		const expiredCount = 5000
+		for i := 0; i < expiredCount; i++ {
+			session := &model.Session{
+				ID:    fmt.Sprintf("expired-session-%d", i),
+				Token: fmt.Sprintf("expired-token-%d", i),
+				Props: map[string]interface{}{},
+			}
+			require.NoError(t, store.CreateSession(context.Background(), session))
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		require.NoError(t, store.RefreshSession(context.Background(), newSession))
+
+		// each batch deletes at most 1000 sessions, so it takes several
+		// batches to delete them all
+		deleted, err := store.CleanUpSessions(context.Background(), 50*time.Millisecond, 0, 1000)
+		require.NoError(t, err)
+		require.Equal(t, int64(expiredCount), deleted)
+
+		_, err = store.GetSessionWithPolicy(context.Background(), "expired-token-0", utils.GetMillis(), 0, 0)
+		require.True(t, model.IsErrNotFound(err))
+		_, err = store.GetSessionWithPolicy(context.Background(), fmt.Sprintf("expired-token-%d", expiredCount-1), utils.GetMillis(), 0, 0)
+		require.True(t, model.IsErrNotFound(err))
+		_, err = store.GetSessionWithPolicy(context.Background(), newSession.Token, utils.GetMillis(), 0, 0)
+		require.NoError(t, err)
+
+		// nothing is left to delete
+		deleted, err = store.CleanUpSessions(context.Background(), 50*time.Millisecond, 0, 1000)
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+	})
+}
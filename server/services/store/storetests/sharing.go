@@ -1,10 +1,13 @@
 package storetests
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,6 +17,31 @@ func StoreTestSharingStore(t *testing.T, setup func(t *testing.T) (store.Store,
 		defer tearDown()
 		testUpsertSharingAndGetSharing(t, store)
 	})
+	t.Run("GetSharingForBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetSharingForBoards(t, store)
+	})
+	t.Run("SharingExpiration", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSharingExpiration(t, store)
+	})
+	t.Run("RotateSharingToken", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRotateSharingToken(t, store)
+	})
+	t.Run("UpsertBoardSnapshotAndGetBoardSnapshot", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpsertBoardSnapshotAndGetBoardSnapshot(t, store)
+	})
+	t.Run("ShareTokens", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testShareTokens(t, store)
+	})
 }
 
 func testUpsertSharingAndGetSharing(t *testing.T, store store.Store) {
@@ -25,9 +53,9 @@ func testUpsertSharingAndGetSharing(t *testing.T, store store.Store) {
 			ModifiedBy: testUserID,
 		}
 
-		err := store.UpsertSharing(sharing)
+		err := store.UpsertSharing(context.Background(), sharing)
 		require.NoError(t, err)
-		newSharing, err := store.GetSharing("sharing-id")
+		newSharing, err := store.GetSharing(context.Background(), "sharing-id")
 		require.NoError(t, err)
 		newSharing.UpdateAt = 0
 		require.Equal(t, sharing, *newSharing)
@@ -40,21 +68,248 @@ func testUpsertSharingAndGetSharing(t *testing.T, store store.Store) {
 			ModifiedBy: "user-id2",
 		}
 
-		newSharing, err := store.GetSharing("sharing-id")
+		newSharing, err := store.GetSharing(context.Background(), "sharing-id")
 		require.NoError(t, err)
 		newSharing.UpdateAt = 0
 		require.NotEqual(t, sharing, *newSharing)
 
-		err = store.UpsertSharing(sharing)
+		err = store.UpsertSharing(context.Background(), sharing)
 		require.NoError(t, err)
-		newSharing, err = store.GetSharing("sharing-id")
+		newSharing, err = store.GetSharing(context.Background(), "sharing-id")
 		require.NoError(t, err)
 		newSharing.UpdateAt = 0
 		require.Equal(t, sharing, *newSharing)
 	})
 	t.Run("Get not existing sharing", func(t *testing.T) {
-		_, err := store.GetSharing("not-existing")
+		_, err := store.GetSharing(context.Background(), "not-existing")
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 	})
 }
+
+func testGetSharingForBoards(t *testing.T, store store.Store) {
+	t.Run("no boards", func(t *testing.T) {
+		sharingMap, err := store.GetSharingForBoards(context.Background(), []string{})
+		require.NoError(t, err)
+		require.Empty(t, sharingMap)
+	})
+
+	t.Run("only enabled sharing is returned", func(t *testing.T) {
+		sharings := []model.Sharing{
+			{ID: "board-1", Enabled: true, Token: "token1", ModifiedBy: testUserID},
+			{ID: "board-2", Enabled: false, Token: "token2", ModifiedBy: testUserID},
+			{ID: "board-3", Enabled: true, Token: "token3", ModifiedBy: testUserID},
+			{ID: "board-4", Enabled: true, Token: "token4", ModifiedBy: testUserID},
+		}
+		for _, sharing := range sharings {
+			require.NoError(t, store.UpsertSharing(context.Background(), sharing))
+		}
+
+		sharingMap, err := store.GetSharingForBoards(context.Background(), []string{"board-1", "board-2", "board-3", "board-5"})
+		require.NoError(t, err)
+		require.Len(t, sharingMap, 2)
+		require.Equal(t, "token1", sharingMap["board-1"].Token)
+		require.Equal(t, "token3", sharingMap["board-3"].Token)
+		require.NotContains(t, sharingMap, "board-2")
+		require.NotContains(t, sharingMap, "board-4")
+	})
+}
+
+func testSharingExpiration(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	sharings := []model.Sharing{
+		{ID: "never-expires", Enabled: true, Token: "token1", ModifiedBy: testUserID},
+		{ID: "expires-later", Enabled: true, Token: "token2", ModifiedBy: testUserID, ExpireAt: now + 60*60*1000},
+		{ID: "expired", Enabled: true, Token: "token3", ModifiedBy: testUserID, ExpireAt: now - 1},
+	}
+	for _, sharing := range sharings {
+		require.NoError(t, store.UpsertSharing(context.Background(), sharing))
+	}
+
+	t.Run("a zero expiration never expires", func(t *testing.T) {
+		sharing, err := store.GetSharing(context.Background(), "never-expires")
+		require.NoError(t, err)
+		require.Zero(t, sharing.ExpireAt)
+	})
+
+	t.Run("a future expiration is kept", func(t *testing.T) {
+		sharing, err := store.GetSharing(context.Background(), "expires-later")
+		require.NoError(t, err)
+		require.Equal(t, now+60*60*1000, sharing.ExpireAt)
+	})
+
+	t.Run("an expired sharing is not found", func(t *testing.T) {
+		sharing, err := store.GetSharing(context.Background(), "expired")
+		require.ErrorIs(t, err, model.ErrSharingExpired)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, sharing)
+	})
+
+	t.Run("expired sharing is not returned for boards", func(t *testing.T) {
+		sharingMap, err := store.GetSharingForBoards(context.Background(), []string{"never-expires", "expires-later", "expired"})
+		require.NoError(t, err)
+		require.Len(t, sharingMap, 2)
+		require.NotContains(t, sharingMap, "expired")
+	})
+
+	t.Run("clearing the expiration makes the sharing valid again", func(t *testing.T) {
+		sharing := sharings[2]
+		sharing.ExpireAt = 0
+		require.NoError(t, store.UpsertSharing(context.Background(), sharing))
+
+		got, err := store.GetSharing(context.Background(), "expired")
+		require.NoError(t, err)
+		require.Equal(t, "token3", got.Token)
+	})
+}
+
+func testRotateSharingToken(t *testing.T, store store.Store) {
+	t.Run("not existing sharing", func(t *testing.T) {
+		sharing, err := store.RotateSharingToken(context.Background(), "not-existing", testUserID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, sharing)
+	})
+
+	t.Run("rotate the token", func(t *testing.T) {
+		expireAt := utils.GetMillis() + 60*60*1000
+		require.NoError(t, store.UpsertSharing(context.Background(), model.Sharing{
+			ID:         "sharing-id",
+			Enabled:    true,
+			Token:      "token",
+			ModifiedBy: "user-id2",
+			ExpireAt:   expireAt,
+		}))
+		before, err := store.GetSharing(context.Background(), "sharing-id")
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+
+		sharing, err := store.RotateSharingToken(context.Background(), "sharing-id", testUserID)
+		require.NoError(t, err)
+		require.NotEmpty(t, sharing.Token)
+		require.NotEqual(t, "token", sharing.Token)
+		require.Equal(t, testUserID, sharing.ModifiedBy)
+		require.Greater(t, sharing.UpdateAt, before.UpdateAt)
+		require.True(t, sharing.Enabled)
+		require.Equal(t, expireAt, sharing.ExpireAt)
+
+		got, err := store.GetSharing(context.Background(), "sharing-id")
+		require.NoError(t, err)
+		require.Equal(t, sharing, got)
+	})
+}
+
+func testUpsertBoardSnapshotAndGetBoardSnapshot(t *testing.T, store store.Store) {
+	t.Run("No snapshot", func(t *testing.T) {
+		snapshot, err := store.GetBoardSnapshot(context.Background(), "board-id")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, snapshot)
+	})
+
+	t.Run("Publish and republish a snapshot", func(t *testing.T) {
+		snapshot := &model.BoardSnapshot{
+			BoardID: "board-id",
+			Board:   &model.Board{ID: "board-id", Title: "first"},
+			Blocks: []*model.Block{
+				{ID: "card-id", BoardID: "board-id", Type: model.TypeCard, Title: "card"},
+			},
+			CreatedBy: testUserID,
+		}
+		require.NoError(t, store.UpsertBoardSnapshot(context.Background(), snapshot))
+		require.NotZero(t, snapshot.CreateAt)
+
+		got, err := store.GetBoardSnapshot(context.Background(), "board-id")
+		require.NoError(t, err)
+		require.Equal(t, "first", got.Board.Title)
+		require.Len(t, got.Blocks, 1)
+		require.Equal(t, "card", got.Blocks[0].Title)
+		require.Equal(t, testUserID, got.CreatedBy)
+
+		require.NoError(t, store.UpsertBoardSnapshot(context.Background(), &model.BoardSnapshot{
+			BoardID:   "board-id",
+			Board:     &model.Board{ID: "board-id", Title: "second"},
+			CreatedBy: "other-user",
+		}))
+
+		got, err = store.GetBoardSnapshot(context.Background(), "board-id")
+		require.NoError(t, err)
+		require.Equal(t, "second", got.Board.Title)
+		require.Empty(t, got.Blocks)
+		require.Equal(t, "other-user", got.CreatedBy)
+	})
+
+	t.Run("Deleting a board removes its snapshot", func(t *testing.T) {
+		board, _, err := store.InsertBoardWithAdmin(context.Background(), &model.Board{
+			ID:     "snapshot-board",
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+		}, testUserID)
+		require.NoError(t, err)
+
+		require.NoError(t, store.UpsertBoardSnapshot(context.Background(), &model.BoardSnapshot{BoardID: board.ID, Board: board}))
+		require.NoError(t, store.DeleteBoard(context.Background(), board.ID, testUserID))
+
+		_, err = store.GetBoardSnapshot(context.Background(), board.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testShareTokens(t *testing.T, store store.Store) {
+	boardToken := &model.ShareToken{
+		BoardID:   "board-id",
+		Scope:     model.ShareTokenScopeBoard,
+		CreatedBy: testUserID,
+	}
+	require.NoError(t, store.CreateShareToken(context.Background(), boardToken))
+	require.NotEmpty(t, boardToken.ID)
+	require.NotEmpty(t, boardToken.Token)
+	require.False(t, boardToken.HasPassword)
+
+	cardToken := &model.ShareToken{
+		BoardID:      "board-id",
+		Scope:        model.ShareTokenScopeCard,
+		CardID:       "card-id",
+		PasswordHash: "hash",
+		ExpireAt:     utils.GetMillis() - 1000,
+		CreatedBy:    testUserID,
+	}
+	require.NoError(t, store.CreateShareToken(context.Background(), cardToken))
+	require.NotEqual(t, boardToken.Token, cardToken.Token)
+
+	t.Run("get share token", func(t *testing.T) {
+		shareToken, err := store.GetShareToken(context.Background(), cardToken.Token)
+		require.NoError(t, err)
+		require.Equal(t, cardToken, shareToken)
+		require.True(t, shareToken.HasPassword)
+		require.True(t, shareToken.IsExpired(utils.GetMillis()))
+
+		_, err = store.GetShareToken(context.Background(), "not-existing")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("get share tokens for board", func(t *testing.T) {
+		shareTokens, err := store.GetShareTokensForBoard(context.Background(), "board-id")
+		require.NoError(t, err)
+		require.Len(t, shareTokens, 2)
+
+		shareTokens, err = store.GetShareTokensForBoard(context.Background(), "other-board-id")
+		require.NoError(t, err)
+		require.Empty(t, shareTokens)
+	})
+
+	t.Run("invalid share token", func(t *testing.T) {
+		var errInvalid model.ErrInvalidShareToken
+		err := store.CreateShareToken(context.Background(), &model.ShareToken{BoardID: "board-id", Scope: model.ShareTokenScopeCard})
+		require.ErrorAs(t, err, &errInvalid)
+	})
+
+	t.Run("delete share token", func(t *testing.T) {
+		err := store.DeleteShareToken(context.Background(), "other-board-id", boardToken.ID, "user-id")
+		require.True(t, model.IsErrNotFound(err))
+
+		require.NoError(t, store.DeleteShareToken(context.Background(), "board-id", boardToken.ID, "user-id"))
+
+		_, err = store.GetShareToken(context.Background(), boardToken.Token)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
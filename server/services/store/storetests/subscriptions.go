@@ -4,13 +4,16 @@
 package storetests
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
 )
 
 //nolint:dupl
@@ -27,6 +30,12 @@ func StoreTestSubscriptionsStore(t *testing.T, setup func(t *testing.T) (store.S
 		testDeleteSubscription(t, store)
 	})
 
+	t.Run("DeleteSubscriptionsForBlock", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteSubscriptionsForBlock(t, store)
+	})
+
 	t.Run("UndeleteSubscription", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -50,6 +59,17 @@ func StoreTestSubscriptionsStore(t *testing.T, setup func(t *testing.T) (store.S
 		defer tearDown()
 		testGetSubscribersForBlock(t, store)
 	})
+
+	t.Run("SubscriptionEvents", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSubscriptionEvents(t, store)
+	})
+	t.Run("UpdateSubscriberNotifiedAt", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateSubscriberNotifiedAt(t, store)
+	})
 }
 
 func testCreateSubscription(t *testing.T, store store.Store) {
@@ -65,7 +85,7 @@ func testCreateSubscription(t *testing.T, store store.Store) {
 					SubscriberType: "user",
 					SubscriberID:   user.ID,
 				}
-				subNew, err := store.CreateSubscription(sub)
+				subNew, err := store.CreateSubscription(context.Background(), sub)
 				require.NoError(t, err, "create subscription should not error")
 
 				assert.NotZero(t, subNew.NotifiedAt)
@@ -76,7 +96,7 @@ func testCreateSubscription(t *testing.T, store store.Store) {
 
 		// ensure each user has the right number of subscriptions
 		for i, user := range users {
-			subs, err := store.GetSubscriptions(user.ID)
+			subs, err := store.GetSubscriptions(context.Background(), user.ID)
 			require.NoError(t, err, "get subscriptions should not error")
 			assert.Len(t, subs, i)
 		}
@@ -93,7 +113,7 @@ func testCreateSubscription(t *testing.T, store store.Store) {
 			SubscriberType: "user",
 			SubscriberID:   user.ID,
 		}
-		subNew, err := store.CreateSubscription(sub)
+		subNew, err := store.CreateSubscription(context.Background(), sub)
 		require.NoError(t, err, "create subscription should not error")
 
 		sub = &model.Subscription{
@@ -103,7 +123,7 @@ func testCreateSubscription(t *testing.T, store store.Store) {
 			SubscriberID:   user.ID,
 		}
 
-		subDup, err := store.CreateSubscription(sub)
+		subDup, err := store.CreateSubscription(context.Background(), sub)
 		require.NoError(t, err, "create duplicate subscription should not error")
 
 		assert.Equal(t, subNew.BlockID, subDup.BlockID)
@@ -117,23 +137,23 @@ func testCreateSubscription(t *testing.T, store store.Store) {
 
 		sub := &model.Subscription{}
 
-		_, err := store.CreateSubscription(sub)
+		_, err := store.CreateSubscription(context.Background(), sub)
 		assert.ErrorAs(t, err, &model.ErrInvalidSubscription{}, "invalid subscription should error")
 
 		sub.BlockType = block.Type
-		_, err = store.CreateSubscription(sub)
+		_, err = store.CreateSubscription(context.Background(), sub)
 		assert.ErrorAs(t, err, &model.ErrInvalidSubscription{}, "invalid subscription should error")
 
 		sub.BlockID = block.ID
-		_, err = store.CreateSubscription(sub)
+		_, err = store.CreateSubscription(context.Background(), sub)
 		assert.ErrorAs(t, err, &model.ErrInvalidSubscription{}, "invalid subscription should error")
 
 		sub.SubscriberType = "user"
-		_, err = store.CreateSubscription(sub)
+		_, err = store.CreateSubscription(context.Background(), sub)
 		assert.ErrorAs(t, err, &model.ErrInvalidSubscription{}, "invalid subscription should error")
 
 		sub.SubscriberID = user.ID
-		subNew, err := store.CreateSubscription(sub)
+		subNew, err := store.CreateSubscription(context.Background(), sub)
 		assert.NoError(t, err, "valid subscription should not error")
 
 		assert.NoError(t, subNew.IsValid(), "created subscription should be valid")
@@ -151,29 +171,160 @@ func testDeleteSubscription(t *testing.T, s store.Store) {
 			SubscriberType: "user",
 			SubscriberID:   user.ID,
 		}
-		subNew, err := s.CreateSubscription(sub)
+		subNew, err := s.CreateSubscription(context.Background(), sub)
 		require.NoError(t, err, "create subscription should not error")
 
 		// check the subscription exists
-		subs, err := s.GetSubscriptions(user.ID)
+		subs, err := s.GetSubscriptions(context.Background(), user.ID)
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Len(t, subs, 1)
 		assert.Equal(t, subNew.BlockID, subs[0].BlockID)
 		assert.Equal(t, subNew.SubscriberID, subs[0].SubscriberID)
 
-		err = s.DeleteSubscription(block.ID, user.ID)
+		count, err := s.DeleteSubscription(context.Background(), block.ID, user.ID)
 		require.NoError(t, err, "delete subscription should not error")
+		require.EqualValues(t, 1, count)
 
 		// check the subscription was deleted
-		subs, err = s.GetSubscriptions(user.ID)
+		subs, err = s.GetSubscriptions(context.Background(), user.ID)
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Empty(t, subs)
+
+		// deleting it again doesn't affect anything
+		count, err = s.DeleteSubscription(context.Background(), block.ID, user.ID)
+		require.NoError(t, err, "delete subscription again should not error")
+		require.Zero(t, count)
 	})
 
 	t.Run("delete non-existent subscription", func(t *testing.T) {
-		err := s.DeleteSubscription("bogus", "bogus")
-		require.Error(t, err, "delete non-existent subscription should error")
+		count, err := s.DeleteSubscription(context.Background(), "bogus", "bogus")
+		require.NoError(t, err, "delete non-existent subscription should not error")
+		require.Zero(t, count)
+	})
+}
+
+func testDeleteSubscriptionsForBlock(t *testing.T, s store.Store) {
+	t.Run("delete subscriptions for block", func(t *testing.T) {
+		users := createTestUsers(t, s, 3)
+		blocks := createTestBlocks(t, s, users[0].ID, 2)
+
+		for _, block := range blocks {
+			for _, user := range users {
+				sub := &model.Subscription{
+					BlockType:      block.Type,
+					BlockID:        block.ID,
+					SubscriberType: "user",
+					SubscriberID:   user.ID,
+				}
+				_, err := s.CreateSubscription(context.Background(), sub)
+				require.NoError(t, err, "create subscription should not error")
+			}
+		}
+
+		err := s.DeleteSubscriptionsForBlock(context.Background(), blocks[0].ID)
+		require.NoError(t, err, "delete subscriptions for block should not error")
+
+		count, err := s.GetSubscribersCountForBlock(context.Background(), blocks[0].ID)
+		require.NoError(t, err, "get subscribers count should not error")
+		assert.Zero(t, count)
+
+		// subscriptions of other blocks are untouched
+		count, err = s.GetSubscribersCountForBlock(context.Background(), blocks[1].ID)
+		require.NoError(t, err, "get subscribers count should not error")
+		assert.Equal(t, len(users), count)
+	})
+
+	t.Run("delete subscriptions for block without subscriptions", func(t *testing.T) {
+		err := s.DeleteSubscriptionsForBlock(context.Background(), "bogus")
+		require.NoError(t, err, "delete subscriptions for block should not error")
+	})
+
+	t.Run("deleting a block removes its subscriptions and hints", func(t *testing.T) {
+		user := createTestUsers(t, s, 1)[0]
+		block := createTestBlocks(t, s, user.ID, 1)[0]
+
+		sub := &model.Subscription{
+			BlockType:      block.Type,
+			BlockID:        block.ID,
+			SubscriberType: "user",
+			SubscriberID:   user.ID,
+		}
+		_, err := s.CreateSubscription(context.Background(), sub)
+		require.NoError(t, err, "create subscription should not error")
+
+		hint := &model.NotificationHint{
+			BlockType:    block.Type,
+			BlockID:      block.ID,
+			ModifiedByID: user.ID,
+		}
+		_, err = s.UpsertNotificationHint(context.Background(), hint, time.Second)
+		require.NoError(t, err, "upsert notification hint should not error")
+
+		_, err = s.DeleteBlock(context.Background(), block.ID, user.ID)
+		require.NoError(t, err, "delete block should not error")
+
+		count, err := s.GetSubscribersCountForBlock(context.Background(), block.ID)
+		require.NoError(t, err, "get subscribers count should not error")
+		assert.Zero(t, count)
+
+		_, err = s.GetSubscription(context.Background(), block.ID, user.ID)
 		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
+
+		_, err = s.GetNotificationHint(context.Background(), block.ID)
+		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
+	})
+
+	t.Run("deleting boards and blocks removes their subscriptions and hints", func(t *testing.T) {
+		user := createTestUsers(t, s, 1)[0]
+		board, err := s.InsertBoard(context.Background(), &model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: "team-id",
+			Type:   model.BoardTypeOpen,
+		}, user.ID)
+		require.NoError(t, err, "insert board should not error")
+
+		deleted := &model.Block{ID: utils.NewID(utils.IDTypeBlock), BoardID: board.ID, Type: model.TypeCard}
+		require.NoError(t, s.InsertBlock(context.Background(), deleted, user.ID), "insert block should not error")
+		live := createTestBlocks(t, s, user.ID, 1)[0]
+
+		for _, block := range []*model.Block{deleted, live} {
+			_, err = s.CreateSubscription(context.Background(), &model.Subscription{
+				BlockType:      block.Type,
+				BlockID:        block.ID,
+				SubscriberType: "user",
+				SubscriberID:   user.ID,
+			})
+			require.NoError(t, err, "create subscription should not error")
+
+			_, err = s.UpsertNotificationHint(context.Background(), &model.NotificationHint{
+				BlockType:    block.Type,
+				BlockID:      block.ID,
+				ModifiedByID: user.ID,
+			}, time.Second)
+			require.NoError(t, err, "upsert notification hint should not error")
+		}
+
+		err = s.DeleteBoardsAndBlocks(context.Background(), &model.DeleteBoardsAndBlocks{
+			Boards: []string{board.ID},
+			Blocks: []string{deleted.ID},
+		}, user.ID)
+		require.NoError(t, err, "delete boards and blocks should not error")
+
+		count, err := s.GetSubscribersCountForBlock(context.Background(), deleted.ID)
+		require.NoError(t, err, "get subscribers count should not error")
+		assert.Zero(t, count)
+
+		// the notification worker only gets the hints of live blocks
+		hintBlockIDs := []string{}
+		for {
+			hint, err := s.GetNextNotificationHint(context.Background(), true)
+			if model.IsErrNotFound(err) {
+				break
+			}
+			require.NoError(t, err, "get next notification hint should not error")
+			hintBlockIDs = append(hintBlockIDs, hint.BlockID)
+		}
+		assert.Equal(t, []string{live.ID}, hintBlockIDs)
 	})
 }
 
@@ -188,30 +339,30 @@ func testUndeleteSubscription(t *testing.T, s store.Store) {
 			SubscriberType: "user",
 			SubscriberID:   user.ID,
 		}
-		subNew, err := s.CreateSubscription(sub)
+		subNew, err := s.CreateSubscription(context.Background(), sub)
 		require.NoError(t, err, "create subscription should not error")
 
 		// check the subscription exists
-		subs, err := s.GetSubscriptions(user.ID)
+		subs, err := s.GetSubscriptions(context.Background(), user.ID)
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Len(t, subs, 1)
 		assert.Equal(t, subNew.BlockID, subs[0].BlockID)
 		assert.Equal(t, subNew.SubscriberID, subs[0].SubscriberID)
 
-		err = s.DeleteSubscription(block.ID, user.ID)
+		_, err = s.DeleteSubscription(context.Background(), block.ID, user.ID)
 		require.NoError(t, err, "delete subscription should not error")
 
 		// check the subscription was deleted
-		subs, err = s.GetSubscriptions(user.ID)
+		subs, err = s.GetSubscriptions(context.Background(), user.ID)
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Empty(t, subs)
 
 		// re-create the subscription
-		subUndeleted, err := s.CreateSubscription(sub)
+		subUndeleted, err := s.CreateSubscription(context.Background(), sub)
 		require.NoError(t, err, "create subscription should not error")
 
 		// check the undeleted subscription exists
-		subs, err = s.GetSubscriptions(user.ID)
+		subs, err = s.GetSubscriptions(context.Background(), user.ID)
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Len(t, subs, 1)
 		assert.Equal(t, subUndeleted.BlockID, subs[0].BlockID)
@@ -230,17 +381,17 @@ func testGetSubscription(t *testing.T, s store.Store) {
 			SubscriberType: "user",
 			SubscriberID:   user.ID,
 		}
-		subNew, err := s.CreateSubscription(sub)
+		subNew, err := s.CreateSubscription(context.Background(), sub)
 		require.NoError(t, err, "create subscription should not error")
 
 		// make sure subscription can be fetched
-		sub, err = s.GetSubscription(block.ID, user.ID)
+		sub, err = s.GetSubscription(context.Background(), block.ID, user.ID)
 		require.NoError(t, err, "get subscription should not error")
 		assert.Equal(t, subNew, sub)
 	})
 
 	t.Run("get non-existent subscription", func(t *testing.T) {
-		sub, err := s.GetSubscription("bogus", "bogus")
+		sub, err := s.GetSubscription(context.Background(), "bogus", "bogus")
 		require.Error(t, err, "get non-existent subscription should error")
 		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
 		require.Nil(t, sub, "get subscription should return nil")
@@ -260,23 +411,23 @@ func testGetSubscriptions(t *testing.T, store store.Store) {
 				SubscriberType: "user",
 				SubscriberID:   user.ID,
 			}
-			_, err := store.CreateSubscription(sub)
+			_, err := store.CreateSubscription(context.Background(), sub)
 			require.NoError(t, err, "create subscription should not error")
 		}
 
 		// ensure user has the right number of subscriptions
-		subs, err := store.GetSubscriptions(user.ID)
+		subs, err := store.GetSubscriptions(context.Background(), user.ID)
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Len(t, subs, len(blocks))
 
 		// ensure author has no subscriptions
-		subs, err = store.GetSubscriptions(author.ID)
+		subs, err = store.GetSubscriptions(context.Background(), author.ID)
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Empty(t, subs)
 	})
 
 	t.Run("get subscriptions for invalid user", func(t *testing.T) {
-		subs, err := store.GetSubscriptions("bogus")
+		subs, err := store.GetSubscriptions(context.Background(), "bogus")
 		require.NoError(t, err, "get subscriptions should not error")
 		assert.Empty(t, subs)
 	})
@@ -294,32 +445,157 @@ func testGetSubscribersForBlock(t *testing.T, store store.Store) {
 				SubscriberType: "user",
 				SubscriberID:   user.ID,
 			}
-			_, err := store.CreateSubscription(sub)
+			_, err := store.CreateSubscription(context.Background(), sub)
 			require.NoError(t, err, "create subscription should not error")
 		}
 
 		// make sure block[1] has the right number of users subscribed
-		subs, err := store.GetSubscribersForBlock(blocks[1].ID)
+		subs, err := store.GetSubscribersForBlock(context.Background(), blocks[1].ID, model.SubscriptionEventsAll)
 		require.NoError(t, err, "get subscribers for block should not error")
 		assert.Len(t, subs, 50)
 
-		count, err := store.GetSubscribersCountForBlock(blocks[1].ID)
+		count, err := store.GetSubscribersCountForBlock(context.Background(), blocks[1].ID)
 		require.NoError(t, err, "get subscribers for block should not error")
 		assert.Equal(t, 50, count)
 
 		// make sure block[0] has zero users subscribed
-		subs, err = store.GetSubscribersForBlock(blocks[0].ID)
+		subs, err = store.GetSubscribersForBlock(context.Background(), blocks[0].ID, model.SubscriptionEventsAll)
 		require.NoError(t, err, "get subscribers for block should not error")
 		assert.Empty(t, subs)
 
-		count, err = store.GetSubscribersCountForBlock(blocks[0].ID)
+		count, err = store.GetSubscribersCountForBlock(context.Background(), blocks[0].ID)
 		require.NoError(t, err, "get subscribers for block should not error")
 		assert.Zero(t, count)
 	})
 
 	t.Run("get subscribers for invalid block", func(t *testing.T) {
-		subs, err := store.GetSubscribersForBlock("bogus")
+		subs, err := store.GetSubscribersForBlock(context.Background(), "bogus", model.SubscriptionEventsAll)
 		require.NoError(t, err, "get subscribers for block should not error")
 		assert.Empty(t, subs)
 	})
 }
+
+func testSubscriptionEvents(t *testing.T, store store.Store) {
+	users := createTestUsers(t, store, 3)
+	blocks := createTestBlocks(t, store, users[0].ID, 1)
+
+	newSubscription := func(userID string, events model.SubscriptionEvents) *model.Subscription {
+		return &model.Subscription{
+			BlockType:      blocks[0].Type,
+			BlockID:        blocks[0].ID,
+			SubscriberType: "user",
+			SubscriberID:   userID,
+			Events:         events,
+		}
+	}
+
+	subscriberIDs := func(t *testing.T, events model.SubscriptionEvents) []string {
+		subs, err := store.GetSubscribersForBlock(context.Background(), blocks[0].ID, events)
+		require.NoError(t, err, "get subscribers for block should not error")
+
+		ids := []string{}
+		for _, sub := range subs {
+			ids = append(ids, sub.SubscriberID)
+		}
+		return ids
+	}
+
+	t.Run("subscriptions without events notify all of them", func(t *testing.T) {
+		sub, err := store.CreateSubscription(context.Background(), newSubscription(users[0].ID, 0))
+		require.NoError(t, err, "create subscription should not error")
+		assert.Equal(t, model.SubscriptionEventsAll, sub.Events)
+
+		sub, err = store.GetSubscription(context.Background(), blocks[0].ID, users[0].ID)
+		require.NoError(t, err, "get subscription should not error")
+		assert.Equal(t, model.SubscriptionEventsAll, sub.Events)
+	})
+
+	t.Run("subscriptions with events", func(t *testing.T) {
+		sub, err := store.CreateSubscription(context.Background(), newSubscription(users[1].ID, model.SubscriptionEventComments))
+		require.NoError(t, err, "create subscription should not error")
+		assert.Equal(t, model.SubscriptionEventComments, sub.Events)
+
+		events := model.SubscriptionEventStatusChange | model.SubscriptionEventAssignment
+		_, err = store.CreateSubscription(context.Background(), newSubscription(users[2].ID, events))
+		require.NoError(t, err, "create subscription should not error")
+
+		sub, err = store.GetSubscription(context.Background(), blocks[0].ID, users[2].ID)
+		require.NoError(t, err, "get subscription should not error")
+		assert.Equal(t, events, sub.Events)
+	})
+
+	t.Run("filter subscribers by event", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{users[0].ID, users[1].ID, users[2].ID}, subscriberIDs(t, model.SubscriptionEventsAll))
+		assert.ElementsMatch(t, []string{users[0].ID, users[1].ID, users[2].ID}, subscriberIDs(t, 0))
+		assert.ElementsMatch(t, []string{users[0].ID, users[1].ID}, subscriberIDs(t, model.SubscriptionEventComments))
+		assert.ElementsMatch(t, []string{users[0].ID, users[2].ID}, subscriberIDs(t, model.SubscriptionEventAssignment))
+		assert.ElementsMatch(t, []string{users[0].ID}, subscriberIDs(t, model.SubscriptionEventOtherChanges))
+	})
+
+	t.Run("update subscription", func(t *testing.T) {
+		sub, err := store.UpdateSubscription(context.Background(), newSubscription(users[1].ID, model.SubscriptionEventOtherChanges))
+		require.NoError(t, err, "update subscription should not error")
+		assert.Equal(t, model.SubscriptionEventOtherChanges, sub.Events)
+		assert.ElementsMatch(t, []string{users[0].ID}, subscriberIDs(t, model.SubscriptionEventComments))
+		assert.ElementsMatch(t, []string{users[0].ID, users[1].ID}, subscriberIDs(t, model.SubscriptionEventOtherChanges))
+
+		// updating to the same events is not an error
+		_, err = store.UpdateSubscription(context.Background(), newSubscription(users[1].ID, model.SubscriptionEventOtherChanges))
+		require.NoError(t, err, "update subscription should not error")
+
+		// resubscribing replaces the events
+		_, err = store.CreateSubscription(context.Background(), newSubscription(users[1].ID, model.SubscriptionEventComments))
+		require.NoError(t, err, "create subscription should not error")
+		assert.ElementsMatch(t, []string{users[0].ID, users[1].ID}, subscriberIDs(t, model.SubscriptionEventComments))
+	})
+
+	t.Run("update invalid subscription", func(t *testing.T) {
+		_, err := store.UpdateSubscription(context.Background(), newSubscription(users[1].ID, model.SubscriptionEventsAll+1))
+		assert.ErrorAs(t, err, &model.ErrInvalidSubscription{}, "invalid events should error")
+
+		_, err = store.UpdateSubscription(context.Background(), newSubscription("bogus", model.SubscriptionEventComments))
+		assert.True(t, model.IsErrNotFound(err), "update non-existent subscription should be not found")
+
+		_, err = store.DeleteSubscription(context.Background(), blocks[0].ID, users[2].ID)
+		require.NoError(t, err, "delete subscription should not error")
+		_, err = store.UpdateSubscription(context.Background(), newSubscription(users[2].ID, model.SubscriptionEventComments))
+		assert.True(t, model.IsErrNotFound(err), "update deleted subscription should be not found")
+	})
+}
+
+func testUpdateSubscriberNotifiedAt(t *testing.T, store store.Store) {
+	t.Run("update single subscriber", func(t *testing.T) {
+		users := createTestUsers(t, store, 2)
+		blocks := createTestBlocks(t, store, users[0].ID, 1)
+
+		for _, user := range users {
+			sub := &model.Subscription{
+				BlockType:      blocks[0].Type,
+				BlockID:        blocks[0].ID,
+				SubscriberType: "user",
+				SubscriberID:   user.ID,
+			}
+			_, err := store.CreateSubscription(context.Background(), sub)
+			require.NoError(t, err, "create subscription should not error")
+		}
+
+		notifiedAt := utils.GetMillis() + 1000
+		err := store.UpdateSubscriberNotifiedAt(context.Background(), blocks[0].ID, users[1].ID, notifiedAt)
+		require.NoError(t, err, "update subscriber notified_at should not error")
+
+		subs, err := store.GetSubscribersForBlock(context.Background(), blocks[0].ID, model.SubscriptionEventsAll)
+		require.NoError(t, err, "get subscribers for block should not error")
+		require.Len(t, subs, 2)
+
+		// subscribers are sorted by notified_at, oldest first
+		assert.Equal(t, users[0].ID, subs[0].SubscriberID)
+		assert.Less(t, subs[0].NotifiedAt, notifiedAt)
+		assert.Equal(t, users[1].ID, subs[1].SubscriberID)
+		assert.Equal(t, notifiedAt, subs[1].NotifiedAt)
+	})
+
+	t.Run("update non-existent subscriber", func(t *testing.T) {
+		err := store.UpdateSubscriberNotifiedAt(context.Background(), "bogus", "bogus", utils.GetMillis())
+		require.NoError(t, err, "update subscriber notified_at should not error")
+	})
+}
@@ -1,9 +1,12 @@
 package storetests
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,34 +34,145 @@ func StoreTestSystemStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testSetGetSystemSettings(t, store)
 	})
+
+	t.Run("GetTypedSystemSettings", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetTypedSystemSettings(t, store)
+	})
+
+	t.Run("CompareAndSetSystemSetting", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCompareAndSetSystemSetting(t, store)
+	})
 }
 
 func testSetGetSystemSettings(t *testing.T, store store.Store) {
 	t.Run("Get empty settings", func(t *testing.T) {
-		settings, err := store.GetSystemSettings()
+		settings, err := store.GetSystemSettings(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, dataMigrationSystemSettings, settings)
 	})
 
 	t.Run("Set, update and get multiple settings", func(t *testing.T) {
-		err := store.SetSystemSetting("test-1", "test-value-1")
+		err := store.SetSystemSetting(context.Background(), "test-1", "test-value-1")
 		require.NoError(t, err)
-		err = store.SetSystemSetting("test-2", "test-value-2")
+		err = store.SetSystemSetting(context.Background(), "test-2", "test-value-2")
 		require.NoError(t, err)
-		settings, err := store.GetSystemSettings()
+		settings, err := store.GetSystemSettings(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, addBaseSettings(map[string]string{"test-1": "test-value-1", "test-2": "test-value-2"}), settings)
 
-		err = store.SetSystemSetting("test-2", "test-value-updated-2")
+		err = store.SetSystemSetting(context.Background(), "test-2", "test-value-updated-2")
 		require.NoError(t, err)
-		settings, err = store.GetSystemSettings()
+		settings, err = store.GetSystemSettings(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, addBaseSettings(map[string]string{"test-1": "test-value-1", "test-2": "test-value-updated-2"}), settings)
 	})
 
 	t.Run("Get a single setting", func(t *testing.T) {
-		value, err := store.GetSystemSetting("test-1")
+		value, err := store.GetSystemSetting(context.Background(), "test-1")
 		require.NoError(t, err)
 		require.Equal(t, "test-value-1", value)
 	})
 }
+
+func testGetTypedSystemSettings(t *testing.T, store store.Store) {
+	t.Run("Missing settings", func(t *testing.T) {
+		boolValue, err := store.GetSystemSettingBool(context.Background(), "missing")
+		require.NoError(t, err)
+		require.False(t, boolValue)
+
+		intValue, err := store.GetSystemSettingInt(context.Background(), "missing")
+		require.NoError(t, err)
+		require.Zero(t, intValue)
+	})
+
+	t.Run("Valid settings", func(t *testing.T) {
+		require.NoError(t, store.SetSystemSetting(context.Background(), "bool-setting", "true"))
+		require.NoError(t, store.SetSystemSetting(context.Background(), "int-setting", "42"))
+
+		boolValue, err := store.GetSystemSettingBool(context.Background(), "bool-setting")
+		require.NoError(t, err)
+		require.True(t, boolValue)
+
+		intValue, err := store.GetSystemSettingInt(context.Background(), "int-setting")
+		require.NoError(t, err)
+		require.Equal(t, 42, intValue)
+	})
+
+	t.Run("Invalid settings", func(t *testing.T) {
+		require.NoError(t, store.SetSystemSetting(context.Background(), "invalid-setting", "not-a-number"))
+
+		_, err := store.GetSystemSettingBool(context.Background(), "invalid-setting")
+		require.ErrorContains(t, err, "invalid-setting is not a valid boolean")
+
+		_, err = store.GetSystemSettingInt(context.Background(), "invalid-setting")
+		require.ErrorContains(t, err, "invalid-setting is not a valid integer")
+	})
+}
+
+func testCompareAndSetSystemSetting(t *testing.T, store store.Store) {
+	t.Run("Missing setting with empty expected value", func(t *testing.T) {
+		changed, err := store.CompareAndSetSystemSetting(context.Background(), "cas-setting", "", "first")
+		require.NoError(t, err)
+		require.True(t, changed)
+
+		value, err := store.GetSystemSetting(context.Background(), "cas-setting")
+		require.NoError(t, err)
+		require.Equal(t, "first", value)
+	})
+
+	t.Run("Missing setting with non empty expected value", func(t *testing.T) {
+		changed, err := store.CompareAndSetSystemSetting(context.Background(), "other-setting", "old", "new")
+		require.NoError(t, err)
+		require.False(t, changed)
+
+		value, err := store.GetSystemSetting(context.Background(), "other-setting")
+		require.NoError(t, err)
+		require.Empty(t, value)
+	})
+
+	t.Run("Matching and mismatching expected value", func(t *testing.T) {
+		changed, err := store.CompareAndSetSystemSetting(context.Background(), "cas-setting", "stale", "second")
+		require.NoError(t, err)
+		require.False(t, changed)
+
+		changed, err = store.CompareAndSetSystemSetting(context.Background(), "cas-setting", "", "second")
+		require.NoError(t, err)
+		require.False(t, changed)
+
+		changed, err = store.CompareAndSetSystemSetting(context.Background(), "cas-setting", "first", "second")
+		require.NoError(t, err)
+		require.True(t, changed)
+
+		value, err := store.GetSystemSetting(context.Background(), "cas-setting")
+		require.NoError(t, err)
+		require.Equal(t, "second", value)
+	})
+
+	t.Run("Concurrent updates only succeed once", func(t *testing.T) {
+		require.NoError(t, store.SetSystemSetting(context.Background(), "leader", "none"))
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		winners := 0
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				changed, err := store.CompareAndSetSystemSetting(context.Background(), "leader", "none", "elected")
+				assert.NoError(t, err)
+				if changed {
+					mu.Lock()
+					winners++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.Equal(t, 1, winners)
+	})
+}
@@ -4,7 +4,9 @@
 package storetests
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
@@ -23,6 +25,12 @@ func StoreTestTeamStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		testGetTeam(t, store)
 	})
 
+	t.Run("GetTeamBySignupToken", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetTeamBySignupToken(t, store)
+	})
+
 	t.Run("UpsertTeamSignupToken", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -40,11 +48,23 @@ func StoreTestTeamStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		defer tearDown()
 		testGetAllTeams(t, store)
 	})
+
+	t.Run("GetTeamsForUserWithBoardCounts", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetTeamsForUserWithBoardCounts(t, store)
+	})
+
+	t.Run("DeleteTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteTeam(t, store)
+	})
 }
 
 func testGetTeam(t *testing.T, store store.Store) {
 	t.Run("Nonexistent team", func(t *testing.T) {
-		got, err := store.GetTeam("nonexistent-id")
+		got, err := store.GetTeam(context.Background(), "nonexistent-id")
 		require.Error(t, err)
 		require.True(t, model.IsErrNotFound(err))
 		require.Nil(t, got)
@@ -57,15 +77,52 @@ func testGetTeam(t *testing.T, store store.Store) {
 			SignupToken: utils.NewID(utils.IDTypeToken),
 		}
 
-		err := store.UpsertTeamSignupToken(*team)
+		err := store.UpsertTeamSignupToken(context.Background(), *team)
 		require.NoError(t, err)
 
-		got, err := store.GetTeam(teamID)
+		got, err := store.GetTeam(context.Background(), teamID)
 		require.NoError(t, err)
 		require.Equal(t, teamID, got.ID)
 	})
 }
 
+func testGetTeamBySignupToken(t *testing.T, store store.Store) {
+	token := utils.NewID(utils.IDTypeToken)
+	require.NoError(t, store.UpsertTeamSignupToken(context.Background(), model.Team{ID: "team-1", SignupToken: token}))
+	require.NoError(t, store.UpsertTeamSignupToken(context.Background(), model.Team{ID: "team-2", SignupToken: utils.NewID(utils.IDTypeToken)}))
+
+	t.Run("Matching token", func(t *testing.T) {
+		got, err := store.GetTeamBySignupToken(context.Background(), token)
+		require.NoError(t, err)
+		require.Equal(t, "team-1", got.ID)
+		require.Equal(t, token, got.SignupToken)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		got, err := store.GetTeamBySignupToken(context.Background(), utils.NewID(utils.IDTypeToken))
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, got)
+	})
+
+	t.Run("Empty token", func(t *testing.T) {
+		got, err := store.GetTeamBySignupToken(context.Background(), "")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, got)
+	})
+
+	t.Run("Replaced token", func(t *testing.T) {
+		newToken := utils.NewID(utils.IDTypeToken)
+		require.NoError(t, store.UpsertTeamSignupToken(context.Background(), model.Team{ID: "team-1", SignupToken: newToken}))
+
+		_, err := store.GetTeamBySignupToken(context.Background(), token)
+		require.True(t, model.IsErrNotFound(err))
+
+		got, err := store.GetTeamBySignupToken(context.Background(), newToken)
+		require.NoError(t, err)
+		require.Equal(t, "team-1", got.ID)
+	})
+}
+
 func testUpsertTeamSignupToken(t *testing.T, store store.Store) {
 	t.Run("Insert and update team with signup token", func(t *testing.T) {
 		teamID := "0"
@@ -75,20 +132,20 @@ func testUpsertTeamSignupToken(t *testing.T, store store.Store) {
 		}
 
 		// insert
-		err := store.UpsertTeamSignupToken(*team)
+		err := store.UpsertTeamSignupToken(context.Background(), *team)
 		require.NoError(t, err)
 
-		got, err := store.GetTeam(teamID)
+		got, err := store.GetTeam(context.Background(), teamID)
 		require.NoError(t, err)
 		require.Equal(t, team.ID, got.ID)
 		require.Equal(t, team.SignupToken, got.SignupToken)
 
 		// update signup token
 		team.SignupToken = utils.NewID(utils.IDTypeToken)
-		err = store.UpsertTeamSignupToken(*team)
+		err = store.UpsertTeamSignupToken(context.Background(), *team)
 		require.NoError(t, err)
 
-		got, err = store.GetTeam(teamID)
+		got, err = store.GetTeam(context.Background(), teamID)
 		require.NoError(t, err)
 		require.Equal(t, team.ID, got.ID)
 		require.Equal(t, team.SignupToken, got.SignupToken)
@@ -106,10 +163,10 @@ func testUpsertTeamSettings(t *testing.T, store store.Store) {
 		}
 
 		// insert
-		err := store.UpsertTeamSettings(*team)
+		err := store.UpsertTeamSettings(context.Background(), *team)
 		require.NoError(t, err)
 
-		got, err := store.GetTeam(teamID)
+		got, err := store.GetTeam(context.Background(), teamID)
 		require.NoError(t, err)
 		require.Equal(t, team.ID, got.ID)
 		require.Equal(t, team.Settings, got.Settings)
@@ -118,10 +175,10 @@ func testUpsertTeamSettings(t *testing.T, store store.Store) {
 		team.Settings = map[string]interface{}{
 			"field1": "B",
 		}
-		err = store.UpsertTeamSettings(*team)
+		err = store.UpsertTeamSettings(context.Background(), *team)
 		require.NoError(t, err)
 
-		got2, err := store.GetTeam(teamID)
+		got2, err := store.GetTeam(context.Background(), teamID)
 		require.NoError(t, err)
 		require.Equal(t, team.ID, got2.ID)
 		require.Equal(t, team.Settings, got2.Settings)
@@ -131,7 +188,7 @@ func testUpsertTeamSettings(t *testing.T, store store.Store) {
 
 func testGetAllTeams(t *testing.T, store store.Store) {
 	t.Run("No teams response", func(t *testing.T) {
-		got, err := store.GetAllTeams()
+		got, err := store.GetAllTeams(context.Background())
 		require.NoError(t, err)
 		require.Empty(t, got)
 	})
@@ -146,12 +203,271 @@ func testGetAllTeams(t *testing.T, store store.Store) {
 				SignupToken: utils.NewID(utils.IDTypeToken),
 			}
 
-			err := store.UpsertTeamSignupToken(*team)
+			err := store.UpsertTeamSignupToken(context.Background(), *team)
 			require.NoError(t, err)
 		}
 
-		got, err := store.GetAllTeams()
+		got, err := store.GetAllTeams(context.Background())
 		require.NoError(t, err)
 		require.Len(t, got, teamCount)
 	})
 }
+
+func testGetTeamsForUserWithBoardCounts(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+
+	t.Run("No teams response", func(t *testing.T) {
+		got, err := store.GetTeamsForUserWithBoardCounts(context.Background(), userID)
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+
+	t.Run("Teams with and without boards", func(t *testing.T) {
+		for _, teamID := range []string{"team-1", "team-2", "team-3"} {
+			team := &model.Team{
+				ID:          teamID,
+				SignupToken: utils.NewID(utils.IDTypeToken),
+			}
+			err := store.UpsertTeamSignupToken(context.Background(), *team)
+			require.NoError(t, err)
+		}
+
+		boards := []*model.Board{
+			{ID: "board-1", TeamID: "team-1", Type: model.BoardTypeOpen},
+			{ID: "board-2", TeamID: "team-1", Type: model.BoardTypePrivate},
+			{ID: "board-3", TeamID: "team-2", Type: model.BoardTypeOpen},
+			{ID: "template-1", TeamID: "team-2", Type: model.BoardTypeOpen, IsTemplate: true},
+		}
+		for _, board := range boards {
+			_, _, err := store.InsertBoardWithAdmin(context.Background(), board, userID)
+			require.NoError(t, err)
+		}
+
+		// a board from another user shouldn't be counted
+		_, _, err := store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: "board-4", TeamID: "team-3", Type: model.BoardTypeOpen}, "other-user")
+		require.NoError(t, err)
+
+		got, err := store.GetTeamsForUserWithBoardCounts(context.Background(), userID)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+
+		counts := map[string]int64{}
+		for _, team := range got {
+			counts[team.ID] = team.BoardCount
+		}
+		require.Equal(t, map[string]int64{"team-1": 2, "team-2": 1, "team-3": 0}, counts)
+	})
+}
+
+func testDeleteTeam(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+
+	seedTeam := func(t *testing.T, teamID string) []string {
+		require.NoError(t, store.UpsertTeamSettings(context.Background(), model.Team{ID: teamID, ModifiedBy: userID}))
+
+		category := model.Category{
+			ID:     teamID + "-category",
+			Name:   "Category",
+			UserID: userID,
+			TeamID: teamID,
+			Type:   model.CategoryTypeCustom,
+		}
+		require.NoError(t, store.CreateCategory(context.Background(), category))
+		require.NoError(t, store.UpsertDefaultCategoryTemplate(context.Background(), model.CategoryTemplate{
+			ID:     teamID + "-template",
+			TeamID: teamID,
+			Name:   "Template",
+		}))
+
+		boardIDs := []string{teamID + "-board-1", teamID + "-board-2"}
+		for _, boardID := range boardIDs {
+			_, _, err := store.InsertBoardWithAdmin(context.Background(), &model.Board{ID: boardID, TeamID: teamID, Type: model.BoardTypeOpen}, userID)
+			require.NoError(t, err)
+
+			cardID := boardID + "-card"
+			InsertBlocks(t, store, []*model.Block{
+				{ID: cardID, BoardID: boardID, ParentID: boardID, Type: model.TypeCard},
+				{ID: boardID + "-comment", BoardID: boardID, ParentID: cardID, Type: model.TypeComment},
+			}, userID)
+			time.Sleep(1 * time.Millisecond)
+			DeleteBlocks(t, store, []*model.Block{{ID: boardID + "-comment"}}, userID)
+
+			require.NoError(t, store.AddUpdateCategoryBoard(context.Background(), userID, category.ID, boardID))
+			require.NoError(t, store.SetBoardFavorite(context.Background(), userID, boardID, true))
+			require.NoError(t, store.UpsertSharing(context.Background(), model.Sharing{ID: boardID, Enabled: true, Token: "token", ModifiedBy: userID}))
+			require.NoError(t, store.UpsertBoardSnapshot(context.Background(), &model.BoardSnapshot{BoardID: boardID, Board: &model.Board{ID: boardID}}))
+			require.NoError(t, store.CreateWebhook(context.Background(), &model.Webhook{BoardID: boardID, URL: "https://example.com/hook", EventTypes: []string{"card.created"}, CreatedBy: userID}))
+			require.NoError(t, store.RecordWebhookDelivery(context.Background(), &model.WebhookDelivery{BoardID: boardID, EventType: "card.created"}))
+			require.NoError(t, store.InsertAuditRecord(context.Background(), &model.AuditRecord{BoardID: boardID, ActorID: userID, Action: model.AuditActionPatchBlock, ResourceID: cardID}))
+			require.NoError(t, store.CreateBoardCustomRole(context.Background(), &model.BoardCustomRole{ID: boardID + "-role", BoardID: boardID, Name: "Reviewer", CreatedBy: userID}))
+			require.NoError(t, store.CreateShareToken(context.Background(), &model.ShareToken{BoardID: boardID, Scope: model.ShareTokenScopeBoard, CreatedBy: userID}))
+
+			for _, blockID := range []string{boardID, cardID} {
+				_, err = store.CreateSubscription(context.Background(), &model.Subscription{
+					BlockType:      model.TypeCard,
+					BlockID:        blockID,
+					SubscriberType: model.SubTypeUser,
+					SubscriberID:   userID,
+				})
+				require.NoError(t, err)
+
+				_, err = store.UpsertNotificationHint(context.Background(), &model.NotificationHint{
+					BlockType:    model.TypeCard,
+					BlockID:      blockID,
+					ModifiedByID: userID,
+				}, time.Second*15)
+				require.NoError(t, err)
+			}
+		}
+		return boardIDs
+	}
+
+	t.Run("Nonexistent team", func(t *testing.T) {
+		count, err := store.DeleteTeam(context.Background(), "nonexistent-team")
+		require.True(t, model.IsErrNotFound(err))
+		require.Zero(t, count)
+	})
+
+	t.Run("Delete team with its boards", func(t *testing.T) {
+		deletedBoardIDs := seedTeam(t, "team-1")
+		keptBoardIDs := seedTeam(t, "team-2")
+
+		count, err := store.DeleteTeam(context.Background(), "team-1")
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		_, err = store.GetTeam(context.Background(), "team-1")
+		require.Error(t, err)
+
+		for _, boardID := range deletedBoardIDs {
+			cardID := boardID + "-card"
+
+			_, err = store.GetBoard(context.Background(), boardID)
+			require.True(t, model.IsErrNotFound(err))
+
+			history, err := store.GetBoardHistory(context.Background(), boardID, model.QueryBoardHistoryOptions{})
+			require.NoError(t, err)
+			require.Empty(t, history)
+
+			blocks, err := store.GetBlocks(context.Background(), model.QueryBlocksOptions{BoardID: boardID, IncludeArchived: true})
+			require.NoError(t, err)
+			require.Empty(t, blocks)
+
+			blockHistory, err := store.GetBlockHistory(context.Background(), boardID+"-comment", model.QueryBlockHistoryOptions{})
+			require.NoError(t, err)
+			require.Empty(t, blockHistory)
+
+			members, err := store.GetMembersForBoard(context.Background(), boardID)
+			require.NoError(t, err)
+			require.Empty(t, members)
+
+			_, err = store.GetSharing(context.Background(), boardID)
+			require.Error(t, err)
+
+			_, err = store.GetBoardSnapshot(context.Background(), boardID)
+			require.True(t, model.IsErrNotFound(err))
+
+			webhooks, err := store.GetWebhooksForBoard(context.Background(), boardID)
+			require.NoError(t, err)
+			require.Empty(t, webhooks)
+
+			records, err := store.GetAuditRecords(context.Background(), boardID, model.QueryAuditOptions{})
+			require.NoError(t, err)
+			require.Empty(t, records)
+
+			roles, err := store.GetBoardCustomRoles(context.Background(), boardID)
+			require.NoError(t, err)
+			require.Empty(t, roles)
+
+			shareTokens, err := store.GetShareTokensForBoard(context.Background(), boardID)
+			require.NoError(t, err)
+			require.Empty(t, shareTokens)
+
+			for _, blockID := range []string{boardID, cardID} {
+				_, err = store.GetSubscription(context.Background(), blockID, userID)
+				require.True(t, model.IsErrNotFound(err))
+
+				_, err = store.GetNotificationHint(context.Background(), blockID)
+				require.True(t, model.IsErrNotFound(err))
+			}
+		}
+
+		_, err = store.GetCategory(context.Background(), "team-1-category")
+		require.True(t, model.IsErrNotFound(err))
+
+		categoryBoards, err := store.GetUserCategoryBoards(context.Background(), userID, "team-1")
+		require.NoError(t, err)
+		require.Empty(t, categoryBoards)
+
+		templates, err := store.GetDefaultCategoryTemplates(context.Background(), "team-1")
+		require.NoError(t, err)
+		require.Empty(t, templates)
+
+		deliveries, err := store.GetPendingWebhookDeliveries(context.Background(), 0)
+		require.NoError(t, err)
+		require.Len(t, deliveries, len(keptBoardIDs))
+		for _, delivery := range deliveries {
+			require.Contains(t, keptBoardIDs, delivery.BoardID)
+		}
+
+		// the other team keeps all its data
+		_, err = store.GetTeam(context.Background(), "team-2")
+		require.NoError(t, err)
+
+		for _, boardID := range keptBoardIDs {
+			_, err = store.GetBoard(context.Background(), boardID)
+			require.NoError(t, err)
+
+			blocks, err := store.GetBlocks(context.Background(), model.QueryBlocksOptions{BoardID: boardID, IncludeArchived: true})
+			require.NoError(t, err)
+			require.Len(t, blocks, 1)
+
+			members, err := store.GetMembersForBoard(context.Background(), boardID)
+			require.NoError(t, err)
+			require.Len(t, members, 1)
+
+			_, err = store.GetSharing(context.Background(), boardID)
+			require.NoError(t, err)
+
+			_, err = store.GetBoardSnapshot(context.Background(), boardID)
+			require.NoError(t, err)
+
+			webhooks, err := store.GetWebhooksForBoard(context.Background(), boardID)
+			require.NoError(t, err)
+			require.Len(t, webhooks, 1)
+
+			records, err := store.GetAuditRecords(context.Background(), boardID, model.QueryAuditOptions{})
+			require.NoError(t, err)
+			require.NotEmpty(t, records)
+
+			roles, err := store.GetBoardCustomRoles(context.Background(), boardID)
+			require.NoError(t, err)
+			require.Len(t, roles, 1)
+
+			shareTokens, err := store.GetShareTokensForBoard(context.Background(), boardID)
+			require.NoError(t, err)
+			require.Len(t, shareTokens, 1)
+
+			for _, blockID := range []string{boardID, boardID + "-card"} {
+				_, err = store.GetSubscription(context.Background(), blockID, userID)
+				require.NoError(t, err)
+
+				_, err = store.GetNotificationHint(context.Background(), blockID)
+				require.NoError(t, err)
+			}
+		}
+
+		favorites, err := store.GetFavoriteBoards(context.Background(), userID, "team-2")
+		require.NoError(t, err)
+		require.Len(t, favorites, len(keptBoardIDs))
+
+		categoryBoards, err = store.GetUserCategoryBoards(context.Background(), userID, "team-2")
+		require.NoError(t, err)
+		require.Len(t, categoryBoards, 1)
+		require.ElementsMatch(t, keptBoardIDs, categoryBoards[0].BoardIDs)
+
+		templates, err = store.GetDefaultCategoryTemplates(context.Background(), "team-2")
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+	})
+}
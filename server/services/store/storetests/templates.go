@@ -0,0 +1,287 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestTemplatesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("ReinstallDefaultTemplates", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testReinstallDefaultTemplates(t, store)
+	})
+
+	t.Run("UpgradeDefaultTemplates", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpgradeDefaultTemplates(t, store)
+	})
+
+	t.Run("GetTemplateBoardsByVersion", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetTemplateBoardsByVersion(t, store)
+	})
+
+	t.Run("GetBoardsCreatedFromTemplate", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsCreatedFromTemplate(t, store)
+	})
+}
+
+func testReinstallDefaultTemplates(t *testing.T, store store.Store) {
+	teamID := testTeamID
+	userID := testUserID
+
+	newTemplates := func() []*model.Board {
+		return []*model.Board{
+			{ID: "template-1", Title: "Template 1", Type: model.BoardTypeOpen},
+			{ID: "template-2", Title: "Template 2", Type: model.BoardTypeOpen},
+		}
+	}
+
+	t.Run("should install the templates as system templates", func(t *testing.T) {
+		err := store.ReinstallDefaultTemplates(context.Background(), teamID, newTemplates(), userID)
+		require.NoError(t, err)
+
+		templates, err := store.GetTemplateBoards(context.Background(), teamID, "")
+		require.NoError(t, err)
+		require.Len(t, templates, 2)
+		for _, template := range templates {
+			require.True(t, template.IsTemplate)
+			require.Equal(t, model.SystemUserID, template.CreatedBy)
+			require.Equal(t, userID, template.ModifiedBy)
+		}
+	})
+
+	t.Run("should replace corrupted or missing default templates", func(t *testing.T) {
+		require.NoError(t, store.DeleteBoard(context.Background(), "template-1", userID))
+
+		err := store.InsertBlock(context.Background(), &model.Block{ID: "stray-block", BoardID: "template-2", Type: model.TypeCard}, userID)
+		require.NoError(t, err)
+
+		err = store.ReinstallDefaultTemplates(context.Background(), teamID, newTemplates(), userID)
+		require.NoError(t, err)
+
+		templates, err := store.GetTemplateBoards(context.Background(), teamID, "")
+		require.NoError(t, err)
+		require.Len(t, templates, 2)
+
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), "template-2", model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("should be idempotent", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			err := store.ReinstallDefaultTemplates(context.Background(), teamID, newTemplates(), userID)
+			require.NoError(t, err)
+		}
+
+		templates, err := store.GetTemplateBoards(context.Background(), teamID, "")
+		require.NoError(t, err)
+		require.Len(t, templates, 2)
+	})
+
+	t.Run("should replace private default templates", func(t *testing.T) {
+		privateTemplate := &model.Board{ID: "private-template", TeamID: teamID, Type: model.BoardTypePrivate, IsTemplate: true}
+		_, err := store.InsertBoard(context.Background(), privateTemplate, model.SystemUserID)
+		require.NoError(t, err)
+
+		err = store.ReinstallDefaultTemplates(context.Background(), teamID, newTemplates(), userID)
+		require.NoError(t, err)
+
+		_, err = store.GetBoard(context.Background(), privateTemplate.ID)
+		require.True(t, model.IsErrNotFound(err))
+
+		templates, err := store.GetTemplateBoards(context.Background(), teamID, "")
+		require.NoError(t, err)
+		require.Len(t, templates, 2)
+	})
+
+	t.Run("should not remove user templates", func(t *testing.T) {
+		userTemplate := &model.Board{ID: "user-template", TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true}
+		_, err := store.InsertBoard(context.Background(), userTemplate, userID)
+		require.NoError(t, err)
+
+		err = store.ReinstallDefaultTemplates(context.Background(), teamID, newTemplates(), userID)
+		require.NoError(t, err)
+
+		templates, err := store.GetTemplateBoards(context.Background(), teamID, "")
+		require.NoError(t, err)
+		require.Len(t, templates, 3)
+	})
+}
+
+func testUpgradeDefaultTemplates(t *testing.T, store store.Store) {
+	teamID := testTeamID
+	userID := testUserID
+
+	newTemplates := func(suffix string) *model.BoardsAndBlocks {
+		return &model.BoardsAndBlocks{
+			Boards: []*model.Board{
+				{ID: "template-1" + suffix, Title: "Template 1", Type: model.BoardTypeOpen},
+				{ID: "template-2" + suffix, Title: "Template 2", Type: model.BoardTypeOpen},
+			},
+			Blocks: []*model.Block{
+				{ID: "block-1" + suffix, BoardID: "template-1" + suffix, ParentID: "template-1" + suffix, Type: model.TypeCard},
+			},
+		}
+	}
+
+	templatesByID := func(t *testing.T) map[string]*model.Board {
+		templates, err := store.GetTemplateBoards(context.Background(), teamID, "")
+		require.NoError(t, err)
+		byID := map[string]*model.Board{}
+		for _, template := range templates {
+			byID[template.ID] = template
+		}
+		return byID
+	}
+
+	_, err := store.CreateBoardsAndBlocks(context.Background(), &model.BoardsAndBlocks{
+		Boards: []*model.Board{
+			{ID: "old-default", TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true, TemplateVersion: 1},
+			{
+				ID:              "customized-default",
+				TeamID:          teamID,
+				Type:            model.BoardTypeOpen,
+				IsTemplate:      true,
+				TemplateVersion: 1,
+				Properties:      map[string]interface{}{model.TemplateCustomizedProperty: true},
+			},
+		},
+		Blocks: []*model.Block{
+			{ID: "old-default-block", BoardID: "old-default", ParentID: "old-default", Type: model.TypeCard},
+		},
+	}, model.SystemUserID)
+	require.NoError(t, err)
+
+	userTemplate := &model.Board{ID: "user-template", TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true}
+	_, err = store.InsertBoard(context.Background(), userTemplate, userID)
+	require.NoError(t, err)
+
+	t.Run("should replace the outdated default templates", func(t *testing.T) {
+		err := store.UpgradeDefaultTemplates(context.Background(), teamID, 2, newTemplates(""))
+		require.NoError(t, err)
+
+		templates := templatesByID(t)
+		require.Len(t, templates, 4)
+		require.NotContains(t, templates, "old-default")
+		require.Contains(t, templates, "customized-default")
+		require.Contains(t, templates, "user-template")
+		for _, id := range []string{"template-1", "template-2"} {
+			require.Contains(t, templates, id)
+			require.Equal(t, model.SystemUserID, templates[id].CreatedBy)
+			require.Equal(t, 2, templates[id].TemplateVersion)
+		}
+
+		blocks, _, err := store.GetBlocksForBoard(context.Background(), "old-default", model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+
+		blocks, _, err = store.GetBlocksForBoard(context.Background(), "template-1", model.QueryBlocksOptions{})
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+	})
+
+	t.Run("should be a no-op when upgrading to the same version", func(t *testing.T) {
+		err := store.UpgradeDefaultTemplates(context.Background(), teamID, 2, newTemplates("-again"))
+		require.NoError(t, err)
+
+		templates := templatesByID(t)
+		require.Len(t, templates, 4)
+		require.NotContains(t, templates, "template-1-again")
+	})
+
+	t.Run("should keep customized templates on later upgrades", func(t *testing.T) {
+		err := store.UpgradeDefaultTemplates(context.Background(), teamID, 3, newTemplates("-v3"))
+		require.NoError(t, err)
+
+		templates := templatesByID(t)
+		require.Len(t, templates, 4)
+		require.Contains(t, templates, "customized-default")
+		require.Contains(t, templates, "template-1-v3")
+		require.Contains(t, templates, "template-2-v3")
+		require.NotContains(t, templates, "template-1")
+		require.Equal(t, 1, templates["customized-default"].TemplateVersion)
+	})
+}
+
+func testGetTemplateBoardsByVersion(t *testing.T, store store.Store) {
+	teamID := testTeamID
+
+	_, err := store.CreateBoardsAndBlocks(context.Background(), &model.BoardsAndBlocks{
+		Boards: []*model.Board{
+			{ID: "template-v1", TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true, TemplateVersion: 1},
+			{ID: "template-v2", TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true, TemplateVersion: 2},
+			{ID: "other-team-v2", TeamID: "other-team", Type: model.BoardTypeOpen, IsTemplate: true, TemplateVersion: 2},
+			{ID: "board-v2", TeamID: teamID, Type: model.BoardTypeOpen, TemplateVersion: 2},
+		},
+	}, model.SystemUserID)
+	require.NoError(t, err)
+
+	templates, err := store.GetTemplateBoardsByVersion(context.Background(), teamID, 2)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	require.Equal(t, "template-v2", templates[0].ID)
+
+	templates, err = store.GetTemplateBoardsByVersion(context.Background(), teamID, 3)
+	require.NoError(t, err)
+	require.Empty(t, templates)
+}
+
+func testGetBoardsCreatedFromTemplate(t *testing.T, store store.Store) {
+	teamID := testTeamID
+	userID := testUserID
+
+	bab := &model.BoardsAndBlocks{
+		Boards: []*model.Board{
+			{ID: "template-id", TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true},
+			{ID: "board-id", TeamID: teamID, Type: model.BoardTypeOpen},
+		},
+		Blocks: []*model.Block{
+			{ID: "template-block-id", BoardID: "template-id", Type: model.TypeCard},
+			{ID: "board-block-id", BoardID: "board-id", Type: model.TypeCard},
+		},
+	}
+	_, err := store.CreateBoardsAndBlocks(context.Background(), bab, userID)
+	require.NoError(t, err)
+
+	t.Run("no boards created from the template", func(t *testing.T) {
+		boards, err := store.GetBoardsCreatedFromTemplate(context.Background(), "template-id")
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+
+	t.Run("boards created from the template", func(t *testing.T) {
+		fromTemplate, _, err := store.DuplicateBoard(context.Background(), "template-id", userID, teamID, false)
+		require.NoError(t, err)
+		require.Equal(t, model.BoardCreationSourceTemplate, fromTemplate.Boards[0].CreationSource)
+		require.Equal(t, "template-id", fromTemplate.Boards[0].SourceID)
+
+		// copies of the template and duplicated boards don't count
+		_, _, err = store.DuplicateBoard(context.Background(), "template-id", userID, teamID, true)
+		require.NoError(t, err)
+		duplicated, _, err := store.DuplicateBoard(context.Background(), "board-id", userID, teamID, false)
+		require.NoError(t, err)
+		require.Equal(t, model.BoardCreationSourceDuplicate, duplicated.Boards[0].CreationSource)
+		require.Equal(t, "board-id", duplicated.Boards[0].SourceID)
+
+		boards, err := store.GetBoardsCreatedFromTemplate(context.Background(), "template-id")
+		require.NoError(t, err)
+		require.Len(t, boards, 1)
+		require.Equal(t, fromTemplate.Boards[0].ID, boards[0].ID)
+		require.Equal(t, model.BoardCreationSourceTemplate, boards[0].CreationSource)
+	})
+}
@@ -4,7 +4,9 @@
 package storetests
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -52,17 +54,35 @@ func StoreTestUserStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		defer tearDown()
 		testPatchUserProps(t, store)
 	})
+
+	t.Run("CreateDuplicatedUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateDuplicatedUser(t, store)
+	})
+
+	t.Run("GetUsersByTeamWithRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetUsersByTeamWithRole(t, store)
+	})
+
+	t.Run("DeactivateAndReactivateUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeactivateAndReactivateUser(t, store)
+	})
 }
 
 func testGetUsersByTeam(t *testing.T, store store.Store) {
 	t.Run("GetTeamUsers", func(t *testing.T) {
-		users, err := store.GetUsersByTeam("team_1", "")
+		users, err := store.GetUsersByTeam(context.Background(), "team_1", "")
 		require.Equal(t, 0, len(users))
 		require.NoError(t, err)
 
 		userID := utils.NewID(utils.IDTypeUser)
 
-		user, err := store.CreateUser(&model.User{
+		user, err := store.CreateUser(context.Background(), &model.User{
 			ID:       userID,
 			Username: "darth.vader",
 		})
@@ -72,13 +92,13 @@ func testGetUsersByTeam(t *testing.T, store store.Store) {
 		require.Equal(t, "darth.vader", user.Username)
 
 		defer func() {
-			_, _ = store.UpdateUser(&model.User{
+			_, _ = store.UpdateUser(context.Background(), &model.User{
 				ID:       userID,
 				DeleteAt: utils.GetMillis(),
 			})
 		}()
 
-		users, err = store.GetUsersByTeam("team_1", "")
+		users, err = store.GetUsersByTeam(context.Background(), "team_1", "")
 		require.Equal(t, 1, len(users))
 		require.Equal(t, "darth.vader", users[0].Username)
 		require.NoError(t, err)
@@ -93,13 +113,13 @@ func testCreateAndGetUser(t *testing.T, store store.Store) {
 	}
 
 	t.Run("CreateUser", func(t *testing.T) {
-		newUser, err := store.CreateUser(user)
+		newUser, err := store.CreateUser(context.Background(), user)
 		require.NoError(t, err)
 		require.NotNil(t, newUser)
 	})
 
 	t.Run("GetUserByID", func(t *testing.T) {
-		got, err := store.GetUserByID(user.ID)
+		got, err := store.GetUserByID(context.Background(), user.ID)
 		require.NoError(t, err)
 		require.Equal(t, user.ID, got.ID)
 		require.Equal(t, user.Username, got.Username)
@@ -107,14 +127,14 @@ func testCreateAndGetUser(t *testing.T, store store.Store) {
 	})
 
 	t.Run("GetUserByID nonexistent", func(t *testing.T) {
-		got, err := store.GetUserByID("nonexistent-id")
+		got, err := store.GetUserByID(context.Background(), "nonexistent-id")
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 		require.Nil(t, got)
 	})
 
 	t.Run("GetUserByUsername", func(t *testing.T) {
-		got, err := store.GetUserByUsername(user.Username)
+		got, err := store.GetUserByUsername(context.Background(), user.Username)
 		require.NoError(t, err)
 		require.Equal(t, user.ID, got.ID)
 		require.Equal(t, user.Username, got.Username)
@@ -122,14 +142,14 @@ func testCreateAndGetUser(t *testing.T, store store.Store) {
 	})
 
 	t.Run("GetUserByUsername nonexistent", func(t *testing.T) {
-		got, err := store.GetUserByID("nonexistent-username")
+		got, err := store.GetUserByID(context.Background(), "nonexistent-username")
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 		require.Nil(t, got)
 	})
 
 	t.Run("GetUserByEmail", func(t *testing.T) {
-		got, err := store.GetUserByEmail(user.Email)
+		got, err := store.GetUserByEmail(context.Background(), user.Email)
 		require.NoError(t, err)
 		require.Equal(t, user.ID, got.ID)
 		require.Equal(t, user.Username, got.Username)
@@ -137,7 +157,7 @@ func testCreateAndGetUser(t *testing.T, store store.Store) {
 	})
 
 	t.Run("GetUserByEmail nonexistent", func(t *testing.T) {
-		got, err := store.GetUserByID("nonexistent-email")
+		got, err := store.GetUserByID(context.Background(), "nonexistent-email")
 		var nf *model.ErrNotFound
 		require.ErrorAs(t, err, &nf)
 		require.Nil(t, got)
@@ -151,7 +171,7 @@ func testGetUsersList(t *testing.T, store store.Store) {
 			Username: fmt.Sprintf("%s-username", id),
 			Email:    fmt.Sprintf("%s@sample.com", id),
 		}
-		newUser, err := store.CreateUser(user)
+		newUser, err := store.CreateUser(context.Background(), user)
 		require.NoError(t, err)
 		require.NotNil(t, newUser)
 	}
@@ -184,7 +204,7 @@ func testGetUsersList(t *testing.T, store store.Store) {
 
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			users, err := store.GetUsersList(tc.UserIDs)
+			users, err := store.GetUsersList(context.Background(), tc.UserIDs)
 			if tc.ExpectedError {
 				require.Error(t, err)
 				require.True(t, model.IsErrNotFound(err))
@@ -205,20 +225,20 @@ func testCreateAndUpdateUser(t *testing.T, store store.Store) {
 	user := &model.User{
 		ID: utils.NewID(utils.IDTypeUser),
 	}
-	newUser, err := store.CreateUser(user)
+	newUser, err := store.CreateUser(context.Background(), user)
 	require.NoError(t, err)
 	require.NotNil(t, newUser)
 
 	t.Run("UpdateUser", func(t *testing.T) {
 		user.Username = "damao"
 		user.Email = "mock@email.com"
-		uUser, err := store.UpdateUser(user)
+		uUser, err := store.UpdateUser(context.Background(), user)
 		require.NoError(t, err)
 		require.NotNil(t, uUser)
 		require.Equal(t, user.Username, uUser.Username)
 		require.Equal(t, user.Email, uUser.Email)
 
-		got, err := store.GetUserByID(user.ID)
+		got, err := store.GetUserByID(context.Background(), user.ID)
 		require.NoError(t, err)
 		require.Equal(t, user.ID, got.ID)
 		require.Equal(t, user.Username, got.Username)
@@ -227,10 +247,10 @@ func testCreateAndUpdateUser(t *testing.T, store store.Store) {
 
 	t.Run("UpdateUserPassword", func(t *testing.T) {
 		newPassword := utils.NewID(utils.IDTypeNone)
-		err := store.UpdateUserPassword(user.Username, newPassword)
+		err := store.UpdateUserPassword(context.Background(), user.Username, newPassword)
 		require.NoError(t, err)
 
-		got, err := store.GetUserByUsername(user.Username)
+		got, err := store.GetUserByUsername(context.Background(), user.Username)
 		require.NoError(t, err)
 		require.Equal(t, user.Username, got.Username)
 		require.Equal(t, newPassword, got.Password)
@@ -238,10 +258,10 @@ func testCreateAndUpdateUser(t *testing.T, store store.Store) {
 
 	t.Run("UpdateUserPasswordByID", func(t *testing.T) {
 		newPassword := utils.NewID(utils.IDTypeNone)
-		err := store.UpdateUserPasswordByID(user.ID, newPassword)
+		err := store.UpdateUserPasswordByID(context.Background(), user.ID, newPassword)
 		require.NoError(t, err)
 
-		got, err := store.GetUserByID(user.ID)
+		got, err := store.GetUserByID(context.Background(), user.ID)
 		require.NoError(t, err)
 		require.Equal(t, user.ID, got.ID)
 		require.Equal(t, newPassword, got.Password)
@@ -251,14 +271,14 @@ func testCreateAndUpdateUser(t *testing.T, store store.Store) {
 func testCreateAndGetRegisteredUserCount(t *testing.T, store store.Store) {
 	randomN := int(time.Now().Unix() % 10)
 	for i := 0; i < randomN; i++ {
-		user, err := store.CreateUser(&model.User{
+		user, err := store.CreateUser(context.Background(), &model.User{
 			ID: utils.NewID(utils.IDTypeUser),
 		})
 		require.NoError(t, err)
 		require.NotNil(t, user)
 	}
 
-	got, err := store.GetRegisteredUserCount()
+	got, err := store.GetRegisteredUserCount(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, randomN, got)
 }
@@ -267,7 +287,7 @@ func testPatchUserProps(t *testing.T, store store.Store) {
 	user := &model.User{
 		ID: utils.NewID(utils.IDTypeUser),
 	}
-	newUser, err := store.CreateUser(user)
+	newUser, err := store.CreateUser(context.Background(), user)
 	require.NoError(t, err)
 	require.NotNil(t, newUser)
 
@@ -284,7 +304,7 @@ func testPatchUserProps(t *testing.T, store store.Store) {
 		},
 	}
 
-	userPreferences, err := store.PatchUserPreferences(user.ID, patch)
+	userPreferences, err := store.PatchUserPreferences(context.Background(), user.ID, patch)
 	require.NoError(t, err)
 	require.Equal(t, 3, len(userPreferences))
 
@@ -306,7 +326,7 @@ func testPatchUserProps(t *testing.T, store store.Store) {
 		},
 	}
 
-	userPreferences, err = store.PatchUserPreferences(user.ID, patch)
+	userPreferences, err = store.PatchUserPreferences(context.Background(), user.ID, patch)
 	require.NoError(t, err)
 
 	for _, preference := range userPreferences {
@@ -329,7 +349,7 @@ func testPatchUserProps(t *testing.T, store store.Store) {
 			key2,
 		},
 	}
-	userPreferences, err = store.PatchUserPreferences(user.ID, patch)
+	userPreferences, err = store.PatchUserPreferences(context.Background(), user.ID, patch)
 	require.NoError(t, err)
 
 	for _, preference := range userPreferences {
@@ -343,3 +363,207 @@ func testPatchUserProps(t *testing.T, store store.Store) {
 		}
 	}
 }
+
+func testCreateDuplicatedUser(t *testing.T, store store.Store) {
+	user, err := store.CreateUser(context.Background(), &model.User{
+		ID:       utils.NewID(utils.IDTypeUser),
+		Username: "original.user",
+		Email:    "original@sample.com",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, user)
+
+	t.Run("duplicated email", func(t *testing.T) {
+		got, err := store.CreateUser(context.Background(), &model.User{
+			ID:       utils.NewID(utils.IDTypeUser),
+			Username: "another.user",
+			Email:    user.Email,
+		})
+		require.True(t, model.IsErrDuplicate(err))
+		require.True(t, model.IsErrConflict(err))
+		require.Nil(t, got)
+	})
+
+	t.Run("duplicated username", func(t *testing.T) {
+		got, err := store.CreateUser(context.Background(), &model.User{
+			ID:       utils.NewID(utils.IDTypeUser),
+			Username: user.Username,
+			Email:    "another@sample.com",
+		})
+		require.True(t, model.IsErrDuplicate(err))
+		require.Nil(t, got)
+	})
+
+	t.Run("update to a duplicated email", func(t *testing.T) {
+		other, err := store.CreateUser(context.Background(), &model.User{
+			ID:       utils.NewID(utils.IDTypeUser),
+			Username: "other.user",
+			Email:    "other@sample.com",
+		})
+		require.NoError(t, err)
+
+		other.Email = user.Email
+		_, err = store.UpdateUser(context.Background(), other)
+		require.True(t, model.IsErrDuplicate(err))
+	})
+
+	t.Run("users without email or username", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := store.CreateUser(context.Background(), &model.User{ID: utils.NewID(utils.IDTypeUser)})
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("concurrent creates with the same email", func(t *testing.T) {
+		attempts := 10
+		errs := make([]error, attempts)
+
+		var wg sync.WaitGroup
+		for i := 0; i < attempts; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = store.CreateUser(context.Background(), &model.User{
+					ID:       utils.NewID(utils.IDTypeUser),
+					Username: fmt.Sprintf("concurrent.user.%d", i),
+					Email:    "concurrent@sample.com",
+				})
+			}(i)
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			require.True(t, model.IsErrDuplicate(err))
+		}
+		require.Equal(t, 1, succeeded)
+
+		got, err := store.GetUserByEmail(context.Background(), "concurrent@sample.com")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+	})
+}
+
+func testGetUsersByTeamWithRole(t *testing.T, store store.Store) {
+	users := createTestUsers(t, store, 3)
+
+	t.Run("all users are regular members of standalone teams", func(t *testing.T) {
+		got, err := store.GetUsersByTeamWithRole(context.Background(), testTeamID, model.TeamRoleUser, false)
+		require.NoError(t, err)
+		require.ElementsMatch(t, getUserIDs(users), getUserIDs(got))
+
+		got, err = store.GetUsersByTeamWithRole(context.Background(), testTeamID, model.TeamRoleUser, true)
+		require.NoError(t, err)
+		require.ElementsMatch(t, getUserIDs(users), getUserIDs(got))
+	})
+
+	t.Run("there are no admins or guests in standalone teams", func(t *testing.T) {
+		got, err := store.GetUsersByTeamWithRole(context.Background(), testTeamID, model.TeamRoleAdmin, false)
+		require.NoError(t, err)
+		require.Empty(t, got)
+
+		got, err = store.GetUsersByTeamWithRole(context.Background(), testTeamID, model.TeamRoleGuest, false)
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		got, err := store.GetUsersByTeamWithRole(context.Background(), testTeamID, "superuser", false)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, got)
+	})
+}
+
+func getUserIDs(users []*model.User) []string {
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	return userIDs
+}
+
+func testDeactivateAndReactivateUser(t *testing.T, store store.Store) {
+	users := createTestUsers(t, store, 2)
+	user := users[0]
+
+	session := &model.Session{
+		ID:     utils.NewID(utils.IDTypeNone),
+		Token:  utils.NewID(utils.IDTypeToken),
+		UserID: user.ID,
+		Props:  map[string]interface{}{},
+	}
+	require.NoError(t, store.CreateSession(context.Background(), session))
+
+	count, err := store.GetActiveUserCount(context.Background(), 60)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	t.Run("deactivate user", func(t *testing.T) {
+		require.NoError(t, store.DeactivateUser(context.Background(), user.ID))
+
+		got, err := store.GetUserByID(context.Background(), user.ID)
+		require.NoError(t, err)
+		require.NotZero(t, got.DeleteAt)
+
+		_, err = store.GetUserByUsername(context.Background(), user.Username)
+		require.True(t, model.IsErrNotFound(err))
+
+		_, err = store.GetUserByEmail(context.Background(), user.Email)
+		require.True(t, model.IsErrNotFound(err))
+
+		count, err := store.GetRegisteredUserCount(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		count, err = store.GetActiveUserCount(context.Background(), 60)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		_, err = store.GetSession(context.Background(), session.Token, 60*60)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("deactivate a deactivated user", func(t *testing.T) {
+		require.NoError(t, store.DeactivateUser(context.Background(), user.ID))
+	})
+
+	t.Run("sessions of deactivated users are cleaned up", func(t *testing.T) {
+		lateSession := &model.Session{
+			ID:     utils.NewID(utils.IDTypeNone),
+			Token:  utils.NewID(utils.IDTypeToken),
+			UserID: user.ID,
+			Props:  map[string]interface{}{},
+		}
+		require.NoError(t, store.CreateSession(context.Background(), lateSession))
+
+		_, err := store.CleanUpSessions(context.Background(), time.Hour, 0, 0)
+		require.NoError(t, err)
+
+		_, err = store.GetSession(context.Background(), lateSession.Token, 60*60)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("reactivate user", func(t *testing.T) {
+		require.NoError(t, store.ReactivateUser(context.Background(), user.ID))
+
+		got, err := store.GetUserByUsername(context.Background(), user.Username)
+		require.NoError(t, err)
+		require.Zero(t, got.DeleteAt)
+
+		count, err := store.GetRegisteredUserCount(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("nonexistent user", func(t *testing.T) {
+		err := store.DeactivateUser(context.Background(), "nonexistent-user")
+		require.True(t, model.IsErrNotFound(err))
+
+		err = store.ReactivateUser(context.Background(), "nonexistent-user")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
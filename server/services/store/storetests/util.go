@@ -4,6 +4,7 @@
 package storetests
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -16,12 +17,13 @@ import (
 func createTestUsers(t *testing.T, store store.Store, num int) []*model.User {
 	var users []*model.User
 	for i := 0; i < num; i++ {
+		userID := utils.NewID(utils.IDTypeUser)
 		user := &model.User{
-			ID:       utils.NewID(utils.IDTypeUser),
-			Username: fmt.Sprintf("mooncake.%d", i),
-			Email:    fmt.Sprintf("mooncake.%d@example.com", i),
+			ID:       userID,
+			Username: fmt.Sprintf("mooncake.%d.%s", i, userID),
+			Email:    fmt.Sprintf("mooncake.%d.%s@example.com", i, userID),
 		}
-		newUser, err := store.CreateUser(user)
+		newUser, err := store.CreateUser(context.Background(), user)
 		require.NoError(t, err)
 		require.NotNil(t, newUser)
 
@@ -39,7 +41,8 @@ func createTestBlocks(t *testing.T, store store.Store, userID string, num int) [
 			Type:      "card",
 			CreatedBy: userID,
 		}
-		err := store.InsertBlock(block, userID)
+		InsertBoardsForBlocks(t, store, []*model.Block{block}, userID)
+		err := store.InsertBlock(context.Background(), block, userID)
 		require.NoError(t, err)
 
 		blocks = append(blocks, block)
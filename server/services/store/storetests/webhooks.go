@@ -0,0 +1,310 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestWebhooksStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateWebhook(t, store)
+	})
+
+	t.Run("UpdateWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateWebhook(t, store)
+	})
+
+	t.Run("DeleteWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteWebhook(t, store)
+	})
+}
+
+func StoreTestWebhookDeliveriesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("RecordWebhookDelivery", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRecordWebhookDelivery(t, store)
+	})
+
+	t.Run("GetPendingWebhookDeliveries", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetPendingWebhookDeliveries(t, store)
+	})
+
+	t.Run("MarkWebhookDelivered", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMarkWebhookDelivered(t, store)
+	})
+}
+
+func testCreateWebhook(t *testing.T, store store.Store) {
+	t.Run("create webhooks", func(t *testing.T) {
+		webhook := &model.Webhook{
+			BoardID:    testBoardID,
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"card.created", "card.updated"},
+			CreatedBy:  testUserID,
+		}
+		require.NoError(t, store.CreateWebhook(context.Background(), webhook), "create webhook should not error")
+		assert.NotEmpty(t, webhook.ID)
+		assert.NotEmpty(t, webhook.Secret, "a secret should be generated")
+
+		other := &model.Webhook{
+			BoardID:    testBoardID,
+			URL:        "http://example.com/other",
+			Secret:     "my-secret",
+			EventTypes: []string{"card.deleted"},
+			CreatedBy:  testUserID,
+		}
+		require.NoError(t, store.CreateWebhook(context.Background(), other), "create webhook should not error")
+
+		webhooks, err := store.GetWebhooksForBoard(context.Background(), testBoardID)
+		require.NoError(t, err, "get webhooks for board should not error")
+		require.Len(t, webhooks, 2)
+
+		got := map[string]*model.Webhook{}
+		for _, w := range webhooks {
+			got[w.ID] = w
+		}
+		assert.Equal(t, webhook.URL, got[webhook.ID].URL)
+		assert.Equal(t, webhook.Secret, got[webhook.ID].Secret)
+		assert.Equal(t, []string{"card.created", "card.updated"}, got[webhook.ID].EventTypes)
+		assert.Equal(t, "my-secret", got[other.ID].Secret)
+		assert.True(t, got[other.ID].WantsEvent("card.deleted"))
+		assert.False(t, got[other.ID].WantsEvent("card.created"))
+
+		webhooks, err = store.GetWebhooksForBoard(context.Background(), "other-board")
+		require.NoError(t, err, "get webhooks for board should not error")
+		assert.Empty(t, webhooks)
+
+		fetched, err := store.GetWebhook(context.Background(), other.ID)
+		require.NoError(t, err, "get webhook should not error")
+		assert.Equal(t, other.URL, fetched.URL)
+		assert.Equal(t, "my-secret", fetched.Secret)
+
+		_, err = store.GetWebhook(context.Background(), "bogus")
+		assert.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		var errInvalid model.ErrInvalidWebhook
+
+		err := store.CreateWebhook(context.Background(), &model.Webhook{BoardID: testBoardID, URL: "ftp://example.com", EventTypes: []string{"card.created"}})
+		require.ErrorAs(t, err, &errInvalid)
+
+		err = store.CreateWebhook(context.Background(), &model.Webhook{BoardID: testBoardID, URL: "https://example.com"})
+		require.ErrorAs(t, err, &errInvalid)
+
+		err = store.CreateWebhook(context.Background(), &model.Webhook{URL: "https://example.com", EventTypes: []string{"card.created"}})
+		require.ErrorAs(t, err, &errInvalid)
+	})
+}
+
+func testUpdateWebhook(t *testing.T, store store.Store) {
+	webhook := &model.Webhook{
+		BoardID:    testBoardID,
+		URL:        "https://example.com/hook",
+		Secret:     "my-secret",
+		EventTypes: []string{"card.created"},
+		CreatedBy:  testUserID,
+	}
+	require.NoError(t, store.CreateWebhook(context.Background(), webhook))
+
+	t.Run("update webhook", func(t *testing.T) {
+		update := &model.Webhook{
+			ID:         webhook.ID,
+			BoardID:    testBoardID,
+			URL:        "https://example.com/new-hook",
+			EventTypes: []string{"card.updated", "card.deleted"},
+		}
+		require.NoError(t, store.UpdateWebhook(context.Background(), update, "user-id"), "update webhook should not error")
+
+		webhooks, err := store.GetWebhooksForBoard(context.Background(), testBoardID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		assert.Equal(t, "https://example.com/new-hook", webhooks[0].URL)
+		assert.Equal(t, []string{"card.updated", "card.deleted"}, webhooks[0].EventTypes)
+		assert.Equal(t, "my-secret", webhooks[0].Secret, "the secret should be kept if not provided")
+		assert.Equal(t, testUserID, webhooks[0].CreatedBy)
+	})
+
+	t.Run("update non-existent webhook", func(t *testing.T) {
+		update := &model.Webhook{
+			ID:         "bogus",
+			BoardID:    testBoardID,
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"card.created"},
+		}
+		err := store.UpdateWebhook(context.Background(), update, "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteWebhook(t *testing.T, store store.Store) {
+	t.Run("delete webhook", func(t *testing.T) {
+		webhook := &model.Webhook{
+			BoardID:    testBoardID,
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"card.created"},
+		}
+		require.NoError(t, store.CreateWebhook(context.Background(), webhook))
+
+		require.NoError(t, store.DeleteWebhook(context.Background(), webhook.ID, "user-id"), "delete webhook should not error")
+
+		webhooks, err := store.GetWebhooksForBoard(context.Background(), testBoardID)
+		require.NoError(t, err)
+		assert.Empty(t, webhooks)
+
+		err = store.DeleteWebhook(context.Background(), webhook.ID, "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("deleting a board removes its webhooks", func(t *testing.T) {
+		board, _, err := store.InsertBoardWithAdmin(context.Background(), &model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+		}, testUserID)
+		require.NoError(t, err)
+
+		webhook := &model.Webhook{
+			BoardID:    board.ID,
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"card.created"},
+		}
+		require.NoError(t, store.CreateWebhook(context.Background(), webhook))
+
+		require.NoError(t, store.DeleteBoard(context.Background(), board.ID, testUserID))
+
+		webhooks, err := store.GetWebhooksForBoard(context.Background(), board.ID)
+		require.NoError(t, err)
+		assert.Empty(t, webhooks)
+	})
+}
+
+func testRecordWebhookDelivery(t *testing.T, store store.Store) {
+	t.Run("record webhook delivery", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{
+			BoardID:   testBoardID,
+			EventType: "card.created",
+			Payload:   `{"id": "card1"}`,
+		}
+
+		err := store.RecordWebhookDelivery(context.Background(), delivery)
+		require.NoError(t, err, "record webhook delivery should not error")
+		assert.NotEmpty(t, delivery.ID)
+		assert.NotZero(t, delivery.CreateAt)
+		assert.Equal(t, delivery.CreateAt, delivery.NextRetryAt)
+		assert.Zero(t, delivery.Attempts)
+	})
+
+	t.Run("invalid webhook delivery", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{
+			BoardID: testBoardID,
+		}
+
+		err := store.RecordWebhookDelivery(context.Background(), delivery)
+		var errInvalid model.ErrInvalidWebhookDelivery
+		require.ErrorAs(t, err, &errInvalid)
+
+		delivery = &model.WebhookDelivery{
+			EventType: "card.created",
+		}
+
+		err = store.RecordWebhookDelivery(context.Background(), delivery)
+		require.ErrorAs(t, err, &errInvalid)
+	})
+}
+
+func testGetPendingWebhookDeliveries(t *testing.T, store store.Store) {
+	t.Run("claim pending deliveries", func(t *testing.T) {
+		due := make([]*model.WebhookDelivery, 0, 3)
+		for i := 0; i < 3; i++ {
+			delivery := &model.WebhookDelivery{
+				WebhookID: "webhook-id",
+				BoardID:   testBoardID,
+				EventType: "card.created",
+				Payload:   "{}",
+			}
+			require.NoError(t, store.RecordWebhookDelivery(context.Background(), delivery))
+			due = append(due, delivery)
+		}
+
+		// deliveries scheduled in the future are not pending yet
+		later := &model.WebhookDelivery{
+			BoardID:     testBoardID,
+			EventType:   "card.created",
+			NextRetryAt: utils.GetMillisForTime(time.Now().Add(time.Hour)),
+		}
+		require.NoError(t, store.RecordWebhookDelivery(context.Background(), later))
+
+		deliveries, err := store.GetPendingWebhookDeliveries(context.Background(), 2)
+		require.NoError(t, err, "get pending webhook deliveries should not error")
+		require.Len(t, deliveries, 2)
+		for _, delivery := range deliveries {
+			assert.Equal(t, "webhook-id", delivery.WebhookID)
+			assert.Equal(t, 1, delivery.Attempts)
+			assert.Greater(t, delivery.NextRetryAt, utils.GetMillis())
+		}
+
+		// claimed deliveries are not returned again
+		deliveries2, err := store.GetPendingWebhookDeliveries(context.Background(), 10)
+		require.NoError(t, err, "get pending webhook deliveries should not error")
+		require.Len(t, deliveries2, 1)
+		assert.NotContains(t, []string{deliveries[0].ID, deliveries[1].ID}, deliveries2[0].ID)
+		assert.NotEqual(t, later.ID, deliveries2[0].ID)
+
+		deliveries3, err := store.GetPendingWebhookDeliveries(context.Background(), 10)
+		require.NoError(t, err, "get pending webhook deliveries should not error")
+		assert.Empty(t, deliveries3)
+	})
+
+	t.Run("no pending deliveries", func(t *testing.T) {
+		deliveries, err := store.GetPendingWebhookDeliveries(context.Background(), 10)
+		require.NoError(t, err, "get pending webhook deliveries should not error")
+		assert.Empty(t, deliveries)
+	})
+}
+
+func testMarkWebhookDelivered(t *testing.T, store store.Store) {
+	t.Run("mark webhook delivered", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{
+			BoardID:   testBoardID,
+			EventType: "card.created",
+			Payload:   "{}",
+		}
+		require.NoError(t, store.RecordWebhookDelivery(context.Background(), delivery))
+
+		err := store.MarkWebhookDelivered(context.Background(), delivery.ID, http.StatusOK)
+		require.NoError(t, err, "mark webhook delivered should not error")
+
+		deliveries, err := store.GetPendingWebhookDeliveries(context.Background(), 10)
+		require.NoError(t, err, "get pending webhook deliveries should not error")
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("mark non-existent webhook delivered", func(t *testing.T) {
+		err := store.MarkWebhookDelivered(context.Background(), "bogus", http.StatusOK)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
@@ -31,16 +33,47 @@ func (wh *Client) NotifyUpdate(block *model.Block) {
 	}
 }
 
+// Deliver posts the payload of a delivery to a board webhook, signed
+// with the webhook secret, and returns the response status.
+func (wh *Client) Deliver(webhook *model.Webhook, delivery *model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Focalboard-Event", delivery.EventType)
+	req.Header.Set("X-Focalboard-Delivery", delivery.ID)
+	req.Header.Set(model.WebhookSignatureHeader, model.SignWebhookPayload(webhook.Secret, []byte(delivery.Payload)))
+
+	resp, err := wh.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	wh.logger.Debug("webhook.Deliver",
+		mlog.String("webhook_id", webhook.ID),
+		mlog.String("delivery_id", delivery.ID),
+		mlog.Int("status", resp.StatusCode),
+	)
+	return resp.StatusCode, nil
+}
+
+const deliveryTimeout = 10 * time.Second
+
 // Client is a webhook client.
 type Client struct {
-	config *config.Configuration
-	logger mlog.LoggerIFace
+	config     *config.Configuration
+	logger     mlog.LoggerIFace
+	httpClient *http.Client
 }
 
 // NewClient creates a new Client.
 func NewClient(config *config.Configuration, logger mlog.LoggerIFace) *Client {
 	return &Client{
-		config: config,
-		logger: logger,
+		config:     config,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: deliveryTimeout},
 	}
 }
@@ -1,6 +1,7 @@
 package webhook
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -37,3 +39,38 @@ func TestClientUpdateNotify(t *testing.T) {
 		t.Error("webhook url not be notified")
 	}
 }
+
+func TestClientDeliver(t *testing.T) {
+	webhook := &model.Webhook{ID: "webhook-id", Secret: "secret"}
+	delivery := &model.WebhookDelivery{ID: "delivery-id", EventType: model.WebhookEventBlockCreated, Payload: `{"id":"delivery-id"}`}
+
+	var received *http.Request
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+	webhook.URL = ts.URL
+
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	client := NewClient(&config.Configuration{}, logger)
+
+	status, err := client.Deliver(webhook, delivery)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, delivery.Payload, string(body))
+	assert.Equal(t, model.WebhookEventBlockCreated, received.Header.Get("X-Focalboard-Event"))
+	assert.Equal(t, "delivery-id", received.Header.Get("X-Focalboard-Delivery"))
+	assert.Equal(t, model.SignWebhookPayload("secret", body), received.Header.Get(model.WebhookSignatureHeader))
+
+	ts.Close()
+	_, err = client.Deliver(webhook, delivery)
+	assert.Error(t, err)
+}
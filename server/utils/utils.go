@@ -3,7 +3,13 @@ package utils
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 )
@@ -50,6 +56,35 @@ func SecondsToMillis(seconds int64) int64 {
 	return seconds * 1000
 }
 
+// DayStartsInRange returns the start of every day, in epoch millis, that
+// overlaps the [from, to) range, using the day boundaries of the given
+// location.
+func DayStartsInRange(from, to int64, loc *time.Location) []int64 {
+	dayStarts := []int64{}
+	if to <= from {
+		return dayStarts
+	}
+
+	t := GetTimeForMillis(from).In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	for GetMillisForTime(day) < to {
+		dayStarts = append(dayStarts, GetMillisForTime(day))
+		day = day.AddDate(0, 0, 1)
+	}
+	return dayStarts
+}
+
+// NormalizeSearchTerm lower-cases a search term and strips its
+// diacritics, so "Café" becomes "cafe".
+func NormalizeSearchTerm(term string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(t, term)
+	if err != nil {
+		normalized = term
+	}
+	return strings.ToLower(normalized)
+}
+
 func StructToMap(v interface{}) (m map[string]interface{}) {
 	b, _ := json.Marshal(v)
 	_ = json.Unmarshal(b, &m)
@@ -2,6 +2,8 @@
 package ws
 
 import (
+	"context"
+
 	"github.com/mattermost/focalboard/server/model"
 )
 
@@ -15,6 +17,7 @@ const (
 	websocketActionUpdateMember             = "UPDATE_MEMBER"
 	websocketActionDeleteMember             = "DELETE_MEMBER"
 	websocketActionUpdateBlock              = "UPDATE_BLOCK"
+	websocketActionPatchBlock               = "PATCH_BLOCK"
 	websocketActionUpdateConfig             = "UPDATE_CLIENT_CONFIG"
 	websocketActionUpdateCategory           = "UPDATE_CATEGORY"
 	websocketActionUpdateCategoryBoard      = "UPDATE_BOARD_CATEGORY"
@@ -23,12 +26,13 @@ const (
 )
 
 type Store interface {
-	GetBlock(blockID string) (*model.Block, error)
-	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
+	GetBlock(ctx context.Context, blockID string) (*model.Block, error)
+	GetMembersForBoard(ctx context.Context, boardID string) ([]*model.BoardMember, error)
 }
 
 type Adapter interface {
 	BroadcastBlockChange(teamID string, block *model.Block)
+	BroadcastBlockPatch(teamID string, oldBlock, block *model.Block)
 	BroadcastBlockDelete(teamID, blockID, boardID string)
 	BroadcastBoardChange(teamID string, board *model.Board)
 	BroadcastBoardDelete(teamID, boardID string)
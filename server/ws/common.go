@@ -12,11 +12,28 @@ type UpdateCategoryMessage struct {
 	BoardCategories *model.BoardCategoryWebsocketData `json:"blockCategories,omitempty"`
 }
 
-// UpdateBlockMsg is sent on block updates.
+// UpdateBlockMsg is sent on block updates. The revision numbers the
+// block messages of the board, it is only set by the standalone server.
 type UpdateBlockMsg struct {
-	Action string       `json:"action"`
-	TeamID string       `json:"teamId"`
-	Block  *model.Block `json:"block"`
+	Action   string       `json:"action"`
+	TeamID   string       `json:"teamId"`
+	Revision int64        `json:"revision,omitempty"`
+	Block    *model.Block `json:"block"`
+}
+
+// PatchBlockMsg is sent on block updates instead of the whole block,
+// with the changes from its previous version. The expected update time
+// of the patch is the update time of the version it applies to, if the
+// client holds another version it has to fetch the block again.
+type PatchBlockMsg struct {
+	Action     string            `json:"action"`
+	TeamID     string            `json:"teamId"`
+	BoardID    string            `json:"boardId"`
+	BlockID    string            `json:"blockId"`
+	Revision   int64             `json:"revision,omitempty"`
+	UpdateAt   int64             `json:"updateAt"`
+	ModifiedBy string            `json:"modifiedBy"`
+	Patch      *model.BlockPatch `json:"patch"`
 }
 
 // UpdateBoardMsg is sent on block updates.
@@ -2,6 +2,7 @@
 package ws
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -27,6 +28,7 @@ type PluginAdapterInterface interface {
 	WebSocketMessageHasBeenPosted(webConnID, userID string, req *mmModel.WebSocketRequest)
 	BroadcastConfigChange(clientConfig model.ClientConfig)
 	BroadcastBlockChange(teamID string, block *model.Block)
+	BroadcastBlockPatch(teamID string, oldBlock, block *model.Block)
 	BroadcastBlockDelete(teamID, blockID, parentID string)
 	BroadcastSubscriptionChange(teamID string, subscription *model.Subscription)
 	BroadcastCardLimitTimestampChange(cardLimitTimestamp int64)
@@ -217,7 +219,7 @@ func (pa *PluginAdapter) getUserIDsForTeamAndBoard(teamID, boardID string, ensur
 		}
 	}
 
-	members, err := pa.store.GetMembersForBoard(boardID)
+	members, err := pa.store.GetMembersForBoard(context.Background(), boardID)
 	if err != nil {
 		pa.logger.Error("error getting members for board",
 			mlog.String("method", "getUserIDsForTeamAndBoard"),
@@ -469,6 +471,32 @@ func (pa *PluginAdapter) BroadcastBlockChange(teamID string, block *model.Block)
 	pa.sendBoardMessage(teamID, block.BoardID, utils.StructToMap(message))
 }
 
+func (pa *PluginAdapter) BroadcastBlockPatch(teamID string, oldBlock, block *model.Block) {
+	patch, ok := model.NewDeltaPatch(oldBlock, block)
+	if !ok {
+		pa.BroadcastBlockChange(teamID, block)
+		return
+	}
+
+	pa.logger.Debug("BroadcastingBlockPatch",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", block.BoardID),
+		mlog.String("blockID", block.ID),
+	)
+
+	message := PatchBlockMsg{
+		Action:     websocketActionPatchBlock,
+		TeamID:     teamID,
+		BoardID:    block.BoardID,
+		BlockID:    block.ID,
+		UpdateAt:   block.UpdateAt,
+		ModifiedBy: block.ModifiedBy,
+		Patch:      patch,
+	}
+
+	pa.sendBoardMessage(teamID, block.BoardID, utils.StructToMap(message))
+}
+
 func (pa *PluginAdapter) BroadcastCategoryChange(category model.Category) {
 	pa.logger.Debug("BroadcastCategoryChange",
 		mlog.String("userID", category.UserID),
@@ -8,6 +8,7 @@ import (
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -350,7 +351,7 @@ func TestGetUserIDsForTeamAndBoard(t *testing.T) {
 	t.Run("should find that only user1 is connected to team 1 and board 1", func(t *testing.T) {
 		mockedMembers := []*model.BoardMember{{UserID: userID1}}
 		th.store.EXPECT().
-			GetMembersForBoard(boardID1).
+			GetMembersForBoard(gomock.Any(), boardID1).
 			Return(mockedMembers, nil).
 			Times(1)
 
@@ -366,7 +367,7 @@ func TestGetUserIDsForTeamAndBoard(t *testing.T) {
 	t.Run("should find that both users are connected to team 2 and board 2", func(t *testing.T) {
 		mockedMembers := []*model.BoardMember{{UserID: userID1}, {UserID: userID2}}
 		th.store.EXPECT().
-			GetMembersForBoard(boardID2).
+			GetMembersForBoard(gomock.Any(), boardID2).
 			Return(mockedMembers, nil).
 			Times(1)
 
@@ -386,7 +387,7 @@ func TestGetUserIDsForTeamAndBoard(t *testing.T) {
 	t.Run("should find that only one user is connected to team 2 and board 2 if there is only one membership with both connected", func(t *testing.T) {
 		mockedMembers := []*model.BoardMember{{UserID: userID1}}
 		th.store.EXPECT().
-			GetMembersForBoard(boardID2).
+			GetMembersForBoard(gomock.Any(), boardID2).
 			Return(mockedMembers, nil).
 			Times(1)
 
@@ -405,7 +406,7 @@ func TestGetUserIDsForTeamAndBoard(t *testing.T) {
 
 		mockedMembers := []*model.BoardMember{{UserID: userID1}, {UserID: userID2}}
 		th.store.EXPECT().
-			GetMembersForBoard(boardID2).
+			GetMembersForBoard(gomock.Any(), boardID2).
 			Return(mockedMembers, nil).
 			Times(1)
 
@@ -422,7 +423,7 @@ func TestGetUserIDsForTeamAndBoard(t *testing.T) {
 		userID3 := mmModel.NewId()
 		mockedMembers := []*model.BoardMember{{UserID: userID1}, {UserID: userID2}}
 		th.store.EXPECT().
-			GetMembersForBoard(boardID2).
+			GetMembersForBoard(gomock.Any(), boardID2).
 			Return(mockedMembers, nil).
 			Times(1)
 
@@ -442,7 +443,7 @@ func TestGetUserIDsForTeamAndBoard(t *testing.T) {
 	t.Run("should not include a user that, although present, has no team access anymore", func(t *testing.T) {
 		mockedMembers := []*model.BoardMember{{UserID: userID1}, {UserID: userID2}}
 		th.store.EXPECT().
-			GetMembersForBoard(boardID2).
+			GetMembersForBoard(gomock.Any(), boardID2).
 			Return(mockedMembers, nil).
 			Times(1)
 
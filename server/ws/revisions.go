@@ -0,0 +1,61 @@
+package ws
+
+import (
+	"sync"
+)
+
+// boardRevisions numbers the block messages of each board. Clients
+// track the last revision they received for a board, and fetch the
+// board again when they find a gap, as they have missed some update.
+// The revisions are kept in memory, so they start over when the
+// server restarts, and the clients see a gap and fall back to a full
+// fetch. Boards are forgotten once nobody listens to them.
+type boardRevisions struct {
+	mu        sync.Mutex
+	revisions map[string]*boardRevision
+}
+
+type boardRevision struct {
+	teamID   string
+	revision int64
+}
+
+func newBoardRevisions() *boardRevisions {
+	return &boardRevisions{
+		revisions: make(map[string]*boardRevision),
+	}
+}
+
+// next returns the revision of a new message for the board.
+func (br *boardRevisions) next(teamID, boardID string) int64 {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	revision, ok := br.revisions[boardID]
+	if !ok {
+		revision = &boardRevision{teamID: teamID}
+		br.revisions[boardID] = revision
+	}
+	revision.revision++
+	return revision.revision
+}
+
+// evict forgets the revision of a board.
+func (br *boardRevisions) evict(boardID string) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	delete(br.revisions, boardID)
+}
+
+// evictTeam forgets the revisions of the boards of a team.
+func (br *boardRevisions) evictTeam(teamID string) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	for boardID, revision := range br.revisions {
+		if revision.teamID == teamID {
+			delete(br.revisions, boardID)
+		}
+	}
+}
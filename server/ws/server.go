@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"sync"
@@ -9,6 +10,8 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/metrics"
+	"github.com/mattermost/focalboard/server/services/ratelimit"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -53,6 +56,9 @@ type Server struct {
 	isMattermostAuth bool
 	logger           mlog.LoggerIFace
 	store            Store
+	rateLimiter      *ratelimit.Limiter
+	revisions        *boardRevisions
+	metrics          *metrics.Metrics
 }
 
 type websocketSession struct {
@@ -67,8 +73,10 @@ func (wss *websocketSession) isAuthenticated() bool {
 	return wss.userID != ""
 }
 
-// NewServer creates a new Server.
-func NewServer(auth *auth.Auth, singleUserToken string, isMattermostAuth bool, logger mlog.LoggerIFace, store Store) *Server {
+// NewServer creates a new Server. The rate limiter is optional, if
+// present the websocket upgrades are rate limited. The metrics are
+// optional too, if present the open connections are reported.
+func NewServer(auth *auth.Auth, singleUserToken string, isMattermostAuth bool, logger mlog.LoggerIFace, store Store, rateLimiter *ratelimit.Limiter, metrics *metrics.Metrics) *Server {
 	return &Server{
 		listeners:        make(map[*websocketSession]bool),
 		listenersByTeam:  make(map[string][]*websocketSession),
@@ -83,6 +91,9 @@ func NewServer(auth *auth.Auth, singleUserToken string, isMattermostAuth bool, l
 		isMattermostAuth: isMattermostAuth,
 		logger:           logger,
 		store:            store,
+		rateLimiter:      rateLimiter,
+		revisions:        newBoardRevisions(),
+		metrics:          metrics,
 	}
 }
 
@@ -92,6 +103,15 @@ func (ws *Server) RegisterRoutes(r *mux.Router) {
 }
 
 func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	if ws.rateLimiter != nil {
+		if !ws.rateLimiter.AllowIP(w, r) {
+			return
+		}
+		if userID := r.Header.Get("Mattermost-User-Id"); ws.isMattermostAuth && userID != "" && !ws.rateLimiter.AllowUser(w, r, userID) {
+			return
+		}
+	}
+
 	// Upgrade initial GET request to a websocket
 	client, err := ws.upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -147,7 +167,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 		if command.Action == websocketActionAuth {
 			ws.logger.Debug(`Command: AUTH`, mlog.Stringer("client", wsSession.conn.RemoteAddr()))
-			ws.authenticateListener(wsSession, command.Token)
+			ws.authenticateListener(r.Context(), wsSession, command.Token)
 
 			continue
 		}
@@ -161,7 +181,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 			)
 
-			if !ws.isCommandReadTokenValid(command) {
+			if !ws.isCommandReadTokenValid(r.Context(), command) {
 				ws.logger.Error(`Rejected invalid read token`,
 					mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 					mlog.String("action", command.Action),
@@ -181,7 +201,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 			)
 
-			if !ws.isCommandReadTokenValid(command) {
+			if !ws.isCommandReadTokenValid(r.Context(), command) {
 				ws.logger.Error(`Rejected invalid read token`,
 					mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 					mlog.String("action", command.Action),
@@ -246,7 +266,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 // isCommandReadTokenValid ensures that a command contains a read
 // token and a set of block ids that said token is valid for.
-func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
+func (ws *Server) isCommandReadTokenValid(ctx context.Context, command WebsocketCommand) bool {
 	if len(command.TeamID) == 0 {
 		return false
 	}
@@ -254,7 +274,7 @@ func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
 	boardID := ""
 	// all the blocks must be part of the same board
 	for _, blockID := range command.BlockIDs {
-		block, err := ws.store.GetBlock(blockID)
+		block, err := ws.store.GetBlock(ctx, blockID)
 		if err != nil {
 			return false
 		}
@@ -270,7 +290,7 @@ func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
 	}
 
 	// the read token must be valid for the board
-	isValid, err := ws.auth.IsValidReadToken(boardID, command.ReadToken)
+	isValid, err := ws.auth.IsValidReadToken(ctx, boardID, command.ReadToken)
 	if err != nil {
 		ws.logger.Error(`ERROR when checking token validity`,
 			mlog.String("teamID", command.TeamID),
@@ -290,6 +310,7 @@ func (ws *Server) addListener(listener *websocketSession) {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	ws.listeners[listener] = true
+	ws.metrics.ObserveWebsocketConnections(len(ws.listeners))
 }
 
 // removeListener removes a listener and all its subscriptions, if
@@ -311,6 +332,7 @@ func (ws *Server) removeListener(listener *websocketSession) {
 	}
 
 	delete(ws.listeners, listener)
+	ws.metrics.ObserveWebsocketConnections(len(ws.listeners))
 }
 
 // subscribeListenerToTeam safely modifies the listener and the
@@ -382,6 +404,9 @@ func (ws *Server) removeListenerFromTeam(listener *websocketSession, teamID stri
 		}
 	}
 	ws.listenersByTeam[teamID] = newTeamListeners
+	if len(newTeamListeners) == 0 {
+		ws.revisions.evictTeam(teamID)
+	}
 
 	// we remove the team from the listener subscription list
 	newListenerTeams := []string{}
@@ -415,7 +440,7 @@ func (ws *Server) removeListenerFromBlock(listener *websocketSession, blockID st
 	listener.blocks = newListenerBlocks
 }
 
-func (ws *Server) getUserIDForToken(token string) string {
+func (ws *Server) getUserIDForToken(ctx context.Context, token string) string {
 	if len(ws.singleUserToken) > 0 {
 		if token == ws.singleUserToken {
 			return model.SingleUser
@@ -424,7 +449,7 @@ func (ws *Server) getUserIDForToken(token string) string {
 		}
 	}
 
-	session, err := ws.auth.GetSession(token)
+	session, err := ws.auth.GetSession(ctx, token)
 	if session == nil || err != nil {
 		return ""
 	}
@@ -432,7 +457,7 @@ func (ws *Server) getUserIDForToken(token string) string {
 	return session.UserID
 }
 
-func (ws *Server) authenticateListener(wsSession *websocketSession, token string) {
+func (ws *Server) authenticateListener(ctx context.Context, wsSession *websocketSession, token string) {
 	ws.logger.Debug("authenticateListener",
 		mlog.String("token", token),
 		mlog.String("wsSession.userID", wsSession.userID),
@@ -448,7 +473,7 @@ func (ws *Server) authenticateListener(wsSession *websocketSession, token string
 	}
 
 	// Authenticate session
-	userID := ws.getUserIDForToken(token)
+	userID := ws.getUserIDForToken(ctx, token)
 	if userID == "" {
 		wsSession.conn.Close()
 		return
@@ -474,7 +499,7 @@ func (ws *Server) getListenersForTeam(teamID string) []*websocketSession {
 // getListenersForTeamAndBoard returns the listeners subscribed to a
 // team changes and members of a given board.
 func (ws *Server) getListenersForTeamAndBoard(teamID, boardID string, ensureUsers ...string) []*websocketSession {
-	members, err := ws.store.GetMembersForBoard(boardID)
+	members, err := ws.store.GetMembersForBoard(context.Background(), boardID)
 	if err != nil {
 		ws.logger.Error("error getting members for board",
 			mlog.String("method", "getListenersForTeamAndBoard"),
@@ -522,29 +547,82 @@ func (ws *Server) BroadcastBlockDelete(teamID, blockID, boardID string) {
 
 // BroadcastBlockChange broadcasts update messages to clients.
 func (ws *Server) BroadcastBlockChange(teamID string, block *model.Block) {
-	blockIDsToNotify := []string{block.ID, block.ParentID}
+	boardListeners, blockListeners := ws.getListenersForBlockMessage(teamID, block)
 
 	message := UpdateBlockMsg{
 		Action: websocketActionUpdateBlock,
 		TeamID: teamID,
 		Block:  block,
 	}
+	ws.sendBlockMessage(blockListeners, teamID, block, message)
+
+	if len(boardListeners) == 0 {
+		ws.revisions.evict(block.BoardID)
+		return
+	}
+	message.Revision = ws.revisions.next(teamID, block.BoardID)
+	ws.sendBlockMessage(boardListeners, teamID, block, message)
+}
+
+// BroadcastBlockPatch broadcasts the changes of a block from its
+// previous version to clients, falling back to the whole block if the
+// changes can't be sent as a patch.
+func (ws *Server) BroadcastBlockPatch(teamID string, oldBlock, block *model.Block) {
+	patch, ok := model.NewDeltaPatch(oldBlock, block)
+	if !ok {
+		ws.BroadcastBlockChange(teamID, block)
+		return
+	}
 
-	listeners := ws.getListenersForTeamAndBoard(teamID, block.BoardID)
+	boardListeners, blockListeners := ws.getListenersForBlockMessage(teamID, block)
+
+	message := PatchBlockMsg{
+		Action:     websocketActionPatchBlock,
+		TeamID:     teamID,
+		BoardID:    block.BoardID,
+		BlockID:    block.ID,
+		UpdateAt:   block.UpdateAt,
+		ModifiedBy: block.ModifiedBy,
+		Patch:      patch,
+	}
+	ws.sendBlockMessage(blockListeners, teamID, block, message)
+
+	if len(boardListeners) == 0 {
+		ws.revisions.evict(block.BoardID)
+		return
+	}
+	message.Revision = ws.revisions.next(teamID, block.BoardID)
+	ws.sendBlockMessage(boardListeners, teamID, block, message)
+}
+
+// getListenersForBlockMessage returns the listeners of the board of a
+// block, and the listeners of the block and its parent. Only the board
+// listeners get the revisions of the board, as the block listeners
+// don't receive all its messages and would see gaps in them.
+func (ws *Server) getListenersForBlockMessage(teamID string, block *model.Block) ([]*websocketSession, []*websocketSession) {
+	blockIDsToNotify := []string{block.ID, block.ParentID}
+
+	boardListeners := ws.getListenersForTeamAndBoard(teamID, block.BoardID)
 	ws.logger.Trace("listener(s) for teamID",
-		mlog.Int("listener_count", len(listeners)),
+		mlog.Int("listener_count", len(boardListeners)),
 		mlog.String("teamID", teamID),
 		mlog.String("boardID", block.BoardID),
 	)
 
+	blockListeners := []*websocketSession{}
 	for _, blockID := range blockIDsToNotify {
-		listeners = append(listeners, ws.getListenersForBlock(blockID)...)
+		blockListeners = append(blockListeners, ws.getListenersForBlock(blockID)...)
 		ws.logger.Trace("listener(s) for blockID",
-			mlog.Int("listener_count", len(listeners)),
+			mlog.Int("listener_count", len(blockListeners)),
 			mlog.String("blockID", blockID),
 		)
 	}
 
+	return boardListeners, blockListeners
+}
+
+// sendBlockMessage sends a message about a block to its listeners.
+func (ws *Server) sendBlockMessage(listeners []*websocketSession, teamID string, block *model.Block, message interface{}) {
 	for _, listener := range listeners {
 		ws.logger.Debug("Broadcast block change",
 			mlog.String("teamID", teamID),
@@ -1,20 +1,26 @@
 package ws
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/model"
+	wsMocks "github.com/mattermost/focalboard/server/ws/mocks"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 
+	"github.com/golang/mock/gomock"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTeamSubscription(t *testing.T) {
-	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil)
+	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil, nil, nil)
 	session := &websocketSession{
 		conn:   &websocket.Conn{},
 		mu:     sync.Mutex{},
@@ -103,8 +109,43 @@ func TestTeamSubscription(t *testing.T) {
 	})
 }
 
+func TestBoardRevisions(t *testing.T) {
+	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil, nil, nil)
+	session := &websocketSession{
+		conn:   &websocket.Conn{},
+		mu:     sync.Mutex{},
+		teams:  []string{},
+		blocks: []string{},
+	}
+	teamID := "fake-team-id"
+	boardID := "fake-board-id"
+
+	t.Run("Should number the messages of each board", func(t *testing.T) {
+		require.Equal(t, int64(1), server.revisions.next(teamID, boardID))
+		require.Equal(t, int64(2), server.revisions.next(teamID, boardID))
+		require.Equal(t, int64(1), server.revisions.next(teamID, "other-fake-board-id"))
+	})
+
+	t.Run("Should start over once evicted", func(t *testing.T) {
+		server.revisions.evict(boardID)
+
+		require.Equal(t, int64(1), server.revisions.next(teamID, boardID))
+	})
+
+	t.Run("Should evict the boards of a team without listeners", func(t *testing.T) {
+		server.addListener(session)
+		server.subscribeListenerToTeam(session, teamID)
+		require.Equal(t, int64(2), server.revisions.next(teamID, boardID))
+
+		server.unsubscribeListenerFromTeam(session, teamID)
+
+		require.Empty(t, server.revisions.revisions)
+		require.Equal(t, int64(1), server.revisions.next(teamID, boardID))
+	})
+}
+
 func TestBlocksSubscription(t *testing.T) {
-	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil)
+	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil, nil, nil)
 	session := &websocketSession{
 		conn:   &websocket.Conn{},
 		mu:     sync.Mutex{},
@@ -224,18 +265,75 @@ func TestBlocksSubscription(t *testing.T) {
 
 func TestGetUserIDForTokenInSingleUserMode(t *testing.T) {
 	singleUserToken := "single-user-token"
-	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil)
+	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil, nil, nil)
 	server.singleUserToken = singleUserToken
 
 	t.Run("Should return nothing if the token is empty", func(t *testing.T) {
-		require.Empty(t, server.getUserIDForToken(""))
+		require.Empty(t, server.getUserIDForToken(context.Background(), ""))
 	})
 
 	t.Run("Should return nothing if the token is invalid", func(t *testing.T) {
-		require.Empty(t, server.getUserIDForToken("invalid-token"))
+		require.Empty(t, server.getUserIDForToken(context.Background(), "invalid-token"))
 	})
 
 	t.Run("Should return the single user ID if the token is correct", func(t *testing.T) {
-		require.Equal(t, model.SingleUser, server.getUserIDForToken(singleUserToken))
+		require.Equal(t, model.SingleUser, server.getUserIDForToken(context.Background(), singleUserToken))
+	})
+}
+
+func TestBroadcastBlockChangeRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := wsMocks.NewMockStore(ctrl)
+	server := NewServer(&auth.Auth{}, "token", false, mlog.CreateConsoleTestLogger(true, mlog.LvlDebug), mockStore, nil, nil)
+
+	teamID := "fake-team-id"
+	boardID := "fake-board-id"
+	cardID := "fake-card-id"
+	userID := "fake-user-id"
+
+	mockStore.EXPECT().GetMembersForBoard(gomock.Any(), boardID).Return([]*model.BoardMember{{BoardID: boardID, UserID: userID}}, nil).AnyTimes()
+
+	// newSession returns a listener backed by a real connection, and
+	// the client side of that connection.
+	newSession := func(t *testing.T) (*websocketSession, *websocket.Conn) {
+		conns := make(chan *websocket.Conn, 1)
+		httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := server.upgrader.Upgrade(w, r, nil)
+			require.NoError(t, err)
+			conns <- conn
+		}))
+		t.Cleanup(httpServer.Close)
+
+		client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+
+		session := &websocketSession{conn: <-conns, teams: []string{}, blocks: []string{}}
+		server.addListener(session)
+		return session, client
+	}
+
+	readRevision := func(t *testing.T, client *websocket.Conn) int64 {
+		var message UpdateBlockMsg
+		require.NoError(t, client.ReadJSON(&message))
+		return message.Revision
+	}
+
+	boardSession, boardClient := newSession(t)
+	boardSession.userID = userID
+	server.subscribeListenerToTeam(boardSession, teamID)
+
+	blockSession, blockClient := newSession(t)
+	server.subscribeListenerToBlocks(blockSession, []string{cardID})
+
+	t.Run("Block listeners should not get the board revisions", func(t *testing.T) {
+		// a change to another card of the board only reaches the board
+		// listener, so the block listener would see a gap
+		server.BroadcastBlockChange(teamID, &model.Block{ID: "other-card-id", BoardID: boardID, ParentID: boardID})
+		require.Equal(t, int64(1), readRevision(t, boardClient))
+
+		server.BroadcastBlockChange(teamID, &model.Block{ID: cardID, BoardID: boardID, ParentID: boardID})
+		require.Equal(t, int64(2), readRevision(t, boardClient))
+		require.Zero(t, readRevision(t, blockClient))
 	})
 }
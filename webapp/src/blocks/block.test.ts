@@ -2,7 +2,7 @@
 // See LICENSE.txt for license information.
 import {TestBlockFactory} from '../test/testBlockFactory'
 
-import {createPatchesFromBlocks, createBlock} from './block'
+import {createPatchesFromBlocks, createBlock, applyBlockDeltaPatch} from './block'
 
 describe('block tests', () => {
     const board = TestBlockFactory.createBoard()
@@ -41,3 +41,49 @@ describe('block tests', () => {
         })
     })
 })
+
+describe('applyBlockDeltaPatch', () => {
+    const board = TestBlockFactory.createBoard()
+    const emptyPatch = {
+        parentId: null,
+        schema: null,
+        type: null,
+        title: null,
+        sortOrder: null,
+        updatedFields: null,
+        deletedFields: null,
+        updatedProperties: null,
+        deletedProperties: null,
+    }
+
+    it('should only change the values of the patch', () => {
+        const card = TestBlockFactory.createCard(board)
+        card.fields.properties = {status: 'todo', priority: 'high'}
+        card.fields.icon = 'a'
+
+        const patched = applyBlockDeltaPatch(card, {
+            ...emptyPatch,
+            title: 'new title',
+            updatedFields: {isTemplate: true},
+            deletedFields: ['icon'],
+            updatedProperties: {status: 'done'},
+            deletedProperties: ['priority'],
+        })
+
+        expect(patched.title).toBe('new title')
+        expect(patched.parentId).toBe(card.parentId)
+        expect(patched.fields.isTemplate).toBe(true)
+        expect(patched.fields.icon).toBeUndefined()
+        expect(patched.fields.properties).toEqual({status: 'done'})
+    })
+
+    it('should not modify the original block', () => {
+        const card = TestBlockFactory.createCard(board)
+        card.fields.properties = {status: 'todo'}
+
+        applyBlockDeltaPatch(card, {...emptyPatch, title: 'new title', updatedProperties: {status: 'done'}})
+
+        expect(card.title).not.toBe('new title')
+        expect(card.fields.properties).toEqual({status: 'todo'})
+    })
+})
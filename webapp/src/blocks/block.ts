@@ -116,5 +116,63 @@ function createPatchesFromBlocks(newBlock: Block, oldBlock: Block): BlockPatch[]
     ]
 }
 
-export type {ContentBlockTypes, BlockTypes, FileInfo}
-export {blockTypes, contentBlockTypes, Block, BlockPatch, createBlock, createPatchesFromBlocks}
+// BlockDeltaPatch is the patch the server sends through the websocket
+// when a block changes, with the values that didn't change set to null.
+// expectedUpdateAt is the update time of the version it applies to
+type BlockDeltaPatch = {
+    parentId: string | null
+    schema: number | null
+    type: BlockTypes | null
+    title: string | null
+    sortOrder: number | null
+    // eslint-disable-next-line @typescript-eslint/no-explicit-any
+    updatedFields: Record<string, any> | null
+    deletedFields: string[] | null
+    // eslint-disable-next-line @typescript-eslint/no-explicit-any
+    updatedProperties: Record<string, any> | null
+    deletedProperties: string[] | null
+    expectedUpdateAt?: number
+}
+
+// applyBlockDeltaPatch returns a copy of the block with the changes of
+// a delta patch applied. As on the server, the property values are
+// changed before the fields, so a patch replacing the whole
+// "properties" field takes precedence
+function applyBlockDeltaPatch(block: Block, patch: BlockDeltaPatch): Block {
+    const patched: Block = {...block, fields: {...block.fields}}
+
+    if (patch.parentId !== null) {
+        patched.parentId = patch.parentId
+    }
+    if (patch.schema !== null) {
+        patched.schema = patch.schema
+    }
+    if (patch.type !== null) {
+        patched.type = patch.type
+    }
+    if (patch.title !== null) {
+        patched.title = patch.title
+    }
+    if (patch.sortOrder !== null) {
+        (patched as any).sortOrder = patch.sortOrder
+    }
+
+    if (patch.updatedProperties || patch.deletedProperties) {
+        const properties = {...(patched.fields.properties || {})}
+        Object.assign(properties, patch.updatedProperties || {})
+        for (const id of patch.deletedProperties || []) {
+            delete properties[id]
+        }
+        patched.fields.properties = properties
+    }
+
+    Object.assign(patched.fields, patch.updatedFields || {})
+    for (const key of patch.deletedFields || []) {
+        delete patched.fields[key]
+    }
+
+    return patched
+}
+
+export type {ContentBlockTypes, BlockTypes, FileInfo, BlockDeltaPatch}
+export {blockTypes, contentBlockTypes, Block, BlockPatch, createBlock, createPatchesFromBlocks, applyBlockDeltaPatch}
@@ -9,6 +9,7 @@ import Workspace from '../../components/workspace'
 import CloudMessage from '../../components/messages/cloudMessage'
 import VersionMessage from '../../components/messages/versionMessage'
 import octoClient from '../../octoClient'
+import store from '../../store'
 import {Subscription, WSClient} from '../../wsclient'
 import {Utils} from '../../utils'
 import {useWebsockets} from '../../hooks/websockets'
@@ -27,13 +28,13 @@ import {
     fetchBoardMembers,
     addMyBoardMemberships,
 } from '../../store/boards'
-import {getCurrentViewId, setCurrent as setCurrentView, updateViews} from '../../store/views'
+import {getCurrentViewId, getViews, setCurrent as setCurrentView, updateViews} from '../../store/views'
 import {initialLoad, initialReadOnlyLoad, loadBoardData} from '../../store/initialLoad'
 import {useAppSelector, useAppDispatch} from '../../store/hooks'
 import {setTeam} from '../../store/teams'
-import {updateCards} from '../../store/cards'
+import {getCards, getTemplates, updateCards} from '../../store/cards'
 import {updateComments} from '../../store/comments'
-import {updateContents} from '../../store/contents'
+import {getContentsById, updateContents} from '../../store/contents'
 import {
     fetchUserBlockSubscriptions,
     getMe,
@@ -145,11 +146,28 @@ const BoardPage = (props: Props): JSX.Element => {
             dispatch(loadAction(match.params.boardId))
         }
 
+        const reloadBoard = (_: WSClient, boardId: string) => {
+            if (boardId === match.params.boardId) {
+                dispatchLoadAction()
+            }
+        }
+
+        const resolveBlock = (blockId: string): Block | undefined => {
+            const state = store.getState()
+            return getCards(state)[blockId] ||
+                getTemplates(state)[blockId] ||
+                getViews(state)[blockId] ||
+                getContentsById(state)[blockId] ||
+                state.comments.comments[blockId]
+        }
+
         Utils.log('useWEbsocket adding onChange handler')
         wsClient.addOnChange(incrementalBlockUpdate, 'block')
         wsClient.addOnChange(incrementalBoardUpdate, 'board')
         wsClient.addOnChange(incrementalBoardMemberUpdate, 'boardMembers')
         wsClient.addOnReconnect(dispatchLoadAction)
+        wsClient.addOnMissedUpdates(reloadBoard)
+        wsClient.setBlockResolver(resolveBlock)
 
         wsClient.setOnFollowBlock((_: WSClient, subscription: Subscription): void => {
             if (subscription.subscriberId === me?.id) {
@@ -168,6 +186,7 @@ const BoardPage = (props: Props): JSX.Element => {
             wsClient.removeOnChange(incrementalBoardUpdate, 'board')
             wsClient.removeOnChange(incrementalBoardMemberUpdate, 'boardMembers')
             wsClient.removeOnReconnect(dispatchLoadAction)
+            wsClient.removeOnMissedUpdates(reloadBoard)
         }
     }, [me?.id, activeBoardId])
 
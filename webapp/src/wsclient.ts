@@ -4,7 +4,7 @@
 import {ClientConfig} from './config/clientConfig'
 
 import {Utils, WSMessagePayloads} from './utils'
-import {Block} from './blocks/block'
+import {Block, BlockDeltaPatch, applyBlockDeltaPatch} from './blocks/block'
 import {Board, BoardMember} from './blocks/board'
 import {OctoUtils} from './octoUtils'
 import {BoardCategoryWebsocketData, Category} from './store/sidebar'
@@ -28,12 +28,19 @@ export type WSMessage = {
     teamId?: string
     member?: BoardMember
     timestamp?: number
+    boardId?: string
+    blockId?: string
+    revision?: number
+    updateAt?: number
+    modifiedBy?: string
+    patch?: BlockDeltaPatch
 }
 
 export const ACTION_UPDATE_BOARD = 'UPDATE_BOARD'
 export const ACTION_UPDATE_MEMBER = 'UPDATE_MEMBER'
 export const ACTION_DELETE_MEMBER = 'DELETE_MEMBER'
 export const ACTION_UPDATE_BLOCK = 'UPDATE_BLOCK'
+export const ACTION_PATCH_BLOCK = 'PATCH_BLOCK'
 export const ACTION_AUTH = 'AUTH'
 export const ACTION_SUBSCRIBE_BLOCKS = 'SUBSCRIBE_BLOCKS'
 export const ACTION_SUBSCRIBE_TEAM = 'SUBSCRIBE_TEAM'
@@ -73,6 +80,8 @@ export interface MMWebSocketClient {
 
 type OnChangeHandler = (client: WSClient, items: any[]) => void
 type OnReconnectHandler = (client: WSClient) => void
+type OnMissedUpdatesHandler = (client: WSClient, boardId: string) => void
+type BlockResolver = (blockId: string) => Block | undefined
 type OnStateChangeHandler = (client: WSClient, state: 'init' | 'open' | 'close') => void
 type OnErrorHandler = (client: WSClient, e: Event) => void
 type OnConfigChangeHandler = (client: WSClient, clientConfig: ClientConfig) => void
@@ -115,6 +124,8 @@ class WSClient {
     state: 'init'|'open'|'close' = 'init'
     onStateChange: OnStateChangeHandler[] = []
     onReconnect: OnReconnectHandler[] = []
+    onMissedUpdates: OnMissedUpdatesHandler[] = []
+    blockResolver: BlockResolver = () => undefined
     onChange: ChangeHandlers = {Block: [], Category: [], BoardCategory: [], Board: [], BoardMember: []}
     onError: OnErrorHandler[] = []
     onConfigChange: OnConfigChangeHandler[] = []
@@ -127,6 +138,7 @@ class WSClient {
     private updateTimeout?: NodeJS.Timeout
     private errorPollId?: NodeJS.Timeout
     private subscriptions: Subscriptions = {Teams: {}}
+    private boardRevisions: Record<string, number> = {}
 
     private logged = false
 
@@ -270,6 +282,25 @@ class WSClient {
         }
     }
 
+    // the missed updates handlers are called when a board has changes
+    // that couldn't be received, so they fetch the board again
+    addOnMissedUpdates(handler: OnMissedUpdatesHandler): void {
+        this.onMissedUpdates.push(handler)
+    }
+
+    removeOnMissedUpdates(handler: OnMissedUpdatesHandler): void {
+        const index = this.onMissedUpdates.indexOf(handler)
+        if (index !== -1) {
+            this.onMissedUpdates.splice(index, 1)
+        }
+    }
+
+    // the block resolver returns the current version of a block, if the
+    // client holds it, to apply the block patches to
+    setBlockResolver(resolver: BlockResolver): void {
+        this.blockResolver = resolver
+    }
+
     addOnStateChange(handler: OnStateChangeHandler): void {
         this.onStateChange.push(handler)
     }
@@ -425,6 +456,11 @@ class WSClient {
                     // ToDo: assert that this actually runs the onopen
                     // contents (auth + this.subscribe())
                     this.open()
+
+                    // the server forgets the revisions of the boards
+                    // nobody listens to, and the reconnect handlers
+                    // fetch the boards again anyway
+                    this.boardRevisions = {}
                     for (const handler of this.onReconnect) {
                         handler(this)
                     }
@@ -458,6 +494,9 @@ class WSClient {
                 case ACTION_UPDATE_BLOCK:
                     this.updateHandler(message)
                     break
+                case ACTION_PATCH_BLOCK:
+                    this.updateHandler(message)
+                    break
                 case ACTION_UPDATE_CATEGORY:
                     this.updateHandler(message)
                     break
@@ -487,12 +526,73 @@ class WSClient {
             return
         }
 
+        if (message.action === ACTION_PATCH_BLOCK) {
+            this.patchHandler(message)
+            return
+        }
+
+        if (message.block && !this.isNextRevision(message.block.boardId, message.revision)) {
+            this.missedUpdatesHandler(message.block.boardId)
+        }
+
         const [data, type] = Utils.fixWSData(message)
         if (data) {
             this.queueUpdateNotification(data, type)
         }
     }
 
+    // patchHandler applies a block patch to the version of the block the
+    // client holds. If the client doesn't hold the version the patch was
+    // computed from, or it missed a message of the board, it falls back
+    // to fetching the board again
+    private patchHandler(message: WSMessage): void {
+        const {boardId, blockId, patch} = message
+        if (!boardId || !blockId || !patch) {
+            return
+        }
+
+        const isNextRevision = this.isNextRevision(boardId, message.revision)
+        const block = this.updatedData.Blocks.find((b) => b.id === blockId) || this.blockResolver(blockId)
+        if (!block) {
+            // the client doesn't show the block, so it has nothing to update
+            if (!isNextRevision) {
+                this.missedUpdatesHandler(boardId)
+            }
+            return
+        }
+
+        if (!isNextRevision || block.updateAt !== patch.expectedUpdateAt) {
+            this.missedUpdatesHandler(boardId)
+            return
+        }
+
+        const patched = applyBlockDeltaPatch(block, patch)
+        patched.updateAt = message.updateAt || patched.updateAt
+        patched.modifiedBy = message.modifiedBy || patched.modifiedBy
+        this.queueUpdateNotification(Utils.fixBlock(patched), 'block')
+    }
+
+    // isNextRevision records the revision of a block message of a board,
+    // and returns false if some message of the board was missed since
+    // the previous one. Listeners of single blocks, like read token
+    // listeners, get their messages without a revision.
+    private isNextRevision(boardId: string, revision?: number): boolean {
+        if (!revision) {
+            return true
+        }
+
+        const lastRevision = this.boardRevisions[boardId]
+        this.boardRevisions[boardId] = revision
+        return lastRevision === undefined || revision === lastRevision + 1
+    }
+
+    private missedUpdatesHandler(boardId: string): void {
+        Utils.log(`WSClient missed updates of board ${boardId}`)
+        for (const handler of this.onMissedUpdates) {
+            handler(this, boardId)
+        }
+    }
+
     setOnFollowBlock(handler: FollowChangeHandler): void {
         this.onFollowBlock = handler
     }
@@ -742,6 +842,8 @@ class WSClient {
         this.ws = null
         this.onChange = {Block: [], Category: [], BoardCategory: [], Board: [], BoardMember: []}
         this.onReconnect = []
+        this.onMissedUpdates = []
+        this.boardRevisions = {}
         this.onStateChange = []
         this.onError = []
 